@@ -28,6 +28,7 @@ import (
 	"github.com/instill-ai/x/zapadapter"
 
 	database "github.com/instill-ai/pipeline-backend/pkg/db"
+	pipelineworker "github.com/instill-ai/pipeline-backend/pkg/worker"
 	mgmtpb "github.com/instill-ai/protogen-go/core/mgmt/v1beta"
 	pipelinepb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
 )
@@ -70,6 +71,12 @@ func DownloadPresetPipelines(ctx context.Context, repo repository.Repository) er
 		}
 	}
 
+	payloadCodecs, err := pipelineworker.NewPayloadCodecs(config.Config.Temporal.Codec)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("Unable to create Temporal payload codecs: %s", err))
+	}
+	temporalClientOptions.DataConverter = pipelineworker.NewDataConverter(payloadCodecs)
+
 	temporalClient, err := client.Dial(temporalClientOptions)
 	if err != nil {
 		logger.Fatal(fmt.Sprintf("Unable to create client: %s", err))
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"net/http"
@@ -22,7 +23,6 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
-	"go.temporal.io/sdk/worker"
 	"go.uber.org/zap"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
@@ -40,6 +40,7 @@ import (
 	"github.com/instill-ai/pipeline-backend/config"
 	"github.com/instill-ai/pipeline-backend/pkg/acl"
 	"github.com/instill-ai/pipeline-backend/pkg/constant"
+	"github.com/instill-ai/pipeline-backend/pkg/eventsource"
 	"github.com/instill-ai/pipeline-backend/pkg/external"
 	"github.com/instill-ai/pipeline-backend/pkg/handler"
 	"github.com/instill-ai/pipeline-backend/pkg/logger"
@@ -48,6 +49,7 @@ import (
 	"github.com/instill-ai/pipeline-backend/pkg/minio"
 	"github.com/instill-ai/pipeline-backend/pkg/repository"
 	"github.com/instill-ai/pipeline-backend/pkg/service"
+	"github.com/instill-ai/pipeline-backend/pkg/session"
 	"github.com/instill-ai/pipeline-backend/pkg/usage"
 	"github.com/instill-ai/x/temporal"
 	"github.com/instill-ai/x/zapadapter"
@@ -123,6 +125,14 @@ func main() {
 		}()
 	}
 
+	if mp, err := customotel.SetupMetrics(ctx, "pipeline-backend"); err != nil {
+		panic(err)
+	} else {
+		defer func() {
+			err = mp.Shutdown(ctx)
+		}()
+	}
+
 	ctx, span := otel.Tracer("main-tracer").Start(ctx,
 		"main",
 	)
@@ -140,29 +150,26 @@ func main() {
 	db := database.GetSharedConnection()
 	defer database.Close(db)
 
-	var temporalClientOptions client.Options
-	var err error
-	if config.Config.Temporal.Ca != "" && config.Config.Temporal.Cert != "" && config.Config.Temporal.Key != "" {
-		if temporalClientOptions, err = temporal.GetTLSClientOption(
-			config.Config.Temporal.HostPort,
-			config.Config.Temporal.Namespace,
-			zapadapter.NewZapAdapter(logger),
-			config.Config.Temporal.Ca,
-			config.Config.Temporal.Cert,
-			config.Config.Temporal.Key,
-			config.Config.Temporal.ServerName,
-			true,
-		); err != nil {
-			logger.Fatal(fmt.Sprintf("Unable to get Temporal client options: %s", err))
-		}
-	} else {
-		if temporalClientOptions, err = temporal.GetClientOption(
-			config.Config.Temporal.HostPort,
-			config.Config.Temporal.Namespace,
-			zapadapter.NewZapAdapter(logger)); err != nil {
-			logger.Fatal(fmt.Sprintf("Unable to get Temporal client options: %s", err))
-		}
+	temporalClientOptions, err := getTemporalClientOptions(
+		config.Config.Temporal.HostPort,
+		config.Config.Temporal.Namespace,
+		config.Config.Temporal.Ca,
+		config.Config.Temporal.Cert,
+		config.Config.Temporal.Key,
+		config.Config.Temporal.ServerName,
+		logger,
+	)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("Unable to get Temporal client options: %s", err))
+	}
+
+	// The payloads are encoded the same way in both clusters, as the
+	// workflows might be read from any of them.
+	payloadCodecs, err := pipelineworker.NewPayloadCodecs(config.Config.Temporal.Codec)
+	if err != nil {
+		logger.Fatal(fmt.Sprintf("Unable to create Temporal payload codecs: %s", err))
 	}
+	temporalClientOptions.DataConverter = pipelineworker.NewDataConverter(payloadCodecs)
 
 	temporalClient, err := client.Dial(temporalClientOptions)
 	if err != nil {
@@ -170,6 +177,30 @@ func main() {
 	}
 	defer temporalClient.Close()
 
+	// The failover cluster might not be reachable while the primary one is
+	// healthy, so the connection is established on first use.
+	var failoverTemporalClient client.Client
+	if fc := config.Config.Temporal.Failover; fc.HostPort != "" {
+		failoverClientOptions, err := getTemporalClientOptions(
+			fc.HostPort,
+			fc.Namespace,
+			fc.Ca,
+			fc.Cert,
+			fc.Key,
+			fc.ServerName,
+			logger,
+		)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("Unable to get failover Temporal client options: %s", err))
+		}
+		failoverClientOptions.DataConverter = temporalClientOptions.DataConverter
+
+		if failoverTemporalClient, err = client.NewLazyClient(failoverClientOptions); err != nil {
+			logger.Fatal(fmt.Sprintf("Unable to create failover client: %s", err))
+		}
+		defer failoverTemporalClient.Close()
+	}
+
 	// Shared options for the logger, with a custom gRPC code to log level function.
 	opts := []grpczap.Option{
 		grpczap.WithDecider(func(fullMethodName string, err error) bool {
@@ -248,13 +279,115 @@ func main() {
 	}
 
 	repo := repository.NewRepository(db, redisClient)
-	ms := memory.NewMemoryStore()
 
 	// Initialize Minio client
 	minioClient, err := minio.NewMinioClientAndInitBucket(ctx, &config.Config.Minio)
 	if err != nil {
 		logger.Fatal("failed to create minio client", zap.Error(err))
 	}
+
+	memoryRedisClient, err := newMemoryRedisClient(redisClient, config.Config.Memory.Redis)
+	if err != nil {
+		logger.Fatal("failed to set up memory Redis client", zap.Error(err))
+	}
+	if config.Config.Memory.Redis.Mode != "" {
+		defer memoryRedisClient.Close()
+	}
+	redisTenancy, err := newMemoryRedisTenancy(redisClient, config.Config.Memory.Redis)
+	if err != nil {
+		logger.Fatal("failed to set up memory Redis tenancy", zap.Error(err))
+	}
+	for _, c := range redisTenancy.Clients {
+		defer c.Close()
+	}
+
+	var memoryPersistence memory.MemoryPersistence
+	switch config.Config.Memory.Persistence {
+	case "":
+	case "redis":
+		memoryPersistence = memory.NewRedisPersistence(memoryRedisClient, time.Duration(config.Config.Memory.TTL)*time.Second, redisTenancy)
+	case "postgres":
+		memoryPersistence = memory.NewPostgresPersistence(db)
+	case "minio":
+		memoryPersistence = memory.NewMinIOPersistence(minioClient)
+	default:
+		logger.Fatal(fmt.Sprintf("unsupported memory persistence backend: %s", config.Config.Memory.Persistence))
+	}
+	if memoryPersistence != nil {
+		memoryPersistence = memory.NewInstrumentedPersistence(memoryPersistence, config.Config.Memory.Persistence)
+	}
+	if memoryPersistence != nil && config.Config.Memory.EncryptionKey != "" {
+		masterKey, err := base64.StdEncoding.DecodeString(config.Config.Memory.EncryptionKey)
+		if err != nil {
+			logger.Fatal("failed to decode memory encryption key", zap.Error(err))
+		}
+		keyWrapper, err := memory.NewMasterKeyWrapper(masterKey)
+		if err != nil {
+			logger.Fatal("failed to set up memory encryption", zap.Error(err))
+		}
+		memoryPersistence = memory.NewEncryptedPersistence(memoryPersistence, keyWrapper, config.Config.Memory.AcceptPlaintext)
+	}
+	if memoryPersistence != nil {
+		memoryPersistence, err = memory.NewCompressedPersistence(memoryPersistence, memory.Compression(config.Config.Memory.Compression))
+		if err != nil {
+			logger.Fatal("failed to set up memory compression", zap.Error(err))
+		}
+	}
+	var blobManager *memory.BlobManager
+	if config.Config.Memory.BlobThreshold > 0 {
+		blobManager = memory.NewBlobManager(memory.NewMinIOBlobStore(minioClient), config.Config.Memory.BlobThreshold<<10)
+		if config.Config.Memory.BlobRetention > 0 {
+			if err := minioClient.ExpireObjects(ctx, memory.MinIOBlobPrefix, config.Config.Memory.BlobRetention); err != nil {
+				logger.Fatal("failed to set memory blob retention", zap.Error(err))
+			}
+		}
+	}
+	var eventPublisher memory.EventPublisher
+	switch config.Config.EventBus.Type {
+	case "":
+	case "redis":
+		eventPublisher = memory.NewRedisEventPublisher(memoryRedisClient, redisTenancy)
+	case "nats":
+		eventPublisher, err = memory.NewNATSEventPublisher(config.Config.EventBus.NATS.URL, config.Config.EventBus.NATS.Subject)
+		if err != nil {
+			logger.Fatal("failed to set up NATS event publisher", zap.Error(err))
+		}
+	case "kafka":
+		kafkaCfg := config.Config.EventBus.Kafka
+		eventPublisher = memory.NewKafkaEventPublisher(&http.Client{}, kafkaCfg.RestProxyURL, kafkaCfg.Topic, kafkaCfg.Username, kafkaCfg.Password)
+	default:
+		logger.Fatal(fmt.Sprintf("unsupported event bus: %s", config.Config.EventBus.Type))
+	}
+	if eventPublisher != nil && config.Config.EventBus.EncryptionKey != "" {
+		key, err := base64.StdEncoding.DecodeString(config.Config.EventBus.EncryptionKey)
+		if err != nil {
+			logger.Fatal("failed to decode event encryption key", zap.Error(err))
+		}
+		eventPublisher, err = memory.NewEncryptedEventPublisher(eventPublisher, key)
+		if err != nil {
+			logger.Fatal("failed to set up event encryption", zap.Error(err))
+		}
+	}
+	if eventPublisher != nil {
+		defer eventPublisher.Close()
+	}
+	var watchdog *memory.Watchdog
+	if wdCfg := config.Config.Memory.Watchdog; wdCfg.Limit > 0 {
+		watchdog = memory.NewWatchdog(int64(wdCfg.Limit)<<20, int64(wdCfg.HeavyTriggerSize)<<10, time.Duration(wdCfg.IdleTime)*time.Second)
+	}
+	ms := memory.NewMemoryStore(memoryPersistence, int64(config.Config.Memory.MaxSize)<<20, blobManager, time.Duration(config.Config.Memory.EventFlushInterval)*time.Millisecond, eventPublisher, watchdog)
+
+	var sessionStore session.Store
+	switch config.Config.Session.Store {
+	case "":
+	case "redis":
+		sessionStore = session.NewRedisStore(redisClient, time.Duration(config.Config.Session.TTL)*time.Second)
+	case "postgres":
+		sessionStore = session.NewPostgresStore(db)
+	default:
+		logger.Fatal(fmt.Sprintf("unsupported session store: %s", config.Config.Session.Store))
+	}
+
 	workerUID, _ := uuid.NewV4()
 	compStore := componentstore.Init(logger, config.Config.Connector.Secrets, nil)
 
@@ -268,6 +401,7 @@ func main() {
 		minioClient,
 		compStore,
 		ms,
+		sessionStore,
 		workerUID,
 	)
 
@@ -303,19 +437,24 @@ func main() {
 		}),
 	)
 
+	publicJSONMarshaler := &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			EmitUnpopulated: true,
+			UseEnumNumbers:  false,
+		},
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			DiscardUnknown: true,
+		},
+	}
 	publicServeMux := runtime.NewServeMux(
 		runtime.WithForwardResponseOption(middleware.HTTPResponseModifier),
 		runtime.WithErrorHandler(middleware.ErrorHandler),
 		runtime.WithIncomingHeaderMatcher(middleware.CustomMatcher),
-		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-			MarshalOptions: protojson.MarshalOptions{
-				EmitUnpopulated: true,
-				UseEnumNumbers:  false,
-			},
-			UnmarshalOptions: protojson.UnmarshalOptions{
-				DiscardUnknown: true,
-			},
-		}),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, publicJSONMarshaler),
+		// The request bodies (e.g. the trigger payloads) can be written in
+		// YAML, like the recipes.
+		runtime.WithMarshalerOption("application/yaml", &middleware.YAMLMarshaler{JSON: publicJSONMarshaler}),
+		runtime.WithMarshalerOption("application/x-yaml", &middleware.YAMLMarshaler{JSON: publicJSONMarshaler}),
 	)
 
 	// Start usage reporter
@@ -368,9 +507,157 @@ func main() {
 	if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/releases/{releaseID=*}/triggerAsync", middleware.AppendCustomHeaderMiddleware(publicServeMux, pipelinePublicServiceClient, handler.HandleTriggerAsyncRelease, ms)); err != nil {
 		logger.Fatal(err.Error())
 	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/releases/{releaseID=*}/tool", middleware.AppendCustomHeaderMiddleware(publicServeMux, pipelinePublicServiceClient, handler.HandleGetPipelineReleaseTool, ms)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/releases/{releaseID=*}/tool/call", middleware.AppendCustomHeaderMiddleware(publicServeMux, pipelinePublicServiceClient, handler.HandleCallPipelineReleaseTool, ms)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if config.Config.Server.MCP.Enabled {
+		if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/mcp", middleware.AppendCustomHeaderMiddleware(publicServeMux, pipelinePublicServiceClient, handler.HandleMCP, ms)); err != nil {
+			logger.Fatal(err.Error())
+		}
+	}
 	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/image", middleware.HandleProfileImage(service, repo)); err != nil {
 		logger.Fatal(err.Error())
 	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/operations/{operationID=*}/wait", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleWaitForTriggerResult)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/retry", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleRetryPipelineTrigger)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/approve", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleApproveRun)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/reject", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleRejectRun)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/state", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetPipelineRunState)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/memory", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetPipelineRunMemory)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/memory/snapshot", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetTriggerMemorySnapshot)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/memory/export", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleExportPipelineRunMemory)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/compare/{otherPipelineRunID=*}", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleComparePipelineRuns)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/lineage", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetPipelineRunLineage)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/artifacts", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleListRunArtifacts)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/runs/{pipelineRunID=*}/artifacts/{artifactUID=*}", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetRunArtifact)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/schedule", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetPipelineScheduleHistory)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/canaries", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleListPipelineCanaries)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/deprecations", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetPipelineDeprecations)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/deprecations/migrate", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleMigratePipelineRecipe)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/dependencies", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleListPipelineDependencies)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/documentation", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetPipelineDocumentation)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/releases/{releaseID=*}/documentation", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetPipelineReleaseDocumentation)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/pipelines/{pipelineID=*}/export", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleExportPipeline)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/deprecated-pipelines", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleListDeprecatedPipelines)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/dependents", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetDependencyImpact)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1beta/schedules/preview", middleware.HandleServiceRequest(publicServeMux, service, handler.HandlePreviewSchedule)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/prompts", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleCreateNamespacePrompt)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/prompts", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleListNamespacePrompts)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/prompts/{promptID=*}", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetNamespacePrompt)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1beta/*/{namespaceID=*}/prompts/{promptID=*}", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleDeleteNamespacePrompt)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/sessions/{sessionID=*}/messages", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleAppendSessionMessages)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/sessions/{sessionID=*}/messages", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleListSessionMessages)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1beta/*/{namespaceID=*}/sessions/{sessionID=*}", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleDeleteSession)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/prompts/{promptID=*}/versions", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleListNamespacePromptVersions)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/prompts/{promptID=*}/versions/{version=*}", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetNamespacePrompt)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/settings", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetNamespaceSettings)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1beta/*/{namespaceID=*}/settings", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleUpdateNamespaceSettings)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/connections/{connectionID=*}/concurrency", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetConnectionConcurrency)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1beta/*/{namespaceID=*}/connections/{connectionID=*}/concurrency", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleUpdateConnectionConcurrency)); err != nil {
+		logger.Fatal(err.Error())
+	}
+	if config.Config.Server.EventSource.Enabled {
+		if err := publicServeMux.HandlePath("POST", "/v1beta/*/{namespaceID=*}/event-sources", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleCreateNamespaceEventSource)); err != nil {
+			logger.Fatal(err.Error())
+		}
+		if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/event-sources", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleListNamespaceEventSources)); err != nil {
+			logger.Fatal(err.Error())
+		}
+		if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/event-sources/{eventSourceID=*}", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleGetNamespaceEventSource)); err != nil {
+			logger.Fatal(err.Error())
+		}
+		if err := publicServeMux.HandlePath("DELETE", "/v1beta/*/{namespaceID=*}/event-sources/{eventSourceID=*}", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleDeleteNamespaceEventSource)); err != nil {
+			logger.Fatal(err.Error())
+		}
+		if err := publicServeMux.HandlePath("GET", "/v1beta/*/{namespaceID=*}/event-sources/{eventSourceID=*}/dead-letters", middleware.HandleServiceRequest(publicServeMux, service, handler.HandleListEventSourceDeadLetters)); err != nil {
+			logger.Fatal(err.Error())
+		}
+	}
+
+	// The Temporal UI decodes the payloads it shows through the codec
+	// server. It isn't exposed on the public port, as the payloads hold the
+	// trigger data and secrets.
+	if config.Config.Temporal.Codec.Server.Enabled && len(payloadCodecs) > 0 {
+		codecHandler := handler.HandlePayloadCodec(payloadCodecs, config.Config.Temporal.Codec.Server.AllowedOrigin)
+		for _, method := range []string{"POST", "OPTIONS"} {
+			if err := privateServeMux.HandlePath(method, "/v1beta/temporal-codec/{operation=*}", codecHandler); err != nil {
+				logger.Fatal(err.Error())
+			}
+		}
+	}
 
 	privateHTTPServer := &http.Server{
 		Addr:              fmt.Sprintf(":%v", config.Config.Server.PrivatePort),
@@ -431,59 +718,59 @@ func main() {
 		compStore,
 		minioClient,
 		ms,
+		sessionStore,
 		workerUID,
 	)
 
-	w := worker.New(temporalClient, pipelineworker.TaskQueue, worker.Options{
-		WorkflowPanicPolicy:                    worker.BlockWorkflow,
-		WorkerStopTimeout:                      gracefulShutdownTimeout,
-		MaxConcurrentWorkflowTaskExecutionSize: 100,
-	})
-	lw := worker.New(temporalClient, workerUID.String(), worker.Options{
-		WorkflowPanicPolicy:                worker.BlockWorkflow,
-		WorkerStopTimeout:                  gracefulShutdownTimeout,
-		MaxConcurrentActivityExecutionSize: 100,
-	})
-	mw := worker.New(temporalClient, fmt.Sprintf("%s-minio", workerUID.String()), worker.Options{
-		WorkflowPanicPolicy:                worker.BlockWorkflow,
-		WorkerStopTimeout:                  gracefulShutdownTimeout,
-		MaxConcurrentActivityExecutionSize: 50,
-	})
-
-	w.RegisterWorkflow(cw.TriggerPipelineWorkflow)
-	w.RegisterWorkflow(cw.SchedulePipelineWorkflow)
-
-	lw.RegisterActivity(cw.ComponentActivity)
-	lw.RegisterActivity(cw.OutputActivity)
-	lw.RegisterActivity(cw.PreIteratorActivity)
-	lw.RegisterActivity(cw.PostIteratorActivity)
-	lw.RegisterActivity(cw.PreTriggerActivity)
-	lw.RegisterActivity(cw.LoadDAGDataActivity)
-	lw.RegisterActivity(cw.PostTriggerActivity)
-	lw.RegisterActivity(cw.ClosePipelineActivity)
-	lw.RegisterActivity(cw.IncreasePipelineTriggerCountActivity)
-	lw.RegisterActivity(cw.UpdatePipelineRunActivity)
-	lw.RegisterActivity(cw.UpsertComponentRunActivity)
-
-	mw.RegisterActivity(cw.UploadInputsToMinioActivity)
-	mw.RegisterActivity(cw.UploadOutputsToMinioActivity)
-	mw.RegisterActivity(cw.UploadRecipeToMinioActivity)
-	mw.RegisterActivity(cw.UploadComponentInputsActivity)
-	mw.RegisterActivity(cw.UploadComponentOutputsActivity)
-
-	err = w.Start()
+	err = cw.Start(pipelineworker.TemporalCluster{
+		Name:   config.Config.Temporal.ClusterName,
+		Client: temporalClient,
+	}, gracefulShutdownTimeout)
 	if err != nil {
 		logger.Fatal(fmt.Sprintf("Unable to start worker: %s", err))
 	}
-	err = lw.Start()
-	if err != nil {
-		logger.Fatal(fmt.Sprintf("Unable to start local worker: %s", err))
+	logger.Info("worker is running.")
+
+	if fc := config.Config.Temporal.Failover; failoverTemporalClient != nil && fc.HealthCheckInterval > 0 {
+		go func() {
+			interval := time.Duration(fc.HealthCheckInterval) * time.Second
+			if err := pipelineworker.WatchTemporalCluster(ctx, temporalClient, interval, fc.MaxFailedChecks); err != nil {
+				return
+			}
+
+			logger.Error("Temporal cluster is unhealthy, failing over", zap.String("cluster", config.Config.Temporal.ClusterName))
+			failover := pipelineworker.TemporalCluster{
+				Name:   fc.ClusterName,
+				Client: failoverTemporalClient,
+			}
+			if err := cw.Failover(failover); err != nil {
+				logger.Error("Unable to fail over to Temporal cluster", zap.String("cluster", fc.ClusterName), zap.Error(err))
+				return
+			}
+			service.FailoverTemporalCluster(failover)
+		}()
 	}
-	err = mw.Start()
-	if err != nil {
-		logger.Fatal(fmt.Sprintf("Unable to start minio worker: %s", err))
+
+	if config.Config.Memory.ReapInterval > 0 {
+		go cw.RunMemoryJanitor(ctx, time.Duration(config.Config.Memory.ReapInterval)*time.Second)
+		logger.Info("memory janitor is running.")
+	}
+	if wdCfg := config.Config.Memory.Watchdog; wdCfg.Limit > 0 && wdCfg.Interval > 0 {
+		go cw.RunMemoryWatchdog(ctx, time.Duration(wdCfg.Interval)*time.Second)
+		logger.Info("memory watchdog is running.")
+	}
+
+	if config.Config.Server.Canary.Interval > 0 {
+		go service.RunCanaryScheduler(ctx, time.Duration(config.Config.Server.Canary.Interval)*time.Second)
+		logger.Info("canary scheduler is running.")
+	}
+
+	if config.Config.Server.EventSource.Enabled {
+		syncInterval := time.Duration(config.Config.Server.EventSource.SyncInterval) * time.Second
+		dispatcher := eventsource.NewDispatcher(repo, redisClient, service.TriggerEventSource, syncInterval)
+		go dispatcher.Run(ctx)
+		logger.Info("event source dispatcher is running.")
 	}
-	logger.Info("worker is running.")
 
 	// kill (no param) default send syscall.SIGTERM
 	// kill -2 is syscall.SIGINT
@@ -514,9 +801,7 @@ func main() {
 		defer shutdownCancel()
 
 		logger.Info("Shutting down worker...")
-		w.Stop()
-		lw.Stop()
-		mw.Stop()
+		cw.Stop()
 
 		logger.Info("Shutting down HTTP server...")
 		_ = privateHTTPServer.Shutdown(shutdownCtx)
@@ -528,6 +813,92 @@ func main() {
 	}
 }
 
+// newMemoryRedisClient returns the client of the Redis deployment the
+// workflow memory uses. Without a cluster or Sentinel deployment, the client
+// of the cache is shared.
+func newMemoryRedisClient(cacheClient *redis.Client, cfg config.MemoryRedisConfig) (redis.UniversalClient, error) {
+	switch cfg.Mode {
+	case "":
+		return cacheClient, nil
+	case "cluster":
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    cfg.Addrs,
+			Username: cfg.Username,
+			Password: cfg.Password,
+		}), nil
+	case "sentinel":
+		if cfg.MasterName == "" {
+			return nil, fmt.Errorf("the master name of the Sentinel deployment is missing")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    cfg.Addrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Username:         cfg.Username,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported Redis mode: %s", cfg.Mode)
+	}
+}
+
+// newMemoryRedisTenancy returns how the memory of each namespace is isolated
+// in Redis, along with the clients of the databases dedicated to some
+// namespaces.
+func newMemoryRedisTenancy(cacheClient *redis.Client, cfg config.MemoryRedisConfig) (memory.RedisTenancy, error) {
+	tenancy := memory.RedisTenancy{KeyPrefix: cfg.KeyPrefix, Isolated: cfg.TenantIsolation}
+	if len(cfg.TenantDBs) == 0 {
+		return tenancy, nil
+	}
+	if !cfg.TenantIsolation {
+		return tenancy, fmt.Errorf("dedicated tenant databases require tenant isolation")
+	}
+
+	tenancy.Clients = make(map[string]redis.UniversalClient, len(cfg.TenantDBs))
+	for tenant, db := range cfg.TenantDBs {
+		switch cfg.Mode {
+		case "":
+			opts := *cacheClient.Options()
+			opts.DB = db
+			tenancy.Clients[tenant] = redis.NewClient(&opts)
+		case "sentinel":
+			tenancy.Clients[tenant] = redis.NewFailoverClient(&redis.FailoverOptions{
+				MasterName:       cfg.MasterName,
+				SentinelAddrs:    cfg.Addrs,
+				SentinelPassword: cfg.SentinelPassword,
+				Username:         cfg.Username,
+				Password:         cfg.Password,
+				DB:               db,
+			})
+		default:
+			return tenancy, fmt.Errorf("dedicated tenant databases aren't supported in Redis %s mode", cfg.Mode)
+		}
+	}
+	return tenancy, nil
+}
+
+func getTemporalClientOptions(hostPort, namespace, ca, cert, key, serverName string, logger *zap.Logger) (client.Options, error) {
+	if ca != "" && cert != "" && key != "" {
+		return temporal.GetTLSClientOption(
+			hostPort,
+			namespace,
+			zapadapter.NewZapAdapter(logger),
+			ca,
+			cert,
+			key,
+			serverName,
+			true,
+		)
+	}
+
+	return temporal.GetClientOption(
+		hostPort,
+		namespace,
+		zapadapter.NewZapAdapter(logger),
+	)
+}
+
 func initTemporalNamespace(ctx context.Context, client client.Client) {
 	logger, _ := logger.GetZapLogger(ctx)
 
@@ -117,6 +117,23 @@ func main() {
 	}
 
 	step := curVersion
+
+	// Rolling back a step runs its custom code before the schema change.
+	for step > expectedVersion {
+		if err := migration.MigrateDown(step); err != nil {
+			panic(err)
+		}
+
+		fmt.Printf("Step down to version %d\n", step-1)
+		if err := m.Steps(-1); err != nil {
+			panic(err)
+		}
+
+		if step, _, err = m.Version(); err != nil {
+			panic(err)
+		}
+	}
+
 	for {
 		if expectedVersion <= step {
 			fmt.Printf("Migration to version %d complete\n", expectedVersion)
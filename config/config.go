@@ -36,6 +36,9 @@ type AppConfig struct {
 	ArtifactBackend ArtifactBackendConfig `koanf:"artifactbackend"`
 	Minio           MinioConfig           `koanf:"minio"`
 	AppBackend      AppBackendConfig      `koanf:"appbackend"`
+	Memory          MemoryConfig          `koanf:"memory"`
+	Session         SessionConfig         `koanf:"session"`
+	EventBus        EventBusConfig        `koanf:"eventbus"`
 }
 
 // InstillCloud config
@@ -76,7 +79,69 @@ type ServerConfig struct {
 		MaxWorkflowTimeout int32 `koanf:"maxworkflowtimeout"`
 		MaxWorkflowRetry   int32 `koanf:"maxworkflowretry"`
 		MaxActivityRetry   int32 `koanf:"maxactivityretry"`
+		// BatchChunkSize is the maximum number of batch items processed by
+		// a single workflow. Larger batches are split into child workflows.
+		// A zero value disables chunking.
+		BatchChunkSize int `koanf:"batchchunksize"`
+		// MaxOutputSize is the size, in kilobytes, above which a component
+		// output is handled by OversizedOutputPolicy instead of being stored
+		// as is. A zero value disables the limit.
+		MaxOutputSize int `koanf:"maxoutputsize"`
+		// OversizedOutputPolicy is what happens to the outputs larger than
+		// MaxOutputSize: "truncate" shortens their largest strings with a
+		// marker and "spill" also stores the full output as a run artifact.
+		OversizedOutputPolicy string `koanf:"oversizedoutputpolicy"`
 	}
+	// Worker defines how many tasks a worker processes concurrently. Zero
+	// values fall back to the defaults.
+	Worker struct {
+		MaxConcurrentWorkflowTaskExecutionSize  int `koanf:"maxconcurrentworkflowtaskexecutionsize"`
+		MaxConcurrentActivityExecutionSize      int `koanf:"maxconcurrentactivityexecutionsize"`
+		MaxConcurrentMinioActivityExecutionSize int `koanf:"maxconcurrentminioactivityexecutionsize"`
+		// MaxConcurrentConnectorActivities and
+		// MaxConcurrentOperatorActivities limit the component activities
+		// that run at once, on top of the global activity limit. Connectors
+		// (AI, data, application and generic components) call external APIs,
+		// so a lower limit protects these from a single busy worker. A zero
+		// value disables the limit.
+		MaxConcurrentConnectorActivities int `koanf:"maxconcurrentconnectoractivities"`
+		MaxConcurrentOperatorActivities  int `koanf:"maxconcurrentoperatoractivities"`
+		// Sandbox runs the jobs of CPU-bound components (e.g. the image or
+		// video operators) in a bounded pool, isolated from the rest of the
+		// worker. An empty list of components disables it.
+		Sandbox struct {
+			Components []string `koanf:"components"`
+			// MaxConcurrency is the number of jobs that run at once. A zero
+			// value defaults to the number of CPUs.
+			MaxConcurrency int `koanf:"maxconcurrency"`
+			// Timeout is the time, in seconds, a job can run before it's
+			// reported as failed. A zero value disables the timeout.
+			Timeout int `koanf:"timeout"`
+		} `koanf:"sandbox"`
+	}
+	MCP struct {
+		Enabled bool `koanf:"enabled"`
+	}
+	EventSource struct {
+		Enabled bool `koanf:"enabled"`
+		// SyncInterval is the period, in seconds, at which the dispatcher
+		// picks up the event sources that have been created or deleted.
+		SyncInterval int `koanf:"syncinterval"`
+	}
+	Canary struct {
+		// Interval is the period, in seconds, at which the due canary
+		// checks of the pipelines are run. When zero, they aren't run.
+		Interval int `koanf:"interval"`
+	}
+	// TriggerInput limits the shape of the trigger data, which is rejected
+	// before its values are built when it exceeds them. Zero values disable
+	// the limits.
+	TriggerInput struct {
+		MaxDepth       int `koanf:"maxdepth"`
+		MaxArrayLength int `koanf:"maxarraylength"`
+		// MaxSize is the size, in kilobytes, of the data of a batch item.
+		MaxSize int `koanf:"maxsize"`
+	} `koanf:"triggerinput"`
 	InstanceID         string `koanf:"instanceid"`
 	DataChanBufferSize int    `koanf:"datachanbuffersize"`
 	InstillCoreHost    string `koanf:"instillcorehost"`
@@ -134,13 +199,62 @@ type LogConfig struct {
 
 // TemporalConfig related to Temporal
 type TemporalConfig struct {
-	HostPort   string `koanf:"hostport"`
-	Namespace  string `koanf:"namespace"`
-	Retention  string `koanf:"retention"`
-	Ca         string `koanf:"ca"`
-	Cert       string `koanf:"cert"`
-	Key        string `koanf:"key"`
-	ServerName string `koanf:"servername"`
+	HostPort    string                 `koanf:"hostport"`
+	Namespace   string                 `koanf:"namespace"`
+	Retention   string                 `koanf:"retention"`
+	Ca          string                 `koanf:"ca"`
+	Cert        string                 `koanf:"cert"`
+	Key         string                 `koanf:"key"`
+	ServerName  string                 `koanf:"servername"`
+	ClusterName string                 `koanf:"clustername"`
+	Failover    TemporalFailoverConfig `koanf:"failover"`
+	Codec       TemporalCodecConfig    `koanf:"codec"`
+}
+
+// TemporalCodecConfig defines how the workflow and activity payloads are
+// encoded before they're sent to Temporal, where they're kept in the
+// workflow history.
+type TemporalCodecConfig struct {
+	// Compression is the algorithm the payloads are compressed with: gzip
+	// or zstd. When empty, the payloads aren't compressed.
+	Compression string `koanf:"compression"`
+	// MinSize is the size, in bytes, from which the payloads are
+	// compressed. Smaller payloads don't benefit from the compression.
+	MinSize int `koanf:"minsize"`
+	// EncryptionKey is the base64-encoded AES key (16, 24 or 32 bytes) the
+	// payloads are encrypted with. When empty, the payloads aren't
+	// encrypted.
+	EncryptionKey string `koanf:"encryptionkey"`
+	// PreviousEncryptionKeys are the keys that were used before the current
+	// one. They're only used to decode the payloads, so the workflow
+	// histories remain readable after a key rotation.
+	PreviousEncryptionKeys []string `koanf:"previousencryptionkeys"`
+	// Server exposes the codec on the private port, so the Temporal UI can
+	// decode the payloads it shows.
+	Server struct {
+		Enabled bool `koanf:"enabled"`
+		// AllowedOrigin is the origin of the Temporal UI, which calls the
+		// codec server from the browser.
+		AllowedOrigin string `koanf:"allowedorigin"`
+	} `koanf:"server"`
+}
+
+// TemporalFailoverConfig defines a secondary Temporal cluster. When the
+// primary cluster fails a number of consecutive health checks, the workers
+// re-register against the secondary one and new runs are dispatched there.
+// Failover is disabled if no host is provided.
+type TemporalFailoverConfig struct {
+	HostPort    string `koanf:"hostport"`
+	Namespace   string `koanf:"namespace"`
+	Ca          string `koanf:"ca"`
+	Cert        string `koanf:"cert"`
+	Key         string `koanf:"key"`
+	ServerName  string `koanf:"servername"`
+	ClusterName string `koanf:"clustername"`
+	// HealthCheckInterval is the time between health checks on the primary
+	// cluster, in seconds.
+	HealthCheckInterval int `koanf:"healthcheckinterval"`
+	MaxFailedChecks     int `koanf:"maxfailedchecks"`
 }
 
 // CacheConfig related to Redis
@@ -150,6 +264,145 @@ type CacheConfig struct {
 	}
 }
 
+// MemoryConfig defines where the workflow memory is persisted.
+type MemoryConfig struct {
+	// Persistence is the backend that keeps a copy of the workflow memory
+	// outside of the worker process: redis, postgres or minio. When empty,
+	// the memory only lives in the worker process.
+	Persistence string `koanf:"persistence"`
+	// TTL is the time a persisted memory is kept since it was last saved or
+	// loaded, in seconds. Pipelines can override it in their recipe. It's
+	// only applied by the Redis backend, the rest of backends keep the
+	// memory until the workflow is purged.
+	TTL int `koanf:"ttl"`
+	// Compression is the algorithm the persisted memory is compressed with:
+	// gzip or zstd. When empty, the memory is persisted uncompressed.
+	Compression string `koanf:"compression"`
+	// MaxSize is the maximum data a pipeline run holds in memory, in
+	// megabytes. Components that exceed it fail. When zero, the memory isn't
+	// limited.
+	MaxSize int `koanf:"maxsize"`
+	// EncryptionKey is the base64-encoded AES master key (16, 24 or 32
+	// bytes) that protects the keys the persisted memory is encrypted with.
+	// When empty, the memory is persisted unencrypted and the memory of the
+	// runs that use secrets or connections can't be persisted.
+	EncryptionKey string `koanf:"encryptionkey"`
+	// AcceptPlaintext allows the workers to load the memory persisted
+	// before the encryption was enabled. It should only be set while the
+	// runs started before enabling it are in flight, as it lets anyone with
+	// write access to the backend inject unencrypted memory.
+	AcceptPlaintext bool `koanf:"acceptplaintext"`
+	// BlobThreshold is the size, in kilobytes, above which the content of
+	// the files (images, audio, documents...) is kept in the blob storage
+	// instead of the memory. When zero, files are kept in memory.
+	BlobThreshold int `koanf:"blobthreshold"`
+	// BlobRetention is the number of days the files kept in the blob
+	// storage are retained. It must be longer than the time the runs keep
+	// their memory. When zero, the retention of the bucket applies.
+	BlobRetention int `koanf:"blobretention"`
+	// ReapInterval is the time, in seconds, between the sweeps that purge
+	// the memory of the workflows whose Temporal execution is closed, e.g.
+	// because the worker crashed before purging it. When zero, the memory
+	// isn't swept.
+	ReapInterval int `koanf:"reapinterval"`
+	// ReapGracePeriod is the time, in seconds, the memory of a closed
+	// workflow is kept before it's reaped, so the trigger requests can
+	// still read its outputs.
+	ReapGracePeriod int `koanf:"reapgraceperiod"`
+	// EventFlushInterval is the window, in milliseconds, in which the events
+	// streamed to the trigger requests are coalesced, so the high-frequency
+	// updates are sent at most once per window. When zero, every event is
+	// sent as soon as it's produced.
+	EventFlushInterval int `koanf:"eventflushinterval"`
+	// Redis is the deployment the memory is persisted to and its events are
+	// published to, when they use Redis. When its mode is empty, the Redis
+	// client of the cache is used.
+	Redis MemoryRedisConfig `koanf:"redis"`
+	// Watchdog limits the data held by the workflow memories of a worker.
+	Watchdog struct {
+		// Limit is the data the workflow memories of the worker can hold,
+		// in megabytes. When zero, the watchdog is disabled.
+		Limit int `koanf:"limit"`
+		// HeavyTriggerSize is the payload size, in kilobytes, from which
+		// the triggers are refused when they don't fit in the limit.
+		HeavyTriggerSize int `koanf:"heavytriggersize"`
+		// IdleTime is the time, in seconds, after which an unused workflow
+		// memory can be spilled to the persistence backend.
+		IdleTime int `koanf:"idletime"`
+		// Interval is the time, in seconds, between two checks of the
+		// memory usage.
+		Interval int `koanf:"interval"`
+	} `koanf:"watchdog"`
+}
+
+// MemoryRedisConfig defines a Redis Cluster or Sentinel deployment.
+type MemoryRedisConfig struct {
+	// Mode is the topology of the deployment: cluster or sentinel.
+	Mode string `koanf:"mode"`
+	// Addrs are the seed nodes of the cluster or the addresses of the
+	// sentinels.
+	Addrs []string `koanf:"addrs"`
+	// MasterName is the name of the master monitored by the sentinels.
+	MasterName string `koanf:"mastername"`
+	Username   string `koanf:"username"`
+	Password   string `koanf:"password"`
+	// SentinelPassword authenticates the connections to the sentinels.
+	SentinelPassword string `koanf:"sentinelpassword"`
+	// DB is the database of the Sentinel deployment. Clusters only have the
+	// database 0.
+	DB int `koanf:"db"`
+	// KeyPrefix is prepended to the memory keys and the event channels, so
+	// several deployments can share the same Redis.
+	KeyPrefix string `koanf:"keyprefix"`
+	// TenantIsolation prefixes the keys and the event channels of each run
+	// with the UID of the namespace that owns the pipeline.
+	TenantIsolation bool `koanf:"tenantisolation"`
+	// TenantDBs moves the memory of some namespaces, indexed by UID, to a
+	// dedicated database. It requires the tenant isolation and isn't
+	// available in clusters.
+	TenantDBs map[string]int `koanf:"tenantdbs"`
+}
+
+// EventBusConfig defines the streaming infrastructure the events of the
+// pipeline runs are published to, besides the trigger requests that stream
+// them.
+type EventBusConfig struct {
+	// Type is the event bus: redis, nats or kafka. When empty, the events
+	// aren't published.
+	Type string `koanf:"type"`
+	// EncryptionKey is the base64-encoded AES key (16, 24 or 32 bytes) the
+	// events are encrypted with, which the consumers must share. When
+	// empty, the events are published as plain JSON, so the inputs and
+	// outputs of the runs are readable by anyone with access to the bus.
+	EncryptionKey string `koanf:"encryptionkey"`
+	// NATS publishes the events to JetStream, under the subject followed by
+	// the workflow ID. A stream must capture the subjects.
+	NATS struct {
+		URL     string `koanf:"url"`
+		Subject string `koanf:"subject"`
+	} `koanf:"nats"`
+	// Kafka produces the events to a topic through the Confluent REST
+	// Proxy.
+	Kafka struct {
+		RestProxyURL string `koanf:"restproxyurl"`
+		Topic        string `koanf:"topic"`
+		Username     string `koanf:"username"`
+		Password     string `koanf:"password"`
+	} `koanf:"kafka"`
+}
+
+// SessionConfig defines where the history of the conversation sessions is
+// stored.
+type SessionConfig struct {
+	// Store is the backend of the sessions: redis or postgres. When empty,
+	// conversation sessions are disabled.
+	Store string `koanf:"store"`
+	// TTL is the time a session is kept since its last message, in seconds.
+	// It's only applied by the Redis backend. When zero, sessions don't
+	// expire.
+	TTL int `koanf:"ttl"`
+}
+
 // MgmtBackendConfig related to mgmt-backend
 type MgmtBackendConfig struct {
 	Host        string `koanf:"host"`
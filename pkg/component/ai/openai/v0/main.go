@@ -200,7 +200,7 @@ func (e *execution) worker(ctx context.Context, client *httpclient.Client, job *
 				FrequencyPenalty: inputStruct.FrequencyPenalty,
 			}
 			resp := textCompletionResp{}
-			req := client.R().SetResult(&resp).SetBody(body)
+			req := client.R().SetContext(ctx).SetResult(&resp).SetBody(body)
 			if _, err := req.Post(completionsPath); err != nil {
 				job.Error.Error(ctx, err)
 				return
@@ -278,7 +278,7 @@ func (e *execution) worker(ctx context.Context, client *httpclient.Client, job *
 
 			}
 
-			req := client.SetDoNotParseResponse(true).R().SetBody(body)
+			req := client.SetDoNotParseResponse(true).R().SetContext(ctx).SetBody(body)
 			restyResp, err := req.Post(completionsPath)
 			if err != nil {
 				job.Error.Error(ctx, err)
@@ -411,7 +411,7 @@ func (e *execution) worker(ctx context.Context, client *httpclient.Client, job *
 		}
 
 		resp := AudioTranscriptionResp{}
-		req := client.R().SetBody(data).SetResult(&resp).SetHeader("Content-Type", ct)
+		req := client.R().SetContext(ctx).SetBody(data).SetResult(&resp).SetHeader("Content-Type", ct)
 		if _, err := req.Post(transcriptionsPath); err != nil {
 			job.Error.Error(ctx, err)
 			return
@@ -436,7 +436,7 @@ func (e *execution) worker(ctx context.Context, client *httpclient.Client, job *
 			return
 		}
 
-		req := client.R().SetBody(TextToSpeechReq{
+		req := client.R().SetContext(ctx).SetBody(TextToSpeechReq{
 			Input:          inputStruct.Text,
 			Model:          inputStruct.Model,
 			Voice:          inputStruct.Voice,
@@ -476,7 +476,7 @@ func (e *execution) worker(ctx context.Context, client *httpclient.Client, job *
 		}
 
 		resp := ImageGenerationsResp{}
-		req := client.R().SetBody(ImageGenerationsReq{
+		req := client.R().SetContext(ctx).SetBody(ImageGenerationsReq{
 			Model:          inputStruct.Model,
 			Prompt:         inputStruct.Prompt,
 			Quality:        inputStruct.Quality,
@@ -574,7 +574,7 @@ func (e *execution) executeEmbedding(ctx context.Context, client *httpclient.Cli
 		}
 	}
 
-	req := client.R().SetBody(reqParams).SetResult(&resp)
+	req := client.R().SetContext(ctx).SetBody(reqParams).SetResult(&resp)
 	if _, err := req.Post(embeddingsPath); err != nil {
 		for _, job := range jobs {
 			job.Error.Error(ctx, err)
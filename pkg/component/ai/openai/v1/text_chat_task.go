@@ -83,8 +83,8 @@ type O1ModelRequester struct {
 	Client httpclient.IClient
 }
 
-// When it supports streaming, the job and ctx will be used.
-func (r *O1ModelRequester) SendChatRequest(_ *base.Job, _ context.Context) (*structpb.Struct, error) {
+// When it supports streaming, the job will be used.
+func (r *O1ModelRequester) SendChatRequest(_ *base.Job, ctx context.Context) (*structpb.Struct, error) {
 
 	input := r.Input
 	// Note: The o1-series models don't support streaming.
@@ -95,7 +95,7 @@ func (r *O1ModelRequester) SendChatRequest(_ *base.Job, _ context.Context) (*str
 	resp := textChatResp{}
 	client := r.Client
 
-	req := client.R().SetResult(&resp).SetBody(chatReq)
+	req := client.R().SetContext(ctx).SetResult(&resp).SetBody(chatReq)
 
 	if resp, err := req.Post(completionsPath); err != nil {
 		errMsg := resp.Body()
@@ -159,7 +159,7 @@ func (r *ChatModelRequester) SendChatRequest(job *base.Job, ctx context.Context)
 
 func sendRequest(chatReq textChatReq, client httpclient.IClient, job *base.Job, ctx context.Context) (ai.TextChatOutput, error) {
 
-	req := client.SetDoNotParseResponse(true).R().SetBody(chatReq)
+	req := client.SetDoNotParseResponse(true).R().SetContext(ctx).SetBody(chatReq)
 
 	outputStruct := ai.TextChatOutput{}
 	restyResp, err := req.Post(completionsPath)
@@ -0,0 +1,224 @@
+package universalai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/ai"
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/pipeline-backend/pkg/component/internal/util/httpclient"
+	"github.com/instill-ai/x/errmsg"
+
+	openaiv1 "github.com/instill-ai/pipeline-backend/pkg/component/ai/openai/v1"
+)
+
+const defaultExtractionMaxRetries = 2
+
+type extractStructuredDataInput struct {
+	Data struct {
+		Text         string         `json:"text"`
+		Schema       map[string]any `json:"schema"`
+		Instructions string         `json:"instructions"`
+	} `json:"data"`
+	Parameter struct {
+		MaxRetries  *int     `json:"max-retries"`
+		MaxTokens   *int     `json:"max-tokens"`
+		Temperature *float32 `json:"temperature"`
+		Seed        *int     `json:"seed"`
+	} `json:"parameter"`
+}
+
+type extractStructuredDataOutput struct {
+	Data struct {
+		Object   map[string]any `json:"object"`
+		Attempts int            `json:"attempts"`
+	} `json:"data"`
+	Metadata ai.Metadata `json:"metadata"`
+}
+
+// discardOutput drops the partial outputs streamed by the chat requests, as
+// they have the format of a chat response rather than the one of this task.
+type discardOutput struct{}
+
+func (discardOutput) Write(context.Context, *structpb.Struct) error { return nil }
+
+// executeExtractStructuredData asks the model to extract the data described
+// by a JSON Schema from a text. When the reply isn't valid JSON or doesn't
+// conform to the schema, the validation error is sent back to the model,
+// which gets up to max-retries chances to fix it.
+func (e *execution) executeExtractStructuredData(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	x := e.ComponentExecution
+	model := getModel(x.GetSetup())
+
+	inputStruct := extractStructuredDataInput{}
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	vendor, ok := modelVendorMap[model]
+	if !ok {
+		return nil, fmt.Errorf("unsupported vendor for model: %s", model)
+	}
+
+	sch, schemaJSON, err := compileExtractionSchema(inputStruct.Data.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newClient(x.GetSetup(), x.GetLogger(), vendor)
+	if err != nil {
+		return nil, err
+	}
+
+	maxRetries := defaultExtractionMaxRetries
+	if inputStruct.Parameter.MaxRetries != nil {
+		maxRetries = max(*inputStruct.Parameter.MaxRetries, 0)
+	}
+
+	chatInput := ai.TextChatInput{
+		Data: ai.InputData{
+			Model: model,
+			Messages: []ai.InputMessage{
+				textMessage("system", extractionPrompt(schemaJSON, inputStruct.Data.Instructions)),
+				textMessage("user", inputStruct.Data.Text),
+			},
+		},
+		Parameter: ai.Parameter{
+			MaxTokens:   inputStruct.Parameter.MaxTokens,
+			Temperature: inputStruct.Parameter.Temperature,
+			Seed:        inputStruct.Parameter.Seed,
+		},
+	}
+	chatJob := &base.Job{Input: job.Input, Output: discardOutput{}, Error: job.Error}
+
+	outputStruct := extractStructuredDataOutput{}
+	var lastErr error
+	for attempt := 1; attempt <= maxRetries+1; attempt++ {
+		var reply string
+		switch vendor {
+		case "openai":
+			reply, err = sendChat(ctx, chatInput, client.(*httpclient.Client), chatJob, &outputStruct.Metadata.Usage)
+		default:
+			return nil, fmt.Errorf("unsupported vendor: %s", vendor)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err := parseExtraction(reply, sch)
+		if err == nil {
+			outputStruct.Data.Object = obj
+			outputStruct.Data.Attempts = attempt
+			return base.ConvertToStructpb(outputStruct)
+		}
+
+		lastErr = err
+		chatInput.Data.Messages = append(chatInput.Data.Messages,
+			textMessage("assistant", reply),
+			textMessage("user", fmt.Sprintf("The reply isn't valid: %s. Reply again with only the JSON object, fixed to conform to the schema.", err)),
+		)
+	}
+
+	return nil, errmsg.AddMessage(
+		fmt.Errorf("extracting structured data: %w", lastErr),
+		fmt.Sprintf("The model didn't return data that conforms to the schema after %d attempts: %s", maxRetries+1, lastErr),
+	)
+}
+
+func textMessage(role, text string) ai.InputMessage {
+	return ai.InputMessage{
+		Role:     role,
+		Contents: []ai.Content{{Type: "text", Text: text}},
+	}
+}
+
+func extractionPrompt(schemaJSON []byte, instructions string) string {
+	prompt := "Extract the information from the text provided by the user. " +
+		"Reply only with a JSON object, without any explanation or code fences, that conforms to the following JSON Schema:\n" +
+		string(schemaJSON)
+	if instructions != "" {
+		prompt += "\n\n" + instructions
+	}
+	return prompt
+}
+
+// sendChat sends a chat request and returns the content of the first
+// choice. The token usage is added to the one of the previous attempts.
+func sendChat(ctx context.Context, input ai.TextChatInput, client *httpclient.Client, job *base.Job, usage *ai.Usage) (string, error) {
+	resp, err := openaiv1.ExecuteTextChat(input, client, job, ctx)
+	if err != nil {
+		return "", err
+	}
+
+	output := ai.TextChatOutput{}
+	if err := base.ConvertFromStructpb(resp, &output); err != nil {
+		return "", err
+	}
+
+	usage.CompletionTokens += output.Metadata.Usage.CompletionTokens
+	usage.PromptTokens += output.Metadata.Usage.PromptTokens
+	usage.TotalTokens += output.Metadata.Usage.TotalTokens
+
+	if len(output.Data.Choices) == 0 {
+		return "", fmt.Errorf("the model returned no choices")
+	}
+	return output.Data.Choices[0].Message.Content, nil
+}
+
+func compileExtractionSchema(schema map[string]any) (*jsonschema.Schema, []byte, error) {
+	if len(schema) == 0 {
+		return nil, nil, errmsg.AddMessage(fmt.Errorf("missing schema"), "A JSON Schema is required to extract structured data.")
+	}
+
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("schema.json", bytes.NewReader(b)); err != nil {
+		return nil, nil, err
+	}
+	sch, err := c.Compile("schema.json")
+	if err != nil {
+		return nil, nil, errmsg.AddMessage(
+			fmt.Errorf("compiling schema: %w", err),
+			fmt.Sprintf("The JSON Schema isn't valid: %s", err),
+		)
+	}
+
+	return sch, b, nil
+}
+
+// parseExtraction decodes the reply of the model and validates it against
+// the schema. Models often wrap JSON in a Markdown code block despite being
+// told not to, so the fences are removed.
+func parseExtraction(reply string, sch *jsonschema.Schema) (map[string]any, error) {
+	reply = strings.TrimSpace(reply)
+	if strings.HasPrefix(reply, "```") {
+		reply = strings.TrimPrefix(reply, "```json")
+		reply = strings.TrimPrefix(reply, "```")
+		reply = strings.TrimSuffix(reply, "```")
+	}
+
+	var v any
+	if err := json.Unmarshal([]byte(reply), &v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if err := sch.Validate(v); err != nil {
+		return nil, fmt.Errorf("doesn't conform to the schema: %w", err)
+	}
+
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("the reply must be a JSON object")
+	}
+	return obj, nil
+}
@@ -0,0 +1,215 @@
+package universalai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/x/errmsg"
+)
+
+var personSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"name": map[string]any{"type": "string"},
+		"age":  map[string]any{"type": "integer"},
+	},
+	"required": []any{"name", "age"},
+}
+
+func TestExecuteExtractStructuredData(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	testCases := []struct {
+		name       string
+		replies    []string
+		maxRetries int
+		want       map[string]any
+		wantErr    string
+	}{
+		{
+			name:       "ok - valid reply",
+			replies:    []string{`{"name": "Ada", "age": 36}`},
+			maxRetries: 2,
+			want: map[string]any{
+				"data": map[string]any{
+					"object":   map[string]any{"name": "Ada", "age": 36},
+					"attempts": 1,
+				},
+				"metadata": map[string]any{
+					"usage": map[string]any{"completion-tokens": 5, "prompt-tokens": 10, "total-tokens": 15},
+				},
+			},
+		},
+		{
+			name: "ok - fixed after a retry",
+			replies: []string{
+				`{"name": "Ada", "age": "thirty-six"}`,
+				"```json\n{\"name\": \"Ada\", \"age\": 36}\n```",
+			},
+			maxRetries: 2,
+			want: map[string]any{
+				"data": map[string]any{
+					"object":   map[string]any{"name": "Ada", "age": 36},
+					"attempts": 2,
+				},
+				"metadata": map[string]any{
+					"usage": map[string]any{"completion-tokens": 10, "prompt-tokens": 20, "total-tokens": 30},
+				},
+			},
+		},
+		{
+			name:       "nok - retries exhausted",
+			replies:    []string{"Ada is 36.", "Ada is 36."},
+			maxRetries: 1,
+			wantErr:    "The model didn't return data that conforms to the schema after 2 attempts",
+		},
+	}
+
+	component := Init(base.Component{})
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			var calls int
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.URL.Path, qt.Equals, "/v1/chat/completions")
+
+				var req struct {
+					Messages []map[string]any `json:"messages"`
+				}
+				c.Check(json.NewDecoder(r.Body).Decode(&req), qt.IsNil)
+
+				// Each retry sends back the previous reply and the
+				// validation error.
+				c.Check(req.Messages, qt.HasLen, 2+2*calls)
+
+				reply := tc.replies[calls]
+				calls++
+
+				resp, err := json.Marshal(map[string]any{
+					"choices": []any{map[string]any{
+						"index":         0,
+						"finish_reason": "stop",
+						"message":       map[string]any{"role": "assistant", "content": reply},
+					}},
+					"usage": map[string]any{"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15},
+				})
+				c.Check(err, qt.IsNil)
+
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintln(w, string(resp))
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup, err := structpb.NewStruct(map[string]any{
+				"model":     "gpt-4o",
+				"api-key":   "testAPIKey",
+				"base-path": srv.URL,
+			})
+			c.Assert(err, qt.IsNil)
+
+			e, err := component.CreateExecution(base.ComponentExecution{
+				Component: component,
+				Setup:     setup,
+				Task:      ExtractStructuredDataTask,
+			})
+			c.Assert(err, qt.IsNil)
+
+			input, err := structpb.NewStruct(map[string]any{
+				"data": map[string]any{
+					"text":   "Ada Lovelace died at 36.",
+					"schema": personSchema,
+				},
+				"parameter": map[string]any{
+					"max-retries": tc.maxRetries,
+				},
+			})
+			c.Assert(err, qt.IsNil)
+
+			got, err := e.(*execution).executeExtractStructuredData(input, &base.Job{}, ctx)
+			if tc.wantErr != "" {
+				c.Check(errmsg.Message(err), qt.Contains, tc.wantErr)
+				c.Check(calls, qt.Equals, tc.maxRetries+1)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			gotJSON, err := got.MarshalJSON()
+			c.Assert(err, qt.IsNil)
+			c.Check(gotJSON, qt.JSONEquals, tc.want)
+		})
+	}
+}
+
+func TestCompileExtractionSchema(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("nok - missing schema", func(c *qt.C) {
+		_, _, err := compileExtractionSchema(nil)
+		c.Check(errmsg.Message(err), qt.Equals, "A JSON Schema is required to extract structured data.")
+	})
+
+	c.Run("nok - invalid schema", func(c *qt.C) {
+		_, _, err := compileExtractionSchema(map[string]any{"type": 3})
+		c.Check(errmsg.Message(err), qt.Matches, "(?s)The JSON Schema isn't valid: .*")
+	})
+}
+
+func TestParseExtraction(t *testing.T) {
+	c := qt.New(t)
+
+	sch, _, err := compileExtractionSchema(personSchema)
+	c.Assert(err, qt.IsNil)
+
+	testCases := []struct {
+		name    string
+		reply   string
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name:  "ok - plain JSON",
+			reply: `{"name": "Ada", "age": 36}`,
+			want:  map[string]any{"name": "Ada", "age": float64(36)},
+		},
+		{
+			name:  "ok - code fences",
+			reply: "```json\n{\"name\": \"Ada\", \"age\": 36}\n```",
+			want:  map[string]any{"name": "Ada", "age": float64(36)},
+		},
+		{
+			name:    "nok - invalid JSON",
+			reply:   "Ada is 36.",
+			wantErr: "invalid JSON: .*",
+		},
+		{
+			name:    "nok - schema violation",
+			reply:   `{"name": "Ada"}`,
+			wantErr: "(?s)doesn't conform to the schema: .*",
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			got, err := parseExtraction(tc.reply, sch)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(got, qt.DeepEquals, tc.want)
+		})
+	}
+}
@@ -14,10 +14,11 @@ import (
 )
 
 const (
-	TextChatTask    = "TASK_CHAT"
-	cfgAPIKey       = "api-key"
-	cfgOrganization = "organization"
-	retryCount      = 3
+	TextChatTask              = "TASK_CHAT"
+	ExtractStructuredDataTask = "TASK_EXTRACT_STRUCTURED_DATA"
+	cfgAPIKey                 = "api-key"
+	cfgOrganization           = "organization"
+	retryCount                = 3
 )
 
 var (
@@ -80,6 +81,8 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 	switch x.Task {
 	case TextChatTask:
 		e.execute = e.executeTextChat
+	case ExtractStructuredDataTask:
+		e.execute = e.executeExtractStructuredData
 	default:
 		return nil, fmt.Errorf("unknown task: %s", x.Task)
 	}
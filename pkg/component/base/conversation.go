@@ -0,0 +1,37 @@
+package base
+
+import "context"
+
+// ConversationMessage is a message in the history of a conversation session.
+type ConversationMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ConversationStore keeps the history of the conversation sessions that
+// chat pipelines can read from and append to. The sessions are scoped to the
+// owner of the pipeline.
+type ConversationStore interface {
+	// AppendMessages adds messages at the end of the history of a session,
+	// creating the session if it doesn't exist.
+	AppendMessages(ctx context.Context, sessionID string, messages []ConversationMessage) error
+	// ListMessages returns the last messages of a session, oldest first. A
+	// non-positive limit returns the whole history.
+	ListMessages(ctx context.Context, sessionID string, limit int) ([]ConversationMessage, error)
+}
+
+type conversationStoreKey struct{}
+
+// ContextWithConversationStore returns a copy of the context that carries a
+// conversation store, which the component executions can fetch with
+// ConversationStoreFromContext.
+func ContextWithConversationStore(ctx context.Context, s ConversationStore) context.Context {
+	return context.WithValue(ctx, conversationStoreKey{}, s)
+}
+
+// ConversationStoreFromContext returns the conversation store in the context,
+// if any.
+func ConversationStoreFromContext(ctx context.Context) (ConversationStore, bool) {
+	s, ok := ctx.Value(conversationStoreKey{}).(ConversationStore)
+	return s, ok && s != nil
+}
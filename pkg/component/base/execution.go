@@ -33,6 +33,11 @@ type Job struct {
 	Input  InputReader
 	Output OutputWriter
 	Error  ErrorHandler
+
+	// Artifact stores the files produced by the job as artifacts of the
+	// pipeline run. It might be nil (e.g. in unit tests), so components must
+	// check it before registering an artifact.
+	Artifact ArtifactWriter
 }
 
 type InputReader interface {
@@ -45,6 +50,21 @@ type ErrorHandler interface {
 	Error(ctx context.Context, err error)
 }
 
+// ArtifactWriter registers the files produced by a component in a pipeline
+// run. Unlike output fields, artifacts aren't kept in the run memory, so they
+// suit large generated files (reports, images, etc.).
+type ArtifactWriter interface {
+	WriteArtifact(ctx context.Context, artifact *Artifact) error
+}
+
+// Artifact is a named file produced by a component.
+type Artifact struct {
+	Name        string
+	ContentType string
+	Content     []byte
+	Metadata    map[string]string
+}
+
 // ComponentExecution implements the common methods for component execution.
 type ComponentExecution struct {
 	Component IComponent
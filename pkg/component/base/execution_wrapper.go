@@ -100,9 +100,10 @@ func (e *ExecutionWrapper) Execute(ctx context.Context, jobs []*Job) (err error)
 	wrappedJobs := make([]*Job, len(validJobs))
 	for batchIdx, job := range validJobs {
 		wrappedJobs[batchIdx] = &Job{
-			Input:  NewInputReader(validInputs[batchIdx], e.GetTaskInputSchema()),
-			Output: NewOutputWriter(job.Output, e.GetTaskOutputSchema()),
-			Error:  job.Error,
+			Input:    NewInputReader(validInputs[batchIdx], e.GetTaskInputSchema()),
+			Output:   NewOutputWriter(job.Output, e.GetTaskOutputSchema()),
+			Error:    job.Error,
+			Artifact: job.Artifact,
 		}
 	}
 
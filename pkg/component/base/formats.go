@@ -56,6 +56,7 @@ func (s InstillAcceptFormatsSchema) Validate(ctx jsonschema.ValidationContext, v
 
 			switch {
 			case instillAcceptFormat == "string",
+				instillAcceptFormat == "file-ref",
 				instillAcceptFormat == "*",
 				instillAcceptFormat == "*/*",
 				strings.HasPrefix(instillAcceptFormat, "semi-structured"),
@@ -117,6 +118,7 @@ func (s InstillFormatSchema) Validate(ctx jsonschema.ValidationContext, v interf
 	case string:
 		switch {
 		case s == "string",
+			s == "file-ref",
 			s == "*",
 			s == "*/*",
 			strings.HasPrefix(string(s), "semi-structured"),
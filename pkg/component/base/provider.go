@@ -0,0 +1,18 @@
+package base
+
+import "errors"
+
+// ErrProviderUnavailable is matched by the errors that components return when
+// the service they connect to is down or has exhausted its quota. Pipelines
+// can fail over to an equivalent provider when they find this error.
+var ErrProviderUnavailable = errors.New("provider unavailable")
+
+// UpstreamStatus returns the HTTP status code with which the service a
+// component connects to responded, if the error carries it.
+func UpstreamStatus(err error) (int, bool) {
+	var se interface{ StatusCode() int }
+	if errors.As(err, &se) {
+		return se.StatusCode(), true
+	}
+	return 0, false
+}
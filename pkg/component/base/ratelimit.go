@@ -0,0 +1,27 @@
+package base
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitHandler is notified when a request issued by a component has been
+// rate limited by the remote service and is going to be retried after the
+// specified wait.
+type RateLimitHandler func(ctx context.Context, wait time.Duration)
+
+type rateLimitHandlerKey struct{}
+
+// ContextWithRateLimitHandler returns a copy of the context that carries a
+// rate limit handler. The component executions that propagate the context to
+// their requests will notify the handler when they're rate limited.
+func ContextWithRateLimitHandler(ctx context.Context, h RateLimitHandler) context.Context {
+	return context.WithValue(ctx, rateLimitHandlerKey{}, h)
+}
+
+// NotifyRateLimited calls the rate limit handler in the context, if any.
+func NotifyRateLimited(ctx context.Context, wait time.Duration) {
+	if h, ok := ctx.Value(rateLimitHandlerKey{}).(RateLimitHandler); ok && h != nil {
+		h(ctx, wait)
+	}
+}
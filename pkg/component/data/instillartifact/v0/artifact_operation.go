@@ -623,3 +623,203 @@ func (e *execution) matchFileStatus(input *structpb.Struct) (*structpb.Struct, e
 	}
 
 }
+
+type ListCatalogsInput struct {
+	Namespace string `json:"namespace"`
+}
+
+type ListCatalogsOutput struct {
+	Catalogs []CatalogOutput `json:"catalogs"`
+}
+
+type CatalogOutput struct {
+	CatalogID   string   `json:"catalog-id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	TotalFiles  uint32   `json:"total-files"`
+	TotalTokens uint32   `json:"total-tokens"`
+	CreateTime  string   `json:"create-time"`
+	UpdateTime  string   `json:"update-time"`
+}
+
+func (e *execution) listCatalogs(input *structpb.Struct) (*structpb.Struct, error) {
+
+	inputStruct := ListCatalogsInput{}
+	err := base.ConvertFromStructpb(input, &inputStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert input to struct: %w", err)
+	}
+
+	artifactClient, connection := e.client, e.connection
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
+
+	catalogsRes, err := artifactClient.ListCatalogs(ctx, &artifactPB.ListCatalogsRequest{
+		NamespaceId: inputStruct.Namespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalogs: %w", err)
+	}
+
+	output := ListCatalogsOutput{
+		Catalogs: []CatalogOutput{},
+	}
+
+	for _, catalogPB := range catalogsRes.Catalogs {
+		tags := catalogPB.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		output.Catalogs = append(output.Catalogs, CatalogOutput{
+			CatalogID:   catalogPB.CatalogId,
+			Name:        catalogPB.Name,
+			Description: catalogPB.Description,
+			Tags:        tags,
+			TotalFiles:  catalogPB.TotalFiles,
+			TotalTokens: catalogPB.TotalTokens,
+			CreateTime:  catalogPB.CreateTime,
+			UpdateTime:  catalogPB.UpdateTime,
+		})
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+type ProcessFilesInput struct {
+	FileUIDs []string `json:"file-uids"`
+}
+
+type ProcessFilesOutput struct {
+	Files []FileOutput `json:"files"`
+}
+
+func (e *execution) processFiles(input *structpb.Struct) (*structpb.Struct, error) {
+
+	inputStruct := ProcessFilesInput{}
+	err := base.ConvertFromStructpb(input, &inputStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert input to struct: %w", err)
+	}
+
+	artifactClient, connection := e.client, e.connection
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
+
+	processRes, err := artifactClient.ProcessCatalogFiles(ctx, &artifactPB.ProcessCatalogFilesRequest{
+		FileUids: inputStruct.FileUIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to process catalog files: %w", err)
+	}
+
+	output := ProcessFilesOutput{
+		Files: []FileOutput{},
+	}
+
+	for _, filePB := range processRes.Files {
+		output.Files = append(output.Files, FileOutput{
+			FileUID:    filePB.FileUid,
+			FileName:   filePB.Name,
+			FileType:   artifactPB.FileType_name[int32(filePB.Type)],
+			CreateTime: filePB.CreateTime.AsTime().Format(time.RFC3339),
+			UpdateTime: filePB.UpdateTime.AsTime().Format(time.RFC3339),
+			Size:       filePB.Size,
+		})
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+type UpdateChunkInput struct {
+	ChunkUID    string `json:"chunk-uid"`
+	Retrievable bool   `json:"retrievable"`
+}
+
+type UpdateChunkOutput struct {
+	Chunk ChunkOutput `json:"chunk"`
+}
+
+func (e *execution) updateChunk(input *structpb.Struct) (*structpb.Struct, error) {
+
+	inputStruct := UpdateChunkInput{}
+	err := base.ConvertFromStructpb(input, &inputStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert input to struct: %w", err)
+	}
+
+	artifactClient, connection := e.client, e.connection
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
+
+	chunkRes, err := artifactClient.UpdateChunk(ctx, &artifactPB.UpdateChunkRequest{
+		ChunkUid:    inputStruct.ChunkUID,
+		Retrievable: inputStruct.Retrievable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update chunk: %w", err)
+	}
+
+	chunkPB := chunkRes.Chunk
+	output := UpdateChunkOutput{
+		Chunk: ChunkOutput{
+			ChunkUID:        chunkPB.ChunkUid,
+			Retrievable:     chunkPB.Retrievable,
+			StartPosition:   chunkPB.StartPos,
+			EndPosition:     chunkPB.EndPos,
+			TokenCount:      chunkPB.Tokens,
+			CreateTime:      chunkPB.CreateTime.AsTime().Format(time.RFC3339),
+			OriginalFileUID: chunkPB.OriginalFileUid,
+		},
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+type DeleteFileInput struct {
+	FileUID string `json:"file-uid"`
+}
+
+type DeleteFileOutput struct {
+	FileUID string `json:"file-uid"`
+}
+
+func (e *execution) deleteFile(input *structpb.Struct) (*structpb.Struct, error) {
+
+	inputStruct := DeleteFileInput{}
+	err := base.ConvertFromStructpb(input, &inputStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert input to struct: %w", err)
+	}
+
+	artifactClient, connection := e.client, e.connection
+
+	defer connection.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, getRequestMetadata(e.SystemVariables))
+
+	deleteRes, err := artifactClient.DeleteCatalogFile(ctx, &artifactPB.DeleteCatalogFileRequest{
+		FileUid: inputStruct.FileUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete catalog file: %w", err)
+	}
+
+	return base.ConvertToStructpb(DeleteFileOutput{
+		FileUID: deleteRes.FileUid,
+	})
+}
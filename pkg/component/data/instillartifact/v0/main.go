@@ -24,6 +24,10 @@ const (
 	taskMatchFileStatus   string = "TASK_MATCH_FILE_STATUS"
 	taskSearchChunks      string = "TASK_RETRIEVE"
 	taskQuery             string = "TASK_ASK"
+	taskListCatalogs      string = "TASK_LIST_CATALOGS"
+	taskProcessFiles      string = "TASK_PROCESS_FILES"
+	taskUpdateChunk       string = "TASK_UPDATE_CHUNK"
+	taskDeleteFile        string = "TASK_DELETE_FILE"
 )
 
 var (
@@ -86,6 +90,14 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = e.searchChunks
 	case taskQuery:
 		e.execute = e.query
+	case taskListCatalogs:
+		e.execute = e.listCatalogs
+	case taskProcessFiles:
+		e.execute = e.processFiles
+	case taskUpdateChunk:
+		e.execute = e.updateChunk
+	case taskDeleteFile:
+		e.execute = e.deleteFile
 	default:
 		return nil, fmt.Errorf("%s task is not supported", x.Task)
 	}
@@ -462,6 +462,254 @@ func Test_matchFileStatus(t *testing.T) {
 
 }
 
+func Test_listCatalogs(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	c.Run("list catalogs", func(c *quicktest.C) {
+		component := Init(base.Component{})
+
+		sysVar := map[string]interface{}{
+			"__ARTIFACT_BACKEND":       "http://localhost:8082",
+			"__PIPELINE_USER_UID":      "fakeUser",
+			"__PIPELINE_REQUESTER_UID": "fakeRequester",
+		}
+
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskListCatalogs},
+		}
+
+		e.execute = e.listCatalogs
+
+		input := ListCatalogsInput{
+			Namespace: "fakeNs",
+		}
+
+		inputStruct, _ := base.ConvertToStructpb(input)
+
+		clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+		clientMock.ListCatalogsMock.Expect(minimock.AnyContext, &artifactPB.ListCatalogsRequest{
+			NamespaceId: "fakeNs",
+		}).Times(1).Return(&artifactPB.ListCatalogsResponse{
+			Catalogs: []*artifactPB.Catalog{
+				{
+					CatalogId:   "fakeID",
+					Name:        "fakeName",
+					Description: "fakeDescription",
+					Tags:        []string{"fakeTag"},
+					TotalFiles:  2,
+					TotalTokens: 100,
+					CreateTime:  "1970-01-01T00:00:01Z",
+					UpdateTime:  "1970-01-01T00:00:02Z",
+				},
+			},
+		}, nil)
+
+		e.client = clientMock
+		e.connection = fakeConnection{}
+
+		output, err := e.execute(inputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		var outputStruct ListCatalogsOutput
+		err = base.ConvertFromStructpb(output, &outputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		c.Assert(outputStruct.Catalogs, quicktest.DeepEquals, []CatalogOutput{
+			{
+				CatalogID:   "fakeID",
+				Name:        "fakeName",
+				Description: "fakeDescription",
+				Tags:        []string{"fakeTag"},
+				TotalFiles:  2,
+				TotalTokens: 100,
+				CreateTime:  "1970-01-01T00:00:01Z",
+				UpdateTime:  "1970-01-01T00:00:02Z",
+			},
+		})
+	})
+}
+
+func Test_processFiles(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	c.Run("process files", func(c *quicktest.C) {
+		component := Init(base.Component{})
+
+		sysVar := map[string]interface{}{
+			"__ARTIFACT_BACKEND":       "http://localhost:8082",
+			"__PIPELINE_USER_UID":      "fakeUser",
+			"__PIPELINE_REQUESTER_UID": "fakeRequester",
+		}
+
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskProcessFiles},
+		}
+
+		e.execute = e.processFiles
+
+		input := ProcessFilesInput{
+			FileUIDs: []string{"fakeFileID"},
+		}
+
+		inputStruct, _ := base.ConvertToStructpb(input)
+
+		clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+		clientMock.ProcessCatalogFilesMock.Expect(minimock.AnyContext, &artifactPB.ProcessCatalogFilesRequest{
+			FileUids: []string{"fakeFileID"},
+		}).Times(1).Return(&artifactPB.ProcessCatalogFilesResponse{
+			Files: []*artifactPB.File{
+				{
+					FileUid: "fakeFileID",
+					Name:    "fakeFileName",
+					Type:    artifactPB.FileType_FILE_TYPE_PDF,
+					Size:    1,
+					CreateTime: &timestamppb.Timestamp{
+						Seconds: 1,
+					},
+					UpdateTime: &timestamppb.Timestamp{
+						Seconds: 1,
+					},
+				},
+			},
+		}, nil)
+
+		e.client = clientMock
+		e.connection = fakeConnection{}
+
+		output, err := e.execute(inputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		var outputStruct ProcessFilesOutput
+		err = base.ConvertFromStructpb(output, &outputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		c.Assert(len(outputStruct.Files), quicktest.Equals, 1)
+		c.Assert(outputStruct.Files[0].FileUID, quicktest.Equals, "fakeFileID")
+		c.Assert(outputStruct.Files[0].FileType, quicktest.Equals, "FILE_TYPE_PDF")
+		c.Assert(outputStruct.Files[0].CreateTime, quicktest.Equals, "1970-01-01T00:00:01Z")
+	})
+}
+
+func Test_updateChunk(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	c.Run("update chunk", func(c *quicktest.C) {
+		component := Init(base.Component{})
+
+		sysVar := map[string]interface{}{
+			"__ARTIFACT_BACKEND":       "http://localhost:8082",
+			"__PIPELINE_USER_UID":      "fakeUser",
+			"__PIPELINE_REQUESTER_UID": "fakeRequester",
+		}
+
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskUpdateChunk},
+		}
+
+		e.execute = e.updateChunk
+
+		input := UpdateChunkInput{
+			ChunkUID:    "fakeChunkID",
+			Retrievable: false,
+		}
+
+		inputStruct, _ := base.ConvertToStructpb(input)
+
+		clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+		clientMock.UpdateChunkMock.Expect(minimock.AnyContext, &artifactPB.UpdateChunkRequest{
+			ChunkUid:    "fakeChunkID",
+			Retrievable: false,
+		}).Times(1).Return(&artifactPB.UpdateChunkResponse{
+			Chunk: &artifactPB.Chunk{
+				ChunkUid:    "fakeChunkID",
+				Retrievable: false,
+				StartPos:    0,
+				EndPos:      1,
+				Tokens:      1,
+				CreateTime: &timestamppb.Timestamp{
+					Seconds: 1,
+				},
+				OriginalFileUid: "fakeFileID",
+			},
+		}, nil)
+
+		e.client = clientMock
+		e.connection = fakeConnection{}
+
+		output, err := e.execute(inputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		var outputStruct UpdateChunkOutput
+		err = base.ConvertFromStructpb(output, &outputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		c.Assert(outputStruct.Chunk.ChunkUID, quicktest.Equals, "fakeChunkID")
+		c.Assert(outputStruct.Chunk.Retrievable, quicktest.Equals, false)
+		c.Assert(outputStruct.Chunk.OriginalFileUID, quicktest.Equals, "fakeFileID")
+	})
+}
+
+func Test_deleteFile(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	c.Run("delete file", func(c *quicktest.C) {
+		component := Init(base.Component{})
+
+		sysVar := map[string]interface{}{
+			"__ARTIFACT_BACKEND":       "http://localhost:8082",
+			"__PIPELINE_USER_UID":      "fakeUser",
+			"__PIPELINE_REQUESTER_UID": "fakeRequester",
+		}
+
+		e := &execution{
+			ComponentExecution: base.ComponentExecution{Component: component, SystemVariables: sysVar, Setup: nil, Task: taskDeleteFile},
+		}
+
+		e.execute = e.deleteFile
+
+		input := DeleteFileInput{
+			FileUID: "fakeFileID",
+		}
+
+		inputStruct, _ := base.ConvertToStructpb(input)
+
+		clientMock := mock.NewArtifactPublicServiceClientMock(mc)
+
+		clientMock.DeleteCatalogFileMock.Expect(minimock.AnyContext, &artifactPB.DeleteCatalogFileRequest{
+			FileUid: "fakeFileID",
+		}).Times(1).Return(&artifactPB.DeleteCatalogFileResponse{
+			FileUid: "fakeFileID",
+		}, nil)
+
+		e.client = clientMock
+		e.connection = fakeConnection{}
+
+		output, err := e.execute(inputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		var outputStruct DeleteFileOutput
+		err = base.ConvertFromStructpb(output, &outputStruct)
+
+		c.Assert(err, quicktest.IsNil)
+
+		c.Assert(outputStruct.FileUID, quicktest.Equals, "fakeFileID")
+	})
+}
+
 type fakeConnection struct{}
 
 func (f fakeConnection) Close() error {
@@ -0,0 +1,430 @@
+package vectorstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/pipeline-backend/pkg/component/internal/mock"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	apiKey     = "secret-key"
+	collection = "colors"
+	threshold  = 0.9
+)
+
+var (
+	recordA = record{
+		ID:       "1",
+		Vector:   []float64{0.1, 0.2},
+		Metadata: map[string]any{"color": "pumpkin"},
+	}
+	queryIn = queryInput{
+		Collection: collection,
+		Vector:     recordA.Vector,
+		TopK:       2,
+		Filter:     map[string]any{"color": "pumpkin"},
+		MinScore:   threshold,
+	}
+	// Only the first match is above the threshold.
+	queryOut = queryOutput{
+		Matches: []match{
+			{ID: "1", Score: 0.99, Metadata: map[string]any{"color": "pumpkin"}},
+		},
+	}
+)
+
+func TestComponent_Execute(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	testcases := []struct {
+		name    string
+		backend string
+
+		task     string
+		execIn   any
+		wantExec any
+
+		wantAuthHeader string
+		wantAuth       string
+		wantMethod     string
+		wantPath       string
+		wantReq        any
+		clientResp     string
+	}{
+		{
+			name:    "ok - pinecone upsert",
+			backend: backendPinecone,
+
+			task:     taskUpsert,
+			execIn:   upsertInput{Collection: collection, Records: []record{recordA}},
+			wantExec: upsertOutput{UpsertedCount: 1},
+
+			wantAuthHeader: "Api-Key",
+			wantAuth:       apiKey,
+			wantMethod:     http.MethodPost,
+			wantPath:       pineconeUpsertPath,
+			wantReq: pineconeUpsertReq{
+				Vectors:   []pineconeVector{{ID: "1", Values: recordA.Vector, Metadata: recordA.Metadata}},
+				Namespace: collection,
+			},
+			clientResp: `{"upsertedCount": 1}`,
+		},
+		{
+			name:    "ok - pinecone query",
+			backend: backendPinecone,
+
+			task:     taskQuery,
+			execIn:   queryIn,
+			wantExec: queryOut,
+
+			wantAuthHeader: "Api-Key",
+			wantAuth:       apiKey,
+			wantMethod:     http.MethodPost,
+			wantPath:       pineconeQueryPath,
+			wantReq: pineconeQueryReq{
+				Namespace:       collection,
+				TopK:            2,
+				Vector:          recordA.Vector,
+				IncludeMetadata: true,
+				Filter:          map[string]any{"color": map[string]any{"$eq": "pumpkin"}},
+			},
+			clientResp: `{
+	"matches": [
+		{"id": "1", "score": 0.99, "metadata": {"color": "pumpkin"}},
+		{"id": "2", "score": 0.87, "metadata": {"color": "cerulean"}}
+	]
+}`,
+		},
+		{
+			name:    "ok - pinecone delete",
+			backend: backendPinecone,
+
+			task:     taskDelete,
+			execIn:   deleteInput{Collection: collection, IDs: []string{"1", "2"}},
+			wantExec: deleteOutput{DeletedCount: 2},
+
+			wantAuthHeader: "Api-Key",
+			wantAuth:       apiKey,
+			wantMethod:     http.MethodPost,
+			wantPath:       pineconeDeletePath,
+			wantReq:        pineconeDeleteReq{IDs: []string{"1", "2"}, Namespace: collection},
+			clientResp:     `{}`,
+		},
+		{
+			name:    "ok - qdrant upsert",
+			backend: backendQdrant,
+
+			task:     taskUpsert,
+			execIn:   upsertInput{Collection: collection, Records: []record{recordA}},
+			wantExec: upsertOutput{UpsertedCount: 1},
+
+			wantAuthHeader: "api-key",
+			wantAuth:       apiKey,
+			wantMethod:     http.MethodPut,
+			wantPath:       "/collections/colors/points",
+			// Numeric IDs are sent as numbers.
+			wantReq:    `{"points": [{"id": 1, "vector": [0.1, 0.2], "payload": {"color": "pumpkin"}}]}`,
+			clientResp: `{"result": {"status": "completed"}, "status": "ok"}`,
+		},
+		{
+			name:    "ok - qdrant query",
+			backend: backendQdrant,
+
+			task:     taskQuery,
+			execIn:   queryIn,
+			wantExec: queryOut,
+
+			wantAuthHeader: "api-key",
+			wantAuth:       apiKey,
+			wantMethod:     http.MethodPost,
+			wantPath:       "/collections/colors/points/search",
+			wantReq: `{
+	"vector": [0.1, 0.2],
+	"limit": 2,
+	"filter": {"must": [{"key": "color", "match": {"value": "pumpkin"}}]},
+	"with_payload": true,
+	"with_vector": false
+}`,
+			clientResp: `{
+	"result": [
+		{"id": 1, "score": 0.99, "payload": {"color": "pumpkin"}},
+		{"id": 2, "score": 0.87, "payload": {"color": "cerulean"}}
+	],
+	"status": "ok"
+}`,
+		},
+		{
+			name:    "ok - qdrant delete",
+			backend: backendQdrant,
+
+			task:     taskDelete,
+			execIn:   deleteInput{Collection: collection, IDs: []string{"1", "7c2b1e4a-5f0d-4a8e-9b3c-2d6f1a0e8b57"}},
+			wantExec: deleteOutput{DeletedCount: 2},
+
+			wantAuthHeader: "api-key",
+			wantAuth:       apiKey,
+			wantMethod:     http.MethodPost,
+			wantPath:       "/collections/colors/points/delete",
+			wantReq:        `{"points": [1, "7c2b1e4a-5f0d-4a8e-9b3c-2d6f1a0e8b57"]}`,
+			clientResp:     `{"result": {"status": "completed"}, "status": "ok"}`,
+		},
+		{
+			name:    "ok - milvus upsert",
+			backend: backendMilvus,
+
+			task:     taskUpsert,
+			execIn:   upsertInput{Collection: collection, Records: []record{recordA}},
+			wantExec: upsertOutput{UpsertedCount: 1},
+
+			wantAuthHeader: "Authorization",
+			wantAuth:       "Bearer " + apiKey,
+			wantMethod:     http.MethodPost,
+			wantPath:       milvusUpsertPath,
+			wantReq: `{
+	"collectionName": "colors",
+	"data": [{"id": "1", "vector": [0.1, 0.2], "color": "pumpkin"}]
+}`,
+			clientResp: `{"code": 0, "data": {"upsertCount": 1, "upsertIds": ["1"]}}`,
+		},
+		{
+			name:    "ok - milvus query",
+			backend: backendMilvus,
+
+			task:     taskQuery,
+			execIn:   queryIn,
+			wantExec: queryOut,
+
+			wantAuthHeader: "Authorization",
+			wantAuth:       "Bearer " + apiKey,
+			wantMethod:     http.MethodPost,
+			wantPath:       milvusSearchPath,
+			wantReq: `{
+	"collectionName": "colors",
+	"data": [[0.1, 0.2]],
+	"annsField": "vector",
+	"limit": 2,
+	"outputFields": ["*"],
+	"filter": "color == \"pumpkin\""
+}`,
+			clientResp: `{
+	"code": 0,
+	"data": [
+		{"id": "1", "distance": 0.99, "color": "pumpkin"},
+		{"id": "2", "distance": 0.87, "color": "cerulean"}
+	]
+}`,
+		},
+		{
+			name:    "ok - milvus delete",
+			backend: backendMilvus,
+
+			task:     taskDelete,
+			execIn:   deleteInput{Collection: collection, IDs: []string{"1", "2"}},
+			wantExec: deleteOutput{DeletedCount: 2},
+
+			wantAuthHeader: "Authorization",
+			wantAuth:       "Bearer " + apiKey,
+			wantMethod:     http.MethodPost,
+			wantPath:       milvusDeletePath,
+			wantReq:        `{"collectionName": "colors", "filter": "id in [\"1\",\"2\"]"}`,
+			clientResp:     `{"code": 0, "data": {}}`,
+		},
+	}
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, tc.wantMethod)
+				c.Check(r.URL.Path, qt.Equals, tc.wantPath)
+				c.Check(r.Header.Get(tc.wantAuthHeader), qt.Equals, tc.wantAuth)
+
+				c.Assert(r.Body, qt.IsNotNil)
+				defer r.Body.Close()
+
+				body, err := io.ReadAll(r.Body)
+				c.Assert(err, qt.IsNil)
+				if want, ok := tc.wantReq.(string); ok {
+					c.Check(string(body), qt.JSONEquals, json.RawMessage(want))
+				} else {
+					c.Check(body, qt.JSONEquals, tc.wantReq)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintln(w, tc.clientResp)
+			})
+
+			srv := httptest.NewServer(h)
+			c.Cleanup(srv.Close)
+
+			setup, _ := structpb.NewStruct(map[string]any{
+				"backend": tc.backend,
+				"url":     srv.URL,
+				"api-key": apiKey,
+			})
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Setup:     setup,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := base.ConvertToStructpb(tc.execIn)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := mock.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				wantJSON, err := json.Marshal(tc.wantExec)
+				c.Assert(err, qt.IsNil)
+				c.Check(wantJSON, qt.JSONEquals, output.AsMap())
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Errorf("unexpected error: %v", err)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Assert(err, qt.IsNil)
+		})
+	}
+
+	c.Run("nok - milvus error code", func(c *qt.C) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintln(w, `{"code": 100, "message": "collection not found[collection=colors]"}`)
+		})
+
+		srv := httptest.NewServer(h)
+		c.Cleanup(srv.Close)
+
+		setup, _ := structpb.NewStruct(map[string]any{
+			"backend": backendMilvus,
+			"url":     srv.URL,
+		})
+
+		exec, err := cmp.CreateExecution(base.ComponentExecution{
+			Component: cmp,
+			Setup:     setup,
+			Task:      taskDelete,
+		})
+		c.Assert(err, qt.IsNil)
+
+		pbIn, err := base.ConvertToStructpb(deleteInput{Collection: collection, IDs: []string{"1"}})
+		c.Assert(err, qt.IsNil)
+
+		ir, ow, eh, job := mock.GenerateMockJob(c)
+		ir.ReadMock.Return(pbIn, nil)
+		ow.WriteMock.Optional().Return(nil)
+		eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+			want := "Milvus responded with error code 100. collection not found[collection=colors]"
+			c.Check(errmsg.Message(err), qt.Equals, want)
+		})
+
+		err = exec.Execute(ctx, []*base.Job{job})
+		c.Check(err, qt.IsNil)
+	})
+
+	c.Run("nok - unsupported backend", func(c *qt.C) {
+		setup, _ := structpb.NewStruct(map[string]any{
+			"backend": "faiss",
+			"url":     "http://localhost",
+		})
+
+		_, err := cmp.CreateExecution(base.ComponentExecution{
+			Component: cmp,
+			Setup:     setup,
+			Task:      taskQuery,
+		})
+		c.Check(err, qt.IsNotNil)
+		c.Check(errmsg.Message(err), qt.Equals, "Vector store backend faiss isn't supported.")
+	})
+}
+
+func TestPgvectorDriver(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	c.Run("upsert", func(c *qt.C) {
+		db, m, err := sqlmock.New()
+		c.Assert(err, qt.IsNil)
+		d := &pgvectorDriver{db: db}
+		c.Cleanup(func() { d.close() })
+
+		m.ExpectBegin()
+		m.ExpectExec(regexp.QuoteMeta(`INSERT INTO "colors" (id, embedding, metadata)`)).
+			WithArgs("1", "[0.1,0.2]", `{"color":"pumpkin"}`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		m.ExpectCommit()
+
+		n, err := d.upsert(ctx, collection, []record{recordA})
+		c.Assert(err, qt.IsNil)
+		c.Check(n, qt.Equals, 1)
+		c.Check(m.ExpectationsWereMet(), qt.IsNil)
+	})
+
+	c.Run("query", func(c *qt.C) {
+		db, m, err := sqlmock.New()
+		c.Assert(err, qt.IsNil)
+		d := &pgvectorDriver{db: db}
+		c.Cleanup(func() { d.close() })
+
+		m.ExpectQuery(regexp.QuoteMeta(`FROM "colors"`)).
+			WithArgs("[0.1,0.2]", `{"color":"pumpkin"}`, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "metadata", "embedding", "score"}).
+				AddRow("1", []byte(`{"color":"pumpkin"}`), "[0.1,0.2]", 0.99))
+
+		q := queryIn
+		q.IncludeVectors = true
+		got, err := d.query(ctx, collection, q)
+		c.Assert(err, qt.IsNil)
+		c.Check(got, qt.DeepEquals, []match{
+			{ID: "1", Score: 0.99, Vector: recordA.Vector, Metadata: recordA.Metadata},
+		})
+		c.Check(m.ExpectationsWereMet(), qt.IsNil)
+	})
+
+	c.Run("delete", func(c *qt.C) {
+		db, m, err := sqlmock.New()
+		c.Assert(err, qt.IsNil)
+		d := &pgvectorDriver{db: db}
+		c.Cleanup(func() { d.close() })
+
+		m.ExpectExec(regexp.QuoteMeta(`DELETE FROM "colors" WHERE id = ANY($1)`)).
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Only the existing records are counted.
+		n, err := d.delete(ctx, collection, []string{"1", "2"})
+		c.Assert(err, qt.IsNil)
+		c.Check(n, qt.Equals, 1)
+		c.Check(m.ExpectationsWereMet(), qt.IsNil)
+	})
+}
+
+func TestFilterOutBelowThreshold(t *testing.T) {
+	c := qt.New(t)
+
+	matches := []match{{ID: "1", Score: 0.99}, {ID: "2", Score: 0.9}, {ID: "3", Score: 0.87}}
+
+	c.Check(filterOutBelowThreshold(matches, 0.9), qt.DeepEquals, matches[:2])
+	c.Check(filterOutBelowThreshold(matches, 0), qt.DeepEquals, matches)
+}
@@ -0,0 +1,122 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	backendPinecone = "pinecone"
+	backendQdrant   = "qdrant"
+	backendMilvus   = "milvus"
+	backendPgvector = "pgvector"
+
+	defaultTopK = 10
+)
+
+// record is a vector along with its ID and metadata.
+type record struct {
+	ID       string         `json:"id"`
+	Vector   []float64      `json:"vector"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+type match struct {
+	ID       string         `json:"id"`
+	Score    float64        `json:"score"`
+	Vector   []float64      `json:"vector,omitempty"`
+	Metadata map[string]any `json:"metadata"`
+}
+
+type upsertInput struct {
+	Collection string   `json:"collection"`
+	Records    []record `json:"records"`
+}
+
+type upsertOutput struct {
+	UpsertedCount int `json:"upserted-count"`
+}
+
+type queryInput struct {
+	Collection string    `json:"collection"`
+	Vector     []float64 `json:"vector"`
+	TopK       int       `json:"top-k"`
+	// Filter matches the records whose metadata fields are equal to the
+	// filter values. Each driver translates it into the filter syntax of its
+	// backend.
+	Filter         map[string]any `json:"filter"`
+	MinScore       float64        `json:"min-score"`
+	IncludeVectors bool           `json:"include-vectors"`
+}
+
+type queryOutput struct {
+	Matches []match `json:"matches"`
+}
+
+type deleteInput struct {
+	Collection string   `json:"collection"`
+	IDs        []string `json:"ids"`
+}
+
+type deleteOutput struct {
+	DeletedCount int `json:"deleted-count"`
+}
+
+// driver implements the vector store operations on a backend. The meaning of
+// a collection depends on the backend: a namespace in Pinecone, a collection
+// in Qdrant and Milvus and a table in PostgreSQL.
+type driver interface {
+	upsert(ctx context.Context, collection string, records []record) (int, error)
+	query(ctx context.Context, collection string, q queryInput) ([]match, error)
+	// delete returns the number of deleted records. Backends that don't
+	// report it count all the requested IDs.
+	delete(ctx context.Context, collection string, ids []string) (int, error)
+	close() error
+}
+
+func newDriver(setup *structpb.Struct, logger *zap.Logger) (driver, error) {
+	switch backend := getBackend(setup); backend {
+	case backendPinecone:
+		return newPineconeDriver(getURL(setup), getAPIKey(setup), logger), nil
+	case backendQdrant:
+		return newQdrantDriver(getURL(setup), getAPIKey(setup), logger), nil
+	case backendMilvus:
+		return newMilvusDriver(getURL(setup), getAPIKey(setup), logger), nil
+	case backendPgvector:
+		return newPgvectorDriver(getURL(setup))
+	default:
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("unsupported backend: %s", backend),
+			fmt.Sprintf("Vector store backend %s isn't supported.", backend),
+		)
+	}
+}
+
+func getBackend(setup *structpb.Struct) string {
+	return setup.GetFields()["backend"].GetStringValue()
+}
+
+func getURL(setup *structpb.Struct) string {
+	return setup.GetFields()["url"].GetStringValue()
+}
+
+func getAPIKey(setup *structpb.Struct) string {
+	return setup.GetFields()["api-key"].GetStringValue()
+}
+
+// filterOutBelowThreshold removes the matches with a score lower than th.
+// Not every backend supports a score threshold, so it's applied here.
+func filterOutBelowThreshold(matches []match, th float64) []match {
+	filtered := make([]match, 0, len(matches))
+	for _, m := range matches {
+		if th <= 0 || m.Score >= th {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
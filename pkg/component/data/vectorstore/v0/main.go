@@ -0,0 +1,141 @@
+//go:generate compogen readme ./config ./README.mdx
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	_ "embed"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	taskUpsert = "TASK_UPSERT"
+	taskQuery  = "TASK_QUERY"
+	taskDelete = "TASK_DELETE"
+)
+
+var (
+	//go:embed config/definition.json
+	definitionJSON []byte
+	//go:embed config/setup.json
+	setupJSON []byte
+	//go:embed config/tasks.json
+	tasksJSON []byte
+
+	once sync.Once
+	comp *component
+)
+
+type component struct {
+	base.Component
+}
+
+type execution struct {
+	base.ComponentExecution
+
+	execute func(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	driver  driver
+}
+
+// Init returns an implementation of IComponent that reads and writes vectors
+// in any of the supported vector databases.
+func Init(bc base.Component) *component {
+	once.Do(func() {
+		comp = &component{Component: bc}
+		err := comp.LoadDefinition(definitionJSON, setupJSON, tasksJSON, nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return comp
+}
+
+// CreateExecution initializes a component executor that can be used in a
+// pipeline trigger.
+func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
+	e := &execution{ComponentExecution: x}
+
+	switch x.Task {
+	case taskUpsert:
+		e.execute = e.upsert
+	case taskQuery:
+		e.execute = e.query
+	case taskDelete:
+		e.execute = e.delete
+	default:
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("not supported task: %s", x.Task),
+			fmt.Sprintf("%s task is not supported.", x.Task),
+		)
+	}
+
+	d, err := newDriver(x.Setup, x.GetLogger())
+	if err != nil {
+		return nil, err
+	}
+	e.driver = d
+
+	return e, nil
+}
+
+func (e *execution) upsert(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := upsertInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	n, err := e.driver.upsert(ctx, inputStruct.Collection, inputStruct.Records)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(upsertOutput{UpsertedCount: n})
+}
+
+func (e *execution) query(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := queryInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+	if inputStruct.TopK <= 0 {
+		inputStruct.TopK = defaultTopK
+	}
+
+	matches, err := e.driver.query(ctx, inputStruct.Collection, inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(queryOutput{
+		Matches: filterOutBelowThreshold(matches, inputStruct.MinScore),
+	})
+}
+
+func (e *execution) delete(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := deleteInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	n, err := e.driver.delete(ctx, inputStruct.Collection, inputStruct.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(deleteOutput{DeletedCount: n})
+}
+
+// Execute executes the derived execution
+func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
+	defer e.driver.close()
+
+	return base.ConcurrentExecutor(ctx, jobs, func(in *structpb.Struct, _ *base.Job, ctx context.Context) (*structpb.Struct, error) {
+		return e.execute(ctx, in)
+	})
+}
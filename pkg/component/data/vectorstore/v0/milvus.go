@@ -0,0 +1,204 @@
+package vectorstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/internal/util/httpclient"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	milvusUpsertPath = "/v2/vectordb/entities/upsert"
+	milvusSearchPath = "/v2/vectordb/entities/search"
+	milvusDeletePath = "/v2/vectordb/entities/delete"
+
+	// The collections are expected to have an `id` VarChar primary key and
+	// a `vector` field. The metadata is stored in dynamic fields.
+	milvusIDField     = "id"
+	milvusVectorField = "vector"
+	milvusScoreField  = "distance"
+)
+
+// Field names are interpolated in the filter expressions, so they're
+// restricted to identifiers.
+var milvusFieldRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type milvusDriver struct {
+	client *httpclient.Client
+}
+
+// newMilvusDriver returns a Milvus driver. The API key is a Zilliz Cloud API
+// key or a Milvus token in the <username>:<password> format.
+func newMilvusDriver(url, apiKey string, logger *zap.Logger) *milvusDriver {
+	c := httpclient.New("Milvus", url,
+		httpclient.WithLogger(logger),
+		httpclient.WithEndUserError(new(milvusResp)),
+	)
+	c.SetHeader("Authorization", "Bearer "+apiKey)
+	c.SetHeader("Content-Type", httpclient.MIMETypeJSON)
+
+	return &milvusDriver{client: c}
+}
+
+// milvusResp is the envelope of the Milvus responses. Errors are returned
+// with a 200 status code and a non-zero code.
+type milvusResp struct {
+	Code int             `json:"code"`
+	Msg  string          `json:"message"`
+	Data json.RawMessage `json:"data"`
+}
+
+func (r milvusResp) Message() string {
+	return r.Msg
+}
+
+func (d *milvusDriver) post(ctx context.Context, path string, body any) (json.RawMessage, error) {
+	resp := milvusResp{}
+	if _, err := d.client.R().SetContext(ctx).SetBody(body).SetResult(&resp).Post(path); err != nil {
+		return nil, httpclient.WrapURLError(err)
+	}
+	if resp.Code != 0 {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("milvus error %d: %s", resp.Code, resp.Msg),
+			fmt.Sprintf("Milvus responded with error code %d. %s", resp.Code, resp.Msg),
+		)
+	}
+	return resp.Data, nil
+}
+
+func (d *milvusDriver) upsert(ctx context.Context, collection string, records []record) (int, error) {
+	entities := make([]map[string]any, len(records))
+	for i, r := range records {
+		entity := make(map[string]any, len(r.Metadata)+2)
+		for k, v := range r.Metadata {
+			entity[k] = v
+		}
+		entity[milvusIDField] = r.ID
+		entity[milvusVectorField] = r.Vector
+		entities[i] = entity
+	}
+
+	data, err := d.post(ctx, milvusUpsertPath, map[string]any{
+		"collectionName": collection,
+		"data":           entities,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	resp := struct {
+		UpsertCount int `json:"upsertCount"`
+	}{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return 0, fmt.Errorf("unmarshalling upsert response: %w", err)
+	}
+	return resp.UpsertCount, nil
+}
+
+func (d *milvusDriver) query(ctx context.Context, collection string, q queryInput) ([]match, error) {
+	outputFields := []string{"*"}
+	if q.IncludeVectors {
+		outputFields = append(outputFields, milvusVectorField)
+	}
+
+	req := map[string]any{
+		"collectionName": collection,
+		"data":           [][]float64{q.Vector},
+		"annsField":      milvusVectorField,
+		"limit":          q.TopK,
+		"outputFields":   outputFields,
+	}
+	filter, err := milvusFilter(q.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter != "" {
+		req["filter"] = filter
+	}
+
+	data, err := d.post(ctx, milvusSearchPath, req)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := []map[string]any{}
+	if err := json.Unmarshal(data, &entities); err != nil {
+		return nil, fmt.Errorf("unmarshalling search response: %w", err)
+	}
+
+	matches := make([]match, len(entities))
+	for i, entity := range entities {
+		m := match{
+			ID:       fmt.Sprint(entity[milvusIDField]),
+			Metadata: map[string]any{},
+		}
+		m.Score, _ = entity[milvusScoreField].(float64)
+		if v, ok := entity[milvusVectorField].([]any); ok && q.IncludeVectors {
+			m.Vector = make([]float64, len(v))
+			for j, x := range v {
+				m.Vector[j], _ = x.(float64)
+			}
+		}
+		for k, v := range entity {
+			if k != milvusIDField && k != milvusVectorField && k != milvusScoreField {
+				m.Metadata[k] = v
+			}
+		}
+		matches[i] = m
+	}
+	return matches, nil
+}
+
+// milvusFilter translates the metadata filter into a boolean expression.
+// The values are encoded as JSON, which matches the literal syntax of the
+// expressions for strings, numbers and booleans.
+func milvusFilter(filter map[string]any) (string, error) {
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	conditions := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if !milvusFieldRegexp.MatchString(k) {
+			return "", errmsg.AddMessage(
+				fmt.Errorf("invalid filter field: %s", k),
+				fmt.Sprintf("Filter field %s isn't a valid Milvus field name.", k),
+			)
+		}
+		v, err := json.Marshal(filter[k])
+		if err != nil {
+			return "", fmt.Errorf("encoding filter value: %w", err)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s == %s", k, v))
+	}
+	return strings.Join(conditions, " and "), nil
+}
+
+func (d *milvusDriver) delete(ctx context.Context, collection string, ids []string) (int, error) {
+	quoted, err := json.Marshal(ids)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = d.post(ctx, milvusDeletePath, map[string]any{
+		"collectionName": collection,
+		"filter":         fmt.Sprintf("%s in %s", milvusIDField, quoted),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+func (d *milvusDriver) close() error {
+	return nil
+}
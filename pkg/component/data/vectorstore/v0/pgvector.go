@@ -0,0 +1,133 @@
+package vectorstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/instill-ai/x/errmsg"
+)
+
+// pgvectorDriver stores the records in PostgreSQL tables with the pgvector
+// extension. The tables are expected to have the following columns:
+//
+//	id text primary key,
+//	embedding vector(<dimensions>),
+//	metadata jsonb
+//
+// The score of a match is the cosine similarity.
+type pgvectorDriver struct {
+	db *sql.DB
+}
+
+func newPgvectorDriver(dsn string) (*pgvectorDriver, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("opening database: %w", err),
+			"Failed to connect to PostgreSQL. Please check that the connection URL is correct.",
+		)
+	}
+	return &pgvectorDriver{db: db}, nil
+}
+
+// vectorLiteral encodes a vector in the text format of pgvector.
+func vectorLiteral(v []float64) string {
+	parts := make([]string, len(v))
+	for i, x := range v {
+		parts[i] = strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+func (d *pgvectorDriver) upsert(ctx context.Context, table string, records []record) (int, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	q := fmt.Sprintf(`INSERT INTO %s (id, embedding, metadata) VALUES ($1, $2::vector, $3::jsonb)
+ON CONFLICT (id) DO UPDATE SET embedding = EXCLUDED.embedding, metadata = EXCLUDED.metadata`, pq.QuoteIdentifier(table))
+	for _, r := range records {
+		metadata, err := json.Marshal(r.Metadata)
+		if err != nil {
+			return 0, fmt.Errorf("encoding metadata of record %s: %w", r.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx, q, r.ID, vectorLiteral(r.Vector), string(metadata)); err != nil {
+			return 0, fmt.Errorf("upserting record %s: %w", r.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+func (d *pgvectorDriver) query(ctx context.Context, table string, q queryInput) ([]match, error) {
+	filter := "{}"
+	if len(q.Filter) > 0 {
+		b, err := json.Marshal(q.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("encoding filter: %w", err)
+		}
+		filter = string(b)
+	}
+
+	// The metadata containment operator matches the records whose metadata
+	// includes every field of the filter.
+	query := fmt.Sprintf(`SELECT id, metadata, embedding::text, 1 - (embedding <=> $1::vector) AS score FROM %s
+WHERE metadata @> $2::jsonb ORDER BY embedding <=> $1::vector LIMIT $3`, pq.QuoteIdentifier(table))
+	rows, err := d.db.QueryContext(ctx, query, vectorLiteral(q.Vector), filter, q.TopK)
+	if err != nil {
+		return nil, fmt.Errorf("querying vectors: %w", err)
+	}
+	defer rows.Close()
+
+	matches := []match{}
+	for rows.Next() {
+		var m match
+		var metadata []byte
+		var embedding string
+		if err := rows.Scan(&m.ID, &metadata, &embedding, &m.Score); err != nil {
+			return nil, err
+		}
+		if len(metadata) > 0 {
+			if err := json.Unmarshal(metadata, &m.Metadata); err != nil {
+				return nil, fmt.Errorf("decoding metadata of record %s: %w", m.ID, err)
+			}
+		}
+		// The text format of a vector is a JSON array.
+		if q.IncludeVectors {
+			if err := json.Unmarshal([]byte(embedding), &m.Vector); err != nil {
+				return nil, fmt.Errorf("decoding vector of record %s: %w", m.ID, err)
+			}
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+func (d *pgvectorDriver) delete(ctx context.Context, table string, ids []string) (int, error) {
+	q := fmt.Sprintf("DELETE FROM %s WHERE id = ANY($1)", pq.QuoteIdentifier(table))
+	res, err := d.db.ExecContext(ctx, q, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("deleting records: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+func (d *pgvectorDriver) close() error {
+	return d.db.Close()
+}
@@ -0,0 +1,131 @@
+package vectorstore
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/internal/util/httpclient"
+)
+
+const (
+	pineconeUpsertPath = "/vectors/upsert"
+	pineconeQueryPath  = "/query"
+	pineconeDeletePath = "/vectors/delete"
+)
+
+type pineconeDriver struct {
+	client *httpclient.Client
+}
+
+func newPineconeDriver(url, apiKey string, logger *zap.Logger) *pineconeDriver {
+	c := httpclient.New("Pinecone", url,
+		httpclient.WithLogger(logger),
+		httpclient.WithEndUserError(new(pineconeErrBody)),
+	)
+	c.SetHeader("Api-Key", apiKey)
+	c.SetHeader("User-Agent", "source_tag=instillai")
+
+	return &pineconeDriver{client: c}
+}
+
+type pineconeVector struct {
+	ID       string         `json:"id"`
+	Values   []float64      `json:"values,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+type pineconeUpsertReq struct {
+	Vectors   []pineconeVector `json:"vectors"`
+	Namespace string           `json:"namespace,omitempty"`
+}
+
+type pineconeUpsertResp struct {
+	UpsertedCount int `json:"upsertedCount"`
+}
+
+type pineconeQueryReq struct {
+	Namespace       string         `json:"namespace,omitempty"`
+	TopK            int            `json:"topK"`
+	Vector          []float64      `json:"vector"`
+	IncludeValues   bool           `json:"includeValues"`
+	IncludeMetadata bool           `json:"includeMetadata"`
+	Filter          map[string]any `json:"filter,omitempty"`
+}
+
+type pineconeQueryResp struct {
+	Matches []struct {
+		pineconeVector
+		Score float64 `json:"score"`
+	} `json:"matches"`
+}
+
+type pineconeDeleteReq struct {
+	IDs       []string `json:"ids"`
+	Namespace string   `json:"namespace,omitempty"`
+}
+
+type pineconeErrBody struct {
+	Msg string `json:"message"`
+}
+
+func (e pineconeErrBody) Message() string {
+	return e.Msg
+}
+
+func (d *pineconeDriver) upsert(ctx context.Context, namespace string, records []record) (int, error) {
+	req := pineconeUpsertReq{
+		Vectors:   make([]pineconeVector, len(records)),
+		Namespace: namespace,
+	}
+	for i, r := range records {
+		req.Vectors[i] = pineconeVector{ID: r.ID, Values: r.Vector, Metadata: r.Metadata}
+	}
+
+	resp := pineconeUpsertResp{}
+	if _, err := d.client.R().SetContext(ctx).SetBody(req).SetResult(&resp).Post(pineconeUpsertPath); err != nil {
+		return 0, httpclient.WrapURLError(err)
+	}
+
+	return resp.UpsertedCount, nil
+}
+
+func (d *pineconeDriver) query(ctx context.Context, namespace string, q queryInput) ([]match, error) {
+	req := pineconeQueryReq{
+		Namespace:       namespace,
+		TopK:            q.TopK,
+		Vector:          q.Vector,
+		IncludeValues:   q.IncludeVectors,
+		IncludeMetadata: true,
+	}
+	if len(q.Filter) > 0 {
+		req.Filter = make(map[string]any, len(q.Filter))
+		for k, v := range q.Filter {
+			req.Filter[k] = map[string]any{"$eq": v}
+		}
+	}
+
+	resp := pineconeQueryResp{}
+	if _, err := d.client.R().SetContext(ctx).SetBody(req).SetResult(&resp).Post(pineconeQueryPath); err != nil {
+		return nil, httpclient.WrapURLError(err)
+	}
+
+	matches := make([]match, len(resp.Matches))
+	for i, m := range resp.Matches {
+		matches[i] = match{ID: m.ID, Score: m.Score, Vector: m.Values, Metadata: m.Metadata}
+	}
+	return matches, nil
+}
+
+func (d *pineconeDriver) delete(ctx context.Context, namespace string, ids []string) (int, error) {
+	req := pineconeDeleteReq{IDs: ids, Namespace: namespace}
+	if _, err := d.client.R().SetContext(ctx).SetBody(req).Post(pineconeDeletePath); err != nil {
+		return 0, httpclient.WrapURLError(err)
+	}
+
+	return len(ids), nil
+}
+
+func (d *pineconeDriver) close() error {
+	return nil
+}
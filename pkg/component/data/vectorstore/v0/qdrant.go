@@ -0,0 +1,158 @@
+package vectorstore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/internal/util/httpclient"
+)
+
+const (
+	qdrantUpsertPath = "/collections/%s/points"
+	qdrantSearchPath = "/collections/%s/points/search"
+	qdrantDeletePath = "/collections/%s/points/delete"
+)
+
+type qdrantDriver struct {
+	client *httpclient.Client
+}
+
+func newQdrantDriver(url, apiKey string, logger *zap.Logger) *qdrantDriver {
+	c := httpclient.New("Qdrant", url,
+		httpclient.WithLogger(logger),
+		httpclient.WithEndUserError(new(qdrantErrBody)),
+	)
+	c.SetHeader("api-key", apiKey)
+	c.SetHeader("Content-Type", httpclient.MIMETypeJSON)
+
+	return &qdrantDriver{client: c}
+}
+
+// qdrantPoint holds the ID as it is: Qdrant only accepts unsigned integers
+// and UUIDs, so the records with other IDs are rejected by the backend.
+type qdrantPoint struct {
+	ID      any            `json:"id"`
+	Vector  []float64      `json:"vector,omitempty"`
+	Payload map[string]any `json:"payload,omitempty"`
+}
+
+type qdrantUpsertReq struct {
+	Points []qdrantPoint `json:"points"`
+}
+
+type qdrantSearchReq struct {
+	Vector      []float64      `json:"vector"`
+	Limit       int            `json:"limit"`
+	Filter      map[string]any `json:"filter,omitempty"`
+	WithPayload bool           `json:"with_payload"`
+	WithVector  bool           `json:"with_vector"`
+}
+
+type qdrantSearchResp struct {
+	Result []struct {
+		qdrantPoint
+		Score float64 `json:"score"`
+	} `json:"result"`
+}
+
+type qdrantDeleteReq struct {
+	Points []any `json:"points"`
+}
+
+type qdrantErrBody struct {
+	Status struct {
+		Error string `json:"error"`
+	} `json:"status"`
+}
+
+func (e qdrantErrBody) Message() string {
+	return e.Status.Error
+}
+
+// qdrantID sends the numeric IDs as numbers, as Qdrant doesn't accept them as
+// strings.
+func qdrantID(id string) any {
+	if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return n
+	}
+	return id
+}
+
+func qdrantIDString(id any) string {
+	if n, ok := id.(float64); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return fmt.Sprint(id)
+}
+
+func (d *qdrantDriver) upsert(ctx context.Context, collection string, records []record) (int, error) {
+	req := qdrantUpsertReq{Points: make([]qdrantPoint, len(records))}
+	for i, r := range records {
+		req.Points[i] = qdrantPoint{ID: qdrantID(r.ID), Vector: r.Vector, Payload: r.Metadata}
+	}
+
+	_, err := d.client.R().SetContext(ctx).
+		SetQueryParam("wait", "true").
+		SetBody(req).
+		Put(fmt.Sprintf(qdrantUpsertPath, collection))
+	if err != nil {
+		return 0, httpclient.WrapURLError(err)
+	}
+
+	return len(records), nil
+}
+
+func (d *qdrantDriver) query(ctx context.Context, collection string, q queryInput) ([]match, error) {
+	req := qdrantSearchReq{
+		Vector:      q.Vector,
+		Limit:       q.TopK,
+		WithPayload: true,
+		WithVector:  q.IncludeVectors,
+	}
+	if len(q.Filter) > 0 {
+		must := make([]map[string]any, 0, len(q.Filter))
+		for k, v := range q.Filter {
+			must = append(must, map[string]any{"key": k, "match": map[string]any{"value": v}})
+		}
+		req.Filter = map[string]any{"must": must}
+	}
+
+	resp := qdrantSearchResp{}
+	_, err := d.client.R().SetContext(ctx).
+		SetBody(req).
+		SetResult(&resp).
+		Post(fmt.Sprintf(qdrantSearchPath, collection))
+	if err != nil {
+		return nil, httpclient.WrapURLError(err)
+	}
+
+	matches := make([]match, len(resp.Result))
+	for i, p := range resp.Result {
+		matches[i] = match{ID: qdrantIDString(p.ID), Score: p.Score, Vector: p.Vector, Metadata: p.Payload}
+	}
+	return matches, nil
+}
+
+func (d *qdrantDriver) delete(ctx context.Context, collection string, ids []string) (int, error) {
+	req := qdrantDeleteReq{Points: make([]any, len(ids))}
+	for i, id := range ids {
+		req.Points[i] = qdrantID(id)
+	}
+
+	_, err := d.client.R().SetContext(ctx).
+		SetQueryParam("wait", "true").
+		SetBody(req).
+		Post(fmt.Sprintf(qdrantDeletePath, collection))
+	if err != nil {
+		return 0, httpclient.WrapURLError(err)
+	}
+
+	return len(ids), nil
+}
+
+func (d *qdrantDriver) close() error {
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/go-resty/resty/v2"
 	"go.uber.org/zap"
 
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
 	"github.com/instill-ai/x/errmsg"
 )
 
@@ -157,10 +158,32 @@ func wrapWithErrMessage(apiName string) func(*resty.Client, *resty.Response) err
 		}
 
 		msg := fmt.Sprintf("%s responded with a %d status code. %s", apiName, resp.StatusCode(), issue)
-		return errmsg.AddMessage(fmt.Errorf("unsuccessful HTTP response"), msg)
+		return errmsg.AddMessage(&responseError{status: resp.StatusCode()}, msg)
 	}
 }
 
+// responseError is returned when the API responds with an error status code.
+type responseError struct {
+	status int
+}
+
+func (e *responseError) Error() string {
+	return "unsuccessful HTTP response"
+}
+
+// StatusCode returns the status code of the response, so it can be reported
+// through base.UpstreamStatus.
+func (e *responseError) StatusCode() int {
+	return e.status
+}
+
+// Is reports whether the error matches base.ErrProviderUnavailable, i.e.
+// whether the API is down or rejected the request due to its quota.
+func (e *responseError) Is(target error) bool {
+	return target == base.ErrProviderUnavailable &&
+		(e.status == http.StatusTooManyRequests || e.status >= http.StatusInternalServerError)
+}
+
 // WithEndUserError will unmarshal error response bodies as the error struct
 // and will use their message as an end-user error.
 func WithEndUserError(e ErrBody) Option {
@@ -169,13 +192,16 @@ func WithEndUserError(e ErrBody) Option {
 	}
 }
 
-// New returns an httpclient configured to call a remote host.
+// New returns an httpclient configured to call a remote host. Requests that
+// are rate limited by the host (429 status) are retried with a backoff. If
+// the request context carries a rate limit handler, it's notified before each
+// retry.
 func New(name, host string, options ...Option) *Client {
 	r := resty.New().
 		SetBaseURL(host).
 		SetHeader("Accept", MIMETypeJSON).
 		SetTimeout(reqTimeout).
-		SetTransport(&http.Transport{MaxIdleConns: 20})
+		SetTransport(newRateLimitTransport(&http.Transport{MaxIdleConns: 20}))
 
 	c := &Client{
 		Client: r,
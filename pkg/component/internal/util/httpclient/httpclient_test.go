@@ -1,17 +1,22 @@
 package httpclient
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
 	qt "github.com/frankban/quicktest"
 
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
 	"github.com/instill-ai/x/errmsg"
 )
 
@@ -74,12 +79,13 @@ func TestClient_SendReqAndUnmarshal(t *testing.T) {
 	})
 
 	testcases := []struct {
-		name           string
-		gotStatus      int
-		gotBody        string
-		gotContentType string
-		wantIssue      string
-		wantLogFields  []string
+		name            string
+		gotStatus       int
+		gotBody         string
+		gotContentType  string
+		wantIssue       string
+		wantUnavailable bool
+		wantLogFields   []string
 	}{
 		{
 			name:           "nok - 401 (unexpected response body)",
@@ -103,6 +109,15 @@ func TestClient_SendReqAndUnmarshal(t *testing.T) {
 			wantIssue:      fmt.Sprintf("%s responded with a 401 status code. Incorrect API key provided.", testName),
 			wantLogFields:  []string{"url", "body", "status"},
 		},
+		{
+			name:            "nok - 503",
+			gotStatus:       http.StatusServiceUnavailable,
+			gotContentType:  "application/json",
+			gotBody:         `{ "message": "The service is overloaded." }`,
+			wantIssue:       fmt.Sprintf("%s responded with a 503 status code. The service is overloaded.", testName),
+			wantUnavailable: true,
+			wantLogFields:   []string{"url", "body", "status"},
+		},
 		{
 			name:           "nok - JSON error",
 			gotStatus:      http.StatusOK,
@@ -133,6 +148,14 @@ func TestClient_SendReqAndUnmarshal(t *testing.T) {
 			_, err := client.R().SetResult(new(okBody)).Post(path)
 			c.Check(err, qt.IsNotNil)
 			c.Check(errmsg.Message(err), qt.Equals, tc.wantIssue)
+			c.Check(errors.Is(err, base.ErrProviderUnavailable), qt.Equals, tc.wantUnavailable)
+
+			// Only the error responses carry the upstream status.
+			status, ok := base.UpstreamStatus(err)
+			c.Check(ok, qt.Equals, tc.wantIssue != "")
+			if ok {
+				c.Check(status, qt.Equals, tc.gotStatus)
+			}
 
 			// Error log contains desired keys.
 			for _, k := range tc.wantLogFields {
@@ -150,6 +173,150 @@ func TestClient_SendReqAndUnmarshal(t *testing.T) {
 	}
 }
 
+func TestClient_RateLimit(t *testing.T) {
+	c := qt.New(t)
+
+	const testName = "Pokédex"
+	const path = "/137"
+	data := struct{ Name string }{Name: "Porygon"}
+
+	c.Run("ok - retried after rate limit", func(c *qt.C) {
+		var calls int
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+
+			// The body is sent again on each retry.
+			body, err := io.ReadAll(r.Body)
+			c.Assert(err, qt.IsNil)
+			c.Check(body, qt.JSONEquals, data)
+
+			if calls < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintln(w, `{"added": 1}`)
+		})
+
+		srv := httptest.NewServer(h)
+		c.Cleanup(srv.Close)
+
+		var waits []time.Duration
+		ctx := base.ContextWithRateLimitHandler(context.Background(), func(_ context.Context, wait time.Duration) {
+			waits = append(waits, wait)
+		})
+
+		var got okBody
+		resp, err := New(testName, srv.URL).R().
+			SetContext(ctx).
+			SetBody(data).
+			SetResult(&got).
+			Post(path)
+
+		c.Assert(err, qt.IsNil)
+		c.Check(resp.IsError(), qt.IsFalse)
+		c.Check(got.Added, qt.Equals, 1)
+		c.Check(calls, qt.Equals, 3)
+		c.Check(waits, qt.DeepEquals, []time.Duration{0, 0})
+	})
+
+	c.Run("nok - wait too long", func(c *qt.C) {
+		var calls int
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		srv := httptest.NewServer(h)
+		c.Cleanup(srv.Close)
+
+		var errResp errBody
+		_, err := New(testName, srv.URL, WithEndUserError(errResp)).R().Post(path)
+		c.Check(err, qt.IsNotNil)
+		c.Check(errmsg.Message(err), qt.Matches, ".*responded with a 429 status code.*")
+		c.Check(errors.Is(err, base.ErrProviderUnavailable), qt.IsTrue)
+		c.Check(calls, qt.Equals, 1)
+	})
+}
+
+func TestRateLimitWait(t *testing.T) {
+	c := qt.New(t)
+
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	testcases := []struct {
+		name     string
+		header   map[string]string
+		wantWait time.Duration
+		wantOK   bool
+	}{
+		{
+			name:     "Retry-After in seconds",
+			header:   map[string]string{"Retry-After": "2"},
+			wantWait: 2 * time.Second,
+			wantOK:   true,
+		},
+		{
+			name:     "Retry-After as HTTP date",
+			header:   map[string]string{"Retry-After": now.Add(5 * time.Second).Format(http.TimeFormat)},
+			wantWait: 5 * time.Second,
+			wantOK:   true,
+		},
+		{
+			name: "OpenAI reset durations",
+			header: map[string]string{
+				"X-Ratelimit-Reset-Requests": "20ms",
+				"X-Ratelimit-Reset-Tokens":   "1m2s",
+			},
+			wantWait: time.Minute + 2*time.Second,
+			wantOK:   true,
+		},
+		{
+			name:     "Anthropic reset timestamp",
+			header:   map[string]string{"Anthropic-Ratelimit-Tokens-Reset": now.Add(3 * time.Second).Format(time.RFC3339)},
+			wantWait: 3 * time.Second,
+			wantOK:   true,
+		},
+		{
+			name:     "reset in seconds",
+			header:   map[string]string{"X-Ratelimit-Reset": "1.5"},
+			wantWait: 1500 * time.Millisecond,
+			wantOK:   true,
+		},
+		{
+			name:     "reset as Unix timestamp",
+			header:   map[string]string{"X-Ratelimit-Reset": strconv.FormatInt(now.Add(10*time.Second).Unix(), 10)},
+			wantWait: 10 * time.Second,
+			wantOK:   true,
+		},
+		{
+			name:     "reset in the past",
+			header:   map[string]string{"Retry-After": now.Add(-time.Minute).Format(http.TimeFormat)},
+			wantWait: 0,
+			wantOK:   true,
+		},
+		{
+			name:   "no headers",
+			header: map[string]string{"X-Ratelimit-Reset-Requests": "soon"},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			h := http.Header{}
+			for k, v := range tc.header {
+				h.Set(k, v)
+			}
+
+			gotWait, gotOK := rateLimitWait(h, now)
+			c.Check(gotOK, qt.Equals, tc.wantOK)
+			c.Check(gotWait, qt.Equals, tc.wantWait)
+		})
+	}
+}
+
 type okBody struct {
 	Added int `json:"added"`
 }
@@ -0,0 +1,182 @@
+package httpclient
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+const (
+	rateLimitMaxRetries = 3
+	rateLimitBaseWait   = 500 * time.Millisecond
+
+	// If the remote service asks to wait longer than this, the rate limited
+	// response is returned instead of stalling the execution.
+	rateLimitMaxWait = 30 * time.Second
+)
+
+// rateLimitTransport retries the requests that receive a 429 response. The
+// wait before each retry is taken from the rate limit headers of the
+// response or, if these aren't present, computed with an exponential backoff.
+// A jitter is applied so concurrent executions don't retry at the same time.
+type rateLimitTransport struct {
+	next http.RoundTripper
+
+	maxRetries int
+	baseWait   time.Duration
+	maxWait    time.Duration
+}
+
+func newRateLimitTransport(next http.RoundTripper) *rateLimitTransport {
+	return &rateLimitTransport{
+		next:       next,
+		maxRetries: rateLimitMaxRetries,
+		baseWait:   rateLimitBaseWait,
+		maxWait:    rateLimitMaxWait,
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt > t.maxRetries {
+			return resp, err
+		}
+
+		// Requests whose body can't be read again aren't retried.
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait, ok := rateLimitWait(resp.Header, time.Now())
+		if !ok {
+			wait = t.backoff(attempt)
+		} else {
+			wait = jitter(wait)
+		}
+		if wait > t.maxWait {
+			return resp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		ctx := req.Context()
+		base.NotifyRateLimited(ctx, wait)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		retry := req.Clone(ctx)
+		if req.GetBody != nil {
+			if retry.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		req = retry
+	}
+}
+
+// backoff returns a random wait between half and the full exponential
+// backoff for the provided attempt.
+func (t *rateLimitTransport) backoff(attempt int) time.Duration {
+	wait := time.Duration(float64(t.baseWait) * math.Pow(2, float64(attempt-1)))
+	if wait > t.maxWait {
+		wait = t.maxWait
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// jitter adds up to 10% to a wait requested by the remote service.
+func jitter(wait time.Duration) time.Duration {
+	return wait + time.Duration(rand.Int63n(int64(wait/10)+1))
+}
+
+// Rate limit headers that hold the time until the limit is reset. Several
+// limits (e.g. on requests and on tokens) might be reported, in which case the
+// longest wait is taken.
+const (
+	// Standard header, in seconds or as an HTTP date.
+	retryAfterHeader = "Retry-After"
+
+	// Generic header, in seconds or as a Unix timestamp.
+	resetHeader = "X-Ratelimit-Reset"
+)
+
+var (
+	// Used by OpenAI, Groq and other OpenAI-compatible APIs, as a duration
+	// (e.g. "6m0s", "20ms").
+	durationResetHeaders = []string{
+		"X-Ratelimit-Reset-Requests",
+		"X-Ratelimit-Reset-Tokens",
+	}
+
+	// Used by Anthropic, as an RFC 3339 timestamp.
+	timestampResetHeaders = []string{
+		"Anthropic-Ratelimit-Requests-Reset",
+		"Anthropic-Ratelimit-Tokens-Reset",
+		"Anthropic-Ratelimit-Input-Tokens-Reset",
+		"Anthropic-Ratelimit-Output-Tokens-Reset",
+	}
+)
+
+// rateLimitWait parses the rate limit headers of a response and returns how
+// long the client should wait before retrying the request. The boolean
+// result is false if no header could be parsed.
+func rateLimitWait(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := strings.TrimSpace(h.Get(retryAfterHeader)); v != "" {
+		if secs, err := strconv.ParseFloat(v, 64); err == nil && secs >= 0 {
+			return time.Duration(secs * float64(time.Second)), true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+
+	var wait time.Duration
+	found := false
+	for _, k := range durationResetHeaders {
+		if d, err := time.ParseDuration(strings.TrimSpace(h.Get(k))); err == nil {
+			wait, found = max(wait, d), true
+		}
+	}
+	for _, k := range timestampResetHeaders {
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(h.Get(k))); err == nil {
+			wait, found = max(wait, t.Sub(now)), true
+		}
+	}
+	if found {
+		return nonNegative(wait), true
+	}
+
+	if v := strings.TrimSpace(h.Get(resetHeader)); v != "" {
+		if secs, err := strconv.ParseFloat(v, 64); err == nil && secs >= 0 {
+			// Values that are too large to be a delay are Unix timestamps.
+			if secs > float64(now.Unix()/2) {
+				return nonNegative(time.Unix(int64(secs), 0).Sub(now)), true
+			}
+			return time.Duration(secs * float64(time.Second)), true
+		}
+	}
+
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
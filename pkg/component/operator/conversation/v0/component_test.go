@@ -0,0 +1,246 @@
+package conversation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/pipeline-backend/pkg/component/internal/mock"
+	"github.com/instill-ai/x/errmsg"
+)
+
+type fakeStore struct {
+	sessions map[string][]base.ConversationMessage
+}
+
+func (s *fakeStore) AppendMessages(_ context.Context, sessionID string, messages []base.ConversationMessage) error {
+	s.sessions[sessionID] = append(s.sessions[sessionID], messages...)
+	return nil
+}
+
+func (s *fakeStore) ListMessages(_ context.Context, sessionID string, limit int) ([]base.ConversationMessage, error) {
+	messages := s.sessions[sessionID]
+	if limit > 0 && len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+	return messages, nil
+}
+
+var history = []base.ConversationMessage{
+	{Role: "assistant", Content: "Hi, how can I help?"},
+	{Role: "user", Content: "What's the capital of France?"},
+	{Role: "assistant", Content: "Paris."},
+	{Role: "user", Content: "And of Italy?"},
+	{Role: "assistant", Content: "Rome."},
+}
+
+func chatMessages(messages ...base.ConversationMessage) []any {
+	out := make([]any, len(messages))
+	for i, m := range messages {
+		out[i] = map[string]any{
+			"role":    m.Role,
+			"content": []any{map[string]any{"type": "text", "text": m.Content}},
+		}
+	}
+	return out
+}
+
+func TestOperator_Execute(t *testing.T) {
+	c := qt.New(t)
+
+	tkm, err := newTokenizer("")
+	c.Assert(err, qt.IsNil)
+
+	system := base.ConversationMessage{Role: "system", Content: "You are a geography tutor."}
+	prompt := base.ConversationMessage{Role: "user", Content: "And of Spain?"}
+	lastTurn := history[3:]
+
+	testcases := []struct {
+		name string
+
+		task      string
+		in        map[string]any
+		want      map[string]any
+		wantErr   string
+		wantStore []base.ConversationMessage
+	}{
+		{
+			name: "ok - append messages",
+
+			task: taskAppendMessages,
+			in: map[string]any{
+				"session-id": "chat",
+				"messages": []any{
+					map[string]any{"role": "user", "content": "And of Spain?"},
+					map[string]any{"role": "assistant", "content": "Madrid."},
+				},
+			},
+			want: map[string]any{"message-count": 2},
+			wantStore: append(append([]base.ConversationMessage{}, history...),
+				prompt,
+				base.ConversationMessage{Role: "assistant", Content: "Madrid."},
+			),
+		},
+		{
+			name: "ok - build prompt",
+
+			task: taskBuildPrompt,
+			in: map[string]any{
+				"session-id":     "chat",
+				"system-message": system.Content,
+				"prompt":         prompt.Content,
+			},
+			want: map[string]any{
+				"messages":    chatMessages(append(append([]base.ConversationMessage{system}, history...), prompt)...),
+				"prompt":      renderTranscript(append(append([]base.ConversationMessage{system}, history...), prompt)),
+				"turn-count":  3,
+				"token-count": tkm.countMessages(append(append([]base.ConversationMessage{system}, history...), prompt)),
+				"truncated":   false,
+			},
+		},
+		{
+			name: "ok - limit turns",
+
+			task: taskBuildPrompt,
+			in: map[string]any{
+				"session-id": "chat",
+				"prompt":     prompt.Content,
+				"max-turns":  1,
+			},
+			want: map[string]any{
+				"messages":    chatMessages(append(append([]base.ConversationMessage{}, lastTurn...), prompt)...),
+				"prompt":      renderTranscript(append(append([]base.ConversationMessage{}, lastTurn...), prompt)),
+				"turn-count":  1,
+				"token-count": tkm.countMessages(append(append([]base.ConversationMessage{}, lastTurn...), prompt)),
+				"truncated":   true,
+			},
+		},
+		{
+			name: "ok - token budget",
+
+			task: taskBuildPrompt,
+			in: map[string]any{
+				"session-id": "chat",
+				"prompt":     prompt.Content,
+				"max-tokens": tkm.countMessages(append(append([]base.ConversationMessage{}, lastTurn...), prompt)),
+			},
+			want: map[string]any{
+				"messages":    chatMessages(append(append([]base.ConversationMessage{}, lastTurn...), prompt)...),
+				"prompt":      renderTranscript(append(append([]base.ConversationMessage{}, lastTurn...), prompt)),
+				"turn-count":  1,
+				"token-count": tkm.countMessages(append(append([]base.ConversationMessage{}, lastTurn...), prompt)),
+				"truncated":   true,
+			},
+		},
+		{
+			name: "ok - new session",
+
+			task: taskBuildPrompt,
+			in: map[string]any{
+				"session-id": "new-chat",
+				"prompt":     prompt.Content,
+			},
+			want: map[string]any{
+				"messages":    chatMessages(prompt),
+				"prompt":      renderTranscript([]base.ConversationMessage{prompt}),
+				"turn-count":  0,
+				"token-count": tkm.countMessages([]base.ConversationMessage{prompt}),
+				"truncated":   false,
+			},
+		},
+		{
+			name: "nok - prompt exceeds budget",
+
+			task: taskBuildPrompt,
+			in: map[string]any{
+				"session-id": "chat",
+				"prompt":     prompt.Content,
+				"max-tokens": 1,
+			},
+			wantErr: fmt.Sprintf("The system message and the prompt take %d tokens, which exceeds the budget of 1 tokens.",
+				tkm.countMessages([]base.ConversationMessage{prompt})),
+		},
+	}
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			store := &fakeStore{sessions: map[string][]base.ConversationMessage{
+				"chat": append([]base.ConversationMessage{}, history...),
+			}}
+			ctx := base.ContextWithConversationStore(context.Background(), store)
+
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(tc.in)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := mock.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				c.Check(tc.wantErr, qt.Equals, "")
+
+				gotJSON, err := output.MarshalJSON()
+				c.Assert(err, qt.IsNil)
+				c.Check(gotJSON, qt.JSONEquals, tc.want)
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(errmsg.Message(err), qt.Equals, tc.wantErr)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+
+			if tc.wantStore != nil {
+				c.Check(store.sessions["chat"], qt.DeepEquals, tc.wantStore)
+			}
+		})
+	}
+
+	c.Run("nok - sessions disabled", func(c *qt.C) {
+		exec, err := cmp.CreateExecution(base.ComponentExecution{
+			Component: cmp,
+			Task:      taskBuildPrompt,
+		})
+		c.Assert(err, qt.IsNil)
+
+		_, _, eh, job := mock.GenerateMockJob(c)
+		eh.ErrorMock.Set(func(ctx context.Context, err error) {
+			c.Check(errmsg.Message(err), qt.Equals, "Conversation sessions aren't enabled in this deployment.")
+		})
+
+		err = exec.Execute(context.Background(), []*base.Job{job})
+		c.Check(err, qt.IsNil)
+	})
+}
+
+func TestOperator_CreateExecution(t *testing.T) {
+	c := qt.New(t)
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	c.Run("nok - unsupported task", func(c *qt.C) {
+		task := "FOOBAR"
+		want := fmt.Sprintf("%s task is not supported.", task)
+
+		_, err := cmp.CreateExecution(base.ComponentExecution{
+			Component: cmp,
+			Task:      task,
+		})
+		c.Check(err, qt.IsNotNil)
+		c.Check(errmsg.Message(err), qt.Equals, want)
+	})
+}
@@ -0,0 +1,193 @@
+package conversation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	defaultMaxTurns = 10
+
+	// historyLimit bounds the messages read from a session, so a long
+	// session doesn't need to be loaded whole to build a prompt.
+	historyLimit = 1000
+)
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type appendMessagesInput struct {
+	SessionID string    `json:"session-id"`
+	Messages  []message `json:"messages"`
+}
+
+type appendMessagesOutput struct {
+	MessageCount int `json:"message-count"`
+}
+
+type buildPromptInput struct {
+	SessionID     string `json:"session-id"`
+	Prompt        string `json:"prompt"`
+	SystemMessage string `json:"system-message"`
+	MaxTurns      *int   `json:"max-turns"`
+	MaxTokens     int    `json:"max-tokens"`
+	Model         string `json:"model"`
+}
+
+// chatContent and chatMessage have the shape of the messages in the input of
+// the chat tasks, so the output can be passed to them as is.
+type chatContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type chatMessage struct {
+	Role    string        `json:"role"`
+	Content []chatContent `json:"content"`
+}
+
+type buildPromptOutput struct {
+	Messages   []chatMessage `json:"messages"`
+	Prompt     string        `json:"prompt"`
+	TurnCount  int           `json:"turn-count"`
+	TokenCount int           `json:"token-count"`
+	Truncated  bool          `json:"truncated"`
+}
+
+func appendMessages(ctx context.Context, store base.ConversationStore, in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := appendMessagesInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	messages := make([]base.ConversationMessage, len(inputStruct.Messages))
+	for i, m := range inputStruct.Messages {
+		messages[i] = base.ConversationMessage{Role: m.Role, Content: m.Content}
+	}
+	if err := store.AppendMessages(ctx, inputStruct.SessionID, messages); err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(appendMessagesOutput{MessageCount: len(messages)})
+}
+
+// buildPrompt injects the last turns of a session between the system message
+// and the prompt. A turn starts with a user message and holds the replies
+// that follow it. When a token budget is set, the oldest turns are dropped
+// until the messages fit in it.
+func buildPrompt(ctx context.Context, store base.ConversationStore, in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := buildPromptInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	maxTurns := defaultMaxTurns
+	if inputStruct.MaxTurns != nil {
+		maxTurns = *inputStruct.MaxTurns
+	}
+
+	history, err := store.ListMessages(ctx, inputStruct.SessionID, historyLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	turns := splitTurns(history)
+	truncated := false
+	if len(turns) > maxTurns {
+		turns, truncated = turns[len(turns)-max(maxTurns, 0):], true
+	}
+
+	tkm, err := newTokenizer(inputStruct.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	// The system message and the prompt are always included, so only the
+	// history is subject to the budget.
+	var fixed []base.ConversationMessage
+	if inputStruct.SystemMessage != "" {
+		fixed = append(fixed, base.ConversationMessage{Role: "system", Content: inputStruct.SystemMessage})
+	}
+	if inputStruct.Prompt != "" {
+		fixed = append(fixed, base.ConversationMessage{Role: "user", Content: inputStruct.Prompt})
+	}
+	tokenCount := tkm.countMessages(fixed)
+
+	turnTokens := make([]int, len(turns))
+	for i, t := range turns {
+		turnTokens[i] = tkm.countMessages(t)
+		tokenCount += turnTokens[i]
+	}
+
+	if budget := inputStruct.MaxTokens; budget > 0 {
+		for len(turns) > 0 && tokenCount > budget {
+			tokenCount -= turnTokens[0]
+			turns, turnTokens, truncated = turns[1:], turnTokens[1:], true
+		}
+		if tokenCount > budget {
+			return nil, errmsg.AddMessage(
+				fmt.Errorf("prompt exceeds token budget: %d > %d", tokenCount, budget),
+				fmt.Sprintf("The system message and the prompt take %d tokens, which exceeds the budget of %d tokens.", tokenCount, budget),
+			)
+		}
+	}
+
+	var messages []base.ConversationMessage
+	if inputStruct.SystemMessage != "" {
+		messages = append(messages, fixed[0])
+	}
+	for _, t := range turns {
+		messages = append(messages, t...)
+	}
+	if inputStruct.Prompt != "" {
+		messages = append(messages, fixed[len(fixed)-1])
+	}
+
+	outputStruct := buildPromptOutput{
+		Messages:   make([]chatMessage, len(messages)),
+		Prompt:     renderTranscript(messages),
+		TurnCount:  len(turns),
+		TokenCount: tokenCount,
+		Truncated:  truncated,
+	}
+	for i, m := range messages {
+		outputStruct.Messages[i] = chatMessage{
+			Role:    m.Role,
+			Content: []chatContent{{Type: "text", Text: m.Content}},
+		}
+	}
+
+	return base.ConvertToStructpb(outputStruct)
+}
+
+// splitTurns groups the messages of a session into turns. The messages
+// before the first user message (e.g. a greeting of the assistant) form a
+// turn of their own.
+func splitTurns(messages []base.ConversationMessage) [][]base.ConversationMessage {
+	var turns [][]base.ConversationMessage
+	for _, m := range messages {
+		if len(turns) == 0 || m.Role == "user" {
+			turns = append(turns, nil)
+		}
+		turns[len(turns)-1] = append(turns[len(turns)-1], m)
+	}
+	return turns
+}
+
+// renderTranscript renders the messages as text, for the models that take a
+// single prompt instead of a list of messages.
+func renderTranscript(messages []base.ConversationMessage) string {
+	lines := make([]string, len(messages))
+	for i, m := range messages {
+		lines[i] = m.Role + ": " + m.Content
+	}
+	return strings.Join(lines, "\n\n")
+}
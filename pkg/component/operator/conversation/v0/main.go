@@ -0,0 +1,94 @@
+//go:generate compogen readme ./config ./README.mdx
+package conversation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	_ "embed"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	taskAppendMessages = "TASK_APPEND_MESSAGES"
+	taskBuildPrompt    = "TASK_BUILD_PROMPT"
+)
+
+var (
+	//go:embed config/definition.json
+	definitionJSON []byte
+	//go:embed config/tasks.json
+	tasksJSON []byte
+
+	once sync.Once
+	comp *component
+)
+
+type component struct {
+	base.Component
+}
+
+type execution struct {
+	base.ComponentExecution
+
+	execute func(context.Context, base.ConversationStore, *structpb.Struct) (*structpb.Struct, error)
+}
+
+// Init returns an implementation of IOperator that reads and writes the
+// history of conversation sessions, so chat pipelines can remember the
+// previous turns of a conversation.
+func Init(bc base.Component) *component {
+	once.Do(func() {
+		comp = &component{Component: bc}
+		err := comp.LoadDefinition(definitionJSON, nil, tasksJSON, nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return comp
+}
+
+// CreateExecution initializes a component executor that can be used in a
+// pipeline trigger.
+func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
+	e := &execution{ComponentExecution: x}
+
+	switch x.Task {
+	case taskAppendMessages:
+		e.execute = appendMessages
+	case taskBuildPrompt:
+		e.execute = buildPrompt
+	default:
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("not supported task: %s", x.Task),
+			fmt.Sprintf("%s task is not supported.", x.Task),
+		)
+	}
+	return e, nil
+}
+
+// Execute executes the derived execution. The sessions are provided by the
+// pipeline backend through the context. The jobs are executed in order, as
+// several batch items might write to the same session.
+func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
+	store, ok := base.ConversationStoreFromContext(ctx)
+	if !ok {
+		err := errmsg.AddMessage(
+			fmt.Errorf("conversation store not found in context"),
+			"Conversation sessions aren't enabled in this deployment.",
+		)
+		for _, job := range jobs {
+			job.Error.Error(ctx, err)
+		}
+		return nil
+	}
+
+	return base.SequentialExecutor(ctx, jobs, func(in *structpb.Struct) (*structpb.Struct, error) {
+		return e.execute(ctx, store, in)
+	})
+}
@@ -0,0 +1,53 @@
+package conversation
+
+import (
+	"fmt"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/x/errmsg"
+
+	tiktoken "github.com/pkoukk/tiktoken-go"
+)
+
+const (
+	defaultModel = "gpt-4o"
+
+	// fallbackEncoding is used for the models that tiktoken doesn't know.
+	// The count is then an estimate of the actual one.
+	fallbackEncoding = tiktoken.MODEL_CL100K_BASE
+
+	// tokensPerMessage approximates the tokens that the chat format adds to
+	// each message (e.g. the role and the delimiters).
+	tokensPerMessage = 4
+)
+
+type tokenizer struct {
+	tkm *tiktoken.Tiktoken
+}
+
+func newTokenizer(model string) (*tokenizer, error) {
+	if model == "" {
+		model = defaultModel
+	}
+
+	tkm, err := tiktoken.EncodingForModel(model)
+	if err != nil {
+		tkm, err = tiktoken.GetEncoding(fallbackEncoding)
+	}
+	if err != nil {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("loading encoding: %w", err),
+			fmt.Sprintf("Couldn't load the tokenizer of model %s.", model),
+		)
+	}
+
+	return &tokenizer{tkm: tkm}, nil
+}
+
+func (t *tokenizer) countMessages(messages []base.ConversationMessage) int {
+	var n int
+	for _, m := range messages {
+		n += tokensPerMessage + len(t.tkm.EncodeOrdinary(m.Content))
+	}
+	return n
+}
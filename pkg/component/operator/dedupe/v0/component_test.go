@@ -0,0 +1,176 @@
+package dedupe
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/pipeline-backend/pkg/component/internal/mock"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	// sha256 of "hello world" and "hello  world".
+	helloHash       = "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	helloSpacesHash = "sha256:e519e9540ff8a84d732c6f6e8db424d9abf4125078130221763fa573067a9059"
+)
+
+func TestOperator_Execute(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	testcases := []struct {
+		name string
+
+		task    string
+		in      map[string]any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name: "ok - hash documents",
+
+			task: taskHashDocuments,
+			in: map[string]any{
+				"documents": []any{map[string]any{"id": "a", "content": "hello world"}},
+			},
+			want: map[string]any{
+				"documents": []any{map[string]any{"id": "a", "content": "hello world", "hash": helloHash}},
+			},
+		},
+		{
+			name: "ok - hash normalized documents",
+
+			task: taskHashDocuments,
+			in: map[string]any{
+				"documents": []any{map[string]any{"id": "a", "content": "  hello \n world "}},
+				"normalize": true,
+			},
+			want: map[string]any{
+				"documents": []any{map[string]any{"id": "a", "content": "  hello \n world ", "hash": helloHash}},
+			},
+		},
+		{
+			name: "nok - unsupported algorithm",
+
+			task: taskHashDocuments,
+			in: map[string]any{
+				"documents": []any{map[string]any{"id": "a", "content": "hello world"}},
+				"algorithm": "crc32",
+			},
+			wantErr: "Hash algorithm crc32 isn't supported.",
+		},
+		{
+			name: "ok - dedupe",
+
+			task: taskDedupe,
+			in: map[string]any{
+				"documents": []any{
+					map[string]any{"id": "a", "content": "hello world"},
+					map[string]any{"id": "b", "content": "hello world"},
+				},
+			},
+			want: map[string]any{
+				"documents":  []any{map[string]any{"id": "a", "content": "hello world", "hash": helloHash}},
+				"duplicates": []any{map[string]any{"id": "b", "duplicate-of": "a"}},
+			},
+		},
+		{
+			name: "ok - diff sync state",
+
+			task: taskDiffSyncState,
+			in: map[string]any{
+				"documents": []any{
+					map[string]any{"id": "new", "content": "hello world"},
+					map[string]any{"id": "changed", "content": "hello  world"},
+					map[string]any{"id": "unchanged", "content": "hello world"},
+				},
+				"state": map[string]any{
+					"changed":   helloHash,
+					"unchanged": helloHash,
+					"deleted":   helloHash,
+				},
+			},
+			want: map[string]any{
+				"new":           []any{map[string]any{"id": "new", "content": "hello world", "hash": helloHash}},
+				"changed":       []any{map[string]any{"id": "changed", "content": "hello  world", "hash": helloSpacesHash}},
+				"unchanged-ids": []any{"unchanged"},
+				"deleted-ids":   []any{"deleted"},
+				"state": map[string]any{
+					"new":       helloHash,
+					"changed":   helloSpacesHash,
+					"unchanged": helloHash,
+				},
+			},
+		},
+		{
+			name: "nok - duplicated ID",
+
+			task: taskDiffSyncState,
+			in: map[string]any{
+				"documents": []any{
+					map[string]any{"id": "a", "content": "hello world"},
+					map[string]any{"id": "a", "content": "hello world"},
+				},
+			},
+			wantErr: "Document ID a is duplicated.",
+		},
+	}
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(tc.in)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := mock.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				c.Check(tc.wantErr, qt.Equals, "")
+
+				gotJSON, err := output.MarshalJSON()
+				c.Assert(err, qt.IsNil)
+				c.Check(gotJSON, qt.JSONEquals, tc.want)
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(errmsg.Message(err), qt.Equals, tc.wantErr)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+		})
+	}
+}
+
+func TestOperator_CreateExecution(t *testing.T) {
+	c := qt.New(t)
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	c.Run("nok - unsupported task", func(c *qt.C) {
+		task := "FOOBAR"
+		want := fmt.Sprintf("%s task is not supported.", task)
+
+		_, err := cmp.CreateExecution(base.ComponentExecution{
+			Component: cmp,
+			Task:      task,
+		})
+		c.Check(err, qt.IsNotNil)
+		c.Check(errmsg.Message(err), qt.Equals, want)
+	})
+}
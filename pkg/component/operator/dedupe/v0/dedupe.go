@@ -0,0 +1,213 @@
+package dedupe
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"sort"
+	"strings"
+
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	algorithmSHA256 = "sha256"
+	algorithmSHA1   = "sha1"
+	algorithmMD5    = "md5"
+)
+
+type document struct {
+	ID       string         `json:"id"`
+	Content  string         `json:"content"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+	Hash     string         `json:"hash,omitempty"`
+}
+
+type hashOptions struct {
+	Algorithm       string `json:"algorithm"`
+	Normalize       bool   `json:"normalize"`
+	IncludeMetadata bool   `json:"include-metadata"`
+}
+
+type hashDocumentsInput struct {
+	Documents []document `json:"documents"`
+	hashOptions
+}
+
+type hashDocumentsOutput struct {
+	Documents []document `json:"documents"`
+}
+
+type dedupeInput struct {
+	Documents []document `json:"documents"`
+	hashOptions
+}
+
+type duplicate struct {
+	ID          string `json:"id"`
+	DuplicateOf string `json:"duplicate-of"`
+}
+
+type dedupeOutput struct {
+	Documents  []document  `json:"documents"`
+	Duplicates []duplicate `json:"duplicates"`
+}
+
+type diffSyncStateInput struct {
+	Documents []document `json:"documents"`
+	// State maps the ID of each document ingested in the previous sync to
+	// its hash.
+	State map[string]string `json:"state"`
+	hashOptions
+}
+
+type diffSyncStateOutput struct {
+	New          []document        `json:"new"`
+	Changed      []document        `json:"changed"`
+	UnchangedIDs []string          `json:"unchanged-ids"`
+	DeletedIDs   []string          `json:"deleted-ids"`
+	State        map[string]string `json:"state"`
+}
+
+func newHash(algorithm string) (hash.Hash, string, error) {
+	switch algorithm {
+	case algorithmSHA256, "":
+		return sha256.New(), algorithmSHA256, nil
+	case algorithmSHA1:
+		return sha1.New(), algorithmSHA1, nil
+	case algorithmMD5:
+		return md5.New(), algorithmMD5, nil
+	}
+
+	return nil, "", errmsg.AddMessage(
+		fmt.Errorf("unsupported algorithm: %s", algorithm),
+		fmt.Sprintf("Hash algorithm %s isn't supported.", algorithm),
+	)
+}
+
+// contentHash returns the hash of a document, prefixed by the algorithm
+// that computed it. The prefix makes the hashes computed with a different
+// algorithm differ, so changing it re-ingests every document instead of
+// comparing hashes that can't match.
+func contentHash(doc document, opts hashOptions) (string, error) {
+	h, algorithm, err := newHash(opts.Algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	content := doc.Content
+	if opts.Normalize {
+		content = normalize(content)
+	}
+	h.Write([]byte(content))
+
+	if opts.IncludeMetadata && len(doc.Metadata) > 0 {
+		// The keys of the encoded maps are sorted, so the encoding is
+		// deterministic.
+		b, err := json.Marshal(doc.Metadata)
+		if err != nil {
+			return "", fmt.Errorf("encoding metadata of document %s: %w", doc.ID, err)
+		}
+		h.Write([]byte{0})
+		h.Write(b)
+	}
+
+	return algorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// normalize trims the content and collapses its whitespace, so changes that
+// only affect the formatting of a document don't trigger a re-ingestion.
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func hashDocuments(docs []document, opts hashOptions) ([]document, error) {
+	hashed := make([]document, len(docs))
+	for i, doc := range docs {
+		h, err := contentHash(doc, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		doc.Hash = h
+		hashed[i] = doc
+	}
+	return hashed, nil
+}
+
+// dedupe keeps the first document of each set of documents with the same
+// hash.
+func dedupe(in dedupeInput) (dedupeOutput, error) {
+	docs, err := hashDocuments(in.Documents, in.hashOptions)
+	if err != nil {
+		return dedupeOutput{}, err
+	}
+
+	out := dedupeOutput{Documents: []document{}, Duplicates: []duplicate{}}
+	seen := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		if id, ok := seen[doc.Hash]; ok {
+			out.Duplicates = append(out.Duplicates, duplicate{ID: doc.ID, DuplicateOf: id})
+			continue
+		}
+
+		seen[doc.Hash] = doc.ID
+		out.Documents = append(out.Documents, doc)
+	}
+	return out, nil
+}
+
+// diffSyncState compares the documents of an ingestion with the state of
+// the previous one. Only the new and changed documents need to be
+// re-embedded, and the deleted ones can be removed from the destination.
+func diffSyncState(in diffSyncStateInput) (diffSyncStateOutput, error) {
+	docs, err := hashDocuments(in.Documents, in.hashOptions)
+	if err != nil {
+		return diffSyncStateOutput{}, err
+	}
+
+	out := diffSyncStateOutput{
+		New:          []document{},
+		Changed:      []document{},
+		UnchangedIDs: []string{},
+		DeletedIDs:   []string{},
+		State:        make(map[string]string, len(docs)),
+	}
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return diffSyncStateOutput{}, errmsg.AddMessage(
+				fmt.Errorf("missing document ID"),
+				"Every document must have an ID to be compared with the sync state.",
+			)
+		}
+		if _, ok := out.State[doc.ID]; ok {
+			return diffSyncStateOutput{}, errmsg.AddMessage(
+				fmt.Errorf("duplicated document ID: %s", doc.ID),
+				fmt.Sprintf("Document ID %s is duplicated.", doc.ID),
+			)
+		}
+		out.State[doc.ID] = doc.Hash
+
+		switch prev, ok := in.State[doc.ID]; {
+		case !ok:
+			out.New = append(out.New, doc)
+		case prev != doc.Hash:
+			out.Changed = append(out.Changed, doc)
+		default:
+			out.UnchangedIDs = append(out.UnchangedIDs, doc.ID)
+		}
+	}
+
+	for id := range in.State {
+		if _, ok := out.State[id]; !ok {
+			out.DeletedIDs = append(out.DeletedIDs, id)
+		}
+	}
+	sort.Strings(out.DeletedIDs)
+
+	return out, nil
+}
@@ -0,0 +1,122 @@
+//go:generate compogen readme ./config ./README.mdx
+package dedupe
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	_ "embed"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	taskHashDocuments = "TASK_HASH_DOCUMENTS"
+	taskDedupe        = "TASK_DEDUPE"
+	taskDiffSyncState = "TASK_DIFF_SYNC_STATE"
+)
+
+var (
+	//go:embed config/definition.json
+	definitionJSON []byte
+	//go:embed config/tasks.json
+	tasksJSON []byte
+
+	once sync.Once
+	comp *component
+)
+
+type component struct {
+	base.Component
+}
+
+type execution struct {
+	base.ComponentExecution
+
+	execute func(*structpb.Struct) (*structpb.Struct, error)
+}
+
+// Init returns an implementation of IOperator that hashes the content of
+// documents to remove duplicates and detect the changes between ingestions.
+func Init(bc base.Component) *component {
+	once.Do(func() {
+		comp = &component{Component: bc}
+		err := comp.LoadDefinition(definitionJSON, nil, tasksJSON, nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return comp
+}
+
+// CreateExecution initializes a component executor that can be used in a
+// pipeline trigger.
+func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
+	e := &execution{ComponentExecution: x}
+
+	switch x.Task {
+	case taskHashDocuments:
+		e.execute = e.hashDocuments
+	case taskDedupe:
+		e.execute = e.dedupe
+	case taskDiffSyncState:
+		e.execute = e.diffSyncState
+	default:
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("not supported task: %s", x.Task),
+			fmt.Sprintf("%s task is not supported.", x.Task),
+		)
+	}
+	return e, nil
+}
+
+func (e *execution) hashDocuments(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := hashDocumentsInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	docs, err := hashDocuments(inputStruct.Documents, inputStruct.hashOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(hashDocumentsOutput{Documents: docs})
+}
+
+func (e *execution) dedupe(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := dedupeInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	outputStruct, err := dedupe(inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(outputStruct)
+}
+
+func (e *execution) diffSyncState(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := diffSyncStateInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	outputStruct, err := diffSyncState(inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(outputStruct)
+}
+
+// Execute executes the derived execution
+func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
+	return base.SequentialExecutor(ctx, jobs, e.execute)
+}
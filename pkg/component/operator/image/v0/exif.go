@@ -0,0 +1,339 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// exifHeader prefixes the EXIF payload in the APP1 segment of the JPEG
+// images.
+var exifHeader = []byte("Exif\x00\x00")
+
+// findEXIF returns the EXIF payload of a JPEG or PNG image, as a TIFF
+// structure, or nil if the image has no metadata.
+func findEXIF(b []byte) []byte {
+	switch {
+	case bytes.HasPrefix(b, []byte{0xff, 0xd8}):
+		return findJPEGEXIF(b)
+	case bytes.HasPrefix(b, []byte("\x89PNG\r\n\x1a\n")):
+		return findPNGEXIF(b)
+	}
+	return nil
+}
+
+// findJPEGEXIF looks for the EXIF payload in the segments that precede the
+// image data.
+func findJPEGEXIF(b []byte) []byte {
+	for i := 2; i+4 <= len(b); {
+		if b[i] != 0xff {
+			return nil
+		}
+		marker := b[i+1]
+		switch {
+		case marker == 0xff:
+			// Fill byte.
+			i++
+			continue
+		case marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7):
+			// Standalone markers.
+			i += 2
+			continue
+		case marker == 0xda || marker == 0xd9:
+			// The image data starts, or the image ends.
+			return nil
+		}
+
+		length := int(binary.BigEndian.Uint16(b[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(b) {
+			return nil
+		}
+		if payload := b[i+4 : end]; marker == 0xe1 && bytes.HasPrefix(payload, exifHeader) {
+			return payload[len(exifHeader):]
+		}
+		i = end
+	}
+	return nil
+}
+
+// findPNGEXIF looks for the eXIf chunk of a PNG image.
+func findPNGEXIF(b []byte) []byte {
+	for i := 8; i+8 <= len(b); {
+		length := int(binary.BigEndian.Uint32(b[i:]))
+		typ := string(b[i+4 : i+8])
+		end := i + 8 + length
+		if length < 0 || end+4 > len(b) {
+			return nil
+		}
+		switch typ {
+		case "eXIf":
+			return b[i+8 : end]
+		case "IEND":
+			return nil
+		}
+		// The chunk is followed by its CRC.
+		i = end + 4
+	}
+	return nil
+}
+
+// IFD entry tags that point to the sub-IFDs.
+const (
+	tagEXIFIFD = 0x8769
+	tagGPSIFD  = 0x8825
+)
+
+// exifTagNames holds the names of the well-known tags, by IFD. The other
+// tags are ignored, as are the values of undefined type, like the maker
+// notes.
+var exifTagNames = map[string]map[uint16]string{
+	"ifd0": {
+		0x010e: "ImageDescription",
+		0x010f: "Make",
+		0x0110: "Model",
+		0x0112: "Orientation",
+		0x011a: "XResolution",
+		0x011b: "YResolution",
+		0x0128: "ResolutionUnit",
+		0x0131: "Software",
+		0x0132: "DateTime",
+		0x013b: "Artist",
+		0x8298: "Copyright",
+	},
+	"exif": {
+		0x829a: "ExposureTime",
+		0x829d: "FNumber",
+		0x8822: "ExposureProgram",
+		0x8827: "ISOSpeedRatings",
+		0x9003: "DateTimeOriginal",
+		0x9004: "DateTimeDigitized",
+		0x9010: "OffsetTime",
+		0x9011: "OffsetTimeOriginal",
+		0x9201: "ShutterSpeedValue",
+		0x9202: "ApertureValue",
+		0x9204: "ExposureBiasValue",
+		0x9207: "MeteringMode",
+		0x9209: "Flash",
+		0x920a: "FocalLength",
+		0xa001: "ColorSpace",
+		0xa002: "PixelXDimension",
+		0xa003: "PixelYDimension",
+		0xa403: "WhiteBalance",
+		0xa405: "FocalLengthIn35mmFilm",
+		0xa420: "ImageUniqueID",
+		0xa431: "BodySerialNumber",
+		0xa433: "LensMake",
+		0xa434: "LensModel",
+	},
+	"gps": {
+		0x0000: "GPSVersionID",
+		0x0001: "GPSLatitudeRef",
+		0x0002: "GPSLatitude",
+		0x0003: "GPSLongitudeRef",
+		0x0004: "GPSLongitude",
+		0x0005: "GPSAltitudeRef",
+		0x0006: "GPSAltitude",
+		0x0007: "GPSTimeStamp",
+		0x000c: "GPSSpeedRef",
+		0x000d: "GPSSpeed",
+		0x0010: "GPSImgDirectionRef",
+		0x0011: "GPSImgDirection",
+		0x001d: "GPSDateStamp",
+	},
+}
+
+// exifTypeSizes holds the size, in bytes, of the IFD entry types.
+var exifTypeSizes = map[uint16]int{
+	1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8,
+}
+
+// exifTags holds the values of the EXIF tags, by name. The integers are
+// uint32 or int32, the rationals float64 and the texts strings. The tags
+// with several values hold slices.
+type exifTags map[string]any
+
+// parseEXIF reads the tags of IFD0 and of its EXIF and GPS sub-IFDs from a
+// TIFF structure. The thumbnail IFD isn't read.
+func parseEXIF(tiff []byte) (exifTags, error) {
+	if len(tiff) < 8 {
+		return nil, fmt.Errorf("truncated TIFF header")
+	}
+
+	var bo binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		bo = binary.LittleEndian
+	case "MM":
+		bo = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid TIFF byte order")
+	}
+	if bo.Uint16(tiff[2:]) != 42 {
+		return nil, fmt.Errorf("invalid TIFF header")
+	}
+
+	p := exifParser{tiff: tiff, bo: bo}
+	tags := exifTags{}
+	pointers, err := p.readIFD(bo.Uint32(tiff[4:]), "ifd0", tags)
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range []struct {
+		tag uint16
+		ifd string
+	}{{tagEXIFIFD, "exif"}, {tagGPSIFD, "gps"}} {
+		if offset, ok := pointers[sub.tag]; ok {
+			if _, err := p.readIFD(offset, sub.ifd, tags); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return tags, nil
+}
+
+type exifParser struct {
+	tiff []byte
+	bo   binary.ByteOrder
+}
+
+// readIFD reads the well-known tags of an IFD into tags and returns the
+// offsets of the sub-IFDs it points to.
+func (p exifParser) readIFD(offset uint32, ifd string, tags exifTags) (map[uint16]uint32, error) {
+	start := int64(offset)
+	if start+2 > int64(len(p.tiff)) {
+		return nil, fmt.Errorf("invalid %s offset", ifd)
+	}
+	n := int64(p.bo.Uint16(p.tiff[start:]))
+	if start+2+n*12 > int64(len(p.tiff)) {
+		return nil, fmt.Errorf("truncated %s", ifd)
+	}
+
+	pointers := map[uint16]uint32{}
+	for i := range n {
+		entry := p.tiff[start+2+i*12 : start+2+(i+1)*12]
+		tag := p.bo.Uint16(entry)
+		typ := p.bo.Uint16(entry[2:])
+		count := int64(p.bo.Uint32(entry[4:]))
+
+		if ifd == "ifd0" && (tag == tagEXIFIFD || tag == tagGPSIFD) {
+			pointers[tag] = p.bo.Uint32(entry[8:])
+			continue
+		}
+		name, ok := exifTagNames[ifd][tag]
+		size, known := exifTypeSizes[typ]
+		if !ok || !known || typ == 7 || count == 0 {
+			continue
+		}
+
+		// The values that fit in 4 bytes are stored in the entry.
+		raw := entry[8:12]
+		if length := count * int64(size); length > 4 {
+			valueOffset := int64(p.bo.Uint32(entry[8:]))
+			if valueOffset+length > int64(len(p.tiff)) {
+				return nil, fmt.Errorf("invalid offset of tag %s", name)
+			}
+			raw = p.tiff[valueOffset : valueOffset+length]
+		}
+		tags[name] = p.decodeValue(typ, int(count), raw)
+	}
+	return pointers, nil
+}
+
+func (p exifParser) decodeValue(typ uint16, count int, raw []byte) any {
+	if typ == 2 {
+		// ASCII values are NUL-terminated.
+		return strings.TrimSpace(strings.TrimRight(string(raw[:count]), "\x00"))
+	}
+
+	size := exifTypeSizes[typ]
+	values := make([]any, count)
+	for i := range values {
+		b := raw[i*size:]
+		switch typ {
+		case 1:
+			values[i] = uint32(b[0])
+		case 6:
+			values[i] = int32(int8(b[0]))
+		case 3:
+			values[i] = uint32(p.bo.Uint16(b))
+		case 8:
+			values[i] = int32(int16(p.bo.Uint16(b)))
+		case 4:
+			values[i] = p.bo.Uint32(b)
+		case 9:
+			values[i] = int32(p.bo.Uint32(b))
+		case 5:
+			values[i] = rational(float64(p.bo.Uint32(b)), float64(p.bo.Uint32(b[4:])))
+		case 10:
+			values[i] = rational(float64(int32(p.bo.Uint32(b))), float64(int32(p.bo.Uint32(b[4:]))))
+		case 11:
+			values[i] = float64(math.Float32frombits(p.bo.Uint32(b)))
+		case 12:
+			values[i] = math.Float64frombits(p.bo.Uint64(b))
+		}
+	}
+	if count == 1 {
+		return values[0]
+	}
+	return values
+}
+
+func rational(num, den float64) float64 {
+	if den == 0 {
+		return 0
+	}
+	return num / den
+}
+
+// orientation returns the orientation tag, between 1 and 8, or 1 if it's
+// missing or invalid.
+func (t exifTags) orientation() int {
+	if o, ok := t["Orientation"].(uint32); ok && o >= 1 && o <= 8 {
+		return int(o)
+	}
+	return 1
+}
+
+// coordinates returns the GPS position, in decimal degrees and meters, if
+// the tags hold it. The southern latitudes, the western longitudes and the
+// altitudes below the sea level are negative.
+func (t exifTags) coordinates() (lat, lng float64, alt *float64, ok bool) {
+	toDegrees := func(v any) (float64, bool) {
+		dms, ok := v.([]any)
+		if !ok || len(dms) != 3 {
+			return 0, false
+		}
+		var deg float64
+		for i, unit := range []float64{1, 60, 3600} {
+			f, ok := dms[i].(float64)
+			if !ok {
+				return 0, false
+			}
+			deg += f / unit
+		}
+		return deg, true
+	}
+
+	lat, latOK := toDegrees(t["GPSLatitude"])
+	lng, lngOK := toDegrees(t["GPSLongitude"])
+	if !latOK || !lngOK {
+		return 0, 0, nil, false
+	}
+	if t["GPSLatitudeRef"] == "S" {
+		lat = -lat
+	}
+	if t["GPSLongitudeRef"] == "W" {
+		lng = -lng
+	}
+
+	if a, isRational := t["GPSAltitude"].(float64); isRational {
+		if ref, _ := t["GPSAltitudeRef"].(uint32); ref == 1 {
+			a = -a
+		}
+		alt = &a
+	}
+	return lat, lng, alt, true
+}
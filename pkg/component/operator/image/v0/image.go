@@ -2,25 +2,27 @@ package image
 
 import (
 	"bytes"
-	"encoding/base64"
 	"fmt"
 	"image"
 	"image/color"
 	"image/png"
+	"strconv"
+	"strings"
 
-	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/pipeline-backend/pkg/data"
 )
 
-// base64Image is a base64 encoded image
+// base64Image is an image encoded as a base64 data URI, or as plain base64
+// in the inputs of older recipes.
 type base64Image string
 
 func decodeBase64Image(base64Img string) (image.Image, error) {
-	imgBytes, err := base64.StdEncoding.DecodeString(base.TrimBase64Mime(base64Img))
+	bin, err := data.DecodeBinary(base64Img)
 	if err != nil {
 		return nil, fmt.Errorf("error decoding base64 image: %v", err)
 	}
 
-	img, _, err := image.Decode(bytes.NewReader(imgBytes))
+	img, _, err := image.Decode(bytes.NewReader(bin.Raw))
 	if err != nil {
 		return nil, fmt.Errorf("error decoding image: %v", err)
 	}
@@ -28,16 +30,33 @@ func decodeBase64Image(base64Img string) (image.Image, error) {
 	return img, nil
 }
 
-func encodeBase64Image(img image.Image) (string, error) {
+// encodePNG encodes an image as a PNG binary value.
+func encodePNG(img image.Image) (*data.Binary, error) {
 	buf := new(bytes.Buffer)
 	err := png.Encode(buf, img)
 	if err != nil {
-		return "", fmt.Errorf("error encoding image: %v", err)
+		return nil, fmt.Errorf("error encoding image: %v", err)
 	}
 
-	base64ByteImg := base64.StdEncoding.EncodeToString(buf.Bytes())
+	return data.NewBinary(buf.Bytes(), "image/png", ""), nil
+}
 
-	return base64ByteImg, nil
+func encodeBase64Image(img image.Image) (string, error) {
+	bin, err := encodePNG(img)
+	if err != nil {
+		return "", err
+	}
+	return bin.Base64(), nil
+}
+
+// encodeImageDataURI encodes an image as a PNG data URI, which is how the
+// tasks output their images.
+func encodeImageDataURI(img image.Image) (base64Image, error) {
+	bin, err := encodePNG(img)
+	if err != nil {
+		return "", err
+	}
+	return base64Image(bin.DataURI()), nil
 }
 
 func convertToRGBA(img image.Image) *image.RGBA {
@@ -51,3 +70,24 @@ func convertToRGBA(img image.Image) *image.RGBA {
 	}
 	return rgba
 }
+
+// parseHexColor parses a color in the #RGB, #RRGGBB or #RRGGBBAA notation.
+func parseHexColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q", s)
+	}
+	c := color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}
+	return color.RGBAModel.Convert(c).(color.RGBA), nil
+}
@@ -0,0 +1,38 @@
+package image
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestImageDataURI(t *testing.T) {
+	c := quicktest.New(t)
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	uri, err := encodeImageDataURI(img)
+	c.Assert(err, quicktest.IsNil)
+	c.Check(strings.HasPrefix(string(uri), "data:image/png;base64,"), quicktest.IsTrue)
+
+	c.Run("data URI", func(c *quicktest.C) {
+		decoded, err := decodeBase64Image(string(uri))
+		c.Assert(err, quicktest.IsNil)
+		c.Check(decoded.Bounds(), quicktest.Equals, img.Bounds())
+	})
+
+	c.Run("plain base64", func(c *quicktest.C) {
+		b64, err := encodeBase64Image(img)
+		c.Assert(err, quicktest.IsNil)
+
+		decoded, err := decodeBase64Image(b64)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(decoded.Bounds(), quicktest.Equals, img.Bounds())
+	})
+
+	c.Run("nok - not base64", func(c *quicktest.C) {
+		_, err := decodeBase64Image("not an image")
+		c.Check(err, quicktest.IsNotNil)
+	})
+}
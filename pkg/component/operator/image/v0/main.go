@@ -8,6 +8,7 @@ import (
 
 	_ "embed"
 	_ "image/gif"
+	_ "image/jpeg"
 	_ "image/png"
 
 	"google.golang.org/protobuf/types/known/structpb"
@@ -56,6 +57,20 @@ func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution,
 		e.execute = crop
 	case "TASK_RESIZE":
 		e.execute = resize
+	case "TASK_ROTATE":
+		e.execute = rotate
+	case "TASK_WATERMARK":
+		e.execute = watermark
+	case "TASK_FILTER":
+		e.execute = filter
+	case "TASK_CONTACT_SHEET":
+		e.execute = contactSheet
+	case "TASK_METADATA":
+		e.execute = metadata
+	case "TASK_DESKEW":
+		e.execute = deskew
+	case "TASK_CHROMA_KEY":
+		e.execute = chromaKey
 	case "TASK_DRAW_CLASSIFICATION":
 		e.execute = drawClassification
 	case "TASK_DRAW_DETECTION":
@@ -0,0 +1,105 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+type chromaKeyInput struct {
+	Image     base64Image `json:"image"`
+	Color     string      `json:"color"`
+	Tolerance *float64    `json:"tolerance"`
+	Feather   *float64    `json:"feather"`
+}
+
+type chromaKeyOutput struct {
+	Image base64Image `json:"image"`
+}
+
+const (
+	defaultChromaKeyTolerance = 10
+	defaultChromaKeyFeather   = 5
+)
+
+// chromaKey makes the pixels close to a background color transparent. The
+// tolerance and the feather are percentages of the largest distance between
+// two colors: the pixels within the tolerance are removed, and those within
+// the feather beyond it fade out, which smooths the edges of the subject.
+func chromaKey(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	var inputStruct chromaKeyInput
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("error converting input to struct: %w", err)
+	}
+
+	tolerance := float64(defaultChromaKeyTolerance)
+	if inputStruct.Tolerance != nil {
+		tolerance = *inputStruct.Tolerance
+	}
+	feather := float64(defaultChromaKeyFeather)
+	if inputStruct.Feather != nil {
+		feather = *inputStruct.Feather
+	}
+	if tolerance < 0 || tolerance > 100 || feather < 0 || feather > 100 {
+		return nil, fmt.Errorf("tolerance and feather must be between 0 and 100")
+	}
+
+	img, err := decodeBase64Image(string(inputStruct.Image))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding image: %w", err)
+	}
+	bounds := img.Bounds()
+
+	// The background color defaults to the one of the top-left pixel.
+	key := color.NRGBAModel.Convert(img.At(bounds.Min.X, bounds.Min.Y)).(color.NRGBA)
+	if inputStruct.Color != "" {
+		c, err := parseHexColor(inputStruct.Color)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color: %w", err)
+		}
+		key = color.NRGBAModel.Convert(c).(color.NRGBA)
+	}
+
+	keyed := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			opacity := keyOpacity(colorDistance(c, key), tolerance, feather)
+			c.A = uint8(math.Round(float64(c.A) * opacity))
+			keyed.SetNRGBA(x-bounds.Min.X, y-bounds.Min.Y, c)
+		}
+	}
+
+	outputImg, err := encodeImageDataURI(keyed)
+	if err != nil {
+		return nil, err
+	}
+	return base.ConvertToStructpb(chromaKeyOutput{Image: outputImg})
+}
+
+// colorDistance returns the euclidean distance between two colors in the RGB
+// space, as a percentage of the distance between black and white.
+func colorDistance(a, b color.NRGBA) float64 {
+	dr := float64(a.R) - float64(b.R)
+	dg := float64(a.G) - float64(b.G)
+	db := float64(a.B) - float64(b.B)
+	return math.Sqrt(dr*dr+dg*dg+db*db) / (math.Sqrt(3) * 255) * 100
+}
+
+// keyOpacity returns the opacity, between 0 and 1, of a pixel at a distance
+// from the background color.
+func keyOpacity(distance, tolerance, feather float64) float64 {
+	switch {
+	case distance <= tolerance:
+		return 0
+	case distance >= tolerance+feather:
+		return 1
+	}
+	return (distance - tolerance) / feather
+}
@@ -0,0 +1,101 @@
+package image
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+func TestChromaKey(t *testing.T) {
+	c := quicktest.New(t)
+
+	// A red square on a green background, with two gray pixels at different
+	// distances from white.
+	img := solidImage(10, 10, green)
+	for y := 3; y < 7; y++ {
+		for x := 3; x < 7; x++ {
+			img.SetRGBA(x, y, red)
+		}
+	}
+	img.SetRGBA(9, 0, color.RGBA{R: 230, G: 230, B: 230, A: 255})
+	img.SetRGBA(9, 1, color.RGBA{R: 217, G: 217, B: 217, A: 255})
+
+	base64Img, err := encodeBase64Image(img)
+	c.Assert(err, quicktest.IsNil)
+
+	zero := 0.0
+	tooLarge := 150.0
+
+	testCases := []struct {
+		name  string
+		input chromaKeyInput
+		// expectedAlpha are checked in the output image.
+		expectedAlpha map[image.Point]uint8
+		expectedError string
+	}{
+		{
+			name:  "top-left color",
+			input: chromaKeyInput{},
+			expectedAlpha: map[image.Point]uint8{
+				{0, 0}: 0, {2, 5}: 0, {3, 3}: 255, {6, 6}: 255, {9, 0}: 255,
+			},
+		},
+		{
+			name:  "feathered edges",
+			input: chromaKeyInput{Color: "#ffffff"},
+			expectedAlpha: map[image.Point]uint8{
+				// The first gray pixel is within the tolerance, and the
+				// second one is almost out of the feather.
+				{9, 0}: 0, {9, 1}: 250, {0, 0}: 255,
+			},
+		},
+		{
+			name:  "no feather",
+			input: chromaKeyInput{Color: "#ff0000", Tolerance: &zero, Feather: &zero},
+			expectedAlpha: map[image.Point]uint8{
+				{3, 3}: 0, {0, 0}: 255, {9, 1}: 255,
+			},
+		},
+		{
+			name:          "invalid tolerance",
+			input:         chromaKeyInput{Tolerance: &tooLarge},
+			expectedError: "tolerance and feather must be between 0 and 100",
+		},
+		{
+			name:          "invalid color",
+			input:         chromaKeyInput{Color: "green"},
+			expectedError: `invalid color: invalid color "green"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			tc.input.Image = base64Image("data:image/png;base64," + base64Img)
+			inputStruct, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, quicktest.IsNil)
+
+			output, err := chromaKey(inputStruct, nil, context.Background())
+			if tc.expectedError != "" {
+				c.Assert(err, quicktest.ErrorMatches, tc.expectedError)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+
+			var out chromaKeyOutput
+			c.Assert(base.ConvertFromStructpb(output, &out), quicktest.IsNil)
+			keyed, err := decodeBase64Image(string(out.Image))
+			c.Assert(err, quicktest.IsNil)
+
+			c.Check(keyed.Bounds().Size(), quicktest.Equals, image.Pt(10, 10))
+			for p, want := range tc.expectedAlpha {
+				got := color.NRGBAModel.Convert(keyed.At(p.X, p.Y)).(color.NRGBA)
+				c.Check(got.A, quicktest.Equals, want, quicktest.Commentf("pixel %v", p))
+			}
+		})
+	}
+}
@@ -68,14 +68,14 @@ func concat(input *structpb.Struct, job *base.Job, ctx context.Context) (*struct
 	}
 
 	// Encode output image
-	base64Output, err := encodeBase64Image(output)
+	outputImg, err := encodeImageDataURI(output)
 	if err != nil {
 		return nil, fmt.Errorf("error encoding output image: %v", err)
 	}
 
 	// Prepare output
 	outputStruct := ConcatOutput{
-		Image: base64Image(fmt.Sprintf("data:image/png;base64,%s", base64Output)),
+		Image: outputImg,
 	}
 
 	return base.ConvertToStructpb(outputStruct)
@@ -0,0 +1,179 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	nr "github.com/nfnt/resize"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+type contactSheetInput struct {
+	Images          []base64Image `json:"images"`
+	Labels          []string      `json:"labels"`
+	Columns         int           `json:"columns"`
+	CellSize        int           `json:"cell-size"`
+	Padding         int           `json:"padding"`
+	FontSize        float64       `json:"font-size"`
+	BackgroundColor string        `json:"background-color"`
+}
+
+type contactSheetOutput struct {
+	Image base64Image `json:"image"`
+}
+
+const (
+	defaultCellSize      = 256
+	defaultLabelFontSize = 14
+
+	// maxContactSheetSide bounds the size of the output image.
+	maxContactSheetSide = 16384
+)
+
+// contactSheet tiles the images into a grid, as thumbnails that fit in
+// square cells, optionally labeled below them. The thumbnails keep their
+// aspect ratio and are centered in their cells. The images smaller than a
+// cell aren't enlarged.
+func contactSheet(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	var inputStruct contactSheetInput
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("error converting input to struct: %w", err)
+	}
+
+	if len(inputStruct.Images) == 0 {
+		return nil, fmt.Errorf("no images provided")
+	}
+	if len(inputStruct.Labels) > len(inputStruct.Images) {
+		return nil, fmt.Errorf("there are more labels than images")
+	}
+	if inputStruct.Columns < 0 || inputStruct.CellSize < 0 || inputStruct.Padding < 0 || inputStruct.FontSize < 0 {
+		return nil, fmt.Errorf("columns, cell size, padding and font size must be greater than or equal to 0")
+	}
+
+	cellSize := inputStruct.CellSize
+	if cellSize == 0 {
+		cellSize = defaultCellSize
+	}
+	background := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	if inputStruct.BackgroundColor != "" {
+		var err error
+		if background, err = parseHexColor(inputStruct.BackgroundColor); err != nil {
+			return nil, fmt.Errorf("invalid background color: %w", err)
+		}
+	}
+
+	var face font.Face
+	var labelHeight int
+	if hasLabels(inputStruct.Labels) {
+		size := inputStruct.FontSize
+		if size == 0 {
+			size = defaultLabelFontSize
+		}
+		f, err := opentype.Parse(IBMPlexSansRegular)
+		if err != nil {
+			return nil, err
+		}
+		if face, err = opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72}); err != nil {
+			return nil, err
+		}
+		defer face.Close()
+
+		metrics := face.Metrics()
+		labelHeight = metrics.Height.Ceil() + int(math.Ceil(size/4))
+	}
+
+	// The grid is square by default, without empty rows or columns.
+	columns, _ := determineGridDimensions(len(inputStruct.Images), inputStruct.Columns, 0)
+	columns = min(columns, len(inputStruct.Images))
+	rows := (len(inputStruct.Images) + columns - 1) / columns
+	cellHeight := cellSize + labelHeight
+	width := columns*cellSize + (columns+1)*inputStruct.Padding
+	height := rows*cellHeight + (rows+1)*inputStruct.Padding
+	if width > maxContactSheetSide || height > maxContactSheetSide {
+		return nil, fmt.Errorf("the contact sheet would be %dx%d, larger than the maximum of %dx%d", width, height, maxContactSheetSide, maxContactSheetSide)
+	}
+
+	sheet := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(sheet, sheet.Bounds(), &image.Uniform{C: background}, image.Point{}, draw.Src)
+
+	var dc *gg.Context
+	if face != nil {
+		dc = gg.NewContextForRGBA(sheet)
+		dc.SetFontFace(face)
+		dc.SetColor(labelColor(background))
+	}
+
+	for i, base64Img := range inputStruct.Images {
+		img, err := decodeBase64Image(string(base64Img))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding image %d: %w", i, err)
+		}
+		thumb := nr.Thumbnail(uint(cellSize), uint(cellSize), img, nr.Lanczos3)
+
+		col, row := i%columns, i/columns
+		cellX := inputStruct.Padding + col*(cellSize+inputStruct.Padding)
+		cellY := inputStruct.Padding + row*(cellHeight+inputStruct.Padding)
+
+		size := thumb.Bounds().Size()
+		at := image.Pt(cellX+(cellSize-size.X)/2, cellY+(cellSize-size.Y)/2)
+		draw.Draw(sheet, image.Rectangle{Min: at, Max: at.Add(size)}, thumb, thumb.Bounds().Min, draw.Over)
+
+		if dc != nil && i < len(inputStruct.Labels) && inputStruct.Labels[i] != "" {
+			label := fitLabel(dc, inputStruct.Labels[i], float64(cellSize))
+			dc.DrawStringAnchored(label, float64(cellX)+float64(cellSize)/2, float64(cellY+cellSize)+float64(labelHeight)/2, 0.5, 0.5)
+		}
+	}
+
+	outputImg, err := encodeImageDataURI(sheet)
+	if err != nil {
+		return nil, err
+	}
+	return base.ConvertToStructpb(contactSheetOutput{Image: outputImg})
+}
+
+func hasLabels(labels []string) bool {
+	for _, l := range labels {
+		if l != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// fitLabel shortens a label with an ellipsis until it fits in a width.
+func fitLabel(dc *gg.Context, label string, width float64) string {
+	label = strings.Join(strings.Fields(label), " ")
+	if w, _ := dc.MeasureString(label); w <= width {
+		return label
+	}
+
+	runes := []rune(label)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		shortened := strings.TrimSpace(string(runes)) + "…"
+		if w, _ := dc.MeasureString(shortened); w <= width {
+			return shortened
+		}
+	}
+	return ""
+}
+
+// labelColor returns black or white, whichever contrasts the most with the
+// background.
+func labelColor(background color.RGBA) color.Color {
+	if color.GrayModel.Convert(background).(color.Gray).Y < 128 && background.A > 127 {
+		return color.White
+	}
+	return color.Black
+}
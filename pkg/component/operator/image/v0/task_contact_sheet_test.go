@@ -0,0 +1,155 @@
+package image
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+func TestContactSheet(t *testing.T) {
+	c := quicktest.New(t)
+
+	toBase64 := func(c *quicktest.C, img image.Image) base64Image {
+		b, err := encodeBase64Image(img)
+		c.Assert(err, quicktest.IsNil)
+		return base64Image("data:image/png;base64," + b)
+	}
+
+	runContactSheet := func(c *quicktest.C, input contactSheetInput) (image.Image, error) {
+		inputStruct, err := base.ConvertToStructpb(input)
+		c.Assert(err, quicktest.IsNil)
+		output, err := contactSheet(inputStruct, nil, context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		var out contactSheetOutput
+		c.Assert(base.ConvertFromStructpb(output, &out), quicktest.IsNil)
+		sheet, err := decodeBase64Image(string(out.Image))
+		c.Assert(err, quicktest.IsNil)
+		return sheet, nil
+	}
+
+	c.Run("grid with padding", func(c *quicktest.C) {
+		sheet, err := runContactSheet(c, contactSheetInput{
+			Images: []base64Image{
+				toBase64(c, solidImage(10, 10, red)),
+				toBase64(c, solidImage(10, 10, green)),
+				toBase64(c, solidImage(10, 10, blue)),
+			},
+			Columns:  2,
+			CellSize: 10,
+			Padding:  2,
+		})
+		c.Assert(err, quicktest.IsNil)
+
+		c.Check(sheet.Bounds().Size(), quicktest.Equals, image.Pt(26, 26))
+		for p, want := range map[image.Point]color.RGBA{
+			{0, 0}: white, {2, 2}: red, {11, 11}: red, {13, 5}: white,
+			{14, 2}: green, {23, 11}: green, {5, 13}: white,
+			{2, 14}: blue, {11, 23}: blue,
+			// The last cell is empty.
+			{18, 18}: white,
+		} {
+			c.Check(color.RGBAModel.Convert(sheet.At(p.X, p.Y)), quicktest.Equals, want, quicktest.Commentf("pixel %v", p))
+		}
+	})
+
+	c.Run("square grid by default", func(c *quicktest.C) {
+		images := make([]base64Image, 4)
+		for i := range images {
+			images[i] = toBase64(c, solidImage(4, 4, red))
+		}
+		sheet, err := runContactSheet(c, contactSheetInput{Images: images, CellSize: 4})
+		c.Assert(err, quicktest.IsNil)
+		c.Check(sheet.Bounds().Size(), quicktest.Equals, image.Pt(8, 8))
+	})
+
+	c.Run("thumbnails are centered", func(c *quicktest.C) {
+		sheet, err := runContactSheet(c, contactSheetInput{
+			Images:          []base64Image{toBase64(c, solidImage(20, 10, red))},
+			CellSize:        10,
+			BackgroundColor: "#00f",
+		})
+		c.Assert(err, quicktest.IsNil)
+
+		// The image is scaled down to 10x5, between two blue bands.
+		c.Check(sheet.Bounds().Size(), quicktest.Equals, image.Pt(10, 10))
+		for p, want := range map[image.Point]color.RGBA{
+			{5, 0}: blue, {5, 1}: blue, {0, 3}: red, {9, 5}: red, {5, 8}: blue, {5, 9}: blue,
+		} {
+			c.Check(color.RGBAModel.Convert(sheet.At(p.X, p.Y)), quicktest.Equals, want, quicktest.Commentf("pixel %v", p))
+		}
+	})
+
+	c.Run("labels", func(c *quicktest.C) {
+		sheet, err := runContactSheet(c, contactSheetInput{
+			Images: []base64Image{
+				toBase64(c, solidImage(40, 40, white)),
+				toBase64(c, solidImage(40, 40, white)),
+			},
+			Labels:   []string{"cat"},
+			CellSize: 40,
+		})
+		c.Assert(err, quicktest.IsNil)
+
+		// The labels are written below the cells, which are taller.
+		bounds := sheet.Bounds()
+		c.Check(bounds.Dx(), quicktest.Equals, 80)
+		c.Check(bounds.Dy() > 40, quicktest.IsTrue)
+
+		var darkBelowFirst, darkElsewhere int
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				if gray(sheet, x, y) > 128 {
+					continue
+				}
+				if x < 40 && y >= 40 {
+					darkBelowFirst++
+				} else {
+					darkElsewhere++
+				}
+			}
+		}
+		c.Check(darkBelowFirst > 0, quicktest.IsTrue)
+		c.Check(darkElsewhere, quicktest.Equals, 0)
+	})
+
+	c.Run("nok - no images", func(c *quicktest.C) {
+		_, err := runContactSheet(c, contactSheetInput{})
+		c.Check(err, quicktest.ErrorMatches, "no images provided")
+	})
+
+	c.Run("nok - more labels than images", func(c *quicktest.C) {
+		_, err := runContactSheet(c, contactSheetInput{
+			Images: []base64Image{toBase64(c, solidImage(4, 4, red))},
+			Labels: []string{"a", "b"},
+		})
+		c.Check(err, quicktest.ErrorMatches, "there are more labels than images")
+	})
+
+	c.Run("nok - invalid background color", func(c *quicktest.C) {
+		_, err := runContactSheet(c, contactSheetInput{
+			Images:          []base64Image{toBase64(c, solidImage(4, 4, red))},
+			BackgroundColor: "white",
+		})
+		c.Check(err, quicktest.ErrorMatches, `invalid background color: invalid color "white"`)
+	})
+
+	c.Run("nok - too large", func(c *quicktest.C) {
+		_, err := runContactSheet(c, contactSheetInput{
+			Images: []base64Image{
+				toBase64(c, solidImage(4, 4, red)),
+				toBase64(c, solidImage(4, 4, red)),
+			},
+			Columns:  2,
+			CellSize: 10000,
+		})
+		c.Check(err, quicktest.ErrorMatches, "the contact sheet would be 20000x10000, larger than the maximum of 16384x16384")
+	})
+}
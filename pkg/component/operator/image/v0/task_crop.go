@@ -146,13 +146,13 @@ func crop(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb
 		croppedImg = cropCornerRadius(croppedImg, radius).(*image.RGBA)
 	}
 
-	base64Img, err := encodeBase64Image(croppedImg)
+	outputImg, err := encodeImageDataURI(croppedImg)
 	if err != nil {
 		return nil, err
 	}
 
 	output := cropOutput{
-		Image: base64Image(fmt.Sprintf("data:image/png;base64,%s", base64Img)),
+		Image: outputImg,
 	}
 
 	return base.ConvertToStructpb(output)
@@ -0,0 +1,323 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/draw"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	nr "github.com/nfnt/resize"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+type point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type deskewInput struct {
+	Image           base64Image `json:"image"`
+	Corners         []point     `json:"corners"`
+	Width           int         `json:"width"`
+	Height          int         `json:"height"`
+	MaxAngle        float64     `json:"max-angle"`
+	BackgroundColor string      `json:"background-color"`
+}
+
+type deskewOutput struct {
+	Image base64Image `json:"image"`
+	Angle *float64    `json:"angle,omitempty"`
+}
+
+const (
+	defaultMaxSkewAngle = 15
+	maxSkewAngle        = 45
+
+	// The skew is detected on a thumbnail of the image, as the angle doesn't
+	// depend on the scale.
+	skewDetectionSide = 1024
+
+	// maxWarpedSide bounds the size of the warped image.
+	maxWarpedSide = 16384
+)
+
+// deskew rectifies a document photo. With 4 corners, the quadrilateral they
+// delimit is warped into a rectangle. Otherwise, the skew of the text lines
+// is detected and the image is rotated to straighten them, keeping its size.
+func deskew(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	var inputStruct deskewInput
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("error converting input to struct: %w", err)
+	}
+
+	if len(inputStruct.Corners) != 0 && len(inputStruct.Corners) != 4 {
+		return nil, fmt.Errorf("corners must have 4 points, got %d", len(inputStruct.Corners))
+	}
+	if inputStruct.Width < 0 || inputStruct.Height < 0 {
+		return nil, fmt.Errorf("width and height must be greater than or equal to 0")
+	}
+	maxAngle := inputStruct.MaxAngle
+	if maxAngle == 0 {
+		maxAngle = defaultMaxSkewAngle
+	}
+	if maxAngle < 0 || maxAngle > maxSkewAngle {
+		return nil, fmt.Errorf("max angle must be between 0 and %d", maxSkewAngle)
+	}
+
+	background := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	if inputStruct.BackgroundColor != "" {
+		var err error
+		if background, err = parseHexColor(inputStruct.BackgroundColor); err != nil {
+			return nil, fmt.Errorf("invalid background color: %w", err)
+		}
+	}
+
+	img, err := decodeBase64Image(string(inputStruct.Image))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding image: %w", err)
+	}
+
+	var output deskewOutput
+	var rectified *image.RGBA
+	if len(inputStruct.Corners) == 4 {
+		if rectified, err = warpPerspective(toOriginRGBA(img), inputStruct.Corners, inputStruct.Width, inputStruct.Height, background); err != nil {
+			return nil, err
+		}
+	} else {
+		angle := detectSkew(img, maxAngle)
+		rectified = toOriginRGBA(img)
+		if angle != 0 {
+			rectified = rotateAngle(rectified, -angle, background, true)
+		}
+		output.Angle = &angle
+	}
+
+	if output.Image, err = encodeImageDataURI(rectified); err != nil {
+		return nil, err
+	}
+	return base.ConvertToStructpb(output)
+}
+
+// warpPerspective maps the quadrilateral delimited by the corners, from the
+// top-left one clockwise, to a rectangle. Its size defaults to the longest
+// of the opposite sides of the quadrilateral.
+func warpPerspective(img *image.RGBA, corners []point, width, height int, background color.RGBA) (*image.RGBA, error) {
+	dist := func(a, b point) float64 {
+		return math.Hypot(a.X-b.X, a.Y-b.Y)
+	}
+	if width == 0 {
+		width = int(math.Round(max(dist(corners[0], corners[1]), dist(corners[3], corners[2]))))
+	}
+	if height == 0 {
+		height = int(math.Round(max(dist(corners[0], corners[3]), dist(corners[1], corners[2]))))
+	}
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("the corners don't delimit a quadrilateral")
+	}
+	if width > maxWarpedSide || height > maxWarpedSide {
+		return nil, fmt.Errorf("the output image would be %dx%d, larger than the maximum of %dx%d", width, height, maxWarpedSide, maxWarpedSide)
+	}
+
+	w, h := float64(width), float64(height)
+	rect := []point{{0, 0}, {w, 0}, {w, h}, {0, h}}
+	// The transform is solved from the destination to the source, so each
+	// output pixel is sampled from the input image.
+	hm, err := homography(rect, corners)
+	if err != nil {
+		return nil, err
+	}
+
+	warped := image.NewRGBA(image.Rect(0, 0, width, height))
+	for v := 0; v < height; v++ {
+		for u := 0; u < width; u++ {
+			// The coordinates are those of the pixel centers.
+			x, y := hm.apply(float64(u)+0.5, float64(v)+0.5)
+			warped.SetRGBA(u, v, sampleBilinear(img, x-0.5, y-0.5, background))
+		}
+	}
+	return warped, nil
+}
+
+// homographyMatrix holds the 8 coefficients of a perspective transform, the
+// 9th being 1.
+type homographyMatrix [8]float64
+
+func (m homographyMatrix) apply(x, y float64) (float64, float64) {
+	d := m[6]*x + m[7]*y + 1
+	return (m[0]*x + m[1]*y + m[2]) / d, (m[3]*x + m[4]*y + m[5]) / d
+}
+
+// homography solves the perspective transform that maps 4 points to 4
+// others.
+func homography(from, to []point) (homographyMatrix, error) {
+	// Each pair of points gives 2 linear equations of the coefficients.
+	var a [8][9]float64
+	for i := range 4 {
+		x, y, tx, ty := from[i].X, from[i].Y, to[i].X, to[i].Y
+		a[2*i] = [9]float64{x, y, 1, 0, 0, 0, -x * tx, -y * tx, tx}
+		a[2*i+1] = [9]float64{0, 0, 0, x, y, 1, -x * ty, -y * ty, ty}
+	}
+
+	// Gaussian elimination with partial pivoting.
+	for col := range 8 {
+		pivot := col
+		for row := col + 1; row < 8; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return homographyMatrix{}, fmt.Errorf("the corners don't delimit a quadrilateral")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for row := range 8 {
+			if row == col {
+				continue
+			}
+			f := a[row][col] / a[col][col]
+			for k := col; k < 9; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+		}
+	}
+
+	var m homographyMatrix
+	for i := range m {
+		m[i] = a[i][8] / a[i][i]
+	}
+	return m, nil
+}
+
+// sampleBilinear interpolates the color of the image at a point, in pixel
+// coordinates. The pixels outside of the image have the background color.
+func sampleBilinear(img *image.RGBA, x, y float64, background color.RGBA) color.RGBA {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+
+	at := func(px, py int) color.RGBA {
+		if !(image.Point{X: px, Y: py}.In(img.Rect)) {
+			return background
+		}
+		return img.RGBAAt(px, py)
+	}
+	ix, iy := int(x0), int(y0)
+	c00, c10, c01, c11 := at(ix, iy), at(ix+1, iy), at(ix, iy+1), at(ix+1, iy+1)
+
+	lerp := func(v00, v10, v01, v11 uint8) uint8 {
+		top := float64(v00)*(1-fx) + float64(v10)*fx
+		bottom := float64(v01)*(1-fx) + float64(v11)*fx
+		return clampUint8(top*(1-fy) + bottom*fy)
+	}
+	return color.RGBA{
+		R: lerp(c00.R, c10.R, c01.R, c11.R),
+		G: lerp(c00.G, c10.G, c01.G, c11.G),
+		B: lerp(c00.B, c10.B, c01.B, c11.B),
+		A: lerp(c00.A, c10.A, c01.A, c11.A),
+	}
+}
+
+// detectSkew returns the clockwise angle, in degrees, of the text lines of
+// the image. The dark pixels are projected on the vertical axis of the
+// image rotated by each candidate angle; the text lines are aligned with it
+// when the projection has the sharpest peaks.
+func detectSkew(img image.Image, maxAngle float64) float64 {
+	thumb := nr.Thumbnail(skewDetectionSide, skewDetectionSide, img, nr.Bilinear)
+	points := darkPixels(thumb)
+	if len(points) == 0 {
+		return 0
+	}
+
+	bounds := thumb.Bounds()
+	diagonal := int(math.Ceil(math.Hypot(float64(bounds.Dx()), float64(bounds.Dy()))))
+	bins := make([]float64, 2*diagonal+1)
+	score := func(angle float64) float64 {
+		clear(bins)
+		sin, cos := math.Sincos(angle * math.Pi / 180)
+		for _, p := range points {
+			bins[int(math.Round(p.Y*cos-p.X*sin))+diagonal]++
+		}
+		var s float64
+		for _, b := range bins {
+			s += b * b
+		}
+		return s
+	}
+
+	// A coarse search is refined around the best angle.
+	best, bestScore := 0.0, score(0)
+	search := func(from, to, step float64) {
+		for angle := from; angle <= to+step/2; angle += step {
+			if s := score(angle); s > bestScore {
+				best, bestScore = angle, s
+			}
+		}
+	}
+	search(-maxAngle, maxAngle, 1)
+	search(max(best-1, -maxAngle), min(best+1, maxAngle), 0.1)
+	return math.Round(best*10) / 10
+}
+
+// darkPixels returns the positions of the pixels darker than the Otsu
+// threshold of the image, from its center.
+func darkPixels(img image.Image) []point {
+	bounds := img.Bounds()
+	gray := image.NewGray(bounds)
+	draw.Draw(gray, bounds, img, bounds.Min, draw.Src)
+
+	var histogram [256]int
+	for _, v := range gray.Pix {
+		histogram[v]++
+	}
+	threshold := otsuThreshold(histogram, len(gray.Pix))
+
+	cx, cy := float64(bounds.Min.X+bounds.Max.X)/2, float64(bounds.Min.Y+bounds.Max.Y)/2
+	var points []point
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if gray.GrayAt(x, y).Y <= threshold {
+				points = append(points, point{X: float64(x) - cx, Y: float64(y) - cy})
+			}
+		}
+	}
+	// A plain image has no foreground.
+	if len(points) == len(gray.Pix) {
+		return nil
+	}
+	return points
+}
+
+// otsuThreshold returns the gray level that best separates the histogram
+// into two classes, by maximizing their between-class variance.
+func otsuThreshold(histogram [256]int, total int) uint8 {
+	var sum float64
+	for i, n := range histogram {
+		sum += float64(i * n)
+	}
+
+	var sumBackground, best float64
+	var weightBackground int
+	var threshold uint8
+	for i, n := range histogram {
+		weightBackground += n
+		if weightBackground == 0 {
+			continue
+		}
+		weightForeground := total - weightBackground
+		if weightForeground == 0 {
+			break
+		}
+		sumBackground += float64(i * n)
+		meanBackground := sumBackground / float64(weightBackground)
+		meanForeground := (sum - sumBackground) / float64(weightForeground)
+		variance := float64(weightBackground) * float64(weightForeground) * (meanBackground - meanForeground) * (meanBackground - meanForeground)
+		if variance > best {
+			best, threshold = variance, uint8(i)
+		}
+	}
+	return threshold
+}
@@ -0,0 +1,139 @@
+package image
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+// textImage returns a white page with black bars, as the lines of a text.
+func textImage() *image.RGBA {
+	img := solidImage(300, 200, white)
+	for y := 40; y < 170; y += 20 {
+		for dy := 0; dy < 4; dy++ {
+			for x := 30; x < 270; x++ {
+				img.SetRGBA(x, y+dy, color.RGBA{A: 255})
+			}
+		}
+	}
+	return img
+}
+
+func TestDeskew(t *testing.T) {
+	c := quicktest.New(t)
+
+	runDeskew := func(c *quicktest.C, img image.Image, input deskewInput) (image.Image, *float64, error) {
+		base64Img, err := encodeBase64Image(img)
+		c.Assert(err, quicktest.IsNil)
+		input.Image = base64Image("data:image/png;base64," + base64Img)
+
+		inputStruct, err := base.ConvertToStructpb(input)
+		c.Assert(err, quicktest.IsNil)
+		output, err := deskew(inputStruct, nil, context.Background())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var out deskewOutput
+		c.Assert(base.ConvertFromStructpb(output, &out), quicktest.IsNil)
+		rectified, err := decodeBase64Image(string(out.Image))
+		c.Assert(err, quicktest.IsNil)
+		return rectified, out.Angle, nil
+	}
+
+	// A red square in the middle of a white image.
+	square := solidImage(20, 20, white)
+	for y := 5; y < 15; y++ {
+		for x := 5; x < 15; x++ {
+			square.SetRGBA(x, y, red)
+		}
+	}
+
+	c.Run("warp rectangle", func(c *quicktest.C) {
+		warped, angle, err := runDeskew(c, square, deskewInput{
+			Corners: []point{{5, 5}, {15, 5}, {15, 15}, {5, 15}},
+		})
+		c.Assert(err, quicktest.IsNil)
+		c.Check(angle, quicktest.IsNil)
+
+		c.Check(warped.Bounds().Size(), quicktest.Equals, image.Pt(10, 10))
+		for _, p := range []image.Point{{0, 0}, {9, 0}, {5, 5}, {0, 9}, {9, 9}} {
+			c.Check(color.RGBAModel.Convert(warped.At(p.X, p.Y)), quicktest.Equals, red, quicktest.Commentf("pixel %v", p))
+		}
+	})
+
+	c.Run("warp into a given size", func(c *quicktest.C) {
+		warped, _, err := runDeskew(c, square, deskewInput{
+			Corners: []point{{0, 0}, {20, 0}, {20, 20}, {0, 20}},
+			Width:   40,
+			Height:  10,
+		})
+		c.Assert(err, quicktest.IsNil)
+
+		// The image is stretched horizontally and squashed vertically.
+		c.Check(warped.Bounds().Size(), quicktest.Equals, image.Pt(40, 10))
+		c.Check(color.RGBAModel.Convert(warped.At(20, 5)), quicktest.Equals, red)
+		c.Check(color.RGBAModel.Convert(warped.At(2, 5)), quicktest.Equals, white)
+		c.Check(color.RGBAModel.Convert(warped.At(37, 5)), quicktest.Equals, white)
+	})
+
+	c.Run("warp trapezoid", func(c *quicktest.C) {
+		// The top side is shorter, as in a photo of a page lying on a desk.
+		warped, _, err := runDeskew(c, square, deskewInput{
+			Corners: []point{{7, 5}, {13, 5}, {15, 15}, {5, 15}},
+		})
+		c.Assert(err, quicktest.IsNil)
+
+		c.Check(warped.Bounds().Size(), quicktest.Equals, image.Pt(10, 10))
+		c.Check(color.RGBAModel.Convert(warped.At(1, 1)), quicktest.Equals, red)
+		c.Check(color.RGBAModel.Convert(warped.At(9, 9)), quicktest.Equals, red)
+	})
+
+	c.Run("detect skew", func(c *quicktest.C) {
+		skewed := rotateAngle(textImage(), 4, white, true)
+		straightened, angle, err := runDeskew(c, skewed, deskewInput{})
+		c.Assert(err, quicktest.IsNil)
+
+		c.Assert(angle, quicktest.IsNotNil)
+		c.Check(math.Abs(*angle-4) <= 0.2, quicktest.IsTrue, quicktest.Commentf("angle %f", *angle))
+		c.Check(straightened.Bounds().Size(), quicktest.Equals, image.Pt(300, 200))
+
+		// The bars are horizontal again.
+		c.Check(math.Abs(detectSkew(straightened, defaultMaxSkewAngle)) <= 0.2, quicktest.IsTrue)
+	})
+
+	c.Run("straight text", func(c *quicktest.C) {
+		_, angle, err := runDeskew(c, textImage(), deskewInput{})
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(angle, quicktest.IsNotNil)
+		c.Check(*angle, quicktest.Equals, 0.0)
+	})
+
+	c.Run("blank page", func(c *quicktest.C) {
+		_, angle, err := runDeskew(c, solidImage(50, 50, white), deskewInput{})
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(angle, quicktest.IsNotNil)
+		c.Check(*angle, quicktest.Equals, 0.0)
+	})
+
+	c.Run("nok - wrong number of corners", func(c *quicktest.C) {
+		_, _, err := runDeskew(c, square, deskewInput{Corners: []point{{0, 0}, {1, 0}, {1, 1}}})
+		c.Check(err, quicktest.ErrorMatches, "corners must have 4 points, got 3")
+	})
+
+	c.Run("nok - degenerate corners", func(c *quicktest.C) {
+		_, _, err := runDeskew(c, square, deskewInput{Corners: []point{{5, 5}, {5, 5}, {5, 5}, {5, 5}}})
+		c.Check(err, quicktest.ErrorMatches, "the corners don't delimit a quadrilateral")
+	})
+
+	c.Run("nok - max angle too large", func(c *quicktest.C) {
+		_, _, err := runDeskew(c, square, deskewInput{MaxAngle: 60})
+		c.Check(err, quicktest.ErrorMatches, "max angle must be between 0 and 45")
+	})
+}
@@ -85,13 +85,13 @@ func drawClassification(input *structpb.Struct, job *base.Job, ctx context.Conte
 		return nil, err
 	}
 
-	base64Img, err := encodeBase64Image(imgRGBA)
+	outputImg, err := encodeImageDataURI(imgRGBA)
 	if err != nil {
 		return nil, err
 	}
 
 	output := drawClassificationOutput{
-		Image: base64Image(fmt.Sprintf("data:image/png;base64,%s", base64Img)),
+		Image: outputImg,
 	}
 
 	return base.ConvertToStructpb(output)
@@ -69,13 +69,13 @@ func drawDetection(input *structpb.Struct, job *base.Job, ctx context.Context) (
 		}
 	}
 
-	base64Img, err := encodeBase64Image(imgRGBA)
+	outputImg, err := encodeImageDataURI(imgRGBA)
 	if err != nil {
 		return nil, err
 	}
 
 	output := drawDetectionOutput{
-		Image: base64Image(fmt.Sprintf("data:image/png;base64,%s", base64Img)),
+		Image: outputImg,
 	}
 
 	return base.ConvertToStructpb(output)
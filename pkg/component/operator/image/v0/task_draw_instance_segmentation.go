@@ -116,13 +116,13 @@ func drawInstanceSegmentation(input *structpb.Struct, job *base.Job, ctx context
 		}
 	}
 
-	base64Img, err := encodeBase64Image(imgRGBA)
+	outputImg, err := encodeImageDataURI(imgRGBA)
 	if err != nil {
 		return nil, err
 	}
 
 	output := drawInstanceSegmentationOutput{
-		Image: base64Image(fmt.Sprintf("data:image/png;base64,%s", base64Img)),
+		Image: outputImg,
 	}
 
 	return base.ConvertToStructpb(output)
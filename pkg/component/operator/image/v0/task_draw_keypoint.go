@@ -82,13 +82,13 @@ func drawKeypoint(input *structpb.Struct, job *base.Job, ctx context.Context) (*
 		}
 	}
 
-	base64Img, err := encodeBase64Image(imgRGBA)
+	outputImg, err := encodeImageDataURI(imgRGBA)
 	if err != nil {
 		return nil, err
 	}
 
 	output := drawKeypointOutput{
-		Image: base64Image(fmt.Sprintf("data:image/png;base64,%s", base64Img)),
+		Image: outputImg,
 	}
 
 	return base.ConvertToStructpb(output)
@@ -92,13 +92,13 @@ func drawOCR(input *structpb.Struct, job *base.Job, ctx context.Context) (*struc
 		}
 	}
 
-	base64Img, err := encodeBase64Image(imgRGBA)
+	outputImg, err := encodeImageDataURI(imgRGBA)
 	if err != nil {
 		return nil, err
 	}
 
 	output := drawOCROutput{
-		Image: base64Image(fmt.Sprintf("data:image/png;base64,%s", base64Img)),
+		Image: outputImg,
 	}
 
 	return base.ConvertToStructpb(output)
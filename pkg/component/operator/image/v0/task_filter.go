@@ -0,0 +1,204 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"slices"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+type filterInput struct {
+	Image  base64Image `json:"image"`
+	Filter string      `json:"filter"`
+	Radius float64     `json:"radius"`
+	Amount float64     `json:"amount"`
+}
+
+type filterOutput struct {
+	Image base64Image `json:"image"`
+}
+
+const (
+	defaultFilterRadius  = 1
+	defaultUnsharpAmount = 1
+
+	// The radii are bounded, as the cost of the filters grows with them.
+	maxBlurRadius   = 50
+	maxMedianRadius = 10
+)
+
+// filter applies a gaussian blur, an unsharp mask or a median filter to the
+// image.
+func filter(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	var inputStruct filterInput
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("error converting input to struct: %w", err)
+	}
+
+	radius := inputStruct.Radius
+	if radius == 0 {
+		radius = defaultFilterRadius
+	}
+	amount := inputStruct.Amount
+	if amount == 0 {
+		amount = defaultUnsharpAmount
+	}
+	if radius < 0 || amount < 0 {
+		return nil, fmt.Errorf("radius and amount must be greater than or equal to 0")
+	}
+
+	img, err := decodeBase64Image(string(inputStruct.Image))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding image: %w", err)
+	}
+
+	var filtered *image.RGBA
+	switch inputStruct.Filter {
+	case "gaussian-blur":
+		if radius > maxBlurRadius {
+			return nil, fmt.Errorf("radius can't be larger than %d", maxBlurRadius)
+		}
+		filtered = gaussianBlur(toOriginRGBA(img), radius)
+	case "unsharp-mask":
+		if radius > maxBlurRadius {
+			return nil, fmt.Errorf("radius can't be larger than %d", maxBlurRadius)
+		}
+		filtered = unsharpMask(toOriginRGBA(img), radius, amount)
+	case "median":
+		if radius > maxMedianRadius {
+			return nil, fmt.Errorf("radius can't be larger than %d", maxMedianRadius)
+		}
+		filtered = medianFilter(toOriginRGBA(img), int(math.Round(radius)))
+	default:
+		return nil, fmt.Errorf("unsupported filter: %s", inputStruct.Filter)
+	}
+
+	outputImg, err := encodeImageDataURI(filtered)
+	if err != nil {
+		return nil, err
+	}
+	return base.ConvertToStructpb(filterOutput{Image: outputImg})
+}
+
+// toOriginRGBA converts the image to RGBA, moving it to the origin.
+func toOriginRGBA(img image.Image) *image.RGBA {
+	rgba := convertToRGBA(img)
+	rgba.Rect = rgba.Rect.Sub(rgba.Rect.Min)
+	return rgba
+}
+
+// gaussianKernel returns the normalized weights of a 1D gaussian of a
+// standard deviation, from its center to the edge at 3 deviations.
+func gaussianKernel(sigma float64) []float64 {
+	size := int(math.Ceil(3 * sigma))
+	kernel := make([]float64, size+1)
+	var sum float64
+	for i := range kernel {
+		kernel[i] = math.Exp(-float64(i*i) / (2 * sigma * sigma))
+		sum += kernel[i]
+		if i > 0 {
+			sum += kernel[i]
+		}
+	}
+	for i := range kernel {
+		kernel[i] /= sum
+	}
+	return kernel
+}
+
+// gaussianBlur blurs the image with a gaussian of a standard deviation, in
+// pixels. The gaussian is separable, so the image is blurred horizontally
+// and then vertically. The edges are extended.
+func gaussianBlur(img *image.RGBA, sigma float64) *image.RGBA {
+	kernel := gaussianKernel(sigma)
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+
+	pass := func(src *image.RGBA, dx, dy int) *image.RGBA {
+		dst := image.NewRGBA(src.Rect)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				var acc [4]float64
+				for k := -len(kernel) + 1; k < len(kernel); k++ {
+					sx := min(max(x+k*dx, 0), w-1)
+					sy := min(max(y+k*dy, 0), h-1)
+					weight := kernel[abs(k)]
+					i := src.PixOffset(sx, sy)
+					for c := range acc {
+						acc[c] += weight * float64(src.Pix[i+c])
+					}
+				}
+				i := dst.PixOffset(x, y)
+				for c := range acc {
+					dst.Pix[i+c] = clampUint8(acc[c])
+				}
+			}
+		}
+		return dst
+	}
+
+	return pass(pass(img, 1, 0), 0, 1)
+}
+
+// unsharpMask sharpens the image by adding to it the difference with its
+// blurred version, scaled by an amount. The alpha channel is kept.
+func unsharpMask(img *image.RGBA, sigma, amount float64) *image.RGBA {
+	blurred := gaussianBlur(img, sigma)
+	sharpened := image.NewRGBA(img.Rect)
+	for i := 0; i < len(img.Pix); i += 4 {
+		alpha := img.Pix[i+3]
+		for c := 0; c < 3; c++ {
+			orig := float64(img.Pix[i+c])
+			v := clampUint8(orig + amount*(orig-float64(blurred.Pix[i+c])))
+			// The colors are premultiplied by the alpha, which they can't
+			// exceed.
+			sharpened.Pix[i+c] = min(v, alpha)
+		}
+		sharpened.Pix[i+3] = alpha
+	}
+	return sharpened
+}
+
+// medianFilter replaces each channel of each pixel by its median in the
+// square window of a radius around it, which removes the salt-and-pepper
+// noise of the scans while keeping the edges of the text.
+func medianFilter(img *image.RGBA, radius int) *image.RGBA {
+	if radius == 0 {
+		return img
+	}
+
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	filtered := image.NewRGBA(img.Rect)
+	window := make([]uint8, 0, (2*radius+1)*(2*radius+1))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			o := filtered.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				window = window[:0]
+				for wy := max(y-radius, 0); wy <= min(y+radius, h-1); wy++ {
+					for wx := max(x-radius, 0); wx <= min(x+radius, w-1); wx++ {
+						window = append(window, img.Pix[img.PixOffset(wx, wy)+c])
+					}
+				}
+				slices.Sort(window)
+				filtered.Pix[o+c] = window[len(window)/2]
+			}
+		}
+	}
+	return filtered
+}
+
+func clampUint8(v float64) uint8 {
+	return uint8(math.Round(min(max(v, 0), 255)))
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
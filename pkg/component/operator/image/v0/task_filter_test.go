@@ -0,0 +1,111 @@
+package image
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+// edgeImage returns a 10x10 image whose left half is black and right half is
+// white.
+func edgeImage() *image.RGBA {
+	img := solidImage(10, 10, white)
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 5; x++ {
+			img.SetRGBA(x, y, color.RGBA{A: 255})
+		}
+	}
+	return img
+}
+
+func gray(img image.Image, x, y int) uint8 {
+	return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y
+}
+
+func TestFilter(t *testing.T) {
+	c := quicktest.New(t)
+
+	runFilter := func(c *quicktest.C, img image.Image, input filterInput) (image.Image, error) {
+		base64Img, err := encodeBase64Image(img)
+		c.Assert(err, quicktest.IsNil)
+		input.Image = base64Image("data:image/png;base64," + base64Img)
+
+		inputStruct, err := base.ConvertToStructpb(input)
+		c.Assert(err, quicktest.IsNil)
+		output, err := filter(inputStruct, nil, context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		var out filterOutput
+		c.Assert(base.ConvertFromStructpb(output, &out), quicktest.IsNil)
+		filtered, err := decodeBase64Image(string(out.Image))
+		c.Assert(err, quicktest.IsNil)
+		c.Check(filtered.Bounds(), quicktest.Equals, img.Bounds())
+		return filtered, nil
+	}
+
+	c.Run("gaussian blur", func(c *quicktest.C) {
+		blurred, err := runFilter(c, edgeImage(), filterInput{Filter: "gaussian-blur", Radius: 1.5})
+		c.Assert(err, quicktest.IsNil)
+
+		// The edge is smoothed, while the far sides keep their colors.
+		c.Check(gray(blurred, 4, 5) > 0, quicktest.IsTrue)
+		c.Check(gray(blurred, 5, 5) < 255, quicktest.IsTrue)
+		c.Check(gray(blurred, 0, 5), quicktest.Equals, uint8(0))
+		c.Check(gray(blurred, 9, 5), quicktest.Equals, uint8(255))
+	})
+
+	c.Run("unsharp mask", func(c *quicktest.C) {
+		img := solidImage(10, 10, color.RGBA{R: 100, G: 100, B: 100, A: 255})
+		for y := 0; y < 10; y++ {
+			for x := 5; x < 10; x++ {
+				img.SetRGBA(x, y, color.RGBA{R: 150, G: 150, B: 150, A: 255})
+			}
+		}
+		sharpened, err := runFilter(c, img, filterInput{Filter: "unsharp-mask", Radius: 1, Amount: 2})
+		c.Assert(err, quicktest.IsNil)
+
+		// The contrast of the edge is increased.
+		c.Check(gray(sharpened, 4, 5) < 100, quicktest.IsTrue)
+		c.Check(gray(sharpened, 5, 5) > 150, quicktest.IsTrue)
+		c.Check(gray(sharpened, 0, 5), quicktest.Equals, uint8(100))
+		c.Check(gray(sharpened, 9, 5), quicktest.Equals, uint8(150))
+	})
+
+	c.Run("median", func(c *quicktest.C) {
+		// Salt-and-pepper noise on each side of the edge.
+		img := edgeImage()
+		img.SetRGBA(1, 1, white)
+		img.SetRGBA(8, 8, color.RGBA{A: 255})
+
+		denoised, err := runFilter(c, img, filterInput{Filter: "median"})
+		c.Assert(err, quicktest.IsNil)
+
+		c.Check(gray(denoised, 1, 1), quicktest.Equals, uint8(0))
+		c.Check(gray(denoised, 8, 8), quicktest.Equals, uint8(255))
+		// The edge is kept.
+		c.Check(gray(denoised, 4, 5), quicktest.Equals, uint8(0))
+		c.Check(gray(denoised, 5, 5), quicktest.Equals, uint8(255))
+	})
+
+	c.Run("nok - unsupported filter", func(c *quicktest.C) {
+		_, err := runFilter(c, edgeImage(), filterInput{Filter: "emboss"})
+		c.Check(err, quicktest.ErrorMatches, "unsupported filter: emboss")
+	})
+
+	c.Run("nok - radius too large", func(c *quicktest.C) {
+		_, err := runFilter(c, edgeImage(), filterInput{Filter: "median", Radius: 11})
+		c.Check(err, quicktest.ErrorMatches, "radius can't be larger than 10")
+	})
+
+	c.Run("nok - negative radius", func(c *quicktest.C) {
+		_, err := runFilter(c, edgeImage(), filterInput{Filter: "gaussian-blur", Radius: -1})
+		c.Check(err, quicktest.ErrorMatches, "radius and amount must be greater than or equal to 0")
+	})
+}
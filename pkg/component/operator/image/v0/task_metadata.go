@@ -0,0 +1,111 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+type metadataInput struct {
+	Image base64Image `json:"image"`
+	Strip bool        `json:"strip"`
+}
+
+type gpsPosition struct {
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Altitude  *float64 `json:"altitude,omitempty"`
+}
+
+type metadataOutput struct {
+	Width       int          `json:"width"`
+	Height      int          `json:"height"`
+	Orientation int          `json:"orientation"`
+	EXIF        exifTags     `json:"exif"`
+	GPS         *gpsPosition `json:"gps,omitempty"`
+	Image       base64Image  `json:"image,omitempty"`
+}
+
+// metadata reads the EXIF metadata of a JPEG or PNG image. The width and
+// height are those of the image as it's displayed, i.e. after applying its
+// orientation. When the metadata is stripped, the image is re-encoded
+// without it, with its orientation applied to the pixels.
+func metadata(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	var inputStruct metadataInput
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("error converting input to struct: %w", err)
+	}
+
+	bin, err := data.DecodeBinary(string(inputStruct.Image))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding base64 image: %w", err)
+	}
+
+	tags := exifTags{}
+	if tiff := findEXIF(bin.Raw); tiff != nil {
+		if tags, err = parseEXIF(tiff); err != nil {
+			return nil, fmt.Errorf("error reading EXIF metadata: %w", err)
+		}
+	}
+
+	output := metadataOutput{
+		Orientation: tags.orientation(),
+		EXIF:        tags,
+	}
+	if lat, lng, alt, ok := tags.coordinates(); ok {
+		output.GPS = &gpsPosition{Latitude: lat, Longitude: lng, Altitude: alt}
+	}
+
+	if inputStruct.Strip {
+		img, _, err := image.Decode(bytes.NewReader(bin.Raw))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding image: %w", err)
+		}
+		oriented := applyOrientation(img, output.Orientation)
+		if output.Image, err = encodeImageDataURI(oriented); err != nil {
+			return nil, err
+		}
+		output.Width, output.Height = oriented.Rect.Dx(), oriented.Rect.Dy()
+	} else {
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(bin.Raw))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding image: %w", err)
+		}
+		output.Width, output.Height = cfg.Width, cfg.Height
+		// The orientations from 5 to 8 swap the sides.
+		if output.Orientation >= 5 {
+			output.Width, output.Height = cfg.Height, cfg.Width
+		}
+	}
+
+	return base.ConvertToStructpb(output)
+}
+
+// applyOrientation transforms the image so that it's displayed upright, as
+// described by an EXIF orientation.
+func applyOrientation(img image.Image, orientation int) *image.RGBA {
+	rgba := toOriginRGBA(img)
+	switch orientation {
+	case 2:
+		return flipImage(rgba, true, false)
+	case 3:
+		return rotateQuarterTurns(rgba, 2)
+	case 4:
+		return flipImage(rgba, false, true)
+	case 5:
+		return flipImage(rotateQuarterTurns(rgba, 1), true, false)
+	case 6:
+		return rotateQuarterTurns(rgba, 1)
+	case 7:
+		return flipImage(rotateQuarterTurns(rgba, 1), false, true)
+	case 8:
+		return rotateQuarterTurns(rgba, 3)
+	}
+	return rgba
+}
@@ -0,0 +1,241 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+type testIFDEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+// buildIFD lays out an IFD at an offset of the TIFF structure, followed by
+// the values that don't fit in its entries.
+func buildIFD(bo binary.ByteOrder, offset int, entries []testIFDEntry) []byte {
+	size := 2 + 12*len(entries) + 4
+	ifd := make([]byte, size)
+	var values []byte
+
+	bo.PutUint16(ifd, uint16(len(entries)))
+	for i, e := range entries {
+		b := ifd[2+12*i:]
+		bo.PutUint16(b, e.tag)
+		bo.PutUint16(b[2:], e.typ)
+		bo.PutUint32(b[4:], e.count)
+		if len(e.value) <= 4 {
+			copy(b[8:12], e.value)
+			continue
+		}
+		bo.PutUint32(b[8:], uint32(offset+size+len(values)))
+		values = append(values, e.value...)
+	}
+	return append(ifd, values...)
+}
+
+func asciiEntry(tag uint16, s string) testIFDEntry {
+	return testIFDEntry{tag: tag, typ: 2, count: uint32(len(s) + 1), value: append([]byte(s), 0)}
+}
+
+func shortEntry(bo binary.ByteOrder, tag, v uint16) testIFDEntry {
+	b := make([]byte, 2)
+	bo.PutUint16(b, v)
+	return testIFDEntry{tag: tag, typ: 3, count: 1, value: b}
+}
+
+func rationalEntry(bo binary.ByteOrder, tag uint16, fractions ...[2]uint32) testIFDEntry {
+	b := make([]byte, 8*len(fractions))
+	for i, f := range fractions {
+		bo.PutUint32(b[8*i:], f[0])
+		bo.PutUint32(b[8*i+4:], f[1])
+	}
+	return testIFDEntry{tag: tag, typ: 5, count: uint32(len(fractions)), value: b}
+}
+
+// testEXIF builds a TIFF structure with a camera make, an orientation and,
+// optionally, the GPS position of Sydney.
+func testEXIF(bo binary.ByteOrder, orientation uint16, withGPS bool) []byte {
+	tiff := make([]byte, 8)
+	if bo == binary.ByteOrder(binary.LittleEndian) {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	bo.PutUint16(tiff[2:], 42)
+	bo.PutUint32(tiff[4:], 8)
+
+	entries := []testIFDEntry{
+		asciiEntry(0x010f, "Instill"),
+		shortEntry(bo, 0x0112, orientation),
+		// Unknown tags are ignored.
+		asciiEntry(0xc4a5, "ignored"),
+	}
+	if !withGPS {
+		return append(tiff, buildIFD(bo, 8, entries)...)
+	}
+
+	// The IFD0 size doesn't depend on the value of the GPS pointer.
+	pointer := testIFDEntry{tag: tagGPSIFD, typ: 4, count: 1, value: make([]byte, 4)}
+	gpsOffset := 8 + len(buildIFD(bo, 8, append(entries, pointer)))
+	bo.PutUint32(pointer.value, uint32(gpsOffset))
+	tiff = append(tiff, buildIFD(bo, 8, append(entries, pointer))...)
+
+	return append(tiff, buildIFD(bo, gpsOffset, []testIFDEntry{
+		asciiEntry(0x0001, "S"),
+		rationalEntry(bo, 0x0002, [2]uint32{33, 1}, [2]uint32{51, 1}, [2]uint32{3600, 100}),
+		asciiEntry(0x0003, "E"),
+		rationalEntry(bo, 0x0004, [2]uint32{151, 1}, [2]uint32{12, 1}, [2]uint32{36, 1}),
+		{tag: 0x0005, typ: 1, count: 1, value: []byte{0}},
+		rationalEntry(bo, 0x0006, [2]uint32{58, 1}),
+	})...)
+}
+
+// withJPEGEXIF inserts an APP1 segment with the EXIF payload after the start
+// of the JPEG image.
+func withJPEGEXIF(jpg, tiff []byte) []byte {
+	segment := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(2+len(exifHeader)+len(tiff)))
+	segment = append(append(segment, exifHeader...), tiff...)
+
+	out := append([]byte{}, jpg[:2]...)
+	out = append(out, segment...)
+	return append(out, jpg[2:]...)
+}
+
+// withPNGEXIF inserts an eXIf chunk after the IHDR chunk of the PNG image.
+func withPNGEXIF(pngBytes, tiff []byte) []byte {
+	const ihdrEnd = 8 + 8 + 13 + 4
+	chunk := make([]byte, 4, 12+len(tiff))
+	binary.BigEndian.PutUint32(chunk, uint32(len(tiff)))
+	chunk = append(append(chunk, "eXIf"...), tiff...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	out := append([]byte{}, pngBytes[:ihdrEnd]...)
+	out = append(out, chunk...)
+	return append(out, pngBytes[ihdrEnd:]...)
+}
+
+func TestMetadata(t *testing.T) {
+	c := quicktest.New(t)
+
+	// The left half of the image is red and the right half green.
+	halves := solidImage(16, 8, red)
+	for y := 0; y < 8; y++ {
+		for x := 8; x < 16; x++ {
+			halves.SetRGBA(x, y, green)
+		}
+	}
+	jpgBuf := new(bytes.Buffer)
+	c.Assert(jpeg.Encode(jpgBuf, halves, &jpeg.Options{Quality: 100}), quicktest.IsNil)
+	pngBuf := new(bytes.Buffer)
+	c.Assert(png.Encode(pngBuf, halves), quicktest.IsNil)
+
+	runMetadata := func(c *quicktest.C, raw []byte, mimeType string, strip bool) (metadataOutput, error) {
+		inputStruct, err := base.ConvertToStructpb(metadataInput{
+			Image: base64Image("data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(raw)),
+			Strip: strip,
+		})
+		c.Assert(err, quicktest.IsNil)
+
+		var out metadataOutput
+		output, err := metadata(inputStruct, nil, context.Background())
+		if err != nil {
+			return out, err
+		}
+		c.Assert(base.ConvertFromStructpb(output, &out), quicktest.IsNil)
+		return out, nil
+	}
+
+	c.Run("JPEG with GPS position", func(c *quicktest.C) {
+		raw := withJPEGEXIF(jpgBuf.Bytes(), testEXIF(binary.BigEndian, 6, true))
+		out, err := runMetadata(c, raw, "image/jpeg", false)
+		c.Assert(err, quicktest.IsNil)
+
+		// The sides are swapped by the orientation.
+		c.Check(out.Width, quicktest.Equals, 8)
+		c.Check(out.Height, quicktest.Equals, 16)
+		c.Check(out.Orientation, quicktest.Equals, 6)
+		c.Check(out.EXIF["Make"], quicktest.Equals, "Instill")
+		c.Check(out.EXIF["Orientation"], quicktest.Equals, float64(6))
+		c.Check(out.EXIF["GPSLatitudeRef"], quicktest.Equals, "S")
+		c.Check(out.EXIF, quicktest.HasLen, 8)
+		c.Check(out.Image, quicktest.Equals, base64Image(""))
+
+		c.Assert(out.GPS, quicktest.IsNotNil)
+		c.Check(math.Abs(out.GPS.Latitude+33.86) < 1e-9, quicktest.IsTrue, quicktest.Commentf("latitude %f", out.GPS.Latitude))
+		c.Check(math.Abs(out.GPS.Longitude-151.21) < 1e-9, quicktest.IsTrue, quicktest.Commentf("longitude %f", out.GPS.Longitude))
+		c.Assert(out.GPS.Altitude, quicktest.IsNotNil)
+		c.Check(*out.GPS.Altitude, quicktest.Equals, 58.0)
+	})
+
+	c.Run("strip JPEG", func(c *quicktest.C) {
+		raw := withJPEGEXIF(jpgBuf.Bytes(), testEXIF(binary.BigEndian, 6, true))
+		out, err := runMetadata(c, raw, "image/jpeg", true)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(out.Width, quicktest.Equals, 8)
+		c.Check(out.Height, quicktest.Equals, 16)
+
+		stripped, err := decodeBase64Image(string(out.Image))
+		c.Assert(err, quicktest.IsNil)
+		c.Check(stripped.Bounds().Size(), quicktest.Equals, image.Pt(8, 16))
+
+		// The image is rotated clockwise, so the red half is on top.
+		top := color.RGBAModel.Convert(stripped.At(4, 3)).(color.RGBA)
+		bottom := color.RGBAModel.Convert(stripped.At(4, 12)).(color.RGBA)
+		c.Check(top.R > 200 && top.G < 50, quicktest.IsTrue, quicktest.Commentf("top %v", top))
+		c.Check(bottom.G > 200 && bottom.R < 50, quicktest.IsTrue, quicktest.Commentf("bottom %v", bottom))
+
+		// The output image has no metadata left.
+		bin, err := data.DecodeBinary(string(out.Image))
+		c.Assert(err, quicktest.IsNil)
+		c.Check(findEXIF(bin.Raw), quicktest.IsNil)
+	})
+
+	c.Run("strip PNG", func(c *quicktest.C) {
+		raw := withPNGEXIF(pngBuf.Bytes(), testEXIF(binary.LittleEndian, 3, false))
+		out, err := runMetadata(c, raw, "image/png", true)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(out.Orientation, quicktest.Equals, 3)
+		c.Check(out.EXIF["Make"], quicktest.Equals, "Instill")
+		c.Check(out.GPS, quicktest.IsNil)
+
+		// The image is rotated by 180°.
+		stripped, err := decodeBase64Image(string(out.Image))
+		c.Assert(err, quicktest.IsNil)
+		c.Check(stripped.Bounds().Size(), quicktest.Equals, image.Pt(16, 8))
+		c.Check(color.RGBAModel.Convert(stripped.At(0, 0)), quicktest.Equals, green)
+		c.Check(color.RGBAModel.Convert(stripped.At(15, 7)), quicktest.Equals, red)
+	})
+
+	c.Run("no metadata", func(c *quicktest.C) {
+		out, err := runMetadata(c, pngBuf.Bytes(), "image/png", false)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(out.Width, quicktest.Equals, 16)
+		c.Check(out.Height, quicktest.Equals, 8)
+		c.Check(out.Orientation, quicktest.Equals, 1)
+		c.Check(out.EXIF, quicktest.HasLen, 0)
+		c.Check(out.GPS, quicktest.IsNil)
+	})
+
+	c.Run("nok - invalid metadata", func(c *quicktest.C) {
+		raw := withJPEGEXIF(jpgBuf.Bytes(), []byte("not a TIFF structure"))
+		_, err := runMetadata(c, raw, "image/jpeg", false)
+		c.Check(err, quicktest.ErrorMatches, "error reading EXIF metadata: invalid TIFF byte order")
+	})
+}
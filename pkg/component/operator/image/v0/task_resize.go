@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"image"
+	"math"
 
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -13,10 +14,20 @@ import (
 )
 
 type resizeInput struct {
-	Image  base64Image `json:"image"`
-	Width  int         `json:"width"`
-	Height int         `json:"height"`
-	Ratio  float64     `json:"ratio"`
+	Image         base64Image `json:"image"`
+	Width         int         `json:"width"`
+	Height        int         `json:"height"`
+	Ratio         float64     `json:"ratio"`
+	Percentage    float64     `json:"percentage"`
+	LongestSide   int         `json:"longest-side"`
+	Interpolation string      `json:"interpolation"`
+}
+
+// interpolations are the interpolation methods of the resize task.
+var interpolations = map[string]nr.InterpolationFunction{
+	"nearest":  nr.NearestNeighbor,
+	"bilinear": nr.Bilinear,
+	"lanczos":  nr.Lanczos3,
 }
 
 type resizeOutput struct {
@@ -48,7 +59,11 @@ func resize(input *structpb.Struct, job *base.Job, ctx context.Context) (*struct
 	}
 
 	// Resize the image
-	resizedImg := nr.Resize(uint(width), uint(height), img, nr.Lanczos3)
+	interpolation := nr.Lanczos3
+	if inputStruct.Interpolation != "" {
+		interpolation = interpolations[inputStruct.Interpolation]
+	}
+	resizedImg := nr.Resize(uint(width), uint(height), img, interpolation)
 	return createOutput(resizedImg)
 }
 
@@ -59,6 +74,15 @@ func validateInputParams(input resizeInput) error {
 	if input.Width < 0 || input.Height < 0 {
 		return fmt.Errorf("width and height must be greater than or equal to 0")
 	}
+	if input.Percentage < 0 {
+		return fmt.Errorf("percentage must be greater than or equal to 0")
+	}
+	if input.LongestSide < 0 {
+		return fmt.Errorf("longest side must be greater than or equal to 0")
+	}
+	if _, ok := interpolations[input.Interpolation]; input.Interpolation != "" && !ok {
+		return fmt.Errorf("unsupported interpolation: %s", input.Interpolation)
+	}
 	return nil
 }
 
@@ -67,10 +91,19 @@ func calculateNewDimensions(input resizeInput, bounds image.Rectangle) (int, int
 		return int(float64(bounds.Dx()) * input.Ratio),
 			int(float64(bounds.Dy()) * input.Ratio)
 	}
+	if input.Percentage > 0 {
+		// A side can't be shrunk below a pixel.
+		return max(int(math.Round(float64(bounds.Dx())*input.Percentage/100)), 1),
+			max(int(math.Round(float64(bounds.Dy())*input.Percentage/100)), 1)
+	}
 
 	aspectRatio := float64(bounds.Dx()) / float64(bounds.Dy())
 
 	switch {
+	case input.LongestSide > 0 && bounds.Dx() >= bounds.Dy():
+		return input.LongestSide, max(int(math.Round(float64(input.LongestSide)/aspectRatio)), 1)
+	case input.LongestSide > 0:
+		return max(int(math.Round(float64(input.LongestSide)*aspectRatio)), 1), input.LongestSide
 	case input.Width > 0 && input.Height > 0:
 		return input.Width, input.Height
 	case input.Width > 0:
@@ -83,12 +116,12 @@ func calculateNewDimensions(input resizeInput, bounds image.Rectangle) (int, int
 }
 
 func createOutput(img image.Image) (*structpb.Struct, error) {
-	base64Img, err := encodeBase64Image(img)
+	outputImg, err := encodeImageDataURI(img)
 	if err != nil {
 		return nil, err
 	}
 	output := resizeOutput{
-		Image: base64Image(fmt.Sprintf("data:image/png;base64,%s", base64Img)),
+		Image: outputImg,
 	}
 	return base.ConvertToStructpb(output)
 }
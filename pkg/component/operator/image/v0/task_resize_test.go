@@ -3,6 +3,7 @@ package image
 import (
 	"context"
 	"image"
+	"image/color"
 	"testing"
 
 	"github.com/frankban/quicktest"
@@ -73,6 +74,70 @@ func TestResize(t *testing.T) {
 			expectedWidth:  100,
 			expectedHeight: 100,
 		},
+		{
+			name: "Resize by percentage",
+			input: resizeInput{
+				Image:      base64Image("data:image/png;base64," + base64Img),
+				Percentage: 50,
+			},
+			expectedWidth:  50,
+			expectedHeight: 50,
+		},
+		{
+			name: "Enlarge by percentage",
+			input: resizeInput{
+				Image:      base64Image("data:image/png;base64," + base64Img),
+				Percentage: 200,
+				Width:      10,
+			},
+			expectedWidth:  200,
+			expectedHeight: 200,
+		},
+		{
+			name: "Resize by longest side",
+			input: resizeInput{
+				Image:       base64Image("data:image/png;base64," + base64Img),
+				LongestSide: 30,
+				Height:      10,
+			},
+			expectedWidth:  30,
+			expectedHeight: 30,
+		},
+		{
+			name: "Resize with bilinear interpolation",
+			input: resizeInput{
+				Image:         base64Image("data:image/png;base64," + base64Img),
+				Width:         40,
+				Interpolation: "bilinear",
+			},
+			expectedWidth:  40,
+			expectedHeight: 40,
+		},
+		{
+			name: "Negative percentage",
+			input: resizeInput{
+				Image:      base64Image("data:image/png;base64," + base64Img),
+				Percentage: -50,
+			},
+			expectedError: "percentage must be greater than or equal to 0",
+		},
+		{
+			name: "Negative longest side",
+			input: resizeInput{
+				Image:       base64Image("data:image/png;base64," + base64Img),
+				LongestSide: -50,
+			},
+			expectedError: "longest side must be greater than or equal to 0",
+		},
+		{
+			name: "Unsupported interpolation",
+			input: resizeInput{
+				Image:         base64Image("data:image/png;base64," + base64Img),
+				Width:         40,
+				Interpolation: "bicubic",
+			},
+			expectedError: "unsupported interpolation: bicubic",
+		},
 		{
 			name: "Negative ratio",
 			input: resizeInput{
@@ -128,3 +193,66 @@ func TestResize(t *testing.T) {
 		})
 	}
 }
+
+func TestResizeLongestSide(t *testing.T) {
+	c := quicktest.New(t)
+
+	testCases := []struct {
+		name           string
+		width, height  int
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{name: "landscape", width: 200, height: 100, expectedWidth: 50, expectedHeight: 25},
+		{name: "portrait", width: 100, height: 200, expectedWidth: 25, expectedHeight: 50},
+		{name: "thin", width: 1000, height: 10, expectedWidth: 50, expectedHeight: 1},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			width, height := calculateNewDimensions(resizeInput{LongestSide: 50}, image.Rect(0, 0, tc.width, tc.height))
+			c.Check(width, quicktest.Equals, tc.expectedWidth)
+			c.Check(height, quicktest.Equals, tc.expectedHeight)
+		})
+	}
+}
+
+func TestResizeNearest(t *testing.T) {
+	c := quicktest.New(t)
+
+	// The nearest neighbor interpolation doesn't blend the colors, so a
+	// two-color image keeps exactly its two colors.
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	img.Set(1, 0, color.RGBA{B: 255, A: 255})
+	base64Img, err := encodeBase64Image(img)
+	c.Assert(err, quicktest.IsNil)
+
+	inputStruct, err := base.ConvertToStructpb(resizeInput{
+		Image:         base64Image("data:image/png;base64," + base64Img),
+		Width:         8,
+		Height:        4,
+		Interpolation: "nearest",
+	})
+	c.Assert(err, quicktest.IsNil)
+
+	output, err := resize(inputStruct, nil, context.Background())
+	c.Assert(err, quicktest.IsNil)
+
+	var resizedOutput resizeOutput
+	c.Assert(base.ConvertFromStructpb(output, &resizedOutput), quicktest.IsNil)
+	resized, err := decodeBase64Image(string(resizedOutput.Image))
+	c.Assert(err, quicktest.IsNil)
+
+	bounds := resized.Bounds()
+	c.Assert(bounds.Dx(), quicktest.Equals, 8)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			want := color.RGBA{R: 255, A: 255}
+			if x-bounds.Min.X >= 4 {
+				want = color.RGBA{B: 255, A: 255}
+			}
+			c.Check(color.RGBAModel.Convert(resized.At(x, y)), quicktest.Equals, want)
+		}
+	}
+}
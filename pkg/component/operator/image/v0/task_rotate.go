@@ -0,0 +1,173 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+type rotateInput struct {
+	Image           base64Image `json:"image"`
+	Angle           float64     `json:"angle"`
+	Flip            string      `json:"flip"`
+	BackgroundColor string      `json:"background-color"`
+	KeepSize        bool        `json:"keep-size"`
+}
+
+type rotateOutput struct {
+	Image base64Image `json:"image"`
+}
+
+// rotate flips the image and then rotates it clockwise by an angle in
+// degrees. The multiples of 90° move the pixels without interpolating them.
+// The rest of the angles expand the canvas to fit the rotated image, unless
+// its size is kept, and fill the uncovered area with the background color.
+func rotate(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	var inputStruct rotateInput
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("error converting input to struct: %w", err)
+	}
+
+	var flipH, flipV bool
+	switch inputStruct.Flip {
+	case "", "none":
+	case "horizontal":
+		flipH = true
+	case "vertical":
+		flipV = true
+	case "both":
+		flipH, flipV = true, true
+	default:
+		return nil, fmt.Errorf("unsupported flip: %s", inputStruct.Flip)
+	}
+
+	background := color.RGBA{}
+	if inputStruct.BackgroundColor != "" {
+		var err error
+		if background, err = parseHexColor(inputStruct.BackgroundColor); err != nil {
+			return nil, fmt.Errorf("invalid background color: %w", err)
+		}
+	}
+
+	img, err := decodeBase64Image(string(inputStruct.Image))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding image: %w", err)
+	}
+
+	rotated := flipImage(img, flipH, flipV)
+
+	angle := math.Mod(inputStruct.Angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	switch {
+	case angle == 0:
+	case angle == 180:
+		rotated = rotateQuarterTurns(rotated, 2)
+	case (angle == 90 || angle == 270) && (!inputStruct.KeepSize || isSquare(rotated)):
+		rotated = rotateQuarterTurns(rotated, int(angle/90))
+	default:
+		rotated = rotateAngle(rotated, angle, background, inputStruct.KeepSize)
+	}
+
+	outputImg, err := encodeImageDataURI(rotated)
+	if err != nil {
+		return nil, err
+	}
+	return base.ConvertToStructpb(rotateOutput{Image: outputImg})
+}
+
+func isSquare(img image.Image) bool {
+	return img.Bounds().Dx() == img.Bounds().Dy()
+}
+
+// flipImage mirrors the image horizontally and/or vertically. The returned
+// image starts at the origin.
+func flipImage(img image.Image, horizontal, vertical bool) *image.RGBA {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	flipped := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sx, sy := x, y
+			if horizontal {
+				sx = w - 1 - x
+			}
+			if vertical {
+				sy = h - 1 - y
+			}
+			flipped.Set(x, y, img.At(bounds.Min.X+sx, bounds.Min.Y+sy))
+		}
+	}
+	return flipped
+}
+
+// rotateQuarterTurns rotates the image clockwise by a number of quarter
+// turns.
+func rotateQuarterTurns(img *image.RGBA, turns int) *image.RGBA {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	turns = ((turns % 4) + 4) % 4
+	size := image.Rect(0, 0, w, h)
+	if turns%2 == 1 {
+		size = image.Rect(0, 0, h, w)
+	}
+
+	rotated := image.NewRGBA(size)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := img.RGBAAt(bounds.Min.X+x, bounds.Min.Y+y)
+			switch turns {
+			case 0:
+				rotated.SetRGBA(x, y, c)
+			case 1:
+				rotated.SetRGBA(h-1-y, x, c)
+			case 2:
+				rotated.SetRGBA(w-1-x, h-1-y, c)
+			case 3:
+				rotated.SetRGBA(y, w-1-x, c)
+			}
+		}
+	}
+	return rotated
+}
+
+// rotateAngle rotates the image clockwise around its center by an angle in
+// degrees, with bilinear interpolation.
+func rotateAngle(img *image.RGBA, angle float64, background color.RGBA, keepSize bool) *image.RGBA {
+	bounds := img.Bounds()
+	w, h := float64(bounds.Dx()), float64(bounds.Dy())
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+
+	dw, dh := w, h
+	if !keepSize {
+		// The bounding box of the rotated image. The rounding absorbs the
+		// floating point error of the exact angles, e.g. 45°.
+		dw = math.Ceil(math.Round((math.Abs(w*cos)+math.Abs(h*sin))*1e6) / 1e6)
+		dh = math.Ceil(math.Round((math.Abs(w*sin)+math.Abs(h*cos))*1e6) / 1e6)
+	}
+
+	rotated := image.NewRGBA(image.Rect(0, 0, int(dw), int(dh)))
+	draw.Draw(rotated, rotated.Bounds(), &image.Uniform{C: background}, image.Point{}, draw.Src)
+
+	// The source-to-destination transform moves the center of the source to
+	// the origin, rotates it and moves it to the center of the destination.
+	// With the y axis pointing down, this rotation is clockwise.
+	cx, cy := float64(bounds.Min.X)+w/2, float64(bounds.Min.Y)+h/2
+	dcx, dcy := dw/2, dh/2
+	s2d := f64.Aff3{
+		cos, -sin, dcx - cos*cx + sin*cy,
+		sin, cos, dcy - sin*cx - cos*cy,
+	}
+	draw.BiLinear.Transform(rotated, s2d, img, bounds, draw.Over, nil)
+	return rotated
+}
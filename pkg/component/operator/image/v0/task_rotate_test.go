@@ -0,0 +1,189 @@
+package image
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+var (
+	red   = color.RGBA{R: 255, A: 255}
+	green = color.RGBA{G: 255, A: 255}
+	blue  = color.RGBA{B: 255, A: 255}
+	white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// quadrantImage returns a 4x2 image whose left half is red and right half is
+// green, except for its bottom-right pixel, which is blue.
+func quadrantImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				img.SetRGBA(x, y, red)
+			} else {
+				img.SetRGBA(x, y, green)
+			}
+		}
+	}
+	img.SetRGBA(3, 1, blue)
+	return img
+}
+
+func TestRotate(t *testing.T) {
+	c := quicktest.New(t)
+
+	base64Img, err := encodeBase64Image(quadrantImage())
+	c.Assert(err, quicktest.IsNil)
+
+	testCases := []struct {
+		name           string
+		input          rotateInput
+		expectedWidth  int
+		expectedHeight int
+		// expectedPixels are checked in the output image.
+		expectedPixels map[image.Point]color.RGBA
+		expectedError  string
+	}{
+		{
+			name:           "no rotation",
+			input:          rotateInput{},
+			expectedWidth:  4,
+			expectedHeight: 2,
+			expectedPixels: map[image.Point]color.RGBA{{0, 0}: red, {3, 1}: blue},
+		},
+		{
+			name:           "rotate 90",
+			input:          rotateInput{Angle: 90},
+			expectedWidth:  2,
+			expectedHeight: 4,
+			expectedPixels: map[image.Point]color.RGBA{{1, 0}: red, {0, 3}: blue, {1, 3}: green},
+		},
+		{
+			name:           "rotate -90",
+			input:          rotateInput{Angle: -90},
+			expectedWidth:  2,
+			expectedHeight: 4,
+			expectedPixels: map[image.Point]color.RGBA{{1, 0}: blue, {0, 3}: red},
+		},
+		{
+			name:           "rotate 180",
+			input:          rotateInput{Angle: 540},
+			expectedWidth:  4,
+			expectedHeight: 2,
+			expectedPixels: map[image.Point]color.RGBA{{0, 0}: blue, {3, 1}: red},
+		},
+		{
+			name:           "flip horizontal",
+			input:          rotateInput{Flip: "horizontal"},
+			expectedWidth:  4,
+			expectedHeight: 2,
+			expectedPixels: map[image.Point]color.RGBA{{0, 1}: blue, {3, 0}: red},
+		},
+		{
+			name:           "flip vertical",
+			input:          rotateInput{Flip: "vertical"},
+			expectedWidth:  4,
+			expectedHeight: 2,
+			expectedPixels: map[image.Point]color.RGBA{{3, 0}: blue, {0, 1}: red},
+		},
+		{
+			name:           "flip both",
+			input:          rotateInput{Flip: "both"},
+			expectedWidth:  4,
+			expectedHeight: 2,
+			expectedPixels: map[image.Point]color.RGBA{{0, 0}: blue},
+		},
+		{
+			name:           "rotate 45 expands the canvas",
+			input:          rotateInput{Angle: 45, BackgroundColor: "#ffffff"},
+			expectedWidth:  5,
+			expectedHeight: 5,
+			expectedPixels: map[image.Point]color.RGBA{{0, 0}: white, {4, 4}: white},
+		},
+		{
+			name:           "rotate 45 keeping the size",
+			input:          rotateInput{Angle: 45, KeepSize: true},
+			expectedWidth:  4,
+			expectedHeight: 2,
+			expectedPixels: map[image.Point]color.RGBA{{3, 0}: {}, {0, 1}: {}},
+		},
+		{
+			name:           "rotate 90 keeping the size",
+			input:          rotateInput{Angle: 90, KeepSize: true, BackgroundColor: "#fff"},
+			expectedWidth:  4,
+			expectedHeight: 2,
+			expectedPixels: map[image.Point]color.RGBA{{0, 0}: white},
+		},
+		{
+			name:          "unsupported flip",
+			input:         rotateInput{Flip: "diagonal"},
+			expectedError: "unsupported flip: diagonal",
+		},
+		{
+			name:          "invalid background color",
+			input:         rotateInput{Angle: 30, BackgroundColor: "white"},
+			expectedError: `invalid background color: invalid color "white"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			tc.input.Image = base64Image("data:image/png;base64," + base64Img)
+			inputStruct, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, quicktest.IsNil)
+
+			output, err := rotate(inputStruct, nil, context.Background())
+			if tc.expectedError != "" {
+				c.Assert(err, quicktest.ErrorMatches, tc.expectedError)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+
+			var out rotateOutput
+			c.Assert(base.ConvertFromStructpb(output, &out), quicktest.IsNil)
+			rotated, err := decodeBase64Image(string(out.Image))
+			c.Assert(err, quicktest.IsNil)
+
+			c.Check(rotated.Bounds().Dx(), quicktest.Equals, tc.expectedWidth)
+			c.Check(rotated.Bounds().Dy(), quicktest.Equals, tc.expectedHeight)
+			for p, want := range tc.expectedPixels {
+				c.Check(color.RGBAModel.Convert(rotated.At(p.X, p.Y)), quicktest.Equals, want, quicktest.Commentf("pixel %v", p))
+			}
+		})
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	c := quicktest.New(t)
+
+	testCases := []struct {
+		in      string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{in: "#ff0000", want: red},
+		{in: "0f0", want: green},
+		{in: "#0000FFFF", want: blue},
+		{in: "#ffffff00", want: color.RGBA{}},
+		{in: "#12345", wantErr: true},
+		{in: "#gggggg", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.in, func(c *quicktest.C) {
+			got, err := parseHexColor(tc.in)
+			if tc.wantErr {
+				c.Check(err, quicktest.IsNotNil)
+				return
+			}
+			c.Check(err, quicktest.IsNil)
+			c.Check(got, quicktest.Equals, tc.want)
+		})
+	}
+}
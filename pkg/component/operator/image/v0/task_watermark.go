@@ -0,0 +1,215 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font/opentype"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	nr "github.com/nfnt/resize"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+type watermarkInput struct {
+	Image        base64Image `json:"image"`
+	Overlay      base64Image `json:"overlay"`
+	OverlayWidth int         `json:"overlay-width"`
+	Text         string      `json:"text"`
+	Font         string      `json:"font"`
+	FontSize     float64     `json:"font-size"`
+	TextColor    string      `json:"text-color"`
+	Opacity      *float64    `json:"opacity"`
+	Anchor       string      `json:"anchor"`
+	Margin       int         `json:"margin"`
+}
+
+type watermarkOutput struct {
+	Image base64Image `json:"image"`
+}
+
+const (
+	defaultFontSize = 24
+	defaultAnchor   = "bottom-right"
+)
+
+// watermark composites an image or a text onto the input image, at one of
+// its corners, the middle of one of its sides or its center.
+func watermark(input *structpb.Struct, job *base.Job, ctx context.Context) (*structpb.Struct, error) {
+	var inputStruct watermarkInput
+	if err := base.ConvertFromStructpb(input, &inputStruct); err != nil {
+		return nil, fmt.Errorf("error converting input to struct: %w", err)
+	}
+
+	switch {
+	case inputStruct.Overlay == "" && inputStruct.Text == "":
+		return nil, fmt.Errorf("either overlay or text must be provided")
+	case inputStruct.Overlay != "" && inputStruct.Text != "":
+		return nil, fmt.Errorf("overlay and text can't be provided together")
+	}
+
+	opacity := 1.0
+	if inputStruct.Opacity != nil {
+		opacity = *inputStruct.Opacity
+	}
+	if opacity < 0 || opacity > 1 {
+		return nil, fmt.Errorf("opacity must be between 0 and 1")
+	}
+	if inputStruct.Margin < 0 {
+		return nil, fmt.Errorf("margin must be greater than or equal to 0")
+	}
+
+	img, err := decodeBase64Image(string(inputStruct.Image))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding image: %w", err)
+	}
+
+	var layer image.Image
+	if inputStruct.Overlay != "" {
+		if layer, err = overlayLayer(inputStruct); err != nil {
+			return nil, err
+		}
+	} else {
+		if layer, err = textLayer(inputStruct); err != nil {
+			return nil, err
+		}
+	}
+
+	anchor := inputStruct.Anchor
+	if anchor == "" {
+		anchor = defaultAnchor
+	}
+	pos, err := anchorPoint(anchor, img.Bounds(), layer.Bounds().Size(), inputStruct.Margin)
+	if err != nil {
+		return nil, err
+	}
+
+	composited := convertToRGBA(img)
+	mask := image.NewUniform(color.Alpha{A: uint8(math.Round(opacity * 255))})
+	r := image.Rectangle{Min: pos, Max: pos.Add(layer.Bounds().Size())}
+	draw.DrawMask(composited, r, layer, layer.Bounds().Min, mask, image.Point{}, draw.Over)
+
+	outputImg, err := encodeImageDataURI(composited)
+	if err != nil {
+		return nil, err
+	}
+	return base.ConvertToStructpb(watermarkOutput{Image: outputImg})
+}
+
+// overlayLayer decodes the overlay image, scaled to its width if it's set.
+func overlayLayer(input watermarkInput) (image.Image, error) {
+	if input.OverlayWidth < 0 {
+		return nil, fmt.Errorf("overlay width must be greater than or equal to 0")
+	}
+
+	overlay, err := decodeBase64Image(string(input.Overlay))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding overlay: %w", err)
+	}
+	if input.OverlayWidth > 0 && input.OverlayWidth != overlay.Bounds().Dx() {
+		// A zero height preserves the aspect ratio.
+		overlay = nr.Resize(uint(input.OverlayWidth), 0, overlay, nr.Lanczos3)
+	}
+	return overlay, nil
+}
+
+// textLayer draws a line of text on a transparent image that fits it.
+func textLayer(input watermarkInput) (image.Image, error) {
+	fontBytes := IBMPlexSansRegular
+	if input.Font != "" {
+		bin, err := data.DecodeBinary(input.Font)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding font: %w", err)
+		}
+		fontBytes = bin.Raw
+	}
+	font, err := opentype.Parse(fontBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing font: %w", err)
+	}
+
+	size := input.FontSize
+	if size == 0 {
+		size = defaultFontSize
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("font size must be greater than 0")
+	}
+	face, err := opentype.NewFace(font, &opentype.FaceOptions{Size: size, DPI: 72})
+	if err != nil {
+		return nil, err
+	}
+	defer face.Close()
+
+	textColor := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	if input.TextColor != "" {
+		if textColor, err = parseHexColor(input.TextColor); err != nil {
+			return nil, fmt.Errorf("invalid text color: %w", err)
+		}
+	}
+
+	// The text is written in a single line.
+	text := strings.Join(strings.Fields(input.Text), " ")
+
+	metrics := face.Metrics()
+	ascent := float64(metrics.Ascent.Ceil())
+	height := ascent + float64(metrics.Descent.Ceil())
+
+	measure := gg.NewContext(1, 1)
+	measure.SetFontFace(face)
+	width, _ := measure.MeasureString(text)
+
+	layer := image.NewRGBA(image.Rect(0, 0, max(int(math.Ceil(width)), 1), int(height)))
+	dc := gg.NewContextForRGBA(layer)
+	dc.SetFontFace(face)
+	dc.SetColor(textColor)
+	dc.DrawString(text, 0, ascent)
+	return layer, nil
+}
+
+// anchorPoint returns the position at which a layer of a size is placed in
+// an image, with a margin to the edges it's anchored to.
+func anchorPoint(anchor string, bounds image.Rectangle, size image.Point, margin int) (image.Point, error) {
+	vertical, horizontal, found := strings.Cut(anchor, "-")
+	if !found {
+		switch anchor {
+		case "top", "bottom":
+			vertical, horizontal = anchor, "center"
+		case "left", "right":
+			vertical, horizontal = "center", anchor
+		default:
+			vertical, horizontal = anchor, anchor
+		}
+	}
+
+	p := image.Point{}
+	switch horizontal {
+	case "left":
+		p.X = bounds.Min.X + margin
+	case "center":
+		p.X = bounds.Min.X + (bounds.Dx()-size.X)/2
+	case "right":
+		p.X = bounds.Max.X - size.X - margin
+	default:
+		return p, fmt.Errorf("unsupported anchor: %s", anchor)
+	}
+	switch vertical {
+	case "top":
+		p.Y = bounds.Min.Y + margin
+	case "center":
+		p.Y = bounds.Min.Y + (bounds.Dy()-size.Y)/2
+	case "bottom":
+		p.Y = bounds.Max.Y - size.Y - margin
+	default:
+		return p, fmt.Errorf("unsupported anchor: %s", anchor)
+	}
+	return p, nil
+}
@@ -0,0 +1,189 @@
+package image
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+func solidImage(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestWatermark(t *testing.T) {
+	c := quicktest.New(t)
+
+	base64Img, err := encodeBase64Image(solidImage(10, 10, white))
+	c.Assert(err, quicktest.IsNil)
+	base64Overlay, err := encodeBase64Image(solidImage(2, 2, red))
+	c.Assert(err, quicktest.IsNil)
+
+	half := 0.5
+	tooOpaque := 1.5
+
+	testCases := []struct {
+		name  string
+		input watermarkInput
+		// expectedPixels are checked in the output image.
+		expectedPixels map[image.Point]color.RGBA
+		expectedError  string
+	}{
+		{
+			name:  "overlay at the default anchor",
+			input: watermarkInput{Overlay: base64Image("data:image/png;base64," + base64Overlay)},
+			expectedPixels: map[image.Point]color.RGBA{
+				{8, 8}: red, {9, 9}: red, {7, 9}: white, {0, 0}: white,
+			},
+		},
+		{
+			name: "overlay at the top left with a margin",
+			input: watermarkInput{
+				Overlay: base64Image("data:image/png;base64," + base64Overlay),
+				Anchor:  "top-left",
+				Margin:  1,
+			},
+			expectedPixels: map[image.Point]color.RGBA{
+				{0, 0}: white, {1, 1}: red, {2, 2}: red, {3, 3}: white,
+			},
+		},
+		{
+			name: "scaled overlay at the center",
+			input: watermarkInput{
+				Overlay:      base64Image("data:image/png;base64," + base64Overlay),
+				OverlayWidth: 4,
+				Anchor:       "center",
+			},
+			expectedPixels: map[image.Point]color.RGBA{
+				{3, 3}: red, {6, 6}: red, {2, 2}: white, {7, 7}: white,
+			},
+		},
+		{
+			name: "overlay on the right side",
+			input: watermarkInput{
+				Overlay: base64Image("data:image/png;base64," + base64Overlay),
+				Anchor:  "right",
+			},
+			expectedPixels: map[image.Point]color.RGBA{
+				{9, 4}: red, {8, 5}: red, {9, 3}: white,
+			},
+		},
+		{
+			name: "translucent overlay",
+			input: watermarkInput{
+				Overlay: base64Image("data:image/png;base64," + base64Overlay),
+				Anchor:  "top-left",
+				Opacity: &half,
+			},
+			expectedPixels: map[image.Point]color.RGBA{
+				{0, 0}: {R: 255, G: 127, B: 127, A: 255},
+			},
+		},
+		{
+			name:          "neither overlay nor text",
+			input:         watermarkInput{},
+			expectedError: "either overlay or text must be provided",
+		},
+		{
+			name: "both overlay and text",
+			input: watermarkInput{
+				Overlay: base64Image("data:image/png;base64," + base64Overlay),
+				Text:    "© Instill",
+			},
+			expectedError: "overlay and text can't be provided together",
+		},
+		{
+			name:          "invalid opacity",
+			input:         watermarkInput{Text: "© Instill", Opacity: &tooOpaque},
+			expectedError: "opacity must be between 0 and 1",
+		},
+		{
+			name:          "invalid anchor",
+			input:         watermarkInput{Text: "© Instill", Anchor: "middle"},
+			expectedError: "unsupported anchor: middle",
+		},
+		{
+			name:          "invalid text color",
+			input:         watermarkInput{Text: "© Instill", TextColor: "black"},
+			expectedError: `invalid text color: invalid color "black"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			tc.input.Image = base64Image("data:image/png;base64," + base64Img)
+			inputStruct, err := base.ConvertToStructpb(tc.input)
+			c.Assert(err, quicktest.IsNil)
+
+			output, err := watermark(inputStruct, nil, context.Background())
+			if tc.expectedError != "" {
+				c.Assert(err, quicktest.ErrorMatches, tc.expectedError)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+
+			var out watermarkOutput
+			c.Assert(base.ConvertFromStructpb(output, &out), quicktest.IsNil)
+			watermarked, err := decodeBase64Image(string(out.Image))
+			c.Assert(err, quicktest.IsNil)
+
+			c.Check(watermarked.Bounds().Size(), quicktest.Equals, image.Pt(10, 10))
+			for p, want := range tc.expectedPixels {
+				c.Check(color.RGBAModel.Convert(watermarked.At(p.X, p.Y)), quicktest.Equals, want, quicktest.Commentf("pixel %v", p))
+			}
+		})
+	}
+}
+
+func TestWatermarkText(t *testing.T) {
+	c := quicktest.New(t)
+
+	base64Img, err := encodeBase64Image(solidImage(200, 100, white))
+	c.Assert(err, quicktest.IsNil)
+
+	inputStruct, err := base.ConvertToStructpb(watermarkInput{
+		Image:     base64Image("data:image/png;base64," + base64Img),
+		Text:      "Instill",
+		FontSize:  20,
+		TextColor: "#000000",
+		Anchor:    "bottom-left",
+		Margin:    5,
+	})
+	c.Assert(err, quicktest.IsNil)
+
+	output, err := watermark(inputStruct, nil, context.Background())
+	c.Assert(err, quicktest.IsNil)
+
+	var out watermarkOutput
+	c.Assert(base.ConvertFromStructpb(output, &out), quicktest.IsNil)
+	watermarked, err := decodeBase64Image(string(out.Image))
+	c.Assert(err, quicktest.IsNil)
+
+	// The text is written in the bottom-left corner only.
+	var darkInCorner, darkElsewhere int
+	bounds := watermarked.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if px := color.GrayModel.Convert(watermarked.At(x, y)).(color.Gray); px.Y > 128 {
+				continue
+			}
+			if x < 100 && y >= 60 {
+				darkInCorner++
+			} else {
+				darkElsewhere++
+			}
+		}
+	}
+	c.Check(darkInCorner > 0, quicktest.IsTrue)
+	c.Check(darkElsewhere, quicktest.Equals, 0)
+}
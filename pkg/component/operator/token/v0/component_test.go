@@ -0,0 +1,139 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/pipeline-backend/pkg/component/internal/mock"
+	"github.com/instill-ai/x/errmsg"
+)
+
+func TestOperator_Execute(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	testcases := []struct {
+		name string
+
+		task    string
+		in      map[string]any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name: "ok - count tokens",
+
+			task: taskCountTokens,
+			in:   map[string]any{"text": "Hello world"},
+			want: map[string]any{"token-count": 2, "encoding": "o200k_base"},
+		},
+		{
+			name: "ok - count tokens with unknown model",
+
+			task: taskCountTokens,
+			in:   map[string]any{"text": "Hello world", "model": "claude-3-5-sonnet"},
+			want: map[string]any{"token-count": 2, "encoding": "cl100k_base"},
+		},
+		{
+			name: "ok - count tokens with encoding",
+
+			task: taskCountTokens,
+			in:   map[string]any{"text": "Hello world", "model": "gpt-4o", "encoding": "r50k_base"},
+			want: map[string]any{"token-count": 2, "encoding": "r50k_base"},
+		},
+		{
+			name: "nok - unsupported encoding",
+
+			task:    taskCountTokens,
+			in:      map[string]any{"text": "Hello world", "encoding": "foo"},
+			wantErr: "Encoding foo isn't supported.",
+		},
+		{
+			name: "ok - text fits",
+
+			task: taskFitContextWindow,
+			in:   map[string]any{"text": "Hello world", "context-window": 10},
+			want: map[string]any{
+				"text":                 "Hello world",
+				"encoding":             "o200k_base",
+				"token-count":          2,
+				"original-token-count": 2,
+				"truncated":            false,
+				"dropped-token-count":  0,
+				"dropped":              []any{},
+			},
+		},
+		{
+			name: "nok - reserved tokens exceed context window",
+
+			task:    taskFitContextWindow,
+			in:      map[string]any{"text": "Hello world", "context-window": 10, "reserved-tokens": 10},
+			wantErr: "The context window must be larger than the reserved tokens.",
+		},
+		{
+			name: "nok - unsupported strategy",
+
+			task:    taskFitContextWindow,
+			in:      map[string]any{"text": "Hello world", "context-window": 1, "strategy": "middle"},
+			wantErr: "Strategy middle isn't supported.",
+		},
+	}
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			exec, err := cmp.CreateExecution(base.ComponentExecution{
+				Component: cmp,
+				Task:      tc.task,
+			})
+			c.Assert(err, qt.IsNil)
+
+			pbIn, err := structpb.NewStruct(tc.in)
+			c.Assert(err, qt.IsNil)
+
+			ir, ow, eh, job := mock.GenerateMockJob(c)
+			ir.ReadMock.Return(pbIn, nil)
+			ow.WriteMock.Optional().Set(func(ctx context.Context, output *structpb.Struct) (err error) {
+				c.Check(tc.wantErr, qt.Equals, "")
+
+				gotJSON, err := output.MarshalJSON()
+				c.Assert(err, qt.IsNil)
+				c.Check(gotJSON, qt.JSONEquals, tc.want)
+				return nil
+			})
+			eh.ErrorMock.Optional().Set(func(ctx context.Context, err error) {
+				c.Check(errmsg.Message(err), qt.Equals, tc.wantErr)
+			})
+
+			err = exec.Execute(ctx, []*base.Job{job})
+			c.Check(err, qt.IsNil)
+		})
+	}
+}
+
+func TestOperator_CreateExecution(t *testing.T) {
+	c := qt.New(t)
+
+	bc := base.Component{}
+	cmp := Init(bc)
+
+	c.Run("nok - unsupported task", func(c *qt.C) {
+		task := "FOOBAR"
+		want := fmt.Sprintf("%s task is not supported.", task)
+
+		_, err := cmp.CreateExecution(base.ComponentExecution{
+			Component: cmp,
+			Task:      task,
+		})
+		c.Check(err, qt.IsNotNil)
+		c.Check(errmsg.Message(err), qt.Equals, want)
+	})
+}
@@ -0,0 +1,324 @@
+package token
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	strategyHead    = "head"
+	strategyTail    = "tail"
+	strategySummary = "summary"
+	strategySplit   = "split"
+)
+
+type fitContextWindowInput struct {
+	Text           string `json:"text"`
+	Model          string `json:"model"`
+	Encoding       string `json:"encoding"`
+	ContextWindow  int    `json:"context-window"`
+	ReservedTokens int    `json:"reserved-tokens"`
+	Strategy       string `json:"strategy"`
+}
+
+type fitContextWindowOutput struct {
+	Text               string    `json:"text"`
+	Encoding           string    `json:"encoding"`
+	TokenCount         int       `json:"token-count"`
+	OriginalTokenCount int       `json:"original-token-count"`
+	Truncated          bool      `json:"truncated"`
+	DroppedTokenCount  int       `json:"dropped-token-count"`
+	Dropped            []segment `json:"dropped"`
+	Chunks             []segment `json:"chunks,omitempty"`
+}
+
+// segment is a fragment of the input text. As in the text chunks of the text
+// operator, the positions are rune offsets and the end position is inclusive.
+type segment struct {
+	Text          string `json:"text"`
+	StartPosition int    `json:"start-position"`
+	EndPosition   int    `json:"end-position"`
+	TokenCount    int    `json:"token-count"`
+}
+
+func newSegment(text string, start, tokenCount int) segment {
+	return segment{
+		Text:          text,
+		StartPosition: start,
+		EndPosition:   start + utf8.RuneCountInString(text) - 1,
+		TokenCount:    tokenCount,
+	}
+}
+
+func fitContextWindow(input fitContextWindowInput) (fitContextWindowOutput, error) {
+	budget := input.ContextWindow - input.ReservedTokens
+	if input.ContextWindow <= 0 || input.ReservedTokens < 0 || budget <= 0 {
+		return fitContextWindowOutput{}, errmsg.AddMessage(
+			fmt.Errorf("invalid token budget: %d", budget),
+			"The context window must be larger than the reserved tokens.",
+		)
+	}
+
+	tkm, encoding, err := newTokenizer(input.Model, input.Encoding)
+	if err != nil {
+		return fitContextWindowOutput{}, err
+	}
+
+	tokens := tkm.encode(input.Text)
+	output := fitContextWindowOutput{
+		Text:               input.Text,
+		Encoding:           encoding,
+		TokenCount:         len(tokens),
+		OriginalTokenCount: len(tokens),
+		Dropped:            []segment{},
+	}
+
+	strategy := input.Strategy
+	if strategy == "" {
+		strategy = strategyHead
+	}
+
+	if strategy == strategySplit {
+		output.Chunks = splitTokens(tkm, tokens, budget)
+		output.Text, output.TokenCount = "", 0
+		if len(output.Chunks) > 0 {
+			output.Text, output.TokenCount = output.Chunks[0].Text, output.Chunks[0].TokenCount
+		}
+		return output, nil
+	}
+
+	if len(tokens) <= budget {
+		return output, nil
+	}
+
+	var kept string
+	switch strategy {
+	case strategyHead:
+		kept, output.Dropped = keepHead(tkm, tokens, budget)
+	case strategyTail:
+		kept, output.Dropped = keepTail(tkm, tokens, budget)
+	case strategySummary:
+		kept, output.Dropped = keepSummary(tkm, input.Text, budget)
+	default:
+		return fitContextWindowOutput{}, errmsg.AddMessage(
+			fmt.Errorf("unsupported strategy: %s", strategy),
+			fmt.Sprintf("Strategy %s isn't supported.", strategy),
+		)
+	}
+
+	output.Text = kept
+	output.TokenCount = len(tkm.encode(kept))
+	output.Truncated = true
+	for _, d := range output.Dropped {
+		output.DroppedTokenCount += d.TokenCount
+	}
+
+	return output, nil
+}
+
+// keepHead keeps the first tokens of a text. The kept text is tokenized
+// again, so the cut is moved back until it fits in the budget.
+func keepHead(tkm *tokenizer, tokens []int, budget int) (string, []segment) {
+	n := tkm.cut(tokens, budget, -1)
+	kept := tkm.decode(tokens[:n])
+	for n > 0 && len(tkm.encode(kept)) > budget {
+		n = tkm.cut(tokens, n-1, -1)
+		kept = tkm.decode(tokens[:n])
+	}
+
+	dropped := tkm.decode(tokens[n:])
+	return kept, []segment{newSegment(dropped, utf8.RuneCountInString(kept), len(tokens)-n)}
+}
+
+// keepTail keeps the last tokens of a text.
+func keepTail(tkm *tokenizer, tokens []int, budget int) (string, []segment) {
+	n := tkm.cut(tokens, len(tokens)-budget, 1)
+	kept := tkm.decode(tokens[n:])
+	for n < len(tokens) && len(tkm.encode(kept)) > budget {
+		n = tkm.cut(tokens, n+1, 1)
+		kept = tkm.decode(tokens[n:])
+	}
+
+	dropped := tkm.decode(tokens[:n])
+	return kept, []segment{newSegment(dropped, 0, n)}
+}
+
+// splitTokens splits a text into chunks of at most budget tokens.
+func splitTokens(tkm *tokenizer, tokens []int, budget int) []segment {
+	chunks := []segment{}
+	position := 0
+	for start := 0; start < len(tokens); {
+		end := min(start+budget, len(tokens))
+		if cut := tkm.cut(tokens, end, -1); cut > start {
+			end = cut
+		} else {
+			// A single character spans more tokens than the budget.
+			end = tkm.cut(tokens, end, 1)
+		}
+
+		text := tkm.decode(tokens[start:end])
+		chunks = append(chunks, newSegment(text, position, end-start))
+		position += utf8.RuneCountInString(text)
+		start = end
+	}
+	return chunks
+}
+
+type sentence struct {
+	segment
+	idx   int
+	score float64
+}
+
+// keepSummary keeps the most representative sentences of a text, in their
+// original order. Sentences are scored by the frequency of their words in
+// the whole text, which is a cheap extractive summary that doesn't need a
+// model. If no sentence fits in the budget, the head of the text is kept.
+func keepSummary(tkm *tokenizer, text string, budget int) (string, []segment) {
+	sentences := splitSentences(text)
+	for i := range sentences {
+		sentences[i].TokenCount = len(tkm.encode(sentences[i].Text))
+	}
+	scoreSentences(sentences)
+
+	ranked := make([]*sentence, len(sentences))
+	for i := range sentences {
+		ranked[i] = &sentences[i]
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	keep := make([]bool, len(sentences))
+	total := 0
+	for _, s := range ranked {
+		if total+s.TokenCount <= budget {
+			keep[s.idx] = true
+			total += s.TokenCount
+		}
+	}
+
+	join := func() string {
+		var sb strings.Builder
+		for i, s := range sentences {
+			if keep[i] {
+				sb.WriteString(s.Text)
+			}
+		}
+		return strings.TrimSpace(sb.String())
+	}
+
+	// Token counts aren't exactly additive, so the least representative
+	// sentences are dropped until the joined text fits.
+	kept := join()
+	for i := len(ranked) - 1; i >= 0 && len(tkm.encode(kept)) > budget; i-- {
+		if keep[ranked[i].idx] {
+			keep[ranked[i].idx] = false
+			kept = join()
+		}
+	}
+
+	if kept == "" {
+		return keepHead(tkm, tkm.encode(text), budget)
+	}
+
+	dropped := []segment{}
+	for i, s := range sentences {
+		if !keep[i] {
+			dropped = append(dropped, s.segment)
+		}
+	}
+	return kept, dropped
+}
+
+// splitSentences splits a text into sentences that, concatenated, form the
+// original text. A sentence ends with a line break or with a terminal
+// punctuation mark followed by whitespace.
+func splitSentences(text string) []sentence {
+	sentences := []sentence{}
+	runes := []rune(text)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		end := -1
+		switch {
+		case runes[i] == '\n':
+			end = i + 1
+		case strings.ContainsRune(".!?。！？", runes[i]) && (i+1 == len(runes) || unicode.IsSpace(runes[i+1])):
+			end = i + 1
+		}
+		if end == -1 {
+			continue
+		}
+
+		// Trailing whitespace belongs to the sentence.
+		for end < len(runes) && unicode.IsSpace(runes[end]) {
+			end++
+		}
+		sentences = append(sentences, sentence{
+			segment: newSegment(string(runes[start:end]), start, 0),
+			idx:     len(sentences),
+		})
+		start, i = end, end-1
+	}
+
+	if start < len(runes) {
+		sentences = append(sentences, sentence{
+			segment: newSegment(string(runes[start:]), start, 0),
+			idx:     len(sentences),
+		})
+	}
+	return sentences
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "were": true, "with": true,
+}
+
+func sentenceWords(s string) []string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	filtered := words[:0]
+	for _, w := range words {
+		if !stopWords[w] {
+			filtered = append(filtered, w)
+		}
+	}
+	return filtered
+}
+
+// scoreSentences scores each sentence with the average normalized frequency
+// of its words.
+func scoreSentences(sentences []sentence) {
+	freq := map[string]float64{}
+	maxFreq := 0.0
+	for _, s := range sentences {
+		for _, w := range sentenceWords(s.Text) {
+			freq[w]++
+			maxFreq = max(maxFreq, freq[w])
+		}
+	}
+	if maxFreq == 0 {
+		return
+	}
+
+	for i := range sentences {
+		words := sentenceWords(sentences[i].Text)
+		if len(words) == 0 {
+			continue
+		}
+
+		score := 0.0
+		for _, w := range words {
+			score += freq[w] / maxFreq
+		}
+		sentences[i].score = score / float64(len(words))
+	}
+}
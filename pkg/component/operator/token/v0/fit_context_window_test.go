@@ -0,0 +1,118 @@
+package token
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+const article = "The cat sat on the mat. The dog chased the cat around the garden. " +
+	"Birds sing in the morning! Later, the cat slept on the mat again.\n" +
+	"Nobody knows why the dog barked at night."
+
+func TestFitContextWindow(t *testing.T) {
+	c := qt.New(t)
+
+	const budget = 16
+	input := fitContextWindowInput{Text: article, ContextWindow: budget + 4, ReservedTokens: 4}
+
+	c.Run("ok - head", func(c *qt.C) {
+		input := input
+		input.Strategy = strategyHead
+
+		got, err := fitContextWindow(input)
+		c.Assert(err, qt.IsNil)
+		c.Check(got.Truncated, qt.IsTrue)
+		c.Check(got.TokenCount <= budget, qt.IsTrue)
+		c.Check(got.OriginalTokenCount > budget, qt.IsTrue)
+		c.Assert(got.Dropped, qt.HasLen, 1)
+		c.Check(got.Text+got.Dropped[0].Text, qt.Equals, article)
+		c.Check(got.Dropped[0].EndPosition, qt.Equals, len([]rune(article))-1)
+		c.Check(got.DroppedTokenCount, qt.Equals, got.Dropped[0].TokenCount)
+	})
+
+	c.Run("ok - tail", func(c *qt.C) {
+		input := input
+		input.Strategy = strategyTail
+
+		got, err := fitContextWindow(input)
+		c.Assert(err, qt.IsNil)
+		c.Check(got.Truncated, qt.IsTrue)
+		c.Check(got.TokenCount <= budget, qt.IsTrue)
+		c.Assert(got.Dropped, qt.HasLen, 1)
+		c.Check(got.Dropped[0].StartPosition, qt.Equals, 0)
+		c.Check(got.Dropped[0].Text+got.Text, qt.Equals, article)
+	})
+
+	c.Run("ok - summary", func(c *qt.C) {
+		input := input
+		input.Strategy = strategySummary
+
+		got, err := fitContextWindow(input)
+		c.Assert(err, qt.IsNil)
+		c.Check(got.Truncated, qt.IsTrue)
+		c.Check(got.TokenCount <= budget, qt.IsTrue)
+		c.Check(got.Text, qt.Not(qt.Equals), "")
+		c.Check(len(got.Dropped) > 0, qt.IsTrue)
+
+		// Kept sentences preserve their order.
+		kept := strings.Fields(got.Text)
+		c.Check(strings.Contains(article, strings.Join(kept[:2], " ")), qt.IsTrue)
+	})
+
+	c.Run("ok - split", func(c *qt.C) {
+		input := input
+		input.Strategy = strategySplit
+
+		got, err := fitContextWindow(input)
+		c.Assert(err, qt.IsNil)
+		c.Check(got.Truncated, qt.IsFalse)
+		c.Assert(len(got.Chunks) > 1, qt.IsTrue)
+		c.Check(got.Text, qt.Equals, got.Chunks[0].Text)
+
+		var joined strings.Builder
+		for _, chunk := range got.Chunks {
+			c.Check(chunk.TokenCount <= budget, qt.IsTrue)
+			c.Check(chunk.StartPosition, qt.Equals, len([]rune(joined.String())))
+			joined.WriteString(chunk.Text)
+		}
+		c.Check(joined.String(), qt.Equals, article)
+	})
+
+	c.Run("ok - multi-byte characters aren't broken", func(c *qt.C) {
+		input := fitContextWindowInput{
+			Text:          strings.Repeat("日本語のテキスト。", 10),
+			ContextWindow: 7,
+			Strategy:      strategyHead,
+		}
+
+		got, err := fitContextWindow(input)
+		c.Assert(err, qt.IsNil)
+		c.Check(strings.ToValidUTF8(got.Text, "?"), qt.Equals, got.Text)
+		c.Check(got.Text+got.Dropped[0].Text, qt.Equals, input.Text)
+	})
+}
+
+func TestSplitSentences(t *testing.T) {
+	c := qt.New(t)
+
+	sentences := splitSentences(article)
+
+	got := make([]string, len(sentences))
+	var joined strings.Builder
+	for i, s := range sentences {
+		got[i] = strings.TrimSpace(s.Text)
+		c.Check(s.StartPosition, qt.Equals, len([]rune(joined.String())))
+		joined.WriteString(s.Text)
+	}
+
+	c.Check(joined.String(), qt.Equals, article)
+	c.Check(got, qt.DeepEquals, []string{
+		"The cat sat on the mat.",
+		"The dog chased the cat around the garden.",
+		"Birds sing in the morning!",
+		"Later, the cat slept on the mat again.",
+		"Nobody knows why the dog barked at night.",
+	})
+}
@@ -0,0 +1,108 @@
+//go:generate compogen readme ./config ./README.mdx
+package token
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	_ "embed"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/component/base"
+	"github.com/instill-ai/x/errmsg"
+)
+
+const (
+	taskCountTokens      = "TASK_COUNT_TOKENS"
+	taskFitContextWindow = "TASK_FIT_CONTEXT_WINDOW"
+)
+
+var (
+	//go:embed config/definition.json
+	definitionJSON []byte
+	//go:embed config/tasks.json
+	tasksJSON []byte
+
+	once sync.Once
+	comp *component
+)
+
+type component struct {
+	base.Component
+}
+
+type execution struct {
+	base.ComponentExecution
+
+	execute func(*structpb.Struct) (*structpb.Struct, error)
+}
+
+// Init returns an implementation of IOperator that counts the tokens of a
+// text and fits it into the context window of a model.
+func Init(bc base.Component) *component {
+	once.Do(func() {
+		comp = &component{Component: bc}
+		err := comp.LoadDefinition(definitionJSON, nil, tasksJSON, nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+	return comp
+}
+
+// CreateExecution initializes a component executor that can be used in a
+// pipeline trigger.
+func (c *component) CreateExecution(x base.ComponentExecution) (base.IExecution, error) {
+	e := &execution{ComponentExecution: x}
+
+	switch x.Task {
+	case taskCountTokens:
+		e.execute = e.countTokens
+	case taskFitContextWindow:
+		e.execute = e.fitContextWindow
+	default:
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("not supported task: %s", x.Task),
+			fmt.Sprintf("%s task is not supported.", x.Task),
+		)
+	}
+	return e, nil
+}
+
+func (e *execution) countTokens(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := countTokensInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	tkm, encoding, err := newTokenizer(inputStruct.Model, inputStruct.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(countTokensOutput{
+		TokenCount: len(tkm.encode(inputStruct.Text)),
+		Encoding:   encoding,
+	})
+}
+
+func (e *execution) fitContextWindow(in *structpb.Struct) (*structpb.Struct, error) {
+	inputStruct := fitContextWindowInput{}
+	if err := base.ConvertFromStructpb(in, &inputStruct); err != nil {
+		return nil, err
+	}
+
+	outputStruct, err := fitContextWindow(inputStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ConvertToStructpb(outputStruct)
+}
+
+// Execute executes the derived execution
+func (e *execution) Execute(ctx context.Context, jobs []*base.Job) error {
+	return base.SequentialExecutor(ctx, jobs, e.execute)
+}
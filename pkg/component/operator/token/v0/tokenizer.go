@@ -0,0 +1,86 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/instill-ai/x/errmsg"
+
+	tiktoken "github.com/pkoukk/tiktoken-go"
+)
+
+const (
+	defaultModel = "gpt-4o"
+
+	// fallbackEncoding is used for the models that tiktoken doesn't know
+	// (e.g. Claude or Llama). Their tokenizers are close enough in size for
+	// the count to be used as an estimate.
+	fallbackEncoding = tiktoken.MODEL_CL100K_BASE
+)
+
+type countTokensInput struct {
+	Text     string `json:"text"`
+	Model    string `json:"model"`
+	Encoding string `json:"encoding"`
+}
+
+type countTokensOutput struct {
+	TokenCount int    `json:"token-count"`
+	Encoding   string `json:"encoding"`
+}
+
+type tokenizer struct {
+	tkm *tiktoken.Tiktoken
+}
+
+// newTokenizer returns the tokenizer of an encoding or, if no encoding is
+// provided, of a model. It returns the name of the encoding as well.
+func newTokenizer(model, encoding string) (*tokenizer, string, error) {
+	if encoding == "" {
+		if model == "" {
+			model = defaultModel
+		}
+
+		encoding = fallbackEncoding
+		if e, ok := tiktoken.MODEL_TO_ENCODING[model]; ok {
+			encoding = e
+		} else {
+			for prefix, e := range tiktoken.MODEL_PREFIX_TO_ENCODING {
+				if strings.HasPrefix(model, prefix) {
+					encoding = e
+					break
+				}
+			}
+		}
+	}
+
+	tkm, err := tiktoken.GetEncoding(encoding)
+	if err != nil {
+		return nil, "", errmsg.AddMessage(
+			fmt.Errorf("loading encoding: %w", err),
+			fmt.Sprintf("Encoding %s isn't supported.", encoding),
+		)
+	}
+
+	return &tokenizer{tkm: tkm}, encoding, nil
+}
+
+// encode tokenizes a text. Special tokens are treated as regular text, as
+// the input isn't trusted.
+func (t *tokenizer) encode(text string) []int {
+	return t.tkm.EncodeOrdinary(text)
+}
+
+func (t *tokenizer) decode(tokens []int) string {
+	return t.tkm.Decode(tokens)
+}
+
+// cut returns the closest position to n, moving in the direction of step,
+// where the tokens can be split without breaking a multi-byte character.
+func (t *tokenizer) cut(tokens []int, n, step int) int {
+	for n > 0 && n < len(tokens) && !utf8.ValidString(t.decode(tokens[:n])) {
+		n += step
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+package store
+
+// Deprecation flags a component, one of its tasks or an input field of a task
+// that pipelines should stop using.
+type Deprecation struct {
+	// Type is the ID of the deprecated component definition.
+	Type string `json:"type"`
+	// Task is the deprecated task. When empty, every task of the component
+	// is deprecated.
+	Task string `json:"task,omitempty"`
+	// Input is the deprecated input field. When empty, the whole task is
+	// deprecated.
+	Input string `json:"input,omitempty"`
+	// Version is the version of the component definition that deprecated it.
+	Version string `json:"version,omitempty"`
+	// Message tells the users what to use instead.
+	Message string `json:"message"`
+	// Replacement is set when the recipes can be migrated mechanically.
+	Replacement *Replacement `json:"replacement,omitempty"`
+}
+
+// Replacement describes how a recipe is rewritten to stop using a deprecated
+// component. A replacement is only declared when the rewritten component
+// produces the same output fields, so the references to it still resolve.
+type Replacement struct {
+	// Type is the ID of the component definition that replaces the
+	// deprecated one. When empty, the component type is kept.
+	Type string `json:"type,omitempty"`
+	// Task is the task that replaces the deprecated one. When empty, the
+	// task is kept.
+	Task string `json:"task,omitempty"`
+	// Input renames the input fields, from their deprecated name to the
+	// new one.
+	Input map[string]string `json:"input,omitempty"`
+}
+
+// deprecations holds the deprecations of the components implemented in this
+// repository.
+var deprecations = []*Deprecation{
+	{
+		Type:    "json",
+		Task:    "TASK_JQ",
+		Input:   "json-string",
+		Message: "Use `json-value` instead. It takes the JSON value rather than its string, so the input must be adapted by hand.",
+	},
+}
+
+// Deprecate adds deprecations to the store.
+func (s *Store) Deprecate(ds ...*Deprecation) {
+	s.deprecations = append(s.deprecations, ds...)
+}
+
+// Deprecations returns the deprecations that apply to a task of a component.
+func (s *Store) Deprecations(compType, task string) []*Deprecation {
+	var ds []*Deprecation
+	for _, d := range s.deprecations {
+		if d.Type == compType && (d.Task == "" || d.Task == task) {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
+
+// ListDeprecations returns all the deprecations in the store.
+func (s *Store) ListDeprecations() []*Deprecation {
+	return append([]*Deprecation{}, s.deprecations...)
+}
@@ -43,16 +43,20 @@ import (
 	"github.com/instill-ai/pipeline-backend/pkg/component/data/qdrant/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/data/redis/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/data/sql/v0"
+	"github.com/instill-ai/pipeline-backend/pkg/component/data/vectorstore/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/data/weaviate/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/data/zilliz/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/generic/collection/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/generic/restapi/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/operator/audio/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/operator/base64/v0"
+	"github.com/instill-ai/pipeline-backend/pkg/component/operator/conversation/v0"
+	"github.com/instill-ai/pipeline-backend/pkg/component/operator/dedupe/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/operator/document/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/operator/image/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/operator/json/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/operator/text/v0"
+	"github.com/instill-ai/pipeline-backend/pkg/component/operator/token/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/operator/video/v0"
 	"github.com/instill-ai/pipeline-backend/pkg/component/operator/web/v0"
 
@@ -69,6 +73,7 @@ type Store struct {
 	componentUIDs   []uuid.UUID
 	componentUIDMap map[uuid.UUID]*component
 	componentIDMap  map[string]*component
+	deprecations    []*Deprecation
 }
 
 type component struct {
@@ -102,6 +107,9 @@ func Init(
 		compStore.Import(json.Init(baseComp))
 		compStore.Import(image.Init(baseComp))
 		compStore.Import(text.Init(baseComp))
+		compStore.Import(token.Init(baseComp))
+		compStore.Import(dedupe.Init(baseComp))
+		compStore.Import(conversation.Init(baseComp))
 		compStore.Import(document.Init(baseComp))
 		compStore.Import(audio.Init(baseComp))
 		compStore.Import(video.Init(baseComp))
@@ -186,6 +194,7 @@ func Init(
 		compStore.Import(zilliz.Init(baseComp))
 		compStore.Import(chroma.Init(baseComp))
 		compStore.Import(qdrant.Init(baseComp))
+		compStore.Import(vectorstore.Init(baseComp))
 		compStore.Import(instillartifact.Init(baseComp))
 		compStore.Import(restapi.Init(baseComp))
 		compStore.Import(collection.Init(baseComp))
@@ -198,6 +207,8 @@ func Init(
 		compStore.Import(whatsapp.Init(baseComp))
 		compStore.Import(freshdesk.Init(baseComp))
 		compStore.Import(asana.Init(baseComp))
+
+		compStore.Deprecate(deprecations...)
 	})
 	return compStore
 }
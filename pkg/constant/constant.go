@@ -36,12 +36,32 @@ const (
 	HeaderAccept           = "Accept"
 	HeaderValueEventStream = "text/event-stream"
 
+	// HeaderEventFormatKey selects the format of the streamed events. With
+	// HeaderValueJSONPatch, the component output updates are streamed as
+	// JSON Patch operations.
+	HeaderEventFormatKey = "Instill-Event-Format"
+	HeaderValueJSONPatch = "json-patch"
+
+	// HeaderEventVersionKey holds the schema versions of the streamed events
+	// a client accepts, as a comma-separated list. The response informs the
+	// version the events are streamed with.
+	HeaderEventVersionKey = "Instill-Event-Version"
+
+	// HeaderRecipeWarningKey holds the type mismatches found in the recipe of
+	// a pipeline that is saved, one per header value.
+	HeaderRecipeWarningKey = "Instill-Recipe-Warning"
+
+	// HeaderForceDeleteKey, when "true", deletes a resource even if some
+	// pipelines or event sources still depend on it.
+	HeaderForceDeleteKey = "Instill-Force-Delete"
+
 	SegMemory     = "memory"
 	SegVariable   = "variable"
 	SegSecret     = "secret"
 	SegConnection = "connection"
 	SegComponent  = "component"
 	SegIteration  = "iterator"
+	SegChunk      = "chunk"
 	SegInput      = "input"
 	SegOutput     = "output"
 )
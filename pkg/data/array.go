@@ -8,6 +8,11 @@ import (
 
 type Array struct {
 	Values []Value
+
+	// shared is set when the array can't be modified in place.
+	shared bool
+	// frozen is set when the array must never change, see Freeze.
+	frozen bool
 }
 
 func NewArray(v []Value) (arr *Array) {
@@ -29,16 +34,45 @@ func (a *Array) Get(path string) (v Value, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if start, end, remainingPath, ok, err := trimFirstSliceFromPath(path, len(a.Values)); ok {
+		if err != nil {
+			return nil, err
+		}
+		return selectEach(a.Values[start:end], remainingPath), nil
+	}
+
 	index, remainingPath, err := trimFirstIndexFromPath(path)
 	if err != nil {
 		return nil, err
 	}
-	if index >= len(a.Values) {
+	if index < 0 || index >= len(a.Values) {
 		return nil, fmt.Errorf("path not found: %s", path)
 	}
 
+	if a.Values[index] == nil {
+		return NewNull().Get(remainingPath)
+	}
 	return a.Values[index].Get(remainingPath)
 }
+
+// selectEach resolves a path on each of the values selected by a wildcard
+// or a slice. The values where the path isn't found are null, so the result
+// keeps the positions of the selection.
+func selectEach(values []Value, path string) *Array {
+	selected := make([]Value, len(values))
+	for i, v := range values {
+		if v == nil {
+			selected[i] = NewNull()
+			continue
+		}
+		got, err := v.Get(path)
+		if err != nil {
+			got = NewNull()
+		}
+		selected[i] = got
+	}
+	return NewArray(selected)
+}
 func (a Array) ToStructValue() (v *structpb.Value, err error) {
 	arr := &structpb.ListValue{Values: make([]*structpb.Value, len(a.Values))}
 	for idx, v := range a.Values {
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Binary is a blob of bytes along with its MIME type and, optionally, the
+// name of the file it came from. Unlike a File, it's always held in memory.
+// At the API boundary it's converted to and from a base64 data URI, e.g.
+// data:image/png;base64,iVBORw0KGgo...
+type Binary struct {
+	Raw         []byte
+	ContentType string
+	FileName    string
+}
+
+// NewBinary returns a binary value. The content type is detected from the
+// content when it's empty.
+func NewBinary(b []byte, contentType, fileName string) *Binary {
+	if contentType == "" {
+		contentType = strings.Split(mimetype.Detect(b).String(), ";")[0]
+	}
+	return &Binary{Raw: b, ContentType: contentType, FileName: fileName}
+}
+
+// DecodeBinary decodes a base64 data URI. A plain base64 string is accepted
+// too, in which case the content type is detected from the content.
+func DecodeBinary(s string) (*Binary, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, ",") && !strings.HasPrefix(s, "data:") {
+		return nil, fmt.Errorf("invalid data URI")
+	}
+
+	b, contentType, fileName, err := decodeDataURL(s)
+	if err != nil {
+		return nil, fmt.Errorf("decoding base64 content: %w", err)
+	}
+	return NewBinary(b, contentType, fileName), nil
+}
+
+func (Binary) isValue() {}
+
+func (b *Binary) GetByteArray() []byte {
+	return b.Raw
+}
+
+// Base64 returns the content encoded in base64, without the data URI
+// header.
+func (b *Binary) Base64() string {
+	return base64.StdEncoding.EncodeToString(b.Raw)
+}
+
+// DataURI returns the content as a base64 data URI. The file name is only
+// included when it's set.
+func (b *Binary) DataURI() string {
+	var sb strings.Builder
+	sb.WriteString("data:")
+	sb.WriteString(b.ContentType)
+	if b.FileName != "" {
+		sb.WriteString(";filename=")
+		sb.WriteString(b.FileName)
+	}
+	sb.WriteString(";base64,")
+	sb.WriteString(b.Base64())
+	return sb.String()
+}
+
+func (b *Binary) Get(path string) (v Value, err error) {
+	switch {
+	case comparePath(path, ""):
+		return b, nil
+	case comparePath(path, ".content-type"):
+		return NewString(b.ContentType), nil
+	case comparePath(path, ".filename"):
+		return NewString(b.FileName), nil
+	case comparePath(path, ".file-size"):
+		return NewNumberFromInteger(len(b.Raw)), nil
+	case comparePath(path, ".base64"):
+		return NewString(b.Base64()), nil
+	case comparePath(path, ".data-url"):
+		return NewString(b.DataURI()), nil
+	case comparePath(path, ".byte-array"):
+		return NewByteArray(b.Raw), nil
+	}
+	return nil, fmt.Errorf("wrong path %s for Binary", path)
+}
+
+func (b Binary) ToStructValue() (v *structpb.Value, err error) {
+	v = structpb.NewStringValue(b.DataURI())
+	return
+}
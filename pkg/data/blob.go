@@ -0,0 +1,82 @@
+package data
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BlobLoader fetches the content of a file that is stored outside the
+// memory.
+type BlobLoader func(key string) ([]byte, error)
+
+// blob references the content of a file in an external store. The content
+// is loaded the first time it's needed and shared by the copies of the
+// file.
+type blob struct {
+	key  string
+	size int
+
+	mu   sync.Mutex
+	load BlobLoader
+	raw  []byte
+}
+
+// NewBlobFile returns a file whose content is stored outside the memory
+// under a key. The content is fetched with the loader the first time it's
+// needed. The loader can be provided later with SetBlobLoader.
+func NewBlobFile(key string, size int, contentType, fileName, sourceURL string, load BlobLoader) *File {
+	return &File{
+		ContentType: contentType,
+		FileName:    fileName,
+		SourceURL:   sourceURL,
+		Cache:       map[string][]byte{},
+		blob:        &blob{key: key, size: size, load: load},
+	}
+}
+
+// BlobKey returns the key of the content of a file stored outside the
+// memory, or an empty string if the content is held by the file.
+func (f *File) BlobKey() string {
+	if f.blob == nil {
+		return ""
+	}
+	return f.blob.key
+}
+
+// SetBlobLoader sets the loader of a file stored outside the memory. It
+// has no effect on the rest of files.
+func (f *File) SetBlobLoader(load BlobLoader) {
+	if f.blob == nil {
+		return
+	}
+
+	f.blob.mu.Lock()
+	defer f.blob.mu.Unlock()
+	f.blob.load = load
+}
+
+// content returns the raw content of the file, fetching it if it's stored
+// outside the memory.
+func (f *File) content() ([]byte, error) {
+	if f.blob == nil {
+		return f.Raw, nil
+	}
+
+	b := f.blob
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.raw != nil {
+		return b.raw, nil
+	}
+	if b.load == nil {
+		return nil, fmt.Errorf("no loader for blob %s", b.key)
+	}
+
+	raw, err := b.load(b.key)
+	if err != nil {
+		return nil, fmt.Errorf("loading blob %s: %w", b.key, err)
+	}
+	b.raw = raw
+	return raw, nil
+}
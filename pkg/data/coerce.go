@@ -0,0 +1,123 @@
+package data
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Coerce converts the parts of a value that don't have the type their schema
+// expects, when the conversion is unambiguous:
+//
+//   - a string holding a number, e.g. "42", to a number or an integer;
+//   - a number to a string, e.g. 0.5 to "0.5";
+//   - a single value to an array holding it.
+//
+// The values that can't be converted are kept, so Validate reports them.
+// The value isn't modified: the maps and arrays that hold a converted value
+// are copied.
+func (s *Schema) Coerce(v Value) Value {
+	return s.coerce(v, s.root)
+}
+
+func (s *Schema) coerce(v Value, sch map[string]any) Value {
+	if v == nil {
+		return v
+	}
+	if _, isNull := v.(*Null); isNull {
+		// The null fields are missing rather than mismatched.
+		return v
+	}
+
+	if types := schemaTypes(sch["type"]); len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return matchesType(v, t) }) {
+		for _, t := range types {
+			if c, ok := coerceType(v, t); ok {
+				v = c
+				break
+			}
+		}
+	}
+
+	switch v := v.(type) {
+	case *Map:
+		props, _ := sch["properties"].(map[string]any)
+		additional, _ := sch["additionalProperties"].(map[string]any)
+
+		var coerced *Map
+		for k, f := range v.Fields {
+			fieldSchema, ok := props[k].(map[string]any)
+			if !ok {
+				if _, ok := props[k]; ok || additional == nil {
+					continue
+				}
+				fieldSchema = additional
+			}
+
+			if c := s.coerce(f, fieldSchema); c != f {
+				if coerced == nil {
+					coerced = &Map{Fields: maps.Clone(v.Fields)}
+				}
+				coerced.Fields[k] = c
+			}
+		}
+		if coerced != nil {
+			return coerced
+		}
+
+	case *Array:
+		items, ok := sch["items"].(map[string]any)
+		if !ok {
+			break
+		}
+
+		var coerced *Array
+		for i, item := range v.Values {
+			if c := s.coerce(item, items); c != item {
+				if coerced == nil {
+					coerced = &Array{Values: slices.Clone(v.Values)}
+				}
+				coerced.Values[i] = c
+			}
+		}
+		if coerced != nil {
+			return coerced
+		}
+	}
+
+	return v
+}
+
+// coerceType converts a value to a schema type.
+func coerceType(v Value, t string) (Value, bool) {
+	switch t {
+	case "array":
+		if _, isArray := v.(*Array); !isArray {
+			return NewArray([]Value{v}), true
+		}
+
+	case "number", "integer":
+		str, ok := v.(*String)
+		if !ok {
+			break
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(str.Raw), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			break
+		}
+		if t == "integer" && f != math.Trunc(f) {
+			break
+		}
+		return NewNumberFromFloat(f), true
+
+	case "string":
+		num, ok := v.(*Number)
+		if !ok || math.IsNaN(num.Raw) || math.IsInf(num.Raw, 0) {
+			break
+		}
+		return NewString(strconv.FormatFloat(num.Raw, 'f', -1, 64)), true
+	}
+
+	return nil, false
+}
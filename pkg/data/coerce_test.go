@@ -0,0 +1,190 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestSchemaCoerce(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		name   string
+		schema string
+		value  Value
+		want   Value
+	}{
+		// Strings to numbers.
+		{
+			name:   "integer from string",
+			schema: `{"type": "integer"}`,
+			value:  NewString("42"),
+			want:   NewNumberFromInteger(42),
+		},
+		{
+			name:   "number from string",
+			schema: `{"type": "number"}`,
+			value:  NewString(" 4.2 "),
+			want:   NewNumberFromFloat(4.2),
+		},
+		{
+			name:   "integer from float string",
+			schema: `{"type": "integer"}`,
+			value:  NewString("4.2"),
+			want:   NewString("4.2"),
+		},
+		{
+			name:   "number from text",
+			schema: `{"type": "number"}`,
+			value:  NewString("forty-two"),
+			want:   NewString("forty-two"),
+		},
+		{
+			name:   "number from NaN",
+			schema: `{"type": "number"}`,
+			value:  NewString("NaN"),
+			want:   NewString("NaN"),
+		},
+		{
+			name:   "number out of range",
+			schema: `{"type": "number"}`,
+			value:  NewString("1e400"),
+			want:   NewString("1e400"),
+		},
+
+		// Numbers to strings.
+		{
+			name:   "string from number",
+			schema: `{"type": "string"}`,
+			value:  NewNumberFromFloat(0.5),
+			want:   NewString("0.5"),
+		},
+		{
+			name:   "string from large number",
+			schema: `{"type": "string"}`,
+			value:  NewNumberFromFloat(1e21),
+			want:   NewString("1000000000000000000000"),
+		},
+		{
+			name:   "string from boolean",
+			schema: `{"type": "string"}`,
+			value:  NewBoolean(true),
+			want:   NewBoolean(true),
+		},
+
+		// Scalars to arrays.
+		{
+			name:   "array from scalar",
+			schema: `{"type": "array", "items": {"type": "string"}}`,
+			value:  NewString("a"),
+			want:   NewArray([]Value{NewString("a")}),
+		},
+		{
+			name:   "array from scalar with converted item",
+			schema: `{"type": "array", "items": {"type": "integer"}}`,
+			value:  NewString("42"),
+			want:   NewArray([]Value{NewNumberFromInteger(42)}),
+		},
+		{
+			name:   "array items",
+			schema: `{"type": "array", "items": {"type": "number"}}`,
+			value:  NewArray([]Value{NewNumberFromInteger(1), NewString("2"), NewString("x")}),
+			want:   NewArray([]Value{NewNumberFromInteger(1), NewNumberFromInteger(2), NewString("x")}),
+		},
+
+		// Ambiguous values are kept.
+		{
+			name:   "type union matching the value",
+			schema: `{"type": ["number", "string"]}`,
+			value:  NewString("42"),
+			want:   NewString("42"),
+		},
+		{
+			name:   "type union in order",
+			schema: `{"type": ["integer", "array"]}`,
+			value:  NewString("42"),
+			want:   NewNumberFromInteger(42),
+		},
+		{
+			name:   "null",
+			schema: `{"type": "number"}`,
+			value:  NewNull(),
+			want:   NewNull(),
+		},
+		{
+			name:   "boolean from string",
+			schema: `{"type": "boolean"}`,
+			value:  NewString("true"),
+			want:   NewString("true"),
+		},
+		{
+			name:   "no type",
+			schema: `{"anyOf": [{"type": "integer"}, {"type": "string"}]}`,
+			value:  NewString("42"),
+			want:   NewString("42"),
+		},
+
+		// Maps.
+		{
+			name: "properties",
+			schema: `{
+				"type": "object",
+				"properties": {
+					"top-k": {"type": "integer"},
+					"any": true,
+					"nested": {"type": "object", "properties": {"labels": {"type": "array", "items": {"type": "string"}}}}
+				}
+			}`,
+			value: NewMap(map[string]Value{
+				"top-k":   NewString("3"),
+				"any":     NewString("3"),
+				"unknown": NewString("3"),
+				"nested":  NewMap(map[string]Value{"labels": NewNumberFromInteger(1)}),
+			}),
+			want: NewMap(map[string]Value{
+				"top-k":   NewNumberFromInteger(3),
+				"any":     NewString("3"),
+				"unknown": NewString("3"),
+				"nested":  NewMap(map[string]Value{"labels": NewArray([]Value{NewString("1")})}),
+			}),
+		},
+		{
+			name:   "additional properties",
+			schema: `{"type": "object", "properties": {"a": {"type": "string"}}, "additionalProperties": {"type": "number"}}`,
+			value:  NewMap(map[string]Value{"a": NewString("1"), "b": NewString("2")}),
+			want:   NewMap(map[string]Value{"a": NewString("1"), "b": NewNumberFromInteger(2)}),
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			s, err := ParseSchema([]byte(tc.schema))
+			c.Assert(err, quicktest.IsNil)
+			c.Check(s.Coerce(tc.value), valueEquals, tc.want)
+		})
+	}
+
+	c.Run("unmodified value", func(c *quicktest.C) {
+		s, err := ParseSchema([]byte(`{"type": "object", "properties": {"a": {"type": "array", "items": {"type": "integer"}}, "b": {"type": "string"}}}`))
+		c.Assert(err, quicktest.IsNil)
+
+		items := NewArray([]Value{NewString("1"), NewNumberFromInteger(2)})
+		b := NewString("b")
+		v := NewMap(map[string]Value{"a": items, "b": b})
+
+		got := s.Coerce(v).(*Map)
+		c.Check(got, valueEquals, NewMap(map[string]Value{"a": NewArray([]Value{NewNumberFromInteger(1), NewNumberFromInteger(2)}), "b": NewString("b")}))
+
+		// The maps and arrays holding a converted value are copied, the
+		// rest is shared.
+		c.Check(got == v, quicktest.IsFalse)
+		c.Check(got.Fields["b"], quicktest.Equals, Value(b))
+		c.Check(v.Fields["a"], quicktest.Equals, Value(items))
+		c.Check(items.Values[0], valueEquals, NewString("1"))
+
+		// A value that matches its schema is returned as is.
+		matching := NewMap(map[string]Value{"b": b})
+		c.Check(s.Coerce(matching), quicktest.Equals, Value(matching))
+	})
+}
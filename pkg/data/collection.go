@@ -0,0 +1,222 @@
+package data
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The collection operations of the arrays read the items through a path
+// relative to each item, e.g. `score` or `box.top` for the detections of an
+// object detector. An empty path uses the items themselves. The items where
+// the path doesn't exist are handled as if it held null.
+
+// itemAt returns the value at a path of an array item, or nil if it doesn't
+// exist or holds null.
+func itemAt(item Value, path string) Value {
+	if item == nil {
+		return nil
+	}
+	v := item
+	if path != "" {
+		if !strings.HasPrefix(path, ".") && !strings.HasPrefix(path, "[") {
+			path = "." + path
+		}
+		var err error
+		if v, err = item.Get(path); err != nil {
+			return nil
+		}
+	}
+	if _, isNull := v.(*Null); isNull {
+		return nil
+	}
+	return v
+}
+
+// Sort returns the items of the array sorted by the value at a path. The
+// sort is stable and the items without a value go last in both directions.
+// The numbers, strings, date-times, durations and booleans are ordered
+// among their kind, and the kinds in that order.
+func (a *Array) Sort(path string, descending bool) *Array {
+	type keyed struct {
+		key  Value
+		item Value
+	}
+	items := make([]keyed, len(a.Values))
+	for i, item := range a.Values {
+		items[i] = keyed{key: itemAt(item, path), item: item}
+	}
+
+	slices.SortStableFunc(items, func(x, y keyed) int {
+		switch {
+		case x.key == nil && y.key == nil:
+			return 0
+		case x.key == nil:
+			return 1
+		case y.key == nil:
+			return -1
+		}
+		if descending {
+			return Compare(y.key, x.key)
+		}
+		return Compare(x.key, y.key)
+	})
+
+	sorted := NewArray(make([]Value, len(items)))
+	for i, it := range items {
+		sorted.Values[i] = it.item
+	}
+	return sorted
+}
+
+// Compare orders two values, returning -1, 0 or +1. The values of different
+// kinds are ordered by kind: numbers, strings, date-times, durations,
+// booleans and the rest, which compare as equal.
+func Compare(a, b Value) int {
+	ka, kb := compareKind(a), compareKind(b)
+	if ka != kb {
+		if ka < kb {
+			return -1
+		}
+		return 1
+	}
+
+	if c, ok := queryCompare(a, b); ok {
+		return c
+	}
+	switch a := a.(type) {
+	case *DateTime:
+		return a.Raw.Compare(b.(*DateTime).Raw)
+	case *Duration:
+		return cmp.Compare(a.Raw, b.(*Duration).Raw)
+	case *Boolean:
+		switch x, y := a.Raw, b.(*Boolean).Raw; {
+		case x == y:
+			return 0
+		case !x:
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+func compareKind(v Value) int {
+	switch v.(type) {
+	case *Number, *Decimal:
+		return 0
+	case *String:
+		return 1
+	case *DateTime:
+		return 2
+	case *Duration:
+		return 3
+	case *Boolean:
+		return 4
+	}
+	return 5
+}
+
+// Filter returns the items of the array for which the predicate is true.
+func (a *Array) Filter(keep func(item Value) bool) *Array {
+	filtered := NewArray(nil)
+	for _, item := range a.Values {
+		if keep(item) {
+			filtered.Values = append(filtered.Values, item)
+		}
+	}
+	return filtered
+}
+
+// FilterExpr returns the items of the array that match a filter expression,
+// written as the JSONPath filters of Query are, e.g. `@.score > 0.5 &&
+// @.label != 'person'`.
+func (a *Array) FilterExpr(expr string) (*Array, error) {
+	p := &queryParser{s: strings.TrimSpace(expr)}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+
+	return a.Filter(func(item Value) bool {
+		return truthy(filter.eval(item, a))
+	}), nil
+}
+
+// GroupBy groups the items of the array by the value at a path. The groups
+// are keyed by the string form of the values, e.g. `cat` or `3`, and keep
+// the order of the items. The items without a value aren't grouped.
+func (a *Array) GroupBy(path string) (*Map, error) {
+	groups := NewMap(nil)
+	for _, item := range a.Values {
+		v := itemAt(item, path)
+		if v == nil {
+			continue
+		}
+		key, err := groupKey(v)
+		if err != nil {
+			return nil, err
+		}
+
+		group, ok := groups.Fields[key].(*Array)
+		if !ok {
+			group = NewArray(nil)
+			groups.Fields[key] = group
+		}
+		group.Values = append(group.Values, item)
+	}
+	return groups, nil
+}
+
+// groupKey returns the string form of a value: the strings themselves, the
+// numbers in their shortest form and the rest as in structpb, with the maps
+// and arrays as JSON.
+func groupKey(v Value) (string, error) {
+	switch v := v.(type) {
+	case *String:
+		return v.Raw, nil
+	case *Number:
+		return strconv.FormatFloat(v.Raw, 'f', -1, 64), nil
+	}
+
+	sv, err := v.ToStructValue()
+	if err != nil {
+		return "", err
+	}
+	if s, ok := sv.AsInterface().(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(sv.AsInterface())
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Unique returns the items of the array without the ones whose value at a
+// path was already seen. The first item of each value is kept. The items
+// without a value count as a single value.
+func (a *Array) Unique(path string) (*Array, error) {
+	seen := map[string]bool{}
+	unique := NewArray(nil)
+	for _, item := range a.Values {
+		// The values are identified by their encoding, which is
+		// deterministic and distinguishes the types.
+		key, err := MarshalMsgpack(itemAt(item, path))
+		if err != nil {
+			return nil, fmt.Errorf("unique: %w", err)
+		}
+		if seen[string(key)] {
+			continue
+		}
+		seen[string(key)] = true
+		unique.Values = append(unique.Values, item)
+	}
+	return unique, nil
+}
@@ -0,0 +1,283 @@
+package data
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+)
+
+// detection returns an item of the output of an object detector.
+func detection(id string, fields map[string]Value) Value {
+	m := NewMap(fields)
+	m.Fields["id"] = NewString(id)
+	return m
+}
+
+// detectionIDs returns the ids of the detections of an array, in order.
+func detectionIDs(arr *Array) []string {
+	ids := make([]string, len(arr.Values))
+	for i, item := range arr.Values {
+		ids[i] = item.(*Map).Fields["id"].(*String).Raw
+	}
+	return ids
+}
+
+func TestArraySort(t *testing.T) {
+	c := quicktest.New(t)
+
+	detections := NewArray([]Value{
+		detection("a", map[string]Value{"score": NewNumberFromFloat(0.5), "box": NewMap(map[string]Value{"top": NewNumberFromInteger(30)})}),
+		detection("b", map[string]Value{"box": NewMap(map[string]Value{"top": NewNumberFromInteger(10)})}),
+		detection("c", map[string]Value{"score": NewNumberFromFloat(0.9), "box": NewString("unknown")}),
+		detection("d", map[string]Value{"score": NewNull(), "box": NewMap(map[string]Value{"top": NewNumberFromInteger(10)})}),
+		detection("e", map[string]Value{"score": NewNumberFromFloat(0.5), "box": NewMap(nil)}),
+		detection("f", map[string]Value{"score": NewString("high"), "box": NewMap(map[string]Value{"top": NewNumberFromInteger(20)})}),
+		detection("g", map[string]Value{"score": NewBoolean(true)}),
+		detection("h", map[string]Value{"score": NewDecimal(big.NewRat(7, 10)), "box": NewMap(map[string]Value{"top": NewNumberFromInteger(10)})}),
+	})
+
+	testcases := []struct {
+		name       string
+		path       string
+		descending bool
+		want       []string
+	}{
+		{
+			// The numbers and decimals are compared by value, then come
+			// the strings and the booleans. The ties keep their order and
+			// the missing and null values go last.
+			name: "ascending",
+			path: "score",
+			want: []string{"a", "e", "h", "c", "f", "g", "b", "d"},
+		},
+		{
+			name:       "descending",
+			path:       "score",
+			descending: true,
+			want:       []string{"g", "f", "c", "h", "a", "e", "b", "d"},
+		},
+		{
+			// The sub-paths that go through a value of another type are
+			// missing too.
+			name: "nested path",
+			path: "box.top",
+			want: []string{"b", "d", "h", "f", "a", "c", "e", "g"},
+		},
+		{
+			name:       "nested path descending",
+			path:       ".box.top",
+			descending: true,
+			want:       []string{"a", "f", "b", "d", "h", "c", "e", "g"},
+		},
+		{
+			name: "missing path",
+			path: "label",
+			want: []string{"a", "b", "c", "d", "e", "f", "g", "h"},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			sorted := detections.Sort(tc.path, tc.descending)
+			c.Check(detectionIDs(sorted), quicktest.DeepEquals, tc.want)
+
+			// The array isn't modified.
+			c.Check(detectionIDs(detections), quicktest.DeepEquals, []string{"a", "b", "c", "d", "e", "f", "g", "h"})
+		})
+	}
+
+	c.Run("mixed types", func(c *quicktest.C) {
+		when := NewDateTime(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+		values := NewArray([]Value{
+			NewDuration(time.Minute),
+			NewString("b"),
+			NewNull(),
+			NewNumberFromInteger(2),
+			NewMap(nil),
+			when,
+			NewBoolean(true),
+			NewString("a"),
+			NewBoolean(false),
+			NewDecimal(big.NewRat(3, 2)),
+			NewDuration(time.Second),
+			NewNumberFromInteger(1),
+		})
+
+		c.Check(values.Sort("", false), valueEquals, NewArray([]Value{
+			NewNumberFromInteger(1),
+			NewDecimal(big.NewRat(3, 2)),
+			NewNumberFromInteger(2),
+			NewString("a"),
+			NewString("b"),
+			when,
+			NewDuration(time.Second),
+			NewDuration(time.Minute),
+			NewBoolean(false),
+			NewBoolean(true),
+			NewMap(nil),
+			NewNull(),
+		}))
+	})
+}
+
+func TestCompare(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		name string
+		a, b Value
+		want int
+	}{
+		{name: "numbers", a: NewNumberFromInteger(1), b: NewNumberFromFloat(1.5), want: -1},
+		{name: "number and decimal", a: NewDecimal(big.NewRat(3, 2)), b: NewNumberFromFloat(1.5), want: 0},
+		{name: "decimals", a: NewDecimal(big.NewRat(1, 3)), b: NewDecimal(big.NewRat(1, 4)), want: 1},
+		{name: "number and string", a: NewString("1"), b: NewNumberFromInteger(2), want: 1},
+		{name: "strings", a: NewString("B"), b: NewString("a"), want: -1},
+		{name: "booleans", a: NewBoolean(true), b: NewBoolean(false), want: 1},
+		{name: "string and boolean", a: NewString("z"), b: NewBoolean(false), want: -1},
+		{name: "maps", a: NewMap(map[string]Value{"a": NewNull()}), b: NewArray(nil), want: 0},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			c.Check(Compare(tc.a, tc.b), quicktest.Equals, tc.want)
+			c.Check(Compare(tc.b, tc.a), quicktest.Equals, -tc.want)
+		})
+	}
+}
+
+func TestArrayFilterExpr(t *testing.T) {
+	c := quicktest.New(t)
+
+	detections := NewArray([]Value{
+		detection("a", map[string]Value{"label": NewString("cat"), "score": NewNumberFromFloat(0.5)}),
+		detection("b", map[string]Value{"label": NewString("dog")}),
+		detection("c", map[string]Value{"label": NewString("cat"), "score": NewNumberFromFloat(0.9), "box": NewMap(map[string]Value{"top": NewNumberFromInteger(10)})}),
+		detection("d", map[string]Value{"label": NewString("person"), "score": NewNull()}),
+		detection("e", map[string]Value{"label": NewString("dog"), "score": NewDecimal(big.NewRat(7, 10)), "box": NewString("unknown")}),
+	})
+
+	testcases := []struct {
+		expr    string
+		want    []string
+		wantErr string
+	}{
+		{expr: "@.score > 0.6", want: []string{"c", "e"}},
+		{expr: "@.score >= 0.5 && @.label != 'person'", want: []string{"a", "c", "e"}},
+		{expr: `@.label == "dog" || @.score > 0.8`, want: []string{"b", "c", "e"}},
+		// The missing and null values are falsy.
+		{expr: "@.score", want: []string{"a", "c", "e"}},
+		{expr: "!@.score", want: []string{"b", "d"}},
+		{expr: "@.box.top < 20", want: []string{"c"}},
+		{expr: "@.label =~ '^d'", want: []string{"b", "e"}},
+		{expr: "@.label == 'bird'", want: []string{}},
+		{expr: "@.score >", wantErr: ".*"},
+		{expr: "@.score > 0.5 0.6", wantErr: `.*unexpected "0.6".*`},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.expr, func(c *quicktest.C) {
+			got, err := detections.FilterExpr(tc.expr)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(detectionIDs(got), quicktest.DeepEquals, tc.want)
+		})
+	}
+}
+
+func TestArrayGroupBy(t *testing.T) {
+	c := quicktest.New(t)
+
+	detections := NewArray([]Value{
+		detection("a", map[string]Value{"label": NewString("cat"), "count": NewNumberFromInteger(3)}),
+		detection("b", map[string]Value{"label": NewString("dog"), "count": NewNumberFromFloat(0.5)}),
+		detection("c", map[string]Value{"label": NewString("cat"), "count": NewString("3")}),
+		detection("d", map[string]Value{"label": NewNull(), "count": NewBoolean(true)}),
+		detection("e", map[string]Value{"count": NewMap(map[string]Value{"cats": NewNumberFromInteger(1)})}),
+		detection("f", map[string]Value{"label": NewString("cat"), "count": NewArray([]Value{NewNumberFromInteger(1)})}),
+	})
+
+	testcases := []struct {
+		path string
+		want map[string][]string
+	}{
+		{
+			// The items without a value aren't grouped.
+			path: "label",
+			want: map[string][]string{"cat": {"a", "c", "f"}, "dog": {"b"}},
+		},
+		{
+			// The keys are the string form of the values, so a number and
+			// the string that writes it are in the same group.
+			path: "count",
+			want: map[string][]string{
+				"3":          {"a", "c"},
+				"0.5":        {"b"},
+				"true":       {"d"},
+				`{"cats":1}`: {"e"},
+				"[1]":        {"f"},
+			},
+		},
+		{
+			path: "missing",
+			want: map[string][]string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.path, func(c *quicktest.C) {
+			groups, err := detections.GroupBy(tc.path)
+			c.Assert(err, quicktest.IsNil)
+
+			got := map[string][]string{}
+			for k, v := range groups.Fields {
+				got[k] = detectionIDs(v.(*Array))
+			}
+			c.Check(got, quicktest.DeepEquals, tc.want)
+		})
+	}
+
+	c.Run("items", func(c *quicktest.C) {
+		groups, err := NewArray([]Value{NewString("a"), NewNull(), NewNumberFromInteger(1), nil, NewString("a")}).GroupBy("")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(groups, valueEquals, NewMap(map[string]Value{
+			"a": NewArray([]Value{NewString("a"), NewString("a")}),
+			"1": NewArray([]Value{NewNumberFromInteger(1)}),
+		}))
+	})
+}
+
+func TestArrayUnique(t *testing.T) {
+	c := quicktest.New(t)
+
+	detections := NewArray([]Value{
+		detection("a", map[string]Value{"label": NewString("cat")}),
+		detection("b", map[string]Value{"label": NewString("dog")}),
+		detection("c", map[string]Value{"label": NewString("cat")}),
+		detection("d", map[string]Value{}),
+		detection("e", map[string]Value{"label": NewNull()}),
+		detection("f", map[string]Value{"label": NewNumberFromInteger(1)}),
+		detection("g", map[string]Value{"label": NewString("1")}),
+		detection("h", map[string]Value{"label": NewMap(map[string]Value{"a": NewString("a"), "b": NewString("b")})}),
+		detection("i", map[string]Value{"label": NewMap(map[string]Value{"b": NewString("b"), "a": NewString("a")})}),
+	})
+
+	// The first item of each value is kept, the missing and null values
+	// are the same value, and the values of different types aren't.
+	got, err := detections.Unique("label")
+	c.Assert(err, quicktest.IsNil)
+	c.Check(detectionIDs(got), quicktest.DeepEquals, []string{"a", "b", "d", "f", "g", "h"})
+
+	got, err = detections.Unique("")
+	c.Assert(err, quicktest.IsNil)
+	c.Check(got.Values, quicktest.HasLen, len(detections.Values))
+
+	got, err = NewArray([]Value{NewString("a"), NewString("b"), NewString("a"), nil, NewNull()}).Unique("")
+	c.Assert(err, quicktest.IsNil)
+	c.Check(got, valueEquals, NewArray([]Value{NewString("a"), NewString("b"), nil}))
+}
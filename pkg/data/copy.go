@@ -0,0 +1,130 @@
+package data
+
+import (
+	"bytes"
+	"maps"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// DeepCopy returns a copy of a value that shares nothing with it: the maps,
+// arrays and contents are copied, so the copy can be modified without
+// affecting the other holders of the value. The copy isn't shared nor
+// frozen. The files in the blob store keep referencing the same blob, as
+// its content never changes.
+func DeepCopy(v Value) Value {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case *Null:
+		return NewNull()
+	case *Boolean:
+		return NewBoolean(v.Raw)
+	case *Number:
+		return NewNumberFromFloat(v.Raw)
+	case *String:
+		return NewString(v.Raw)
+	case *ByteArray:
+		return NewByteArray(bytes.Clone(v.Raw))
+	case *Decimal:
+		return &Decimal{Raw: new(big.Rat).Set(v.GetDecimal())}
+	case *DateTime:
+		return NewDateTime(v.Raw)
+	case *Duration:
+		return NewDuration(v.Raw)
+	case *Proto:
+		return &Proto{Raw: proto.Clone(v.Raw).(*anypb.Any)}
+	case *Binary:
+		return NewBinary(bytes.Clone(v.Raw), v.ContentType, v.FileName)
+	case *FileRef:
+		ref := *v
+		ref.Metadata = maps.Clone(v.Metadata)
+		return &ref
+	case *File:
+		return copyFile(v)
+	case *Image:
+		return &Image{File: *copyFile(&v.File), Width: v.Width, Height: v.Height}
+	case *Video:
+		return &Video{File: *copyFile(&v.File)}
+	case *Audio:
+		return &Audio{File: *copyFile(&v.File)}
+	case *Document:
+		return &Document{File: *copyFile(&v.File)}
+
+	case *Map:
+		m := &Map{Fields: make(map[string]Value, len(v.Fields))}
+		for k, f := range v.Fields {
+			m.Fields[k] = DeepCopy(f)
+		}
+		return m
+	case *Array:
+		a := &Array{Values: make([]Value, len(v.Values))}
+		for i, item := range v.Values {
+			a.Values[i] = DeepCopy(item)
+		}
+		return a
+	}
+	return v
+}
+
+func copyFile(f *File) *File {
+	c := &File{
+		Raw:         bytes.Clone(f.Raw),
+		ContentType: f.ContentType,
+		FileName:    f.FileName,
+		SourceURL:   f.SourceURL,
+		blob:        f.blob,
+	}
+	if f.Cache != nil {
+		c.Cache = make(map[string][]byte, len(f.Cache))
+		for k, b := range f.Cache {
+			c.Cache[k] = bytes.Clone(b)
+		}
+	}
+	return c
+}
+
+// Freeze marks a value, and the values it holds, as frozen: a promise to
+// every holder that it never changes. A frozen value is shared, so the
+// maps and arrays can only be modified through a writable copy: modifying
+// them in place, e.g. with Set, fails with ErrFrozenValue.
+//
+// The values that several components hold (e.g. an output referenced by the
+// input of the downstream components) are frozen, so a component can't
+// modify what the others read. A value that must be modified in place
+// afterwards can be copied with DeepCopy.
+func Freeze(v Value) Value {
+	switch v := v.(type) {
+	case *Map:
+		if v.frozen {
+			return v
+		}
+		v.frozen = true
+		for _, f := range v.Fields {
+			Freeze(f)
+		}
+	case *Array:
+		if v.frozen {
+			return v
+		}
+		v.frozen = true
+		for _, item := range v.Values {
+			Freeze(item)
+		}
+	}
+	return Share(v)
+}
+
+// IsFrozen tells whether a map or an array is frozen. The files are frozen
+// as soon as they're shared.
+func IsFrozen(v Value) bool {
+	switch v := v.(type) {
+	case *Map:
+		return v.frozen
+	case *Array:
+		return v.frozen
+	}
+	return IsShared(v)
+}
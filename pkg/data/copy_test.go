@@ -0,0 +1,105 @@
+package data
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDeepCopy(t *testing.T) {
+	c := quicktest.New(t)
+
+	file := &File{Raw: []byte("hello"), ContentType: "text/plain", Cache: map[string][]byte{"text/plain": []byte("hello")}}
+	ref := &FileRef{URI: "s3://bucket/key", Metadata: map[string]string{"etag": "abc"}}
+	decimal := NewDecimal(big.NewRat(1, 3))
+	orig := NewMap(map[string]Value{
+		"file":    file,
+		"ref":     ref,
+		"decimal": decimal,
+		"bytes":   NewByteArray([]byte{1, 2}),
+		"when":    NewDateTime(time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)),
+		"list":    NewArray([]Value{NewString("a"), NewMap(map[string]Value{"n": NewNumberFromInteger(1)})}),
+	})
+	Freeze(orig)
+
+	cp := DeepCopy(orig).(*Map)
+	c.Check(cp, valueEquals, orig)
+	c.Check(IsShared(cp), quicktest.IsFalse)
+	c.Check(IsFrozen(cp), quicktest.IsFalse)
+
+	// The copy can be modified without affecting the original value.
+	c.Assert(cp.Set("list[1].n", NewNumberFromInteger(2)), quicktest.IsNil)
+	c.Assert(cp.Set("list[0]", NewString("b")), quicktest.IsNil)
+	cp.Fields["file"].(*File).Raw[0] = 'j'
+	cp.Fields["ref"].(*FileRef).Metadata["etag"] = "def"
+	cp.Fields["decimal"].(*Decimal).Raw.SetInt64(2)
+	cp.Fields["bytes"].(*ByteArray).Raw[0] = 9
+
+	n, err := orig.Get("list[1].n")
+	c.Assert(err, quicktest.IsNil)
+	c.Check(n, quicktest.DeepEquals, NewNumberFromInteger(1))
+	s, err := orig.Get("list[0]")
+	c.Assert(err, quicktest.IsNil)
+	c.Check(s, quicktest.DeepEquals, NewString("a"))
+	c.Check(string(file.Raw), quicktest.Equals, "hello")
+	c.Check(ref.Metadata["etag"], quicktest.Equals, "abc")
+	c.Check(decimal.Raw.RatString(), quicktest.Equals, "1/3")
+	c.Check(orig.Fields["bytes"].(*ByteArray).Raw, quicktest.DeepEquals, []byte{1, 2})
+
+	c.Check(DeepCopy(nil), quicktest.IsNil)
+}
+
+func TestFreeze(t *testing.T) {
+	c := quicktest.New(t)
+
+	newValue := func() (*Map, *Array, *File) {
+		file := &File{Raw: []byte("hello"), ContentType: "text/plain"}
+		list := NewArray([]Value{NewString("a"), file})
+		return NewMap(map[string]Value{"list": list}), list, file
+	}
+
+	m, list, file := newValue()
+	c.Check(Freeze(m), quicktest.Equals, Value(m))
+	for _, v := range []Value{m, list, file} {
+		c.Check(IsFrozen(v), quicktest.IsTrue)
+		c.Check(IsShared(v), quicktest.IsTrue)
+	}
+
+	c.Run("nok - set", func(c *quicktest.C) {
+		c.Check(m.Set("list[0]", NewString("b")), quicktest.ErrorIs, ErrFrozenValue)
+		c.Check(m.Set("other", NewString("b")), quicktest.ErrorIs, ErrFrozenValue)
+		c.Check(list.Set("[0]", NewString("b")), quicktest.ErrorIs, ErrFrozenValue)
+		c.Check(m.Fields, quicktest.HasLen, 1)
+		c.Check(list.Values[0], quicktest.DeepEquals, NewString("a"))
+	})
+
+	c.Run("nok - decode", func(c *quicktest.C) {
+		c.Check(yaml.Unmarshal([]byte("other: b"), m), quicktest.ErrorIs, ErrFrozenValue)
+		c.Check(yaml.Unmarshal([]byte("[b]"), list), quicktest.ErrorIs, ErrFrozenValue)
+		c.Check(m.Fields, quicktest.HasLen, 1)
+		c.Check(list.Values, quicktest.HasLen, 2)
+	})
+
+	c.Run("writable copy", func(c *quicktest.C) {
+		w := m.Writable()
+		c.Check(w, quicktest.Not(quicktest.Equals), m)
+		c.Check(IsFrozen(w), quicktest.IsFalse)
+		c.Assert(w.Set("list[0]", NewString("b")), quicktest.IsNil)
+
+		got, err := w.Get("list[0]")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, quicktest.DeepEquals, NewString("b"))
+		c.Check(list.Values[0], quicktest.DeepEquals, NewString("a"))
+	})
+
+	c.Run("shared values aren't frozen", func(c *quicktest.C) {
+		m, list, _ := newValue()
+		Share(m)
+		c.Check(IsFrozen(m), quicktest.IsFalse)
+		c.Check(IsFrozen(list), quicktest.IsFalse)
+		c.Check(m.Set("other", NewString("b")), quicktest.ErrorIs, ErrSharedValue)
+	})
+}
@@ -1,9 +1,11 @@
 package data
 
 import (
-	"encoding/gob"
 	"fmt"
+	"math/big"
+	"time"
 
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -27,6 +29,14 @@ func NewValue(in any) (val Value, err error) {
 		return NewNumberFromInteger(in), nil
 	case string:
 		return NewString(in), nil
+	case time.Time:
+		return NewDateTime(in), nil
+	case time.Duration:
+		return NewDuration(in), nil
+	case *big.Rat:
+		return NewDecimal(in), nil
+	case proto.Message:
+		return NewProto(in)
 	case []any:
 		arr := NewArray(make([]Value, len(in)))
 		for i, item := range in {
@@ -124,18 +134,3 @@ func NewValueFromStruct(in *structpb.Value) (val Value, err error) {
 
 	return nil, fmt.Errorf("NewValueFromStruct error")
 }
-
-func init() {
-	gob.Register(&Map{})
-	gob.Register(&Array{})
-	gob.Register(&Null{})
-	gob.Register(&Boolean{})
-	gob.Register(&Number{})
-	gob.Register(&String{})
-	gob.Register(&ByteArray{})
-	gob.Register(&File{})
-	gob.Register(&Image{})
-	gob.Register(&Video{})
-	gob.Register(&Audio{})
-	gob.Register(&Document{})
-}
@@ -0,0 +1,152 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DateTime is an instant with the time zone it's expressed in. It's
+// converted to structpb as an RFC 3339 string, so it round-trips through the
+// component inputs and outputs as the strings they already use.
+type DateTime struct {
+	Raw time.Time
+}
+
+// dateTimeLayouts are the layouts ParseDateTime accepts, from the most to the
+// least precise. The ones without an offset are read in the location passed
+// to the parser.
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	time.DateTime,
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+func NewDateTime(t time.Time) *DateTime {
+	return &DateTime{Raw: t}
+}
+
+// ParseDateTime parses a date or a datetime. The strings without an offset
+// are read in loc, or in UTC if loc is nil.
+func ParseDateTime(s string, loc *time.Location) (*DateTime, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	s = strings.TrimSpace(s)
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return NewDateTime(t), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid datetime %q", s)
+}
+
+// LoadLocation returns the location of an IANA time zone name, e.g.
+// Europe/Madrid, or of a fixed offset, e.g. +02:00.
+func LoadLocation(name string) (*time.Location, error) {
+	if t, err := time.Parse("-07:00", name); err == nil {
+		_, offset := t.Zone()
+		return time.FixedZone(name, offset), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q", name)
+	}
+	return loc, nil
+}
+
+func (DateTime) isValue() {}
+
+func (d *DateTime) GetDateTime() time.Time {
+	return d.Raw
+}
+
+// Format returns the textual representation of the datetime in a Go layout.
+func (d *DateTime) Format(layout string) string {
+	return d.Raw.Format(layout)
+}
+
+// In returns the same instant in another time zone.
+func (d *DateTime) In(tz string) (*DateTime, error) {
+	loc, err := LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+	return NewDateTime(d.Raw.In(loc)), nil
+}
+
+// Add returns the datetime shifted by a duration.
+func (d *DateTime) Add(dur time.Duration) *DateTime {
+	return NewDateTime(d.Raw.Add(dur))
+}
+
+// AddDate returns the datetime shifted by a number of years, months and days
+// in its time zone, so the wall clock is kept across DST changes.
+func (d *DateTime) AddDate(years, months, days int) *DateTime {
+	return NewDateTime(d.Raw.AddDate(years, months, days))
+}
+
+// Sub returns the duration between two datetimes.
+func (d *DateTime) Sub(o *DateTime) time.Duration {
+	return d.Raw.Sub(o.Raw)
+}
+
+// Compare returns -1 if the datetime is before another one, +1 if it's after
+// and 0 if both are the same instant, regardless of their time zones.
+func (d *DateTime) Compare(o *DateTime) int {
+	return d.Raw.Compare(o.Raw)
+}
+
+// Equal tells whether two datetimes are the same instant.
+func (d *DateTime) Equal(o *DateTime) bool {
+	return d.Raw.Equal(o.Raw)
+}
+
+func (d *DateTime) String() string {
+	return d.Raw.Format(time.RFC3339Nano)
+}
+
+func (d *DateTime) Get(path string) (v Value, err error) {
+	switch {
+	case comparePath(path, ""):
+		return d, nil
+	case comparePath(path, ".year"):
+		return NewNumberFromInteger(d.Raw.Year()), nil
+	case comparePath(path, ".month"):
+		return NewNumberFromInteger(int(d.Raw.Month())), nil
+	case comparePath(path, ".day"):
+		return NewNumberFromInteger(d.Raw.Day()), nil
+	case comparePath(path, ".hour"):
+		return NewNumberFromInteger(d.Raw.Hour()), nil
+	case comparePath(path, ".minute"):
+		return NewNumberFromInteger(d.Raw.Minute()), nil
+	case comparePath(path, ".second"):
+		return NewNumberFromInteger(d.Raw.Second()), nil
+	case comparePath(path, ".weekday"):
+		return NewString(d.Raw.Weekday().String()), nil
+	case comparePath(path, ".unix"):
+		return NewNumberFromInteger(int(d.Raw.Unix())), nil
+	case comparePath(path, ".timezone"):
+		return NewString(d.Raw.Location().String()), nil
+	case comparePath(path, ".date"):
+		return NewString(d.Raw.Format(time.DateOnly)), nil
+	}
+	return nil, fmt.Errorf("wrong path %s for DateTime", path)
+}
+
+func (d DateTime) ToStructValue() (v *structpb.Value, err error) {
+	v = structpb.NewStringValue(d.Raw.Format(time.RFC3339Nano))
+	return
+}
+
+func (d *DateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
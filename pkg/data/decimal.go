@@ -0,0 +1,219 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Decimal is an exact rational number, meant for the values that can't lose
+// precision, e.g. amounts of money. It's converted to structpb and to JSON as
+// a decimal string, as a float64 would round it. The zero value is zero.
+type Decimal struct {
+	Raw *big.Rat
+}
+
+// decimalPrecision is the number of decimals with which the numbers that
+// don't have a finite decimal expansion (e.g. 1/3) are written.
+const decimalPrecision = 34
+
+var bigTen = big.NewInt(10)
+
+func NewDecimal(r *big.Rat) *Decimal {
+	return &Decimal{Raw: new(big.Rat).Set(r)}
+}
+
+func NewDecimalFromInteger(i int64) *Decimal {
+	return &Decimal{Raw: new(big.Rat).SetInt64(i)}
+}
+
+// NewDecimalFromFloat returns the decimal with the shortest representation
+// that rounds to the float, e.g. 0.1 instead of
+// 0.1000000000000000055511151231257827.
+func NewDecimalFromFloat(f float64) (*Decimal, error) {
+	return ParseDecimal(fmt.Sprintf("%v", f))
+}
+
+// ParseDecimal parses a decimal number (e.g. -12.50), a number in scientific
+// notation (e.g. 1.5e-3) or a fraction (e.g. 1/3).
+func ParseDecimal(s string) (*Decimal, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", s)
+	}
+	return &Decimal{Raw: r}, nil
+}
+
+func (Decimal) isValue() {}
+
+func (d *Decimal) GetDecimal() *big.Rat {
+	return d.rat()
+}
+
+// rat returns the value of the decimal. The zero value of Decimal, whose Raw
+// is nil, is zero.
+func (d *Decimal) rat() *big.Rat {
+	if d.Raw == nil {
+		return new(big.Rat)
+	}
+	return d.Raw
+}
+
+// Float returns the float64 closest to the decimal.
+func (d *Decimal) Float() float64 {
+	f, _ := d.rat().Float64()
+	return f
+}
+
+// IsInteger tells whether the decimal has no fractional part.
+func (d *Decimal) IsInteger() bool {
+	return d.rat().IsInt()
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the decimal.
+func (d *Decimal) Sign() int {
+	return d.rat().Sign()
+}
+
+func (d *Decimal) Add(o *Decimal) *Decimal {
+	return &Decimal{Raw: new(big.Rat).Add(d.rat(), o.rat())}
+}
+
+func (d *Decimal) Sub(o *Decimal) *Decimal {
+	return &Decimal{Raw: new(big.Rat).Sub(d.rat(), o.rat())}
+}
+
+func (d *Decimal) Mul(o *Decimal) *Decimal {
+	return &Decimal{Raw: new(big.Rat).Mul(d.rat(), o.rat())}
+}
+
+// Div returns the quotient of two decimals. The result is exact, so 1/3 is
+// kept as a fraction until it's rounded or written.
+func (d *Decimal) Div(o *Decimal) (*Decimal, error) {
+	if o.rat().Sign() == 0 {
+		return nil, fmt.Errorf("division by zero")
+	}
+	return &Decimal{Raw: new(big.Rat).Quo(d.rat(), o.rat())}, nil
+}
+
+func (d *Decimal) Neg() *Decimal {
+	return &Decimal{Raw: new(big.Rat).Neg(d.rat())}
+}
+
+func (d *Decimal) Abs() *Decimal {
+	return &Decimal{Raw: new(big.Rat).Abs(d.rat())}
+}
+
+// Compare returns -1 if the decimal is lower than another one, +1 if it's
+// greater and 0 if both are equal.
+func (d *Decimal) Compare(o *Decimal) int {
+	return d.rat().Cmp(o.rat())
+}
+
+// Round rounds the decimal to a number of decimal places, with the halves
+// rounded away from zero. A negative number of places rounds to the left of
+// the decimal point, e.g. -2 rounds to the hundreds.
+func (d *Decimal) Round(places int) *Decimal {
+	exp := int64(places)
+	if exp < 0 {
+		exp = -exp
+	}
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(bigTen, big.NewInt(exp), nil))
+	if places < 0 {
+		scale.Inv(scale)
+	}
+
+	// x = |d| * 10^places + 1/2, truncated.
+	x := new(big.Rat).Mul(new(big.Rat).Abs(d.rat()), scale)
+	x.Add(x, big.NewRat(1, 2))
+	q := new(big.Int).Quo(x.Num(), x.Denom())
+	if d.rat().Sign() < 0 {
+		q.Neg(q)
+	}
+
+	return &Decimal{Raw: new(big.Rat).Quo(new(big.Rat).SetInt(q), scale)}
+}
+
+// Scale returns the number of decimals needed to write the decimal exactly,
+// or -1 if its decimal expansion is infinite (e.g. 1/3).
+func (d *Decimal) Scale() int {
+	// A fraction has a finite expansion when its reduced denominator only
+	// has 2 and 5 as prime factors.
+	den := new(big.Int).Set(d.rat().Denom())
+	twos, fives := 0, 0
+	for _, f := range []struct {
+		factor *big.Int
+		count  *int
+	}{{big.NewInt(2), &twos}, {big.NewInt(5), &fives}} {
+		m := new(big.Int)
+		for {
+			q, r := new(big.Int).QuoRem(den, f.factor, m)
+			if r.Sign() != 0 {
+				break
+			}
+			den = q
+			*f.count++
+		}
+	}
+	if den.Cmp(big.NewInt(1)) != 0 {
+		return -1
+	}
+	return max(twos, fives)
+}
+
+// String writes the decimal without exponent. The numbers with an infinite
+// decimal expansion are rounded to 34 decimals.
+func (d *Decimal) String() string {
+	scale := d.Scale()
+	if scale < 0 {
+		return strings.TrimRight(strings.TrimRight(d.rat().FloatString(decimalPrecision), "0"), ".")
+	}
+	return d.rat().FloatString(scale)
+}
+
+// StringFixed writes the decimal rounded to a number of decimals, keeping
+// the trailing zeros, e.g. 12.50.
+func (d *Decimal) StringFixed(places int) string {
+	return d.Round(places).Raw.FloatString(max(places, 0))
+}
+
+func (d *Decimal) Get(path string) (v Value, err error) {
+	switch {
+	case comparePath(path, ""):
+		return d, nil
+	case comparePath(path, ".string"):
+		return NewString(d.String()), nil
+	case comparePath(path, ".number"):
+		return NewNumberFromFloat(d.Float()), nil
+	case comparePath(path, ".numerator"):
+		return NewString(d.rat().Num().String()), nil
+	case comparePath(path, ".denominator"):
+		return NewString(d.rat().Denom().String()), nil
+	}
+	return nil, fmt.Errorf("wrong path %s for Decimal", path)
+}
+
+func (d Decimal) ToStructValue() (v *structpb.Value, err error) {
+	v = structpb.NewStringValue(d.String())
+	return
+}
+
+// MarshalJSON writes the decimal as a JSON string, as its structpb form, so
+// the JSON decoders that read numbers as float64 don't round it.
+func (d *Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON reads a decimal from a JSON number or string.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	d.Raw = parsed.Raw
+	return nil
+}
@@ -0,0 +1,76 @@
+package data
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestDecimal(t *testing.T) {
+	c := quicktest.New(t)
+
+	c.Run("zero value", func(c *quicktest.C) {
+		var d Decimal
+		c.Check(d.String(), quicktest.Equals, "0")
+		c.Check(d.Float(), quicktest.Equals, 0.0)
+		c.Check(d.Sign(), quicktest.Equals, 0)
+		c.Check(d.IsInteger(), quicktest.IsTrue)
+		c.Check(d.Scale(), quicktest.Equals, 0)
+		c.Check(d.Round(2).String(), quicktest.Equals, "0")
+		c.Check(d.Add(NewDecimalFromInteger(2)).String(), quicktest.Equals, "2")
+		c.Check(d.Compare(NewDecimalFromInteger(0)), quicktest.Equals, 0)
+
+		_, err := NewDecimalFromInteger(1).Div(&d)
+		c.Check(err, quicktest.ErrorMatches, "division by zero")
+
+		numerator, err := d.Get(".numerator")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(numerator, quicktest.DeepEquals, NewString("0"))
+
+		pbv, err := d.ToStructValue()
+		c.Assert(err, quicktest.IsNil)
+		c.Check(pbv.GetStringValue(), quicktest.Equals, "0")
+	})
+
+	c.Run("JSON", func(c *quicktest.C) {
+		testcases := []struct {
+			d    *Decimal
+			want string
+		}{
+			{d: NewDecimal(big.NewRat(1, 3)), want: `"0.3333333333333333333333333333333333"`},
+			{d: NewDecimal(big.NewRat(-25, 2)), want: `"-12.5"`},
+			{d: NewDecimalFromInteger(12345678901234567), want: `"12345678901234567"`},
+			{d: &Decimal{}, want: `"0"`},
+		}
+
+		for _, tc := range testcases {
+			c.Run(tc.want, func(c *quicktest.C) {
+				b, err := json.Marshal(tc.d)
+				c.Assert(err, quicktest.IsNil)
+				c.Check(string(b), quicktest.Equals, tc.want)
+
+				// The JSON form matches the structpb one.
+				pbv, err := tc.d.ToStructValue()
+				c.Assert(err, quicktest.IsNil)
+				c.Check(string(b), quicktest.JSONEquals, pbv.AsInterface())
+
+				var got Decimal
+				c.Assert(json.Unmarshal(b, &got), quicktest.IsNil)
+				c.Check(got.String(), quicktest.Equals, tc.d.String())
+			})
+		}
+
+		c.Run("from number", func(c *quicktest.C) {
+			var got Decimal
+			c.Assert(json.Unmarshal([]byte("0.1"), &got), quicktest.IsNil)
+			c.Check(got.Compare(NewDecimal(big.NewRat(1, 10))), quicktest.Equals, 0)
+		})
+
+		c.Run("nok - invalid", func(c *quicktest.C) {
+			var got Decimal
+			c.Check(json.Unmarshal([]byte(`"ten"`), &got), quicktest.ErrorMatches, `invalid decimal "ten"`)
+		})
+	})
+}
@@ -0,0 +1,239 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ChangeType is the kind of a change between two values.
+type ChangeType string
+
+const (
+	// ChangeAdded is a map field or an array item that only exists in the
+	// new value.
+	ChangeAdded ChangeType = "added"
+	// ChangeRemoved is a map field or an array item that only exists in the
+	// old value.
+	ChangeRemoved ChangeType = "removed"
+	// ChangeModified is a value that exists in both but differs. Two maps or
+	// two arrays are compared field by field, so they're never modified as a
+	// whole.
+	ChangeModified ChangeType = "modified"
+)
+
+// PathSegment is a step of the path of a change: a map key or an array
+// index.
+type PathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// ChangePath is the location of a change from the root of the compared
+// values.
+type ChangePath []PathSegment
+
+// String writes the path as the references of the recipes, e.g.
+// objects[0].label.
+func (p ChangePath) String() string {
+	var b strings.Builder
+	for _, s := range p {
+		switch {
+		case s.IsIndex:
+			fmt.Fprintf(&b, "[%d]", s.Index)
+		case b.Len() > 0:
+			b.WriteString("." + s.Key)
+		default:
+			b.WriteString(s.Key)
+		}
+	}
+	return b.String()
+}
+
+// Pointer writes the path as an RFC 6901 JSON Pointer, e.g.
+// /objects/0/label.
+func (p ChangePath) Pointer() string {
+	var b strings.Builder
+	for _, s := range p {
+		b.WriteByte('/')
+		if s.IsIndex {
+			b.WriteString(strconv.Itoa(s.Index))
+			continue
+		}
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(s.Key, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+func (p ChangePath) withKey(k string) ChangePath {
+	return append(p[:len(p):len(p)], PathSegment{Key: k})
+}
+
+func (p ChangePath) withIndex(i int) ChangePath {
+	return append(p[:len(p):len(p)], PathSegment{Index: i, IsIndex: true})
+}
+
+// Change is a difference between two values. From is nil for the added
+// values and To is nil for the removed ones.
+type Change struct {
+	Type ChangeType
+	Path ChangePath
+	From Value
+	To   Value
+}
+
+// Changeset holds the changes that turn a value into another one.
+type Changeset []Change
+
+// Diff compares two values structurally. The changes are sorted so they can
+// be applied in order, as JSON Patch operations are: the fields of a map
+// are sorted by key, the removed items of an array go from the last one and
+// the added ones are appended after them.
+func Diff(from, to Value) Changeset {
+	var changes Changeset
+	diff(nil, from, to, &changes)
+	return changes
+}
+
+func diff(path ChangePath, from, to Value, changes *Changeset) {
+	switch to := to.(type) {
+	case *Map:
+		from, ok := from.(*Map)
+		if !ok {
+			break
+		}
+
+		removed := make([]string, 0)
+		for k := range from.Fields {
+			if _, ok := to.Fields[k]; !ok {
+				removed = append(removed, k)
+			}
+		}
+		sort.Strings(removed)
+		for _, k := range removed {
+			*changes = append(*changes, Change{Type: ChangeRemoved, Path: path.withKey(k), From: from.Fields[k]})
+		}
+
+		keys := make([]string, 0, len(to.Fields))
+		for k := range to.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if f, ok := from.Fields[k]; ok {
+				diff(path.withKey(k), f, to.Fields[k], changes)
+			} else {
+				*changes = append(*changes, Change{Type: ChangeAdded, Path: path.withKey(k), To: to.Fields[k]})
+			}
+		}
+		return
+
+	case *Array:
+		from, ok := from.(*Array)
+		if !ok {
+			break
+		}
+
+		common := min(len(from.Values), len(to.Values))
+		for i := range common {
+			diff(path.withIndex(i), from.Values[i], to.Values[i], changes)
+		}
+		for i := len(from.Values) - 1; i >= common; i-- {
+			*changes = append(*changes, Change{Type: ChangeRemoved, Path: path.withIndex(i), From: from.Values[i]})
+		}
+		for i := common; i < len(to.Values); i++ {
+			*changes = append(*changes, Change{Type: ChangeAdded, Path: path.withIndex(i), To: to.Values[i]})
+		}
+		return
+
+	default:
+		if Equal(from, to) {
+			return
+		}
+	}
+
+	*changes = append(*changes, Change{Type: ChangeModified, Path: path, From: from, To: to})
+}
+
+// Equal tells whether two values are identical. The values of different
+// types are compared through their structpb representation, e.g. a string
+// equals a file whose data URI is that string.
+func Equal(a, b Value) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+
+	switch a := a.(type) {
+	case *Null:
+		_, ok := b.(*Null)
+		return ok
+	case *Boolean:
+		if b, ok := b.(*Boolean); ok {
+			return a.Raw == b.Raw
+		}
+	case *Number:
+		if b, ok := b.(*Number); ok {
+			return a.Raw == b.Raw
+		}
+	case *String:
+		if b, ok := b.(*String); ok {
+			return a.Raw == b.Raw
+		}
+	case *Decimal:
+		if b, ok := b.(*Decimal); ok {
+			return a.Compare(b) == 0
+		}
+	case *DateTime:
+		if b, ok := b.(*DateTime); ok {
+			return a.Equal(b)
+		}
+	case *Duration:
+		if b, ok := b.(*Duration); ok {
+			return a.Raw == b.Raw
+		}
+	case *Proto:
+		if b, ok := b.(*Proto); ok {
+			return proto.Equal(a.Raw, b.Raw)
+		}
+	case *Map:
+		b, ok := b.(*Map)
+		if !ok || len(a.Fields) != len(b.Fields) {
+			return false
+		}
+		for k, v := range a.Fields {
+			if w, ok := b.Fields[k]; !ok || !Equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case *Array:
+		b, ok := b.(*Array)
+		if !ok || len(a.Values) != len(b.Values) {
+			return false
+		}
+		for i := range a.Values {
+			if !Equal(a.Values[i], b.Values[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	sa, err := a.ToStructValue()
+	if err != nil {
+		return false
+	}
+	sb, err := b.ToStructValue()
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(sa.AsInterface(), sb.AsInterface())
+}
@@ -0,0 +1,191 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestDiff(t *testing.T) {
+	c := quicktest.New(t)
+
+	stringArray := func(ss ...string) *Array {
+		values := make([]Value, len(ss))
+		for i, s := range ss {
+			values[i] = NewString(s)
+		}
+		return NewArray(values)
+	}
+
+	testcases := []struct {
+		name string
+		from Value
+		to   Value
+		want []string
+	}{
+		{
+			name: "equal",
+			from: NewMap(map[string]Value{"a": stringArray("x"), "b": NewNumberFromInteger(1)}),
+			to:   NewMap(map[string]Value{"a": stringArray("x"), "b": NewNumberFromInteger(1)}),
+		},
+		{
+			name: "root",
+			from: NewString("a"),
+			to:   NewString("b"),
+			want: []string{"modified "},
+		},
+		{
+			name: "map fields",
+			from: NewMap(map[string]Value{"b": NewString("b"), "d": NewString("d"), "c": NewString("c"), "a": NewString("a")}),
+			to:   NewMap(map[string]Value{"c": NewString("c"), "a": NewString("z"), "f": NewString("f"), "e": NewString("e")}),
+			// The removed fields go first, then the rest in the order of
+			// their keys.
+			want: []string{"removed b", "removed d", "modified a", "added e", "added f"},
+		},
+		{
+			name: "removed items",
+			from: stringArray("a", "b", "c", "d"),
+			to:   stringArray("z"),
+			// The items are removed from the last one, so the indexes of
+			// the rest don't shift.
+			want: []string{"modified [0]", "removed [3]", "removed [2]", "removed [1]"},
+		},
+		{
+			name: "added items",
+			from: stringArray("a"),
+			to:   stringArray("a", "b", "c"),
+			want: []string{"added [1]", "added [2]"},
+		},
+		{
+			name: "nested",
+			from: NewMap(map[string]Value{
+				"objects": NewArray([]Value{NewMap(map[string]Value{"label": NewString("cat")})}),
+			}),
+			to: NewMap(map[string]Value{
+				"objects": NewArray([]Value{NewMap(map[string]Value{"label": NewString("dog"), "score": NewNumberFromFloat(0.9)})}),
+			}),
+			want: []string{"modified objects[0].label", "added objects[0].score"},
+		},
+		{
+			// A map and an array aren't compared field by field.
+			name: "type change",
+			from: NewMap(map[string]Value{"a": stringArray("x")}),
+			to:   NewMap(map[string]Value{"a": NewMap(map[string]Value{"x": NewString("x")})}),
+			want: []string{"modified a"},
+		},
+		{
+			name: "null",
+			from: NewMap(map[string]Value{"a": NewNull()}),
+			to:   NewMap(map[string]Value{"a": NewString("a")}),
+			want: []string{"modified a"},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			changes := Diff(tc.from, tc.to)
+			got := make([]string, len(changes))
+			for i, change := range changes {
+				got[i] = fmt.Sprintf("%s %s", change.Type, change.Path)
+			}
+			c.Check(got, quicktest.HasLen, len(tc.want))
+			if len(tc.want) > 0 {
+				c.Check(got, quicktest.DeepEquals, tc.want)
+			}
+		})
+	}
+
+	c.Run("values", func(c *quicktest.C) {
+		changes := Diff(
+			NewMap(map[string]Value{"a": NewString("a"), "b": NewString("b")}),
+			NewMap(map[string]Value{"a": NewString("z"), "c": NewString("c")}),
+		)
+		c.Assert(changes, quicktest.HasLen, 3)
+		c.Check(changes[0].From, valueEquals, NewString("b"))
+		c.Check(changes[0].To, quicktest.IsNil)
+		c.Check(changes[1].From, valueEquals, NewString("a"))
+		c.Check(changes[1].To, valueEquals, NewString("z"))
+		c.Check(changes[2].From, quicktest.IsNil)
+		c.Check(changes[2].To, valueEquals, NewString("c"))
+	})
+}
+
+func TestChangePath(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		path        ChangePath
+		wantString  string
+		wantPointer string
+	}{
+		{path: nil, wantString: "", wantPointer: ""},
+		{path: ChangePath{{Index: 0, IsIndex: true}}, wantString: "[0]", wantPointer: "/0"},
+		{
+			path:        ChangePath{{Key: "objects"}, {Index: 1, IsIndex: true}, {Key: "label"}},
+			wantString:  "objects[1].label",
+			wantPointer: "/objects/1/label",
+		},
+		{
+			// The pointer escapes the tildes before the slashes, so "~1"
+			// isn't read back as a slash.
+			path:        ChangePath{{Key: "a/b"}, {Key: "~1"}, {Key: ""}},
+			wantString:  "a/b.~1.",
+			wantPointer: "/a~1b/~01/",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Check(tc.path.String(), quicktest.Equals, tc.wantString)
+		c.Check(tc.path.Pointer(), quicktest.Equals, tc.wantPointer)
+	}
+
+	// Extending a path doesn't modify the paths that share its segments.
+	base := make(ChangePath, 1, 4)
+	base[0] = PathSegment{Key: "a"}
+	b := base.withKey("b")
+	c.Check(base.withIndex(0).String(), quicktest.Equals, "a[0]")
+	c.Check(b.String(), quicktest.Equals, "a.b")
+}
+
+func TestEqual(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		name string
+		a, b Value
+		want bool
+	}{
+		{name: "nil", want: true},
+		{name: "nil and null", a: NewNull(), b: nil, want: false},
+		{name: "numbers", a: NewNumberFromInteger(1), b: NewNumberFromFloat(1), want: true},
+		{name: "strings", a: NewString("a"), b: NewString("b"), want: false},
+		{name: "string and number", a: NewString("1"), b: NewNumberFromInteger(1), want: false},
+		{
+			name: "maps",
+			a:    NewMap(map[string]Value{"a": NewArray([]Value{NewNull()})}),
+			b:    NewMap(map[string]Value{"a": NewArray([]Value{NewNull()})}),
+			want: true,
+		},
+		{
+			name: "maps with other keys",
+			a:    NewMap(map[string]Value{"a": NewNull()}),
+			b:    NewMap(map[string]Value{"b": NewNull()}),
+			want: false,
+		},
+		{
+			name: "arrays of other lengths",
+			a:    NewArray([]Value{NewNull()}),
+			b:    NewArray([]Value{NewNull(), NewNull()}),
+			want: false,
+		},
+		{name: "decimals", a: NewDecimalFromInteger(2), b: NewDecimalFromInteger(2), want: true},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			c.Check(Equal(tc.a, tc.b), quicktest.Equals, tc.want)
+			c.Check(Equal(tc.b, tc.a), quicktest.Equals, tc.want)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Duration is a length of time. It's converted to structpb as a number of
+// seconds, which is how the recipes and the component schemas expressed
+// durations before it existed.
+type Duration struct {
+	Raw time.Duration
+}
+
+// isoDurationPattern matches an ISO 8601 duration, e.g. P1DT2H30M or PT0.5S.
+var isoDurationPattern = regexp.MustCompile(`^([-+])?P(?:(\d+(?:[.,]\d+)?)Y)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+func NewDuration(d time.Duration) *Duration {
+	return &Duration{Raw: d}
+}
+
+// NewDurationFromSeconds returns a duration of a number of seconds, which
+// can be fractional.
+func NewDurationFromSeconds(s float64) *Duration {
+	return &Duration{Raw: time.Duration(math.Round(s * float64(time.Second)))}
+}
+
+// ParseDuration parses a duration in the Go syntax (e.g. 1h30m), in the ISO
+// 8601 syntax (e.g. PT1H30M) or as a plain number of seconds (e.g. 90). In
+// ISO 8601, a day is 24 hours and a week 7 days. Years and months don't have
+// a fixed length, so they're rejected.
+func ParseDuration(s string) (*Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("empty duration")
+	}
+
+	if f, err := strconv.ParseFloat(s, 64); err == nil || errors.Is(err, strconv.ErrRange) {
+		// ParseFloat also accepts NaN and infinities.
+		if err == nil && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			return nil, fmt.Errorf("invalid duration %q", s)
+		}
+		if math.IsInf(f, 0) || math.Abs(f*float64(time.Second)) > math.MaxInt64 {
+			return nil, fmt.Errorf("invalid duration %q: out of range", s)
+		}
+		return NewDurationFromSeconds(f), nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return NewDuration(d), nil
+	}
+
+	m := isoDurationPattern.FindStringSubmatch(strings.ToUpper(s))
+	if m == nil || strings.HasSuffix(strings.ToUpper(s), "T") || strings.Join(m[2:], "") == "" {
+		// A duration needs at least one component, e.g. P and -P are
+		// invalid.
+		return nil, fmt.Errorf("invalid duration %q", s)
+	}
+	if m[2] != "" || m[3] != "" {
+		return nil, fmt.Errorf("invalid duration %q: years and months don't have a fixed length", s)
+	}
+
+	units := []struct {
+		value string
+		unit  time.Duration
+	}{
+		{m[4], 7 * 24 * time.Hour},
+		{m[5], 24 * time.Hour},
+		{m[6], time.Hour},
+		{m[7], time.Minute},
+		{m[8], time.Second},
+	}
+
+	var total float64
+	for _, u := range units {
+		if u.value == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(strings.Replace(u.value, ",", ".", 1), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q", s)
+		}
+		total += f * float64(u.unit)
+	}
+	if total > math.MaxInt64 {
+		return nil, fmt.Errorf("invalid duration %q: out of range", s)
+	}
+	if m[1] == "-" {
+		total = -total
+	}
+	return NewDuration(time.Duration(math.Round(total))), nil
+}
+
+func (Duration) isValue() {}
+
+func (d *Duration) GetDuration() time.Duration {
+	return d.Raw
+}
+
+// Seconds returns the duration as a number of seconds.
+func (d *Duration) Seconds() float64 {
+	return d.Raw.Seconds()
+}
+
+// Add returns the sum of two durations.
+func (d *Duration) Add(o *Duration) *Duration {
+	return NewDuration(d.Raw + o.Raw)
+}
+
+// Sub returns the difference of two durations.
+func (d *Duration) Sub(o *Duration) *Duration {
+	return NewDuration(d.Raw - o.Raw)
+}
+
+// Mul returns the duration scaled by a factor.
+func (d *Duration) Mul(f float64) *Duration {
+	return NewDuration(time.Duration(math.Round(float64(d.Raw) * f)))
+}
+
+// Abs returns the absolute value of the duration.
+func (d *Duration) Abs() *Duration {
+	return NewDuration(d.Raw.Abs())
+}
+
+// Compare returns -1 if the duration is shorter than another one, +1 if
+// it's longer and 0 if both are equal.
+func (d *Duration) Compare(o *Duration) int {
+	switch {
+	case d.Raw < o.Raw:
+		return -1
+	case d.Raw > o.Raw:
+		return 1
+	}
+	return 0
+}
+
+// String returns the duration in the Go syntax, e.g. 1h30m0s.
+func (d *Duration) String() string {
+	return d.Raw.String()
+}
+
+// ISO returns the duration in the ISO 8601 syntax, e.g. PT1H30M. The hours
+// aren't grouped into days, as a day isn't always 24 hours long.
+func (d *Duration) ISO() string {
+	if d.Raw == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	v := d.Raw
+	if v < 0 {
+		b.WriteByte('-')
+		v = -v
+	}
+	b.WriteString("PT")
+
+	if h := v / time.Hour; h > 0 {
+		b.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+		v -= h * time.Hour
+	}
+	if m := v / time.Minute; m > 0 {
+		b.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+		v -= m * time.Minute
+	}
+	if v > 0 {
+		b.WriteString(strconv.FormatFloat(v.Seconds(), 'f', -1, 64) + "S")
+	}
+	return b.String()
+}
+
+func (d *Duration) Get(path string) (v Value, err error) {
+	switch {
+	case comparePath(path, ""):
+		return d, nil
+	case comparePath(path, ".seconds"):
+		return NewNumberFromFloat(d.Raw.Seconds()), nil
+	case comparePath(path, ".milliseconds"):
+		return NewNumberFromInteger(int(d.Raw.Milliseconds())), nil
+	case comparePath(path, ".minutes"):
+		return NewNumberFromFloat(d.Raw.Minutes()), nil
+	case comparePath(path, ".hours"):
+		return NewNumberFromFloat(d.Raw.Hours()), nil
+	case comparePath(path, ".iso"):
+		return NewString(d.ISO()), nil
+	}
+	return nil, fmt.Errorf("wrong path %s for Duration", path)
+}
+
+func (d Duration) ToStructValue() (v *structpb.Value, err error) {
+	v = structpb.NewNumberValue(d.Raw.Seconds())
+	return
+}
+
+func (d *Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Raw.Seconds())
+}
@@ -0,0 +1,55 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestParseDuration(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		in      string
+		want    time.Duration
+		wantErr string
+	}{
+		{in: "90", want: 90 * time.Second},
+		{in: "0.5", want: 500 * time.Millisecond},
+		{in: "-1.5", want: -1500 * time.Millisecond},
+		{in: "1h30m", want: 90 * time.Minute},
+		{in: "PT1H30M", want: 90 * time.Minute},
+		{in: "P1DT2H", want: 26 * time.Hour},
+		{in: "P1W", want: 7 * 24 * time.Hour},
+		{in: "-PT0,5S", want: -500 * time.Millisecond},
+		{in: " pt10s ", want: 10 * time.Second},
+		{in: "", wantErr: "empty duration"},
+		{in: "NaN", wantErr: `invalid duration "NaN"`},
+		{in: "Inf", wantErr: `invalid duration "Inf"`},
+		{in: "-infinity", wantErr: `invalid duration "-infinity"`},
+		{in: "1e300", wantErr: `invalid duration "1e300": out of range`},
+		{in: "1e400", wantErr: `invalid duration "1e400": out of range`},
+		{in: "P", wantErr: `invalid duration "P"`},
+		{in: "-P", wantErr: `invalid duration "-P"`},
+		{in: "+P", wantErr: `invalid duration "\+P"`},
+		{in: "PT", wantErr: `invalid duration "PT"`},
+		{in: "P1DT", wantErr: `invalid duration "P1DT"`},
+		{in: "P1Y", wantErr: `invalid duration "P1Y": years and months don't have a fixed length`},
+		{in: "P1M", wantErr: `invalid duration "P1M": years and months don't have a fixed length`},
+		{in: "P999999999W", wantErr: `invalid duration "P999999999W": out of range`},
+		{in: "soon", wantErr: `invalid duration "soon"`},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.in, func(c *quicktest.C) {
+			got, err := ParseDuration(tc.in)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got.GetDuration(), quicktest.Equals, tc.want)
+		})
+	}
+}
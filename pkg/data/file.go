@@ -16,6 +16,14 @@ type File struct {
 	FileName    string
 	SourceURL   string
 	Cache       map[string][]byte
+
+	// blob is set when the content is stored outside the memory, in which
+	// case Raw is empty.
+	blob *blob
+
+	// shared is set when the file can be read concurrently, in which case
+	// the conversions aren't cached.
+	shared bool
 }
 
 func NewFileFromBytes(b []byte, contentType, fileName string) (bin *File, err error) {
@@ -79,11 +87,24 @@ func (f *File) GetByteArray(contentType string) (ba *ByteArray, err error) {
 		return NewByteArray(c), nil
 	}
 
-	b, err := convertFile(f.Raw, f.ContentType, contentType)
+	raw, err := f.content()
+	if err != nil {
+		return nil, err
+	}
+	if f.blob != nil && contentType == f.ContentType {
+		if !f.shared {
+			f.Cache[contentType] = raw
+		}
+		return NewByteArray(raw), nil
+	}
+
+	b, err := convertFile(raw, f.ContentType, contentType)
 	if err != nil {
 		return nil, fmt.Errorf("can not convert data from %s to %s", f.ContentType, contentType)
 	}
-	f.Cache[contentType] = b
+	if !f.shared {
+		f.Cache[contentType] = b
+	}
 	return NewByteArray(b), nil
 }
 
@@ -109,6 +130,9 @@ func (f *File) GetBase64(contentType string) (b64 *String, err error) {
 }
 
 func (f *File) GetFileSize() (size *Number) {
+	if f.blob != nil {
+		return NewNumberFromInteger(f.blob.size)
+	}
 	return NewNumberFromInteger(len(f.Raw))
 }
 
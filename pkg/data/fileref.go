@@ -0,0 +1,293 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// FileRef references a file by its URI instead of holding its content, e.g.
+// https://example.com/report.pdf or artifact://pipeline-runs/artifact/....
+// The content is only fetched when it's read, and it can be streamed, so
+// the large files don't need to be held in the workflow memory. It's
+// converted to structpb as its URI.
+type FileRef struct {
+	URI         string
+	ContentType string
+	FileName    string
+	// Size is the size of the content in bytes, or 0 if it's unknown.
+	Size     int
+	Metadata map[string]string
+}
+
+// FileOpener opens the content of the files referenced by the URIs of a
+// scheme.
+type FileOpener func(ctx context.Context, uri string) (io.ReadCloser, error)
+
+var (
+	fileOpenersMu sync.RWMutex
+	fileOpeners   = map[string]FileOpener{
+		"http":  openHTTPFile,
+		"https": openHTTPFile,
+	}
+)
+
+// RegisterFileOpener sets the opener of the URIs of a scheme. The HTTP
+// openers are registered by default, the rest (e.g. the ones of the blob
+// storage) are registered by the worker.
+func RegisterFileOpener(scheme string, open FileOpener) {
+	fileOpenersMu.Lock()
+	defer fileOpenersMu.Unlock()
+	fileOpeners[strings.ToLower(scheme)] = open
+}
+
+func fileOpener(uri string) (FileOpener, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file URI %q: %w", uri, err)
+	}
+
+	fileOpenersMu.RLock()
+	defer fileOpenersMu.RUnlock()
+	open, ok := fileOpeners[strings.ToLower(u.Scheme)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported file URI scheme %q", u.Scheme)
+	}
+	return open, nil
+}
+
+// fileRefTimeout bounds the time an HTTP file takes to be fetched.
+const fileRefTimeout = 5 * time.Minute
+
+// maxFileRefSize is the size of the largest file a reference can be
+// materialized into, the same as the largest payload of a request.
+var maxFileRefSize int64 = 256 << 20
+
+// fileHTTPClient fetches the files of the HTTP URIs. As the URIs might come
+// from the user input, the requests can't reach the private, loopback or
+// link-local addresses (e.g. a cloud metadata endpoint). The address is
+// checked when the connection is made, so neither a redirect nor the DNS
+// resolution can work around it.
+var fileHTTPClient = newFileHTTPClient(false)
+
+func newFileHTTPClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddr(addr) {
+				return fmt.Errorf("%s isn't a public address", addr)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would be the address that is checked.
+	transport.Proxy = nil
+	return &http.Client{Transport: transport, Timeout: fileRefTimeout}
+}
+
+// sharedAddressSpace is used by the carrier-grade NATs and by some cloud
+// metadata endpoints.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+func openHTTPFile(ctx context.Context, uri string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := fileHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", uri, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// NewFileRef returns a reference to a file. It fails if the scheme of the
+// URI has no opener.
+func NewFileRef(uri, contentType, fileName string, size int) (*FileRef, error) {
+	if _, err := fileOpener(uri); err != nil {
+		return nil, err
+	}
+	return &FileRef{
+		URI:         uri,
+		ContentType: contentType,
+		FileName:    fileName,
+		Size:        size,
+		Metadata:    map[string]string{},
+	}, nil
+}
+
+func (FileRef) isValue() {}
+
+// Open returns a reader of the content of the file. The caller must close
+// it.
+func (f *FileRef) Open(ctx context.Context) (io.ReadCloser, error) {
+	open, err := fileOpener(f.URI)
+	if err != nil {
+		return nil, err
+	}
+	return open(ctx, f.URI)
+}
+
+// Materialize fetches the content of the file. The content type is
+// detected from the content if the reference doesn't have one. The files
+// larger than maxFileRefSize can only be streamed with Open.
+func (f *FileRef) Materialize(ctx context.Context) (*File, error) {
+	if int64(f.Size) > maxFileRefSize {
+		return nil, fmt.Errorf("%s exceeds the maximum file size of %d bytes", f.URI, maxFileRefSize)
+	}
+
+	r, err := f.Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	b, err := io.ReadAll(io.LimitReader(r, maxFileRefSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", f.URI, err)
+	}
+	if int64(len(b)) > maxFileRefSize {
+		return nil, fmt.Errorf("%s exceeds the maximum file size of %d bytes", f.URI, maxFileRefSize)
+	}
+
+	file, err := NewFileFromBytes(b, f.ContentType, f.FileName)
+	if err != nil {
+		return nil, err
+	}
+	file.SourceURL = f.URI
+	return file, nil
+}
+
+// LoadFile fetches the file of a URI, which can be a data URI or the URI of
+// a file reference.
+func LoadFile(ctx context.Context, uri string) (*File, error) {
+	if strings.HasPrefix(uri, "data:") {
+		return newFileFromDataURL(uri)
+	}
+
+	ref, err := NewFileRef(uri, "", "", 0)
+	if err != nil {
+		return nil, err
+	}
+	return ref.Materialize(ctx)
+}
+
+// MaterializeFileRefs returns the value with the file references it holds
+// replaced by their content, e.g. before the value is passed to a component
+// as its input. The maps and arrays that hold a reference are copied, so the
+// value itself isn't modified.
+func MaterializeFileRefs(ctx context.Context, v Value) (Value, error) {
+	m, _, err := materializeFileRefs(ctx, v)
+	return m, err
+}
+
+func materializeFileRefs(ctx context.Context, v Value) (Value, bool, error) {
+	switch v := v.(type) {
+	case *FileRef:
+		f, err := v.Materialize(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		return f, true, nil
+	case *Map:
+		var fields map[string]Value
+		for k, fv := range v.Fields {
+			m, changed, err := materializeFileRefs(ctx, fv)
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %w", k, err)
+			}
+			if !changed {
+				continue
+			}
+			if fields == nil {
+				fields = maps.Clone(v.Fields)
+			}
+			fields[k] = m
+		}
+		if fields == nil {
+			return v, false, nil
+		}
+		return NewMap(fields), true, nil
+	case *Array:
+		var values []Value
+		for i, av := range v.Values {
+			m, changed, err := materializeFileRefs(ctx, av)
+			if err != nil {
+				return nil, false, fmt.Errorf("[%d]: %w", i, err)
+			}
+			if !changed {
+				continue
+			}
+			if values == nil {
+				values = slices.Clone(v.Values)
+			}
+			values[i] = m
+		}
+		if values == nil {
+			return v, false, nil
+		}
+		return NewArray(values), true, nil
+	}
+	return v, false, nil
+}
+
+func (f *FileRef) Get(path string) (v Value, err error) {
+	switch {
+	case comparePath(path, ""):
+		return f, nil
+	case comparePath(path, ".uri"):
+		return NewString(f.URI), nil
+	case comparePath(path, ".content-type"):
+		return NewString(f.ContentType), nil
+	case comparePath(path, ".filename"):
+		return NewString(f.FileName), nil
+	case comparePath(path, ".file-size"):
+		return NewNumberFromInteger(f.Size), nil
+	case comparePath(path, ".metadata"):
+		fields := make(map[string]Value, len(f.Metadata))
+		for k, v := range f.Metadata {
+			fields[k] = NewString(v)
+		}
+		return NewMap(fields), nil
+	}
+	return nil, fmt.Errorf("wrong path %s for FileRef", path)
+}
+
+func (f FileRef) ToStructValue() (v *structpb.Value, err error) {
+	v = structpb.NewStringValue(f.URI)
+	return
+}
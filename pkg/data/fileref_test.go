@@ -0,0 +1,213 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+// serveFiles serves the content of the files by their path. The HTTP client
+// is allowed to reach the server, which listens on the loopback address.
+func serveFiles(c *quicktest.C, files map[string]string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, content)
+	}))
+	c.Cleanup(srv.Close)
+
+	client := fileHTTPClient
+	fileHTTPClient = newFileHTTPClient(true)
+	c.Cleanup(func() { fileHTTPClient = client })
+	return srv
+}
+
+func TestFileRef(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	c.Run("nok - unsupported scheme", func(c *quicktest.C) {
+		_, err := NewFileRef("ftp://example.com/report.pdf", "", "", 0)
+		c.Check(err, quicktest.ErrorMatches, `unsupported file URI scheme "ftp"`)
+	})
+
+	c.Run("ok - get", func(c *quicktest.C) {
+		ref, err := NewFileRef("https://example.com/report.pdf", "application/pdf", "report.pdf", 42)
+		c.Assert(err, quicktest.IsNil)
+
+		for path, want := range map[string]Value{
+			"":              ref,
+			".uri":          NewString("https://example.com/report.pdf"),
+			".content-type": NewString("application/pdf"),
+			".filename":     NewString("report.pdf"),
+			".file-size":    NewNumberFromInteger(42),
+		} {
+			got, err := ref.Get(path)
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, valueEquals, want, quicktest.Commentf(path))
+		}
+		_, err = ref.Get(".content")
+		c.Check(err, quicktest.ErrorMatches, "wrong path .content for FileRef")
+
+		sv, err := ref.ToStructValue()
+		c.Assert(err, quicktest.IsNil)
+		c.Check(sv.GetStringValue(), quicktest.Equals, "https://example.com/report.pdf")
+	})
+
+	c.Run("ok - open and materialize", func(c *quicktest.C) {
+		srv := serveFiles(c, map[string]string{"/hello.txt": "hello world"})
+		ref, err := NewFileRef(srv.URL+"/hello.txt", "", "hello.txt", 0)
+		c.Assert(err, quicktest.IsNil)
+
+		r, err := ref.Open(ctx)
+		c.Assert(err, quicktest.IsNil)
+		b, err := io.ReadAll(r)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(r.Close(), quicktest.IsNil)
+		c.Check(string(b), quicktest.Equals, "hello world")
+
+		f, err := ref.Materialize(ctx)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(string(f.Raw), quicktest.Equals, "hello world")
+		c.Check(f.ContentType, quicktest.Equals, "text/plain")
+		c.Check(f.FileName, quicktest.Equals, "hello.txt")
+		c.Check(f.SourceURL, quicktest.Equals, ref.URI)
+	})
+
+	c.Run("nok - unexpected status", func(c *quicktest.C) {
+		srv := serveFiles(c, nil)
+		_, err := LoadFile(ctx, srv.URL+"/missing.txt")
+		c.Check(err, quicktest.ErrorMatches, `fetching .*/missing.txt: unexpected status 404 Not Found`)
+	})
+
+	c.Run("nok - private address", func(c *quicktest.C) {
+		srv := serveFiles(c, map[string]string{"/hello.txt": "hello world"})
+		fileHTTPClient = newFileHTTPClient(false)
+
+		_, err := LoadFile(ctx, srv.URL+"/hello.txt")
+		c.Check(err, quicktest.ErrorMatches, `.*127\.0\.0\.1 isn't a public address`)
+	})
+
+	c.Run("nok - too large", func(c *quicktest.C) {
+		srv := serveFiles(c, map[string]string{"/large.txt": strings.Repeat("a", 11)})
+		maxSize := maxFileRefSize
+		maxFileRefSize = 10
+		c.Cleanup(func() { maxFileRefSize = maxSize })
+
+		ref, err := NewFileRef(srv.URL+"/large.txt", "", "", 0)
+		c.Assert(err, quicktest.IsNil)
+		_, err = ref.Materialize(ctx)
+		c.Check(err, quicktest.ErrorMatches, `.*/large.txt exceeds the maximum file size of 10 bytes`)
+
+		// The declared size is checked before the file is fetched.
+		ref, err = NewFileRef(srv.URL+"/missing.txt", "", "", 11)
+		c.Assert(err, quicktest.IsNil)
+		_, err = ref.Materialize(ctx)
+		c.Check(err, quicktest.ErrorMatches, `.*/missing.txt exceeds the maximum file size of 10 bytes`)
+
+		// The files can still be streamed.
+		r, err := ref.Open(ctx)
+		c.Check(err, quicktest.ErrorMatches, `.*unexpected status 404 Not Found`)
+		c.Check(r, quicktest.IsNil)
+	})
+
+	c.Run("ok - data URI", func(c *quicktest.C) {
+		f, err := LoadFile(ctx, "data:text/plain;base64,aGVsbG8=")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(string(f.Raw), quicktest.Equals, "hello")
+		c.Check(f.ContentType, quicktest.Equals, "text/plain")
+	})
+
+	c.Run("ok - registered scheme", func(c *quicktest.C) {
+		RegisterFileOpener("test", func(_ context.Context, uri string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("content of " + uri)), nil
+		})
+
+		f, err := LoadFile(ctx, "test://bucket/key")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(string(f.Raw), quicktest.Equals, "content of test://bucket/key")
+	})
+}
+
+func TestMaterializeFileRefs(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	srv := serveFiles(c, map[string]string{"/a.txt": "a", "/b.txt": "b"})
+	newRef := func(name string) *FileRef {
+		ref, err := NewFileRef(srv.URL+"/"+name, "text/plain", name, 0)
+		c.Assert(err, quicktest.IsNil)
+		return ref
+	}
+
+	c.Run("ok", func(c *quicktest.C) {
+		list := NewArray([]Value{newRef("b.txt"), NewString("c")})
+		input := NewMap(map[string]Value{
+			"file":  newRef("a.txt"),
+			"list":  list,
+			"other": NewMap(map[string]Value{"text": NewString("d")}),
+		})
+		Freeze(input)
+
+		got, err := MaterializeFileRefs(ctx, input)
+		c.Assert(err, quicktest.IsNil)
+
+		m := got.(*Map)
+		c.Check(string(m.Fields["file"].(*File).Raw), quicktest.Equals, "a")
+		c.Check(string(m.Fields["list"].(*Array).Values[0].(*File).Raw), quicktest.Equals, "b")
+		c.Check(m.Fields["list"].(*Array).Values[1], valueEquals, NewString("c"))
+		// The values without references aren't copied.
+		c.Check(m.Fields["other"], quicktest.Equals, input.Fields["other"])
+
+		// The original value still holds the references.
+		c.Check(input.Fields["file"], quicktest.FitsTypeOf, &FileRef{})
+		c.Check(list.Values[0], quicktest.FitsTypeOf, &FileRef{})
+	})
+
+	c.Run("ok - no references", func(c *quicktest.C) {
+		input := NewMap(map[string]Value{"text": NewString("a")})
+		got, err := MaterializeFileRefs(ctx, input)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, quicktest.Equals, Value(input))
+	})
+
+	c.Run("nok", func(c *quicktest.C) {
+		input := NewMap(map[string]Value{"list": NewArray([]Value{newRef("missing.txt")})})
+		_, err := MaterializeFileRefs(ctx, input)
+		c.Check(err, quicktest.ErrorMatches, `list: \[0\]: fetching .*: unexpected status 404 Not Found`)
+	})
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	c := quicktest.New(t)
+
+	for addr, want := range map[string]bool{
+		"93.184.216.34":      true,
+		"2606:2800:220:1::1": true,
+		"127.0.0.1":          false,
+		"10.0.0.1":           false,
+		"172.16.0.1":         false,
+		"192.168.1.1":        false,
+		"169.254.169.254":    false,
+		"100.100.100.200":    false,
+		"0.0.0.0":            false,
+		"224.0.0.1":          false,
+		"::1":                false,
+		"fd00::1":            false,
+		"fe80::1":            false,
+		"::ffff:127.0.0.1":   false,
+		"::ffff:169.254.1.1": false,
+	} {
+		c.Check(isPublicAddr(netip.MustParseAddr(addr)), quicktest.Equals, want, quicktest.Commentf(addr))
+	}
+}
@@ -0,0 +1,50 @@
+package data
+
+// GetOr returns the value at a path, or the default value if the path
+// doesn't exist, goes through a value of the wrong type (e.g. a key of a
+// string) or holds null.
+func GetOr(v Value, path string, defaultValue Value) Value {
+	if v == nil {
+		return defaultValue
+	}
+	got, err := v.Get(path)
+	if err != nil || got == nil {
+		return defaultValue
+	}
+	if _, isNull := got.(*Null); isNull {
+		return defaultValue
+	}
+	return got
+}
+
+// ExistsAt tells whether a path resolves in a value. A path that holds null
+// exists.
+func ExistsAt(v Value, path string) bool {
+	if v == nil {
+		return false
+	}
+	got, err := v.Get(path)
+	return err == nil && got != nil
+}
+
+// GetOr returns the value at a path of the map, or the default value, as
+// the GetOr function does.
+func (m *Map) GetOr(path string, defaultValue Value) Value {
+	return GetOr(m, path, defaultValue)
+}
+
+// ExistsAt tells whether a path resolves in the map.
+func (m *Map) ExistsAt(path string) bool {
+	return ExistsAt(m, path)
+}
+
+// GetOr returns the value at a path of the array, or the default value, as
+// the GetOr function does.
+func (a *Array) GetOr(path string, defaultValue Value) Value {
+	return GetOr(a, path, defaultValue)
+}
+
+// ExistsAt tells whether a path resolves in the array.
+func (a *Array) ExistsAt(path string) bool {
+	return ExistsAt(a, path)
+}
@@ -0,0 +1,57 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestGetOr(t *testing.T) {
+	c := quicktest.New(t)
+
+	v := NewMap(map[string]Value{
+		"title": NewString("Report"),
+		"empty": NewString(""),
+		"none":  NewNull(),
+		"items": NewArray([]Value{NewNumberFromInteger(1), NewNull()}),
+	})
+	fallback := NewString("fallback")
+
+	testcases := []struct {
+		path       string
+		want       Value
+		wantExists bool
+	}{
+		{path: "title", want: NewString("Report"), wantExists: true},
+		// An empty string isn't missing.
+		{path: "empty", want: NewString(""), wantExists: true},
+		// A null exists, but it's replaced by the default value.
+		{path: "none", want: fallback, wantExists: true},
+		{path: "missing", want: fallback},
+		{path: "missing.title", want: fallback},
+		{path: "title.label", want: fallback},
+		{path: "items[0]", want: NewNumberFromInteger(1), wantExists: true},
+		{path: "items[1]", want: fallback, wantExists: true},
+		{path: "items[2]", want: fallback},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.path, func(c *quicktest.C) {
+			c.Check(v.GetOr(tc.path, fallback), valueEquals, tc.want)
+			c.Check(v.ExistsAt(tc.path), quicktest.Equals, tc.wantExists)
+		})
+	}
+
+	c.Run("array", func(c *quicktest.C) {
+		arr := NewArray([]Value{NewString("a")})
+		c.Check(arr.GetOr("[0]", fallback), valueEquals, NewString("a"))
+		c.Check(arr.GetOr("[1]", fallback), valueEquals, fallback)
+		c.Check(arr.ExistsAt("[0]"), quicktest.IsTrue)
+		c.Check(arr.ExistsAt("[1]"), quicktest.IsFalse)
+	})
+
+	c.Run("nil", func(c *quicktest.C) {
+		c.Check(GetOr(nil, "title", fallback), valueEquals, fallback)
+		c.Check(ExistsAt(nil, "title"), quicktest.IsFalse)
+	})
+}
@@ -0,0 +1,114 @@
+package data
+
+import (
+	"context"
+)
+
+// ArrayIterator walks the items of an array one at a time. Array.Iter visits
+// the items of an array that is already in memory, without copying them.
+// NewArrayIterator produces each item when the iterator reaches it, so the
+// items of a source that builds them on demand (e.g. by rendering a
+// reference) are only held once they're visited.
+//
+//	it := arr.Iter()
+//	for it.Next() {
+//		process(it.Index(), it.Value())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ArrayIterator struct {
+	n   int
+	at  func(i int) (Value, error)
+	idx int
+	cur Value
+	err error
+}
+
+// NewArrayIterator returns an iterator over n items, where the i-th item is
+// produced by at when the iterator reaches it. The iteration stops at the
+// first error returned by at.
+func NewArrayIterator(n int, at func(i int) (Value, error)) *ArrayIterator {
+	return &ArrayIterator{n: n, at: at, idx: -1}
+}
+
+// Iter returns an iterator over the items of the array. The iterator holds
+// the items the array had when it was created: the items appended later
+// aren't visited.
+func (a *Array) Iter() *ArrayIterator {
+	values := a.Values
+	return NewArrayIterator(len(values), func(i int) (Value, error) {
+		if values[i] == nil {
+			return NewNull(), nil
+		}
+		return values[i], nil
+	})
+}
+
+// Next advances the iterator to the next item. It returns false when there
+// are no more items or an item couldn't be produced.
+func (it *ArrayIterator) Next() bool {
+	if it.err != nil || it.idx+1 >= it.n {
+		it.cur = nil
+		return false
+	}
+
+	it.idx++
+	if it.cur, it.err = it.at(it.idx); it.err != nil {
+		it.cur = nil
+		return false
+	}
+	return true
+}
+
+// Index returns the index of the current item.
+func (it *ArrayIterator) Index() int {
+	return it.idx
+}
+
+// Value returns the current item.
+func (it *ArrayIterator) Value() Value {
+	return it.cur
+}
+
+// Len returns the number of items of the iteration.
+func (it *ArrayIterator) Len() int {
+	return it.n
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ArrayIterator) Err() error {
+	return it.err
+}
+
+// Stream sends the remaining items to a channel, which is closed when the
+// iteration ends or the context is done. At most buffer items are produced
+// ahead of the consumer. Err must only be checked after the channel is
+// closed.
+func (it *ArrayIterator) Stream(ctx context.Context, buffer int) <-chan Value {
+	ch := make(chan Value, buffer)
+	go func() {
+		defer close(ch)
+		for it.Next() {
+			select {
+			case ch <- it.cur:
+			case <-ctx.Done():
+				it.err = ctx.Err()
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Collect returns the remaining items in an array.
+func (it *ArrayIterator) Collect() (*Array, error) {
+	values := make([]Value, 0, max(it.n-it.idx-1, 0))
+	for it.Next() {
+		values = append(values, it.cur)
+	}
+	if it.err != nil {
+		return nil, it.err
+	}
+	return NewArray(values), nil
+}
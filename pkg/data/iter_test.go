@@ -0,0 +1,154 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestArrayIterator(t *testing.T) {
+	c := quicktest.New(t)
+
+	c.Run("ok - array", func(c *quicktest.C) {
+		arr := NewArray([]Value{NewString("a"), nil, NewNumberFromInteger(1)})
+		it := arr.Iter()
+		c.Check(it.Len(), quicktest.Equals, 3)
+
+		var got []Value
+		for it.Next() {
+			c.Check(it.Index(), quicktest.Equals, len(got))
+			got = append(got, it.Value())
+		}
+		c.Check(it.Err(), quicktest.IsNil)
+		c.Check(it.Value(), quicktest.IsNil)
+		// The missing items are visited as nulls.
+		c.Check(got, valueEquals, []Value{NewString("a"), NewNull(), NewNumberFromInteger(1)})
+
+		// The items appended after the iterator was created aren't visited.
+		it = arr.Iter()
+		arr.Values = append(arr.Values, NewString("b"))
+		rest, err := it.Collect()
+		c.Assert(err, quicktest.IsNil)
+		c.Check(rest.Values, quicktest.HasLen, 3)
+	})
+
+	c.Run("ok - lazy source", func(c *quicktest.C) {
+		var produced []int
+		it := NewArrayIterator(3, func(i int) (Value, error) {
+			produced = append(produced, i)
+			return NewString(fmt.Sprint(i)), nil
+		})
+		c.Check(produced, quicktest.HasLen, 0)
+
+		c.Assert(it.Next(), quicktest.IsTrue)
+		c.Check(produced, quicktest.DeepEquals, []int{0})
+
+		// Collect returns the remaining items.
+		rest, err := it.Collect()
+		c.Assert(err, quicktest.IsNil)
+		c.Check(rest, valueEquals, NewArray([]Value{NewString("1"), NewString("2")}))
+		c.Check(produced, quicktest.DeepEquals, []int{0, 1, 2})
+		c.Check(it.Next(), quicktest.IsFalse)
+	})
+
+	c.Run("nok - source error", func(c *quicktest.C) {
+		var produced int
+		it := NewArrayIterator(3, func(i int) (Value, error) {
+			produced++
+			if i == 1 {
+				return nil, errors.New("reference not found")
+			}
+			return NewNumberFromInteger(i), nil
+		})
+
+		_, err := it.Collect()
+		c.Check(err, quicktest.ErrorMatches, "reference not found")
+		c.Check(it.Err(), quicktest.ErrorMatches, "reference not found")
+
+		// The iteration stops at the first error.
+		c.Check(it.Next(), quicktest.IsFalse)
+		c.Check(produced, quicktest.Equals, 2)
+	})
+
+	c.Run("ok - empty", func(c *quicktest.C) {
+		got, err := NewArray(nil).Iter().Collect()
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got.Values, quicktest.HasLen, 0)
+	})
+}
+
+func TestArrayIteratorStream(t *testing.T) {
+	c := quicktest.New(t)
+
+	c.Run("ok", func(c *quicktest.C) {
+		it := NewArray([]Value{NewString("a"), NewString("b"), NewString("c")}).Iter()
+
+		var got []Value
+		for v := range it.Stream(context.Background(), 1) {
+			got = append(got, v)
+		}
+		c.Check(it.Err(), quicktest.IsNil)
+		c.Check(got, valueEquals, []Value{NewString("a"), NewString("b"), NewString("c")})
+	})
+
+	c.Run("ok - bounded buffer", func(c *quicktest.C) {
+		produced := make(chan int, 10)
+		it := NewArrayIterator(10, func(i int) (Value, error) {
+			produced <- i
+			return NewNumberFromInteger(i), nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ch := it.Stream(ctx, 2)
+
+		// Without a consumer, the buffered items and the one being sent are
+		// the only ones produced.
+		time.Sleep(50 * time.Millisecond)
+		c.Check(len(produced), quicktest.Equals, 3)
+
+		v := <-ch
+		c.Check(v, valueEquals, NewNumberFromInteger(0))
+	})
+
+	c.Run("nok - cancellation", func(c *quicktest.C) {
+		it := NewArrayIterator(10, func(i int) (Value, error) {
+			return NewNumberFromInteger(i), nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := it.Stream(ctx, 0)
+		c.Check(<-ch, valueEquals, NewNumberFromInteger(0))
+
+		// The producer waits to send the next item when the context is
+		// cancelled, and closes the channel without sending it.
+		cancel()
+		time.Sleep(20 * time.Millisecond)
+		var rest int
+		for range ch {
+			rest++
+		}
+		c.Check(rest, quicktest.Equals, 0)
+		c.Check(it.Err(), quicktest.ErrorIs, context.Canceled)
+	})
+
+	c.Run("nok - source error", func(c *quicktest.C) {
+		it := NewArrayIterator(3, func(i int) (Value, error) {
+			if i == 2 {
+				return nil, errors.New("reference not found")
+			}
+			return NewNumberFromInteger(i), nil
+		})
+
+		var got int
+		for range it.Stream(context.Background(), 0) {
+			got++
+		}
+		c.Check(got, quicktest.Equals, 2)
+		c.Check(it.Err(), quicktest.ErrorMatches, "reference not found")
+	})
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Limits bounds the shape of the values built from untrusted input, such as
+// the trigger data of a pipeline, so that a malicious payload is rejected
+// before its values are constructed. A zero limit is disabled.
+type Limits struct {
+	// MaxDepth is the number of nested maps and arrays a value can have.
+	MaxDepth int
+	// MaxArrayLength is the number of items an array can have.
+	MaxArrayLength int
+	// MaxBytes is the size of a value, counted as the bytes of its strings
+	// and keys plus 8 bytes per number and 1 per boolean or null.
+	MaxBytes int64
+}
+
+// The limits a LimitError reports.
+const (
+	LimitDepth       = "depth"
+	LimitArrayLength = "array length"
+	LimitBytes       = "size"
+)
+
+// LimitError is returned when a value exceeds one of its Limits.
+type LimitError struct {
+	// Path is the path of the value where the limit was exceeded, e.g.
+	// `items[3].name`. It is empty for the whole value.
+	Path string
+	// Limit is the limit that was exceeded, e.g. LimitDepth.
+	Limit string
+	// Max is the value of the limit.
+	Max int64
+}
+
+func (e *LimitError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("value exceeds the maximum %s of %d", e.Limit, e.Max)
+	}
+	return fmt.Sprintf("value at %s exceeds the maximum %s of %d", e.Path, e.Limit, e.Max)
+}
+
+// CheckStruct checks that a structpb value is within the limits. It only
+// walks the value, so it can be called before the value is converted.
+func (l Limits) CheckStruct(in *structpb.Value) error {
+	var size int64
+	return l.checkStruct(in, "", 0, &size)
+}
+
+func (l Limits) checkStruct(in *structpb.Value, path string, depth int, size *int64) error {
+	switch in := in.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		*size += int64(len(in.StringValue))
+	case *structpb.Value_NumberValue:
+		*size += 8
+	case *structpb.Value_ListValue:
+		if err := l.checkDepth(path, depth+1); err != nil {
+			return err
+		}
+		items := in.ListValue.GetValues()
+		if l.MaxArrayLength > 0 && len(items) > l.MaxArrayLength {
+			return &LimitError{Path: path, Limit: LimitArrayLength, Max: int64(l.MaxArrayLength)}
+		}
+		for i, item := range items {
+			if err := l.checkStruct(item, fmt.Sprintf("%s[%d]", path, i), depth+1, size); err != nil {
+				return err
+			}
+		}
+	case *structpb.Value_StructValue:
+		if err := l.checkDepth(path, depth+1); err != nil {
+			return err
+		}
+		for k, v := range in.StructValue.GetFields() {
+			*size += int64(len(k))
+			if err := l.checkStruct(v, joinPath(path, k), depth+1, size); err != nil {
+				return err
+			}
+		}
+	default:
+		*size++
+	}
+
+	if l.MaxBytes > 0 && *size > l.MaxBytes {
+		// The size is reported for the whole value, as it isn't caused by
+		// the value where it was exceeded.
+		return &LimitError{Limit: LimitBytes, Max: l.MaxBytes}
+	}
+	return nil
+}
+
+func (l Limits) checkDepth(path string, depth int) error {
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return &LimitError{Path: path, Limit: LimitDepth, Max: int64(l.MaxDepth)}
+	}
+	return nil
+}
+
+// NewValueFromStructWithLimits converts a structpb value as NewValueFromStruct
+// does, after checking that it is within the limits.
+func NewValueFromStructWithLimits(in *structpb.Value, l Limits) (Value, error) {
+	if err := l.CheckStruct(in); err != nil {
+		return nil, err
+	}
+	return NewValueFromStruct(in)
+}
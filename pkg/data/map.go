@@ -2,12 +2,18 @@ package data
 
 import (
 	"fmt"
+	"sort"
 
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type Map struct {
 	Fields map[string]Value
+
+	// shared is set when the map can't be modified in place.
+	shared bool
+	// frozen is set when the map must never change, see Freeze.
+	frozen bool
 }
 
 func NewMap(m map[string]Value) (mp *Map) {
@@ -35,11 +41,30 @@ func (m *Map) Get(path string) (v Value, err error) {
 		return nil, err
 	}
 
-	if v, ok := m.Fields[key]; !ok {
+	// A wildcard selects the values of every field, sorted by key, unless
+	// the map has a field named "*".
+	if _, ok := m.Fields[key]; !ok && key == pathWildcard {
+		keys := make([]string, 0, len(m.Fields))
+		for k := range m.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		values := make([]Value, len(keys))
+		for i, k := range keys {
+			values[i] = m.Fields[k]
+		}
+		return selectEach(values, remainingPath), nil
+	}
+
+	v, ok := m.Fields[key]
+	if !ok {
 		return nil, fmt.Errorf("path not found: %s", path)
-	} else {
-		return v.Get(remainingPath)
 	}
+	if v == nil {
+		v = NewNull()
+	}
+	return v.Get(remainingPath)
 }
 
 func (m Map) ToStructValue() (v *structpb.Value, err error) {
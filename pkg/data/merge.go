@@ -0,0 +1,115 @@
+package data
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// MergeStrategy defines how Map.Merge resolves the fields that are set in
+// both maps, unless both values are maps, which are merged recursively.
+type MergeStrategy int
+
+const (
+	// MergeOverride keeps the value of the merged map.
+	MergeOverride MergeStrategy = iota
+	// MergeKeep keeps the value of the receiver.
+	MergeKeep
+	// MergeAppendArrays concatenates the arrays, the items of the receiver
+	// first. The rest of the conflicts are resolved as with MergeOverride.
+	MergeAppendArrays
+	// MergeError fails on the first conflict.
+	MergeError
+)
+
+func (s MergeStrategy) String() string {
+	switch s {
+	case MergeOverride:
+		return "override"
+	case MergeKeep:
+		return "keep"
+	case MergeAppendArrays:
+		return "append-arrays"
+	case MergeError:
+		return "error"
+	}
+	return fmt.Sprintf("MergeStrategy(%d)", int(s))
+}
+
+// ParseMergeStrategy parses the name of a strategy, e.g. append-arrays.
+func ParseMergeStrategy(s string) (MergeStrategy, error) {
+	for _, strategy := range []MergeStrategy{MergeOverride, MergeKeep, MergeAppendArrays, MergeError} {
+		if strategy.String() == s {
+			return strategy, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown merge strategy %q", s)
+}
+
+// Merge returns a deep merge of the map with another one. The maps found at
+// the same key in both are merged recursively and the rest of the conflicts
+// are resolved with the strategy.
+//
+// Neither map is modified: the maps on the path of a merged field are
+// copied, while the rest of the values are held by both the result and the
+// original maps.
+func (m *Map) Merge(other *Map, strategy MergeStrategy) (*Map, error) {
+	return mergeMaps(m, other, strategy, "")
+}
+
+func mergeMaps(dst, src *Map, strategy MergeStrategy, path string) (*Map, error) {
+	merged := &Map{Fields: maps.Clone(dst.Fields)}
+	if merged.Fields == nil {
+		merged.Fields = map[string]Value{}
+	}
+
+	// The keys are sorted so the conflict that is reported doesn't depend on
+	// the map iteration order.
+	keys := make([]string, 0, len(src.Fields))
+	for k := range src.Fields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		srcVal := src.Fields[k]
+		dstVal, conflict := merged.Fields[k]
+		if !conflict {
+			merged.Fields[k] = srcVal
+			continue
+		}
+
+		fieldPath := k
+		if path != "" {
+			fieldPath = path + "." + k
+		}
+
+		dstMap, dstIsMap := dstVal.(*Map)
+		srcMap, srcIsMap := srcVal.(*Map)
+		if dstIsMap && srcIsMap {
+			m, err := mergeMaps(dstMap, srcMap, strategy, fieldPath)
+			if err != nil {
+				return nil, err
+			}
+			merged.Fields[k] = m
+			continue
+		}
+
+		switch strategy {
+		case MergeKeep:
+		case MergeAppendArrays:
+			dstArr, dstIsArr := dstVal.(*Array)
+			srcArr, srcIsArr := srcVal.(*Array)
+			if dstIsArr && srcIsArr {
+				merged.Fields[k] = NewArray(slices.Concat(dstArr.Values, srcArr.Values))
+				continue
+			}
+			merged.Fields[k] = srcVal
+		case MergeError:
+			return nil, fmt.Errorf("merge conflict at %s", fieldPath)
+		default:
+			merged.Fields[k] = srcVal
+		}
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,184 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestMapMerge(t *testing.T) {
+	c := quicktest.New(t)
+
+	newMap := func(fields map[string]Value) *Map { return NewMap(fields) }
+	stringArray := func(ss ...string) *Array {
+		values := make([]Value, len(ss))
+		for i, s := range ss {
+			values[i] = NewString(s)
+		}
+		return NewArray(values)
+	}
+
+	// dst and src conflict in every way: a scalar, an array, a nested map
+	// with its own conflict and a field only set in each of them.
+	newDst := func() *Map {
+		return newMap(map[string]Value{
+			"name": NewString("dst"),
+			"tags": stringArray("a"),
+			"options": newMap(map[string]Value{
+				"top-k":  NewNumberFromInteger(1),
+				"labels": stringArray("x"),
+				"model":  NewString("small"),
+			}),
+			"dst-only": NewBoolean(true),
+		})
+	}
+	newSrc := func() *Map {
+		return newMap(map[string]Value{
+			"name": NewString("src"),
+			"tags": stringArray("b", "c"),
+			"options": newMap(map[string]Value{
+				"top-k":       NewNumberFromInteger(2),
+				"labels":      stringArray("y"),
+				"temperature": NewNumberFromFloat(0.5),
+			}),
+			"src-only": NewNull(),
+		})
+	}
+
+	testcases := []struct {
+		strategy MergeStrategy
+		want     *Map
+		wantErr  string
+	}{
+		{
+			strategy: MergeOverride,
+			want: newMap(map[string]Value{
+				"name": NewString("src"),
+				"tags": stringArray("b", "c"),
+				"options": newMap(map[string]Value{
+					"top-k":       NewNumberFromInteger(2),
+					"labels":      stringArray("y"),
+					"model":       NewString("small"),
+					"temperature": NewNumberFromFloat(0.5),
+				}),
+				"dst-only": NewBoolean(true),
+				"src-only": NewNull(),
+			}),
+		},
+		{
+			strategy: MergeKeep,
+			want: newMap(map[string]Value{
+				"name": NewString("dst"),
+				"tags": stringArray("a"),
+				"options": newMap(map[string]Value{
+					"top-k":       NewNumberFromInteger(1),
+					"labels":      stringArray("x"),
+					"model":       NewString("small"),
+					"temperature": NewNumberFromFloat(0.5),
+				}),
+				"dst-only": NewBoolean(true),
+				"src-only": NewNull(),
+			}),
+		},
+		{
+			strategy: MergeAppendArrays,
+			want: newMap(map[string]Value{
+				"name": NewString("src"),
+				"tags": stringArray("a", "b", "c"),
+				"options": newMap(map[string]Value{
+					"top-k":       NewNumberFromInteger(2),
+					"labels":      stringArray("x", "y"),
+					"model":       NewString("small"),
+					"temperature": NewNumberFromFloat(0.5),
+				}),
+				"dst-only": NewBoolean(true),
+				"src-only": NewNull(),
+			}),
+		},
+		{
+			// The conflicts are reported in the order of the keys.
+			strategy: MergeError,
+			wantErr:  "merge conflict at name",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.strategy.String(), func(c *quicktest.C) {
+			dst, src := newDst(), newSrc()
+			got, err := dst.Merge(src, tc.strategy)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, valueEquals, tc.want)
+
+			// Neither map is modified.
+			c.Check(dst, valueEquals, newDst())
+			c.Check(src, valueEquals, newSrc())
+		})
+	}
+
+	c.Run("nok - nested conflict", func(c *quicktest.C) {
+		dst := newMap(map[string]Value{
+			"options": newMap(map[string]Value{"model": NewString("small")}),
+			"name":    NewString("dst"),
+		})
+		src := newMap(map[string]Value{
+			"options": newMap(map[string]Value{"model": NewString("large")}),
+		})
+		_, err := dst.Merge(src, MergeError)
+		c.Check(err, quicktest.ErrorMatches, "merge conflict at options.model")
+
+		// The maps are merged recursively without conflicts.
+		src = newMap(map[string]Value{
+			"options": newMap(map[string]Value{"temperature": NewNumberFromFloat(0.5)}),
+		})
+		got, err := dst.Merge(src, MergeError)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, valueEquals, newMap(map[string]Value{
+			"options": newMap(map[string]Value{
+				"model":       NewString("small"),
+				"temperature": NewNumberFromFloat(0.5),
+			}),
+			"name": NewString("dst"),
+		}))
+	})
+
+	c.Run("map and scalar", func(c *quicktest.C) {
+		dst := newMap(map[string]Value{"options": newMap(map[string]Value{"model": NewString("small")})})
+		src := newMap(map[string]Value{"options": NewString("default")})
+
+		got, err := dst.Merge(src, MergeOverride)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got.Fields["options"], valueEquals, NewString("default"))
+
+		got, err = dst.Merge(src, MergeKeep)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got.Fields["options"], valueEquals, dst.Fields["options"])
+	})
+
+	c.Run("nil maps", func(c *quicktest.C) {
+		got, err := (&Map{}).Merge(newMap(map[string]Value{"a": NewString("a")}), MergeError)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, valueEquals, newMap(map[string]Value{"a": NewString("a")}))
+
+		got, err = newMap(map[string]Value{"a": NewString("a")}).Merge(&Map{}, MergeError)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, valueEquals, newMap(map[string]Value{"a": NewString("a")}))
+	})
+}
+
+func TestParseMergeStrategy(t *testing.T) {
+	c := quicktest.New(t)
+
+	for _, strategy := range []MergeStrategy{MergeOverride, MergeKeep, MergeAppendArrays, MergeError} {
+		got, err := ParseMergeStrategy(strategy.String())
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, quicktest.Equals, strategy)
+	}
+
+	_, err := ParseMergeStrategy("union")
+	c.Check(err, quicktest.ErrorMatches, `unknown merge strategy "union"`)
+	c.Check(MergeStrategy(9).String(), quicktest.Equals, "MergeStrategy(9)")
+}
@@ -0,0 +1,627 @@
+package data
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Values can be serialized in MessagePack (https://msgpack.org), a compact
+// binary format that is much cheaper to encode and decode than the
+// structpb/JSON path, as the files don't go through base64 and the numbers
+// aren't formatted. The JSON types map to the MessagePack ones and the rest
+// of the values are extension types whose payload is a MessagePack array:
+//
+//	1  decimal    str: fraction, e.g. 1/3
+//	2  duration   int: nanoseconds
+//	3  date-time  [int: unix nanoseconds, str: IANA location or "", int: offset in seconds]
+//	4  binary     [bin: content, str: content type, str: file name]
+//	5  file       [bin: content, str: content type, str: file name, str: source URL]
+//	6  image      [bin, str, str, str, int: width, int: height]
+//	7  video      same as file
+//	8  audio      same as file
+//	9  document   same as file
+//	10 file ref   [str: URI, str: content type, str: file name, int: size, map: metadata]
+//	11 proto      [str: type URL, bin: message]
+//
+// The content of the files in the blob store is embedded, so the encoded
+// value can be decoded anywhere.
+const (
+	msgpackExtDecimal int8 = iota + 1
+	msgpackExtDuration
+	msgpackExtDateTime
+	msgpackExtBinary
+	msgpackExtFile
+	msgpackExtImage
+	msgpackExtVideo
+	msgpackExtAudio
+	msgpackExtDocument
+	msgpackExtFileRef
+	msgpackExtProto
+)
+
+// MarshalMsgpack encodes a value in MessagePack. The map fields are sorted
+// by key, so equal values have the same encoding.
+func MarshalMsgpack(v Value) ([]byte, error) {
+	return appendMsgpack(nil, v)
+}
+
+// UnmarshalMsgpack decodes a value encoded by MarshalMsgpack. The integers
+// are decoded as numbers.
+func UnmarshalMsgpack(b []byte) (Value, error) {
+	d := &msgpackDecoder{b: b}
+	v, err := d.value()
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(b) {
+		return nil, fmt.Errorf("msgpack: %d trailing bytes", len(b)-d.pos)
+	}
+	return v, nil
+}
+
+func appendMsgpack(b []byte, v Value) ([]byte, error) {
+	var err error
+	switch v := v.(type) {
+	case nil, *Null:
+		return append(b, 0xc0), nil
+	case *Boolean:
+		if v.Raw {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case *Number:
+		// The integers are written in their shortest form.
+		if v.Raw == math.Trunc(v.Raw) && math.Abs(v.Raw) <= maxExactInteger && !(v.Raw == 0 && math.Signbit(v.Raw)) {
+			return appendMsgpackInt(b, int64(v.Raw)), nil
+		}
+		b = append(b, 0xcb)
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(v.Raw)), nil
+	case *String:
+		return appendMsgpackString(b, v.Raw), nil
+	case *ByteArray:
+		return appendMsgpackBin(b, v.Raw), nil
+
+	case *Map:
+		keys := make([]string, 0, len(v.Fields))
+		for k := range v.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b = appendMsgpackHeader(b, len(keys), 0x80, 0xde, 0xdf)
+		for _, k := range keys {
+			b = appendMsgpackString(b, k)
+			if b, err = appendMsgpack(b, v.Fields[k]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+
+	case *Array:
+		b = appendMsgpackHeader(b, len(v.Values), 0x90, 0xdc, 0xdd)
+		for _, item := range v.Values {
+			if b, err = appendMsgpack(b, item); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+
+	case *Decimal:
+		return appendMsgpackExt(b, msgpackExtDecimal, appendMsgpackString(nil, v.GetDecimal().String())), nil
+	case *Duration:
+		return appendMsgpackExt(b, msgpackExtDuration, appendMsgpackInt(nil, int64(v.Raw))), nil
+	case *DateTime:
+		var location string
+		name, offset := v.Raw.Zone()
+		if loc := v.Raw.Location(); loc != time.Local && loc.String() != name {
+			location = loc.String()
+		}
+		p := appendMsgpackHeader(nil, 3, 0x90, 0xdc, 0xdd)
+		p = appendMsgpackInt(p, v.Raw.UnixNano())
+		p = appendMsgpackString(p, location)
+		p = appendMsgpackInt(p, int64(offset))
+		return appendMsgpackExt(b, msgpackExtDateTime, p), nil
+	case *Binary:
+		p := appendMsgpackHeader(nil, 3, 0x90, 0xdc, 0xdd)
+		p = appendMsgpackBin(p, v.Raw)
+		p = appendMsgpackString(p, v.ContentType)
+		p = appendMsgpackString(p, v.FileName)
+		return appendMsgpackExt(b, msgpackExtBinary, p), nil
+	case *Proto:
+		p := appendMsgpackHeader(nil, 2, 0x90, 0xdc, 0xdd)
+		p = appendMsgpackString(p, v.TypeURL())
+		p = appendMsgpackBin(p, v.Raw.GetValue())
+		return appendMsgpackExt(b, msgpackExtProto, p), nil
+
+	case *File:
+		return appendMsgpackFile(b, msgpackExtFile, v, nil)
+	case *Image:
+		return appendMsgpackFile(b, msgpackExtImage, &v.File, []int{v.Width, v.Height})
+	case *Video:
+		return appendMsgpackFile(b, msgpackExtVideo, &v.File, nil)
+	case *Audio:
+		return appendMsgpackFile(b, msgpackExtAudio, &v.File, nil)
+	case *Document:
+		return appendMsgpackFile(b, msgpackExtDocument, &v.File, nil)
+
+	case *FileRef:
+		keys := make([]string, 0, len(v.Metadata))
+		for k := range v.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		p := appendMsgpackHeader(nil, 5, 0x90, 0xdc, 0xdd)
+		p = appendMsgpackString(p, v.URI)
+		p = appendMsgpackString(p, v.ContentType)
+		p = appendMsgpackString(p, v.FileName)
+		p = appendMsgpackInt(p, int64(v.Size))
+		p = appendMsgpackHeader(p, len(keys), 0x80, 0xde, 0xdf)
+		for _, k := range keys {
+			p = appendMsgpackString(p, k)
+			p = appendMsgpackString(p, v.Metadata[k])
+		}
+		return appendMsgpackExt(b, msgpackExtFileRef, p), nil
+	}
+
+	return nil, fmt.Errorf("msgpack: unsupported value %T", v)
+}
+
+func appendMsgpackFile(b []byte, ext int8, f *File, shape []int) ([]byte, error) {
+	raw, err := f.content()
+	if err != nil {
+		return nil, err
+	}
+
+	p := appendMsgpackHeader(nil, 4+len(shape), 0x90, 0xdc, 0xdd)
+	p = appendMsgpackBin(p, raw)
+	p = appendMsgpackString(p, f.ContentType)
+	p = appendMsgpackString(p, f.FileName)
+	p = appendMsgpackString(p, f.SourceURL)
+	for _, n := range shape {
+		p = appendMsgpackInt(p, int64(n))
+	}
+	return appendMsgpackExt(b, ext, p), nil
+}
+
+func appendMsgpackInt(b []byte, i int64) []byte {
+	switch {
+	case i >= 0 && i <= 0x7f:
+		return append(b, byte(i))
+	case i < 0 && i >= -32:
+		return append(b, byte(int8(i)))
+	case i >= math.MinInt8 && i <= math.MaxInt8:
+		return append(b, 0xd0, byte(int8(i)))
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(int16(i)))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(int32(i)))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(i))
+}
+
+// appendMsgpackHeader writes the header of a map or an array, which holds
+// its length: fix is the first byte of the short form, and h16 and h32 the
+// first bytes of the 16- and 32-bit forms.
+func appendMsgpackHeader(b []byte, n int, fix, h16, h32 byte) []byte {
+	switch {
+	case n < 16:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, h16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, h32), uint32(n))
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+func appendMsgpackBin(b []byte, raw []byte) []byte {
+	switch n := len(raw); {
+	case n <= math.MaxUint8:
+		b = append(b, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xc5), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xc6), uint32(n))
+	}
+	return append(b, raw...)
+}
+
+func appendMsgpackExt(b []byte, ext int8, payload []byte) []byte {
+	switch n := len(payload); {
+	case n <= math.MaxUint8:
+		b = append(b, 0xc7, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xc8), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xc9), uint32(n))
+	}
+	return append(append(b, byte(ext)), payload...)
+}
+
+// maxMsgpackDepth is the number of nested arrays, maps and extensions a
+// decoded value can have, so a crafted input can't exhaust the stack. It's
+// the same as the one of encoding/json.
+const maxMsgpackDepth = 10000
+
+// msgpackDecoder reads the values of a MessagePack buffer.
+type msgpackDecoder struct {
+	b     []byte
+	pos   int
+	depth int
+}
+
+// nest is called when a container is entered. The returned function must be
+// called when it's left.
+func (d *msgpackDecoder) nest() (leave func(), err error) {
+	if d.depth >= maxMsgpackDepth {
+		return nil, d.errorf("exceeded max depth of %d", maxMsgpackDepth)
+	}
+	d.depth++
+	return func() { d.depth-- }, nil
+}
+
+func (d *msgpackDecoder) errorf(format string, a ...any) error {
+	return fmt.Errorf("msgpack: offset %d: %s", d.pos, fmt.Sprintf(format, a...))
+}
+
+// read consumes n bytes.
+func (d *msgpackDecoder) read(n int) ([]byte, error) {
+	if n < 0 || n > len(d.b)-d.pos {
+		return nil, d.errorf("unexpected end of data")
+	}
+	p := d.b[d.pos : d.pos+n]
+	d.pos += n
+	return p, nil
+}
+
+// uint reads a big-endian unsigned integer of n bytes.
+func (d *msgpackDecoder) uint(n int) (uint64, error) {
+	p, err := d.read(n)
+	if err != nil {
+		return 0, err
+	}
+	var u uint64
+	for _, c := range p {
+		u = u<<8 | uint64(c)
+	}
+	return u, nil
+}
+
+func (d *msgpackDecoder) value() (Value, error) {
+	p, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c := p[0]; {
+	case c <= 0x7f:
+		return NewNumberFromInteger(int(c)), nil
+	case c >= 0xe0:
+		return NewNumberFromInteger(int(int8(c))), nil
+	case c&0xe0 == 0xa0:
+		return d.str(int(c & 0x1f))
+	case c&0xf0 == 0x90:
+		return d.array(int(c & 0x0f))
+	case c&0xf0 == 0x80:
+		return d.mapping(int(c & 0x0f))
+	}
+
+	var n uint64
+	switch c := p[0]; c {
+	case 0xc0:
+		return NewNull(), nil
+	case 0xc2:
+		return NewBoolean(false), nil
+	case 0xc3:
+		return NewBoolean(true), nil
+
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		if n, err = d.uint(1 << (c - 0xcc)); err != nil {
+			return nil, err
+		}
+		return NewNumberFromFloat(float64(n)), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		i, err := d.signed(c)
+		if err != nil {
+			return nil, err
+		}
+		return NewNumberFromFloat(float64(i)), nil
+	case 0xca:
+		if n, err = d.uint(4); err != nil {
+			return nil, err
+		}
+		return NewNumberFromFloat(float64(math.Float32frombits(uint32(n)))), nil
+	case 0xcb:
+		if n, err = d.uint(8); err != nil {
+			return nil, err
+		}
+		return NewNumberFromFloat(math.Float64frombits(n)), nil
+
+	case 0xd9, 0xda, 0xdb:
+		if n, err = d.uint(1 << (c - 0xd9)); err != nil {
+			return nil, err
+		}
+		return d.str(int(n))
+	case 0xc4, 0xc5, 0xc6:
+		if n, err = d.uint(1 << (c - 0xc4)); err != nil {
+			return nil, err
+		}
+		raw, err := d.read(int(n))
+		if err != nil {
+			return nil, err
+		}
+		return NewByteArray(append([]byte(nil), raw...)), nil
+	case 0xdc, 0xdd:
+		if n, err = d.uint(2 << (c - 0xdc)); err != nil {
+			return nil, err
+		}
+		return d.array(int(n))
+	case 0xde, 0xdf:
+		if n, err = d.uint(2 << (c - 0xde)); err != nil {
+			return nil, err
+		}
+		return d.mapping(int(n))
+
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.ext(1 << (c - 0xd4))
+	case 0xc7, 0xc8, 0xc9:
+		if n, err = d.uint(1 << (c - 0xc7)); err != nil {
+			return nil, err
+		}
+		return d.ext(int(n))
+	}
+
+	return nil, d.errorf("unsupported type 0x%02x", p[0])
+}
+
+// signed reads the signed integer whose type byte is c.
+func (d *msgpackDecoder) signed(c byte) (int64, error) {
+	size := 1 << (c - 0xd0)
+	n, err := d.uint(size)
+	if err != nil {
+		return 0, err
+	}
+	shift := 64 - 8*size
+	return int64(n<<shift) >> shift, nil
+}
+
+// integer reads an integer exactly, as the numbers are decoded as float64,
+// which can't hold every int64.
+func (d *msgpackDecoder) integer() (int64, error) {
+	p, err := d.read(1)
+	if err != nil {
+		return 0, err
+	}
+	switch c := p[0]; {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c >= 0xd0 && c <= 0xd3:
+		return d.signed(c)
+	}
+	return 0, d.errorf("expected an integer")
+}
+
+func (d *msgpackDecoder) str(n int) (*String, error) {
+	p, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+	return NewString(string(p)), nil
+}
+
+func (d *msgpackDecoder) array(n int) (*Array, error) {
+	// Every item takes at least a byte, which bounds the allocation.
+	if n > len(d.b)-d.pos {
+		return nil, d.errorf("unexpected end of data")
+	}
+	leave, err := d.nest()
+	if err != nil {
+		return nil, err
+	}
+	defer leave()
+
+	arr := NewArray(make([]Value, n))
+	for i := range n {
+		v, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		arr.Values[i] = v
+	}
+	return arr, nil
+}
+
+func (d *msgpackDecoder) mapping(n int) (*Map, error) {
+	// Every entry takes at least two bytes.
+	if n > (len(d.b)-d.pos)/2 {
+		return nil, d.errorf("unexpected end of data")
+	}
+	leave, err := d.nest()
+	if err != nil {
+		return nil, err
+	}
+	defer leave()
+
+	mp := NewMap(make(map[string]Value, n))
+	for range n {
+		k, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(*String)
+		if !ok {
+			return nil, d.errorf("map key isn't a string")
+		}
+		if mp.Fields[key.Raw], err = d.value(); err != nil {
+			return nil, err
+		}
+	}
+	return mp, nil
+}
+
+func (d *msgpackDecoder) ext(n int) (Value, error) {
+	t, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := int8(t[0])
+	leave, err := d.nest()
+	if err != nil {
+		return nil, err
+	}
+	defer leave()
+	pd := &msgpackDecoder{b: payload, depth: d.depth}
+	switch ext {
+	case msgpackExtDuration:
+		i, err := pd.integer()
+		if err != nil {
+			return nil, err
+		}
+		return NewDuration(time.Duration(i)), nil
+
+	case msgpackExtDateTime:
+		if h, err := pd.read(1); err != nil || h[0] != 0x93 {
+			return nil, d.errorf("invalid date-time")
+		}
+		unixNano, err := pd.integer()
+		if err != nil {
+			return nil, err
+		}
+		location, err := pd.value()
+		if err != nil {
+			return nil, err
+		}
+		offset, err := pd.integer()
+		if err != nil {
+			return nil, err
+		}
+
+		loc := time.FixedZone("", int(offset))
+		if location, ok := location.(*String); ok && location.Raw != "" {
+			if l, err := time.LoadLocation(location.Raw); err == nil {
+				loc = l
+			}
+		}
+		return NewDateTime(time.Unix(0, unixNano).In(loc)), nil
+	}
+
+	v, err := pd.value()
+	if err != nil {
+		return nil, err
+	}
+
+	switch ext {
+	case msgpackExtDecimal:
+		// The decimals are encoded as fractions. An exponent, e.g.
+		// 1e1000000000, would expand to a huge number.
+		s, ok := v.(*String)
+		if !ok || strings.ContainsAny(s.Raw, "eEpP") {
+			return nil, d.errorf("invalid decimal")
+		}
+		return ParseDecimal(s.Raw)
+	}
+
+	arr, ok := v.(*Array)
+	if !ok {
+		return nil, d.errorf("invalid extension %d", ext)
+	}
+	items := arr.Values
+	str := func(i int) string {
+		if i < len(items) {
+			if s, ok := items[i].(*String); ok {
+				return s.Raw
+			}
+		}
+		return ""
+	}
+	num := func(i int) int64 {
+		if i < len(items) {
+			if n, ok := items[i].(*Number); ok {
+				return int64(n.Raw)
+			}
+		}
+		return 0
+	}
+	raw := func(i int) []byte {
+		if i < len(items) {
+			if ba, ok := items[i].(*ByteArray); ok {
+				return ba.Raw
+			}
+		}
+		return nil
+	}
+
+	switch ext {
+	case msgpackExtBinary:
+		return &Binary{Raw: raw(0), ContentType: str(1), FileName: str(2)}, nil
+	case msgpackExtProto:
+		return NewProtoFromAny(&anypb.Any{TypeUrl: str(0), Value: raw(1)}), nil
+
+	case msgpackExtFile, msgpackExtImage, msgpackExtVideo, msgpackExtAudio, msgpackExtDocument:
+		f := File{
+			Raw:         raw(0),
+			ContentType: str(1),
+			FileName:    str(2),
+			SourceURL:   str(3),
+		}
+		f.Cache = map[string][]byte{f.ContentType: f.Raw}
+		switch ext {
+		case msgpackExtImage:
+			return &Image{File: f, Width: int(num(4)), Height: int(num(5))}, nil
+		case msgpackExtVideo:
+			return &Video{File: f}, nil
+		case msgpackExtAudio:
+			return &Audio{File: f}, nil
+		case msgpackExtDocument:
+			return &Document{File: f}, nil
+		}
+		return &f, nil
+
+	case msgpackExtFileRef:
+		ref := &FileRef{
+			URI:         str(0),
+			ContentType: str(1),
+			FileName:    str(2),
+			Size:        int(num(3)),
+			Metadata:    map[string]string{},
+		}
+		if len(items) > 4 {
+			if md, ok := items[4].(*Map); ok {
+				for k, v := range md.Fields {
+					if s, ok := v.(*String); ok {
+						ref.Metadata[k] = s.Raw
+					}
+				}
+			}
+		}
+		return ref, nil
+	}
+
+	return nil, d.errorf("unsupported extension %d", ext)
+}
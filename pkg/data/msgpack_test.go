@@ -0,0 +1,210 @@
+package data
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// valueOptions compare values by content. The decimals are compared by
+// value and the messages with protocmp.
+var valueOptions = []cmp.Option{
+	cmpopts.IgnoreUnexported(File{}, Map{}, Array{}),
+	cmp.Comparer(func(a, b *big.Rat) bool { return a.Cmp(b) == 0 }),
+	protocmp.Transform(),
+}
+
+var valueEquals = quicktest.CmpEquals(valueOptions...)
+
+// msgpackFile returns a file as it's decoded, with the conversion cache
+// rebuilt from the raw content.
+func msgpackFile(raw, contentType, fileName string) File {
+	return File{
+		Raw:         []byte(raw),
+		ContentType: contentType,
+		FileName:    fileName,
+		SourceURL:   "https://example.com/" + fileName,
+		Cache:       map[string][]byte{contentType: []byte(raw)},
+	}
+}
+
+func msgpackValues(c *quicktest.C) map[string]Value {
+	msg, err := NewProto(structpb.NewStringValue("hello"))
+	c.Assert(err, quicktest.IsNil)
+
+	many := map[string]Value{}
+	items := make([]Value, 20)
+	for i := range items {
+		items[i] = NewNumberFromInteger(i)
+		many[strings.Repeat("k", i+1)] = NewBoolean(i%2 == 0)
+	}
+
+	file := msgpackFile("plain text", "text/plain", "a.txt")
+	return map[string]Value{
+		"null":            NewNull(),
+		"true":            NewBoolean(true),
+		"false":           NewBoolean(false),
+		"positive fixint": NewNumberFromInteger(127),
+		"negative fixint": NewNumberFromInteger(-32),
+		"int8":            NewNumberFromInteger(-100),
+		"int16":           NewNumberFromInteger(1000),
+		"int32":           NewNumberFromInteger(-100000),
+		"int64":           NewNumberFromInteger(1 << 40),
+		"float":           NewNumberFromFloat(-1.5),
+		"short string":    NewString("hello"),
+		"str8":            NewString(strings.Repeat("a", 200)),
+		"str16":           NewString(strings.Repeat("a", 70000)),
+		"byte array":      NewByteArray([]byte{0, 1, 2}),
+		"bin16":           NewByteArray(bytes.Repeat([]byte{1}, 300)),
+		"decimal":         NewDecimal(big.NewRat(-1, 3)),
+		"duration":        NewDuration(-1500 * time.Millisecond),
+		"date-time":       NewDateTime(time.Date(2024, 5, 6, 7, 8, 9, 10, time.FixedZone("", 3600))),
+		"binary":          &Binary{Raw: []byte("raw"), ContentType: "application/octet-stream", FileName: "raw.bin"},
+		"file":            &file,
+		"image":           &Image{File: msgpackFile("png", "image/png", "a.png"), Width: 3, Height: 2},
+		"video":           &Video{File: msgpackFile("mp4", "video/mp4", "a.mp4")},
+		"audio":           &Audio{File: msgpackFile("wav", "audio/wav", "a.wav")},
+		"document":        &Document{File: msgpackFile("pdf", "application/pdf", "a.pdf")},
+		"file ref": &FileRef{
+			URI:         "s3://bucket/key.pdf",
+			ContentType: "application/pdf",
+			FileName:    "key.pdf",
+			Size:        42,
+			Metadata:    map[string]string{"etag": "abc"},
+		},
+		"proto":     msg,
+		"array":     NewArray([]Value{NewString("a"), NewNull(), NewArray(nil)}),
+		"array16":   NewArray(items),
+		"map":       NewMap(map[string]Value{"nested": NewMap(map[string]Value{"n": NewNumberFromInteger(1)}), "empty": NewMap(nil)}),
+		"map16":     NewMap(many),
+		"empty map": NewMap(nil),
+	}
+}
+
+func TestMsgpack(t *testing.T) {
+	c := quicktest.New(t)
+
+	for kind, v := range msgpackValues(c) {
+		c.Run(kind, func(c *quicktest.C) {
+			b, err := MarshalMsgpack(v)
+			c.Assert(err, quicktest.IsNil)
+
+			got, err := UnmarshalMsgpack(b)
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, valueEquals, v)
+		})
+	}
+
+	c.Run("encoding", func(c *quicktest.C) {
+		testcases := []struct {
+			name string
+			v    Value
+			want []byte
+		}{
+			{name: "fixint", v: NewNumberFromInteger(1), want: []byte{0x01}},
+			{name: "negative fixint", v: NewNumberFromInteger(-1), want: []byte{0xff}},
+			{name: "int16", v: NewNumberFromInteger(200), want: []byte{0xd1, 0x00, 0xc8}},
+			{name: "negative zero", v: NewNumberFromFloat(math.Copysign(0, -1)), want: []byte{0xcb, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+			{name: "sorted map", v: NewMap(map[string]Value{"b": NewNumberFromInteger(1), "a": NewBoolean(true)}), want: []byte{0x82, 0xa1, 'a', 0xc3, 0xa1, 'b', 0x01}},
+		}
+		for _, tc := range testcases {
+			c.Run(tc.name, func(c *quicktest.C) {
+				b, err := MarshalMsgpack(tc.v)
+				c.Assert(err, quicktest.IsNil)
+				c.Check(b, quicktest.DeepEquals, tc.want)
+			})
+		}
+	})
+}
+
+func TestUnmarshalMsgpackMalformed(t *testing.T) {
+	c := quicktest.New(t)
+
+	// The long string is left out, as every prefix and byte of the
+	// encoding is decoded.
+	values := msgpackValues(c)
+	delete(values, "str16")
+	all, err := MarshalMsgpack(NewMap(values))
+	c.Assert(err, quicktest.IsNil)
+
+	// Every extension and the array in its payload are nested.
+	deepExt := []byte{0xc0}
+	for range maxMsgpackDepth/2 + 1 {
+		deepExt = appendMsgpackExt(nil, msgpackExtBinary, append([]byte{0x91}, deepExt...))
+	}
+
+	c.Run("truncated", func(c *quicktest.C) {
+		for n := range len(all) {
+			if _, err := UnmarshalMsgpack(all[:n]); err == nil {
+				c.Fatalf("no error when truncated to %d bytes", n)
+			}
+		}
+	})
+
+	c.Run("corrupted", func(c *quicktest.C) {
+		// The corrupted inputs might still be valid, but they must not
+		// make the decoder panic.
+		b := bytes.Clone(all)
+		for i := range b {
+			for _, mask := range []byte{0x01, 0x10, 0x80, 0xff} {
+				b[i] ^= mask
+				_, _ = UnmarshalMsgpack(b)
+				b[i] ^= mask
+			}
+		}
+	})
+
+	testcases := []struct {
+		name    string
+		in      []byte
+		wantErr string
+	}{
+		{name: "empty", in: nil, wantErr: "msgpack: offset 0: unexpected end of data"},
+		{name: "trailing bytes", in: []byte{0xc0, 0xc0}, wantErr: "msgpack: 1 trailing bytes"},
+		{name: "unsupported type", in: []byte{0xc1}, wantErr: "msgpack: offset 1: unsupported type 0xc1"},
+		{name: "oversized array", in: []byte{0xdd, 0xff, 0xff, 0xff, 0xff}, wantErr: ".*unexpected end of data"},
+		{name: "oversized map", in: []byte{0xdf, 0xff, 0xff, 0xff, 0xff, 0xc0, 0xc0}, wantErr: ".*unexpected end of data"},
+		{name: "oversized string", in: []byte{0xdb, 0xff, 0xff, 0xff, 0xff, 'a'}, wantErr: ".*unexpected end of data"},
+		{name: "oversized binary", in: []byte{0xc6, 0xff, 0xff, 0xff, 0xff, 0x00}, wantErr: ".*unexpected end of data"},
+		{name: "oversized extension", in: []byte{0xc9, 0xff, 0xff, 0xff, 0xff, 0x01}, wantErr: ".*unexpected end of data"},
+		{name: "map key isn't a string", in: []byte{0x81, 0x01, 0x01}, wantErr: ".*map key isn't a string"},
+		{
+			name:    "decimal with exponent",
+			in:      appendMsgpackExt(nil, msgpackExtDecimal, appendMsgpackString(nil, "1e1000000000")),
+			wantErr: ".*invalid decimal",
+		},
+		{
+			name:    "too deep",
+			in:      append(bytes.Repeat([]byte{0x91}, maxMsgpackDepth+1), 0xc0),
+			wantErr: ".*exceeded max depth of 10000",
+		},
+		{
+			name:    "too deep in extensions",
+			in:      deepExt,
+			wantErr: ".*exceeded max depth of 10000",
+		},
+		{name: "invalid date-time", in: appendMsgpackExt(nil, msgpackExtDateTime, []byte{0x92, 0x00, 0xa0}), wantErr: ".*invalid date-time"},
+		{name: "unknown extension", in: appendMsgpackExt(nil, 100, []byte{0x90}), wantErr: ".*unsupported extension 100"},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			_, err := UnmarshalMsgpack(tc.in)
+			c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+		})
+	}
+
+	c.Run("max depth", func(c *quicktest.C) {
+		_, err := UnmarshalMsgpack(append(bytes.Repeat([]byte{0x91}, maxMsgpackDepth), 0xc0))
+		c.Check(err, quicktest.IsNil)
+	})
+}
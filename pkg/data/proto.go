@@ -0,0 +1,131 @@
+package data
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Proto is a protobuf message of any type, held in its binary encoding along
+// with its type URL (see anypb.Any). It lets the components that call gRPC
+// services pass typed messages to each other without losing the types that
+// JSON can't express, e.g. 64-bit integers or bytes.
+//
+// It's converted to structpb as the JSON mapping of an Any, e.g.
+// {"@type": "type.googleapis.com/google.protobuf.Timestamp", "value":
+// "2024-01-01T00:00:00Z"}. If the message type isn't linked in the binary,
+// the value is its base64 encoding.
+type Proto struct {
+	Raw *anypb.Any
+}
+
+// NewProto returns the value of a message. An Any is held as is. The
+// message is encoded deterministically, so the equal messages (e.g. with
+// map fields) have the same encoding.
+func NewProto(m proto.Message) (*Proto, error) {
+	if a, ok := m.(*anypb.Any); ok {
+		return NewProtoFromAny(a), nil
+	}
+	a := new(anypb.Any)
+	if err := anypb.MarshalFrom(a, m, proto.MarshalOptions{Deterministic: true}); err != nil {
+		return nil, fmt.Errorf("wrapping proto message: %w", err)
+	}
+	return &Proto{Raw: a}, nil
+}
+
+// NewProtoFromAny returns the value of the message held in an Any.
+func NewProtoFromAny(a *anypb.Any) *Proto {
+	return &Proto{Raw: a}
+}
+
+func (Proto) isValue() {}
+
+// TypeURL returns the type URL of the message, e.g.
+// type.googleapis.com/google.protobuf.Timestamp.
+func (p *Proto) TypeURL() string {
+	return p.Raw.GetTypeUrl()
+}
+
+// MessageName returns the full name of the message type, e.g.
+// google.protobuf.Timestamp.
+func (p *Proto) MessageName() string {
+	return string(p.Raw.MessageName())
+}
+
+// Message decodes the message. Its type must be linked in the binary.
+func (p *Proto) Message() (proto.Message, error) {
+	m, err := p.Raw.UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s message: %w", p.MessageName(), err)
+	}
+	return m, nil
+}
+
+// UnmarshalTo decodes the message into m, which must be of the same type.
+func (p *Proto) UnmarshalTo(m proto.Message) error {
+	if err := p.Raw.UnmarshalTo(m); err != nil {
+		return fmt.Errorf("decoding %s message: %w", p.MessageName(), err)
+	}
+	return nil
+}
+
+// Get returns the type of the message with the `.type-url` and
+// `.message-name` paths. The rest of the paths are read in the JSON mapping
+// of the message, e.g. `.displayName`.
+func (p *Proto) Get(path string) (v Value, err error) {
+	switch {
+	case comparePath(path, ""):
+		return p, nil
+	case comparePath(path, ".type-url"):
+		return NewString(p.TypeURL()), nil
+	case comparePath(path, ".message-name"):
+		return NewString(p.MessageName()), nil
+	}
+
+	sv, err := p.ToStructValue()
+	if err != nil {
+		return nil, err
+	}
+	m, err := NewValueFromStruct(sv)
+	if err != nil {
+		return nil, err
+	}
+	if v, err = m.Get(path); err != nil {
+		return nil, fmt.Errorf("wrong path %s for Proto", path)
+	}
+	return v, nil
+}
+
+func (p Proto) ToStructValue() (v *structpb.Value, err error) {
+	if p.Raw == nil {
+		return structpb.NewNullValue(), nil
+	}
+
+	b, err := protojson.Marshal(p.Raw)
+	if err != nil {
+		// The message type isn't known, so its fields can't be mapped.
+		return structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+			"@type": structpb.NewStringValue(p.Raw.GetTypeUrl()),
+			"value": structpb.NewStringValue(base64.StdEncoding.EncodeToString(p.Raw.GetValue())),
+		}}), nil
+	}
+
+	v = &structpb.Value{}
+	if err := protojson.Unmarshal(b, v); err != nil {
+		return nil, fmt.Errorf("converting %s message: %w", p.MessageName(), err)
+	}
+	return v, nil
+}
+
+// MarshalJSON writes the message as its JSON mapping.
+func (p *Proto) MarshalJSON() ([]byte, error) {
+	sv, err := p.ToStructValue()
+	if err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(sv)
+}
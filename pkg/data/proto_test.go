@@ -0,0 +1,210 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// protoValues returns messages whose types JSON can't express, and one whose
+// type isn't linked in the binary.
+func protoValues(c *quicktest.C) map[string]*Proto {
+	newProto := func(m proto.Message) *Proto {
+		p, err := NewProto(m)
+		c.Assert(err, quicktest.IsNil)
+		return p
+	}
+	settings, err := structpb.NewStruct(map[string]any{"model": "small", "labels": []any{"cat", "dog"}})
+	c.Assert(err, quicktest.IsNil)
+
+	return map[string]*Proto{
+		"int64":     newProto(wrapperspb.Int64(1<<60 + 1)),
+		"bytes":     newProto(wrapperspb.Bytes([]byte{0, 0xff})),
+		"timestamp": newProto(timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC))),
+		"struct":    newProto(settings),
+		"empty":     newProto(&emptypb.Empty{}),
+		"unknown":   NewProtoFromAny(&anypb.Any{TypeUrl: "type.googleapis.com/acme.v1.Invoice", Value: []byte{0x08, 0x96, 0x01}}),
+	}
+}
+
+func TestProto(t *testing.T) {
+	c := quicktest.New(t)
+
+	ts := timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	p, err := NewProto(ts)
+	c.Assert(err, quicktest.IsNil)
+	c.Check(p.TypeURL(), quicktest.Equals, "type.googleapis.com/google.protobuf.Timestamp")
+	c.Check(p.MessageName(), quicktest.Equals, "google.protobuf.Timestamp")
+
+	c.Run("ok - decode", func(c *quicktest.C) {
+		m, err := p.Message()
+		c.Assert(err, quicktest.IsNil)
+		c.Check(proto.Equal(m, ts), quicktest.IsTrue)
+
+		got := new(timestamppb.Timestamp)
+		c.Assert(p.UnmarshalTo(got), quicktest.IsNil)
+		c.Check(proto.Equal(got, ts), quicktest.IsTrue)
+	})
+
+	c.Run("nok - decode", func(c *quicktest.C) {
+		err := p.UnmarshalTo(new(wrapperspb.StringValue))
+		c.Check(err, quicktest.ErrorMatches, "decoding google.protobuf.Timestamp message: .*")
+
+		_, err = NewProtoFromAny(&anypb.Any{TypeUrl: "type.googleapis.com/acme.v1.Invoice"}).Message()
+		c.Check(err, quicktest.ErrorMatches, "decoding acme.v1.Invoice message: .*")
+	})
+
+	c.Run("ok - any", func(c *quicktest.C) {
+		a, err := anypb.New(ts)
+		c.Assert(err, quicktest.IsNil)
+		got, err := NewProto(a)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got.Raw, quicktest.Equals, a)
+	})
+
+	c.Run("ok - deterministic", func(c *quicktest.C) {
+		fields := map[string]any{}
+		for i := range 32 {
+			fields[fmt.Sprintf("field-%d", i)] = i
+		}
+		encodings := map[string]bool{}
+		for range 5 {
+			s, err := structpb.NewStruct(fields)
+			c.Assert(err, quicktest.IsNil)
+			p, err := NewProto(s)
+			c.Assert(err, quicktest.IsNil)
+			encodings[string(p.Raw.GetValue())] = true
+		}
+		c.Check(encodings, quicktest.HasLen, 1)
+	})
+
+	c.Run("ok - JSON mapping", func(c *quicktest.C) {
+		testcases := []struct {
+			name string
+			p    *Proto
+			want string
+		}{
+			{
+				name: "timestamp",
+				p:    p,
+				want: `{"@type": "type.googleapis.com/google.protobuf.Timestamp", "value": "2024-01-02T03:04:05Z"}`,
+			},
+			{
+				// The 64-bit integers are strings in JSON.
+				name: "int64",
+				p:    protoValues(c)["int64"],
+				want: `{"@type": "type.googleapis.com/google.protobuf.Int64Value", "value": "1152921504606846977"}`,
+			},
+			{
+				name: "unknown",
+				p:    protoValues(c)["unknown"],
+				want: `{"@type": "type.googleapis.com/acme.v1.Invoice", "value": "CJYB"}`,
+			},
+			{
+				name: "nil",
+				p:    &Proto{},
+				want: `null`,
+			},
+		}
+
+		for _, tc := range testcases {
+			c.Run(tc.name, func(c *quicktest.C) {
+				b, err := tc.p.MarshalJSON()
+				c.Assert(err, quicktest.IsNil)
+				c.Check(b, quicktest.JSONEquals, json.RawMessage(tc.want))
+			})
+		}
+	})
+
+	c.Run("ok - get", func(c *quicktest.C) {
+		settings := protoValues(c)["struct"]
+
+		testcases := []struct {
+			path    string
+			want    Value
+			wantErr string
+		}{
+			{path: "", want: settings},
+			{path: ".type-url", want: NewString("type.googleapis.com/google.protobuf.Struct")},
+			{path: ".message-name", want: NewString("google.protobuf.Struct")},
+			// The well-known types are held in the value field of their
+			// JSON mapping.
+			{path: ".value.model", want: NewString("small")},
+			{path: ".value.labels[1]", want: NewString("dog")},
+			{path: ".missing", wantErr: "wrong path .missing for Proto"},
+		}
+
+		for _, tc := range testcases {
+			got, err := settings.Get(tc.path)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				continue
+			}
+			c.Assert(err, quicktest.IsNil, quicktest.Commentf(tc.path))
+			c.Check(got, valueEquals, tc.want, quicktest.Commentf(tc.path))
+		}
+	})
+
+	c.Run("ok - equal and copy", func(c *quicktest.C) {
+		for name, p := range protoValues(c) {
+			cp := DeepCopy(p).(*Proto)
+			c.Check(Equal(p, cp), quicktest.IsTrue, quicktest.Commentf(name))
+			c.Check(cp.Raw == p.Raw, quicktest.IsFalse, quicktest.Commentf(name))
+		}
+		values := protoValues(c)
+		c.Check(Equal(values["int64"], values["bytes"]), quicktest.IsFalse)
+	})
+
+	c.Run("ok - YAML", func(c *quicktest.C) {
+		// The messages are written as their JSON mapping.
+		b, err := ToYAML(protoValues(c)["int64"])
+		c.Assert(err, quicktest.IsNil)
+		c.Check(string(b), quicktest.Equals, "'@type': type.googleapis.com/google.protobuf.Int64Value\nvalue: \"1152921504606846977\"\n")
+	})
+}
+
+func TestProtoMsgpack(t *testing.T) {
+	c := quicktest.New(t)
+
+	for name, p := range protoValues(c) {
+		c.Run(name, func(c *quicktest.C) {
+			b, err := MarshalMsgpack(NewArray([]Value{p}))
+			c.Assert(err, quicktest.IsNil)
+
+			// The encoding is deterministic.
+			again, err := MarshalMsgpack(NewArray([]Value{DeepCopy(p)}))
+			c.Assert(err, quicktest.IsNil)
+			c.Check(bytes.Equal(b, again), quicktest.IsTrue)
+
+			got, err := UnmarshalMsgpack(b)
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, valueEquals, NewArray([]Value{p}))
+
+			// The message is decoded with the values JSON can't hold.
+			decoded := got.(*Array).Values[0].(*Proto)
+			c.Check(decoded.TypeURL(), quicktest.Equals, p.TypeURL())
+			c.Check(bytes.Equal(decoded.Raw.GetValue(), p.Raw.GetValue()), quicktest.IsTrue)
+		})
+	}
+
+	c.Run("int64", func(c *quicktest.C) {
+		b, err := MarshalMsgpack(protoValues(c)["int64"])
+		c.Assert(err, quicktest.IsNil)
+		got, err := UnmarshalMsgpack(b)
+		c.Assert(err, quicktest.IsNil)
+
+		n := new(wrapperspb.Int64Value)
+		c.Assert(got.(*Proto).UnmarshalTo(n), quicktest.IsNil)
+		c.Check(n.GetValue(), quicktest.Equals, int64(1<<60+1))
+	})
+}
@@ -0,0 +1,710 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Query evaluates a JSONPath expression on a value, e.g.
+//
+//	$.objects[?(@.score > 0.5)].label
+//
+// The expression supports the child (`.name`, `['name']`), wildcard (`*`),
+// index (`[0]`, `[-1]`), slice (`[1:3]`), union (`['a','b']`, `[0,2]`),
+// recursive descent (`..name`) and filter (`[?(...)]`) selectors. The
+// filters compare the paths relative to the current item (`@`) or to the
+// root (`$`) with numbers, single- or double-quoted strings, booleans and
+// null, through the `==`, `!=`, `<`, `<=`, `>`, `>=` and `=~` (regular
+// expression) operators, combined with `&&`, `||`, `!` and parentheses. A
+// path on its own tests whether it exists and isn't null or false.
+//
+// The paths that select a single value (e.g. `$.objects[0].label`) return
+// that value, or null if it doesn't exist. The rest return an array with the
+// selected values, which might be empty.
+func Query(v Value, expr string) (Value, error) {
+	q, err := parseQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := q.selectFrom(v, v)
+	if q.definite() {
+		if len(matches) == 0 {
+			return NewNull(), nil
+		}
+		return matches[0], nil
+	}
+	return NewArray(matches), nil
+}
+
+// Query evaluates a JSONPath expression on the map. See the Query function.
+func (m *Map) Query(expr string) (Value, error) {
+	return Query(m, expr)
+}
+
+// Query evaluates a JSONPath expression on the array. See the Query
+// function.
+func (a *Array) Query(expr string) (Value, error) {
+	return Query(a, expr)
+}
+
+// queryPath is a sequence of selectors.
+type queryPath []querySelector
+
+func (q queryPath) definite() bool {
+	for _, s := range q {
+		if s.recursive {
+			return false
+		}
+		switch s.kind {
+		case selectName:
+			if len(s.names) != 1 {
+				return false
+			}
+		case selectIndex:
+			if len(s.indices) != 1 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// selectFrom applies the selectors to a value. The root is the value against
+// which the paths starting with `$` in the filters are evaluated.
+func (q queryPath) selectFrom(v, root Value) []Value {
+	nodes := []Value{v}
+	for _, s := range q {
+		var next []Value
+		for _, n := range nodes {
+			if s.recursive {
+				for _, d := range descendants(n) {
+					next = append(next, s.apply(d, root)...)
+				}
+				continue
+			}
+			next = append(next, s.apply(n, root)...)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+type selectorKind int
+
+const (
+	selectName selectorKind = iota
+	selectWildcard
+	selectIndex
+	selectSlice
+	selectFilter
+)
+
+type querySelector struct {
+	kind selectorKind
+	// recursive applies the selector to the value and all its descendants.
+	recursive bool
+
+	names   []string
+	indices []int
+	// start and end are the bounds of a slice. They're nil when omitted.
+	start, end *int
+	filter     queryExpr
+}
+
+func (s querySelector) apply(v Value, root Value) []Value {
+	switch s.kind {
+	case selectName:
+		var selected []Value
+		for _, name := range s.names {
+			if child, ok := childOf(v, name); ok {
+				selected = append(selected, child)
+			}
+		}
+		return selected
+	case selectWildcard:
+		return childrenOf(v)
+	case selectIndex:
+		arr, ok := v.(*Array)
+		if !ok {
+			return nil
+		}
+		var selected []Value
+		for _, i := range s.indices {
+			if i < 0 {
+				i += len(arr.Values)
+			}
+			if i >= 0 && i < len(arr.Values) {
+				selected = append(selected, arr.Values[i])
+			}
+		}
+		return selected
+	case selectSlice:
+		arr, ok := v.(*Array)
+		if !ok {
+			return nil
+		}
+		bound := func(b *int, defaultValue int) int {
+			if b == nil {
+				return defaultValue
+			}
+			i := *b
+			if i < 0 {
+				i += len(arr.Values)
+			}
+			return min(max(i, 0), len(arr.Values))
+		}
+		start, end := bound(s.start, 0), bound(s.end, len(arr.Values))
+		if start >= end {
+			return nil
+		}
+		return arr.Values[start:end]
+	case selectFilter:
+		var selected []Value
+		for _, child := range childrenOf(v) {
+			if truthy(s.filter.eval(child, root)) {
+				selected = append(selected, child)
+			}
+		}
+		return selected
+	}
+	return nil
+}
+
+// childOf returns a field of a map. The rest of the values are read through
+// their paths, e.g. the width of an image.
+func childOf(v Value, name string) (Value, bool) {
+	switch v := v.(type) {
+	case *Map:
+		child, ok := v.Fields[name]
+		return child, ok && child != nil
+	case *Array, *Null, nil:
+		return nil, false
+	}
+	child, err := v.Get("." + name)
+	return child, err == nil
+}
+
+// childrenOf returns the items of an array or the field values of a map,
+// sorted by key.
+func childrenOf(v Value) []Value {
+	switch v := v.(type) {
+	case *Array:
+		return v.Values
+	case *Map:
+		keys := make([]string, 0, len(v.Fields))
+		for k := range v.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		children := make([]Value, 0, len(keys))
+		for _, k := range keys {
+			if v.Fields[k] != nil {
+				children = append(children, v.Fields[k])
+			}
+		}
+		return children
+	}
+	return nil
+}
+
+// descendants returns a value and all the values nested in it.
+func descendants(v Value) []Value {
+	all := []Value{v}
+	for _, child := range childrenOf(v) {
+		all = append(all, descendants(child)...)
+	}
+	return all
+}
+
+// queryExpr is an expression of a filter. It returns nil when it refers to
+// a path that doesn't exist.
+type queryExpr interface {
+	eval(current, root Value) Value
+}
+
+type queryLiteral struct{ v Value }
+
+func (e queryLiteral) eval(_, _ Value) Value { return e.v }
+
+type queryPathExpr struct {
+	// fromRoot tells whether the path starts with `$` rather than `@`.
+	fromRoot bool
+	path     queryPath
+}
+
+func (e queryPathExpr) eval(current, root Value) Value {
+	start := current
+	if e.fromRoot {
+		start = root
+	}
+	matches := e.path.selectFrom(start, root)
+	switch {
+	case len(matches) == 0:
+		return nil
+	case e.path.definite():
+		return matches[0]
+	}
+	return NewArray(matches)
+}
+
+type queryNot struct{ operand queryExpr }
+
+func (e queryNot) eval(current, root Value) Value {
+	return NewBoolean(!truthy(e.operand.eval(current, root)))
+}
+
+type queryLogical struct {
+	op          string
+	left, right queryExpr
+}
+
+func (e queryLogical) eval(current, root Value) Value {
+	left := truthy(e.left.eval(current, root))
+	if e.op == "&&" {
+		return NewBoolean(left && truthy(e.right.eval(current, root)))
+	}
+	return NewBoolean(left || truthy(e.right.eval(current, root)))
+}
+
+type queryComparison struct {
+	op          string
+	left, right queryExpr
+}
+
+func (e queryComparison) eval(current, root Value) Value {
+	left, right := e.left.eval(current, root), e.right.eval(current, root)
+
+	switch e.op {
+	case "==":
+		return NewBoolean(queryEqual(left, right))
+	case "!=":
+		return NewBoolean(!queryEqual(left, right))
+	case "=~":
+		s, ok1 := left.(*String)
+		p, ok2 := right.(*String)
+		if !ok1 || !ok2 {
+			return NewBoolean(false)
+		}
+		matched, err := regexp.MatchString(p.Raw, s.Raw)
+		return NewBoolean(err == nil && matched)
+	}
+
+	cmp, ok := queryCompare(left, right)
+	if !ok {
+		return NewBoolean(false)
+	}
+	switch e.op {
+	case "<":
+		return NewBoolean(cmp < 0)
+	case "<=":
+		return NewBoolean(cmp <= 0)
+	case ">":
+		return NewBoolean(cmp > 0)
+	default:
+		return NewBoolean(cmp >= 0)
+	}
+}
+
+func truthy(v Value) bool {
+	switch v := v.(type) {
+	case nil, *Null:
+		return false
+	case *Boolean:
+		return v.Raw
+	}
+	return true
+}
+
+func queryNumber(v Value) (float64, bool) {
+	switch v := v.(type) {
+	case *Number:
+		return v.Raw, true
+	case *Decimal:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+func queryEqual(a, b Value) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if cmp, ok := queryCompare(a, b); ok {
+		return cmp == 0
+	}
+	sa, err := a.ToStructValue()
+	if err != nil {
+		return false
+	}
+	sb, err := b.ToStructValue()
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(sa.AsInterface(), sb.AsInterface())
+}
+
+// queryCompare orders two numbers or two strings. ok is false if the values
+// can't be ordered.
+func queryCompare(a, b Value) (cmp int, ok bool) {
+	if da, ok := a.(*Decimal); ok {
+		if db, ok := b.(*Decimal); ok {
+			return da.Compare(db), true
+		}
+	}
+	if na, ok := queryNumber(a); ok {
+		if nb, ok := queryNumber(b); ok {
+			switch {
+			case na < nb:
+				return -1, true
+			case na > nb:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	if sa, ok := a.(*String); ok {
+		if sb, ok := b.(*String); ok {
+			return strings.Compare(sa.Raw, sb.Raw), true
+		}
+	}
+	return 0, false
+}
+
+// queryParser parses a JSONPath expression.
+type queryParser struct {
+	s   string
+	pos int
+}
+
+func parseQuery(expr string) (queryPath, error) {
+	p := &queryParser{s: strings.TrimSpace(expr)}
+	if p.peek() == '$' {
+		p.pos++
+	} else if p.peek() != '.' && p.peek() != '[' && p.peek() != 0 {
+		// The leading `$.` can be omitted.
+		p.s = "." + p.s
+	}
+
+	q, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return q, nil
+}
+
+func (p *queryParser) errorf(format string, a ...any) error {
+	return fmt.Errorf("invalid query %q at position %d: %s", p.s, p.pos, fmt.Sprintf(format, a...))
+}
+
+func (p *queryParser) peek() byte {
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *queryParser) skipSpaces() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *queryParser) consume(token string) bool {
+	if strings.HasPrefix(p.s[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) parsePath() (queryPath, error) {
+	var q queryPath
+	for {
+		var s querySelector
+		var err error
+		switch {
+		case p.consume(".."):
+			if p.peek() == '[' {
+				s, err = p.parseBracket()
+			} else {
+				s, err = p.parseDotted()
+			}
+			s.recursive = true
+		case p.consume("."):
+			s, err = p.parseDotted()
+		case p.peek() == '[':
+			s, err = p.parseBracket()
+		default:
+			return q, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		q = append(q, s)
+	}
+}
+
+// parseDotted parses the selector after a dot, which is a wildcard or a
+// name. The names can hold hyphens, e.g. `.content-type`.
+func (p *queryParser) parseDotted() (querySelector, error) {
+	if p.consume("*") {
+		return querySelector{kind: selectWildcard}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(".[]()<>=!&|,'\" \t", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	if p.pos == start {
+		return querySelector{}, p.errorf("expected a name")
+	}
+	return querySelector{kind: selectName, names: []string{p.s[start:p.pos]}}, nil
+}
+
+func (p *queryParser) parseBracket() (querySelector, error) {
+	p.pos++ // [
+	p.skipSpaces()
+
+	var s querySelector
+	var err error
+	switch c := p.peek(); {
+	case c == '?':
+		p.pos++
+		s.kind = selectFilter
+		s.filter, err = p.parseOr()
+	case c == '*':
+		p.pos++
+		s.kind = selectWildcard
+	case c == '\'' || c == '"':
+		s.kind = selectName
+		for {
+			var name string
+			if name, err = p.parseString(); err != nil {
+				break
+			}
+			s.names = append(s.names, name)
+			if p.skipSpaces(); !p.consume(",") {
+				break
+			}
+			p.skipSpaces()
+		}
+	default:
+		s, err = p.parseIndices()
+	}
+	if err != nil {
+		return querySelector{}, err
+	}
+
+	p.skipSpaces()
+	if !p.consume("]") {
+		return querySelector{}, p.errorf("expected ']'")
+	}
+	return s, nil
+}
+
+// parseIndices parses a slice or a union of indices.
+func (p *queryParser) parseIndices() (querySelector, error) {
+	first, hasFirst, err := p.parseInt()
+	if err != nil {
+		return querySelector{}, err
+	}
+
+	p.skipSpaces()
+	if p.consume(":") {
+		s := querySelector{kind: selectSlice}
+		if hasFirst {
+			s.start = &first
+		}
+		p.skipSpaces()
+		end, hasEnd, err := p.parseInt()
+		if err != nil {
+			return querySelector{}, err
+		}
+		if hasEnd {
+			s.end = &end
+		}
+		return s, nil
+	}
+
+	if !hasFirst {
+		return querySelector{}, p.errorf("expected an index")
+	}
+	s := querySelector{kind: selectIndex, indices: []int{first}}
+	for p.skipSpaces(); p.consume(","); p.skipSpaces() {
+		p.skipSpaces()
+		i, ok, err := p.parseInt()
+		if err != nil {
+			return querySelector{}, err
+		}
+		if !ok {
+			return querySelector{}, p.errorf("expected an index")
+		}
+		s.indices = append(s.indices, i)
+	}
+	return s, nil
+}
+
+func (p *queryParser) parseInt() (n int, ok bool, err error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, false, nil
+	}
+	n, err = strconv.Atoi(p.s[start:p.pos])
+	if err != nil {
+		return 0, false, p.errorf("invalid index %q", p.s[start:p.pos])
+	}
+	return n, true, nil
+}
+
+// parseString parses a single- or double-quoted string. A quote is escaped
+// with a backslash.
+func (p *queryParser) parseString() (string, error) {
+	quote := p.peek()
+	if quote != '\'' && quote != '"' {
+		return "", p.errorf("expected a string")
+	}
+	p.pos++
+
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		p.pos++
+		switch {
+		case c == '\\' && p.pos < len(p.s):
+			b.WriteByte(p.s[p.pos])
+			p.pos++
+		case c == quote:
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *queryParser) parseOr() (queryExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.skipSpaces(); p.consume("||"); p.skipSpaces() {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = queryLogical{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.skipSpaces(); p.consume("&&"); p.skipSpaces() {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = queryLogical{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseNot() (queryExpr, error) {
+	p.skipSpaces()
+	if p.peek() == '!' && !strings.HasPrefix(p.s[p.pos:], "!=") {
+		p.pos++
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() (queryExpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	// The two-character operators go first, so `<=` isn't read as `<`.
+	for _, op := range []string{"==", "!=", "<=", ">=", "=~", "<", ">"} {
+		if p.consume(op) {
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return queryComparison{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseOperand() (queryExpr, error) {
+	p.skipSpaces()
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, p.errorf("expected ')'")
+		}
+		return e, nil
+	case c == '@' || c == '$':
+		p.pos++
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		return queryPathExpr{fromRoot: c == '$', path: path}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return queryLiteral{v: NewString(s)}, nil
+	case c == '-' || c >= '0' && c <= '9':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.s) && strings.ContainsRune("0123456789.eE+-", rune(p.s[p.pos])) {
+			p.pos++
+		}
+		f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", p.s[start:p.pos])
+		}
+		return queryLiteral{v: NewNumberFromFloat(f)}, nil
+	case p.consume("true"):
+		return queryLiteral{v: NewBoolean(true)}, nil
+	case p.consume("false"):
+		return queryLiteral{v: NewBoolean(false)}, nil
+	case p.consume("null"):
+		return queryLiteral{v: NewNull()}, nil
+	}
+	return nil, p.errorf("expected a path or a literal")
+}
@@ -0,0 +1,155 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestQuery(t *testing.T) {
+	c := quicktest.New(t)
+
+	v, err := NewValue(map[string]any{
+		"store": map[string]any{
+			"book": []any{
+				map[string]any{"title": "A", "price": 8.95, "category": "reference"},
+				map[string]any{"title": "B", "price": 12.99, "category": "fiction", "isbn": "0-553"},
+				map[string]any{"title": "C", "price": 8.99, "category": "fiction", "isbn": "0-395"},
+			},
+			"bicycle": map[string]any{"color": "red", "price": 19.95},
+		},
+		"limit":        10,
+		"content-type": "text/plain",
+		"mixed":        []any{1, "1", true, nil, map[string]any{"v": 2}, []any{3}},
+	})
+	c.Assert(err, quicktest.IsNil)
+	doc := v.(*Map)
+	doc.Fields["total"], err = ParseDecimal("12.99")
+	c.Assert(err, quicktest.IsNil)
+
+	testcases := []struct {
+		expr string
+		want any
+	}{
+		// Child selectors.
+		{expr: "$.store.bicycle.color", want: "red"},
+		{expr: "store.bicycle.color", want: "red"},
+		{expr: "$['store']['bicycle']['color']", want: "red"},
+		{expr: `$["content-type"]`, want: "text/plain"},
+		{expr: "$.content-type", want: "text/plain"},
+		{expr: "$.store.bicycle['color', 'price']", want: []any{"red", 19.95}},
+		{expr: "$.store.missing", want: nil},
+		{expr: "$.limit.missing", want: nil},
+		{expr: "$.store.book.title", want: nil},
+
+		// Indexes and slices.
+		{expr: "$.store.book[0].title", want: "A"},
+		{expr: "$.store.book[-1].title", want: "C"},
+		{expr: "$.store.book[3].title", want: nil},
+		{expr: "$.store.book[-4].title", want: nil},
+		{expr: "$.store.book[0, 2].title", want: []any{"A", "C"}},
+		{expr: "$.store.book[0,7,-9].title", want: []any{"A"}},
+		{expr: "$.store.book[1:].title", want: []any{"B", "C"}},
+		{expr: "$.store.book[:-1].title", want: []any{"A", "B"}},
+		{expr: "$.store.book[-100:100].title", want: []any{"A", "B", "C"}},
+		{expr: "$.store.book[2:1]", want: []any{}},
+		{expr: "$.store.bicycle[0]", want: nil},
+
+		// Wildcards and recursive descent.
+		{expr: "$.store.book[*].title", want: []any{"A", "B", "C"}},
+		{expr: "$.store.bicycle.*", want: []any{"red", 19.95}},
+		{expr: "$..price", want: []any{19.95, 8.95, 12.99, 8.99}},
+		{expr: "$..book[0].title", want: []any{"A"}},
+		{expr: "$..missing", want: []any{}},
+
+		// Filters.
+		{expr: "$.store.book[?(@.price < 9)].title", want: []any{"A", "C"}},
+		{expr: "$.store.book[?@.price < 9].title", want: []any{"A", "C"}},
+		{expr: "$.store.book[?(@.isbn)].title", want: []any{"B", "C"}},
+		{expr: "$.store.book[?(!@.isbn)].title", want: []any{"A"}},
+		{expr: "$.store.book[?(@.category == 'fiction' && @.price > 10)].title", want: []any{"B"}},
+		{expr: `$.store.book[?(@.category == "reference" || @.price > 12)].title`, want: []any{"A", "B"}},
+		{expr: "$.store.book[?(!(@.price > 9))].title", want: []any{"A", "C"}},
+		{expr: "$.store.book[?(@.price >= 8.99 && @.price <= 12.99)].title", want: []any{"B", "C"}},
+		{expr: "$.store.book[?(@.price != 8.95)].title", want: []any{"B", "C"}},
+		{expr: "$.store.book[?(@.price > $.limit)].title", want: []any{"B"}},
+		{expr: "$.store.book[?(@.title =~ '^[AB]$')].title", want: []any{"A", "B"}},
+		{expr: "$.store.book[?(@.title =~ '[')].title", want: []any{}},
+		{expr: "$.store.book[?(@.missing == null)].title", want: []any{}},
+
+		// Filters on values of mixed types, which only match the values
+		// of the same type.
+		{expr: "$.mixed[?(@ == 1)]", want: []any{1.0}},
+		{expr: "$.mixed[?(@ == '1')]", want: []any{"1"}},
+		{expr: "$.mixed[?(@ > 0)]", want: []any{1.0}},
+		{expr: "$.mixed[?(@ < 'z')]", want: []any{"1"}},
+		{expr: "$.mixed[?(@ =~ '1')]", want: []any{"1"}},
+		{expr: "$.mixed[?(@ == true)]", want: []any{true}},
+		{expr: "$.mixed[?(@ == null)]", want: []any{nil}},
+		{expr: "$.mixed[?(@)]", want: []any{1.0, "1", true, map[string]any{"v": 2.0}, []any{3.0}}},
+		{expr: "$.mixed[?(@.v == 2)]", want: []any{map[string]any{"v": 2.0}}},
+		{expr: "$.mixed[?(@[0] == 3)]", want: []any{[]any{3.0}}},
+		{expr: "$.limit[?(@ == 10)]", want: []any{}},
+
+		// Decimals are compared with numbers by value.
+		{expr: "$[?(@ == 12.99)]", want: []any{"12.99"}},
+		{expr: "$[?(@ > 11)]", want: []any{"12.99"}},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.expr, func(c *quicktest.C) {
+			got, err := doc.Query(tc.expr)
+			c.Assert(err, quicktest.IsNil)
+			pbv, err := got.ToStructValue()
+			c.Assert(err, quicktest.IsNil)
+			c.Check(pbv.AsInterface(), quicktest.DeepEquals, tc.want)
+		})
+	}
+
+	c.Run("root", func(c *quicktest.C) {
+		got, err := doc.Query("$")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, quicktest.Equals, Value(doc))
+	})
+
+	c.Run("array", func(c *quicktest.C) {
+		got, err := NewArray([]Value{NewString("a"), NewString("b")}).Query("[-1]")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, quicktest.DeepEquals, Value(NewString("b")))
+	})
+}
+
+func TestQueryMalformed(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "$.store[", wantErr: "expected an index"},
+		{expr: "$.store[0", wantErr: "expected ']'"},
+		{expr: "$.store[0,]", wantErr: "expected an index"},
+		{expr: "$.store[0:1:2]", wantErr: "expected ']'"},
+		{expr: "$.store[-]", wantErr: `invalid index "-"`},
+		{expr: "$.store[99999999999999999999]", wantErr: `invalid index "99999999999999999999"`},
+		{expr: "$.store.", wantErr: "expected a name"},
+		{expr: "$..", wantErr: "expected a name"},
+		{expr: "$.store]", wantErr: `unexpected "\]"`},
+		{expr: "$.store['a", wantErr: "unterminated string"},
+		{expr: "$.store['a' 'b']", wantErr: "expected ']'"},
+		{expr: "$.store[?(@.price < 9]", wantErr: "expected '\\)'"},
+		{expr: "$.store[?(@.price < )]", wantErr: "expected a path or a literal"},
+		{expr: "$.store[?(@.price < 1e)]", wantErr: `invalid number "1e"`},
+		{expr: "$.store[?(@.price < 9) @.title]", wantErr: "expected ']'"},
+		{expr: "$.store[?()]", wantErr: "expected a path or a literal"},
+		{expr: "$.store[?(!)]", wantErr: "expected a path or a literal"},
+		{expr: "$.store[?(@.a &&)]", wantErr: "expected a path or a literal"},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.expr, func(c *quicktest.C) {
+			_, err := Query(NewMap(nil), tc.expr)
+			c.Check(err, quicktest.ErrorMatches, `invalid query ".*" at position \d+: `+tc.wantErr)
+		})
+	}
+}
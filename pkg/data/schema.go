@@ -0,0 +1,363 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Schema is a JSON Schema against which the values can be validated, e.g.
+// the input schema of a component task. Only the keywords used by the
+// component definitions are checked; the rest are ignored.
+type Schema struct {
+	root map[string]any
+
+	mu       sync.Mutex
+	patterns map[string]*regexp.Regexp
+}
+
+// ParseSchema parses a JSON Schema document.
+func ParseSchema(b []byte) (*Schema, error) {
+	var root map[string]any
+	if err := json.Unmarshal(b, &root); err != nil {
+		return nil, fmt.Errorf("parsing JSON Schema: %w", err)
+	}
+	return &Schema{root: root, patterns: map[string]*regexp.Regexp{}}, nil
+}
+
+// ValidationError is a value that doesn't match its schema.
+type ValidationError struct {
+	// Path is the location of the value, e.g. comp.input.texts[0].
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("field %s %s at %s", fieldName(e.Path), e.Message, e.Path)
+}
+
+// fieldName returns the last key of a path, e.g. texts[0] for
+// comp.input.texts[0].
+func fieldName(path string) string {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
+
+// ValidationErrors holds every mismatch found in a value.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate checks a value against the schema. The path is the location of
+// the value, which prefixes the paths of the errors. It returns
+// ValidationErrors if the value doesn't match.
+//
+// The values are checked as they're converted to structpb, e.g. the files
+// are strings (their data URI) and the fields with a null value are
+// missing. Decimals are accepted both as numbers and as strings.
+func (s *Schema) Validate(v Value, path string) error {
+	if errs := s.validate(v, s.root, path); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (s *Schema) validate(v Value, sch map[string]any, path string) (errs ValidationErrors) {
+	addErr := func(format string, a ...any) {
+		errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+
+	if types := schemaTypes(sch["type"]); len(types) > 0 {
+		if !slices.ContainsFunc(types, func(t string) bool { return matchesType(v, t) }) {
+			addErr("must be %s, got %s", strings.Join(types, " or "), typeName(v))
+
+			// The rest of the keywords don't apply to a value of the wrong
+			// type.
+			return errs
+		}
+	}
+
+	if c, ok := sch["const"]; ok && !equalsJSON(v, c) {
+		addErr("must be %v", c)
+	}
+	if enum, ok := sch["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return equalsJSON(v, e) }) {
+		addErr("must be one of %v", enum)
+	}
+
+	switch v := v.(type) {
+	case *Map:
+		errs = append(errs, s.validateMap(v, sch, path)...)
+	case *Array:
+		errs = append(errs, s.validateArray(v, sch, path)...)
+	case *String:
+		errs = append(errs, s.validateString(v, sch, path)...)
+	case *Number:
+		errs = append(errs, validateNumber(v.Raw, sch, path)...)
+	case *Decimal:
+		errs = append(errs, validateNumber(v.Float(), sch, path)...)
+	}
+
+	if all, ok := sch["allOf"].([]any); ok {
+		for _, sub := range all {
+			if sub, ok := sub.(map[string]any); ok {
+				errs = append(errs, s.validate(v, sub, path)...)
+			}
+		}
+	}
+	if anyOf, ok := sch["anyOf"].([]any); ok {
+		if matches, closest := s.validateBranches(v, anyOf, path); matches == 0 {
+			errs = append(errs, closest...)
+		}
+	}
+	if oneOf, ok := sch["oneOf"].([]any); ok {
+		matches, closest := s.validateBranches(v, oneOf, path)
+		switch {
+		case matches == 0:
+			errs = append(errs, closest...)
+		case matches > 1:
+			addErr("must match exactly one of the allowed schemas")
+		}
+	}
+
+	return errs
+}
+
+// validateBranches validates a value against the branches of anyOf or oneOf.
+// It returns the number of branches that match and, if none does, the errors
+// of the branch that matches best, which is usually the one the user meant.
+func (s *Schema) validateBranches(v Value, branches []any, path string) (matches int, closest ValidationErrors) {
+	for _, b := range branches {
+		b, ok := b.(map[string]any)
+		if !ok {
+			continue
+		}
+		errs := s.validate(v, b, path)
+		if len(errs) == 0 {
+			matches++
+			continue
+		}
+		if closest == nil || len(errs) < len(closest) {
+			closest = errs
+		}
+	}
+	return matches, closest
+}
+
+func (s *Schema) validateMap(m *Map, sch map[string]any, path string) (errs ValidationErrors) {
+	// The null fields are dropped when the map is converted to structpb.
+	present := func(k string) bool {
+		v, ok := m.Fields[k]
+		if !ok || v == nil {
+			return false
+		}
+		_, isNull := v.(*Null)
+		return !isNull
+	}
+
+	if required, ok := sch["required"].([]any); ok {
+		for _, r := range required {
+			if k, ok := r.(string); ok && !present(k) {
+				errs = append(errs, &ValidationError{Path: joinPath(path, k), Message: "is required"})
+			}
+		}
+	}
+
+	props, _ := sch["properties"].(map[string]any)
+	keys := make([]string, 0, len(m.Fields))
+	for k := range m.Fields {
+		if present(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if prop, ok := props[k].(map[string]any); ok {
+			errs = append(errs, s.validate(m.Fields[k], prop, joinPath(path, k))...)
+			continue
+		}
+		if _, ok := props[k]; ok {
+			continue
+		}
+
+		switch additional := sch["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				errs = append(errs, &ValidationError{Path: joinPath(path, k), Message: "is not allowed"})
+			}
+		case map[string]any:
+			errs = append(errs, s.validate(m.Fields[k], additional, joinPath(path, k))...)
+		}
+	}
+
+	return errs
+}
+
+func (s *Schema) validateArray(a *Array, sch map[string]any, path string) (errs ValidationErrors) {
+	if n, ok := schemaInt(sch["minItems"]); ok && len(a.Values) < n {
+		errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf("must have at least %d items", n)})
+	}
+	if n, ok := schemaInt(sch["maxItems"]); ok && len(a.Values) > n {
+		errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf("must have at most %d items", n)})
+	}
+
+	if items, ok := sch["items"].(map[string]any); ok {
+		for i, item := range a.Values {
+			if item == nil {
+				item = NewNull()
+			}
+			errs = append(errs, s.validate(item, items, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+
+	return errs
+}
+
+func (s *Schema) validateString(str *String, sch map[string]any, path string) (errs ValidationErrors) {
+	length := utf8.RuneCountInString(str.Raw)
+	if n, ok := schemaInt(sch["minLength"]); ok && length < n {
+		errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf("must have at least %d characters", n)})
+	}
+	if n, ok := schemaInt(sch["maxLength"]); ok && length > n {
+		errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf("must have at most %d characters", n)})
+	}
+
+	if pattern, ok := sch["pattern"].(string); ok {
+		// The patterns that RE2 can't compile (e.g. the ones with
+		// lookarounds) aren't checked.
+		if re := s.pattern(pattern); re != nil && !re.MatchString(str.Raw) {
+			errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf("must match the pattern %s", pattern)})
+		}
+	}
+
+	return errs
+}
+
+func (s *Schema) pattern(p string) *regexp.Regexp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	re, ok := s.patterns[p]
+	if !ok {
+		re, _ = regexp.Compile(p)
+		s.patterns[p] = re
+	}
+	return re
+}
+
+func validateNumber(n float64, sch map[string]any, path string) (errs ValidationErrors) {
+	if minimum, ok := sch["minimum"].(float64); ok && n < minimum {
+		errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf("must be at least %v", minimum)})
+	}
+	if maximum, ok := sch["maximum"].(float64); ok && n > maximum {
+		errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf("must be at most %v", maximum)})
+	}
+	if bound, ok := sch["exclusiveMinimum"].(float64); ok && n <= bound {
+		errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf("must be greater than %v", bound)})
+	}
+	if bound, ok := sch["exclusiveMaximum"].(float64); ok && n >= bound {
+		errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf("must be lower than %v", bound)})
+	}
+	if m, ok := sch["multipleOf"].(float64); ok && m > 0 {
+		if q := n / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf("must be a multiple of %v", m)})
+		}
+	}
+	return errs
+}
+
+// schemaTypes reads the type keyword, which can be a type or a list of
+// types.
+func schemaTypes(t any) []string {
+	switch t := t.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, s := range t {
+			if s, ok := s.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func schemaInt(v any) (int, bool) {
+	f, ok := v.(float64)
+	return int(f), ok
+}
+
+// typeName returns the JSON type of a value.
+func typeName(v Value) string {
+	switch v := v.(type) {
+	case nil, *Null:
+		return "null"
+	case *Boolean:
+		return "boolean"
+	case *Number:
+		if v.Raw == math.Trunc(v.Raw) {
+			return "integer"
+		}
+		return "number"
+	case *Decimal:
+		if v.IsInteger() {
+			return "integer"
+		}
+		return "number"
+	case *Map, *Proto:
+		return "object"
+	case *Array:
+		return "array"
+	}
+	// Strings, files and the rest of the values that are written as
+	// strings.
+	return "string"
+}
+
+func matchesType(v Value, t string) bool {
+	actual := typeName(v)
+	switch t {
+	case "number":
+		return actual == "number" || actual == "integer"
+	case "string":
+		if _, ok := v.(*Decimal); ok {
+			return true
+		}
+	}
+	return actual == t
+}
+
+// equalsJSON compares a value with a JSON value of the schema.
+func equalsJSON(v Value, j any) bool {
+	if v == nil {
+		return j == nil
+	}
+	sv, err := v.ToStructValue()
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(sv.AsInterface(), j)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
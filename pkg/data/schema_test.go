@@ -0,0 +1,215 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	c := quicktest.New(t)
+
+	file, err := NewFileFromBytes([]byte("hello"), "text/plain", "hello.txt")
+	c.Assert(err, quicktest.IsNil)
+	ref := &FileRef{URI: "https://example.com/report.pdf"}
+	decimal := func(s string) Value {
+		d, err := ParseDecimal(s)
+		c.Assert(err, quicktest.IsNil)
+		return d
+	}
+	newMap := func(fields map[string]Value) Value { return NewMap(fields) }
+
+	testcases := []struct {
+		name    string
+		schema  string
+		value   Value
+		wantErr string
+	}{
+		// Types and type unions.
+		{
+			name:   "ok - type union",
+			schema: `{"type": ["string", "null"]}`,
+			value:  NewString("a"),
+		},
+		{
+			name:   "ok - null in a type union",
+			schema: `{"type": ["string", "null"]}`,
+			value:  NewNull(),
+		},
+		{
+			name:    "nok - type union",
+			schema:  `{"type": ["string", "null"]}`,
+			value:   NewNumberFromInteger(1),
+			wantErr: "field x must be string or null, got integer at x",
+		},
+		{
+			name:   "ok - integer as number",
+			schema: `{"type": "number"}`,
+			value:  NewNumberFromInteger(4),
+		},
+		{
+			name:    "nok - number as integer",
+			schema:  `{"type": "integer"}`,
+			value:   NewNumberFromFloat(4.5),
+			wantErr: "field x must be integer, got number at x",
+		},
+
+		// anyOf and oneOf.
+		{
+			name:   "ok - anyOf",
+			schema: `{"anyOf": [{"type": "string"}, {"type": "array", "items": {"type": "string"}}]}`,
+			value:  NewArray([]Value{NewString("a"), NewString("b")}),
+		},
+		{
+			name:    "nok - anyOf reports the closest branch",
+			schema:  `{"anyOf": [{"type": "string"}, {"type": "object", "required": ["a"]}]}`,
+			value:   newMap(map[string]Value{"b": NewString("b")}),
+			wantErr: "field x must be string, got object at x",
+		},
+		{
+			name:    "nok - anyOf reports the branch with fewer errors",
+			schema:  `{"anyOf": [{"type": "object", "required": ["a", "b"]}, {"type": "object", "required": ["a"]}]}`,
+			value:   newMap(nil),
+			wantErr: "field a is required at x.a",
+		},
+		{
+			name:   "ok - oneOf",
+			schema: `{"oneOf": [{"type": "integer"}, {"type": "string"}]}`,
+			value:  NewString("a"),
+		},
+		{
+			name:    "nok - oneOf matches several branches",
+			schema:  `{"oneOf": [{"type": "integer"}, {"type": "number"}]}`,
+			value:   NewNumberFromInteger(4),
+			wantErr: "field x must match exactly one of the allowed schemas at x",
+		},
+		{
+			name: "ok - oneOf by const",
+			schema: `{"type": "object", "oneOf": [
+				{"properties": {"method": {"const": "Token"}, "size": {"type": "integer"}}},
+				{"properties": {"method": {"const": "Recursive"}, "separators": {"type": "array"}}}
+			]}`,
+			value: newMap(map[string]Value{"method": NewString("Recursive"), "separators": NewArray(nil)}),
+		},
+		{
+			name: "nok - oneOf by const",
+			schema: `{"type": "object", "oneOf": [
+				{"properties": {"method": {"const": "Token"}}},
+				{"properties": {"method": {"const": "Recursive"}}}
+			]}`,
+			value:   newMap(map[string]Value{"method": NewString("Markdown")}),
+			wantErr: "field method must be Token at x.method",
+		},
+
+		// Files are validated as their data URI.
+		{
+			name:   "ok - file as string",
+			schema: `{"type": "string"}`,
+			value:  file,
+		},
+		{
+			name:   "ok - file reference as string",
+			schema: `{"type": "string"}`,
+			value:  ref,
+		},
+		{
+			name:    "nok - file as object",
+			schema:  `{"type": "object"}`,
+			value:   file,
+			wantErr: "field x must be object, got string at x",
+		},
+
+		// Decimals are accepted as numbers and as strings.
+		{
+			name:   "ok - decimal as number",
+			schema: `{"type": "number", "maximum": 1}`,
+			value:  decimal("0.5"),
+		},
+		{
+			name:   "ok - decimal as integer",
+			schema: `{"type": "integer"}`,
+			value:  decimal("2"),
+		},
+		{
+			name:   "ok - decimal as string",
+			schema: `{"type": "string"}`,
+			value:  decimal("0.5"),
+		},
+		{
+			name:    "nok - decimal bounds",
+			schema:  `{"type": "number", "minimum": 1}`,
+			value:   decimal("0.5"),
+			wantErr: "field x must be at least 1 at x",
+		},
+		{
+			name:    "nok - decimal as integer",
+			schema:  `{"type": "integer"}`,
+			value:   decimal("0.5"),
+			wantErr: "field x must be integer, got number at x",
+		},
+
+		// The null fields are missing, as in structpb.
+		{
+			name:   "ok - null",
+			schema: `{"type": "null"}`,
+			value:  nil,
+		},
+		{
+			name:    "nok - null required field",
+			schema:  `{"type": "object", "required": ["a"], "properties": {"a": {"type": "string"}}}`,
+			value:   newMap(map[string]Value{"a": NewNull()}),
+			wantErr: "field a is required at x.a",
+		},
+		{
+			name:   "ok - null optional field",
+			schema: `{"type": "object", "properties": {"a": {"type": "string"}}, "additionalProperties": false}`,
+			value:  newMap(map[string]Value{"a": NewNull(), "b": nil}),
+		},
+		{
+			name:    "nok - null item",
+			schema:  `{"type": "array", "items": {"type": "string"}}`,
+			value:   NewArray([]Value{NewString("a"), nil}),
+			wantErr: `field x\[1\] must be string, got null at x\[1\]`,
+		},
+
+		// The errors of every field are reported.
+		{
+			name: "nok - several fields",
+			schema: `{"type": "object", "required": ["a"], "additionalProperties": false, "properties": {
+				"b": {"type": "string", "maxLength": 2},
+				"c": {"type": "array", "minItems": 1}
+			}}`,
+			value: newMap(map[string]Value{
+				"b": NewString("abc"),
+				"c": NewArray(nil),
+				"d": NewBoolean(true),
+			}),
+			wantErr: "field a is required at x.a; " +
+				"field b must have at most 2 characters at x.b; " +
+				"field c must have at least 1 items at x.c; " +
+				"field d is not allowed at x.d",
+		},
+		{
+			name:   "ok - unsupported pattern",
+			schema: `{"type": "string", "pattern": "^(?!a)"}`,
+			value:  NewString("a"),
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			s, err := ParseSchema([]byte(tc.schema))
+			c.Assert(err, quicktest.IsNil)
+
+			err = s.Validate(tc.value, "x")
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Check(err, quicktest.IsNil)
+		})
+	}
+
+	_, err = ParseSchema([]byte(`{"type":`))
+	c.Check(err, quicktest.ErrorMatches, "parsing JSON Schema: .*")
+}
@@ -0,0 +1,131 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSharedValue is returned when a shared value is modified in place. Its
+// writable copy must be modified instead.
+var ErrSharedValue = errors.New("shared values can't be modified in place")
+
+// ErrFrozenValue is returned when a frozen value is modified in place. As
+// for the shared values, its writable copy must be modified instead.
+var ErrFrozenValue = errors.New("frozen values can't be modified")
+
+// checkWritable returns the error of an in-place modification of a map or
+// an array, if it isn't allowed.
+func checkWritable(shared, frozen bool) error {
+	switch {
+	case frozen:
+		return ErrFrozenValue
+	case shared:
+		return ErrSharedValue
+	}
+	return nil
+}
+
+// Set sets the value at a path of the map, e.g. `items[2].name`, creating
+// the missing maps and arrays on the path: a key creates a map and an index
+// an array, which is padded with nulls up to the index. Negative indexes
+// count from the end of an existing array.
+//
+// The map must be writable, i.e. neither shared nor frozen. The shared maps and arrays on the path are
+// replaced by a writable copy, so the other holders of the values aren't
+// affected.
+func (m *Map) Set(path string, v Value) error {
+	if err := checkWritable(m.shared, m.frozen); err != nil {
+		return err
+	}
+	if path == "" {
+		return fmt.Errorf("empty path")
+	}
+	path, err := StandardizePath(path)
+	if err != nil {
+		return err
+	}
+	_, err = setPath(m, path, v)
+	return err
+}
+
+// Set sets the value at a path of the array, e.g. `[2].name`, as Map.Set
+// does.
+func (a *Array) Set(path string, v Value) error {
+	if err := checkWritable(a.shared, a.frozen); err != nil {
+		return err
+	}
+	if path == "" {
+		return fmt.Errorf("empty path")
+	}
+	path, err := StandardizePath(path)
+	if err != nil {
+		return err
+	}
+	_, err = setPath(a, path, v)
+	return err
+}
+
+// setPath sets the value at a standardized path of a value, and returns the
+// value that holds it, which is a copy of the original one if it was
+// shared.
+func setPath(cur Value, path string, v Value) (Value, error) {
+	if path == "" {
+		return v, nil
+	}
+
+	if strings.HasPrefix(path, `["`) {
+		key, remainingPath, err := trimFirstKeyFromPath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var m *Map
+		switch cur := cur.(type) {
+		case nil, *Null:
+			m = NewMap(nil)
+		case *Map:
+			m = cur.Writable()
+		default:
+			return nil, fmt.Errorf("can not set field %s of %s value", key, typeName(cur))
+		}
+
+		field, err := setPath(m.Fields[key], remainingPath, v)
+		if err != nil {
+			return nil, err
+		}
+		m.Fields[key] = field
+		return m, nil
+	}
+
+	index, remainingPath, err := trimFirstIndexFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var a *Array
+	switch cur := cur.(type) {
+	case nil, *Null:
+		a = NewArray(nil)
+	case *Array:
+		a = cur.Writable()
+	default:
+		return nil, fmt.Errorf("can not set item %d of %s value", index, typeName(cur))
+	}
+
+	if index < 0 {
+		if index += len(a.Values); index < 0 {
+			return nil, fmt.Errorf("index out of range: %d", index-len(a.Values))
+		}
+	}
+	for len(a.Values) <= index {
+		a.Values = append(a.Values, NewNull())
+	}
+
+	item, err := setPath(a.Values[index], remainingPath, v)
+	if err != nil {
+		return nil, err
+	}
+	a.Values[index] = item
+	return a, nil
+}
@@ -0,0 +1,240 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// A value can be shared by several holders, e.g. the batch items of a
+// pipeline trigger that receive the same variable, rather than copied for
+// each of them. Shared values are copy-on-write: they mustn't be modified in
+// place, and the maps and arrays provide a writable copy through Writable.
+//
+// The files don't cache their conversions once they're shared, as they can
+// be read concurrently.
+
+// Share marks a value, and the values it holds, as shared.
+func Share(v Value) Value {
+	switch v := v.(type) {
+	case *Map:
+		if v.shared {
+			return v
+		}
+		v.shared = true
+		for _, f := range v.Fields {
+			Share(f)
+		}
+	case *Array:
+		if v.shared {
+			return v
+		}
+		v.shared = true
+		for _, item := range v.Values {
+			Share(item)
+		}
+	case *File:
+		v.shared = true
+	case *Image:
+		v.shared = true
+	case *Video:
+		v.shared = true
+	case *Audio:
+		v.shared = true
+	case *Document:
+		v.shared = true
+	}
+	return v
+}
+
+// IsShared tells whether a value is shared.
+func IsShared(v Value) bool {
+	switch v := v.(type) {
+	case *Map:
+		return v.shared
+	case *Array:
+		return v.shared
+	case *File:
+		return v.shared
+	case *Image:
+		return v.shared
+	case *Video:
+		return v.shared
+	case *Audio:
+		return v.shared
+	case *Document:
+		return v.shared
+	}
+	return false
+}
+
+// Writable returns a map whose fields can be modified. A shared map is
+// copied, while the values it holds are still shared.
+func (m *Map) Writable() *Map {
+	if !m.shared {
+		return m
+	}
+	return &Map{Fields: maps.Clone(m.Fields)}
+}
+
+// Writable returns an array whose items can be modified. A shared array is
+// copied, while the values it holds are still shared.
+func (a *Array) Writable() *Array {
+	if !a.shared {
+		return a
+	}
+	return &Array{Values: slices.Clone(a.Values)}
+}
+
+// Interner deduplicates values: the identical values it interns are replaced
+// by a single, shared instance. The values are compared by content, so
+// identical files are shared even if they were read separately.
+type Interner struct {
+	values map[[sha256.Size]byte]Value
+}
+
+// NewInterner returns an empty interner.
+func NewInterner() *Interner {
+	return &Interner{values: map[[sha256.Size]byte]Value{}}
+}
+
+// Intern returns the shared instance of a value. If no identical value was
+// interned before, the value is shared and becomes that instance. The
+// values held by a map or an array that isn't shared yet are interned too,
+// so partially identical values share their common parts.
+func (in *Interner) Intern(v Value) Value {
+	v, _, _ = in.intern(v)
+	return v
+}
+
+// intern returns the shared instance of a value along with its digest. The
+// values of unknown types aren't interned.
+func (in *Interner) intern(v Value) (Value, [sha256.Size]byte, bool) {
+	var digest [sha256.Size]byte
+
+	h := sha256.New()
+	switch v := v.(type) {
+	case *Null:
+		writeHashString(h, "null")
+	case *Boolean:
+		writeHashString(h, "boolean")
+		if v.Raw {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	case *Number:
+		writeHashString(h, "number")
+		_ = binary.Write(h, binary.BigEndian, math.Float64bits(v.Raw))
+	case *String:
+		writeHashString(h, "string")
+		writeHashString(h, v.Raw)
+	case *DateTime:
+		writeHashString(h, "datetime")
+		writeHashString(h, v.Raw.Format(time.RFC3339Nano))
+		writeHashString(h, v.Raw.Location().String())
+	case *Decimal:
+		writeHashString(h, "decimal")
+		writeHashString(h, v.GetDecimal().String())
+	case *Duration:
+		writeHashString(h, "duration")
+		_ = binary.Write(h, binary.BigEndian, int64(v.Raw))
+	case *ByteArray:
+		writeHashString(h, "bytes")
+		writeHashBytes(h, v.Raw)
+	case *Binary:
+		writeHashString(h, "binary")
+		writeHashString(h, v.ContentType)
+		writeHashString(h, v.FileName)
+		writeHashBytes(h, v.Raw)
+	case *Proto:
+		writeHashString(h, "proto")
+		writeHashString(h, v.TypeURL())
+		writeHashBytes(h, v.Raw.GetValue())
+	case *FileRef:
+		writeHashString(h, "file-ref")
+		writeHashString(h, v.URI)
+		writeHashString(h, v.ContentType)
+		writeHashString(h, v.FileName)
+	case *File:
+		writeHashString(h, "file")
+		writeHashFile(h, v)
+	case *Image:
+		writeHashString(h, "image")
+		writeHashFile(h, &v.File)
+	case *Video:
+		writeHashString(h, "video")
+		writeHashFile(h, &v.File)
+	case *Audio:
+		writeHashString(h, "audio")
+		writeHashFile(h, &v.File)
+	case *Document:
+		writeHashString(h, "document")
+		writeHashFile(h, &v.File)
+	case *Map:
+		writeHashString(h, "map")
+		keys := make([]string, 0, len(v.Fields))
+		for k := range v.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			f, d, ok := in.intern(v.Fields[k])
+			if !ok {
+				return v, digest, false
+			}
+			if !v.shared {
+				v.Fields[k] = f
+			}
+			writeHashString(h, k)
+			h.Write(d[:])
+		}
+	case *Array:
+		writeHashString(h, "array")
+		_ = binary.Write(h, binary.BigEndian, uint64(len(v.Values)))
+		for i, item := range v.Values {
+			item, d, ok := in.intern(item)
+			if !ok {
+				return v, digest, false
+			}
+			if !v.shared {
+				v.Values[i] = item
+			}
+			h.Write(d[:])
+		}
+	default:
+		return v, digest, false
+	}
+
+	copy(digest[:], h.Sum(nil))
+	if existing, ok := in.values[digest]; ok {
+		return existing, digest, true
+	}
+	in.values[digest] = Share(v)
+	return v, digest, true
+}
+
+func writeHashFile(h hash.Hash, f *File) {
+	writeHashString(h, f.ContentType)
+	writeHashString(h, f.FileName)
+	writeHashString(h, f.SourceURL)
+	writeHashString(h, f.BlobKey())
+	writeHashBytes(h, f.Raw)
+}
+
+// writeHashString prefixes the string with its length, so the digests of
+// different sequences of strings can't collide.
+func writeHashString(h hash.Hash, s string) {
+	writeHashBytes(h, []byte(s))
+}
+
+func writeHashBytes(h hash.Hash, b []byte) {
+	_ = binary.Write(h, binary.BigEndian, uint64(len(b)))
+	h.Write(b)
+}
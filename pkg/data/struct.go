@@ -0,0 +1,490 @@
+package data
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// The Go structs are mapped to values through their `instill` tags, which
+// hold the kebab-case name of a field, as the component inputs and outputs
+// use:
+//
+//	type ResizeInput struct {
+//		Image  *Image  `instill:"image"`
+//		Width  int     `instill:"width"`
+//		Ratio  float64 `instill:"ratio,omitempty"`
+//		Debug  bool    `instill:"-"`
+//	}
+//
+// The fields without an `instill` tag use the name of their `json` tag, or
+// their Go name. The `omitempty` option leaves out the zero values when
+// marshaling. The fields whose type is a value (e.g. *Image or Value) hold
+// the value itself, so the files aren't converted, and the protobuf messages
+// are held as Proto values.
+
+var (
+	valueType    = reflect.TypeOf((*Value)(nil)).Elem()
+	messageType  = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	anyType      = reflect.TypeOf((*anypb.Any)(nil))
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// structField is a field of a Go struct mapped to a map field.
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// structFields returns the mapped fields of a struct type. The fields of the
+// embedded structs are promoted.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+
+		tag, ok := f.Tag.Lookup("instill")
+		if !ok {
+			tag = f.Tag.Get("json")
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for _, embedded := range structFields(ft) {
+					embedded.index = append([]int{i}, embedded.index...)
+					fields = append(fields, embedded)
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, structField{
+			name:      name,
+			index:     []int{i},
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+	return fields
+}
+
+// Marshal converts a Go value to a value. The structs and the maps with
+// string keys are converted to maps, the slices and arrays to arrays, and
+// nil pointers, slices, maps and interfaces to null. The time.Time and
+// time.Duration values are converted to date-times and durations, and
+// []byte to byte arrays.
+func Marshal(v any) (Value, error) {
+	return marshalValue(reflect.ValueOf(v), "")
+}
+
+func marshalValue(rv reflect.Value, path string) (Value, error) {
+	if !rv.IsValid() {
+		return NewNull(), nil
+	}
+
+	if rv.Type().Implements(valueType) {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() || rv.Kind() == reflect.Interface && rv.IsNil() {
+			return NewNull(), nil
+		}
+		return rv.Interface().(Value), nil
+	}
+	if rv.Type().Implements(messageType) {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() || rv.Kind() == reflect.Interface && rv.IsNil() {
+			return NewNull(), nil
+		}
+		p, err := NewProto(rv.Interface().(proto.Message))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pathOrRoot(path), err)
+		}
+		return p, nil
+	}
+
+	switch rv.Type() {
+	case timeType:
+		return NewDateTime(rv.Interface().(time.Time)), nil
+	case durationType:
+		return NewDuration(time.Duration(rv.Int())), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return NewNull(), nil
+		}
+		return marshalValue(rv.Elem(), path)
+
+	case reflect.Bool:
+		return NewBoolean(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewNumberFromFloat(float64(rv.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return NewNumberFromFloat(float64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return NewNumberFromFloat(rv.Float()), nil
+	case reflect.String:
+		return NewString(rv.String()), nil
+
+	case reflect.Slice:
+		if rv.IsNil() {
+			return NewNull(), nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return NewByteArray(rv.Bytes()), nil
+		}
+		fallthrough
+	case reflect.Array:
+		arr := NewArray(make([]Value, rv.Len()))
+		for i := range rv.Len() {
+			item, err := marshalValue(rv.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			arr.Values[i] = item
+		}
+		return arr, nil
+
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%s: unsupported map key type %s", pathOrRoot(path), rv.Type().Key())
+		}
+		if rv.IsNil() {
+			return NewNull(), nil
+		}
+		mp := NewMap(make(map[string]Value, rv.Len()))
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := iter.Key().String()
+			f, err := marshalValue(iter.Value(), joinPath(path, k))
+			if err != nil {
+				return nil, err
+			}
+			mp.Fields[k] = f
+		}
+		return mp, nil
+
+	case reflect.Struct:
+		mp := NewMap(nil)
+		for _, f := range structFields(rv.Type()) {
+			fv, ok := fieldByIndex(rv, f.index)
+			if !ok || f.omitEmpty && fv.IsZero() {
+				continue
+			}
+			val, err := marshalValue(fv, joinPath(path, f.name))
+			if err != nil {
+				return nil, err
+			}
+			mp.Fields[f.name] = val
+		}
+		return mp, nil
+	}
+
+	return nil, fmt.Errorf("%s: unsupported type %s", pathOrRoot(path), rv.Type())
+}
+
+// fieldByIndex returns a field of a struct, which is missing if it's held
+// by a nil embedded pointer.
+func fieldByIndex(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(idx)
+	}
+	return rv, true
+}
+
+// Unmarshal binds a value to the Go value pointed to by to, as Marshal maps
+// them. Null leaves the Go value zeroed. The values are converted as they
+// are to and from structpb: e.g. a file is bound to a string as its data
+// URI, a byte array to a base64 string and a string to a number is an
+// error.
+func Unmarshal(v Value, to any) error {
+	rv := reflect.ValueOf(to)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("unmarshal target must be a non-nil pointer, got %T", to)
+	}
+	return unmarshalValue(v, rv.Elem(), "")
+}
+
+func unmarshalValue(v Value, rv reflect.Value, path string) error {
+	if v == nil {
+		v = NewNull()
+	}
+	mismatch := func() error {
+		return fmt.Errorf("%s: can not unmarshal %s into %s", pathOrRoot(path), typeName(v), rv.Type())
+	}
+
+	// The fields that hold values are set directly.
+	if rv.Type().Implements(valueType) {
+		if _, isNull := v.(*Null); isNull && rv.Type() != valueType {
+			rv.SetZero()
+			return nil
+		}
+		if !reflect.TypeOf(v).AssignableTo(rv.Type()) {
+			return mismatch()
+		}
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	if _, isNull := v.(*Null); isNull {
+		rv.SetZero()
+		return nil
+	}
+
+	// The messages are decoded from the Proto values, which must hold a
+	// message of their type unless they're bound to an Any.
+	if rv.Kind() == reflect.Pointer && rv.Type().Implements(messageType) {
+		p, ok := v.(*Proto)
+		if !ok {
+			return mismatch()
+		}
+		if rv.Type() == anyType {
+			rv.Set(reflect.ValueOf(proto.Clone(p.Raw)))
+			return nil
+		}
+		m := reflect.New(rv.Type().Elem())
+		if err := p.UnmarshalTo(m.Interface().(proto.Message)); err != nil {
+			return fmt.Errorf("%s: %w", pathOrRoot(path), err)
+		}
+		rv.Set(m)
+		return nil
+	}
+
+	switch rv.Type() {
+	case timeType:
+		switch v := v.(type) {
+		case *DateTime:
+			rv.Set(reflect.ValueOf(v.Raw))
+			return nil
+		case *String:
+			t, err := ParseDateTime(v.Raw, time.UTC)
+			if err != nil {
+				return fmt.Errorf("%s: %w", pathOrRoot(path), err)
+			}
+			rv.Set(reflect.ValueOf(t.Raw))
+			return nil
+		}
+		return mismatch()
+	case durationType:
+		switch v := v.(type) {
+		case *Duration:
+			rv.SetInt(int64(v.Raw))
+			return nil
+		case *String:
+			d, err := ParseDuration(v.Raw)
+			if err != nil {
+				return fmt.Errorf("%s: %w", pathOrRoot(path), err)
+			}
+			rv.SetInt(int64(d.Raw))
+			return nil
+		}
+		return mismatch()
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return unmarshalValue(v, rv.Elem(), path)
+
+	case reflect.Interface:
+		if rv.NumMethod() > 0 {
+			return mismatch()
+		}
+		sv, err := v.ToStructValue()
+		if err != nil {
+			return fmt.Errorf("%s: %w", pathOrRoot(path), err)
+		}
+		rv.Set(reflect.ValueOf(sv.AsInterface()))
+		return nil
+
+	case reflect.Bool:
+		b, ok := v.(*Boolean)
+		if !ok {
+			return mismatch()
+		}
+		rv.SetBool(b.Raw)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		var f float64
+		switch v := v.(type) {
+		case *Number:
+			f = v.Raw
+		case *Decimal:
+			f = v.Float()
+		default:
+			return mismatch()
+		}
+		return setNumber(rv, f, path)
+
+	case reflect.String:
+		if s, ok := v.(*String); ok {
+			rv.SetString(s.Raw)
+			return nil
+		}
+		switch v.(type) {
+		case *Map, *Array, *Boolean, *Number:
+			return mismatch()
+		}
+		// The files and the rest of the values are strings in structpb.
+		sv, err := v.ToStructValue()
+		if err != nil {
+			return fmt.Errorf("%s: %w", pathOrRoot(path), err)
+		}
+		rv.SetString(sv.GetStringValue())
+		return nil
+
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			switch v := v.(type) {
+			case *ByteArray:
+				rv.SetBytes(v.Raw)
+				return nil
+			case *Binary:
+				rv.SetBytes(v.Raw)
+				return nil
+			case *String:
+				b, err := base64.StdEncoding.DecodeString(v.Raw)
+				if err != nil {
+					return fmt.Errorf("%s: %w", pathOrRoot(path), err)
+				}
+				rv.SetBytes(b)
+				return nil
+			}
+		}
+		arr, ok := v.(*Array)
+		if !ok {
+			return mismatch()
+		}
+		s := reflect.MakeSlice(rv.Type(), len(arr.Values), len(arr.Values))
+		for i, item := range arr.Values {
+			if err := unmarshalValue(item, s.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(s)
+		return nil
+
+	case reflect.Array:
+		arr, ok := v.(*Array)
+		if !ok {
+			return mismatch()
+		}
+		if len(arr.Values) > rv.Len() {
+			return fmt.Errorf("%s: %d items don't fit in %s", pathOrRoot(path), len(arr.Values), rv.Type())
+		}
+		rv.SetZero()
+		for i, item := range arr.Values {
+			if err := unmarshalValue(item, rv.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		m, ok := v.(*Map)
+		if !ok || rv.Type().Key().Kind() != reflect.String {
+			return mismatch()
+		}
+		mp := reflect.MakeMapWithSize(rv.Type(), len(m.Fields))
+		for k, f := range m.Fields {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := unmarshalValue(f, elem, joinPath(path, k)); err != nil {
+				return err
+			}
+			mp.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), elem)
+		}
+		rv.Set(mp)
+		return nil
+
+	case reflect.Struct:
+		m, ok := v.(*Map)
+		if !ok {
+			return mismatch()
+		}
+		for _, f := range structFields(rv.Type()) {
+			val, ok := m.Fields[f.name]
+			if !ok {
+				continue
+			}
+			fv := rv
+			for i, idx := range f.index {
+				if i > 0 && fv.Kind() == reflect.Pointer {
+					if fv.IsNil() {
+						if !fv.CanSet() {
+							return fmt.Errorf("%s: can not set embedded pointer to unexported struct %s", joinPath(path, f.name), fv.Type().Elem())
+						}
+						fv.Set(reflect.New(fv.Type().Elem()))
+					}
+					fv = fv.Elem()
+				}
+				fv = fv.Field(idx)
+			}
+			if err := unmarshalValue(val, fv, joinPath(path, f.name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%s: unsupported type %s", pathOrRoot(path), rv.Type())
+}
+
+// setNumber sets a numeric Go value, checking that the number fits.
+func setNumber(rv reflect.Value, f float64, path string) error {
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(f)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if f != math.Trunc(f) || rv.OverflowInt(int64(f)) || math.Abs(f) > maxExactInteger {
+			return fmt.Errorf("%s: %v doesn't fit in %s", pathOrRoot(path), f, rv.Type())
+		}
+		rv.SetInt(int64(f))
+		return nil
+	}
+	if f != math.Trunc(f) || f < 0 || rv.OverflowUint(uint64(f)) || f > maxExactInteger {
+		return fmt.Errorf("%s: %v doesn't fit in %s", pathOrRoot(path), f, rv.Type())
+	}
+	rv.SetUint(uint64(f))
+	return nil
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return path
+}
@@ -0,0 +1,281 @@
+package data
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type structBase struct {
+	ID string `instill:"id"`
+}
+
+type StructMeta struct {
+	Tags []string `json:"tags,omitempty"`
+}
+
+type structInput struct {
+	structBase
+	*StructMeta
+
+	Name     string            `instill:"name"`
+	Ratio    float64           `instill:"ratio,omitempty"`
+	Count    *int              `instill:"count"`
+	Debug    bool              `instill:"-"`
+	JSONName string            `json:"json-name"`
+	Plain    string            // The Go name is used.
+	Image    *Image            `instill:"image"`
+	Any      Value             `instill:"any"`
+	When     time.Time         `instill:"when"`
+	Timeout  time.Duration     `instill:"timeout"`
+	Raw      []byte            `instill:"raw"`
+	Labels   map[string]string `instill:"labels"`
+	Nested   *structBase       `instill:"nested"`
+
+	hidden string
+}
+
+// structHidden embeds a pointer to an unexported struct, which can't be
+// allocated when it's unmarshaled.
+type structHidden struct {
+	*structBase
+}
+
+func TestMarshal(t *testing.T) {
+	c := quicktest.New(t)
+
+	when := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	count := 3
+	in := structInput{
+		structBase: structBase{ID: "a"},
+		StructMeta: &StructMeta{Tags: []string{"x"}},
+		Name:       "name",
+		Count:      &count,
+		Debug:      true,
+		JSONName:   "json",
+		Plain:      "plain",
+		Any:        NewString("any"),
+		When:       when,
+		Timeout:    time.Second,
+		Raw:        []byte{1},
+		Labels:     map[string]string{"k": "v"},
+		hidden:     "hidden",
+	}
+
+	got, err := Marshal(in)
+	c.Assert(err, quicktest.IsNil)
+	c.Check(got, valueEquals, NewMap(map[string]Value{
+		"id":        NewString("a"),
+		"tags":      NewArray([]Value{NewString("x")}),
+		"name":      NewString("name"),
+		"count":     NewNumberFromInteger(3),
+		"json-name": NewString("json"),
+		"Plain":     NewString("plain"),
+		"image":     NewNull(),
+		"any":       NewString("any"),
+		"when":      NewDateTime(when),
+		"timeout":   NewDuration(time.Second),
+		"raw":       NewByteArray([]byte{1}),
+		"labels":    NewMap(map[string]Value{"k": NewString("v")}),
+		"nested":    NewNull(),
+	}))
+
+	c.Run("round trip", func(c *quicktest.C) {
+		var out structInput
+		c.Assert(Unmarshal(got, &out), quicktest.IsNil)
+
+		// The ignored and unexported fields aren't bound.
+		want := in
+		want.Debug = false
+		want.hidden = ""
+		c.Check(out, quicktest.CmpEquals(cmp.AllowUnexported(structInput{})), want)
+	})
+
+	c.Run("nil embedded pointer", func(c *quicktest.C) {
+		got, err := Marshal(structInput{Ratio: 0.5})
+		c.Assert(err, quicktest.IsNil)
+		m := got.(*Map)
+		c.Check(m.Fields["ratio"], valueEquals, NewNumberFromFloat(0.5))
+		c.Check(m.Fields["id"], valueEquals, NewString(""))
+
+		// The fields of the nil embedded struct are left out.
+		_, ok := m.Fields["tags"]
+		c.Check(ok, quicktest.IsFalse)
+
+		got, err = Marshal(structHidden{})
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, valueEquals, NewMap(nil))
+	})
+
+	c.Run("omitempty", func(c *quicktest.C) {
+		got, err := Marshal(structInput{StructMeta: &StructMeta{}})
+		c.Assert(err, quicktest.IsNil)
+		m := got.(*Map)
+		for _, k := range []string{"tags", "ratio"} {
+			_, ok := m.Fields[k]
+			c.Check(ok, quicktest.IsFalse, quicktest.Commentf(k))
+		}
+	})
+
+	msg, err := NewProto(structpb.NewStringValue("hello"))
+	c.Assert(err, quicktest.IsNil)
+
+	testcases := []struct {
+		name    string
+		in      any
+		want    Value
+		wantErr string
+	}{
+		{name: "nil", in: nil, want: NewNull()},
+		{name: "nil pointer", in: (*structInput)(nil), want: NewNull()},
+		{name: "nil value", in: (*Image)(nil), want: NewNull()},
+		{name: "nil slice", in: []string(nil), want: NewNull()},
+		{name: "nil map", in: map[string]int(nil), want: NewNull()},
+		{name: "unsigned", in: uint8(200), want: NewNumberFromInteger(200)},
+		{name: "array", in: [2]bool{true}, want: NewArray([]Value{NewBoolean(true), NewBoolean(false)})},
+		{name: "message", in: structpb.NewStringValue("hello"), want: msg},
+		{name: "value", in: NewDecimal(big.NewRat(1, 3)), want: NewDecimal(big.NewRat(1, 3))},
+		{name: "nok - map key", in: map[int]string{1: "a"}, wantErr: "value: unsupported map key type int"},
+		{name: "nok - type", in: make(chan int), wantErr: "value: unsupported type chan int"},
+		{
+			name:    "nok - nested type",
+			in:      map[string]any{"list": []any{1, func() {}}},
+			wantErr: `list\[1\]: unsupported type func\(\)`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			got, err := Marshal(tc.in)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, valueEquals, tc.want)
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	c := quicktest.New(t)
+
+	msg, err := NewProto(structpb.NewStringValue("hello"))
+	c.Assert(err, quicktest.IsNil)
+	file, err := NewFileFromBytes([]byte("hello"), "text/plain", "a.txt")
+	c.Assert(err, quicktest.IsNil)
+	count := 1
+
+	testcases := []struct {
+		name    string
+		in      Value
+		to      any
+		want    any
+		wantErr string
+	}{
+		{name: "nil", in: nil, to: &count, want: 0},
+		{name: "null", in: NewNull(), to: new(*int), want: (*int)(nil)},
+		{name: "null into a struct", in: NewNull(), to: &structBase{ID: "a"}, want: structBase{}},
+		{name: "null into a value", in: NewNull(), to: new(Value), want: Value(NewNull())},
+		{name: "null into a file", in: NewNull(), to: new(*Image), want: (*Image)(nil)},
+		{name: "pointer", in: NewNumberFromInteger(2), to: new(*int), want: func() *int { n := 2; return &n }()},
+		{name: "unsigned", in: NewNumberFromInteger(200), to: new(uint8), want: uint8(200)},
+		{name: "decimal", in: NewDecimal(big.NewRat(1, 4)), to: new(float64), want: 0.25},
+		{name: "date-time string", in: NewString("2024-05-06T07:08:09Z"), to: new(time.Time), want: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)},
+		{name: "duration string", in: NewString("1m30s"), to: new(time.Duration), want: 90 * time.Second},
+		{name: "base64 string", in: NewString("aGVsbG8="), to: new([]byte), want: []byte("hello")},
+		{name: "file as a string", in: file, to: new(string), want: "data:text/plain;filename=a.txt;base64,aGVsbG8="},
+		{name: "file as a value", in: file, to: new(*File), want: file},
+		{name: "array", in: NewArray([]Value{NewNumberFromInteger(1)}), to: new([2]int), want: [2]int{1, 0}},
+		{name: "interface", in: NewMap(map[string]Value{"a": NewArray([]Value{NewBoolean(true)})}), to: new(any), want: any(map[string]any{"a": []any{true}})},
+		{name: "message", in: msg, to: new(*structpb.Value), want: structpb.NewStringValue("hello")},
+		{name: "any message", in: msg, to: new(*anypb.Any), want: msg.Raw},
+		{
+			name: "embedded structs",
+			in:   NewMap(map[string]Value{"id": NewString("a"), "tags": NewArray([]Value{NewString("x")}), "unknown": NewNull()}),
+			to:   &structInput{},
+			want: structInput{structBase: structBase{ID: "a"}, StructMeta: &StructMeta{Tags: []string{"x"}}},
+		},
+		{
+			name: "ignored field",
+			in:   NewMap(map[string]Value{"Debug": NewBoolean(true), "hidden": NewString("a")}),
+			to:   &structInput{},
+			want: structInput{},
+		},
+		{
+			name:    "nok - not a pointer",
+			in:      NewNull(),
+			to:      structBase{},
+			wantErr: "unmarshal target must be a non-nil pointer, got data.structBase",
+		},
+		{
+			name:    "nok - nil pointer",
+			in:      NewNull(),
+			to:      (*structBase)(nil),
+			wantErr: `unmarshal target must be a non-nil pointer, got \*data.structBase`,
+		},
+		{name: "nok - string into a number", in: NewString("1"), to: new(int), wantErr: "value: can not unmarshal string into int"},
+		{name: "nok - number into a string", in: NewNumberFromInteger(1), to: new(string), wantErr: "value: can not unmarshal integer into string"},
+		{name: "nok - map into a slice", in: NewMap(nil), to: new([]string), wantErr: `value: can not unmarshal object into \[\]string`},
+		{name: "nok - array into a struct", in: NewArray(nil), to: new(structBase), wantErr: "value: can not unmarshal array into data.structBase"},
+		{name: "nok - file into an image", in: file, to: new(*Image), wantErr: `value: can not unmarshal string into \*data.Image`},
+		{name: "nok - fraction into an integer", in: NewNumberFromFloat(1.5), to: new(int), wantErr: "value: 1.5 doesn't fit in int"},
+		{name: "nok - overflow", in: NewNumberFromInteger(300), to: new(int8), wantErr: "value: 300 doesn't fit in int8"},
+		{name: "nok - negative unsigned", in: NewNumberFromInteger(-1), to: new(uint), wantErr: "value: -1 doesn't fit in uint"},
+		{name: "nok - too many items", in: NewArray([]Value{NewNull(), NewNull(), NewNull()}), to: new([2]int), wantErr: `value: 3 items don't fit in \[2\]int`},
+		{name: "nok - non-string map key", in: NewMap(nil), to: new(map[int]string), wantErr: `value: can not unmarshal object into map\[int\]string`},
+		{name: "nok - interface with methods", in: NewString("a"), to: new(error), wantErr: "value: can not unmarshal string into error"},
+		{name: "nok - message type", in: NewString("a"), to: new(*structpb.Value), wantErr: `value: can not unmarshal string into \*structpb.Value`},
+		{name: "nok - invalid date-time", in: NewString("yesterday"), to: new(time.Time), wantErr: "value: .*"},
+		{
+			name:    "nok - field",
+			in:      NewMap(map[string]Value{"count": NewString("x")}),
+			to:      &structInput{},
+			wantErr: "count: can not unmarshal string into int",
+		},
+		{
+			name:    "nok - nested field",
+			in:      NewMap(map[string]Value{"labels": NewMap(map[string]Value{"k": NewNumberFromInteger(1)})}),
+			to:      &structInput{},
+			wantErr: "labels.k: can not unmarshal integer into string",
+		},
+		{
+			name:    "nok - item",
+			in:      NewMap(map[string]Value{"tags": NewArray([]Value{NewString("a"), NewBoolean(true)})}),
+			to:      &structInput{},
+			wantErr: `tags\[1\]: can not unmarshal boolean into string`,
+		},
+		{
+			name:    "nok - unexported embedded pointer",
+			in:      NewMap(map[string]Value{"id": NewString("a")}),
+			to:      &structHidden{},
+			wantErr: "id: can not set embedded pointer to unexported struct data.structBase",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			err := Unmarshal(tc.in, tc.to)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			got := reflect.ValueOf(tc.to).Elem().Interface()
+			c.Check(got, quicktest.CmpEquals(append(valueOptions, cmp.AllowUnexported(structInput{}))...), tc.want)
+		})
+	}
+
+	c.Run("unexported embedded pointer", func(c *quicktest.C) {
+		// An allocated struct is bound, as encoding/json does.
+		to := &structHidden{structBase: &structBase{}}
+		c.Assert(Unmarshal(NewMap(map[string]Value{"id": NewString("a")}), to), quicktest.IsNil)
+		c.Check(to.ID, quicktest.Equals, "a")
+	})
+}
@@ -72,6 +72,54 @@ func trimFirstIndexFromPath(path string) (index int, remainingPath string, err e
 	return 0, "", fmt.Errorf("can not parse index from path: %s", path)
 }
 
+// pathWildcard selects every item of an array or every field of a map in a
+// path, e.g. `objects[*].label`.
+const pathWildcard = "*"
+
+// trimFirstSliceFromPath parses a wildcard (`[*]`) or a slice
+// (`[start:end]`) at the beginning of a path, returning the bounds it
+// selects in an array of the provided length. The bounds of a slice can be
+// omitted or negative (counted from the end), and are clamped to the array.
+// ok is false if the path doesn't start with a wildcard or a slice.
+func trimFirstSliceFromPath(path string, length int) (start, end int, remainingPath string, ok bool, err error) {
+	key, remainingPath, _ := strings.Cut(path, "]")
+	if !strings.HasPrefix(key, "[") {
+		return 0, 0, "", false, nil
+	}
+	key = key[1:]
+	if key == pathWildcard {
+		return 0, length, remainingPath, true, nil
+	}
+
+	from, to, isSlice := strings.Cut(key, ":")
+	if !isSlice {
+		return 0, 0, "", false, nil
+	}
+
+	bound := func(s string, defaultValue int) (int, error) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return defaultValue, nil
+		}
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("can not parse slice from path: %s", path)
+		}
+		if i < 0 {
+			i += length
+		}
+		return min(max(i, 0), length), nil
+	}
+	if start, err = bound(from, 0); err != nil {
+		return 0, 0, "", true, err
+	}
+	if end, err = bound(to, length); err != nil {
+		return 0, 0, "", true, err
+	}
+
+	return start, max(start, end), remainingPath, true, nil
+}
+
 func comparePath(path1, path2 string) bool {
 	var err error
 	path1, err = StandardizePath(path1)
@@ -0,0 +1,336 @@
+package data
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxExactInteger is the greatest integer a float64 holds exactly. The YAML
+// integers beyond it are decoded as decimals so they don't lose precision.
+const maxExactInteger = 1 << 53
+
+// maxYAMLNodes bounds the number of values decoded from a YAML document.
+// The aliases are expanded, so a small document could otherwise hold an
+// exponential number of values.
+const maxYAMLNodes = 1_000_000
+
+// yamlDecoder decodes YAML nodes into values.
+type yamlDecoder struct {
+	nodes int
+}
+
+// NewValueFromYAML decodes a YAML document. The scalars keep their YAML type:
+// besides the JSON types, the timestamps (explicitly tagged, e.g.
+// !!timestamp 2024-01-01) are decoded as date-times and the !!binary
+// scalars as binaries. The anchors, aliases and merge keys are resolved.
+func NewValueFromYAML(b []byte) (Value, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("decoding YAML: %w", err)
+	}
+	if doc.Kind == 0 {
+		// Empty document.
+		return NewNull(), nil
+	}
+	return new(yamlDecoder).decode(&doc)
+}
+
+// ToYAML encodes a value as a YAML document. The map fields are sorted by
+// key. The files are written as their data URI, as in structpb.
+func ToYAML(v Value) ([]byte, error) {
+	n, err := yamlNodeFromValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML implements yaml.Marshaler, so the maps can be held by the
+// structs encoded in YAML.
+func (m *Map) MarshalYAML() (any, error) {
+	return yamlNodeFromValue(m)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (m *Map) UnmarshalYAML(n *yaml.Node) error {
+	if err := checkWritable(m.shared, m.frozen); err != nil {
+		return err
+	}
+	v, err := new(yamlDecoder).decode(n)
+	if err != nil {
+		return err
+	}
+	decoded, ok := v.(*Map)
+	if !ok {
+		return fmt.Errorf("YAML line %d: expected a mapping", n.Line)
+	}
+	m.Fields = decoded.Fields
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (a *Array) MarshalYAML() (any, error) {
+	return yamlNodeFromValue(a)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (a *Array) UnmarshalYAML(n *yaml.Node) error {
+	if err := checkWritable(a.shared, a.frozen); err != nil {
+		return err
+	}
+	v, err := new(yamlDecoder).decode(n)
+	if err != nil {
+		return err
+	}
+	decoded, ok := v.(*Array)
+	if !ok {
+		return fmt.Errorf("YAML line %d: expected a sequence", n.Line)
+	}
+	a.Values = decoded.Values
+	return nil
+}
+
+func (d *yamlDecoder) decode(n *yaml.Node) (Value, error) {
+	if d.nodes++; d.nodes > maxYAMLNodes {
+		return nil, fmt.Errorf("YAML document too large: more than %d values", maxYAMLNodes)
+	}
+
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return NewNull(), nil
+		}
+		return d.decode(n.Content[0])
+
+	case yaml.AliasNode:
+		return d.decode(n.Alias)
+
+	case yaml.SequenceNode:
+		arr := NewArray(make([]Value, len(n.Content)))
+		for i, item := range n.Content {
+			v, err := d.decode(item)
+			if err != nil {
+				return nil, err
+			}
+			arr.Values[i] = v
+		}
+		return arr, nil
+
+	case yaml.MappingNode:
+		mp := NewMap(nil)
+		var merged []*Map
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+
+			val, err := d.decode(v)
+			if err != nil {
+				return nil, err
+			}
+
+			// The fields of a merge key (<<: *anchor) are added unless
+			// the mapping sets them.
+			if k.ShortTag() == "!!merge" {
+				switch val := val.(type) {
+				case *Map:
+					merged = append(merged, val)
+				case *Array:
+					for _, item := range val.Values {
+						if m, ok := item.(*Map); ok {
+							merged = append(merged, m)
+						}
+					}
+				default:
+					return nil, fmt.Errorf("YAML line %d: the merge key must reference a mapping", k.Line)
+				}
+				continue
+			}
+
+			mp.Fields[yamlKey(k)] = val
+		}
+		for _, m := range merged {
+			for k, v := range m.Fields {
+				if _, ok := mp.Fields[k]; !ok {
+					mp.Fields[k] = v
+				}
+			}
+		}
+		return mp, nil
+
+	case yaml.ScalarNode:
+		return valueFromYAMLScalar(n)
+	}
+
+	return nil, fmt.Errorf("YAML line %d: unsupported node", n.Line)
+}
+
+// yamlKey returns the key of a mapping field. The keys that aren't strings
+// (e.g. 1 or true) are written as they appear in the document.
+func yamlKey(n *yaml.Node) string {
+	if n.Kind == yaml.AliasNode {
+		return yamlKey(n.Alias)
+	}
+	return n.Value
+}
+
+func valueFromYAMLScalar(n *yaml.Node) (Value, error) {
+	switch n.ShortTag() {
+	case "!!null":
+		return NewNull(), nil
+
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return nil, err
+		}
+		return NewBoolean(b), nil
+
+	case "!!int":
+		// The base is inferred from the prefix (0x, 0o, 0b), as YAML does.
+		var i big.Int
+		if _, ok := i.SetString(n.Value, 0); !ok {
+			return nil, fmt.Errorf("YAML line %d: invalid integer %q", n.Line, n.Value)
+		}
+		if i.CmpAbs(big.NewInt(maxExactInteger)) > 0 {
+			return NewDecimal(new(big.Rat).SetInt(&i)), nil
+		}
+		return NewNumberFromFloat(float64(i.Int64())), nil
+
+	case "!!float":
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return nil, err
+		}
+		return NewNumberFromFloat(f), nil
+
+	case "!!timestamp":
+		// The untagged timestamps are kept as strings, as when YAML is
+		// decoded into Go values, so the dates in the texts of a recipe
+		// aren't converted.
+		if n.Style&yaml.TaggedStyle == 0 {
+			return NewString(n.Value), nil
+		}
+		var t time.Time
+		if err := n.Decode(&t); err != nil {
+			return nil, err
+		}
+		return NewDateTime(t), nil
+
+	case "!!binary":
+		b, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(n.Value), ""))
+		if err != nil {
+			return nil, fmt.Errorf("YAML line %d: invalid binary: %w", n.Line, err)
+		}
+		return NewBinary(b, "", ""), nil
+	}
+
+	return NewString(n.Value), nil
+}
+
+func yamlNodeFromValue(v Value) (*yaml.Node, error) {
+	scalar := func(tag, value string) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+	}
+
+	switch v := v.(type) {
+	case nil, *Null:
+		return scalar("!!null", "null"), nil
+	case *Boolean:
+		return scalar("!!bool", strconv.FormatBool(v.Raw)), nil
+	case *Number:
+		switch {
+		case math.IsNaN(v.Raw):
+			return scalar("!!float", ".nan"), nil
+		case math.IsInf(v.Raw, 1):
+			return scalar("!!float", ".inf"), nil
+		case math.IsInf(v.Raw, -1):
+			return scalar("!!float", "-.inf"), nil
+		case v.Raw == math.Trunc(v.Raw) && math.Abs(v.Raw) <= maxExactInteger:
+			return scalar("!!int", strconv.FormatInt(int64(v.Raw), 10)), nil
+		}
+		return scalar("!!float", strconv.FormatFloat(v.Raw, 'g', -1, 64)), nil
+	case *Decimal:
+		if v.IsInteger() {
+			return scalar("!!int", v.String()), nil
+		}
+		return scalar("!!float", v.String()), nil
+	case *String:
+		n := scalar("!!str", v.Raw)
+		if strings.Contains(v.Raw, "\n") {
+			n.Style = yaml.LiteralStyle
+		}
+		return n, nil
+	case *DateTime:
+		n := scalar("!!timestamp", v.Raw.Format(time.RFC3339Nano))
+		n.Style = yaml.TaggedStyle
+		return n, nil
+	case *Duration:
+		return scalar("!!str", v.String()), nil
+	case *Binary:
+		return scalar("!!binary", v.Base64()), nil
+	case *Proto:
+		// The messages are written as their JSON mapping.
+		sv, err := v.ToStructValue()
+		if err != nil {
+			return nil, err
+		}
+		m, err := NewValueFromStruct(sv)
+		if err != nil {
+			return nil, err
+		}
+		return yamlNodeFromValue(m)
+
+	case *Map:
+		keys := make([]string, 0, len(v.Fields))
+		for k := range v.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		n := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, k := range keys {
+			val, err := yamlNodeFromValue(v.Fields[k])
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, scalar("!!str", k), val)
+		}
+		return n, nil
+
+	case *Array:
+		n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range v.Values {
+			val, err := yamlNodeFromValue(item)
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, val)
+		}
+		return n, nil
+	}
+
+	// The files and the rest of the values are written as in structpb.
+	sv, err := v.ToStructValue()
+	if err != nil {
+		return nil, err
+	}
+	return scalar("!!str", sv.GetStringValue()), nil
+}
@@ -0,0 +1,237 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewValueFromYAML(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		name    string
+		in      string
+		want    Value
+		wantErr string
+	}{
+		{
+			name: "ok - scalars",
+			in: `
+string: hello
+quoted: "42"
+int: 42
+hex: 0x1f
+float: 4.2
+bool: true
+null: ~
+`,
+			want: NewMap(map[string]Value{
+				"string": NewString("hello"),
+				"quoted": NewString("42"),
+				"int":    NewNumberFromInteger(42),
+				"hex":    NewNumberFromInteger(31),
+				"float":  NewNumberFromFloat(4.2),
+				"bool":   NewBoolean(true),
+				"null":   NewNull(),
+			}),
+		},
+		{
+			// The integers a float64 can't hold exactly keep their value.
+			name: "ok - large integer",
+			in:   "9007199254740993",
+			want: NewDecimal(new(big.Rat).SetInt64(9007199254740993)),
+		},
+		{
+			name: "ok - timestamps",
+			in: `
+untagged: 2024-01-02
+tagged: !!timestamp 2024-01-02T03:04:05Z
+`,
+			want: NewMap(map[string]Value{
+				"untagged": NewString("2024-01-02"),
+				"tagged":   NewDateTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			}),
+		},
+		{
+			name: "ok - binary",
+			in: `!!binary |
+  aGVs
+  bG8=
+`,
+			want: NewBinary([]byte("hello"), "", ""),
+		},
+		{
+			name: "ok - keys",
+			in: `
+1: a
+true: b
+`,
+			want: NewMap(map[string]Value{"1": NewString("a"), "true": NewString("b")}),
+		},
+		{
+			name: "ok - anchors",
+			in: `
+base: &base
+  model: small
+  labels: &labels [cat, dog]
+copy: *base
+labels: *labels
+`,
+			want: NewMap(map[string]Value{
+				"base": NewMap(map[string]Value{
+					"model":  NewString("small"),
+					"labels": NewArray([]Value{NewString("cat"), NewString("dog")}),
+				}),
+				"copy": NewMap(map[string]Value{
+					"model":  NewString("small"),
+					"labels": NewArray([]Value{NewString("cat"), NewString("dog")}),
+				}),
+				"labels": NewArray([]Value{NewString("cat"), NewString("dog")}),
+			}),
+		},
+		{
+			// The fields of the mapping override the merged ones, and the
+			// first merged mapping overrides the next ones.
+			name: "ok - merge keys",
+			in: `
+defaults: &defaults {model: small, top-k: 1}
+fallback: &fallback {model: large, temperature: 0.5}
+one:
+  <<: *defaults
+  top-k: 2
+many:
+  <<: [*defaults, *fallback]
+`,
+			want: NewMap(map[string]Value{
+				"defaults": NewMap(map[string]Value{"model": NewString("small"), "top-k": NewNumberFromInteger(1)}),
+				"fallback": NewMap(map[string]Value{"model": NewString("large"), "temperature": NewNumberFromFloat(0.5)}),
+				"one":      NewMap(map[string]Value{"model": NewString("small"), "top-k": NewNumberFromInteger(2)}),
+				"many": NewMap(map[string]Value{
+					"model":       NewString("small"),
+					"top-k":       NewNumberFromInteger(1),
+					"temperature": NewNumberFromFloat(0.5),
+				}),
+			}),
+		},
+		{
+			name: "ok - empty",
+			in:   "",
+			want: NewNull(),
+		},
+		{
+			name:    "nok - merge key to a scalar",
+			in:      "a: &a hello\nb:\n  <<: *a\n",
+			wantErr: "YAML line 3: the merge key must reference a mapping",
+		},
+		{
+			name:    "nok - invalid binary",
+			in:      "!!binary not base64",
+			wantErr: "YAML line 1: invalid binary: .*",
+		},
+		{
+			name:    "nok - syntax",
+			in:      "a: [b",
+			wantErr: "decoding YAML: .*",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			got, err := NewValueFromYAML([]byte(tc.in))
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, valueEquals, tc.want)
+		})
+	}
+
+	c.Run("nok - alias bomb", func(c *quicktest.C) {
+		// Each level references the previous one 10 times, so the
+		// document expands to 10^7 values.
+		var b strings.Builder
+		b.WriteString("l0: &l0 [x, x, x, x, x, x, x, x, x, x]\n")
+		for i := 1; i < 7; i++ {
+			ref := fmt.Sprintf("*l%d", i-1)
+			fmt.Fprintf(&b, "l%d: &l%d [%s]\n", i, i, strings.Repeat(ref+", ", 9)+ref)
+		}
+
+		_, err := NewValueFromYAML([]byte(b.String()))
+		c.Check(err, quicktest.ErrorMatches, fmt.Sprintf("YAML document too large: more than %d values", maxYAMLNodes))
+	})
+}
+
+func TestToYAML(t *testing.T) {
+	c := quicktest.New(t)
+
+	v := NewMap(map[string]Value{
+		"text":     NewString("line 1\nline 2"),
+		"number":   NewNumberFromInteger(42),
+		"float":    NewNumberFromFloat(4.2),
+		"decimal":  NewDecimal(new(big.Rat).SetInt64(9007199254740993)),
+		"when":     NewDateTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		"binary":   NewBinary([]byte("hello"), "", ""),
+		"nothing":  NewNull(),
+		"list":     NewArray([]Value{NewBoolean(false), NewString("42")}),
+		"empty":    NewMap(nil),
+		"duration": NewDuration(90 * time.Second),
+	})
+
+	b, err := ToYAML(v)
+	c.Assert(err, quicktest.IsNil)
+	c.Check(string(b), quicktest.Equals, `binary: !!binary aGVsbG8=
+decimal: 9007199254740993
+duration: 1m30s
+empty: {}
+float: 4.2
+list:
+  - false
+  - "42"
+nothing: null
+number: 42
+text: |-
+  line 1
+  line 2
+when: !!timestamp 2024-01-02T03:04:05Z
+`)
+
+	// The values keep their type, except the durations, which YAML
+	// doesn't have.
+	got, err := NewValueFromYAML(b)
+	c.Assert(err, quicktest.IsNil)
+	v.Fields["duration"] = NewString("1m30s")
+	c.Check(got, valueEquals, v)
+}
+
+func TestMapUnmarshalYAML(t *testing.T) {
+	c := quicktest.New(t)
+
+	var s struct {
+		Setup *Map   `yaml:"setup"`
+		Tags  *Array `yaml:"tags"`
+	}
+	err := yaml.Unmarshal([]byte(`
+base: &base {model: small}
+setup:
+  <<: *base
+  top-k: 1
+tags: [a, 1]
+`), &s)
+	c.Assert(err, quicktest.IsNil)
+	c.Check(s.Setup, valueEquals, NewMap(map[string]Value{"model": NewString("small"), "top-k": NewNumberFromInteger(1)}))
+	c.Check(s.Tags, valueEquals, NewArray([]Value{NewString("a"), NewNumberFromInteger(1)}))
+
+	out, err := yaml.Marshal(s)
+	c.Assert(err, quicktest.IsNil)
+	c.Check(string(out), quicktest.Equals, "setup:\n    model: small\n    top-k: 1\ntags:\n    - a\n    - 1\n")
+
+	c.Check(yaml.Unmarshal([]byte("setup: [a]"), &s), quicktest.ErrorMatches, "YAML line 1: expected a mapping")
+	c.Check(yaml.Unmarshal([]byte("tags: {a: b}"), &s), quicktest.ErrorMatches, "YAML line 1: expected a sequence")
+}
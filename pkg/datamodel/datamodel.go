@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,6 +25,17 @@ import (
 
 const Iterator = "iterator"
 
+// Approval is the type of the components that pause a pipeline run until a
+// reviewer approves or rejects it.
+const Approval = "approval"
+
+// Actions applied by an approval component when no reviewer has taken a
+// decision before the timeout.
+const (
+	ApprovalActionApprove = "approve"
+	ApprovalActionReject  = "reject"
+)
+
 // BaseDynamicHardDelete contains common columns for all tables with static UUID as primary key
 type BaseDynamicHardDelete struct {
 	UID        uuid.UUID `gorm:"type:uuid;primary_key;<-:create"` // allow read and create
@@ -146,6 +160,19 @@ type PipelineRelease struct {
 
 type ComponentMap map[string]*Component
 
+// WithErrorBranches returns the components along with the components of their
+// error branches. The latter live in the same workflow memory as their owner.
+func (m ComponentMap) WithErrorBranches() ComponentMap {
+	all := make(ComponentMap, len(m))
+	for id, comp := range m {
+		all[id] = comp
+		for branchID, branchComp := range comp.OnError {
+			all[branchID] = branchComp
+		}
+	}
+	return all
+}
+
 // Recipe is the data model of the pipeline recipe
 type Recipe struct {
 	Version   string               `json:"version,omitempty" yaml:"version,omitempty"`
@@ -154,8 +181,118 @@ type Recipe struct {
 	Variable  map[string]*Variable `json:"variable,omitempty" yaml:"variable,omitempty"`
 	Secret    map[string]string    `json:"secret,omitempty" yaml:"secret,omitempty"`
 	Output    map[string]*Output   `json:"output,omitempty" yaml:"output,omitempty"`
+
+	// MaxDuration is the wall-clock budget of a pipeline run, expressed as a
+	// duration string (e.g. "90s", "5m" or "PT5M"). Once it's exhausted, no
+	// new components are scheduled and the partial outputs are returned.
+	MaxDuration string `json:"maxDuration,omitempty" yaml:"max-duration,omitempty"`
+
+	// MemoryTTL overrides the time the persisted memory of a run is kept
+	// since it was last accessed (e.g. "6h"), for pipelines whose runs wait
+	// longer than the deployment default.
+	MemoryTTL string `json:"memoryTtl,omitempty" yaml:"memory-ttl,omitempty"`
+
+	// ComponentTimeout bounds the execution of each component, as a duration
+	// string (e.g. "2m" or "PT2M"). Defaults to the workflow timeout of the
+	// deployment.
+	ComponentTimeout string `json:"componentTimeout,omitempty" yaml:"component-timeout,omitempty"`
+
+	// Retry configures how the failed component executions are retried.
+	Retry *RetryPolicy `json:"retry,omitempty" yaml:"retry,omitempty"`
+
+	// ErrorPolicy tells whether a run keeps scheduling components after one
+	// of them fails. It continues by default.
+	ErrorPolicy string `json:"errorPolicy,omitempty" yaml:"error-policy,omitempty"`
+
+	// Coercion tells whether the component inputs are converted to the
+	// types of the task input schema, e.g. "42" to 42, before they're
+	// validated. They're converted by default.
+	Coercion string `json:"coercion,omitempty" yaml:"coercion,omitempty"`
+
+	// Capture controls which runs store their full inputs and outputs. Every
+	// run stores them by default.
+	Capture *CapturePolicy `json:"capture,omitempty" yaml:"capture,omitempty"`
+
+	// Canary holds the synthetic checks of the pipeline, indexed by ID.
+	Canary map[string]*Canary `json:"canary,omitempty" yaml:"canary,omitempty"`
+}
+
+// CapturePolicy controls which runs of a pipeline store the inputs and the
+// outputs of the pipeline and of its components, so the debuggability of
+// the runs can be balanced against the storage cost and the sensitivity of
+// the data.
+type CapturePolicy struct {
+	// Mode is one of the capture modes. Defaults to CaptureAll.
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+	// SampleRate is the percentage of runs that are captured in the sampled
+	// mode.
+	SampleRate float64 `json:"sampleRate,omitempty" yaml:"sample-rate,omitempty"`
+}
+
+// Capture modes of a recipe.
+const (
+	// CaptureAll stores the payloads of every run.
+	CaptureAll = "all"
+	// CaptureSampled stores the payloads of a percentage of the runs.
+	CaptureSampled = "sampled"
+	// CaptureFailures stores the payloads of the failed runs only. They're
+	// stored once the run completes, rather than as they're produced.
+	CaptureFailures = "failures"
+	// CaptureNever doesn't store any payload. Such runs can't be retried
+	// with their original inputs.
+	CaptureNever = "never"
+)
+
+// ModeFor returns the capture mode of a run. The sampled mode is resolved
+// into CaptureAll or CaptureNever from the trigger ID of the run, so every
+// activity of the run takes the same decision.
+func (p *CapturePolicy) ModeFor(pipelineTriggerID string) string {
+	if p == nil || p.Mode == "" {
+		return CaptureAll
+	}
+	if p.Mode != CaptureSampled {
+		return p.Mode
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(pipelineTriggerID))
+	if float64(h.Sum32()%10000) < p.SampleRate*100 {
+		return CaptureAll
+	}
+	return CaptureNever
 }
 
+// RetryPolicy configures the retries of the component executions. The unset
+// fields keep the defaults of the deployment.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a component is executed, including
+	// the first attempt. A single attempt disables the retries.
+	MaxAttempts int32 `json:"maxAttempts,omitempty" yaml:"max-attempts,omitempty"`
+	// InitialInterval is the delay before the first retry, as a duration
+	// string (e.g. "1s" or "PT1S"). The delay doubles with each retry.
+	InitialInterval string `json:"initialInterval,omitempty" yaml:"initial-interval,omitempty"`
+}
+
+// Coercion modes of a recipe.
+const (
+	// CoercionLenient converts the component inputs whose type doesn't
+	// match the task input schema when the conversion is unambiguous.
+	CoercionLenient = "lenient"
+	// CoercionStrict rejects the component inputs whose type doesn't match
+	// the task input schema.
+	CoercionStrict = "strict"
+)
+
+// Error policies of a recipe.
+const (
+	// ErrorPolicyContinue executes the components that don't depend on the
+	// failed ones.
+	ErrorPolicyContinue = "continue"
+	// ErrorPolicyFailFast stops scheduling components once one fails. The
+	// components that are already running aren't cancelled.
+	ErrorPolicyFailFast = "fail-fast"
+)
+
 func convertRecipeYAMLToRecipe(recipeYAML string) (*Recipe, error) {
 
 	recipe := &Recipe{}
@@ -296,12 +433,86 @@ type Event struct {
 	Type  string         `json:"type,omitempty" yaml:"type,omitempty"`
 	Event string         `json:"event,omitempty" yaml:"event,omitempty"`
 	Setup map[string]any `json:"setup,omitempty" yaml:"setup,omitempty"`
+
+	// Deduplication drops the events that were already received, for the
+	// senders that deliver them at least once.
+	Deduplication *Deduplication `json:"deduplication,omitempty" yaml:"deduplication,omitempty"`
+}
+
+// Deduplication identifies the events that trigger a pipeline, so the
+// duplicates received within a time window are dropped.
+type Deduplication struct {
+	// Field is a JSONPath expression that resolves the event ID in the
+	// payload (e.g. "$.id").
+	Field string `json:"field" yaml:"field"`
+
+	// Window is a duration string (e.g. "1h") that bounds how long an event
+	// ID is remembered.
+	Window string `json:"window" yaml:"window"`
+}
+
+// ParseWindow returns the deduplication window.
+func (d *Deduplication) ParseWindow() (time.Duration, error) {
+	w, err := time.ParseDuration(d.Window)
+	if err != nil || w <= 0 {
+		return 0, fmt.Errorf("deduplication window must be a positive duration, e.g. 10m or 24h")
+	}
+	return w, nil
 }
 
 type Schedule struct {
 	Cron string `json:"cron,omitempty" yaml:"cron,omitempty"`
+
+	// Timezone is the IANA name of the timezone in which the cron expression
+	// is evaluated (e.g. "Europe/Paris"). UTC is used by default.
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+
+	// Overlap is the policy applied when the schedule fires while a
+	// previous scheduled run of the pipeline is still executing. The new run
+	// is skipped by default.
+	Overlap string `json:"overlap,omitempty" yaml:"overlap,omitempty"`
+
+	// CatchupWindow is a duration string (e.g. "1h") that bounds how late a
+	// run missed during a scheduler downtime can still be started. Missed
+	// runs go through the overlap policy like the rest.
+	CatchupWindow string `json:"catchupWindow,omitempty" yaml:"catchup-window,omitempty"`
+}
+
+// Canary is a synthetic check of a pipeline: the pipeline is triggered on a
+// schedule with fixed inputs and its output is checked against a set of
+// assertions, so a broken upstream API is caught before the users are
+// affected.
+type Canary struct {
+	Cron string `json:"cron" yaml:"cron"`
+
+	// Timezone is the IANA name of the timezone in which the cron expression
+	// is evaluated. UTC is used by default.
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+
+	// Input holds the values of the pipeline variables.
+	Input map[string]any `json:"input,omitempty" yaml:"input,omitempty"`
+
+	// Assert holds conditions on the pipeline output, with the syntax of the
+	// component conditions (e.g. `${output.summary} != ""`). The check fails
+	// if the run fails or if any of them is false.
+	Assert []string `json:"assert,omitempty" yaml:"assert,omitempty"`
+
+	// AlertAfter is the number of consecutive failures that raise an alert.
+	// Defaults to 1.
+	AlertAfter int `json:"alertAfter,omitempty" yaml:"alert-after,omitempty"`
 }
 
+// Overlap policies of a schedule.
+const (
+	// ScheduleOverlapSkip doesn't start the new run.
+	ScheduleOverlapSkip = "skip"
+	// ScheduleOverlapQueue starts the new run once the previous one is done.
+	ScheduleOverlapQueue = "queue"
+	// ScheduleOverlapCancelPrevious cancels the previous run and starts the
+	// new one.
+	ScheduleOverlapCancelPrevious = "cancel-previous"
+)
+
 type Component struct {
 	// Common fields
 	Type      string         `json:"type,omitempty" yaml:"type,omitempty"`
@@ -312,6 +523,10 @@ type Component struct {
 
 	// The YAML header comment will be parsed into the `Description` field.
 	Description string `json:"description,omitempty"  yaml:"-"`
+	// Note is a free-form annotation of the component (e.g. the reason
+	// behind its setup), in Markdown. It's rendered in the documentation of
+	// the pipeline.
+	Note string `json:"note,omitempty" yaml:"note,omitempty"`
 
 	// Fields for regular components
 	Setup      any         `json:"setup,omitempty" yaml:"setup,omitempty"`
@@ -323,6 +538,69 @@ type Component struct {
 	Component         ComponentMap          `json:"component,omitempty" yaml:"component,omitempty"`
 	OutputElements    map[string]string     `json:"outputElements,omitempty" yaml:"output-elements,omitempty"`
 	DataSpecification *pb.DataSpecification `json:"dataSpecification,omitempty" yaml:"-"`
+
+	// Fields for approval components. The rendered input is presented to the
+	// reviewers. When the timeout (a duration string, e.g. "24h") elapses
+	// without a decision, the default action is applied, which is a
+	// rejection unless specified otherwise.
+	Timeout       string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	DefaultAction string `json:"defaultAction,omitempty" yaml:"default-action,omitempty"`
+
+	// OnError is the error branch of a regular component. When the component
+	// fails, these components are executed instead of failing the pipeline
+	// run. They can read the failure through `${<component-id>.error}`.
+	OnError ComponentMap `json:"onError,omitempty" yaml:"on-error,omitempty"`
+
+	// Failover is an ordered group of equivalent providers for a regular
+	// component. When the provider of the component is unavailable (e.g. an
+	// outage or an exhausted quota), the execution is retried on the next
+	// provider in the group.
+	Failover []*FailoverTarget `json:"failover,omitempty" yaml:"failover,omitempty"`
+
+	// Cache enables the semantic cache of a regular component, which serves
+	// the output of a previous request when the prompt is similar enough.
+	Cache *ComponentCache `json:"cache,omitempty" yaml:"cache,omitempty"`
+
+	// Batching configures how the requests of a component with many inputs
+	// (e.g. an embedding task over a large array) are split by the worker.
+	Batching *ComponentBatching `json:"batching,omitempty" yaml:"batching,omitempty"`
+}
+
+// ComponentBatching configures the sub-batches in which the inputs of a
+// request are executed. Failed sub-batches are retried on their own.
+type ComponentBatching struct {
+	// Size is the maximum number of inputs in a sub-batch. Defaults to 32.
+	Size int `json:"size,omitempty" yaml:"size,omitempty"`
+	// Concurrency is the maximum number of sub-batches executed at once.
+	// Defaults to 4.
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	// MaxRetries is the number of times a failed sub-batch is retried.
+	// Defaults to 2.
+	MaxRetries int `json:"maxRetries,omitempty" yaml:"max-retries,omitempty"`
+}
+
+// ComponentCache configures the semantic cache of a component. Only the
+// requests with the same setup and input, other than the prompt, share their
+// cached outputs.
+type ComponentCache struct {
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Threshold is the minimum cosine similarity, between 0 and 1, between
+	// two prompts for a cached output to be served. Defaults to 0.95.
+	Threshold float64 `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+	// TTL is the time a cached output is served, as a duration string (e.g.
+	// "1h"). Defaults to 24h.
+	TTL string `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	// Prompt is the input field that holds the prompt. Defaults to "prompt".
+	Prompt string `json:"prompt,omitempty" yaml:"prompt,omitempty"`
+}
+
+// FailoverTarget is a provider in the failover group of a component. The type
+// and task default to the ones of the component, and the setup usually
+// references a different connection.
+type FailoverTarget struct {
+	Type  string `json:"type,omitempty" yaml:"type,omitempty"`
+	Task  string `json:"task,omitempty" yaml:"task,omitempty"`
+	Setup any    `json:"setup,omitempty" yaml:"setup,omitempty"`
 }
 
 type Definition struct {
@@ -624,4 +902,236 @@ type Connection struct {
 	Scopes             pq.StringArray      `gorm:"type:text[]"`
 	OAuthAccessDetails datatypes.JSON      `gorm:"type:jsonb"`
 	Integration        ComponentDefinition `gorm:"foreignKey:IntegrationUID;references:UID"`
+	// MaxConcurrency limits the calls that the components make with the
+	// connection at once, across all pipelines. Zero means no limit.
+	MaxConcurrency int32
+}
+
+// EventSourceType is the messaging system an event source consumes from.
+type EventSourceType string
+
+// Supported event source types.
+const (
+	EventSourceTypeKafka  EventSourceType = "kafka"
+	EventSourceTypeSQS    EventSourceType = "sqs"
+	EventSourceTypePubSub EventSourceType = "pubsub"
+
+	// EventSourceTypeObjectStorage triggers the pipeline with the objects
+	// created under a bucket prefix.
+	EventSourceTypeObjectStorage EventSourceType = "object-storage"
+)
+
+// EventSource is the data model for the `event_source` table. An event
+// source consumes the messages of a Kafka topic, an SQS queue or a Pub/Sub
+// subscription, or the objects dropped in a bucket, and triggers a pipeline
+// with each of them.
+type EventSource struct {
+	BaseDynamic
+	ID           string
+	NamespaceUID uuid.UUID
+	PipelineUID  uuid.UUID
+	// ReleaseID is optional. When empty, the latest recipe of the pipeline is
+	// triggered.
+	ReleaseID string
+	Type      EventSourceType
+	Setup     datatypes.JSON `gorm:"type:jsonb"`
+	// VariableMapping maps each pipeline variable to a JSONPath expression
+	// evaluated against the message payload.
+	VariableMapping datatypes.JSON `gorm:"type:jsonb"`
+	// MaxAttempts is the number of times a message is triggered before it is
+	// moved to the dead-letter queue.
+	MaxAttempts int32
+	// Checkpoint holds the position of the last acknowledged message, in a
+	// format that depends on the source type.
+	Checkpoint datatypes.JSON `gorm:"type:jsonb"`
+	// Deduplication holds the optional deduplication settings of the
+	// messages, as a JSON-encoded Deduplication.
+	Deduplication datatypes.JSON `gorm:"type:jsonb"`
+}
+
+// Prompt is the data model for the `prompt` table. Each row is an immutable
+// version of a named prompt template. Components reference a prompt with
+// `prompt://<id>@<version>`, or `prompt://<id>` for its latest version.
+type Prompt struct {
+	BaseDynamicHardDelete
+	ID           string
+	NamespaceUID uuid.UUID
+	Version      int32
+	Description  string
+	// Template is rendered as a component input string, so it can reference
+	// the pipeline data with the `${...}` syntax.
+	Template string
+	// Variables holds the references found in the template.
+	Variables pq.StringArray `gorm:"type:text[]"`
+}
+
+// PipelineCanary is the data model for the `pipeline_canary` table. It holds
+// the schedule and the outcome of the canary checks of a pipeline, which are
+// defined in its recipe.
+type PipelineCanary struct {
+	PipelineUID         uuid.UUID `gorm:"primaryKey"`
+	CanaryID            string    `gorm:"primaryKey"`
+	NextRunTime         time.Time
+	LastRunTime         sql.NullTime
+	LastStatus          string
+	LastError           string
+	LastTriggerUID      uuid.NullUUID `gorm:"type:uuid"`
+	ConsecutiveFailures int32
+	CreateTime          time.Time `gorm:"autoCreateTime:nano"`
+	UpdateTime          time.Time `gorm:"autoUpdateTime:nano"`
+}
+
+// Outcomes of a canary check.
+const (
+	CanaryPassed = "passed"
+	CanaryFailed = "failed"
+)
+
+// NamespaceSettings is the data model for the `namespace_settings` table. It
+// holds the defaults that the recipes of a namespace inherit unless they
+// override them.
+type NamespaceSettings struct {
+	NamespaceUID         uuid.UUID `gorm:"primaryKey"`
+	MaxDuration          string
+	ComponentTimeout     string
+	RetryMaxAttempts     int32
+	RetryInitialInterval string
+	ErrorPolicy          string
+	// CacheTTL is the TTL of the components that enable the semantic cache
+	// without setting one.
+	CacheTTL string
+	// AllowedComponents and BlockedComponents are the component policy of
+	// the namespace, i.e. the component definitions, optionally restricted
+	// to some versions, that its pipelines can and can't use.
+	AllowedComponents pq.StringArray `gorm:"type:text[]"`
+	BlockedComponents pq.StringArray `gorm:"type:text[]"`
+	CreateTime        time.Time      `gorm:"autoCreateTime:nano"`
+	UpdateTime        time.Time      `gorm:"autoUpdateTime:nano"`
+}
+
+// ApplyTo sets the defaults of the namespace in the fields that a recipe
+// doesn't set.
+func (s *NamespaceSettings) ApplyTo(r *Recipe) {
+	if r.MaxDuration == "" {
+		r.MaxDuration = s.MaxDuration
+	}
+	if r.ComponentTimeout == "" {
+		r.ComponentTimeout = s.ComponentTimeout
+	}
+	if r.ErrorPolicy == "" {
+		r.ErrorPolicy = s.ErrorPolicy
+	}
+
+	if s.RetryMaxAttempts > 0 || s.RetryInitialInterval != "" {
+		retry := &RetryPolicy{}
+		if r.Retry != nil {
+			*retry = *r.Retry
+		}
+		if retry.MaxAttempts == 0 {
+			retry.MaxAttempts = s.RetryMaxAttempts
+		}
+		if retry.InitialInterval == "" {
+			retry.InitialInterval = s.RetryInitialInterval
+		}
+		r.Retry = retry
+	}
+
+	if s.CacheTTL != "" {
+		applyCacheTTL(r.Component, s.CacheTTL)
+	}
+}
+
+func applyCacheTTL(components ComponentMap, ttl string) {
+	for _, comp := range components.WithErrorBranches() {
+		if comp.Cache != nil && comp.Cache.TTL == "" {
+			comp.Cache.TTL = ttl
+		}
+		applyCacheTTL(comp.Component, ttl)
+	}
+}
+
+// ComponentViolation is a component of a recipe that the component policy of
+// its namespace doesn't allow.
+type ComponentViolation struct {
+	// Location is the path of the component in the recipe, e.g.
+	// `component.loop.component.run` or `component.llm.failover.0`.
+	Location string
+	Type     string
+	Version  string
+}
+
+// Message describes the violation to the users.
+func (v ComponentViolation) Message() string {
+	return fmt.Sprintf("component %s@%s isn't allowed in this namespace", v.Type, v.Version)
+}
+
+// ComponentViolations returns the components of a recipe that the component
+// policy of the namespace doesn't allow, including the components of the
+// iterators, the error branches and the failover targets. The version of a
+// component type is the one of its definition in the deployment.
+//
+// The policy entries are component definition IDs, optionally followed by a
+// version or a version prefix (e.g. `openai@1.2.0` or `openai@1`). The
+// blocked components take precedence over the allowed ones, and every
+// component is allowed when the allow list is empty.
+func (s *NamespaceSettings) ComponentViolations(r *Recipe, versionOf func(compType string) string) []ComponentViolation {
+	if len(s.AllowedComponents) == 0 && len(s.BlockedComponents) == 0 {
+		return nil
+	}
+
+	var violations []ComponentViolation
+	check := func(loc, compType string) {
+		version := versionOf(compType)
+		allowed := len(s.AllowedComponents) == 0 || slices.ContainsFunc(s.AllowedComponents, func(entry string) bool {
+			return matchComponentEntry(entry, compType, version)
+		})
+		blocked := slices.ContainsFunc(s.BlockedComponents, func(entry string) bool {
+			return matchComponentEntry(entry, compType, version)
+		})
+		if !allowed || blocked {
+			violations = append(violations, ComponentViolation{Location: loc, Type: compType, Version: version})
+		}
+	}
+
+	var walk func(prefix string, components ComponentMap)
+	walk = func(prefix string, components ComponentMap) {
+		for id, comp := range components {
+			loc := prefix + id
+			switch comp.Type {
+			case Iterator:
+				walk(loc+".component.", comp.Component)
+				continue
+			case Approval:
+				continue
+			}
+
+			check(loc, comp.Type)
+			for i, target := range comp.Failover {
+				if target.Type != "" {
+					check(fmt.Sprintf("%s.failover.%d", loc, i), target.Type)
+				}
+			}
+			walk(loc+".on-error.", comp.OnError)
+		}
+	}
+	walk("component.", r.Component)
+
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Location < violations[j].Location })
+	return violations
+}
+
+// matchComponentEntry tells whether an entry of a component policy matches
+// a version of a component type.
+func matchComponentEntry(entry, compType, version string) bool {
+	entryType, entryVersion, hasVersion := strings.Cut(entry, "@")
+	if entryType != compType {
+		return false
+	}
+	if !hasVersion {
+		return true
+	}
+
+	entryVersion = strings.TrimPrefix(entryVersion, "v")
+	version = strings.TrimPrefix(version, "v")
+	return version == entryVersion || strings.HasPrefix(version, entryVersion+".")
 }
@@ -1,6 +1,7 @@
 package datamodel
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/frankban/quicktest"
@@ -37,3 +38,131 @@ func TestDatamodel_TagNames(t *testing.T) {
 		c.Assert(tagNames, quicktest.DeepEquals, tc.expected)
 	}
 }
+
+func TestDatamodel_WithErrorBranches(t *testing.T) {
+	c := quicktest.New(t)
+
+	fallback := &Component{Type: "openai", Task: "TASK_TEXT_GENERATION"}
+	notify := &Component{Type: "slack", Task: "TASK_WRITE_MESSAGE"}
+	primary := &Component{
+		Type:    "anthropic",
+		Task:    "TASK_TEXT_GENERATION",
+		OnError: ComponentMap{"fallback": fallback},
+	}
+
+	comps := ComponentMap{"primary": primary, "notify": notify}
+	c.Check(comps.WithErrorBranches(), quicktest.DeepEquals, ComponentMap{
+		"primary":  primary,
+		"fallback": fallback,
+		"notify":   notify,
+	})
+
+	// The original map isn't modified.
+	c.Check(comps, quicktest.HasLen, 2)
+}
+
+func TestDatamodel_NamespaceSettings(t *testing.T) {
+	c := quicktest.New(t)
+
+	settings := &NamespaceSettings{
+		MaxDuration:          "10m",
+		ComponentTimeout:     "1m",
+		RetryMaxAttempts:     3,
+		RetryInitialInterval: "2s",
+		ErrorPolicy:          ErrorPolicyFailFast,
+		CacheTTL:             "1h",
+		AllowedComponents:    []string{"openai", "json"},
+	}
+
+	newRecipe := func() *Recipe {
+		return &Recipe{
+			ComponentTimeout: "5m",
+			Retry:            &RetryPolicy{MaxAttempts: 1},
+			Component: ComponentMap{
+				"llm": {
+					Type:     "openai",
+					Cache:    &ComponentCache{Enabled: true},
+					Failover: []*FailoverTarget{{Type: "anthropic"}},
+				},
+				"loop": {
+					Type: Iterator,
+					Component: ComponentMap{
+						"parse": {Type: "json", Cache: &ComponentCache{Enabled: true, TTL: "5m"}},
+						"run":   {Type: "python"},
+					},
+				},
+				"review": {Type: Approval},
+			},
+		}
+	}
+
+	c.Run("apply defaults", func(c *quicktest.C) {
+		r := newRecipe()
+		settings.ApplyTo(r)
+
+		c.Check(r.MaxDuration, quicktest.Equals, "10m")
+		c.Check(r.ComponentTimeout, quicktest.Equals, "5m")
+		c.Check(r.Retry, quicktest.DeepEquals, &RetryPolicy{MaxAttempts: 1, InitialInterval: "2s"})
+		c.Check(r.ErrorPolicy, quicktest.Equals, ErrorPolicyFailFast)
+		c.Check(r.Component["llm"].Cache.TTL, quicktest.Equals, "1h")
+		c.Check(r.Component["loop"].Component["parse"].Cache.TTL, quicktest.Equals, "5m")
+	})
+
+	c.Run("component policy", func(c *quicktest.C) {
+		versionOf := func(compType string) string {
+			if compType == "json" {
+				return "0.2.1"
+			}
+			return "1.0.0"
+		}
+		locations := func(violations []ComponentViolation) []string {
+			locs := make([]string, 0, len(violations))
+			for _, v := range violations {
+				locs = append(locs, v.Location)
+			}
+			return locs
+		}
+
+		c.Check(locations(settings.ComponentViolations(newRecipe(), versionOf)), quicktest.DeepEquals, []string{
+			"component.llm.failover.0",
+			"component.loop.component.run",
+		})
+
+		versioned := &NamespaceSettings{
+			AllowedComponents: []string{"openai", "anthropic", "json@0.1", "python"},
+			BlockedComponents: []string{"python@1.0.0"},
+		}
+		c.Check(locations(versioned.ComponentViolations(newRecipe(), versionOf)), quicktest.DeepEquals, []string{
+			"component.loop.component.parse",
+			"component.loop.component.run",
+		})
+
+		c.Check((&NamespaceSettings{}).ComponentViolations(newRecipe(), versionOf), quicktest.HasLen, 0)
+	})
+}
+
+func TestDatamodel_CapturePolicy(t *testing.T) {
+	c := quicktest.New(t)
+
+	var unset *CapturePolicy
+	c.Check(unset.ModeFor("run"), quicktest.Equals, CaptureAll)
+	c.Check((&CapturePolicy{}).ModeFor("run"), quicktest.Equals, CaptureAll)
+	c.Check((&CapturePolicy{Mode: CaptureFailures}).ModeFor("run"), quicktest.Equals, CaptureFailures)
+	c.Check((&CapturePolicy{Mode: CaptureNever}).ModeFor("run"), quicktest.Equals, CaptureNever)
+
+	c.Run("sampled", func(c *quicktest.C) {
+		c.Check((&CapturePolicy{Mode: CaptureSampled, SampleRate: 100}).ModeFor("run"), quicktest.Equals, CaptureAll)
+		c.Check((&CapturePolicy{Mode: CaptureSampled}).ModeFor("run"), quicktest.Equals, CaptureNever)
+
+		p := &CapturePolicy{Mode: CaptureSampled, SampleRate: 25}
+		captured := 0
+		for i := range 1000 {
+			mode := p.ModeFor(fmt.Sprintf("run-%d", i))
+			c.Assert(mode, quicktest.Equals, p.ModeFor(fmt.Sprintf("run-%d", i)))
+			if mode == CaptureAll {
+				captured++
+			}
+		}
+		c.Check(captured > 150 && captured < 350, quicktest.IsTrue, quicktest.Commentf("captured %d runs", captured))
+	})
+}
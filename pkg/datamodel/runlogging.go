@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
 	"gopkg.in/guregu/null.v4"
+	"gorm.io/datatypes"
 
 	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
 )
@@ -77,21 +79,117 @@ type PipelineRun struct {
 	Inputs             JSONB          `gorm:"type:jsonb" json:"inputs"`                                                      // Input files for the run
 	Outputs            JSONB          `gorm:"type:jsonb" json:"outputs"`                                                     // Output files from the run
 	RecipeSnapshot     JSONB          `gorm:"type:jsonb" json:"recipe-snapshot"`                                             // Snapshot of the pipeline recipe used for this run
+	Secrets            JSONB          `gorm:"type:jsonb" json:"-"`                                                           // Trigger-time secrets of the run, only read to retry it
+	RetriedFromUID     uuid.NullUUID  `gorm:"type:uuid" json:"retried-from-uid"`                                             // Trigger UID of the run this run retries, if any
+	ScheduleID         string         `gorm:"type:varchar(255)" json:"schedule-id"`                                          // ID of the schedule that triggered the run, if any
+	TemporalCluster    string         `gorm:"type:varchar(255)" json:"temporal-cluster"`                                     // Name of the Temporal cluster that owns the run execution
 	StartedTime        time.Time      `gorm:"type:timestamp with time zone;index" json:"started-time,omitempty"`             // Time when the run started execution
 	CompletedTime      null.Time      `gorm:"type:timestamp with time zone;index" json:"completed-time,omitempty"`           // Time when the run completed
 	Error              null.String    `gorm:"type:text" json:"error-msg"`                                                    // Error message if the run failed
+	MemorySize         null.Int       `gorm:"type:bigint" json:"memory-size"`                                                // Bytes of data held in the run memory
+	BatchMemorySizes   pq.Int64Array  `gorm:"type:bigint[]" json:"batch-memory-sizes"`                                       // Bytes of data held in the run memory by each batch item
+	BatchResults       BatchResults   `gorm:"type:jsonb" json:"batch-results"`                                               // Outcome of each batch item
 	Components         []ComponentRun `gorm:"foreignKey:PipelineTriggerUID;references:PipelineTriggerUID" json:"components"` // Execution details for each component in the pipeline
 }
 
 // ComponentRun represents the execution details of a single component within a pipeline run.
 type ComponentRun struct {
-	PipelineTriggerUID uuid.UUID   `gorm:"type:uuid;primaryKey;index" json:"pipeline-trigger-uid"`    // Links to the parent PipelineRun
-	ComponentID        string      `gorm:"type:varchar(255);primaryKey" json:"component-id"`          // Unique identifier for each pipeline component
-	Status             RunStatus   `gorm:"type:varchar(50);index" json:"status"`                      // Completion status of the component (e.g., Completed, Errored)
-	TotalDuration      null.Int    `gorm:"type:bigint" json:"total-duration"`                         // Time taken to execute the component in nanoseconds
-	StartedTime        time.Time   `gorm:"type:timestamp with time zone;index" json:"started-time"`   // Time when the component started execution
-	CompletedTime      null.Time   `gorm:"type:timestamp with time zone;index" json:"completed-time"` // Time when the component finished execution
-	Error              null.String `gorm:"type:text" json:"error-msg"`                                // Error message if the component failed
-	Inputs             JSONB       `gorm:"type:jsonb" json:"inputs"`                                  // Input files for the component
-	Outputs            JSONB       `gorm:"type:jsonb" json:"outputs"`                                 // Output files from the component
+	PipelineTriggerUID uuid.UUID     `gorm:"type:uuid;primaryKey;index" json:"pipeline-trigger-uid"`    // Links to the parent PipelineRun
+	ComponentID        string        `gorm:"type:varchar(255);primaryKey" json:"component-id"`          // Unique identifier for each pipeline component
+	Status             RunStatus     `gorm:"type:varchar(50);index" json:"status"`                      // Completion status of the component (e.g., Completed, Errored)
+	TotalDuration      null.Int      `gorm:"type:bigint" json:"total-duration"`                         // Time taken to execute the component in nanoseconds
+	StartedTime        time.Time     `gorm:"type:timestamp with time zone;index" json:"started-time"`   // Time when the component started execution
+	CompletedTime      null.Time     `gorm:"type:timestamp with time zone;index" json:"completed-time"` // Time when the component finished execution
+	Error              null.String   `gorm:"type:text" json:"error-msg"`                                // Error message if the component failed
+	Inputs             JSONB         `gorm:"type:jsonb" json:"inputs"`                                  // Input files for the component
+	Outputs            JSONB         `gorm:"type:jsonb" json:"outputs"`                                 // Output files from the component
+	Provider           string        `gorm:"type:varchar(255)" json:"provider"`                         // Component type that served the run
+	FailoverIndex      int           `gorm:"type:integer" json:"failover-index"`                        // Position of the provider in the failover group (0 is the component itself)
+	OutputActions      OutputActions `gorm:"type:jsonb" json:"output-actions"`                          // Actions taken on the outputs that exceeded the size limit
+}
+
+// Actions taken on a component output that exceeds the size limit.
+const (
+	// OutputActionTruncated means the largest strings of the output were
+	// truncated.
+	OutputActionTruncated = "truncated"
+	// OutputActionSpilled means the full output was stored as a run
+	// artifact and the output was truncated.
+	OutputActionSpilled = "spilled"
+)
+
+// OutputAction records that the output of a batch item was altered because
+// it exceeded the size limit.
+type OutputAction struct {
+	BatchIndex int    `json:"batch-index"`
+	Action     string `json:"action"`
+	Size       int    `json:"size"`               // Size of the original output in bytes
+	Artifact   string `json:"artifact,omitempty"` // Name of the artifact that holds the full output
+}
+
+// OutputActions is the list of output actions of a component run.
+type OutputActions []OutputAction
+
+// Value marshals the OutputActions to a value.
+func (a OutputActions) Value() (driver.Value, error) {
+	value, err := json.Marshal(a)
+	return string(value), err
+}
+
+// Scan unmarshals a value into the OutputActions.
+func (a *OutputActions) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, a)
+}
+
+// BatchResult is the outcome of a batch item in a pipeline run. The items of
+// a batch run independently, so some of them might fail while the rest
+// complete.
+type BatchResult struct {
+	BatchIndex  int       `json:"batch-index"`
+	Status      RunStatus `json:"status"`
+	ComponentID string    `json:"component-id,omitempty"` // First component that failed in the item
+	Error       string    `json:"error-msg,omitempty"`
+}
+
+// BatchResults is the list of batch item outcomes of a pipeline run.
+type BatchResults []BatchResult
+
+// Value marshals the BatchResults to a value.
+func (r BatchResults) Value() (driver.Value, error) {
+	value, err := json.Marshal(r)
+	return string(value), err
+}
+
+// Scan unmarshals a value into the BatchResults.
+func (r *BatchResults) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+// RunArtifact is a file that a component registers during a pipeline run,
+// e.g. a generated report or image. The content is kept in the blob storage
+// and the metadata in this record.
+type RunArtifact struct {
+	UID                uuid.UUID         `gorm:"type:uuid;primaryKey" json:"uid"`
+	PipelineTriggerUID uuid.UUID         `gorm:"type:uuid" json:"pipeline-trigger-uid"`
+	ComponentID        string            `gorm:"type:varchar(255)" json:"component-id"`
+	BatchIndex         int               `json:"batch-index"`
+	Name               string            `gorm:"type:varchar(255)" json:"name"`
+	ContentType        string            `gorm:"type:varchar(255)" json:"content-type"`
+	Size               int64             `json:"size"`
+	ObjectKey          string            `gorm:"type:text" json:"-"`
+	Metadata           datatypes.JSONMap `gorm:"type:jsonb" json:"metadata"`
+	CreateTime         time.Time         `gorm:"autoCreateTime:nano" json:"create-time"`
+}
+
+// TableName maps the RunArtifact object to a SQL table.
+func (RunArtifact) TableName() string {
+	return "pipeline_run_artifact"
 }
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS event_source_pipeline_uid;
+DROP INDEX IF EXISTS unique_event_source_id_namespace;
+DROP TABLE IF EXISTS event_source;
+DROP TYPE valid_event_source_type;
+
+COMMIT;
@@ -0,0 +1,28 @@
+BEGIN;
+
+CREATE TYPE valid_event_source_type AS ENUM (
+  'kafka',
+  'sqs',
+  'pubsub'
+);
+
+CREATE TABLE IF NOT EXISTS event_source (
+  uid              UUID                    PRIMARY KEY,
+  id               VARCHAR(255)            NOT NULL,
+  namespace_uid    UUID                    NOT NULL,
+  pipeline_uid     UUID                    NOT NULL,
+  release_id       VARCHAR(255)            NOT NULL DEFAULT '',
+  type             valid_event_source_type NOT NULL,
+  setup            JSONB                   NOT NULL,
+  variable_mapping JSONB                   NOT NULL DEFAULT '{}',
+  max_attempts     INTEGER                 NOT NULL DEFAULT 5,
+  checkpoint       JSONB                   NOT NULL DEFAULT '{}',
+  create_time      TIMESTAMPTZ             NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time      TIMESTAMPTZ             NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  delete_time      TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX unique_event_source_id_namespace ON event_source (id, namespace_uid) WHERE delete_time IS NULL;
+CREATE INDEX event_source_pipeline_uid ON event_source (pipeline_uid);
+
+COMMIT;
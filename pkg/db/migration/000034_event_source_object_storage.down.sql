@@ -0,0 +1,14 @@
+BEGIN;
+
+DELETE FROM event_source WHERE type = 'object-storage';
+
+ALTER TYPE valid_event_source_type RENAME TO valid_event_source_type_old;
+CREATE TYPE valid_event_source_type AS ENUM (
+  'kafka',
+  'sqs',
+  'pubsub'
+);
+ALTER TABLE event_source ALTER COLUMN type TYPE valid_event_source_type USING type::text::valid_event_source_type;
+DROP TYPE valid_event_source_type_old;
+
+COMMIT;
@@ -0,0 +1 @@
+ALTER TYPE valid_event_source_type ADD VALUE 'object-storage';
@@ -0,0 +1,7 @@
+BEGIN;
+
+alter table pipeline_run
+    drop column if exists retried_from_uid,
+    drop column if exists secrets;
+
+COMMIT;
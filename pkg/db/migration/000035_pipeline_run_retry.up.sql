@@ -0,0 +1,10 @@
+BEGIN;
+
+alter table pipeline_run
+    add retried_from_uid uuid,
+    add secrets jsonb;
+
+comment on column pipeline_run.retried_from_uid is 'trigger UID of the run this run retries';
+comment on column pipeline_run.secrets is 'reference to the trigger-time secrets of the run';
+
+COMMIT;
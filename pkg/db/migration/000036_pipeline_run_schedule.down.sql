@@ -0,0 +1,8 @@
+BEGIN;
+
+drop index if exists idx_pipeline_run_schedule_id;
+
+alter table pipeline_run
+    drop column if exists schedule_id;
+
+COMMIT;
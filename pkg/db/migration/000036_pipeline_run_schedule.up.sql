@@ -0,0 +1,10 @@
+BEGIN;
+
+alter table pipeline_run
+    add schedule_id varchar(255);
+
+comment on column pipeline_run.schedule_id is 'ID of the schedule that triggered the run';
+
+create index if not exists idx_pipeline_run_schedule_id on pipeline_run (schedule_id, started_time desc);
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS pipeline_run_artifact (
+  uid                  UUID         PRIMARY KEY,
+  pipeline_trigger_uid UUID         NOT NULL,
+  component_id         VARCHAR(255) NOT NULL,
+  batch_index          INTEGER      NOT NULL DEFAULT 0,
+  name                 VARCHAR(255) NOT NULL,
+  content_type         VARCHAR(255) NOT NULL,
+  size                 BIGINT       NOT NULL DEFAULT 0,
+  object_key           TEXT         NOT NULL,
+  metadata             JSONB        NOT NULL DEFAULT '{}',
+  create_time          TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX unique_pipeline_run_artifact_name ON pipeline_run_artifact (pipeline_trigger_uid, component_id, batch_index, name);
+
+COMMIT;
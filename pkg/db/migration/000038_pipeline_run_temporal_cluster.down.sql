@@ -0,0 +1,6 @@
+BEGIN;
+
+alter table pipeline_run
+    drop column if exists temporal_cluster;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+alter table pipeline_run
+    add temporal_cluster varchar(255);
+
+comment on column pipeline_run.temporal_cluster is 'Name of the Temporal cluster that owns the run execution';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+alter table component_run
+    drop column if exists provider,
+    drop column if exists failover_index;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+alter table component_run
+    add provider varchar(255),
+    add failover_index integer default 0;
+
+comment on column component_run.provider is 'Component type that served the run';
+comment on column component_run.failover_index is 'Position of the provider in the failover group of the component';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+drop table if exists pipeline_trigger_memory;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+create table if not exists pipeline_trigger_memory (
+    workflow_id varchar(255) primary key,
+    snapshot jsonb not null,
+    update_time timestamptz not null default current_timestamp
+);
+
+comment on table pipeline_trigger_memory is 'Workflow memory snapshots, used when the memory persistence backend is postgres';
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- The binary snapshots are converted to JSON by the migration tool before
+-- this step runs. If any is left, the conversion below fails and the
+-- snapshots are kept.
+alter table pipeline_trigger_memory
+    alter column snapshot type jsonb using convert_from(snapshot, 'UTF8')::jsonb;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- The workflow memory snapshots are stored in a versioned binary format. The
+-- existing JSON snapshots are kept as their UTF-8 bytes, which the worker
+-- still reads.
+alter table pipeline_trigger_memory
+    alter column snapshot type bytea using convert_to(snapshot::text, 'UTF8');
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS unique_prompt_id_namespace_version;
+DROP TABLE IF EXISTS prompt;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS prompt (
+  uid           UUID         PRIMARY KEY,
+  id            VARCHAR(255) NOT NULL,
+  namespace_uid UUID         NOT NULL,
+  version       INTEGER      NOT NULL,
+  description   TEXT         NOT NULL DEFAULT '',
+  template      TEXT         NOT NULL,
+  variables     TEXT[]       NOT NULL DEFAULT '{}',
+  create_time   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX unique_prompt_id_namespace_version ON prompt (namespace_uid, id, version);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+alter table pipeline_run
+    drop column if exists memory_size,
+    drop column if exists batch_memory_sizes;
+
+COMMIT;
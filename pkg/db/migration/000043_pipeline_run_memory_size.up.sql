@@ -0,0 +1,10 @@
+BEGIN;
+
+alter table pipeline_run
+    add memory_size bigint,
+    add batch_memory_sizes bigint[];
+
+comment on column pipeline_run.memory_size is 'Bytes of data held in the run memory';
+comment on column pipeline_run.batch_memory_sizes is 'Bytes of data held in the run memory by each batch item';
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+create table if not exists session_message (
+    id bigserial primary key,
+    namespace_uid uuid not null,
+    session_id varchar(255) not null,
+    role varchar(255) not null,
+    content text not null default '',
+    create_time timestamptz not null default current_timestamp
+);
+
+create index if not exists session_message_namespace_session_id on session_message (namespace_uid, session_id, id);
+
+comment on table session_message is 'Conversation session history, used when the session store backend is postgres';
+
+COMMIT;
@@ -0,0 +1 @@
+ALTER TABLE event_source DROP COLUMN IF EXISTS deduplication;
@@ -0,0 +1 @@
+ALTER TABLE event_source ADD COLUMN IF NOT EXISTS deduplication JSONB;
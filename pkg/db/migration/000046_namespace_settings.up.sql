@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS namespace_settings (
+  namespace_uid          UUID         PRIMARY KEY,
+  max_duration           VARCHAR(255) NOT NULL DEFAULT '',
+  component_timeout      VARCHAR(255) NOT NULL DEFAULT '',
+  retry_max_attempts     INTEGER      NOT NULL DEFAULT 0,
+  retry_initial_interval VARCHAR(255) NOT NULL DEFAULT '',
+  error_policy           VARCHAR(255) NOT NULL DEFAULT '',
+  cache_ttl              VARCHAR(255) NOT NULL DEFAULT '',
+  allowed_components     TEXT[]       NOT NULL DEFAULT '{}',
+  create_time            TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time            TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMIT;
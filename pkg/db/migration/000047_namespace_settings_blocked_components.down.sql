@@ -0,0 +1 @@
+ALTER TABLE namespace_settings DROP COLUMN IF EXISTS blocked_components;
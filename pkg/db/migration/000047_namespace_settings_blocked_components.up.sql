@@ -0,0 +1 @@
+ALTER TABLE namespace_settings ADD COLUMN IF NOT EXISTS blocked_components TEXT[] NOT NULL DEFAULT '{}';
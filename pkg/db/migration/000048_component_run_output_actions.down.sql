@@ -0,0 +1,6 @@
+BEGIN;
+
+alter table component_run
+    drop column if exists output_actions;
+
+COMMIT;
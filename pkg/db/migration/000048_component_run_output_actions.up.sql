@@ -0,0 +1,8 @@
+BEGIN;
+
+alter table component_run
+    add output_actions jsonb;
+
+comment on column component_run.output_actions is 'Actions taken on the outputs that exceeded the size limit';
+
+COMMIT;
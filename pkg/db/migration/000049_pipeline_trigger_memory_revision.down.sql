@@ -0,0 +1,6 @@
+BEGIN;
+
+alter table pipeline_trigger_memory
+    drop column if exists revision;
+
+COMMIT;
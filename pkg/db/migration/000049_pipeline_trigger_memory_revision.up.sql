@@ -0,0 +1,8 @@
+BEGIN;
+
+alter table pipeline_trigger_memory
+    add revision bigint not null default 0;
+
+comment on column pipeline_trigger_memory.revision is 'Counter incremented on every save, so concurrent workers do not overwrite each other''s memory';
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS pipeline_canary (
+  pipeline_uid         UUID         NOT NULL,
+  canary_id            VARCHAR(255) NOT NULL,
+  next_run_time        TIMESTAMPTZ  NOT NULL,
+  last_run_time        TIMESTAMPTZ,
+  last_status          VARCHAR(255) NOT NULL DEFAULT '',
+  last_error           TEXT         NOT NULL DEFAULT '',
+  last_trigger_uid     UUID,
+  consecutive_failures INTEGER      NOT NULL DEFAULT 0,
+  create_time          TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  update_time          TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (pipeline_uid, canary_id)
+);
+
+CREATE INDEX IF NOT EXISTS pipeline_canary_next_run_time ON pipeline_canary (next_run_time);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE connection ADD COLUMN IF NOT EXISTS max_concurrency INTEGER NOT NULL DEFAULT 0;
+
+COMMIT;
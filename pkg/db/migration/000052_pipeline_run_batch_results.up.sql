@@ -0,0 +1,8 @@
+BEGIN;
+
+alter table pipeline_run
+    add batch_results jsonb;
+
+comment on column pipeline_run.batch_results is 'Outcome of each batch item';
+
+COMMIT;
@@ -0,0 +1,32 @@
+package convert000041
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/pipeline-backend/pkg/db/migration/convert"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+)
+
+// SnapshotDowngrader rewrites the binary workflow memory snapshots as JSON
+// before the 000041 migration is rolled back, so the snapshot column can go
+// back to jsonb without dropping the memory of the runs in flight.
+type SnapshotDowngrader struct {
+	convert.Basic
+
+	// Persistence reads the snapshots, decrypting and decompressing them
+	// as the workers do.
+	Persistence memory.MemoryPersistence
+}
+
+func (c *SnapshotDowngrader) Migrate() error {
+	downgraded, err := memory.DowngradeWorkflowMemory(context.Background(), c.Persistence)
+	if err != nil {
+		return fmt.Errorf("downgrading workflow memory snapshots: %w", err)
+	}
+
+	c.Logger.Info("Workflow memory snapshots downgraded to JSON.", zap.Int("snapshots", downgraded))
+	return nil
+}
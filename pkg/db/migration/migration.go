@@ -2,7 +2,12 @@ package migration
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 
+	"gorm.io/gorm"
+
+	"github.com/instill-ai/pipeline-backend/config"
 	"github.com/instill-ai/pipeline-backend/pkg/db/migration/convert"
 	"github.com/instill-ai/pipeline-backend/pkg/db/migration/convert/convert000013"
 	"github.com/instill-ai/pipeline-backend/pkg/db/migration/convert/convert000015"
@@ -15,8 +20,10 @@ import (
 	"github.com/instill-ai/pipeline-backend/pkg/db/migration/convert/convert000029"
 	"github.com/instill-ai/pipeline-backend/pkg/db/migration/convert/convert000031"
 	"github.com/instill-ai/pipeline-backend/pkg/db/migration/convert/convert000032"
+	"github.com/instill-ai/pipeline-backend/pkg/db/migration/convert/convert000041"
 	"github.com/instill-ai/pipeline-backend/pkg/external"
 	"github.com/instill-ai/pipeline-backend/pkg/logger"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
 
 	database "github.com/instill-ai/pipeline-backend/pkg/db"
 )
@@ -82,3 +89,54 @@ func Migrate(version uint) error {
 
 	return m.Migrate()
 }
+
+// MigrateDown executes custom code before a database migration is rolled
+// back, typically to convert the records that the previous schema can't
+// hold.
+func MigrateDown(version uint) error {
+	var m migration
+	ctx := context.Background()
+	l, _ := logger.GetZapLogger(ctx)
+
+	db := database.GetConnection().WithContext(ctx)
+	defer database.Close(db)
+
+	bc := convert.Basic{
+		DB:     db,
+		Logger: l,
+	}
+
+	switch version {
+	case 41:
+		p, err := memoryPersistence(db)
+		if err != nil {
+			return err
+		}
+		m = &convert000041.SnapshotDowngrader{Basic: bc, Persistence: p}
+	default:
+		return nil
+	}
+
+	return m.Migrate()
+}
+
+// memoryPersistence returns the PostgreSQL backend of the workflow memory,
+// with the encryption and compression the workers use.
+func memoryPersistence(db *gorm.DB) (memory.MemoryPersistence, error) {
+	p := memory.NewPostgresPersistence(db)
+	if key := config.Config.Memory.EncryptionKey; key != "" {
+		masterKey, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("decoding memory encryption key: %w", err)
+		}
+		keyWrapper, err := memory.NewMasterKeyWrapper(masterKey)
+		if err != nil {
+			return nil, fmt.Errorf("setting up memory encryption: %w", err)
+		}
+		// The snapshots are rewritten in plain text, so a conversion that
+		// was interrupted can be resumed.
+		p = memory.NewEncryptedPersistence(p, keyWrapper, true)
+	}
+
+	return memory.NewCompressedPersistence(p, memory.Compression(config.Config.Memory.Compression))
+}
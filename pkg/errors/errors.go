@@ -29,4 +29,8 @@ var (
 	// ErrAlreadyExists is used when a resource can't be created because it
 	// already exists.
 	ErrAlreadyExists = errmsg.AddMessage(fmt.Errorf("resource already exists"), "Resource already exists.")
+	// ErrFailedPrecondition is used when a request can't be performed in the
+	// current state of the system (e.g., a resource that is still referenced
+	// is deleted).
+	ErrFailedPrecondition = fmt.Errorf("failed precondition")
 )
@@ -0,0 +1,147 @@
+package eventsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	minio "github.com/minio/minio-go/v7"
+)
+
+const s3Endpoint = "s3.amazonaws.com"
+
+type objectInfo struct {
+	Bucket       string
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// bucket lists and presigns the objects of an object storage bucket.
+type bucket interface {
+	list(ctx context.Context, prefix string) ([]objectInfo, error)
+	presign(ctx context.Context, key string, expiry time.Duration) (string, error)
+	close() error
+}
+
+func newBucket(ctx context.Context, setup objectStorageSetup) (bucket, error) {
+	switch setup.Provider {
+	case objectStorageProviderS3, objectStorageProviderMinIO:
+		return newS3Bucket(setup)
+	case objectStorageProviderGCS:
+		return newGCSBucket(ctx, setup)
+	}
+	return nil, fmt.Errorf("unsupported object storage provider %q", setup.Provider)
+}
+
+// s3Bucket accesses S3 and S3-compatible (e.g. MinIO) buckets.
+type s3Bucket struct {
+	client *minio.Client
+	name   string
+}
+
+func newS3Bucket(setup objectStorageSetup) (*s3Bucket, error) {
+	endpoint, secure := setup.Endpoint, setup.UseSSL
+	if setup.Provider == objectStorageProviderS3 {
+		endpoint, secure = s3Endpoint, true
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(setup.AccessKeyID, setup.SecretAccessKey, ""),
+		Secure: secure,
+		Region: setup.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating S3 client: %w", err)
+	}
+
+	return &s3Bucket{client: client, name: setup.Bucket}, nil
+}
+
+func (b *s3Bucket) list(ctx context.Context, prefix string) ([]objectInfo, error) {
+	objects := []objectInfo{}
+	for o := range b.client.ListObjects(ctx, b.name, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if o.Err != nil {
+			return nil, o.Err
+		}
+		objects = append(objects, objectInfo{
+			Bucket:       b.name,
+			Key:          o.Key,
+			Size:         o.Size,
+			ContentType:  o.ContentType,
+			LastModified: o.LastModified,
+		})
+	}
+	return objects, nil
+}
+
+func (b *s3Bucket) presign(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	u, err := b.client.PresignedGetObject(ctx, b.name, key, expiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func (b *s3Bucket) close() error {
+	return nil
+}
+
+// gcsBucket accesses Google Cloud Storage buckets. The credentials must
+// belong to a service account, as its private key signs the object URLs.
+type gcsBucket struct {
+	client *storage.Client
+	name   string
+}
+
+func newGCSBucket(ctx context.Context, setup objectStorageSetup) (*gcsBucket, error) {
+	client, err := storage.NewClient(ctx, option.WithCredentialsJSON([]byte(setup.CredentialsJSON)))
+	if err != nil {
+		return nil, fmt.Errorf("creating GCS client: %w", err)
+	}
+
+	return &gcsBucket{client: client, name: setup.Bucket}, nil
+}
+
+func (b *gcsBucket) list(ctx context.Context, prefix string) ([]objectInfo, error) {
+	objects := []objectInfo{}
+	it := b.client.Bucket(b.name).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, objectInfo{
+			Bucket:       b.name,
+			Key:          attrs.Name,
+			Size:         attrs.Size,
+			ContentType:  attrs.ContentType,
+			LastModified: attrs.Updated,
+		})
+	}
+	return objects, nil
+}
+
+func (b *gcsBucket) presign(_ context.Context, key string, expiry time.Duration) (string, error) {
+	return b.client.Bucket(b.name).SignedURL(key, &storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(expiry),
+		Scheme:  storage.SigningSchemeV4,
+	})
+}
+
+func (b *gcsBucket) close() error {
+	return b.client.Close()
+}
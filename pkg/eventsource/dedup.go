@@ -0,0 +1,117 @@
+package eventsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/gofrs/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// Upstream systems deliver their events at least once, so the same event
+// can reach a pipeline several times. When deduplication is configured, the
+// IDs of the events that triggered a pipeline are kept in a Redis sorted
+// set, scored by the time they were received, and the events whose ID is
+// already in the set are dropped. The IDs older than the window are removed
+// from the set on each check.
+
+// EventSourceDedupKey returns the Redis key of the event IDs seen by an
+// event source.
+func EventSourceDedupKey(uid uuid.UUID) string {
+	return fmt.Sprintf("event_source:%s:dedup", uid)
+}
+
+// ObjectClaimKey returns the Redis key of the objects claimed by the
+// consumers of an object storage event source.
+func ObjectClaimKey(uid uuid.UUID) string {
+	return fmt.Sprintf("event_source:%s:objects", uid)
+}
+
+// WebhookDedupKey returns the Redis key of the event IDs seen by a webhook
+// event of a pipeline.
+func WebhookDedupKey(pipelineUID uuid.UUID, eventID string) string {
+	return fmt.Sprintf("pipeline:%s:event:%s:dedup", pipelineUID, eventID)
+}
+
+// ValidateDeduplication checks the deduplication settings of a trigger.
+func ValidateDeduplication(d *datamodel.Deduplication) error {
+	if d == nil {
+		return nil
+	}
+	if !strings.HasPrefix(d.Field, "$") {
+		return fmt.Errorf("deduplication field must be a JSONPath expression, e.g. $.id")
+	}
+	if _, err := d.ParseWindow(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// EventID resolves the ID of an event from its payload. Payloads that
+// aren't valid JSON are treated as a string, as in Variables.
+func EventID(payload []byte, field string) (string, error) {
+	var data any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		data = string(payload)
+	}
+
+	res, err := jsonpath.Get(field, data)
+	if err != nil {
+		return "", fmt.Errorf("resolving event ID: %w", err)
+	}
+
+	var id string
+	switch v := res.(type) {
+	case string:
+		id = v
+	case float64:
+		id = strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("encoding event ID: %w", err)
+		}
+		id = string(b)
+	}
+
+	if id == "" {
+		return "", fmt.Errorf("event ID at %s is empty", field)
+	}
+	return id, nil
+}
+
+// ClaimEvent records an event ID in a deduplication set. It returns false if
+// the ID was already recorded within the window, in which case the event is
+// a duplicate and mustn't trigger the pipeline.
+func ClaimEvent(ctx context.Context, rc *redis.Client, key, eventID string, window time.Duration) (bool, error) {
+	now := time.Now()
+	var added *redis.IntCmd
+	_, err := rc.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(now.Add(-window).UnixMilli(), 10))
+		added = pipe.ZAddNX(ctx, key, redis.Z{Score: float64(now.UnixMilli()), Member: eventID})
+		pipe.Expire(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("checking event ID: %w", err)
+	}
+
+	return added.Val() == 1, nil
+}
+
+// ReleaseEvent removes an event ID from a deduplication set, so the event
+// can be delivered again after it failed to trigger the pipeline.
+func ReleaseEvent(ctx context.Context, rc *redis.Client, key, eventID string) error {
+	if err := rc.ZRem(ctx, key, eventID).Err(); err != nil {
+		return fmt.Errorf("releasing event ID: %w", err)
+	}
+	return nil
+}
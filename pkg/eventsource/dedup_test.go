@@ -0,0 +1,102 @@
+package eventsource
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+func TestEventID(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name    string
+		payload string
+		field   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "ok - string",
+			payload: `{"event": {"id": "evt-1"}}`,
+			field:   "$.event.id",
+			want:    "evt-1",
+		},
+		{
+			name:    "ok - number",
+			payload: `{"id": 1234567}`,
+			field:   "$.id",
+			want:    "1234567",
+		},
+		{
+			name:    "ok - raw payload",
+			payload: "evt-1",
+			field:   "$",
+			want:    "evt-1",
+		},
+		{
+			name:    "nok - missing field",
+			payload: `{"type": "url_verification"}`,
+			field:   "$.event_id",
+			wantErr: "resolving event ID.*",
+		},
+		{
+			name:    "nok - empty ID",
+			payload: `{"id": ""}`,
+			field:   "$.id",
+			wantErr: `event ID at \$.id is empty`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			got, err := EventID([]byte(tc.payload), tc.field)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(got, qt.Equals, tc.want)
+		})
+	}
+}
+
+func TestValidateDeduplication(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name    string
+		dedup   *datamodel.Deduplication
+		wantErr string
+	}{
+		{name: "ok - not set"},
+		{
+			name:  "ok",
+			dedup: &datamodel.Deduplication{Field: "$.id", Window: "1h"},
+		},
+		{
+			name:    "nok - field isn't a JSONPath",
+			dedup:   &datamodel.Deduplication{Field: "id", Window: "1h"},
+			wantErr: "deduplication field must be a JSONPath expression.*",
+		},
+		{
+			name:    "nok - invalid window",
+			dedup:   &datamodel.Deduplication{Field: "$.id", Window: "-5m"},
+			wantErr: "deduplication window must be a positive duration.*",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			err := ValidateDeduplication(tc.dedup)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Check(err, qt.IsNil)
+		})
+	}
+}
@@ -0,0 +1,423 @@
+package eventsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/logger"
+	"github.com/instill-ai/pipeline-backend/pkg/repository"
+)
+
+const (
+	// DefaultMaxAttempts is used when an event source doesn't define the
+	// number of attempts.
+	DefaultMaxAttempts = 5
+
+	// maxDeadLetters is the number of dead letters retained per event source.
+	maxDeadLetters = 1000
+
+	defaultSyncInterval  = 30 * time.Second
+	initialRetryInterval = time.Second
+	maxRetryInterval     = 30 * time.Second
+)
+
+// TriggerFunc triggers the pipeline of an event source with the variables
+// extracted from a message.
+type TriggerFunc func(ctx context.Context, es *datamodel.EventSource, variables *structpb.Struct) error
+
+// DeadLetter is a message that couldn't trigger the pipeline after the
+// maximum number of attempts.
+type DeadLetter struct {
+	MessageID string    `json:"messageId"`
+	Payload   string    `json:"payload"`
+	Error     string    `json:"error"`
+	Attempts  int32     `json:"attempts"`
+	Time      time.Time `json:"time"`
+}
+
+// DeadLetterKey returns the Redis key of the dead-letter queue of an event
+// source.
+func DeadLetterKey(uid uuid.UUID) string {
+	return fmt.Sprintf("event_source:%s:dead_letter", uid)
+}
+
+// Dispatcher consumes the messages of every event source and triggers their
+// pipelines. Several dispatchers can run in parallel: messages are shared
+// among them by the brokers (competing consumers in SQS and Pub/Sub,
+// consumer groups in Kafka).
+type Dispatcher struct {
+	repository   repository.Repository
+	redisClient  *redis.Client
+	trigger      TriggerFunc
+	syncInterval time.Duration
+	consumerID   uuid.UUID
+
+	mu        sync.Mutex
+	consumers map[uuid.UUID]*consumer
+}
+
+type consumer struct {
+	updateTime time.Time
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// NewDispatcher returns an initialized dispatcher.
+func NewDispatcher(r repository.Repository, rc *redis.Client, trigger TriggerFunc, syncInterval time.Duration) *Dispatcher {
+	if syncInterval <= 0 {
+		syncInterval = defaultSyncInterval
+	}
+
+	return &Dispatcher{
+		repository:   r,
+		redisClient:  rc,
+		trigger:      trigger,
+		syncInterval: syncInterval,
+		consumerID:   uuid.Must(uuid.NewV4()),
+		consumers:    map[uuid.UUID]*consumer{},
+	}
+}
+
+// Run starts and stops the consumers as the event sources are created and
+// deleted. It blocks until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	logger, _ := logger.GetZapLogger(ctx)
+
+	ticker := time.NewTicker(d.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.sync(ctx); err != nil {
+			logger.Error("Couldn't sync event sources", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			d.stopAll()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) sync(ctx context.Context) error {
+	sources, err := d.repository.ListEventSourcesAdmin(ctx)
+	if err != nil {
+		return fmt.Errorf("listing event sources: %w", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	active := make(map[uuid.UUID]bool, len(sources))
+	for _, es := range sources {
+		active[es.UID] = true
+
+		c, ok := d.consumers[es.UID]
+		if ok && c.updateTime.Equal(es.UpdateTime) {
+			continue
+		}
+		if ok {
+			// The configuration changed, the consumer is restarted.
+			c.stop()
+		}
+
+		consumerCtx, cancel := context.WithCancel(ctx)
+		c = &consumer{
+			updateTime: es.UpdateTime,
+			cancel:     cancel,
+			done:       make(chan struct{}),
+		}
+		d.consumers[es.UID] = c
+
+		go func(es *datamodel.EventSource) {
+			defer close(c.done)
+			d.consume(consumerCtx, es)
+		}(es)
+	}
+
+	for uid, c := range d.consumers {
+		if !active[uid] {
+			c.stop()
+			delete(d.consumers, uid)
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) stopAll() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for uid, c := range d.consumers {
+		c.stop()
+		delete(d.consumers, uid)
+	}
+}
+
+func (c *consumer) stop() {
+	c.cancel()
+	<-c.done
+}
+
+// consume receives the messages of an event source until the context is
+// cancelled. Messages are acknowledged only after they have triggered the
+// pipeline or have been moved to the dead-letter queue, so a failure in the
+// dispatcher leads to redelivery rather than to message loss.
+func (d *Dispatcher) consume(ctx context.Context, es *datamodel.EventSource) {
+	logger, _ := logger.GetZapLogger(ctx)
+	logger = logger.With(zap.String("eventSourceUID", es.UID.String()))
+
+	mapping := map[string]string{}
+	if len(es.VariableMapping) > 0 {
+		if err := json.Unmarshal(es.VariableMapping, &mapping); err != nil {
+			logger.Error("Invalid variable mapping", zap.Error(err))
+			return
+		}
+	}
+
+	retryInterval := initialRetryInterval
+	for ctx.Err() == nil {
+		src, err := New(ctx, es, d.consumerID)
+		if err != nil {
+			logger.Error("Couldn't connect to event source", zap.Error(err))
+			if !sleep(ctx, retryInterval) {
+				return
+			}
+			retryInterval = min(2*retryInterval, maxRetryInterval)
+			continue
+		}
+
+		retryInterval = initialRetryInterval
+		err = d.consumeSource(ctx, es, src, mapping)
+
+		// The context might be cancelled at this point.
+		closeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if closeErr := src.Close(closeCtx); closeErr != nil {
+			logger.Warn("Couldn't close event source", zap.Error(closeErr))
+		}
+		cancel()
+
+		if err != nil && ctx.Err() == nil {
+			logger.Error("Event source consumption interrupted", zap.Error(err))
+			if !sleep(ctx, retryInterval) {
+				return
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) consumeSource(ctx context.Context, es *datamodel.EventSource, src Source, mapping map[string]string) error {
+	for ctx.Err() == nil {
+		msgs, err := src.Receive(ctx)
+		if err != nil {
+			return err
+		}
+		if len(msgs) == 0 {
+			continue
+		}
+
+		for _, m := range msgs {
+			if err := d.process(ctx, es, m, mapping); err != nil {
+				// The batch isn't acknowledged, the messages will be
+				// delivered again.
+				return err
+			}
+		}
+
+		if err := src.Ack(ctx, msgs); err != nil {
+			return err
+		}
+
+		if checkpoint := src.Checkpoint(); checkpoint != nil {
+			b, err := json.Marshal(checkpoint)
+			if err != nil {
+				return fmt.Errorf("marshalling checkpoint: %w", err)
+			}
+			if err := d.repository.UpdateEventSourceCheckpoint(ctx, es.UID, b); err != nil {
+				return fmt.Errorf("storing checkpoint: %w", err)
+			}
+		}
+	}
+
+	return ctx.Err()
+}
+
+// process triggers the pipeline with a message. Failed triggers are retried
+// with an exponential backoff. When the attempts are exhausted, the message is
+// moved to the dead-letter queue. An error is returned only if the message
+// can't be handled at all (e.g. the dispatcher is stopping), in which case it
+// mustn't be acknowledged.
+func (d *Dispatcher) process(ctx context.Context, es *datamodel.EventSource, m *Message, mapping map[string]string) error {
+	maxAttempts := es.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	if m.claimID != "" {
+		// The object is handled by the replica that claims it first, even
+		// if it ends up in the dead-letter queue.
+		claimed, err := ClaimEvent(ctx, d.redisClient, ObjectClaimKey(es.UID), m.claimID, objectClaimWindow)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return nil
+		}
+	}
+
+	variables, err := Variables(m.Payload, mapping)
+	if err != nil {
+		// Malformed messages won't succeed in subsequent attempts.
+		return d.deadLetter(ctx, es, m, err, 1)
+	}
+
+	eventID, duplicate, err := d.claim(ctx, es, m)
+	if err != nil {
+		return d.deadLetter(ctx, es, m, err, 1)
+	}
+	if duplicate {
+		return nil
+	}
+
+	// The message will be delivered again, so it mustn't be taken for a
+	// duplicate or for an object claimed by this replica.
+	interrupted := func() error {
+		d.release(es, EventSourceDedupKey(es.UID), eventID)
+		d.release(es, ObjectClaimKey(es.UID), m.claimID)
+		return ctx.Err()
+	}
+
+	retryInterval := initialRetryInterval
+	for attempt := int32(1); ; attempt++ {
+		err = d.trigger(ctx, es, variables)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return interrupted()
+		}
+		if attempt >= maxAttempts {
+			d.release(es, EventSourceDedupKey(es.UID), eventID)
+			return d.deadLetter(ctx, es, m, err, attempt)
+		}
+
+		if !sleep(ctx, retryInterval) {
+			return interrupted()
+		}
+		retryInterval = min(2*retryInterval, maxRetryInterval)
+	}
+}
+
+// claim records the ID of a message when the event source deduplicates its
+// messages. It reports whether the message is a duplicate of one received
+// within the deduplication window.
+func (d *Dispatcher) claim(ctx context.Context, es *datamodel.EventSource, m *Message) (eventID string, duplicate bool, err error) {
+	if len(es.Deduplication) == 0 || string(es.Deduplication) == "null" {
+		return "", false, nil
+	}
+
+	dedup := new(datamodel.Deduplication)
+	if err := json.Unmarshal(es.Deduplication, dedup); err != nil {
+		return "", false, fmt.Errorf("unmarshalling deduplication: %w", err)
+	}
+	window, err := dedup.ParseWindow()
+	if err != nil {
+		return "", false, err
+	}
+
+	eventID, err = EventID(m.Payload, dedup.Field)
+	if err != nil {
+		// Messages without an ID can't be told apart, so they always
+		// trigger the pipeline.
+		logger, _ := logger.GetZapLogger(ctx)
+		logger.Warn("Message can't be deduplicated",
+			zap.String("eventSourceUID", es.UID.String()),
+			zap.String("messageID", m.ID),
+			zap.Error(err),
+		)
+		return "", false, nil
+	}
+
+	claimed, err := ClaimEvent(ctx, d.redisClient, EventSourceDedupKey(es.UID), eventID, window)
+	if err != nil {
+		return "", false, err
+	}
+	if !claimed {
+		logger, _ := logger.GetZapLogger(ctx)
+		logger.Info("Dropping duplicate message",
+			zap.String("eventSourceUID", es.UID.String()),
+			zap.String("messageID", m.ID),
+			zap.String("eventID", eventID),
+		)
+	}
+
+	return eventID, !claimed, nil
+}
+
+// release forgets the ID of a message that didn't trigger the pipeline. The
+// context of the consumer might be cancelled, so a new one is used.
+func (d *Dispatcher) release(es *datamodel.EventSource, key, eventID string) {
+	if eventID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := ReleaseEvent(ctx, d.redisClient, key, eventID); err != nil {
+		logger, _ := logger.GetZapLogger(ctx)
+		logger.Warn("Couldn't release event ID", zap.String("eventSourceUID", es.UID.String()), zap.Error(err))
+	}
+}
+
+func (d *Dispatcher) deadLetter(ctx context.Context, es *datamodel.EventSource, m *Message, cause error, attempts int32) error {
+	logger, _ := logger.GetZapLogger(ctx)
+	logger.Warn("Moving message to dead-letter queue",
+		zap.String("eventSourceUID", es.UID.String()),
+		zap.String("messageID", m.ID),
+		zap.Error(cause),
+	)
+
+	b, err := json.Marshal(DeadLetter{
+		MessageID: m.ID,
+		Payload:   string(m.Payload),
+		Error:     cause.Error(),
+		Attempts:  attempts,
+		Time:      time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshalling dead letter: %w", err)
+	}
+
+	key := DeadLetterKey(es.UID)
+	pipe := d.redisClient.TxPipeline()
+	pipe.LPush(ctx, key, b)
+	pipe.LTrim(ctx, key, 0, maxDeadLetters-1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("storing dead letter: %w", err)
+	}
+
+	return nil
+}
+
+// sleep waits for the given duration. It returns false if the context is
+// cancelled in the meantime.
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
@@ -0,0 +1,115 @@
+package eventsource
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	qt "github.com/frankban/quicktest"
+	"github.com/gofrs/uuid"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+func TestDispatcherProcess(t *testing.T) {
+	c := qt.New(t)
+
+	mr := miniredis.RunT(t)
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	c.Cleanup(func() { rc.Close() })
+
+	es := &datamodel.EventSource{
+		BaseDynamic:   datamodel.BaseDynamic{UID: uuid.Must(uuid.NewV4())},
+		MaxAttempts:   3,
+		Deduplication: []byte(`{"field": "$.id", "window": "1h"}`),
+	}
+	m := &Message{ID: "msg-1", Payload: []byte(`{"id": "evt-1", "text": "hello"}`)}
+
+	c.Run("interrupted retry", func(c *qt.C) {
+		var mu sync.Mutex
+		var triggered []string
+		fail := true
+		d := NewDispatcher(nil, rc, func(_ context.Context, _ *datamodel.EventSource, variables *structpb.Struct) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if fail {
+				return errors.New("pipeline unavailable")
+			}
+			triggered = append(triggered, variables.GetFields()["text"].GetStringValue())
+			return nil
+		}, 0)
+
+		// The dispatcher stops while it waits to retry the first attempt.
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		err := d.process(ctx, es, m, nil)
+		c.Check(err, qt.ErrorIs, context.Canceled)
+
+		// The redelivered message isn't taken for a duplicate.
+		mu.Lock()
+		fail = false
+		mu.Unlock()
+		c.Assert(d.process(context.Background(), es, m, nil), qt.IsNil)
+		c.Check(triggered, qt.DeepEquals, []string{"hello"})
+
+		// The message is a duplicate once it triggered the pipeline.
+		c.Assert(d.process(context.Background(), es, m, nil), qt.IsNil)
+		c.Check(triggered, qt.HasLen, 1)
+	})
+	c.Run("polled object", func(c *qt.C) {
+		es := &datamodel.EventSource{
+			BaseDynamic: datamodel.BaseDynamic{UID: uuid.Must(uuid.NewV4())},
+			MaxAttempts: 3,
+		}
+		m := &Message{ID: "report.pdf", Payload: []byte(`{"key": "report.pdf"}`), claimID: "report.pdf@2024-05-06T00:00:00Z"}
+
+		var mu sync.Mutex
+		triggered := map[string]int{}
+		fail := false
+		newReplica := func(name string) *Dispatcher {
+			return NewDispatcher(nil, rc, func(_ context.Context, _ *datamodel.EventSource, _ *structpb.Struct) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if fail {
+					return errors.New("pipeline unavailable")
+				}
+				triggered[name]++
+				return nil
+			}, 0)
+		}
+		first, second := newReplica("first"), newReplica("second")
+
+		// The replica that claims the object first triggers the pipeline.
+		c.Assert(first.process(context.Background(), es, m, nil), qt.IsNil)
+		c.Assert(second.process(context.Background(), es, m, nil), qt.IsNil)
+		c.Check(triggered, qt.DeepEquals, map[string]int{"first": 1})
+
+		// An object overwritten later is claimed again.
+		overwritten := *m
+		overwritten.claimID = "report.pdf@2024-05-07T00:00:00Z"
+		c.Assert(second.process(context.Background(), es, &overwritten, nil), qt.IsNil)
+		c.Check(triggered, qt.DeepEquals, map[string]int{"first": 1, "second": 1})
+
+		// The claim of an interrupted replica is released, so the object
+		// is handled by another one.
+		other := *m
+		other.claimID = "other.pdf@2024-05-06T00:00:00Z"
+		mu.Lock()
+		fail = true
+		mu.Unlock()
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		c.Check(first.process(ctx, es, &other, nil), qt.ErrorIs, context.Canceled)
+
+		mu.Lock()
+		fail = false
+		mu.Unlock()
+		c.Assert(second.process(context.Background(), es, &other, nil), qt.IsNil)
+		c.Check(triggered, qt.DeepEquals, map[string]int{"first": 1, "second": 2})
+	})
+}
@@ -0,0 +1,184 @@
+// Package eventsource consumes the messages of external brokers (Kafka, SQS
+// and Pub/Sub) and the objects dropped in buckets (S3, GCS and MinIO), and
+// triggers pipelines with them.
+package eventsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/gofrs/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// Message is a message received from an event source.
+type Message struct {
+	ID      string
+	Payload []byte
+
+	// ackID identifies the message in the broker when it is acknowledged.
+	ackID string
+	// partition and offset locate Kafka records.
+	partition int32
+	offset    int64
+	// modTime is the last modification time of a polled object.
+	modTime time.Time
+	// claimID identifies a polled object. The consumers of every replica
+	// list the bucket, so the object is claimed before it triggers the
+	// pipeline.
+	claimID string
+}
+
+// Source consumes messages from a broker. Messages are delivered at least
+// once: a message that isn't acknowledged is delivered again.
+type Source interface {
+	// Receive waits for the next batch of messages. The batch might be empty
+	// if no messages arrived during the poll interval of the broker.
+	Receive(ctx context.Context) ([]*Message, error)
+	// Ack acknowledges the messages so they aren't delivered again.
+	Ack(ctx context.Context, msgs []*Message) error
+	// Checkpoint returns the position of the source after the last
+	// acknowledged message.
+	Checkpoint() map[string]any
+	// Close releases the resources held by the source.
+	Close(ctx context.Context) error
+}
+
+// sensitiveSetupFields are the setup fields that are hidden when an event
+// source is returned to the client.
+var sensitiveSetupFields = []string{
+	"secret-access-key",
+	"credentials-json",
+	"password",
+}
+
+const redactedValue = "*****"
+
+// New returns a source that consumes messages according to the event source
+// setup. The consumer ID identifies the process consuming the messages.
+func New(ctx context.Context, es *datamodel.EventSource, consumerID uuid.UUID) (Source, error) {
+	checkpoint := map[string]any{}
+	if len(es.Checkpoint) > 0 {
+		if err := json.Unmarshal(es.Checkpoint, &checkpoint); err != nil {
+			return nil, fmt.Errorf("unmarshalling checkpoint: %w", err)
+		}
+	}
+
+	if es.Type == datamodel.EventSourceTypeObjectStorage {
+		setup := objectStorageSetup{}
+		if err := json.Unmarshal(es.Setup, &setup); err != nil {
+			return nil, fmt.Errorf("unmarshalling setup: %w", err)
+		}
+		return newObjectStorageSource(ctx, setup, checkpoint, es.CreateTime, consumerID)
+	}
+
+	return newSource(ctx, es.Type, es.Setup, checkpoint, consumerID)
+}
+
+// newSource returns a source that consumes messages from a broker.
+func newSource(ctx context.Context, t datamodel.EventSourceType, setupJSON []byte, checkpoint map[string]any, consumerID uuid.UUID) (Source, error) {
+	switch t {
+	case datamodel.EventSourceTypeSQS:
+		setup := sqsSetup{}
+		if err := json.Unmarshal(setupJSON, &setup); err != nil {
+			return nil, fmt.Errorf("unmarshalling setup: %w", err)
+		}
+		return newSQSSource(setup)
+	case datamodel.EventSourceTypePubSub:
+		setup := pubSubSetup{}
+		if err := json.Unmarshal(setupJSON, &setup); err != nil {
+			return nil, fmt.Errorf("unmarshalling setup: %w", err)
+		}
+		return newPubSubSource(ctx, setup)
+	case datamodel.EventSourceTypeKafka:
+		setup := kafkaSetup{}
+		if err := json.Unmarshal(setupJSON, &setup); err != nil {
+			return nil, fmt.Errorf("unmarshalling setup: %w", err)
+		}
+		return newKafkaSource(ctx, setup, checkpoint, consumerID)
+	}
+
+	return nil, fmt.Errorf("unsupported event source type %q", t)
+}
+
+// ValidateSetup checks that the setup contains the fields required by the
+// event source type.
+func ValidateSetup(t datamodel.EventSourceType, setup []byte) error {
+	var v interface{ validate() error }
+	switch t {
+	case datamodel.EventSourceTypeSQS:
+		v = &sqsSetup{}
+	case datamodel.EventSourceTypePubSub:
+		v = &pubSubSetup{}
+	case datamodel.EventSourceTypeKafka:
+		v = &kafkaSetup{}
+	case datamodel.EventSourceTypeObjectStorage:
+		v = &objectStorageSetup{}
+	default:
+		return fmt.Errorf("unsupported event source type %q", t)
+	}
+
+	if err := json.Unmarshal(setup, v); err != nil {
+		return fmt.Errorf("invalid setup: %w", err)
+	}
+	return v.validate()
+}
+
+// RedactSetup returns a copy of the setup where the credentials are hidden.
+// Nested setups (e.g. the notification queue of an object storage source) are
+// redacted too.
+func RedactSetup(setup map[string]any) map[string]any {
+	redacted := make(map[string]any, len(setup))
+	for k, v := range setup {
+		if nested, ok := v.(map[string]any); ok {
+			v = RedactSetup(nested)
+		}
+		redacted[k] = v
+	}
+	for _, f := range sensitiveSetupFields {
+		if _, ok := redacted[f]; ok {
+			redacted[f] = redactedValue
+		}
+	}
+	return redacted
+}
+
+// Variables builds the pipeline variables from a message payload. Each entry
+// in the mapping assigns the result of a JSONPath expression to a variable.
+// Payloads that aren't valid JSON are treated as a string, so they can be
+// referenced with `$`. When the mapping is empty, the payload must be a JSON
+// object and its fields are passed as variables.
+func Variables(payload []byte, mapping map[string]string) (*structpb.Struct, error) {
+	var data any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		data = string(payload)
+	}
+
+	if len(mapping) == 0 {
+		obj, ok := data.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("payload must be a JSON object when no variable mapping is defined")
+		}
+		return structpb.NewStruct(obj)
+	}
+
+	variables := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(mapping))}
+	for key, path := range mapping {
+		res, err := jsonpath.Get(path, data)
+		if err != nil {
+			return nil, fmt.Errorf("resolving variable %s: %w", key, err)
+		}
+
+		variables.Fields[key], err = structpb.NewValue(res)
+		if err != nil {
+			return nil, fmt.Errorf("converting variable %s: %w", key, err)
+		}
+	}
+
+	return variables, nil
+}
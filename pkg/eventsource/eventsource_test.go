@@ -0,0 +1,209 @@
+package eventsource
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+func TestVariables(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name    string
+		payload string
+		mapping map[string]string
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name:    "ok - mapping",
+			payload: `{"order": {"id": "o-1", "items": [{"sku": "a"}, {"sku": "b"}]}}`,
+			mapping: map[string]string{
+				"order-id":   "$.order.id",
+				"first-item": "$.order.items[0].sku",
+			},
+			want: map[string]any{"order-id": "o-1", "first-item": "a"},
+		},
+		{
+			name:    "ok - no mapping",
+			payload: `{"text": "hello", "count": 2}`,
+			want:    map[string]any{"text": "hello", "count": float64(2)},
+		},
+		{
+			name:    "ok - raw payload",
+			payload: "plain text",
+			mapping: map[string]string{"text": "$"},
+			want:    map[string]any{"text": "plain text"},
+		},
+		{
+			name:    "nok - no mapping and non-object payload",
+			payload: `["a", "b"]`,
+			wantErr: "payload must be a JSON object.*",
+		},
+		{
+			name:    "nok - missing field",
+			payload: `{"text": "hello"}`,
+			mapping: map[string]string{"text": "$.missing"},
+			wantErr: "resolving variable text.*",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			got, err := Variables([]byte(tc.payload), tc.mapping)
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+
+			c.Assert(err, qt.IsNil)
+			c.Check(got.AsMap(), qt.DeepEquals, tc.want)
+		})
+	}
+}
+
+func TestValidateSetup(t *testing.T) {
+	c := qt.New(t)
+
+	c.Check(ValidateSetup(datamodel.EventSourceTypeKafka, []byte(`{
+		"rest-proxy-url": "http://kafka-rest:8082",
+		"topic": "orders",
+		"consumer-group": "pipeline"
+	}`)), qt.IsNil)
+
+	c.Check(ValidateSetup(datamodel.EventSourceTypeSQS, []byte(`{"region": "eu-west-1"}`)),
+		qt.ErrorMatches, "queue-url is required")
+
+	c.Check(ValidateSetup("mqtt", []byte(`{}`)),
+		qt.ErrorMatches, `unsupported event source type "mqtt"`)
+}
+
+func TestRedactSetup(t *testing.T) {
+	c := qt.New(t)
+
+	setup := map[string]any{
+		"queue-url":         "https://sqs.eu-west-1.amazonaws.com/123/orders",
+		"secret-access-key": "s3cr3t",
+		"notification": map[string]any{
+			"type":  "pubsub",
+			"setup": map[string]any{"credentials-json": "{}"},
+		},
+	}
+
+	got := RedactSetup(setup)
+	c.Check(got["queue-url"], qt.Equals, setup["queue-url"])
+	c.Check(got["secret-access-key"], qt.Equals, redactedValue)
+	c.Check(got["notification"], qt.DeepEquals, map[string]any{
+		"type":  "pubsub",
+		"setup": map[string]any{"credentials-json": redactedValue},
+	})
+	// The original setup isn't modified.
+	c.Check(setup["secret-access-key"], qt.Equals, "s3cr3t")
+}
+
+func TestValidateSetup_ObjectStorage(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name    string
+		setup   string
+		wantErr string
+	}{
+		{
+			name: "ok - polling",
+			setup: `{
+				"provider": "minio",
+				"endpoint": "minio:9000",
+				"bucket": "inbox",
+				"prefix": "invoices/",
+				"access-key-id": "minioadmin",
+				"secret-access-key": "minioadmin"
+			}`,
+		},
+		{
+			name: "ok - notifications",
+			setup: `{
+				"provider": "gcs",
+				"bucket": "inbox",
+				"credentials-json": "{}",
+				"notification": {
+					"type": "pubsub",
+					"setup": {"project-id": "p", "subscription": "s", "credentials-json": "{}"}
+				}
+			}`,
+		},
+		{
+			name:    "nok - unknown provider",
+			setup:   `{"provider": "azure", "bucket": "inbox"}`,
+			wantErr: "provider must be one of s3, gcs or minio",
+		},
+		{
+			name: "nok - invalid notification",
+			setup: `{
+				"provider": "s3",
+				"bucket": "inbox",
+				"region": "eu-west-1",
+				"access-key-id": "id",
+				"secret-access-key": "secret",
+				"notification": {"type": "sqs", "setup": {"region": "eu-west-1"}}
+			}`,
+			wantErr: "invalid notification setup: queue-url is required",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			err := ValidateSetup(datamodel.EventSourceTypeObjectStorage, []byte(tc.setup))
+			if tc.wantErr != "" {
+				c.Check(err, qt.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Check(err, qt.IsNil)
+		})
+	}
+}
+
+func TestParseNotification(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("S3", func(c *qt.C) {
+		got := parseNotification([]byte(`{"Records": [
+			{
+				"eventName": "ObjectCreated:Put",
+				"eventTime": "2024-10-01T10:00:00.000Z",
+				"s3": {"bucket": {"name": "inbox"}, "object": {"key": "invoices/march+2024.pdf", "size": 1024}}
+			},
+			{
+				"eventName": "ObjectRemoved:Delete",
+				"s3": {"bucket": {"name": "inbox"}, "object": {"key": "invoices/old.pdf"}}
+			}
+		]}`))
+
+		c.Assert(got, qt.HasLen, 1)
+		c.Check(got[0].Bucket, qt.Equals, "inbox")
+		c.Check(got[0].Key, qt.Equals, "invoices/march 2024.pdf")
+		c.Check(got[0].Size, qt.Equals, int64(1024))
+	})
+
+	c.Run("GCS", func(c *qt.C) {
+		got := parseNotification([]byte(`{
+			"bucket": "inbox",
+			"name": "invoices/march.pdf",
+			"size": "2048",
+			"contentType": "application/pdf",
+			"updated": "2024-10-01T10:00:00.000Z"
+		}`))
+
+		c.Assert(got, qt.HasLen, 1)
+		c.Check(got[0].Key, qt.Equals, "invoices/march.pdf")
+		c.Check(got[0].Size, qt.Equals, int64(2048))
+		c.Check(got[0].ContentType, qt.Equals, "application/pdf")
+	})
+
+	c.Run("unknown format", func(c *qt.C) {
+		c.Check(parseNotification([]byte(`{"Event": "s3:TestEvent"}`)), qt.HasLen, 0)
+	})
+}
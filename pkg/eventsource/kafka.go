@@ -0,0 +1,234 @@
+package eventsource
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// The Kafka source consumes a topic through the Confluent REST Proxy (API
+// v2), which lets the backend join a consumer group without a native Kafka
+// client.
+// ref: https://docs.confluent.io/platform/current/kafka-rest/api.html
+const (
+	kafkaContentType       = "application/vnd.kafka.v2+json"
+	kafkaBinaryContentType = "application/vnd.kafka.binary.v2+json"
+	kafkaOffsetsKey        = "offsets"
+)
+
+type kafkaSetup struct {
+	RestProxyURL  string `json:"rest-proxy-url"`
+	Topic         string `json:"topic"`
+	ConsumerGroup string `json:"consumer-group"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+}
+
+func (s *kafkaSetup) validate() error {
+	switch {
+	case s.RestProxyURL == "":
+		return fmt.Errorf("rest-proxy-url is required")
+	case s.Topic == "":
+		return fmt.Errorf("topic is required")
+	case s.ConsumerGroup == "":
+		return fmt.Errorf("consumer-group is required")
+	}
+	return nil
+}
+
+type kafkaSource struct {
+	client  *http.Client
+	setup   kafkaSetup
+	baseURI string
+
+	// offsets holds, for each partition, the offset of the next record to
+	// consume.
+	offsets map[int32]int64
+}
+
+type kafkaRecord struct {
+	Topic     string `json:"topic"`
+	Value     string `json:"value"`
+	Partition int32  `json:"partition"`
+	Offset    int64  `json:"offset"`
+}
+
+type kafkaOffset struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Offset    int64  `json:"offset"`
+}
+
+// newKafkaSource creates a consumer instance in the consumer group and
+// subscribes it to the topic. If the checkpoint contains offsets, the
+// consumer is moved to them, so no records are lost if the offsets committed
+// in the broker expired.
+func newKafkaSource(ctx context.Context, setup kafkaSetup, checkpoint map[string]any, consumerID uuid.UUID) (*kafkaSource, error) {
+	s := &kafkaSource{
+		client:  &http.Client{},
+		setup:   setup,
+		offsets: map[int32]int64{},
+	}
+
+	instance := struct {
+		InstanceID string `json:"instance_id"`
+		BaseURI    string `json:"base_uri"`
+	}{}
+	groupURL := fmt.Sprintf("%s/consumers/%s", strings.TrimSuffix(setup.RestProxyURL, "/"), setup.ConsumerGroup)
+	err := s.do(ctx, http.MethodPost, groupURL, map[string]any{
+		"name":               consumerID.String(),
+		"format":             "binary",
+		"auto.offset.reset":  "earliest",
+		"auto.commit.enable": "false",
+	}, &instance)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kafka consumer: %w", err)
+	}
+	s.baseURI = instance.BaseURI
+
+	if err := s.do(ctx, http.MethodPost, s.baseURI+"/subscription", map[string]any{"topics": []string{setup.Topic}}, nil); err != nil {
+		_ = s.Close(ctx)
+		return nil, fmt.Errorf("subscribing to Kafka topic: %w", err)
+	}
+
+	offsets, _ := checkpoint[kafkaOffsetsKey].(map[string]any)
+	positions := make([]kafkaOffset, 0, len(offsets))
+	for p, o := range offsets {
+		partition, err := strconv.ParseInt(p, 10, 32)
+		if err != nil {
+			continue
+		}
+		offset, ok := o.(float64)
+		if !ok {
+			continue
+		}
+
+		s.offsets[int32(partition)] = int64(offset)
+		positions = append(positions, kafkaOffset{Topic: setup.Topic, Partition: int32(partition), Offset: int64(offset)})
+	}
+
+	if len(positions) > 0 {
+		// The partitions are assigned to the consumer in the first poll, so
+		// seeking might fail for partitions owned by other group members.
+		// In that case, the committed offsets are used.
+		_ = s.do(ctx, http.MethodPost, s.baseURI+"/positions", map[string]any{"offsets": positions}, nil)
+	}
+
+	return s, nil
+}
+
+func (s *kafkaSource) Receive(ctx context.Context) ([]*Message, error) {
+	var records []kafkaRecord
+	if err := s.do(ctx, http.MethodGet, s.baseURI+"/records", nil, &records); err != nil {
+		return nil, fmt.Errorf("fetching Kafka records: %w", err)
+	}
+
+	msgs := make([]*Message, 0, len(records))
+	for _, r := range records {
+		payload, err := base64.StdEncoding.DecodeString(r.Value)
+		if err != nil {
+			return nil, fmt.Errorf("decoding Kafka record: %w", err)
+		}
+
+		msgs = append(msgs, &Message{
+			ID:        fmt.Sprintf("%s-%d-%d", r.Topic, r.Partition, r.Offset),
+			Payload:   payload,
+			partition: r.Partition,
+			offset:    r.Offset,
+		})
+	}
+
+	return msgs, nil
+}
+
+// Ack commits the offsets of the fetched records. Records are fetched and
+// acknowledged in batches, so the batch is committed as a whole.
+func (s *kafkaSource) Ack(ctx context.Context, msgs []*Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	// An empty body commits all the records fetched by the consumer.
+	if err := s.do(ctx, http.MethodPost, s.baseURI+"/offsets", nil, nil); err != nil {
+		return fmt.Errorf("committing Kafka offsets: %w", err)
+	}
+
+	for _, m := range msgs {
+		if next := m.offset + 1; next > s.offsets[m.partition] {
+			s.offsets[m.partition] = next
+		}
+	}
+	return nil
+}
+
+func (s *kafkaSource) Checkpoint() map[string]any {
+	if len(s.offsets) == 0 {
+		return nil
+	}
+
+	offsets := make(map[string]any, len(s.offsets))
+	for p, o := range s.offsets {
+		offsets[strconv.Itoa(int(p))] = o
+	}
+	return map[string]any{kafkaOffsetsKey: offsets}
+}
+
+// Close deletes the consumer instance so its partitions are reassigned to
+// the rest of the group.
+func (s *kafkaSource) Close(ctx context.Context) error {
+	if s.baseURI == "" {
+		return nil
+	}
+	return s.do(ctx, http.MethodDelete, s.baseURI, nil, nil)
+}
+
+func (s *kafkaSource) do(ctx context.Context, method, url string, body, dst any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", kafkaContentType)
+	if strings.HasSuffix(url, "/records") {
+		req.Header.Set("Accept", kafkaBinaryContentType)
+	}
+	if s.setup.Username != "" {
+		req.SetBasicAuth(s.setup.Username, s.setup.Password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, respBody)
+	}
+
+	if dst == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, dst)
+}
@@ -0,0 +1,431 @@
+package eventsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// The object storage source triggers the pipeline once per object created
+// under a bucket prefix. New objects are detected either by listing the
+// prefix periodically or by consuming the bucket notifications from a queue
+// (S3 and MinIO publish them to SQS or Kafka, GCS to Pub/Sub). Every replica
+// lists the prefix, so the listed objects are claimed in Redis and only the
+// first replica to claim an object triggers the pipeline with it.
+const (
+	objectStorageProviderS3    = "s3"
+	objectStorageProviderGCS   = "gcs"
+	objectStorageProviderMinIO = "minio"
+
+	defaultObjectPollInterval = time.Minute
+	// objectStorageMaxObjects caps the number of objects in a batch, so the
+	// checkpoint advances steadily when many files are dropped at once.
+	objectStorageMaxObjects = 100
+	// The pipeline reads the object through a presigned URL, so the file
+	// isn't transferred until the pipeline uses it.
+	objectURLExpiry = time.Hour
+
+	// objectClaimWindow is the time the polled objects are remembered as
+	// claimed. It covers the listings of the replicas that lag behind.
+	objectClaimWindow = 7 * 24 * time.Hour
+
+	objectLastModifiedKey = "last-modified"
+	objectLastKeysKey     = "last-keys"
+)
+
+type objectStorageSetup struct {
+	Provider string `json:"provider"`
+	Bucket   string `json:"bucket"`
+	Prefix   string `json:"prefix"`
+
+	// S3 and MinIO. The endpoint is only used by MinIO.
+	Endpoint        string `json:"endpoint"`
+	UseSSL          bool   `json:"use-ssl"`
+	Region          string `json:"region"`
+	AccessKeyID     string `json:"access-key-id"`
+	SecretAccessKey string `json:"secret-access-key"`
+
+	// GCS.
+	CredentialsJSON string `json:"credentials-json"`
+
+	// PollInterval is the number of seconds between two listings of the
+	// prefix.
+	PollInterval int `json:"poll-interval"`
+
+	// Notification, when defined, replaces the polling with the consumption
+	// of the bucket notifications.
+	Notification *notificationSetup `json:"notification"`
+}
+
+type notificationSetup struct {
+	Type  datamodel.EventSourceType `json:"type"`
+	Setup json.RawMessage           `json:"setup"`
+}
+
+func (s *objectStorageSetup) validate() error {
+	if s.Bucket == "" {
+		return fmt.Errorf("bucket is required")
+	}
+
+	switch s.Provider {
+	case objectStorageProviderS3:
+		if s.Region == "" {
+			return fmt.Errorf("region is required")
+		}
+		if s.AccessKeyID == "" || s.SecretAccessKey == "" {
+			return fmt.Errorf("access-key-id and secret-access-key are required")
+		}
+	case objectStorageProviderMinIO:
+		if s.Endpoint == "" {
+			return fmt.Errorf("endpoint is required")
+		}
+		if s.AccessKeyID == "" || s.SecretAccessKey == "" {
+			return fmt.Errorf("access-key-id and secret-access-key are required")
+		}
+	case objectStorageProviderGCS:
+		if s.CredentialsJSON == "" {
+			return fmt.Errorf("credentials-json is required")
+		}
+	default:
+		return fmt.Errorf("provider must be one of s3, gcs or minio")
+	}
+
+	if s.PollInterval < 0 {
+		return fmt.Errorf("poll-interval must be positive")
+	}
+
+	if s.Notification != nil {
+		if s.Notification.Type == datamodel.EventSourceTypeObjectStorage {
+			return fmt.Errorf("notification type must be kafka, sqs or pubsub")
+		}
+		if err := ValidateSetup(s.Notification.Type, s.Notification.Setup); err != nil {
+			return fmt.Errorf("invalid notification setup: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// objectEvent is the payload of the messages produced by the object storage
+// source. Without a variable mapping, its fields are passed as variables.
+type objectEvent struct {
+	Bucket       string    `json:"bucket"`
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	ContentType  string    `json:"content-type,omitempty"`
+	LastModified time.Time `json:"last-modified"`
+	// File is a presigned URL to the object.
+	File string `json:"file"`
+}
+
+type objectStorageSource struct {
+	setup  objectStorageSetup
+	bucket bucket
+
+	// Polling state. Objects modified before lastModified, or at that time
+	// and with a key in lastKeys, have already been processed.
+	pollInterval time.Duration
+	polled       bool
+	lastModified time.Time
+	lastKeys     map[string]bool
+
+	// Notification state. The notifications in pending produced the last
+	// batch of messages and are acknowledged along with it.
+	notifications Source
+	pending       []*Message
+}
+
+// newObjectStorageSource returns an object storage source. When polling
+// without a checkpoint, only the objects modified after the event source
+// creation trigger the pipeline.
+func newObjectStorageSource(ctx context.Context, setup objectStorageSetup, checkpoint map[string]any, createTime time.Time, consumerID uuid.UUID) (*objectStorageSource, error) {
+	b, err := newBucket(ctx, setup)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &objectStorageSource{
+		setup:        setup,
+		bucket:       b,
+		pollInterval: defaultObjectPollInterval,
+		lastModified: createTime,
+		lastKeys:     map[string]bool{},
+	}
+	if setup.PollInterval > 0 {
+		s.pollInterval = time.Duration(setup.PollInterval) * time.Second
+	}
+
+	if setup.Notification != nil {
+		s.notifications, err = newSource(ctx, setup.Notification.Type, setup.Notification.Setup, checkpoint, consumerID)
+		if err != nil {
+			_ = b.close()
+			return nil, fmt.Errorf("creating notification source: %w", err)
+		}
+		return s, nil
+	}
+
+	if lm, ok := checkpoint[objectLastModifiedKey].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, lm); err == nil {
+			s.lastModified = t
+		}
+	}
+	if keys, ok := checkpoint[objectLastKeysKey].([]any); ok {
+		for _, k := range keys {
+			if key, ok := k.(string); ok {
+				s.lastKeys[key] = true
+			}
+		}
+	}
+
+	return s, nil
+}
+
+func (s *objectStorageSource) Receive(ctx context.Context) ([]*Message, error) {
+	if s.notifications != nil {
+		return s.receiveNotifications(ctx)
+	}
+
+	if s.polled && !sleep(ctx, s.pollInterval) {
+		return nil, ctx.Err()
+	}
+	s.polled = true
+
+	objects, err := s.bucket.list(ctx, s.setup.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing objects: %w", err)
+	}
+
+	newObjects := make([]objectInfo, 0, len(objects))
+	for _, o := range objects {
+		switch {
+		case strings.HasSuffix(o.Key, "/"):
+			// Folder placeholder.
+		case o.LastModified.Before(s.lastModified):
+		case o.LastModified.Equal(s.lastModified) && s.lastKeys[o.Key]:
+		default:
+			newObjects = append(newObjects, o)
+		}
+	}
+
+	slices.SortFunc(newObjects, func(a, b objectInfo) int {
+		if c := a.LastModified.Compare(b.LastModified); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+	if len(newObjects) > objectStorageMaxObjects {
+		newObjects = newObjects[:objectStorageMaxObjects]
+	}
+
+	msgs := make([]*Message, 0, len(newObjects))
+	for _, o := range newObjects {
+		m, err := s.message(ctx, o.Key, o)
+		if err != nil {
+			return nil, err
+		}
+		m.modTime = o.LastModified
+		// An object overwritten later is a new one.
+		m.claimID = o.Key + "@" + o.LastModified.UTC().Format(time.RFC3339Nano)
+		msgs = append(msgs, m)
+	}
+
+	return msgs, nil
+}
+
+// receiveNotifications turns the bucket notifications into one message per
+// created object. Notifications that don't refer to any created object under
+// the prefix are acknowledged right away.
+func (s *objectStorageSource) receiveNotifications(ctx context.Context) ([]*Message, error) {
+	notifications, err := s.notifications.Receive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.pending = s.pending[:0]
+	ignored := []*Message{}
+	msgs := []*Message{}
+	for _, n := range notifications {
+		objects := s.filter(parseNotification(n.Payload))
+		if len(objects) == 0 {
+			ignored = append(ignored, n)
+			continue
+		}
+
+		s.pending = append(s.pending, n)
+		for _, o := range objects {
+			m, err := s.message(ctx, fmt.Sprintf("%s:%s", n.ID, o.Key), o)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, m)
+		}
+	}
+
+	if err := s.notifications.Ack(ctx, ignored); err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+func (s *objectStorageSource) filter(objects []objectInfo) []objectInfo {
+	filtered := make([]objectInfo, 0, len(objects))
+	for _, o := range objects {
+		if o.Bucket != s.setup.Bucket || !strings.HasPrefix(o.Key, s.setup.Prefix) || strings.HasSuffix(o.Key, "/") {
+			continue
+		}
+		filtered = append(filtered, o)
+	}
+	return filtered
+}
+
+func (s *objectStorageSource) message(ctx context.Context, id string, o objectInfo) (*Message, error) {
+	fileURL, err := s.bucket.presign(ctx, o.Key, objectURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("presigning object URL: %w", err)
+	}
+
+	payload, err := json.Marshal(objectEvent{
+		Bucket:       s.setup.Bucket,
+		Key:          o.Key,
+		Size:         o.Size,
+		ContentType:  o.ContentType,
+		LastModified: o.LastModified,
+		File:         fileURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling object event: %w", err)
+	}
+
+	return &Message{ID: id, Payload: payload, ackID: o.Key}, nil
+}
+
+func (s *objectStorageSource) Ack(ctx context.Context, msgs []*Message) error {
+	if s.notifications != nil {
+		// The dispatcher acknowledges the whole batch returned by Receive.
+		err := s.notifications.Ack(ctx, s.pending)
+		s.pending = s.pending[:0]
+		return err
+	}
+
+	for _, m := range msgs {
+		switch {
+		case m.modTime.After(s.lastModified):
+			s.lastModified = m.modTime
+			s.lastKeys = map[string]bool{m.ackID: true}
+		case m.modTime.Equal(s.lastModified):
+			s.lastKeys[m.ackID] = true
+		}
+	}
+	return nil
+}
+
+func (s *objectStorageSource) Checkpoint() map[string]any {
+	if s.notifications != nil {
+		return s.notifications.Checkpoint()
+	}
+
+	keys := make([]string, 0, len(s.lastKeys))
+	for k := range s.lastKeys {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return map[string]any{
+		objectLastModifiedKey: s.lastModified.Format(time.RFC3339Nano),
+		objectLastKeysKey:     keys,
+	}
+}
+
+func (s *objectStorageSource) Close(ctx context.Context) error {
+	var err error
+	if s.notifications != nil {
+		err = s.notifications.Close(ctx)
+	}
+	if closeErr := s.bucket.close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// s3Notification is the event format of S3 and MinIO.
+// ref: https://docs.aws.amazon.com/AmazonS3/latest/userguide/notification-content-structure.html
+type s3Notification struct {
+	Records []struct {
+		EventName string    `json:"eventName"`
+		EventTime time.Time `json:"eventTime"`
+		S3        struct {
+			Bucket struct {
+				Name string `json:"name"`
+			} `json:"bucket"`
+			Object struct {
+				Key         string `json:"key"`
+				Size        int64  `json:"size"`
+				ContentType string `json:"contentType"`
+			} `json:"object"`
+		} `json:"s3"`
+	} `json:"Records"`
+}
+
+// gcsNotification is the object resource sent by GCS in the Pub/Sub
+// notifications. The subscription should only receive OBJECT_FINALIZE events.
+// ref: https://cloud.google.com/storage/docs/pubsub-notifications
+type gcsNotification struct {
+	Bucket      string    `json:"bucket"`
+	Name        string    `json:"name"`
+	Size        string    `json:"size"`
+	ContentType string    `json:"contentType"`
+	Updated     time.Time `json:"updated"`
+}
+
+// parseNotification extracts the created objects from a bucket notification.
+// Unknown formats produce no objects.
+func parseNotification(payload []byte) []objectInfo {
+	s3n := s3Notification{}
+	if err := json.Unmarshal(payload, &s3n); err == nil && len(s3n.Records) > 0 {
+		objects := make([]objectInfo, 0, len(s3n.Records))
+		for _, r := range s3n.Records {
+			// S3 uses `ObjectCreated:Put` and MinIO `s3:ObjectCreated:Put`.
+			if !strings.Contains(r.EventName, "ObjectCreated") {
+				continue
+			}
+
+			// Object keys are URL-encoded in the notifications.
+			key, err := url.QueryUnescape(r.S3.Object.Key)
+			if err != nil {
+				continue
+			}
+			objects = append(objects, objectInfo{
+				Bucket:       r.S3.Bucket.Name,
+				Key:          key,
+				Size:         r.S3.Object.Size,
+				ContentType:  r.S3.Object.ContentType,
+				LastModified: r.EventTime,
+			})
+		}
+		return objects
+	}
+
+	gcsn := gcsNotification{}
+	if err := json.Unmarshal(payload, &gcsn); err == nil && gcsn.Bucket != "" && gcsn.Name != "" {
+		size, _ := strconv.ParseInt(gcsn.Size, 10, 64)
+		return []objectInfo{{
+			Bucket:       gcsn.Bucket,
+			Key:          gcsn.Name,
+			Size:         size,
+			ContentType:  gcsn.ContentType,
+			LastModified: gcsn.Updated,
+		}}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package eventsource
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// The Pub/Sub source uses the REST API to pull messages from a subscription.
+// ref: https://cloud.google.com/pubsub/docs/reference/rest
+const (
+	pubSubBaseURL     = "https://pubsub.googleapis.com/v1"
+	pubSubScope       = "https://www.googleapis.com/auth/pubsub"
+	pubSubMaxMessages = 10
+)
+
+type pubSubSetup struct {
+	ProjectID       string `json:"project-id"`
+	Subscription    string `json:"subscription"`
+	CredentialsJSON string `json:"credentials-json"`
+}
+
+func (s *pubSubSetup) validate() error {
+	switch {
+	case s.ProjectID == "":
+		return fmt.Errorf("project-id is required")
+	case s.Subscription == "":
+		return fmt.Errorf("subscription is required")
+	case s.CredentialsJSON == "":
+		return fmt.Errorf("credentials-json is required")
+	}
+	return nil
+}
+
+type pubSubSource struct {
+	client       *http.Client
+	subscription string
+
+	lastMessageID string
+	lastAckTime   time.Time
+}
+
+type pubSubPullResponse struct {
+	ReceivedMessages []struct {
+		AckID   string `json:"ackId"`
+		Message struct {
+			MessageID string `json:"messageId"`
+			Data      string `json:"data"`
+		} `json:"message"`
+	} `json:"receivedMessages"`
+}
+
+func newPubSubSource(ctx context.Context, setup pubSubSetup) (*pubSubSource, error) {
+	creds, err := google.CredentialsFromJSON(ctx, []byte(setup.CredentialsJSON), pubSubScope)
+	if err != nil {
+		return nil, fmt.Errorf("reading Google credentials: %w", err)
+	}
+
+	return &pubSubSource{
+		// The token source outlives the context of the request that creates
+		// the source.
+		client:       oauth2.NewClient(context.Background(), creds.TokenSource),
+		subscription: fmt.Sprintf("projects/%s/subscriptions/%s", setup.ProjectID, setup.Subscription),
+	}, nil
+}
+
+func (s *pubSubSource) Receive(ctx context.Context) ([]*Message, error) {
+	resp := pubSubPullResponse{}
+	if err := s.post(ctx, "pull", map[string]any{"maxMessages": pubSubMaxMessages}, &resp); err != nil {
+		return nil, fmt.Errorf("pulling Pub/Sub messages: %w", err)
+	}
+
+	msgs := make([]*Message, 0, len(resp.ReceivedMessages))
+	for _, rm := range resp.ReceivedMessages {
+		payload, err := base64.StdEncoding.DecodeString(rm.Message.Data)
+		if err != nil {
+			return nil, fmt.Errorf("decoding Pub/Sub message %s: %w", rm.Message.MessageID, err)
+		}
+
+		msgs = append(msgs, &Message{
+			ID:      rm.Message.MessageID,
+			Payload: payload,
+			ackID:   rm.AckID,
+		})
+	}
+
+	return msgs, nil
+}
+
+func (s *pubSubSource) Ack(ctx context.Context, msgs []*Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	ackIDs := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		ackIDs = append(ackIDs, m.ackID)
+	}
+
+	if err := s.post(ctx, "acknowledge", map[string]any{"ackIds": ackIDs}, nil); err != nil {
+		return fmt.Errorf("acknowledging Pub/Sub messages: %w", err)
+	}
+
+	s.lastMessageID = msgs[len(msgs)-1].ID
+	s.lastAckTime = time.Now()
+	return nil
+}
+
+func (s *pubSubSource) Checkpoint() map[string]any {
+	if s.lastMessageID == "" {
+		return nil
+	}
+	return map[string]any{
+		"last-message-id": s.lastMessageID,
+		"last-ack-time":   s.lastAckTime.Format(time.RFC3339Nano),
+	}
+}
+
+func (s *pubSubSource) Close(context.Context) error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+func (s *pubSubSource) post(ctx context.Context, method string, body, dst any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s:%s", pubSubBaseURL, s.subscription, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, respBody)
+	}
+
+	if dst == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, dst)
+}
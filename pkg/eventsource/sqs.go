@@ -0,0 +1,126 @@
+package eventsource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+const (
+	sqsMaxMessages = 10
+	// Long polling reduces the number of empty responses.
+	sqsWaitTimeSeconds = 20
+)
+
+type sqsSetup struct {
+	QueueURL        string `json:"queue-url"`
+	Region          string `json:"region"`
+	AccessKeyID     string `json:"access-key-id"`
+	SecretAccessKey string `json:"secret-access-key"`
+}
+
+func (s *sqsSetup) validate() error {
+	switch {
+	case s.QueueURL == "":
+		return fmt.Errorf("queue-url is required")
+	case s.Region == "":
+		return fmt.Errorf("region is required")
+	case s.AccessKeyID == "" || s.SecretAccessKey == "":
+		return fmt.Errorf("access-key-id and secret-access-key are required")
+	}
+	return nil
+}
+
+type sqsSource struct {
+	client   *sqs.SQS
+	queueURL string
+
+	lastMessageID string
+	lastAckTime   time.Time
+}
+
+func newSQSSource(setup sqsSetup) (*sqsSource, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(setup.Region),
+		Credentials: credentials.NewStaticCredentials(setup.AccessKeyID, setup.SecretAccessKey, ""),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating AWS session: %w", err)
+	}
+
+	return &sqsSource{
+		client:   sqs.New(sess),
+		queueURL: setup.QueueURL,
+	}, nil
+}
+
+func (s *sqsSource) Receive(ctx context.Context) ([]*Message, error) {
+	out, err := s.client.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(s.queueURL),
+		MaxNumberOfMessages: aws.Int64(sqsMaxMessages),
+		WaitTimeSeconds:     aws.Int64(sqsWaitTimeSeconds),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("receiving SQS messages: %w", err)
+	}
+
+	msgs := make([]*Message, 0, len(out.Messages))
+	for _, m := range out.Messages {
+		msgs = append(msgs, &Message{
+			ID:      aws.StringValue(m.MessageId),
+			Payload: []byte(aws.StringValue(m.Body)),
+			ackID:   aws.StringValue(m.ReceiptHandle),
+		})
+	}
+
+	return msgs, nil
+}
+
+func (s *sqsSource) Ack(ctx context.Context, msgs []*Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	entries := make([]*sqs.DeleteMessageBatchRequestEntry, 0, len(msgs))
+	for i, m := range msgs {
+		entries = append(entries, &sqs.DeleteMessageBatchRequestEntry{
+			Id:            aws.String(strconv.Itoa(i)),
+			ReceiptHandle: aws.String(m.ackID),
+		})
+	}
+
+	out, err := s.client.DeleteMessageBatchWithContext(ctx, &sqs.DeleteMessageBatchInput{
+		QueueUrl: aws.String(s.queueURL),
+		Entries:  entries,
+	})
+	if err != nil {
+		return fmt.Errorf("deleting SQS messages: %w", err)
+	}
+	if len(out.Failed) > 0 {
+		return fmt.Errorf("deleting SQS messages: %d messages failed: %s", len(out.Failed), aws.StringValue(out.Failed[0].Message))
+	}
+
+	s.lastMessageID = msgs[len(msgs)-1].ID
+	s.lastAckTime = time.Now()
+	return nil
+}
+
+func (s *sqsSource) Checkpoint() map[string]any {
+	if s.lastMessageID == "" {
+		return nil
+	}
+	return map[string]any{
+		"last-message-id": s.lastMessageID,
+		"last-ack-time":   s.lastAckTime.Format(time.RFC3339Nano),
+	}
+}
+
+func (s *sqsSource) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// HandleApproveRun approves an approval component of a running pipeline.
+func HandleApproveRun(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	review, err := decodeRunReview(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.ApproveRun(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"], review); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{}, nil
+}
+
+// HandleRejectRun rejects an approval component of a running pipeline.
+func HandleRejectRun(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	review, err := decodeRunReview(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.RejectRun(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"], review); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{}, nil
+}
+
+func decodeRunReview(req *http.Request) (*service.RunReview, error) {
+	review := new(service.RunReview)
+	if err := json.NewDecoder(req.Body).Decode(review); err != nil {
+		return nil, fmt.Errorf("%w: invalid request body: %w", errdomain.ErrInvalidArgument, err)
+	}
+
+	return review, nil
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+)
+
+// HandleListRunArtifacts lists the artifacts registered in a pipeline run.
+func HandleListRunArtifacts(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	artifacts, err := srv.ListRunArtifacts(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"])
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"artifacts": artifacts}, nil
+}
+
+// HandleGetRunArtifact returns an artifact of a pipeline run, with a
+// presigned URL to download it.
+func HandleGetRunArtifact(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.GetRunArtifact(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"], pathParams["artifactUID"])
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+)
+
+// HandleListPipelineCanaries returns the canary checks of a pipeline and the
+// outcome of their last run.
+func HandleListPipelineCanaries(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	canaries, err := srv.ListPipelineCanaries(ctx, pathParams["namespaceID"], pathParams["pipelineID"])
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"canaries": canaries}, nil
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// HandleGetConnectionConcurrency returns the concurrency limit of a
+// connection and the calls that are running with it.
+func HandleGetConnectionConcurrency(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.GetConnectionConcurrency(ctx, pathParams["namespaceID"], pathParams["connectionID"])
+}
+
+// HandleUpdateConnectionConcurrency sets the maximum number of concurrent
+// calls that the components make with a connection, across all pipelines.
+func HandleUpdateConnectionConcurrency(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	cc := new(service.ConnectionConcurrency)
+	if err := json.NewDecoder(req.Body).Decode(cc); err != nil {
+		return nil, fmt.Errorf("%w: invalid request body: %w", errdomain.ErrInvalidArgument, err)
+	}
+
+	return srv.UpdateConnectionConcurrency(ctx, pathParams["namespaceID"], pathParams["connectionID"], cc)
+}
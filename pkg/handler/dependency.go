@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+)
+
+// HandleListPipelineDependencies returns the connections, secrets and
+// prompts a pipeline recipe references.
+func HandleListPipelineDependencies(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.ListPipelineDependencies(ctx, pathParams["namespaceID"], pathParams["pipelineID"])
+}
+
+// HandleGetDependencyImpact returns the resources of a namespace that would
+// break if the resource in the kind and id query parameters was deleted or
+// rotated.
+func HandleGetDependencyImpact(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	q := req.URL.Query()
+	return srv.GetDependencyImpact(ctx, pathParams["namespaceID"], q.Get("kind"), q.Get("id"))
+}
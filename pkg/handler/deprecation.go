@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+)
+
+// HandleListDeprecatedPipelines returns the pipelines of a namespace that use
+// deprecated components.
+func HandleListDeprecatedPipelines(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.ListDeprecatedPipelines(ctx, pathParams["namespaceID"])
+}
+
+// HandleGetPipelineDeprecations returns the deprecated components of a
+// pipeline along with a preview of the migration of its recipe.
+func HandleGetPipelineDeprecations(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.GetPipelineDeprecations(ctx, pathParams["namespaceID"], pathParams["pipelineID"])
+}
+
+// HandleMigratePipelineRecipe replaces the deprecated components of a
+// pipeline recipe that can be migrated mechanically.
+func HandleMigratePipelineRecipe(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.MigratePipelineRecipe(ctx, pathParams["namespaceID"], pathParams["pipelineID"])
+}
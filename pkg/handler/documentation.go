@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+)
+
+// HandleGetPipelineDocumentation returns the README of a pipeline and the
+// annotations of its components, also rendered as Markdown.
+func HandleGetPipelineDocumentation(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.GetPipelineDocumentation(ctx, pathParams["namespaceID"], pathParams["pipelineID"])
+}
+
+// HandleGetPipelineReleaseDocumentation returns the documentation of a
+// pipeline release.
+func HandleGetPipelineReleaseDocumentation(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.GetPipelineReleaseDocumentation(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["releaseID"])
+}
+
+// HandleExportPipeline returns a bundle with the recipe of a pipeline and
+// its documentation.
+func HandleExportPipeline(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.ExportPipeline(ctx, pathParams["namespaceID"], pathParams["pipelineID"])
+}
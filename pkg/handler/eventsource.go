@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// HandleCreateNamespaceEventSource creates an event source that triggers a
+// pipeline with the messages of a Kafka topic, an SQS queue or a Pub/Sub
+// subscription, or with the files dropped in an S3, GCS or MinIO bucket.
+func HandleCreateNamespaceEventSource(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	es := new(service.EventSource)
+	if err := json.NewDecoder(req.Body).Decode(es); err != nil {
+		return nil, fmt.Errorf("%w: invalid request body: %w", errdomain.ErrInvalidArgument, err)
+	}
+
+	return srv.CreateNamespaceEventSource(ctx, pathParams["namespaceID"], es)
+}
+
+// HandleListNamespaceEventSources lists the event sources of a namespace.
+func HandleListNamespaceEventSources(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	eventSources, err := srv.ListNamespaceEventSources(ctx, pathParams["namespaceID"])
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"eventSources": eventSources}, nil
+}
+
+// HandleGetNamespaceEventSource returns an event source.
+func HandleGetNamespaceEventSource(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.GetNamespaceEventSource(ctx, pathParams["namespaceID"], pathParams["eventSourceID"])
+}
+
+// HandleDeleteNamespaceEventSource deletes an event source. The dispatcher
+// stops consuming its messages in the next synchronization.
+func HandleDeleteNamespaceEventSource(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	if err := srv.DeleteNamespaceEventSource(ctx, pathParams["namespaceID"], pathParams["eventSourceID"]); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{}, nil
+}
+
+// HandleListEventSourceDeadLetters lists the messages of an event source that
+// exhausted their trigger attempts.
+func HandleListEventSourceDeadLetters(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	deadLetters, err := srv.ListEventSourceDeadLetters(ctx, pathParams["namespaceID"], pathParams["eventSourceID"])
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"deadLetters": deadLetters}, nil
+}
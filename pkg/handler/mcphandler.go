@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/x/errmsg"
+
+	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+// The MCP (Model Context Protocol) server exposes the published pipelines of
+// a namespace as tools. The messages follow the JSON-RPC 2.0 specification
+// and are sent through HTTP POST requests.
+// ref: https://spec.modelcontextprotocol.io
+const (
+	mcpProtocolVersion = "2024-11-05"
+	mcpServerName      = "pipeline-backend"
+
+	mcpMethodInitialize = "initialize"
+	mcpMethodPing       = "ping"
+	mcpMethodToolsList  = "tools/list"
+	mcpMethodToolsCall  = "tools/call"
+
+	jsonRPCVersion        = "2.0"
+	jsonRPCParseError     = -32700
+	jsonRPCInvalidRequest = -32600
+	jsonRPCMethodNotFound = -32601
+	jsonRPCInvalidParams  = -32602
+	jsonRPCInternalError  = -32603
+	mcpToolsListPageSize  = int32(100)
+)
+
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
+
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+type mcpToolsListParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type mcpToolsCallParams struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type mcpToolsCallResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError"`
+}
+
+// HandleMCP serves the MCP endpoint of a namespace.
+func HandleMCP(mux *runtime.ServeMux, client pb.PipelinePublicServiceClient, w http.ResponseWriter, req *http.Request, pathParams map[string]string, _ memory.MemoryStore) {
+
+	ctx := req.Context()
+	_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+
+	annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/vdp.pipeline.v1beta.PipelinePublicService/ListNamespacePipelines", runtime.WithHTTPPathPattern("/v1beta/{name=namespaces/*}/mcp"))
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+		return
+	}
+
+	var rpcReq jsonRPCRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxToolArgumentsSize)).Decode(&rpcReq); err != nil {
+		writeJSON(w, jsonRPCResponse{
+			JSONRPC: jsonRPCVersion,
+			ID:      json.RawMessage("null"),
+			Error:   &jsonRPCError{Code: jsonRPCParseError, Message: err.Error()},
+		})
+		return
+	}
+
+	// Notifications don't expect a response.
+	if len(rpcReq.ID) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	resp := jsonRPCResponse{
+		JSONRPC: jsonRPCVersion,
+		ID:      rpcReq.ID,
+	}
+	resp.Result, resp.Error = handleMCPRequest(annotatedContext, client, pathParams["namespaceID"], &rpcReq)
+
+	writeJSON(w, resp)
+}
+
+func handleMCPRequest(ctx context.Context, client pb.PipelinePublicServiceClient, namespaceID string, rpcReq *jsonRPCRequest) (any, *jsonRPCError) {
+	if rpcReq.JSONRPC != jsonRPCVersion {
+		return nil, &jsonRPCError{Code: jsonRPCInvalidRequest, Message: "unsupported JSON-RPC version"}
+	}
+
+	switch rpcReq.Method {
+	case mcpMethodInitialize:
+		return map[string]any{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities": map[string]any{
+				"tools": map[string]any{"listChanged": false},
+			},
+			"serverInfo": map[string]any{
+				"name":    mcpServerName,
+				"version": "v1beta",
+			},
+		}, nil
+
+	case mcpMethodPing:
+		return map[string]any{}, nil
+
+	case mcpMethodToolsList:
+		params := mcpToolsListParams{}
+		if len(rpcReq.Params) > 0 {
+			if err := json.Unmarshal(rpcReq.Params, &params); err != nil {
+				return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: err.Error()}
+			}
+		}
+		return listMCPTools(ctx, client, namespaceID, params.Cursor)
+
+	case mcpMethodToolsCall:
+		params := mcpToolsCallParams{}
+		if err := json.Unmarshal(rpcReq.Params, &params); err != nil {
+			return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: err.Error()}
+		}
+		return callMCPTool(ctx, client, namespaceID, &params)
+	}
+
+	return nil, &jsonRPCError{Code: jsonRPCMethodNotFound, Message: fmt.Sprintf("method %s not found", rpcReq.Method)}
+}
+
+// listMCPTools lists the pipelines in the namespace that have, at least, one
+// release. The input schema of each tool is taken from the latest release.
+func listMCPTools(ctx context.Context, client pb.PipelinePublicServiceClient, namespaceID, cursor string) (any, *jsonRPCError) {
+	view := pb.Pipeline_VIEW_FULL
+	pageSize := mcpToolsListPageSize
+	resp, err := client.ListNamespacePipelines(ctx, &pb.ListNamespacePipelinesRequest{
+		NamespaceId: namespaceID,
+		PageSize:    &pageSize,
+		PageToken:   &cursor,
+		View:        &view,
+	})
+	if err != nil {
+		return nil, &jsonRPCError{Code: jsonRPCInternalError, Message: errmsg.MessageOrErr(err)}
+	}
+
+	tools := make([]mcpTool, 0, len(resp.GetPipelines()))
+	for _, p := range resp.GetPipelines() {
+		if len(p.GetReleases()) == 0 {
+			continue
+		}
+
+		latest := p.GetReleases()[0]
+		description := p.GetDescription()
+		if description == "" {
+			description = fmt.Sprintf("Triggers the %s pipeline (release %s).", p.GetId(), latest.GetId())
+		}
+
+		tools = append(tools, mcpTool{
+			Name:        toolName(p.GetId()),
+			Description: description,
+			InputSchema: toolInputSchema(latest.GetDataSpecification()),
+		})
+	}
+
+	result := map[string]any{"tools": tools}
+	if resp.GetNextPageToken() != "" {
+		result["nextCursor"] = resp.GetNextPageToken()
+	}
+	return result, nil
+}
+
+// callMCPTool triggers the latest release of the pipeline identified by the
+// tool name. Execution errors are reported in the tool result so the model
+// can react to them.
+func callMCPTool(ctx context.Context, client pb.PipelinePublicServiceClient, namespaceID string, params *mcpToolsCallParams) (any, *jsonRPCError) {
+	if params.Name == "" {
+		return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: "tool name is required"}
+	}
+
+	args, err := structpb.NewStruct(params.Arguments)
+	if err != nil {
+		return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: err.Error()}
+	}
+
+	view := pb.Pipeline_VIEW_FULL
+	pipelineResp, err := client.GetNamespacePipeline(ctx, &pb.GetNamespacePipelineRequest{
+		NamespaceId: namespaceID,
+		PipelineId:  params.Name,
+		View:        &view,
+	})
+	if err != nil {
+		return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: errmsg.MessageOrErr(err)}
+	}
+	if len(pipelineResp.GetPipeline().GetReleases()) == 0 {
+		return nil, &jsonRPCError{Code: jsonRPCInvalidParams, Message: fmt.Sprintf("pipeline %s has no releases", params.Name)}
+	}
+
+	triggerResp, err := client.TriggerNamespacePipelineRelease(ctx, &pb.TriggerNamespacePipelineReleaseRequest{
+		NamespaceId: namespaceID,
+		PipelineId:  params.Name,
+		ReleaseId:   pipelineResp.GetPipeline().GetReleases()[0].GetId(),
+		Data:        []*pb.TriggerData{{Variable: args}},
+	})
+	if err != nil {
+		return mcpToolsCallResult{
+			Content: []mcpContent{{Type: "text", Text: errmsg.MessageOrErr(err)}},
+			IsError: true,
+		}, nil
+	}
+
+	output := &structpb.Struct{}
+	if len(triggerResp.GetOutputs()) > 0 {
+		output = triggerResp.GetOutputs()[0]
+	}
+
+	b, err := protojson.Marshal(output)
+	if err != nil {
+		return nil, &jsonRPCError{Code: jsonRPCInternalError, Message: err.Error()}
+	}
+
+	return mcpToolsCallResult{
+		Content: []mcpContent{{Type: "text", Text: string(b)}},
+	}, nil
+}
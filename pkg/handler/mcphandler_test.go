@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/frankban/quicktest"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+// mcpClient serves the pipelines of a namespace and records the triggers of
+// their releases.
+type mcpClient struct {
+	*triggerClient
+	pipelines []*pb.Pipeline
+}
+
+func (mc *mcpClient) ListNamespacePipelines(_ context.Context, _ *pb.ListNamespacePipelinesRequest, _ ...grpc.CallOption) (*pb.ListNamespacePipelinesResponse, error) {
+	return &pb.ListNamespacePipelinesResponse{Pipelines: mc.pipelines}, nil
+}
+
+func (mc *mcpClient) GetNamespacePipeline(_ context.Context, req *pb.GetNamespacePipelineRequest, _ ...grpc.CallOption) (*pb.GetNamespacePipelineResponse, error) {
+	for _, p := range mc.pipelines {
+		if p.GetId() == req.GetPipelineId() {
+			return &pb.GetNamespacePipelineResponse{Pipeline: p}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "pipeline %s not found", req.GetPipelineId())
+}
+
+type mcpResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *jsonRPCError   `json:"error"`
+}
+
+func TestHandleMCP(t *testing.T) {
+	c := quicktest.New(t)
+
+	input, err := structpb.NewStruct(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{"type": "string", "instillFormat": "string"},
+		},
+	})
+	c.Assert(err, quicktest.IsNil)
+
+	newClient := func() *mcpClient {
+		return &mcpClient{
+			triggerClient: &triggerClient{},
+			pipelines: []*pb.Pipeline{
+				{
+					Id:          "search",
+					Description: "Searches the documents.",
+					Releases: []*pb.PipelineRelease{
+						{Id: "v2.0.0", DataSpecification: &pb.DataSpecification{Input: input}},
+						{Id: "v1.0.0"},
+					},
+				},
+				{Id: "draft"},
+			},
+		}
+	}
+	post := func(client pb.PipelinePublicServiceClient, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1beta/namespaces/acme/mcp", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		HandleMCP(runtime.NewServeMux(), client, rec, req, map[string]string{"namespaceID": "acme"}, nil)
+		return rec
+	}
+	call := func(c *quicktest.C, client pb.PipelinePublicServiceClient, body string) mcpResponse {
+		rec := post(client, body)
+		c.Assert(rec.Code, quicktest.Equals, http.StatusOK)
+
+		var resp mcpResponse
+		c.Assert(json.Unmarshal(rec.Body.Bytes(), &resp), quicktest.IsNil)
+		c.Check(resp.JSONRPC, quicktest.Equals, jsonRPCVersion)
+		return resp
+	}
+
+	c.Run("ok - initialize", func(c *quicktest.C) {
+		resp := call(c, newClient(), `{"jsonrpc": "2.0", "id": 1, "method": "initialize"}`)
+		c.Check(string(resp.ID), quicktest.Equals, "1")
+		c.Assert(resp.Error, quicktest.IsNil)
+		c.Check([]byte(resp.Result), quicktest.JSONEquals, map[string]any{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{"listChanged": false}},
+			"serverInfo":      map[string]any{"name": mcpServerName, "version": "v1beta"},
+		})
+	})
+
+	c.Run("ok - notification", func(c *quicktest.C) {
+		rec := post(newClient(), `{"jsonrpc": "2.0", "method": "notifications/initialized"}`)
+		c.Check(rec.Code, quicktest.Equals, http.StatusAccepted)
+		c.Check(rec.Body.Len(), quicktest.Equals, 0)
+	})
+
+	c.Run("ok - tools/list", func(c *quicktest.C) {
+		resp := call(c, newClient(), `{"jsonrpc": "2.0", "id": "list", "method": "tools/list"}`)
+		c.Check(string(resp.ID), quicktest.Equals, `"list"`)
+		c.Assert(resp.Error, quicktest.IsNil)
+
+		// The pipelines without releases aren't listed.
+		c.Check([]byte(resp.Result), quicktest.JSONEquals, map[string]any{
+			"tools": []any{
+				map[string]any{
+					"name":        "search",
+					"description": "Searches the documents.",
+					"inputSchema": map[string]any{
+						"type":       "object",
+						"properties": map[string]any{"query": map[string]any{"type": "string"}},
+					},
+				},
+			},
+		})
+	})
+
+	c.Run("ok - tools/call", func(c *quicktest.C) {
+		client := newClient()
+		resp := call(c, client, `{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "search", "arguments": {"query": "hello"}}}`)
+		c.Assert(resp.Error, quicktest.IsNil)
+
+		var result mcpToolsCallResult
+		c.Assert(json.Unmarshal(resp.Result, &result), quicktest.IsNil)
+		c.Check(result.IsError, quicktest.IsFalse)
+		c.Assert(result.Content, quicktest.HasLen, 1)
+		c.Check(result.Content[0].Type, quicktest.Equals, "text")
+		c.Check([]byte(result.Content[0].Text), quicktest.JSONEquals, map[string]any{"query": "hello"})
+
+		// The latest release is triggered.
+		c.Assert(client.requests, quicktest.HasLen, 1)
+		req := client.requests[0]
+		c.Check(req.GetNamespaceId(), quicktest.Equals, "acme")
+		c.Check(req.GetPipelineId(), quicktest.Equals, "search")
+		c.Check(req.GetReleaseId(), quicktest.Equals, "v2.0.0")
+	})
+
+	testcases := []struct {
+		name     string
+		body     string
+		wantID   string
+		wantCode int
+		wantMsg  string
+	}{
+		{
+			name:     "nok - pipeline without releases",
+			body:     `{"jsonrpc": "2.0", "id": 3, "method": "tools/call", "params": {"name": "draft"}}`,
+			wantID:   "3",
+			wantCode: jsonRPCInvalidParams,
+			wantMsg:  "pipeline draft has no releases",
+		},
+		{
+			name:     "nok - unknown pipeline",
+			body:     `{"jsonrpc": "2.0", "id": 3, "method": "tools/call", "params": {"name": "missing"}}`,
+			wantID:   "3",
+			wantCode: jsonRPCInvalidParams,
+			wantMsg:  ".*pipeline missing not found",
+		},
+		{
+			name:     "nok - tool without name",
+			body:     `{"jsonrpc": "2.0", "id": 3, "method": "tools/call", "params": {}}`,
+			wantID:   "3",
+			wantCode: jsonRPCInvalidParams,
+			wantMsg:  "tool name is required",
+		},
+		{
+			name:     "nok - unknown method",
+			body:     `{"jsonrpc": "2.0", "id": 4, "method": "resources/list"}`,
+			wantID:   "4",
+			wantCode: jsonRPCMethodNotFound,
+			wantMsg:  "method resources/list not found",
+		},
+		{
+			name:     "nok - unsupported version",
+			body:     `{"jsonrpc": "1.0", "id": 5, "method": "initialize"}`,
+			wantID:   "5",
+			wantCode: jsonRPCInvalidRequest,
+			wantMsg:  "unsupported JSON-RPC version",
+		},
+		{
+			name:     "nok - malformed request",
+			body:     `{"jsonrpc": "2.0", "id": 6, "method": `,
+			wantID:   "null",
+			wantCode: jsonRPCParseError,
+			wantMsg:  ".+",
+		},
+		{
+			name:     "nok - malformed params",
+			body:     `{"jsonrpc": "2.0", "id": 7, "method": "tools/list", "params": []}`,
+			wantID:   "7",
+			wantCode: jsonRPCInvalidParams,
+			wantMsg:  ".+",
+		},
+	}
+
+	c.Run("nok - too large", func(c *quicktest.C) {
+		maxSize := maxToolArgumentsSize
+		maxToolArgumentsSize = 16
+		c.Cleanup(func() { maxToolArgumentsSize = maxSize })
+
+		resp := call(c, newClient(), `{"jsonrpc": "2.0", "id": 1, "method": "initialize"}`)
+		c.Assert(resp.Error, quicktest.IsNotNil)
+		c.Check(resp.Error.Code, quicktest.Equals, jsonRPCParseError)
+		c.Check(resp.Error.Message, quicktest.Matches, ".*request body too large")
+	})
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			client := newClient()
+			resp := call(c, client, tc.body)
+			c.Check(string(resp.ID), quicktest.Equals, tc.wantID)
+			c.Check(len(resp.Result), quicktest.Equals, 0)
+			c.Assert(resp.Error, quicktest.IsNotNil)
+			c.Check(resp.Error.Code, quicktest.Equals, tc.wantCode)
+			c.Check(resp.Error.Message, quicktest.Matches, tc.wantMsg)
+
+			// The pipeline isn't triggered.
+			c.Check(client.requests, quicktest.HasLen, 0)
+		})
+	}
+}
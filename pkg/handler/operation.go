@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// HandleWaitForTriggerResult long-polls the operation of an async trigger
+// until it completes or the timeout query parameter (e.g. 30s or a number of
+// seconds) elapses.
+func HandleWaitForTriggerResult(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	var timeout time.Duration
+	if v := req.URL.Query().Get("timeout"); v != "" {
+		d, err := data.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid timeout: %w", errdomain.ErrInvalidArgument, err)
+		}
+		timeout = d.Raw
+	}
+
+	return srv.WaitForTriggerResult(ctx, pathParams["operationID"], timeout)
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.temporal.io/sdk/converter"
+)
+
+// HandlePayloadCodec serves the Temporal codec server protocol, through
+// which the Temporal UI and CLI encode and decode the payloads of the
+// workflow histories. The operation (encode or decode) is the last segment
+// of the path.
+// ref: https://docs.temporal.io/production-deployment/data-encryption
+func HandlePayloadCodec(codecs []converter.PayloadCodec, allowedOrigin string) runtime.HandlerFunc {
+	codecHandler := converter.NewPayloadCodecHTTPHandler(codecs...)
+
+	return func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		// The Temporal UI calls the codec server from the browser.
+		if allowedOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Namespace, Authorization")
+		}
+		if req.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		switch pathParams["operation"] {
+		case "encode", "decode":
+			codecHandler.ServeHTTP(w, req)
+		default:
+			http.NotFound(w, req)
+		}
+	}
+}
@@ -1944,6 +1944,7 @@ func (h *PublicHandler) ListPipelineRuns(ctx context.Context, req *pb.ListPipeli
 	declarations, err := filtering.NewDeclarations([]filtering.DeclarationOption{
 		filtering.DeclareStandardFunctions(),
 		filtering.DeclareIdent("pipelineTriggerUID", filtering.TypeString),
+		filtering.DeclareIdent("retriedFromUID", filtering.TypeString),
 		filtering.DeclareIdent("status", filtering.TypeString),
 		filtering.DeclareIdent("source", filtering.TypeString),
 		filtering.DeclareIdent("startTime", filtering.TypeTimestamp),
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+)
+
+// HandleRetryPipelineTrigger triggers a pipeline asynchronously with the
+// inputs and recipe of a previous run. Like the async trigger endpoints, it
+// returns the long-running operation of the new run.
+func HandleRetryPipelineTrigger(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.RetryPipelineTrigger(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"])
+}
+
+// HandleGetPipelineRunState returns the live state of a pipeline run, read
+// from the workflow that orchestrates it.
+func HandleGetPipelineRunState(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.GetPipelineRunState(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"])
+}
+
+// HandleGetPipelineRunMemory returns the memory of a pipeline run, with the
+// secrets redacted, to debug how the references of its recipe resolved.
+func HandleGetPipelineRunMemory(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.GetPipelineRunMemory(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"])
+}
+
+// HandleGetTriggerMemorySnapshot returns the values a path resolves to in
+// the memory of a pipeline run, which can still be running. The path is
+// read from the `path` query parameter.
+func HandleGetTriggerMemorySnapshot(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	return srv.GetTriggerMemorySnapshot(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"], req.URL.Query().Get("path"))
+}
+
+// HandleExportPipelineRunMemory archives the memory of a finished pipeline
+// run and returns the artifact that holds it.
+func HandleExportPipelineRunMemory(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.ExportPipelineRunMemory(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"])
+}
+
+// HandleComparePipelineRuns diffs a pipeline run against another run of the
+// same pipeline.
+func HandleComparePipelineRuns(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.CompareRuns(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"], pathParams["otherPipelineRunID"])
+}
+
+// HandleGetPipelineRunLineage returns the lineage graph of a pipeline run.
+// With the `format=openlineage` query parameter, the graph is returned as an
+// OpenLineage run event.
+func HandleGetPipelineRunLineage(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	if req.URL.Query().Get("format") == "openlineage" {
+		return srv.GetPipelineRunOpenLineage(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"])
+	}
+	return srv.GetPipelineRunLineage(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pathParams["pipelineRunID"])
+}
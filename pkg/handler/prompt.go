@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// HandleCreateNamespacePrompt creates a new version of a prompt in the
+// registry of a namespace.
+func HandleCreateNamespacePrompt(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	p := new(service.Prompt)
+	if err := json.NewDecoder(req.Body).Decode(p); err != nil {
+		return nil, fmt.Errorf("%w: invalid request body: %w", errdomain.ErrInvalidArgument, err)
+	}
+
+	return srv.CreateNamespacePrompt(ctx, pathParams["namespaceID"], p)
+}
+
+// HandleListNamespacePrompts lists the latest version of the prompts of a
+// namespace.
+func HandleListNamespacePrompts(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	prompts, err := srv.ListNamespacePrompts(ctx, pathParams["namespaceID"])
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"prompts": prompts}, nil
+}
+
+// HandleGetNamespacePrompt returns a version of a prompt, or its latest
+// version when the path doesn't specify one.
+func HandleGetNamespacePrompt(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	var version int32
+	if v, ok := pathParams["version"]; ok {
+		parsed, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("%w: invalid prompt version %q", errdomain.ErrInvalidArgument, v)
+		}
+		version = int32(parsed)
+	}
+
+	return srv.GetNamespacePrompt(ctx, pathParams["namespaceID"], pathParams["promptID"], version)
+}
+
+// HandleListNamespacePromptVersions lists the versions of a prompt.
+func HandleListNamespacePromptVersions(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	prompts, err := srv.ListNamespacePromptVersions(ctx, pathParams["namespaceID"], pathParams["promptID"])
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"prompts": prompts}, nil
+}
+
+// HandleDeleteNamespacePrompt deletes a prompt and all its versions.
+func HandleDeleteNamespacePrompt(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	if err := srv.DeleteNamespacePrompt(ctx, pathParams["namespaceID"], pathParams["promptID"]); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{}, nil
+}
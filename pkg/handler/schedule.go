@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// HandlePreviewSchedule validates a cron expression and returns its next run
+// times. The number of run times is set with the count query parameter.
+func HandlePreviewSchedule(ctx context.Context, srv service.Service, req *http.Request, _ map[string]string) (any, error) {
+	preview := new(service.SchedulePreview)
+	if err := json.NewDecoder(req.Body).Decode(preview); err != nil {
+		return nil, fmt.Errorf("%w: invalid request body: %w", errdomain.ErrInvalidArgument, err)
+	}
+
+	count, err := intQueryParam(req, "count")
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.PreviewSchedule(ctx, preview, count)
+}
+
+// HandleGetPipelineScheduleHistory returns the schedules of a pipeline and the
+// runs they have triggered.
+func HandleGetPipelineScheduleHistory(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	pageSize, err := intQueryParam(req, "pageSize")
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.GetPipelineScheduleHistory(ctx, pathParams["namespaceID"], pathParams["pipelineID"], pageSize)
+}
+
+func intQueryParam(req *http.Request, key string) (int, error) {
+	v := req.URL.Query().Get(key)
+	if v == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid %s: %w", errdomain.ErrInvalidArgument, key, err)
+	}
+	return i, nil
+}
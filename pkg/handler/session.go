@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+	"github.com/instill-ai/pipeline-backend/pkg/session"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// HandleAppendSessionMessages appends messages to the history of a
+// conversation session.
+func HandleAppendSessionMessages(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	body := struct {
+		Messages []session.Message `json:"messages"`
+	}{}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: invalid request body: %w", errdomain.ErrInvalidArgument, err)
+	}
+
+	if err := srv.AppendSessionMessages(ctx, pathParams["namespaceID"], pathParams["sessionID"], body.Messages); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{}, nil
+}
+
+// HandleListSessionMessages returns the history of a conversation session.
+// The `limit` query parameter returns only the last messages.
+func HandleListSessionMessages(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	var limit int
+	if v := req.URL.Query().Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("%w: invalid limit %q", errdomain.ErrInvalidArgument, v)
+		}
+		limit = parsed
+	}
+
+	messages, err := srv.ListSessionMessages(ctx, pathParams["namespaceID"], pathParams["sessionID"], limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"messages": messages}, nil
+}
+
+// HandleDeleteSession deletes the history of a conversation session.
+func HandleDeleteSession(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	if err := srv.DeleteSession(ctx, pathParams["namespaceID"], pathParams["sessionID"]); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{}, nil
+}
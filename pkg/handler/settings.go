@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/service"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// HandleGetNamespaceSettings returns the defaults that the recipes of a
+// namespace inherit.
+func HandleGetNamespaceSettings(ctx context.Context, srv service.Service, _ *http.Request, pathParams map[string]string) (any, error) {
+	return srv.GetNamespaceSettings(ctx, pathParams["namespaceID"])
+}
+
+// HandleUpdateNamespaceSettings replaces the defaults that the recipes of a
+// namespace inherit.
+func HandleUpdateNamespaceSettings(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error) {
+	settings := new(service.NamespaceSettings)
+	if err := json.NewDecoder(req.Body).Decode(settings); err != nil {
+		return nil, fmt.Errorf("%w: invalid request body: %w", errdomain.ErrInvalidArgument, err)
+	}
+
+	return srv.UpdateNamespaceSettings(ctx, pathParams["namespaceID"], settings)
+}
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/constant"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+
+	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+// ToolFormat defines the flavour of the function definition that LLM agent
+// frameworks expect.
+type ToolFormat string
+
+const (
+	// ToolFormatOpenAI produces a tool in the OpenAI function calling format.
+	ToolFormatOpenAI ToolFormat = "openai"
+	// ToolFormatAnthropic produces a tool in the Anthropic tool use format.
+	ToolFormatAnthropic ToolFormat = "anthropic"
+)
+
+// Tool names are restricted by the LLM vendors to this character set and
+// length.
+var toolNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+const maxToolNameLength = 64
+
+// maxToolArgumentsSize limits the body of a tool call, as the arguments are
+// sent to the pipeline in a single message.
+var maxToolArgumentsSize int64 = constant.MaxPayloadSize
+
+// HandleGetPipelineReleaseTool returns a tool (function) definition generated
+// from the data specification of a pipeline release, so it can be used by LLM
+// agents.
+func HandleGetPipelineReleaseTool(mux *runtime.ServeMux, client pb.PipelinePublicServiceClient, w http.ResponseWriter, req *http.Request, pathParams map[string]string, _ memory.MemoryStore) {
+
+	ctx := req.Context()
+	_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+
+	annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/vdp.pipeline.v1beta.PipelinePublicService/GetNamespacePipelineRelease", runtime.WithHTTPPathPattern("/v1beta/{name=namespaces/*/pipelines/*/releases/*}/tool"))
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+		return
+	}
+
+	format := ToolFormat(req.URL.Query().Get("format"))
+	if format == "" {
+		format = ToolFormatOpenAI
+	}
+
+	view := pb.Pipeline_VIEW_FULL
+	resp, err := client.GetNamespacePipelineRelease(annotatedContext, &pb.GetNamespacePipelineReleaseRequest{
+		NamespaceId: pathParams["namespaceID"],
+		PipelineId:  pathParams["pipelineID"],
+		ReleaseId:   pathParams["releaseID"],
+		View:        &view,
+	})
+	if err != nil {
+		runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+		return
+	}
+
+	tool, err := generateTool(pathParams["pipelineID"], resp.GetRelease(), format)
+	if err != nil {
+		runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, status.Errorf(codes.InvalidArgument, "%v", err))
+		return
+	}
+
+	writeJSON(w, tool)
+}
+
+// HandleCallPipelineReleaseTool is the execution shim for the tools generated
+// by HandleGetPipelineReleaseTool. The request body contains the arguments of
+// the tool call, which are passed to the pipeline as variables. The response
+// contains the pipeline output.
+func HandleCallPipelineReleaseTool(mux *runtime.ServeMux, client pb.PipelinePublicServiceClient, w http.ResponseWriter, req *http.Request, pathParams map[string]string, _ memory.MemoryStore) {
+
+	ctx := req.Context()
+	_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+
+	annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/vdp.pipeline.v1beta.PipelinePublicService/TriggerNamespacePipelineRelease", runtime.WithHTTPPathPattern("/v1beta/{name=namespaces/*/pipelines/*/releases/*}/tool/call"))
+	if err != nil {
+		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+		return
+	}
+
+	args, err := parseToolArguments(http.MaxBytesReader(w, req.Body, maxToolArgumentsSize))
+	if err != nil {
+		runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, status.Errorf(codes.InvalidArgument, "%v", err))
+		return
+	}
+
+	resp, err := client.TriggerNamespacePipelineRelease(annotatedContext, &pb.TriggerNamespacePipelineReleaseRequest{
+		NamespaceId: pathParams["namespaceID"],
+		PipelineId:  pathParams["pipelineID"],
+		ReleaseId:   pathParams["releaseID"],
+		Data:        []*pb.TriggerData{{Variable: args}},
+	})
+	if err != nil {
+		runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+		return
+	}
+
+	output := &structpb.Struct{}
+	if len(resp.GetOutputs()) > 0 {
+		output = resp.GetOutputs()[0]
+	}
+
+	b, err := protojson.Marshal(output)
+	if err != nil {
+		runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(b)
+}
+
+// parseToolArguments reads the arguments of a tool call. Some vendors send
+// the arguments as a JSON-encoded string, so both an object and a string
+// containing an object are accepted.
+func parseToolArguments(body io.Reader) (*structpb.Struct, error) {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	args := &structpb.Struct{}
+	if len(strings.TrimSpace(string(b))) == 0 {
+		return args, nil
+	}
+
+	var encoded string
+	if err := json.Unmarshal(b, &encoded); err == nil {
+		b = []byte(encoded)
+	}
+
+	if err := protojson.Unmarshal(b, args); err != nil {
+		return nil, fmt.Errorf("tool arguments must be a JSON object: %w", err)
+	}
+	return args, nil
+}
+
+func generateTool(pipelineID string, release *pb.PipelineRelease, format ToolFormat) (map[string]any, error) {
+	name := toolName(fmt.Sprintf("%s_%s", pipelineID, release.GetId()))
+
+	description := release.GetDescription()
+	if description == "" {
+		description = fmt.Sprintf("Triggers the %s pipeline (release %s).", pipelineID, release.GetId())
+	}
+
+	parameters := toolInputSchema(release.GetDataSpecification())
+
+	switch format {
+	case ToolFormatOpenAI:
+		return map[string]any{
+			"type": "function",
+			"function": map[string]any{
+				"name":        name,
+				"description": description,
+				"parameters":  parameters,
+			},
+		}, nil
+	case ToolFormatAnthropic:
+		return map[string]any{
+			"name":         name,
+			"description":  description,
+			"input_schema": parameters,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported tool format %q", format)
+}
+
+func toolName(id string) string {
+	name := toolNameRegexp.ReplaceAllString(id, "_")
+	if len(name) > maxToolNameLength {
+		name = name[:maxToolNameLength]
+	}
+	return name
+}
+
+// toolInputSchema builds the JSON schema of the tool arguments from the
+// variables of a pipeline.
+func toolInputSchema(spec *pb.DataSpecification) map[string]any {
+	if input := spec.GetInput(); input != nil {
+		return toolSchema(input.AsMap()).(map[string]any)
+	}
+	return map[string]any{
+		"type":       "object",
+		"properties": map[string]any{},
+	}
+}
+
+// toolSchema removes the Instill-specific keywords (e.g. instillFormat,
+// instillUIOrder) from a JSON schema, as they aren't understood by the LLM
+// vendors.
+func toolSchema(schema any) any {
+	switch s := schema.(type) {
+	case map[string]any:
+		cleaned := make(map[string]any, len(s))
+		for k, v := range s {
+			if strings.HasPrefix(k, "instill") {
+				continue
+			}
+			// The keys under `properties` are variable IDs, not keywords.
+			if props, ok := v.(map[string]any); ok && k == "properties" {
+				cleanedProps := make(map[string]any, len(props))
+				for id, prop := range props {
+					cleanedProps[id] = toolSchema(prop)
+				}
+				cleaned[k] = cleanedProps
+				continue
+			}
+			cleaned[k] = toolSchema(v)
+		}
+		return cleaned
+	case []any:
+		cleaned := make([]any, len(s))
+		for i, v := range s {
+			cleaned[i] = toolSchema(v)
+		}
+		return cleaned
+	}
+	return schema
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/frankban/quicktest"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+func TestGenerateTool(t *testing.T) {
+	c := quicktest.New(t)
+
+	input, err := structpb.NewStruct(map[string]any{
+		"type":                    "object",
+		"required":                []any{"query", "options"},
+		"instillEditOnNodeFields": []any{"query"},
+		"properties": map[string]any{
+			"query": map[string]any{
+				"type":           "string",
+				"title":          "Query",
+				"description":    "The question to answer.",
+				"instillFormat":  "string",
+				"instillUIOrder": 0,
+			},
+			"options": map[string]any{
+				"type":           "object",
+				"required":       []any{"top-k"},
+				"instillUIOrder": 1,
+				"properties": map[string]any{
+					"top-k": map[string]any{
+						"type":          "integer",
+						"description":   "The number of results.",
+						"instillFormat": "integer",
+					},
+					// Variable IDs aren't keywords, so they're kept.
+					"instillTag": map[string]any{
+						"type":          "string",
+						"instillFormat": "string",
+					},
+				},
+			},
+			"tags": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":          "string",
+					"instillFormat": "string",
+				},
+			},
+		},
+	})
+	c.Assert(err, quicktest.IsNil)
+
+	release := &pb.PipelineRelease{
+		Id:                "v1.0.0",
+		DataSpecification: &pb.DataSpecification{Input: input},
+	}
+
+	testcases := []struct {
+		format ToolFormat
+		golden string
+	}{
+		{format: ToolFormatOpenAI, golden: "testdata/tool_openai.json"},
+		{format: ToolFormatAnthropic, golden: "testdata/tool_anthropic.json"},
+	}
+
+	for _, tc := range testcases {
+		c.Run(string(tc.format), func(c *quicktest.C) {
+			tool, err := generateTool("my-pipeline", release, tc.format)
+			c.Assert(err, quicktest.IsNil)
+
+			want, err := os.ReadFile(tc.golden)
+			c.Assert(err, quicktest.IsNil)
+			c.Check(want, quicktest.JSONEquals, tool)
+		})
+	}
+
+	c.Run("no variables", func(c *quicktest.C) {
+		tool, err := generateTool("my-pipeline", &pb.PipelineRelease{Id: "v1.0.0", Description: "Answers questions."}, ToolFormatAnthropic)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(tool["description"], quicktest.Equals, "Answers questions.")
+		c.Check(tool["input_schema"], quicktest.DeepEquals, map[string]any{
+			"type":       "object",
+			"properties": map[string]any{},
+		})
+	})
+
+	c.Run("long name", func(c *quicktest.C) {
+		tool, err := generateTool(strings.Repeat("p", 70), release, ToolFormatAnthropic)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(tool["name"], quicktest.Equals, strings.Repeat("p", maxToolNameLength))
+	})
+
+	c.Run("nok - unsupported format", func(c *quicktest.C) {
+		_, err := generateTool("my-pipeline", release, "gemini")
+		c.Check(err, quicktest.ErrorMatches, `unsupported tool format "gemini"`)
+	})
+}
+
+func TestParseToolArguments(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		name    string
+		body    string
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			name: "object",
+			body: `{"query": "hello", "options": {"top-k": 3}}`,
+			want: map[string]any{"query": "hello", "options": map[string]any{"top-k": float64(3)}},
+		},
+		{
+			name: "encoded object",
+			body: `"{\"query\": \"hello\"}"`,
+			want: map[string]any{"query": "hello"},
+		},
+		{
+			name: "empty body",
+			body: " \n",
+			want: map[string]any{},
+		},
+		{
+			name:    "nok - array",
+			body:    `["hello"]`,
+			wantErr: "tool arguments must be a JSON object: .*",
+		},
+		{
+			name:    "nok - encoded array",
+			body:    `"[1, 2]"`,
+			wantErr: "tool arguments must be a JSON object: .*",
+		},
+		{
+			name:    "nok - plain string",
+			body:    `"hello"`,
+			wantErr: "tool arguments must be a JSON object: .*",
+		},
+		{
+			name:    "nok - malformed",
+			body:    `{"query": `,
+			wantErr: "tool arguments must be a JSON object: .*",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			args, err := parseToolArguments(strings.NewReader(tc.body))
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(args.AsMap(), quicktest.DeepEquals, tc.want)
+		})
+	}
+}
+
+// triggerClient records the triggers of a pipeline release and replies with
+// the variables as output.
+type triggerClient struct {
+	pb.PipelinePublicServiceClient
+	requests []*pb.TriggerNamespacePipelineReleaseRequest
+}
+
+func (tc *triggerClient) TriggerNamespacePipelineRelease(_ context.Context, req *pb.TriggerNamespacePipelineReleaseRequest, _ ...grpc.CallOption) (*pb.TriggerNamespacePipelineReleaseResponse, error) {
+	tc.requests = append(tc.requests, req)
+	return &pb.TriggerNamespacePipelineReleaseResponse{
+		Outputs: []*structpb.Struct{req.GetData()[0].GetVariable()},
+	}, nil
+}
+
+func TestHandleCallPipelineReleaseTool(t *testing.T) {
+	c := quicktest.New(t)
+
+	pathParams := map[string]string{
+		"namespaceID": "acme",
+		"pipelineID":  "my-pipeline",
+		"releaseID":   "v1.0.0",
+	}
+	call := func(client pb.PipelinePublicServiceClient, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1beta/namespaces/acme/pipelines/my-pipeline/releases/v1.0.0/tool/call", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		HandleCallPipelineReleaseTool(runtime.NewServeMux(), client, rec, req, pathParams, nil)
+		return rec
+	}
+
+	c.Run("ok", func(c *quicktest.C) {
+		client := &triggerClient{}
+		rec := call(client, `"{\"query\": \"hello\"}"`)
+		c.Check(rec.Code, quicktest.Equals, http.StatusOK)
+		c.Check(rec.Body.Bytes(), quicktest.JSONEquals, map[string]any{"query": "hello"})
+
+		c.Assert(client.requests, quicktest.HasLen, 1)
+		req := client.requests[0]
+		c.Check(req.GetNamespaceId(), quicktest.Equals, "acme")
+		c.Check(req.GetPipelineId(), quicktest.Equals, "my-pipeline")
+		c.Check(req.GetReleaseId(), quicktest.Equals, "v1.0.0")
+		c.Assert(req.GetData(), quicktest.HasLen, 1)
+		c.Check(req.GetData()[0].GetVariable().AsMap(), quicktest.DeepEquals, map[string]any{"query": "hello"})
+	})
+
+	c.Run("nok - invalid arguments", func(c *quicktest.C) {
+		client := &triggerClient{}
+		rec := call(client, `["hello"]`)
+		c.Check(rec.Code, quicktest.Equals, http.StatusBadRequest)
+		c.Check(rec.Body.String(), quicktest.Contains, "tool arguments must be a JSON object")
+
+		// The pipeline isn't triggered.
+		c.Check(client.requests, quicktest.HasLen, 0)
+	})
+
+	c.Run("nok - too large", func(c *quicktest.C) {
+		maxSize := maxToolArgumentsSize
+		maxToolArgumentsSize = 16
+		c.Cleanup(func() { maxToolArgumentsSize = maxSize })
+
+		client := &triggerClient{}
+		rec := call(client, `{"query": "hello world"}`)
+		c.Check(rec.Code, quicktest.Equals, http.StatusBadRequest)
+		c.Check(rec.Body.String(), quicktest.Contains, "request body too large")
+		c.Check(client.requests, quicktest.HasLen, 0)
+	})
+}
@@ -208,6 +208,14 @@ func HandleTrigger(mux *runtime.ServeMux, client pb.PipelinePublicServiceClient,
 
 	var sh streamingHandlerFunc
 	if req.Header.Get(constant.HeaderAccept) == "text/event-stream" {
+		// The event version is negotiated before the pipeline is triggered.
+		version, err := memory.NegotiateEventVersion(req.Header.Get(constant.HeaderEventVersionKey))
+		if err != nil {
+			_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
 		sh = func(triggerID string) (err error) {
 
 			wfm, err := ms.GetWorkflowMemory(ctx, triggerID)
@@ -219,6 +227,7 @@ func HandleTrigger(mux *runtime.ServeMux, client pb.PipelinePublicServiceClient,
 			}()
 			ch := wfm.ListenEvent(ctx)
 
+			w.Header().Set(constant.HeaderEventVersionKey, strconv.Itoa(version))
 			w.Header().Set("Content-Type", "text/event-stream")
 			w.Header().Set("Cache-Control", "no-cache")
 			w.Header().Set("Connection", "keep-alive")
@@ -236,7 +245,7 @@ func HandleTrigger(mux *runtime.ServeMux, client pb.PipelinePublicServiceClient,
 						break
 					}
 
-					b, err := json.Marshal(event.Data)
+					b, err := memory.EncodeEventData(event, version)
 					if err != nil {
 						return err
 					}
@@ -527,6 +536,14 @@ func HandleTriggerRelease(mux *runtime.ServeMux, client pb.PipelinePublicService
 	ctx := req.Context()
 	var sh streamingHandlerFunc
 	if req.Header.Get(constant.HeaderAccept) == "text/event-stream" {
+		// The event version is negotiated before the pipeline is triggered.
+		version, err := memory.NegotiateEventVersion(req.Header.Get(constant.HeaderEventVersionKey))
+		if err != nil {
+			_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
 		sh = func(triggerID string) (err error) {
 
 			wfm, err := ms.GetWorkflowMemory(ctx, triggerID)
@@ -538,6 +555,7 @@ func HandleTriggerRelease(mux *runtime.ServeMux, client pb.PipelinePublicService
 			}()
 			ch := wfm.ListenEvent(ctx)
 
+			w.Header().Set(constant.HeaderEventVersionKey, strconv.Itoa(version))
 			w.Header().Set("Content-Type", "text/event-stream")
 			w.Header().Set("Cache-Control", "no-cache")
 			w.Header().Set("Connection", "keep-alive")
@@ -554,7 +572,7 @@ func HandleTriggerRelease(mux *runtime.ServeMux, client pb.PipelinePublicService
 						break
 					}
 
-					b, err := json.Marshal(event.Data)
+					b, err := memory.EncodeEventData(event, version)
 					if err != nil {
 						return err
 					}
@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+
+	miniox "github.com/instill-ai/pipeline-backend/pkg/minio"
+)
+
+// MinIOBlobPrefix is the path under which the blobs of the workflow memory
+// are stored. The blobs aren't deleted when a workflow is purged, as the
+// memory of an iterator is copied into the memory of its parent, so the
+// deployment must expire the objects under this prefix once the run
+// retention is over.
+const MinIOBlobPrefix = "pipeline-trigger-blobs/"
+
+// blobLoadTimeout bounds the time it takes to fetch a blob. Blobs are
+// loaded lazily, when a component reads the content of a file, so there's
+// no request context to bound it.
+const blobLoadTimeout = 5 * time.Minute
+
+// BlobStore keeps the content of the large files of the workflow memory.
+type BlobStore interface {
+	PutBlob(ctx context.Context, key string, b []byte, contentType string) error
+	GetBlob(ctx context.Context, key string) ([]byte, error)
+}
+
+type minioBlobStore struct {
+	client miniox.MinioI
+}
+
+// NewMinIOBlobStore returns a blob store backed by the blob storage.
+func NewMinIOBlobStore(client miniox.MinioI) BlobStore {
+	return &minioBlobStore{client: client}
+}
+
+func (s *minioBlobStore) PutBlob(ctx context.Context, key string, b []byte, contentType string) error {
+	_, _, err := s.client.UploadFileBytes(ctx, key, b, contentType)
+	return err
+}
+
+func (s *minioBlobStore) GetBlob(ctx context.Context, key string) ([]byte, error) {
+	return s.client.GetFile(ctx, key)
+}
+
+// BlobManager moves the content of the large files (images, audio,
+// documents...) of the workflow memory to a blob store. The memory holds a
+// reference to the content instead, which is fetched again the first time
+// a component reads it. This keeps the payloads of a run out of the worker
+// memory and out of the persisted snapshots.
+type BlobManager struct {
+	store     BlobStore
+	threshold int
+}
+
+// NewBlobManager returns a blob manager that moves the files whose content
+// is larger than the threshold, in bytes, to the blob store.
+func NewBlobManager(store BlobStore, threshold int) *BlobManager {
+	return &BlobManager{store: store, threshold: threshold}
+}
+
+func blobPath(workflowID string, content []byte) string {
+	sum := sha256.Sum256(content)
+	return MinIOBlobPrefix + workflowID + "/" + hex.EncodeToString(sum[:])
+}
+
+func (m *BlobManager) load(key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), blobLoadTimeout)
+	defer cancel()
+
+	return m.store.GetBlob(ctx, key)
+}
+
+// offload replaces the large files of a value by references to the blob
+// store. The maps and arrays of the value are updated in place, as the
+// value is owned by the memory once it's stored, unless they're shared.
+func (m *BlobManager) offload(ctx context.Context, workflowID string, v data.Value) (data.Value, error) {
+	if m == nil {
+		return v, nil
+	}
+
+	switch v := v.(type) {
+	case *data.File:
+		return m.offloadFile(ctx, workflowID, v)
+	case *data.Image:
+		f, err := m.offloadFile(ctx, workflowID, &v.File)
+		if err != nil || f == &v.File {
+			return v, err
+		}
+		return &data.Image{File: *f, Width: v.Width, Height: v.Height}, nil
+	case *data.Video:
+		f, err := m.offloadFile(ctx, workflowID, &v.File)
+		if err != nil || f == &v.File {
+			return v, err
+		}
+		return &data.Video{File: *f}, nil
+	case *data.Audio:
+		f, err := m.offloadFile(ctx, workflowID, &v.File)
+		if err != nil || f == &v.File {
+			return v, err
+		}
+		return &data.Audio{File: *f}, nil
+	case *data.Document:
+		f, err := m.offloadFile(ctx, workflowID, &v.File)
+		if err != nil || f == &v.File {
+			return v, err
+		}
+		return &data.Document{File: *f}, nil
+	case *data.Map:
+		w := v
+		for k, f := range v.Fields {
+			offloaded, err := m.offload(ctx, workflowID, f)
+			if err != nil {
+				return nil, err
+			}
+			if offloaded != f {
+				if w == v {
+					w = v.Writable()
+				}
+				w.Fields[k] = offloaded
+			}
+		}
+		return w, nil
+	case *data.Array:
+		w := v
+		for i, item := range v.Values {
+			offloaded, err := m.offload(ctx, workflowID, item)
+			if err != nil {
+				return nil, err
+			}
+			if offloaded != item {
+				if w == v {
+					w = v.Writable()
+				}
+				w.Values[i] = offloaded
+			}
+		}
+		return w, nil
+	}
+	return v, nil
+}
+
+// offloadFile returns the file itself if it isn't moved to the blob store.
+// The blobs are content-addressed, so the same file produced by several
+// components is stored once.
+func (m *BlobManager) offloadFile(ctx context.Context, workflowID string, f *data.File) (*data.File, error) {
+	if f.BlobKey() != "" || len(f.Raw) <= m.threshold {
+		return f, nil
+	}
+
+	key := blobPath(workflowID, f.Raw)
+	if err := m.store.PutBlob(ctx, key, f.Raw, f.ContentType); err != nil {
+		return nil, fmt.Errorf("storing blob: %w", err)
+	}
+
+	return data.NewBlobFile(key, len(f.Raw), f.ContentType, f.FileName, f.SourceURL, m.load), nil
+}
+
+// bind sets the loader of the file references of a value that was decoded
+// from a snapshot.
+func (m *BlobManager) bind(v data.Value) {
+	switch v := v.(type) {
+	case *data.File:
+		v.SetBlobLoader(m.load)
+	case *data.Image:
+		v.SetBlobLoader(m.load)
+	case *data.Video:
+		v.SetBlobLoader(m.load)
+	case *data.Audio:
+		v.SetBlobLoader(m.load)
+	case *data.Document:
+		v.SetBlobLoader(m.load)
+	case *data.Map:
+		for _, f := range v.Fields {
+			m.bind(f)
+		}
+	case *data.Array:
+		for _, item := range v.Values {
+			m.bind(item)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+// A checkpoint holds the memory of a single component across the batch, so
+// the progress of a run can be saved after each component without rewriting
+// the whole snapshot. Checkpoints are stored next to the snapshot, under
+// their own ID, and use the Value message of the snapshot schema:
+//
+//	| "IWFC" (4 bytes) | version (1 byte) | Checkpoint message |
+//
+//	message Checkpoint {
+//	  string component_id = 1;
+//	  repeated Value data = 2; // one per batch item, null if not initialized
+//	}
+//
+// When the memory is restored, the checkpoints of the recipe components
+// replace the component memory found in the snapshot. A full commit
+// includes the memory of every component, so it deletes the checkpoints it
+// supersedes.
+
+var checkpointMagic = []byte("IWFC")
+
+const checkpointVersion = 1
+
+const (
+	checkpointFieldComponentID protowire.Number = 1
+	checkpointFieldData        protowire.Number = 2
+)
+
+const checkpointInfix = "/components/"
+
+func checkpointID(workflowID, componentID string) string {
+	return workflowID + checkpointInfix + componentID
+}
+
+// CommitComponentMemory saves the memory of a component in the persistence
+// backend, if any. It's meant to be called when a component completes, as
+// it only writes the slice of the memory that the component changed.
+func (ms *memoryStore) CommitComponentMemory(ctx context.Context, workflowID, componentID string) (err error) {
+	if ms.persistence == nil {
+		return nil
+	}
+
+	v, ok := ms.workflows.Load(workflowID)
+	if !ok {
+		return fmt.Errorf("workflow memory not found")
+	}
+
+	wfm := v.(*workflowMemory)
+	ctx = wfm.tenantContext(ctx)
+	if err := wfm.loadPartitions(ctx, componentID); err != nil {
+		return err
+	}
+
+	wfm.mu.Lock()
+	b, err := wfm.marshalCheckpoint(componentID)
+	ttl := wfm.ttl()
+	if err == nil {
+		wfm.addCheckpoint(componentID)
+	}
+	wfm.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ms.persistence.Save(ctx, checkpointID(workflowID, componentID), b, ttl)
+}
+
+// loadCheckpoints applies the persisted checkpoints to a workflow memory
+// that was loaded from a snapshot.
+func (ms *memoryStore) loadCheckpoints(ctx context.Context, wfm *workflowMemory) error {
+	if wfm.Recipe == nil {
+		return nil
+	}
+
+	for componentID := range wfm.Recipe.Component {
+		b, err := ms.persistence.Load(ctx, checkpointID(wfm.ID, componentID))
+		if err != nil {
+			if errors.Is(err, ErrSnapshotNotFound) {
+				continue
+			}
+			return fmt.Errorf("loading checkpoint of component %s: %w", componentID, err)
+		}
+
+		values, err := unmarshalCheckpoint(b)
+		if err != nil {
+			return fmt.Errorf("decoding checkpoint of component %s: %w", componentID, err)
+		}
+		if len(values) != len(wfm.Data) {
+			return fmt.Errorf("checkpoint of component %s has %d batch items, expected %d", componentID, len(values), len(wfm.Data))
+		}
+
+		for idx, v := range values {
+			batch, ok := wfm.Data[idx].(*data.Map)
+			if !ok {
+				continue
+			}
+			if _, isNull := v.(*data.Null); isNull {
+				continue
+			}
+			batch.Fields[componentID] = v
+		}
+		// The checkpoint is newer than the persisted component memory.
+		delete(wfm.pending, componentID)
+		wfm.addCheckpoint(componentID)
+	}
+
+	return nil
+}
+
+// deleteCheckpoints removes the checkpoints of a workflow memory from the
+// persistence backend. Components without a checkpoint are ignored.
+func (ms *memoryStore) deleteCheckpoints(ctx context.Context, workflowID string, componentIDs []string) error {
+	for _, componentID := range componentIDs {
+		if err := ms.persistence.Delete(ctx, checkpointID(workflowID, componentID)); err != nil {
+			return fmt.Errorf("deleting checkpoint of component %s: %w", componentID, err)
+		}
+	}
+	return nil
+}
+
+// addCheckpoint must be called with the workflow memory lock held.
+func (wfm *workflowMemory) addCheckpoint(componentID string) {
+	if wfm.checkpoints == nil {
+		wfm.checkpoints = map[string]bool{}
+	}
+	wfm.checkpoints[componentID] = true
+}
+
+// takeCheckpoints returns the components with a checkpoint and forgets
+// them. It must be called with the workflow memory lock held.
+func (wfm *workflowMemory) takeCheckpoints() []string {
+	ids := make([]string, 0, len(wfm.checkpoints))
+	for id := range wfm.checkpoints {
+		ids = append(ids, id)
+	}
+	wfm.checkpoints = nil
+	return ids
+}
+
+// marshalCheckpoint must be called with the workflow memory lock held.
+func (wfm *workflowMemory) marshalCheckpoint(componentID string) ([]byte, error) {
+	b := make([]byte, 0, 256)
+	b = append(b, checkpointMagic...)
+	b = append(b, checkpointVersion)
+
+	b = protowire.AppendTag(b, checkpointFieldComponentID, protowire.BytesType)
+	b = protowire.AppendString(b, componentID)
+	for idx, batch := range wfm.Data {
+		var comp data.Value
+		if m, ok := batch.(*data.Map); ok {
+			comp = redactValue(m.Fields[componentID], wfm.secretsOf(idx))
+		}
+
+		value, err := appendValue(nil, comp)
+		if err != nil {
+			return nil, fmt.Errorf("encoding batch item %d: %w", idx, err)
+		}
+		b = protowire.AppendTag(b, checkpointFieldData, protowire.BytesType)
+		b = protowire.AppendBytes(b, value)
+	}
+
+	return b, nil
+}
+
+func unmarshalCheckpoint(b []byte) ([]data.Value, error) {
+	if !bytes.HasPrefix(b, checkpointMagic) {
+		return nil, fmt.Errorf("invalid component checkpoint")
+	}
+
+	b = b[len(checkpointMagic):]
+	if len(b) == 0 {
+		return nil, fmt.Errorf("component checkpoint is truncated")
+	}
+	if version := b[0]; version != checkpointVersion {
+		return nil, fmt.Errorf("unsupported component checkpoint version %d", version)
+	}
+
+	values := []data.Value{}
+	err := consumeFields(b[1:], func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num == checkpointFieldData && typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			value, err := consumeValue(v)
+			if err != nil {
+				return 0, fmt.Errorf("decoding batch item %d: %w", len(values), err)
+			}
+			values = append(values, value)
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+func TestCommitComponentMemory(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	recipe := &datamodel.Recipe{
+		Component: datamodel.ComponentMap{
+			"a": {Type: "json", Task: "TASK_MARSHAL"},
+			"b": {Type: "json", Task: "TASK_MARSHAL"},
+		},
+	}
+	output := func(s string) data.Value {
+		return data.NewMap(map[string]data.Value{"string": data.NewString(s)})
+	}
+	checkOutput := func(c *quicktest.C, wfm WorkflowMemory, idx int, compID string, want data.Value) {
+		got, err := wfm.GetComponentData(ctx, idx, compID, ComponentDataOutput)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, valueEquals, want, quicktest.Commentf("%s of item %d", compID, idx))
+	}
+
+	p := newMapPersistence()
+	ms := NewMemoryStore(p, 0, nil, 0, nil, nil)
+	wfm, err := ms.NewWorkflowMemory(ctx, "workflow", recipe, 2)
+	c.Assert(err, quicktest.IsNil)
+	for idx := range 2 {
+		wfm.InitComponent(ctx, idx, "a")
+		wfm.InitComponent(ctx, idx, "b")
+	}
+	c.Assert(ms.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+
+	// Component a completes and only its memory is saved.
+	c.Assert(wfm.SetComponentData(ctx, 0, "a", ComponentDataOutput, output("output of a")), quicktest.IsNil)
+	c.Assert(wfm.SetComponentData(ctx, 1, "a", ComponentDataOutput, output("other output of a")), quicktest.IsNil)
+	c.Assert(ms.CommitComponentMemory(ctx, "workflow", "a"), quicktest.IsNil)
+	c.Check(p.keys(), quicktest.DeepEquals, []string{"workflow", "workflow/components/a"})
+
+	// The process restarts before the memory is committed, and the
+	// checkpoint is applied to the snapshot when the memory is restored.
+	restarted := NewMemoryStore(p, 0, nil, 0, nil, nil)
+	wfm, err = restarted.GetWorkflowMemory(ctx, "workflow")
+	c.Assert(err, quicktest.IsNil)
+	checkOutput(c, wfm, 0, "a", output("output of a"))
+	checkOutput(c, wfm, 1, "a", output("other output of a"))
+	checkOutput(c, wfm, 0, "b", data.NewMap(nil))
+
+	// The commit includes the memory of every component, so it deletes the
+	// checkpoint.
+	c.Assert(wfm.SetComponentData(ctx, 0, "b", ComponentDataOutput, output("output of b")), quicktest.IsNil)
+	c.Assert(restarted.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+	c.Check(p.keys(), quicktest.DeepEquals, []string{"workflow"})
+
+	wfm, err = NewMemoryStore(p, 0, nil, 0, nil, nil).GetWorkflowMemory(ctx, "workflow")
+	c.Assert(err, quicktest.IsNil)
+	checkOutput(c, wfm, 0, "a", output("output of a"))
+	checkOutput(c, wfm, 1, "a", output("other output of a"))
+	checkOutput(c, wfm, 0, "b", output("output of b"))
+
+	c.Run("nok - batch items mismatch", func(c *quicktest.C) {
+		other, err := ms.NewWorkflowMemory(ctx, "other", recipe, 3)
+		c.Assert(err, quicktest.IsNil)
+		for idx := range 3 {
+			other.InitComponent(ctx, idx, "a")
+		}
+		c.Assert(ms.CommitComponentMemory(ctx, "other", "a"), quicktest.IsNil)
+		p.snapshots["workflow/components/a"] = p.snapshots["other/components/a"]
+
+		_, err = NewMemoryStore(p, 0, nil, 0, nil, nil).GetWorkflowMemory(ctx, "workflow")
+		c.Check(err, quicktest.ErrorMatches, "checkpoint of component a has 3 batch items, expected 2")
+	})
+}
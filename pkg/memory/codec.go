@@ -0,0 +1,809 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// Snapshots are serialized with the protobuf wire format, preceded by a
+// header that holds a magic number and the version of the schema:
+//
+//	| "IWFM" (4 bytes) | version (1 byte) | Snapshot message |
+//
+// Version 1 of the schema is:
+//
+//	message Snapshot {
+//	  string id = 1;
+//	  bytes recipe = 2; // JSON, as stored in the pipeline table
+//	  repeated Value data = 3;
+//	  // Components whose memory is persisted apart from the snapshot, in
+//	  // which case data only holds the pipeline scopes. See partition.go.
+//	  repeated string partitions = 4;
+//	}
+//	message Value {
+//	  oneof kind {
+//	    bool null = 1;
+//	    bool boolean = 2;
+//	    double number = 3;
+//	    string string = 4;
+//	    bytes byte_array = 5;
+//	    File file = 6;
+//	    File image = 7;
+//	    File video = 8;
+//	    File audio = 9;
+//	    File document = 10;
+//	    Map map = 11;
+//	    Array array = 12;
+//	    DateTime datetime = 13;
+//	    sint64 duration = 14; // nanoseconds
+//	    File binary = 15; // only raw, content_type and file_name are set
+//	    string decimal = 16; // fraction, e.g. 1/3
+//	    FileRef file_ref = 17;
+//	    google.protobuf.Any proto = 18;
+//	  }
+//	}
+//	message DateTime {
+//	  int64 unix_nano = 1; // only set if it fits, for the older workers
+//	  string location = 2; // IANA name, or empty for a fixed offset
+//	  int64 offset = 3; // seconds east of UTC
+//	  int64 seconds = 4; // since the Unix epoch
+//	  int64 nanos = 5;
+//	  string zone = 6; // name of the fixed offset, e.g. CET
+//	}
+//	message File {
+//	  bytes raw = 1;
+//	  string content_type = 2;
+//	  string file_name = 3;
+//	  string source_url = 4;
+//	  int64 width = 5;
+//	  int64 height = 6;
+//	  string blob_key = 7; // set instead of raw when the content is in the blob store
+//	  int64 blob_size = 8;
+//	}
+//	message FileRef {
+//	  string uri = 1;
+//	  string content_type = 2;
+//	  string file_name = 3;
+//	  int64 size = 4;
+//	  map<string, string> metadata = 5;
+//	}
+//	message Map {
+//	  message Entry {
+//	    string key = 1;
+//	    Value value = 2;
+//	  }
+//	  repeated Entry entries = 1;
+//	}
+//	message Array {
+//	  repeated Value values = 1;
+//	}
+//
+// Fields can be added to the schema without bumping the version, as the
+// decoder skips unknown fields. Changes that alter the meaning of existing
+// fields require a new version and a decoder for it.
+//
+// Snapshots without the header were written by older versions as JSON. They
+// are still decoded, so the runs that were in flight during an upgrade can
+// resume.
+
+var snapshotMagic = []byte("IWFM")
+
+const snapshotVersion = 1
+
+// Field numbers of the snapshot schema.
+const (
+	snapshotFieldID     protowire.Number = 1
+	snapshotFieldRecipe protowire.Number = 2
+	snapshotFieldData   protowire.Number = 3
+
+	snapshotFieldPartitions protowire.Number = 4
+
+	valueFieldNull      protowire.Number = 1
+	valueFieldBoolean   protowire.Number = 2
+	valueFieldNumber    protowire.Number = 3
+	valueFieldString    protowire.Number = 4
+	valueFieldByteArray protowire.Number = 5
+	valueFieldFile      protowire.Number = 6
+	valueFieldImage     protowire.Number = 7
+	valueFieldVideo     protowire.Number = 8
+	valueFieldAudio     protowire.Number = 9
+	valueFieldDocument  protowire.Number = 10
+	valueFieldMap       protowire.Number = 11
+	valueFieldArray     protowire.Number = 12
+	valueFieldDateTime  protowire.Number = 13
+	valueFieldDuration  protowire.Number = 14
+	valueFieldBinary    protowire.Number = 15
+	valueFieldDecimal   protowire.Number = 16
+	valueFieldFileRef   protowire.Number = 17
+	valueFieldProto     protowire.Number = 18
+
+	fileFieldRaw         protowire.Number = 1
+	fileFieldContentType protowire.Number = 2
+	fileFieldFileName    protowire.Number = 3
+	fileFieldSourceURL   protowire.Number = 4
+	fileFieldWidth       protowire.Number = 5
+	fileFieldHeight      protowire.Number = 6
+	fileFieldBlobKey     protowire.Number = 7
+	fileFieldBlobSize    protowire.Number = 8
+
+	mapFieldEntries    protowire.Number = 1
+	mapEntryFieldKey   protowire.Number = 1
+	mapEntryFieldValue protowire.Number = 2
+
+	arrayFieldValues protowire.Number = 1
+
+	fileRefFieldURI         protowire.Number = 1
+	fileRefFieldContentType protowire.Number = 2
+	fileRefFieldFileName    protowire.Number = 3
+	fileRefFieldSize        protowire.Number = 4
+	fileRefFieldMetadata    protowire.Number = 5
+
+	dateTimeFieldUnixNano protowire.Number = 1
+	dateTimeFieldLocation protowire.Number = 2
+	dateTimeFieldOffset   protowire.Number = 3
+	dateTimeFieldSeconds  protowire.Number = 4
+	dateTimeFieldNanos    protowire.Number = 5
+	dateTimeFieldZone     protowire.Number = 6
+)
+
+// marshalSnapshot encodes the memory of the workflow, except for the
+// components in partitions, which are persisted apart. It must be called
+// with the workflow memory lock held.
+func (wfm *workflowMemory) marshalSnapshot(partitions []string) ([]byte, error) {
+	recipe, err := json.Marshal(wfm.Recipe)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling recipe: %w", err)
+	}
+
+	b := make([]byte, 0, 1024)
+	b = append(b, snapshotMagic...)
+	b = append(b, snapshotVersion)
+
+	b = protowire.AppendTag(b, snapshotFieldID, protowire.BytesType)
+	b = protowire.AppendString(b, wfm.ID)
+	b = protowire.AppendTag(b, snapshotFieldRecipe, protowire.BytesType)
+	b = protowire.AppendBytes(b, recipe)
+	for idx := range wfm.Data {
+		value, err := appendValue(nil, withoutFields(wfm.redactedBatch(idx), partitions))
+		if err != nil {
+			return nil, fmt.Errorf("encoding batch item %d: %w", idx, err)
+		}
+		b = protowire.AppendTag(b, snapshotFieldData, protowire.BytesType)
+		b = protowire.AppendBytes(b, value)
+	}
+	for _, id := range partitions {
+		b = protowire.AppendTag(b, snapshotFieldPartitions, protowire.BytesType)
+		b = protowire.AppendString(b, id)
+	}
+
+	return b, nil
+}
+
+func unmarshalSnapshot(b []byte) (*workflowMemory, error) {
+	if !bytes.HasPrefix(b, snapshotMagic) {
+		return unmarshalJSONSnapshot(b)
+	}
+
+	b = b[len(snapshotMagic):]
+	if len(b) == 0 {
+		return nil, fmt.Errorf("workflow memory snapshot is truncated")
+	}
+
+	version := b[0]
+	switch version {
+	case 1:
+		return unmarshalSnapshotV1(b[1:])
+	default:
+		return nil, fmt.Errorf("unsupported workflow memory snapshot version %d", version)
+	}
+}
+
+func unmarshalSnapshotV1(b []byte) (*workflowMemory, error) {
+	wfm := &workflowMemory{
+		Data:    []data.Value{},
+		channel: make(chan *Event),
+	}
+
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == snapshotFieldID && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			wfm.ID = v
+			return n, nil
+		case num == snapshotFieldRecipe && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			r := &datamodel.Recipe{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return 0, fmt.Errorf("unmarshalling recipe: %w", err)
+			}
+			wfm.Recipe = r
+			return n, nil
+		case num == snapshotFieldData && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			value, err := consumeValue(v)
+			if err != nil {
+				return 0, fmt.Errorf("decoding batch item %d: %w", len(wfm.Data), err)
+			}
+			wfm.Data = append(wfm.Data, value)
+			return n, nil
+		case num == snapshotFieldPartitions && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return n, nil
+			}
+			if wfm.pending == nil {
+				wfm.pending = map[string]bool{}
+			}
+			wfm.pending[v] = true
+			wfm.partitioned = true
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wfm, nil
+}
+
+func appendValue(b []byte, v data.Value) ([]byte, error) {
+	switch v := v.(type) {
+	case nil, *data.Null:
+		b = protowire.AppendTag(b, valueFieldNull, protowire.VarintType)
+		b = protowire.AppendVarint(b, 1)
+	case *data.Boolean:
+		b = protowire.AppendTag(b, valueFieldBoolean, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(v.Raw))
+	case *data.Number:
+		b = protowire.AppendTag(b, valueFieldNumber, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(v.Raw))
+	case *data.String:
+		b = protowire.AppendTag(b, valueFieldString, protowire.BytesType)
+		b = protowire.AppendString(b, v.Raw)
+	case *data.ByteArray:
+		b = protowire.AppendTag(b, valueFieldByteArray, protowire.BytesType)
+		b = protowire.AppendBytes(b, v.Raw)
+	case *data.Decimal:
+		// The fraction is kept instead of the decimal expansion, which
+		// might be rounded.
+		b = protowire.AppendTag(b, valueFieldDecimal, protowire.BytesType)
+		b = protowire.AppendString(b, v.GetDecimal().String())
+	case *data.DateTime:
+		b = appendDateTime(b, v)
+	case *data.Duration:
+		b = protowire.AppendTag(b, valueFieldDuration, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(v.Raw)))
+	case *data.Binary:
+		var msg []byte
+		msg = protowire.AppendTag(msg, fileFieldRaw, protowire.BytesType)
+		msg = protowire.AppendBytes(msg, v.Raw)
+		msg = protowire.AppendTag(msg, fileFieldContentType, protowire.BytesType)
+		msg = protowire.AppendString(msg, v.ContentType)
+		msg = protowire.AppendTag(msg, fileFieldFileName, protowire.BytesType)
+		msg = protowire.AppendString(msg, v.FileName)
+		b = protowire.AppendTag(b, valueFieldBinary, protowire.BytesType)
+		b = protowire.AppendBytes(b, msg)
+	case *data.FileRef:
+		b = appendFileRef(b, v)
+	case *data.Proto:
+		msg, err := proto.MarshalOptions{Deterministic: true}.Marshal(v.Raw)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, valueFieldProto, protowire.BytesType)
+		b = protowire.AppendBytes(b, msg)
+	case *data.File:
+		b = appendFile(b, valueFieldFile, v, 0, 0)
+	case *data.Image:
+		b = appendFile(b, valueFieldImage, &v.File, v.Width, v.Height)
+	case *data.Video:
+		b = appendFile(b, valueFieldVideo, &v.File, 0, 0)
+	case *data.Audio:
+		b = appendFile(b, valueFieldAudio, &v.File, 0, 0)
+	case *data.Document:
+		b = appendFile(b, valueFieldDocument, &v.File, 0, 0)
+	case *data.Map:
+		var m []byte
+		for k, f := range v.Fields {
+			value, err := appendValue(nil, f)
+			if err != nil {
+				return nil, err
+			}
+
+			var entry []byte
+			entry = protowire.AppendTag(entry, mapEntryFieldKey, protowire.BytesType)
+			entry = protowire.AppendString(entry, k)
+			entry = protowire.AppendTag(entry, mapEntryFieldValue, protowire.BytesType)
+			entry = protowire.AppendBytes(entry, value)
+
+			m = protowire.AppendTag(m, mapFieldEntries, protowire.BytesType)
+			m = protowire.AppendBytes(m, entry)
+		}
+		b = protowire.AppendTag(b, valueFieldMap, protowire.BytesType)
+		b = protowire.AppendBytes(b, m)
+	case *data.Array:
+		var arr []byte
+		for _, item := range v.Values {
+			value, err := appendValue(nil, item)
+			if err != nil {
+				return nil, err
+			}
+			arr = protowire.AppendTag(arr, arrayFieldValues, protowire.BytesType)
+			arr = protowire.AppendBytes(arr, value)
+		}
+		b = protowire.AppendTag(b, valueFieldArray, protowire.BytesType)
+		b = protowire.AppendBytes(b, arr)
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", v)
+	}
+
+	return b, nil
+}
+
+func appendFile(b []byte, num protowire.Number, f *data.File, width, height int) []byte {
+	var msg []byte
+	if key := f.BlobKey(); key != "" {
+		msg = protowire.AppendTag(msg, fileFieldBlobKey, protowire.BytesType)
+		msg = protowire.AppendString(msg, key)
+		msg = protowire.AppendTag(msg, fileFieldBlobSize, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(f.GetFileSize().GetInteger()))
+	} else {
+		msg = protowire.AppendTag(msg, fileFieldRaw, protowire.BytesType)
+		msg = protowire.AppendBytes(msg, f.Raw)
+	}
+	msg = protowire.AppendTag(msg, fileFieldContentType, protowire.BytesType)
+	msg = protowire.AppendString(msg, f.ContentType)
+	msg = protowire.AppendTag(msg, fileFieldFileName, protowire.BytesType)
+	msg = protowire.AppendString(msg, f.FileName)
+	msg = protowire.AppendTag(msg, fileFieldSourceURL, protowire.BytesType)
+	msg = protowire.AppendString(msg, f.SourceURL)
+	if width != 0 || height != 0 {
+		msg = protowire.AppendTag(msg, fileFieldWidth, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(width))
+		msg = protowire.AppendTag(msg, fileFieldHeight, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(height))
+	}
+
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+// The nanoseconds since the epoch only fit an int64 between the years 1678
+// and 2262, so the times are kept as seconds and nanoseconds.
+var (
+	minUnixNano = time.Unix(0, math.MinInt64)
+	maxUnixNano = time.Unix(0, math.MaxInt64)
+)
+
+func appendDateTime(b []byte, d *data.DateTime) []byte {
+	var msg []byte
+	if !d.Raw.Before(minUnixNano) && !d.Raw.After(maxUnixNano) {
+		msg = protowire.AppendTag(msg, dateTimeFieldUnixNano, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(d.Raw.UnixNano()))
+	}
+	msg = protowire.AppendTag(msg, dateTimeFieldSeconds, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, uint64(d.Raw.Unix()))
+	msg = protowire.AppendTag(msg, dateTimeFieldNanos, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, uint64(d.Raw.Nanosecond()))
+
+	// The fixed zones (e.g. the ones parsed from an RFC 3339 offset) can't
+	// be loaded by name, so their name and offset are kept instead. The
+	// local zone is the one of the process, which might not be the one of
+	// the process that loads the memory.
+	name, offset := d.Raw.Zone()
+	if loc := d.Raw.Location(); loc != time.Local && loc.String() != name {
+		msg = protowire.AppendTag(msg, dateTimeFieldLocation, protowire.BytesType)
+		msg = protowire.AppendString(msg, loc.String())
+	}
+	msg = protowire.AppendTag(msg, dateTimeFieldOffset, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, uint64(int64(offset)))
+	if name != "" {
+		msg = protowire.AppendTag(msg, dateTimeFieldZone, protowire.BytesType)
+		msg = protowire.AppendString(msg, name)
+	}
+
+	b = protowire.AppendTag(b, valueFieldDateTime, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func appendFileRef(b []byte, f *data.FileRef) []byte {
+	var msg []byte
+	msg = protowire.AppendTag(msg, fileRefFieldURI, protowire.BytesType)
+	msg = protowire.AppendString(msg, f.URI)
+	msg = protowire.AppendTag(msg, fileRefFieldContentType, protowire.BytesType)
+	msg = protowire.AppendString(msg, f.ContentType)
+	msg = protowire.AppendTag(msg, fileRefFieldFileName, protowire.BytesType)
+	msg = protowire.AppendString(msg, f.FileName)
+	msg = protowire.AppendTag(msg, fileRefFieldSize, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, uint64(f.Size))
+	for k, v := range f.Metadata {
+		var entry []byte
+		entry = protowire.AppendTag(entry, mapEntryFieldKey, protowire.BytesType)
+		entry = protowire.AppendString(entry, k)
+		entry = protowire.AppendTag(entry, mapEntryFieldValue, protowire.BytesType)
+		entry = protowire.AppendString(entry, v)
+
+		msg = protowire.AppendTag(msg, fileRefFieldMetadata, protowire.BytesType)
+		msg = protowire.AppendBytes(msg, entry)
+	}
+
+	b = protowire.AppendTag(b, valueFieldFileRef, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func consumeValue(b []byte) (data.Value, error) {
+	var value data.Value
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			switch num {
+			case valueFieldNull:
+				value = data.NewNull()
+			case valueFieldBoolean:
+				value = data.NewBoolean(protowire.DecodeBool(v))
+			case valueFieldDuration:
+				value = data.NewDuration(time.Duration(protowire.DecodeZigZag(v)))
+			}
+			return n, nil
+		case protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(b)
+			if num == valueFieldNumber {
+				value = data.NewNumberFromFloat(math.Float64frombits(v))
+			}
+			return n, nil
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+
+			var err error
+			switch num {
+			case valueFieldString:
+				value = data.NewString(string(v))
+			case valueFieldByteArray:
+				value = data.NewByteArray(append([]byte(nil), v...))
+			case valueFieldFile, valueFieldImage, valueFieldVideo, valueFieldAudio, valueFieldDocument:
+				value, err = consumeFile(num, v)
+			case valueFieldMap:
+				value, err = consumeMap(v)
+			case valueFieldArray:
+				value, err = consumeArray(v)
+			case valueFieldDateTime:
+				value, err = consumeDateTime(v)
+			case valueFieldBinary:
+				value, err = consumeBinary(v)
+			case valueFieldDecimal:
+				value, err = data.ParseDecimal(string(v))
+			case valueFieldFileRef:
+				value, err = consumeFileRef(v)
+			case valueFieldProto:
+				a := &anypb.Any{}
+				if err = proto.Unmarshal(v, a); err == nil {
+					value = data.NewProtoFromAny(a)
+				}
+			}
+			return n, err
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("value without a known kind")
+	}
+
+	return value, nil
+}
+
+func consumeFile(kind protowire.Number, b []byte) (data.Value, error) {
+	f := data.File{}
+	var width, height int
+	var blobKey string
+	var blobSize int
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			switch num {
+			case fileFieldRaw:
+				f.Raw = append([]byte(nil), v...)
+			case fileFieldContentType:
+				f.ContentType = string(v)
+			case fileFieldFileName:
+				f.FileName = string(v)
+			case fileFieldSourceURL:
+				f.SourceURL = string(v)
+			case fileFieldBlobKey:
+				blobKey = string(v)
+			}
+			return n, nil
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			switch num {
+			case fileFieldWidth:
+				width = int(v)
+			case fileFieldHeight:
+				height = int(v)
+			case fileFieldBlobSize:
+				blobSize = int(v)
+			}
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The conversion cache isn't persisted, it's rebuilt from the raw
+	// content. The files in the blob store get their loader once the
+	// memory is restored.
+	if blobKey != "" {
+		f = *data.NewBlobFile(blobKey, blobSize, f.ContentType, f.FileName, f.SourceURL, nil)
+	} else {
+		f.Cache = map[string][]byte{f.ContentType: f.Raw}
+	}
+
+	switch kind {
+	case valueFieldImage:
+		return &data.Image{File: f, Width: width, Height: height}, nil
+	case valueFieldVideo:
+		return &data.Video{File: f}, nil
+	case valueFieldAudio:
+		return &data.Audio{File: f}, nil
+	case valueFieldDocument:
+		return &data.Document{File: f}, nil
+	default:
+		return &f, nil
+	}
+}
+
+func consumeDateTime(b []byte) (data.Value, error) {
+	var unixNano, seconds, nanos, offset int64
+	var hasSeconds bool
+	var location, zone string
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == dateTimeFieldUnixNano && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			unixNano = int64(v)
+			return n, nil
+		case num == dateTimeFieldSeconds && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			seconds, hasSeconds = int64(v), true
+			return n, nil
+		case num == dateTimeFieldNanos && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			nanos = int64(v)
+			return n, nil
+		case num == dateTimeFieldOffset && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			offset = int64(v)
+			return n, nil
+		case num == dateTimeFieldLocation && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			location = v
+			return n, nil
+		case num == dateTimeFieldZone && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			zone = v
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The snapshots of the older workers only have the nanoseconds since
+	// the epoch.
+	t := time.Unix(0, unixNano)
+	if hasSeconds {
+		t = time.Unix(seconds, nanos)
+	}
+
+	loc := time.FixedZone(zone, int(offset))
+	if location != "" {
+		if l, err := time.LoadLocation(location); err == nil {
+			loc = l
+		}
+	}
+	return data.NewDateTime(t.In(loc)), nil
+}
+
+func consumeBinary(b []byte) (data.Value, error) {
+	bin := &data.Binary{}
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+		switch num {
+		case fileFieldRaw:
+			bin.Raw = append([]byte(nil), v...)
+		case fileFieldContentType:
+			bin.ContentType = string(v)
+		case fileFieldFileName:
+			bin.FileName = string(v)
+		}
+		return n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bin, nil
+}
+
+func consumeFileRef(b []byte) (data.Value, error) {
+	// The reference is decoded without checking its scheme, as the opener
+	// might be registered after the memory is loaded.
+	ref := &data.FileRef{Metadata: map[string]string{}}
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num == fileRefFieldSize && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(b)
+			ref.Size = int(v)
+			return n, nil
+		}
+		if typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+		switch num {
+		case fileRefFieldURI:
+			ref.URI = string(v)
+		case fileRefFieldContentType:
+			ref.ContentType = string(v)
+		case fileRefFieldFileName:
+			ref.FileName = string(v)
+		case fileRefFieldMetadata:
+			var key, value string
+			err := consumeFields(v, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+				if typ != protowire.BytesType {
+					return protowire.ConsumeFieldValue(num, typ, b), nil
+				}
+				s, n := protowire.ConsumeString(b)
+				switch num {
+				case mapEntryFieldKey:
+					key = s
+				case mapEntryFieldValue:
+					value = s
+				}
+				return n, nil
+			})
+			if err != nil {
+				return 0, err
+			}
+			ref.Metadata[key] = value
+		}
+		return n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+func consumeMap(b []byte) (data.Value, error) {
+	m := data.NewMap(nil)
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != mapFieldEntries || typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+
+		entry, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+
+		var key string
+		var value data.Value = data.NewNull()
+		err := consumeFields(entry, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+			if typ != protowire.BytesType {
+				return protowire.ConsumeFieldValue(num, typ, b), nil
+			}
+
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+
+			var err error
+			switch num {
+			case mapEntryFieldKey:
+				key = string(v)
+			case mapEntryFieldValue:
+				value, err = consumeValue(v)
+			}
+			return n, err
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		m.Fields[key] = value
+		return n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func consumeArray(b []byte) (data.Value, error) {
+	arr := data.NewArray(nil)
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if num != arrayFieldValues || typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+
+		value, err := consumeValue(v)
+		if err != nil {
+			return 0, err
+		}
+		arr.Values = append(arr.Values, value)
+		return n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return arr, nil
+}
+
+// consumeFields iterates over the fields of a message. The field function
+// consumes the value of a field and returns its length, or a negative length
+// if the value is malformed.
+func consumeFields(b []byte, field func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		n, err := field(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+
+	return nil
+}
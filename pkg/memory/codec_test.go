@@ -0,0 +1,315 @@
+package memory
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	// The zones are loaded by name regardless of the system database.
+	_ "time/tzdata"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// valueEquals compares the decoded values with the expected ones. The
+// decimals are compared by value and the messages with protocmp.
+var valueEquals = quicktest.CmpEquals(
+	cmpopts.IgnoreUnexported(data.File{}, data.Map{}, data.Array{}),
+	cmp.Comparer(func(a, b *big.Rat) bool { return a.Cmp(b) == 0 }),
+	protocmp.Transform(),
+)
+
+// testFile returns a file as the codec decodes it, with the conversion cache
+// rebuilt from the raw content.
+func testFile(raw, contentType, fileName string) data.File {
+	return data.File{
+		Raw:         []byte(raw),
+		ContentType: contentType,
+		FileName:    fileName,
+		SourceURL:   "https://example.com/" + fileName,
+		Cache:       map[string][]byte{contentType: []byte(raw)},
+	}
+}
+
+func testValues(c *quicktest.C) map[string]data.Value {
+	msg, err := data.NewProto(structpb.NewStringValue("hello"))
+	c.Assert(err, quicktest.IsNil)
+
+	file := testFile("plain text", "text/plain", "a.txt")
+	return map[string]data.Value{
+		"null":       data.NewNull(),
+		"boolean":    data.NewBoolean(true),
+		"number":     data.NewNumberFromFloat(-1.5),
+		"string":     data.NewString("hello"),
+		"byte-array": data.NewByteArray([]byte{0, 1, 2}),
+		"decimal":    data.NewDecimal(big.NewRat(1, 3)),
+		"datetime":   data.NewDateTime(time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC)),
+		"duration":   data.NewDuration(-1500 * time.Millisecond),
+		"binary":     &data.Binary{Raw: []byte("raw"), ContentType: "application/octet-stream", FileName: "raw.bin"},
+		"file":       &file,
+		"image":      &data.Image{File: testFile("png", "image/png", "a.png"), Width: 3, Height: 2},
+		"video":      &data.Video{File: testFile("mp4", "video/mp4", "a.mp4")},
+		"audio":      &data.Audio{File: testFile("wav", "audio/wav", "a.wav")},
+		"document":   &data.Document{File: testFile("pdf", "application/pdf", "a.pdf")},
+		"file-ref": &data.FileRef{
+			URI:         "s3://bucket/key.pdf",
+			ContentType: "application/pdf",
+			FileName:    "key.pdf",
+			Size:        42,
+			Metadata:    map[string]string{"etag": "abc"},
+		},
+		"proto": msg,
+		"array": data.NewArray([]data.Value{data.NewString("a"), data.NewNull(), data.NewArray(nil)}),
+		"map": data.NewMap(map[string]data.Value{
+			"nested": data.NewMap(map[string]data.Value{"n": data.NewNumberFromInteger(1)}),
+			"empty":  data.NewMap(nil),
+		}),
+	}
+}
+
+func TestValueCodec(t *testing.T) {
+	c := quicktest.New(t)
+
+	for kind, v := range testValues(c) {
+		c.Run(kind, func(c *quicktest.C) {
+			b, err := appendValue(nil, v)
+			c.Assert(err, quicktest.IsNil)
+
+			got, err := consumeValue(b)
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, valueEquals, v)
+		})
+	}
+
+	c.Run("nok - unknown kind", func(c *quicktest.C) {
+		// A value with only a field of a newer schema.
+		_, err := consumeValue([]byte{0xf8, 0x01, 0x01})
+		c.Check(err, quicktest.ErrorMatches, "value without a known kind")
+	})
+}
+
+func TestDateTimeCodec(t *testing.T) {
+	c := quicktest.New(t)
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	c.Assert(err, quicktest.IsNil)
+
+	testcases := []struct {
+		name string
+		in   time.Time
+		// zone is the location the time is decoded in, if it isn't the one
+		// it's encoded in.
+		zone *time.Location
+	}{
+		{name: "first year", in: time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "last year", in: time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC)},
+		{name: "before 1678", in: time.Date(1600, 2, 29, 12, 0, 0, 1, time.UTC)},
+		{name: "after 2262", in: time.Date(2300, 6, 1, 0, 0, 0, 5, time.UTC)},
+		{name: "before the epoch", in: time.Date(1969, 12, 31, 23, 59, 59, 1, time.UTC)},
+		{name: "IANA location", in: time.Date(2024, 7, 1, 12, 0, 0, 0, paris)},
+		{name: "IANA location in the first year", in: time.Date(1, 1, 1, 0, 0, 0, 0, paris)},
+		{name: "named fixed zone", in: time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("EST", -5*3600))},
+		{name: "RFC 3339 offset", in: time.Date(9999, 1, 1, 0, 0, 0, 0, time.FixedZone("", 5*3600+1800))},
+		{
+			name: "local zone",
+			in:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local),
+			zone: time.FixedZone(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local).Zone()),
+		},
+	}
+
+	checkDateTime := func(c *quicktest.C, got data.Value, want time.Time, zone *time.Location) {
+		c.Assert(got, quicktest.FitsTypeOf, &data.DateTime{})
+		raw := got.(*data.DateTime).Raw
+		c.Check(raw.Equal(want), quicktest.IsTrue, quicktest.Commentf("got %s, want %s", raw, want))
+		if zone == nil {
+			zone = want.Location()
+		}
+		c.Check(raw.Location().String(), quicktest.Equals, zone.String())
+		gotName, gotOffset := raw.Zone()
+		wantName, wantOffset := want.Zone()
+		c.Check(gotName, quicktest.Equals, wantName)
+		c.Check(gotOffset, quicktest.Equals, wantOffset)
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			b, err := appendValue(nil, data.NewDateTime(tc.in))
+			c.Assert(err, quicktest.IsNil)
+			got, err := consumeValue(b)
+			c.Assert(err, quicktest.IsNil)
+			checkDateTime(c, got, tc.in, tc.zone)
+
+			// The time is kept through a snapshot of the memory.
+			wfm := &workflowMemory{
+				ID: "workflow",
+				Data: []data.Value{data.NewMap(map[string]data.Value{
+					"variable": data.NewMap(map[string]data.Value{"when": data.NewDateTime(tc.in)}),
+				})},
+			}
+			snapshot, err := wfm.marshalSnapshot(nil)
+			c.Assert(err, quicktest.IsNil)
+			restored, err := unmarshalSnapshot(snapshot)
+			c.Assert(err, quicktest.IsNil)
+			when, err := restored.Data[0].(*data.Map).Get("variable.when")
+			c.Assert(err, quicktest.IsNil)
+			checkDateTime(c, when, tc.in, tc.zone)
+		})
+	}
+
+	c.Run("older snapshot", func(c *quicktest.C) {
+		// The older workers only encoded the nanoseconds since the epoch.
+		want := time.Date(2024, 5, 6, 7, 8, 9, 10, time.FixedZone("", 3600))
+		var msg []byte
+		msg = protowire.AppendTag(msg, dateTimeFieldUnixNano, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(want.UnixNano()))
+		msg = protowire.AppendTag(msg, dateTimeFieldOffset, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, 3600)
+		b := protowire.AppendTag(nil, valueFieldDateTime, protowire.BytesType)
+		b = protowire.AppendBytes(b, msg)
+
+		got, err := consumeValue(b)
+		c.Assert(err, quicktest.IsNil)
+		checkDateTime(c, got, want, nil)
+	})
+}
+
+func TestProtoCodec(t *testing.T) {
+	c := quicktest.New(t)
+
+	n, err := data.NewProto(wrapperspb.Int64(1<<60 + 1))
+	c.Assert(err, quicktest.IsNil)
+	raw, err := data.NewProto(wrapperspb.Bytes([]byte{0, 0xff}))
+	c.Assert(err, quicktest.IsNil)
+	settings, err := structpb.NewStruct(map[string]any{"model": "small", "labels": []any{"cat", "dog"}})
+	c.Assert(err, quicktest.IsNil)
+	s, err := data.NewProto(settings)
+	c.Assert(err, quicktest.IsNil)
+	// The messages of the types that aren't linked in the binary are kept
+	// as they are.
+	unknown := data.NewProtoFromAny(&anypb.Any{TypeUrl: "type.googleapis.com/acme.v1.Invoice", Value: []byte{0x08, 0x96, 0x01}})
+
+	testcases := map[string]data.Value{
+		"int64":   n,
+		"bytes":   raw,
+		"struct":  s,
+		"unknown": unknown,
+		"nested": data.NewMap(map[string]data.Value{
+			"messages": data.NewArray([]data.Value{n, unknown}),
+		}),
+	}
+
+	for name, v := range testcases {
+		c.Run(name, func(c *quicktest.C) {
+			b, err := appendValue(nil, v)
+			c.Assert(err, quicktest.IsNil)
+
+			// The encoding is deterministic.
+			again, err := appendValue(nil, data.DeepCopy(v))
+			c.Assert(err, quicktest.IsNil)
+			c.Check(bytes.Equal(b, again), quicktest.IsTrue)
+
+			got, err := consumeValue(b)
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, valueEquals, v)
+		})
+	}
+
+	c.Run("int64", func(c *quicktest.C) {
+		b, err := appendValue(nil, n)
+		c.Assert(err, quicktest.IsNil)
+		got, err := consumeValue(b)
+		c.Assert(err, quicktest.IsNil)
+
+		// The 64-bit integers JSON can't hold keep their precision.
+		decoded := new(wrapperspb.Int64Value)
+		c.Assert(got.(*data.Proto).UnmarshalTo(decoded), quicktest.IsNil)
+		c.Check(decoded.GetValue(), quicktest.Equals, int64(1<<60+1))
+	})
+}
+
+func TestSnapshotCodec(t *testing.T) {
+	c := quicktest.New(t)
+
+	wfm := &workflowMemory{
+		ID: "workflow",
+		Recipe: &datamodel.Recipe{
+			Version:   "v1beta",
+			Component: datamodel.ComponentMap{"json": {Type: "json", Task: "TASK_MARSHAL"}},
+		},
+		Data: []data.Value{
+			data.NewMap(testValues(c)),
+			data.NewMap(map[string]data.Value{"variable": data.NewMap(nil)}),
+		},
+	}
+
+	b, err := wfm.marshalSnapshot(nil)
+	c.Assert(err, quicktest.IsNil)
+	c.Check(bytes.HasPrefix(b, []byte("IWFM\x01")), quicktest.IsTrue)
+
+	c.Run("round trip", func(c *quicktest.C) {
+		got, err := unmarshalSnapshot(b)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got.ID, quicktest.Equals, "workflow")
+		c.Check(got.Recipe, quicktest.DeepEquals, wfm.Recipe)
+		c.Check(got.Data, valueEquals, wfm.Data)
+		c.Check(got.partitioned, quicktest.IsFalse)
+	})
+
+	c.Run("partitions", func(c *quicktest.C) {
+		b, err := wfm.marshalSnapshot([]string{"json"})
+		c.Assert(err, quicktest.IsNil)
+
+		got, err := unmarshalSnapshot(b)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got.partitioned, quicktest.IsTrue)
+		c.Check(got.pending, quicktest.DeepEquals, map[string]bool{"json": true})
+	})
+
+	c.Run("legacy JSON", func(c *quicktest.C) {
+		legacy := &workflowMemory{
+			ID: "workflow",
+			Data: []data.Value{data.NewMap(map[string]data.Value{
+				"variable": data.NewMap(map[string]data.Value{
+					"text": data.NewString("hello"),
+					"list": data.NewArray([]data.Value{data.NewNumberFromInteger(1), data.NewBoolean(false)}),
+				}),
+			})},
+		}
+		b, err := legacy.marshalJSONSnapshot()
+		c.Assert(err, quicktest.IsNil)
+
+		got, err := unmarshalSnapshot(b)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got.ID, quicktest.Equals, "workflow")
+		c.Check(got.Data, valueEquals, legacy.Data)
+	})
+
+	c.Run("nok - newer version", func(c *quicktest.C) {
+		newer := bytes.Clone(b)
+		newer[len(snapshotMagic)] = snapshotVersion + 1
+
+		_, err := unmarshalSnapshot(newer)
+		c.Check(err, quicktest.ErrorMatches, "unsupported workflow memory snapshot version 2")
+	})
+
+	c.Run("nok - missing version", func(c *quicktest.C) {
+		_, err := unmarshalSnapshot(snapshotMagic)
+		c.Check(err, quicktest.ErrorMatches, "workflow memory snapshot is truncated")
+	})
+
+	c.Run("nok - truncated", func(c *quicktest.C) {
+		_, err := unmarshalSnapshot(b[:len(b)-3])
+		c.Check(err, quicktest.IsNotNil)
+	})
+}
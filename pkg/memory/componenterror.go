@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+// Codes of the errors of a failed component.
+const (
+	// ComponentErrorInternal is used when the failure can't be attributed to
+	// the input or to the service the component connects to.
+	ComponentErrorInternal = "INTERNAL"
+	// ComponentErrorInvalidInput means the component rejected its input or
+	// setup.
+	ComponentErrorInvalidInput = "INVALID_INPUT"
+	// ComponentErrorUpstream means the service the component connects to
+	// rejected the request.
+	ComponentErrorUpstream = "UPSTREAM_ERROR"
+	// ComponentErrorUpstreamUnavailable means the service the component
+	// connects to is down or has exhausted its quota.
+	ComponentErrorUpstreamUnavailable = "UPSTREAM_UNAVAILABLE"
+	// ComponentErrorTimeout means the component didn't finish in time.
+	ComponentErrorTimeout = "TIMEOUT"
+)
+
+// ComponentError is the error of a failed component. It's kept in the error
+// section of the component memory, so it can be referenced in the recipe
+// and inspected after the run.
+type ComponentError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	// Retryable tells whether triggering the component again might succeed.
+	Retryable bool `json:"retryable"`
+	// UpstreamStatus is the HTTP status with which the service the component
+	// connects to responded, if any.
+	UpstreamStatus int `json:"upstreamStatus,omitempty"`
+}
+
+func (e ComponentError) toValue() *data.Map {
+	return data.NewMap(map[string]data.Value{
+		"code":           data.NewString(e.Code),
+		"message":        data.NewString(e.Message),
+		"retryable":      data.NewBoolean(e.Retryable),
+		"upstreamStatus": data.NewNumberFromInteger(e.UpstreamStatus),
+	})
+}
+
+// ComponentErrorFromValue reads the error section of a component memory.
+// Missing fields are left empty.
+func ComponentErrorFromValue(v data.Value) ComponentError {
+	var e ComponentError
+
+	m, ok := v.(*data.Map)
+	if !ok {
+		return e
+	}
+	if s, ok := m.Fields["code"].(*data.String); ok {
+		e.Code = s.GetString()
+	}
+	if s, ok := m.Fields["message"].(*data.String); ok {
+		e.Message = s.GetString()
+	}
+	if b, ok := m.Fields["retryable"].(*data.Boolean); ok {
+		e.Retryable = b.GetBoolean()
+	}
+	if n, ok := m.Fields["upstreamStatus"].(*data.Number); ok {
+		e.UpstreamStatus = n.GetInteger()
+	}
+	return e
+}
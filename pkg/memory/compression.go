@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the algorithm used to compress the persisted snapshots.
+type Compression string
+
+// Supported compression algorithms.
+const (
+	CompressionNone Compression = ""
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+	// The zstd encoder and decoder are safe for concurrent use through
+	// EncodeAll and DecodeAll.
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+type compressedPersistence struct {
+	MemoryPersistence
+	compression Compression
+}
+
+// NewCompressedPersistence wraps a persistence backend so the snapshots are
+// compressed before they're saved. Payloads such as base64 images compress
+// well, which keeps the memory footprint of the backend under control.
+//
+// The compression is detected when a snapshot is loaded, so the algorithm
+// can be changed (or disabled) without losing the snapshots that were
+// already persisted.
+func NewCompressedPersistence(p MemoryPersistence, c Compression) (MemoryPersistence, error) {
+	switch c {
+	case CompressionNone, CompressionGzip, CompressionZstd:
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm: %s", c)
+	}
+
+	return &compressedPersistence{MemoryPersistence: p, compression: c}, nil
+}
+
+func (p *compressedPersistence) Save(ctx context.Context, workflowID string, snapshot []byte, ttl time.Duration) error {
+	b, err := compress(snapshot, p.compression)
+	if err != nil {
+		return fmt.Errorf("compressing workflow memory snapshot: %w", err)
+	}
+
+	return p.MemoryPersistence.Save(ctx, workflowID, b, ttl)
+}
+
+func (p *compressedPersistence) Load(ctx context.Context, workflowID string) ([]byte, error) {
+	b, err := p.MemoryPersistence.Load(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := decompress(b)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing workflow memory snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+func (p *compressedPersistence) SaveRevision(ctx context.Context, workflowID string, snapshot []byte, ttl time.Duration, revision int64) (int64, error) {
+	b, err := compress(snapshot, p.compression)
+	if err != nil {
+		return 0, fmt.Errorf("compressing workflow memory snapshot: %w", err)
+	}
+
+	return saveRevision(ctx, p.MemoryPersistence, workflowID, b, ttl, revision)
+}
+
+func (p *compressedPersistence) LoadRevision(ctx context.Context, workflowID string) ([]byte, int64, error) {
+	b, revision, err := loadRevision(ctx, p.MemoryPersistence, workflowID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	snapshot, err := decompress(b)
+	if err != nil {
+		return nil, 0, fmt.Errorf("decompressing workflow memory snapshot: %w", err)
+	}
+
+	return snapshot, revision, nil
+}
+
+func (p *compressedPersistence) SavePartitions(ctx context.Context, workflowID string, snapshot []byte, partitions map[string][]byte, ttl time.Duration, revision int64) (int64, error) {
+	b, err := compress(snapshot, p.compression)
+	if err != nil {
+		return 0, fmt.Errorf("compressing workflow memory snapshot: %w", err)
+	}
+
+	compressed := make(map[string][]byte, len(partitions))
+	for id, partition := range partitions {
+		if compressed[id], err = compress(partition, p.compression); err != nil {
+			return 0, fmt.Errorf("compressing memory of component %s: %w", id, err)
+		}
+	}
+
+	return savePartitionsTo(ctx, p.MemoryPersistence, workflowID, b, compressed, ttl, revision)
+}
+
+func (p *compressedPersistence) LoadPartitions(ctx context.Context, workflowID string, componentIDs []string) (map[string][]byte, error) {
+	partitions, err := loadPartitionsFrom(ctx, p.MemoryPersistence, workflowID, componentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, b := range partitions {
+		if partitions[id], err = decompress(b); err != nil {
+			return nil, fmt.Errorf("decompressing memory of component %s: %w", id, err)
+		}
+	}
+	return partitions, nil
+}
+
+func (p *compressedPersistence) ListWorkflows(ctx context.Context) ([]string, error) {
+	return listWorkflows(ctx, p.MemoryPersistence)
+}
+
+func (p *compressedPersistence) Unwrap() MemoryPersistence {
+	return p.MemoryPersistence
+}
+
+func compress(b []byte, c Compression) ([]byte, error) {
+	switch c {
+	case CompressionGzip:
+		buf := new(bytes.Buffer)
+		zw := gzip.NewWriter(buf)
+		if _, err := zw.Write(b); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		return zstdEncoder.EncodeAll(b, make([]byte, 0, len(b)/2)), nil
+	}
+
+	return b, nil
+}
+
+// decompress detects the compression of a snapshot from its magic number.
+// Uncompressed snapshots start with the header of the binary format or, in
+// the legacy format, with a JSON object, so they don't collide with the
+// compressed ones.
+func decompress(b []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(b, zstdMagic):
+		return zstdDecoder.DecodeAll(b, nil)
+	case bytes.HasPrefix(b, gzipMagic):
+		zr, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+
+		return io.ReadAll(zr)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+// DowngradeWorkflowMemory rewrites the persisted memory of every workflow as
+// a legacy JSON snapshot, which the workers that predate the binary format
+// can read. It's run before the binary snapshot migration is rolled back.
+//
+// The memory is read through the provided backend, so it's decrypted and
+// decompressed, and the JSON snapshots are written to the innermost backend
+// as plain text. The component checkpoints, which the legacy format doesn't
+// keep apart, are merged into the snapshots.
+func DowngradeWorkflowMemory(ctx context.Context, p MemoryPersistence) (downgraded int, err error) {
+	workflowIDs, err := listWorkflows(ctx, p)
+	if err != nil {
+		return 0, fmt.Errorf("listing persisted workflow memory: %w", err)
+	}
+
+	ms := &memoryStore{persistence: p}
+	backend := p
+	for w, ok := backend.(wrappedPersistence); ok; w, ok = backend.(wrappedPersistence) {
+		backend = w.Unwrap()
+	}
+
+	for _, workflowID := range workflowIDs {
+		wfm, err := ms.loadWorkflowMemory(ctx, workflowID)
+		if err != nil {
+			return downgraded, fmt.Errorf("loading workflow memory %s: %w", workflowID, err)
+		}
+		b, err := wfm.marshalJSONSnapshot()
+		if err != nil {
+			return downgraded, fmt.Errorf("encoding workflow memory %s: %w", workflowID, err)
+		}
+		if err := backend.Save(ctx, workflowID, b, wfm.ttl()); err != nil {
+			return downgraded, fmt.Errorf("saving workflow memory %s: %w", workflowID, err)
+		}
+		if err := ms.deleteCheckpoints(ctx, workflowID, wfm.takeCheckpoints()); err != nil {
+			return downgraded, err
+		}
+		downgraded++
+	}
+
+	return downgraded, nil
+}
+
+// marshalJSONSnapshot encodes the workflow memory in the legacy JSON form.
+// The values that the legacy form doesn't have are encoded as their
+// structpb representation, e.g. date-times and decimals as strings.
+func (wfm *workflowMemory) marshalJSONSnapshot() ([]byte, error) {
+	s := jsonSnapshot{
+		ID:     wfm.ID,
+		Recipe: wfm.Recipe,
+		Data:   make([]*snapshotValue, len(wfm.Data)),
+	}
+	for idx, v := range wfm.Data {
+		sv, err := encodeSnapshotValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("encoding batch item %d: %w", idx, err)
+		}
+		s.Data[idx] = sv
+	}
+
+	return json.Marshal(s)
+}
+
+func encodeSnapshotValue(v data.Value) (*snapshotValue, error) {
+	file := func(f data.File) *data.File {
+		// The cache is rebuilt from the raw content when the value is
+		// decoded.
+		f.Cache = nil
+		return &f
+	}
+
+	switch v := v.(type) {
+	case nil, *data.Null:
+		return &snapshotValue{Type: snapshotNull}, nil
+	case *data.Boolean:
+		return &snapshotValue{Type: snapshotBoolean, Boolean: v.Raw}, nil
+	case *data.Number:
+		return &snapshotValue{Type: snapshotNumber, Number: v.Raw}, nil
+	case *data.String:
+		return &snapshotValue{Type: snapshotString, String: v.Raw}, nil
+	case *data.ByteArray:
+		return &snapshotValue{Type: snapshotByteArray, Bytes: v.Raw}, nil
+	case *data.Binary:
+		return &snapshotValue{Type: snapshotByteArray, Bytes: v.Raw}, nil
+	case *data.File:
+		return &snapshotValue{Type: snapshotFile, File: file(*v)}, nil
+	case *data.Image:
+		return &snapshotValue{Type: snapshotImage, File: file(v.File), Width: v.Width, Height: v.Height}, nil
+	case *data.Video:
+		return &snapshotValue{Type: snapshotVideo, File: file(v.File)}, nil
+	case *data.Audio:
+		return &snapshotValue{Type: snapshotAudio, File: file(v.File)}, nil
+	case *data.Document:
+		return &snapshotValue{Type: snapshotDocument, File: file(v.File)}, nil
+	case *data.Map:
+		fields := make(map[string]*snapshotValue, len(v.Fields))
+		for k, f := range v.Fields {
+			sv, err := encodeSnapshotValue(f)
+			if err != nil {
+				return nil, err
+			}
+			fields[k] = sv
+		}
+		return &snapshotValue{Type: snapshotMap, Fields: fields}, nil
+	case *data.Array:
+		values := make([]*snapshotValue, len(v.Values))
+		for i, item := range v.Values {
+			sv, err := encodeSnapshotValue(item)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = sv
+		}
+		return &snapshotValue{Type: snapshotArray, Values: values}, nil
+	}
+
+	pbv, err := v.ToStructValue()
+	if err != nil {
+		return nil, fmt.Errorf("converting %T: %w", v, err)
+	}
+	switch raw := pbv.AsInterface().(type) {
+	case string:
+		// Strings might be parsed as files by data.NewValue.
+		return &snapshotValue{Type: snapshotString, String: raw}, nil
+	default:
+		legacy, err := data.NewValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("converting %T: %w", v, err)
+		}
+		return encodeSnapshotValue(legacy)
+	}
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// mapPersistence keeps the snapshots in a map.
+type mapPersistence struct {
+	mu        sync.Mutex
+	snapshots map[string][]byte
+}
+
+func newMapPersistence() *mapPersistence {
+	return &mapPersistence{snapshots: map[string][]byte{}}
+}
+
+func (p *mapPersistence) Save(_ context.Context, workflowID string, snapshot []byte, _ time.Duration) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.snapshots[workflowID] = snapshot
+	return nil
+}
+
+func (p *mapPersistence) Load(_ context.Context, workflowID string) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b, ok := p.snapshots[workflowID]
+	if !ok {
+		return nil, ErrSnapshotNotFound
+	}
+	return b, nil
+}
+
+func (p *mapPersistence) Touch(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (p *mapPersistence) Delete(_ context.Context, workflowID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.snapshots, workflowID)
+	return nil
+}
+
+func (p *mapPersistence) ListWorkflows(context.Context) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var ids []string
+	for id := range p.snapshots {
+		if !isCheckpointID(id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (p *mapPersistence) keys() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	keys := make([]string, 0, len(p.snapshots))
+	for k := range p.snapshots {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestDowngradeWorkflowMemory(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	p := newMapPersistence()
+	ms := NewMemoryStore(p, 0, nil, 0, nil, nil)
+	recipe := &datamodel.Recipe{
+		Component: datamodel.ComponentMap{"json": {Type: "json", Task: "TASK_MARSHAL"}},
+	}
+
+	wfm, err := ms.NewWorkflowMemory(ctx, "workflow", recipe, 1)
+	c.Assert(err, quicktest.IsNil)
+	c.Assert(wfm.Set(ctx, 0, string(PipelineVariable), data.NewMap(map[string]data.Value{
+		"when":  data.NewDateTime(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)),
+		"delay": data.NewDuration(90 * time.Second),
+	})), quicktest.IsNil)
+	c.Assert(ms.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+
+	// The component memory is only in a checkpoint.
+	wfm.InitComponent(ctx, 0, "json")
+	c.Assert(wfm.SetComponentData(ctx, 0, "json", ComponentDataOutput, data.NewMap(map[string]data.Value{
+		"string": data.NewString(`{"a":1}`),
+	})), quicktest.IsNil)
+	c.Assert(ms.CommitComponentMemory(ctx, "workflow", "json"), quicktest.IsNil)
+	c.Assert(p.keys(), quicktest.DeepEquals, []string{"workflow", "workflow/components/json"})
+
+	downgraded, err := DowngradeWorkflowMemory(ctx, p)
+	c.Assert(err, quicktest.IsNil)
+	c.Check(downgraded, quicktest.Equals, 1)
+
+	// The checkpoint is merged into a JSON snapshot.
+	c.Check(p.keys(), quicktest.DeepEquals, []string{"workflow"})
+	b := p.snapshots["workflow"]
+	c.Check(string(b[:1]), quicktest.Equals, "{")
+
+	legacy, err := unmarshalJSONSnapshot(b)
+	c.Assert(err, quicktest.IsNil)
+	c.Check(legacy.ID, quicktest.Equals, "workflow")
+	c.Assert(legacy.Data, quicktest.HasLen, 1)
+
+	batch := legacy.Data[0]
+	output, err := batch.Get("json.output.string")
+	c.Assert(err, quicktest.IsNil)
+	c.Check(output, valueEquals, data.NewString(`{"a":1}`))
+
+	// The values the legacy format doesn't have are kept in their structpb
+	// form.
+	when, err := batch.Get("variable.when")
+	c.Assert(err, quicktest.IsNil)
+	c.Check(when, valueEquals, data.NewString("2024-05-06T07:08:09Z"))
+	delay, err := batch.Get("variable.delay")
+	c.Assert(err, quicktest.IsNil)
+	c.Check(delay, valueEquals, data.NewNumberFromFloat(90))
+
+	c.Run("idempotent", func(c *quicktest.C) {
+		downgraded, err := DowngradeWorkflowMemory(ctx, p)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(downgraded, quicktest.Equals, 1)
+		c.Check(p.snapshots["workflow"], quicktest.DeepEquals, b)
+	})
+}
@@ -0,0 +1,302 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// encryptionMagic prefixes the encrypted snapshots. Its first byte can't
+// start a compressed, binary or JSON snapshot, so the snapshots persisted
+// before the encryption was enabled can be told apart.
+var encryptionMagic = []byte{0xe5, 'E', 'N', 'C', 1}
+
+// ErrUnencryptedSnapshot is returned when a snapshot that isn't encrypted is
+// loaded from an encrypted backend. Otherwise, anyone with write access to
+// the backend could make the workers load a crafted memory.
+var ErrUnencryptedSnapshot = errors.New("workflow memory snapshot isn't encrypted")
+
+const dataKeySize = 32
+
+// KeyWrapper encrypts and decrypts the data keys of the workflow memory. It
+// can be backed by a KMS, which keeps the master key out of the process.
+type KeyWrapper interface {
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error)
+}
+
+type masterKeyWrapper struct {
+	aead cipher.AEAD
+}
+
+// NewMasterKeyWrapper returns a KeyWrapper that encrypts the data keys with
+// a local AES master key of 16, 24 or 32 bytes.
+func NewMasterKeyWrapper(masterKey []byte) (KeyWrapper, error) {
+	aead, err := newAEAD(masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("initializing master key: %w", err)
+	}
+
+	return &masterKeyWrapper{aead: aead}, nil
+}
+
+func (w *masterKeyWrapper) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	return seal(w.aead, dataKey, nil)
+}
+
+func (w *masterKeyWrapper) UnwrapKey(_ context.Context, wrappedKey []byte) ([]byte, error) {
+	return open(w.aead, wrappedKey, nil)
+}
+
+type encryptedPersistence struct {
+	MemoryPersistence
+	wrapper KeyWrapper
+
+	// acceptPlaintext allows loading the snapshots persisted before the
+	// encryption was enabled.
+	acceptPlaintext bool
+
+	// The snapshots are encrypted with a data key that is generated when
+	// the first one is saved. Its wrapped form is stored along with each
+	// snapshot, so any worker can decrypt it. If the key can't be wrapped
+	// (e.g. the KMS is unavailable), it's generated again on the next save.
+	initMu      sync.Mutex
+	initialized bool
+	aead        cipher.AEAD
+	wrappedKey  []byte
+
+	// unwrapped caches the data keys of other workers, indexed by their
+	// wrapped form, to avoid a KMS round trip on every load.
+	unwrapped sync.Map
+}
+
+// NewEncryptedPersistence wraps a persistence backend so the snapshots are
+// encrypted with AES-GCM before they're saved. The workflow memory holds
+// user documents and, often, secrets, so it shouldn't be readable by anyone
+// with access to the backend.
+//
+// As encrypted data doesn't compress, this wrapper must be placed below the
+// compression one.
+//
+// The snapshots that aren't encrypted are rejected with
+// ErrUnencryptedSnapshot, unless acceptPlaintext is set while the encryption
+// is enabled on a backend that holds the memory of runs in flight. They're
+// encrypted the next time they're saved.
+func NewEncryptedPersistence(p MemoryPersistence, w KeyWrapper, acceptPlaintext bool) MemoryPersistence {
+	return &encryptedPersistence{MemoryPersistence: p, wrapper: w, acceptPlaintext: acceptPlaintext}
+}
+
+func (p *encryptedPersistence) init(ctx context.Context) (cipher.AEAD, []byte, error) {
+	p.initMu.Lock()
+	defer p.initMu.Unlock()
+
+	if p.initialized {
+		return p.aead, p.wrappedKey, nil
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, fmt.Errorf("generating data key: %w", err)
+	}
+
+	wrappedKey, err := p.wrapper.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("wrapping data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p.aead, p.wrappedKey, p.initialized = aead, wrappedKey, true
+	return aead, wrappedKey, nil
+}
+
+func (p *encryptedPersistence) Save(ctx context.Context, workflowID string, snapshot []byte, ttl time.Duration) error {
+	b, err := p.encrypt(ctx, workflowID, snapshot)
+	if err != nil {
+		return err
+	}
+
+	return p.MemoryPersistence.Save(ctx, workflowID, b, ttl)
+}
+
+func (p *encryptedPersistence) Load(ctx context.Context, workflowID string) ([]byte, error) {
+	b, err := p.MemoryPersistence.Load(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.decrypt(ctx, workflowID, b)
+}
+
+func (p *encryptedPersistence) SaveRevision(ctx context.Context, workflowID string, snapshot []byte, ttl time.Duration, revision int64) (int64, error) {
+	b, err := p.encrypt(ctx, workflowID, snapshot)
+	if err != nil {
+		return 0, err
+	}
+
+	return saveRevision(ctx, p.MemoryPersistence, workflowID, b, ttl, revision)
+}
+
+func (p *encryptedPersistence) LoadRevision(ctx context.Context, workflowID string) ([]byte, int64, error) {
+	b, revision, err := loadRevision(ctx, p.MemoryPersistence, workflowID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	snapshot, err := p.decrypt(ctx, workflowID, b)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return snapshot, revision, nil
+}
+
+// SavePartitions encrypts the memory of each component with the ID of its
+// checkpoint as additional data, so it can't be swapped with the memory of
+// another component either.
+func (p *encryptedPersistence) SavePartitions(ctx context.Context, workflowID string, snapshot []byte, partitions map[string][]byte, ttl time.Duration, revision int64) (int64, error) {
+	b, err := p.encrypt(ctx, workflowID, snapshot)
+	if err != nil {
+		return 0, err
+	}
+
+	encrypted := make(map[string][]byte, len(partitions))
+	for id, partition := range partitions {
+		if encrypted[id], err = p.encrypt(ctx, checkpointID(workflowID, id), partition); err != nil {
+			return 0, err
+		}
+	}
+
+	return savePartitionsTo(ctx, p.MemoryPersistence, workflowID, b, encrypted, ttl, revision)
+}
+
+func (p *encryptedPersistence) LoadPartitions(ctx context.Context, workflowID string, componentIDs []string) (map[string][]byte, error) {
+	partitions, err := loadPartitionsFrom(ctx, p.MemoryPersistence, workflowID, componentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, b := range partitions {
+		if partitions[id], err = p.decrypt(ctx, checkpointID(workflowID, id), b); err != nil {
+			return nil, err
+		}
+	}
+	return partitions, nil
+}
+
+func (p *encryptedPersistence) encrypt(ctx context.Context, workflowID string, snapshot []byte) ([]byte, error) {
+	aead, wrappedKey, err := p.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The workflow ID is authenticated, so a snapshot can't be swapped with
+	// the one of another workflow.
+	ciphertext, err := seal(aead, snapshot, []byte(workflowID))
+	if err != nil {
+		return nil, fmt.Errorf("encrypting workflow memory snapshot: %w", err)
+	}
+
+	b := make([]byte, 0, len(encryptionMagic)+2+len(wrappedKey)+len(ciphertext))
+	b = append(b, encryptionMagic...)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(wrappedKey)))
+	b = append(b, wrappedKey...)
+	b = append(b, ciphertext...)
+
+	return b, nil
+}
+
+func (p *encryptedPersistence) decrypt(ctx context.Context, workflowID string, b []byte) ([]byte, error) {
+	if !bytes.HasPrefix(b, encryptionMagic) {
+		if !p.acceptPlaintext {
+			return nil, ErrUnencryptedSnapshot
+		}
+		return b, nil
+	}
+
+	b = b[len(encryptionMagic):]
+	if len(b) < 2 {
+		return nil, fmt.Errorf("truncated workflow memory snapshot")
+	}
+	keyLen := int(binary.BigEndian.Uint16(b))
+	b = b[2:]
+	if len(b) < keyLen {
+		return nil, fmt.Errorf("truncated workflow memory snapshot")
+	}
+
+	aead, err := p.dataKeyAEAD(ctx, b[:keyLen])
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := open(aead, b[keyLen:], []byte(workflowID))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting workflow memory snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+func (p *encryptedPersistence) ListWorkflows(ctx context.Context) ([]string, error) {
+	return listWorkflows(ctx, p.MemoryPersistence)
+}
+
+func (p *encryptedPersistence) Unwrap() MemoryPersistence {
+	return p.MemoryPersistence
+}
+
+func (p *encryptedPersistence) dataKeyAEAD(ctx context.Context, wrappedKey []byte) (cipher.AEAD, error) {
+	if aead, ok := p.unwrapped.Load(string(wrappedKey)); ok {
+		return aead.(cipher.AEAD), nil
+	}
+
+	dataKey, err := p.wrapper.UnwrapKey(ctx, wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key: %w", err)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	p.unwrapped.Store(string(wrappedKey), aead)
+	return aead, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext and prepends the random nonce it used.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
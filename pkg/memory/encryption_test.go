@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+func TestEncryptedPersistence(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	wrapper, err := NewMasterKeyWrapper(bytes.Repeat([]byte{1}, 32))
+	c.Assert(err, quicktest.IsNil)
+
+	backend := newMapPersistence()
+	p := NewEncryptedPersistence(backend, wrapper, false)
+	snapshot := []byte(`{"id":"workflow"}`)
+	c.Assert(p.Save(ctx, "workflow", snapshot, 0), quicktest.IsNil)
+
+	c.Run("round trip", func(c *quicktest.C) {
+		stored := backend.snapshots["workflow"]
+		c.Check(bytes.HasPrefix(stored, encryptionMagic), quicktest.IsTrue)
+		c.Check(bytes.Contains(stored, snapshot), quicktest.IsFalse)
+
+		got, err := p.Load(ctx, "workflow")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, quicktest.DeepEquals, snapshot)
+	})
+
+	c.Run("other worker", func(c *quicktest.C) {
+		// The data key is wrapped along with the snapshot.
+		got, err := NewEncryptedPersistence(backend, wrapper, false).Load(ctx, "workflow")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, quicktest.DeepEquals, snapshot)
+	})
+
+	c.Run("nok - plaintext", func(c *quicktest.C) {
+		c.Assert(backend.Save(ctx, "plaintext", snapshot, 0), quicktest.IsNil)
+
+		_, err := p.Load(ctx, "plaintext")
+		c.Check(err, quicktest.ErrorIs, ErrUnencryptedSnapshot)
+	})
+
+	c.Run("plaintext accepted while migrating", func(c *quicktest.C) {
+		c.Assert(backend.Save(ctx, "plaintext", snapshot, 0), quicktest.IsNil)
+
+		got, err := NewEncryptedPersistence(backend, wrapper, true).Load(ctx, "plaintext")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, quicktest.DeepEquals, snapshot)
+	})
+
+	c.Run("nok - swapped workflow", func(c *quicktest.C) {
+		backend.snapshots["swapped"] = backend.snapshots["workflow"]
+
+		_, err := p.Load(ctx, "swapped")
+		c.Check(err, quicktest.ErrorMatches, "decrypting workflow memory snapshot: .*")
+	})
+
+	c.Run("nok - tampered", func(c *quicktest.C) {
+		tampered := bytes.Clone(backend.snapshots["workflow"])
+		tampered[len(tampered)-1] ^= 1
+		backend.snapshots["tampered"] = tampered
+
+		_, err := p.Load(ctx, "tampered")
+		c.Check(err, quicktest.ErrorMatches, "decrypting workflow memory snapshot: .*")
+	})
+
+	c.Run("nok - truncated", func(c *quicktest.C) {
+		backend.snapshots["truncated"] = encryptionMagic
+
+		_, err := p.Load(ctx, "truncated")
+		c.Check(err, quicktest.ErrorMatches, "truncated workflow memory snapshot")
+	})
+
+	c.Run("nok - wrong master key", func(c *quicktest.C) {
+		other, err := NewMasterKeyWrapper(bytes.Repeat([]byte{2}, 32))
+		c.Assert(err, quicktest.IsNil)
+
+		_, err = NewEncryptedPersistence(backend, other, false).Load(ctx, "workflow")
+		c.Check(err, quicktest.ErrorMatches, "unwrapping data key: .*")
+	})
+}
+
+// flakyKeyWrapper fails to wrap the first data keys, as a KMS that is
+// unavailable for a moment.
+type flakyKeyWrapper struct {
+	KeyWrapper
+	failures int
+	calls    int
+}
+
+func (w *flakyKeyWrapper) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	w.calls++
+	if w.calls <= w.failures {
+		return nil, errors.New("KMS unavailable")
+	}
+	return w.KeyWrapper.WrapKey(ctx, dataKey)
+}
+
+func TestEncryptedPersistenceInitRetry(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	master, err := NewMasterKeyWrapper(bytes.Repeat([]byte{1}, 32))
+	c.Assert(err, quicktest.IsNil)
+	wrapper := &flakyKeyWrapper{KeyWrapper: master, failures: 1}
+
+	backend := newMapPersistence()
+	p := NewEncryptedPersistence(backend, wrapper, false)
+	snapshot := []byte(`{"id":"workflow"}`)
+
+	err = p.Save(ctx, "workflow", snapshot, 0)
+	c.Check(err, quicktest.ErrorMatches, "wrapping data key: KMS unavailable")
+	c.Check(backend.snapshots, quicktest.HasLen, 0)
+
+	// The failure isn't kept, the data key is generated again.
+	c.Assert(p.Save(ctx, "workflow", snapshot, 0), quicktest.IsNil)
+	got, err := p.Load(ctx, "workflow")
+	c.Assert(err, quicktest.IsNil)
+	c.Check(got, quicktest.DeepEquals, snapshot)
+
+	// Once it's wrapped, the data key is reused.
+	c.Assert(p.Save(ctx, "other", snapshot, 0), quicktest.IsNil)
+	c.Check(wrapper.calls, quicktest.Equals, 2)
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// eventBuffer coalesces the events that a workflow memory sends within a
+// flush window, so the high-frequency updates (e.g. the tokens of a
+// streaming LLM) reach the listeners at a bounded rate. Within a window,
+// only the last event of each kind is kept, except for the output patches,
+// which are merged.
+type eventBuffer struct {
+	mu      sync.Mutex
+	pending []*Event
+	// positions holds the position in pending of the event of each kind.
+	positions map[string]int
+	timer     *time.Timer
+
+	// sendMu keeps the flushed events in order.
+	sendMu sync.Mutex
+}
+
+// SendEvent publishes an event to the listeners of the workflow memory. When
+// a flush interval is set, the event is buffered until the window closes or
+// the pipeline is closed.
+func (wfm *workflowMemory) SendEvent(ctx context.Context, event *Event) {
+	if event.Version == 0 {
+		event.Version = CurrentEventVersion
+	}
+
+	if wfm.flushInterval <= 0 {
+		wfm.deliver(ctx, event)
+		return
+	}
+
+	if event.Event == string(PipelineClosed) {
+		wfm.flushEvents(ctx, event)
+		return
+	}
+
+	b := &wfm.events
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.add(event)
+	if b.timer == nil {
+		b.timer = time.AfterFunc(wfm.flushInterval, func() { wfm.flushEvents(context.Background()) })
+	}
+}
+
+// flushEvents sends the buffered events, followed by the provided ones.
+func (wfm *workflowMemory) flushEvents(ctx context.Context, last ...*Event) {
+	b := &wfm.events
+	b.sendMu.Lock()
+	defer b.sendMu.Unlock()
+
+	b.mu.Lock()
+	pending := b.pending
+	b.pending, b.positions = nil, nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.mu.Unlock()
+
+	for _, event := range append(pending, last...) {
+		if event != nil {
+			wfm.deliver(ctx, event)
+		}
+	}
+}
+
+// add buffers an event. An event of a kind that is already buffered replaces
+// the previous one, and is moved to the end so the events keep their
+// causal order.
+func (b *eventBuffer) add(event *Event) {
+	if b.positions == nil {
+		b.positions = map[string]int{}
+	}
+
+	key, ok := eventKey(event)
+	if !ok {
+		b.pending = append(b.pending, event)
+		return
+	}
+
+	if pos, ok := b.positions[key]; ok {
+		prev := b.pending[pos]
+		b.pending[pos] = nil
+
+		// The patches apply to the output of the previous event, so they're
+		// merged with the patches of the buffered one. A full output can't
+		// be patched without decoding it, so the patch is sent after it.
+		if patch, ok := event.Data.(ComponentOutputPatchedEventData); ok {
+			switch prevData := prev.Data.(type) {
+			case ComponentOutputPatchedEventData:
+				patch.Patch = append(prevData.Patch, patch.Patch...)
+				event = &Event{Event: event.Event, Data: patch}
+			case ComponentOutputUpdatedEventData:
+				b.pending = append(b.pending, prev)
+			}
+		}
+	}
+
+	b.positions[key] = len(b.pending)
+	b.pending = append(b.pending, event)
+}
+
+// eventKey identifies the kind of an event, i.e. the type of event and the
+// batch item and component it's about. The full outputs and the output
+// patches of a component are of the same kind.
+func eventKey(event *Event) (string, bool) {
+	switch d := event.Data.(type) {
+	case ComponentStatusUpdatedEventData:
+		return fmt.Sprintf("%s/%d/%s", event.Event, d.BatchIndex, d.ComponentID), true
+	case ComponentInputUpdatedEventData:
+		return fmt.Sprintf("%s/%d/%s", event.Event, d.BatchIndex, d.ComponentID), true
+	case ComponentOutputUpdatedEventData:
+		return fmt.Sprintf("%s/%d/%s", ComponentOutputUpdated, d.BatchIndex, d.ComponentID), true
+	case ComponentOutputPatchedEventData:
+		return fmt.Sprintf("%s/%d/%s", ComponentOutputUpdated, d.BatchIndex, d.ComponentID), true
+	case ComponentErrorUpdatedEventData:
+		return fmt.Sprintf("%s/%d/%s", event.Event, d.BatchIndex, d.ComponentID), true
+	case PipelineStatusUpdatedEventData:
+		return fmt.Sprintf("%s/%d", event.Event, d.BatchIndex), true
+	case PipelineOutputUpdatedEventData:
+		return fmt.Sprintf("%s/%d", event.Event, d.BatchIndex), true
+	}
+	return "", false
+}
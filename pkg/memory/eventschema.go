@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The payload of the events is versioned, so the streaming consumers (the
+// console, the SDKs, the subscribers of the event bus) keep working when it
+// evolves. A consumer negotiates the version it understands and the events
+// are encoded with it. The changes of each version are:
+//
+//   - Version 1: the original payload.
+//   - Version 2: the component ID field of the component events is
+//     `componentId` instead of `componentID`, as the rest of fields.
+const (
+	EventVersion1 = 1
+	EventVersion2 = 2
+
+	// CurrentEventVersion is the version the events are produced with.
+	CurrentEventVersion = EventVersion2
+)
+
+// ErrUnsupportedEventVersion is returned when none of the versions a
+// consumer accepts are supported.
+var ErrUnsupportedEventVersion = errors.New("unsupported event version")
+
+// supportedEventVersions holds the versions the events can be encoded in,
+// from the latest to the oldest.
+var supportedEventVersions = []int{EventVersion2, EventVersion1}
+
+// NegotiateEventVersion returns the latest supported version among a
+// comma-separated list of accepted versions. When the list is empty, the
+// current version is used.
+func NegotiateEventVersion(accepted string) (int, error) {
+	if strings.TrimSpace(accepted) == "" {
+		return CurrentEventVersion, nil
+	}
+
+	best := 0
+	for _, s := range strings.Split(accepted, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrUnsupportedEventVersion, s)
+		}
+		if slices.Contains(supportedEventVersions, v) && v > best {
+			best = v
+		}
+	}
+	if best == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedEventVersion, accepted)
+	}
+
+	return best, nil
+}
+
+// eventFieldRenames holds, for each version, the fields of the event
+// payloads that were renamed in the next one, by their new name.
+var eventFieldRenames = map[int]map[string]string{
+	EventVersion1: {"componentId": "componentID"},
+}
+
+// EncodeEventData encodes the payload of an event as JSON in a given
+// version of the schema.
+func EncodeEventData(event *Event, version int) ([]byte, error) {
+	if !slices.Contains(supportedEventVersions, version) {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedEventVersion, version)
+	}
+
+	b, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling event data: %w", err)
+	}
+
+	// Older versions are produced by downgrading the current payload, one
+	// version at a time.
+	for v := CurrentEventVersion - 1; v >= version; v-- {
+		if b, err = renameEventFields(b, eventFieldRenames[v]); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// DecodeEvent decodes an event published to the event bus in any supported
+// version. Encrypted events must be decrypted first. The payload is upgraded to the current version, so consumers
+// only need to handle the latest schema. The data is decoded into generic
+// JSON values.
+func DecodeEvent(b []byte) (workflowID string, event *Event, err error) {
+	var pe struct {
+		WorkflowID string          `json:"workflowId"`
+		Version    int             `json:"version"`
+		Event      string          `json:"event"`
+		Data       json.RawMessage `json:"data"`
+		Encrypted  []byte          `json:"encrypted"`
+	}
+	if err := json.Unmarshal(b, &pe); err != nil {
+		return "", nil, fmt.Errorf("unmarshalling event: %w", err)
+	}
+	if pe.Encrypted != nil {
+		return "", nil, fmt.Errorf("event is encrypted and must be decrypted with DecryptEvent")
+	}
+
+	// The events published before the payload was versioned don't inform
+	// it.
+	if pe.Version == 0 {
+		pe.Version = EventVersion1
+	}
+	if !slices.Contains(supportedEventVersions, pe.Version) {
+		return "", nil, fmt.Errorf("%w: %d", ErrUnsupportedEventVersion, pe.Version)
+	}
+
+	payload := []byte(pe.Data)
+	for v := pe.Version; v < CurrentEventVersion; v++ {
+		upgrade := make(map[string]string, len(eventFieldRenames[v]))
+		for newName, oldName := range eventFieldRenames[v] {
+			upgrade[oldName] = newName
+		}
+		if payload, err = renameEventFields(payload, upgrade); err != nil {
+			return "", nil, err
+		}
+	}
+
+	event = &Event{Event: pe.Event, Version: CurrentEventVersion}
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &event.Data); err != nil {
+			return "", nil, fmt.Errorf("unmarshalling event data: %w", err)
+		}
+	}
+
+	return pe.WorkflowID, event, nil
+}
+
+// renameEventFields renames the top-level fields of a JSON payload. The
+// inputs and outputs of the components are user data and aren't changed.
+func renameEventFields(b []byte, renames map[string]string) ([]byte, error) {
+	if len(renames) == 0 {
+		return b, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		// Only object payloads have fields to rename.
+		return b, nil
+	}
+
+	for from, to := range renames {
+		if v, ok := fields[from]; ok {
+			delete(fields, from)
+			fields[to] = v
+		}
+	}
+
+	return json.Marshal(fields)
+}
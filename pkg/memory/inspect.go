@@ -0,0 +1,372 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+// ErrWorkflowMemoryNotFound is returned when a workflow memory isn't in the
+// process nor in the persistence backend.
+var ErrWorkflowMemoryNotFound = errors.New("workflow memory not found")
+
+// Sources of an inspected workflow memory.
+const (
+	MemorySourceProcess     = "process"
+	MemorySourcePersistence = "persistence"
+)
+
+const redactedValue = "[REDACTED]"
+
+// Connection fields hold both credentials and plain settings (e.g. a
+// region), so only the values that are long enough to be a credential are
+// redacted from the rest of the memory.
+const minRedactedConnectionValueLength = 8
+
+// MemoryTree is a readable copy of a workflow memory, used to debug how the
+// references of a recipe were resolved.
+type MemoryTree struct {
+	WorkflowID string `json:"workflowId"`
+	Source     string `json:"source"`
+	Size       int64  `json:"sizeBytes"`
+	// ComponentIDs lists the components of the recipe, so the ones that
+	// haven't run yet can be told apart from the ones that don't exist.
+	ComponentIDs []string     `json:"componentIds,omitempty"`
+	Batches      []*BatchTree `json:"batches"`
+}
+
+// BatchTree holds the memory of a batch item. The secrets, connections and
+// component setups are left out, and their values are redacted from the
+// rest of the tree.
+type BatchTree struct {
+	Variable   map[string]any            `json:"variable"`
+	Output     map[string]any            `json:"output"`
+	Components map[string]*ComponentTree `json:"components"`
+}
+
+// ComponentTree holds the memory of a component in a batch item.
+type ComponentTree struct {
+	Status map[string]bool `json:"status"`
+	Input  any             `json:"input"`
+	Output any             `json:"output"`
+	Error  *ComponentError `json:"error,omitempty"`
+}
+
+// MemorySnapshot holds the values a path of a workflow memory resolves to,
+// one per batch item. The items where the path isn't found hold a nil
+// value.
+type MemorySnapshot struct {
+	WorkflowID string `json:"workflowId"`
+	Source     string `json:"source"`
+	Path       string `json:"path"`
+	Values     []any  `json:"values"`
+}
+
+// ErrForbiddenSnapshotPath is returned when a memory snapshot references a
+// value that can't be inspected.
+var ErrForbiddenSnapshotPath = errors.New("path can't be inspected")
+
+// InspectWorkflowMemory returns the memory tree of a workflow. The memory is
+// read from the process or, if it isn't there, from the persistence
+// backend. Unlike GetWorkflowMemory, a persisted memory isn't kept in the
+// process and its expiration isn't refreshed, so inspecting a run doesn't
+// extend its lifetime.
+func (ms *memoryStore) InspectWorkflowMemory(ctx context.Context, workflowID string) (tree *MemoryTree, err error) {
+	err = ms.inspect(ctx, workflowID, func(wfm *workflowMemory, source string) error {
+		tree = wfm.tree(source)
+		return nil
+	})
+	return tree, err
+}
+
+// SnapshotWorkflowMemory returns the value a path resolves to in each batch
+// item of a workflow memory. The path is a reference in the recipe syntax
+// (e.g. `my-comp.output.texts[0]`). As in InspectWorkflowMemory, the values
+// are redacted and the secrets, connections and component setups can't be
+// referenced.
+//
+// A workflow that runs in another process is read from the persistence
+// backend, so the snapshot reflects the last component that committed its
+// memory.
+func (ms *memoryStore) SnapshotWorkflowMemory(ctx context.Context, workflowID, path string) (snapshot *MemorySnapshot, err error) {
+	if err := checkSnapshotPath(path); err != nil {
+		return nil, err
+	}
+
+	err = ms.inspect(ctx, workflowID, func(wfm *workflowMemory, source string) error {
+		snapshot = &MemorySnapshot{
+			WorkflowID: wfm.ID,
+			Source:     source,
+			Path:       path,
+			Values:     make([]any, len(wfm.Data)),
+		}
+		for idx, v := range wfm.Data {
+			snapshot.Values[idx] = snapshotValue(v, path)
+		}
+		return nil
+	})
+	return snapshot, err
+}
+
+// inspect calls fn with the memory of a workflow, read from the process or
+// from the persistence backend.
+func (ms *memoryStore) inspect(ctx context.Context, workflowID string, fn func(wfm *workflowMemory, source string) error) error {
+	if v, ok := ms.workflows.Load(workflowID); ok {
+		wfm := v.(*workflowMemory)
+		if err := wfm.loadPartitions(ctx); err != nil {
+			return err
+		}
+		wfm.mu.Lock()
+		defer wfm.mu.Unlock()
+
+		return fn(wfm, MemorySourceProcess)
+	}
+
+	if ms.persistence == nil {
+		return ErrWorkflowMemoryNotFound
+	}
+
+	ctx, err := ms.tenantContext(ctx, workflowID)
+	if err != nil {
+		return err
+	}
+	b, err := ms.persistence.Load(ctx, workflowID)
+	if err != nil {
+		if errors.Is(err, ErrSnapshotNotFound) {
+			return ErrWorkflowMemoryNotFound
+		}
+		return fmt.Errorf("loading workflow memory: %w", err)
+	}
+
+	wfm, err := unmarshalSnapshot(b)
+	if err != nil {
+		return err
+	}
+	if err := ms.bindPartitions(wfm); err != nil {
+		return err
+	}
+	if err := ms.loadCheckpoints(ctx, wfm); err != nil {
+		return err
+	}
+	wfm.computeSizes()
+	if err := wfm.loadPartitions(ctx); err != nil {
+		return err
+	}
+
+	return fn(wfm, MemorySourcePersistence)
+}
+
+// tree must be called with the workflow memory lock held.
+func (wfm *workflowMemory) tree(source string) *MemoryTree {
+	t := &MemoryTree{
+		WorkflowID: wfm.ID,
+		Source:     source,
+		Batches:    make([]*BatchTree, len(wfm.Data)),
+		Size:       wfm.totalSize(),
+	}
+
+	if wfm.Recipe != nil {
+		for id := range wfm.Recipe.Component {
+			t.ComponentIDs = append(t.ComponentIDs, id)
+		}
+		sort.Strings(t.ComponentIDs)
+	}
+
+	for idx, v := range wfm.Data {
+		t.Batches[idx] = batchTree(v)
+	}
+	return t
+}
+
+func batchTree(v data.Value) *BatchTree {
+	bt := &BatchTree{
+		Variable:   map[string]any{},
+		Output:     map[string]any{},
+		Components: map[string]*ComponentTree{},
+	}
+
+	m, ok := v.(*data.Map)
+	if !ok {
+		return bt
+	}
+
+	r := newRedactor(m)
+	for k, f := range m.Fields {
+		switch k {
+		case string(PipelineSecret), string(PipelineConnection), string(PipelineOutputTemplate):
+		case string(PipelineVariable):
+			bt.Variable, _ = r.value(f).(map[string]any)
+		case string(PipelineOutput):
+			bt.Output, _ = r.value(f).(map[string]any)
+		default:
+			if comp, ok := f.(*data.Map); ok {
+				bt.Components[k] = r.component(comp)
+			}
+		}
+	}
+	return bt
+}
+
+// checkSnapshotPath rejects the paths that reference secrets, connections,
+// the output template or the setup of a component.
+func checkSnapshotPath(path string) error {
+	keys := strings.FieldsFunc(path, func(r rune) bool { return r == '.' || r == '[' })
+	if len(keys) == 0 {
+		return nil
+	}
+
+	switch keys[0] {
+	case string(PipelineSecret), string(PipelineConnection), string(PipelineOutputTemplate):
+		return fmt.Errorf("%w: %s", ErrForbiddenSnapshotPath, path)
+	}
+	if len(keys) > 1 && keys[1] == string(ComponentDataSetup) {
+		return fmt.Errorf("%w: %s", ErrForbiddenSnapshotPath, path)
+	}
+	return nil
+}
+
+// snapshotValue resolves a path in a batch item and redacts the result. An
+// empty path returns the whole batch tree.
+func snapshotValue(v data.Value, path string) any {
+	m, ok := v.(*data.Map)
+	if !ok {
+		return nil
+	}
+	if path == "" {
+		return batchTree(m)
+	}
+
+	// The first key of the path is checked, so the secrets can't be read
+	// with a wildcard.
+	keys := strings.FieldsFunc(path, func(r rune) bool { return r == '.' || r == '[' })
+	if _, ok := m.Fields[keys[0]]; !ok {
+		return nil
+	}
+
+	selected, err := m.Get(path)
+	if err != nil {
+		return nil
+	}
+	return newRedactor(m).value(selected)
+}
+
+// redactor replaces the secret values found in a batch item.
+type redactor struct {
+	secrets []string
+}
+
+func newRedactor(batch *data.Map) *redactor {
+	r := &redactor{}
+	collectStrings(batch.Fields[string(PipelineSecret)], minRedactedSecretLength, &r.secrets)
+	collectStrings(batch.Fields[string(PipelineConnection)], minRedactedConnectionValueLength, &r.secrets)
+	sortSecrets(r.secrets)
+	return r
+}
+
+func collectStrings(v data.Value, minLength int, dst *[]string) {
+	switch v := v.(type) {
+	case *data.String:
+		if len(v.GetString()) >= minLength {
+			*dst = append(*dst, v.GetString())
+		}
+	case *data.Map:
+		for _, f := range v.Fields {
+			collectStrings(f, minLength, dst)
+		}
+	case *data.Array:
+		for _, item := range v.Values {
+			collectStrings(item, minLength, dst)
+		}
+	}
+}
+
+func (r *redactor) component(comp *data.Map) *ComponentTree {
+	ct := &ComponentTree{
+		Status: map[string]bool{},
+		Input:  r.value(comp.Fields[string(ComponentDataInput)]),
+		Output: r.value(comp.Fields[string(ComponentDataOutput)]),
+	}
+
+	if status, ok := comp.Fields[string(ComponentDataStatus)].(*data.Map); ok {
+		for k, f := range status.Fields {
+			if b, ok := f.(*data.Boolean); ok {
+				ct.Status[k] = b.GetBoolean()
+			}
+		}
+	}
+	if e := ComponentErrorFromValue(comp.Fields[string(ComponentDataError)]); e.Message != "" {
+		e.Message = r.string(e.Message)
+		ct.Error = &e
+	}
+	return ct
+}
+
+// value converts a memory value into its JSON form. Binary contents are
+// described instead of being included, as they aren't useful to debug a
+// reference and would bloat the response.
+func (r *redactor) value(v data.Value) any {
+	switch v := v.(type) {
+	case nil, *data.Null:
+		return nil
+	case *data.Boolean:
+		return v.GetBoolean()
+	case *data.Number:
+		return v.GetFloat()
+	case *data.String:
+		return r.string(v.GetString())
+	case *data.Decimal:
+		return v.String()
+	case *data.DateTime:
+		return v.String()
+	case *data.Duration:
+		return v.String()
+	case *data.ByteArray:
+		return map[string]any{"type": "byte-array", "sizeBytes": len(v.GetByteArray())}
+	case *data.Binary:
+		return map[string]any{"type": "binary", "contentType": v.ContentType, "fileName": v.FileName, "sizeBytes": len(v.Raw)}
+	case *data.Proto:
+		return map[string]any{"type": "proto", "messageName": v.MessageName(), "sizeBytes": len(v.Raw.GetValue())}
+	case *data.FileRef:
+		return map[string]any{"type": "file-ref", "uri": r.string(v.URI), "contentType": v.ContentType, "fileName": v.FileName, "sizeBytes": v.Size}
+	case *data.File:
+		return fileDigest("file", v)
+	case *data.Image:
+		return fileDigest("image", &v.File)
+	case *data.Video:
+		return fileDigest("video", &v.File)
+	case *data.Audio:
+		return fileDigest("audio", &v.File)
+	case *data.Document:
+		return fileDigest("document", &v.File)
+	case *data.Map:
+		m := make(map[string]any, len(v.Fields))
+		for k, f := range v.Fields {
+			m[k] = r.value(f)
+		}
+		return m
+	case *data.Array:
+		a := make([]any, len(v.Values))
+		for i, item := range v.Values {
+			a[i] = r.value(item)
+		}
+		return a
+	}
+	return nil
+}
+
+func (r *redactor) string(s string) string {
+	return maskSecrets(s, r.secrets)
+}
+
+func fileDigest(t string, f *data.File) map[string]any {
+	return map[string]any{
+		"type":        t,
+		"contentType": f.ContentType,
+		"fileName":    f.FileName,
+		"sizeBytes":   f.GetFileSize().GetInteger(),
+	}
+}
@@ -2,10 +2,11 @@ package memory
 
 import (
 	"context"
-	"encoding/gob"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/instill-ai/pipeline-backend/pkg/data"
@@ -34,6 +35,11 @@ const (
 	ComponentStatusSkipped   ComponentStatusType = "skipped"
 	ComponentStatusErrored   ComponentStatusType = "errored"
 	ComponentStatusCompleted ComponentStatusType = "completed"
+
+	// ComponentStatusRateLimited is set when the requests of a component are
+	// rate limited by the remote service and retried after a wait. It's
+	// cleared once the component execution finishes.
+	ComponentStatusRateLimited ComponentStatusType = "rate_limited"
 )
 
 const (
@@ -55,8 +61,19 @@ type MemoryStore interface {
 	NewWorkflowMemory(ctx context.Context, workflowID string, recipe *datamodel.Recipe, batchSize int) (workflow WorkflowMemory, err error)
 	GetWorkflowMemory(ctx context.Context, workflowID string) (workflow WorkflowMemory, err error)
 	PurgeWorkflowMemory(ctx context.Context, workflowID string) (err error)
+	CommitWorkflowMemory(ctx context.Context, workflowID string) (err error)
+	ReleaseWorkflowMemory(ctx context.Context, workflowID string) (released bool, err error)
+	CommitComponentMemory(ctx context.Context, workflowID, componentID string) (err error)
+	InspectWorkflowMemory(ctx context.Context, workflowID string) (tree *MemoryTree, err error)
+	SnapshotWorkflowMemory(ctx context.Context, workflowID, path string) (snapshot *MemorySnapshot, err error)
+	ListWorkflowMemory(ctx context.Context) (workflowIDs []string, err error)
+	PurgeTenantMemory(ctx context.Context, tenant string) (deleted int, err error)
 
 	SendWorkflowStatusEvent(ctx context.Context, workflowID string, event Event) (err error)
+
+	MemoryUsage() WorkerMemoryUsage
+	AdmitTrigger(ctx context.Context, workflowID string, payloadSize int64) (release func(), err error)
+	SpillIdleWorkflowMemory(ctx context.Context) (spilled int, err error)
 }
 
 type WorkflowMemory interface {
@@ -70,16 +87,20 @@ type WorkflowMemory interface {
 	GetComponentStatus(ctx context.Context, batchIdx int, componentID string, t ComponentStatusType) (value bool, err error)
 	SetPipelineData(ctx context.Context, batchIdx int, t PipelineDataType, value data.Value) (err error)
 	GetPipelineData(ctx context.Context, batchIdx int, t PipelineDataType) (value data.Value, err error)
-	SetComponentErrorMessage(ctx context.Context, batchIdx int, componentID string, msg string) (err error)
+	SetComponentError(ctx context.Context, batchIdx int, componentID string, compErr ComponentError) (err error)
 
 	EnableStreaming()
 	IsStreaming() bool
+	EnablePatchEvents()
 	SendEvent(ctx context.Context, event *Event)
 	ListenEvent(ctx context.Context) chan *Event
 
 	GetBatchSize() int
+	GetMemorySize() MemorySize
 	SetRecipe(*datamodel.Recipe)
 	GetRecipe() *datamodel.Recipe
+	GetTenant() string
+	Hold() (release func())
 }
 
 type ComponentStatus struct {
@@ -89,16 +110,96 @@ type ComponentStatus struct {
 }
 
 type memoryStore struct {
-	workflows sync.Map
+	workflows     sync.Map
+	persistence   MemoryPersistence
+	encrypted     bool
+	maxSize       int64
+	blobs         *BlobManager
+	flushInterval time.Duration
+	publisher     EventPublisher
+	watchdog      *Watchdog
 }
 
 type workflowMemory struct {
-	mu        sync.Mutex
+	// mu is the workflow lock. The operations on a single batch item hold it
+	// in read mode along with the lock of the item in batchMu, so the
+	// activities of a parallel batch run don't block each other. The
+	// operations on the whole memory (snapshots, checkpoints, inspection)
+	// hold it in write mode.
+	mu      sync.RWMutex
+	batchMu []sync.Mutex
+	// sharedMu guards the bookkeeping shared by the batch items, i.e. the
+	// size accounting, the changed keys and the streamed outputs. It's
+	// acquired last.
+	sharedMu sync.Mutex
+
 	ID        string
 	Data      []data.Value
 	Recipe    *datamodel.Recipe
 	Streaming bool
 	channel   chan *Event
+
+	// tenant is the namespace the memory belongs to, if any.
+	tenant string
+
+	// sizes holds the size of each batch item, which is checked against
+	// maxSize (if positive) before a value is stored.
+	sizes   []int64
+	maxSize int64
+
+	// checkpoints holds the components whose memory was persisted apart
+	// from the snapshot.
+	checkpoints map[string]bool
+
+	// revision is the revision of the persisted snapshot the memory is based
+	// on, and dirty holds the keys of the batch items (components and
+	// pipeline scopes) changed since then, which are kept when the memory is
+	// merged with the one committed by another worker. dirty is guarded by
+	// sharedMu.
+	revision int64
+	dirty    map[string]bool
+
+	// partitionStore fetches the memory of the components persisted apart
+	// from the snapshot, and pending holds the components that weren't
+	// fetched yet. partitioned tells whether every component is persisted
+	// apart, so the commits only save the changed ones. pending and
+	// partitioned are guarded by mu, and partitionMu serializes the loads.
+	partitionStore PartitionedPersistence
+	pending        map[string]bool
+	partitioned    bool
+	partitionMu    sync.Mutex
+
+	// secrets holds the strings of the secret scope of each batch item,
+	// which are masked when the memory leaves the process.
+	secrets [][]string
+
+	// blobs moves the large files out of the memory, if set.
+	blobs *BlobManager
+
+	// shares counts the references to the values shared by several batch
+	// items, whose size is only counted once. savedSize holds the size of
+	// the extra references.
+	shares    map[data.Value]int
+	savedSize int64
+
+	// patchEvents streams the updates of the component outputs as JSON
+	// Patch operations, computed against the output held by outputStreams.
+	patchEvents   bool
+	outputStreams map[string]*outputStream
+
+	// flushInterval, if positive, is the window in which the events are
+	// coalesced before they're sent to the listeners.
+	flushInterval time.Duration
+	events        eventBuffer
+
+	// publisher routes the events to an event bus, if set.
+	publisher EventPublisher
+
+	// holds counts the activities that use the memory and lastUsed holds
+	// when it was last used (in Unix nanoseconds), which tell whether the
+	// memory can be spilled.
+	holds    atomic.Int32
+	lastUsed atomic.Int64
 }
 
 type ComponentEventType string
@@ -107,6 +208,9 @@ type PipelineEventType string
 type Event struct {
 	Event string `json:"event"`
 	Data  any    `json:"data"`
+	// Version is the schema version of the payload. Events without a
+	// version have the current one.
+	Version int `json:"version,omitempty"`
 }
 
 type PipelineEventData struct {
@@ -125,6 +229,11 @@ type PipelineOutputUpdatedEventData struct {
 	Output any `json:"output"`
 }
 
+type PipelineTimedOutEventData struct {
+	PipelineEventData
+	MaxDuration string `json:"maxDuration"`
+}
+
 type PipelineErrorUpdatedEventData struct {
 	PipelineEventData
 	Error MessageError `json:"error"`
@@ -132,7 +241,7 @@ type PipelineErrorUpdatedEventData struct {
 
 type ComponentEventData struct {
 	UpdateTime  time.Time `json:"updateTime"`
-	ComponentID string    `json:"componentID"`
+	ComponentID string    `json:"componentId"`
 	BatchIndex  int       `json:"batchIndex"`
 
 	Status map[ComponentStatusType]bool `json:"status"`
@@ -151,9 +260,17 @@ type ComponentOutputUpdatedEventData struct {
 	Output any `json:"output"`
 }
 
+// ComponentOutputPatchedEventData holds the JSON Patch operations that turn
+// the output of the previous output event of a component into the current
+// one.
+type ComponentOutputPatchedEventData struct {
+	ComponentEventData
+	Patch []PatchOperation `json:"patch"`
+}
+
 type ComponentErrorUpdatedEventData struct {
 	ComponentEventData
-	Error MessageError `json:"error"`
+	Error ComponentError `json:"error"`
 }
 type MessageError struct {
 	Message string `json:"message"`
@@ -164,28 +281,52 @@ const (
 	PipelineOutputUpdated PipelineEventType = "PIPELINE_OUTPUT_UPDATED"
 	PipelineErrorUpdated  PipelineEventType = "PIPELINE_ERROR_UPDATED"
 	PipelineClosed        PipelineEventType = "PIPELINE_CLOSED"
+	PipelineTimedOut      PipelineEventType = "PIPELINE_TIMED_OUT"
 
 	ComponentStatusUpdated ComponentEventType = "COMPONENT_STATUS_UPDATED"
 	ComponentInputUpdated  ComponentEventType = "COMPONENT_INPUT_UPDATED"
 	ComponentOutputUpdated ComponentEventType = "COMPONENT_OUTPUT_UPDATED"
+	ComponentOutputPatched ComponentEventType = "COMPONENT_OUTPUT_PATCHED"
 	ComponentErrorUpdated  ComponentEventType = "COMPONENT_ERROR_UPDATED"
 )
 
-func init() {
-	gob.Register(ComponentStatusUpdatedEventData{})
-	gob.Register(ComponentInputUpdatedEventData{})
-	gob.Register(ComponentOutputUpdatedEventData{})
-	gob.Register(ComponentErrorUpdatedEventData{})
-	gob.Register(MessageError{})
-	gob.Register(PipelineStatusUpdatedEventData{})
-	gob.Register(PipelineOutputUpdatedEventData{})
-	gob.Register(PipelineErrorUpdatedEventData{})
-}
-
-func NewMemoryStore() MemoryStore {
-	return &memoryStore{
-		workflows: sync.Map{},
+// NewMemoryStore returns a memory store that keeps the workflow memory in
+// the process. If a persistence backend is provided, the committed memory is
+// also saved there and restored when the workflow memory isn't found in the
+// process.
+//
+// A positive maxSize limits the bytes of data each workflow memory holds.
+// Storing a value beyond the limit fails with ErrMemoryLimitExceeded, so a
+// run with large payloads fails instead of exhausting the worker memory.
+//
+// If a blob manager is provided, the large files are kept in its blob store
+// and don't count towards the limit.
+//
+// A positive flushInterval batches the streamed events: the events of a
+// workflow are coalesced and sent at most once per interval, and the pending
+// ones are flushed when the pipeline is closed.
+//
+// If an event publisher is provided, the events are also published to its
+// event bus.
+//
+// If a watchdog is provided, the data held by the workflow memories of the
+// process is limited: the idle memories are spilled to the persistence
+// backend and the heavy triggers are refused when the limit is approached.
+func NewMemoryStore(persistence MemoryPersistence, maxSize int64, blobs *BlobManager, flushInterval time.Duration, publisher EventPublisher, watchdog *Watchdog) MemoryStore {
+	_, encrypted := unwrapAs[*encryptedPersistence](persistence)
+	ms := &memoryStore{
+		workflows:     sync.Map{},
+		persistence:   persistence,
+		encrypted:     encrypted,
+		maxSize:       maxSize,
+		blobs:         blobs,
+		flushInterval: flushInterval,
+		publisher:     publisher,
+		watchdog:      watchdog,
 	}
+	ms.registerMetrics()
+
+	return ms
 }
 
 func (ms *memoryStore) NewWorkflowMemory(ctx context.Context, workflowID string, r *datamodel.Recipe, batchSize int) (workflow WorkflowMemory, err error) {
@@ -201,13 +342,21 @@ func (ms *memoryStore) NewWorkflowMemory(ctx context.Context, workflowID string,
 		wfmData[idx] = m
 	}
 
-	ms.workflows.Store(workflowID, &workflowMemory{
-		mu:      sync.Mutex{},
+	newWFM := &workflowMemory{
 		ID:      workflowID,
 		Data:    wfmData,
 		Recipe:  r,
 		channel: make(chan *Event),
-	})
+		maxSize: ms.maxSize,
+		blobs:   ms.blobs,
+		tenant:  TenantFromContext(ctx),
+
+		flushInterval: ms.flushInterval,
+		publisher:     ms.publisher,
+	}
+	newWFM.touch()
+	newWFM.initBatches()
+	ms.workflows.Store(workflowID, newWFM)
 
 	wfm, ok := ms.workflows.Load(workflowID)
 	if !ok {
@@ -220,17 +369,344 @@ func (ms *memoryStore) NewWorkflowMemory(ctx context.Context, workflowID string,
 func (ms *memoryStore) GetWorkflowMemory(ctx context.Context, workflowID string) (workflow WorkflowMemory, err error) {
 	wfm, ok := ms.workflows.Load(workflowID)
 	if !ok {
-		return nil, fmt.Errorf("workflow memory not found")
+		return ms.restoreWorkflowMemory(ctx, workflowID)
 	}
 
+	wfm.(*workflowMemory).touch()
 	return wfm.(WorkflowMemory), nil
 }
 
 func (ms *memoryStore) PurgeWorkflowMemory(ctx context.Context, workflowID string) (err error) {
-	ms.workflows.Delete(workflowID)
+	ctx, err = ms.tenantContext(ctx, workflowID)
+	if err != nil {
+		return err
+	}
+
+	v, inProcess := ms.workflows.LoadAndDelete(workflowID)
+	if ms.persistence == nil {
+		return nil
+	}
+
+	// The checkpoints are found through the recipe, which is read from the
+	// snapshot if the memory isn't in the process.
+	var wfm *workflowMemory
+	if inProcess {
+		wfm = v.(*workflowMemory)
+	} else if b, err := ms.persistence.Load(ctx, workflowID); err == nil {
+		wfm, _ = unmarshalSnapshot(b)
+	}
+	if wfm != nil && wfm.Recipe != nil {
+		componentIDs := make([]string, 0, len(wfm.Recipe.Component))
+		for id := range wfm.Recipe.Component {
+			componentIDs = append(componentIDs, id)
+		}
+		if err := ms.deleteCheckpoints(ctx, workflowID, componentIDs); err != nil {
+			return err
+		}
+	}
+
+	return ms.persistence.Delete(ctx, workflowID)
+}
+
+// ListWorkflowMemory returns the workflows whose memory is held by the
+// process or by the persistence backend. Backends that can't list their
+// snapshots (e.g. MinIO) only contribute the memory of the process.
+func (ms *memoryStore) ListWorkflowMemory(ctx context.Context) (workflowIDs []string, err error) {
+	seen := map[string]bool{}
+	ms.workflows.Range(func(k, _ any) bool {
+		seen[k.(string)] = true
+		workflowIDs = append(workflowIDs, k.(string))
+		return true
+	})
+
+	if ms.persistence == nil {
+		return workflowIDs, nil
+	}
+
+	persisted, err := listWorkflows(ctx, ms.persistence)
+	if err != nil {
+		return nil, fmt.Errorf("listing persisted workflow memory: %w", err)
+	}
+	for _, id := range persisted {
+		if !seen[id] {
+			workflowIDs = append(workflowIDs, id)
+		}
+	}
+
+	return workflowIDs, nil
+}
+
+// maxCommitAttempts bounds the times a snapshot is merged and saved again
+// when other workers keep committing the memory of the same run.
+const maxCommitAttempts = 5
+
+// CommitWorkflowMemory saves a snapshot of the workflow memory in the
+// persistence backend, if any. The snapshot holds the memory of every
+// component, so the component checkpoints are deleted once it's saved.
+//
+// If the backend is versioned and another worker committed the memory since
+// it was loaded, the persisted memory is merged with the changes of the
+// process and the commit is retried.
+func (ms *memoryStore) CommitWorkflowMemory(ctx context.Context, workflowID string) (err error) {
+	if ms.persistence == nil {
+		return nil
+	}
+
+	v, ok := ms.workflows.Load(workflowID)
+	if !ok {
+		return fmt.Errorf("workflow memory not found")
+	}
+
+	wfm := v.(*workflowMemory)
+	ctx = wfm.tenantContext(ctx)
+	for attempt := 1; ; attempt++ {
+		err := ms.saveSnapshot(ctx, wfm)
+		if !errors.Is(err, ErrRevisionConflict) || attempt == maxCommitAttempts {
+			return err
+		}
+
+		revisionConflicts.Add(ctx, 1)
+		if err := ms.mergePersistedMemory(ctx, wfm); err != nil {
+			return fmt.Errorf("merging concurrent workflow memory: %w", err)
+		}
+	}
+}
+
+// ReleaseWorkflowMemory commits the workflow memory and drops it from the
+// process, so the next activity that uses it restores it from the
+// persistence backend, whichever worker executes it. Without a backend, or
+// if the memory holds secrets that the backend can't encrypt, the memory can
+// only live in the process and it isn't released.
+func (ms *memoryStore) ReleaseWorkflowMemory(ctx context.Context, workflowID string) (released bool, err error) {
+	if ms.persistence == nil {
+		return false, nil
+	}
+
+	v, ok := ms.workflows.Load(workflowID)
+	if !ok {
+		// The memory is already persisted.
+		return true, nil
+	}
+	if err := ms.CommitWorkflowMemory(ctx, workflowID); errors.Is(err, ErrUnencryptedSecrets) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	ms.workflows.CompareAndDelete(workflowID, v)
+
+	return true, nil
+}
+
+func (ms *memoryStore) saveSnapshot(ctx context.Context, wfm *workflowMemory) error {
+	partitionStore, isPartitioned := asPartitioned(ms.persistence)
+
+	wfm.mu.Lock()
+	var b []byte
+	var partitions map[string][]byte
+	var err error
+	switch {
+	case !ms.encrypted && wfm.holdsCredentials():
+		err = ErrUnencryptedSecrets
+	case isPartitioned:
+		b, partitions, err = wfm.marshalPartitions()
+	default:
+		b, err = wfm.marshalSnapshot(nil)
+	}
+	ttl := wfm.ttl()
+	revision := wfm.revision
+	var checkpoints, dirty []string
+	if err == nil {
+		checkpoints = wfm.takeCheckpoints()
+		dirty = wfm.takeDirty()
+	}
+	wfm.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	var newRevision int64
+	if isPartitioned {
+		newRevision, err = partitionStore.SavePartitions(ctx, wfm.ID, b, partitions, ttl, revision)
+	} else {
+		newRevision, err = saveRevision(ctx, ms.persistence, wfm.ID, b, ttl, revision)
+	}
+	wfm.mu.Lock()
+	if err != nil {
+		// The changes are kept for the next commit.
+		for _, id := range checkpoints {
+			wfm.addCheckpoint(id)
+		}
+		for _, key := range dirty {
+			wfm.dirty[key] = true
+		}
+	} else {
+		wfm.partitioned = isPartitioned
+		if wfm.revision == revision {
+			wfm.revision = newRevision
+		}
+	}
+	wfm.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ms.deleteCheckpoints(ctx, wfm.ID, checkpoints)
+}
+
+// mergePersistedMemory replaces the memory of the process with the one
+// persisted by the other workers of the run, except for the keys the process
+// changed since it loaded it. A component is executed by a single activity
+// at a time, so the workers don't change the same keys.
+//
+// The batch items of the process are replaced by the persisted ones while the
+// workflow lock is held in write mode, so no operation on them is in flight,
+// and the next ones find the merged items. The values read from the memory
+// before (e.g. the rendered input of a component that is executing) keep
+// referencing the replaced items, which isn't an issue as the stored outputs
+// and variables are frozen.
+func (ms *memoryStore) mergePersistedMemory(ctx context.Context, wfm *workflowMemory) error {
+	latest, err := ms.loadWorkflowMemory(ctx, wfm.ID)
+	if err != nil {
+		return err
+	}
+
+	wfm.mu.Lock()
+	defer wfm.mu.Unlock()
+
+	if len(latest.Data) != len(wfm.Data) {
+		return fmt.Errorf("persisted memory has %d batch items, expected %d", len(latest.Data), len(wfm.Data))
+	}
+
+	for idx, v := range latest.Data {
+		persisted, persistedOK := v.(*data.Map)
+		local, localOK := wfm.Data[idx].(*data.Map)
+		if persistedOK != localOK {
+			// The changes of the process would be lost.
+			return fmt.Errorf("persisted memory batch item %d doesn't match the one of the process", idx)
+		}
+		if !persistedOK {
+			continue
+		}
+		for key := range wfm.dirty {
+			if value, ok := local.Fields[key]; ok {
+				persisted.Fields[key] = value
+			} else {
+				delete(persisted.Fields, key)
+			}
+		}
+	}
+
+	// The components that weren't changed are fetched again if the latest
+	// memory is partitioned. The changed ones were loaded before they were
+	// written.
+	for key := range wfm.dirty {
+		delete(latest.pending, key)
+	}
+	wfm.pending = latest.pending
+	wfm.partitioned = latest.partitioned
+	wfm.partitionStore = latest.partitionStore
+
+	wfm.Data = latest.Data
+	if wfm.blobs != nil {
+		for _, v := range wfm.Data {
+			wfm.blobs.bind(v)
+		}
+	}
+	wfm.initBatches()
+	for id := range latest.checkpoints {
+		wfm.addCheckpoint(id)
+	}
+	wfm.revision = latest.revision
+
 	return nil
 }
 
+// restoreWorkflowMemory loads the workflow memory from the persistence
+// backend into the process.
+func (ms *memoryStore) restoreWorkflowMemory(ctx context.Context, workflowID string) (WorkflowMemory, error) {
+	if ms.persistence == nil {
+		return nil, fmt.Errorf("workflow memory not found")
+	}
+
+	ctx, err := ms.tenantContext(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	wfm, err := ms.loadWorkflowMemory(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	wfm.maxSize = ms.maxSize
+	wfm.blobs = ms.blobs
+	wfm.flushInterval = ms.flushInterval
+	wfm.publisher = ms.publisher
+	wfm.touch()
+	if wfm.blobs != nil {
+		for _, v := range wfm.Data {
+			wfm.blobs.bind(v)
+		}
+	}
+	wfm.initBatches()
+
+	// The expiration slides on every access, so the memory of a long-running
+	// run doesn't expire while it's still being executed.
+	if err := ms.persistence.Touch(ctx, workflowID, wfm.ttl()); err != nil {
+		return nil, fmt.Errorf("refreshing workflow memory expiration: %w", err)
+	}
+	for componentID := range wfm.checkpoints {
+		if err := ms.persistence.Touch(ctx, checkpointID(workflowID, componentID), wfm.ttl()); err != nil {
+			return nil, fmt.Errorf("refreshing checkpoint expiration: %w", err)
+		}
+	}
+
+	// Another activity might have restored the memory concurrently.
+	v, _ := ms.workflows.LoadOrStore(workflowID, wfm)
+	return v.(WorkflowMemory), nil
+}
+
+// loadWorkflowMemory reads the persisted snapshot of a workflow, along with
+// its revision, and applies the component checkpoints to it.
+func (ms *memoryStore) loadWorkflowMemory(ctx context.Context, workflowID string) (*workflowMemory, error) {
+	b, revision, err := loadRevision(ctx, ms.persistence, workflowID)
+	if err != nil {
+		if errors.Is(err, ErrSnapshotNotFound) {
+			return nil, fmt.Errorf("workflow memory not found")
+		}
+		return nil, fmt.Errorf("loading workflow memory: %w", err)
+	}
+
+	wfm, err := unmarshalSnapshot(b)
+	if err != nil {
+		return nil, err
+	}
+	wfm.tenant = TenantFromContext(ctx)
+	if err := ms.bindPartitions(wfm); err != nil {
+		return nil, err
+	}
+	if err := ms.loadCheckpoints(ctx, wfm); err != nil {
+		return nil, err
+	}
+	wfm.revision = revision
+
+	return wfm, nil
+}
+
+// ttl returns the time the pipeline keeps its persisted memory, or zero to
+// use the default of the deployment.
+func (wfm *workflowMemory) ttl() time.Duration {
+	if wfm.Recipe == nil || wfm.Recipe.MemoryTTL == "" {
+		return 0
+	}
+
+	// The recipe validation rejects invalid durations.
+	ttl, err := data.ParseDuration(wfm.Recipe.MemoryTTL)
+	if err != nil || ttl.GetDuration() < 0 {
+		return 0
+	}
+	return ttl.GetDuration()
+}
+
 func (ms *memoryStore) SendWorkflowStatusEvent(ctx context.Context, workflowID string, event Event) (err error) {
 	wfm, err := ms.GetWorkflowMemory(ctx, workflowID)
 	if err != nil {
@@ -248,41 +724,117 @@ func (wfm *workflowMemory) IsStreaming() bool {
 	return wfm.Streaming
 }
 
+// EnablePatchEvents streams the updates of the component outputs as JSON
+// Patch operations rather than as full outputs.
+func (wfm *workflowMemory) EnablePatchEvents() {
+	wfm.patchEvents = true
+}
+
+// initBatches sets up the locks and the bookkeeping of the batch items. It
+// must be called before the memory is shared, or with the workflow lock held
+// in write mode.
+func (wfm *workflowMemory) initBatches() {
+	wfm.batchMu = make([]sync.Mutex, len(wfm.Data))
+	wfm.computeSizes()
+	wfm.computeSecrets()
+}
+
+// markDirty records that a key of the batch items was changed. It must be
+// called with the lock of the batch item held.
+func (wfm *workflowMemory) markDirty(key string) {
+	wfm.sharedMu.Lock()
+	defer wfm.sharedMu.Unlock()
+
+	if wfm.dirty == nil {
+		wfm.dirty = map[string]bool{}
+	}
+	wfm.dirty[key] = true
+}
+
+// takeDirty returns the changed keys and forgets them. It must be called
+// with the workflow memory lock held in write mode.
+func (wfm *workflowMemory) takeDirty() []string {
+	keys := make([]string, 0, len(wfm.dirty))
+	for key := range wfm.dirty {
+		keys = append(keys, key)
+	}
+	wfm.dirty = map[string]bool{}
+	return keys
+}
+
+// lockBatch locks a batch item for an operation that only reads or writes
+// that item, and returns the function that unlocks it.
+func (wfm *workflowMemory) lockBatch(batchIdx int) (unlock func()) {
+	wfm.mu.RLock()
+	if batchIdx < 0 || batchIdx >= len(wfm.batchMu) {
+		wfm.mu.RUnlock()
+		panic(fmt.Sprintf("batch index %d out of range [0:%d]", batchIdx, len(wfm.batchMu)))
+	}
+
+	mu := &wfm.batchMu[batchIdx]
+	mu.Lock()
+	return func() {
+		mu.Unlock()
+		wfm.mu.RUnlock()
+	}
+}
+
 func (wfm *workflowMemory) InitComponent(ctx context.Context, batchIdx int, componentID string) {
-	wfm.mu.Lock()
-	defer wfm.mu.Unlock()
+	// If the persisted memory of the component can't be loaded, the next
+	// access retries it and keeps the initialized batch item.
+	_ = wfm.loadPartitions(ctx, componentID)
+
+	defer wfm.lockBatch(batchIdx)()
 
 	compMemory := data.NewMap(
 		map[string]data.Value{
 			string(ComponentDataInput):  data.NewMap(nil),
 			string(ComponentDataOutput): data.NewMap(nil),
 			string(ComponentDataSetup):  data.NewMap(nil),
-			string(ComponentDataError): data.NewMap(
-				map[string]data.Value{
-					"message": data.NewString(""),
-				},
-			),
+			string(ComponentDataError):  ComponentError{}.toValue(),
 			string(ComponentDataStatus): data.NewMap(
 				map[string]data.Value{
-					"started":   data.NewBoolean(false),
-					"skipped":   data.NewBoolean(false),
-					"errored":   data.NewBoolean(false),
-					"completed": data.NewBoolean(false),
+					"started":      data.NewBoolean(false),
+					"skipped":      data.NewBoolean(false),
+					"errored":      data.NewBoolean(false),
+					"completed":    data.NewBoolean(false),
+					"rate_limited": data.NewBoolean(false),
 				},
 			),
 		},
 	)
+
+	// The initial component memory is tiny, so it isn't checked against the
+	// limit.
+	_ = wfm.resize(batchIdx, wfm.Data[batchIdx].(*data.Map).Fields[componentID], compMemory, false)
 	wfm.Data[batchIdx].(*data.Map).Fields[componentID] = compMemory
+	wfm.markDirty(componentID)
 }
 
 func (wfm *workflowMemory) SetComponentData(ctx context.Context, batchIdx int, componentID string, t ComponentDataType, value data.Value) (err error) {
-	wfm.mu.Lock()
-	defer wfm.mu.Unlock()
+	if value, err = wfm.blobs.offload(ctx, wfm.ID, value); err != nil {
+		return err
+	}
+	if t == ComponentDataOutput {
+		// The downstream components hold the output through their rendered
+		// inputs, so none of them can modify it in place.
+		data.Freeze(value)
+	}
+	if err := wfm.loadPartitions(ctx, componentID); err != nil {
+		return err
+	}
+
+	defer wfm.lockBatch(batchIdx)()
 
 	if _, ok := wfm.Data[batchIdx].(*data.Map).Fields[componentID]; !ok {
 		return fmt.Errorf("component %s not exist", componentID)
 	}
-	wfm.Data[batchIdx].(*data.Map).Fields[componentID].(*data.Map).Fields[string(t)] = value
+	compMemory := wfm.Data[batchIdx].(*data.Map).Fields[componentID].(*data.Map)
+	if err := wfm.resize(batchIdx, compMemory.Fields[string(t)], value, true); err != nil {
+		return err
+	}
+	compMemory.Fields[string(t)] = value
+	wfm.markDirty(componentID)
 
 	if t == ComponentDataInput {
 		if err := wfm.sendComponentEvent(ctx, batchIdx, componentID, ComponentInputUpdated); err != nil {
@@ -296,8 +848,11 @@ func (wfm *workflowMemory) SetComponentData(ctx context.Context, batchIdx int, c
 	return nil
 }
 func (wfm *workflowMemory) GetComponentData(ctx context.Context, batchIdx int, componentID string, t ComponentDataType) (value data.Value, err error) {
-	wfm.mu.Lock()
-	defer wfm.mu.Unlock()
+	if err := wfm.loadPartitions(ctx, componentID); err != nil {
+		return nil, err
+	}
+
+	defer wfm.lockBatch(batchIdx)()
 
 	if _, ok := wfm.Data[batchIdx].(*data.Map).Fields[componentID]; !ok {
 		return nil, fmt.Errorf("component %s not exist", componentID)
@@ -306,13 +861,17 @@ func (wfm *workflowMemory) GetComponentData(ctx context.Context, batchIdx int, c
 }
 
 func (wfm *workflowMemory) SetComponentStatus(ctx context.Context, batchIdx int, componentID string, t ComponentStatusType, value bool) (err error) {
-	wfm.mu.Lock()
-	defer wfm.mu.Unlock()
+	if err := wfm.loadPartitions(ctx, componentID); err != nil {
+		return err
+	}
+
+	defer wfm.lockBatch(batchIdx)()
 
 	if _, ok := wfm.Data[batchIdx].(*data.Map).Fields[componentID]; !ok {
 		return fmt.Errorf("component %s not exist", componentID)
 	}
 	wfm.Data[batchIdx].(*data.Map).Fields[componentID].(*data.Map).Fields["status"].(*data.Map).Fields[string(t)] = data.NewBoolean(value)
+	wfm.markDirty(componentID)
 
 	if err := wfm.sendComponentEvent(ctx, batchIdx, componentID, ComponentStatusUpdated); err != nil {
 		return err
@@ -320,14 +879,18 @@ func (wfm *workflowMemory) SetComponentStatus(ctx context.Context, batchIdx int,
 
 	return nil
 }
-func (wfm *workflowMemory) SetComponentErrorMessage(ctx context.Context, batchIdx int, componentID string, msg string) (err error) {
-	wfm.mu.Lock()
-	defer wfm.mu.Unlock()
+func (wfm *workflowMemory) SetComponentError(ctx context.Context, batchIdx int, componentID string, compErr ComponentError) (err error) {
+	if err := wfm.loadPartitions(ctx, componentID); err != nil {
+		return err
+	}
+
+	defer wfm.lockBatch(batchIdx)()
 
 	if _, ok := wfm.Data[batchIdx].(*data.Map).Fields[componentID]; !ok {
 		return fmt.Errorf("component %s not exist", componentID)
 	}
-	wfm.Data[batchIdx].(*data.Map).Fields[componentID].(*data.Map).Fields["error"].(*data.Map).Fields["message"] = data.NewString(msg)
+	wfm.Data[batchIdx].(*data.Map).Fields[componentID].(*data.Map).Fields[string(ComponentDataError)] = compErr.toValue()
+	wfm.markDirty(componentID)
 
 	if err := wfm.sendComponentEvent(ctx, batchIdx, componentID, ComponentErrorUpdated); err != nil {
 		return err
@@ -336,8 +899,11 @@ func (wfm *workflowMemory) SetComponentErrorMessage(ctx context.Context, batchId
 	return nil
 }
 func (wfm *workflowMemory) GetComponentStatus(ctx context.Context, batchIdx int, componentID string, t ComponentStatusType) (value bool, err error) {
-	wfm.mu.Lock()
-	defer wfm.mu.Unlock()
+	if err := wfm.loadPartitions(ctx, componentID); err != nil {
+		return false, err
+	}
+
+	defer wfm.lockBatch(batchIdx)()
 
 	if _, ok := wfm.Data[batchIdx].(*data.Map).Fields[componentID]; !ok {
 		return false, fmt.Errorf("component %s not exist", componentID)
@@ -346,14 +912,27 @@ func (wfm *workflowMemory) GetComponentStatus(ctx context.Context, batchIdx int,
 }
 
 func (wfm *workflowMemory) SetPipelineData(ctx context.Context, batchIdx int, t PipelineDataType, value data.Value) (err error) {
-	wfm.mu.Lock()
-	defer wfm.mu.Unlock()
+	if value, err = wfm.blobs.offload(ctx, wfm.ID, value); err != nil {
+		return err
+	}
+	if t == PipelineVariable {
+		data.Freeze(value)
+	}
+
+	defer wfm.lockBatch(batchIdx)()
 
+	if err := wfm.resize(batchIdx, wfm.Data[batchIdx].(*data.Map).Fields[string(t)], value, true); err != nil {
+		return err
+	}
 	wfm.Data[batchIdx].(*data.Map).Fields[string(t)] = value
+	wfm.markDirty(string(t))
+	if t == PipelineSecret {
+		wfm.trackSecrets(batchIdx)
+	}
 
 	if wfm.Streaming {
 		// TODO: simplify struct conversion
-		s, err := value.ToStructValue()
+		s, err := redactValue(value, wfm.secretsOf(batchIdx)).ToStructValue()
 		if err != nil {
 			return err
 		}
@@ -390,8 +969,7 @@ func (wfm *workflowMemory) SetPipelineData(ctx context.Context, batchIdx int, t
 }
 
 func (wfm *workflowMemory) GetPipelineData(ctx context.Context, batchIdx int, t PipelineDataType) (value data.Value, err error) {
-	wfm.mu.Lock()
-	defer wfm.mu.Unlock()
+	defer wfm.lockBatch(batchIdx)()
 
 	if v, ok := wfm.Data[batchIdx].(*data.Map).Fields[string(t)]; !ok {
 		return nil, fmt.Errorf("%s not exist", string(t))
@@ -401,24 +979,37 @@ func (wfm *workflowMemory) GetPipelineData(ctx context.Context, batchIdx int, t
 }
 
 func (wfm *workflowMemory) Set(ctx context.Context, batchIdx int, key string, value data.Value) (err error) {
-	wfm.mu.Lock()
-	defer wfm.mu.Unlock()
+	if value, err = wfm.blobs.offload(ctx, wfm.ID, value); err != nil {
+		return err
+	}
+	if err := wfm.loadPartitions(ctx, key); err != nil {
+		return err
+	}
+
+	defer wfm.lockBatch(batchIdx)()
 
+	if err := wfm.resize(batchIdx, wfm.Data[batchIdx].(*data.Map).Fields[key], value, true); err != nil {
+		return err
+	}
 	wfm.Data[batchIdx].(*data.Map).Fields[key] = value
+	wfm.markDirty(key)
+	if key == string(PipelineSecret) {
+		wfm.trackSecrets(batchIdx)
+	}
 	return nil
 }
 
 func (wfm *workflowMemory) Get(ctx context.Context, batchIdx int, path string) (memory data.Value, err error) {
-	wfm.mu.Lock()
-	defer wfm.mu.Unlock()
+	if err := wfm.loadPath(ctx, path); err != nil {
+		return nil, err
+	}
+
+	defer wfm.lockBatch(batchIdx)()
 
 	return wfm.Data[batchIdx].Get(path)
 
 }
 
-func (wfm *workflowMemory) SendEvent(ctx context.Context, event *Event) {
-	wfm.channel <- event
-}
 func (wfm *workflowMemory) ListenEvent(ctx context.Context) chan *Event {
 	return wfm.channel
 }
@@ -442,16 +1033,18 @@ func (wfm *workflowMemory) getComponentEventData(_ context.Context, batchIdx int
 	skipped := st.Fields[string(ComponentStatusSkipped)].(*data.Boolean).GetBoolean()
 	errored := st.Fields[string(ComponentStatusErrored)].(*data.Boolean).GetBoolean()
 	completed := st.Fields[string(ComponentStatusCompleted)].(*data.Boolean).GetBoolean()
+	rateLimited := st.Fields[string(ComponentStatusRateLimited)].(*data.Boolean).GetBoolean()
 
 	return ComponentEventData{
 		UpdateTime:  time.Now(),
 		ComponentID: componentID,
 		BatchIndex:  batchIdx,
 		Status: map[ComponentStatusType]bool{
-			ComponentStatusStarted:   started,
-			ComponentStatusSkipped:   skipped,
-			ComponentStatusErrored:   errored,
-			ComponentStatusCompleted: completed,
+			ComponentStatusStarted:     started,
+			ComponentStatusSkipped:     skipped,
+			ComponentStatusErrored:     errored,
+			ComponentStatusCompleted:   completed,
+			ComponentStatusRateLimited: rateLimited,
 		},
 	}
 }
@@ -463,6 +1056,7 @@ func (wfm *workflowMemory) sendComponentEvent(ctx context.Context, batchIdx int,
 		switch t {
 		case ComponentInputUpdated:
 			value := wfm.Data[batchIdx].(*data.Map).Fields[componentID].(*data.Map).Fields[string(ComponentDataInput)]
+			value = redactValue(value, wfm.secretsOf(batchIdx))
 
 			// TODO: simplify struct conversion
 			s, err := value.ToStructValue()
@@ -490,6 +1084,7 @@ func (wfm *workflowMemory) sendComponentEvent(ctx context.Context, batchIdx int,
 		case ComponentOutputUpdated:
 
 			value := wfm.Data[batchIdx].(*data.Map).Fields[componentID].(*data.Map).Fields[string(ComponentDataOutput)]
+			value = redactValue(value, wfm.secretsOf(batchIdx))
 
 			// TODO: simplify struct conversion
 			s, err := value.ToStructValue()
@@ -506,6 +1101,19 @@ func (wfm *workflowMemory) sendComponentEvent(ctx context.Context, batchIdx int,
 				return err
 			}
 
+			if wfm.patchEvents {
+				if patch, ok := wfm.outputPatch(batchIdx, componentID, value, data); ok {
+					event = &Event{
+						Event: string(ComponentOutputPatched),
+						Data: ComponentOutputPatchedEventData{
+							ComponentEventData: wfm.getComponentEventData(ctx, batchIdx, componentID),
+							Patch:              patch,
+						},
+					}
+					break
+				}
+			}
+
 			event = &Event{
 				Event: string(ComponentOutputUpdated),
 				Data: ComponentOutputUpdatedEventData{
@@ -515,14 +1123,13 @@ func (wfm *workflowMemory) sendComponentEvent(ctx context.Context, batchIdx int,
 			}
 
 		case ComponentErrorUpdated:
-			message := wfm.Data[batchIdx].(*data.Map).Fields[componentID].(*data.Map).Fields["error"].(*data.Map).Fields["message"].(*data.String)
+			compErr := ComponentErrorFromValue(wfm.Data[batchIdx].(*data.Map).Fields[componentID].(*data.Map).Fields[string(ComponentDataError)])
+			compErr.Message = maskSecrets(compErr.Message, wfm.secretsOf(batchIdx))
 			event = &Event{
 				Event: string(ComponentErrorUpdated),
 				Data: ComponentErrorUpdatedEventData{
 					ComponentEventData: wfm.getComponentEventData(ctx, batchIdx, componentID),
-					Error: MessageError{
-						Message: message.GetString(),
-					},
+					Error:              compErr,
 				},
 			}
 
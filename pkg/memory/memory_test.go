@@ -0,0 +1,248 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// versionedPersistence keeps the snapshots in a map along with their
+// revision, as a backend shared by several workers.
+type versionedPersistence struct {
+	*mapPersistence
+	revisions map[string]int64
+
+	// saveErr, if set, is returned by the next save.
+	saveErr error
+}
+
+func newVersionedPersistence() *versionedPersistence {
+	return &versionedPersistence{mapPersistence: newMapPersistence(), revisions: map[string]int64{}}
+}
+
+func (p *versionedPersistence) LoadRevision(ctx context.Context, workflowID string) ([]byte, int64, error) {
+	b, err := p.Load(ctx, workflowID)
+	if err != nil {
+		return nil, 0, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return b, p.revisions[workflowID], nil
+}
+
+func (p *versionedPersistence) SaveRevision(_ context.Context, workflowID string, snapshot []byte, _ time.Duration, revision int64) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.saveErr; err != nil {
+		p.saveErr = nil
+		return 0, err
+	}
+	if p.revisions[workflowID] != revision {
+		return 0, ErrRevisionConflict
+	}
+	p.snapshots[workflowID] = snapshot
+	p.revisions[workflowID]++
+	return p.revisions[workflowID], nil
+}
+
+func TestCommitWorkflowMemory(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	recipe := &datamodel.Recipe{
+		Component: datamodel.ComponentMap{
+			"a": {Type: "json", Task: "TASK_MARSHAL"},
+			"b": {Type: "json", Task: "TASK_MARSHAL"},
+		},
+	}
+	output := func(s string) data.Value {
+		return data.NewMap(map[string]data.Value{"string": data.NewString(s)})
+	}
+	// setOutput sets the output of a component on a worker.
+	setOutput := func(c *quicktest.C, ms MemoryStore, compID, s string) {
+		wfm, err := ms.GetWorkflowMemory(ctx, "workflow")
+		c.Assert(err, quicktest.IsNil)
+		wfm.InitComponent(ctx, 0, compID)
+		c.Assert(wfm.SetComponentData(ctx, 0, compID, ComponentDataOutput, output(s)), quicktest.IsNil)
+	}
+	// checkOutputs checks the outputs of the components in the committed
+	// memory, as a new worker finds it.
+	checkOutputs := func(c *quicktest.C, p MemoryPersistence, want map[string]string) {
+		wfm, err := NewMemoryStore(p, 0, nil, 0, nil, nil).GetWorkflowMemory(ctx, "workflow")
+		c.Assert(err, quicktest.IsNil)
+		for compID, s := range want {
+			got, err := wfm.GetComponentData(ctx, 0, compID, ComponentDataOutput)
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, valueEquals, output(s), quicktest.Commentf(compID))
+		}
+	}
+	// newWorkers returns two workers that load the memory of the same run.
+	newWorkers := func(c *quicktest.C, p MemoryPersistence) (MemoryStore, MemoryStore) {
+		first := NewMemoryStore(p, 0, nil, 0, nil, nil)
+		_, err := first.NewWorkflowMemory(ctx, "workflow", recipe, 1)
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(first.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+
+		second := NewMemoryStore(p, 0, nil, 0, nil, nil)
+		_, err = second.GetWorkflowMemory(ctx, "workflow")
+		c.Assert(err, quicktest.IsNil)
+		return first, second
+	}
+
+	c.Run("ok - concurrent commits", func(c *quicktest.C) {
+		p := newVersionedPersistence()
+		first, second := newWorkers(c, p)
+
+		setOutput(c, first, "a", "from first")
+		setOutput(c, second, "b", "from second")
+		c.Assert(first.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+		// The memory of the second worker is merged with the one committed
+		// by the first.
+		c.Assert(second.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+
+		checkOutputs(c, p, map[string]string{"a": "from first", "b": "from second"})
+		c.Check(p.revisions["workflow"], quicktest.Equals, int64(3))
+	})
+
+	c.Run("ok - failed save", func(c *quicktest.C) {
+		p := newVersionedPersistence()
+		first, second := newWorkers(c, p)
+
+		setOutput(c, first, "a", "from first")
+		p.saveErr = errors.New("backend unavailable")
+		c.Check(first.CommitWorkflowMemory(ctx, "workflow"), quicktest.ErrorMatches, "backend unavailable")
+
+		setOutput(c, second, "b", "from second")
+		c.Assert(second.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+
+		// The change of the first worker is still kept when the memory is
+		// merged.
+		c.Assert(first.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+		checkOutputs(c, p, map[string]string{"a": "from first", "b": "from second"})
+	})
+
+	c.Run("nok - batch items mismatch", func(c *quicktest.C) {
+		p := newVersionedPersistence()
+		first, _ := newWorkers(c, p)
+
+		// Another run with more batch items is persisted in place of the
+		// run.
+		other := NewMemoryStore(p, 0, nil, 0, nil, nil)
+		_, err := other.NewWorkflowMemory(ctx, "other", recipe, 2)
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(other.CommitWorkflowMemory(ctx, "other"), quicktest.IsNil)
+		p.snapshots["workflow"] = p.snapshots["other"]
+		p.revisions["workflow"]++
+
+		setOutput(c, first, "a", "from first")
+		err = first.CommitWorkflowMemory(ctx, "workflow")
+		c.Check(err, quicktest.ErrorMatches, "merging concurrent workflow memory: persisted memory has 2 batch items, expected 1")
+	})
+}
+
+func TestWorkflowMemoryConcurrency(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	const batchSize, iterations = 8, 50
+	recipe := &datamodel.Recipe{
+		Component: datamodel.ComponentMap{"a": {Type: "json", Task: "TASK_MARSHAL"}},
+	}
+	output := func(idx, i int) data.Value {
+		return data.NewMap(map[string]data.Value{"string": data.NewString(fmt.Sprintf("item %d, iteration %d", idx, i))})
+	}
+	// write runs the operations of an activity on a batch item.
+	write := func(wfm WorkflowMemory, idx int) error {
+		wfm.InitComponent(ctx, idx, "a")
+		for i := range iterations {
+			if err := wfm.SetComponentData(ctx, idx, "a", ComponentDataOutput, output(idx, i)); err != nil {
+				return err
+			}
+			variable := data.NewMap(map[string]data.Value{"i": data.NewNumberFromInteger(i)})
+			if err := wfm.Set(ctx, idx, string(PipelineVariable), variable); err != nil {
+				return err
+			}
+			if _, err := wfm.GetComponentData(ctx, idx, "a", ComponentDataOutput); err != nil {
+				return err
+			}
+			_ = wfm.GetMemorySize()
+		}
+		return nil
+	}
+
+	p := newVersionedPersistence()
+	ms := NewMemoryStore(p, 1<<20, nil, 0, nil, nil)
+	wfm, err := ms.NewWorkflowMemory(ctx, "workflow", recipe, batchSize)
+	c.Assert(err, quicktest.IsNil)
+
+	// The activities of a parallel batch run write their batch item while
+	// the memory is committed.
+	var wg sync.WaitGroup
+	errs := make(chan error, batchSize+1)
+	for idx := range batchSize {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := write(wfm, idx); err != nil {
+				errs <- err
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	var committer sync.WaitGroup
+	committer.Add(1)
+	go func() {
+		defer committer.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if err := ms.CommitWorkflowMemory(ctx, "workflow"); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(done)
+	committer.Wait()
+	close(errs)
+	for err := range errs {
+		c.Check(err, quicktest.IsNil)
+	}
+
+	// The last writes of every batch item are committed.
+	c.Assert(ms.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+	restored, err := NewMemoryStore(p, 0, nil, 0, nil, nil).GetWorkflowMemory(ctx, "workflow")
+	c.Assert(err, quicktest.IsNil)
+	for idx := range batchSize {
+		got, err := restored.GetComponentData(ctx, idx, "a", ComponentDataOutput)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, valueEquals, output(idx, iterations-1), quicktest.Commentf("item %d", idx))
+
+		variable, err := restored.Get(ctx, idx, "variable.i")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(variable, valueEquals, data.NewNumberFromInteger(iterations-1))
+	}
+
+	// No size update is lost, i.e. the sizes match the ones of the same
+	// writes made one after the other.
+	sequential, err := NewMemoryStore(nil, 1<<20, nil, 0, nil, nil).NewWorkflowMemory(ctx, "sequential", recipe, batchSize)
+	c.Assert(err, quicktest.IsNil)
+	for idx := range batchSize {
+		c.Assert(write(sequential, idx), quicktest.IsNil)
+	}
+	c.Check(wfm.GetMemorySize(), quicktest.DeepEquals, sequential.GetMemorySize())
+}
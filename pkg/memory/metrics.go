@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// The memory store is instrumented with OpenTelemetry metrics, which the
+// collector exports (e.g. to Prometheus) along with the rest of metrics of
+// the backend. The instruments are created from the global meter provider,
+// so they're no-ops until the metrics are set up.
+
+const meterName = "github.com/instill-ai/pipeline-backend/pkg/memory"
+
+var (
+	persistenceDuration metric.Float64Histogram
+	persistenceErrors   metric.Int64Counter
+	revisionConflicts   metric.Int64Counter
+	publishFailures     metric.Int64Counter
+)
+
+func init() {
+	meter := otel.Meter(meterName)
+
+	persistenceDuration, _ = meter.Float64Histogram(
+		"pipeline_memory_persistence_duration_seconds",
+		metric.WithDescription("Time it takes to save and load the workflow memory snapshots in the persistence backend."),
+		metric.WithUnit("s"),
+	)
+	persistenceErrors, _ = meter.Int64Counter(
+		"pipeline_memory_persistence_errors_total",
+		metric.WithDescription("Failed operations on the persistence backend of the workflow memory."),
+	)
+	revisionConflicts, _ = meter.Int64Counter(
+		"pipeline_memory_revision_conflicts_total",
+		metric.WithDescription("Snapshots that were saved concurrently by another worker and had to be merged."),
+	)
+	publishFailures, _ = meter.Int64Counter(
+		"pipeline_memory_event_publish_failures_total",
+		metric.WithDescription("Events that couldn't be published to the event bus."),
+	)
+}
+
+// registerMetrics reports the workflow memories held by the store and the
+// bytes of data they hold each time the metrics are collected.
+func (ms *memoryStore) registerMetrics() {
+	meter := otel.Meter(meterName)
+
+	workflows, err := meter.Int64ObservableGauge(
+		"pipeline_memory_workflows",
+		metric.WithDescription("Workflow memories held by the process."),
+	)
+	if err != nil {
+		return
+	}
+	heldBytes, err := meter.Int64ObservableGauge(
+		"pipeline_memory_bytes",
+		metric.WithDescription("Bytes of data held by the workflow memories of the process."),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return
+	}
+	limitBytes, err := meter.Int64ObservableGauge(
+		"pipeline_memory_limit_bytes",
+		metric.WithDescription("Bytes of data the workflow memories of the process can hold, or 0 if it isn't limited."),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return
+	}
+
+	_, _ = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		usage := ms.MemoryUsage()
+		var count int64
+		ms.workflows.Range(func(_, _ any) bool {
+			count++
+			return true
+		})
+
+		o.ObserveInt64(workflows, count)
+		o.ObserveInt64(heldBytes, usage.Total)
+		o.ObserveInt64(limitBytes, usage.Limit)
+		return nil
+	}, workflows, heldBytes, limitBytes)
+}
+
+type instrumentedPersistence struct {
+	MemoryPersistence
+	attrs metric.MeasurementOption
+}
+
+// NewInstrumentedPersistence wraps a persistence backend so the latency and
+// the failures of its operations are measured, labelled with the name of
+// the backend.
+func NewInstrumentedPersistence(p MemoryPersistence, backend string) MemoryPersistence {
+	return &instrumentedPersistence{
+		MemoryPersistence: p,
+		attrs:             metric.WithAttributes(attribute.String("backend", backend)),
+	}
+}
+
+func (p *instrumentedPersistence) record(ctx context.Context, op string, start time.Time, err error) {
+	opAttr := metric.WithAttributes(attribute.String("operation", op))
+	persistenceDuration.Record(ctx, time.Since(start).Seconds(), p.attrs, opAttr)
+	if err != nil && !errors.Is(err, ErrSnapshotNotFound) && !errors.Is(err, ErrRevisionConflict) {
+		persistenceErrors.Add(ctx, 1, p.attrs, opAttr)
+	}
+}
+
+func (p *instrumentedPersistence) Save(ctx context.Context, workflowID string, snapshot []byte, ttl time.Duration) (err error) {
+	start := time.Now()
+	defer func() { p.record(ctx, "save", start, err) }()
+	return p.MemoryPersistence.Save(ctx, workflowID, snapshot, ttl)
+}
+
+func (p *instrumentedPersistence) Load(ctx context.Context, workflowID string) (snapshot []byte, err error) {
+	start := time.Now()
+	defer func() { p.record(ctx, "load", start, err) }()
+	return p.MemoryPersistence.Load(ctx, workflowID)
+}
+
+func (p *instrumentedPersistence) SaveRevision(ctx context.Context, workflowID string, snapshot []byte, ttl time.Duration, revision int64) (newRevision int64, err error) {
+	start := time.Now()
+	defer func() { p.record(ctx, "save", start, err) }()
+	return saveRevision(ctx, p.MemoryPersistence, workflowID, snapshot, ttl, revision)
+}
+
+func (p *instrumentedPersistence) LoadRevision(ctx context.Context, workflowID string) (snapshot []byte, revision int64, err error) {
+	start := time.Now()
+	defer func() { p.record(ctx, "load", start, err) }()
+	return loadRevision(ctx, p.MemoryPersistence, workflowID)
+}
+
+func (p *instrumentedPersistence) SavePartitions(ctx context.Context, workflowID string, snapshot []byte, partitions map[string][]byte, ttl time.Duration, revision int64) (newRevision int64, err error) {
+	start := time.Now()
+	defer func() { p.record(ctx, "save", start, err) }()
+	return savePartitionsTo(ctx, p.MemoryPersistence, workflowID, snapshot, partitions, ttl, revision)
+}
+
+func (p *instrumentedPersistence) LoadPartitions(ctx context.Context, workflowID string, componentIDs []string) (partitions map[string][]byte, err error) {
+	start := time.Now()
+	defer func() { p.record(ctx, "load_partitions", start, err) }()
+	return loadPartitionsFrom(ctx, p.MemoryPersistence, workflowID, componentIDs)
+}
+
+func (p *instrumentedPersistence) ListWorkflows(ctx context.Context) ([]string, error) {
+	return listWorkflows(ctx, p.MemoryPersistence)
+}
+
+func (p *instrumentedPersistence) Unwrap() MemoryPersistence {
+	return p.MemoryPersistence
+}
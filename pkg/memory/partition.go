@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+// With a partitioned backend, the memory of each component is persisted
+// apart from the snapshot, in the checkpoint format, and the snapshot only
+// holds the pipeline scopes and the list of components. When the memory is
+// restored, the components aren't fetched until an activity reads or writes
+// them, so the activities of large runs don't decode the outputs of every
+// component they don't use.
+//
+// Once the memory is partitioned, a commit only saves the components that
+// changed since the memory was loaded.
+
+// pipelineScopes are the keys of a batch item that are kept in the snapshot.
+var pipelineScopes = map[string]bool{
+	string(PipelineVariable):       true,
+	string(PipelineSecret):         true,
+	string(PipelineConnection):     true,
+	string(PipelineOutput):         true,
+	string(PipelineOutputTemplate): true,
+}
+
+// withoutFields returns a batch item without some of its keys.
+func withoutFields(v data.Value, keys []string) data.Value {
+	m, ok := v.(*data.Map)
+	if !ok || len(keys) == 0 {
+		return v
+	}
+
+	excluded := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		excluded[k] = true
+	}
+	fields := make(map[string]data.Value, len(m.Fields))
+	for k, f := range m.Fields {
+		if !excluded[k] {
+			fields[k] = f
+		}
+	}
+	return data.NewMap(fields)
+}
+
+// partitionIDs returns the keys of the batch items that are persisted apart,
+// including the ones that weren't loaded. It must be called with the
+// workflow memory lock held.
+func (wfm *workflowMemory) partitionIDs() []string {
+	seen := map[string]bool{}
+	for id := range wfm.pending {
+		seen[id] = true
+	}
+	for _, v := range wfm.Data {
+		if m, ok := v.(*data.Map); ok {
+			for k := range m.Fields {
+				if !pipelineScopes[k] {
+					seen[k] = true
+				}
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// marshalPartitions encodes the snapshot of a partitioned memory and the
+// components that need to be saved along with it. The components that
+// weren't loaded are already persisted. It must be called with the workflow
+// memory lock held in write mode.
+func (wfm *workflowMemory) marshalPartitions() (snapshot []byte, partitions map[string][]byte, err error) {
+	ids := wfm.partitionIDs()
+
+	partitions = map[string][]byte{}
+	for _, id := range ids {
+		if wfm.pending[id] || (wfm.partitioned && !wfm.dirty[id]) {
+			continue
+		}
+		if partitions[id], err = wfm.marshalCheckpoint(id); err != nil {
+			return nil, nil, fmt.Errorf("encoding component %s: %w", id, err)
+		}
+	}
+
+	if snapshot, err = wfm.marshalSnapshot(ids); err != nil {
+		return nil, nil, err
+	}
+	return snapshot, partitions, nil
+}
+
+// bindPartitions sets the backend the pending components of a restored
+// memory are fetched from.
+func (ms *memoryStore) bindPartitions(wfm *workflowMemory) error {
+	if len(wfm.pending) == 0 {
+		return nil
+	}
+
+	partitionStore, ok := asPartitioned(ms.persistence)
+	if !ok {
+		return fmt.Errorf("workflow memory is partitioned but the persistence backend doesn't support it")
+	}
+	wfm.partitionStore = partitionStore
+	return nil
+}
+
+// pathRoot returns the key of the batch item a memory path starts with, or
+// an empty string if the path refers to the whole item.
+func pathRoot(path string) string {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
+// loadPath loads the component a memory path refers to, if it's pending. A
+// path to the whole batch item loads every pending component.
+func (wfm *workflowMemory) loadPath(ctx context.Context, path string) error {
+	if root := pathRoot(path); root != "" {
+		return wfm.loadPartitions(ctx, root)
+	}
+	return wfm.loadPartitions(ctx)
+}
+
+// loadPartitions fetches the memory of the given components, or of every
+// component if none is given, when it wasn't loaded yet. It must be called
+// without the workflow memory lock held.
+func (wfm *workflowMemory) loadPartitions(ctx context.Context, componentIDs ...string) error {
+	wfm.mu.RLock()
+	ids := wfm.pendingOf(componentIDs)
+	wfm.mu.RUnlock()
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// The loads are serialized so a component is only fetched once.
+	wfm.partitionMu.Lock()
+	defer wfm.partitionMu.Unlock()
+
+	for {
+		wfm.mu.RLock()
+		ids = wfm.pendingOf(componentIDs)
+		revision := wfm.revision
+		store := wfm.partitionStore
+		wfm.mu.RUnlock()
+		if len(ids) == 0 {
+			return nil
+		}
+
+		partitions, err := store.LoadPartitions(wfm.tenantContext(ctx), wfm.ID, ids)
+		if err != nil {
+			return fmt.Errorf("loading component memory: %w", err)
+		}
+
+		wfm.mu.Lock()
+		if wfm.revision != revision {
+			// The memory was merged with a newer revision in the meantime,
+			// so the pending components are fetched again.
+			wfm.mu.Unlock()
+			continue
+		}
+		err = wfm.applyPartitions(ids, partitions)
+		wfm.mu.Unlock()
+		return err
+	}
+}
+
+// pendingOf returns the given components that weren't loaded, or every
+// pending component if none is given. A memory with pending components
+// always has a partition store. It must be called with the workflow memory
+// lock held.
+func (wfm *workflowMemory) pendingOf(componentIDs []string) []string {
+	if len(wfm.pending) == 0 {
+		return nil
+	}
+
+	var ids []string
+	if len(componentIDs) == 0 {
+		for id := range wfm.pending {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	for _, id := range componentIDs {
+		if wfm.pending[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// applyPartitions sets the fetched memory of some components. The batch
+// items where a component was written since the memory was loaded keep
+// their value. It must be called with the workflow memory lock held in write
+// mode.
+func (wfm *workflowMemory) applyPartitions(ids []string, partitions map[string][]byte) error {
+	for _, id := range ids {
+		var values []data.Value
+		if b, ok := partitions[id]; ok {
+			var err error
+			if values, err = unmarshalCheckpoint(b); err != nil {
+				return fmt.Errorf("decoding memory of component %s: %w", id, err)
+			}
+			if len(values) != len(wfm.Data) {
+				return fmt.Errorf("memory of component %s has %d batch items, expected %d", id, len(values), len(wfm.Data))
+			}
+		}
+
+		for idx, v := range values {
+			batch, ok := wfm.Data[idx].(*data.Map)
+			if !ok {
+				continue
+			}
+			if _, isNull := v.(*data.Null); isNull {
+				continue
+			}
+			if _, written := batch.Fields[id]; written {
+				continue
+			}
+			if wfm.blobs != nil {
+				wfm.blobs.bind(v)
+			}
+			_ = wfm.resize(idx, nil, v, false)
+			batch.Fields[id] = v
+		}
+		delete(wfm.pending, id)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// partitionedPersistence keeps the memory of the components apart from the
+// snapshots, and records the components it saves and loads.
+type partitionedPersistence struct {
+	*versionedPersistence
+	partitions map[string]map[string][]byte
+
+	saved  [][]string
+	loaded [][]string
+}
+
+func newPartitionedPersistence() *partitionedPersistence {
+	return &partitionedPersistence{versionedPersistence: newVersionedPersistence(), partitions: map[string]map[string][]byte{}}
+}
+
+func (p *partitionedPersistence) SavePartitions(ctx context.Context, workflowID string, snapshot []byte, partitions map[string][]byte, ttl time.Duration, revision int64) (int64, error) {
+	newRevision, err := p.SaveRevision(ctx, workflowID, snapshot, ttl, revision)
+	if err != nil {
+		return 0, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.partitions[workflowID] == nil {
+		p.partitions[workflowID] = map[string][]byte{}
+	}
+	ids := make([]string, 0, len(partitions))
+	for id, b := range partitions {
+		p.partitions[workflowID][id] = b
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	p.saved = append(p.saved, ids)
+	return newRevision, nil
+}
+
+func (p *partitionedPersistence) LoadPartitions(_ context.Context, workflowID string, componentIDs []string) (map[string][]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	partitions := map[string][]byte{}
+	for _, id := range componentIDs {
+		if b, ok := p.partitions[workflowID][id]; ok {
+			partitions[id] = b
+		}
+	}
+	p.loaded = append(p.loaded, append([]string(nil), componentIDs...))
+	return partitions, nil
+}
+
+func TestPartitionedWorkflowMemory(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	recipe := &datamodel.Recipe{
+		Component: datamodel.ComponentMap{
+			"a": {Type: "json", Task: "TASK_MARSHAL"},
+			"b": {Type: "json", Task: "TASK_MARSHAL"},
+		},
+	}
+	output := func(s string) data.Value {
+		return data.NewMap(map[string]data.Value{"string": data.NewString(s)})
+	}
+	checkOutput := func(c *quicktest.C, wfm WorkflowMemory, idx int, compID, want string) {
+		got, err := wfm.GetComponentData(ctx, idx, compID, ComponentDataOutput)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, valueEquals, output(want), quicktest.Commentf("%s of item %d", compID, idx))
+	}
+
+	p := newPartitionedPersistence()
+	first := NewMemoryStore(p, 0, nil, 0, nil, nil)
+	wfm, err := first.NewWorkflowMemory(ctx, "workflow", recipe, 2)
+	c.Assert(err, quicktest.IsNil)
+	for idx := range 2 {
+		c.Assert(wfm.Set(ctx, idx, string(PipelineVariable), data.NewMap(map[string]data.Value{"x": data.NewString("pipeline variable")})), quicktest.IsNil)
+		for _, compID := range []string{"a", "b"} {
+			wfm.InitComponent(ctx, idx, compID)
+			c.Assert(wfm.SetComponentData(ctx, idx, compID, ComponentDataOutput, output("output of "+compID)), quicktest.IsNil)
+		}
+	}
+	c.Assert(first.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+
+	// The components are persisted apart from the snapshot.
+	c.Check(p.saved, quicktest.DeepEquals, [][]string{{"a", "b"}})
+	c.Check(bytes.Contains(p.snapshots["workflow"], []byte("output of")), quicktest.IsFalse)
+	c.Check(bytes.Contains(p.snapshots["workflow"], []byte("pipeline variable")), quicktest.IsTrue)
+
+	c.Run("ok - lazy load", func(c *quicktest.C) {
+		p.loaded = nil
+		wfm, err := NewMemoryStore(p, 0, nil, 0, nil, nil).GetWorkflowMemory(ctx, "workflow")
+		c.Assert(err, quicktest.IsNil)
+
+		// The pipeline scopes are in the snapshot.
+		x, err := wfm.Get(ctx, 1, "variable.x")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(x, valueEquals, data.NewString("pipeline variable"))
+		c.Check(p.loaded, quicktest.HasLen, 0)
+
+		// A component is fetched the first time it's accessed, for every
+		// batch item.
+		checkOutput(c, wfm, 0, "a", "output of a")
+		checkOutput(c, wfm, 1, "a", "output of a")
+		c.Check(p.loaded, quicktest.DeepEquals, [][]string{{"a"}})
+
+		got, err := wfm.Get(ctx, 1, "b.output.string")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(got, valueEquals, data.NewString("output of b"))
+		c.Check(p.loaded, quicktest.DeepEquals, [][]string{{"a"}, {"b"}})
+	})
+
+	c.Run("ok - commit changed components", func(c *quicktest.C) {
+		p.saved, p.loaded = nil, nil
+		second := NewMemoryStore(p, 0, nil, 0, nil, nil)
+		wfm, err := second.GetWorkflowMemory(ctx, "workflow")
+		c.Assert(err, quicktest.IsNil)
+
+		c.Assert(wfm.SetComponentData(ctx, 0, "a", ComponentDataOutput, output("new output of a")), quicktest.IsNil)
+		c.Assert(second.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+
+		// Only the component that changed is saved, and the one that
+		// wasn't loaded is kept.
+		c.Check(p.saved, quicktest.DeepEquals, [][]string{{"a"}})
+		c.Check(p.loaded, quicktest.DeepEquals, [][]string{{"a"}})
+
+		restored, err := NewMemoryStore(p, 0, nil, 0, nil, nil).GetWorkflowMemory(ctx, "workflow")
+		c.Assert(err, quicktest.IsNil)
+		checkOutput(c, restored, 0, "a", "new output of a")
+		checkOutput(c, restored, 1, "a", "output of a")
+		checkOutput(c, restored, 0, "b", "output of b")
+		checkOutput(c, restored, 1, "b", "output of b")
+	})
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+// The output of a streaming component (e.g. an LLM that generates a text
+// token by token) is updated many times, each update holding the whole
+// output accumulated so far. When the patch events are enabled, the
+// listeners receive the full output once and then the RFC 6902 JSON Patch
+// operations that turn the last sent output into the new one. A full
+// snapshot is still sent periodically, so the listeners can recover from a
+// patch they failed to apply.
+
+// patchSnapshotInterval is the number of patch events sent for a component
+// output between two full snapshots.
+const patchSnapshotInterval = 50
+
+// JSON Patch operations. PatchOpAppend is an extension to RFC 6902 that
+// appends its value to the string at the path, so a growing text doesn't
+// have to be replaced.
+const (
+	PatchOpAdd     = "add"
+	PatchOpRemove  = "remove"
+	PatchOpReplace = "replace"
+	PatchOpAppend  = "append"
+)
+
+// PatchOperation is a JSON Patch operation.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// MarshalJSON omits the value of the remove operations, as a null value is
+// a valid value for the rest of operations.
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == PatchOpRemove {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{Op: o.Op, Path: o.Path})
+	}
+
+	type operation PatchOperation
+	return json.Marshal(operation(o))
+}
+
+// outputStream holds the last output sent to the listeners of a component.
+type outputStream struct {
+	last    data.Value
+	patches int
+}
+
+// outputPatch returns the operations that turn the last output sent for a
+// component into a new one, whose JSON representation is output. It returns
+// false when a full snapshot should be sent instead, which is then recorded
+// as the last output.
+func (wfm *workflowMemory) outputPatch(batchIdx int, componentID string, value data.Value, output any) ([]PatchOperation, bool) {
+	wfm.sharedMu.Lock()
+	defer wfm.sharedMu.Unlock()
+
+	if wfm.outputStreams == nil {
+		wfm.outputStreams = map[string]*outputStream{}
+	}
+
+	// The output is kept to compute the next patch, so it mustn't be
+	// modified in place.
+	data.Share(value)
+
+	key := fmt.Sprintf("%d/%s", batchIdx, componentID)
+	stream, ok := wfm.outputStreams[key]
+	if !ok || stream.patches >= patchSnapshotInterval {
+		wfm.outputStreams[key] = &outputStream{last: value}
+		return nil, false
+	}
+
+	ops, err := patchOperations(data.Diff(stream.last, value))
+	if err != nil {
+		wfm.outputStreams[key] = &outputStream{last: value}
+		return nil, false
+	}
+
+	// A patch that isn't smaller than the output isn't worth it.
+	patchSize, err := json.Marshal(ops)
+	if err != nil {
+		return nil, false
+	}
+	outputSize, err := json.Marshal(output)
+	if err != nil || len(patchSize) >= len(outputSize) {
+		wfm.outputStreams[key] = &outputStream{last: value}
+		return nil, false
+	}
+
+	stream.last = value
+	stream.patches++
+	return ops, true
+}
+
+// patchOperations converts the changes between two values into JSON Patch
+// operations.
+func patchOperations(changes data.Changeset) ([]PatchOperation, error) {
+	ops := make([]PatchOperation, 0, len(changes))
+	for _, c := range changes {
+		op := PatchOperation{Path: c.Path.Pointer()}
+		switch c.Type {
+		case data.ChangeRemoved:
+			op.Op = PatchOpRemove
+			ops = append(ops, op)
+			continue
+		case data.ChangeAdded:
+			op.Op = PatchOpAdd
+
+			// The added items go after the last one.
+			if last := len(c.Path) - 1; last >= 0 && c.Path[last].IsIndex {
+				op.Path = c.Path[:last].Pointer() + "/-"
+			}
+		default:
+			op.Op = PatchOpReplace
+
+			// A growing text is appended rather than replaced.
+			from, fromIsString := c.From.(*data.String)
+			to, toIsString := c.To.(*data.String)
+			if fromIsString && toIsString && len(from.Raw) > 0 && strings.HasPrefix(to.Raw, from.Raw) {
+				ops = append(ops, PatchOperation{Op: PatchOpAppend, Path: op.Path, Value: to.Raw[len(from.Raw):]})
+				continue
+			}
+		}
+
+		v, err := c.To.ToStructValue()
+		if err != nil {
+			return nil, err
+		}
+		op.Value = v.AsInterface()
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
@@ -0,0 +1,275 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+// applyPatch applies the JSON Patch operations to a JSON document, as the
+// listeners of the patch events do.
+func applyPatch(doc any, ops []map[string]any) (any, error) {
+	for _, op := range ops {
+		path, _ := op["path"].(string)
+		var err error
+		doc, err = applyOperation(doc, op["op"].(string), splitPointer(path), op["value"])
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op["op"], path, err)
+		}
+	}
+	return doc, nil
+}
+
+func splitPointer(p string) []string {
+	if p == "" {
+		return nil
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+func applyOperation(doc any, op string, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		switch op {
+		case PatchOpAdd, PatchOpReplace:
+			return value, nil
+		case PatchOpAppend:
+			s, ok := doc.(string)
+			if !ok {
+				return nil, fmt.Errorf("can't append to %T", doc)
+			}
+			return s + value.(string), nil
+		}
+		return nil, fmt.Errorf("can't %s the root", op)
+	}
+
+	token, last := tokens[0], len(tokens) == 1
+	switch d := doc.(type) {
+	case map[string]any:
+		if last && op == PatchOpRemove {
+			if _, ok := d[token]; !ok {
+				return nil, fmt.Errorf("missing field %q", token)
+			}
+			delete(d, token)
+			return d, nil
+		}
+		if last && op == PatchOpAdd {
+			d[token] = value
+			return d, nil
+		}
+		f, ok := d[token]
+		if !ok {
+			return nil, fmt.Errorf("missing field %q", token)
+		}
+		v, err := applyOperation(f, op, tokens[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		d[token] = v
+		return d, nil
+
+	case []any:
+		if last && op == PatchOpAdd && token == "-" {
+			return append(d, value), nil
+		}
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= len(d) {
+			return nil, fmt.Errorf("invalid index %q", token)
+		}
+		if last && op == PatchOpRemove {
+			return append(d[:i], d[i+1:]...), nil
+		}
+		v, err := applyOperation(d[i], op, tokens[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		d[i] = v
+		return d, nil
+	}
+	return nil, fmt.Errorf("can't %s %q in %T", op, token, doc)
+}
+
+// jsonValue returns the JSON representation of a value, as it's sent to the
+// listeners.
+func jsonValue(c *quicktest.C, v data.Value) any {
+	s, err := v.ToStructValue()
+	c.Assert(err, quicktest.IsNil)
+	b, err := json.Marshal(s.AsInterface())
+	c.Assert(err, quicktest.IsNil)
+	var doc any
+	c.Assert(json.Unmarshal(b, &doc), quicktest.IsNil)
+	return doc
+}
+
+// sentOperations returns the operations as the listeners decode them.
+func sentOperations(c *quicktest.C, ops []PatchOperation) []map[string]any {
+	b, err := json.Marshal(ops)
+	c.Assert(err, quicktest.IsNil)
+	var sent []map[string]any
+	c.Assert(json.Unmarshal(b, &sent), quicktest.IsNil)
+	return sent
+}
+
+func TestPatchOperations(t *testing.T) {
+	c := quicktest.New(t)
+
+	stringArray := func(ss ...string) *data.Array {
+		values := make([]data.Value, len(ss))
+		for i, s := range ss {
+			values[i] = data.NewString(s)
+		}
+		return data.NewArray(values)
+	}
+
+	testcases := []struct {
+		name    string
+		from    data.Value
+		to      data.Value
+		wantOps string
+	}{
+		{
+			name:    "growing text",
+			from:    data.NewMap(map[string]data.Value{"text": data.NewString("Hello")}),
+			to:      data.NewMap(map[string]data.Value{"text": data.NewString("Hello, world")}),
+			wantOps: `[{"op":"append","path":"/text","value":", world"}]`,
+		},
+		{
+			// An empty text is replaced, as appending to it doesn't save
+			// anything.
+			name:    "text from empty",
+			from:    data.NewMap(map[string]data.Value{"text": data.NewString("")}),
+			to:      data.NewMap(map[string]data.Value{"text": data.NewString("Hello")}),
+			wantOps: `[{"op":"replace","path":"/text","value":"Hello"}]`,
+		},
+		{
+			name:    "rewritten text",
+			from:    data.NewMap(map[string]data.Value{"text": data.NewString("Hello")}),
+			to:      data.NewMap(map[string]data.Value{"text": data.NewString("Goodbye")}),
+			wantOps: `[{"op":"replace","path":"/text","value":"Goodbye"}]`,
+		},
+		{
+			name:    "appended items",
+			from:    data.NewMap(map[string]data.Value{"tokens": stringArray("a")}),
+			to:      data.NewMap(map[string]data.Value{"tokens": stringArray("a", "b", "c")}),
+			wantOps: `[{"op":"add","path":"/tokens/-","value":"b"},{"op":"add","path":"/tokens/-","value":"c"}]`,
+		},
+		{
+			name:    "removed items",
+			from:    data.NewMap(map[string]data.Value{"tokens": stringArray("a", "b", "c", "d")}),
+			to:      data.NewMap(map[string]data.Value{"tokens": stringArray("a", "x")}),
+			wantOps: `[{"op":"replace","path":"/tokens/1","value":"x"},{"op":"remove","path":"/tokens/3"},{"op":"remove","path":"/tokens/2"}]`,
+		},
+		{
+			name: "fields",
+			from: data.NewMap(map[string]data.Value{"a": data.NewString("a"), "b": data.NewNull()}),
+			to:   data.NewMap(map[string]data.Value{"b": data.NewNull(), "c": data.NewNull()}),
+			// The removals have no value, the null is a value of the rest.
+			wantOps: `[{"op":"remove","path":"/a"},{"op":"add","path":"/c","value":null}]`,
+		},
+		{
+			name:    "escaped keys",
+			from:    data.NewMap(map[string]data.Value{"a/b": data.NewString("x"), "~": data.NewString("y")}),
+			to:      data.NewMap(map[string]data.Value{"a/b": data.NewString("xx"), "~": data.NewNumberFromInteger(1)}),
+			wantOps: `[{"op":"append","path":"/a~1b","value":"x"},{"op":"replace","path":"/~0","value":1}]`,
+		},
+		{
+			name: "nested",
+			from: data.NewMap(map[string]data.Value{
+				"choices": data.NewArray([]data.Value{
+					data.NewMap(map[string]data.Value{"text": data.NewString("The"), "finish": data.NewNull()}),
+				}),
+			}),
+			to: data.NewMap(map[string]data.Value{
+				"choices": data.NewArray([]data.Value{
+					data.NewMap(map[string]data.Value{"text": data.NewString("The end"), "finish": data.NewString("stop")}),
+					data.NewMap(map[string]data.Value{"text": data.NewString("")}),
+				}),
+			}),
+			wantOps: `[{"op":"replace","path":"/choices/0/finish","value":"stop"},{"op":"append","path":"/choices/0/text","value":" end"},{"op":"add","path":"/choices/-","value":{"text":""}}]`,
+		},
+		{
+			name:    "type change",
+			from:    data.NewMap(map[string]data.Value{"a": stringArray("x")}),
+			to:      data.NewMap(map[string]data.Value{"a": data.NewString("x")}),
+			wantOps: `[{"op":"replace","path":"/a","value":"x"}]`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			ops, err := patchOperations(data.Diff(tc.from, tc.to))
+			c.Assert(err, quicktest.IsNil)
+
+			b, err := json.Marshal(ops)
+			c.Assert(err, quicktest.IsNil)
+			c.Check(string(b), quicktest.JSONEquals, json.RawMessage(tc.wantOps))
+
+			got, err := applyPatch(jsonValue(c, tc.from), sentOperations(c, ops))
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, quicktest.DeepEquals, jsonValue(c, tc.to))
+		})
+	}
+}
+
+func TestOutputPatch(t *testing.T) {
+	c := quicktest.New(t)
+
+	output := func(text string, tokens int) data.Value {
+		values := make([]data.Value, tokens)
+		for i := range values {
+			values[i] = data.NewNumberFromInteger(i)
+		}
+		return data.NewMap(map[string]data.Value{
+			"text":   data.NewString(text),
+			"tokens": data.NewArray(values),
+		})
+	}
+
+	wfm := &workflowMemory{}
+	send := func(c *quicktest.C, batchIdx int, v data.Value) ([]PatchOperation, bool) {
+		return wfm.outputPatch(batchIdx, "llm", v, jsonValue(c, v))
+	}
+
+	// The first output is sent as a snapshot.
+	text := strings.Repeat("lorem ipsum ", 10)
+	_, ok := send(c, 0, output(text, 1))
+	c.Assert(ok, quicktest.IsFalse)
+	listener := jsonValue(c, output(text, 1))
+
+	// The listener rebuilds every output from the patches.
+	for i := 1; i < patchSnapshotInterval+1; i++ {
+		text += fmt.Sprintf("token %d ", i)
+		ops, ok := send(c, 0, output(text, i+1))
+		c.Assert(ok, quicktest.IsTrue, quicktest.Commentf("output %d", i))
+
+		var err error
+		listener, err = applyPatch(listener, sentOperations(c, ops))
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(listener, quicktest.DeepEquals, jsonValue(c, output(text, i+1)))
+	}
+
+	// A snapshot is sent after the patch interval, and the patches follow
+	// it again.
+	text += "end"
+	_, ok = send(c, 0, output(text, 1))
+	c.Check(ok, quicktest.IsFalse)
+	_, ok = send(c, 0, output(text+".", 1))
+	c.Check(ok, quicktest.IsTrue)
+
+	// Each batch item has its own stream.
+	_, ok = send(c, 1, output(text, 1))
+	c.Check(ok, quicktest.IsFalse)
+
+	// A patch that isn't smaller than the output is replaced by a snapshot.
+	_, ok = send(c, 1, data.NewMap(map[string]data.Value{"a": data.NewString("b")}))
+	c.Check(ok, quicktest.IsFalse)
+}
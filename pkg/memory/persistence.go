@@ -0,0 +1,288 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// ErrSnapshotNotFound is returned by a persistence backend when there's no
+// snapshot for a workflow.
+var ErrSnapshotNotFound = errors.New("workflow memory snapshot not found")
+
+// MemoryPersistence keeps snapshots of the workflow memory outside of the
+// worker process, so a workflow can be resumed by a different worker (e.g.
+// after a restart) and large payloads don't need to live in a single
+// backend.
+//
+// Backends that expire the snapshots keep them for the provided TTL after
+// they're saved or touched, or for the backend default when the TTL is zero.
+// The rest of backends ignore it.
+type MemoryPersistence interface {
+	Save(ctx context.Context, workflowID string, snapshot []byte, ttl time.Duration) error
+	Load(ctx context.Context, workflowID string) (snapshot []byte, err error)
+	Touch(ctx context.Context, workflowID string, ttl time.Duration) error
+	Delete(ctx context.Context, workflowID string) error
+}
+
+// MemoryLister is implemented by the persistence backends that can list the
+// workflows whose snapshot they hold, so the memory of the workflows that
+// weren't purged can be found and reaped.
+type MemoryLister interface {
+	ListWorkflows(ctx context.Context) (workflowIDs []string, err error)
+}
+
+// listWorkflows lists the snapshots of a backend, or none if the backend
+// can't list them.
+func listWorkflows(ctx context.Context, p MemoryPersistence) ([]string, error) {
+	l, ok := p.(MemoryLister)
+	if !ok {
+		return nil, nil
+	}
+	return l.ListWorkflows(ctx)
+}
+
+// ErrRevisionConflict is returned by a versioned backend when a snapshot was
+// saved by another worker since its revision was read.
+var ErrRevisionConflict = errors.New("workflow memory snapshot was modified concurrently")
+
+// VersionedPersistence is implemented by the persistence backends that keep
+// a revision counter along with each snapshot. A snapshot is only saved if
+// the persisted revision is the one it was based on, so the activities of a
+// run that execute on different workers don't overwrite each other's
+// memory.
+type VersionedPersistence interface {
+	LoadRevision(ctx context.Context, workflowID string) (snapshot []byte, revision int64, err error)
+	// SaveRevision saves a snapshot if the persisted revision is the
+	// provided one (zero if there's no snapshot yet) and returns the new
+	// revision. Otherwise, it returns ErrRevisionConflict.
+	SaveRevision(ctx context.Context, workflowID string, snapshot []byte, ttl time.Duration, revision int64) (newRevision int64, err error)
+}
+
+// PartitionedPersistence is implemented by the versioned backends that can
+// keep the memory of each component apart from the snapshot, so a worker
+// only fetches and decodes the components its activities read.
+type PartitionedPersistence interface {
+	VersionedPersistence
+
+	// SavePartitions saves a snapshot along with the memory of some of its
+	// components if the persisted revision is the provided one, and returns
+	// the new revision. The persisted components that aren't provided are
+	// kept. Otherwise, it returns ErrRevisionConflict.
+	SavePartitions(ctx context.Context, workflowID string, snapshot []byte, partitions map[string][]byte, ttl time.Duration, revision int64) (newRevision int64, err error)
+	// LoadPartitions reads the memory of some components of a snapshot.
+	// The components without persisted memory are left out.
+	LoadPartitions(ctx context.Context, workflowID string, componentIDs []string) (partitions map[string][]byte, err error)
+}
+
+// errPartitionsUnsupported is returned by a wrapper when the backend below it
+// can't persist the components apart.
+var errPartitionsUnsupported = errors.New("persistence backend doesn't support partitioned memory")
+
+// wrappedPersistence is implemented by the backends that wrap another one,
+// e.g. to compress or encrypt the snapshots.
+type wrappedPersistence interface {
+	Unwrap() MemoryPersistence
+}
+
+// asPartitioned returns a backend as a partitioned one if it and the
+// backends it wraps support partitions. The wrappers implement
+// PartitionedPersistence regardless of the backend below them, so the whole
+// chain is checked.
+func asPartitioned(p MemoryPersistence) (PartitionedPersistence, bool) {
+	partitioned, ok := p.(PartitionedPersistence)
+	if !ok {
+		return nil, false
+	}
+	for inner := p; ; {
+		w, isWrapper := inner.(wrappedPersistence)
+		if !isWrapper {
+			return partitioned, true
+		}
+		inner = w.Unwrap()
+		if _, ok := inner.(PartitionedPersistence); !ok {
+			return nil, false
+		}
+	}
+}
+
+// savePartitionsTo and loadPartitionsFrom forward the partitions of a
+// wrapper to the backend it wraps.
+func savePartitionsTo(ctx context.Context, p MemoryPersistence, workflowID string, snapshot []byte, partitions map[string][]byte, ttl time.Duration, revision int64) (int64, error) {
+	partitioned, ok := p.(PartitionedPersistence)
+	if !ok {
+		return 0, errPartitionsUnsupported
+	}
+	return partitioned.SavePartitions(ctx, workflowID, snapshot, partitions, ttl, revision)
+}
+
+func loadPartitionsFrom(ctx context.Context, p MemoryPersistence, workflowID string, componentIDs []string) (map[string][]byte, error) {
+	partitioned, ok := p.(PartitionedPersistence)
+	if !ok {
+		return nil, errPartitionsUnsupported
+	}
+	return partitioned.LoadPartitions(ctx, workflowID, componentIDs)
+}
+
+// loadRevision loads a snapshot along with its revision. Backends that
+// aren't versioned report a zero revision.
+func loadRevision(ctx context.Context, p MemoryPersistence, workflowID string) ([]byte, int64, error) {
+	if v, ok := p.(VersionedPersistence); ok {
+		return v.LoadRevision(ctx, workflowID)
+	}
+	snapshot, err := p.Load(ctx, workflowID)
+	return snapshot, 0, err
+}
+
+// saveRevision saves a snapshot if its revision is still the persisted one.
+// Backends that aren't versioned save it unconditionally.
+func saveRevision(ctx context.Context, p MemoryPersistence, workflowID string, snapshot []byte, ttl time.Duration, revision int64) (int64, error) {
+	if v, ok := p.(VersionedPersistence); ok {
+		return v.SaveRevision(ctx, workflowID, snapshot, ttl, revision)
+	}
+	if err := p.Save(ctx, workflowID, snapshot, ttl); err != nil {
+		return 0, err
+	}
+	return revision + 1, nil
+}
+
+// isCheckpointID tells whether a persisted ID belongs to a component
+// checkpoint rather than to a workflow snapshot.
+func isCheckpointID(id string) bool {
+	return strings.Contains(id, checkpointInfix)
+}
+
+// jsonSnapshot is the legacy, JSON form of a persisted workflow memory.
+//
+// In any format, the streaming state isn't persisted: the event listeners are
+// bound to the process that triggered the workflow, so a restored memory
+// doesn't send events.
+type jsonSnapshot struct {
+	ID     string            `json:"id"`
+	Recipe *datamodel.Recipe `json:"recipe"`
+	Data   []*snapshotValue  `json:"data"`
+}
+
+// snapshotValue holds a data.Value along with its type in a JSON snapshot.
+type snapshotValue struct {
+	Type string `json:"type"`
+
+	Boolean bool                      `json:"boolean,omitempty"`
+	Number  float64                   `json:"number,omitempty"`
+	String  string                    `json:"string,omitempty"`
+	Bytes   []byte                    `json:"bytes,omitempty"`
+	File    *data.File                `json:"file,omitempty"`
+	Width   int                       `json:"width,omitempty"`
+	Height  int                       `json:"height,omitempty"`
+	Fields  map[string]*snapshotValue `json:"fields,omitempty"`
+	Values  []*snapshotValue          `json:"values,omitempty"`
+}
+
+const (
+	snapshotNull      = "null"
+	snapshotBoolean   = "boolean"
+	snapshotNumber    = "number"
+	snapshotString    = "string"
+	snapshotByteArray = "byte-array"
+	snapshotFile      = "file"
+	snapshotImage     = "image"
+	snapshotVideo     = "video"
+	snapshotAudio     = "audio"
+	snapshotDocument  = "document"
+	snapshotMap       = "map"
+	snapshotArray     = "array"
+)
+
+func decodeSnapshotValue(sv *snapshotValue) (data.Value, error) {
+	if sv == nil {
+		return data.NewNull(), nil
+	}
+
+	file := func() data.File {
+		f := data.File{}
+		if sv.File != nil {
+			f = *sv.File
+		}
+		if f.Cache == nil {
+			f.Cache = map[string][]byte{f.ContentType: f.Raw}
+		}
+		return f
+	}
+
+	switch sv.Type {
+	case snapshotNull:
+		return data.NewNull(), nil
+	case snapshotBoolean:
+		return data.NewBoolean(sv.Boolean), nil
+	case snapshotNumber:
+		return data.NewNumberFromFloat(sv.Number), nil
+	case snapshotString:
+		return data.NewString(sv.String), nil
+	case snapshotByteArray:
+		return data.NewByteArray(sv.Bytes), nil
+	case snapshotFile:
+		f := file()
+		return &f, nil
+	case snapshotImage:
+		return &data.Image{File: file(), Width: sv.Width, Height: sv.Height}, nil
+	case snapshotVideo:
+		return &data.Video{File: file()}, nil
+	case snapshotAudio:
+		return &data.Audio{File: file()}, nil
+	case snapshotDocument:
+		return &data.Document{File: file()}, nil
+	case snapshotMap:
+		fields := make(map[string]data.Value, len(sv.Fields))
+		for k, f := range sv.Fields {
+			v, err := decodeSnapshotValue(f)
+			if err != nil {
+				return nil, err
+			}
+			fields[k] = v
+		}
+		return data.NewMap(fields), nil
+	case snapshotArray:
+		values := make([]data.Value, len(sv.Values))
+		for i, item := range sv.Values {
+			v, err := decodeSnapshotValue(item)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		return data.NewArray(values), nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type %q", sv.Type)
+}
+
+// unmarshalJSONSnapshot decodes the snapshots written before the versioned
+// binary format was introduced.
+func unmarshalJSONSnapshot(b []byte) (*workflowMemory, error) {
+	var s jsonSnapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("unmarshalling workflow memory snapshot: %w", err)
+	}
+
+	wfm := &workflowMemory{
+		ID:      s.ID,
+		Recipe:  s.Recipe,
+		Data:    make([]data.Value, len(s.Data)),
+		channel: make(chan *Event),
+	}
+	for idx, sv := range s.Data {
+		v, err := decodeSnapshotValue(sv)
+		if err != nil {
+			return nil, fmt.Errorf("decoding batch item %d: %w", idx, err)
+		}
+		wfm.Data[idx] = v
+	}
+
+	return wfm, nil
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+
+	miniox "github.com/instill-ai/pipeline-backend/pkg/minio"
+)
+
+const minioMemoryPrefix = "pipeline-trigger-memory/"
+
+type minioPersistence struct {
+	client miniox.MinioI
+}
+
+// NewMinIOPersistence returns a persistence backend that keeps the workflow
+// memory in the blob storage, for workflows with payloads that are too
+// large for Redis or PostgreSQL.
+func NewMinIOPersistence(client miniox.MinioI) MemoryPersistence {
+	return &minioPersistence{client: client}
+}
+
+func (p *minioPersistence) Save(ctx context.Context, workflowID string, snapshot []byte, _ time.Duration) error {
+	_, _, err := p.client.UploadFileBytes(ctx, minioMemoryPath(workflowID), snapshot, "application/octet-stream")
+	return err
+}
+
+func (p *minioPersistence) Load(ctx context.Context, workflowID string) ([]byte, error) {
+	b, err := p.client.GetFile(ctx, minioMemoryPath(workflowID))
+	if isNoSuchKey(err) {
+		// Snapshots written before the binary format have a different
+		// path.
+		b, err = p.client.GetFile(ctx, minioLegacyMemoryPath(workflowID))
+	}
+	if isNoSuchKey(err) {
+		return nil, ErrSnapshotNotFound
+	}
+	return b, err
+}
+
+// Touch is a no-op, the snapshots are kept until the workflow is purged.
+func (p *minioPersistence) Touch(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (p *minioPersistence) Delete(ctx context.Context, workflowID string) error {
+	if err := p.client.DeleteFile(ctx, minioLegacyMemoryPath(workflowID)); err != nil && !isNoSuchKey(err) {
+		return err
+	}
+	return p.client.DeleteFile(ctx, minioMemoryPath(workflowID))
+}
+
+func minioMemoryPath(workflowID string) string {
+	return minioMemoryPrefix + workflowID
+}
+
+func minioLegacyMemoryPath(workflowID string) string {
+	return minioMemoryPrefix + workflowID + ".json"
+}
+
+func isNoSuchKey(err error) bool {
+	return err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey"
+}
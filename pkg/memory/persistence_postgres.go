@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// workflowMemoryRecord is a workflow memory snapshot stored in PostgreSQL.
+type workflowMemoryRecord struct {
+	WorkflowID string    `gorm:"type:varchar(255);primaryKey"`
+	Snapshot   []byte    `gorm:"type:bytea"`
+	Revision   int64     `gorm:"not null;default:0"`
+	UpdateTime time.Time `gorm:"autoUpdateTime:nano"`
+}
+
+// TableName maps the workflowMemoryRecord object to a SQL table.
+func (workflowMemoryRecord) TableName() string {
+	return "pipeline_trigger_memory"
+}
+
+type postgresPersistence struct {
+	db *gorm.DB
+}
+
+// NewPostgresPersistence returns a persistence backend that keeps the
+// workflow memory in PostgreSQL, for deployments that need the memory to be
+// durable.
+func NewPostgresPersistence(db *gorm.DB) MemoryPersistence {
+	return &postgresPersistence{db: db}
+}
+
+func (p *postgresPersistence) Save(ctx context.Context, workflowID string, snapshot []byte, _ time.Duration) error {
+	record := &workflowMemoryRecord{WorkflowID: workflowID, Snapshot: snapshot}
+	// The revision is only changed by SaveRevision.
+	return p.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"snapshot", "update_time"}),
+	}).Create(record).Error
+}
+
+func (p *postgresPersistence) LoadRevision(ctx context.Context, workflowID string) ([]byte, int64, error) {
+	record := &workflowMemoryRecord{}
+	err := p.db.WithContext(ctx).Where("workflow_id = ?", workflowID).First(record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return record.Snapshot, record.Revision, nil
+}
+
+// SaveRevision updates the snapshot only if its row still holds the expected
+// revision. The first revision of a snapshot is inserted, and the insertion
+// is ignored if another worker created the row in the meantime.
+func (p *postgresPersistence) SaveRevision(ctx context.Context, workflowID string, snapshot []byte, _ time.Duration, revision int64) (int64, error) {
+	var result *gorm.DB
+	if revision == 0 {
+		result = p.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&workflowMemoryRecord{WorkflowID: workflowID, Snapshot: snapshot, Revision: 1})
+	} else {
+		result = p.db.WithContext(ctx).Model(&workflowMemoryRecord{}).
+			Where("workflow_id = ? AND revision = ?", workflowID, revision).
+			Updates(map[string]any{"snapshot": snapshot, "revision": revision + 1})
+	}
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, ErrRevisionConflict
+	}
+	return revision + 1, nil
+}
+
+func (p *postgresPersistence) Load(ctx context.Context, workflowID string) ([]byte, error) {
+	record := &workflowMemoryRecord{}
+	err := p.db.WithContext(ctx).Where("workflow_id = ?", workflowID).First(record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return record.Snapshot, nil
+}
+
+// Touch is a no-op, the snapshots are kept until the workflow is purged.
+func (p *postgresPersistence) Touch(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (p *postgresPersistence) Delete(ctx context.Context, workflowID string) error {
+	return p.db.WithContext(ctx).Where("workflow_id = ?", workflowID).Delete(&workflowMemoryRecord{}).Error
+}
+
+func (p *postgresPersistence) ListWorkflows(ctx context.Context) ([]string, error) {
+	var ids []string
+	err := p.db.WithContext(ctx).Model(&workflowMemoryRecord{}).
+		Where("workflow_id NOT LIKE ?", "%"+checkpointInfix+"%").
+		Pluck("workflow_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,390 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisMemoryKeyPrefix   = "pipeline_trigger_memory:"
+	redisRevisionKeyPrefix = "pipeline_trigger_memory_revision:"
+
+	// The memory of the components is kept in a hash, with a field per
+	// component, so they can be fetched individually.
+	redisPartitionKeyPrefix = "pipeline_trigger_memory_components:"
+
+	// The tenant of each run is recorded apart from its memory, without the
+	// tenant prefix, so a worker that restores the memory can find it.
+	redisTenantKeyPrefix = "pipeline_trigger_memory_tenant:"
+)
+
+// saveRevisionScript saves a snapshot and increments its revision if the
+// persisted revision is the expected one, or returns -1. A missing revision
+// key counts as revision 0.
+var saveRevisionScript = redis.NewScript(`
+local revision = tonumber(redis.call('GET', KEYS[2]) or '0')
+if revision ~= tonumber(ARGV[2]) then
+	return -1
+end
+revision = revision + 1
+local ttl = tonumber(ARGV[3])
+if ttl > 0 then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+	redis.call('SET', KEYS[2], revision, 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], ARGV[1])
+	redis.call('SET', KEYS[2], revision)
+end
+return revision
+`)
+
+// savePartitionsScript works as saveRevisionScript and also sets the
+// component fields passed after the TTL in the partition hash.
+var savePartitionsScript = redis.NewScript(`
+local revision = tonumber(redis.call('GET', KEYS[2]) or '0')
+if revision ~= tonumber(ARGV[2]) then
+	return -1
+end
+revision = revision + 1
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('SET', KEYS[2], revision)
+for i = 4, #ARGV, 2 do
+	redis.call('HSET', KEYS[3], ARGV[i], ARGV[i + 1])
+end
+local ttl = tonumber(ARGV[3])
+if ttl > 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+	redis.call('PEXPIRE', KEYS[2], ttl)
+	redis.call('PEXPIRE', KEYS[3], ttl)
+else
+	redis.call('PERSIST', KEYS[3])
+end
+return revision
+`)
+
+type redisPersistence struct {
+	client  redis.UniversalClient
+	ttl     time.Duration
+	hashTag bool
+	tenancy RedisTenancy
+}
+
+// NewRedisPersistence returns a persistence backend that keeps the workflow
+// memory in Redis. Unless a pipeline sets its own TTL, the snapshots expire
+// after the provided one, so the memory of workflows that aren't purged (e.g.
+// due to a worker crash) doesn't pile up. The client can be a standalone,
+// Sentinel (failover) or cluster client.
+func NewRedisPersistence(client redis.UniversalClient, ttl time.Duration, tenancy RedisTenancy) MemoryPersistence {
+	return &redisPersistence{client: client, ttl: ttl, hashTag: isRedisCluster(client), tenancy: tenancy}
+}
+
+// redisRun holds the client and the keys of the memory of a run.
+type redisRun struct {
+	client    redis.UniversalClient
+	memory    string
+	revision  string
+	partition string
+}
+
+func (p *redisPersistence) run(ctx context.Context, workflowID string) (*redisRun, error) {
+	client, prefix, err := p.tenancy.route(ctx, p.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisRun{
+		client:    client,
+		memory:    prefix + redisRunKey(redisMemoryKeyPrefix, workflowID, p.hashTag),
+		revision:  prefix + redisRunKey(redisRevisionKeyPrefix, workflowID, p.hashTag),
+		partition: prefix + redisRunKey(redisPartitionKeyPrefix, workflowID, p.hashTag),
+	}, nil
+}
+
+// tenantKey returns the key that records the tenant of a run. Checkpoints
+// share the key of their run.
+func (p *redisPersistence) tenantKey(workflowID string) string {
+	runID, _, _ := strings.Cut(workflowID, checkpointInfix)
+	return p.tenancy.KeyPrefix + redisTenantKeyPrefix + runID
+}
+
+// recordTenant saves the tenant of a run, if any, with the expiration of
+// its memory.
+func (p *redisPersistence) recordTenant(ctx context.Context, workflowID string, ttl time.Duration) error {
+	tenant := p.tenancy.tenant(ctx)
+	if tenant == "" {
+		return nil
+	}
+	return p.client.Set(ctx, p.tenantKey(workflowID), tenant, p.expiration(ttl)).Err()
+}
+
+// ResolveTenant reads the tenant recorded for a run.
+func (p *redisPersistence) ResolveTenant(ctx context.Context, workflowID string) (string, error) {
+	if !p.tenancy.Isolated {
+		return "", nil
+	}
+
+	tenant, err := p.client.Get(ctx, p.tenantKey(workflowID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return tenant, err
+}
+
+func (p *redisPersistence) Save(ctx context.Context, workflowID string, snapshot []byte, ttl time.Duration) error {
+	r, err := p.run(ctx, workflowID)
+	if err != nil {
+		return err
+	}
+	if err := r.client.Set(ctx, r.memory, snapshot, p.expiration(ttl)).Err(); err != nil {
+		return err
+	}
+	return p.recordTenant(ctx, workflowID, ttl)
+}
+
+func (p *redisPersistence) Load(ctx context.Context, workflowID string) ([]byte, error) {
+	r, err := p.run(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := r.client.Get(ctx, r.memory).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrSnapshotNotFound
+	}
+	return b, err
+}
+
+// LoadRevision reads a snapshot and its revision atomically.
+func (p *redisPersistence) LoadRevision(ctx context.Context, workflowID string) ([]byte, int64, error) {
+	r, err := p.run(ctx, workflowID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	values, err := r.client.MGet(ctx, r.memory, r.revision).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	snapshot, ok := values[0].(string)
+	if !ok {
+		return nil, 0, ErrSnapshotNotFound
+	}
+
+	var revision int64
+	if s, ok := values[1].(string); ok {
+		if revision, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, 0, fmt.Errorf("invalid workflow memory revision %q", s)
+		}
+	}
+
+	return []byte(snapshot), revision, nil
+}
+
+func (p *redisPersistence) SaveRevision(ctx context.Context, workflowID string, snapshot []byte, ttl time.Duration, revision int64) (int64, error) {
+	r, err := p.run(ctx, workflowID)
+	if err != nil {
+		return 0, err
+	}
+
+	keys := []string{r.memory, r.revision}
+	newRevision, err := saveRevisionScript.Run(ctx, r.client, keys, snapshot, revision, p.expiration(ttl).Milliseconds()).Int64()
+	if err != nil {
+		return 0, err
+	}
+	if newRevision < 0 {
+		return 0, ErrRevisionConflict
+	}
+
+	return newRevision, p.recordTenant(ctx, workflowID, ttl)
+}
+
+// SavePartitions saves a snapshot and the memory of the provided components
+// atomically, in the same way as SaveRevision.
+func (p *redisPersistence) SavePartitions(ctx context.Context, workflowID string, snapshot []byte, partitions map[string][]byte, ttl time.Duration, revision int64) (int64, error) {
+	componentIDs := make([]string, 0, len(partitions))
+	for id := range partitions {
+		componentIDs = append(componentIDs, id)
+	}
+	sort.Strings(componentIDs)
+
+	args := make([]any, 0, 3+2*len(partitions))
+	args = append(args, snapshot, revision, p.expiration(ttl).Milliseconds())
+	for _, id := range componentIDs {
+		args = append(args, id, partitions[id])
+	}
+
+	r, err := p.run(ctx, workflowID)
+	if err != nil {
+		return 0, err
+	}
+
+	keys := []string{r.memory, r.revision, r.partition}
+	newRevision, err := savePartitionsScript.Run(ctx, r.client, keys, args...).Int64()
+	if err != nil {
+		return 0, err
+	}
+	if newRevision < 0 {
+		return 0, ErrRevisionConflict
+	}
+
+	return newRevision, p.recordTenant(ctx, workflowID, ttl)
+}
+
+// LoadPartitions reads the memory of some components with a single request.
+func (p *redisPersistence) LoadPartitions(ctx context.Context, workflowID string, componentIDs []string) (map[string][]byte, error) {
+	r, err := p.run(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.client.HMGet(ctx, r.partition, componentIDs...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := make(map[string][]byte, len(componentIDs))
+	for i, v := range values {
+		if s, ok := v.(string); ok {
+			partitions[componentIDs[i]] = []byte(s)
+		}
+	}
+	return partitions, nil
+}
+
+// Touch resets the expiration of a snapshot, so the memory of a run that is
+// still active doesn't expire while it waits (e.g. for an approval).
+func (p *redisPersistence) Touch(ctx context.Context, workflowID string, ttl time.Duration) error {
+	r, err := p.run(ctx, workflowID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Expire(ctx, r.memory, p.expiration(ttl))
+		pipe.Expire(ctx, r.revision, p.expiration(ttl))
+		pipe.Expire(ctx, r.partition, p.expiration(ttl))
+		return nil
+	})
+	if err != nil || p.tenancy.tenant(ctx) == "" {
+		return err
+	}
+	return p.client.Expire(ctx, p.tenantKey(workflowID), p.expiration(ttl)).Err()
+}
+
+func (p *redisPersistence) expiration(ttl time.Duration) time.Duration {
+	if ttl > 0 {
+		return ttl
+	}
+	return p.ttl
+}
+
+func (p *redisPersistence) Delete(ctx context.Context, workflowID string) error {
+	r, err := p.run(ctx, workflowID)
+	if err != nil {
+		return err
+	}
+	if err := r.client.Del(ctx, r.memory, r.revision, r.partition).Err(); err != nil {
+		return err
+	}
+
+	// The tenant of a run is kept until the run itself is deleted.
+	if isCheckpointID(workflowID) || p.tenancy.tenant(ctx) == "" {
+		return nil
+	}
+	return p.client.Del(ctx, p.tenantKey(workflowID)).Err()
+}
+
+// ListWorkflows lists the runs of every tenant, including the ones whose
+// memory is kept in a dedicated database.
+func (p *redisPersistence) ListWorkflows(ctx context.Context) ([]string, error) {
+	pattern := p.tenancy.KeyPrefix + "*" + redisMemoryKeyPrefix + "*"
+
+	seen := map[string]bool{}
+	var ids []string
+	for _, client := range p.tenancy.clients(p.client) {
+		keys, err := scanRedisKeys(ctx, client, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			// The tenant prefixes don't have colons, so the key of the
+			// memory starts at the first occurrence of its prefix.
+			id := workflowIDFromRedisKey(redisMemoryKeyPrefix, key[strings.Index(key, redisMemoryKeyPrefix):])
+			if !isCheckpointID(id) && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// FlushTenant deletes the memory, the revisions, the component memory and
+// the checkpoints of every run of a tenant.
+func (p *redisPersistence) FlushTenant(ctx context.Context, tenant string) (int, error) {
+	if !p.tenancy.Isolated {
+		return 0, fmt.Errorf("the memory keys aren't isolated per tenant")
+	}
+
+	ctx = WithTenant(ctx, tenant)
+	client, prefix, err := p.tenancy.route(ctx, p.client)
+	if err != nil {
+		return 0, err
+	}
+
+	keys, err := scanRedisKeys(ctx, client, prefix+"*")
+	if err != nil {
+		return 0, err
+	}
+
+	// The keys are deleted one by one, as they can be in different slots of
+	// a cluster.
+	for _, key := range keys {
+		if err := client.Del(ctx, key).Err(); err != nil {
+			return 0, fmt.Errorf("deleting %s: %w", key, err)
+		}
+	}
+	return len(keys), nil
+}
+
+// scanRedisKeys returns the keys that match a pattern. A scan only covers
+// the keys of a node, so each master of a cluster is scanned.
+func scanRedisKeys(ctx context.Context, client redis.UniversalClient, pattern string) ([]string, error) {
+	cc, ok := client.(*redis.ClusterClient)
+	if !ok {
+		return scanRedisNode(ctx, client, pattern)
+	}
+
+	var mu sync.Mutex
+	var keys []string
+	err := cc.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		nodeKeys, err := scanRedisNode(ctx, node, pattern)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, nodeKeys...)
+		return nil
+	})
+	return keys, err
+}
+
+func scanRedisNode(ctx context.Context, client redis.Cmdable, pattern string) ([]string, error) {
+	var keys []string
+	iter := client.Scan(ctx, 0, pattern, 1000).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	return keys, iter.Err()
+}
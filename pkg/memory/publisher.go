@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/pipeline-backend/pkg/logger"
+)
+
+// eventPublishTimeout bounds the time it takes to publish an event, so an
+// unavailable event bus doesn't stall the pipeline runs.
+const eventPublishTimeout = 5 * time.Second
+
+// EventPublisher routes the events of the pipeline runs to a streaming
+// infrastructure (Redis pub/sub, NATS JetStream, Kafka...), so they can be
+// consumed outside of the trigger requests that stream them.
+type EventPublisher interface {
+	// Publish sends an event of a workflow to the bus.
+	Publish(ctx context.Context, workflowID string, event *Event) error
+	// Close releases the connections of the publisher.
+	Close() error
+}
+
+// publishedEvent is the message an event is published as. The payload is
+// encoded with the current version of the schema, which consumers can decode
+// with DecodeEvent.
+type publishedEvent struct {
+	WorkflowID string          `json:"workflowId"`
+	Version    int             `json:"version"`
+	Event      string          `json:"event"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func marshalEvent(workflowID string, event *Event) ([]byte, error) {
+	b, err := EncodeEventData(event, CurrentEventVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(publishedEvent{
+		WorkflowID: workflowID,
+		Version:    CurrentEventVersion,
+		Event:      event.Event,
+		Data:       b,
+	})
+}
+
+// messagePublisher is implemented by the publishers that can send an
+// already encoded message, which is what the encryption wrapper needs.
+type messagePublisher interface {
+	EventPublisher
+	publishMessage(ctx context.Context, workflowID string, msg []byte) error
+}
+
+// ErrUnencryptedEvent is returned by DecryptEvent when the message isn't
+// encrypted.
+var ErrUnencryptedEvent = errors.New("event isn't encrypted")
+
+// sealedEvent is the message an encrypted event is published as. The
+// workflow ID is kept in clear text so the consumers can route the events
+// before decrypting them.
+type sealedEvent struct {
+	WorkflowID string `json:"workflowId"`
+	// Encrypted holds the nonce and the AES-GCM encryption of the
+	// publishedEvent message, authenticated with the workflow ID.
+	Encrypted []byte `json:"encrypted"`
+}
+
+type encryptedEventPublisher struct {
+	messagePublisher
+	aead cipher.AEAD
+}
+
+// NewEncryptedEventPublisher wraps an event publisher so the events are
+// encrypted with a shared AES key of 16, 24 or 32 bytes. The events carry
+// the inputs and outputs of the components, which might be user documents,
+// so the event bus shouldn't be able to read them. The consumers decrypt
+// the messages with DecryptEvent.
+func NewEncryptedEventPublisher(p EventPublisher, key []byte) (EventPublisher, error) {
+	mp, ok := p.(messagePublisher)
+	if !ok {
+		return nil, fmt.Errorf("event publisher %T doesn't support encryption", p)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("initializing event encryption key: %w", err)
+	}
+
+	return &encryptedEventPublisher{messagePublisher: mp, aead: aead}, nil
+}
+
+func (p *encryptedEventPublisher) Publish(ctx context.Context, workflowID string, event *Event) error {
+	b, err := marshalEvent(workflowID, event)
+	if err != nil {
+		return err
+	}
+	encrypted, err := seal(p.aead, b, []byte(workflowID))
+	if err != nil {
+		return fmt.Errorf("encrypting event: %w", err)
+	}
+	msg, err := json.Marshal(sealedEvent{WorkflowID: workflowID, Encrypted: encrypted})
+	if err != nil {
+		return err
+	}
+
+	return p.publishMessage(ctx, workflowID, msg)
+}
+
+// DecryptEvent decrypts a message published with an encryption key, so it
+// can be decoded with DecodeEvent.
+func DecryptEvent(b, key []byte) ([]byte, error) {
+	var se sealedEvent
+	if err := json.Unmarshal(b, &se); err != nil {
+		return nil, fmt.Errorf("unmarshalling event: %w", err)
+	}
+	if se.Encrypted == nil {
+		return nil, ErrUnencryptedEvent
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := open(aead, se.Encrypted, []byte(se.WorkflowID))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting event: %w", err)
+	}
+
+	return msg, nil
+}
+
+// deliver publishes an event to the event bus, if any, and sends it to the
+// listeners of the workflow memory. A failure to publish the event doesn't
+// prevent its delivery to the listeners.
+func (wfm *workflowMemory) deliver(ctx context.Context, event *Event) {
+	if wfm.publisher != nil {
+		pubCtx, cancel := context.WithTimeout(wfm.tenantContext(context.WithoutCancel(ctx)), eventPublishTimeout)
+		if err := wfm.publisher.Publish(pubCtx, wfm.ID, event); err != nil {
+			publishFailures.Add(ctx, 1, metric.WithAttributes(attribute.String("event", event.Event)))
+			logger, _ := logger.GetZapLogger(ctx)
+			logger.Warn("Failed to publish event",
+				zap.String("workflowID", wfm.ID),
+				zap.String("event", event.Event),
+				zap.Error(err),
+			)
+		}
+		cancel()
+	}
+
+	wfm.channel <- event
+}
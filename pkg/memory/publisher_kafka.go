@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// kafkaJSONContentType is the content type of the JSON records produced
+// through the Confluent REST Proxy (API v2).
+// ref: https://docs.confluent.io/platform/current/kafka-rest/api.html
+const kafkaJSONContentType = "application/vnd.kafka.json.v2+json"
+
+type kafkaEventPublisher struct {
+	client   *http.Client
+	endpoint string
+	username string
+	password string
+}
+
+// NewKafkaEventPublisher returns an event publisher that produces the events
+// to a Kafka topic through the Confluent REST Proxy, so the backend doesn't
+// need a native Kafka client. The records are keyed by workflow ID, which
+// keeps the events of a run in order within a partition.
+func NewKafkaEventPublisher(client *http.Client, restProxyURL, topic, username, password string) EventPublisher {
+	return &kafkaEventPublisher{
+		client:   client,
+		endpoint: strings.TrimSuffix(restProxyURL, "/") + "/topics/" + url.PathEscape(topic),
+		username: username,
+		password: password,
+	}
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaProduceRecord `json:"records"`
+}
+
+type kafkaProduceRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+func (p *kafkaEventPublisher) Publish(ctx context.Context, workflowID string, event *Event) error {
+	value, err := marshalEvent(workflowID, event)
+	if err != nil {
+		return err
+	}
+
+	return p.publishMessage(ctx, workflowID, value)
+}
+
+func (p *kafkaEventPublisher) publishMessage(ctx context.Context, workflowID string, value []byte) error {
+	body, err := json.Marshal(kafkaProduceRequest{
+		Records: []kafkaProduceRecord{{Key: workflowID, Value: value}},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaJSONContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if p.username != "" {
+		req.SetBasicAuth(p.username, p.password)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("producing record: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("producing record: %s: %s", resp.Status, respBody)
+	}
+
+	var produced kafkaProduceResponse
+	if err := json.Unmarshal(respBody, &produced); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	for _, o := range produced.Offsets {
+		if o.ErrorCode != nil {
+			return fmt.Errorf("producing record: %s (code %d)", o.Error, *o.ErrorCode)
+		}
+	}
+
+	return nil
+}
+
+func (p *kafkaEventPublisher) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
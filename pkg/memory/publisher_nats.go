@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type natsEventPublisher struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	subject string
+}
+
+// NewNATSEventPublisher returns an event publisher that sends the events to
+// NATS JetStream, under the provided subject followed by the workflow ID.
+// The server URL (nats:// or tls://) can hold a user and password, or a
+// token as user. A JetStream stream must capture the subjects, otherwise the
+// publications aren't acknowledged.
+//
+// The server doesn't need to be available when the publisher is created:
+// the client keeps reconnecting in the background, and the publications
+// fail in the meantime.
+func NewNATSEventPublisher(serverURL, subject string) (EventPublisher, error) {
+	if subject == "" {
+		return nil, fmt.Errorf("NATS subject is required")
+	}
+
+	conn, err := nats.Connect(serverURL,
+		nats.Name("pipeline-backend"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to NATS: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("initializing JetStream: %w", err)
+	}
+
+	return &natsEventPublisher{conn: conn, js: js, subject: subject}, nil
+}
+
+func (p *natsEventPublisher) Publish(ctx context.Context, workflowID string, event *Event) error {
+	payload, err := marshalEvent(workflowID, event)
+	if err != nil {
+		return err
+	}
+
+	return p.publishMessage(ctx, workflowID, payload)
+}
+
+func (p *natsEventPublisher) publishMessage(ctx context.Context, workflowID string, payload []byte) error {
+	// Dots separate the tokens of a subject.
+	subject := p.subject + "." + strings.NewReplacer(".", "_", " ", "_").Replace(workflowID)
+
+	// The publication waits for the acknowledgement of the stream.
+	if _, err := p.js.Publish(ctx, subject, payload); err != nil {
+		return fmt.Errorf("publishing to NATS: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the pending messages before closing the connection.
+func (p *natsEventPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisEventChannelPrefix = "pipeline_trigger_events:"
+
+type redisEventPublisher struct {
+	client  redis.UniversalClient
+	hashTag bool
+	tenancy RedisTenancy
+}
+
+// NewRedisEventPublisher returns an event publisher that sends the events of
+// each workflow to its own Redis pub/sub channel. Subscribers can listen to
+// every run with a pattern subscription, e.g. to the runs of a tenant with
+// <prefix><tenant>:pipeline_trigger_events:* when the tenants are isolated.
+// In a Redis Cluster, the workflow ID in the channel name is hash-tagged, as
+// the rest of keys of the run.
+func NewRedisEventPublisher(client redis.UniversalClient, tenancy RedisTenancy) EventPublisher {
+	return &redisEventPublisher{client: client, hashTag: isRedisCluster(client), tenancy: tenancy}
+}
+
+func (p *redisEventPublisher) Publish(ctx context.Context, workflowID string, event *Event) error {
+	b, err := marshalEvent(workflowID, event)
+	if err != nil {
+		return err
+	}
+
+	return p.publishMessage(ctx, workflowID, b)
+}
+
+func (p *redisEventPublisher) publishMessage(ctx context.Context, workflowID string, b []byte) error {
+	client, prefix, err := p.tenancy.route(ctx, p.client)
+	if err != nil {
+		return err
+	}
+	return client.Publish(ctx, prefix+redisRunKey(redisEventChannelPrefix, workflowID, p.hashTag), b).Err()
+}
+
+// Close doesn't close the client, which is shared with the rest of the
+// backend.
+func (p *redisEventPublisher) Close() error {
+	return nil
+}
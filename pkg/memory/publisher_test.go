@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/frankban/quicktest"
+)
+
+// recordingPublisher keeps the messages it publishes.
+type recordingPublisher struct {
+	messages [][]byte
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, workflowID string, event *Event) error {
+	b, err := marshalEvent(workflowID, event)
+	if err != nil {
+		return err
+	}
+	return p.publishMessage(ctx, workflowID, b)
+}
+
+func (p *recordingPublisher) publishMessage(_ context.Context, _ string, msg []byte) error {
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
+
+func TestEncryptedEventPublisher(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	key := bytes.Repeat([]byte{1}, 32)
+	event := &Event{
+		Event: string(ComponentOutputUpdated),
+		Data:  map[string]any{"componentId": "json", "output": "a user document"},
+	}
+
+	recorder := &recordingPublisher{}
+	p, err := NewEncryptedEventPublisher(recorder, key)
+	c.Assert(err, quicktest.IsNil)
+	c.Assert(p.Publish(ctx, "workflow", event), quicktest.IsNil)
+	c.Assert(recorder.messages, quicktest.HasLen, 1)
+	msg := recorder.messages[0]
+
+	c.Run("round trip", func(c *quicktest.C) {
+		c.Check(bytes.Contains(msg, []byte("a user document")), quicktest.IsFalse)
+
+		b, err := DecryptEvent(msg, key)
+		c.Assert(err, quicktest.IsNil)
+		workflowID, got, err := DecodeEvent(b)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(workflowID, quicktest.Equals, "workflow")
+		c.Check(got.Event, quicktest.Equals, event.Event)
+		c.Check(got.Data, quicktest.DeepEquals, event.Data)
+	})
+
+	c.Run("nok - decoded without decryption", func(c *quicktest.C) {
+		_, _, err := DecodeEvent(msg)
+		c.Check(err, quicktest.ErrorMatches, "event is encrypted and must be decrypted with DecryptEvent")
+	})
+
+	c.Run("nok - wrong key", func(c *quicktest.C) {
+		_, err := DecryptEvent(msg, bytes.Repeat([]byte{2}, 32))
+		c.Check(err, quicktest.ErrorMatches, "decrypting event: .*")
+	})
+
+	c.Run("nok - plain text", func(c *quicktest.C) {
+		plain := &recordingPublisher{}
+		c.Assert(plain.Publish(ctx, "workflow", event), quicktest.IsNil)
+
+		_, err := DecryptEvent(plain.messages[0], key)
+		c.Check(err, quicktest.ErrorIs, ErrUnencryptedEvent)
+	})
+
+	c.Run("nok - invalid key", func(c *quicktest.C) {
+		_, err := NewEncryptedEventPublisher(recorder, []byte("short"))
+		c.Check(err, quicktest.ErrorMatches, "initializing event encryption key: .*")
+	})
+}
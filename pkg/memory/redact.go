@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+// The values of the secret scope are copied into the rest of the memory when
+// the component inputs are rendered. The memory keeps track of the strings
+// that originate from the secret scope of each batch item, so these copies
+// are masked before the memory leaves the process, either in an event or in
+// a snapshot. The memory used by the execution isn't modified.
+//
+// The secret and connection scopes are kept in the snapshots, since the
+// activities that resume a run on another worker need them to render the
+// component setups and the secrets provided in the trigger request can't be
+// resolved again. They're only persisted if the backend is encrypted.
+
+// ErrUnencryptedSecrets is returned when the memory of a run that uses
+// secrets or connections is committed to a backend that isn't encrypted.
+var ErrUnencryptedSecrets = errors.New("workflow memory holds secrets and the persistence backend isn't encrypted")
+
+// minRedactedSecretLength is the length below which the secrets aren't
+// masked, as masking every occurrence of a character or two would make the
+// memory unreadable without protecting anything.
+const minRedactedSecretLength = 4
+
+// trackSecrets records the strings of the secret scope of a batch item. It
+// must be called with the lock of the batch item held whenever the scope
+// changes.
+func (wfm *workflowMemory) trackSecrets(batchIdx int) {
+	if len(wfm.secrets) != len(wfm.Data) {
+		wfm.computeSecrets()
+		return
+	}
+
+	wfm.secrets[batchIdx] = secretStrings(wfm.Data[batchIdx])
+}
+
+// computeSecrets must be called with the workflow memory lock held in write
+// mode.
+func (wfm *workflowMemory) computeSecrets() {
+	wfm.secrets = make([][]string, len(wfm.Data))
+	for idx, v := range wfm.Data {
+		wfm.secrets[idx] = secretStrings(v)
+	}
+}
+
+// secretsOf must be called with the lock of the batch item held.
+func (wfm *workflowMemory) secretsOf(batchIdx int) []string {
+	if len(wfm.secrets) != len(wfm.Data) {
+		wfm.computeSecrets()
+	}
+	return wfm.secrets[batchIdx]
+}
+
+func secretStrings(batch data.Value) []string {
+	m, ok := batch.(*data.Map)
+	if !ok {
+		return nil
+	}
+
+	var secrets []string
+	collectStrings(m.Fields[string(PipelineSecret)], minRedactedSecretLength, &secrets)
+	sortSecrets(secrets)
+	return secrets
+}
+
+// holdsCredentials reports whether a batch item of the memory has secrets or
+// connections. It must be called with the workflow memory lock held.
+func (wfm *workflowMemory) holdsCredentials() bool {
+	for _, v := range wfm.Data {
+		m, ok := v.(*data.Map)
+		if !ok {
+			continue
+		}
+		for _, k := range []string{string(PipelineSecret), string(PipelineConnection)} {
+			if scope, ok := m.Fields[k].(*data.Map); ok && len(scope.Fields) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sortSecrets puts the longer secrets first, so a secret that contains
+// another one is replaced as a whole.
+func sortSecrets(secrets []string) {
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+}
+
+func maskSecrets(s string, secrets []string) string {
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redactedValue)
+	}
+	return s
+}
+
+// redactValue returns a copy of a value where the secrets are masked. The
+// values without strings, such as files, are shared with the original.
+func redactValue(v data.Value, secrets []string) data.Value {
+	if len(secrets) == 0 {
+		return v
+	}
+
+	switch v := v.(type) {
+	case *data.String:
+		if s := maskSecrets(v.GetString(), secrets); s != v.GetString() {
+			return data.NewString(s)
+		}
+		return v
+	case *data.Map:
+		fields := make(map[string]data.Value, len(v.Fields))
+		for k, f := range v.Fields {
+			fields[k] = redactValue(f, secrets)
+		}
+		return data.NewMap(fields)
+	case *data.Array:
+		values := make([]data.Value, len(v.Values))
+		for i, item := range v.Values {
+			values[i] = redactValue(item, secrets)
+		}
+		return data.NewArray(values)
+	}
+	return v
+}
+
+// redactedBatch returns a copy of a batch item where the secrets are masked
+// in the component memory and in the pipeline output, which are the places
+// where rendered values end up. It must be called with the workflow memory
+// lock held.
+func (wfm *workflowMemory) redactedBatch(batchIdx int) data.Value {
+	m, ok := wfm.Data[batchIdx].(*data.Map)
+	secrets := wfm.secretsOf(batchIdx)
+	if !ok || len(secrets) == 0 {
+		return wfm.Data[batchIdx]
+	}
+
+	fields := make(map[string]data.Value, len(m.Fields))
+	for k, f := range m.Fields {
+		switch k {
+		case string(PipelineSecret), string(PipelineConnection), string(PipelineVariable), string(PipelineOutputTemplate):
+			fields[k] = f
+		default:
+			fields[k] = redactValue(f, secrets)
+		}
+	}
+	return data.NewMap(fields)
+}
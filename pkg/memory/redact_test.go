@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+func TestSecretPersistence(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	recipe := &datamodel.Recipe{
+		Component: datamodel.ComponentMap{"json": {Type: "json", Task: "TASK_MARSHAL"}},
+	}
+	const apiKey = "sk-0123456789"
+
+	// newRun creates a memory where the secrets were rendered into the
+	// output of a component.
+	newRun := func(c *quicktest.C, ms MemoryStore) {
+		wfm, err := ms.NewWorkflowMemory(ctx, "workflow", recipe, 1)
+		c.Assert(err, quicktest.IsNil)
+		c.Assert(wfm.SetPipelineData(ctx, 0, PipelineSecret, data.NewMap(map[string]data.Value{
+			"api-key": data.NewString(apiKey),
+			"short":   data.NewString("a"),
+		})), quicktest.IsNil)
+
+		wfm.InitComponent(ctx, 0, "json")
+		c.Assert(wfm.SetComponentData(ctx, 0, "json", ComponentDataOutput, data.NewMap(map[string]data.Value{
+			"string": data.NewString("Bearer " + apiKey + " for a run"),
+		})), quicktest.IsNil)
+	}
+
+	c.Run("encrypted backend", func(c *quicktest.C) {
+		wrapper, err := NewMasterKeyWrapper(bytes.Repeat([]byte{1}, 32))
+		c.Assert(err, quicktest.IsNil)
+		p := NewEncryptedPersistence(newMapPersistence(), wrapper, false)
+
+		// The memory is committed by the worker that created it and
+		// restored by another one.
+		ms := NewMemoryStore(p, 0, nil, 0, nil, nil)
+		newRun(c, ms)
+		c.Assert(ms.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+
+		restored, err := NewMemoryStore(p, 0, nil, 0, nil, nil).GetWorkflowMemory(ctx, "workflow")
+		c.Assert(err, quicktest.IsNil)
+
+		// The secret scope is kept to render the component setups.
+		secret, err := restored.Get(ctx, 0, "secret.api-key")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(secret, valueEquals, data.NewString(apiKey))
+
+		// Its copies are masked, except for the secrets too short to be
+		// masked.
+		output, err := restored.Get(ctx, 0, "json.output.string")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(output, valueEquals, data.NewString("Bearer "+redactedValue+" for a run"))
+	})
+
+	c.Run("nok - unencrypted backend", func(c *quicktest.C) {
+		p := newMapPersistence()
+		ms := NewMemoryStore(p, 0, nil, 0, nil, nil)
+		newRun(c, ms)
+
+		err := ms.CommitWorkflowMemory(ctx, "workflow")
+		c.Check(err, quicktest.ErrorIs, ErrUnencryptedSecrets)
+		c.Check(p.keys(), quicktest.HasLen, 0)
+
+		// The memory stays in the process.
+		released, err := ms.ReleaseWorkflowMemory(ctx, "workflow")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(released, quicktest.IsFalse)
+		_, err = ms.GetWorkflowMemory(ctx, "workflow")
+		c.Check(err, quicktest.IsNil)
+	})
+
+	c.Run("unencrypted backend without secrets", func(c *quicktest.C) {
+		p := newMapPersistence()
+		ms := NewMemoryStore(p, 0, nil, 0, nil, nil)
+		_, err := ms.NewWorkflowMemory(ctx, "workflow", recipe, 1)
+		c.Assert(err, quicktest.IsNil)
+
+		c.Assert(ms.CommitWorkflowMemory(ctx, "workflow"), quicktest.IsNil)
+		c.Check(p.keys(), quicktest.DeepEquals, []string{"workflow"})
+	})
+}
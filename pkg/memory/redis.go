@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// In a Redis Cluster, the keys a script or a transaction touches must be in
+// the same slot. The keys of a run are hash-tagged with its workflow ID, so
+// its memory, its revision, its component hash, its checkpoints and its event
+// channel are kept together. A standalone or Sentinel deployment isn't
+// sharded, so the keys keep their plain form there. The tenant prefix of a
+// key precedes the hash tag, so it doesn't change the slot of the run.
+
+// isRedisCluster tells whether a client talks to a Redis Cluster.
+func isRedisCluster(client redis.UniversalClient) bool {
+	_, ok := client.(*redis.ClusterClient)
+	return ok
+}
+
+// redisRunKey returns the key of a workflow under a prefix. With hash tags,
+// the workflow ID of the run is wrapped in braces. The checkpoints share the
+// hash tag of the run they belong to.
+func redisRunKey(prefix, workflowID string, hashTag bool) string {
+	if !hashTag {
+		return prefix + workflowID
+	}
+
+	runID, component, isCheckpoint := strings.Cut(workflowID, checkpointInfix)
+	key := prefix + "{" + runID + "}"
+	if isCheckpoint {
+		key += checkpointInfix + component
+	}
+	return key
+}
+
+// workflowIDFromRedisKey is the inverse of redisRunKey.
+func workflowIDFromRedisKey(prefix, key string) string {
+	id := strings.TrimPrefix(key, prefix)
+	if !strings.HasPrefix(id, "{") {
+		return id
+	}
+
+	runID, rest, _ := strings.Cut(id[1:], "}")
+	return runID + rest
+}
+
+// RedisTenancy isolates the memory keys and the event channels of each
+// tenant, i.e. the namespace that owns the pipeline of a run.
+//
+// With isolation, the keys and channels of a run are prefixed by its
+// tenant, so the memory of a tenant can be flushed at once and a subscriber
+// can be restricted (e.g. with a Redis ACL channel pattern) to the events of
+// its tenant.
+type RedisTenancy struct {
+	// KeyPrefix is prepended to every key and channel, so several
+	// deployments can share a Redis instance.
+	KeyPrefix string
+	// Isolated prefixes the keys and channels of a run with its tenant.
+	Isolated bool
+	// Clients holds the clients of the tenants that have a dedicated
+	// database, indexed by tenant. The rest of tenants use the default
+	// client.
+	Clients map[string]redis.UniversalClient
+}
+
+// tenant returns the tenant the keys of an operation are isolated for.
+func (t RedisTenancy) tenant(ctx context.Context) string {
+	if !t.Isolated {
+		return ""
+	}
+	return TenantFromContext(ctx)
+}
+
+// route returns the client and the key prefix of the tenant of an
+// operation. The tenant can't have characters that Redis interprets in key
+// patterns or hash tags, nor be named after the prefix of a memory key, as
+// flushing it would delete the keys of the runs without a tenant.
+func (t RedisTenancy) route(ctx context.Context, defaultClient redis.UniversalClient) (redis.UniversalClient, string, error) {
+	tenant := t.tenant(ctx)
+	if tenant == "" {
+		return defaultClient, t.KeyPrefix, nil
+	}
+	if strings.ContainsAny(tenant, ":{}*?[]\\") {
+		return nil, "", fmt.Errorf("invalid memory tenant %q", tenant)
+	}
+	switch tenant + ":" {
+	case redisMemoryKeyPrefix, redisRevisionKeyPrefix, redisPartitionKeyPrefix, redisTenantKeyPrefix:
+		return nil, "", fmt.Errorf("invalid memory tenant %q", tenant)
+	}
+
+	client := defaultClient
+	if c, ok := t.Clients[tenant]; ok {
+		client = c
+	}
+	return client, t.KeyPrefix + tenant + ":", nil
+}
+
+// clients returns the default client followed by the dedicated ones.
+func (t RedisTenancy) clients(defaultClient redis.UniversalClient) []redis.UniversalClient {
+	tenants := make([]string, 0, len(t.Clients))
+	for tenant := range t.Clients {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	clients := make([]redis.UniversalClient, 0, 1+len(tenants))
+	clients = append(clients, defaultClient)
+	for _, tenant := range tenants {
+		clients = append(clients, t.Clients[tenant])
+	}
+	return clients
+}
@@ -0,0 +1,292 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/frankban/quicktest"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisRunKey(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		name       string
+		workflowID string
+		hashTag    bool
+		want       string
+	}{
+		{
+			name:       "run",
+			workflowID: "run-a",
+			want:       "pipeline_trigger_memory:run-a",
+		},
+		{
+			name:       "run with hash tag",
+			workflowID: "run-a",
+			hashTag:    true,
+			want:       "pipeline_trigger_memory:{run-a}",
+		},
+		{
+			name:       "checkpoint",
+			workflowID: checkpointID("run-a", "comp"),
+			want:       "pipeline_trigger_memory:run-a/components/comp",
+		},
+		{
+			// The checkpoints are in the slot of their run.
+			name:       "checkpoint with hash tag",
+			workflowID: checkpointID("run-a", "comp"),
+			hashTag:    true,
+			want:       "pipeline_trigger_memory:{run-a}/components/comp",
+		},
+		{
+			name:       "run with braces",
+			workflowID: "run-{a}",
+			want:       "pipeline_trigger_memory:run-{a}",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			key := redisRunKey(redisMemoryKeyPrefix, tc.workflowID, tc.hashTag)
+			c.Check(key, quicktest.Equals, tc.want)
+			c.Check(workflowIDFromRedisKey(redisMemoryKeyPrefix, key), quicktest.Equals, tc.workflowID)
+		})
+	}
+
+	c.Run("same slot", func(c *quicktest.C) {
+		run := redisRunKey(redisMemoryKeyPrefix, "run-a", true)
+		for _, key := range []string{
+			redisRunKey(redisRevisionKeyPrefix, "run-a", true),
+			redisRunKey(redisPartitionKeyPrefix, "run-a", true),
+			redisRunKey(redisMemoryKeyPrefix, checkpointID("run-a", "comp"), true),
+			"acme:" + redisRunKey(redisEventChannelPrefix, "run-a", true),
+		} {
+			c.Check(redisHashTag(key), quicktest.Equals, redisHashTag(run), quicktest.Commentf(key))
+		}
+	})
+}
+
+// redisHashTag returns the part of a key that Redis Cluster hashes to find
+// its slot.
+func redisHashTag(key string) string {
+	start := strings.Index(key, "{")
+	if start < 0 {
+		return key
+	}
+	end := strings.Index(key[start+1:], "}")
+	if end <= 0 {
+		return key
+	}
+	return key[start+1 : start+1+end]
+}
+
+func TestRedisTenancyRoute(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	defaultClient := redis.NewClient(&redis.Options{})
+	dedicated := redis.NewClient(&redis.Options{DB: 1})
+	c.Cleanup(func() {
+		defaultClient.Close()
+		dedicated.Close()
+	})
+
+	isolated := RedisTenancy{
+		KeyPrefix: "staging:",
+		Isolated:  true,
+		Clients:   map[string]redis.UniversalClient{"dedicated": dedicated},
+	}
+
+	testcases := []struct {
+		name       string
+		tenancy    RedisTenancy
+		tenant     string
+		wantClient redis.UniversalClient
+		wantPrefix string
+		wantErr    string
+	}{
+		{
+			name:       "ok - not isolated",
+			tenancy:    RedisTenancy{KeyPrefix: "staging:", Clients: isolated.Clients},
+			tenant:     "dedicated",
+			wantClient: defaultClient,
+			wantPrefix: "staging:",
+		},
+		{
+			name:       "ok - no tenant",
+			tenancy:    isolated,
+			wantClient: defaultClient,
+			wantPrefix: "staging:",
+		},
+		{
+			name:       "ok - tenant",
+			tenancy:    isolated,
+			tenant:     "acme",
+			wantClient: defaultClient,
+			wantPrefix: "staging:acme:",
+		},
+		{
+			name:       "ok - dedicated database",
+			tenancy:    isolated,
+			tenant:     "dedicated",
+			wantClient: dedicated,
+			wantPrefix: "staging:dedicated:",
+		},
+		{
+			name:    "nok - colon",
+			tenancy: isolated,
+			tenant:  "acme:runs",
+			wantErr: `invalid memory tenant "acme:runs"`,
+		},
+		{
+			name:    "nok - pattern",
+			tenancy: isolated,
+			tenant:  "acme*",
+			wantErr: `invalid memory tenant "acme\*"`,
+		},
+		{
+			name:    "nok - hash tag",
+			tenancy: isolated,
+			tenant:  "{acme}",
+			wantErr: `invalid memory tenant "{acme}"`,
+		},
+		{
+			name:    "nok - key prefix",
+			tenancy: isolated,
+			tenant:  "pipeline_trigger_memory",
+			wantErr: `invalid memory tenant "pipeline_trigger_memory"`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			client, prefix, err := tc.tenancy.route(WithTenant(ctx, tc.tenant), defaultClient)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(client, quicktest.Equals, tc.wantClient)
+			c.Check(prefix, quicktest.Equals, tc.wantPrefix)
+		})
+	}
+}
+
+func TestRedisPersistenceTenants(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	mr := miniredis.RunT(t)
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	dedicated := redis.NewClient(&redis.Options{Addr: mr.Addr(), DB: 1})
+	c.Cleanup(func() {
+		rc.Close()
+		dedicated.Close()
+	})
+
+	p := NewRedisPersistence(rc, 0, RedisTenancy{
+		Isolated: true,
+		Clients:  map[string]redis.UniversalClient{"dedicated": dedicated},
+	}).(*redisPersistence)
+
+	acme := WithTenant(ctx, "acme")
+	globex := WithTenant(ctx, "globex")
+	c.Assert(p.Save(acme, "run-a", []byte("a"), 0), quicktest.IsNil)
+	c.Assert(p.Save(acme, checkpointID("run-a", "comp"), []byte("a checkpoint"), 0), quicktest.IsNil)
+	_, err := p.SaveRevision(globex, "run-b", []byte("b"), 0, 0)
+	c.Assert(err, quicktest.IsNil)
+	c.Assert(p.Save(ctx, "run-c", []byte("c"), 0), quicktest.IsNil)
+	c.Assert(p.Save(WithTenant(ctx, "dedicated"), "run-d", []byte("d"), 0), quicktest.IsNil)
+
+	c.Run("ok - keys", func(c *quicktest.C) {
+		keys := mr.DB(0).Keys()
+		sort.Strings(keys)
+		c.Check(keys, quicktest.DeepEquals, []string{
+			"acme:pipeline_trigger_memory:run-a",
+			"acme:pipeline_trigger_memory:run-a/components/comp",
+			"globex:pipeline_trigger_memory:run-b",
+			"globex:pipeline_trigger_memory_revision:run-b",
+			"pipeline_trigger_memory:run-c",
+			"pipeline_trigger_memory_tenant:run-a",
+			"pipeline_trigger_memory_tenant:run-b",
+			"pipeline_trigger_memory_tenant:run-d",
+		})
+		c.Check(mr.DB(1).Keys(), quicktest.DeepEquals, []string{"dedicated:pipeline_trigger_memory:run-d"})
+	})
+
+	c.Run("ok - tenant of a run", func(c *quicktest.C) {
+		for workflowID, want := range map[string]string{
+			"run-a": "acme",
+			"run-b": "globex",
+			"run-c": "",
+			"run-d": "dedicated",
+		} {
+			tenant, err := p.ResolveTenant(ctx, workflowID)
+			c.Assert(err, quicktest.IsNil)
+			c.Check(tenant, quicktest.Equals, want, quicktest.Commentf(workflowID))
+		}
+	})
+
+	c.Run("nok - read another tenant", func(c *quicktest.C) {
+		_, err := p.Load(globex, "run-a")
+		c.Check(err, quicktest.ErrorIs, ErrSnapshotNotFound)
+		_, _, err = p.LoadRevision(acme, "run-b")
+		c.Check(err, quicktest.ErrorIs, ErrSnapshotNotFound)
+		_, err = p.Load(ctx, "run-a")
+		c.Check(err, quicktest.ErrorIs, ErrSnapshotNotFound)
+		_, err = p.Load(acme, "run-d")
+		c.Check(err, quicktest.ErrorIs, ErrSnapshotNotFound)
+
+		b, err := p.Load(acme, "run-a")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(string(b), quicktest.Equals, "a")
+	})
+
+	c.Run("ok - list workflows", func(c *quicktest.C) {
+		ids, err := p.ListWorkflows(ctx)
+		c.Assert(err, quicktest.IsNil)
+		sort.Strings(ids)
+		c.Check(ids, quicktest.DeepEquals, []string{"run-a", "run-b", "run-c", "run-d"})
+	})
+
+	c.Run("nok - invalid tenant", func(c *quicktest.C) {
+		c.Check(p.Save(WithTenant(ctx, "acme*"), "run-e", []byte("e"), 0), quicktest.ErrorMatches, `invalid memory tenant "acme\*"`)
+		_, err := p.FlushTenant(ctx, "*")
+		c.Check(err, quicktest.ErrorMatches, `invalid memory tenant "\*"`)
+		_, err = p.FlushTenant(ctx, "pipeline_trigger_memory")
+		c.Check(err, quicktest.ErrorMatches, `invalid memory tenant "pipeline_trigger_memory"`)
+	})
+
+	c.Run("ok - flush a tenant", func(c *quicktest.C) {
+		deleted, err := p.FlushTenant(ctx, "globex")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(deleted, quicktest.Equals, 2)
+
+		_, _, err = p.LoadRevision(globex, "run-b")
+		c.Check(err, quicktest.ErrorIs, ErrSnapshotNotFound)
+
+		// The memory of the rest of tenants is kept.
+		for tenant, workflowID := range map[string]string{"acme": "run-a", "": "run-c", "dedicated": "run-d"} {
+			_, err := p.Load(WithTenant(ctx, tenant), workflowID)
+			c.Check(err, quicktest.IsNil, quicktest.Commentf(workflowID))
+		}
+
+		deleted, err = p.FlushTenant(ctx, "dedicated")
+		c.Assert(err, quicktest.IsNil)
+		c.Check(deleted, quicktest.Equals, 1)
+		c.Check(mr.DB(1).Keys(), quicktest.HasLen, 0)
+		_, err = p.Load(acme, "run-a")
+		c.Check(err, quicktest.IsNil)
+	})
+
+	c.Run("nok - flush without isolation", func(c *quicktest.C) {
+		p := NewRedisPersistence(rc, 0, RedisTenancy{}).(*redisPersistence)
+		_, err := p.FlushTenant(ctx, "acme")
+		c.Check(err, quicktest.ErrorMatches, "the memory keys aren't isolated per tenant")
+	})
+}
@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/x/errmsg"
+)
+
+// ErrMemoryLimitExceeded is returned when a value doesn't fit in the memory
+// limit of a pipeline run.
+var ErrMemoryLimitExceeded = errors.New("memory limit exceeded")
+
+// MemorySize is the size, in bytes, of the data held in a workflow memory.
+// Each batch item counts the values it holds, while the total counts the
+// values shared by several batch items once.
+type MemorySize struct {
+	Total   int64
+	Batches []int64
+}
+
+// valueSize estimates the bytes a value takes in memory. Only the payload is
+// counted (strings, bytes, file contents and map keys), as it outweighs the
+// overhead of the data structures in the runs that stress the worker.
+func valueSize(v data.Value) int64 {
+	switch v := v.(type) {
+	case *data.Boolean:
+		return 1
+	case *data.Number:
+		return 8
+	case *data.String:
+		return int64(len(v.Raw))
+	case *data.Decimal:
+		return int64(len(v.GetDecimal().Num().Bits())+len(v.GetDecimal().Denom().Bits())) * 8
+	case *data.Duration:
+		return 8
+	case *data.DateTime:
+		return 8 + int64(len(v.Raw.Location().String()))
+	case *data.ByteArray:
+		return int64(len(v.Raw))
+	case *data.Binary:
+		return int64(len(v.Raw) + len(v.ContentType) + len(v.FileName))
+	case *data.Proto:
+		return int64(len(v.TypeURL()) + len(v.Raw.GetValue()))
+	case *data.FileRef:
+		// The content isn't held in memory.
+		size := int64(len(v.URI) + len(v.ContentType) + len(v.FileName))
+		for k, m := range v.Metadata {
+			size += int64(len(k) + len(m))
+		}
+		return size
+	case *data.File:
+		return fileSize(v)
+	case *data.Image:
+		return fileSize(&v.File)
+	case *data.Video:
+		return fileSize(&v.File)
+	case *data.Audio:
+		return fileSize(&v.File)
+	case *data.Document:
+		return fileSize(&v.File)
+	case *data.Map:
+		var size int64
+		for k, f := range v.Fields {
+			size += int64(len(k)) + valueSize(f)
+		}
+		return size
+	case *data.Array:
+		var size int64
+		for _, item := range v.Values {
+			size += valueSize(item)
+		}
+		return size
+	}
+	return 0
+}
+
+// fileSize doesn't count the cached conversions of a file, as the one in the
+// original format shares the buffer of the raw content.
+func fileSize(f *data.File) int64 {
+	return int64(len(f.Raw) + len(f.ContentType) + len(f.FileName) + len(f.SourceURL))
+}
+
+// resize updates the size of a batch item when a value replaces another one.
+// If the memory is limited and the new value doesn't fit, the size isn't
+// updated and an error is returned, so the value mustn't be stored.
+//
+// resize must be called with the lock of the batch item held. The size of
+// the other items is read and the shared values are counted under sharedMu.
+func (wfm *workflowMemory) resize(batchIdx int, oldValue, newValue data.Value, enforce bool) error {
+	if newValue == oldValue {
+		return nil
+	}
+
+	wfm.sharedMu.Lock()
+	defer wfm.sharedMu.Unlock()
+
+	delta := valueSize(newValue) - valueSize(oldValue)
+	if enforce && wfm.maxSize > 0 && delta > 0 {
+		if total := wfm.totalSize() + delta - wfm.sharedSize(newValue); total > wfm.maxSize {
+			return errmsg.AddMessage(
+				fmt.Errorf("%w: the run needs %d bytes and the limit is %d bytes", ErrMemoryLimitExceeded, total, wfm.maxSize),
+				fmt.Sprintf("Memory limit exceeded: the pipeline run can't hold more than %.1f MB of data.", float64(wfm.maxSize)/(1<<20)),
+			)
+		}
+	}
+
+	wfm.sizes[batchIdx] += delta
+	wfm.retain(newValue)
+	wfm.release(oldValue)
+	return nil
+}
+
+// computeSizes must be called with the workflow memory lock held in write
+// mode.
+func (wfm *workflowMemory) computeSizes() {
+	wfm.sizes = make([]int64, len(wfm.Data))
+	wfm.shares = map[data.Value]int{}
+	wfm.savedSize = 0
+	for idx, v := range wfm.Data {
+		wfm.sizes[idx] = valueSize(v)
+		if m, ok := v.(*data.Map); ok {
+			for _, f := range m.Fields {
+				wfm.retain(f)
+			}
+		}
+	}
+}
+
+func (wfm *workflowMemory) totalSize() int64 {
+	var total int64
+	for _, s := range wfm.sizes {
+		total += s
+	}
+	return total - wfm.savedSize
+}
+
+// sharedSize returns the bytes of a value that are already held by the
+// memory, as the value, or some of the values it holds, are shared with
+// other batch items.
+func (wfm *workflowMemory) sharedSize(v data.Value) int64 {
+	if data.IsShared(v) && wfm.shares[v] > 0 {
+		return valueSize(v)
+	}
+
+	var size int64
+	switch v := v.(type) {
+	case *data.Map:
+		for _, f := range v.Fields {
+			size += wfm.sharedSize(f)
+		}
+	case *data.Array:
+		for _, item := range v.Values {
+			size += wfm.sharedSize(item)
+		}
+	}
+	return size
+}
+
+// retain counts a new reference to the shared values of a value. Only the
+// first reference to a shared value counts towards the total size.
+func (wfm *workflowMemory) retain(v data.Value) {
+	if data.IsShared(v) {
+		if wfm.shares == nil {
+			wfm.shares = map[data.Value]int{}
+		}
+		wfm.shares[v]++
+		if wfm.shares[v] > 1 {
+			wfm.savedSize += valueSize(v)
+			return
+		}
+	}
+
+	switch v := v.(type) {
+	case *data.Map:
+		for _, f := range v.Fields {
+			wfm.retain(f)
+		}
+	case *data.Array:
+		for _, item := range v.Values {
+			wfm.retain(item)
+		}
+	}
+}
+
+// release drops a reference to the shared values of a value.
+func (wfm *workflowMemory) release(v data.Value) {
+	if data.IsShared(v) {
+		if n := wfm.shares[v]; n > 1 {
+			wfm.shares[v]--
+			wfm.savedSize -= valueSize(v)
+			return
+		}
+		delete(wfm.shares, v)
+	}
+
+	switch v := v.(type) {
+	case *data.Map:
+		for _, f := range v.Fields {
+			wfm.release(f)
+		}
+	case *data.Array:
+		for _, item := range v.Values {
+			wfm.release(item)
+		}
+	}
+}
+
+func (wfm *workflowMemory) GetMemorySize() MemorySize {
+	wfm.mu.RLock()
+	defer wfm.mu.RUnlock()
+	wfm.sharedMu.Lock()
+	defer wfm.sharedMu.Unlock()
+
+	return MemorySize{
+		Total:   wfm.totalSize(),
+		Batches: append([]int64(nil), wfm.sizes...),
+	}
+}
@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// The memory of a run belongs to a tenant, i.e. the namespace that owns the
+// triggered pipeline. The tenant is set in the context of the request that
+// creates the memory and the store keeps it along with the memory, so the
+// activities that use it later don't need to carry it. The backends that
+// isolate the tenants (e.g. Redis) read it from the context of each
+// operation.
+//
+// A worker that restores the memory of a run asks the backend for its
+// tenant. The memory of the runs without a tenant isn't isolated.
+
+type tenantContextKey struct{}
+
+// WithTenant returns a context whose workflow memory operations belong to a
+// tenant.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant of a context, or an empty string if
+// it has none.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenant
+}
+
+// TenantResolver is implemented by the persistence backends that record the
+// tenant of the runs whose memory they hold.
+type TenantResolver interface {
+	// ResolveTenant returns the tenant of a workflow, or an empty string if
+	// it has none.
+	ResolveTenant(ctx context.Context, workflowID string) (tenant string, err error)
+}
+
+// TenantFlusher is implemented by the persistence backends that can delete
+// the memory of every run of a tenant at once.
+type TenantFlusher interface {
+	FlushTenant(ctx context.Context, tenant string) (deleted int, err error)
+}
+
+// unwrapAs returns the first backend in a chain of wrappers that implements
+// an interface.
+func unwrapAs[T any](p MemoryPersistence) (T, bool) {
+	for {
+		if t, ok := p.(T); ok {
+			return t, true
+		}
+		w, ok := p.(wrappedPersistence)
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		p = w.Unwrap()
+	}
+}
+
+// tenantContext binds a context to the tenant of a workflow memory.
+func (wfm *workflowMemory) tenantContext(ctx context.Context) context.Context {
+	if wfm.tenant == "" || TenantFromContext(ctx) == wfm.tenant {
+		return ctx
+	}
+	return WithTenant(ctx, wfm.tenant)
+}
+
+// GetTenant returns the tenant the workflow memory belongs to.
+func (wfm *workflowMemory) GetTenant() string {
+	return wfm.tenant
+}
+
+// tenantContext binds a context to the tenant of a workflow, which is read
+// from the memory of the process or, if it isn't there, from the
+// persistence backend. A tenant already set in the context is kept.
+func (ms *memoryStore) tenantContext(ctx context.Context, workflowID string) (context.Context, error) {
+	if TenantFromContext(ctx) != "" {
+		return ctx, nil
+	}
+	if v, ok := ms.workflows.Load(workflowID); ok {
+		return v.(*workflowMemory).tenantContext(ctx), nil
+	}
+
+	resolver, ok := unwrapAs[TenantResolver](ms.persistence)
+	if !ok {
+		return ctx, nil
+	}
+
+	// The checkpoints belong to the tenant of their run.
+	runID, _, _ := strings.Cut(workflowID, checkpointInfix)
+	tenant, err := resolver.ResolveTenant(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("resolving workflow memory tenant: %w", err)
+	}
+	if tenant == "" {
+		return ctx, nil
+	}
+	return WithTenant(ctx, tenant), nil
+}
+
+// PurgeTenantMemory releases the workflow memories of a tenant from the
+// process and deletes the ones persisted by the backend, e.g. when the
+// namespace is deleted. It returns the number of persisted keys that were
+// deleted, or zero if the backend can't flush a tenant.
+func (ms *memoryStore) PurgeTenantMemory(ctx context.Context, tenant string) (deleted int, err error) {
+	if tenant == "" {
+		return 0, fmt.Errorf("missing tenant")
+	}
+
+	ms.workflows.Range(func(k, v any) bool {
+		if v.(*workflowMemory).tenant == tenant {
+			ms.workflows.CompareAndDelete(k, v)
+		}
+		return true
+	})
+
+	flusher, ok := unwrapAs[TenantFlusher](ms.persistence)
+	if !ok {
+		return 0, nil
+	}
+	return flusher.FlushTenant(ctx, tenant)
+}
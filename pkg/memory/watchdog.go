@@ -0,0 +1,206 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/instill-ai/x/errmsg"
+)
+
+// ErrWorkerMemoryExhausted is returned when a heavy trigger is refused
+// because the worker is close to its memory limit.
+var ErrWorkerMemoryExhausted = errors.New("worker memory exhausted")
+
+// watchdogSpillRatio is the fraction of the limit above which the idle
+// workflow memories are spilled to the persistence backend.
+const watchdogSpillRatio = 0.8
+
+// Watchdog keeps the data held by the workflow memories of a worker under a
+// limit. Each in-flight trigger is attributed the size of its workflow
+// memory and, while its request is being decoded, the size of its payload.
+// When the usage approaches the limit, the memories that no activity uses
+// are saved to the persistence backend and released from the process, and
+// the heavy triggers are refused until the usage goes down.
+type Watchdog struct {
+	limit     int64
+	heavySize int64
+	idleTime  time.Duration
+
+	mu       sync.Mutex
+	payloads map[string]int64
+}
+
+// NewWatchdog returns a watchdog that limits the workflow memories of the
+// process to limit bytes. The triggers whose payload is at least heavySize
+// bytes are refused if they don't fit, and the memories that weren't used
+// for idleTime can be spilled.
+func NewWatchdog(limit, heavySize int64, idleTime time.Duration) *Watchdog {
+	return &Watchdog{
+		limit:     limit,
+		heavySize: heavySize,
+		idleTime:  idleTime,
+		payloads:  map[string]int64{},
+	}
+}
+
+// WorkerMemoryUsage is the data held by the workflow memories of a worker,
+// in bytes.
+type WorkerMemoryUsage struct {
+	Total int64
+	Limit int64
+	// Workflows holds the bytes attributed to each in-flight workflow, i.e.
+	// the size of its memory and of the payload being decoded.
+	Workflows map[string]int64
+}
+
+// TopWorkflows returns the IDs of the n workflows that hold the most data.
+func (u WorkerMemoryUsage) TopWorkflows(n int) []string {
+	ids := make([]string, 0, len(u.Workflows))
+	for id := range u.Workflows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return u.Workflows[ids[i]] > u.Workflows[ids[j]] })
+	return ids[:min(n, len(ids))]
+}
+
+// MemoryUsage returns the data held by the workflow memories of the process.
+func (ms *memoryStore) MemoryUsage() WorkerMemoryUsage {
+	usage := WorkerMemoryUsage{Workflows: map[string]int64{}}
+	ms.workflows.Range(func(k, v any) bool {
+		size := v.(*workflowMemory).GetMemorySize().Total
+		usage.Workflows[k.(string)] += size
+		usage.Total += size
+		return true
+	})
+
+	if w := ms.watchdog; w != nil {
+		usage.Limit = w.limit
+
+		w.mu.Lock()
+		for id, size := range w.payloads {
+			usage.Workflows[id] += size
+			usage.Total += size
+		}
+		w.mu.Unlock()
+	}
+
+	return usage
+}
+
+// AdmitTrigger accounts the payload of a trigger until the returned function
+// is called, which should happen once the payload is held by the workflow
+// memory. If the worker doesn't have room for a heavy payload, even after
+// spilling the idle workflow memories, the trigger is refused.
+func (ms *memoryStore) AdmitTrigger(ctx context.Context, workflowID string, payloadSize int64) (release func(), err error) {
+	w := ms.watchdog
+	if w == nil {
+		return func() {}, nil
+	}
+
+	usage := ms.MemoryUsage()
+	if usage.Total+payloadSize > int64(float64(w.limit)*watchdogSpillRatio) {
+		if _, err := ms.SpillIdleWorkflowMemory(ctx); err != nil {
+			return nil, fmt.Errorf("spilling idle workflow memory: %w", err)
+		}
+		usage = ms.MemoryUsage()
+	}
+
+	if payloadSize >= w.heavySize && usage.Total+payloadSize > w.limit {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("%w: the trigger needs %d bytes and %d of %d bytes are in use", ErrWorkerMemoryExhausted, payloadSize, usage.Total, w.limit),
+			"The pipeline backend is running out of memory to process such a large request. Please retry later or reduce the size of the request.",
+		)
+	}
+
+	w.mu.Lock()
+	w.payloads[workflowID] += payloadSize
+	w.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			if w.payloads[workflowID] -= payloadSize; w.payloads[workflowID] <= 0 {
+				delete(w.payloads, workflowID)
+			}
+		})
+	}, nil
+}
+
+// SpillIdleWorkflowMemory saves the idle workflow memories to the
+// persistence backend and releases them from the process, largest first,
+// until the usage is below the spill threshold. A workflow memory is idle if
+// no activity holds it, no request streams its events and it wasn't used
+// for the idle time of the watchdog. It returns how many were spilled.
+func (ms *memoryStore) SpillIdleWorkflowMemory(ctx context.Context) (int, error) {
+	w := ms.watchdog
+	if w == nil || ms.persistence == nil {
+		return 0, nil
+	}
+
+	usage := ms.MemoryUsage()
+	threshold := int64(float64(w.limit) * watchdogSpillRatio)
+	spilled := 0
+	for _, workflowID := range usage.TopWorkflows(len(usage.Workflows)) {
+		if usage.Total <= threshold {
+			break
+		}
+
+		v, ok := ms.workflows.Load(workflowID)
+		if !ok {
+			continue
+		}
+		wfm := v.(*workflowMemory)
+		if !wfm.idle(w.idleTime) {
+			continue
+		}
+
+		if err := ms.CommitWorkflowMemory(ctx, workflowID); errors.Is(err, ErrUnencryptedSecrets) {
+			// The memory can't leave the process.
+			continue
+		} else if err != nil {
+			return spilled, fmt.Errorf("saving workflow memory %s: %w", workflowID, err)
+		}
+		// The memory might have been used while it was saved.
+		if !wfm.idle(w.idleTime) {
+			continue
+		}
+		ms.workflows.CompareAndDelete(workflowID, wfm)
+
+		usage.Total -= usage.Workflows[workflowID]
+		spilled++
+	}
+
+	return spilled, nil
+}
+
+// Hold marks the workflow memory as in use until the returned function is
+// called, so it isn't spilled while an activity works with it.
+func (wfm *workflowMemory) Hold() (release func()) {
+	wfm.holds.Add(1)
+	wfm.touch()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			wfm.touch()
+			wfm.holds.Add(-1)
+		})
+	}
+}
+
+func (wfm *workflowMemory) touch() {
+	wfm.lastUsed.Store(time.Now().UnixNano())
+}
+
+func (wfm *workflowMemory) idle(idleTime time.Duration) bool {
+	if wfm.holds.Load() > 0 || wfm.IsStreaming() {
+		return false
+	}
+	return time.Since(time.Unix(0, wfm.lastUsed.Load())) >= idleTime
+}
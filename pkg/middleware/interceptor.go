@@ -15,6 +15,7 @@ import (
 
 	"github.com/instill-ai/pipeline-backend/pkg/acl"
 	"github.com/instill-ai/pipeline-backend/pkg/handler"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
 	"github.com/instill-ai/pipeline-backend/pkg/repository"
 	"github.com/instill-ai/pipeline-backend/pkg/service"
 	"github.com/instill-ai/x/errmsg"
@@ -114,9 +115,14 @@ func AsGRPCError(err error) error {
 		errors.Is(err, service.ErrUnauthenticated):
 
 		code = codes.Unauthenticated
+	case
+		errors.Is(err, errdomain.ErrFailedPrecondition):
+
+		code = codes.FailedPrecondition
 
 	case
-		errors.Is(err, service.ErrRateLimiting):
+		errors.Is(err, service.ErrRateLimiting),
+		errors.Is(err, memory.ErrWorkerMemoryExhausted):
 
 		code = codes.ResourceExhausted
 	default:
@@ -3,11 +3,13 @@ package middleware
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/instill-ai/pipeline-backend/pkg/memory"
 	"github.com/instill-ai/pipeline-backend/pkg/repository"
@@ -26,6 +28,48 @@ func AppendCustomHeaderMiddleware(mux *runtime.ServeMux, client pb.PipelinePubli
 	})
 }
 
+// ServiceHandlerFunc handles an HTTP request by calling the service layer. The
+// returned value is written as the JSON response body.
+type ServiceHandlerFunc func(ctx context.Context, srv service.Service, req *http.Request, pathParams map[string]string) (any, error)
+
+// HandleServiceRequest adapts a ServiceHandlerFunc to the gateway mux. The
+// request headers are forwarded as incoming metadata, as they are in the gRPC
+// server, so the service can authenticate the requester. Errors are
+// transformed the same way as in the gRPC interceptors.
+func HandleServiceRequest(mux *runtime.ServeMux, srv service.Service, next ServiceHandlerFunc) runtime.HandlerFunc {
+
+	return runtime.HandlerFunc(func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, r)
+
+		ctx, err := runtime.AnnotateIncomingContext(r.Context(), mux, r, r.URL.Path)
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		resp, err := next(ctx, srv, r, pathParams)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, AsGRPCError(err))
+			return
+		}
+
+		var b []byte
+		if msg, ok := resp.(proto.Message); ok {
+			b, err = outboundMarshaler.Marshal(msg)
+		} else {
+			b, err = json.Marshal(resp)
+		}
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, AsGRPCError(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(b)
+	})
+}
+
 func HandleProfileImage(srv service.Service, repo repository.Repository) runtime.HandlerFunc {
 
 	return runtime.HandlerFunc(func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
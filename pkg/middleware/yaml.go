@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+// YAMLMarshaler reads and writes the HTTP bodies in YAML, so e.g. the
+// trigger payloads can be written in the same format as the recipes. The
+// messages are mapped to YAML as the JSON marshaler maps them to JSON.
+type YAMLMarshaler struct {
+	JSON *runtime.JSONPb
+}
+
+// ContentType returns the YAML media type.
+func (*YAMLMarshaler) ContentType(_ any) string {
+	return "application/yaml"
+}
+
+// Marshal writes a message in YAML.
+func (m *YAMLMarshaler) Marshal(v any) ([]byte, error) {
+	b, err := m.JSON.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return nil, err
+	}
+	val, err := data.NewJSONValue(decoded)
+	if err != nil {
+		return nil, err
+	}
+	return data.ToYAML(val)
+}
+
+// Unmarshal reads a message from YAML.
+func (m *YAMLMarshaler) Unmarshal(b []byte, v any) error {
+	val, err := data.NewValueFromYAML(b)
+	if err != nil {
+		return err
+	}
+	if _, isNull := val.(*data.Null); isNull {
+		// An empty body.
+		return nil
+	}
+
+	sv, err := val.ToStructValue()
+	if err != nil {
+		return fmt.Errorf("converting YAML body: %w", err)
+	}
+	j, err := protojson.Marshal(sv)
+	if err != nil {
+		return fmt.Errorf("converting YAML body: %w", err)
+	}
+	return m.JSON.Unmarshal(j, v)
+}
+
+// NewDecoder returns a decoder that reads a YAML body.
+func (m *YAMLMarshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	return runtime.DecoderFunc(func(v any) error {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if len(b) == 0 {
+			return io.EOF
+		}
+		return m.Unmarshal(b, v)
+	})
+}
+
+// NewEncoder returns an encoder that writes YAML documents.
+func (m *YAMLMarshaler) NewEncoder(w io.Writer) runtime.Encoder {
+	return runtime.EncoderFunc(func(v any) error {
+		b, err := m.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	})
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	pipelinepb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+func TestYAMLMarshaler(t *testing.T) {
+	c := qt.New(t)
+
+	m := &YAMLMarshaler{JSON: &runtime.JSONPb{
+		MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+		UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}}
+	c.Check(m.ContentType(nil), qt.Equals, "application/yaml")
+
+	newInput := func(fields map[string]any) *structpb.Struct {
+		s, err := structpb.NewStruct(fields)
+		c.Assert(err, qt.IsNil)
+		return s
+	}
+
+	c.Run("ok - round trip", func(c *qt.C) {
+		req := &pipelinepb.TriggerNamespacePipelineRequest{
+			NamespaceId: "acme",
+			PipelineId:  "chat",
+			Inputs: []*structpb.Struct{
+				newInput(map[string]any{"prompt": "line 1\nline 2", "top-k": 2, "tags": []any{"a", "42"}}),
+			},
+		}
+
+		b, err := m.Marshal(req)
+		c.Assert(err, qt.IsNil)
+		c.Check(string(b), qt.Equals, `data: []
+inputs:
+  - prompt: |-
+      line 1
+      line 2
+    tags:
+      - a
+      - "42"
+    top-k: 2
+namespaceId: acme
+pipelineId: chat
+`)
+
+		got := new(pipelinepb.TriggerNamespacePipelineRequest)
+		c.Assert(m.Unmarshal(b, got), qt.IsNil)
+		c.Check(got, qt.CmpEquals(protocmp.Transform()), req)
+	})
+
+	c.Run("ok - anchors and merge keys", func(c *qt.C) {
+		got := new(pipelinepb.TriggerNamespacePipelineRequest)
+		err := m.Unmarshal([]byte(`
+namespaceId: acme
+pipelineId: chat
+defaults: &defaults
+  model: small
+  top-k: 1
+inputs:
+  - <<: *defaults
+    prompt: hello
+  - <<: *defaults
+    top-k: 2
+    prompt: !!binary aGVsbG8=
+`), got)
+		c.Assert(err, qt.IsNil)
+
+		// The unknown fields are discarded, as in the JSON bodies, and the
+		// binaries are sent as data URIs.
+		c.Check(got, qt.CmpEquals(protocmp.Transform()), &pipelinepb.TriggerNamespacePipelineRequest{
+			NamespaceId: "acme",
+			PipelineId:  "chat",
+			Inputs: []*structpb.Struct{
+				newInput(map[string]any{"model": "small", "top-k": 1, "prompt": "hello"}),
+				newInput(map[string]any{"model": "small", "top-k": 2, "prompt": "data:text/plain;base64,aGVsbG8="}),
+			},
+		})
+	})
+
+	c.Run("ok - empty body", func(c *qt.C) {
+		got := &pipelinepb.TriggerNamespacePipelineRequest{NamespaceId: "acme"}
+		c.Assert(m.Unmarshal(nil, got), qt.IsNil)
+		c.Check(got.NamespaceId, qt.Equals, "acme")
+
+		c.Check(m.NewDecoder(strings.NewReader("")).Decode(got), qt.Equals, io.EOF)
+	})
+
+	c.Run("ok - encoder and decoder", func(c *qt.C) {
+		req := &pipelinepb.TriggerNamespacePipelineRequest{NamespaceId: "acme", PipelineId: "chat"}
+
+		var buf bytes.Buffer
+		c.Assert(m.NewEncoder(&buf).Encode(req), qt.IsNil)
+
+		got := new(pipelinepb.TriggerNamespacePipelineRequest)
+		c.Assert(m.NewDecoder(&buf).Decode(got), qt.IsNil)
+		c.Check(got, qt.CmpEquals(protocmp.Transform()), req)
+	})
+
+	c.Run("nok - invalid YAML", func(c *qt.C) {
+		got := new(pipelinepb.TriggerNamespacePipelineRequest)
+		c.Check(m.Unmarshal([]byte("inputs: [a"), got), qt.ErrorMatches, "decoding YAML: .*")
+
+		// The body must still match the message.
+		c.Check(m.Unmarshal([]byte("inputs: a"), got), qt.IsNotNil)
+	})
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"mime"
 	"net"
+	"net/url"
 	"sync"
 	"time"
 
@@ -28,6 +30,7 @@ type MinioI interface {
 	DeleteFile(ctx context.Context, filePath string) (err error)
 	GetFile(ctx context.Context, filePath string) ([]byte, error)
 	GetFilesByPaths(ctx context.Context, filePaths []string) ([]FileContent, error)
+	GetDownloadURL(ctx context.Context, filePath, fileName string, expiry time.Duration) (string, error)
 }
 
 const Location = "us-east-1"
@@ -168,6 +171,51 @@ func (m *Minio) GetFile(ctx context.Context, filePathName string) ([]byte, error
 	return buf.Bytes(), nil
 }
 
+// GetDownloadURL returns a presigned URL to download a file. The file is
+// served as an attachment with the provided name.
+func (m *Minio) GetDownloadURL(ctx context.Context, filePath, fileName string, expiry time.Duration) (string, error) {
+	reqParams := url.Values{}
+	reqParams.Set("response-content-disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+
+	presignedURL, err := m.client.PresignedGetObject(ctx, m.bucket, filePath, expiry, reqParams)
+	if err != nil {
+		return "", err
+	}
+
+	return presignedURL.String(), nil
+}
+
+// ExpireObjects sets a lifecycle rule that deletes the objects under a
+// prefix the given number of days after they're created. The rule replaces
+// any previous rule for the same prefix.
+func (m *Minio) ExpireObjects(ctx context.Context, prefix string, days int) error {
+	lccfg, err := m.client.GetBucketLifecycle(ctx, m.bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code != "NoSuchLifecycleConfiguration" {
+			return err
+		}
+		lccfg = lifecycle.NewConfiguration()
+	}
+
+	ruleID := "expire-" + prefix
+	rules := make([]lifecycle.Rule, 0, len(lccfg.Rules)+1)
+	for _, r := range lccfg.Rules {
+		if r.ID != ruleID {
+			rules = append(rules, r)
+		}
+	}
+	lccfg.Rules = append(rules, lifecycle.Rule{
+		ID:         ruleID,
+		Status:     "Enabled",
+		RuleFilter: lifecycle.Filter{Prefix: prefix},
+		Expiration: lifecycle.Expiration{
+			Days: lifecycle.ExpirationDays(days),
+		},
+	})
+
+	return m.client.SetBucketLifecycle(ctx, m.bucket, lccfg)
+}
+
 // FileContent represents a file and its content
 type FileContent struct {
 	Name    string
@@ -5,6 +5,7 @@ package mock
 import (
 	"context"
 	"sync"
+	"time"
 
 	mm_atomic "sync/atomic"
 	mm_time "time"
@@ -28,6 +29,13 @@ type MinioIMock struct {
 	beforeDeleteFileCounter uint64
 	DeleteFileMock          mMinioIMockDeleteFile
 
+	funcGetDownloadURL          func(ctx context.Context, filePath string, fileName string, expiry time.Duration) (s1 string, err error)
+	funcGetDownloadURLOrigin    string
+	inspectFuncGetDownloadURL   func(ctx context.Context, filePath string, fileName string, expiry time.Duration)
+	afterGetDownloadURLCounter  uint64
+	beforeGetDownloadURLCounter uint64
+	GetDownloadURLMock          mMinioIMockGetDownloadURL
+
 	funcGetFile          func(ctx context.Context, filePath string) (ba1 []byte, err error)
 	funcGetFileOrigin    string
 	inspectFuncGetFile   func(ctx context.Context, filePath string)
@@ -68,6 +76,9 @@ func NewMinioIMock(t minimock.Tester) *MinioIMock {
 	m.DeleteFileMock = mMinioIMockDeleteFile{mock: m}
 	m.DeleteFileMock.callArgs = []*MinioIMockDeleteFileParams{}
 
+	m.GetDownloadURLMock = mMinioIMockGetDownloadURL{mock: m}
+	m.GetDownloadURLMock.callArgs = []*MinioIMockGetDownloadURLParams{}
+
 	m.GetFileMock = mMinioIMockGetFile{mock: m}
 	m.GetFileMock.callArgs = []*MinioIMockGetFileParams{}
 
@@ -427,6 +438,411 @@ func (m *MinioIMock) MinimockDeleteFileInspect() {
 	}
 }
 
+type mMinioIMockGetDownloadURL struct {
+	optional           bool
+	mock               *MinioIMock
+	defaultExpectation *MinioIMockGetDownloadURLExpectation
+	expectations       []*MinioIMockGetDownloadURLExpectation
+
+	callArgs []*MinioIMockGetDownloadURLParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// MinioIMockGetDownloadURLExpectation specifies expectation struct of the MinioI.GetDownloadURL
+type MinioIMockGetDownloadURLExpectation struct {
+	mock               *MinioIMock
+	params             *MinioIMockGetDownloadURLParams
+	paramPtrs          *MinioIMockGetDownloadURLParamPtrs
+	expectationOrigins MinioIMockGetDownloadURLExpectationOrigins
+	results            *MinioIMockGetDownloadURLResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// MinioIMockGetDownloadURLParams contains parameters of the MinioI.GetDownloadURL
+type MinioIMockGetDownloadURLParams struct {
+	ctx      context.Context
+	filePath string
+	fileName string
+	expiry   time.Duration
+}
+
+// MinioIMockGetDownloadURLParamPtrs contains pointers to parameters of the MinioI.GetDownloadURL
+type MinioIMockGetDownloadURLParamPtrs struct {
+	ctx      *context.Context
+	filePath *string
+	fileName *string
+	expiry   *time.Duration
+}
+
+// MinioIMockGetDownloadURLResults contains results of the MinioI.GetDownloadURL
+type MinioIMockGetDownloadURLResults struct {
+	s1  string
+	err error
+}
+
+// MinioIMockGetDownloadURLOrigins contains origins of expectations of the MinioI.GetDownloadURL
+type MinioIMockGetDownloadURLExpectationOrigins struct {
+	origin         string
+	originCtx      string
+	originFilePath string
+	originFileName string
+	originExpiry   string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) Optional() *mMinioIMockGetDownloadURL {
+	mmGetDownloadURL.optional = true
+	return mmGetDownloadURL
+}
+
+// Expect sets up expected params for MinioI.GetDownloadURL
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) Expect(ctx context.Context, filePath string, fileName string, expiry time.Duration) *mMinioIMockGetDownloadURL {
+	if mmGetDownloadURL.mock.funcGetDownloadURL != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Set")
+	}
+
+	if mmGetDownloadURL.defaultExpectation == nil {
+		mmGetDownloadURL.defaultExpectation = &MinioIMockGetDownloadURLExpectation{}
+	}
+
+	if mmGetDownloadURL.defaultExpectation.paramPtrs != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by ExpectParams functions")
+	}
+
+	mmGetDownloadURL.defaultExpectation.params = &MinioIMockGetDownloadURLParams{ctx, filePath, fileName, expiry}
+	mmGetDownloadURL.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetDownloadURL.expectations {
+		if minimock.Equal(e.params, mmGetDownloadURL.defaultExpectation.params) {
+			mmGetDownloadURL.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetDownloadURL.defaultExpectation.params)
+		}
+	}
+
+	return mmGetDownloadURL
+}
+
+// ExpectCtxParam1 sets up expected param ctx for MinioI.GetDownloadURL
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) ExpectCtxParam1(ctx context.Context) *mMinioIMockGetDownloadURL {
+	if mmGetDownloadURL.mock.funcGetDownloadURL != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Set")
+	}
+
+	if mmGetDownloadURL.defaultExpectation == nil {
+		mmGetDownloadURL.defaultExpectation = &MinioIMockGetDownloadURLExpectation{}
+	}
+
+	if mmGetDownloadURL.defaultExpectation.params != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Expect")
+	}
+
+	if mmGetDownloadURL.defaultExpectation.paramPtrs == nil {
+		mmGetDownloadURL.defaultExpectation.paramPtrs = &MinioIMockGetDownloadURLParamPtrs{}
+	}
+	mmGetDownloadURL.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetDownloadURL.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetDownloadURL
+}
+
+// ExpectFilePathParam2 sets up expected param filePath for MinioI.GetDownloadURL
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) ExpectFilePathParam2(filePath string) *mMinioIMockGetDownloadURL {
+	if mmGetDownloadURL.mock.funcGetDownloadURL != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Set")
+	}
+
+	if mmGetDownloadURL.defaultExpectation == nil {
+		mmGetDownloadURL.defaultExpectation = &MinioIMockGetDownloadURLExpectation{}
+	}
+
+	if mmGetDownloadURL.defaultExpectation.params != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Expect")
+	}
+
+	if mmGetDownloadURL.defaultExpectation.paramPtrs == nil {
+		mmGetDownloadURL.defaultExpectation.paramPtrs = &MinioIMockGetDownloadURLParamPtrs{}
+	}
+	mmGetDownloadURL.defaultExpectation.paramPtrs.filePath = &filePath
+	mmGetDownloadURL.defaultExpectation.expectationOrigins.originFilePath = minimock.CallerInfo(1)
+
+	return mmGetDownloadURL
+}
+
+// ExpectFileNameParam3 sets up expected param fileName for MinioI.GetDownloadURL
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) ExpectFileNameParam3(fileName string) *mMinioIMockGetDownloadURL {
+	if mmGetDownloadURL.mock.funcGetDownloadURL != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Set")
+	}
+
+	if mmGetDownloadURL.defaultExpectation == nil {
+		mmGetDownloadURL.defaultExpectation = &MinioIMockGetDownloadURLExpectation{}
+	}
+
+	if mmGetDownloadURL.defaultExpectation.params != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Expect")
+	}
+
+	if mmGetDownloadURL.defaultExpectation.paramPtrs == nil {
+		mmGetDownloadURL.defaultExpectation.paramPtrs = &MinioIMockGetDownloadURLParamPtrs{}
+	}
+	mmGetDownloadURL.defaultExpectation.paramPtrs.fileName = &fileName
+	mmGetDownloadURL.defaultExpectation.expectationOrigins.originFileName = minimock.CallerInfo(1)
+
+	return mmGetDownloadURL
+}
+
+// ExpectExpiryParam4 sets up expected param expiry for MinioI.GetDownloadURL
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) ExpectExpiryParam4(expiry time.Duration) *mMinioIMockGetDownloadURL {
+	if mmGetDownloadURL.mock.funcGetDownloadURL != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Set")
+	}
+
+	if mmGetDownloadURL.defaultExpectation == nil {
+		mmGetDownloadURL.defaultExpectation = &MinioIMockGetDownloadURLExpectation{}
+	}
+
+	if mmGetDownloadURL.defaultExpectation.params != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Expect")
+	}
+
+	if mmGetDownloadURL.defaultExpectation.paramPtrs == nil {
+		mmGetDownloadURL.defaultExpectation.paramPtrs = &MinioIMockGetDownloadURLParamPtrs{}
+	}
+	mmGetDownloadURL.defaultExpectation.paramPtrs.expiry = &expiry
+	mmGetDownloadURL.defaultExpectation.expectationOrigins.originExpiry = minimock.CallerInfo(1)
+
+	return mmGetDownloadURL
+}
+
+// Inspect accepts an inspector function that has same arguments as the MinioI.GetDownloadURL
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) Inspect(f func(ctx context.Context, filePath string, fileName string, expiry time.Duration)) *mMinioIMockGetDownloadURL {
+	if mmGetDownloadURL.mock.inspectFuncGetDownloadURL != nil {
+		mmGetDownloadURL.mock.t.Fatalf("Inspect function is already set for MinioIMock.GetDownloadURL")
+	}
+
+	mmGetDownloadURL.mock.inspectFuncGetDownloadURL = f
+
+	return mmGetDownloadURL
+}
+
+// Return sets up results that will be returned by MinioI.GetDownloadURL
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) Return(s1 string, err error) *MinioIMock {
+	if mmGetDownloadURL.mock.funcGetDownloadURL != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Set")
+	}
+
+	if mmGetDownloadURL.defaultExpectation == nil {
+		mmGetDownloadURL.defaultExpectation = &MinioIMockGetDownloadURLExpectation{mock: mmGetDownloadURL.mock}
+	}
+	mmGetDownloadURL.defaultExpectation.results = &MinioIMockGetDownloadURLResults{s1, err}
+	mmGetDownloadURL.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetDownloadURL.mock
+}
+
+// Set uses given function f to mock the MinioI.GetDownloadURL method
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) Set(f func(ctx context.Context, filePath string, fileName string, expiry time.Duration) (s1 string, err error)) *MinioIMock {
+	if mmGetDownloadURL.defaultExpectation != nil {
+		mmGetDownloadURL.mock.t.Fatalf("Default expectation is already set for the MinioI.GetDownloadURL method")
+	}
+
+	if len(mmGetDownloadURL.expectations) > 0 {
+		mmGetDownloadURL.mock.t.Fatalf("Some expectations are already set for the MinioI.GetDownloadURL method")
+	}
+
+	mmGetDownloadURL.mock.funcGetDownloadURL = f
+	mmGetDownloadURL.mock.funcGetDownloadURLOrigin = minimock.CallerInfo(1)
+	return mmGetDownloadURL.mock
+}
+
+// When sets expectation for the MinioI.GetDownloadURL which will trigger the result defined by the following
+// Then helper
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) When(ctx context.Context, filePath string, fileName string, expiry time.Duration) *MinioIMockGetDownloadURLExpectation {
+	if mmGetDownloadURL.mock.funcGetDownloadURL != nil {
+		mmGetDownloadURL.mock.t.Fatalf("MinioIMock.GetDownloadURL mock is already set by Set")
+	}
+
+	expectation := &MinioIMockGetDownloadURLExpectation{
+		mock:               mmGetDownloadURL.mock,
+		params:             &MinioIMockGetDownloadURLParams{ctx, filePath, fileName, expiry},
+		expectationOrigins: MinioIMockGetDownloadURLExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetDownloadURL.expectations = append(mmGetDownloadURL.expectations, expectation)
+	return expectation
+}
+
+// Then sets up MinioI.GetDownloadURL return parameters for the expectation previously defined by the When method
+func (e *MinioIMockGetDownloadURLExpectation) Then(s1 string, err error) *MinioIMock {
+	e.results = &MinioIMockGetDownloadURLResults{s1, err}
+	return e.mock
+}
+
+// Times sets number of times MinioI.GetDownloadURL should be invoked
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) Times(n uint64) *mMinioIMockGetDownloadURL {
+	if n == 0 {
+		mmGetDownloadURL.mock.t.Fatalf("Times of MinioIMock.GetDownloadURL mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetDownloadURL.expectedInvocations, n)
+	mmGetDownloadURL.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetDownloadURL
+}
+
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) invocationsDone() bool {
+	if len(mmGetDownloadURL.expectations) == 0 && mmGetDownloadURL.defaultExpectation == nil && mmGetDownloadURL.mock.funcGetDownloadURL == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetDownloadURL.mock.afterGetDownloadURLCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetDownloadURL.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetDownloadURL implements mm_minio.MinioI
+func (mmGetDownloadURL *MinioIMock) GetDownloadURL(ctx context.Context, filePath string, fileName string, expiry time.Duration) (s1 string, err error) {
+	mm_atomic.AddUint64(&mmGetDownloadURL.beforeGetDownloadURLCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetDownloadURL.afterGetDownloadURLCounter, 1)
+
+	mmGetDownloadURL.t.Helper()
+
+	if mmGetDownloadURL.inspectFuncGetDownloadURL != nil {
+		mmGetDownloadURL.inspectFuncGetDownloadURL(ctx, filePath, fileName, expiry)
+	}
+
+	mm_params := MinioIMockGetDownloadURLParams{ctx, filePath, fileName, expiry}
+
+	// Record call args
+	mmGetDownloadURL.GetDownloadURLMock.mutex.Lock()
+	mmGetDownloadURL.GetDownloadURLMock.callArgs = append(mmGetDownloadURL.GetDownloadURLMock.callArgs, &mm_params)
+	mmGetDownloadURL.GetDownloadURLMock.mutex.Unlock()
+
+	for _, e := range mmGetDownloadURL.GetDownloadURLMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.s1, e.results.err
+		}
+	}
+
+	if mmGetDownloadURL.GetDownloadURLMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetDownloadURL.GetDownloadURLMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetDownloadURL.GetDownloadURLMock.defaultExpectation.params
+		mm_want_ptrs := mmGetDownloadURL.GetDownloadURLMock.defaultExpectation.paramPtrs
+
+		mm_got := MinioIMockGetDownloadURLParams{ctx, filePath, fileName, expiry}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetDownloadURL.t.Errorf("MinioIMock.GetDownloadURL got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetDownloadURL.GetDownloadURLMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.filePath != nil && !minimock.Equal(*mm_want_ptrs.filePath, mm_got.filePath) {
+				mmGetDownloadURL.t.Errorf("MinioIMock.GetDownloadURL got unexpected parameter filePath, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetDownloadURL.GetDownloadURLMock.defaultExpectation.expectationOrigins.originFilePath, *mm_want_ptrs.filePath, mm_got.filePath, minimock.Diff(*mm_want_ptrs.filePath, mm_got.filePath))
+			}
+
+			if mm_want_ptrs.fileName != nil && !minimock.Equal(*mm_want_ptrs.fileName, mm_got.fileName) {
+				mmGetDownloadURL.t.Errorf("MinioIMock.GetDownloadURL got unexpected parameter fileName, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetDownloadURL.GetDownloadURLMock.defaultExpectation.expectationOrigins.originFileName, *mm_want_ptrs.fileName, mm_got.fileName, minimock.Diff(*mm_want_ptrs.fileName, mm_got.fileName))
+			}
+
+			if mm_want_ptrs.expiry != nil && !minimock.Equal(*mm_want_ptrs.expiry, mm_got.expiry) {
+				mmGetDownloadURL.t.Errorf("MinioIMock.GetDownloadURL got unexpected parameter expiry, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetDownloadURL.GetDownloadURLMock.defaultExpectation.expectationOrigins.originExpiry, *mm_want_ptrs.expiry, mm_got.expiry, minimock.Diff(*mm_want_ptrs.expiry, mm_got.expiry))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetDownloadURL.t.Errorf("MinioIMock.GetDownloadURL got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetDownloadURL.GetDownloadURLMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetDownloadURL.GetDownloadURLMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetDownloadURL.t.Fatal("No results are set for the MinioIMock.GetDownloadURL")
+		}
+		return (*mm_results).s1, (*mm_results).err
+	}
+	if mmGetDownloadURL.funcGetDownloadURL != nil {
+		return mmGetDownloadURL.funcGetDownloadURL(ctx, filePath, fileName, expiry)
+	}
+	mmGetDownloadURL.t.Fatalf("Unexpected call to MinioIMock.GetDownloadURL. %v %v %v %v", ctx, filePath, fileName, expiry)
+	return
+}
+
+// GetDownloadURLAfterCounter returns a count of finished MinioIMock.GetDownloadURL invocations
+func (mmGetDownloadURL *MinioIMock) GetDownloadURLAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetDownloadURL.afterGetDownloadURLCounter)
+}
+
+// GetDownloadURLBeforeCounter returns a count of MinioIMock.GetDownloadURL invocations
+func (mmGetDownloadURL *MinioIMock) GetDownloadURLBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetDownloadURL.beforeGetDownloadURLCounter)
+}
+
+// Calls returns a list of arguments used in each call to MinioIMock.GetDownloadURL.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetDownloadURL *mMinioIMockGetDownloadURL) Calls() []*MinioIMockGetDownloadURLParams {
+	mmGetDownloadURL.mutex.RLock()
+
+	argCopy := make([]*MinioIMockGetDownloadURLParams, len(mmGetDownloadURL.callArgs))
+	copy(argCopy, mmGetDownloadURL.callArgs)
+
+	mmGetDownloadURL.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetDownloadURLDone returns true if the count of the GetDownloadURL invocations corresponds
+// the number of defined expectations
+func (m *MinioIMock) MinimockGetDownloadURLDone() bool {
+	if m.GetDownloadURLMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetDownloadURLMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetDownloadURLMock.invocationsDone()
+}
+
+// MinimockGetDownloadURLInspect logs each unmet expectation
+func (m *MinioIMock) MinimockGetDownloadURLInspect() {
+	for _, e := range m.GetDownloadURLMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to MinioIMock.GetDownloadURL at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetDownloadURLCounter := mm_atomic.LoadUint64(&m.afterGetDownloadURLCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetDownloadURLMock.defaultExpectation != nil && afterGetDownloadURLCounter < 1 {
+		if m.GetDownloadURLMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to MinioIMock.GetDownloadURL at\n%s", m.GetDownloadURLMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to MinioIMock.GetDownloadURL at\n%s with params: %#v", m.GetDownloadURLMock.defaultExpectation.expectationOrigins.origin, *m.GetDownloadURLMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetDownloadURL != nil && afterGetDownloadURLCounter < 1 {
+		m.t.Errorf("Expected call to MinioIMock.GetDownloadURL at\n%s", m.funcGetDownloadURLOrigin)
+	}
+
+	if !m.GetDownloadURLMock.invocationsDone() && afterGetDownloadURLCounter > 0 {
+		m.t.Errorf("Expected %d calls to MinioIMock.GetDownloadURL at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetDownloadURLMock.expectedInvocations), m.GetDownloadURLMock.expectedInvocationsOrigin, afterGetDownloadURLCounter)
+	}
+}
+
 type mMinioIMockGetFile struct {
 	optional           bool
 	mock               *MinioIMock
@@ -1931,6 +2347,8 @@ func (m *MinioIMock) MinimockFinish() {
 		if !m.minimockDone() {
 			m.MinimockDeleteFileInspect()
 
+			m.MinimockGetDownloadURLInspect()
+
 			m.MinimockGetFileInspect()
 
 			m.MinimockGetFilesByPathsInspect()
@@ -1962,6 +2380,7 @@ func (m *MinioIMock) minimockDone() bool {
 	done := true
 	return done &&
 		m.MinimockDeleteFileDone() &&
+		m.MinimockGetDownloadURLDone() &&
 		m.MinimockGetFileDone() &&
 		m.MinimockGetFilesByPathsDone() &&
 		m.MinimockUploadFileDone() &&
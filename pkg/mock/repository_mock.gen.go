@@ -5,6 +5,7 @@ package mock
 import (
 	"context"
 	"sync"
+	"time"
 
 	mm_atomic "sync/atomic"
 	mm_time "time"
@@ -48,6 +49,13 @@ type RepositoryMock struct {
 	beforeCheckPinnedUserCounter uint64
 	CheckPinnedUserMock          mRepositoryMockCheckPinnedUser
 
+	funcClaimDuePipelineCanaries          func(ctx context.Context, now time.Time, lease time.Duration, limit int) (pa1 []*datamodel.PipelineCanary, err error)
+	funcClaimDuePipelineCanariesOrigin    string
+	inspectFuncClaimDuePipelineCanaries   func(ctx context.Context, now time.Time, lease time.Duration, limit int)
+	afterClaimDuePipelineCanariesCounter  uint64
+	beforeClaimDuePipelineCanariesCounter uint64
+	ClaimDuePipelineCanariesMock          mRepositoryMockClaimDuePipelineCanaries
+
 	funcCreateNamespaceConnection          func(ctx context.Context, cp1 *datamodel.Connection) (cp2 *datamodel.Connection, err error)
 	funcCreateNamespaceConnectionOrigin    string
 	inspectFuncCreateNamespaceConnection   func(ctx context.Context, cp1 *datamodel.Connection)
@@ -55,6 +63,13 @@ type RepositoryMock struct {
 	beforeCreateNamespaceConnectionCounter uint64
 	CreateNamespaceConnectionMock          mRepositoryMockCreateNamespaceConnection
 
+	funcCreateNamespaceEventSource          func(ctx context.Context, ep1 *datamodel.EventSource) (err error)
+	funcCreateNamespaceEventSourceOrigin    string
+	inspectFuncCreateNamespaceEventSource   func(ctx context.Context, ep1 *datamodel.EventSource)
+	afterCreateNamespaceEventSourceCounter  uint64
+	beforeCreateNamespaceEventSourceCounter uint64
+	CreateNamespaceEventSourceMock          mRepositoryMockCreateNamespaceEventSource
+
 	funcCreateNamespacePipeline          func(ctx context.Context, pipeline *datamodel.Pipeline) (err error)
 	funcCreateNamespacePipelineOrigin    string
 	inspectFuncCreateNamespacePipeline   func(ctx context.Context, pipeline *datamodel.Pipeline)
@@ -69,6 +84,13 @@ type RepositoryMock struct {
 	beforeCreateNamespacePipelineReleaseCounter uint64
 	CreateNamespacePipelineReleaseMock          mRepositoryMockCreateNamespacePipelineRelease
 
+	funcCreateNamespacePromptVersion          func(ctx context.Context, pp1 *datamodel.Prompt) (err error)
+	funcCreateNamespacePromptVersionOrigin    string
+	inspectFuncCreateNamespacePromptVersion   func(ctx context.Context, pp1 *datamodel.Prompt)
+	afterCreateNamespacePromptVersionCounter  uint64
+	beforeCreateNamespacePromptVersionCounter uint64
+	CreateNamespacePromptVersionMock          mRepositoryMockCreateNamespacePromptVersion
+
 	funcCreateNamespaceSecret          func(ctx context.Context, ownerPermalink string, secret *datamodel.Secret) (err error)
 	funcCreateNamespaceSecretOrigin    string
 	inspectFuncCreateNamespaceSecret   func(ctx context.Context, ownerPermalink string, secret *datamodel.Secret)
@@ -90,6 +112,13 @@ type RepositoryMock struct {
 	beforeDeleteNamespaceConnectionByIDCounter uint64
 	DeleteNamespaceConnectionByIDMock          mRepositoryMockDeleteNamespaceConnectionByID
 
+	funcDeleteNamespaceEventSourceByID          func(ctx context.Context, nsUID uuid.UUID, id string) (err error)
+	funcDeleteNamespaceEventSourceByIDOrigin    string
+	inspectFuncDeleteNamespaceEventSourceByID   func(ctx context.Context, nsUID uuid.UUID, id string)
+	afterDeleteNamespaceEventSourceByIDCounter  uint64
+	beforeDeleteNamespaceEventSourceByIDCounter uint64
+	DeleteNamespaceEventSourceByIDMock          mRepositoryMockDeleteNamespaceEventSourceByID
+
 	funcDeleteNamespacePipelineByID          func(ctx context.Context, ownerPermalink string, id string) (err error)
 	funcDeleteNamespacePipelineByIDOrigin    string
 	inspectFuncDeleteNamespacePipelineByID   func(ctx context.Context, ownerPermalink string, id string)
@@ -104,6 +133,13 @@ type RepositoryMock struct {
 	beforeDeleteNamespacePipelineReleaseByIDCounter uint64
 	DeleteNamespacePipelineReleaseByIDMock          mRepositoryMockDeleteNamespacePipelineReleaseByID
 
+	funcDeleteNamespacePromptByID          func(ctx context.Context, nsUID uuid.UUID, id string) (err error)
+	funcDeleteNamespacePromptByIDOrigin    string
+	inspectFuncDeleteNamespacePromptByID   func(ctx context.Context, nsUID uuid.UUID, id string)
+	afterDeleteNamespacePromptByIDCounter  uint64
+	beforeDeleteNamespacePromptByIDCounter uint64
+	DeleteNamespacePromptByIDMock          mRepositoryMockDeleteNamespacePromptByID
+
 	funcDeleteNamespaceSecretByID          func(ctx context.Context, ownerPermalink string, id string) (err error)
 	funcDeleteNamespaceSecretByIDOrigin    string
 	inspectFuncDeleteNamespaceSecretByID   func(ctx context.Context, ownerPermalink string, id string)
@@ -146,6 +182,13 @@ type RepositoryMock struct {
 	beforeGetNamespaceConnectionByIDCounter uint64
 	GetNamespaceConnectionByIDMock          mRepositoryMockGetNamespaceConnectionByID
 
+	funcGetNamespaceEventSourceByID          func(ctx context.Context, nsUID uuid.UUID, id string) (ep1 *datamodel.EventSource, err error)
+	funcGetNamespaceEventSourceByIDOrigin    string
+	inspectFuncGetNamespaceEventSourceByID   func(ctx context.Context, nsUID uuid.UUID, id string)
+	afterGetNamespaceEventSourceByIDCounter  uint64
+	beforeGetNamespaceEventSourceByIDCounter uint64
+	GetNamespaceEventSourceByIDMock          mRepositoryMockGetNamespaceEventSourceByID
+
 	funcGetNamespacePipelineByID          func(ctx context.Context, ownerPermalink string, id string, isBasicView bool, embedReleases bool) (pp1 *datamodel.Pipeline, err error)
 	funcGetNamespacePipelineByIDOrigin    string
 	inspectFuncGetNamespacePipelineByID   func(ctx context.Context, ownerPermalink string, id string, isBasicView bool, embedReleases bool)
@@ -160,6 +203,13 @@ type RepositoryMock struct {
 	beforeGetNamespacePipelineReleaseByIDCounter uint64
 	GetNamespacePipelineReleaseByIDMock          mRepositoryMockGetNamespacePipelineReleaseByID
 
+	funcGetNamespacePromptByID          func(ctx context.Context, nsUID uuid.UUID, id string, version int32) (pp1 *datamodel.Prompt, err error)
+	funcGetNamespacePromptByIDOrigin    string
+	inspectFuncGetNamespacePromptByID   func(ctx context.Context, nsUID uuid.UUID, id string, version int32)
+	afterGetNamespacePromptByIDCounter  uint64
+	beforeGetNamespacePromptByIDCounter uint64
+	GetNamespacePromptByIDMock          mRepositoryMockGetNamespacePromptByID
+
 	funcGetNamespaceSecretByID          func(ctx context.Context, ownerPermalink string, id string) (sp1 *datamodel.Secret, err error)
 	funcGetNamespaceSecretByIDOrigin    string
 	inspectFuncGetNamespaceSecretByID   func(ctx context.Context, ownerPermalink string, id string)
@@ -167,6 +217,13 @@ type RepositoryMock struct {
 	beforeGetNamespaceSecretByIDCounter uint64
 	GetNamespaceSecretByIDMock          mRepositoryMockGetNamespaceSecretByID
 
+	funcGetNamespaceSettings          func(ctx context.Context, nsUID uuid.UUID) (np1 *datamodel.NamespaceSettings, err error)
+	funcGetNamespaceSettingsOrigin    string
+	inspectFuncGetNamespaceSettings   func(ctx context.Context, nsUID uuid.UUID)
+	afterGetNamespaceSettingsCounter  uint64
+	beforeGetNamespaceSettingsCounter uint64
+	GetNamespaceSettingsMock          mRepositoryMockGetNamespaceSettings
+
 	funcGetPaginatedComponentRunsByPipelineRunIDWithPermissions          func(ctx context.Context, pipelineRunID string, page int, pageSize int, filter filtering.Filter, order ordering.OrderBy) (ca1 []datamodel.ComponentRun, i1 int64, err error)
 	funcGetPaginatedComponentRunsByPipelineRunIDWithPermissionsOrigin    string
 	inspectFuncGetPaginatedComponentRunsByPipelineRunIDWithPermissions   func(ctx context.Context, pipelineRunID string, page int, pageSize int, filter filtering.Filter, order ordering.OrderBy)
@@ -223,6 +280,13 @@ type RepositoryMock struct {
 	beforeGetPipelineRunByUIDCounter uint64
 	GetPipelineRunByUIDMock          mRepositoryMockGetPipelineRunByUID
 
+	funcGetRunArtifact          func(ctx context.Context, pipelineTriggerUID uuid.UUID, artifactUID uuid.UUID) (rp1 *datamodel.RunArtifact, err error)
+	funcGetRunArtifactOrigin    string
+	inspectFuncGetRunArtifact   func(ctx context.Context, pipelineTriggerUID uuid.UUID, artifactUID uuid.UUID)
+	afterGetRunArtifactCounter  uint64
+	beforeGetRunArtifactCounter uint64
+	GetRunArtifactMock          mRepositoryMockGetRunArtifact
+
 	funcListComponentDefinitionUIDs          func(ctx context.Context, l1 mm_repository.ListComponentDefinitionsParams) (uids []*datamodel.ComponentDefinition, totalSize int64, err error)
 	funcListComponentDefinitionUIDsOrigin    string
 	inspectFuncListComponentDefinitionUIDs   func(ctx context.Context, l1 mm_repository.ListComponentDefinitionsParams)
@@ -230,6 +294,13 @@ type RepositoryMock struct {
 	beforeListComponentDefinitionUIDsCounter uint64
 	ListComponentDefinitionUIDsMock          mRepositoryMockListComponentDefinitionUIDs
 
+	funcListEventSourcesAdmin          func(ctx context.Context) (epa1 []*datamodel.EventSource, err error)
+	funcListEventSourcesAdminOrigin    string
+	inspectFuncListEventSourcesAdmin   func(ctx context.Context)
+	afterListEventSourcesAdminCounter  uint64
+	beforeListEventSourcesAdminCounter uint64
+	ListEventSourcesAdminMock          mRepositoryMockListEventSourcesAdmin
+
 	funcListIntegrations          func(ctx context.Context, l1 mm_repository.ListIntegrationsParams) (i1 mm_repository.IntegrationList, err error)
 	funcListIntegrationsOrigin    string
 	inspectFuncListIntegrations   func(ctx context.Context, l1 mm_repository.ListIntegrationsParams)
@@ -244,6 +315,13 @@ type RepositoryMock struct {
 	beforeListNamespaceConnectionsCounter uint64
 	ListNamespaceConnectionsMock          mRepositoryMockListNamespaceConnections
 
+	funcListNamespaceEventSources          func(ctx context.Context, nsUID uuid.UUID) (epa1 []*datamodel.EventSource, err error)
+	funcListNamespaceEventSourcesOrigin    string
+	inspectFuncListNamespaceEventSources   func(ctx context.Context, nsUID uuid.UUID)
+	afterListNamespaceEventSourcesCounter  uint64
+	beforeListNamespaceEventSourcesCounter uint64
+	ListNamespaceEventSourcesMock          mRepositoryMockListNamespaceEventSources
+
 	funcListNamespacePipelineReleases          func(ctx context.Context, ownerPermalink string, pipelineUID uuid.UUID, pageSize int64, pageToken string, isBasicView bool, filter filtering.Filter, showDeleted bool, returnCount bool) (ppa1 []*datamodel.PipelineRelease, i1 int64, s1 string, err error)
 	funcListNamespacePipelineReleasesOrigin    string
 	inspectFuncListNamespacePipelineReleases   func(ctx context.Context, ownerPermalink string, pipelineUID uuid.UUID, pageSize int64, pageToken string, isBasicView bool, filter filtering.Filter, showDeleted bool, returnCount bool)
@@ -258,6 +336,20 @@ type RepositoryMock struct {
 	beforeListNamespacePipelinesCounter uint64
 	ListNamespacePipelinesMock          mRepositoryMockListNamespacePipelines
 
+	funcListNamespacePromptVersions          func(ctx context.Context, nsUID uuid.UUID, id string) (ppa1 []*datamodel.Prompt, err error)
+	funcListNamespacePromptVersionsOrigin    string
+	inspectFuncListNamespacePromptVersions   func(ctx context.Context, nsUID uuid.UUID, id string)
+	afterListNamespacePromptVersionsCounter  uint64
+	beforeListNamespacePromptVersionsCounter uint64
+	ListNamespacePromptVersionsMock          mRepositoryMockListNamespacePromptVersions
+
+	funcListNamespacePrompts          func(ctx context.Context, nsUID uuid.UUID) (ppa1 []*datamodel.Prompt, err error)
+	funcListNamespacePromptsOrigin    string
+	inspectFuncListNamespacePrompts   func(ctx context.Context, nsUID uuid.UUID)
+	afterListNamespacePromptsCounter  uint64
+	beforeListNamespacePromptsCounter uint64
+	ListNamespacePromptsMock          mRepositoryMockListNamespacePrompts
+
 	funcListNamespaceSecrets          func(ctx context.Context, ownerPermalink string, pageSize int64, pageToken string, filter filtering.Filter) (spa1 []*datamodel.Secret, i1 int64, s1 string, err error)
 	funcListNamespaceSecretsOrigin    string
 	inspectFuncListNamespaceSecrets   func(ctx context.Context, ownerPermalink string, pageSize int64, pageToken string, filter filtering.Filter)
@@ -265,6 +357,13 @@ type RepositoryMock struct {
 	beforeListNamespaceSecretsCounter uint64
 	ListNamespaceSecretsMock          mRepositoryMockListNamespaceSecrets
 
+	funcListPipelineCanaries          func(ctx context.Context, pipelineUID uuid.UUID) (pa1 []*datamodel.PipelineCanary, err error)
+	funcListPipelineCanariesOrigin    string
+	inspectFuncListPipelineCanaries   func(ctx context.Context, pipelineUID uuid.UUID)
+	afterListPipelineCanariesCounter  uint64
+	beforeListPipelineCanariesCounter uint64
+	ListPipelineCanariesMock          mRepositoryMockListPipelineCanaries
+
 	funcListPipelineIDsByConnectionID          func(ctx context.Context, l1 mm_repository.ListPipelineIDsByConnectionIDParams) (p1 mm_repository.PipelinesByConnectionList, err error)
 	funcListPipelineIDsByConnectionIDOrigin    string
 	inspectFuncListPipelineIDsByConnectionID   func(ctx context.Context, l1 mm_repository.ListPipelineIDsByConnectionIDParams)
@@ -272,6 +371,13 @@ type RepositoryMock struct {
 	beforeListPipelineIDsByConnectionIDCounter uint64
 	ListPipelineIDsByConnectionIDMock          mRepositoryMockListPipelineIDsByConnectionID
 
+	funcListPipelineRunsByScheduleID          func(ctx context.Context, scheduleID string, limit int) (pa1 []datamodel.PipelineRun, err error)
+	funcListPipelineRunsByScheduleIDOrigin    string
+	inspectFuncListPipelineRunsByScheduleID   func(ctx context.Context, scheduleID string, limit int)
+	afterListPipelineRunsByScheduleIDCounter  uint64
+	beforeListPipelineRunsByScheduleIDCounter uint64
+	ListPipelineRunsByScheduleIDMock          mRepositoryMockListPipelineRunsByScheduleID
+
 	funcListPipelineTags          func(ctx context.Context, pipelineUID uuid.UUID) (ta1 []datamodel.Tag, err error)
 	funcListPipelineTagsOrigin    string
 	inspectFuncListPipelineTags   func(ctx context.Context, pipelineUID uuid.UUID)
@@ -293,6 +399,13 @@ type RepositoryMock struct {
 	beforeListPipelinesAdminCounter uint64
 	ListPipelinesAdminMock          mRepositoryMockListPipelinesAdmin
 
+	funcListRunArtifacts          func(ctx context.Context, pipelineTriggerUID uuid.UUID) (ra1 []datamodel.RunArtifact, err error)
+	funcListRunArtifactsOrigin    string
+	inspectFuncListRunArtifacts   func(ctx context.Context, pipelineTriggerUID uuid.UUID)
+	afterListRunArtifactsCounter  uint64
+	beforeListRunArtifactsCounter uint64
+	ListRunArtifactsMock          mRepositoryMockListRunArtifacts
+
 	funcPinUser          func(ctx context.Context, table string)
 	funcPinUserOrigin    string
 	inspectFuncPinUser   func(ctx context.Context, table string)
@@ -300,6 +413,13 @@ type RepositoryMock struct {
 	beforePinUserCounter uint64
 	PinUserMock          mRepositoryMockPinUser
 
+	funcSyncPipelineCanaries          func(ctx context.Context, pipelineUID uuid.UUID, canaries []*datamodel.PipelineCanary) (err error)
+	funcSyncPipelineCanariesOrigin    string
+	inspectFuncSyncPipelineCanaries   func(ctx context.Context, pipelineUID uuid.UUID, canaries []*datamodel.PipelineCanary)
+	afterSyncPipelineCanariesCounter  uint64
+	beforeSyncPipelineCanariesCounter uint64
+	SyncPipelineCanariesMock          mRepositoryMockSyncPipelineCanaries
+
 	funcTranspileFilter          func(f1 filtering.Filter) (ep1 *clause.Expr, err error)
 	funcTranspileFilterOrigin    string
 	inspectFuncTranspileFilter   func(f1 filtering.Filter)
@@ -314,6 +434,13 @@ type RepositoryMock struct {
 	beforeUpdateComponentRunCounter uint64
 	UpdateComponentRunMock          mRepositoryMockUpdateComponentRun
 
+	funcUpdateEventSourceCheckpoint          func(ctx context.Context, uid uuid.UUID, checkpoint []byte) (err error)
+	funcUpdateEventSourceCheckpointOrigin    string
+	inspectFuncUpdateEventSourceCheckpoint   func(ctx context.Context, uid uuid.UUID, checkpoint []byte)
+	afterUpdateEventSourceCheckpointCounter  uint64
+	beforeUpdateEventSourceCheckpointCounter uint64
+	UpdateEventSourceCheckpointMock          mRepositoryMockUpdateEventSourceCheckpoint
+
 	funcUpdateNamespaceConnectionByUID          func(ctx context.Context, u1 uuid.UUID, cp1 *datamodel.Connection) (cp2 *datamodel.Connection, err error)
 	funcUpdateNamespaceConnectionByUIDOrigin    string
 	inspectFuncUpdateNamespaceConnectionByUID   func(ctx context.Context, u1 uuid.UUID, cp1 *datamodel.Connection)
@@ -321,6 +448,13 @@ type RepositoryMock struct {
 	beforeUpdateNamespaceConnectionByUIDCounter uint64
 	UpdateNamespaceConnectionByUIDMock          mRepositoryMockUpdateNamespaceConnectionByUID
 
+	funcUpdateNamespaceConnectionMaxConcurrency          func(ctx context.Context, nsUID uuid.UUID, id string, maxConcurrency int32) (cp1 *datamodel.Connection, err error)
+	funcUpdateNamespaceConnectionMaxConcurrencyOrigin    string
+	inspectFuncUpdateNamespaceConnectionMaxConcurrency   func(ctx context.Context, nsUID uuid.UUID, id string, maxConcurrency int32)
+	afterUpdateNamespaceConnectionMaxConcurrencyCounter  uint64
+	beforeUpdateNamespaceConnectionMaxConcurrencyCounter uint64
+	UpdateNamespaceConnectionMaxConcurrencyMock          mRepositoryMockUpdateNamespaceConnectionMaxConcurrency
+
 	funcUpdateNamespacePipelineByUID          func(ctx context.Context, uid uuid.UUID, pipeline *datamodel.Pipeline) (err error)
 	funcUpdateNamespacePipelineByUIDOrigin    string
 	inspectFuncUpdateNamespacePipelineByUID   func(ctx context.Context, uid uuid.UUID, pipeline *datamodel.Pipeline)
@@ -356,6 +490,13 @@ type RepositoryMock struct {
 	beforeUpdateNamespaceSecretByIDCounter uint64
 	UpdateNamespaceSecretByIDMock          mRepositoryMockUpdateNamespaceSecretByID
 
+	funcUpdatePipelineCanary          func(ctx context.Context, pp1 *datamodel.PipelineCanary) (err error)
+	funcUpdatePipelineCanaryOrigin    string
+	inspectFuncUpdatePipelineCanary   func(ctx context.Context, pp1 *datamodel.PipelineCanary)
+	afterUpdatePipelineCanaryCounter  uint64
+	beforeUpdatePipelineCanaryCounter uint64
+	UpdatePipelineCanaryMock          mRepositoryMockUpdatePipelineCanary
+
 	funcUpdatePipelineRun          func(ctx context.Context, pipelineTriggerUID string, pipelineRun *datamodel.PipelineRun) (err error)
 	funcUpdatePipelineRunOrigin    string
 	inspectFuncUpdatePipelineRun   func(ctx context.Context, pipelineTriggerUID string, pipelineRun *datamodel.PipelineRun)
@@ -377,12 +518,26 @@ type RepositoryMock struct {
 	beforeUpsertComponentRunCounter uint64
 	UpsertComponentRunMock          mRepositoryMockUpsertComponentRun
 
+	funcUpsertNamespaceSettings          func(ctx context.Context, np1 *datamodel.NamespaceSettings) (err error)
+	funcUpsertNamespaceSettingsOrigin    string
+	inspectFuncUpsertNamespaceSettings   func(ctx context.Context, np1 *datamodel.NamespaceSettings)
+	afterUpsertNamespaceSettingsCounter  uint64
+	beforeUpsertNamespaceSettingsCounter uint64
+	UpsertNamespaceSettingsMock          mRepositoryMockUpsertNamespaceSettings
+
 	funcUpsertPipelineRun          func(ctx context.Context, pipelineRun *datamodel.PipelineRun) (err error)
 	funcUpsertPipelineRunOrigin    string
 	inspectFuncUpsertPipelineRun   func(ctx context.Context, pipelineRun *datamodel.PipelineRun)
 	afterUpsertPipelineRunCounter  uint64
 	beforeUpsertPipelineRunCounter uint64
 	UpsertPipelineRunMock          mRepositoryMockUpsertPipelineRun
+
+	funcUpsertRunArtifact          func(ctx context.Context, rp1 *datamodel.RunArtifact) (err error)
+	funcUpsertRunArtifactOrigin    string
+	inspectFuncUpsertRunArtifact   func(ctx context.Context, rp1 *datamodel.RunArtifact)
+	afterUpsertRunArtifactCounter  uint64
+	beforeUpsertRunArtifactCounter uint64
+	UpsertRunArtifactMock          mRepositoryMockUpsertRunArtifact
 }
 
 // NewRepositoryMock returns a mock for mm_repository.Repository
@@ -402,15 +557,24 @@ func NewRepositoryMock(t minimock.Tester) *RepositoryMock {
 	m.CheckPinnedUserMock = mRepositoryMockCheckPinnedUser{mock: m}
 	m.CheckPinnedUserMock.callArgs = []*RepositoryMockCheckPinnedUserParams{}
 
+	m.ClaimDuePipelineCanariesMock = mRepositoryMockClaimDuePipelineCanaries{mock: m}
+	m.ClaimDuePipelineCanariesMock.callArgs = []*RepositoryMockClaimDuePipelineCanariesParams{}
+
 	m.CreateNamespaceConnectionMock = mRepositoryMockCreateNamespaceConnection{mock: m}
 	m.CreateNamespaceConnectionMock.callArgs = []*RepositoryMockCreateNamespaceConnectionParams{}
 
+	m.CreateNamespaceEventSourceMock = mRepositoryMockCreateNamespaceEventSource{mock: m}
+	m.CreateNamespaceEventSourceMock.callArgs = []*RepositoryMockCreateNamespaceEventSourceParams{}
+
 	m.CreateNamespacePipelineMock = mRepositoryMockCreateNamespacePipeline{mock: m}
 	m.CreateNamespacePipelineMock.callArgs = []*RepositoryMockCreateNamespacePipelineParams{}
 
 	m.CreateNamespacePipelineReleaseMock = mRepositoryMockCreateNamespacePipelineRelease{mock: m}
 	m.CreateNamespacePipelineReleaseMock.callArgs = []*RepositoryMockCreateNamespacePipelineReleaseParams{}
 
+	m.CreateNamespacePromptVersionMock = mRepositoryMockCreateNamespacePromptVersion{mock: m}
+	m.CreateNamespacePromptVersionMock.callArgs = []*RepositoryMockCreateNamespacePromptVersionParams{}
+
 	m.CreateNamespaceSecretMock = mRepositoryMockCreateNamespaceSecret{mock: m}
 	m.CreateNamespaceSecretMock.callArgs = []*RepositoryMockCreateNamespaceSecretParams{}
 
@@ -420,12 +584,18 @@ func NewRepositoryMock(t minimock.Tester) *RepositoryMock {
 	m.DeleteNamespaceConnectionByIDMock = mRepositoryMockDeleteNamespaceConnectionByID{mock: m}
 	m.DeleteNamespaceConnectionByIDMock.callArgs = []*RepositoryMockDeleteNamespaceConnectionByIDParams{}
 
+	m.DeleteNamespaceEventSourceByIDMock = mRepositoryMockDeleteNamespaceEventSourceByID{mock: m}
+	m.DeleteNamespaceEventSourceByIDMock.callArgs = []*RepositoryMockDeleteNamespaceEventSourceByIDParams{}
+
 	m.DeleteNamespacePipelineByIDMock = mRepositoryMockDeleteNamespacePipelineByID{mock: m}
 	m.DeleteNamespacePipelineByIDMock.callArgs = []*RepositoryMockDeleteNamespacePipelineByIDParams{}
 
 	m.DeleteNamespacePipelineReleaseByIDMock = mRepositoryMockDeleteNamespacePipelineReleaseByID{mock: m}
 	m.DeleteNamespacePipelineReleaseByIDMock.callArgs = []*RepositoryMockDeleteNamespacePipelineReleaseByIDParams{}
 
+	m.DeleteNamespacePromptByIDMock = mRepositoryMockDeleteNamespacePromptByID{mock: m}
+	m.DeleteNamespacePromptByIDMock.callArgs = []*RepositoryMockDeleteNamespacePromptByIDParams{}
+
 	m.DeleteNamespaceSecretByIDMock = mRepositoryMockDeleteNamespaceSecretByID{mock: m}
 	m.DeleteNamespaceSecretByIDMock.callArgs = []*RepositoryMockDeleteNamespaceSecretByIDParams{}
 
@@ -444,15 +614,24 @@ func NewRepositoryMock(t minimock.Tester) *RepositoryMock {
 	m.GetNamespaceConnectionByIDMock = mRepositoryMockGetNamespaceConnectionByID{mock: m}
 	m.GetNamespaceConnectionByIDMock.callArgs = []*RepositoryMockGetNamespaceConnectionByIDParams{}
 
+	m.GetNamespaceEventSourceByIDMock = mRepositoryMockGetNamespaceEventSourceByID{mock: m}
+	m.GetNamespaceEventSourceByIDMock.callArgs = []*RepositoryMockGetNamespaceEventSourceByIDParams{}
+
 	m.GetNamespacePipelineByIDMock = mRepositoryMockGetNamespacePipelineByID{mock: m}
 	m.GetNamespacePipelineByIDMock.callArgs = []*RepositoryMockGetNamespacePipelineByIDParams{}
 
 	m.GetNamespacePipelineReleaseByIDMock = mRepositoryMockGetNamespacePipelineReleaseByID{mock: m}
 	m.GetNamespacePipelineReleaseByIDMock.callArgs = []*RepositoryMockGetNamespacePipelineReleaseByIDParams{}
 
+	m.GetNamespacePromptByIDMock = mRepositoryMockGetNamespacePromptByID{mock: m}
+	m.GetNamespacePromptByIDMock.callArgs = []*RepositoryMockGetNamespacePromptByIDParams{}
+
 	m.GetNamespaceSecretByIDMock = mRepositoryMockGetNamespaceSecretByID{mock: m}
 	m.GetNamespaceSecretByIDMock.callArgs = []*RepositoryMockGetNamespaceSecretByIDParams{}
 
+	m.GetNamespaceSettingsMock = mRepositoryMockGetNamespaceSettings{mock: m}
+	m.GetNamespaceSettingsMock.callArgs = []*RepositoryMockGetNamespaceSettingsParams{}
+
 	m.GetPaginatedComponentRunsByPipelineRunIDWithPermissionsMock = mRepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissions{mock: m}
 	m.GetPaginatedComponentRunsByPipelineRunIDWithPermissionsMock.callArgs = []*RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParams{}
 
@@ -477,27 +656,48 @@ func NewRepositoryMock(t minimock.Tester) *RepositoryMock {
 	m.GetPipelineRunByUIDMock = mRepositoryMockGetPipelineRunByUID{mock: m}
 	m.GetPipelineRunByUIDMock.callArgs = []*RepositoryMockGetPipelineRunByUIDParams{}
 
+	m.GetRunArtifactMock = mRepositoryMockGetRunArtifact{mock: m}
+	m.GetRunArtifactMock.callArgs = []*RepositoryMockGetRunArtifactParams{}
+
 	m.ListComponentDefinitionUIDsMock = mRepositoryMockListComponentDefinitionUIDs{mock: m}
 	m.ListComponentDefinitionUIDsMock.callArgs = []*RepositoryMockListComponentDefinitionUIDsParams{}
 
+	m.ListEventSourcesAdminMock = mRepositoryMockListEventSourcesAdmin{mock: m}
+	m.ListEventSourcesAdminMock.callArgs = []*RepositoryMockListEventSourcesAdminParams{}
+
 	m.ListIntegrationsMock = mRepositoryMockListIntegrations{mock: m}
 	m.ListIntegrationsMock.callArgs = []*RepositoryMockListIntegrationsParams{}
 
 	m.ListNamespaceConnectionsMock = mRepositoryMockListNamespaceConnections{mock: m}
 	m.ListNamespaceConnectionsMock.callArgs = []*RepositoryMockListNamespaceConnectionsParams{}
 
+	m.ListNamespaceEventSourcesMock = mRepositoryMockListNamespaceEventSources{mock: m}
+	m.ListNamespaceEventSourcesMock.callArgs = []*RepositoryMockListNamespaceEventSourcesParams{}
+
 	m.ListNamespacePipelineReleasesMock = mRepositoryMockListNamespacePipelineReleases{mock: m}
 	m.ListNamespacePipelineReleasesMock.callArgs = []*RepositoryMockListNamespacePipelineReleasesParams{}
 
 	m.ListNamespacePipelinesMock = mRepositoryMockListNamespacePipelines{mock: m}
 	m.ListNamespacePipelinesMock.callArgs = []*RepositoryMockListNamespacePipelinesParams{}
 
+	m.ListNamespacePromptVersionsMock = mRepositoryMockListNamespacePromptVersions{mock: m}
+	m.ListNamespacePromptVersionsMock.callArgs = []*RepositoryMockListNamespacePromptVersionsParams{}
+
+	m.ListNamespacePromptsMock = mRepositoryMockListNamespacePrompts{mock: m}
+	m.ListNamespacePromptsMock.callArgs = []*RepositoryMockListNamespacePromptsParams{}
+
 	m.ListNamespaceSecretsMock = mRepositoryMockListNamespaceSecrets{mock: m}
 	m.ListNamespaceSecretsMock.callArgs = []*RepositoryMockListNamespaceSecretsParams{}
 
+	m.ListPipelineCanariesMock = mRepositoryMockListPipelineCanaries{mock: m}
+	m.ListPipelineCanariesMock.callArgs = []*RepositoryMockListPipelineCanariesParams{}
+
 	m.ListPipelineIDsByConnectionIDMock = mRepositoryMockListPipelineIDsByConnectionID{mock: m}
 	m.ListPipelineIDsByConnectionIDMock.callArgs = []*RepositoryMockListPipelineIDsByConnectionIDParams{}
 
+	m.ListPipelineRunsByScheduleIDMock = mRepositoryMockListPipelineRunsByScheduleID{mock: m}
+	m.ListPipelineRunsByScheduleIDMock.callArgs = []*RepositoryMockListPipelineRunsByScheduleIDParams{}
+
 	m.ListPipelineTagsMock = mRepositoryMockListPipelineTags{mock: m}
 	m.ListPipelineTagsMock.callArgs = []*RepositoryMockListPipelineTagsParams{}
 
@@ -507,18 +707,30 @@ func NewRepositoryMock(t minimock.Tester) *RepositoryMock {
 	m.ListPipelinesAdminMock = mRepositoryMockListPipelinesAdmin{mock: m}
 	m.ListPipelinesAdminMock.callArgs = []*RepositoryMockListPipelinesAdminParams{}
 
+	m.ListRunArtifactsMock = mRepositoryMockListRunArtifacts{mock: m}
+	m.ListRunArtifactsMock.callArgs = []*RepositoryMockListRunArtifactsParams{}
+
 	m.PinUserMock = mRepositoryMockPinUser{mock: m}
 	m.PinUserMock.callArgs = []*RepositoryMockPinUserParams{}
 
+	m.SyncPipelineCanariesMock = mRepositoryMockSyncPipelineCanaries{mock: m}
+	m.SyncPipelineCanariesMock.callArgs = []*RepositoryMockSyncPipelineCanariesParams{}
+
 	m.TranspileFilterMock = mRepositoryMockTranspileFilter{mock: m}
 	m.TranspileFilterMock.callArgs = []*RepositoryMockTranspileFilterParams{}
 
 	m.UpdateComponentRunMock = mRepositoryMockUpdateComponentRun{mock: m}
 	m.UpdateComponentRunMock.callArgs = []*RepositoryMockUpdateComponentRunParams{}
 
+	m.UpdateEventSourceCheckpointMock = mRepositoryMockUpdateEventSourceCheckpoint{mock: m}
+	m.UpdateEventSourceCheckpointMock.callArgs = []*RepositoryMockUpdateEventSourceCheckpointParams{}
+
 	m.UpdateNamespaceConnectionByUIDMock = mRepositoryMockUpdateNamespaceConnectionByUID{mock: m}
 	m.UpdateNamespaceConnectionByUIDMock.callArgs = []*RepositoryMockUpdateNamespaceConnectionByUIDParams{}
 
+	m.UpdateNamespaceConnectionMaxConcurrencyMock = mRepositoryMockUpdateNamespaceConnectionMaxConcurrency{mock: m}
+	m.UpdateNamespaceConnectionMaxConcurrencyMock.callArgs = []*RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams{}
+
 	m.UpdateNamespacePipelineByUIDMock = mRepositoryMockUpdateNamespacePipelineByUID{mock: m}
 	m.UpdateNamespacePipelineByUIDMock.callArgs = []*RepositoryMockUpdateNamespacePipelineByUIDParams{}
 
@@ -534,6 +746,9 @@ func NewRepositoryMock(t minimock.Tester) *RepositoryMock {
 	m.UpdateNamespaceSecretByIDMock = mRepositoryMockUpdateNamespaceSecretByID{mock: m}
 	m.UpdateNamespaceSecretByIDMock.callArgs = []*RepositoryMockUpdateNamespaceSecretByIDParams{}
 
+	m.UpdatePipelineCanaryMock = mRepositoryMockUpdatePipelineCanary{mock: m}
+	m.UpdatePipelineCanaryMock.callArgs = []*RepositoryMockUpdatePipelineCanaryParams{}
+
 	m.UpdatePipelineRunMock = mRepositoryMockUpdatePipelineRun{mock: m}
 	m.UpdatePipelineRunMock.callArgs = []*RepositoryMockUpdatePipelineRunParams{}
 
@@ -543,9 +758,15 @@ func NewRepositoryMock(t minimock.Tester) *RepositoryMock {
 	m.UpsertComponentRunMock = mRepositoryMockUpsertComponentRun{mock: m}
 	m.UpsertComponentRunMock.callArgs = []*RepositoryMockUpsertComponentRunParams{}
 
+	m.UpsertNamespaceSettingsMock = mRepositoryMockUpsertNamespaceSettings{mock: m}
+	m.UpsertNamespaceSettingsMock.callArgs = []*RepositoryMockUpsertNamespaceSettingsParams{}
+
 	m.UpsertPipelineRunMock = mRepositoryMockUpsertPipelineRun{mock: m}
 	m.UpsertPipelineRunMock.callArgs = []*RepositoryMockUpsertPipelineRunParams{}
 
+	m.UpsertRunArtifactMock = mRepositoryMockUpsertRunArtifact{mock: m}
+	m.UpsertRunArtifactMock.callArgs = []*RepositoryMockUpsertRunArtifactParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
@@ -1608,6 +1829,411 @@ func (m *RepositoryMock) MinimockCheckPinnedUserInspect() {
 	}
 }
 
+type mRepositoryMockClaimDuePipelineCanaries struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockClaimDuePipelineCanariesExpectation
+	expectations       []*RepositoryMockClaimDuePipelineCanariesExpectation
+
+	callArgs []*RepositoryMockClaimDuePipelineCanariesParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockClaimDuePipelineCanariesExpectation specifies expectation struct of the Repository.ClaimDuePipelineCanaries
+type RepositoryMockClaimDuePipelineCanariesExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockClaimDuePipelineCanariesParams
+	paramPtrs          *RepositoryMockClaimDuePipelineCanariesParamPtrs
+	expectationOrigins RepositoryMockClaimDuePipelineCanariesExpectationOrigins
+	results            *RepositoryMockClaimDuePipelineCanariesResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockClaimDuePipelineCanariesParams contains parameters of the Repository.ClaimDuePipelineCanaries
+type RepositoryMockClaimDuePipelineCanariesParams struct {
+	ctx   context.Context
+	now   time.Time
+	lease time.Duration
+	limit int
+}
+
+// RepositoryMockClaimDuePipelineCanariesParamPtrs contains pointers to parameters of the Repository.ClaimDuePipelineCanaries
+type RepositoryMockClaimDuePipelineCanariesParamPtrs struct {
+	ctx   *context.Context
+	now   *time.Time
+	lease *time.Duration
+	limit *int
+}
+
+// RepositoryMockClaimDuePipelineCanariesResults contains results of the Repository.ClaimDuePipelineCanaries
+type RepositoryMockClaimDuePipelineCanariesResults struct {
+	pa1 []*datamodel.PipelineCanary
+	err error
+}
+
+// RepositoryMockClaimDuePipelineCanariesOrigins contains origins of expectations of the Repository.ClaimDuePipelineCanaries
+type RepositoryMockClaimDuePipelineCanariesExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originNow   string
+	originLease string
+	originLimit string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) Optional() *mRepositoryMockClaimDuePipelineCanaries {
+	mmClaimDuePipelineCanaries.optional = true
+	return mmClaimDuePipelineCanaries
+}
+
+// Expect sets up expected params for Repository.ClaimDuePipelineCanaries
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) Expect(ctx context.Context, now time.Time, lease time.Duration, limit int) *mRepositoryMockClaimDuePipelineCanaries {
+	if mmClaimDuePipelineCanaries.mock.funcClaimDuePipelineCanaries != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Set")
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation == nil {
+		mmClaimDuePipelineCanaries.defaultExpectation = &RepositoryMockClaimDuePipelineCanariesExpectation{}
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by ExpectParams functions")
+	}
+
+	mmClaimDuePipelineCanaries.defaultExpectation.params = &RepositoryMockClaimDuePipelineCanariesParams{ctx, now, lease, limit}
+	mmClaimDuePipelineCanaries.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmClaimDuePipelineCanaries.expectations {
+		if minimock.Equal(e.params, mmClaimDuePipelineCanaries.defaultExpectation.params) {
+			mmClaimDuePipelineCanaries.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmClaimDuePipelineCanaries.defaultExpectation.params)
+		}
+	}
+
+	return mmClaimDuePipelineCanaries
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ClaimDuePipelineCanaries
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) ExpectCtxParam1(ctx context.Context) *mRepositoryMockClaimDuePipelineCanaries {
+	if mmClaimDuePipelineCanaries.mock.funcClaimDuePipelineCanaries != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Set")
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation == nil {
+		mmClaimDuePipelineCanaries.defaultExpectation = &RepositoryMockClaimDuePipelineCanariesExpectation{}
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation.params != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Expect")
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs == nil {
+		mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs = &RepositoryMockClaimDuePipelineCanariesParamPtrs{}
+	}
+	mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs.ctx = &ctx
+	mmClaimDuePipelineCanaries.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmClaimDuePipelineCanaries
+}
+
+// ExpectNowParam2 sets up expected param now for Repository.ClaimDuePipelineCanaries
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) ExpectNowParam2(now time.Time) *mRepositoryMockClaimDuePipelineCanaries {
+	if mmClaimDuePipelineCanaries.mock.funcClaimDuePipelineCanaries != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Set")
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation == nil {
+		mmClaimDuePipelineCanaries.defaultExpectation = &RepositoryMockClaimDuePipelineCanariesExpectation{}
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation.params != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Expect")
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs == nil {
+		mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs = &RepositoryMockClaimDuePipelineCanariesParamPtrs{}
+	}
+	mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs.now = &now
+	mmClaimDuePipelineCanaries.defaultExpectation.expectationOrigins.originNow = minimock.CallerInfo(1)
+
+	return mmClaimDuePipelineCanaries
+}
+
+// ExpectLeaseParam3 sets up expected param lease for Repository.ClaimDuePipelineCanaries
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) ExpectLeaseParam3(lease time.Duration) *mRepositoryMockClaimDuePipelineCanaries {
+	if mmClaimDuePipelineCanaries.mock.funcClaimDuePipelineCanaries != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Set")
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation == nil {
+		mmClaimDuePipelineCanaries.defaultExpectation = &RepositoryMockClaimDuePipelineCanariesExpectation{}
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation.params != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Expect")
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs == nil {
+		mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs = &RepositoryMockClaimDuePipelineCanariesParamPtrs{}
+	}
+	mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs.lease = &lease
+	mmClaimDuePipelineCanaries.defaultExpectation.expectationOrigins.originLease = minimock.CallerInfo(1)
+
+	return mmClaimDuePipelineCanaries
+}
+
+// ExpectLimitParam4 sets up expected param limit for Repository.ClaimDuePipelineCanaries
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) ExpectLimitParam4(limit int) *mRepositoryMockClaimDuePipelineCanaries {
+	if mmClaimDuePipelineCanaries.mock.funcClaimDuePipelineCanaries != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Set")
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation == nil {
+		mmClaimDuePipelineCanaries.defaultExpectation = &RepositoryMockClaimDuePipelineCanariesExpectation{}
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation.params != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Expect")
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs == nil {
+		mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs = &RepositoryMockClaimDuePipelineCanariesParamPtrs{}
+	}
+	mmClaimDuePipelineCanaries.defaultExpectation.paramPtrs.limit = &limit
+	mmClaimDuePipelineCanaries.defaultExpectation.expectationOrigins.originLimit = minimock.CallerInfo(1)
+
+	return mmClaimDuePipelineCanaries
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.ClaimDuePipelineCanaries
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) Inspect(f func(ctx context.Context, now time.Time, lease time.Duration, limit int)) *mRepositoryMockClaimDuePipelineCanaries {
+	if mmClaimDuePipelineCanaries.mock.inspectFuncClaimDuePipelineCanaries != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ClaimDuePipelineCanaries")
+	}
+
+	mmClaimDuePipelineCanaries.mock.inspectFuncClaimDuePipelineCanaries = f
+
+	return mmClaimDuePipelineCanaries
+}
+
+// Return sets up results that will be returned by Repository.ClaimDuePipelineCanaries
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) Return(pa1 []*datamodel.PipelineCanary, err error) *RepositoryMock {
+	if mmClaimDuePipelineCanaries.mock.funcClaimDuePipelineCanaries != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Set")
+	}
+
+	if mmClaimDuePipelineCanaries.defaultExpectation == nil {
+		mmClaimDuePipelineCanaries.defaultExpectation = &RepositoryMockClaimDuePipelineCanariesExpectation{mock: mmClaimDuePipelineCanaries.mock}
+	}
+	mmClaimDuePipelineCanaries.defaultExpectation.results = &RepositoryMockClaimDuePipelineCanariesResults{pa1, err}
+	mmClaimDuePipelineCanaries.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmClaimDuePipelineCanaries.mock
+}
+
+// Set uses given function f to mock the Repository.ClaimDuePipelineCanaries method
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) Set(f func(ctx context.Context, now time.Time, lease time.Duration, limit int) (pa1 []*datamodel.PipelineCanary, err error)) *RepositoryMock {
+	if mmClaimDuePipelineCanaries.defaultExpectation != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("Default expectation is already set for the Repository.ClaimDuePipelineCanaries method")
+	}
+
+	if len(mmClaimDuePipelineCanaries.expectations) > 0 {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("Some expectations are already set for the Repository.ClaimDuePipelineCanaries method")
+	}
+
+	mmClaimDuePipelineCanaries.mock.funcClaimDuePipelineCanaries = f
+	mmClaimDuePipelineCanaries.mock.funcClaimDuePipelineCanariesOrigin = minimock.CallerInfo(1)
+	return mmClaimDuePipelineCanaries.mock
+}
+
+// When sets expectation for the Repository.ClaimDuePipelineCanaries which will trigger the result defined by the following
+// Then helper
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) When(ctx context.Context, now time.Time, lease time.Duration, limit int) *RepositoryMockClaimDuePipelineCanariesExpectation {
+	if mmClaimDuePipelineCanaries.mock.funcClaimDuePipelineCanaries != nil {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("RepositoryMock.ClaimDuePipelineCanaries mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockClaimDuePipelineCanariesExpectation{
+		mock:               mmClaimDuePipelineCanaries.mock,
+		params:             &RepositoryMockClaimDuePipelineCanariesParams{ctx, now, lease, limit},
+		expectationOrigins: RepositoryMockClaimDuePipelineCanariesExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmClaimDuePipelineCanaries.expectations = append(mmClaimDuePipelineCanaries.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.ClaimDuePipelineCanaries return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockClaimDuePipelineCanariesExpectation) Then(pa1 []*datamodel.PipelineCanary, err error) *RepositoryMock {
+	e.results = &RepositoryMockClaimDuePipelineCanariesResults{pa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.ClaimDuePipelineCanaries should be invoked
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) Times(n uint64) *mRepositoryMockClaimDuePipelineCanaries {
+	if n == 0 {
+		mmClaimDuePipelineCanaries.mock.t.Fatalf("Times of RepositoryMock.ClaimDuePipelineCanaries mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmClaimDuePipelineCanaries.expectedInvocations, n)
+	mmClaimDuePipelineCanaries.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmClaimDuePipelineCanaries
+}
+
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) invocationsDone() bool {
+	if len(mmClaimDuePipelineCanaries.expectations) == 0 && mmClaimDuePipelineCanaries.defaultExpectation == nil && mmClaimDuePipelineCanaries.mock.funcClaimDuePipelineCanaries == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmClaimDuePipelineCanaries.mock.afterClaimDuePipelineCanariesCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmClaimDuePipelineCanaries.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ClaimDuePipelineCanaries implements mm_repository.Repository
+func (mmClaimDuePipelineCanaries *RepositoryMock) ClaimDuePipelineCanaries(ctx context.Context, now time.Time, lease time.Duration, limit int) (pa1 []*datamodel.PipelineCanary, err error) {
+	mm_atomic.AddUint64(&mmClaimDuePipelineCanaries.beforeClaimDuePipelineCanariesCounter, 1)
+	defer mm_atomic.AddUint64(&mmClaimDuePipelineCanaries.afterClaimDuePipelineCanariesCounter, 1)
+
+	mmClaimDuePipelineCanaries.t.Helper()
+
+	if mmClaimDuePipelineCanaries.inspectFuncClaimDuePipelineCanaries != nil {
+		mmClaimDuePipelineCanaries.inspectFuncClaimDuePipelineCanaries(ctx, now, lease, limit)
+	}
+
+	mm_params := RepositoryMockClaimDuePipelineCanariesParams{ctx, now, lease, limit}
+
+	// Record call args
+	mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.mutex.Lock()
+	mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.callArgs = append(mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.callArgs, &mm_params)
+	mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.mutex.Unlock()
+
+	for _, e := range mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.pa1, e.results.err
+		}
+	}
+
+	if mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.defaultExpectation.Counter, 1)
+		mm_want := mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.defaultExpectation.params
+		mm_want_ptrs := mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockClaimDuePipelineCanariesParams{ctx, now, lease, limit}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmClaimDuePipelineCanaries.t.Errorf("RepositoryMock.ClaimDuePipelineCanaries got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.now != nil && !minimock.Equal(*mm_want_ptrs.now, mm_got.now) {
+				mmClaimDuePipelineCanaries.t.Errorf("RepositoryMock.ClaimDuePipelineCanaries got unexpected parameter now, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.defaultExpectation.expectationOrigins.originNow, *mm_want_ptrs.now, mm_got.now, minimock.Diff(*mm_want_ptrs.now, mm_got.now))
+			}
+
+			if mm_want_ptrs.lease != nil && !minimock.Equal(*mm_want_ptrs.lease, mm_got.lease) {
+				mmClaimDuePipelineCanaries.t.Errorf("RepositoryMock.ClaimDuePipelineCanaries got unexpected parameter lease, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.defaultExpectation.expectationOrigins.originLease, *mm_want_ptrs.lease, mm_got.lease, minimock.Diff(*mm_want_ptrs.lease, mm_got.lease))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmClaimDuePipelineCanaries.t.Errorf("RepositoryMock.ClaimDuePipelineCanaries got unexpected parameter limit, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.defaultExpectation.expectationOrigins.originLimit, *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmClaimDuePipelineCanaries.t.Errorf("RepositoryMock.ClaimDuePipelineCanaries got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmClaimDuePipelineCanaries.ClaimDuePipelineCanariesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmClaimDuePipelineCanaries.t.Fatal("No results are set for the RepositoryMock.ClaimDuePipelineCanaries")
+		}
+		return (*mm_results).pa1, (*mm_results).err
+	}
+	if mmClaimDuePipelineCanaries.funcClaimDuePipelineCanaries != nil {
+		return mmClaimDuePipelineCanaries.funcClaimDuePipelineCanaries(ctx, now, lease, limit)
+	}
+	mmClaimDuePipelineCanaries.t.Fatalf("Unexpected call to RepositoryMock.ClaimDuePipelineCanaries. %v %v %v %v", ctx, now, lease, limit)
+	return
+}
+
+// ClaimDuePipelineCanariesAfterCounter returns a count of finished RepositoryMock.ClaimDuePipelineCanaries invocations
+func (mmClaimDuePipelineCanaries *RepositoryMock) ClaimDuePipelineCanariesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmClaimDuePipelineCanaries.afterClaimDuePipelineCanariesCounter)
+}
+
+// ClaimDuePipelineCanariesBeforeCounter returns a count of RepositoryMock.ClaimDuePipelineCanaries invocations
+func (mmClaimDuePipelineCanaries *RepositoryMock) ClaimDuePipelineCanariesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmClaimDuePipelineCanaries.beforeClaimDuePipelineCanariesCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.ClaimDuePipelineCanaries.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmClaimDuePipelineCanaries *mRepositoryMockClaimDuePipelineCanaries) Calls() []*RepositoryMockClaimDuePipelineCanariesParams {
+	mmClaimDuePipelineCanaries.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockClaimDuePipelineCanariesParams, len(mmClaimDuePipelineCanaries.callArgs))
+	copy(argCopy, mmClaimDuePipelineCanaries.callArgs)
+
+	mmClaimDuePipelineCanaries.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockClaimDuePipelineCanariesDone returns true if the count of the ClaimDuePipelineCanaries invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockClaimDuePipelineCanariesDone() bool {
+	if m.ClaimDuePipelineCanariesMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ClaimDuePipelineCanariesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ClaimDuePipelineCanariesMock.invocationsDone()
+}
+
+// MinimockClaimDuePipelineCanariesInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockClaimDuePipelineCanariesInspect() {
+	for _, e := range m.ClaimDuePipelineCanariesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.ClaimDuePipelineCanaries at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterClaimDuePipelineCanariesCounter := mm_atomic.LoadUint64(&m.afterClaimDuePipelineCanariesCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ClaimDuePipelineCanariesMock.defaultExpectation != nil && afterClaimDuePipelineCanariesCounter < 1 {
+		if m.ClaimDuePipelineCanariesMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.ClaimDuePipelineCanaries at\n%s", m.ClaimDuePipelineCanariesMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.ClaimDuePipelineCanaries at\n%s with params: %#v", m.ClaimDuePipelineCanariesMock.defaultExpectation.expectationOrigins.origin, *m.ClaimDuePipelineCanariesMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcClaimDuePipelineCanaries != nil && afterClaimDuePipelineCanariesCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ClaimDuePipelineCanaries at\n%s", m.funcClaimDuePipelineCanariesOrigin)
+	}
+
+	if !m.ClaimDuePipelineCanariesMock.invocationsDone() && afterClaimDuePipelineCanariesCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ClaimDuePipelineCanaries at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ClaimDuePipelineCanariesMock.expectedInvocations), m.ClaimDuePipelineCanariesMock.expectedInvocationsOrigin, afterClaimDuePipelineCanariesCounter)
+	}
+}
+
 type mRepositoryMockCreateNamespaceConnection struct {
 	optional           bool
 	mock               *RepositoryMock
@@ -1951,39 +2577,381 @@ func (m *RepositoryMock) MinimockCreateNamespaceConnectionInspect() {
 	}
 }
 
-type mRepositoryMockCreateNamespacePipeline struct {
+type mRepositoryMockCreateNamespaceEventSource struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockCreateNamespacePipelineExpectation
-	expectations       []*RepositoryMockCreateNamespacePipelineExpectation
+	defaultExpectation *RepositoryMockCreateNamespaceEventSourceExpectation
+	expectations       []*RepositoryMockCreateNamespaceEventSourceExpectation
 
-	callArgs []*RepositoryMockCreateNamespacePipelineParams
+	callArgs []*RepositoryMockCreateNamespaceEventSourceParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockCreateNamespacePipelineExpectation specifies expectation struct of the Repository.CreateNamespacePipeline
-type RepositoryMockCreateNamespacePipelineExpectation struct {
+// RepositoryMockCreateNamespaceEventSourceExpectation specifies expectation struct of the Repository.CreateNamespaceEventSource
+type RepositoryMockCreateNamespaceEventSourceExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockCreateNamespacePipelineParams
-	paramPtrs          *RepositoryMockCreateNamespacePipelineParamPtrs
-	expectationOrigins RepositoryMockCreateNamespacePipelineExpectationOrigins
-	results            *RepositoryMockCreateNamespacePipelineResults
+	params             *RepositoryMockCreateNamespaceEventSourceParams
+	paramPtrs          *RepositoryMockCreateNamespaceEventSourceParamPtrs
+	expectationOrigins RepositoryMockCreateNamespaceEventSourceExpectationOrigins
+	results            *RepositoryMockCreateNamespaceEventSourceResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockCreateNamespacePipelineParams contains parameters of the Repository.CreateNamespacePipeline
-type RepositoryMockCreateNamespacePipelineParams struct {
-	ctx      context.Context
-	pipeline *datamodel.Pipeline
+// RepositoryMockCreateNamespaceEventSourceParams contains parameters of the Repository.CreateNamespaceEventSource
+type RepositoryMockCreateNamespaceEventSourceParams struct {
+	ctx context.Context
+	ep1 *datamodel.EventSource
 }
 
-// RepositoryMockCreateNamespacePipelineParamPtrs contains pointers to parameters of the Repository.CreateNamespacePipeline
-type RepositoryMockCreateNamespacePipelineParamPtrs struct {
-	ctx      *context.Context
+// RepositoryMockCreateNamespaceEventSourceParamPtrs contains pointers to parameters of the Repository.CreateNamespaceEventSource
+type RepositoryMockCreateNamespaceEventSourceParamPtrs struct {
+	ctx *context.Context
+	ep1 **datamodel.EventSource
+}
+
+// RepositoryMockCreateNamespaceEventSourceResults contains results of the Repository.CreateNamespaceEventSource
+type RepositoryMockCreateNamespaceEventSourceResults struct {
+	err error
+}
+
+// RepositoryMockCreateNamespaceEventSourceOrigins contains origins of expectations of the Repository.CreateNamespaceEventSource
+type RepositoryMockCreateNamespaceEventSourceExpectationOrigins struct {
+	origin    string
+	originCtx string
+	originEp1 string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) Optional() *mRepositoryMockCreateNamespaceEventSource {
+	mmCreateNamespaceEventSource.optional = true
+	return mmCreateNamespaceEventSource
+}
+
+// Expect sets up expected params for Repository.CreateNamespaceEventSource
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) Expect(ctx context.Context, ep1 *datamodel.EventSource) *mRepositoryMockCreateNamespaceEventSource {
+	if mmCreateNamespaceEventSource.mock.funcCreateNamespaceEventSource != nil {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("RepositoryMock.CreateNamespaceEventSource mock is already set by Set")
+	}
+
+	if mmCreateNamespaceEventSource.defaultExpectation == nil {
+		mmCreateNamespaceEventSource.defaultExpectation = &RepositoryMockCreateNamespaceEventSourceExpectation{}
+	}
+
+	if mmCreateNamespaceEventSource.defaultExpectation.paramPtrs != nil {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("RepositoryMock.CreateNamespaceEventSource mock is already set by ExpectParams functions")
+	}
+
+	mmCreateNamespaceEventSource.defaultExpectation.params = &RepositoryMockCreateNamespaceEventSourceParams{ctx, ep1}
+	mmCreateNamespaceEventSource.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmCreateNamespaceEventSource.expectations {
+		if minimock.Equal(e.params, mmCreateNamespaceEventSource.defaultExpectation.params) {
+			mmCreateNamespaceEventSource.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateNamespaceEventSource.defaultExpectation.params)
+		}
+	}
+
+	return mmCreateNamespaceEventSource
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.CreateNamespaceEventSource
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) ExpectCtxParam1(ctx context.Context) *mRepositoryMockCreateNamespaceEventSource {
+	if mmCreateNamespaceEventSource.mock.funcCreateNamespaceEventSource != nil {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("RepositoryMock.CreateNamespaceEventSource mock is already set by Set")
+	}
+
+	if mmCreateNamespaceEventSource.defaultExpectation == nil {
+		mmCreateNamespaceEventSource.defaultExpectation = &RepositoryMockCreateNamespaceEventSourceExpectation{}
+	}
+
+	if mmCreateNamespaceEventSource.defaultExpectation.params != nil {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("RepositoryMock.CreateNamespaceEventSource mock is already set by Expect")
+	}
+
+	if mmCreateNamespaceEventSource.defaultExpectation.paramPtrs == nil {
+		mmCreateNamespaceEventSource.defaultExpectation.paramPtrs = &RepositoryMockCreateNamespaceEventSourceParamPtrs{}
+	}
+	mmCreateNamespaceEventSource.defaultExpectation.paramPtrs.ctx = &ctx
+	mmCreateNamespaceEventSource.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmCreateNamespaceEventSource
+}
+
+// ExpectEp1Param2 sets up expected param ep1 for Repository.CreateNamespaceEventSource
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) ExpectEp1Param2(ep1 *datamodel.EventSource) *mRepositoryMockCreateNamespaceEventSource {
+	if mmCreateNamespaceEventSource.mock.funcCreateNamespaceEventSource != nil {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("RepositoryMock.CreateNamespaceEventSource mock is already set by Set")
+	}
+
+	if mmCreateNamespaceEventSource.defaultExpectation == nil {
+		mmCreateNamespaceEventSource.defaultExpectation = &RepositoryMockCreateNamespaceEventSourceExpectation{}
+	}
+
+	if mmCreateNamespaceEventSource.defaultExpectation.params != nil {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("RepositoryMock.CreateNamespaceEventSource mock is already set by Expect")
+	}
+
+	if mmCreateNamespaceEventSource.defaultExpectation.paramPtrs == nil {
+		mmCreateNamespaceEventSource.defaultExpectation.paramPtrs = &RepositoryMockCreateNamespaceEventSourceParamPtrs{}
+	}
+	mmCreateNamespaceEventSource.defaultExpectation.paramPtrs.ep1 = &ep1
+	mmCreateNamespaceEventSource.defaultExpectation.expectationOrigins.originEp1 = minimock.CallerInfo(1)
+
+	return mmCreateNamespaceEventSource
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.CreateNamespaceEventSource
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) Inspect(f func(ctx context.Context, ep1 *datamodel.EventSource)) *mRepositoryMockCreateNamespaceEventSource {
+	if mmCreateNamespaceEventSource.mock.inspectFuncCreateNamespaceEventSource != nil {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("Inspect function is already set for RepositoryMock.CreateNamespaceEventSource")
+	}
+
+	mmCreateNamespaceEventSource.mock.inspectFuncCreateNamespaceEventSource = f
+
+	return mmCreateNamespaceEventSource
+}
+
+// Return sets up results that will be returned by Repository.CreateNamespaceEventSource
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) Return(err error) *RepositoryMock {
+	if mmCreateNamespaceEventSource.mock.funcCreateNamespaceEventSource != nil {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("RepositoryMock.CreateNamespaceEventSource mock is already set by Set")
+	}
+
+	if mmCreateNamespaceEventSource.defaultExpectation == nil {
+		mmCreateNamespaceEventSource.defaultExpectation = &RepositoryMockCreateNamespaceEventSourceExpectation{mock: mmCreateNamespaceEventSource.mock}
+	}
+	mmCreateNamespaceEventSource.defaultExpectation.results = &RepositoryMockCreateNamespaceEventSourceResults{err}
+	mmCreateNamespaceEventSource.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmCreateNamespaceEventSource.mock
+}
+
+// Set uses given function f to mock the Repository.CreateNamespaceEventSource method
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) Set(f func(ctx context.Context, ep1 *datamodel.EventSource) (err error)) *RepositoryMock {
+	if mmCreateNamespaceEventSource.defaultExpectation != nil {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("Default expectation is already set for the Repository.CreateNamespaceEventSource method")
+	}
+
+	if len(mmCreateNamespaceEventSource.expectations) > 0 {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("Some expectations are already set for the Repository.CreateNamespaceEventSource method")
+	}
+
+	mmCreateNamespaceEventSource.mock.funcCreateNamespaceEventSource = f
+	mmCreateNamespaceEventSource.mock.funcCreateNamespaceEventSourceOrigin = minimock.CallerInfo(1)
+	return mmCreateNamespaceEventSource.mock
+}
+
+// When sets expectation for the Repository.CreateNamespaceEventSource which will trigger the result defined by the following
+// Then helper
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) When(ctx context.Context, ep1 *datamodel.EventSource) *RepositoryMockCreateNamespaceEventSourceExpectation {
+	if mmCreateNamespaceEventSource.mock.funcCreateNamespaceEventSource != nil {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("RepositoryMock.CreateNamespaceEventSource mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockCreateNamespaceEventSourceExpectation{
+		mock:               mmCreateNamespaceEventSource.mock,
+		params:             &RepositoryMockCreateNamespaceEventSourceParams{ctx, ep1},
+		expectationOrigins: RepositoryMockCreateNamespaceEventSourceExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmCreateNamespaceEventSource.expectations = append(mmCreateNamespaceEventSource.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.CreateNamespaceEventSource return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockCreateNamespaceEventSourceExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockCreateNamespaceEventSourceResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.CreateNamespaceEventSource should be invoked
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) Times(n uint64) *mRepositoryMockCreateNamespaceEventSource {
+	if n == 0 {
+		mmCreateNamespaceEventSource.mock.t.Fatalf("Times of RepositoryMock.CreateNamespaceEventSource mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmCreateNamespaceEventSource.expectedInvocations, n)
+	mmCreateNamespaceEventSource.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmCreateNamespaceEventSource
+}
+
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) invocationsDone() bool {
+	if len(mmCreateNamespaceEventSource.expectations) == 0 && mmCreateNamespaceEventSource.defaultExpectation == nil && mmCreateNamespaceEventSource.mock.funcCreateNamespaceEventSource == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmCreateNamespaceEventSource.mock.afterCreateNamespaceEventSourceCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmCreateNamespaceEventSource.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// CreateNamespaceEventSource implements mm_repository.Repository
+func (mmCreateNamespaceEventSource *RepositoryMock) CreateNamespaceEventSource(ctx context.Context, ep1 *datamodel.EventSource) (err error) {
+	mm_atomic.AddUint64(&mmCreateNamespaceEventSource.beforeCreateNamespaceEventSourceCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreateNamespaceEventSource.afterCreateNamespaceEventSourceCounter, 1)
+
+	mmCreateNamespaceEventSource.t.Helper()
+
+	if mmCreateNamespaceEventSource.inspectFuncCreateNamespaceEventSource != nil {
+		mmCreateNamespaceEventSource.inspectFuncCreateNamespaceEventSource(ctx, ep1)
+	}
+
+	mm_params := RepositoryMockCreateNamespaceEventSourceParams{ctx, ep1}
+
+	// Record call args
+	mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.mutex.Lock()
+	mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.callArgs = append(mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.callArgs, &mm_params)
+	mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.mutex.Unlock()
+
+	for _, e := range mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.defaultExpectation.params
+		mm_want_ptrs := mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockCreateNamespaceEventSourceParams{ctx, ep1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmCreateNamespaceEventSource.t.Errorf("RepositoryMock.CreateNamespaceEventSource got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.ep1 != nil && !minimock.Equal(*mm_want_ptrs.ep1, mm_got.ep1) {
+				mmCreateNamespaceEventSource.t.Errorf("RepositoryMock.CreateNamespaceEventSource got unexpected parameter ep1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.defaultExpectation.expectationOrigins.originEp1, *mm_want_ptrs.ep1, mm_got.ep1, minimock.Diff(*mm_want_ptrs.ep1, mm_got.ep1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmCreateNamespaceEventSource.t.Errorf("RepositoryMock.CreateNamespaceEventSource got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmCreateNamespaceEventSource.CreateNamespaceEventSourceMock.defaultExpectation.results
+		if mm_results == nil {
+			mmCreateNamespaceEventSource.t.Fatal("No results are set for the RepositoryMock.CreateNamespaceEventSource")
+		}
+		return (*mm_results).err
+	}
+	if mmCreateNamespaceEventSource.funcCreateNamespaceEventSource != nil {
+		return mmCreateNamespaceEventSource.funcCreateNamespaceEventSource(ctx, ep1)
+	}
+	mmCreateNamespaceEventSource.t.Fatalf("Unexpected call to RepositoryMock.CreateNamespaceEventSource. %v %v", ctx, ep1)
+	return
+}
+
+// CreateNamespaceEventSourceAfterCounter returns a count of finished RepositoryMock.CreateNamespaceEventSource invocations
+func (mmCreateNamespaceEventSource *RepositoryMock) CreateNamespaceEventSourceAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateNamespaceEventSource.afterCreateNamespaceEventSourceCounter)
+}
+
+// CreateNamespaceEventSourceBeforeCounter returns a count of RepositoryMock.CreateNamespaceEventSource invocations
+func (mmCreateNamespaceEventSource *RepositoryMock) CreateNamespaceEventSourceBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateNamespaceEventSource.beforeCreateNamespaceEventSourceCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.CreateNamespaceEventSource.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmCreateNamespaceEventSource *mRepositoryMockCreateNamespaceEventSource) Calls() []*RepositoryMockCreateNamespaceEventSourceParams {
+	mmCreateNamespaceEventSource.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockCreateNamespaceEventSourceParams, len(mmCreateNamespaceEventSource.callArgs))
+	copy(argCopy, mmCreateNamespaceEventSource.callArgs)
+
+	mmCreateNamespaceEventSource.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockCreateNamespaceEventSourceDone returns true if the count of the CreateNamespaceEventSource invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockCreateNamespaceEventSourceDone() bool {
+	if m.CreateNamespaceEventSourceMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.CreateNamespaceEventSourceMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.CreateNamespaceEventSourceMock.invocationsDone()
+}
+
+// MinimockCreateNamespaceEventSourceInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockCreateNamespaceEventSourceInspect() {
+	for _, e := range m.CreateNamespaceEventSourceMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.CreateNamespaceEventSource at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterCreateNamespaceEventSourceCounter := mm_atomic.LoadUint64(&m.afterCreateNamespaceEventSourceCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.CreateNamespaceEventSourceMock.defaultExpectation != nil && afterCreateNamespaceEventSourceCounter < 1 {
+		if m.CreateNamespaceEventSourceMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.CreateNamespaceEventSource at\n%s", m.CreateNamespaceEventSourceMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.CreateNamespaceEventSource at\n%s with params: %#v", m.CreateNamespaceEventSourceMock.defaultExpectation.expectationOrigins.origin, *m.CreateNamespaceEventSourceMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcCreateNamespaceEventSource != nil && afterCreateNamespaceEventSourceCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.CreateNamespaceEventSource at\n%s", m.funcCreateNamespaceEventSourceOrigin)
+	}
+
+	if !m.CreateNamespaceEventSourceMock.invocationsDone() && afterCreateNamespaceEventSourceCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.CreateNamespaceEventSource at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.CreateNamespaceEventSourceMock.expectedInvocations), m.CreateNamespaceEventSourceMock.expectedInvocationsOrigin, afterCreateNamespaceEventSourceCounter)
+	}
+}
+
+type mRepositoryMockCreateNamespacePipeline struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockCreateNamespacePipelineExpectation
+	expectations       []*RepositoryMockCreateNamespacePipelineExpectation
+
+	callArgs []*RepositoryMockCreateNamespacePipelineParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockCreateNamespacePipelineExpectation specifies expectation struct of the Repository.CreateNamespacePipeline
+type RepositoryMockCreateNamespacePipelineExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockCreateNamespacePipelineParams
+	paramPtrs          *RepositoryMockCreateNamespacePipelineParamPtrs
+	expectationOrigins RepositoryMockCreateNamespacePipelineExpectationOrigins
+	results            *RepositoryMockCreateNamespacePipelineResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockCreateNamespacePipelineParams contains parameters of the Repository.CreateNamespacePipeline
+type RepositoryMockCreateNamespacePipelineParams struct {
+	ctx      context.Context
+	pipeline *datamodel.Pipeline
+}
+
+// RepositoryMockCreateNamespacePipelineParamPtrs contains pointers to parameters of the Repository.CreateNamespacePipeline
+type RepositoryMockCreateNamespacePipelineParamPtrs struct {
+	ctx      *context.Context
 	pipeline **datamodel.Pipeline
 }
 
@@ -2697,55 +3665,52 @@ func (m *RepositoryMock) MinimockCreateNamespacePipelineReleaseInspect() {
 	}
 }
 
-type mRepositoryMockCreateNamespaceSecret struct {
+type mRepositoryMockCreateNamespacePromptVersion struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockCreateNamespaceSecretExpectation
-	expectations       []*RepositoryMockCreateNamespaceSecretExpectation
+	defaultExpectation *RepositoryMockCreateNamespacePromptVersionExpectation
+	expectations       []*RepositoryMockCreateNamespacePromptVersionExpectation
 
-	callArgs []*RepositoryMockCreateNamespaceSecretParams
+	callArgs []*RepositoryMockCreateNamespacePromptVersionParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockCreateNamespaceSecretExpectation specifies expectation struct of the Repository.CreateNamespaceSecret
-type RepositoryMockCreateNamespaceSecretExpectation struct {
+// RepositoryMockCreateNamespacePromptVersionExpectation specifies expectation struct of the Repository.CreateNamespacePromptVersion
+type RepositoryMockCreateNamespacePromptVersionExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockCreateNamespaceSecretParams
-	paramPtrs          *RepositoryMockCreateNamespaceSecretParamPtrs
-	expectationOrigins RepositoryMockCreateNamespaceSecretExpectationOrigins
-	results            *RepositoryMockCreateNamespaceSecretResults
+	params             *RepositoryMockCreateNamespacePromptVersionParams
+	paramPtrs          *RepositoryMockCreateNamespacePromptVersionParamPtrs
+	expectationOrigins RepositoryMockCreateNamespacePromptVersionExpectationOrigins
+	results            *RepositoryMockCreateNamespacePromptVersionResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockCreateNamespaceSecretParams contains parameters of the Repository.CreateNamespaceSecret
-type RepositoryMockCreateNamespaceSecretParams struct {
-	ctx            context.Context
-	ownerPermalink string
-	secret         *datamodel.Secret
+// RepositoryMockCreateNamespacePromptVersionParams contains parameters of the Repository.CreateNamespacePromptVersion
+type RepositoryMockCreateNamespacePromptVersionParams struct {
+	ctx context.Context
+	pp1 *datamodel.Prompt
 }
 
-// RepositoryMockCreateNamespaceSecretParamPtrs contains pointers to parameters of the Repository.CreateNamespaceSecret
-type RepositoryMockCreateNamespaceSecretParamPtrs struct {
-	ctx            *context.Context
-	ownerPermalink *string
-	secret         **datamodel.Secret
+// RepositoryMockCreateNamespacePromptVersionParamPtrs contains pointers to parameters of the Repository.CreateNamespacePromptVersion
+type RepositoryMockCreateNamespacePromptVersionParamPtrs struct {
+	ctx *context.Context
+	pp1 **datamodel.Prompt
 }
 
-// RepositoryMockCreateNamespaceSecretResults contains results of the Repository.CreateNamespaceSecret
-type RepositoryMockCreateNamespaceSecretResults struct {
+// RepositoryMockCreateNamespacePromptVersionResults contains results of the Repository.CreateNamespacePromptVersion
+type RepositoryMockCreateNamespacePromptVersionResults struct {
 	err error
 }
 
-// RepositoryMockCreateNamespaceSecretOrigins contains origins of expectations of the Repository.CreateNamespaceSecret
-type RepositoryMockCreateNamespaceSecretExpectationOrigins struct {
-	origin               string
-	originCtx            string
-	originOwnerPermalink string
-	originSecret         string
+// RepositoryMockCreateNamespacePromptVersionOrigins contains origins of expectations of the Repository.CreateNamespacePromptVersion
+type RepositoryMockCreateNamespacePromptVersionExpectationOrigins struct {
+	origin    string
+	originCtx string
+	originPp1 string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -2753,67 +3718,412 @@ type RepositoryMockCreateNamespaceSecretExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmCreateNamespaceSecret *mRepositoryMockCreateNamespaceSecret) Optional() *mRepositoryMockCreateNamespaceSecret {
-	mmCreateNamespaceSecret.optional = true
-	return mmCreateNamespaceSecret
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) Optional() *mRepositoryMockCreateNamespacePromptVersion {
+	mmCreateNamespacePromptVersion.optional = true
+	return mmCreateNamespacePromptVersion
 }
 
-// Expect sets up expected params for Repository.CreateNamespaceSecret
-func (mmCreateNamespaceSecret *mRepositoryMockCreateNamespaceSecret) Expect(ctx context.Context, ownerPermalink string, secret *datamodel.Secret) *mRepositoryMockCreateNamespaceSecret {
-	if mmCreateNamespaceSecret.mock.funcCreateNamespaceSecret != nil {
-		mmCreateNamespaceSecret.mock.t.Fatalf("RepositoryMock.CreateNamespaceSecret mock is already set by Set")
+// Expect sets up expected params for Repository.CreateNamespacePromptVersion
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) Expect(ctx context.Context, pp1 *datamodel.Prompt) *mRepositoryMockCreateNamespacePromptVersion {
+	if mmCreateNamespacePromptVersion.mock.funcCreateNamespacePromptVersion != nil {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("RepositoryMock.CreateNamespacePromptVersion mock is already set by Set")
 	}
 
-	if mmCreateNamespaceSecret.defaultExpectation == nil {
-		mmCreateNamespaceSecret.defaultExpectation = &RepositoryMockCreateNamespaceSecretExpectation{}
+	if mmCreateNamespacePromptVersion.defaultExpectation == nil {
+		mmCreateNamespacePromptVersion.defaultExpectation = &RepositoryMockCreateNamespacePromptVersionExpectation{}
 	}
 
-	if mmCreateNamespaceSecret.defaultExpectation.paramPtrs != nil {
-		mmCreateNamespaceSecret.mock.t.Fatalf("RepositoryMock.CreateNamespaceSecret mock is already set by ExpectParams functions")
+	if mmCreateNamespacePromptVersion.defaultExpectation.paramPtrs != nil {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("RepositoryMock.CreateNamespacePromptVersion mock is already set by ExpectParams functions")
 	}
 
-	mmCreateNamespaceSecret.defaultExpectation.params = &RepositoryMockCreateNamespaceSecretParams{ctx, ownerPermalink, secret}
-	mmCreateNamespaceSecret.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmCreateNamespaceSecret.expectations {
-		if minimock.Equal(e.params, mmCreateNamespaceSecret.defaultExpectation.params) {
-			mmCreateNamespaceSecret.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateNamespaceSecret.defaultExpectation.params)
+	mmCreateNamespacePromptVersion.defaultExpectation.params = &RepositoryMockCreateNamespacePromptVersionParams{ctx, pp1}
+	mmCreateNamespacePromptVersion.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmCreateNamespacePromptVersion.expectations {
+		if minimock.Equal(e.params, mmCreateNamespacePromptVersion.defaultExpectation.params) {
+			mmCreateNamespacePromptVersion.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateNamespacePromptVersion.defaultExpectation.params)
 		}
 	}
 
-	return mmCreateNamespaceSecret
+	return mmCreateNamespacePromptVersion
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Repository.CreateNamespaceSecret
-func (mmCreateNamespaceSecret *mRepositoryMockCreateNamespaceSecret) ExpectCtxParam1(ctx context.Context) *mRepositoryMockCreateNamespaceSecret {
-	if mmCreateNamespaceSecret.mock.funcCreateNamespaceSecret != nil {
-		mmCreateNamespaceSecret.mock.t.Fatalf("RepositoryMock.CreateNamespaceSecret mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Repository.CreateNamespacePromptVersion
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) ExpectCtxParam1(ctx context.Context) *mRepositoryMockCreateNamespacePromptVersion {
+	if mmCreateNamespacePromptVersion.mock.funcCreateNamespacePromptVersion != nil {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("RepositoryMock.CreateNamespacePromptVersion mock is already set by Set")
 	}
 
-	if mmCreateNamespaceSecret.defaultExpectation == nil {
-		mmCreateNamespaceSecret.defaultExpectation = &RepositoryMockCreateNamespaceSecretExpectation{}
+	if mmCreateNamespacePromptVersion.defaultExpectation == nil {
+		mmCreateNamespacePromptVersion.defaultExpectation = &RepositoryMockCreateNamespacePromptVersionExpectation{}
 	}
 
-	if mmCreateNamespaceSecret.defaultExpectation.params != nil {
-		mmCreateNamespaceSecret.mock.t.Fatalf("RepositoryMock.CreateNamespaceSecret mock is already set by Expect")
+	if mmCreateNamespacePromptVersion.defaultExpectation.params != nil {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("RepositoryMock.CreateNamespacePromptVersion mock is already set by Expect")
 	}
 
-	if mmCreateNamespaceSecret.defaultExpectation.paramPtrs == nil {
-		mmCreateNamespaceSecret.defaultExpectation.paramPtrs = &RepositoryMockCreateNamespaceSecretParamPtrs{}
+	if mmCreateNamespacePromptVersion.defaultExpectation.paramPtrs == nil {
+		mmCreateNamespacePromptVersion.defaultExpectation.paramPtrs = &RepositoryMockCreateNamespacePromptVersionParamPtrs{}
 	}
-	mmCreateNamespaceSecret.defaultExpectation.paramPtrs.ctx = &ctx
-	mmCreateNamespaceSecret.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+	mmCreateNamespacePromptVersion.defaultExpectation.paramPtrs.ctx = &ctx
+	mmCreateNamespacePromptVersion.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmCreateNamespaceSecret
+	return mmCreateNamespacePromptVersion
 }
 
-// ExpectOwnerPermalinkParam2 sets up expected param ownerPermalink for Repository.CreateNamespaceSecret
-func (mmCreateNamespaceSecret *mRepositoryMockCreateNamespaceSecret) ExpectOwnerPermalinkParam2(ownerPermalink string) *mRepositoryMockCreateNamespaceSecret {
-	if mmCreateNamespaceSecret.mock.funcCreateNamespaceSecret != nil {
-		mmCreateNamespaceSecret.mock.t.Fatalf("RepositoryMock.CreateNamespaceSecret mock is already set by Set")
+// ExpectPp1Param2 sets up expected param pp1 for Repository.CreateNamespacePromptVersion
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) ExpectPp1Param2(pp1 *datamodel.Prompt) *mRepositoryMockCreateNamespacePromptVersion {
+	if mmCreateNamespacePromptVersion.mock.funcCreateNamespacePromptVersion != nil {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("RepositoryMock.CreateNamespacePromptVersion mock is already set by Set")
 	}
 
-	if mmCreateNamespaceSecret.defaultExpectation == nil {
-		mmCreateNamespaceSecret.defaultExpectation = &RepositoryMockCreateNamespaceSecretExpectation{}
+	if mmCreateNamespacePromptVersion.defaultExpectation == nil {
+		mmCreateNamespacePromptVersion.defaultExpectation = &RepositoryMockCreateNamespacePromptVersionExpectation{}
+	}
+
+	if mmCreateNamespacePromptVersion.defaultExpectation.params != nil {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("RepositoryMock.CreateNamespacePromptVersion mock is already set by Expect")
+	}
+
+	if mmCreateNamespacePromptVersion.defaultExpectation.paramPtrs == nil {
+		mmCreateNamespacePromptVersion.defaultExpectation.paramPtrs = &RepositoryMockCreateNamespacePromptVersionParamPtrs{}
+	}
+	mmCreateNamespacePromptVersion.defaultExpectation.paramPtrs.pp1 = &pp1
+	mmCreateNamespacePromptVersion.defaultExpectation.expectationOrigins.originPp1 = minimock.CallerInfo(1)
+
+	return mmCreateNamespacePromptVersion
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.CreateNamespacePromptVersion
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) Inspect(f func(ctx context.Context, pp1 *datamodel.Prompt)) *mRepositoryMockCreateNamespacePromptVersion {
+	if mmCreateNamespacePromptVersion.mock.inspectFuncCreateNamespacePromptVersion != nil {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("Inspect function is already set for RepositoryMock.CreateNamespacePromptVersion")
+	}
+
+	mmCreateNamespacePromptVersion.mock.inspectFuncCreateNamespacePromptVersion = f
+
+	return mmCreateNamespacePromptVersion
+}
+
+// Return sets up results that will be returned by Repository.CreateNamespacePromptVersion
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) Return(err error) *RepositoryMock {
+	if mmCreateNamespacePromptVersion.mock.funcCreateNamespacePromptVersion != nil {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("RepositoryMock.CreateNamespacePromptVersion mock is already set by Set")
+	}
+
+	if mmCreateNamespacePromptVersion.defaultExpectation == nil {
+		mmCreateNamespacePromptVersion.defaultExpectation = &RepositoryMockCreateNamespacePromptVersionExpectation{mock: mmCreateNamespacePromptVersion.mock}
+	}
+	mmCreateNamespacePromptVersion.defaultExpectation.results = &RepositoryMockCreateNamespacePromptVersionResults{err}
+	mmCreateNamespacePromptVersion.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmCreateNamespacePromptVersion.mock
+}
+
+// Set uses given function f to mock the Repository.CreateNamespacePromptVersion method
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) Set(f func(ctx context.Context, pp1 *datamodel.Prompt) (err error)) *RepositoryMock {
+	if mmCreateNamespacePromptVersion.defaultExpectation != nil {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("Default expectation is already set for the Repository.CreateNamespacePromptVersion method")
+	}
+
+	if len(mmCreateNamespacePromptVersion.expectations) > 0 {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("Some expectations are already set for the Repository.CreateNamespacePromptVersion method")
+	}
+
+	mmCreateNamespacePromptVersion.mock.funcCreateNamespacePromptVersion = f
+	mmCreateNamespacePromptVersion.mock.funcCreateNamespacePromptVersionOrigin = minimock.CallerInfo(1)
+	return mmCreateNamespacePromptVersion.mock
+}
+
+// When sets expectation for the Repository.CreateNamespacePromptVersion which will trigger the result defined by the following
+// Then helper
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) When(ctx context.Context, pp1 *datamodel.Prompt) *RepositoryMockCreateNamespacePromptVersionExpectation {
+	if mmCreateNamespacePromptVersion.mock.funcCreateNamespacePromptVersion != nil {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("RepositoryMock.CreateNamespacePromptVersion mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockCreateNamespacePromptVersionExpectation{
+		mock:               mmCreateNamespacePromptVersion.mock,
+		params:             &RepositoryMockCreateNamespacePromptVersionParams{ctx, pp1},
+		expectationOrigins: RepositoryMockCreateNamespacePromptVersionExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmCreateNamespacePromptVersion.expectations = append(mmCreateNamespacePromptVersion.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.CreateNamespacePromptVersion return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockCreateNamespacePromptVersionExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockCreateNamespacePromptVersionResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.CreateNamespacePromptVersion should be invoked
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) Times(n uint64) *mRepositoryMockCreateNamespacePromptVersion {
+	if n == 0 {
+		mmCreateNamespacePromptVersion.mock.t.Fatalf("Times of RepositoryMock.CreateNamespacePromptVersion mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmCreateNamespacePromptVersion.expectedInvocations, n)
+	mmCreateNamespacePromptVersion.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmCreateNamespacePromptVersion
+}
+
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) invocationsDone() bool {
+	if len(mmCreateNamespacePromptVersion.expectations) == 0 && mmCreateNamespacePromptVersion.defaultExpectation == nil && mmCreateNamespacePromptVersion.mock.funcCreateNamespacePromptVersion == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmCreateNamespacePromptVersion.mock.afterCreateNamespacePromptVersionCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmCreateNamespacePromptVersion.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// CreateNamespacePromptVersion implements mm_repository.Repository
+func (mmCreateNamespacePromptVersion *RepositoryMock) CreateNamespacePromptVersion(ctx context.Context, pp1 *datamodel.Prompt) (err error) {
+	mm_atomic.AddUint64(&mmCreateNamespacePromptVersion.beforeCreateNamespacePromptVersionCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreateNamespacePromptVersion.afterCreateNamespacePromptVersionCounter, 1)
+
+	mmCreateNamespacePromptVersion.t.Helper()
+
+	if mmCreateNamespacePromptVersion.inspectFuncCreateNamespacePromptVersion != nil {
+		mmCreateNamespacePromptVersion.inspectFuncCreateNamespacePromptVersion(ctx, pp1)
+	}
+
+	mm_params := RepositoryMockCreateNamespacePromptVersionParams{ctx, pp1}
+
+	// Record call args
+	mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.mutex.Lock()
+	mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.callArgs = append(mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.callArgs, &mm_params)
+	mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.mutex.Unlock()
+
+	for _, e := range mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.defaultExpectation.params
+		mm_want_ptrs := mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockCreateNamespacePromptVersionParams{ctx, pp1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmCreateNamespacePromptVersion.t.Errorf("RepositoryMock.CreateNamespacePromptVersion got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.pp1 != nil && !minimock.Equal(*mm_want_ptrs.pp1, mm_got.pp1) {
+				mmCreateNamespacePromptVersion.t.Errorf("RepositoryMock.CreateNamespacePromptVersion got unexpected parameter pp1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.defaultExpectation.expectationOrigins.originPp1, *mm_want_ptrs.pp1, mm_got.pp1, minimock.Diff(*mm_want_ptrs.pp1, mm_got.pp1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmCreateNamespacePromptVersion.t.Errorf("RepositoryMock.CreateNamespacePromptVersion got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmCreateNamespacePromptVersion.CreateNamespacePromptVersionMock.defaultExpectation.results
+		if mm_results == nil {
+			mmCreateNamespacePromptVersion.t.Fatal("No results are set for the RepositoryMock.CreateNamespacePromptVersion")
+		}
+		return (*mm_results).err
+	}
+	if mmCreateNamespacePromptVersion.funcCreateNamespacePromptVersion != nil {
+		return mmCreateNamespacePromptVersion.funcCreateNamespacePromptVersion(ctx, pp1)
+	}
+	mmCreateNamespacePromptVersion.t.Fatalf("Unexpected call to RepositoryMock.CreateNamespacePromptVersion. %v %v", ctx, pp1)
+	return
+}
+
+// CreateNamespacePromptVersionAfterCounter returns a count of finished RepositoryMock.CreateNamespacePromptVersion invocations
+func (mmCreateNamespacePromptVersion *RepositoryMock) CreateNamespacePromptVersionAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateNamespacePromptVersion.afterCreateNamespacePromptVersionCounter)
+}
+
+// CreateNamespacePromptVersionBeforeCounter returns a count of RepositoryMock.CreateNamespacePromptVersion invocations
+func (mmCreateNamespacePromptVersion *RepositoryMock) CreateNamespacePromptVersionBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateNamespacePromptVersion.beforeCreateNamespacePromptVersionCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.CreateNamespacePromptVersion.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmCreateNamespacePromptVersion *mRepositoryMockCreateNamespacePromptVersion) Calls() []*RepositoryMockCreateNamespacePromptVersionParams {
+	mmCreateNamespacePromptVersion.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockCreateNamespacePromptVersionParams, len(mmCreateNamespacePromptVersion.callArgs))
+	copy(argCopy, mmCreateNamespacePromptVersion.callArgs)
+
+	mmCreateNamespacePromptVersion.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockCreateNamespacePromptVersionDone returns true if the count of the CreateNamespacePromptVersion invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockCreateNamespacePromptVersionDone() bool {
+	if m.CreateNamespacePromptVersionMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.CreateNamespacePromptVersionMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.CreateNamespacePromptVersionMock.invocationsDone()
+}
+
+// MinimockCreateNamespacePromptVersionInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockCreateNamespacePromptVersionInspect() {
+	for _, e := range m.CreateNamespacePromptVersionMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.CreateNamespacePromptVersion at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterCreateNamespacePromptVersionCounter := mm_atomic.LoadUint64(&m.afterCreateNamespacePromptVersionCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.CreateNamespacePromptVersionMock.defaultExpectation != nil && afterCreateNamespacePromptVersionCounter < 1 {
+		if m.CreateNamespacePromptVersionMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.CreateNamespacePromptVersion at\n%s", m.CreateNamespacePromptVersionMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.CreateNamespacePromptVersion at\n%s with params: %#v", m.CreateNamespacePromptVersionMock.defaultExpectation.expectationOrigins.origin, *m.CreateNamespacePromptVersionMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcCreateNamespacePromptVersion != nil && afterCreateNamespacePromptVersionCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.CreateNamespacePromptVersion at\n%s", m.funcCreateNamespacePromptVersionOrigin)
+	}
+
+	if !m.CreateNamespacePromptVersionMock.invocationsDone() && afterCreateNamespacePromptVersionCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.CreateNamespacePromptVersion at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.CreateNamespacePromptVersionMock.expectedInvocations), m.CreateNamespacePromptVersionMock.expectedInvocationsOrigin, afterCreateNamespacePromptVersionCounter)
+	}
+}
+
+type mRepositoryMockCreateNamespaceSecret struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockCreateNamespaceSecretExpectation
+	expectations       []*RepositoryMockCreateNamespaceSecretExpectation
+
+	callArgs []*RepositoryMockCreateNamespaceSecretParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockCreateNamespaceSecretExpectation specifies expectation struct of the Repository.CreateNamespaceSecret
+type RepositoryMockCreateNamespaceSecretExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockCreateNamespaceSecretParams
+	paramPtrs          *RepositoryMockCreateNamespaceSecretParamPtrs
+	expectationOrigins RepositoryMockCreateNamespaceSecretExpectationOrigins
+	results            *RepositoryMockCreateNamespaceSecretResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockCreateNamespaceSecretParams contains parameters of the Repository.CreateNamespaceSecret
+type RepositoryMockCreateNamespaceSecretParams struct {
+	ctx            context.Context
+	ownerPermalink string
+	secret         *datamodel.Secret
+}
+
+// RepositoryMockCreateNamespaceSecretParamPtrs contains pointers to parameters of the Repository.CreateNamespaceSecret
+type RepositoryMockCreateNamespaceSecretParamPtrs struct {
+	ctx            *context.Context
+	ownerPermalink *string
+	secret         **datamodel.Secret
+}
+
+// RepositoryMockCreateNamespaceSecretResults contains results of the Repository.CreateNamespaceSecret
+type RepositoryMockCreateNamespaceSecretResults struct {
+	err error
+}
+
+// RepositoryMockCreateNamespaceSecretOrigins contains origins of expectations of the Repository.CreateNamespaceSecret
+type RepositoryMockCreateNamespaceSecretExpectationOrigins struct {
+	origin               string
+	originCtx            string
+	originOwnerPermalink string
+	originSecret         string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmCreateNamespaceSecret *mRepositoryMockCreateNamespaceSecret) Optional() *mRepositoryMockCreateNamespaceSecret {
+	mmCreateNamespaceSecret.optional = true
+	return mmCreateNamespaceSecret
+}
+
+// Expect sets up expected params for Repository.CreateNamespaceSecret
+func (mmCreateNamespaceSecret *mRepositoryMockCreateNamespaceSecret) Expect(ctx context.Context, ownerPermalink string, secret *datamodel.Secret) *mRepositoryMockCreateNamespaceSecret {
+	if mmCreateNamespaceSecret.mock.funcCreateNamespaceSecret != nil {
+		mmCreateNamespaceSecret.mock.t.Fatalf("RepositoryMock.CreateNamespaceSecret mock is already set by Set")
+	}
+
+	if mmCreateNamespaceSecret.defaultExpectation == nil {
+		mmCreateNamespaceSecret.defaultExpectation = &RepositoryMockCreateNamespaceSecretExpectation{}
+	}
+
+	if mmCreateNamespaceSecret.defaultExpectation.paramPtrs != nil {
+		mmCreateNamespaceSecret.mock.t.Fatalf("RepositoryMock.CreateNamespaceSecret mock is already set by ExpectParams functions")
+	}
+
+	mmCreateNamespaceSecret.defaultExpectation.params = &RepositoryMockCreateNamespaceSecretParams{ctx, ownerPermalink, secret}
+	mmCreateNamespaceSecret.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmCreateNamespaceSecret.expectations {
+		if minimock.Equal(e.params, mmCreateNamespaceSecret.defaultExpectation.params) {
+			mmCreateNamespaceSecret.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateNamespaceSecret.defaultExpectation.params)
+		}
+	}
+
+	return mmCreateNamespaceSecret
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.CreateNamespaceSecret
+func (mmCreateNamespaceSecret *mRepositoryMockCreateNamespaceSecret) ExpectCtxParam1(ctx context.Context) *mRepositoryMockCreateNamespaceSecret {
+	if mmCreateNamespaceSecret.mock.funcCreateNamespaceSecret != nil {
+		mmCreateNamespaceSecret.mock.t.Fatalf("RepositoryMock.CreateNamespaceSecret mock is already set by Set")
+	}
+
+	if mmCreateNamespaceSecret.defaultExpectation == nil {
+		mmCreateNamespaceSecret.defaultExpectation = &RepositoryMockCreateNamespaceSecretExpectation{}
+	}
+
+	if mmCreateNamespaceSecret.defaultExpectation.params != nil {
+		mmCreateNamespaceSecret.mock.t.Fatalf("RepositoryMock.CreateNamespaceSecret mock is already set by Expect")
+	}
+
+	if mmCreateNamespaceSecret.defaultExpectation.paramPtrs == nil {
+		mmCreateNamespaceSecret.defaultExpectation.paramPtrs = &RepositoryMockCreateNamespaceSecretParamPtrs{}
+	}
+	mmCreateNamespaceSecret.defaultExpectation.paramPtrs.ctx = &ctx
+	mmCreateNamespaceSecret.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmCreateNamespaceSecret
+}
+
+// ExpectOwnerPermalinkParam2 sets up expected param ownerPermalink for Repository.CreateNamespaceSecret
+func (mmCreateNamespaceSecret *mRepositoryMockCreateNamespaceSecret) ExpectOwnerPermalinkParam2(ownerPermalink string) *mRepositoryMockCreateNamespaceSecret {
+	if mmCreateNamespaceSecret.mock.funcCreateNamespaceSecret != nil {
+		mmCreateNamespaceSecret.mock.t.Fatalf("RepositoryMock.CreateNamespaceSecret mock is already set by Set")
+	}
+
+	if mmCreateNamespaceSecret.defaultExpectation == nil {
+		mmCreateNamespaceSecret.defaultExpectation = &RepositoryMockCreateNamespaceSecretExpectation{}
 	}
 
 	if mmCreateNamespaceSecret.defaultExpectation.params != nil {
@@ -3816,43 +5126,416 @@ func (m *RepositoryMock) MinimockDeleteNamespaceConnectionByIDInspect() {
 	}
 }
 
-type mRepositoryMockDeleteNamespacePipelineByID struct {
+type mRepositoryMockDeleteNamespaceEventSourceByID struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockDeleteNamespacePipelineByIDExpectation
-	expectations       []*RepositoryMockDeleteNamespacePipelineByIDExpectation
+	defaultExpectation *RepositoryMockDeleteNamespaceEventSourceByIDExpectation
+	expectations       []*RepositoryMockDeleteNamespaceEventSourceByIDExpectation
 
-	callArgs []*RepositoryMockDeleteNamespacePipelineByIDParams
+	callArgs []*RepositoryMockDeleteNamespaceEventSourceByIDParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockDeleteNamespacePipelineByIDExpectation specifies expectation struct of the Repository.DeleteNamespacePipelineByID
-type RepositoryMockDeleteNamespacePipelineByIDExpectation struct {
+// RepositoryMockDeleteNamespaceEventSourceByIDExpectation specifies expectation struct of the Repository.DeleteNamespaceEventSourceByID
+type RepositoryMockDeleteNamespaceEventSourceByIDExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockDeleteNamespacePipelineByIDParams
-	paramPtrs          *RepositoryMockDeleteNamespacePipelineByIDParamPtrs
-	expectationOrigins RepositoryMockDeleteNamespacePipelineByIDExpectationOrigins
-	results            *RepositoryMockDeleteNamespacePipelineByIDResults
+	params             *RepositoryMockDeleteNamespaceEventSourceByIDParams
+	paramPtrs          *RepositoryMockDeleteNamespaceEventSourceByIDParamPtrs
+	expectationOrigins RepositoryMockDeleteNamespaceEventSourceByIDExpectationOrigins
+	results            *RepositoryMockDeleteNamespaceEventSourceByIDResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockDeleteNamespacePipelineByIDParams contains parameters of the Repository.DeleteNamespacePipelineByID
-type RepositoryMockDeleteNamespacePipelineByIDParams struct {
-	ctx            context.Context
-	ownerPermalink string
-	id             string
+// RepositoryMockDeleteNamespaceEventSourceByIDParams contains parameters of the Repository.DeleteNamespaceEventSourceByID
+type RepositoryMockDeleteNamespaceEventSourceByIDParams struct {
+	ctx   context.Context
+	nsUID uuid.UUID
+	id    string
 }
 
-// RepositoryMockDeleteNamespacePipelineByIDParamPtrs contains pointers to parameters of the Repository.DeleteNamespacePipelineByID
-type RepositoryMockDeleteNamespacePipelineByIDParamPtrs struct {
-	ctx            *context.Context
-	ownerPermalink *string
-	id             *string
-}
+// RepositoryMockDeleteNamespaceEventSourceByIDParamPtrs contains pointers to parameters of the Repository.DeleteNamespaceEventSourceByID
+type RepositoryMockDeleteNamespaceEventSourceByIDParamPtrs struct {
+	ctx   *context.Context
+	nsUID *uuid.UUID
+	id    *string
+}
+
+// RepositoryMockDeleteNamespaceEventSourceByIDResults contains results of the Repository.DeleteNamespaceEventSourceByID
+type RepositoryMockDeleteNamespaceEventSourceByIDResults struct {
+	err error
+}
+
+// RepositoryMockDeleteNamespaceEventSourceByIDOrigins contains origins of expectations of the Repository.DeleteNamespaceEventSourceByID
+type RepositoryMockDeleteNamespaceEventSourceByIDExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originNsUID string
+	originId    string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) Optional() *mRepositoryMockDeleteNamespaceEventSourceByID {
+	mmDeleteNamespaceEventSourceByID.optional = true
+	return mmDeleteNamespaceEventSourceByID
+}
+
+// Expect sets up expected params for Repository.DeleteNamespaceEventSourceByID
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) Expect(ctx context.Context, nsUID uuid.UUID, id string) *mRepositoryMockDeleteNamespaceEventSourceByID {
+	if mmDeleteNamespaceEventSourceByID.mock.funcDeleteNamespaceEventSourceByID != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation == nil {
+		mmDeleteNamespaceEventSourceByID.defaultExpectation = &RepositoryMockDeleteNamespaceEventSourceByIDExpectation{}
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation.paramPtrs != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceEventSourceByID mock is already set by ExpectParams functions")
+	}
+
+	mmDeleteNamespaceEventSourceByID.defaultExpectation.params = &RepositoryMockDeleteNamespaceEventSourceByIDParams{ctx, nsUID, id}
+	mmDeleteNamespaceEventSourceByID.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmDeleteNamespaceEventSourceByID.expectations {
+		if minimock.Equal(e.params, mmDeleteNamespaceEventSourceByID.defaultExpectation.params) {
+			mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteNamespaceEventSourceByID.defaultExpectation.params)
+		}
+	}
+
+	return mmDeleteNamespaceEventSourceByID
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.DeleteNamespaceEventSourceByID
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) ExpectCtxParam1(ctx context.Context) *mRepositoryMockDeleteNamespaceEventSourceByID {
+	if mmDeleteNamespaceEventSourceByID.mock.funcDeleteNamespaceEventSourceByID != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation == nil {
+		mmDeleteNamespaceEventSourceByID.defaultExpectation = &RepositoryMockDeleteNamespaceEventSourceByIDExpectation{}
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation.params != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceEventSourceByID mock is already set by Expect")
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation.paramPtrs == nil {
+		mmDeleteNamespaceEventSourceByID.defaultExpectation.paramPtrs = &RepositoryMockDeleteNamespaceEventSourceByIDParamPtrs{}
+	}
+	mmDeleteNamespaceEventSourceByID.defaultExpectation.paramPtrs.ctx = &ctx
+	mmDeleteNamespaceEventSourceByID.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmDeleteNamespaceEventSourceByID
+}
+
+// ExpectNsUIDParam2 sets up expected param nsUID for Repository.DeleteNamespaceEventSourceByID
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) ExpectNsUIDParam2(nsUID uuid.UUID) *mRepositoryMockDeleteNamespaceEventSourceByID {
+	if mmDeleteNamespaceEventSourceByID.mock.funcDeleteNamespaceEventSourceByID != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation == nil {
+		mmDeleteNamespaceEventSourceByID.defaultExpectation = &RepositoryMockDeleteNamespaceEventSourceByIDExpectation{}
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation.params != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceEventSourceByID mock is already set by Expect")
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation.paramPtrs == nil {
+		mmDeleteNamespaceEventSourceByID.defaultExpectation.paramPtrs = &RepositoryMockDeleteNamespaceEventSourceByIDParamPtrs{}
+	}
+	mmDeleteNamespaceEventSourceByID.defaultExpectation.paramPtrs.nsUID = &nsUID
+	mmDeleteNamespaceEventSourceByID.defaultExpectation.expectationOrigins.originNsUID = minimock.CallerInfo(1)
+
+	return mmDeleteNamespaceEventSourceByID
+}
+
+// ExpectIdParam3 sets up expected param id for Repository.DeleteNamespaceEventSourceByID
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) ExpectIdParam3(id string) *mRepositoryMockDeleteNamespaceEventSourceByID {
+	if mmDeleteNamespaceEventSourceByID.mock.funcDeleteNamespaceEventSourceByID != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation == nil {
+		mmDeleteNamespaceEventSourceByID.defaultExpectation = &RepositoryMockDeleteNamespaceEventSourceByIDExpectation{}
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation.params != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceEventSourceByID mock is already set by Expect")
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation.paramPtrs == nil {
+		mmDeleteNamespaceEventSourceByID.defaultExpectation.paramPtrs = &RepositoryMockDeleteNamespaceEventSourceByIDParamPtrs{}
+	}
+	mmDeleteNamespaceEventSourceByID.defaultExpectation.paramPtrs.id = &id
+	mmDeleteNamespaceEventSourceByID.defaultExpectation.expectationOrigins.originId = minimock.CallerInfo(1)
+
+	return mmDeleteNamespaceEventSourceByID
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.DeleteNamespaceEventSourceByID
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) Inspect(f func(ctx context.Context, nsUID uuid.UUID, id string)) *mRepositoryMockDeleteNamespaceEventSourceByID {
+	if mmDeleteNamespaceEventSourceByID.mock.inspectFuncDeleteNamespaceEventSourceByID != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("Inspect function is already set for RepositoryMock.DeleteNamespaceEventSourceByID")
+	}
+
+	mmDeleteNamespaceEventSourceByID.mock.inspectFuncDeleteNamespaceEventSourceByID = f
+
+	return mmDeleteNamespaceEventSourceByID
+}
+
+// Return sets up results that will be returned by Repository.DeleteNamespaceEventSourceByID
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) Return(err error) *RepositoryMock {
+	if mmDeleteNamespaceEventSourceByID.mock.funcDeleteNamespaceEventSourceByID != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation == nil {
+		mmDeleteNamespaceEventSourceByID.defaultExpectation = &RepositoryMockDeleteNamespaceEventSourceByIDExpectation{mock: mmDeleteNamespaceEventSourceByID.mock}
+	}
+	mmDeleteNamespaceEventSourceByID.defaultExpectation.results = &RepositoryMockDeleteNamespaceEventSourceByIDResults{err}
+	mmDeleteNamespaceEventSourceByID.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmDeleteNamespaceEventSourceByID.mock
+}
+
+// Set uses given function f to mock the Repository.DeleteNamespaceEventSourceByID method
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) Set(f func(ctx context.Context, nsUID uuid.UUID, id string) (err error)) *RepositoryMock {
+	if mmDeleteNamespaceEventSourceByID.defaultExpectation != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("Default expectation is already set for the Repository.DeleteNamespaceEventSourceByID method")
+	}
+
+	if len(mmDeleteNamespaceEventSourceByID.expectations) > 0 {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("Some expectations are already set for the Repository.DeleteNamespaceEventSourceByID method")
+	}
+
+	mmDeleteNamespaceEventSourceByID.mock.funcDeleteNamespaceEventSourceByID = f
+	mmDeleteNamespaceEventSourceByID.mock.funcDeleteNamespaceEventSourceByIDOrigin = minimock.CallerInfo(1)
+	return mmDeleteNamespaceEventSourceByID.mock
+}
+
+// When sets expectation for the Repository.DeleteNamespaceEventSourceByID which will trigger the result defined by the following
+// Then helper
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) When(ctx context.Context, nsUID uuid.UUID, id string) *RepositoryMockDeleteNamespaceEventSourceByIDExpectation {
+	if mmDeleteNamespaceEventSourceByID.mock.funcDeleteNamespaceEventSourceByID != nil {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockDeleteNamespaceEventSourceByIDExpectation{
+		mock:               mmDeleteNamespaceEventSourceByID.mock,
+		params:             &RepositoryMockDeleteNamespaceEventSourceByIDParams{ctx, nsUID, id},
+		expectationOrigins: RepositoryMockDeleteNamespaceEventSourceByIDExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmDeleteNamespaceEventSourceByID.expectations = append(mmDeleteNamespaceEventSourceByID.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.DeleteNamespaceEventSourceByID return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockDeleteNamespaceEventSourceByIDExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockDeleteNamespaceEventSourceByIDResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.DeleteNamespaceEventSourceByID should be invoked
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) Times(n uint64) *mRepositoryMockDeleteNamespaceEventSourceByID {
+	if n == 0 {
+		mmDeleteNamespaceEventSourceByID.mock.t.Fatalf("Times of RepositoryMock.DeleteNamespaceEventSourceByID mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmDeleteNamespaceEventSourceByID.expectedInvocations, n)
+	mmDeleteNamespaceEventSourceByID.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmDeleteNamespaceEventSourceByID
+}
+
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) invocationsDone() bool {
+	if len(mmDeleteNamespaceEventSourceByID.expectations) == 0 && mmDeleteNamespaceEventSourceByID.defaultExpectation == nil && mmDeleteNamespaceEventSourceByID.mock.funcDeleteNamespaceEventSourceByID == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmDeleteNamespaceEventSourceByID.mock.afterDeleteNamespaceEventSourceByIDCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeleteNamespaceEventSourceByID.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// DeleteNamespaceEventSourceByID implements mm_repository.Repository
+func (mmDeleteNamespaceEventSourceByID *RepositoryMock) DeleteNamespaceEventSourceByID(ctx context.Context, nsUID uuid.UUID, id string) (err error) {
+	mm_atomic.AddUint64(&mmDeleteNamespaceEventSourceByID.beforeDeleteNamespaceEventSourceByIDCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteNamespaceEventSourceByID.afterDeleteNamespaceEventSourceByIDCounter, 1)
+
+	mmDeleteNamespaceEventSourceByID.t.Helper()
+
+	if mmDeleteNamespaceEventSourceByID.inspectFuncDeleteNamespaceEventSourceByID != nil {
+		mmDeleteNamespaceEventSourceByID.inspectFuncDeleteNamespaceEventSourceByID(ctx, nsUID, id)
+	}
+
+	mm_params := RepositoryMockDeleteNamespaceEventSourceByIDParams{ctx, nsUID, id}
+
+	// Record call args
+	mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.mutex.Lock()
+	mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.callArgs = append(mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.callArgs, &mm_params)
+	mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.mutex.Unlock()
+
+	for _, e := range mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.defaultExpectation.params
+		mm_want_ptrs := mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockDeleteNamespaceEventSourceByIDParams{ctx, nsUID, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmDeleteNamespaceEventSourceByID.t.Errorf("RepositoryMock.DeleteNamespaceEventSourceByID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.nsUID != nil && !minimock.Equal(*mm_want_ptrs.nsUID, mm_got.nsUID) {
+				mmDeleteNamespaceEventSourceByID.t.Errorf("RepositoryMock.DeleteNamespaceEventSourceByID got unexpected parameter nsUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.defaultExpectation.expectationOrigins.originNsUID, *mm_want_ptrs.nsUID, mm_got.nsUID, minimock.Diff(*mm_want_ptrs.nsUID, mm_got.nsUID))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmDeleteNamespaceEventSourceByID.t.Errorf("RepositoryMock.DeleteNamespaceEventSourceByID got unexpected parameter id, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.defaultExpectation.expectationOrigins.originId, *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeleteNamespaceEventSourceByID.t.Errorf("RepositoryMock.DeleteNamespaceEventSourceByID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeleteNamespaceEventSourceByID.DeleteNamespaceEventSourceByIDMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteNamespaceEventSourceByID.t.Fatal("No results are set for the RepositoryMock.DeleteNamespaceEventSourceByID")
+		}
+		return (*mm_results).err
+	}
+	if mmDeleteNamespaceEventSourceByID.funcDeleteNamespaceEventSourceByID != nil {
+		return mmDeleteNamespaceEventSourceByID.funcDeleteNamespaceEventSourceByID(ctx, nsUID, id)
+	}
+	mmDeleteNamespaceEventSourceByID.t.Fatalf("Unexpected call to RepositoryMock.DeleteNamespaceEventSourceByID. %v %v %v", ctx, nsUID, id)
+	return
+}
+
+// DeleteNamespaceEventSourceByIDAfterCounter returns a count of finished RepositoryMock.DeleteNamespaceEventSourceByID invocations
+func (mmDeleteNamespaceEventSourceByID *RepositoryMock) DeleteNamespaceEventSourceByIDAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteNamespaceEventSourceByID.afterDeleteNamespaceEventSourceByIDCounter)
+}
+
+// DeleteNamespaceEventSourceByIDBeforeCounter returns a count of RepositoryMock.DeleteNamespaceEventSourceByID invocations
+func (mmDeleteNamespaceEventSourceByID *RepositoryMock) DeleteNamespaceEventSourceByIDBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteNamespaceEventSourceByID.beforeDeleteNamespaceEventSourceByIDCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.DeleteNamespaceEventSourceByID.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeleteNamespaceEventSourceByID *mRepositoryMockDeleteNamespaceEventSourceByID) Calls() []*RepositoryMockDeleteNamespaceEventSourceByIDParams {
+	mmDeleteNamespaceEventSourceByID.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockDeleteNamespaceEventSourceByIDParams, len(mmDeleteNamespaceEventSourceByID.callArgs))
+	copy(argCopy, mmDeleteNamespaceEventSourceByID.callArgs)
+
+	mmDeleteNamespaceEventSourceByID.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeleteNamespaceEventSourceByIDDone returns true if the count of the DeleteNamespaceEventSourceByID invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockDeleteNamespaceEventSourceByIDDone() bool {
+	if m.DeleteNamespaceEventSourceByIDMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.DeleteNamespaceEventSourceByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.DeleteNamespaceEventSourceByIDMock.invocationsDone()
+}
+
+// MinimockDeleteNamespaceEventSourceByIDInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockDeleteNamespaceEventSourceByIDInspect() {
+	for _, e := range m.DeleteNamespaceEventSourceByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.DeleteNamespaceEventSourceByID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterDeleteNamespaceEventSourceByIDCounter := mm_atomic.LoadUint64(&m.afterDeleteNamespaceEventSourceByIDCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteNamespaceEventSourceByIDMock.defaultExpectation != nil && afterDeleteNamespaceEventSourceByIDCounter < 1 {
+		if m.DeleteNamespaceEventSourceByIDMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.DeleteNamespaceEventSourceByID at\n%s", m.DeleteNamespaceEventSourceByIDMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.DeleteNamespaceEventSourceByID at\n%s with params: %#v", m.DeleteNamespaceEventSourceByIDMock.defaultExpectation.expectationOrigins.origin, *m.DeleteNamespaceEventSourceByIDMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteNamespaceEventSourceByID != nil && afterDeleteNamespaceEventSourceByIDCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.DeleteNamespaceEventSourceByID at\n%s", m.funcDeleteNamespaceEventSourceByIDOrigin)
+	}
+
+	if !m.DeleteNamespaceEventSourceByIDMock.invocationsDone() && afterDeleteNamespaceEventSourceByIDCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.DeleteNamespaceEventSourceByID at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.DeleteNamespaceEventSourceByIDMock.expectedInvocations), m.DeleteNamespaceEventSourceByIDMock.expectedInvocationsOrigin, afterDeleteNamespaceEventSourceByIDCounter)
+	}
+}
+
+type mRepositoryMockDeleteNamespacePipelineByID struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockDeleteNamespacePipelineByIDExpectation
+	expectations       []*RepositoryMockDeleteNamespacePipelineByIDExpectation
+
+	callArgs []*RepositoryMockDeleteNamespacePipelineByIDParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockDeleteNamespacePipelineByIDExpectation specifies expectation struct of the Repository.DeleteNamespacePipelineByID
+type RepositoryMockDeleteNamespacePipelineByIDExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockDeleteNamespacePipelineByIDParams
+	paramPtrs          *RepositoryMockDeleteNamespacePipelineByIDParamPtrs
+	expectationOrigins RepositoryMockDeleteNamespacePipelineByIDExpectationOrigins
+	results            *RepositoryMockDeleteNamespacePipelineByIDResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockDeleteNamespacePipelineByIDParams contains parameters of the Repository.DeleteNamespacePipelineByID
+type RepositoryMockDeleteNamespacePipelineByIDParams struct {
+	ctx            context.Context
+	ownerPermalink string
+	id             string
+}
+
+// RepositoryMockDeleteNamespacePipelineByIDParamPtrs contains pointers to parameters of the Repository.DeleteNamespacePipelineByID
+type RepositoryMockDeleteNamespacePipelineByIDParamPtrs struct {
+	ctx            *context.Context
+	ownerPermalink *string
+	id             *string
+}
 
 // RepositoryMockDeleteNamespacePipelineByIDResults contains results of the Repository.DeleteNamespacePipelineByID
 type RepositoryMockDeleteNamespacePipelineByIDResults struct {
@@ -4593,55 +6276,55 @@ func (m *RepositoryMock) MinimockDeleteNamespacePipelineReleaseByIDInspect() {
 	}
 }
 
-type mRepositoryMockDeleteNamespaceSecretByID struct {
+type mRepositoryMockDeleteNamespacePromptByID struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockDeleteNamespaceSecretByIDExpectation
-	expectations       []*RepositoryMockDeleteNamespaceSecretByIDExpectation
+	defaultExpectation *RepositoryMockDeleteNamespacePromptByIDExpectation
+	expectations       []*RepositoryMockDeleteNamespacePromptByIDExpectation
 
-	callArgs []*RepositoryMockDeleteNamespaceSecretByIDParams
+	callArgs []*RepositoryMockDeleteNamespacePromptByIDParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockDeleteNamespaceSecretByIDExpectation specifies expectation struct of the Repository.DeleteNamespaceSecretByID
-type RepositoryMockDeleteNamespaceSecretByIDExpectation struct {
+// RepositoryMockDeleteNamespacePromptByIDExpectation specifies expectation struct of the Repository.DeleteNamespacePromptByID
+type RepositoryMockDeleteNamespacePromptByIDExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockDeleteNamespaceSecretByIDParams
-	paramPtrs          *RepositoryMockDeleteNamespaceSecretByIDParamPtrs
-	expectationOrigins RepositoryMockDeleteNamespaceSecretByIDExpectationOrigins
-	results            *RepositoryMockDeleteNamespaceSecretByIDResults
+	params             *RepositoryMockDeleteNamespacePromptByIDParams
+	paramPtrs          *RepositoryMockDeleteNamespacePromptByIDParamPtrs
+	expectationOrigins RepositoryMockDeleteNamespacePromptByIDExpectationOrigins
+	results            *RepositoryMockDeleteNamespacePromptByIDResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockDeleteNamespaceSecretByIDParams contains parameters of the Repository.DeleteNamespaceSecretByID
-type RepositoryMockDeleteNamespaceSecretByIDParams struct {
-	ctx            context.Context
-	ownerPermalink string
-	id             string
+// RepositoryMockDeleteNamespacePromptByIDParams contains parameters of the Repository.DeleteNamespacePromptByID
+type RepositoryMockDeleteNamespacePromptByIDParams struct {
+	ctx   context.Context
+	nsUID uuid.UUID
+	id    string
 }
 
-// RepositoryMockDeleteNamespaceSecretByIDParamPtrs contains pointers to parameters of the Repository.DeleteNamespaceSecretByID
-type RepositoryMockDeleteNamespaceSecretByIDParamPtrs struct {
-	ctx            *context.Context
-	ownerPermalink *string
-	id             *string
+// RepositoryMockDeleteNamespacePromptByIDParamPtrs contains pointers to parameters of the Repository.DeleteNamespacePromptByID
+type RepositoryMockDeleteNamespacePromptByIDParamPtrs struct {
+	ctx   *context.Context
+	nsUID *uuid.UUID
+	id    *string
 }
 
-// RepositoryMockDeleteNamespaceSecretByIDResults contains results of the Repository.DeleteNamespaceSecretByID
-type RepositoryMockDeleteNamespaceSecretByIDResults struct {
+// RepositoryMockDeleteNamespacePromptByIDResults contains results of the Repository.DeleteNamespacePromptByID
+type RepositoryMockDeleteNamespacePromptByIDResults struct {
 	err error
 }
 
-// RepositoryMockDeleteNamespaceSecretByIDOrigins contains origins of expectations of the Repository.DeleteNamespaceSecretByID
-type RepositoryMockDeleteNamespaceSecretByIDExpectationOrigins struct {
-	origin               string
-	originCtx            string
-	originOwnerPermalink string
-	originId             string
+// RepositoryMockDeleteNamespacePromptByIDOrigins contains origins of expectations of the Repository.DeleteNamespacePromptByID
+type RepositoryMockDeleteNamespacePromptByIDExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originNsUID string
+	originId    string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -4649,18 +6332,391 @@ type RepositoryMockDeleteNamespaceSecretByIDExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmDeleteNamespaceSecretByID *mRepositoryMockDeleteNamespaceSecretByID) Optional() *mRepositoryMockDeleteNamespaceSecretByID {
-	mmDeleteNamespaceSecretByID.optional = true
-	return mmDeleteNamespaceSecretByID
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) Optional() *mRepositoryMockDeleteNamespacePromptByID {
+	mmDeleteNamespacePromptByID.optional = true
+	return mmDeleteNamespacePromptByID
 }
 
-// Expect sets up expected params for Repository.DeleteNamespaceSecretByID
-func (mmDeleteNamespaceSecretByID *mRepositoryMockDeleteNamespaceSecretByID) Expect(ctx context.Context, ownerPermalink string, id string) *mRepositoryMockDeleteNamespaceSecretByID {
-	if mmDeleteNamespaceSecretByID.mock.funcDeleteNamespaceSecretByID != nil {
-		mmDeleteNamespaceSecretByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceSecretByID mock is already set by Set")
+// Expect sets up expected params for Repository.DeleteNamespacePromptByID
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) Expect(ctx context.Context, nsUID uuid.UUID, id string) *mRepositoryMockDeleteNamespacePromptByID {
+	if mmDeleteNamespacePromptByID.mock.funcDeleteNamespacePromptByID != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("RepositoryMock.DeleteNamespacePromptByID mock is already set by Set")
 	}
 
-	if mmDeleteNamespaceSecretByID.defaultExpectation == nil {
+	if mmDeleteNamespacePromptByID.defaultExpectation == nil {
+		mmDeleteNamespacePromptByID.defaultExpectation = &RepositoryMockDeleteNamespacePromptByIDExpectation{}
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation.paramPtrs != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("RepositoryMock.DeleteNamespacePromptByID mock is already set by ExpectParams functions")
+	}
+
+	mmDeleteNamespacePromptByID.defaultExpectation.params = &RepositoryMockDeleteNamespacePromptByIDParams{ctx, nsUID, id}
+	mmDeleteNamespacePromptByID.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmDeleteNamespacePromptByID.expectations {
+		if minimock.Equal(e.params, mmDeleteNamespacePromptByID.defaultExpectation.params) {
+			mmDeleteNamespacePromptByID.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteNamespacePromptByID.defaultExpectation.params)
+		}
+	}
+
+	return mmDeleteNamespacePromptByID
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.DeleteNamespacePromptByID
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) ExpectCtxParam1(ctx context.Context) *mRepositoryMockDeleteNamespacePromptByID {
+	if mmDeleteNamespacePromptByID.mock.funcDeleteNamespacePromptByID != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("RepositoryMock.DeleteNamespacePromptByID mock is already set by Set")
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation == nil {
+		mmDeleteNamespacePromptByID.defaultExpectation = &RepositoryMockDeleteNamespacePromptByIDExpectation{}
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation.params != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("RepositoryMock.DeleteNamespacePromptByID mock is already set by Expect")
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation.paramPtrs == nil {
+		mmDeleteNamespacePromptByID.defaultExpectation.paramPtrs = &RepositoryMockDeleteNamespacePromptByIDParamPtrs{}
+	}
+	mmDeleteNamespacePromptByID.defaultExpectation.paramPtrs.ctx = &ctx
+	mmDeleteNamespacePromptByID.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmDeleteNamespacePromptByID
+}
+
+// ExpectNsUIDParam2 sets up expected param nsUID for Repository.DeleteNamespacePromptByID
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) ExpectNsUIDParam2(nsUID uuid.UUID) *mRepositoryMockDeleteNamespacePromptByID {
+	if mmDeleteNamespacePromptByID.mock.funcDeleteNamespacePromptByID != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("RepositoryMock.DeleteNamespacePromptByID mock is already set by Set")
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation == nil {
+		mmDeleteNamespacePromptByID.defaultExpectation = &RepositoryMockDeleteNamespacePromptByIDExpectation{}
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation.params != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("RepositoryMock.DeleteNamespacePromptByID mock is already set by Expect")
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation.paramPtrs == nil {
+		mmDeleteNamespacePromptByID.defaultExpectation.paramPtrs = &RepositoryMockDeleteNamespacePromptByIDParamPtrs{}
+	}
+	mmDeleteNamespacePromptByID.defaultExpectation.paramPtrs.nsUID = &nsUID
+	mmDeleteNamespacePromptByID.defaultExpectation.expectationOrigins.originNsUID = minimock.CallerInfo(1)
+
+	return mmDeleteNamespacePromptByID
+}
+
+// ExpectIdParam3 sets up expected param id for Repository.DeleteNamespacePromptByID
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) ExpectIdParam3(id string) *mRepositoryMockDeleteNamespacePromptByID {
+	if mmDeleteNamespacePromptByID.mock.funcDeleteNamespacePromptByID != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("RepositoryMock.DeleteNamespacePromptByID mock is already set by Set")
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation == nil {
+		mmDeleteNamespacePromptByID.defaultExpectation = &RepositoryMockDeleteNamespacePromptByIDExpectation{}
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation.params != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("RepositoryMock.DeleteNamespacePromptByID mock is already set by Expect")
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation.paramPtrs == nil {
+		mmDeleteNamespacePromptByID.defaultExpectation.paramPtrs = &RepositoryMockDeleteNamespacePromptByIDParamPtrs{}
+	}
+	mmDeleteNamespacePromptByID.defaultExpectation.paramPtrs.id = &id
+	mmDeleteNamespacePromptByID.defaultExpectation.expectationOrigins.originId = minimock.CallerInfo(1)
+
+	return mmDeleteNamespacePromptByID
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.DeleteNamespacePromptByID
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) Inspect(f func(ctx context.Context, nsUID uuid.UUID, id string)) *mRepositoryMockDeleteNamespacePromptByID {
+	if mmDeleteNamespacePromptByID.mock.inspectFuncDeleteNamespacePromptByID != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("Inspect function is already set for RepositoryMock.DeleteNamespacePromptByID")
+	}
+
+	mmDeleteNamespacePromptByID.mock.inspectFuncDeleteNamespacePromptByID = f
+
+	return mmDeleteNamespacePromptByID
+}
+
+// Return sets up results that will be returned by Repository.DeleteNamespacePromptByID
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) Return(err error) *RepositoryMock {
+	if mmDeleteNamespacePromptByID.mock.funcDeleteNamespacePromptByID != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("RepositoryMock.DeleteNamespacePromptByID mock is already set by Set")
+	}
+
+	if mmDeleteNamespacePromptByID.defaultExpectation == nil {
+		mmDeleteNamespacePromptByID.defaultExpectation = &RepositoryMockDeleteNamespacePromptByIDExpectation{mock: mmDeleteNamespacePromptByID.mock}
+	}
+	mmDeleteNamespacePromptByID.defaultExpectation.results = &RepositoryMockDeleteNamespacePromptByIDResults{err}
+	mmDeleteNamespacePromptByID.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmDeleteNamespacePromptByID.mock
+}
+
+// Set uses given function f to mock the Repository.DeleteNamespacePromptByID method
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) Set(f func(ctx context.Context, nsUID uuid.UUID, id string) (err error)) *RepositoryMock {
+	if mmDeleteNamespacePromptByID.defaultExpectation != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("Default expectation is already set for the Repository.DeleteNamespacePromptByID method")
+	}
+
+	if len(mmDeleteNamespacePromptByID.expectations) > 0 {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("Some expectations are already set for the Repository.DeleteNamespacePromptByID method")
+	}
+
+	mmDeleteNamespacePromptByID.mock.funcDeleteNamespacePromptByID = f
+	mmDeleteNamespacePromptByID.mock.funcDeleteNamespacePromptByIDOrigin = minimock.CallerInfo(1)
+	return mmDeleteNamespacePromptByID.mock
+}
+
+// When sets expectation for the Repository.DeleteNamespacePromptByID which will trigger the result defined by the following
+// Then helper
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) When(ctx context.Context, nsUID uuid.UUID, id string) *RepositoryMockDeleteNamespacePromptByIDExpectation {
+	if mmDeleteNamespacePromptByID.mock.funcDeleteNamespacePromptByID != nil {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("RepositoryMock.DeleteNamespacePromptByID mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockDeleteNamespacePromptByIDExpectation{
+		mock:               mmDeleteNamespacePromptByID.mock,
+		params:             &RepositoryMockDeleteNamespacePromptByIDParams{ctx, nsUID, id},
+		expectationOrigins: RepositoryMockDeleteNamespacePromptByIDExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmDeleteNamespacePromptByID.expectations = append(mmDeleteNamespacePromptByID.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.DeleteNamespacePromptByID return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockDeleteNamespacePromptByIDExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockDeleteNamespacePromptByIDResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.DeleteNamespacePromptByID should be invoked
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) Times(n uint64) *mRepositoryMockDeleteNamespacePromptByID {
+	if n == 0 {
+		mmDeleteNamespacePromptByID.mock.t.Fatalf("Times of RepositoryMock.DeleteNamespacePromptByID mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmDeleteNamespacePromptByID.expectedInvocations, n)
+	mmDeleteNamespacePromptByID.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmDeleteNamespacePromptByID
+}
+
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) invocationsDone() bool {
+	if len(mmDeleteNamespacePromptByID.expectations) == 0 && mmDeleteNamespacePromptByID.defaultExpectation == nil && mmDeleteNamespacePromptByID.mock.funcDeleteNamespacePromptByID == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmDeleteNamespacePromptByID.mock.afterDeleteNamespacePromptByIDCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeleteNamespacePromptByID.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// DeleteNamespacePromptByID implements mm_repository.Repository
+func (mmDeleteNamespacePromptByID *RepositoryMock) DeleteNamespacePromptByID(ctx context.Context, nsUID uuid.UUID, id string) (err error) {
+	mm_atomic.AddUint64(&mmDeleteNamespacePromptByID.beforeDeleteNamespacePromptByIDCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteNamespacePromptByID.afterDeleteNamespacePromptByIDCounter, 1)
+
+	mmDeleteNamespacePromptByID.t.Helper()
+
+	if mmDeleteNamespacePromptByID.inspectFuncDeleteNamespacePromptByID != nil {
+		mmDeleteNamespacePromptByID.inspectFuncDeleteNamespacePromptByID(ctx, nsUID, id)
+	}
+
+	mm_params := RepositoryMockDeleteNamespacePromptByIDParams{ctx, nsUID, id}
+
+	// Record call args
+	mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.mutex.Lock()
+	mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.callArgs = append(mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.callArgs, &mm_params)
+	mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.mutex.Unlock()
+
+	for _, e := range mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.defaultExpectation.params
+		mm_want_ptrs := mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockDeleteNamespacePromptByIDParams{ctx, nsUID, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmDeleteNamespacePromptByID.t.Errorf("RepositoryMock.DeleteNamespacePromptByID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.nsUID != nil && !minimock.Equal(*mm_want_ptrs.nsUID, mm_got.nsUID) {
+				mmDeleteNamespacePromptByID.t.Errorf("RepositoryMock.DeleteNamespacePromptByID got unexpected parameter nsUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.defaultExpectation.expectationOrigins.originNsUID, *mm_want_ptrs.nsUID, mm_got.nsUID, minimock.Diff(*mm_want_ptrs.nsUID, mm_got.nsUID))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmDeleteNamespacePromptByID.t.Errorf("RepositoryMock.DeleteNamespacePromptByID got unexpected parameter id, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.defaultExpectation.expectationOrigins.originId, *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeleteNamespacePromptByID.t.Errorf("RepositoryMock.DeleteNamespacePromptByID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeleteNamespacePromptByID.DeleteNamespacePromptByIDMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteNamespacePromptByID.t.Fatal("No results are set for the RepositoryMock.DeleteNamespacePromptByID")
+		}
+		return (*mm_results).err
+	}
+	if mmDeleteNamespacePromptByID.funcDeleteNamespacePromptByID != nil {
+		return mmDeleteNamespacePromptByID.funcDeleteNamespacePromptByID(ctx, nsUID, id)
+	}
+	mmDeleteNamespacePromptByID.t.Fatalf("Unexpected call to RepositoryMock.DeleteNamespacePromptByID. %v %v %v", ctx, nsUID, id)
+	return
+}
+
+// DeleteNamespacePromptByIDAfterCounter returns a count of finished RepositoryMock.DeleteNamespacePromptByID invocations
+func (mmDeleteNamespacePromptByID *RepositoryMock) DeleteNamespacePromptByIDAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteNamespacePromptByID.afterDeleteNamespacePromptByIDCounter)
+}
+
+// DeleteNamespacePromptByIDBeforeCounter returns a count of RepositoryMock.DeleteNamespacePromptByID invocations
+func (mmDeleteNamespacePromptByID *RepositoryMock) DeleteNamespacePromptByIDBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteNamespacePromptByID.beforeDeleteNamespacePromptByIDCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.DeleteNamespacePromptByID.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeleteNamespacePromptByID *mRepositoryMockDeleteNamespacePromptByID) Calls() []*RepositoryMockDeleteNamespacePromptByIDParams {
+	mmDeleteNamespacePromptByID.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockDeleteNamespacePromptByIDParams, len(mmDeleteNamespacePromptByID.callArgs))
+	copy(argCopy, mmDeleteNamespacePromptByID.callArgs)
+
+	mmDeleteNamespacePromptByID.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeleteNamespacePromptByIDDone returns true if the count of the DeleteNamespacePromptByID invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockDeleteNamespacePromptByIDDone() bool {
+	if m.DeleteNamespacePromptByIDMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.DeleteNamespacePromptByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.DeleteNamespacePromptByIDMock.invocationsDone()
+}
+
+// MinimockDeleteNamespacePromptByIDInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockDeleteNamespacePromptByIDInspect() {
+	for _, e := range m.DeleteNamespacePromptByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.DeleteNamespacePromptByID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterDeleteNamespacePromptByIDCounter := mm_atomic.LoadUint64(&m.afterDeleteNamespacePromptByIDCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteNamespacePromptByIDMock.defaultExpectation != nil && afterDeleteNamespacePromptByIDCounter < 1 {
+		if m.DeleteNamespacePromptByIDMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.DeleteNamespacePromptByID at\n%s", m.DeleteNamespacePromptByIDMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.DeleteNamespacePromptByID at\n%s with params: %#v", m.DeleteNamespacePromptByIDMock.defaultExpectation.expectationOrigins.origin, *m.DeleteNamespacePromptByIDMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteNamespacePromptByID != nil && afterDeleteNamespacePromptByIDCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.DeleteNamespacePromptByID at\n%s", m.funcDeleteNamespacePromptByIDOrigin)
+	}
+
+	if !m.DeleteNamespacePromptByIDMock.invocationsDone() && afterDeleteNamespacePromptByIDCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.DeleteNamespacePromptByID at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.DeleteNamespacePromptByIDMock.expectedInvocations), m.DeleteNamespacePromptByIDMock.expectedInvocationsOrigin, afterDeleteNamespacePromptByIDCounter)
+	}
+}
+
+type mRepositoryMockDeleteNamespaceSecretByID struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockDeleteNamespaceSecretByIDExpectation
+	expectations       []*RepositoryMockDeleteNamespaceSecretByIDExpectation
+
+	callArgs []*RepositoryMockDeleteNamespaceSecretByIDParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockDeleteNamespaceSecretByIDExpectation specifies expectation struct of the Repository.DeleteNamespaceSecretByID
+type RepositoryMockDeleteNamespaceSecretByIDExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockDeleteNamespaceSecretByIDParams
+	paramPtrs          *RepositoryMockDeleteNamespaceSecretByIDParamPtrs
+	expectationOrigins RepositoryMockDeleteNamespaceSecretByIDExpectationOrigins
+	results            *RepositoryMockDeleteNamespaceSecretByIDResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockDeleteNamespaceSecretByIDParams contains parameters of the Repository.DeleteNamespaceSecretByID
+type RepositoryMockDeleteNamespaceSecretByIDParams struct {
+	ctx            context.Context
+	ownerPermalink string
+	id             string
+}
+
+// RepositoryMockDeleteNamespaceSecretByIDParamPtrs contains pointers to parameters of the Repository.DeleteNamespaceSecretByID
+type RepositoryMockDeleteNamespaceSecretByIDParamPtrs struct {
+	ctx            *context.Context
+	ownerPermalink *string
+	id             *string
+}
+
+// RepositoryMockDeleteNamespaceSecretByIDResults contains results of the Repository.DeleteNamespaceSecretByID
+type RepositoryMockDeleteNamespaceSecretByIDResults struct {
+	err error
+}
+
+// RepositoryMockDeleteNamespaceSecretByIDOrigins contains origins of expectations of the Repository.DeleteNamespaceSecretByID
+type RepositoryMockDeleteNamespaceSecretByIDExpectationOrigins struct {
+	origin               string
+	originCtx            string
+	originOwnerPermalink string
+	originId             string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmDeleteNamespaceSecretByID *mRepositoryMockDeleteNamespaceSecretByID) Optional() *mRepositoryMockDeleteNamespaceSecretByID {
+	mmDeleteNamespaceSecretByID.optional = true
+	return mmDeleteNamespaceSecretByID
+}
+
+// Expect sets up expected params for Repository.DeleteNamespaceSecretByID
+func (mmDeleteNamespaceSecretByID *mRepositoryMockDeleteNamespaceSecretByID) Expect(ctx context.Context, ownerPermalink string, id string) *mRepositoryMockDeleteNamespaceSecretByID {
+	if mmDeleteNamespaceSecretByID.mock.funcDeleteNamespaceSecretByID != nil {
+		mmDeleteNamespaceSecretByID.mock.t.Fatalf("RepositoryMock.DeleteNamespaceSecretByID mock is already set by Set")
+	}
+
+	if mmDeleteNamespaceSecretByID.defaultExpectation == nil {
 		mmDeleteNamespaceSecretByID.defaultExpectation = &RepositoryMockDeleteNamespaceSecretByIDExpectation{}
 	}
 
@@ -6773,59 +8829,433 @@ func (m *RepositoryMock) MinimockGetNamespaceConnectionByIDInspect() {
 	}
 }
 
-type mRepositoryMockGetNamespacePipelineByID struct {
+type mRepositoryMockGetNamespaceEventSourceByID struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockGetNamespacePipelineByIDExpectation
-	expectations       []*RepositoryMockGetNamespacePipelineByIDExpectation
+	defaultExpectation *RepositoryMockGetNamespaceEventSourceByIDExpectation
+	expectations       []*RepositoryMockGetNamespaceEventSourceByIDExpectation
 
-	callArgs []*RepositoryMockGetNamespacePipelineByIDParams
+	callArgs []*RepositoryMockGetNamespaceEventSourceByIDParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockGetNamespacePipelineByIDExpectation specifies expectation struct of the Repository.GetNamespacePipelineByID
-type RepositoryMockGetNamespacePipelineByIDExpectation struct {
+// RepositoryMockGetNamespaceEventSourceByIDExpectation specifies expectation struct of the Repository.GetNamespaceEventSourceByID
+type RepositoryMockGetNamespaceEventSourceByIDExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockGetNamespacePipelineByIDParams
-	paramPtrs          *RepositoryMockGetNamespacePipelineByIDParamPtrs
-	expectationOrigins RepositoryMockGetNamespacePipelineByIDExpectationOrigins
-	results            *RepositoryMockGetNamespacePipelineByIDResults
+	params             *RepositoryMockGetNamespaceEventSourceByIDParams
+	paramPtrs          *RepositoryMockGetNamespaceEventSourceByIDParamPtrs
+	expectationOrigins RepositoryMockGetNamespaceEventSourceByIDExpectationOrigins
+	results            *RepositoryMockGetNamespaceEventSourceByIDResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockGetNamespacePipelineByIDParams contains parameters of the Repository.GetNamespacePipelineByID
-type RepositoryMockGetNamespacePipelineByIDParams struct {
-	ctx            context.Context
-	ownerPermalink string
-	id             string
-	isBasicView    bool
-	embedReleases  bool
+// RepositoryMockGetNamespaceEventSourceByIDParams contains parameters of the Repository.GetNamespaceEventSourceByID
+type RepositoryMockGetNamespaceEventSourceByIDParams struct {
+	ctx   context.Context
+	nsUID uuid.UUID
+	id    string
 }
 
-// RepositoryMockGetNamespacePipelineByIDParamPtrs contains pointers to parameters of the Repository.GetNamespacePipelineByID
-type RepositoryMockGetNamespacePipelineByIDParamPtrs struct {
-	ctx            *context.Context
-	ownerPermalink *string
-	id             *string
-	isBasicView    *bool
-	embedReleases  *bool
+// RepositoryMockGetNamespaceEventSourceByIDParamPtrs contains pointers to parameters of the Repository.GetNamespaceEventSourceByID
+type RepositoryMockGetNamespaceEventSourceByIDParamPtrs struct {
+	ctx   *context.Context
+	nsUID *uuid.UUID
+	id    *string
 }
 
-// RepositoryMockGetNamespacePipelineByIDResults contains results of the Repository.GetNamespacePipelineByID
-type RepositoryMockGetNamespacePipelineByIDResults struct {
-	pp1 *datamodel.Pipeline
+// RepositoryMockGetNamespaceEventSourceByIDResults contains results of the Repository.GetNamespaceEventSourceByID
+type RepositoryMockGetNamespaceEventSourceByIDResults struct {
+	ep1 *datamodel.EventSource
 	err error
 }
 
-// RepositoryMockGetNamespacePipelineByIDOrigins contains origins of expectations of the Repository.GetNamespacePipelineByID
-type RepositoryMockGetNamespacePipelineByIDExpectationOrigins struct {
-	origin               string
-	originCtx            string
-	originOwnerPermalink string
+// RepositoryMockGetNamespaceEventSourceByIDOrigins contains origins of expectations of the Repository.GetNamespaceEventSourceByID
+type RepositoryMockGetNamespaceEventSourceByIDExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originNsUID string
+	originId    string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) Optional() *mRepositoryMockGetNamespaceEventSourceByID {
+	mmGetNamespaceEventSourceByID.optional = true
+	return mmGetNamespaceEventSourceByID
+}
+
+// Expect sets up expected params for Repository.GetNamespaceEventSourceByID
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) Expect(ctx context.Context, nsUID uuid.UUID, id string) *mRepositoryMockGetNamespaceEventSourceByID {
+	if mmGetNamespaceEventSourceByID.mock.funcGetNamespaceEventSourceByID != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.GetNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation == nil {
+		mmGetNamespaceEventSourceByID.defaultExpectation = &RepositoryMockGetNamespaceEventSourceByIDExpectation{}
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation.paramPtrs != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.GetNamespaceEventSourceByID mock is already set by ExpectParams functions")
+	}
+
+	mmGetNamespaceEventSourceByID.defaultExpectation.params = &RepositoryMockGetNamespaceEventSourceByIDParams{ctx, nsUID, id}
+	mmGetNamespaceEventSourceByID.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetNamespaceEventSourceByID.expectations {
+		if minimock.Equal(e.params, mmGetNamespaceEventSourceByID.defaultExpectation.params) {
+			mmGetNamespaceEventSourceByID.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetNamespaceEventSourceByID.defaultExpectation.params)
+		}
+	}
+
+	return mmGetNamespaceEventSourceByID
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetNamespaceEventSourceByID
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetNamespaceEventSourceByID {
+	if mmGetNamespaceEventSourceByID.mock.funcGetNamespaceEventSourceByID != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.GetNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation == nil {
+		mmGetNamespaceEventSourceByID.defaultExpectation = &RepositoryMockGetNamespaceEventSourceByIDExpectation{}
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation.params != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.GetNamespaceEventSourceByID mock is already set by Expect")
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation.paramPtrs == nil {
+		mmGetNamespaceEventSourceByID.defaultExpectation.paramPtrs = &RepositoryMockGetNamespaceEventSourceByIDParamPtrs{}
+	}
+	mmGetNamespaceEventSourceByID.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetNamespaceEventSourceByID.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetNamespaceEventSourceByID
+}
+
+// ExpectNsUIDParam2 sets up expected param nsUID for Repository.GetNamespaceEventSourceByID
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) ExpectNsUIDParam2(nsUID uuid.UUID) *mRepositoryMockGetNamespaceEventSourceByID {
+	if mmGetNamespaceEventSourceByID.mock.funcGetNamespaceEventSourceByID != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.GetNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation == nil {
+		mmGetNamespaceEventSourceByID.defaultExpectation = &RepositoryMockGetNamespaceEventSourceByIDExpectation{}
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation.params != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.GetNamespaceEventSourceByID mock is already set by Expect")
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation.paramPtrs == nil {
+		mmGetNamespaceEventSourceByID.defaultExpectation.paramPtrs = &RepositoryMockGetNamespaceEventSourceByIDParamPtrs{}
+	}
+	mmGetNamespaceEventSourceByID.defaultExpectation.paramPtrs.nsUID = &nsUID
+	mmGetNamespaceEventSourceByID.defaultExpectation.expectationOrigins.originNsUID = minimock.CallerInfo(1)
+
+	return mmGetNamespaceEventSourceByID
+}
+
+// ExpectIdParam3 sets up expected param id for Repository.GetNamespaceEventSourceByID
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) ExpectIdParam3(id string) *mRepositoryMockGetNamespaceEventSourceByID {
+	if mmGetNamespaceEventSourceByID.mock.funcGetNamespaceEventSourceByID != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.GetNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation == nil {
+		mmGetNamespaceEventSourceByID.defaultExpectation = &RepositoryMockGetNamespaceEventSourceByIDExpectation{}
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation.params != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.GetNamespaceEventSourceByID mock is already set by Expect")
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation.paramPtrs == nil {
+		mmGetNamespaceEventSourceByID.defaultExpectation.paramPtrs = &RepositoryMockGetNamespaceEventSourceByIDParamPtrs{}
+	}
+	mmGetNamespaceEventSourceByID.defaultExpectation.paramPtrs.id = &id
+	mmGetNamespaceEventSourceByID.defaultExpectation.expectationOrigins.originId = minimock.CallerInfo(1)
+
+	return mmGetNamespaceEventSourceByID
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetNamespaceEventSourceByID
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) Inspect(f func(ctx context.Context, nsUID uuid.UUID, id string)) *mRepositoryMockGetNamespaceEventSourceByID {
+	if mmGetNamespaceEventSourceByID.mock.inspectFuncGetNamespaceEventSourceByID != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetNamespaceEventSourceByID")
+	}
+
+	mmGetNamespaceEventSourceByID.mock.inspectFuncGetNamespaceEventSourceByID = f
+
+	return mmGetNamespaceEventSourceByID
+}
+
+// Return sets up results that will be returned by Repository.GetNamespaceEventSourceByID
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) Return(ep1 *datamodel.EventSource, err error) *RepositoryMock {
+	if mmGetNamespaceEventSourceByID.mock.funcGetNamespaceEventSourceByID != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.GetNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	if mmGetNamespaceEventSourceByID.defaultExpectation == nil {
+		mmGetNamespaceEventSourceByID.defaultExpectation = &RepositoryMockGetNamespaceEventSourceByIDExpectation{mock: mmGetNamespaceEventSourceByID.mock}
+	}
+	mmGetNamespaceEventSourceByID.defaultExpectation.results = &RepositoryMockGetNamespaceEventSourceByIDResults{ep1, err}
+	mmGetNamespaceEventSourceByID.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetNamespaceEventSourceByID.mock
+}
+
+// Set uses given function f to mock the Repository.GetNamespaceEventSourceByID method
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) Set(f func(ctx context.Context, nsUID uuid.UUID, id string) (ep1 *datamodel.EventSource, err error)) *RepositoryMock {
+	if mmGetNamespaceEventSourceByID.defaultExpectation != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("Default expectation is already set for the Repository.GetNamespaceEventSourceByID method")
+	}
+
+	if len(mmGetNamespaceEventSourceByID.expectations) > 0 {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("Some expectations are already set for the Repository.GetNamespaceEventSourceByID method")
+	}
+
+	mmGetNamespaceEventSourceByID.mock.funcGetNamespaceEventSourceByID = f
+	mmGetNamespaceEventSourceByID.mock.funcGetNamespaceEventSourceByIDOrigin = minimock.CallerInfo(1)
+	return mmGetNamespaceEventSourceByID.mock
+}
+
+// When sets expectation for the Repository.GetNamespaceEventSourceByID which will trigger the result defined by the following
+// Then helper
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) When(ctx context.Context, nsUID uuid.UUID, id string) *RepositoryMockGetNamespaceEventSourceByIDExpectation {
+	if mmGetNamespaceEventSourceByID.mock.funcGetNamespaceEventSourceByID != nil {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("RepositoryMock.GetNamespaceEventSourceByID mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetNamespaceEventSourceByIDExpectation{
+		mock:               mmGetNamespaceEventSourceByID.mock,
+		params:             &RepositoryMockGetNamespaceEventSourceByIDParams{ctx, nsUID, id},
+		expectationOrigins: RepositoryMockGetNamespaceEventSourceByIDExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetNamespaceEventSourceByID.expectations = append(mmGetNamespaceEventSourceByID.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetNamespaceEventSourceByID return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetNamespaceEventSourceByIDExpectation) Then(ep1 *datamodel.EventSource, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetNamespaceEventSourceByIDResults{ep1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetNamespaceEventSourceByID should be invoked
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) Times(n uint64) *mRepositoryMockGetNamespaceEventSourceByID {
+	if n == 0 {
+		mmGetNamespaceEventSourceByID.mock.t.Fatalf("Times of RepositoryMock.GetNamespaceEventSourceByID mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetNamespaceEventSourceByID.expectedInvocations, n)
+	mmGetNamespaceEventSourceByID.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetNamespaceEventSourceByID
+}
+
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) invocationsDone() bool {
+	if len(mmGetNamespaceEventSourceByID.expectations) == 0 && mmGetNamespaceEventSourceByID.defaultExpectation == nil && mmGetNamespaceEventSourceByID.mock.funcGetNamespaceEventSourceByID == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetNamespaceEventSourceByID.mock.afterGetNamespaceEventSourceByIDCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetNamespaceEventSourceByID.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetNamespaceEventSourceByID implements mm_repository.Repository
+func (mmGetNamespaceEventSourceByID *RepositoryMock) GetNamespaceEventSourceByID(ctx context.Context, nsUID uuid.UUID, id string) (ep1 *datamodel.EventSource, err error) {
+	mm_atomic.AddUint64(&mmGetNamespaceEventSourceByID.beforeGetNamespaceEventSourceByIDCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetNamespaceEventSourceByID.afterGetNamespaceEventSourceByIDCounter, 1)
+
+	mmGetNamespaceEventSourceByID.t.Helper()
+
+	if mmGetNamespaceEventSourceByID.inspectFuncGetNamespaceEventSourceByID != nil {
+		mmGetNamespaceEventSourceByID.inspectFuncGetNamespaceEventSourceByID(ctx, nsUID, id)
+	}
+
+	mm_params := RepositoryMockGetNamespaceEventSourceByIDParams{ctx, nsUID, id}
+
+	// Record call args
+	mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.mutex.Lock()
+	mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.callArgs = append(mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.callArgs, &mm_params)
+	mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.mutex.Unlock()
+
+	for _, e := range mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ep1, e.results.err
+		}
+	}
+
+	if mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.defaultExpectation.params
+		mm_want_ptrs := mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetNamespaceEventSourceByIDParams{ctx, nsUID, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetNamespaceEventSourceByID.t.Errorf("RepositoryMock.GetNamespaceEventSourceByID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.nsUID != nil && !minimock.Equal(*mm_want_ptrs.nsUID, mm_got.nsUID) {
+				mmGetNamespaceEventSourceByID.t.Errorf("RepositoryMock.GetNamespaceEventSourceByID got unexpected parameter nsUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.defaultExpectation.expectationOrigins.originNsUID, *mm_want_ptrs.nsUID, mm_got.nsUID, minimock.Diff(*mm_want_ptrs.nsUID, mm_got.nsUID))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmGetNamespaceEventSourceByID.t.Errorf("RepositoryMock.GetNamespaceEventSourceByID got unexpected parameter id, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.defaultExpectation.expectationOrigins.originId, *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetNamespaceEventSourceByID.t.Errorf("RepositoryMock.GetNamespaceEventSourceByID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetNamespaceEventSourceByID.GetNamespaceEventSourceByIDMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetNamespaceEventSourceByID.t.Fatal("No results are set for the RepositoryMock.GetNamespaceEventSourceByID")
+		}
+		return (*mm_results).ep1, (*mm_results).err
+	}
+	if mmGetNamespaceEventSourceByID.funcGetNamespaceEventSourceByID != nil {
+		return mmGetNamespaceEventSourceByID.funcGetNamespaceEventSourceByID(ctx, nsUID, id)
+	}
+	mmGetNamespaceEventSourceByID.t.Fatalf("Unexpected call to RepositoryMock.GetNamespaceEventSourceByID. %v %v %v", ctx, nsUID, id)
+	return
+}
+
+// GetNamespaceEventSourceByIDAfterCounter returns a count of finished RepositoryMock.GetNamespaceEventSourceByID invocations
+func (mmGetNamespaceEventSourceByID *RepositoryMock) GetNamespaceEventSourceByIDAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetNamespaceEventSourceByID.afterGetNamespaceEventSourceByIDCounter)
+}
+
+// GetNamespaceEventSourceByIDBeforeCounter returns a count of RepositoryMock.GetNamespaceEventSourceByID invocations
+func (mmGetNamespaceEventSourceByID *RepositoryMock) GetNamespaceEventSourceByIDBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetNamespaceEventSourceByID.beforeGetNamespaceEventSourceByIDCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetNamespaceEventSourceByID.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetNamespaceEventSourceByID *mRepositoryMockGetNamespaceEventSourceByID) Calls() []*RepositoryMockGetNamespaceEventSourceByIDParams {
+	mmGetNamespaceEventSourceByID.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetNamespaceEventSourceByIDParams, len(mmGetNamespaceEventSourceByID.callArgs))
+	copy(argCopy, mmGetNamespaceEventSourceByID.callArgs)
+
+	mmGetNamespaceEventSourceByID.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetNamespaceEventSourceByIDDone returns true if the count of the GetNamespaceEventSourceByID invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetNamespaceEventSourceByIDDone() bool {
+	if m.GetNamespaceEventSourceByIDMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetNamespaceEventSourceByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetNamespaceEventSourceByIDMock.invocationsDone()
+}
+
+// MinimockGetNamespaceEventSourceByIDInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetNamespaceEventSourceByIDInspect() {
+	for _, e := range m.GetNamespaceEventSourceByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetNamespaceEventSourceByID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetNamespaceEventSourceByIDCounter := mm_atomic.LoadUint64(&m.afterGetNamespaceEventSourceByIDCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetNamespaceEventSourceByIDMock.defaultExpectation != nil && afterGetNamespaceEventSourceByIDCounter < 1 {
+		if m.GetNamespaceEventSourceByIDMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.GetNamespaceEventSourceByID at\n%s", m.GetNamespaceEventSourceByIDMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetNamespaceEventSourceByID at\n%s with params: %#v", m.GetNamespaceEventSourceByIDMock.defaultExpectation.expectationOrigins.origin, *m.GetNamespaceEventSourceByIDMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetNamespaceEventSourceByID != nil && afterGetNamespaceEventSourceByIDCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.GetNamespaceEventSourceByID at\n%s", m.funcGetNamespaceEventSourceByIDOrigin)
+	}
+
+	if !m.GetNamespaceEventSourceByIDMock.invocationsDone() && afterGetNamespaceEventSourceByIDCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetNamespaceEventSourceByID at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetNamespaceEventSourceByIDMock.expectedInvocations), m.GetNamespaceEventSourceByIDMock.expectedInvocationsOrigin, afterGetNamespaceEventSourceByIDCounter)
+	}
+}
+
+type mRepositoryMockGetNamespacePipelineByID struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetNamespacePipelineByIDExpectation
+	expectations       []*RepositoryMockGetNamespacePipelineByIDExpectation
+
+	callArgs []*RepositoryMockGetNamespacePipelineByIDParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockGetNamespacePipelineByIDExpectation specifies expectation struct of the Repository.GetNamespacePipelineByID
+type RepositoryMockGetNamespacePipelineByIDExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockGetNamespacePipelineByIDParams
+	paramPtrs          *RepositoryMockGetNamespacePipelineByIDParamPtrs
+	expectationOrigins RepositoryMockGetNamespacePipelineByIDExpectationOrigins
+	results            *RepositoryMockGetNamespacePipelineByIDResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockGetNamespacePipelineByIDParams contains parameters of the Repository.GetNamespacePipelineByID
+type RepositoryMockGetNamespacePipelineByIDParams struct {
+	ctx            context.Context
+	ownerPermalink string
+	id             string
+	isBasicView    bool
+	embedReleases  bool
+}
+
+// RepositoryMockGetNamespacePipelineByIDParamPtrs contains pointers to parameters of the Repository.GetNamespacePipelineByID
+type RepositoryMockGetNamespacePipelineByIDParamPtrs struct {
+	ctx            *context.Context
+	ownerPermalink *string
+	id             *string
+	isBasicView    *bool
+	embedReleases  *bool
+}
+
+// RepositoryMockGetNamespacePipelineByIDResults contains results of the Repository.GetNamespacePipelineByID
+type RepositoryMockGetNamespacePipelineByIDResults struct {
+	pp1 *datamodel.Pipeline
+	err error
+}
+
+// RepositoryMockGetNamespacePipelineByIDOrigins contains origins of expectations of the Repository.GetNamespacePipelineByID
+type RepositoryMockGetNamespacePipelineByIDExpectationOrigins struct {
+	origin               string
+	originCtx            string
+	originOwnerPermalink string
 	originId             string
 	originIsBasicView    string
 	originEmbedReleases  string
@@ -7645,56 +10075,59 @@ func (m *RepositoryMock) MinimockGetNamespacePipelineReleaseByIDInspect() {
 	}
 }
 
-type mRepositoryMockGetNamespaceSecretByID struct {
+type mRepositoryMockGetNamespacePromptByID struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockGetNamespaceSecretByIDExpectation
-	expectations       []*RepositoryMockGetNamespaceSecretByIDExpectation
+	defaultExpectation *RepositoryMockGetNamespacePromptByIDExpectation
+	expectations       []*RepositoryMockGetNamespacePromptByIDExpectation
 
-	callArgs []*RepositoryMockGetNamespaceSecretByIDParams
+	callArgs []*RepositoryMockGetNamespacePromptByIDParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockGetNamespaceSecretByIDExpectation specifies expectation struct of the Repository.GetNamespaceSecretByID
-type RepositoryMockGetNamespaceSecretByIDExpectation struct {
+// RepositoryMockGetNamespacePromptByIDExpectation specifies expectation struct of the Repository.GetNamespacePromptByID
+type RepositoryMockGetNamespacePromptByIDExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockGetNamespaceSecretByIDParams
-	paramPtrs          *RepositoryMockGetNamespaceSecretByIDParamPtrs
-	expectationOrigins RepositoryMockGetNamespaceSecretByIDExpectationOrigins
-	results            *RepositoryMockGetNamespaceSecretByIDResults
+	params             *RepositoryMockGetNamespacePromptByIDParams
+	paramPtrs          *RepositoryMockGetNamespacePromptByIDParamPtrs
+	expectationOrigins RepositoryMockGetNamespacePromptByIDExpectationOrigins
+	results            *RepositoryMockGetNamespacePromptByIDResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockGetNamespaceSecretByIDParams contains parameters of the Repository.GetNamespaceSecretByID
-type RepositoryMockGetNamespaceSecretByIDParams struct {
-	ctx            context.Context
-	ownerPermalink string
-	id             string
+// RepositoryMockGetNamespacePromptByIDParams contains parameters of the Repository.GetNamespacePromptByID
+type RepositoryMockGetNamespacePromptByIDParams struct {
+	ctx     context.Context
+	nsUID   uuid.UUID
+	id      string
+	version int32
 }
 
-// RepositoryMockGetNamespaceSecretByIDParamPtrs contains pointers to parameters of the Repository.GetNamespaceSecretByID
-type RepositoryMockGetNamespaceSecretByIDParamPtrs struct {
-	ctx            *context.Context
-	ownerPermalink *string
-	id             *string
+// RepositoryMockGetNamespacePromptByIDParamPtrs contains pointers to parameters of the Repository.GetNamespacePromptByID
+type RepositoryMockGetNamespacePromptByIDParamPtrs struct {
+	ctx     *context.Context
+	nsUID   *uuid.UUID
+	id      *string
+	version *int32
 }
 
-// RepositoryMockGetNamespaceSecretByIDResults contains results of the Repository.GetNamespaceSecretByID
-type RepositoryMockGetNamespaceSecretByIDResults struct {
-	sp1 *datamodel.Secret
+// RepositoryMockGetNamespacePromptByIDResults contains results of the Repository.GetNamespacePromptByID
+type RepositoryMockGetNamespacePromptByIDResults struct {
+	pp1 *datamodel.Prompt
 	err error
 }
 
-// RepositoryMockGetNamespaceSecretByIDOrigins contains origins of expectations of the Repository.GetNamespaceSecretByID
-type RepositoryMockGetNamespaceSecretByIDExpectationOrigins struct {
-	origin               string
-	originCtx            string
-	originOwnerPermalink string
-	originId             string
+// RepositoryMockGetNamespacePromptByIDOrigins contains origins of expectations of the Repository.GetNamespacePromptByID
+type RepositoryMockGetNamespacePromptByIDExpectationOrigins struct {
+	origin        string
+	originCtx     string
+	originNsUID   string
+	originId      string
+	originVersion string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -7702,19 +10135,421 @@ type RepositoryMockGetNamespaceSecretByIDExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmGetNamespaceSecretByID *mRepositoryMockGetNamespaceSecretByID) Optional() *mRepositoryMockGetNamespaceSecretByID {
-	mmGetNamespaceSecretByID.optional = true
-	return mmGetNamespaceSecretByID
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) Optional() *mRepositoryMockGetNamespacePromptByID {
+	mmGetNamespacePromptByID.optional = true
+	return mmGetNamespacePromptByID
 }
 
-// Expect sets up expected params for Repository.GetNamespaceSecretByID
-func (mmGetNamespaceSecretByID *mRepositoryMockGetNamespaceSecretByID) Expect(ctx context.Context, ownerPermalink string, id string) *mRepositoryMockGetNamespaceSecretByID {
-	if mmGetNamespaceSecretByID.mock.funcGetNamespaceSecretByID != nil {
-		mmGetNamespaceSecretByID.mock.t.Fatalf("RepositoryMock.GetNamespaceSecretByID mock is already set by Set")
+// Expect sets up expected params for Repository.GetNamespacePromptByID
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) Expect(ctx context.Context, nsUID uuid.UUID, id string, version int32) *mRepositoryMockGetNamespacePromptByID {
+	if mmGetNamespacePromptByID.mock.funcGetNamespacePromptByID != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Set")
 	}
 
-	if mmGetNamespaceSecretByID.defaultExpectation == nil {
-		mmGetNamespaceSecretByID.defaultExpectation = &RepositoryMockGetNamespaceSecretByIDExpectation{}
+	if mmGetNamespacePromptByID.defaultExpectation == nil {
+		mmGetNamespacePromptByID.defaultExpectation = &RepositoryMockGetNamespacePromptByIDExpectation{}
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation.paramPtrs != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by ExpectParams functions")
+	}
+
+	mmGetNamespacePromptByID.defaultExpectation.params = &RepositoryMockGetNamespacePromptByIDParams{ctx, nsUID, id, version}
+	mmGetNamespacePromptByID.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetNamespacePromptByID.expectations {
+		if minimock.Equal(e.params, mmGetNamespacePromptByID.defaultExpectation.params) {
+			mmGetNamespacePromptByID.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetNamespacePromptByID.defaultExpectation.params)
+		}
+	}
+
+	return mmGetNamespacePromptByID
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetNamespacePromptByID
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetNamespacePromptByID {
+	if mmGetNamespacePromptByID.mock.funcGetNamespacePromptByID != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Set")
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation == nil {
+		mmGetNamespacePromptByID.defaultExpectation = &RepositoryMockGetNamespacePromptByIDExpectation{}
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation.params != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Expect")
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation.paramPtrs == nil {
+		mmGetNamespacePromptByID.defaultExpectation.paramPtrs = &RepositoryMockGetNamespacePromptByIDParamPtrs{}
+	}
+	mmGetNamespacePromptByID.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetNamespacePromptByID.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetNamespacePromptByID
+}
+
+// ExpectNsUIDParam2 sets up expected param nsUID for Repository.GetNamespacePromptByID
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) ExpectNsUIDParam2(nsUID uuid.UUID) *mRepositoryMockGetNamespacePromptByID {
+	if mmGetNamespacePromptByID.mock.funcGetNamespacePromptByID != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Set")
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation == nil {
+		mmGetNamespacePromptByID.defaultExpectation = &RepositoryMockGetNamespacePromptByIDExpectation{}
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation.params != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Expect")
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation.paramPtrs == nil {
+		mmGetNamespacePromptByID.defaultExpectation.paramPtrs = &RepositoryMockGetNamespacePromptByIDParamPtrs{}
+	}
+	mmGetNamespacePromptByID.defaultExpectation.paramPtrs.nsUID = &nsUID
+	mmGetNamespacePromptByID.defaultExpectation.expectationOrigins.originNsUID = minimock.CallerInfo(1)
+
+	return mmGetNamespacePromptByID
+}
+
+// ExpectIdParam3 sets up expected param id for Repository.GetNamespacePromptByID
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) ExpectIdParam3(id string) *mRepositoryMockGetNamespacePromptByID {
+	if mmGetNamespacePromptByID.mock.funcGetNamespacePromptByID != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Set")
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation == nil {
+		mmGetNamespacePromptByID.defaultExpectation = &RepositoryMockGetNamespacePromptByIDExpectation{}
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation.params != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Expect")
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation.paramPtrs == nil {
+		mmGetNamespacePromptByID.defaultExpectation.paramPtrs = &RepositoryMockGetNamespacePromptByIDParamPtrs{}
+	}
+	mmGetNamespacePromptByID.defaultExpectation.paramPtrs.id = &id
+	mmGetNamespacePromptByID.defaultExpectation.expectationOrigins.originId = minimock.CallerInfo(1)
+
+	return mmGetNamespacePromptByID
+}
+
+// ExpectVersionParam4 sets up expected param version for Repository.GetNamespacePromptByID
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) ExpectVersionParam4(version int32) *mRepositoryMockGetNamespacePromptByID {
+	if mmGetNamespacePromptByID.mock.funcGetNamespacePromptByID != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Set")
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation == nil {
+		mmGetNamespacePromptByID.defaultExpectation = &RepositoryMockGetNamespacePromptByIDExpectation{}
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation.params != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Expect")
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation.paramPtrs == nil {
+		mmGetNamespacePromptByID.defaultExpectation.paramPtrs = &RepositoryMockGetNamespacePromptByIDParamPtrs{}
+	}
+	mmGetNamespacePromptByID.defaultExpectation.paramPtrs.version = &version
+	mmGetNamespacePromptByID.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmGetNamespacePromptByID
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetNamespacePromptByID
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) Inspect(f func(ctx context.Context, nsUID uuid.UUID, id string, version int32)) *mRepositoryMockGetNamespacePromptByID {
+	if mmGetNamespacePromptByID.mock.inspectFuncGetNamespacePromptByID != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetNamespacePromptByID")
+	}
+
+	mmGetNamespacePromptByID.mock.inspectFuncGetNamespacePromptByID = f
+
+	return mmGetNamespacePromptByID
+}
+
+// Return sets up results that will be returned by Repository.GetNamespacePromptByID
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) Return(pp1 *datamodel.Prompt, err error) *RepositoryMock {
+	if mmGetNamespacePromptByID.mock.funcGetNamespacePromptByID != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Set")
+	}
+
+	if mmGetNamespacePromptByID.defaultExpectation == nil {
+		mmGetNamespacePromptByID.defaultExpectation = &RepositoryMockGetNamespacePromptByIDExpectation{mock: mmGetNamespacePromptByID.mock}
+	}
+	mmGetNamespacePromptByID.defaultExpectation.results = &RepositoryMockGetNamespacePromptByIDResults{pp1, err}
+	mmGetNamespacePromptByID.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetNamespacePromptByID.mock
+}
+
+// Set uses given function f to mock the Repository.GetNamespacePromptByID method
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) Set(f func(ctx context.Context, nsUID uuid.UUID, id string, version int32) (pp1 *datamodel.Prompt, err error)) *RepositoryMock {
+	if mmGetNamespacePromptByID.defaultExpectation != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("Default expectation is already set for the Repository.GetNamespacePromptByID method")
+	}
+
+	if len(mmGetNamespacePromptByID.expectations) > 0 {
+		mmGetNamespacePromptByID.mock.t.Fatalf("Some expectations are already set for the Repository.GetNamespacePromptByID method")
+	}
+
+	mmGetNamespacePromptByID.mock.funcGetNamespacePromptByID = f
+	mmGetNamespacePromptByID.mock.funcGetNamespacePromptByIDOrigin = minimock.CallerInfo(1)
+	return mmGetNamespacePromptByID.mock
+}
+
+// When sets expectation for the Repository.GetNamespacePromptByID which will trigger the result defined by the following
+// Then helper
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) When(ctx context.Context, nsUID uuid.UUID, id string, version int32) *RepositoryMockGetNamespacePromptByIDExpectation {
+	if mmGetNamespacePromptByID.mock.funcGetNamespacePromptByID != nil {
+		mmGetNamespacePromptByID.mock.t.Fatalf("RepositoryMock.GetNamespacePromptByID mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetNamespacePromptByIDExpectation{
+		mock:               mmGetNamespacePromptByID.mock,
+		params:             &RepositoryMockGetNamespacePromptByIDParams{ctx, nsUID, id, version},
+		expectationOrigins: RepositoryMockGetNamespacePromptByIDExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetNamespacePromptByID.expectations = append(mmGetNamespacePromptByID.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetNamespacePromptByID return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetNamespacePromptByIDExpectation) Then(pp1 *datamodel.Prompt, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetNamespacePromptByIDResults{pp1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetNamespacePromptByID should be invoked
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) Times(n uint64) *mRepositoryMockGetNamespacePromptByID {
+	if n == 0 {
+		mmGetNamespacePromptByID.mock.t.Fatalf("Times of RepositoryMock.GetNamespacePromptByID mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetNamespacePromptByID.expectedInvocations, n)
+	mmGetNamespacePromptByID.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetNamespacePromptByID
+}
+
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) invocationsDone() bool {
+	if len(mmGetNamespacePromptByID.expectations) == 0 && mmGetNamespacePromptByID.defaultExpectation == nil && mmGetNamespacePromptByID.mock.funcGetNamespacePromptByID == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetNamespacePromptByID.mock.afterGetNamespacePromptByIDCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetNamespacePromptByID.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetNamespacePromptByID implements mm_repository.Repository
+func (mmGetNamespacePromptByID *RepositoryMock) GetNamespacePromptByID(ctx context.Context, nsUID uuid.UUID, id string, version int32) (pp1 *datamodel.Prompt, err error) {
+	mm_atomic.AddUint64(&mmGetNamespacePromptByID.beforeGetNamespacePromptByIDCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetNamespacePromptByID.afterGetNamespacePromptByIDCounter, 1)
+
+	mmGetNamespacePromptByID.t.Helper()
+
+	if mmGetNamespacePromptByID.inspectFuncGetNamespacePromptByID != nil {
+		mmGetNamespacePromptByID.inspectFuncGetNamespacePromptByID(ctx, nsUID, id, version)
+	}
+
+	mm_params := RepositoryMockGetNamespacePromptByIDParams{ctx, nsUID, id, version}
+
+	// Record call args
+	mmGetNamespacePromptByID.GetNamespacePromptByIDMock.mutex.Lock()
+	mmGetNamespacePromptByID.GetNamespacePromptByIDMock.callArgs = append(mmGetNamespacePromptByID.GetNamespacePromptByIDMock.callArgs, &mm_params)
+	mmGetNamespacePromptByID.GetNamespacePromptByIDMock.mutex.Unlock()
+
+	for _, e := range mmGetNamespacePromptByID.GetNamespacePromptByIDMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.pp1, e.results.err
+		}
+	}
+
+	if mmGetNamespacePromptByID.GetNamespacePromptByIDMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetNamespacePromptByID.GetNamespacePromptByIDMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetNamespacePromptByID.GetNamespacePromptByIDMock.defaultExpectation.params
+		mm_want_ptrs := mmGetNamespacePromptByID.GetNamespacePromptByIDMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetNamespacePromptByIDParams{ctx, nsUID, id, version}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetNamespacePromptByID.t.Errorf("RepositoryMock.GetNamespacePromptByID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetNamespacePromptByID.GetNamespacePromptByIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.nsUID != nil && !minimock.Equal(*mm_want_ptrs.nsUID, mm_got.nsUID) {
+				mmGetNamespacePromptByID.t.Errorf("RepositoryMock.GetNamespacePromptByID got unexpected parameter nsUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetNamespacePromptByID.GetNamespacePromptByIDMock.defaultExpectation.expectationOrigins.originNsUID, *mm_want_ptrs.nsUID, mm_got.nsUID, minimock.Diff(*mm_want_ptrs.nsUID, mm_got.nsUID))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmGetNamespacePromptByID.t.Errorf("RepositoryMock.GetNamespacePromptByID got unexpected parameter id, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetNamespacePromptByID.GetNamespacePromptByIDMock.defaultExpectation.expectationOrigins.originId, *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmGetNamespacePromptByID.t.Errorf("RepositoryMock.GetNamespacePromptByID got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetNamespacePromptByID.GetNamespacePromptByIDMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetNamespacePromptByID.t.Errorf("RepositoryMock.GetNamespacePromptByID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetNamespacePromptByID.GetNamespacePromptByIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetNamespacePromptByID.GetNamespacePromptByIDMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetNamespacePromptByID.t.Fatal("No results are set for the RepositoryMock.GetNamespacePromptByID")
+		}
+		return (*mm_results).pp1, (*mm_results).err
+	}
+	if mmGetNamespacePromptByID.funcGetNamespacePromptByID != nil {
+		return mmGetNamespacePromptByID.funcGetNamespacePromptByID(ctx, nsUID, id, version)
+	}
+	mmGetNamespacePromptByID.t.Fatalf("Unexpected call to RepositoryMock.GetNamespacePromptByID. %v %v %v %v", ctx, nsUID, id, version)
+	return
+}
+
+// GetNamespacePromptByIDAfterCounter returns a count of finished RepositoryMock.GetNamespacePromptByID invocations
+func (mmGetNamespacePromptByID *RepositoryMock) GetNamespacePromptByIDAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetNamespacePromptByID.afterGetNamespacePromptByIDCounter)
+}
+
+// GetNamespacePromptByIDBeforeCounter returns a count of RepositoryMock.GetNamespacePromptByID invocations
+func (mmGetNamespacePromptByID *RepositoryMock) GetNamespacePromptByIDBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetNamespacePromptByID.beforeGetNamespacePromptByIDCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetNamespacePromptByID.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetNamespacePromptByID *mRepositoryMockGetNamespacePromptByID) Calls() []*RepositoryMockGetNamespacePromptByIDParams {
+	mmGetNamespacePromptByID.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetNamespacePromptByIDParams, len(mmGetNamespacePromptByID.callArgs))
+	copy(argCopy, mmGetNamespacePromptByID.callArgs)
+
+	mmGetNamespacePromptByID.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetNamespacePromptByIDDone returns true if the count of the GetNamespacePromptByID invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetNamespacePromptByIDDone() bool {
+	if m.GetNamespacePromptByIDMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetNamespacePromptByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetNamespacePromptByIDMock.invocationsDone()
+}
+
+// MinimockGetNamespacePromptByIDInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetNamespacePromptByIDInspect() {
+	for _, e := range m.GetNamespacePromptByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetNamespacePromptByID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetNamespacePromptByIDCounter := mm_atomic.LoadUint64(&m.afterGetNamespacePromptByIDCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetNamespacePromptByIDMock.defaultExpectation != nil && afterGetNamespacePromptByIDCounter < 1 {
+		if m.GetNamespacePromptByIDMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.GetNamespacePromptByID at\n%s", m.GetNamespacePromptByIDMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetNamespacePromptByID at\n%s with params: %#v", m.GetNamespacePromptByIDMock.defaultExpectation.expectationOrigins.origin, *m.GetNamespacePromptByIDMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetNamespacePromptByID != nil && afterGetNamespacePromptByIDCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.GetNamespacePromptByID at\n%s", m.funcGetNamespacePromptByIDOrigin)
+	}
+
+	if !m.GetNamespacePromptByIDMock.invocationsDone() && afterGetNamespacePromptByIDCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetNamespacePromptByID at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetNamespacePromptByIDMock.expectedInvocations), m.GetNamespacePromptByIDMock.expectedInvocationsOrigin, afterGetNamespacePromptByIDCounter)
+	}
+}
+
+type mRepositoryMockGetNamespaceSecretByID struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetNamespaceSecretByIDExpectation
+	expectations       []*RepositoryMockGetNamespaceSecretByIDExpectation
+
+	callArgs []*RepositoryMockGetNamespaceSecretByIDParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockGetNamespaceSecretByIDExpectation specifies expectation struct of the Repository.GetNamespaceSecretByID
+type RepositoryMockGetNamespaceSecretByIDExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockGetNamespaceSecretByIDParams
+	paramPtrs          *RepositoryMockGetNamespaceSecretByIDParamPtrs
+	expectationOrigins RepositoryMockGetNamespaceSecretByIDExpectationOrigins
+	results            *RepositoryMockGetNamespaceSecretByIDResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockGetNamespaceSecretByIDParams contains parameters of the Repository.GetNamespaceSecretByID
+type RepositoryMockGetNamespaceSecretByIDParams struct {
+	ctx            context.Context
+	ownerPermalink string
+	id             string
+}
+
+// RepositoryMockGetNamespaceSecretByIDParamPtrs contains pointers to parameters of the Repository.GetNamespaceSecretByID
+type RepositoryMockGetNamespaceSecretByIDParamPtrs struct {
+	ctx            *context.Context
+	ownerPermalink *string
+	id             *string
+}
+
+// RepositoryMockGetNamespaceSecretByIDResults contains results of the Repository.GetNamespaceSecretByID
+type RepositoryMockGetNamespaceSecretByIDResults struct {
+	sp1 *datamodel.Secret
+	err error
+}
+
+// RepositoryMockGetNamespaceSecretByIDOrigins contains origins of expectations of the Repository.GetNamespaceSecretByID
+type RepositoryMockGetNamespaceSecretByIDExpectationOrigins struct {
+	origin               string
+	originCtx            string
+	originOwnerPermalink string
+	originId             string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetNamespaceSecretByID *mRepositoryMockGetNamespaceSecretByID) Optional() *mRepositoryMockGetNamespaceSecretByID {
+	mmGetNamespaceSecretByID.optional = true
+	return mmGetNamespaceSecretByID
+}
+
+// Expect sets up expected params for Repository.GetNamespaceSecretByID
+func (mmGetNamespaceSecretByID *mRepositoryMockGetNamespaceSecretByID) Expect(ctx context.Context, ownerPermalink string, id string) *mRepositoryMockGetNamespaceSecretByID {
+	if mmGetNamespaceSecretByID.mock.funcGetNamespaceSecretByID != nil {
+		mmGetNamespaceSecretByID.mock.t.Fatalf("RepositoryMock.GetNamespaceSecretByID mock is already set by Set")
+	}
+
+	if mmGetNamespaceSecretByID.defaultExpectation == nil {
+		mmGetNamespaceSecretByID.defaultExpectation = &RepositoryMockGetNamespaceSecretByIDExpectation{}
 	}
 
 	if mmGetNamespaceSecretByID.defaultExpectation.paramPtrs != nil {
@@ -8019,44 +10854,387 @@ func (m *RepositoryMock) MinimockGetNamespaceSecretByIDInspect() {
 	}
 }
 
-type mRepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissions struct {
+type mRepositoryMockGetNamespaceSettings struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsExpectation
-	expectations       []*RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsExpectation
+	defaultExpectation *RepositoryMockGetNamespaceSettingsExpectation
+	expectations       []*RepositoryMockGetNamespaceSettingsExpectation
 
-	callArgs []*RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParams
+	callArgs []*RepositoryMockGetNamespaceSettingsParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsExpectation specifies expectation struct of the Repository.GetPaginatedComponentRunsByPipelineRunIDWithPermissions
-type RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsExpectation struct {
+// RepositoryMockGetNamespaceSettingsExpectation specifies expectation struct of the Repository.GetNamespaceSettings
+type RepositoryMockGetNamespaceSettingsExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParams
-	paramPtrs          *RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParamPtrs
-	expectationOrigins RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsExpectationOrigins
-	results            *RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsResults
+	params             *RepositoryMockGetNamespaceSettingsParams
+	paramPtrs          *RepositoryMockGetNamespaceSettingsParamPtrs
+	expectationOrigins RepositoryMockGetNamespaceSettingsExpectationOrigins
+	results            *RepositoryMockGetNamespaceSettingsResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParams contains parameters of the Repository.GetPaginatedComponentRunsByPipelineRunIDWithPermissions
-type RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParams struct {
-	ctx           context.Context
-	pipelineRunID string
-	page          int
-	pageSize      int
-	filter        filtering.Filter
-	order         ordering.OrderBy
+// RepositoryMockGetNamespaceSettingsParams contains parameters of the Repository.GetNamespaceSettings
+type RepositoryMockGetNamespaceSettingsParams struct {
+	ctx   context.Context
+	nsUID uuid.UUID
 }
 
-// RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParamPtrs contains pointers to parameters of the Repository.GetPaginatedComponentRunsByPipelineRunIDWithPermissions
-type RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParamPtrs struct {
-	ctx           *context.Context
-	pipelineRunID *string
+// RepositoryMockGetNamespaceSettingsParamPtrs contains pointers to parameters of the Repository.GetNamespaceSettings
+type RepositoryMockGetNamespaceSettingsParamPtrs struct {
+	ctx   *context.Context
+	nsUID *uuid.UUID
+}
+
+// RepositoryMockGetNamespaceSettingsResults contains results of the Repository.GetNamespaceSettings
+type RepositoryMockGetNamespaceSettingsResults struct {
+	np1 *datamodel.NamespaceSettings
+	err error
+}
+
+// RepositoryMockGetNamespaceSettingsOrigins contains origins of expectations of the Repository.GetNamespaceSettings
+type RepositoryMockGetNamespaceSettingsExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originNsUID string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) Optional() *mRepositoryMockGetNamespaceSettings {
+	mmGetNamespaceSettings.optional = true
+	return mmGetNamespaceSettings
+}
+
+// Expect sets up expected params for Repository.GetNamespaceSettings
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) Expect(ctx context.Context, nsUID uuid.UUID) *mRepositoryMockGetNamespaceSettings {
+	if mmGetNamespaceSettings.mock.funcGetNamespaceSettings != nil {
+		mmGetNamespaceSettings.mock.t.Fatalf("RepositoryMock.GetNamespaceSettings mock is already set by Set")
+	}
+
+	if mmGetNamespaceSettings.defaultExpectation == nil {
+		mmGetNamespaceSettings.defaultExpectation = &RepositoryMockGetNamespaceSettingsExpectation{}
+	}
+
+	if mmGetNamespaceSettings.defaultExpectation.paramPtrs != nil {
+		mmGetNamespaceSettings.mock.t.Fatalf("RepositoryMock.GetNamespaceSettings mock is already set by ExpectParams functions")
+	}
+
+	mmGetNamespaceSettings.defaultExpectation.params = &RepositoryMockGetNamespaceSettingsParams{ctx, nsUID}
+	mmGetNamespaceSettings.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetNamespaceSettings.expectations {
+		if minimock.Equal(e.params, mmGetNamespaceSettings.defaultExpectation.params) {
+			mmGetNamespaceSettings.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetNamespaceSettings.defaultExpectation.params)
+		}
+	}
+
+	return mmGetNamespaceSettings
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetNamespaceSettings
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetNamespaceSettings {
+	if mmGetNamespaceSettings.mock.funcGetNamespaceSettings != nil {
+		mmGetNamespaceSettings.mock.t.Fatalf("RepositoryMock.GetNamespaceSettings mock is already set by Set")
+	}
+
+	if mmGetNamespaceSettings.defaultExpectation == nil {
+		mmGetNamespaceSettings.defaultExpectation = &RepositoryMockGetNamespaceSettingsExpectation{}
+	}
+
+	if mmGetNamespaceSettings.defaultExpectation.params != nil {
+		mmGetNamespaceSettings.mock.t.Fatalf("RepositoryMock.GetNamespaceSettings mock is already set by Expect")
+	}
+
+	if mmGetNamespaceSettings.defaultExpectation.paramPtrs == nil {
+		mmGetNamespaceSettings.defaultExpectation.paramPtrs = &RepositoryMockGetNamespaceSettingsParamPtrs{}
+	}
+	mmGetNamespaceSettings.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetNamespaceSettings.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetNamespaceSettings
+}
+
+// ExpectNsUIDParam2 sets up expected param nsUID for Repository.GetNamespaceSettings
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) ExpectNsUIDParam2(nsUID uuid.UUID) *mRepositoryMockGetNamespaceSettings {
+	if mmGetNamespaceSettings.mock.funcGetNamespaceSettings != nil {
+		mmGetNamespaceSettings.mock.t.Fatalf("RepositoryMock.GetNamespaceSettings mock is already set by Set")
+	}
+
+	if mmGetNamespaceSettings.defaultExpectation == nil {
+		mmGetNamespaceSettings.defaultExpectation = &RepositoryMockGetNamespaceSettingsExpectation{}
+	}
+
+	if mmGetNamespaceSettings.defaultExpectation.params != nil {
+		mmGetNamespaceSettings.mock.t.Fatalf("RepositoryMock.GetNamespaceSettings mock is already set by Expect")
+	}
+
+	if mmGetNamespaceSettings.defaultExpectation.paramPtrs == nil {
+		mmGetNamespaceSettings.defaultExpectation.paramPtrs = &RepositoryMockGetNamespaceSettingsParamPtrs{}
+	}
+	mmGetNamespaceSettings.defaultExpectation.paramPtrs.nsUID = &nsUID
+	mmGetNamespaceSettings.defaultExpectation.expectationOrigins.originNsUID = minimock.CallerInfo(1)
+
+	return mmGetNamespaceSettings
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetNamespaceSettings
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) Inspect(f func(ctx context.Context, nsUID uuid.UUID)) *mRepositoryMockGetNamespaceSettings {
+	if mmGetNamespaceSettings.mock.inspectFuncGetNamespaceSettings != nil {
+		mmGetNamespaceSettings.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetNamespaceSettings")
+	}
+
+	mmGetNamespaceSettings.mock.inspectFuncGetNamespaceSettings = f
+
+	return mmGetNamespaceSettings
+}
+
+// Return sets up results that will be returned by Repository.GetNamespaceSettings
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) Return(np1 *datamodel.NamespaceSettings, err error) *RepositoryMock {
+	if mmGetNamespaceSettings.mock.funcGetNamespaceSettings != nil {
+		mmGetNamespaceSettings.mock.t.Fatalf("RepositoryMock.GetNamespaceSettings mock is already set by Set")
+	}
+
+	if mmGetNamespaceSettings.defaultExpectation == nil {
+		mmGetNamespaceSettings.defaultExpectation = &RepositoryMockGetNamespaceSettingsExpectation{mock: mmGetNamespaceSettings.mock}
+	}
+	mmGetNamespaceSettings.defaultExpectation.results = &RepositoryMockGetNamespaceSettingsResults{np1, err}
+	mmGetNamespaceSettings.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetNamespaceSettings.mock
+}
+
+// Set uses given function f to mock the Repository.GetNamespaceSettings method
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) Set(f func(ctx context.Context, nsUID uuid.UUID) (np1 *datamodel.NamespaceSettings, err error)) *RepositoryMock {
+	if mmGetNamespaceSettings.defaultExpectation != nil {
+		mmGetNamespaceSettings.mock.t.Fatalf("Default expectation is already set for the Repository.GetNamespaceSettings method")
+	}
+
+	if len(mmGetNamespaceSettings.expectations) > 0 {
+		mmGetNamespaceSettings.mock.t.Fatalf("Some expectations are already set for the Repository.GetNamespaceSettings method")
+	}
+
+	mmGetNamespaceSettings.mock.funcGetNamespaceSettings = f
+	mmGetNamespaceSettings.mock.funcGetNamespaceSettingsOrigin = minimock.CallerInfo(1)
+	return mmGetNamespaceSettings.mock
+}
+
+// When sets expectation for the Repository.GetNamespaceSettings which will trigger the result defined by the following
+// Then helper
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) When(ctx context.Context, nsUID uuid.UUID) *RepositoryMockGetNamespaceSettingsExpectation {
+	if mmGetNamespaceSettings.mock.funcGetNamespaceSettings != nil {
+		mmGetNamespaceSettings.mock.t.Fatalf("RepositoryMock.GetNamespaceSettings mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetNamespaceSettingsExpectation{
+		mock:               mmGetNamespaceSettings.mock,
+		params:             &RepositoryMockGetNamespaceSettingsParams{ctx, nsUID},
+		expectationOrigins: RepositoryMockGetNamespaceSettingsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetNamespaceSettings.expectations = append(mmGetNamespaceSettings.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetNamespaceSettings return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetNamespaceSettingsExpectation) Then(np1 *datamodel.NamespaceSettings, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetNamespaceSettingsResults{np1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetNamespaceSettings should be invoked
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) Times(n uint64) *mRepositoryMockGetNamespaceSettings {
+	if n == 0 {
+		mmGetNamespaceSettings.mock.t.Fatalf("Times of RepositoryMock.GetNamespaceSettings mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetNamespaceSettings.expectedInvocations, n)
+	mmGetNamespaceSettings.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetNamespaceSettings
+}
+
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) invocationsDone() bool {
+	if len(mmGetNamespaceSettings.expectations) == 0 && mmGetNamespaceSettings.defaultExpectation == nil && mmGetNamespaceSettings.mock.funcGetNamespaceSettings == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetNamespaceSettings.mock.afterGetNamespaceSettingsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetNamespaceSettings.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetNamespaceSettings implements mm_repository.Repository
+func (mmGetNamespaceSettings *RepositoryMock) GetNamespaceSettings(ctx context.Context, nsUID uuid.UUID) (np1 *datamodel.NamespaceSettings, err error) {
+	mm_atomic.AddUint64(&mmGetNamespaceSettings.beforeGetNamespaceSettingsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetNamespaceSettings.afterGetNamespaceSettingsCounter, 1)
+
+	mmGetNamespaceSettings.t.Helper()
+
+	if mmGetNamespaceSettings.inspectFuncGetNamespaceSettings != nil {
+		mmGetNamespaceSettings.inspectFuncGetNamespaceSettings(ctx, nsUID)
+	}
+
+	mm_params := RepositoryMockGetNamespaceSettingsParams{ctx, nsUID}
+
+	// Record call args
+	mmGetNamespaceSettings.GetNamespaceSettingsMock.mutex.Lock()
+	mmGetNamespaceSettings.GetNamespaceSettingsMock.callArgs = append(mmGetNamespaceSettings.GetNamespaceSettingsMock.callArgs, &mm_params)
+	mmGetNamespaceSettings.GetNamespaceSettingsMock.mutex.Unlock()
+
+	for _, e := range mmGetNamespaceSettings.GetNamespaceSettingsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.np1, e.results.err
+		}
+	}
+
+	if mmGetNamespaceSettings.GetNamespaceSettingsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetNamespaceSettings.GetNamespaceSettingsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetNamespaceSettings.GetNamespaceSettingsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetNamespaceSettings.GetNamespaceSettingsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetNamespaceSettingsParams{ctx, nsUID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetNamespaceSettings.t.Errorf("RepositoryMock.GetNamespaceSettings got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetNamespaceSettings.GetNamespaceSettingsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.nsUID != nil && !minimock.Equal(*mm_want_ptrs.nsUID, mm_got.nsUID) {
+				mmGetNamespaceSettings.t.Errorf("RepositoryMock.GetNamespaceSettings got unexpected parameter nsUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetNamespaceSettings.GetNamespaceSettingsMock.defaultExpectation.expectationOrigins.originNsUID, *mm_want_ptrs.nsUID, mm_got.nsUID, minimock.Diff(*mm_want_ptrs.nsUID, mm_got.nsUID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetNamespaceSettings.t.Errorf("RepositoryMock.GetNamespaceSettings got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetNamespaceSettings.GetNamespaceSettingsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetNamespaceSettings.GetNamespaceSettingsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetNamespaceSettings.t.Fatal("No results are set for the RepositoryMock.GetNamespaceSettings")
+		}
+		return (*mm_results).np1, (*mm_results).err
+	}
+	if mmGetNamespaceSettings.funcGetNamespaceSettings != nil {
+		return mmGetNamespaceSettings.funcGetNamespaceSettings(ctx, nsUID)
+	}
+	mmGetNamespaceSettings.t.Fatalf("Unexpected call to RepositoryMock.GetNamespaceSettings. %v %v", ctx, nsUID)
+	return
+}
+
+// GetNamespaceSettingsAfterCounter returns a count of finished RepositoryMock.GetNamespaceSettings invocations
+func (mmGetNamespaceSettings *RepositoryMock) GetNamespaceSettingsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetNamespaceSettings.afterGetNamespaceSettingsCounter)
+}
+
+// GetNamespaceSettingsBeforeCounter returns a count of RepositoryMock.GetNamespaceSettings invocations
+func (mmGetNamespaceSettings *RepositoryMock) GetNamespaceSettingsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetNamespaceSettings.beforeGetNamespaceSettingsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetNamespaceSettings.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetNamespaceSettings *mRepositoryMockGetNamespaceSettings) Calls() []*RepositoryMockGetNamespaceSettingsParams {
+	mmGetNamespaceSettings.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetNamespaceSettingsParams, len(mmGetNamespaceSettings.callArgs))
+	copy(argCopy, mmGetNamespaceSettings.callArgs)
+
+	mmGetNamespaceSettings.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetNamespaceSettingsDone returns true if the count of the GetNamespaceSettings invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetNamespaceSettingsDone() bool {
+	if m.GetNamespaceSettingsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetNamespaceSettingsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetNamespaceSettingsMock.invocationsDone()
+}
+
+// MinimockGetNamespaceSettingsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetNamespaceSettingsInspect() {
+	for _, e := range m.GetNamespaceSettingsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetNamespaceSettings at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetNamespaceSettingsCounter := mm_atomic.LoadUint64(&m.afterGetNamespaceSettingsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetNamespaceSettingsMock.defaultExpectation != nil && afterGetNamespaceSettingsCounter < 1 {
+		if m.GetNamespaceSettingsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.GetNamespaceSettings at\n%s", m.GetNamespaceSettingsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetNamespaceSettings at\n%s with params: %#v", m.GetNamespaceSettingsMock.defaultExpectation.expectationOrigins.origin, *m.GetNamespaceSettingsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetNamespaceSettings != nil && afterGetNamespaceSettingsCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.GetNamespaceSettings at\n%s", m.funcGetNamespaceSettingsOrigin)
+	}
+
+	if !m.GetNamespaceSettingsMock.invocationsDone() && afterGetNamespaceSettingsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetNamespaceSettings at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetNamespaceSettingsMock.expectedInvocations), m.GetNamespaceSettingsMock.expectedInvocationsOrigin, afterGetNamespaceSettingsCounter)
+	}
+}
+
+type mRepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissions struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsExpectation
+	expectations       []*RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsExpectation
+
+	callArgs []*RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsExpectation specifies expectation struct of the Repository.GetPaginatedComponentRunsByPipelineRunIDWithPermissions
+type RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParams
+	paramPtrs          *RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParamPtrs
+	expectationOrigins RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsExpectationOrigins
+	results            *RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParams contains parameters of the Repository.GetPaginatedComponentRunsByPipelineRunIDWithPermissions
+type RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParams struct {
+	ctx           context.Context
+	pipelineRunID string
+	page          int
+	pageSize      int
+	filter        filtering.Filter
+	order         ordering.OrderBy
+}
+
+// RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParamPtrs contains pointers to parameters of the Repository.GetPaginatedComponentRunsByPipelineRunIDWithPermissions
+type RepositoryMockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsParamPtrs struct {
+	ctx           *context.Context
+	pipelineRunID *string
 	page          *int
 	pageSize      *int
 	filter        *filtering.Filter
@@ -11293,54 +14471,56 @@ func (m *RepositoryMock) MinimockGetPipelineRunByUIDInspect() {
 	}
 }
 
-type mRepositoryMockListComponentDefinitionUIDs struct {
+type mRepositoryMockGetRunArtifact struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockListComponentDefinitionUIDsExpectation
-	expectations       []*RepositoryMockListComponentDefinitionUIDsExpectation
+	defaultExpectation *RepositoryMockGetRunArtifactExpectation
+	expectations       []*RepositoryMockGetRunArtifactExpectation
 
-	callArgs []*RepositoryMockListComponentDefinitionUIDsParams
+	callArgs []*RepositoryMockGetRunArtifactParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockListComponentDefinitionUIDsExpectation specifies expectation struct of the Repository.ListComponentDefinitionUIDs
-type RepositoryMockListComponentDefinitionUIDsExpectation struct {
+// RepositoryMockGetRunArtifactExpectation specifies expectation struct of the Repository.GetRunArtifact
+type RepositoryMockGetRunArtifactExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockListComponentDefinitionUIDsParams
-	paramPtrs          *RepositoryMockListComponentDefinitionUIDsParamPtrs
-	expectationOrigins RepositoryMockListComponentDefinitionUIDsExpectationOrigins
-	results            *RepositoryMockListComponentDefinitionUIDsResults
+	params             *RepositoryMockGetRunArtifactParams
+	paramPtrs          *RepositoryMockGetRunArtifactParamPtrs
+	expectationOrigins RepositoryMockGetRunArtifactExpectationOrigins
+	results            *RepositoryMockGetRunArtifactResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockListComponentDefinitionUIDsParams contains parameters of the Repository.ListComponentDefinitionUIDs
-type RepositoryMockListComponentDefinitionUIDsParams struct {
-	ctx context.Context
-	l1  mm_repository.ListComponentDefinitionsParams
+// RepositoryMockGetRunArtifactParams contains parameters of the Repository.GetRunArtifact
+type RepositoryMockGetRunArtifactParams struct {
+	ctx                context.Context
+	pipelineTriggerUID uuid.UUID
+	artifactUID        uuid.UUID
 }
 
-// RepositoryMockListComponentDefinitionUIDsParamPtrs contains pointers to parameters of the Repository.ListComponentDefinitionUIDs
-type RepositoryMockListComponentDefinitionUIDsParamPtrs struct {
-	ctx *context.Context
-	l1  *mm_repository.ListComponentDefinitionsParams
+// RepositoryMockGetRunArtifactParamPtrs contains pointers to parameters of the Repository.GetRunArtifact
+type RepositoryMockGetRunArtifactParamPtrs struct {
+	ctx                *context.Context
+	pipelineTriggerUID *uuid.UUID
+	artifactUID        *uuid.UUID
 }
 
-// RepositoryMockListComponentDefinitionUIDsResults contains results of the Repository.ListComponentDefinitionUIDs
-type RepositoryMockListComponentDefinitionUIDsResults struct {
-	uids      []*datamodel.ComponentDefinition
-	totalSize int64
-	err       error
+// RepositoryMockGetRunArtifactResults contains results of the Repository.GetRunArtifact
+type RepositoryMockGetRunArtifactResults struct {
+	rp1 *datamodel.RunArtifact
+	err error
 }
 
-// RepositoryMockListComponentDefinitionUIDsOrigins contains origins of expectations of the Repository.ListComponentDefinitionUIDs
-type RepositoryMockListComponentDefinitionUIDsExpectationOrigins struct {
-	origin    string
-	originCtx string
-	originL1  string
+// RepositoryMockGetRunArtifactOrigins contains origins of expectations of the Repository.GetRunArtifact
+type RepositoryMockGetRunArtifactExpectationOrigins struct {
+	origin                   string
+	originCtx                string
+	originPipelineTriggerUID string
+	originArtifactUID        string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -11348,54 +14528,426 @@ type RepositoryMockListComponentDefinitionUIDsExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmListComponentDefinitionUIDs *mRepositoryMockListComponentDefinitionUIDs) Optional() *mRepositoryMockListComponentDefinitionUIDs {
-	mmListComponentDefinitionUIDs.optional = true
-	return mmListComponentDefinitionUIDs
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) Optional() *mRepositoryMockGetRunArtifact {
+	mmGetRunArtifact.optional = true
+	return mmGetRunArtifact
 }
 
-// Expect sets up expected params for Repository.ListComponentDefinitionUIDs
-func (mmListComponentDefinitionUIDs *mRepositoryMockListComponentDefinitionUIDs) Expect(ctx context.Context, l1 mm_repository.ListComponentDefinitionsParams) *mRepositoryMockListComponentDefinitionUIDs {
-	if mmListComponentDefinitionUIDs.mock.funcListComponentDefinitionUIDs != nil {
-		mmListComponentDefinitionUIDs.mock.t.Fatalf("RepositoryMock.ListComponentDefinitionUIDs mock is already set by Set")
+// Expect sets up expected params for Repository.GetRunArtifact
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) Expect(ctx context.Context, pipelineTriggerUID uuid.UUID, artifactUID uuid.UUID) *mRepositoryMockGetRunArtifact {
+	if mmGetRunArtifact.mock.funcGetRunArtifact != nil {
+		mmGetRunArtifact.mock.t.Fatalf("RepositoryMock.GetRunArtifact mock is already set by Set")
 	}
 
-	if mmListComponentDefinitionUIDs.defaultExpectation == nil {
-		mmListComponentDefinitionUIDs.defaultExpectation = &RepositoryMockListComponentDefinitionUIDsExpectation{}
+	if mmGetRunArtifact.defaultExpectation == nil {
+		mmGetRunArtifact.defaultExpectation = &RepositoryMockGetRunArtifactExpectation{}
 	}
 
-	if mmListComponentDefinitionUIDs.defaultExpectation.paramPtrs != nil {
-		mmListComponentDefinitionUIDs.mock.t.Fatalf("RepositoryMock.ListComponentDefinitionUIDs mock is already set by ExpectParams functions")
+	if mmGetRunArtifact.defaultExpectation.paramPtrs != nil {
+		mmGetRunArtifact.mock.t.Fatalf("RepositoryMock.GetRunArtifact mock is already set by ExpectParams functions")
 	}
 
-	mmListComponentDefinitionUIDs.defaultExpectation.params = &RepositoryMockListComponentDefinitionUIDsParams{ctx, l1}
-	mmListComponentDefinitionUIDs.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmListComponentDefinitionUIDs.expectations {
-		if minimock.Equal(e.params, mmListComponentDefinitionUIDs.defaultExpectation.params) {
-			mmListComponentDefinitionUIDs.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListComponentDefinitionUIDs.defaultExpectation.params)
+	mmGetRunArtifact.defaultExpectation.params = &RepositoryMockGetRunArtifactParams{ctx, pipelineTriggerUID, artifactUID}
+	mmGetRunArtifact.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetRunArtifact.expectations {
+		if minimock.Equal(e.params, mmGetRunArtifact.defaultExpectation.params) {
+			mmGetRunArtifact.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetRunArtifact.defaultExpectation.params)
 		}
 	}
 
-	return mmListComponentDefinitionUIDs
+	return mmGetRunArtifact
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Repository.ListComponentDefinitionUIDs
-func (mmListComponentDefinitionUIDs *mRepositoryMockListComponentDefinitionUIDs) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListComponentDefinitionUIDs {
-	if mmListComponentDefinitionUIDs.mock.funcListComponentDefinitionUIDs != nil {
-		mmListComponentDefinitionUIDs.mock.t.Fatalf("RepositoryMock.ListComponentDefinitionUIDs mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetRunArtifact
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetRunArtifact {
+	if mmGetRunArtifact.mock.funcGetRunArtifact != nil {
+		mmGetRunArtifact.mock.t.Fatalf("RepositoryMock.GetRunArtifact mock is already set by Set")
 	}
 
-	if mmListComponentDefinitionUIDs.defaultExpectation == nil {
-		mmListComponentDefinitionUIDs.defaultExpectation = &RepositoryMockListComponentDefinitionUIDsExpectation{}
+	if mmGetRunArtifact.defaultExpectation == nil {
+		mmGetRunArtifact.defaultExpectation = &RepositoryMockGetRunArtifactExpectation{}
 	}
 
-	if mmListComponentDefinitionUIDs.defaultExpectation.params != nil {
-		mmListComponentDefinitionUIDs.mock.t.Fatalf("RepositoryMock.ListComponentDefinitionUIDs mock is already set by Expect")
+	if mmGetRunArtifact.defaultExpectation.params != nil {
+		mmGetRunArtifact.mock.t.Fatalf("RepositoryMock.GetRunArtifact mock is already set by Expect")
 	}
 
-	if mmListComponentDefinitionUIDs.defaultExpectation.paramPtrs == nil {
-		mmListComponentDefinitionUIDs.defaultExpectation.paramPtrs = &RepositoryMockListComponentDefinitionUIDsParamPtrs{}
+	if mmGetRunArtifact.defaultExpectation.paramPtrs == nil {
+		mmGetRunArtifact.defaultExpectation.paramPtrs = &RepositoryMockGetRunArtifactParamPtrs{}
 	}
-	mmListComponentDefinitionUIDs.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetRunArtifact.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetRunArtifact.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetRunArtifact
+}
+
+// ExpectPipelineTriggerUIDParam2 sets up expected param pipelineTriggerUID for Repository.GetRunArtifact
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) ExpectPipelineTriggerUIDParam2(pipelineTriggerUID uuid.UUID) *mRepositoryMockGetRunArtifact {
+	if mmGetRunArtifact.mock.funcGetRunArtifact != nil {
+		mmGetRunArtifact.mock.t.Fatalf("RepositoryMock.GetRunArtifact mock is already set by Set")
+	}
+
+	if mmGetRunArtifact.defaultExpectation == nil {
+		mmGetRunArtifact.defaultExpectation = &RepositoryMockGetRunArtifactExpectation{}
+	}
+
+	if mmGetRunArtifact.defaultExpectation.params != nil {
+		mmGetRunArtifact.mock.t.Fatalf("RepositoryMock.GetRunArtifact mock is already set by Expect")
+	}
+
+	if mmGetRunArtifact.defaultExpectation.paramPtrs == nil {
+		mmGetRunArtifact.defaultExpectation.paramPtrs = &RepositoryMockGetRunArtifactParamPtrs{}
+	}
+	mmGetRunArtifact.defaultExpectation.paramPtrs.pipelineTriggerUID = &pipelineTriggerUID
+	mmGetRunArtifact.defaultExpectation.expectationOrigins.originPipelineTriggerUID = minimock.CallerInfo(1)
+
+	return mmGetRunArtifact
+}
+
+// ExpectArtifactUIDParam3 sets up expected param artifactUID for Repository.GetRunArtifact
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) ExpectArtifactUIDParam3(artifactUID uuid.UUID) *mRepositoryMockGetRunArtifact {
+	if mmGetRunArtifact.mock.funcGetRunArtifact != nil {
+		mmGetRunArtifact.mock.t.Fatalf("RepositoryMock.GetRunArtifact mock is already set by Set")
+	}
+
+	if mmGetRunArtifact.defaultExpectation == nil {
+		mmGetRunArtifact.defaultExpectation = &RepositoryMockGetRunArtifactExpectation{}
+	}
+
+	if mmGetRunArtifact.defaultExpectation.params != nil {
+		mmGetRunArtifact.mock.t.Fatalf("RepositoryMock.GetRunArtifact mock is already set by Expect")
+	}
+
+	if mmGetRunArtifact.defaultExpectation.paramPtrs == nil {
+		mmGetRunArtifact.defaultExpectation.paramPtrs = &RepositoryMockGetRunArtifactParamPtrs{}
+	}
+	mmGetRunArtifact.defaultExpectation.paramPtrs.artifactUID = &artifactUID
+	mmGetRunArtifact.defaultExpectation.expectationOrigins.originArtifactUID = minimock.CallerInfo(1)
+
+	return mmGetRunArtifact
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetRunArtifact
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) Inspect(f func(ctx context.Context, pipelineTriggerUID uuid.UUID, artifactUID uuid.UUID)) *mRepositoryMockGetRunArtifact {
+	if mmGetRunArtifact.mock.inspectFuncGetRunArtifact != nil {
+		mmGetRunArtifact.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetRunArtifact")
+	}
+
+	mmGetRunArtifact.mock.inspectFuncGetRunArtifact = f
+
+	return mmGetRunArtifact
+}
+
+// Return sets up results that will be returned by Repository.GetRunArtifact
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) Return(rp1 *datamodel.RunArtifact, err error) *RepositoryMock {
+	if mmGetRunArtifact.mock.funcGetRunArtifact != nil {
+		mmGetRunArtifact.mock.t.Fatalf("RepositoryMock.GetRunArtifact mock is already set by Set")
+	}
+
+	if mmGetRunArtifact.defaultExpectation == nil {
+		mmGetRunArtifact.defaultExpectation = &RepositoryMockGetRunArtifactExpectation{mock: mmGetRunArtifact.mock}
+	}
+	mmGetRunArtifact.defaultExpectation.results = &RepositoryMockGetRunArtifactResults{rp1, err}
+	mmGetRunArtifact.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetRunArtifact.mock
+}
+
+// Set uses given function f to mock the Repository.GetRunArtifact method
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) Set(f func(ctx context.Context, pipelineTriggerUID uuid.UUID, artifactUID uuid.UUID) (rp1 *datamodel.RunArtifact, err error)) *RepositoryMock {
+	if mmGetRunArtifact.defaultExpectation != nil {
+		mmGetRunArtifact.mock.t.Fatalf("Default expectation is already set for the Repository.GetRunArtifact method")
+	}
+
+	if len(mmGetRunArtifact.expectations) > 0 {
+		mmGetRunArtifact.mock.t.Fatalf("Some expectations are already set for the Repository.GetRunArtifact method")
+	}
+
+	mmGetRunArtifact.mock.funcGetRunArtifact = f
+	mmGetRunArtifact.mock.funcGetRunArtifactOrigin = minimock.CallerInfo(1)
+	return mmGetRunArtifact.mock
+}
+
+// When sets expectation for the Repository.GetRunArtifact which will trigger the result defined by the following
+// Then helper
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) When(ctx context.Context, pipelineTriggerUID uuid.UUID, artifactUID uuid.UUID) *RepositoryMockGetRunArtifactExpectation {
+	if mmGetRunArtifact.mock.funcGetRunArtifact != nil {
+		mmGetRunArtifact.mock.t.Fatalf("RepositoryMock.GetRunArtifact mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetRunArtifactExpectation{
+		mock:               mmGetRunArtifact.mock,
+		params:             &RepositoryMockGetRunArtifactParams{ctx, pipelineTriggerUID, artifactUID},
+		expectationOrigins: RepositoryMockGetRunArtifactExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetRunArtifact.expectations = append(mmGetRunArtifact.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetRunArtifact return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetRunArtifactExpectation) Then(rp1 *datamodel.RunArtifact, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetRunArtifactResults{rp1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetRunArtifact should be invoked
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) Times(n uint64) *mRepositoryMockGetRunArtifact {
+	if n == 0 {
+		mmGetRunArtifact.mock.t.Fatalf("Times of RepositoryMock.GetRunArtifact mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetRunArtifact.expectedInvocations, n)
+	mmGetRunArtifact.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetRunArtifact
+}
+
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) invocationsDone() bool {
+	if len(mmGetRunArtifact.expectations) == 0 && mmGetRunArtifact.defaultExpectation == nil && mmGetRunArtifact.mock.funcGetRunArtifact == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetRunArtifact.mock.afterGetRunArtifactCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetRunArtifact.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetRunArtifact implements mm_repository.Repository
+func (mmGetRunArtifact *RepositoryMock) GetRunArtifact(ctx context.Context, pipelineTriggerUID uuid.UUID, artifactUID uuid.UUID) (rp1 *datamodel.RunArtifact, err error) {
+	mm_atomic.AddUint64(&mmGetRunArtifact.beforeGetRunArtifactCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetRunArtifact.afterGetRunArtifactCounter, 1)
+
+	mmGetRunArtifact.t.Helper()
+
+	if mmGetRunArtifact.inspectFuncGetRunArtifact != nil {
+		mmGetRunArtifact.inspectFuncGetRunArtifact(ctx, pipelineTriggerUID, artifactUID)
+	}
+
+	mm_params := RepositoryMockGetRunArtifactParams{ctx, pipelineTriggerUID, artifactUID}
+
+	// Record call args
+	mmGetRunArtifact.GetRunArtifactMock.mutex.Lock()
+	mmGetRunArtifact.GetRunArtifactMock.callArgs = append(mmGetRunArtifact.GetRunArtifactMock.callArgs, &mm_params)
+	mmGetRunArtifact.GetRunArtifactMock.mutex.Unlock()
+
+	for _, e := range mmGetRunArtifact.GetRunArtifactMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.rp1, e.results.err
+		}
+	}
+
+	if mmGetRunArtifact.GetRunArtifactMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetRunArtifact.GetRunArtifactMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetRunArtifact.GetRunArtifactMock.defaultExpectation.params
+		mm_want_ptrs := mmGetRunArtifact.GetRunArtifactMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetRunArtifactParams{ctx, pipelineTriggerUID, artifactUID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetRunArtifact.t.Errorf("RepositoryMock.GetRunArtifact got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetRunArtifact.GetRunArtifactMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.pipelineTriggerUID != nil && !minimock.Equal(*mm_want_ptrs.pipelineTriggerUID, mm_got.pipelineTriggerUID) {
+				mmGetRunArtifact.t.Errorf("RepositoryMock.GetRunArtifact got unexpected parameter pipelineTriggerUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetRunArtifact.GetRunArtifactMock.defaultExpectation.expectationOrigins.originPipelineTriggerUID, *mm_want_ptrs.pipelineTriggerUID, mm_got.pipelineTriggerUID, minimock.Diff(*mm_want_ptrs.pipelineTriggerUID, mm_got.pipelineTriggerUID))
+			}
+
+			if mm_want_ptrs.artifactUID != nil && !minimock.Equal(*mm_want_ptrs.artifactUID, mm_got.artifactUID) {
+				mmGetRunArtifact.t.Errorf("RepositoryMock.GetRunArtifact got unexpected parameter artifactUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetRunArtifact.GetRunArtifactMock.defaultExpectation.expectationOrigins.originArtifactUID, *mm_want_ptrs.artifactUID, mm_got.artifactUID, minimock.Diff(*mm_want_ptrs.artifactUID, mm_got.artifactUID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetRunArtifact.t.Errorf("RepositoryMock.GetRunArtifact got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetRunArtifact.GetRunArtifactMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetRunArtifact.GetRunArtifactMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetRunArtifact.t.Fatal("No results are set for the RepositoryMock.GetRunArtifact")
+		}
+		return (*mm_results).rp1, (*mm_results).err
+	}
+	if mmGetRunArtifact.funcGetRunArtifact != nil {
+		return mmGetRunArtifact.funcGetRunArtifact(ctx, pipelineTriggerUID, artifactUID)
+	}
+	mmGetRunArtifact.t.Fatalf("Unexpected call to RepositoryMock.GetRunArtifact. %v %v %v", ctx, pipelineTriggerUID, artifactUID)
+	return
+}
+
+// GetRunArtifactAfterCounter returns a count of finished RepositoryMock.GetRunArtifact invocations
+func (mmGetRunArtifact *RepositoryMock) GetRunArtifactAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetRunArtifact.afterGetRunArtifactCounter)
+}
+
+// GetRunArtifactBeforeCounter returns a count of RepositoryMock.GetRunArtifact invocations
+func (mmGetRunArtifact *RepositoryMock) GetRunArtifactBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetRunArtifact.beforeGetRunArtifactCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetRunArtifact.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetRunArtifact *mRepositoryMockGetRunArtifact) Calls() []*RepositoryMockGetRunArtifactParams {
+	mmGetRunArtifact.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetRunArtifactParams, len(mmGetRunArtifact.callArgs))
+	copy(argCopy, mmGetRunArtifact.callArgs)
+
+	mmGetRunArtifact.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetRunArtifactDone returns true if the count of the GetRunArtifact invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetRunArtifactDone() bool {
+	if m.GetRunArtifactMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetRunArtifactMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetRunArtifactMock.invocationsDone()
+}
+
+// MinimockGetRunArtifactInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetRunArtifactInspect() {
+	for _, e := range m.GetRunArtifactMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetRunArtifact at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetRunArtifactCounter := mm_atomic.LoadUint64(&m.afterGetRunArtifactCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetRunArtifactMock.defaultExpectation != nil && afterGetRunArtifactCounter < 1 {
+		if m.GetRunArtifactMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.GetRunArtifact at\n%s", m.GetRunArtifactMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetRunArtifact at\n%s with params: %#v", m.GetRunArtifactMock.defaultExpectation.expectationOrigins.origin, *m.GetRunArtifactMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetRunArtifact != nil && afterGetRunArtifactCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.GetRunArtifact at\n%s", m.funcGetRunArtifactOrigin)
+	}
+
+	if !m.GetRunArtifactMock.invocationsDone() && afterGetRunArtifactCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetRunArtifact at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetRunArtifactMock.expectedInvocations), m.GetRunArtifactMock.expectedInvocationsOrigin, afterGetRunArtifactCounter)
+	}
+}
+
+type mRepositoryMockListComponentDefinitionUIDs struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockListComponentDefinitionUIDsExpectation
+	expectations       []*RepositoryMockListComponentDefinitionUIDsExpectation
+
+	callArgs []*RepositoryMockListComponentDefinitionUIDsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockListComponentDefinitionUIDsExpectation specifies expectation struct of the Repository.ListComponentDefinitionUIDs
+type RepositoryMockListComponentDefinitionUIDsExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockListComponentDefinitionUIDsParams
+	paramPtrs          *RepositoryMockListComponentDefinitionUIDsParamPtrs
+	expectationOrigins RepositoryMockListComponentDefinitionUIDsExpectationOrigins
+	results            *RepositoryMockListComponentDefinitionUIDsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockListComponentDefinitionUIDsParams contains parameters of the Repository.ListComponentDefinitionUIDs
+type RepositoryMockListComponentDefinitionUIDsParams struct {
+	ctx context.Context
+	l1  mm_repository.ListComponentDefinitionsParams
+}
+
+// RepositoryMockListComponentDefinitionUIDsParamPtrs contains pointers to parameters of the Repository.ListComponentDefinitionUIDs
+type RepositoryMockListComponentDefinitionUIDsParamPtrs struct {
+	ctx *context.Context
+	l1  *mm_repository.ListComponentDefinitionsParams
+}
+
+// RepositoryMockListComponentDefinitionUIDsResults contains results of the Repository.ListComponentDefinitionUIDs
+type RepositoryMockListComponentDefinitionUIDsResults struct {
+	uids      []*datamodel.ComponentDefinition
+	totalSize int64
+	err       error
+}
+
+// RepositoryMockListComponentDefinitionUIDsOrigins contains origins of expectations of the Repository.ListComponentDefinitionUIDs
+type RepositoryMockListComponentDefinitionUIDsExpectationOrigins struct {
+	origin    string
+	originCtx string
+	originL1  string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmListComponentDefinitionUIDs *mRepositoryMockListComponentDefinitionUIDs) Optional() *mRepositoryMockListComponentDefinitionUIDs {
+	mmListComponentDefinitionUIDs.optional = true
+	return mmListComponentDefinitionUIDs
+}
+
+// Expect sets up expected params for Repository.ListComponentDefinitionUIDs
+func (mmListComponentDefinitionUIDs *mRepositoryMockListComponentDefinitionUIDs) Expect(ctx context.Context, l1 mm_repository.ListComponentDefinitionsParams) *mRepositoryMockListComponentDefinitionUIDs {
+	if mmListComponentDefinitionUIDs.mock.funcListComponentDefinitionUIDs != nil {
+		mmListComponentDefinitionUIDs.mock.t.Fatalf("RepositoryMock.ListComponentDefinitionUIDs mock is already set by Set")
+	}
+
+	if mmListComponentDefinitionUIDs.defaultExpectation == nil {
+		mmListComponentDefinitionUIDs.defaultExpectation = &RepositoryMockListComponentDefinitionUIDsExpectation{}
+	}
+
+	if mmListComponentDefinitionUIDs.defaultExpectation.paramPtrs != nil {
+		mmListComponentDefinitionUIDs.mock.t.Fatalf("RepositoryMock.ListComponentDefinitionUIDs mock is already set by ExpectParams functions")
+	}
+
+	mmListComponentDefinitionUIDs.defaultExpectation.params = &RepositoryMockListComponentDefinitionUIDsParams{ctx, l1}
+	mmListComponentDefinitionUIDs.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmListComponentDefinitionUIDs.expectations {
+		if minimock.Equal(e.params, mmListComponentDefinitionUIDs.defaultExpectation.params) {
+			mmListComponentDefinitionUIDs.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListComponentDefinitionUIDs.defaultExpectation.params)
+		}
+	}
+
+	return mmListComponentDefinitionUIDs
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ListComponentDefinitionUIDs
+func (mmListComponentDefinitionUIDs *mRepositoryMockListComponentDefinitionUIDs) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListComponentDefinitionUIDs {
+	if mmListComponentDefinitionUIDs.mock.funcListComponentDefinitionUIDs != nil {
+		mmListComponentDefinitionUIDs.mock.t.Fatalf("RepositoryMock.ListComponentDefinitionUIDs mock is already set by Set")
+	}
+
+	if mmListComponentDefinitionUIDs.defaultExpectation == nil {
+		mmListComponentDefinitionUIDs.defaultExpectation = &RepositoryMockListComponentDefinitionUIDsExpectation{}
+	}
+
+	if mmListComponentDefinitionUIDs.defaultExpectation.params != nil {
+		mmListComponentDefinitionUIDs.mock.t.Fatalf("RepositoryMock.ListComponentDefinitionUIDs mock is already set by Expect")
+	}
+
+	if mmListComponentDefinitionUIDs.defaultExpectation.paramPtrs == nil {
+		mmListComponentDefinitionUIDs.defaultExpectation.paramPtrs = &RepositoryMockListComponentDefinitionUIDsParamPtrs{}
+	}
+	mmListComponentDefinitionUIDs.defaultExpectation.paramPtrs.ctx = &ctx
 	mmListComponentDefinitionUIDs.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
 	return mmListComponentDefinitionUIDs
@@ -11637,49 +15189,361 @@ func (m *RepositoryMock) MinimockListComponentDefinitionUIDsInspect() {
 	}
 }
 
-type mRepositoryMockListIntegrations struct {
+type mRepositoryMockListEventSourcesAdmin struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockListIntegrationsExpectation
-	expectations       []*RepositoryMockListIntegrationsExpectation
+	defaultExpectation *RepositoryMockListEventSourcesAdminExpectation
+	expectations       []*RepositoryMockListEventSourcesAdminExpectation
 
-	callArgs []*RepositoryMockListIntegrationsParams
+	callArgs []*RepositoryMockListEventSourcesAdminParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockListIntegrationsExpectation specifies expectation struct of the Repository.ListIntegrations
-type RepositoryMockListIntegrationsExpectation struct {
+// RepositoryMockListEventSourcesAdminExpectation specifies expectation struct of the Repository.ListEventSourcesAdmin
+type RepositoryMockListEventSourcesAdminExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockListIntegrationsParams
-	paramPtrs          *RepositoryMockListIntegrationsParamPtrs
-	expectationOrigins RepositoryMockListIntegrationsExpectationOrigins
-	results            *RepositoryMockListIntegrationsResults
+	params             *RepositoryMockListEventSourcesAdminParams
+	paramPtrs          *RepositoryMockListEventSourcesAdminParamPtrs
+	expectationOrigins RepositoryMockListEventSourcesAdminExpectationOrigins
+	results            *RepositoryMockListEventSourcesAdminResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockListIntegrationsParams contains parameters of the Repository.ListIntegrations
-type RepositoryMockListIntegrationsParams struct {
+// RepositoryMockListEventSourcesAdminParams contains parameters of the Repository.ListEventSourcesAdmin
+type RepositoryMockListEventSourcesAdminParams struct {
 	ctx context.Context
-	l1  mm_repository.ListIntegrationsParams
 }
 
-// RepositoryMockListIntegrationsParamPtrs contains pointers to parameters of the Repository.ListIntegrations
-type RepositoryMockListIntegrationsParamPtrs struct {
+// RepositoryMockListEventSourcesAdminParamPtrs contains pointers to parameters of the Repository.ListEventSourcesAdmin
+type RepositoryMockListEventSourcesAdminParamPtrs struct {
 	ctx *context.Context
-	l1  *mm_repository.ListIntegrationsParams
 }
 
-// RepositoryMockListIntegrationsResults contains results of the Repository.ListIntegrations
-type RepositoryMockListIntegrationsResults struct {
-	i1  mm_repository.IntegrationList
-	err error
+// RepositoryMockListEventSourcesAdminResults contains results of the Repository.ListEventSourcesAdmin
+type RepositoryMockListEventSourcesAdminResults struct {
+	epa1 []*datamodel.EventSource
+	err  error
 }
 
-// RepositoryMockListIntegrationsOrigins contains origins of expectations of the Repository.ListIntegrations
+// RepositoryMockListEventSourcesAdminOrigins contains origins of expectations of the Repository.ListEventSourcesAdmin
+type RepositoryMockListEventSourcesAdminExpectationOrigins struct {
+	origin    string
+	originCtx string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmListEventSourcesAdmin *mRepositoryMockListEventSourcesAdmin) Optional() *mRepositoryMockListEventSourcesAdmin {
+	mmListEventSourcesAdmin.optional = true
+	return mmListEventSourcesAdmin
+}
+
+// Expect sets up expected params for Repository.ListEventSourcesAdmin
+func (mmListEventSourcesAdmin *mRepositoryMockListEventSourcesAdmin) Expect(ctx context.Context) *mRepositoryMockListEventSourcesAdmin {
+	if mmListEventSourcesAdmin.mock.funcListEventSourcesAdmin != nil {
+		mmListEventSourcesAdmin.mock.t.Fatalf("RepositoryMock.ListEventSourcesAdmin mock is already set by Set")
+	}
+
+	if mmListEventSourcesAdmin.defaultExpectation == nil {
+		mmListEventSourcesAdmin.defaultExpectation = &RepositoryMockListEventSourcesAdminExpectation{}
+	}
+
+	if mmListEventSourcesAdmin.defaultExpectation.paramPtrs != nil {
+		mmListEventSourcesAdmin.mock.t.Fatalf("RepositoryMock.ListEventSourcesAdmin mock is already set by ExpectParams functions")
+	}
+
+	mmListEventSourcesAdmin.defaultExpectation.params = &RepositoryMockListEventSourcesAdminParams{ctx}
+	mmListEventSourcesAdmin.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmListEventSourcesAdmin.expectations {
+		if minimock.Equal(e.params, mmListEventSourcesAdmin.defaultExpectation.params) {
+			mmListEventSourcesAdmin.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListEventSourcesAdmin.defaultExpectation.params)
+		}
+	}
+
+	return mmListEventSourcesAdmin
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ListEventSourcesAdmin
+func (mmListEventSourcesAdmin *mRepositoryMockListEventSourcesAdmin) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListEventSourcesAdmin {
+	if mmListEventSourcesAdmin.mock.funcListEventSourcesAdmin != nil {
+		mmListEventSourcesAdmin.mock.t.Fatalf("RepositoryMock.ListEventSourcesAdmin mock is already set by Set")
+	}
+
+	if mmListEventSourcesAdmin.defaultExpectation == nil {
+		mmListEventSourcesAdmin.defaultExpectation = &RepositoryMockListEventSourcesAdminExpectation{}
+	}
+
+	if mmListEventSourcesAdmin.defaultExpectation.params != nil {
+		mmListEventSourcesAdmin.mock.t.Fatalf("RepositoryMock.ListEventSourcesAdmin mock is already set by Expect")
+	}
+
+	if mmListEventSourcesAdmin.defaultExpectation.paramPtrs == nil {
+		mmListEventSourcesAdmin.defaultExpectation.paramPtrs = &RepositoryMockListEventSourcesAdminParamPtrs{}
+	}
+	mmListEventSourcesAdmin.defaultExpectation.paramPtrs.ctx = &ctx
+	mmListEventSourcesAdmin.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmListEventSourcesAdmin
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.ListEventSourcesAdmin
+func (mmListEventSourcesAdmin *mRepositoryMockListEventSourcesAdmin) Inspect(f func(ctx context.Context)) *mRepositoryMockListEventSourcesAdmin {
+	if mmListEventSourcesAdmin.mock.inspectFuncListEventSourcesAdmin != nil {
+		mmListEventSourcesAdmin.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ListEventSourcesAdmin")
+	}
+
+	mmListEventSourcesAdmin.mock.inspectFuncListEventSourcesAdmin = f
+
+	return mmListEventSourcesAdmin
+}
+
+// Return sets up results that will be returned by Repository.ListEventSourcesAdmin
+func (mmListEventSourcesAdmin *mRepositoryMockListEventSourcesAdmin) Return(epa1 []*datamodel.EventSource, err error) *RepositoryMock {
+	if mmListEventSourcesAdmin.mock.funcListEventSourcesAdmin != nil {
+		mmListEventSourcesAdmin.mock.t.Fatalf("RepositoryMock.ListEventSourcesAdmin mock is already set by Set")
+	}
+
+	if mmListEventSourcesAdmin.defaultExpectation == nil {
+		mmListEventSourcesAdmin.defaultExpectation = &RepositoryMockListEventSourcesAdminExpectation{mock: mmListEventSourcesAdmin.mock}
+	}
+	mmListEventSourcesAdmin.defaultExpectation.results = &RepositoryMockListEventSourcesAdminResults{epa1, err}
+	mmListEventSourcesAdmin.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmListEventSourcesAdmin.mock
+}
+
+// Set uses given function f to mock the Repository.ListEventSourcesAdmin method
+func (mmListEventSourcesAdmin *mRepositoryMockListEventSourcesAdmin) Set(f func(ctx context.Context) (epa1 []*datamodel.EventSource, err error)) *RepositoryMock {
+	if mmListEventSourcesAdmin.defaultExpectation != nil {
+		mmListEventSourcesAdmin.mock.t.Fatalf("Default expectation is already set for the Repository.ListEventSourcesAdmin method")
+	}
+
+	if len(mmListEventSourcesAdmin.expectations) > 0 {
+		mmListEventSourcesAdmin.mock.t.Fatalf("Some expectations are already set for the Repository.ListEventSourcesAdmin method")
+	}
+
+	mmListEventSourcesAdmin.mock.funcListEventSourcesAdmin = f
+	mmListEventSourcesAdmin.mock.funcListEventSourcesAdminOrigin = minimock.CallerInfo(1)
+	return mmListEventSourcesAdmin.mock
+}
+
+// When sets expectation for the Repository.ListEventSourcesAdmin which will trigger the result defined by the following
+// Then helper
+func (mmListEventSourcesAdmin *mRepositoryMockListEventSourcesAdmin) When(ctx context.Context) *RepositoryMockListEventSourcesAdminExpectation {
+	if mmListEventSourcesAdmin.mock.funcListEventSourcesAdmin != nil {
+		mmListEventSourcesAdmin.mock.t.Fatalf("RepositoryMock.ListEventSourcesAdmin mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockListEventSourcesAdminExpectation{
+		mock:               mmListEventSourcesAdmin.mock,
+		params:             &RepositoryMockListEventSourcesAdminParams{ctx},
+		expectationOrigins: RepositoryMockListEventSourcesAdminExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmListEventSourcesAdmin.expectations = append(mmListEventSourcesAdmin.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.ListEventSourcesAdmin return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockListEventSourcesAdminExpectation) Then(epa1 []*datamodel.EventSource, err error) *RepositoryMock {
+	e.results = &RepositoryMockListEventSourcesAdminResults{epa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.ListEventSourcesAdmin should be invoked
+func (mmListEventSourcesAdmin *mRepositoryMockListEventSourcesAdmin) Times(n uint64) *mRepositoryMockListEventSourcesAdmin {
+	if n == 0 {
+		mmListEventSourcesAdmin.mock.t.Fatalf("Times of RepositoryMock.ListEventSourcesAdmin mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmListEventSourcesAdmin.expectedInvocations, n)
+	mmListEventSourcesAdmin.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmListEventSourcesAdmin
+}
+
+func (mmListEventSourcesAdmin *mRepositoryMockListEventSourcesAdmin) invocationsDone() bool {
+	if len(mmListEventSourcesAdmin.expectations) == 0 && mmListEventSourcesAdmin.defaultExpectation == nil && mmListEventSourcesAdmin.mock.funcListEventSourcesAdmin == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmListEventSourcesAdmin.mock.afterListEventSourcesAdminCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmListEventSourcesAdmin.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ListEventSourcesAdmin implements mm_repository.Repository
+func (mmListEventSourcesAdmin *RepositoryMock) ListEventSourcesAdmin(ctx context.Context) (epa1 []*datamodel.EventSource, err error) {
+	mm_atomic.AddUint64(&mmListEventSourcesAdmin.beforeListEventSourcesAdminCounter, 1)
+	defer mm_atomic.AddUint64(&mmListEventSourcesAdmin.afterListEventSourcesAdminCounter, 1)
+
+	mmListEventSourcesAdmin.t.Helper()
+
+	if mmListEventSourcesAdmin.inspectFuncListEventSourcesAdmin != nil {
+		mmListEventSourcesAdmin.inspectFuncListEventSourcesAdmin(ctx)
+	}
+
+	mm_params := RepositoryMockListEventSourcesAdminParams{ctx}
+
+	// Record call args
+	mmListEventSourcesAdmin.ListEventSourcesAdminMock.mutex.Lock()
+	mmListEventSourcesAdmin.ListEventSourcesAdminMock.callArgs = append(mmListEventSourcesAdmin.ListEventSourcesAdminMock.callArgs, &mm_params)
+	mmListEventSourcesAdmin.ListEventSourcesAdminMock.mutex.Unlock()
+
+	for _, e := range mmListEventSourcesAdmin.ListEventSourcesAdminMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.epa1, e.results.err
+		}
+	}
+
+	if mmListEventSourcesAdmin.ListEventSourcesAdminMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmListEventSourcesAdmin.ListEventSourcesAdminMock.defaultExpectation.Counter, 1)
+		mm_want := mmListEventSourcesAdmin.ListEventSourcesAdminMock.defaultExpectation.params
+		mm_want_ptrs := mmListEventSourcesAdmin.ListEventSourcesAdminMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockListEventSourcesAdminParams{ctx}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmListEventSourcesAdmin.t.Errorf("RepositoryMock.ListEventSourcesAdmin got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListEventSourcesAdmin.ListEventSourcesAdminMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmListEventSourcesAdmin.t.Errorf("RepositoryMock.ListEventSourcesAdmin got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmListEventSourcesAdmin.ListEventSourcesAdminMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmListEventSourcesAdmin.ListEventSourcesAdminMock.defaultExpectation.results
+		if mm_results == nil {
+			mmListEventSourcesAdmin.t.Fatal("No results are set for the RepositoryMock.ListEventSourcesAdmin")
+		}
+		return (*mm_results).epa1, (*mm_results).err
+	}
+	if mmListEventSourcesAdmin.funcListEventSourcesAdmin != nil {
+		return mmListEventSourcesAdmin.funcListEventSourcesAdmin(ctx)
+	}
+	mmListEventSourcesAdmin.t.Fatalf("Unexpected call to RepositoryMock.ListEventSourcesAdmin. %v", ctx)
+	return
+}
+
+// ListEventSourcesAdminAfterCounter returns a count of finished RepositoryMock.ListEventSourcesAdmin invocations
+func (mmListEventSourcesAdmin *RepositoryMock) ListEventSourcesAdminAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListEventSourcesAdmin.afterListEventSourcesAdminCounter)
+}
+
+// ListEventSourcesAdminBeforeCounter returns a count of RepositoryMock.ListEventSourcesAdmin invocations
+func (mmListEventSourcesAdmin *RepositoryMock) ListEventSourcesAdminBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListEventSourcesAdmin.beforeListEventSourcesAdminCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.ListEventSourcesAdmin.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmListEventSourcesAdmin *mRepositoryMockListEventSourcesAdmin) Calls() []*RepositoryMockListEventSourcesAdminParams {
+	mmListEventSourcesAdmin.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockListEventSourcesAdminParams, len(mmListEventSourcesAdmin.callArgs))
+	copy(argCopy, mmListEventSourcesAdmin.callArgs)
+
+	mmListEventSourcesAdmin.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListEventSourcesAdminDone returns true if the count of the ListEventSourcesAdmin invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockListEventSourcesAdminDone() bool {
+	if m.ListEventSourcesAdminMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListEventSourcesAdminMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListEventSourcesAdminMock.invocationsDone()
+}
+
+// MinimockListEventSourcesAdminInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockListEventSourcesAdminInspect() {
+	for _, e := range m.ListEventSourcesAdminMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.ListEventSourcesAdmin at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterListEventSourcesAdminCounter := mm_atomic.LoadUint64(&m.afterListEventSourcesAdminCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListEventSourcesAdminMock.defaultExpectation != nil && afterListEventSourcesAdminCounter < 1 {
+		if m.ListEventSourcesAdminMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.ListEventSourcesAdmin at\n%s", m.ListEventSourcesAdminMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.ListEventSourcesAdmin at\n%s with params: %#v", m.ListEventSourcesAdminMock.defaultExpectation.expectationOrigins.origin, *m.ListEventSourcesAdminMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcListEventSourcesAdmin != nil && afterListEventSourcesAdminCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ListEventSourcesAdmin at\n%s", m.funcListEventSourcesAdminOrigin)
+	}
+
+	if !m.ListEventSourcesAdminMock.invocationsDone() && afterListEventSourcesAdminCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ListEventSourcesAdmin at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListEventSourcesAdminMock.expectedInvocations), m.ListEventSourcesAdminMock.expectedInvocationsOrigin, afterListEventSourcesAdminCounter)
+	}
+}
+
+type mRepositoryMockListIntegrations struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockListIntegrationsExpectation
+	expectations       []*RepositoryMockListIntegrationsExpectation
+
+	callArgs []*RepositoryMockListIntegrationsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockListIntegrationsExpectation specifies expectation struct of the Repository.ListIntegrations
+type RepositoryMockListIntegrationsExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockListIntegrationsParams
+	paramPtrs          *RepositoryMockListIntegrationsParamPtrs
+	expectationOrigins RepositoryMockListIntegrationsExpectationOrigins
+	results            *RepositoryMockListIntegrationsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockListIntegrationsParams contains parameters of the Repository.ListIntegrations
+type RepositoryMockListIntegrationsParams struct {
+	ctx context.Context
+	l1  mm_repository.ListIntegrationsParams
+}
+
+// RepositoryMockListIntegrationsParamPtrs contains pointers to parameters of the Repository.ListIntegrations
+type RepositoryMockListIntegrationsParamPtrs struct {
+	ctx *context.Context
+	l1  *mm_repository.ListIntegrationsParams
+}
+
+// RepositoryMockListIntegrationsResults contains results of the Repository.ListIntegrations
+type RepositoryMockListIntegrationsResults struct {
+	i1  mm_repository.IntegrationList
+	err error
+}
+
+// RepositoryMockListIntegrationsOrigins contains origins of expectations of the Repository.ListIntegrations
 type RepositoryMockListIntegrationsExpectationOrigins struct {
 	origin    string
 	originCtx string
@@ -12323,76 +16187,53 @@ func (m *RepositoryMock) MinimockListNamespaceConnectionsInspect() {
 	}
 }
 
-type mRepositoryMockListNamespacePipelineReleases struct {
+type mRepositoryMockListNamespaceEventSources struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockListNamespacePipelineReleasesExpectation
-	expectations       []*RepositoryMockListNamespacePipelineReleasesExpectation
+	defaultExpectation *RepositoryMockListNamespaceEventSourcesExpectation
+	expectations       []*RepositoryMockListNamespaceEventSourcesExpectation
 
-	callArgs []*RepositoryMockListNamespacePipelineReleasesParams
+	callArgs []*RepositoryMockListNamespaceEventSourcesParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockListNamespacePipelineReleasesExpectation specifies expectation struct of the Repository.ListNamespacePipelineReleases
-type RepositoryMockListNamespacePipelineReleasesExpectation struct {
+// RepositoryMockListNamespaceEventSourcesExpectation specifies expectation struct of the Repository.ListNamespaceEventSources
+type RepositoryMockListNamespaceEventSourcesExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockListNamespacePipelineReleasesParams
-	paramPtrs          *RepositoryMockListNamespacePipelineReleasesParamPtrs
-	expectationOrigins RepositoryMockListNamespacePipelineReleasesExpectationOrigins
-	results            *RepositoryMockListNamespacePipelineReleasesResults
+	params             *RepositoryMockListNamespaceEventSourcesParams
+	paramPtrs          *RepositoryMockListNamespaceEventSourcesParamPtrs
+	expectationOrigins RepositoryMockListNamespaceEventSourcesExpectationOrigins
+	results            *RepositoryMockListNamespaceEventSourcesResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockListNamespacePipelineReleasesParams contains parameters of the Repository.ListNamespacePipelineReleases
-type RepositoryMockListNamespacePipelineReleasesParams struct {
-	ctx            context.Context
-	ownerPermalink string
-	pipelineUID    uuid.UUID
-	pageSize       int64
-	pageToken      string
-	isBasicView    bool
-	filter         filtering.Filter
-	showDeleted    bool
-	returnCount    bool
+// RepositoryMockListNamespaceEventSourcesParams contains parameters of the Repository.ListNamespaceEventSources
+type RepositoryMockListNamespaceEventSourcesParams struct {
+	ctx   context.Context
+	nsUID uuid.UUID
 }
 
-// RepositoryMockListNamespacePipelineReleasesParamPtrs contains pointers to parameters of the Repository.ListNamespacePipelineReleases
-type RepositoryMockListNamespacePipelineReleasesParamPtrs struct {
-	ctx            *context.Context
-	ownerPermalink *string
-	pipelineUID    *uuid.UUID
-	pageSize       *int64
-	pageToken      *string
-	isBasicView    *bool
-	filter         *filtering.Filter
-	showDeleted    *bool
-	returnCount    *bool
+// RepositoryMockListNamespaceEventSourcesParamPtrs contains pointers to parameters of the Repository.ListNamespaceEventSources
+type RepositoryMockListNamespaceEventSourcesParamPtrs struct {
+	ctx   *context.Context
+	nsUID *uuid.UUID
 }
 
-// RepositoryMockListNamespacePipelineReleasesResults contains results of the Repository.ListNamespacePipelineReleases
-type RepositoryMockListNamespacePipelineReleasesResults struct {
-	ppa1 []*datamodel.PipelineRelease
-	i1   int64
-	s1   string
+// RepositoryMockListNamespaceEventSourcesResults contains results of the Repository.ListNamespaceEventSources
+type RepositoryMockListNamespaceEventSourcesResults struct {
+	epa1 []*datamodel.EventSource
 	err  error
 }
 
-// RepositoryMockListNamespacePipelineReleasesOrigins contains origins of expectations of the Repository.ListNamespacePipelineReleases
-type RepositoryMockListNamespacePipelineReleasesExpectationOrigins struct {
-	origin               string
-	originCtx            string
-	originOwnerPermalink string
-	originPipelineUID    string
-	originPageSize       string
-	originPageToken      string
-	originIsBasicView    string
-	originFilter         string
-	originShowDeleted    string
-	originReturnCount    string
+// RepositoryMockListNamespaceEventSourcesOrigins contains origins of expectations of the Repository.ListNamespaceEventSources
+type RepositoryMockListNamespaceEventSourcesExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originNsUID string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -12400,43 +16241,409 @@ type RepositoryMockListNamespacePipelineReleasesExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmListNamespacePipelineReleases *mRepositoryMockListNamespacePipelineReleases) Optional() *mRepositoryMockListNamespacePipelineReleases {
-	mmListNamespacePipelineReleases.optional = true
-	return mmListNamespacePipelineReleases
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) Optional() *mRepositoryMockListNamespaceEventSources {
+	mmListNamespaceEventSources.optional = true
+	return mmListNamespaceEventSources
 }
 
-// Expect sets up expected params for Repository.ListNamespacePipelineReleases
-func (mmListNamespacePipelineReleases *mRepositoryMockListNamespacePipelineReleases) Expect(ctx context.Context, ownerPermalink string, pipelineUID uuid.UUID, pageSize int64, pageToken string, isBasicView bool, filter filtering.Filter, showDeleted bool, returnCount bool) *mRepositoryMockListNamespacePipelineReleases {
-	if mmListNamespacePipelineReleases.mock.funcListNamespacePipelineReleases != nil {
-		mmListNamespacePipelineReleases.mock.t.Fatalf("RepositoryMock.ListNamespacePipelineReleases mock is already set by Set")
+// Expect sets up expected params for Repository.ListNamespaceEventSources
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) Expect(ctx context.Context, nsUID uuid.UUID) *mRepositoryMockListNamespaceEventSources {
+	if mmListNamespaceEventSources.mock.funcListNamespaceEventSources != nil {
+		mmListNamespaceEventSources.mock.t.Fatalf("RepositoryMock.ListNamespaceEventSources mock is already set by Set")
 	}
 
-	if mmListNamespacePipelineReleases.defaultExpectation == nil {
-		mmListNamespacePipelineReleases.defaultExpectation = &RepositoryMockListNamespacePipelineReleasesExpectation{}
+	if mmListNamespaceEventSources.defaultExpectation == nil {
+		mmListNamespaceEventSources.defaultExpectation = &RepositoryMockListNamespaceEventSourcesExpectation{}
 	}
 
-	if mmListNamespacePipelineReleases.defaultExpectation.paramPtrs != nil {
-		mmListNamespacePipelineReleases.mock.t.Fatalf("RepositoryMock.ListNamespacePipelineReleases mock is already set by ExpectParams functions")
+	if mmListNamespaceEventSources.defaultExpectation.paramPtrs != nil {
+		mmListNamespaceEventSources.mock.t.Fatalf("RepositoryMock.ListNamespaceEventSources mock is already set by ExpectParams functions")
 	}
 
-	mmListNamespacePipelineReleases.defaultExpectation.params = &RepositoryMockListNamespacePipelineReleasesParams{ctx, ownerPermalink, pipelineUID, pageSize, pageToken, isBasicView, filter, showDeleted, returnCount}
-	mmListNamespacePipelineReleases.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmListNamespacePipelineReleases.expectations {
-		if minimock.Equal(e.params, mmListNamespacePipelineReleases.defaultExpectation.params) {
-			mmListNamespacePipelineReleases.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListNamespacePipelineReleases.defaultExpectation.params)
+	mmListNamespaceEventSources.defaultExpectation.params = &RepositoryMockListNamespaceEventSourcesParams{ctx, nsUID}
+	mmListNamespaceEventSources.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmListNamespaceEventSources.expectations {
+		if minimock.Equal(e.params, mmListNamespaceEventSources.defaultExpectation.params) {
+			mmListNamespaceEventSources.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListNamespaceEventSources.defaultExpectation.params)
 		}
 	}
 
-	return mmListNamespacePipelineReleases
+	return mmListNamespaceEventSources
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Repository.ListNamespacePipelineReleases
-func (mmListNamespacePipelineReleases *mRepositoryMockListNamespacePipelineReleases) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListNamespacePipelineReleases {
-	if mmListNamespacePipelineReleases.mock.funcListNamespacePipelineReleases != nil {
-		mmListNamespacePipelineReleases.mock.t.Fatalf("RepositoryMock.ListNamespacePipelineReleases mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Repository.ListNamespaceEventSources
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListNamespaceEventSources {
+	if mmListNamespaceEventSources.mock.funcListNamespaceEventSources != nil {
+		mmListNamespaceEventSources.mock.t.Fatalf("RepositoryMock.ListNamespaceEventSources mock is already set by Set")
 	}
 
-	if mmListNamespacePipelineReleases.defaultExpectation == nil {
+	if mmListNamespaceEventSources.defaultExpectation == nil {
+		mmListNamespaceEventSources.defaultExpectation = &RepositoryMockListNamespaceEventSourcesExpectation{}
+	}
+
+	if mmListNamespaceEventSources.defaultExpectation.params != nil {
+		mmListNamespaceEventSources.mock.t.Fatalf("RepositoryMock.ListNamespaceEventSources mock is already set by Expect")
+	}
+
+	if mmListNamespaceEventSources.defaultExpectation.paramPtrs == nil {
+		mmListNamespaceEventSources.defaultExpectation.paramPtrs = &RepositoryMockListNamespaceEventSourcesParamPtrs{}
+	}
+	mmListNamespaceEventSources.defaultExpectation.paramPtrs.ctx = &ctx
+	mmListNamespaceEventSources.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmListNamespaceEventSources
+}
+
+// ExpectNsUIDParam2 sets up expected param nsUID for Repository.ListNamespaceEventSources
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) ExpectNsUIDParam2(nsUID uuid.UUID) *mRepositoryMockListNamespaceEventSources {
+	if mmListNamespaceEventSources.mock.funcListNamespaceEventSources != nil {
+		mmListNamespaceEventSources.mock.t.Fatalf("RepositoryMock.ListNamespaceEventSources mock is already set by Set")
+	}
+
+	if mmListNamespaceEventSources.defaultExpectation == nil {
+		mmListNamespaceEventSources.defaultExpectation = &RepositoryMockListNamespaceEventSourcesExpectation{}
+	}
+
+	if mmListNamespaceEventSources.defaultExpectation.params != nil {
+		mmListNamespaceEventSources.mock.t.Fatalf("RepositoryMock.ListNamespaceEventSources mock is already set by Expect")
+	}
+
+	if mmListNamespaceEventSources.defaultExpectation.paramPtrs == nil {
+		mmListNamespaceEventSources.defaultExpectation.paramPtrs = &RepositoryMockListNamespaceEventSourcesParamPtrs{}
+	}
+	mmListNamespaceEventSources.defaultExpectation.paramPtrs.nsUID = &nsUID
+	mmListNamespaceEventSources.defaultExpectation.expectationOrigins.originNsUID = minimock.CallerInfo(1)
+
+	return mmListNamespaceEventSources
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.ListNamespaceEventSources
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) Inspect(f func(ctx context.Context, nsUID uuid.UUID)) *mRepositoryMockListNamespaceEventSources {
+	if mmListNamespaceEventSources.mock.inspectFuncListNamespaceEventSources != nil {
+		mmListNamespaceEventSources.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ListNamespaceEventSources")
+	}
+
+	mmListNamespaceEventSources.mock.inspectFuncListNamespaceEventSources = f
+
+	return mmListNamespaceEventSources
+}
+
+// Return sets up results that will be returned by Repository.ListNamespaceEventSources
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) Return(epa1 []*datamodel.EventSource, err error) *RepositoryMock {
+	if mmListNamespaceEventSources.mock.funcListNamespaceEventSources != nil {
+		mmListNamespaceEventSources.mock.t.Fatalf("RepositoryMock.ListNamespaceEventSources mock is already set by Set")
+	}
+
+	if mmListNamespaceEventSources.defaultExpectation == nil {
+		mmListNamespaceEventSources.defaultExpectation = &RepositoryMockListNamespaceEventSourcesExpectation{mock: mmListNamespaceEventSources.mock}
+	}
+	mmListNamespaceEventSources.defaultExpectation.results = &RepositoryMockListNamespaceEventSourcesResults{epa1, err}
+	mmListNamespaceEventSources.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmListNamespaceEventSources.mock
+}
+
+// Set uses given function f to mock the Repository.ListNamespaceEventSources method
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) Set(f func(ctx context.Context, nsUID uuid.UUID) (epa1 []*datamodel.EventSource, err error)) *RepositoryMock {
+	if mmListNamespaceEventSources.defaultExpectation != nil {
+		mmListNamespaceEventSources.mock.t.Fatalf("Default expectation is already set for the Repository.ListNamespaceEventSources method")
+	}
+
+	if len(mmListNamespaceEventSources.expectations) > 0 {
+		mmListNamespaceEventSources.mock.t.Fatalf("Some expectations are already set for the Repository.ListNamespaceEventSources method")
+	}
+
+	mmListNamespaceEventSources.mock.funcListNamespaceEventSources = f
+	mmListNamespaceEventSources.mock.funcListNamespaceEventSourcesOrigin = minimock.CallerInfo(1)
+	return mmListNamespaceEventSources.mock
+}
+
+// When sets expectation for the Repository.ListNamespaceEventSources which will trigger the result defined by the following
+// Then helper
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) When(ctx context.Context, nsUID uuid.UUID) *RepositoryMockListNamespaceEventSourcesExpectation {
+	if mmListNamespaceEventSources.mock.funcListNamespaceEventSources != nil {
+		mmListNamespaceEventSources.mock.t.Fatalf("RepositoryMock.ListNamespaceEventSources mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockListNamespaceEventSourcesExpectation{
+		mock:               mmListNamespaceEventSources.mock,
+		params:             &RepositoryMockListNamespaceEventSourcesParams{ctx, nsUID},
+		expectationOrigins: RepositoryMockListNamespaceEventSourcesExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmListNamespaceEventSources.expectations = append(mmListNamespaceEventSources.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.ListNamespaceEventSources return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockListNamespaceEventSourcesExpectation) Then(epa1 []*datamodel.EventSource, err error) *RepositoryMock {
+	e.results = &RepositoryMockListNamespaceEventSourcesResults{epa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.ListNamespaceEventSources should be invoked
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) Times(n uint64) *mRepositoryMockListNamespaceEventSources {
+	if n == 0 {
+		mmListNamespaceEventSources.mock.t.Fatalf("Times of RepositoryMock.ListNamespaceEventSources mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmListNamespaceEventSources.expectedInvocations, n)
+	mmListNamespaceEventSources.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmListNamespaceEventSources
+}
+
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) invocationsDone() bool {
+	if len(mmListNamespaceEventSources.expectations) == 0 && mmListNamespaceEventSources.defaultExpectation == nil && mmListNamespaceEventSources.mock.funcListNamespaceEventSources == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmListNamespaceEventSources.mock.afterListNamespaceEventSourcesCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmListNamespaceEventSources.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ListNamespaceEventSources implements mm_repository.Repository
+func (mmListNamespaceEventSources *RepositoryMock) ListNamespaceEventSources(ctx context.Context, nsUID uuid.UUID) (epa1 []*datamodel.EventSource, err error) {
+	mm_atomic.AddUint64(&mmListNamespaceEventSources.beforeListNamespaceEventSourcesCounter, 1)
+	defer mm_atomic.AddUint64(&mmListNamespaceEventSources.afterListNamespaceEventSourcesCounter, 1)
+
+	mmListNamespaceEventSources.t.Helper()
+
+	if mmListNamespaceEventSources.inspectFuncListNamespaceEventSources != nil {
+		mmListNamespaceEventSources.inspectFuncListNamespaceEventSources(ctx, nsUID)
+	}
+
+	mm_params := RepositoryMockListNamespaceEventSourcesParams{ctx, nsUID}
+
+	// Record call args
+	mmListNamespaceEventSources.ListNamespaceEventSourcesMock.mutex.Lock()
+	mmListNamespaceEventSources.ListNamespaceEventSourcesMock.callArgs = append(mmListNamespaceEventSources.ListNamespaceEventSourcesMock.callArgs, &mm_params)
+	mmListNamespaceEventSources.ListNamespaceEventSourcesMock.mutex.Unlock()
+
+	for _, e := range mmListNamespaceEventSources.ListNamespaceEventSourcesMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.epa1, e.results.err
+		}
+	}
+
+	if mmListNamespaceEventSources.ListNamespaceEventSourcesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmListNamespaceEventSources.ListNamespaceEventSourcesMock.defaultExpectation.Counter, 1)
+		mm_want := mmListNamespaceEventSources.ListNamespaceEventSourcesMock.defaultExpectation.params
+		mm_want_ptrs := mmListNamespaceEventSources.ListNamespaceEventSourcesMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockListNamespaceEventSourcesParams{ctx, nsUID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmListNamespaceEventSources.t.Errorf("RepositoryMock.ListNamespaceEventSources got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListNamespaceEventSources.ListNamespaceEventSourcesMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.nsUID != nil && !minimock.Equal(*mm_want_ptrs.nsUID, mm_got.nsUID) {
+				mmListNamespaceEventSources.t.Errorf("RepositoryMock.ListNamespaceEventSources got unexpected parameter nsUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListNamespaceEventSources.ListNamespaceEventSourcesMock.defaultExpectation.expectationOrigins.originNsUID, *mm_want_ptrs.nsUID, mm_got.nsUID, minimock.Diff(*mm_want_ptrs.nsUID, mm_got.nsUID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmListNamespaceEventSources.t.Errorf("RepositoryMock.ListNamespaceEventSources got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmListNamespaceEventSources.ListNamespaceEventSourcesMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmListNamespaceEventSources.ListNamespaceEventSourcesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmListNamespaceEventSources.t.Fatal("No results are set for the RepositoryMock.ListNamespaceEventSources")
+		}
+		return (*mm_results).epa1, (*mm_results).err
+	}
+	if mmListNamespaceEventSources.funcListNamespaceEventSources != nil {
+		return mmListNamespaceEventSources.funcListNamespaceEventSources(ctx, nsUID)
+	}
+	mmListNamespaceEventSources.t.Fatalf("Unexpected call to RepositoryMock.ListNamespaceEventSources. %v %v", ctx, nsUID)
+	return
+}
+
+// ListNamespaceEventSourcesAfterCounter returns a count of finished RepositoryMock.ListNamespaceEventSources invocations
+func (mmListNamespaceEventSources *RepositoryMock) ListNamespaceEventSourcesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListNamespaceEventSources.afterListNamespaceEventSourcesCounter)
+}
+
+// ListNamespaceEventSourcesBeforeCounter returns a count of RepositoryMock.ListNamespaceEventSources invocations
+func (mmListNamespaceEventSources *RepositoryMock) ListNamespaceEventSourcesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListNamespaceEventSources.beforeListNamespaceEventSourcesCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.ListNamespaceEventSources.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmListNamespaceEventSources *mRepositoryMockListNamespaceEventSources) Calls() []*RepositoryMockListNamespaceEventSourcesParams {
+	mmListNamespaceEventSources.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockListNamespaceEventSourcesParams, len(mmListNamespaceEventSources.callArgs))
+	copy(argCopy, mmListNamespaceEventSources.callArgs)
+
+	mmListNamespaceEventSources.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListNamespaceEventSourcesDone returns true if the count of the ListNamespaceEventSources invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockListNamespaceEventSourcesDone() bool {
+	if m.ListNamespaceEventSourcesMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListNamespaceEventSourcesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListNamespaceEventSourcesMock.invocationsDone()
+}
+
+// MinimockListNamespaceEventSourcesInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockListNamespaceEventSourcesInspect() {
+	for _, e := range m.ListNamespaceEventSourcesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.ListNamespaceEventSources at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterListNamespaceEventSourcesCounter := mm_atomic.LoadUint64(&m.afterListNamespaceEventSourcesCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListNamespaceEventSourcesMock.defaultExpectation != nil && afterListNamespaceEventSourcesCounter < 1 {
+		if m.ListNamespaceEventSourcesMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.ListNamespaceEventSources at\n%s", m.ListNamespaceEventSourcesMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.ListNamespaceEventSources at\n%s with params: %#v", m.ListNamespaceEventSourcesMock.defaultExpectation.expectationOrigins.origin, *m.ListNamespaceEventSourcesMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcListNamespaceEventSources != nil && afterListNamespaceEventSourcesCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ListNamespaceEventSources at\n%s", m.funcListNamespaceEventSourcesOrigin)
+	}
+
+	if !m.ListNamespaceEventSourcesMock.invocationsDone() && afterListNamespaceEventSourcesCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ListNamespaceEventSources at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListNamespaceEventSourcesMock.expectedInvocations), m.ListNamespaceEventSourcesMock.expectedInvocationsOrigin, afterListNamespaceEventSourcesCounter)
+	}
+}
+
+type mRepositoryMockListNamespacePipelineReleases struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockListNamespacePipelineReleasesExpectation
+	expectations       []*RepositoryMockListNamespacePipelineReleasesExpectation
+
+	callArgs []*RepositoryMockListNamespacePipelineReleasesParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockListNamespacePipelineReleasesExpectation specifies expectation struct of the Repository.ListNamespacePipelineReleases
+type RepositoryMockListNamespacePipelineReleasesExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockListNamespacePipelineReleasesParams
+	paramPtrs          *RepositoryMockListNamespacePipelineReleasesParamPtrs
+	expectationOrigins RepositoryMockListNamespacePipelineReleasesExpectationOrigins
+	results            *RepositoryMockListNamespacePipelineReleasesResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockListNamespacePipelineReleasesParams contains parameters of the Repository.ListNamespacePipelineReleases
+type RepositoryMockListNamespacePipelineReleasesParams struct {
+	ctx            context.Context
+	ownerPermalink string
+	pipelineUID    uuid.UUID
+	pageSize       int64
+	pageToken      string
+	isBasicView    bool
+	filter         filtering.Filter
+	showDeleted    bool
+	returnCount    bool
+}
+
+// RepositoryMockListNamespacePipelineReleasesParamPtrs contains pointers to parameters of the Repository.ListNamespacePipelineReleases
+type RepositoryMockListNamespacePipelineReleasesParamPtrs struct {
+	ctx            *context.Context
+	ownerPermalink *string
+	pipelineUID    *uuid.UUID
+	pageSize       *int64
+	pageToken      *string
+	isBasicView    *bool
+	filter         *filtering.Filter
+	showDeleted    *bool
+	returnCount    *bool
+}
+
+// RepositoryMockListNamespacePipelineReleasesResults contains results of the Repository.ListNamespacePipelineReleases
+type RepositoryMockListNamespacePipelineReleasesResults struct {
+	ppa1 []*datamodel.PipelineRelease
+	i1   int64
+	s1   string
+	err  error
+}
+
+// RepositoryMockListNamespacePipelineReleasesOrigins contains origins of expectations of the Repository.ListNamespacePipelineReleases
+type RepositoryMockListNamespacePipelineReleasesExpectationOrigins struct {
+	origin               string
+	originCtx            string
+	originOwnerPermalink string
+	originPipelineUID    string
+	originPageSize       string
+	originPageToken      string
+	originIsBasicView    string
+	originFilter         string
+	originShowDeleted    string
+	originReturnCount    string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmListNamespacePipelineReleases *mRepositoryMockListNamespacePipelineReleases) Optional() *mRepositoryMockListNamespacePipelineReleases {
+	mmListNamespacePipelineReleases.optional = true
+	return mmListNamespacePipelineReleases
+}
+
+// Expect sets up expected params for Repository.ListNamespacePipelineReleases
+func (mmListNamespacePipelineReleases *mRepositoryMockListNamespacePipelineReleases) Expect(ctx context.Context, ownerPermalink string, pipelineUID uuid.UUID, pageSize int64, pageToken string, isBasicView bool, filter filtering.Filter, showDeleted bool, returnCount bool) *mRepositoryMockListNamespacePipelineReleases {
+	if mmListNamespacePipelineReleases.mock.funcListNamespacePipelineReleases != nil {
+		mmListNamespacePipelineReleases.mock.t.Fatalf("RepositoryMock.ListNamespacePipelineReleases mock is already set by Set")
+	}
+
+	if mmListNamespacePipelineReleases.defaultExpectation == nil {
+		mmListNamespacePipelineReleases.defaultExpectation = &RepositoryMockListNamespacePipelineReleasesExpectation{}
+	}
+
+	if mmListNamespacePipelineReleases.defaultExpectation.paramPtrs != nil {
+		mmListNamespacePipelineReleases.mock.t.Fatalf("RepositoryMock.ListNamespacePipelineReleases mock is already set by ExpectParams functions")
+	}
+
+	mmListNamespacePipelineReleases.defaultExpectation.params = &RepositoryMockListNamespacePipelineReleasesParams{ctx, ownerPermalink, pipelineUID, pageSize, pageToken, isBasicView, filter, showDeleted, returnCount}
+	mmListNamespacePipelineReleases.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmListNamespacePipelineReleases.expectations {
+		if minimock.Equal(e.params, mmListNamespacePipelineReleases.defaultExpectation.params) {
+			mmListNamespacePipelineReleases.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListNamespacePipelineReleases.defaultExpectation.params)
+		}
+	}
+
+	return mmListNamespacePipelineReleases
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ListNamespacePipelineReleases
+func (mmListNamespacePipelineReleases *mRepositoryMockListNamespacePipelineReleases) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListNamespacePipelineReleases {
+	if mmListNamespacePipelineReleases.mock.funcListNamespacePipelineReleases != nil {
+		mmListNamespacePipelineReleases.mock.t.Fatalf("RepositoryMock.ListNamespacePipelineReleases mock is already set by Set")
+	}
+
+	if mmListNamespacePipelineReleases.defaultExpectation == nil {
 		mmListNamespacePipelineReleases.defaultExpectation = &RepositoryMockListNamespacePipelineReleasesExpectation{}
 	}
 
@@ -13478,38 +17685,755 @@ func (m *RepositoryMock) MinimockListNamespacePipelinesInspect() {
 	}
 }
 
-type mRepositoryMockListNamespaceSecrets struct {
+type mRepositoryMockListNamespacePromptVersions struct {
 	optional           bool
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockListNamespaceSecretsExpectation
-	expectations       []*RepositoryMockListNamespaceSecretsExpectation
+	defaultExpectation *RepositoryMockListNamespacePromptVersionsExpectation
+	expectations       []*RepositoryMockListNamespacePromptVersionsExpectation
 
-	callArgs []*RepositoryMockListNamespaceSecretsParams
+	callArgs []*RepositoryMockListNamespacePromptVersionsParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RepositoryMockListNamespaceSecretsExpectation specifies expectation struct of the Repository.ListNamespaceSecrets
-type RepositoryMockListNamespaceSecretsExpectation struct {
+// RepositoryMockListNamespacePromptVersionsExpectation specifies expectation struct of the Repository.ListNamespacePromptVersions
+type RepositoryMockListNamespacePromptVersionsExpectation struct {
 	mock               *RepositoryMock
-	params             *RepositoryMockListNamespaceSecretsParams
-	paramPtrs          *RepositoryMockListNamespaceSecretsParamPtrs
-	expectationOrigins RepositoryMockListNamespaceSecretsExpectationOrigins
-	results            *RepositoryMockListNamespaceSecretsResults
+	params             *RepositoryMockListNamespacePromptVersionsParams
+	paramPtrs          *RepositoryMockListNamespacePromptVersionsParamPtrs
+	expectationOrigins RepositoryMockListNamespacePromptVersionsExpectationOrigins
+	results            *RepositoryMockListNamespacePromptVersionsResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RepositoryMockListNamespaceSecretsParams contains parameters of the Repository.ListNamespaceSecrets
-type RepositoryMockListNamespaceSecretsParams struct {
-	ctx            context.Context
-	ownerPermalink string
-	pageSize       int64
-	pageToken      string
-	filter         filtering.Filter
-}
+// RepositoryMockListNamespacePromptVersionsParams contains parameters of the Repository.ListNamespacePromptVersions
+type RepositoryMockListNamespacePromptVersionsParams struct {
+	ctx   context.Context
+	nsUID uuid.UUID
+	id    string
+}
+
+// RepositoryMockListNamespacePromptVersionsParamPtrs contains pointers to parameters of the Repository.ListNamespacePromptVersions
+type RepositoryMockListNamespacePromptVersionsParamPtrs struct {
+	ctx   *context.Context
+	nsUID *uuid.UUID
+	id    *string
+}
+
+// RepositoryMockListNamespacePromptVersionsResults contains results of the Repository.ListNamespacePromptVersions
+type RepositoryMockListNamespacePromptVersionsResults struct {
+	ppa1 []*datamodel.Prompt
+	err  error
+}
+
+// RepositoryMockListNamespacePromptVersionsOrigins contains origins of expectations of the Repository.ListNamespacePromptVersions
+type RepositoryMockListNamespacePromptVersionsExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originNsUID string
+	originId    string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) Optional() *mRepositoryMockListNamespacePromptVersions {
+	mmListNamespacePromptVersions.optional = true
+	return mmListNamespacePromptVersions
+}
+
+// Expect sets up expected params for Repository.ListNamespacePromptVersions
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) Expect(ctx context.Context, nsUID uuid.UUID, id string) *mRepositoryMockListNamespacePromptVersions {
+	if mmListNamespacePromptVersions.mock.funcListNamespacePromptVersions != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("RepositoryMock.ListNamespacePromptVersions mock is already set by Set")
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation == nil {
+		mmListNamespacePromptVersions.defaultExpectation = &RepositoryMockListNamespacePromptVersionsExpectation{}
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation.paramPtrs != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("RepositoryMock.ListNamespacePromptVersions mock is already set by ExpectParams functions")
+	}
+
+	mmListNamespacePromptVersions.defaultExpectation.params = &RepositoryMockListNamespacePromptVersionsParams{ctx, nsUID, id}
+	mmListNamespacePromptVersions.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmListNamespacePromptVersions.expectations {
+		if minimock.Equal(e.params, mmListNamespacePromptVersions.defaultExpectation.params) {
+			mmListNamespacePromptVersions.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListNamespacePromptVersions.defaultExpectation.params)
+		}
+	}
+
+	return mmListNamespacePromptVersions
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ListNamespacePromptVersions
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListNamespacePromptVersions {
+	if mmListNamespacePromptVersions.mock.funcListNamespacePromptVersions != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("RepositoryMock.ListNamespacePromptVersions mock is already set by Set")
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation == nil {
+		mmListNamespacePromptVersions.defaultExpectation = &RepositoryMockListNamespacePromptVersionsExpectation{}
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation.params != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("RepositoryMock.ListNamespacePromptVersions mock is already set by Expect")
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation.paramPtrs == nil {
+		mmListNamespacePromptVersions.defaultExpectation.paramPtrs = &RepositoryMockListNamespacePromptVersionsParamPtrs{}
+	}
+	mmListNamespacePromptVersions.defaultExpectation.paramPtrs.ctx = &ctx
+	mmListNamespacePromptVersions.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmListNamespacePromptVersions
+}
+
+// ExpectNsUIDParam2 sets up expected param nsUID for Repository.ListNamespacePromptVersions
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) ExpectNsUIDParam2(nsUID uuid.UUID) *mRepositoryMockListNamespacePromptVersions {
+	if mmListNamespacePromptVersions.mock.funcListNamespacePromptVersions != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("RepositoryMock.ListNamespacePromptVersions mock is already set by Set")
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation == nil {
+		mmListNamespacePromptVersions.defaultExpectation = &RepositoryMockListNamespacePromptVersionsExpectation{}
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation.params != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("RepositoryMock.ListNamespacePromptVersions mock is already set by Expect")
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation.paramPtrs == nil {
+		mmListNamespacePromptVersions.defaultExpectation.paramPtrs = &RepositoryMockListNamespacePromptVersionsParamPtrs{}
+	}
+	mmListNamespacePromptVersions.defaultExpectation.paramPtrs.nsUID = &nsUID
+	mmListNamespacePromptVersions.defaultExpectation.expectationOrigins.originNsUID = minimock.CallerInfo(1)
+
+	return mmListNamespacePromptVersions
+}
+
+// ExpectIdParam3 sets up expected param id for Repository.ListNamespacePromptVersions
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) ExpectIdParam3(id string) *mRepositoryMockListNamespacePromptVersions {
+	if mmListNamespacePromptVersions.mock.funcListNamespacePromptVersions != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("RepositoryMock.ListNamespacePromptVersions mock is already set by Set")
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation == nil {
+		mmListNamespacePromptVersions.defaultExpectation = &RepositoryMockListNamespacePromptVersionsExpectation{}
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation.params != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("RepositoryMock.ListNamespacePromptVersions mock is already set by Expect")
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation.paramPtrs == nil {
+		mmListNamespacePromptVersions.defaultExpectation.paramPtrs = &RepositoryMockListNamespacePromptVersionsParamPtrs{}
+	}
+	mmListNamespacePromptVersions.defaultExpectation.paramPtrs.id = &id
+	mmListNamespacePromptVersions.defaultExpectation.expectationOrigins.originId = minimock.CallerInfo(1)
+
+	return mmListNamespacePromptVersions
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.ListNamespacePromptVersions
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) Inspect(f func(ctx context.Context, nsUID uuid.UUID, id string)) *mRepositoryMockListNamespacePromptVersions {
+	if mmListNamespacePromptVersions.mock.inspectFuncListNamespacePromptVersions != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ListNamespacePromptVersions")
+	}
+
+	mmListNamespacePromptVersions.mock.inspectFuncListNamespacePromptVersions = f
+
+	return mmListNamespacePromptVersions
+}
+
+// Return sets up results that will be returned by Repository.ListNamespacePromptVersions
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) Return(ppa1 []*datamodel.Prompt, err error) *RepositoryMock {
+	if mmListNamespacePromptVersions.mock.funcListNamespacePromptVersions != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("RepositoryMock.ListNamespacePromptVersions mock is already set by Set")
+	}
+
+	if mmListNamespacePromptVersions.defaultExpectation == nil {
+		mmListNamespacePromptVersions.defaultExpectation = &RepositoryMockListNamespacePromptVersionsExpectation{mock: mmListNamespacePromptVersions.mock}
+	}
+	mmListNamespacePromptVersions.defaultExpectation.results = &RepositoryMockListNamespacePromptVersionsResults{ppa1, err}
+	mmListNamespacePromptVersions.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmListNamespacePromptVersions.mock
+}
+
+// Set uses given function f to mock the Repository.ListNamespacePromptVersions method
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) Set(f func(ctx context.Context, nsUID uuid.UUID, id string) (ppa1 []*datamodel.Prompt, err error)) *RepositoryMock {
+	if mmListNamespacePromptVersions.defaultExpectation != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("Default expectation is already set for the Repository.ListNamespacePromptVersions method")
+	}
+
+	if len(mmListNamespacePromptVersions.expectations) > 0 {
+		mmListNamespacePromptVersions.mock.t.Fatalf("Some expectations are already set for the Repository.ListNamespacePromptVersions method")
+	}
+
+	mmListNamespacePromptVersions.mock.funcListNamespacePromptVersions = f
+	mmListNamespacePromptVersions.mock.funcListNamespacePromptVersionsOrigin = minimock.CallerInfo(1)
+	return mmListNamespacePromptVersions.mock
+}
+
+// When sets expectation for the Repository.ListNamespacePromptVersions which will trigger the result defined by the following
+// Then helper
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) When(ctx context.Context, nsUID uuid.UUID, id string) *RepositoryMockListNamespacePromptVersionsExpectation {
+	if mmListNamespacePromptVersions.mock.funcListNamespacePromptVersions != nil {
+		mmListNamespacePromptVersions.mock.t.Fatalf("RepositoryMock.ListNamespacePromptVersions mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockListNamespacePromptVersionsExpectation{
+		mock:               mmListNamespacePromptVersions.mock,
+		params:             &RepositoryMockListNamespacePromptVersionsParams{ctx, nsUID, id},
+		expectationOrigins: RepositoryMockListNamespacePromptVersionsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmListNamespacePromptVersions.expectations = append(mmListNamespacePromptVersions.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.ListNamespacePromptVersions return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockListNamespacePromptVersionsExpectation) Then(ppa1 []*datamodel.Prompt, err error) *RepositoryMock {
+	e.results = &RepositoryMockListNamespacePromptVersionsResults{ppa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.ListNamespacePromptVersions should be invoked
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) Times(n uint64) *mRepositoryMockListNamespacePromptVersions {
+	if n == 0 {
+		mmListNamespacePromptVersions.mock.t.Fatalf("Times of RepositoryMock.ListNamespacePromptVersions mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmListNamespacePromptVersions.expectedInvocations, n)
+	mmListNamespacePromptVersions.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmListNamespacePromptVersions
+}
+
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) invocationsDone() bool {
+	if len(mmListNamespacePromptVersions.expectations) == 0 && mmListNamespacePromptVersions.defaultExpectation == nil && mmListNamespacePromptVersions.mock.funcListNamespacePromptVersions == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmListNamespacePromptVersions.mock.afterListNamespacePromptVersionsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmListNamespacePromptVersions.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ListNamespacePromptVersions implements mm_repository.Repository
+func (mmListNamespacePromptVersions *RepositoryMock) ListNamespacePromptVersions(ctx context.Context, nsUID uuid.UUID, id string) (ppa1 []*datamodel.Prompt, err error) {
+	mm_atomic.AddUint64(&mmListNamespacePromptVersions.beforeListNamespacePromptVersionsCounter, 1)
+	defer mm_atomic.AddUint64(&mmListNamespacePromptVersions.afterListNamespacePromptVersionsCounter, 1)
+
+	mmListNamespacePromptVersions.t.Helper()
+
+	if mmListNamespacePromptVersions.inspectFuncListNamespacePromptVersions != nil {
+		mmListNamespacePromptVersions.inspectFuncListNamespacePromptVersions(ctx, nsUID, id)
+	}
+
+	mm_params := RepositoryMockListNamespacePromptVersionsParams{ctx, nsUID, id}
+
+	// Record call args
+	mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.mutex.Lock()
+	mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.callArgs = append(mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.callArgs, &mm_params)
+	mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.mutex.Unlock()
+
+	for _, e := range mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ppa1, e.results.err
+		}
+	}
+
+	if mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.defaultExpectation.Counter, 1)
+		mm_want := mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.defaultExpectation.params
+		mm_want_ptrs := mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockListNamespacePromptVersionsParams{ctx, nsUID, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmListNamespacePromptVersions.t.Errorf("RepositoryMock.ListNamespacePromptVersions got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.nsUID != nil && !minimock.Equal(*mm_want_ptrs.nsUID, mm_got.nsUID) {
+				mmListNamespacePromptVersions.t.Errorf("RepositoryMock.ListNamespacePromptVersions got unexpected parameter nsUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.defaultExpectation.expectationOrigins.originNsUID, *mm_want_ptrs.nsUID, mm_got.nsUID, minimock.Diff(*mm_want_ptrs.nsUID, mm_got.nsUID))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmListNamespacePromptVersions.t.Errorf("RepositoryMock.ListNamespacePromptVersions got unexpected parameter id, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.defaultExpectation.expectationOrigins.originId, *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmListNamespacePromptVersions.t.Errorf("RepositoryMock.ListNamespacePromptVersions got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmListNamespacePromptVersions.ListNamespacePromptVersionsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmListNamespacePromptVersions.t.Fatal("No results are set for the RepositoryMock.ListNamespacePromptVersions")
+		}
+		return (*mm_results).ppa1, (*mm_results).err
+	}
+	if mmListNamespacePromptVersions.funcListNamespacePromptVersions != nil {
+		return mmListNamespacePromptVersions.funcListNamespacePromptVersions(ctx, nsUID, id)
+	}
+	mmListNamespacePromptVersions.t.Fatalf("Unexpected call to RepositoryMock.ListNamespacePromptVersions. %v %v %v", ctx, nsUID, id)
+	return
+}
+
+// ListNamespacePromptVersionsAfterCounter returns a count of finished RepositoryMock.ListNamespacePromptVersions invocations
+func (mmListNamespacePromptVersions *RepositoryMock) ListNamespacePromptVersionsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListNamespacePromptVersions.afterListNamespacePromptVersionsCounter)
+}
+
+// ListNamespacePromptVersionsBeforeCounter returns a count of RepositoryMock.ListNamespacePromptVersions invocations
+func (mmListNamespacePromptVersions *RepositoryMock) ListNamespacePromptVersionsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListNamespacePromptVersions.beforeListNamespacePromptVersionsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.ListNamespacePromptVersions.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmListNamespacePromptVersions *mRepositoryMockListNamespacePromptVersions) Calls() []*RepositoryMockListNamespacePromptVersionsParams {
+	mmListNamespacePromptVersions.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockListNamespacePromptVersionsParams, len(mmListNamespacePromptVersions.callArgs))
+	copy(argCopy, mmListNamespacePromptVersions.callArgs)
+
+	mmListNamespacePromptVersions.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListNamespacePromptVersionsDone returns true if the count of the ListNamespacePromptVersions invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockListNamespacePromptVersionsDone() bool {
+	if m.ListNamespacePromptVersionsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListNamespacePromptVersionsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListNamespacePromptVersionsMock.invocationsDone()
+}
+
+// MinimockListNamespacePromptVersionsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockListNamespacePromptVersionsInspect() {
+	for _, e := range m.ListNamespacePromptVersionsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.ListNamespacePromptVersions at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterListNamespacePromptVersionsCounter := mm_atomic.LoadUint64(&m.afterListNamespacePromptVersionsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListNamespacePromptVersionsMock.defaultExpectation != nil && afterListNamespacePromptVersionsCounter < 1 {
+		if m.ListNamespacePromptVersionsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.ListNamespacePromptVersions at\n%s", m.ListNamespacePromptVersionsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.ListNamespacePromptVersions at\n%s with params: %#v", m.ListNamespacePromptVersionsMock.defaultExpectation.expectationOrigins.origin, *m.ListNamespacePromptVersionsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcListNamespacePromptVersions != nil && afterListNamespacePromptVersionsCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ListNamespacePromptVersions at\n%s", m.funcListNamespacePromptVersionsOrigin)
+	}
+
+	if !m.ListNamespacePromptVersionsMock.invocationsDone() && afterListNamespacePromptVersionsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ListNamespacePromptVersions at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListNamespacePromptVersionsMock.expectedInvocations), m.ListNamespacePromptVersionsMock.expectedInvocationsOrigin, afterListNamespacePromptVersionsCounter)
+	}
+}
+
+type mRepositoryMockListNamespacePrompts struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockListNamespacePromptsExpectation
+	expectations       []*RepositoryMockListNamespacePromptsExpectation
+
+	callArgs []*RepositoryMockListNamespacePromptsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockListNamespacePromptsExpectation specifies expectation struct of the Repository.ListNamespacePrompts
+type RepositoryMockListNamespacePromptsExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockListNamespacePromptsParams
+	paramPtrs          *RepositoryMockListNamespacePromptsParamPtrs
+	expectationOrigins RepositoryMockListNamespacePromptsExpectationOrigins
+	results            *RepositoryMockListNamespacePromptsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockListNamespacePromptsParams contains parameters of the Repository.ListNamespacePrompts
+type RepositoryMockListNamespacePromptsParams struct {
+	ctx   context.Context
+	nsUID uuid.UUID
+}
+
+// RepositoryMockListNamespacePromptsParamPtrs contains pointers to parameters of the Repository.ListNamespacePrompts
+type RepositoryMockListNamespacePromptsParamPtrs struct {
+	ctx   *context.Context
+	nsUID *uuid.UUID
+}
+
+// RepositoryMockListNamespacePromptsResults contains results of the Repository.ListNamespacePrompts
+type RepositoryMockListNamespacePromptsResults struct {
+	ppa1 []*datamodel.Prompt
+	err  error
+}
+
+// RepositoryMockListNamespacePromptsOrigins contains origins of expectations of the Repository.ListNamespacePrompts
+type RepositoryMockListNamespacePromptsExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originNsUID string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) Optional() *mRepositoryMockListNamespacePrompts {
+	mmListNamespacePrompts.optional = true
+	return mmListNamespacePrompts
+}
+
+// Expect sets up expected params for Repository.ListNamespacePrompts
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) Expect(ctx context.Context, nsUID uuid.UUID) *mRepositoryMockListNamespacePrompts {
+	if mmListNamespacePrompts.mock.funcListNamespacePrompts != nil {
+		mmListNamespacePrompts.mock.t.Fatalf("RepositoryMock.ListNamespacePrompts mock is already set by Set")
+	}
+
+	if mmListNamespacePrompts.defaultExpectation == nil {
+		mmListNamespacePrompts.defaultExpectation = &RepositoryMockListNamespacePromptsExpectation{}
+	}
+
+	if mmListNamespacePrompts.defaultExpectation.paramPtrs != nil {
+		mmListNamespacePrompts.mock.t.Fatalf("RepositoryMock.ListNamespacePrompts mock is already set by ExpectParams functions")
+	}
+
+	mmListNamespacePrompts.defaultExpectation.params = &RepositoryMockListNamespacePromptsParams{ctx, nsUID}
+	mmListNamespacePrompts.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmListNamespacePrompts.expectations {
+		if minimock.Equal(e.params, mmListNamespacePrompts.defaultExpectation.params) {
+			mmListNamespacePrompts.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListNamespacePrompts.defaultExpectation.params)
+		}
+	}
+
+	return mmListNamespacePrompts
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ListNamespacePrompts
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListNamespacePrompts {
+	if mmListNamespacePrompts.mock.funcListNamespacePrompts != nil {
+		mmListNamespacePrompts.mock.t.Fatalf("RepositoryMock.ListNamespacePrompts mock is already set by Set")
+	}
+
+	if mmListNamespacePrompts.defaultExpectation == nil {
+		mmListNamespacePrompts.defaultExpectation = &RepositoryMockListNamespacePromptsExpectation{}
+	}
+
+	if mmListNamespacePrompts.defaultExpectation.params != nil {
+		mmListNamespacePrompts.mock.t.Fatalf("RepositoryMock.ListNamespacePrompts mock is already set by Expect")
+	}
+
+	if mmListNamespacePrompts.defaultExpectation.paramPtrs == nil {
+		mmListNamespacePrompts.defaultExpectation.paramPtrs = &RepositoryMockListNamespacePromptsParamPtrs{}
+	}
+	mmListNamespacePrompts.defaultExpectation.paramPtrs.ctx = &ctx
+	mmListNamespacePrompts.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmListNamespacePrompts
+}
+
+// ExpectNsUIDParam2 sets up expected param nsUID for Repository.ListNamespacePrompts
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) ExpectNsUIDParam2(nsUID uuid.UUID) *mRepositoryMockListNamespacePrompts {
+	if mmListNamespacePrompts.mock.funcListNamespacePrompts != nil {
+		mmListNamespacePrompts.mock.t.Fatalf("RepositoryMock.ListNamespacePrompts mock is already set by Set")
+	}
+
+	if mmListNamespacePrompts.defaultExpectation == nil {
+		mmListNamespacePrompts.defaultExpectation = &RepositoryMockListNamespacePromptsExpectation{}
+	}
+
+	if mmListNamespacePrompts.defaultExpectation.params != nil {
+		mmListNamespacePrompts.mock.t.Fatalf("RepositoryMock.ListNamespacePrompts mock is already set by Expect")
+	}
+
+	if mmListNamespacePrompts.defaultExpectation.paramPtrs == nil {
+		mmListNamespacePrompts.defaultExpectation.paramPtrs = &RepositoryMockListNamespacePromptsParamPtrs{}
+	}
+	mmListNamespacePrompts.defaultExpectation.paramPtrs.nsUID = &nsUID
+	mmListNamespacePrompts.defaultExpectation.expectationOrigins.originNsUID = minimock.CallerInfo(1)
+
+	return mmListNamespacePrompts
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.ListNamespacePrompts
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) Inspect(f func(ctx context.Context, nsUID uuid.UUID)) *mRepositoryMockListNamespacePrompts {
+	if mmListNamespacePrompts.mock.inspectFuncListNamespacePrompts != nil {
+		mmListNamespacePrompts.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ListNamespacePrompts")
+	}
+
+	mmListNamespacePrompts.mock.inspectFuncListNamespacePrompts = f
+
+	return mmListNamespacePrompts
+}
+
+// Return sets up results that will be returned by Repository.ListNamespacePrompts
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) Return(ppa1 []*datamodel.Prompt, err error) *RepositoryMock {
+	if mmListNamespacePrompts.mock.funcListNamespacePrompts != nil {
+		mmListNamespacePrompts.mock.t.Fatalf("RepositoryMock.ListNamespacePrompts mock is already set by Set")
+	}
+
+	if mmListNamespacePrompts.defaultExpectation == nil {
+		mmListNamespacePrompts.defaultExpectation = &RepositoryMockListNamespacePromptsExpectation{mock: mmListNamespacePrompts.mock}
+	}
+	mmListNamespacePrompts.defaultExpectation.results = &RepositoryMockListNamespacePromptsResults{ppa1, err}
+	mmListNamespacePrompts.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmListNamespacePrompts.mock
+}
+
+// Set uses given function f to mock the Repository.ListNamespacePrompts method
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) Set(f func(ctx context.Context, nsUID uuid.UUID) (ppa1 []*datamodel.Prompt, err error)) *RepositoryMock {
+	if mmListNamespacePrompts.defaultExpectation != nil {
+		mmListNamespacePrompts.mock.t.Fatalf("Default expectation is already set for the Repository.ListNamespacePrompts method")
+	}
+
+	if len(mmListNamespacePrompts.expectations) > 0 {
+		mmListNamespacePrompts.mock.t.Fatalf("Some expectations are already set for the Repository.ListNamespacePrompts method")
+	}
+
+	mmListNamespacePrompts.mock.funcListNamespacePrompts = f
+	mmListNamespacePrompts.mock.funcListNamespacePromptsOrigin = minimock.CallerInfo(1)
+	return mmListNamespacePrompts.mock
+}
+
+// When sets expectation for the Repository.ListNamespacePrompts which will trigger the result defined by the following
+// Then helper
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) When(ctx context.Context, nsUID uuid.UUID) *RepositoryMockListNamespacePromptsExpectation {
+	if mmListNamespacePrompts.mock.funcListNamespacePrompts != nil {
+		mmListNamespacePrompts.mock.t.Fatalf("RepositoryMock.ListNamespacePrompts mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockListNamespacePromptsExpectation{
+		mock:               mmListNamespacePrompts.mock,
+		params:             &RepositoryMockListNamespacePromptsParams{ctx, nsUID},
+		expectationOrigins: RepositoryMockListNamespacePromptsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmListNamespacePrompts.expectations = append(mmListNamespacePrompts.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.ListNamespacePrompts return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockListNamespacePromptsExpectation) Then(ppa1 []*datamodel.Prompt, err error) *RepositoryMock {
+	e.results = &RepositoryMockListNamespacePromptsResults{ppa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.ListNamespacePrompts should be invoked
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) Times(n uint64) *mRepositoryMockListNamespacePrompts {
+	if n == 0 {
+		mmListNamespacePrompts.mock.t.Fatalf("Times of RepositoryMock.ListNamespacePrompts mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmListNamespacePrompts.expectedInvocations, n)
+	mmListNamespacePrompts.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmListNamespacePrompts
+}
+
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) invocationsDone() bool {
+	if len(mmListNamespacePrompts.expectations) == 0 && mmListNamespacePrompts.defaultExpectation == nil && mmListNamespacePrompts.mock.funcListNamespacePrompts == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmListNamespacePrompts.mock.afterListNamespacePromptsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmListNamespacePrompts.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ListNamespacePrompts implements mm_repository.Repository
+func (mmListNamespacePrompts *RepositoryMock) ListNamespacePrompts(ctx context.Context, nsUID uuid.UUID) (ppa1 []*datamodel.Prompt, err error) {
+	mm_atomic.AddUint64(&mmListNamespacePrompts.beforeListNamespacePromptsCounter, 1)
+	defer mm_atomic.AddUint64(&mmListNamespacePrompts.afterListNamespacePromptsCounter, 1)
+
+	mmListNamespacePrompts.t.Helper()
+
+	if mmListNamespacePrompts.inspectFuncListNamespacePrompts != nil {
+		mmListNamespacePrompts.inspectFuncListNamespacePrompts(ctx, nsUID)
+	}
+
+	mm_params := RepositoryMockListNamespacePromptsParams{ctx, nsUID}
+
+	// Record call args
+	mmListNamespacePrompts.ListNamespacePromptsMock.mutex.Lock()
+	mmListNamespacePrompts.ListNamespacePromptsMock.callArgs = append(mmListNamespacePrompts.ListNamespacePromptsMock.callArgs, &mm_params)
+	mmListNamespacePrompts.ListNamespacePromptsMock.mutex.Unlock()
+
+	for _, e := range mmListNamespacePrompts.ListNamespacePromptsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ppa1, e.results.err
+		}
+	}
+
+	if mmListNamespacePrompts.ListNamespacePromptsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmListNamespacePrompts.ListNamespacePromptsMock.defaultExpectation.Counter, 1)
+		mm_want := mmListNamespacePrompts.ListNamespacePromptsMock.defaultExpectation.params
+		mm_want_ptrs := mmListNamespacePrompts.ListNamespacePromptsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockListNamespacePromptsParams{ctx, nsUID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmListNamespacePrompts.t.Errorf("RepositoryMock.ListNamespacePrompts got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListNamespacePrompts.ListNamespacePromptsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.nsUID != nil && !minimock.Equal(*mm_want_ptrs.nsUID, mm_got.nsUID) {
+				mmListNamespacePrompts.t.Errorf("RepositoryMock.ListNamespacePrompts got unexpected parameter nsUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListNamespacePrompts.ListNamespacePromptsMock.defaultExpectation.expectationOrigins.originNsUID, *mm_want_ptrs.nsUID, mm_got.nsUID, minimock.Diff(*mm_want_ptrs.nsUID, mm_got.nsUID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmListNamespacePrompts.t.Errorf("RepositoryMock.ListNamespacePrompts got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmListNamespacePrompts.ListNamespacePromptsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmListNamespacePrompts.ListNamespacePromptsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmListNamespacePrompts.t.Fatal("No results are set for the RepositoryMock.ListNamespacePrompts")
+		}
+		return (*mm_results).ppa1, (*mm_results).err
+	}
+	if mmListNamespacePrompts.funcListNamespacePrompts != nil {
+		return mmListNamespacePrompts.funcListNamespacePrompts(ctx, nsUID)
+	}
+	mmListNamespacePrompts.t.Fatalf("Unexpected call to RepositoryMock.ListNamespacePrompts. %v %v", ctx, nsUID)
+	return
+}
+
+// ListNamespacePromptsAfterCounter returns a count of finished RepositoryMock.ListNamespacePrompts invocations
+func (mmListNamespacePrompts *RepositoryMock) ListNamespacePromptsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListNamespacePrompts.afterListNamespacePromptsCounter)
+}
+
+// ListNamespacePromptsBeforeCounter returns a count of RepositoryMock.ListNamespacePrompts invocations
+func (mmListNamespacePrompts *RepositoryMock) ListNamespacePromptsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListNamespacePrompts.beforeListNamespacePromptsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.ListNamespacePrompts.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmListNamespacePrompts *mRepositoryMockListNamespacePrompts) Calls() []*RepositoryMockListNamespacePromptsParams {
+	mmListNamespacePrompts.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockListNamespacePromptsParams, len(mmListNamespacePrompts.callArgs))
+	copy(argCopy, mmListNamespacePrompts.callArgs)
+
+	mmListNamespacePrompts.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListNamespacePromptsDone returns true if the count of the ListNamespacePrompts invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockListNamespacePromptsDone() bool {
+	if m.ListNamespacePromptsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListNamespacePromptsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListNamespacePromptsMock.invocationsDone()
+}
+
+// MinimockListNamespacePromptsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockListNamespacePromptsInspect() {
+	for _, e := range m.ListNamespacePromptsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.ListNamespacePrompts at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterListNamespacePromptsCounter := mm_atomic.LoadUint64(&m.afterListNamespacePromptsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListNamespacePromptsMock.defaultExpectation != nil && afterListNamespacePromptsCounter < 1 {
+		if m.ListNamespacePromptsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.ListNamespacePrompts at\n%s", m.ListNamespacePromptsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.ListNamespacePrompts at\n%s with params: %#v", m.ListNamespacePromptsMock.defaultExpectation.expectationOrigins.origin, *m.ListNamespacePromptsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcListNamespacePrompts != nil && afterListNamespacePromptsCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ListNamespacePrompts at\n%s", m.funcListNamespacePromptsOrigin)
+	}
+
+	if !m.ListNamespacePromptsMock.invocationsDone() && afterListNamespacePromptsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ListNamespacePrompts at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListNamespacePromptsMock.expectedInvocations), m.ListNamespacePromptsMock.expectedInvocationsOrigin, afterListNamespacePromptsCounter)
+	}
+}
+
+type mRepositoryMockListNamespaceSecrets struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockListNamespaceSecretsExpectation
+	expectations       []*RepositoryMockListNamespaceSecretsExpectation
+
+	callArgs []*RepositoryMockListNamespaceSecretsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockListNamespaceSecretsExpectation specifies expectation struct of the Repository.ListNamespaceSecrets
+type RepositoryMockListNamespaceSecretsExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockListNamespaceSecretsParams
+	paramPtrs          *RepositoryMockListNamespaceSecretsParamPtrs
+	expectationOrigins RepositoryMockListNamespaceSecretsExpectationOrigins
+	results            *RepositoryMockListNamespaceSecretsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockListNamespaceSecretsParams contains parameters of the Repository.ListNamespaceSecrets
+type RepositoryMockListNamespaceSecretsParams struct {
+	ctx            context.Context
+	ownerPermalink string
+	pageSize       int64
+	pageToken      string
+	filter         filtering.Filter
+}
 
 // RepositoryMockListNamespaceSecretsParamPtrs contains pointers to parameters of the Repository.ListNamespaceSecrets
 type RepositoryMockListNamespaceSecretsParamPtrs struct {
@@ -13902,17 +18826,360 @@ func (m *RepositoryMock) MinimockListNamespaceSecretsInspect() {
 		if m.ListNamespaceSecretsMock.defaultExpectation.params == nil {
 			m.t.Errorf("Expected call to RepositoryMock.ListNamespaceSecrets at\n%s", m.ListNamespaceSecretsMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.ListNamespaceSecrets at\n%s with params: %#v", m.ListNamespaceSecretsMock.defaultExpectation.expectationOrigins.origin, *m.ListNamespaceSecretsMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.ListNamespaceSecrets at\n%s with params: %#v", m.ListNamespaceSecretsMock.defaultExpectation.expectationOrigins.origin, *m.ListNamespaceSecretsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcListNamespaceSecrets != nil && afterListNamespaceSecretsCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ListNamespaceSecrets at\n%s", m.funcListNamespaceSecretsOrigin)
+	}
+
+	if !m.ListNamespaceSecretsMock.invocationsDone() && afterListNamespaceSecretsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ListNamespaceSecrets at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListNamespaceSecretsMock.expectedInvocations), m.ListNamespaceSecretsMock.expectedInvocationsOrigin, afterListNamespaceSecretsCounter)
+	}
+}
+
+type mRepositoryMockListPipelineCanaries struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockListPipelineCanariesExpectation
+	expectations       []*RepositoryMockListPipelineCanariesExpectation
+
+	callArgs []*RepositoryMockListPipelineCanariesParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockListPipelineCanariesExpectation specifies expectation struct of the Repository.ListPipelineCanaries
+type RepositoryMockListPipelineCanariesExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockListPipelineCanariesParams
+	paramPtrs          *RepositoryMockListPipelineCanariesParamPtrs
+	expectationOrigins RepositoryMockListPipelineCanariesExpectationOrigins
+	results            *RepositoryMockListPipelineCanariesResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockListPipelineCanariesParams contains parameters of the Repository.ListPipelineCanaries
+type RepositoryMockListPipelineCanariesParams struct {
+	ctx         context.Context
+	pipelineUID uuid.UUID
+}
+
+// RepositoryMockListPipelineCanariesParamPtrs contains pointers to parameters of the Repository.ListPipelineCanaries
+type RepositoryMockListPipelineCanariesParamPtrs struct {
+	ctx         *context.Context
+	pipelineUID *uuid.UUID
+}
+
+// RepositoryMockListPipelineCanariesResults contains results of the Repository.ListPipelineCanaries
+type RepositoryMockListPipelineCanariesResults struct {
+	pa1 []*datamodel.PipelineCanary
+	err error
+}
+
+// RepositoryMockListPipelineCanariesOrigins contains origins of expectations of the Repository.ListPipelineCanaries
+type RepositoryMockListPipelineCanariesExpectationOrigins struct {
+	origin            string
+	originCtx         string
+	originPipelineUID string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) Optional() *mRepositoryMockListPipelineCanaries {
+	mmListPipelineCanaries.optional = true
+	return mmListPipelineCanaries
+}
+
+// Expect sets up expected params for Repository.ListPipelineCanaries
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) Expect(ctx context.Context, pipelineUID uuid.UUID) *mRepositoryMockListPipelineCanaries {
+	if mmListPipelineCanaries.mock.funcListPipelineCanaries != nil {
+		mmListPipelineCanaries.mock.t.Fatalf("RepositoryMock.ListPipelineCanaries mock is already set by Set")
+	}
+
+	if mmListPipelineCanaries.defaultExpectation == nil {
+		mmListPipelineCanaries.defaultExpectation = &RepositoryMockListPipelineCanariesExpectation{}
+	}
+
+	if mmListPipelineCanaries.defaultExpectation.paramPtrs != nil {
+		mmListPipelineCanaries.mock.t.Fatalf("RepositoryMock.ListPipelineCanaries mock is already set by ExpectParams functions")
+	}
+
+	mmListPipelineCanaries.defaultExpectation.params = &RepositoryMockListPipelineCanariesParams{ctx, pipelineUID}
+	mmListPipelineCanaries.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmListPipelineCanaries.expectations {
+		if minimock.Equal(e.params, mmListPipelineCanaries.defaultExpectation.params) {
+			mmListPipelineCanaries.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListPipelineCanaries.defaultExpectation.params)
+		}
+	}
+
+	return mmListPipelineCanaries
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ListPipelineCanaries
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListPipelineCanaries {
+	if mmListPipelineCanaries.mock.funcListPipelineCanaries != nil {
+		mmListPipelineCanaries.mock.t.Fatalf("RepositoryMock.ListPipelineCanaries mock is already set by Set")
+	}
+
+	if mmListPipelineCanaries.defaultExpectation == nil {
+		mmListPipelineCanaries.defaultExpectation = &RepositoryMockListPipelineCanariesExpectation{}
+	}
+
+	if mmListPipelineCanaries.defaultExpectation.params != nil {
+		mmListPipelineCanaries.mock.t.Fatalf("RepositoryMock.ListPipelineCanaries mock is already set by Expect")
+	}
+
+	if mmListPipelineCanaries.defaultExpectation.paramPtrs == nil {
+		mmListPipelineCanaries.defaultExpectation.paramPtrs = &RepositoryMockListPipelineCanariesParamPtrs{}
+	}
+	mmListPipelineCanaries.defaultExpectation.paramPtrs.ctx = &ctx
+	mmListPipelineCanaries.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmListPipelineCanaries
+}
+
+// ExpectPipelineUIDParam2 sets up expected param pipelineUID for Repository.ListPipelineCanaries
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) ExpectPipelineUIDParam2(pipelineUID uuid.UUID) *mRepositoryMockListPipelineCanaries {
+	if mmListPipelineCanaries.mock.funcListPipelineCanaries != nil {
+		mmListPipelineCanaries.mock.t.Fatalf("RepositoryMock.ListPipelineCanaries mock is already set by Set")
+	}
+
+	if mmListPipelineCanaries.defaultExpectation == nil {
+		mmListPipelineCanaries.defaultExpectation = &RepositoryMockListPipelineCanariesExpectation{}
+	}
+
+	if mmListPipelineCanaries.defaultExpectation.params != nil {
+		mmListPipelineCanaries.mock.t.Fatalf("RepositoryMock.ListPipelineCanaries mock is already set by Expect")
+	}
+
+	if mmListPipelineCanaries.defaultExpectation.paramPtrs == nil {
+		mmListPipelineCanaries.defaultExpectation.paramPtrs = &RepositoryMockListPipelineCanariesParamPtrs{}
+	}
+	mmListPipelineCanaries.defaultExpectation.paramPtrs.pipelineUID = &pipelineUID
+	mmListPipelineCanaries.defaultExpectation.expectationOrigins.originPipelineUID = minimock.CallerInfo(1)
+
+	return mmListPipelineCanaries
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.ListPipelineCanaries
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) Inspect(f func(ctx context.Context, pipelineUID uuid.UUID)) *mRepositoryMockListPipelineCanaries {
+	if mmListPipelineCanaries.mock.inspectFuncListPipelineCanaries != nil {
+		mmListPipelineCanaries.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ListPipelineCanaries")
+	}
+
+	mmListPipelineCanaries.mock.inspectFuncListPipelineCanaries = f
+
+	return mmListPipelineCanaries
+}
+
+// Return sets up results that will be returned by Repository.ListPipelineCanaries
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) Return(pa1 []*datamodel.PipelineCanary, err error) *RepositoryMock {
+	if mmListPipelineCanaries.mock.funcListPipelineCanaries != nil {
+		mmListPipelineCanaries.mock.t.Fatalf("RepositoryMock.ListPipelineCanaries mock is already set by Set")
+	}
+
+	if mmListPipelineCanaries.defaultExpectation == nil {
+		mmListPipelineCanaries.defaultExpectation = &RepositoryMockListPipelineCanariesExpectation{mock: mmListPipelineCanaries.mock}
+	}
+	mmListPipelineCanaries.defaultExpectation.results = &RepositoryMockListPipelineCanariesResults{pa1, err}
+	mmListPipelineCanaries.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmListPipelineCanaries.mock
+}
+
+// Set uses given function f to mock the Repository.ListPipelineCanaries method
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) Set(f func(ctx context.Context, pipelineUID uuid.UUID) (pa1 []*datamodel.PipelineCanary, err error)) *RepositoryMock {
+	if mmListPipelineCanaries.defaultExpectation != nil {
+		mmListPipelineCanaries.mock.t.Fatalf("Default expectation is already set for the Repository.ListPipelineCanaries method")
+	}
+
+	if len(mmListPipelineCanaries.expectations) > 0 {
+		mmListPipelineCanaries.mock.t.Fatalf("Some expectations are already set for the Repository.ListPipelineCanaries method")
+	}
+
+	mmListPipelineCanaries.mock.funcListPipelineCanaries = f
+	mmListPipelineCanaries.mock.funcListPipelineCanariesOrigin = minimock.CallerInfo(1)
+	return mmListPipelineCanaries.mock
+}
+
+// When sets expectation for the Repository.ListPipelineCanaries which will trigger the result defined by the following
+// Then helper
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) When(ctx context.Context, pipelineUID uuid.UUID) *RepositoryMockListPipelineCanariesExpectation {
+	if mmListPipelineCanaries.mock.funcListPipelineCanaries != nil {
+		mmListPipelineCanaries.mock.t.Fatalf("RepositoryMock.ListPipelineCanaries mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockListPipelineCanariesExpectation{
+		mock:               mmListPipelineCanaries.mock,
+		params:             &RepositoryMockListPipelineCanariesParams{ctx, pipelineUID},
+		expectationOrigins: RepositoryMockListPipelineCanariesExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmListPipelineCanaries.expectations = append(mmListPipelineCanaries.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.ListPipelineCanaries return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockListPipelineCanariesExpectation) Then(pa1 []*datamodel.PipelineCanary, err error) *RepositoryMock {
+	e.results = &RepositoryMockListPipelineCanariesResults{pa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.ListPipelineCanaries should be invoked
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) Times(n uint64) *mRepositoryMockListPipelineCanaries {
+	if n == 0 {
+		mmListPipelineCanaries.mock.t.Fatalf("Times of RepositoryMock.ListPipelineCanaries mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmListPipelineCanaries.expectedInvocations, n)
+	mmListPipelineCanaries.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmListPipelineCanaries
+}
+
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) invocationsDone() bool {
+	if len(mmListPipelineCanaries.expectations) == 0 && mmListPipelineCanaries.defaultExpectation == nil && mmListPipelineCanaries.mock.funcListPipelineCanaries == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmListPipelineCanaries.mock.afterListPipelineCanariesCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmListPipelineCanaries.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ListPipelineCanaries implements mm_repository.Repository
+func (mmListPipelineCanaries *RepositoryMock) ListPipelineCanaries(ctx context.Context, pipelineUID uuid.UUID) (pa1 []*datamodel.PipelineCanary, err error) {
+	mm_atomic.AddUint64(&mmListPipelineCanaries.beforeListPipelineCanariesCounter, 1)
+	defer mm_atomic.AddUint64(&mmListPipelineCanaries.afterListPipelineCanariesCounter, 1)
+
+	mmListPipelineCanaries.t.Helper()
+
+	if mmListPipelineCanaries.inspectFuncListPipelineCanaries != nil {
+		mmListPipelineCanaries.inspectFuncListPipelineCanaries(ctx, pipelineUID)
+	}
+
+	mm_params := RepositoryMockListPipelineCanariesParams{ctx, pipelineUID}
+
+	// Record call args
+	mmListPipelineCanaries.ListPipelineCanariesMock.mutex.Lock()
+	mmListPipelineCanaries.ListPipelineCanariesMock.callArgs = append(mmListPipelineCanaries.ListPipelineCanariesMock.callArgs, &mm_params)
+	mmListPipelineCanaries.ListPipelineCanariesMock.mutex.Unlock()
+
+	for _, e := range mmListPipelineCanaries.ListPipelineCanariesMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.pa1, e.results.err
+		}
+	}
+
+	if mmListPipelineCanaries.ListPipelineCanariesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmListPipelineCanaries.ListPipelineCanariesMock.defaultExpectation.Counter, 1)
+		mm_want := mmListPipelineCanaries.ListPipelineCanariesMock.defaultExpectation.params
+		mm_want_ptrs := mmListPipelineCanaries.ListPipelineCanariesMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockListPipelineCanariesParams{ctx, pipelineUID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmListPipelineCanaries.t.Errorf("RepositoryMock.ListPipelineCanaries got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListPipelineCanaries.ListPipelineCanariesMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.pipelineUID != nil && !minimock.Equal(*mm_want_ptrs.pipelineUID, mm_got.pipelineUID) {
+				mmListPipelineCanaries.t.Errorf("RepositoryMock.ListPipelineCanaries got unexpected parameter pipelineUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListPipelineCanaries.ListPipelineCanariesMock.defaultExpectation.expectationOrigins.originPipelineUID, *mm_want_ptrs.pipelineUID, mm_got.pipelineUID, minimock.Diff(*mm_want_ptrs.pipelineUID, mm_got.pipelineUID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmListPipelineCanaries.t.Errorf("RepositoryMock.ListPipelineCanaries got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmListPipelineCanaries.ListPipelineCanariesMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmListPipelineCanaries.ListPipelineCanariesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmListPipelineCanaries.t.Fatal("No results are set for the RepositoryMock.ListPipelineCanaries")
+		}
+		return (*mm_results).pa1, (*mm_results).err
+	}
+	if mmListPipelineCanaries.funcListPipelineCanaries != nil {
+		return mmListPipelineCanaries.funcListPipelineCanaries(ctx, pipelineUID)
+	}
+	mmListPipelineCanaries.t.Fatalf("Unexpected call to RepositoryMock.ListPipelineCanaries. %v %v", ctx, pipelineUID)
+	return
+}
+
+// ListPipelineCanariesAfterCounter returns a count of finished RepositoryMock.ListPipelineCanaries invocations
+func (mmListPipelineCanaries *RepositoryMock) ListPipelineCanariesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListPipelineCanaries.afterListPipelineCanariesCounter)
+}
+
+// ListPipelineCanariesBeforeCounter returns a count of RepositoryMock.ListPipelineCanaries invocations
+func (mmListPipelineCanaries *RepositoryMock) ListPipelineCanariesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListPipelineCanaries.beforeListPipelineCanariesCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.ListPipelineCanaries.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmListPipelineCanaries *mRepositoryMockListPipelineCanaries) Calls() []*RepositoryMockListPipelineCanariesParams {
+	mmListPipelineCanaries.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockListPipelineCanariesParams, len(mmListPipelineCanaries.callArgs))
+	copy(argCopy, mmListPipelineCanaries.callArgs)
+
+	mmListPipelineCanaries.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListPipelineCanariesDone returns true if the count of the ListPipelineCanaries invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockListPipelineCanariesDone() bool {
+	if m.ListPipelineCanariesMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListPipelineCanariesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListPipelineCanariesMock.invocationsDone()
+}
+
+// MinimockListPipelineCanariesInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockListPipelineCanariesInspect() {
+	for _, e := range m.ListPipelineCanariesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelineCanaries at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterListPipelineCanariesCounter := mm_atomic.LoadUint64(&m.afterListPipelineCanariesCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListPipelineCanariesMock.defaultExpectation != nil && afterListPipelineCanariesCounter < 1 {
+		if m.ListPipelineCanariesMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelineCanaries at\n%s", m.ListPipelineCanariesMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelineCanaries at\n%s with params: %#v", m.ListPipelineCanariesMock.defaultExpectation.expectationOrigins.origin, *m.ListPipelineCanariesMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcListNamespaceSecrets != nil && afterListNamespaceSecretsCounter < 1 {
-		m.t.Errorf("Expected call to RepositoryMock.ListNamespaceSecrets at\n%s", m.funcListNamespaceSecretsOrigin)
+	if m.funcListPipelineCanaries != nil && afterListPipelineCanariesCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ListPipelineCanaries at\n%s", m.funcListPipelineCanariesOrigin)
 	}
 
-	if !m.ListNamespaceSecretsMock.invocationsDone() && afterListNamespaceSecretsCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.ListNamespaceSecrets at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.ListNamespaceSecretsMock.expectedInvocations), m.ListNamespaceSecretsMock.expectedInvocationsOrigin, afterListNamespaceSecretsCounter)
+	if !m.ListPipelineCanariesMock.invocationsDone() && afterListPipelineCanariesCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ListPipelineCanaries at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListPipelineCanariesMock.expectedInvocations), m.ListPipelineCanariesMock.expectedInvocationsOrigin, afterListPipelineCanariesCounter)
 	}
 }
 
@@ -14108,154 +19375,528 @@ func (e *RepositoryMockListPipelineIDsByConnectionIDExpectation) Then(p1 mm_repo
 	return e.mock
 }
 
-// Times sets number of times Repository.ListPipelineIDsByConnectionID should be invoked
-func (mmListPipelineIDsByConnectionID *mRepositoryMockListPipelineIDsByConnectionID) Times(n uint64) *mRepositoryMockListPipelineIDsByConnectionID {
+// Times sets number of times Repository.ListPipelineIDsByConnectionID should be invoked
+func (mmListPipelineIDsByConnectionID *mRepositoryMockListPipelineIDsByConnectionID) Times(n uint64) *mRepositoryMockListPipelineIDsByConnectionID {
+	if n == 0 {
+		mmListPipelineIDsByConnectionID.mock.t.Fatalf("Times of RepositoryMock.ListPipelineIDsByConnectionID mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmListPipelineIDsByConnectionID.expectedInvocations, n)
+	mmListPipelineIDsByConnectionID.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmListPipelineIDsByConnectionID
+}
+
+func (mmListPipelineIDsByConnectionID *mRepositoryMockListPipelineIDsByConnectionID) invocationsDone() bool {
+	if len(mmListPipelineIDsByConnectionID.expectations) == 0 && mmListPipelineIDsByConnectionID.defaultExpectation == nil && mmListPipelineIDsByConnectionID.mock.funcListPipelineIDsByConnectionID == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmListPipelineIDsByConnectionID.mock.afterListPipelineIDsByConnectionIDCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmListPipelineIDsByConnectionID.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ListPipelineIDsByConnectionID implements mm_repository.Repository
+func (mmListPipelineIDsByConnectionID *RepositoryMock) ListPipelineIDsByConnectionID(ctx context.Context, l1 mm_repository.ListPipelineIDsByConnectionIDParams) (p1 mm_repository.PipelinesByConnectionList, err error) {
+	mm_atomic.AddUint64(&mmListPipelineIDsByConnectionID.beforeListPipelineIDsByConnectionIDCounter, 1)
+	defer mm_atomic.AddUint64(&mmListPipelineIDsByConnectionID.afterListPipelineIDsByConnectionIDCounter, 1)
+
+	mmListPipelineIDsByConnectionID.t.Helper()
+
+	if mmListPipelineIDsByConnectionID.inspectFuncListPipelineIDsByConnectionID != nil {
+		mmListPipelineIDsByConnectionID.inspectFuncListPipelineIDsByConnectionID(ctx, l1)
+	}
+
+	mm_params := RepositoryMockListPipelineIDsByConnectionIDParams{ctx, l1}
+
+	// Record call args
+	mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.mutex.Lock()
+	mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.callArgs = append(mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.callArgs, &mm_params)
+	mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.mutex.Unlock()
+
+	for _, e := range mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.p1, e.results.err
+		}
+	}
+
+	if mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.Counter, 1)
+		mm_want := mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.params
+		mm_want_ptrs := mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockListPipelineIDsByConnectionIDParams{ctx, l1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmListPipelineIDsByConnectionID.t.Errorf("RepositoryMock.ListPipelineIDsByConnectionID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.l1 != nil && !minimock.Equal(*mm_want_ptrs.l1, mm_got.l1) {
+				mmListPipelineIDsByConnectionID.t.Errorf("RepositoryMock.ListPipelineIDsByConnectionID got unexpected parameter l1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.expectationOrigins.originL1, *mm_want_ptrs.l1, mm_got.l1, minimock.Diff(*mm_want_ptrs.l1, mm_got.l1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmListPipelineIDsByConnectionID.t.Errorf("RepositoryMock.ListPipelineIDsByConnectionID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.results
+		if mm_results == nil {
+			mmListPipelineIDsByConnectionID.t.Fatal("No results are set for the RepositoryMock.ListPipelineIDsByConnectionID")
+		}
+		return (*mm_results).p1, (*mm_results).err
+	}
+	if mmListPipelineIDsByConnectionID.funcListPipelineIDsByConnectionID != nil {
+		return mmListPipelineIDsByConnectionID.funcListPipelineIDsByConnectionID(ctx, l1)
+	}
+	mmListPipelineIDsByConnectionID.t.Fatalf("Unexpected call to RepositoryMock.ListPipelineIDsByConnectionID. %v %v", ctx, l1)
+	return
+}
+
+// ListPipelineIDsByConnectionIDAfterCounter returns a count of finished RepositoryMock.ListPipelineIDsByConnectionID invocations
+func (mmListPipelineIDsByConnectionID *RepositoryMock) ListPipelineIDsByConnectionIDAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListPipelineIDsByConnectionID.afterListPipelineIDsByConnectionIDCounter)
+}
+
+// ListPipelineIDsByConnectionIDBeforeCounter returns a count of RepositoryMock.ListPipelineIDsByConnectionID invocations
+func (mmListPipelineIDsByConnectionID *RepositoryMock) ListPipelineIDsByConnectionIDBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListPipelineIDsByConnectionID.beforeListPipelineIDsByConnectionIDCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.ListPipelineIDsByConnectionID.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmListPipelineIDsByConnectionID *mRepositoryMockListPipelineIDsByConnectionID) Calls() []*RepositoryMockListPipelineIDsByConnectionIDParams {
+	mmListPipelineIDsByConnectionID.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockListPipelineIDsByConnectionIDParams, len(mmListPipelineIDsByConnectionID.callArgs))
+	copy(argCopy, mmListPipelineIDsByConnectionID.callArgs)
+
+	mmListPipelineIDsByConnectionID.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListPipelineIDsByConnectionIDDone returns true if the count of the ListPipelineIDsByConnectionID invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockListPipelineIDsByConnectionIDDone() bool {
+	if m.ListPipelineIDsByConnectionIDMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListPipelineIDsByConnectionIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListPipelineIDsByConnectionIDMock.invocationsDone()
+}
+
+// MinimockListPipelineIDsByConnectionIDInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockListPipelineIDsByConnectionIDInspect() {
+	for _, e := range m.ListPipelineIDsByConnectionIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelineIDsByConnectionID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterListPipelineIDsByConnectionIDCounter := mm_atomic.LoadUint64(&m.afterListPipelineIDsByConnectionIDCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListPipelineIDsByConnectionIDMock.defaultExpectation != nil && afterListPipelineIDsByConnectionIDCounter < 1 {
+		if m.ListPipelineIDsByConnectionIDMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelineIDsByConnectionID at\n%s", m.ListPipelineIDsByConnectionIDMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelineIDsByConnectionID at\n%s with params: %#v", m.ListPipelineIDsByConnectionIDMock.defaultExpectation.expectationOrigins.origin, *m.ListPipelineIDsByConnectionIDMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcListPipelineIDsByConnectionID != nil && afterListPipelineIDsByConnectionIDCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ListPipelineIDsByConnectionID at\n%s", m.funcListPipelineIDsByConnectionIDOrigin)
+	}
+
+	if !m.ListPipelineIDsByConnectionIDMock.invocationsDone() && afterListPipelineIDsByConnectionIDCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ListPipelineIDsByConnectionID at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListPipelineIDsByConnectionIDMock.expectedInvocations), m.ListPipelineIDsByConnectionIDMock.expectedInvocationsOrigin, afterListPipelineIDsByConnectionIDCounter)
+	}
+}
+
+type mRepositoryMockListPipelineRunsByScheduleID struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockListPipelineRunsByScheduleIDExpectation
+	expectations       []*RepositoryMockListPipelineRunsByScheduleIDExpectation
+
+	callArgs []*RepositoryMockListPipelineRunsByScheduleIDParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockListPipelineRunsByScheduleIDExpectation specifies expectation struct of the Repository.ListPipelineRunsByScheduleID
+type RepositoryMockListPipelineRunsByScheduleIDExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockListPipelineRunsByScheduleIDParams
+	paramPtrs          *RepositoryMockListPipelineRunsByScheduleIDParamPtrs
+	expectationOrigins RepositoryMockListPipelineRunsByScheduleIDExpectationOrigins
+	results            *RepositoryMockListPipelineRunsByScheduleIDResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockListPipelineRunsByScheduleIDParams contains parameters of the Repository.ListPipelineRunsByScheduleID
+type RepositoryMockListPipelineRunsByScheduleIDParams struct {
+	ctx        context.Context
+	scheduleID string
+	limit      int
+}
+
+// RepositoryMockListPipelineRunsByScheduleIDParamPtrs contains pointers to parameters of the Repository.ListPipelineRunsByScheduleID
+type RepositoryMockListPipelineRunsByScheduleIDParamPtrs struct {
+	ctx        *context.Context
+	scheduleID *string
+	limit      *int
+}
+
+// RepositoryMockListPipelineRunsByScheduleIDResults contains results of the Repository.ListPipelineRunsByScheduleID
+type RepositoryMockListPipelineRunsByScheduleIDResults struct {
+	pa1 []datamodel.PipelineRun
+	err error
+}
+
+// RepositoryMockListPipelineRunsByScheduleIDOrigins contains origins of expectations of the Repository.ListPipelineRunsByScheduleID
+type RepositoryMockListPipelineRunsByScheduleIDExpectationOrigins struct {
+	origin           string
+	originCtx        string
+	originScheduleID string
+	originLimit      string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) Optional() *mRepositoryMockListPipelineRunsByScheduleID {
+	mmListPipelineRunsByScheduleID.optional = true
+	return mmListPipelineRunsByScheduleID
+}
+
+// Expect sets up expected params for Repository.ListPipelineRunsByScheduleID
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) Expect(ctx context.Context, scheduleID string, limit int) *mRepositoryMockListPipelineRunsByScheduleID {
+	if mmListPipelineRunsByScheduleID.mock.funcListPipelineRunsByScheduleID != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("RepositoryMock.ListPipelineRunsByScheduleID mock is already set by Set")
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation == nil {
+		mmListPipelineRunsByScheduleID.defaultExpectation = &RepositoryMockListPipelineRunsByScheduleIDExpectation{}
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation.paramPtrs != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("RepositoryMock.ListPipelineRunsByScheduleID mock is already set by ExpectParams functions")
+	}
+
+	mmListPipelineRunsByScheduleID.defaultExpectation.params = &RepositoryMockListPipelineRunsByScheduleIDParams{ctx, scheduleID, limit}
+	mmListPipelineRunsByScheduleID.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmListPipelineRunsByScheduleID.expectations {
+		if minimock.Equal(e.params, mmListPipelineRunsByScheduleID.defaultExpectation.params) {
+			mmListPipelineRunsByScheduleID.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListPipelineRunsByScheduleID.defaultExpectation.params)
+		}
+	}
+
+	return mmListPipelineRunsByScheduleID
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ListPipelineRunsByScheduleID
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListPipelineRunsByScheduleID {
+	if mmListPipelineRunsByScheduleID.mock.funcListPipelineRunsByScheduleID != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("RepositoryMock.ListPipelineRunsByScheduleID mock is already set by Set")
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation == nil {
+		mmListPipelineRunsByScheduleID.defaultExpectation = &RepositoryMockListPipelineRunsByScheduleIDExpectation{}
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation.params != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("RepositoryMock.ListPipelineRunsByScheduleID mock is already set by Expect")
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation.paramPtrs == nil {
+		mmListPipelineRunsByScheduleID.defaultExpectation.paramPtrs = &RepositoryMockListPipelineRunsByScheduleIDParamPtrs{}
+	}
+	mmListPipelineRunsByScheduleID.defaultExpectation.paramPtrs.ctx = &ctx
+	mmListPipelineRunsByScheduleID.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmListPipelineRunsByScheduleID
+}
+
+// ExpectScheduleIDParam2 sets up expected param scheduleID for Repository.ListPipelineRunsByScheduleID
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) ExpectScheduleIDParam2(scheduleID string) *mRepositoryMockListPipelineRunsByScheduleID {
+	if mmListPipelineRunsByScheduleID.mock.funcListPipelineRunsByScheduleID != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("RepositoryMock.ListPipelineRunsByScheduleID mock is already set by Set")
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation == nil {
+		mmListPipelineRunsByScheduleID.defaultExpectation = &RepositoryMockListPipelineRunsByScheduleIDExpectation{}
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation.params != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("RepositoryMock.ListPipelineRunsByScheduleID mock is already set by Expect")
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation.paramPtrs == nil {
+		mmListPipelineRunsByScheduleID.defaultExpectation.paramPtrs = &RepositoryMockListPipelineRunsByScheduleIDParamPtrs{}
+	}
+	mmListPipelineRunsByScheduleID.defaultExpectation.paramPtrs.scheduleID = &scheduleID
+	mmListPipelineRunsByScheduleID.defaultExpectation.expectationOrigins.originScheduleID = minimock.CallerInfo(1)
+
+	return mmListPipelineRunsByScheduleID
+}
+
+// ExpectLimitParam3 sets up expected param limit for Repository.ListPipelineRunsByScheduleID
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) ExpectLimitParam3(limit int) *mRepositoryMockListPipelineRunsByScheduleID {
+	if mmListPipelineRunsByScheduleID.mock.funcListPipelineRunsByScheduleID != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("RepositoryMock.ListPipelineRunsByScheduleID mock is already set by Set")
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation == nil {
+		mmListPipelineRunsByScheduleID.defaultExpectation = &RepositoryMockListPipelineRunsByScheduleIDExpectation{}
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation.params != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("RepositoryMock.ListPipelineRunsByScheduleID mock is already set by Expect")
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation.paramPtrs == nil {
+		mmListPipelineRunsByScheduleID.defaultExpectation.paramPtrs = &RepositoryMockListPipelineRunsByScheduleIDParamPtrs{}
+	}
+	mmListPipelineRunsByScheduleID.defaultExpectation.paramPtrs.limit = &limit
+	mmListPipelineRunsByScheduleID.defaultExpectation.expectationOrigins.originLimit = minimock.CallerInfo(1)
+
+	return mmListPipelineRunsByScheduleID
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.ListPipelineRunsByScheduleID
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) Inspect(f func(ctx context.Context, scheduleID string, limit int)) *mRepositoryMockListPipelineRunsByScheduleID {
+	if mmListPipelineRunsByScheduleID.mock.inspectFuncListPipelineRunsByScheduleID != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ListPipelineRunsByScheduleID")
+	}
+
+	mmListPipelineRunsByScheduleID.mock.inspectFuncListPipelineRunsByScheduleID = f
+
+	return mmListPipelineRunsByScheduleID
+}
+
+// Return sets up results that will be returned by Repository.ListPipelineRunsByScheduleID
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) Return(pa1 []datamodel.PipelineRun, err error) *RepositoryMock {
+	if mmListPipelineRunsByScheduleID.mock.funcListPipelineRunsByScheduleID != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("RepositoryMock.ListPipelineRunsByScheduleID mock is already set by Set")
+	}
+
+	if mmListPipelineRunsByScheduleID.defaultExpectation == nil {
+		mmListPipelineRunsByScheduleID.defaultExpectation = &RepositoryMockListPipelineRunsByScheduleIDExpectation{mock: mmListPipelineRunsByScheduleID.mock}
+	}
+	mmListPipelineRunsByScheduleID.defaultExpectation.results = &RepositoryMockListPipelineRunsByScheduleIDResults{pa1, err}
+	mmListPipelineRunsByScheduleID.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmListPipelineRunsByScheduleID.mock
+}
+
+// Set uses given function f to mock the Repository.ListPipelineRunsByScheduleID method
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) Set(f func(ctx context.Context, scheduleID string, limit int) (pa1 []datamodel.PipelineRun, err error)) *RepositoryMock {
+	if mmListPipelineRunsByScheduleID.defaultExpectation != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("Default expectation is already set for the Repository.ListPipelineRunsByScheduleID method")
+	}
+
+	if len(mmListPipelineRunsByScheduleID.expectations) > 0 {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("Some expectations are already set for the Repository.ListPipelineRunsByScheduleID method")
+	}
+
+	mmListPipelineRunsByScheduleID.mock.funcListPipelineRunsByScheduleID = f
+	mmListPipelineRunsByScheduleID.mock.funcListPipelineRunsByScheduleIDOrigin = minimock.CallerInfo(1)
+	return mmListPipelineRunsByScheduleID.mock
+}
+
+// When sets expectation for the Repository.ListPipelineRunsByScheduleID which will trigger the result defined by the following
+// Then helper
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) When(ctx context.Context, scheduleID string, limit int) *RepositoryMockListPipelineRunsByScheduleIDExpectation {
+	if mmListPipelineRunsByScheduleID.mock.funcListPipelineRunsByScheduleID != nil {
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("RepositoryMock.ListPipelineRunsByScheduleID mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockListPipelineRunsByScheduleIDExpectation{
+		mock:               mmListPipelineRunsByScheduleID.mock,
+		params:             &RepositoryMockListPipelineRunsByScheduleIDParams{ctx, scheduleID, limit},
+		expectationOrigins: RepositoryMockListPipelineRunsByScheduleIDExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmListPipelineRunsByScheduleID.expectations = append(mmListPipelineRunsByScheduleID.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.ListPipelineRunsByScheduleID return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockListPipelineRunsByScheduleIDExpectation) Then(pa1 []datamodel.PipelineRun, err error) *RepositoryMock {
+	e.results = &RepositoryMockListPipelineRunsByScheduleIDResults{pa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.ListPipelineRunsByScheduleID should be invoked
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) Times(n uint64) *mRepositoryMockListPipelineRunsByScheduleID {
 	if n == 0 {
-		mmListPipelineIDsByConnectionID.mock.t.Fatalf("Times of RepositoryMock.ListPipelineIDsByConnectionID mock can not be zero")
+		mmListPipelineRunsByScheduleID.mock.t.Fatalf("Times of RepositoryMock.ListPipelineRunsByScheduleID mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmListPipelineIDsByConnectionID.expectedInvocations, n)
-	mmListPipelineIDsByConnectionID.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmListPipelineIDsByConnectionID
+	mm_atomic.StoreUint64(&mmListPipelineRunsByScheduleID.expectedInvocations, n)
+	mmListPipelineRunsByScheduleID.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmListPipelineRunsByScheduleID
 }
 
-func (mmListPipelineIDsByConnectionID *mRepositoryMockListPipelineIDsByConnectionID) invocationsDone() bool {
-	if len(mmListPipelineIDsByConnectionID.expectations) == 0 && mmListPipelineIDsByConnectionID.defaultExpectation == nil && mmListPipelineIDsByConnectionID.mock.funcListPipelineIDsByConnectionID == nil {
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) invocationsDone() bool {
+	if len(mmListPipelineRunsByScheduleID.expectations) == 0 && mmListPipelineRunsByScheduleID.defaultExpectation == nil && mmListPipelineRunsByScheduleID.mock.funcListPipelineRunsByScheduleID == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmListPipelineIDsByConnectionID.mock.afterListPipelineIDsByConnectionIDCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmListPipelineIDsByConnectionID.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmListPipelineRunsByScheduleID.mock.afterListPipelineRunsByScheduleIDCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmListPipelineRunsByScheduleID.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// ListPipelineIDsByConnectionID implements mm_repository.Repository
-func (mmListPipelineIDsByConnectionID *RepositoryMock) ListPipelineIDsByConnectionID(ctx context.Context, l1 mm_repository.ListPipelineIDsByConnectionIDParams) (p1 mm_repository.PipelinesByConnectionList, err error) {
-	mm_atomic.AddUint64(&mmListPipelineIDsByConnectionID.beforeListPipelineIDsByConnectionIDCounter, 1)
-	defer mm_atomic.AddUint64(&mmListPipelineIDsByConnectionID.afterListPipelineIDsByConnectionIDCounter, 1)
+// ListPipelineRunsByScheduleID implements mm_repository.Repository
+func (mmListPipelineRunsByScheduleID *RepositoryMock) ListPipelineRunsByScheduleID(ctx context.Context, scheduleID string, limit int) (pa1 []datamodel.PipelineRun, err error) {
+	mm_atomic.AddUint64(&mmListPipelineRunsByScheduleID.beforeListPipelineRunsByScheduleIDCounter, 1)
+	defer mm_atomic.AddUint64(&mmListPipelineRunsByScheduleID.afterListPipelineRunsByScheduleIDCounter, 1)
 
-	mmListPipelineIDsByConnectionID.t.Helper()
+	mmListPipelineRunsByScheduleID.t.Helper()
 
-	if mmListPipelineIDsByConnectionID.inspectFuncListPipelineIDsByConnectionID != nil {
-		mmListPipelineIDsByConnectionID.inspectFuncListPipelineIDsByConnectionID(ctx, l1)
+	if mmListPipelineRunsByScheduleID.inspectFuncListPipelineRunsByScheduleID != nil {
+		mmListPipelineRunsByScheduleID.inspectFuncListPipelineRunsByScheduleID(ctx, scheduleID, limit)
 	}
 
-	mm_params := RepositoryMockListPipelineIDsByConnectionIDParams{ctx, l1}
+	mm_params := RepositoryMockListPipelineRunsByScheduleIDParams{ctx, scheduleID, limit}
 
 	// Record call args
-	mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.mutex.Lock()
-	mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.callArgs = append(mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.callArgs, &mm_params)
-	mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.mutex.Unlock()
+	mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.mutex.Lock()
+	mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.callArgs = append(mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.callArgs, &mm_params)
+	mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.mutex.Unlock()
 
-	for _, e := range mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.expectations {
+	for _, e := range mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.p1, e.results.err
+			return e.results.pa1, e.results.err
 		}
 	}
 
-	if mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.Counter, 1)
-		mm_want := mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.params
-		mm_want_ptrs := mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.paramPtrs
+	if mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.defaultExpectation.Counter, 1)
+		mm_want := mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.defaultExpectation.params
+		mm_want_ptrs := mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockListPipelineIDsByConnectionIDParams{ctx, l1}
+		mm_got := RepositoryMockListPipelineRunsByScheduleIDParams{ctx, scheduleID, limit}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmListPipelineIDsByConnectionID.t.Errorf("RepositoryMock.ListPipelineIDsByConnectionID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmListPipelineRunsByScheduleID.t.Errorf("RepositoryMock.ListPipelineRunsByScheduleID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.l1 != nil && !minimock.Equal(*mm_want_ptrs.l1, mm_got.l1) {
-				mmListPipelineIDsByConnectionID.t.Errorf("RepositoryMock.ListPipelineIDsByConnectionID got unexpected parameter l1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.expectationOrigins.originL1, *mm_want_ptrs.l1, mm_got.l1, minimock.Diff(*mm_want_ptrs.l1, mm_got.l1))
+			if mm_want_ptrs.scheduleID != nil && !minimock.Equal(*mm_want_ptrs.scheduleID, mm_got.scheduleID) {
+				mmListPipelineRunsByScheduleID.t.Errorf("RepositoryMock.ListPipelineRunsByScheduleID got unexpected parameter scheduleID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.defaultExpectation.expectationOrigins.originScheduleID, *mm_want_ptrs.scheduleID, mm_got.scheduleID, minimock.Diff(*mm_want_ptrs.scheduleID, mm_got.scheduleID))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmListPipelineRunsByScheduleID.t.Errorf("RepositoryMock.ListPipelineRunsByScheduleID got unexpected parameter limit, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.defaultExpectation.expectationOrigins.originLimit, *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmListPipelineIDsByConnectionID.t.Errorf("RepositoryMock.ListPipelineIDsByConnectionID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmListPipelineRunsByScheduleID.t.Errorf("RepositoryMock.ListPipelineRunsByScheduleID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmListPipelineIDsByConnectionID.ListPipelineIDsByConnectionIDMock.defaultExpectation.results
+		mm_results := mmListPipelineRunsByScheduleID.ListPipelineRunsByScheduleIDMock.defaultExpectation.results
 		if mm_results == nil {
-			mmListPipelineIDsByConnectionID.t.Fatal("No results are set for the RepositoryMock.ListPipelineIDsByConnectionID")
+			mmListPipelineRunsByScheduleID.t.Fatal("No results are set for the RepositoryMock.ListPipelineRunsByScheduleID")
 		}
-		return (*mm_results).p1, (*mm_results).err
+		return (*mm_results).pa1, (*mm_results).err
 	}
-	if mmListPipelineIDsByConnectionID.funcListPipelineIDsByConnectionID != nil {
-		return mmListPipelineIDsByConnectionID.funcListPipelineIDsByConnectionID(ctx, l1)
+	if mmListPipelineRunsByScheduleID.funcListPipelineRunsByScheduleID != nil {
+		return mmListPipelineRunsByScheduleID.funcListPipelineRunsByScheduleID(ctx, scheduleID, limit)
 	}
-	mmListPipelineIDsByConnectionID.t.Fatalf("Unexpected call to RepositoryMock.ListPipelineIDsByConnectionID. %v %v", ctx, l1)
+	mmListPipelineRunsByScheduleID.t.Fatalf("Unexpected call to RepositoryMock.ListPipelineRunsByScheduleID. %v %v %v", ctx, scheduleID, limit)
 	return
 }
 
-// ListPipelineIDsByConnectionIDAfterCounter returns a count of finished RepositoryMock.ListPipelineIDsByConnectionID invocations
-func (mmListPipelineIDsByConnectionID *RepositoryMock) ListPipelineIDsByConnectionIDAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmListPipelineIDsByConnectionID.afterListPipelineIDsByConnectionIDCounter)
+// ListPipelineRunsByScheduleIDAfterCounter returns a count of finished RepositoryMock.ListPipelineRunsByScheduleID invocations
+func (mmListPipelineRunsByScheduleID *RepositoryMock) ListPipelineRunsByScheduleIDAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListPipelineRunsByScheduleID.afterListPipelineRunsByScheduleIDCounter)
 }
 
-// ListPipelineIDsByConnectionIDBeforeCounter returns a count of RepositoryMock.ListPipelineIDsByConnectionID invocations
-func (mmListPipelineIDsByConnectionID *RepositoryMock) ListPipelineIDsByConnectionIDBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmListPipelineIDsByConnectionID.beforeListPipelineIDsByConnectionIDCounter)
+// ListPipelineRunsByScheduleIDBeforeCounter returns a count of RepositoryMock.ListPipelineRunsByScheduleID invocations
+func (mmListPipelineRunsByScheduleID *RepositoryMock) ListPipelineRunsByScheduleIDBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListPipelineRunsByScheduleID.beforeListPipelineRunsByScheduleIDCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.ListPipelineIDsByConnectionID.
+// Calls returns a list of arguments used in each call to RepositoryMock.ListPipelineRunsByScheduleID.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmListPipelineIDsByConnectionID *mRepositoryMockListPipelineIDsByConnectionID) Calls() []*RepositoryMockListPipelineIDsByConnectionIDParams {
-	mmListPipelineIDsByConnectionID.mutex.RLock()
+func (mmListPipelineRunsByScheduleID *mRepositoryMockListPipelineRunsByScheduleID) Calls() []*RepositoryMockListPipelineRunsByScheduleIDParams {
+	mmListPipelineRunsByScheduleID.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockListPipelineIDsByConnectionIDParams, len(mmListPipelineIDsByConnectionID.callArgs))
-	copy(argCopy, mmListPipelineIDsByConnectionID.callArgs)
+	argCopy := make([]*RepositoryMockListPipelineRunsByScheduleIDParams, len(mmListPipelineRunsByScheduleID.callArgs))
+	copy(argCopy, mmListPipelineRunsByScheduleID.callArgs)
 
-	mmListPipelineIDsByConnectionID.mutex.RUnlock()
+	mmListPipelineRunsByScheduleID.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockListPipelineIDsByConnectionIDDone returns true if the count of the ListPipelineIDsByConnectionID invocations corresponds
+// MinimockListPipelineRunsByScheduleIDDone returns true if the count of the ListPipelineRunsByScheduleID invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockListPipelineIDsByConnectionIDDone() bool {
-	if m.ListPipelineIDsByConnectionIDMock.optional {
+func (m *RepositoryMock) MinimockListPipelineRunsByScheduleIDDone() bool {
+	if m.ListPipelineRunsByScheduleIDMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.ListPipelineIDsByConnectionIDMock.expectations {
+	for _, e := range m.ListPipelineRunsByScheduleIDMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.ListPipelineIDsByConnectionIDMock.invocationsDone()
+	return m.ListPipelineRunsByScheduleIDMock.invocationsDone()
 }
 
-// MinimockListPipelineIDsByConnectionIDInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockListPipelineIDsByConnectionIDInspect() {
-	for _, e := range m.ListPipelineIDsByConnectionIDMock.expectations {
+// MinimockListPipelineRunsByScheduleIDInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockListPipelineRunsByScheduleIDInspect() {
+	for _, e := range m.ListPipelineRunsByScheduleIDMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.ListPipelineIDsByConnectionID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelineRunsByScheduleID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterListPipelineIDsByConnectionIDCounter := mm_atomic.LoadUint64(&m.afterListPipelineIDsByConnectionIDCounter)
+	afterListPipelineRunsByScheduleIDCounter := mm_atomic.LoadUint64(&m.afterListPipelineRunsByScheduleIDCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.ListPipelineIDsByConnectionIDMock.defaultExpectation != nil && afterListPipelineIDsByConnectionIDCounter < 1 {
-		if m.ListPipelineIDsByConnectionIDMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to RepositoryMock.ListPipelineIDsByConnectionID at\n%s", m.ListPipelineIDsByConnectionIDMock.defaultExpectation.returnOrigin)
+	if m.ListPipelineRunsByScheduleIDMock.defaultExpectation != nil && afterListPipelineRunsByScheduleIDCounter < 1 {
+		if m.ListPipelineRunsByScheduleIDMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelineRunsByScheduleID at\n%s", m.ListPipelineRunsByScheduleIDMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.ListPipelineIDsByConnectionID at\n%s with params: %#v", m.ListPipelineIDsByConnectionIDMock.defaultExpectation.expectationOrigins.origin, *m.ListPipelineIDsByConnectionIDMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelineRunsByScheduleID at\n%s with params: %#v", m.ListPipelineRunsByScheduleIDMock.defaultExpectation.expectationOrigins.origin, *m.ListPipelineRunsByScheduleIDMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcListPipelineIDsByConnectionID != nil && afterListPipelineIDsByConnectionIDCounter < 1 {
-		m.t.Errorf("Expected call to RepositoryMock.ListPipelineIDsByConnectionID at\n%s", m.funcListPipelineIDsByConnectionIDOrigin)
+	if m.funcListPipelineRunsByScheduleID != nil && afterListPipelineRunsByScheduleIDCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ListPipelineRunsByScheduleID at\n%s", m.funcListPipelineRunsByScheduleIDOrigin)
 	}
 
-	if !m.ListPipelineIDsByConnectionIDMock.invocationsDone() && afterListPipelineIDsByConnectionIDCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.ListPipelineIDsByConnectionID at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.ListPipelineIDsByConnectionIDMock.expectedInvocations), m.ListPipelineIDsByConnectionIDMock.expectedInvocationsOrigin, afterListPipelineIDsByConnectionIDCounter)
+	if !m.ListPipelineRunsByScheduleIDMock.invocationsDone() && afterListPipelineRunsByScheduleIDCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ListPipelineRunsByScheduleID at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListPipelineRunsByScheduleIDMock.expectedInvocations), m.ListPipelineRunsByScheduleIDMock.expectedInvocationsOrigin, afterListPipelineRunsByScheduleIDCounter)
 	}
 }
 
@@ -15671,27 +21312,370 @@ func (m *RepositoryMock) MinimockListPipelinesAdminDone() bool {
 func (m *RepositoryMock) MinimockListPipelinesAdminInspect() {
 	for _, e := range m.ListPipelinesAdminMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.ListPipelinesAdmin at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelinesAdmin at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterListPipelinesAdminCounter := mm_atomic.LoadUint64(&m.afterListPipelinesAdminCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListPipelinesAdminMock.defaultExpectation != nil && afterListPipelinesAdminCounter < 1 {
+		if m.ListPipelinesAdminMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelinesAdmin at\n%s", m.ListPipelinesAdminMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.ListPipelinesAdmin at\n%s with params: %#v", m.ListPipelinesAdminMock.defaultExpectation.expectationOrigins.origin, *m.ListPipelinesAdminMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcListPipelinesAdmin != nil && afterListPipelinesAdminCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ListPipelinesAdmin at\n%s", m.funcListPipelinesAdminOrigin)
+	}
+
+	if !m.ListPipelinesAdminMock.invocationsDone() && afterListPipelinesAdminCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ListPipelinesAdmin at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListPipelinesAdminMock.expectedInvocations), m.ListPipelinesAdminMock.expectedInvocationsOrigin, afterListPipelinesAdminCounter)
+	}
+}
+
+type mRepositoryMockListRunArtifacts struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockListRunArtifactsExpectation
+	expectations       []*RepositoryMockListRunArtifactsExpectation
+
+	callArgs []*RepositoryMockListRunArtifactsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockListRunArtifactsExpectation specifies expectation struct of the Repository.ListRunArtifacts
+type RepositoryMockListRunArtifactsExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockListRunArtifactsParams
+	paramPtrs          *RepositoryMockListRunArtifactsParamPtrs
+	expectationOrigins RepositoryMockListRunArtifactsExpectationOrigins
+	results            *RepositoryMockListRunArtifactsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockListRunArtifactsParams contains parameters of the Repository.ListRunArtifacts
+type RepositoryMockListRunArtifactsParams struct {
+	ctx                context.Context
+	pipelineTriggerUID uuid.UUID
+}
+
+// RepositoryMockListRunArtifactsParamPtrs contains pointers to parameters of the Repository.ListRunArtifacts
+type RepositoryMockListRunArtifactsParamPtrs struct {
+	ctx                *context.Context
+	pipelineTriggerUID *uuid.UUID
+}
+
+// RepositoryMockListRunArtifactsResults contains results of the Repository.ListRunArtifacts
+type RepositoryMockListRunArtifactsResults struct {
+	ra1 []datamodel.RunArtifact
+	err error
+}
+
+// RepositoryMockListRunArtifactsOrigins contains origins of expectations of the Repository.ListRunArtifacts
+type RepositoryMockListRunArtifactsExpectationOrigins struct {
+	origin                   string
+	originCtx                string
+	originPipelineTriggerUID string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) Optional() *mRepositoryMockListRunArtifacts {
+	mmListRunArtifacts.optional = true
+	return mmListRunArtifacts
+}
+
+// Expect sets up expected params for Repository.ListRunArtifacts
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) Expect(ctx context.Context, pipelineTriggerUID uuid.UUID) *mRepositoryMockListRunArtifacts {
+	if mmListRunArtifacts.mock.funcListRunArtifacts != nil {
+		mmListRunArtifacts.mock.t.Fatalf("RepositoryMock.ListRunArtifacts mock is already set by Set")
+	}
+
+	if mmListRunArtifacts.defaultExpectation == nil {
+		mmListRunArtifacts.defaultExpectation = &RepositoryMockListRunArtifactsExpectation{}
+	}
+
+	if mmListRunArtifacts.defaultExpectation.paramPtrs != nil {
+		mmListRunArtifacts.mock.t.Fatalf("RepositoryMock.ListRunArtifacts mock is already set by ExpectParams functions")
+	}
+
+	mmListRunArtifacts.defaultExpectation.params = &RepositoryMockListRunArtifactsParams{ctx, pipelineTriggerUID}
+	mmListRunArtifacts.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmListRunArtifacts.expectations {
+		if minimock.Equal(e.params, mmListRunArtifacts.defaultExpectation.params) {
+			mmListRunArtifacts.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListRunArtifacts.defaultExpectation.params)
+		}
+	}
+
+	return mmListRunArtifacts
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ListRunArtifacts
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) ExpectCtxParam1(ctx context.Context) *mRepositoryMockListRunArtifacts {
+	if mmListRunArtifacts.mock.funcListRunArtifacts != nil {
+		mmListRunArtifacts.mock.t.Fatalf("RepositoryMock.ListRunArtifacts mock is already set by Set")
+	}
+
+	if mmListRunArtifacts.defaultExpectation == nil {
+		mmListRunArtifacts.defaultExpectation = &RepositoryMockListRunArtifactsExpectation{}
+	}
+
+	if mmListRunArtifacts.defaultExpectation.params != nil {
+		mmListRunArtifacts.mock.t.Fatalf("RepositoryMock.ListRunArtifacts mock is already set by Expect")
+	}
+
+	if mmListRunArtifacts.defaultExpectation.paramPtrs == nil {
+		mmListRunArtifacts.defaultExpectation.paramPtrs = &RepositoryMockListRunArtifactsParamPtrs{}
+	}
+	mmListRunArtifacts.defaultExpectation.paramPtrs.ctx = &ctx
+	mmListRunArtifacts.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmListRunArtifacts
+}
+
+// ExpectPipelineTriggerUIDParam2 sets up expected param pipelineTriggerUID for Repository.ListRunArtifacts
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) ExpectPipelineTriggerUIDParam2(pipelineTriggerUID uuid.UUID) *mRepositoryMockListRunArtifacts {
+	if mmListRunArtifacts.mock.funcListRunArtifacts != nil {
+		mmListRunArtifacts.mock.t.Fatalf("RepositoryMock.ListRunArtifacts mock is already set by Set")
+	}
+
+	if mmListRunArtifacts.defaultExpectation == nil {
+		mmListRunArtifacts.defaultExpectation = &RepositoryMockListRunArtifactsExpectation{}
+	}
+
+	if mmListRunArtifacts.defaultExpectation.params != nil {
+		mmListRunArtifacts.mock.t.Fatalf("RepositoryMock.ListRunArtifacts mock is already set by Expect")
+	}
+
+	if mmListRunArtifacts.defaultExpectation.paramPtrs == nil {
+		mmListRunArtifacts.defaultExpectation.paramPtrs = &RepositoryMockListRunArtifactsParamPtrs{}
+	}
+	mmListRunArtifacts.defaultExpectation.paramPtrs.pipelineTriggerUID = &pipelineTriggerUID
+	mmListRunArtifacts.defaultExpectation.expectationOrigins.originPipelineTriggerUID = minimock.CallerInfo(1)
+
+	return mmListRunArtifacts
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.ListRunArtifacts
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) Inspect(f func(ctx context.Context, pipelineTriggerUID uuid.UUID)) *mRepositoryMockListRunArtifacts {
+	if mmListRunArtifacts.mock.inspectFuncListRunArtifacts != nil {
+		mmListRunArtifacts.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ListRunArtifacts")
+	}
+
+	mmListRunArtifacts.mock.inspectFuncListRunArtifacts = f
+
+	return mmListRunArtifacts
+}
+
+// Return sets up results that will be returned by Repository.ListRunArtifacts
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) Return(ra1 []datamodel.RunArtifact, err error) *RepositoryMock {
+	if mmListRunArtifacts.mock.funcListRunArtifacts != nil {
+		mmListRunArtifacts.mock.t.Fatalf("RepositoryMock.ListRunArtifacts mock is already set by Set")
+	}
+
+	if mmListRunArtifacts.defaultExpectation == nil {
+		mmListRunArtifacts.defaultExpectation = &RepositoryMockListRunArtifactsExpectation{mock: mmListRunArtifacts.mock}
+	}
+	mmListRunArtifacts.defaultExpectation.results = &RepositoryMockListRunArtifactsResults{ra1, err}
+	mmListRunArtifacts.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmListRunArtifacts.mock
+}
+
+// Set uses given function f to mock the Repository.ListRunArtifacts method
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) Set(f func(ctx context.Context, pipelineTriggerUID uuid.UUID) (ra1 []datamodel.RunArtifact, err error)) *RepositoryMock {
+	if mmListRunArtifacts.defaultExpectation != nil {
+		mmListRunArtifacts.mock.t.Fatalf("Default expectation is already set for the Repository.ListRunArtifacts method")
+	}
+
+	if len(mmListRunArtifacts.expectations) > 0 {
+		mmListRunArtifacts.mock.t.Fatalf("Some expectations are already set for the Repository.ListRunArtifacts method")
+	}
+
+	mmListRunArtifacts.mock.funcListRunArtifacts = f
+	mmListRunArtifacts.mock.funcListRunArtifactsOrigin = minimock.CallerInfo(1)
+	return mmListRunArtifacts.mock
+}
+
+// When sets expectation for the Repository.ListRunArtifacts which will trigger the result defined by the following
+// Then helper
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) When(ctx context.Context, pipelineTriggerUID uuid.UUID) *RepositoryMockListRunArtifactsExpectation {
+	if mmListRunArtifacts.mock.funcListRunArtifacts != nil {
+		mmListRunArtifacts.mock.t.Fatalf("RepositoryMock.ListRunArtifacts mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockListRunArtifactsExpectation{
+		mock:               mmListRunArtifacts.mock,
+		params:             &RepositoryMockListRunArtifactsParams{ctx, pipelineTriggerUID},
+		expectationOrigins: RepositoryMockListRunArtifactsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmListRunArtifacts.expectations = append(mmListRunArtifacts.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.ListRunArtifacts return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockListRunArtifactsExpectation) Then(ra1 []datamodel.RunArtifact, err error) *RepositoryMock {
+	e.results = &RepositoryMockListRunArtifactsResults{ra1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.ListRunArtifacts should be invoked
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) Times(n uint64) *mRepositoryMockListRunArtifacts {
+	if n == 0 {
+		mmListRunArtifacts.mock.t.Fatalf("Times of RepositoryMock.ListRunArtifacts mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmListRunArtifacts.expectedInvocations, n)
+	mmListRunArtifacts.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmListRunArtifacts
+}
+
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) invocationsDone() bool {
+	if len(mmListRunArtifacts.expectations) == 0 && mmListRunArtifacts.defaultExpectation == nil && mmListRunArtifacts.mock.funcListRunArtifacts == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmListRunArtifacts.mock.afterListRunArtifactsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmListRunArtifacts.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ListRunArtifacts implements mm_repository.Repository
+func (mmListRunArtifacts *RepositoryMock) ListRunArtifacts(ctx context.Context, pipelineTriggerUID uuid.UUID) (ra1 []datamodel.RunArtifact, err error) {
+	mm_atomic.AddUint64(&mmListRunArtifacts.beforeListRunArtifactsCounter, 1)
+	defer mm_atomic.AddUint64(&mmListRunArtifacts.afterListRunArtifactsCounter, 1)
+
+	mmListRunArtifacts.t.Helper()
+
+	if mmListRunArtifacts.inspectFuncListRunArtifacts != nil {
+		mmListRunArtifacts.inspectFuncListRunArtifacts(ctx, pipelineTriggerUID)
+	}
+
+	mm_params := RepositoryMockListRunArtifactsParams{ctx, pipelineTriggerUID}
+
+	// Record call args
+	mmListRunArtifacts.ListRunArtifactsMock.mutex.Lock()
+	mmListRunArtifacts.ListRunArtifactsMock.callArgs = append(mmListRunArtifacts.ListRunArtifactsMock.callArgs, &mm_params)
+	mmListRunArtifacts.ListRunArtifactsMock.mutex.Unlock()
+
+	for _, e := range mmListRunArtifacts.ListRunArtifactsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ra1, e.results.err
+		}
+	}
+
+	if mmListRunArtifacts.ListRunArtifactsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmListRunArtifacts.ListRunArtifactsMock.defaultExpectation.Counter, 1)
+		mm_want := mmListRunArtifacts.ListRunArtifactsMock.defaultExpectation.params
+		mm_want_ptrs := mmListRunArtifacts.ListRunArtifactsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockListRunArtifactsParams{ctx, pipelineTriggerUID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmListRunArtifacts.t.Errorf("RepositoryMock.ListRunArtifacts got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListRunArtifacts.ListRunArtifactsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.pipelineTriggerUID != nil && !minimock.Equal(*mm_want_ptrs.pipelineTriggerUID, mm_got.pipelineTriggerUID) {
+				mmListRunArtifacts.t.Errorf("RepositoryMock.ListRunArtifacts got unexpected parameter pipelineTriggerUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListRunArtifacts.ListRunArtifactsMock.defaultExpectation.expectationOrigins.originPipelineTriggerUID, *mm_want_ptrs.pipelineTriggerUID, mm_got.pipelineTriggerUID, minimock.Diff(*mm_want_ptrs.pipelineTriggerUID, mm_got.pipelineTriggerUID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmListRunArtifacts.t.Errorf("RepositoryMock.ListRunArtifacts got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmListRunArtifacts.ListRunArtifactsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmListRunArtifacts.ListRunArtifactsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmListRunArtifacts.t.Fatal("No results are set for the RepositoryMock.ListRunArtifacts")
+		}
+		return (*mm_results).ra1, (*mm_results).err
+	}
+	if mmListRunArtifacts.funcListRunArtifacts != nil {
+		return mmListRunArtifacts.funcListRunArtifacts(ctx, pipelineTriggerUID)
+	}
+	mmListRunArtifacts.t.Fatalf("Unexpected call to RepositoryMock.ListRunArtifacts. %v %v", ctx, pipelineTriggerUID)
+	return
+}
+
+// ListRunArtifactsAfterCounter returns a count of finished RepositoryMock.ListRunArtifacts invocations
+func (mmListRunArtifacts *RepositoryMock) ListRunArtifactsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListRunArtifacts.afterListRunArtifactsCounter)
+}
+
+// ListRunArtifactsBeforeCounter returns a count of RepositoryMock.ListRunArtifacts invocations
+func (mmListRunArtifacts *RepositoryMock) ListRunArtifactsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListRunArtifacts.beforeListRunArtifactsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.ListRunArtifacts.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmListRunArtifacts *mRepositoryMockListRunArtifacts) Calls() []*RepositoryMockListRunArtifactsParams {
+	mmListRunArtifacts.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockListRunArtifactsParams, len(mmListRunArtifacts.callArgs))
+	copy(argCopy, mmListRunArtifacts.callArgs)
+
+	mmListRunArtifacts.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListRunArtifactsDone returns true if the count of the ListRunArtifacts invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockListRunArtifactsDone() bool {
+	if m.ListRunArtifactsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListRunArtifactsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListRunArtifactsMock.invocationsDone()
+}
+
+// MinimockListRunArtifactsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockListRunArtifactsInspect() {
+	for _, e := range m.ListRunArtifactsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.ListRunArtifacts at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterListPipelinesAdminCounter := mm_atomic.LoadUint64(&m.afterListPipelinesAdminCounter)
+	afterListRunArtifactsCounter := mm_atomic.LoadUint64(&m.afterListRunArtifactsCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.ListPipelinesAdminMock.defaultExpectation != nil && afterListPipelinesAdminCounter < 1 {
-		if m.ListPipelinesAdminMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to RepositoryMock.ListPipelinesAdmin at\n%s", m.ListPipelinesAdminMock.defaultExpectation.returnOrigin)
+	if m.ListRunArtifactsMock.defaultExpectation != nil && afterListRunArtifactsCounter < 1 {
+		if m.ListRunArtifactsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.ListRunArtifacts at\n%s", m.ListRunArtifactsMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.ListPipelinesAdmin at\n%s with params: %#v", m.ListPipelinesAdminMock.defaultExpectation.expectationOrigins.origin, *m.ListPipelinesAdminMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.ListRunArtifacts at\n%s with params: %#v", m.ListRunArtifactsMock.defaultExpectation.expectationOrigins.origin, *m.ListRunArtifactsMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcListPipelinesAdmin != nil && afterListPipelinesAdminCounter < 1 {
-		m.t.Errorf("Expected call to RepositoryMock.ListPipelinesAdmin at\n%s", m.funcListPipelinesAdminOrigin)
+	if m.funcListRunArtifacts != nil && afterListRunArtifactsCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.ListRunArtifacts at\n%s", m.funcListRunArtifactsOrigin)
 	}
 
-	if !m.ListPipelinesAdminMock.invocationsDone() && afterListPipelinesAdminCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.ListPipelinesAdmin at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.ListPipelinesAdminMock.expectedInvocations), m.ListPipelinesAdminMock.expectedInvocationsOrigin, afterListPipelinesAdminCounter)
+	if !m.ListRunArtifactsMock.invocationsDone() && afterListRunArtifactsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ListRunArtifacts at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListRunArtifactsMock.expectedInvocations), m.ListRunArtifactsMock.expectedInvocationsOrigin, afterListRunArtifactsCounter)
 	}
 }
 
@@ -15880,131 +21864,504 @@ func (mmPinUser *mRepositoryMockPinUser) invocationsDone() bool {
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// PinUser implements mm_repository.Repository
-func (mmPinUser *RepositoryMock) PinUser(ctx context.Context, table string) {
-	mm_atomic.AddUint64(&mmPinUser.beforePinUserCounter, 1)
-	defer mm_atomic.AddUint64(&mmPinUser.afterPinUserCounter, 1)
+// PinUser implements mm_repository.Repository
+func (mmPinUser *RepositoryMock) PinUser(ctx context.Context, table string) {
+	mm_atomic.AddUint64(&mmPinUser.beforePinUserCounter, 1)
+	defer mm_atomic.AddUint64(&mmPinUser.afterPinUserCounter, 1)
+
+	mmPinUser.t.Helper()
+
+	if mmPinUser.inspectFuncPinUser != nil {
+		mmPinUser.inspectFuncPinUser(ctx, table)
+	}
+
+	mm_params := RepositoryMockPinUserParams{ctx, table}
+
+	// Record call args
+	mmPinUser.PinUserMock.mutex.Lock()
+	mmPinUser.PinUserMock.callArgs = append(mmPinUser.PinUserMock.callArgs, &mm_params)
+	mmPinUser.PinUserMock.mutex.Unlock()
+
+	for _, e := range mmPinUser.PinUserMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmPinUser.PinUserMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmPinUser.PinUserMock.defaultExpectation.Counter, 1)
+		mm_want := mmPinUser.PinUserMock.defaultExpectation.params
+		mm_want_ptrs := mmPinUser.PinUserMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockPinUserParams{ctx, table}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmPinUser.t.Errorf("RepositoryMock.PinUser got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmPinUser.PinUserMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.table != nil && !minimock.Equal(*mm_want_ptrs.table, mm_got.table) {
+				mmPinUser.t.Errorf("RepositoryMock.PinUser got unexpected parameter table, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmPinUser.PinUserMock.defaultExpectation.expectationOrigins.originTable, *mm_want_ptrs.table, mm_got.table, minimock.Diff(*mm_want_ptrs.table, mm_got.table))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmPinUser.t.Errorf("RepositoryMock.PinUser got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmPinUser.PinUserMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmPinUser.funcPinUser != nil {
+		mmPinUser.funcPinUser(ctx, table)
+		return
+	}
+	mmPinUser.t.Fatalf("Unexpected call to RepositoryMock.PinUser. %v %v", ctx, table)
+
+}
+
+// PinUserAfterCounter returns a count of finished RepositoryMock.PinUser invocations
+func (mmPinUser *RepositoryMock) PinUserAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmPinUser.afterPinUserCounter)
+}
+
+// PinUserBeforeCounter returns a count of RepositoryMock.PinUser invocations
+func (mmPinUser *RepositoryMock) PinUserBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmPinUser.beforePinUserCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.PinUser.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmPinUser *mRepositoryMockPinUser) Calls() []*RepositoryMockPinUserParams {
+	mmPinUser.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockPinUserParams, len(mmPinUser.callArgs))
+	copy(argCopy, mmPinUser.callArgs)
+
+	mmPinUser.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockPinUserDone returns true if the count of the PinUser invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockPinUserDone() bool {
+	if m.PinUserMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.PinUserMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.PinUserMock.invocationsDone()
+}
+
+// MinimockPinUserInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockPinUserInspect() {
+	for _, e := range m.PinUserMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.PinUser at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterPinUserCounter := mm_atomic.LoadUint64(&m.afterPinUserCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.PinUserMock.defaultExpectation != nil && afterPinUserCounter < 1 {
+		if m.PinUserMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.PinUser at\n%s", m.PinUserMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.PinUser at\n%s with params: %#v", m.PinUserMock.defaultExpectation.expectationOrigins.origin, *m.PinUserMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcPinUser != nil && afterPinUserCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.PinUser at\n%s", m.funcPinUserOrigin)
+	}
+
+	if !m.PinUserMock.invocationsDone() && afterPinUserCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.PinUser at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.PinUserMock.expectedInvocations), m.PinUserMock.expectedInvocationsOrigin, afterPinUserCounter)
+	}
+}
+
+type mRepositoryMockSyncPipelineCanaries struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockSyncPipelineCanariesExpectation
+	expectations       []*RepositoryMockSyncPipelineCanariesExpectation
+
+	callArgs []*RepositoryMockSyncPipelineCanariesParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockSyncPipelineCanariesExpectation specifies expectation struct of the Repository.SyncPipelineCanaries
+type RepositoryMockSyncPipelineCanariesExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockSyncPipelineCanariesParams
+	paramPtrs          *RepositoryMockSyncPipelineCanariesParamPtrs
+	expectationOrigins RepositoryMockSyncPipelineCanariesExpectationOrigins
+	results            *RepositoryMockSyncPipelineCanariesResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockSyncPipelineCanariesParams contains parameters of the Repository.SyncPipelineCanaries
+type RepositoryMockSyncPipelineCanariesParams struct {
+	ctx         context.Context
+	pipelineUID uuid.UUID
+	canaries    []*datamodel.PipelineCanary
+}
+
+// RepositoryMockSyncPipelineCanariesParamPtrs contains pointers to parameters of the Repository.SyncPipelineCanaries
+type RepositoryMockSyncPipelineCanariesParamPtrs struct {
+	ctx         *context.Context
+	pipelineUID *uuid.UUID
+	canaries    *[]*datamodel.PipelineCanary
+}
+
+// RepositoryMockSyncPipelineCanariesResults contains results of the Repository.SyncPipelineCanaries
+type RepositoryMockSyncPipelineCanariesResults struct {
+	err error
+}
+
+// RepositoryMockSyncPipelineCanariesOrigins contains origins of expectations of the Repository.SyncPipelineCanaries
+type RepositoryMockSyncPipelineCanariesExpectationOrigins struct {
+	origin            string
+	originCtx         string
+	originPipelineUID string
+	originCanaries    string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) Optional() *mRepositoryMockSyncPipelineCanaries {
+	mmSyncPipelineCanaries.optional = true
+	return mmSyncPipelineCanaries
+}
+
+// Expect sets up expected params for Repository.SyncPipelineCanaries
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) Expect(ctx context.Context, pipelineUID uuid.UUID, canaries []*datamodel.PipelineCanary) *mRepositoryMockSyncPipelineCanaries {
+	if mmSyncPipelineCanaries.mock.funcSyncPipelineCanaries != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("RepositoryMock.SyncPipelineCanaries mock is already set by Set")
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation == nil {
+		mmSyncPipelineCanaries.defaultExpectation = &RepositoryMockSyncPipelineCanariesExpectation{}
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation.paramPtrs != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("RepositoryMock.SyncPipelineCanaries mock is already set by ExpectParams functions")
+	}
+
+	mmSyncPipelineCanaries.defaultExpectation.params = &RepositoryMockSyncPipelineCanariesParams{ctx, pipelineUID, canaries}
+	mmSyncPipelineCanaries.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmSyncPipelineCanaries.expectations {
+		if minimock.Equal(e.params, mmSyncPipelineCanaries.defaultExpectation.params) {
+			mmSyncPipelineCanaries.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSyncPipelineCanaries.defaultExpectation.params)
+		}
+	}
+
+	return mmSyncPipelineCanaries
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.SyncPipelineCanaries
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) ExpectCtxParam1(ctx context.Context) *mRepositoryMockSyncPipelineCanaries {
+	if mmSyncPipelineCanaries.mock.funcSyncPipelineCanaries != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("RepositoryMock.SyncPipelineCanaries mock is already set by Set")
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation == nil {
+		mmSyncPipelineCanaries.defaultExpectation = &RepositoryMockSyncPipelineCanariesExpectation{}
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation.params != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("RepositoryMock.SyncPipelineCanaries mock is already set by Expect")
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation.paramPtrs == nil {
+		mmSyncPipelineCanaries.defaultExpectation.paramPtrs = &RepositoryMockSyncPipelineCanariesParamPtrs{}
+	}
+	mmSyncPipelineCanaries.defaultExpectation.paramPtrs.ctx = &ctx
+	mmSyncPipelineCanaries.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmSyncPipelineCanaries
+}
+
+// ExpectPipelineUIDParam2 sets up expected param pipelineUID for Repository.SyncPipelineCanaries
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) ExpectPipelineUIDParam2(pipelineUID uuid.UUID) *mRepositoryMockSyncPipelineCanaries {
+	if mmSyncPipelineCanaries.mock.funcSyncPipelineCanaries != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("RepositoryMock.SyncPipelineCanaries mock is already set by Set")
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation == nil {
+		mmSyncPipelineCanaries.defaultExpectation = &RepositoryMockSyncPipelineCanariesExpectation{}
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation.params != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("RepositoryMock.SyncPipelineCanaries mock is already set by Expect")
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation.paramPtrs == nil {
+		mmSyncPipelineCanaries.defaultExpectation.paramPtrs = &RepositoryMockSyncPipelineCanariesParamPtrs{}
+	}
+	mmSyncPipelineCanaries.defaultExpectation.paramPtrs.pipelineUID = &pipelineUID
+	mmSyncPipelineCanaries.defaultExpectation.expectationOrigins.originPipelineUID = minimock.CallerInfo(1)
+
+	return mmSyncPipelineCanaries
+}
+
+// ExpectCanariesParam3 sets up expected param canaries for Repository.SyncPipelineCanaries
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) ExpectCanariesParam3(canaries []*datamodel.PipelineCanary) *mRepositoryMockSyncPipelineCanaries {
+	if mmSyncPipelineCanaries.mock.funcSyncPipelineCanaries != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("RepositoryMock.SyncPipelineCanaries mock is already set by Set")
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation == nil {
+		mmSyncPipelineCanaries.defaultExpectation = &RepositoryMockSyncPipelineCanariesExpectation{}
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation.params != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("RepositoryMock.SyncPipelineCanaries mock is already set by Expect")
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation.paramPtrs == nil {
+		mmSyncPipelineCanaries.defaultExpectation.paramPtrs = &RepositoryMockSyncPipelineCanariesParamPtrs{}
+	}
+	mmSyncPipelineCanaries.defaultExpectation.paramPtrs.canaries = &canaries
+	mmSyncPipelineCanaries.defaultExpectation.expectationOrigins.originCanaries = minimock.CallerInfo(1)
+
+	return mmSyncPipelineCanaries
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.SyncPipelineCanaries
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) Inspect(f func(ctx context.Context, pipelineUID uuid.UUID, canaries []*datamodel.PipelineCanary)) *mRepositoryMockSyncPipelineCanaries {
+	if mmSyncPipelineCanaries.mock.inspectFuncSyncPipelineCanaries != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("Inspect function is already set for RepositoryMock.SyncPipelineCanaries")
+	}
+
+	mmSyncPipelineCanaries.mock.inspectFuncSyncPipelineCanaries = f
+
+	return mmSyncPipelineCanaries
+}
+
+// Return sets up results that will be returned by Repository.SyncPipelineCanaries
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) Return(err error) *RepositoryMock {
+	if mmSyncPipelineCanaries.mock.funcSyncPipelineCanaries != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("RepositoryMock.SyncPipelineCanaries mock is already set by Set")
+	}
+
+	if mmSyncPipelineCanaries.defaultExpectation == nil {
+		mmSyncPipelineCanaries.defaultExpectation = &RepositoryMockSyncPipelineCanariesExpectation{mock: mmSyncPipelineCanaries.mock}
+	}
+	mmSyncPipelineCanaries.defaultExpectation.results = &RepositoryMockSyncPipelineCanariesResults{err}
+	mmSyncPipelineCanaries.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmSyncPipelineCanaries.mock
+}
+
+// Set uses given function f to mock the Repository.SyncPipelineCanaries method
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) Set(f func(ctx context.Context, pipelineUID uuid.UUID, canaries []*datamodel.PipelineCanary) (err error)) *RepositoryMock {
+	if mmSyncPipelineCanaries.defaultExpectation != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("Default expectation is already set for the Repository.SyncPipelineCanaries method")
+	}
+
+	if len(mmSyncPipelineCanaries.expectations) > 0 {
+		mmSyncPipelineCanaries.mock.t.Fatalf("Some expectations are already set for the Repository.SyncPipelineCanaries method")
+	}
+
+	mmSyncPipelineCanaries.mock.funcSyncPipelineCanaries = f
+	mmSyncPipelineCanaries.mock.funcSyncPipelineCanariesOrigin = minimock.CallerInfo(1)
+	return mmSyncPipelineCanaries.mock
+}
+
+// When sets expectation for the Repository.SyncPipelineCanaries which will trigger the result defined by the following
+// Then helper
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) When(ctx context.Context, pipelineUID uuid.UUID, canaries []*datamodel.PipelineCanary) *RepositoryMockSyncPipelineCanariesExpectation {
+	if mmSyncPipelineCanaries.mock.funcSyncPipelineCanaries != nil {
+		mmSyncPipelineCanaries.mock.t.Fatalf("RepositoryMock.SyncPipelineCanaries mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockSyncPipelineCanariesExpectation{
+		mock:               mmSyncPipelineCanaries.mock,
+		params:             &RepositoryMockSyncPipelineCanariesParams{ctx, pipelineUID, canaries},
+		expectationOrigins: RepositoryMockSyncPipelineCanariesExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmSyncPipelineCanaries.expectations = append(mmSyncPipelineCanaries.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.SyncPipelineCanaries return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockSyncPipelineCanariesExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockSyncPipelineCanariesResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.SyncPipelineCanaries should be invoked
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) Times(n uint64) *mRepositoryMockSyncPipelineCanaries {
+	if n == 0 {
+		mmSyncPipelineCanaries.mock.t.Fatalf("Times of RepositoryMock.SyncPipelineCanaries mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmSyncPipelineCanaries.expectedInvocations, n)
+	mmSyncPipelineCanaries.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmSyncPipelineCanaries
+}
+
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) invocationsDone() bool {
+	if len(mmSyncPipelineCanaries.expectations) == 0 && mmSyncPipelineCanaries.defaultExpectation == nil && mmSyncPipelineCanaries.mock.funcSyncPipelineCanaries == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmSyncPipelineCanaries.mock.afterSyncPipelineCanariesCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSyncPipelineCanaries.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// SyncPipelineCanaries implements mm_repository.Repository
+func (mmSyncPipelineCanaries *RepositoryMock) SyncPipelineCanaries(ctx context.Context, pipelineUID uuid.UUID, canaries []*datamodel.PipelineCanary) (err error) {
+	mm_atomic.AddUint64(&mmSyncPipelineCanaries.beforeSyncPipelineCanariesCounter, 1)
+	defer mm_atomic.AddUint64(&mmSyncPipelineCanaries.afterSyncPipelineCanariesCounter, 1)
 
-	mmPinUser.t.Helper()
+	mmSyncPipelineCanaries.t.Helper()
 
-	if mmPinUser.inspectFuncPinUser != nil {
-		mmPinUser.inspectFuncPinUser(ctx, table)
+	if mmSyncPipelineCanaries.inspectFuncSyncPipelineCanaries != nil {
+		mmSyncPipelineCanaries.inspectFuncSyncPipelineCanaries(ctx, pipelineUID, canaries)
 	}
 
-	mm_params := RepositoryMockPinUserParams{ctx, table}
+	mm_params := RepositoryMockSyncPipelineCanariesParams{ctx, pipelineUID, canaries}
 
 	// Record call args
-	mmPinUser.PinUserMock.mutex.Lock()
-	mmPinUser.PinUserMock.callArgs = append(mmPinUser.PinUserMock.callArgs, &mm_params)
-	mmPinUser.PinUserMock.mutex.Unlock()
+	mmSyncPipelineCanaries.SyncPipelineCanariesMock.mutex.Lock()
+	mmSyncPipelineCanaries.SyncPipelineCanariesMock.callArgs = append(mmSyncPipelineCanaries.SyncPipelineCanariesMock.callArgs, &mm_params)
+	mmSyncPipelineCanaries.SyncPipelineCanariesMock.mutex.Unlock()
 
-	for _, e := range mmPinUser.PinUserMock.expectations {
+	for _, e := range mmSyncPipelineCanaries.SyncPipelineCanariesMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return
+			return e.results.err
 		}
 	}
 
-	if mmPinUser.PinUserMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmPinUser.PinUserMock.defaultExpectation.Counter, 1)
-		mm_want := mmPinUser.PinUserMock.defaultExpectation.params
-		mm_want_ptrs := mmPinUser.PinUserMock.defaultExpectation.paramPtrs
+	if mmSyncPipelineCanaries.SyncPipelineCanariesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSyncPipelineCanaries.SyncPipelineCanariesMock.defaultExpectation.Counter, 1)
+		mm_want := mmSyncPipelineCanaries.SyncPipelineCanariesMock.defaultExpectation.params
+		mm_want_ptrs := mmSyncPipelineCanaries.SyncPipelineCanariesMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockPinUserParams{ctx, table}
+		mm_got := RepositoryMockSyncPipelineCanariesParams{ctx, pipelineUID, canaries}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmPinUser.t.Errorf("RepositoryMock.PinUser got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmPinUser.PinUserMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmSyncPipelineCanaries.t.Errorf("RepositoryMock.SyncPipelineCanaries got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSyncPipelineCanaries.SyncPipelineCanariesMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.table != nil && !minimock.Equal(*mm_want_ptrs.table, mm_got.table) {
-				mmPinUser.t.Errorf("RepositoryMock.PinUser got unexpected parameter table, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmPinUser.PinUserMock.defaultExpectation.expectationOrigins.originTable, *mm_want_ptrs.table, mm_got.table, minimock.Diff(*mm_want_ptrs.table, mm_got.table))
+			if mm_want_ptrs.pipelineUID != nil && !minimock.Equal(*mm_want_ptrs.pipelineUID, mm_got.pipelineUID) {
+				mmSyncPipelineCanaries.t.Errorf("RepositoryMock.SyncPipelineCanaries got unexpected parameter pipelineUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSyncPipelineCanaries.SyncPipelineCanariesMock.defaultExpectation.expectationOrigins.originPipelineUID, *mm_want_ptrs.pipelineUID, mm_got.pipelineUID, minimock.Diff(*mm_want_ptrs.pipelineUID, mm_got.pipelineUID))
+			}
+
+			if mm_want_ptrs.canaries != nil && !minimock.Equal(*mm_want_ptrs.canaries, mm_got.canaries) {
+				mmSyncPipelineCanaries.t.Errorf("RepositoryMock.SyncPipelineCanaries got unexpected parameter canaries, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSyncPipelineCanaries.SyncPipelineCanariesMock.defaultExpectation.expectationOrigins.originCanaries, *mm_want_ptrs.canaries, mm_got.canaries, minimock.Diff(*mm_want_ptrs.canaries, mm_got.canaries))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmPinUser.t.Errorf("RepositoryMock.PinUser got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmPinUser.PinUserMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmSyncPipelineCanaries.t.Errorf("RepositoryMock.SyncPipelineCanaries got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmSyncPipelineCanaries.SyncPipelineCanariesMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		return
-
+		mm_results := mmSyncPipelineCanaries.SyncPipelineCanariesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSyncPipelineCanaries.t.Fatal("No results are set for the RepositoryMock.SyncPipelineCanaries")
+		}
+		return (*mm_results).err
 	}
-	if mmPinUser.funcPinUser != nil {
-		mmPinUser.funcPinUser(ctx, table)
-		return
+	if mmSyncPipelineCanaries.funcSyncPipelineCanaries != nil {
+		return mmSyncPipelineCanaries.funcSyncPipelineCanaries(ctx, pipelineUID, canaries)
 	}
-	mmPinUser.t.Fatalf("Unexpected call to RepositoryMock.PinUser. %v %v", ctx, table)
-
+	mmSyncPipelineCanaries.t.Fatalf("Unexpected call to RepositoryMock.SyncPipelineCanaries. %v %v %v", ctx, pipelineUID, canaries)
+	return
 }
 
-// PinUserAfterCounter returns a count of finished RepositoryMock.PinUser invocations
-func (mmPinUser *RepositoryMock) PinUserAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmPinUser.afterPinUserCounter)
+// SyncPipelineCanariesAfterCounter returns a count of finished RepositoryMock.SyncPipelineCanaries invocations
+func (mmSyncPipelineCanaries *RepositoryMock) SyncPipelineCanariesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSyncPipelineCanaries.afterSyncPipelineCanariesCounter)
 }
 
-// PinUserBeforeCounter returns a count of RepositoryMock.PinUser invocations
-func (mmPinUser *RepositoryMock) PinUserBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmPinUser.beforePinUserCounter)
+// SyncPipelineCanariesBeforeCounter returns a count of RepositoryMock.SyncPipelineCanaries invocations
+func (mmSyncPipelineCanaries *RepositoryMock) SyncPipelineCanariesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSyncPipelineCanaries.beforeSyncPipelineCanariesCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.PinUser.
+// Calls returns a list of arguments used in each call to RepositoryMock.SyncPipelineCanaries.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmPinUser *mRepositoryMockPinUser) Calls() []*RepositoryMockPinUserParams {
-	mmPinUser.mutex.RLock()
+func (mmSyncPipelineCanaries *mRepositoryMockSyncPipelineCanaries) Calls() []*RepositoryMockSyncPipelineCanariesParams {
+	mmSyncPipelineCanaries.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockPinUserParams, len(mmPinUser.callArgs))
-	copy(argCopy, mmPinUser.callArgs)
+	argCopy := make([]*RepositoryMockSyncPipelineCanariesParams, len(mmSyncPipelineCanaries.callArgs))
+	copy(argCopy, mmSyncPipelineCanaries.callArgs)
 
-	mmPinUser.mutex.RUnlock()
+	mmSyncPipelineCanaries.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockPinUserDone returns true if the count of the PinUser invocations corresponds
+// MinimockSyncPipelineCanariesDone returns true if the count of the SyncPipelineCanaries invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockPinUserDone() bool {
-	if m.PinUserMock.optional {
+func (m *RepositoryMock) MinimockSyncPipelineCanariesDone() bool {
+	if m.SyncPipelineCanariesMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.PinUserMock.expectations {
+	for _, e := range m.SyncPipelineCanariesMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.PinUserMock.invocationsDone()
+	return m.SyncPipelineCanariesMock.invocationsDone()
 }
 
-// MinimockPinUserInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockPinUserInspect() {
-	for _, e := range m.PinUserMock.expectations {
+// MinimockSyncPipelineCanariesInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockSyncPipelineCanariesInspect() {
+	for _, e := range m.SyncPipelineCanariesMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.PinUser at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.SyncPipelineCanaries at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterPinUserCounter := mm_atomic.LoadUint64(&m.afterPinUserCounter)
+	afterSyncPipelineCanariesCounter := mm_atomic.LoadUint64(&m.afterSyncPipelineCanariesCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.PinUserMock.defaultExpectation != nil && afterPinUserCounter < 1 {
-		if m.PinUserMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to RepositoryMock.PinUser at\n%s", m.PinUserMock.defaultExpectation.returnOrigin)
+	if m.SyncPipelineCanariesMock.defaultExpectation != nil && afterSyncPipelineCanariesCounter < 1 {
+		if m.SyncPipelineCanariesMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.SyncPipelineCanaries at\n%s", m.SyncPipelineCanariesMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.PinUser at\n%s with params: %#v", m.PinUserMock.defaultExpectation.expectationOrigins.origin, *m.PinUserMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.SyncPipelineCanaries at\n%s with params: %#v", m.SyncPipelineCanariesMock.defaultExpectation.expectationOrigins.origin, *m.SyncPipelineCanariesMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcPinUser != nil && afterPinUserCounter < 1 {
-		m.t.Errorf("Expected call to RepositoryMock.PinUser at\n%s", m.funcPinUserOrigin)
+	if m.funcSyncPipelineCanaries != nil && afterSyncPipelineCanariesCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.SyncPipelineCanaries at\n%s", m.funcSyncPipelineCanariesOrigin)
 	}
 
-	if !m.PinUserMock.invocationsDone() && afterPinUserCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.PinUser at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.PinUserMock.expectedInvocations), m.PinUserMock.expectedInvocationsOrigin, afterPinUserCounter)
+	if !m.SyncPipelineCanariesMock.invocationsDone() && afterSyncPipelineCanariesCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.SyncPipelineCanaries at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.SyncPipelineCanariesMock.expectedInvocations), m.SyncPipelineCanariesMock.expectedInvocationsOrigin, afterSyncPipelineCanariesCounter)
 	}
 }
 
@@ -16710,17 +23067,390 @@ func (m *RepositoryMock) MinimockUpdateComponentRunInspect() {
 		if m.UpdateComponentRunMock.defaultExpectation.params == nil {
 			m.t.Errorf("Expected call to RepositoryMock.UpdateComponentRun at\n%s", m.UpdateComponentRunMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.UpdateComponentRun at\n%s with params: %#v", m.UpdateComponentRunMock.defaultExpectation.expectationOrigins.origin, *m.UpdateComponentRunMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.UpdateComponentRun at\n%s with params: %#v", m.UpdateComponentRunMock.defaultExpectation.expectationOrigins.origin, *m.UpdateComponentRunMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcUpdateComponentRun != nil && afterUpdateComponentRunCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.UpdateComponentRun at\n%s", m.funcUpdateComponentRunOrigin)
+	}
+
+	if !m.UpdateComponentRunMock.invocationsDone() && afterUpdateComponentRunCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.UpdateComponentRun at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.UpdateComponentRunMock.expectedInvocations), m.UpdateComponentRunMock.expectedInvocationsOrigin, afterUpdateComponentRunCounter)
+	}
+}
+
+type mRepositoryMockUpdateEventSourceCheckpoint struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockUpdateEventSourceCheckpointExpectation
+	expectations       []*RepositoryMockUpdateEventSourceCheckpointExpectation
+
+	callArgs []*RepositoryMockUpdateEventSourceCheckpointParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockUpdateEventSourceCheckpointExpectation specifies expectation struct of the Repository.UpdateEventSourceCheckpoint
+type RepositoryMockUpdateEventSourceCheckpointExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockUpdateEventSourceCheckpointParams
+	paramPtrs          *RepositoryMockUpdateEventSourceCheckpointParamPtrs
+	expectationOrigins RepositoryMockUpdateEventSourceCheckpointExpectationOrigins
+	results            *RepositoryMockUpdateEventSourceCheckpointResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockUpdateEventSourceCheckpointParams contains parameters of the Repository.UpdateEventSourceCheckpoint
+type RepositoryMockUpdateEventSourceCheckpointParams struct {
+	ctx        context.Context
+	uid        uuid.UUID
+	checkpoint []byte
+}
+
+// RepositoryMockUpdateEventSourceCheckpointParamPtrs contains pointers to parameters of the Repository.UpdateEventSourceCheckpoint
+type RepositoryMockUpdateEventSourceCheckpointParamPtrs struct {
+	ctx        *context.Context
+	uid        *uuid.UUID
+	checkpoint *[]byte
+}
+
+// RepositoryMockUpdateEventSourceCheckpointResults contains results of the Repository.UpdateEventSourceCheckpoint
+type RepositoryMockUpdateEventSourceCheckpointResults struct {
+	err error
+}
+
+// RepositoryMockUpdateEventSourceCheckpointOrigins contains origins of expectations of the Repository.UpdateEventSourceCheckpoint
+type RepositoryMockUpdateEventSourceCheckpointExpectationOrigins struct {
+	origin           string
+	originCtx        string
+	originUid        string
+	originCheckpoint string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) Optional() *mRepositoryMockUpdateEventSourceCheckpoint {
+	mmUpdateEventSourceCheckpoint.optional = true
+	return mmUpdateEventSourceCheckpoint
+}
+
+// Expect sets up expected params for Repository.UpdateEventSourceCheckpoint
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) Expect(ctx context.Context, uid uuid.UUID, checkpoint []byte) *mRepositoryMockUpdateEventSourceCheckpoint {
+	if mmUpdateEventSourceCheckpoint.mock.funcUpdateEventSourceCheckpoint != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("RepositoryMock.UpdateEventSourceCheckpoint mock is already set by Set")
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation == nil {
+		mmUpdateEventSourceCheckpoint.defaultExpectation = &RepositoryMockUpdateEventSourceCheckpointExpectation{}
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation.paramPtrs != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("RepositoryMock.UpdateEventSourceCheckpoint mock is already set by ExpectParams functions")
+	}
+
+	mmUpdateEventSourceCheckpoint.defaultExpectation.params = &RepositoryMockUpdateEventSourceCheckpointParams{ctx, uid, checkpoint}
+	mmUpdateEventSourceCheckpoint.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmUpdateEventSourceCheckpoint.expectations {
+		if minimock.Equal(e.params, mmUpdateEventSourceCheckpoint.defaultExpectation.params) {
+			mmUpdateEventSourceCheckpoint.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUpdateEventSourceCheckpoint.defaultExpectation.params)
+		}
+	}
+
+	return mmUpdateEventSourceCheckpoint
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.UpdateEventSourceCheckpoint
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) ExpectCtxParam1(ctx context.Context) *mRepositoryMockUpdateEventSourceCheckpoint {
+	if mmUpdateEventSourceCheckpoint.mock.funcUpdateEventSourceCheckpoint != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("RepositoryMock.UpdateEventSourceCheckpoint mock is already set by Set")
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation == nil {
+		mmUpdateEventSourceCheckpoint.defaultExpectation = &RepositoryMockUpdateEventSourceCheckpointExpectation{}
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation.params != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("RepositoryMock.UpdateEventSourceCheckpoint mock is already set by Expect")
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation.paramPtrs == nil {
+		mmUpdateEventSourceCheckpoint.defaultExpectation.paramPtrs = &RepositoryMockUpdateEventSourceCheckpointParamPtrs{}
+	}
+	mmUpdateEventSourceCheckpoint.defaultExpectation.paramPtrs.ctx = &ctx
+	mmUpdateEventSourceCheckpoint.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmUpdateEventSourceCheckpoint
+}
+
+// ExpectUidParam2 sets up expected param uid for Repository.UpdateEventSourceCheckpoint
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) ExpectUidParam2(uid uuid.UUID) *mRepositoryMockUpdateEventSourceCheckpoint {
+	if mmUpdateEventSourceCheckpoint.mock.funcUpdateEventSourceCheckpoint != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("RepositoryMock.UpdateEventSourceCheckpoint mock is already set by Set")
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation == nil {
+		mmUpdateEventSourceCheckpoint.defaultExpectation = &RepositoryMockUpdateEventSourceCheckpointExpectation{}
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation.params != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("RepositoryMock.UpdateEventSourceCheckpoint mock is already set by Expect")
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation.paramPtrs == nil {
+		mmUpdateEventSourceCheckpoint.defaultExpectation.paramPtrs = &RepositoryMockUpdateEventSourceCheckpointParamPtrs{}
+	}
+	mmUpdateEventSourceCheckpoint.defaultExpectation.paramPtrs.uid = &uid
+	mmUpdateEventSourceCheckpoint.defaultExpectation.expectationOrigins.originUid = minimock.CallerInfo(1)
+
+	return mmUpdateEventSourceCheckpoint
+}
+
+// ExpectCheckpointParam3 sets up expected param checkpoint for Repository.UpdateEventSourceCheckpoint
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) ExpectCheckpointParam3(checkpoint []byte) *mRepositoryMockUpdateEventSourceCheckpoint {
+	if mmUpdateEventSourceCheckpoint.mock.funcUpdateEventSourceCheckpoint != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("RepositoryMock.UpdateEventSourceCheckpoint mock is already set by Set")
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation == nil {
+		mmUpdateEventSourceCheckpoint.defaultExpectation = &RepositoryMockUpdateEventSourceCheckpointExpectation{}
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation.params != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("RepositoryMock.UpdateEventSourceCheckpoint mock is already set by Expect")
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation.paramPtrs == nil {
+		mmUpdateEventSourceCheckpoint.defaultExpectation.paramPtrs = &RepositoryMockUpdateEventSourceCheckpointParamPtrs{}
+	}
+	mmUpdateEventSourceCheckpoint.defaultExpectation.paramPtrs.checkpoint = &checkpoint
+	mmUpdateEventSourceCheckpoint.defaultExpectation.expectationOrigins.originCheckpoint = minimock.CallerInfo(1)
+
+	return mmUpdateEventSourceCheckpoint
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.UpdateEventSourceCheckpoint
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) Inspect(f func(ctx context.Context, uid uuid.UUID, checkpoint []byte)) *mRepositoryMockUpdateEventSourceCheckpoint {
+	if mmUpdateEventSourceCheckpoint.mock.inspectFuncUpdateEventSourceCheckpoint != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("Inspect function is already set for RepositoryMock.UpdateEventSourceCheckpoint")
+	}
+
+	mmUpdateEventSourceCheckpoint.mock.inspectFuncUpdateEventSourceCheckpoint = f
+
+	return mmUpdateEventSourceCheckpoint
+}
+
+// Return sets up results that will be returned by Repository.UpdateEventSourceCheckpoint
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) Return(err error) *RepositoryMock {
+	if mmUpdateEventSourceCheckpoint.mock.funcUpdateEventSourceCheckpoint != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("RepositoryMock.UpdateEventSourceCheckpoint mock is already set by Set")
+	}
+
+	if mmUpdateEventSourceCheckpoint.defaultExpectation == nil {
+		mmUpdateEventSourceCheckpoint.defaultExpectation = &RepositoryMockUpdateEventSourceCheckpointExpectation{mock: mmUpdateEventSourceCheckpoint.mock}
+	}
+	mmUpdateEventSourceCheckpoint.defaultExpectation.results = &RepositoryMockUpdateEventSourceCheckpointResults{err}
+	mmUpdateEventSourceCheckpoint.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmUpdateEventSourceCheckpoint.mock
+}
+
+// Set uses given function f to mock the Repository.UpdateEventSourceCheckpoint method
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) Set(f func(ctx context.Context, uid uuid.UUID, checkpoint []byte) (err error)) *RepositoryMock {
+	if mmUpdateEventSourceCheckpoint.defaultExpectation != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("Default expectation is already set for the Repository.UpdateEventSourceCheckpoint method")
+	}
+
+	if len(mmUpdateEventSourceCheckpoint.expectations) > 0 {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("Some expectations are already set for the Repository.UpdateEventSourceCheckpoint method")
+	}
+
+	mmUpdateEventSourceCheckpoint.mock.funcUpdateEventSourceCheckpoint = f
+	mmUpdateEventSourceCheckpoint.mock.funcUpdateEventSourceCheckpointOrigin = minimock.CallerInfo(1)
+	return mmUpdateEventSourceCheckpoint.mock
+}
+
+// When sets expectation for the Repository.UpdateEventSourceCheckpoint which will trigger the result defined by the following
+// Then helper
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) When(ctx context.Context, uid uuid.UUID, checkpoint []byte) *RepositoryMockUpdateEventSourceCheckpointExpectation {
+	if mmUpdateEventSourceCheckpoint.mock.funcUpdateEventSourceCheckpoint != nil {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("RepositoryMock.UpdateEventSourceCheckpoint mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockUpdateEventSourceCheckpointExpectation{
+		mock:               mmUpdateEventSourceCheckpoint.mock,
+		params:             &RepositoryMockUpdateEventSourceCheckpointParams{ctx, uid, checkpoint},
+		expectationOrigins: RepositoryMockUpdateEventSourceCheckpointExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmUpdateEventSourceCheckpoint.expectations = append(mmUpdateEventSourceCheckpoint.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.UpdateEventSourceCheckpoint return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockUpdateEventSourceCheckpointExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockUpdateEventSourceCheckpointResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.UpdateEventSourceCheckpoint should be invoked
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) Times(n uint64) *mRepositoryMockUpdateEventSourceCheckpoint {
+	if n == 0 {
+		mmUpdateEventSourceCheckpoint.mock.t.Fatalf("Times of RepositoryMock.UpdateEventSourceCheckpoint mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmUpdateEventSourceCheckpoint.expectedInvocations, n)
+	mmUpdateEventSourceCheckpoint.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmUpdateEventSourceCheckpoint
+}
+
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) invocationsDone() bool {
+	if len(mmUpdateEventSourceCheckpoint.expectations) == 0 && mmUpdateEventSourceCheckpoint.defaultExpectation == nil && mmUpdateEventSourceCheckpoint.mock.funcUpdateEventSourceCheckpoint == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmUpdateEventSourceCheckpoint.mock.afterUpdateEventSourceCheckpointCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmUpdateEventSourceCheckpoint.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// UpdateEventSourceCheckpoint implements mm_repository.Repository
+func (mmUpdateEventSourceCheckpoint *RepositoryMock) UpdateEventSourceCheckpoint(ctx context.Context, uid uuid.UUID, checkpoint []byte) (err error) {
+	mm_atomic.AddUint64(&mmUpdateEventSourceCheckpoint.beforeUpdateEventSourceCheckpointCounter, 1)
+	defer mm_atomic.AddUint64(&mmUpdateEventSourceCheckpoint.afterUpdateEventSourceCheckpointCounter, 1)
+
+	mmUpdateEventSourceCheckpoint.t.Helper()
+
+	if mmUpdateEventSourceCheckpoint.inspectFuncUpdateEventSourceCheckpoint != nil {
+		mmUpdateEventSourceCheckpoint.inspectFuncUpdateEventSourceCheckpoint(ctx, uid, checkpoint)
+	}
+
+	mm_params := RepositoryMockUpdateEventSourceCheckpointParams{ctx, uid, checkpoint}
+
+	// Record call args
+	mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.mutex.Lock()
+	mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.callArgs = append(mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.callArgs, &mm_params)
+	mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.mutex.Unlock()
+
+	for _, e := range mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.defaultExpectation.Counter, 1)
+		mm_want := mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.defaultExpectation.params
+		mm_want_ptrs := mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockUpdateEventSourceCheckpointParams{ctx, uid, checkpoint}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmUpdateEventSourceCheckpoint.t.Errorf("RepositoryMock.UpdateEventSourceCheckpoint got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.uid != nil && !minimock.Equal(*mm_want_ptrs.uid, mm_got.uid) {
+				mmUpdateEventSourceCheckpoint.t.Errorf("RepositoryMock.UpdateEventSourceCheckpoint got unexpected parameter uid, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.defaultExpectation.expectationOrigins.originUid, *mm_want_ptrs.uid, mm_got.uid, minimock.Diff(*mm_want_ptrs.uid, mm_got.uid))
+			}
+
+			if mm_want_ptrs.checkpoint != nil && !minimock.Equal(*mm_want_ptrs.checkpoint, mm_got.checkpoint) {
+				mmUpdateEventSourceCheckpoint.t.Errorf("RepositoryMock.UpdateEventSourceCheckpoint got unexpected parameter checkpoint, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.defaultExpectation.expectationOrigins.originCheckpoint, *mm_want_ptrs.checkpoint, mm_got.checkpoint, minimock.Diff(*mm_want_ptrs.checkpoint, mm_got.checkpoint))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmUpdateEventSourceCheckpoint.t.Errorf("RepositoryMock.UpdateEventSourceCheckpoint got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmUpdateEventSourceCheckpoint.UpdateEventSourceCheckpointMock.defaultExpectation.results
+		if mm_results == nil {
+			mmUpdateEventSourceCheckpoint.t.Fatal("No results are set for the RepositoryMock.UpdateEventSourceCheckpoint")
+		}
+		return (*mm_results).err
+	}
+	if mmUpdateEventSourceCheckpoint.funcUpdateEventSourceCheckpoint != nil {
+		return mmUpdateEventSourceCheckpoint.funcUpdateEventSourceCheckpoint(ctx, uid, checkpoint)
+	}
+	mmUpdateEventSourceCheckpoint.t.Fatalf("Unexpected call to RepositoryMock.UpdateEventSourceCheckpoint. %v %v %v", ctx, uid, checkpoint)
+	return
+}
+
+// UpdateEventSourceCheckpointAfterCounter returns a count of finished RepositoryMock.UpdateEventSourceCheckpoint invocations
+func (mmUpdateEventSourceCheckpoint *RepositoryMock) UpdateEventSourceCheckpointAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateEventSourceCheckpoint.afterUpdateEventSourceCheckpointCounter)
+}
+
+// UpdateEventSourceCheckpointBeforeCounter returns a count of RepositoryMock.UpdateEventSourceCheckpoint invocations
+func (mmUpdateEventSourceCheckpoint *RepositoryMock) UpdateEventSourceCheckpointBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateEventSourceCheckpoint.beforeUpdateEventSourceCheckpointCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.UpdateEventSourceCheckpoint.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmUpdateEventSourceCheckpoint *mRepositoryMockUpdateEventSourceCheckpoint) Calls() []*RepositoryMockUpdateEventSourceCheckpointParams {
+	mmUpdateEventSourceCheckpoint.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockUpdateEventSourceCheckpointParams, len(mmUpdateEventSourceCheckpoint.callArgs))
+	copy(argCopy, mmUpdateEventSourceCheckpoint.callArgs)
+
+	mmUpdateEventSourceCheckpoint.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockUpdateEventSourceCheckpointDone returns true if the count of the UpdateEventSourceCheckpoint invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockUpdateEventSourceCheckpointDone() bool {
+	if m.UpdateEventSourceCheckpointMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.UpdateEventSourceCheckpointMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.UpdateEventSourceCheckpointMock.invocationsDone()
+}
+
+// MinimockUpdateEventSourceCheckpointInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockUpdateEventSourceCheckpointInspect() {
+	for _, e := range m.UpdateEventSourceCheckpointMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.UpdateEventSourceCheckpoint at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterUpdateEventSourceCheckpointCounter := mm_atomic.LoadUint64(&m.afterUpdateEventSourceCheckpointCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.UpdateEventSourceCheckpointMock.defaultExpectation != nil && afterUpdateEventSourceCheckpointCounter < 1 {
+		if m.UpdateEventSourceCheckpointMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.UpdateEventSourceCheckpoint at\n%s", m.UpdateEventSourceCheckpointMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.UpdateEventSourceCheckpoint at\n%s with params: %#v", m.UpdateEventSourceCheckpointMock.defaultExpectation.expectationOrigins.origin, *m.UpdateEventSourceCheckpointMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcUpdateComponentRun != nil && afterUpdateComponentRunCounter < 1 {
-		m.t.Errorf("Expected call to RepositoryMock.UpdateComponentRun at\n%s", m.funcUpdateComponentRunOrigin)
+	if m.funcUpdateEventSourceCheckpoint != nil && afterUpdateEventSourceCheckpointCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.UpdateEventSourceCheckpoint at\n%s", m.funcUpdateEventSourceCheckpointOrigin)
 	}
 
-	if !m.UpdateComponentRunMock.invocationsDone() && afterUpdateComponentRunCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.UpdateComponentRun at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.UpdateComponentRunMock.expectedInvocations), m.UpdateComponentRunMock.expectedInvocationsOrigin, afterUpdateComponentRunCounter)
+	if !m.UpdateEventSourceCheckpointMock.invocationsDone() && afterUpdateEventSourceCheckpointCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.UpdateEventSourceCheckpoint at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.UpdateEventSourceCheckpointMock.expectedInvocations), m.UpdateEventSourceCheckpointMock.expectedInvocationsOrigin, afterUpdateEventSourceCheckpointCounter)
 	}
 }
 
@@ -16981,120 +23711,525 @@ func (mmUpdateNamespaceConnectionByUID *RepositoryMock) UpdateNamespaceConnectio
 	mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.callArgs = append(mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.callArgs, &mm_params)
 	mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.mutex.Unlock()
 
-	for _, e := range mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.expectations {
+	for _, e := range mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.cp2, e.results.err
+		}
+	}
+
+	if mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.Counter, 1)
+		mm_want := mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.params
+		mm_want_ptrs := mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockUpdateNamespaceConnectionByUIDParams{ctx, u1, cp1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmUpdateNamespaceConnectionByUID.t.Errorf("RepositoryMock.UpdateNamespaceConnectionByUID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.u1 != nil && !minimock.Equal(*mm_want_ptrs.u1, mm_got.u1) {
+				mmUpdateNamespaceConnectionByUID.t.Errorf("RepositoryMock.UpdateNamespaceConnectionByUID got unexpected parameter u1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.expectationOrigins.originU1, *mm_want_ptrs.u1, mm_got.u1, minimock.Diff(*mm_want_ptrs.u1, mm_got.u1))
+			}
+
+			if mm_want_ptrs.cp1 != nil && !minimock.Equal(*mm_want_ptrs.cp1, mm_got.cp1) {
+				mmUpdateNamespaceConnectionByUID.t.Errorf("RepositoryMock.UpdateNamespaceConnectionByUID got unexpected parameter cp1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.expectationOrigins.originCp1, *mm_want_ptrs.cp1, mm_got.cp1, minimock.Diff(*mm_want_ptrs.cp1, mm_got.cp1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmUpdateNamespaceConnectionByUID.t.Errorf("RepositoryMock.UpdateNamespaceConnectionByUID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.results
+		if mm_results == nil {
+			mmUpdateNamespaceConnectionByUID.t.Fatal("No results are set for the RepositoryMock.UpdateNamespaceConnectionByUID")
+		}
+		return (*mm_results).cp2, (*mm_results).err
+	}
+	if mmUpdateNamespaceConnectionByUID.funcUpdateNamespaceConnectionByUID != nil {
+		return mmUpdateNamespaceConnectionByUID.funcUpdateNamespaceConnectionByUID(ctx, u1, cp1)
+	}
+	mmUpdateNamespaceConnectionByUID.t.Fatalf("Unexpected call to RepositoryMock.UpdateNamespaceConnectionByUID. %v %v %v", ctx, u1, cp1)
+	return
+}
+
+// UpdateNamespaceConnectionByUIDAfterCounter returns a count of finished RepositoryMock.UpdateNamespaceConnectionByUID invocations
+func (mmUpdateNamespaceConnectionByUID *RepositoryMock) UpdateNamespaceConnectionByUIDAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateNamespaceConnectionByUID.afterUpdateNamespaceConnectionByUIDCounter)
+}
+
+// UpdateNamespaceConnectionByUIDBeforeCounter returns a count of RepositoryMock.UpdateNamespaceConnectionByUID invocations
+func (mmUpdateNamespaceConnectionByUID *RepositoryMock) UpdateNamespaceConnectionByUIDBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateNamespaceConnectionByUID.beforeUpdateNamespaceConnectionByUIDCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.UpdateNamespaceConnectionByUID.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmUpdateNamespaceConnectionByUID *mRepositoryMockUpdateNamespaceConnectionByUID) Calls() []*RepositoryMockUpdateNamespaceConnectionByUIDParams {
+	mmUpdateNamespaceConnectionByUID.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockUpdateNamespaceConnectionByUIDParams, len(mmUpdateNamespaceConnectionByUID.callArgs))
+	copy(argCopy, mmUpdateNamespaceConnectionByUID.callArgs)
+
+	mmUpdateNamespaceConnectionByUID.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockUpdateNamespaceConnectionByUIDDone returns true if the count of the UpdateNamespaceConnectionByUID invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockUpdateNamespaceConnectionByUIDDone() bool {
+	if m.UpdateNamespaceConnectionByUIDMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.UpdateNamespaceConnectionByUIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.UpdateNamespaceConnectionByUIDMock.invocationsDone()
+}
+
+// MinimockUpdateNamespaceConnectionByUIDInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockUpdateNamespaceConnectionByUIDInspect() {
+	for _, e := range m.UpdateNamespaceConnectionByUIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionByUID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterUpdateNamespaceConnectionByUIDCounter := mm_atomic.LoadUint64(&m.afterUpdateNamespaceConnectionByUIDCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.UpdateNamespaceConnectionByUIDMock.defaultExpectation != nil && afterUpdateNamespaceConnectionByUIDCounter < 1 {
+		if m.UpdateNamespaceConnectionByUIDMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionByUID at\n%s", m.UpdateNamespaceConnectionByUIDMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionByUID at\n%s with params: %#v", m.UpdateNamespaceConnectionByUIDMock.defaultExpectation.expectationOrigins.origin, *m.UpdateNamespaceConnectionByUIDMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcUpdateNamespaceConnectionByUID != nil && afterUpdateNamespaceConnectionByUIDCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionByUID at\n%s", m.funcUpdateNamespaceConnectionByUIDOrigin)
+	}
+
+	if !m.UpdateNamespaceConnectionByUIDMock.invocationsDone() && afterUpdateNamespaceConnectionByUIDCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.UpdateNamespaceConnectionByUID at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.UpdateNamespaceConnectionByUIDMock.expectedInvocations), m.UpdateNamespaceConnectionByUIDMock.expectedInvocationsOrigin, afterUpdateNamespaceConnectionByUIDCounter)
+	}
+}
+
+type mRepositoryMockUpdateNamespaceConnectionMaxConcurrency struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation
+	expectations       []*RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation
+
+	callArgs []*RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation specifies expectation struct of the Repository.UpdateNamespaceConnectionMaxConcurrency
+type RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams
+	paramPtrs          *RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParamPtrs
+	expectationOrigins RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectationOrigins
+	results            *RepositoryMockUpdateNamespaceConnectionMaxConcurrencyResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams contains parameters of the Repository.UpdateNamespaceConnectionMaxConcurrency
+type RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams struct {
+	ctx            context.Context
+	nsUID          uuid.UUID
+	id             string
+	maxConcurrency int32
+}
+
+// RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParamPtrs contains pointers to parameters of the Repository.UpdateNamespaceConnectionMaxConcurrency
+type RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParamPtrs struct {
+	ctx            *context.Context
+	nsUID          *uuid.UUID
+	id             *string
+	maxConcurrency *int32
+}
+
+// RepositoryMockUpdateNamespaceConnectionMaxConcurrencyResults contains results of the Repository.UpdateNamespaceConnectionMaxConcurrency
+type RepositoryMockUpdateNamespaceConnectionMaxConcurrencyResults struct {
+	cp1 *datamodel.Connection
+	err error
+}
+
+// RepositoryMockUpdateNamespaceConnectionMaxConcurrencyOrigins contains origins of expectations of the Repository.UpdateNamespaceConnectionMaxConcurrency
+type RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectationOrigins struct {
+	origin               string
+	originCtx            string
+	originNsUID          string
+	originId             string
+	originMaxConcurrency string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) Optional() *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency {
+	mmUpdateNamespaceConnectionMaxConcurrency.optional = true
+	return mmUpdateNamespaceConnectionMaxConcurrency
+}
+
+// Expect sets up expected params for Repository.UpdateNamespaceConnectionMaxConcurrency
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) Expect(ctx context.Context, nsUID uuid.UUID, id string, maxConcurrency int32) *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency {
+	if mmUpdateNamespaceConnectionMaxConcurrency.mock.funcUpdateNamespaceConnectionMaxConcurrency != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Set")
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation == nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation{}
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by ExpectParams functions")
+	}
+
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.params = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams{ctx, nsUID, id, maxConcurrency}
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmUpdateNamespaceConnectionMaxConcurrency.expectations {
+		if minimock.Equal(e.params, mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.params) {
+			mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.params)
+		}
+	}
+
+	return mmUpdateNamespaceConnectionMaxConcurrency
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.UpdateNamespaceConnectionMaxConcurrency
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) ExpectCtxParam1(ctx context.Context) *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency {
+	if mmUpdateNamespaceConnectionMaxConcurrency.mock.funcUpdateNamespaceConnectionMaxConcurrency != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Set")
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation == nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation{}
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.params != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Expect")
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs == nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParamPtrs{}
+	}
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs.ctx = &ctx
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmUpdateNamespaceConnectionMaxConcurrency
+}
+
+// ExpectNsUIDParam2 sets up expected param nsUID for Repository.UpdateNamespaceConnectionMaxConcurrency
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) ExpectNsUIDParam2(nsUID uuid.UUID) *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency {
+	if mmUpdateNamespaceConnectionMaxConcurrency.mock.funcUpdateNamespaceConnectionMaxConcurrency != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Set")
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation == nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation{}
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.params != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Expect")
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs == nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParamPtrs{}
+	}
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs.nsUID = &nsUID
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.expectationOrigins.originNsUID = minimock.CallerInfo(1)
+
+	return mmUpdateNamespaceConnectionMaxConcurrency
+}
+
+// ExpectIdParam3 sets up expected param id for Repository.UpdateNamespaceConnectionMaxConcurrency
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) ExpectIdParam3(id string) *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency {
+	if mmUpdateNamespaceConnectionMaxConcurrency.mock.funcUpdateNamespaceConnectionMaxConcurrency != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Set")
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation == nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation{}
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.params != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Expect")
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs == nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParamPtrs{}
+	}
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs.id = &id
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.expectationOrigins.originId = minimock.CallerInfo(1)
+
+	return mmUpdateNamespaceConnectionMaxConcurrency
+}
+
+// ExpectMaxConcurrencyParam4 sets up expected param maxConcurrency for Repository.UpdateNamespaceConnectionMaxConcurrency
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) ExpectMaxConcurrencyParam4(maxConcurrency int32) *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency {
+	if mmUpdateNamespaceConnectionMaxConcurrency.mock.funcUpdateNamespaceConnectionMaxConcurrency != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Set")
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation == nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation{}
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.params != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Expect")
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs == nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParamPtrs{}
+	}
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.paramPtrs.maxConcurrency = &maxConcurrency
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.expectationOrigins.originMaxConcurrency = minimock.CallerInfo(1)
+
+	return mmUpdateNamespaceConnectionMaxConcurrency
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.UpdateNamespaceConnectionMaxConcurrency
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) Inspect(f func(ctx context.Context, nsUID uuid.UUID, id string, maxConcurrency int32)) *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency {
+	if mmUpdateNamespaceConnectionMaxConcurrency.mock.inspectFuncUpdateNamespaceConnectionMaxConcurrency != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("Inspect function is already set for RepositoryMock.UpdateNamespaceConnectionMaxConcurrency")
+	}
+
+	mmUpdateNamespaceConnectionMaxConcurrency.mock.inspectFuncUpdateNamespaceConnectionMaxConcurrency = f
+
+	return mmUpdateNamespaceConnectionMaxConcurrency
+}
+
+// Return sets up results that will be returned by Repository.UpdateNamespaceConnectionMaxConcurrency
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) Return(cp1 *datamodel.Connection, err error) *RepositoryMock {
+	if mmUpdateNamespaceConnectionMaxConcurrency.mock.funcUpdateNamespaceConnectionMaxConcurrency != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Set")
+	}
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation == nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation{mock: mmUpdateNamespaceConnectionMaxConcurrency.mock}
+	}
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.results = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyResults{cp1, err}
+	mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmUpdateNamespaceConnectionMaxConcurrency.mock
+}
+
+// Set uses given function f to mock the Repository.UpdateNamespaceConnectionMaxConcurrency method
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) Set(f func(ctx context.Context, nsUID uuid.UUID, id string, maxConcurrency int32) (cp1 *datamodel.Connection, err error)) *RepositoryMock {
+	if mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("Default expectation is already set for the Repository.UpdateNamespaceConnectionMaxConcurrency method")
+	}
+
+	if len(mmUpdateNamespaceConnectionMaxConcurrency.expectations) > 0 {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("Some expectations are already set for the Repository.UpdateNamespaceConnectionMaxConcurrency method")
+	}
+
+	mmUpdateNamespaceConnectionMaxConcurrency.mock.funcUpdateNamespaceConnectionMaxConcurrency = f
+	mmUpdateNamespaceConnectionMaxConcurrency.mock.funcUpdateNamespaceConnectionMaxConcurrencyOrigin = minimock.CallerInfo(1)
+	return mmUpdateNamespaceConnectionMaxConcurrency.mock
+}
+
+// When sets expectation for the Repository.UpdateNamespaceConnectionMaxConcurrency which will trigger the result defined by the following
+// Then helper
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) When(ctx context.Context, nsUID uuid.UUID, id string, maxConcurrency int32) *RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation {
+	if mmUpdateNamespaceConnectionMaxConcurrency.mock.funcUpdateNamespaceConnectionMaxConcurrency != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation{
+		mock:               mmUpdateNamespaceConnectionMaxConcurrency.mock,
+		params:             &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams{ctx, nsUID, id, maxConcurrency},
+		expectationOrigins: RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmUpdateNamespaceConnectionMaxConcurrency.expectations = append(mmUpdateNamespaceConnectionMaxConcurrency.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.UpdateNamespaceConnectionMaxConcurrency return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockUpdateNamespaceConnectionMaxConcurrencyExpectation) Then(cp1 *datamodel.Connection, err error) *RepositoryMock {
+	e.results = &RepositoryMockUpdateNamespaceConnectionMaxConcurrencyResults{cp1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.UpdateNamespaceConnectionMaxConcurrency should be invoked
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) Times(n uint64) *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency {
+	if n == 0 {
+		mmUpdateNamespaceConnectionMaxConcurrency.mock.t.Fatalf("Times of RepositoryMock.UpdateNamespaceConnectionMaxConcurrency mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmUpdateNamespaceConnectionMaxConcurrency.expectedInvocations, n)
+	mmUpdateNamespaceConnectionMaxConcurrency.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmUpdateNamespaceConnectionMaxConcurrency
+}
+
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) invocationsDone() bool {
+	if len(mmUpdateNamespaceConnectionMaxConcurrency.expectations) == 0 && mmUpdateNamespaceConnectionMaxConcurrency.defaultExpectation == nil && mmUpdateNamespaceConnectionMaxConcurrency.mock.funcUpdateNamespaceConnectionMaxConcurrency == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmUpdateNamespaceConnectionMaxConcurrency.mock.afterUpdateNamespaceConnectionMaxConcurrencyCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmUpdateNamespaceConnectionMaxConcurrency.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// UpdateNamespaceConnectionMaxConcurrency implements mm_repository.Repository
+func (mmUpdateNamespaceConnectionMaxConcurrency *RepositoryMock) UpdateNamespaceConnectionMaxConcurrency(ctx context.Context, nsUID uuid.UUID, id string, maxConcurrency int32) (cp1 *datamodel.Connection, err error) {
+	mm_atomic.AddUint64(&mmUpdateNamespaceConnectionMaxConcurrency.beforeUpdateNamespaceConnectionMaxConcurrencyCounter, 1)
+	defer mm_atomic.AddUint64(&mmUpdateNamespaceConnectionMaxConcurrency.afterUpdateNamespaceConnectionMaxConcurrencyCounter, 1)
+
+	mmUpdateNamespaceConnectionMaxConcurrency.t.Helper()
+
+	if mmUpdateNamespaceConnectionMaxConcurrency.inspectFuncUpdateNamespaceConnectionMaxConcurrency != nil {
+		mmUpdateNamespaceConnectionMaxConcurrency.inspectFuncUpdateNamespaceConnectionMaxConcurrency(ctx, nsUID, id, maxConcurrency)
+	}
+
+	mm_params := RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams{ctx, nsUID, id, maxConcurrency}
+
+	// Record call args
+	mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.mutex.Lock()
+	mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.callArgs = append(mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.callArgs, &mm_params)
+	mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.mutex.Unlock()
+
+	for _, e := range mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.cp2, e.results.err
+			return e.results.cp1, e.results.err
 		}
 	}
 
-	if mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.Counter, 1)
-		mm_want := mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.params
-		mm_want_ptrs := mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.paramPtrs
+	if mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.Counter, 1)
+		mm_want := mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.params
+		mm_want_ptrs := mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockUpdateNamespaceConnectionByUIDParams{ctx, u1, cp1}
+		mm_got := RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams{ctx, nsUID, id, maxConcurrency}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmUpdateNamespaceConnectionByUID.t.Errorf("RepositoryMock.UpdateNamespaceConnectionByUID got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmUpdateNamespaceConnectionMaxConcurrency.t.Errorf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.u1 != nil && !minimock.Equal(*mm_want_ptrs.u1, mm_got.u1) {
-				mmUpdateNamespaceConnectionByUID.t.Errorf("RepositoryMock.UpdateNamespaceConnectionByUID got unexpected parameter u1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.expectationOrigins.originU1, *mm_want_ptrs.u1, mm_got.u1, minimock.Diff(*mm_want_ptrs.u1, mm_got.u1))
+			if mm_want_ptrs.nsUID != nil && !minimock.Equal(*mm_want_ptrs.nsUID, mm_got.nsUID) {
+				mmUpdateNamespaceConnectionMaxConcurrency.t.Errorf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency got unexpected parameter nsUID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.expectationOrigins.originNsUID, *mm_want_ptrs.nsUID, mm_got.nsUID, minimock.Diff(*mm_want_ptrs.nsUID, mm_got.nsUID))
 			}
 
-			if mm_want_ptrs.cp1 != nil && !minimock.Equal(*mm_want_ptrs.cp1, mm_got.cp1) {
-				mmUpdateNamespaceConnectionByUID.t.Errorf("RepositoryMock.UpdateNamespaceConnectionByUID got unexpected parameter cp1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.expectationOrigins.originCp1, *mm_want_ptrs.cp1, mm_got.cp1, minimock.Diff(*mm_want_ptrs.cp1, mm_got.cp1))
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmUpdateNamespaceConnectionMaxConcurrency.t.Errorf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency got unexpected parameter id, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.expectationOrigins.originId, *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+			if mm_want_ptrs.maxConcurrency != nil && !minimock.Equal(*mm_want_ptrs.maxConcurrency, mm_got.maxConcurrency) {
+				mmUpdateNamespaceConnectionMaxConcurrency.t.Errorf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency got unexpected parameter maxConcurrency, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.expectationOrigins.originMaxConcurrency, *mm_want_ptrs.maxConcurrency, mm_got.maxConcurrency, minimock.Diff(*mm_want_ptrs.maxConcurrency, mm_got.maxConcurrency))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmUpdateNamespaceConnectionByUID.t.Errorf("RepositoryMock.UpdateNamespaceConnectionByUID got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmUpdateNamespaceConnectionMaxConcurrency.t.Errorf("RepositoryMock.UpdateNamespaceConnectionMaxConcurrency got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmUpdateNamespaceConnectionByUID.UpdateNamespaceConnectionByUIDMock.defaultExpectation.results
+		mm_results := mmUpdateNamespaceConnectionMaxConcurrency.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.results
 		if mm_results == nil {
-			mmUpdateNamespaceConnectionByUID.t.Fatal("No results are set for the RepositoryMock.UpdateNamespaceConnectionByUID")
+			mmUpdateNamespaceConnectionMaxConcurrency.t.Fatal("No results are set for the RepositoryMock.UpdateNamespaceConnectionMaxConcurrency")
 		}
-		return (*mm_results).cp2, (*mm_results).err
+		return (*mm_results).cp1, (*mm_results).err
 	}
-	if mmUpdateNamespaceConnectionByUID.funcUpdateNamespaceConnectionByUID != nil {
-		return mmUpdateNamespaceConnectionByUID.funcUpdateNamespaceConnectionByUID(ctx, u1, cp1)
+	if mmUpdateNamespaceConnectionMaxConcurrency.funcUpdateNamespaceConnectionMaxConcurrency != nil {
+		return mmUpdateNamespaceConnectionMaxConcurrency.funcUpdateNamespaceConnectionMaxConcurrency(ctx, nsUID, id, maxConcurrency)
 	}
-	mmUpdateNamespaceConnectionByUID.t.Fatalf("Unexpected call to RepositoryMock.UpdateNamespaceConnectionByUID. %v %v %v", ctx, u1, cp1)
+	mmUpdateNamespaceConnectionMaxConcurrency.t.Fatalf("Unexpected call to RepositoryMock.UpdateNamespaceConnectionMaxConcurrency. %v %v %v %v", ctx, nsUID, id, maxConcurrency)
 	return
 }
 
-// UpdateNamespaceConnectionByUIDAfterCounter returns a count of finished RepositoryMock.UpdateNamespaceConnectionByUID invocations
-func (mmUpdateNamespaceConnectionByUID *RepositoryMock) UpdateNamespaceConnectionByUIDAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmUpdateNamespaceConnectionByUID.afterUpdateNamespaceConnectionByUIDCounter)
+// UpdateNamespaceConnectionMaxConcurrencyAfterCounter returns a count of finished RepositoryMock.UpdateNamespaceConnectionMaxConcurrency invocations
+func (mmUpdateNamespaceConnectionMaxConcurrency *RepositoryMock) UpdateNamespaceConnectionMaxConcurrencyAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateNamespaceConnectionMaxConcurrency.afterUpdateNamespaceConnectionMaxConcurrencyCounter)
 }
 
-// UpdateNamespaceConnectionByUIDBeforeCounter returns a count of RepositoryMock.UpdateNamespaceConnectionByUID invocations
-func (mmUpdateNamespaceConnectionByUID *RepositoryMock) UpdateNamespaceConnectionByUIDBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmUpdateNamespaceConnectionByUID.beforeUpdateNamespaceConnectionByUIDCounter)
+// UpdateNamespaceConnectionMaxConcurrencyBeforeCounter returns a count of RepositoryMock.UpdateNamespaceConnectionMaxConcurrency invocations
+func (mmUpdateNamespaceConnectionMaxConcurrency *RepositoryMock) UpdateNamespaceConnectionMaxConcurrencyBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateNamespaceConnectionMaxConcurrency.beforeUpdateNamespaceConnectionMaxConcurrencyCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.UpdateNamespaceConnectionByUID.
+// Calls returns a list of arguments used in each call to RepositoryMock.UpdateNamespaceConnectionMaxConcurrency.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmUpdateNamespaceConnectionByUID *mRepositoryMockUpdateNamespaceConnectionByUID) Calls() []*RepositoryMockUpdateNamespaceConnectionByUIDParams {
-	mmUpdateNamespaceConnectionByUID.mutex.RLock()
+func (mmUpdateNamespaceConnectionMaxConcurrency *mRepositoryMockUpdateNamespaceConnectionMaxConcurrency) Calls() []*RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams {
+	mmUpdateNamespaceConnectionMaxConcurrency.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockUpdateNamespaceConnectionByUIDParams, len(mmUpdateNamespaceConnectionByUID.callArgs))
-	copy(argCopy, mmUpdateNamespaceConnectionByUID.callArgs)
+	argCopy := make([]*RepositoryMockUpdateNamespaceConnectionMaxConcurrencyParams, len(mmUpdateNamespaceConnectionMaxConcurrency.callArgs))
+	copy(argCopy, mmUpdateNamespaceConnectionMaxConcurrency.callArgs)
 
-	mmUpdateNamespaceConnectionByUID.mutex.RUnlock()
+	mmUpdateNamespaceConnectionMaxConcurrency.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockUpdateNamespaceConnectionByUIDDone returns true if the count of the UpdateNamespaceConnectionByUID invocations corresponds
+// MinimockUpdateNamespaceConnectionMaxConcurrencyDone returns true if the count of the UpdateNamespaceConnectionMaxConcurrency invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockUpdateNamespaceConnectionByUIDDone() bool {
-	if m.UpdateNamespaceConnectionByUIDMock.optional {
+func (m *RepositoryMock) MinimockUpdateNamespaceConnectionMaxConcurrencyDone() bool {
+	if m.UpdateNamespaceConnectionMaxConcurrencyMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.UpdateNamespaceConnectionByUIDMock.expectations {
+	for _, e := range m.UpdateNamespaceConnectionMaxConcurrencyMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.UpdateNamespaceConnectionByUIDMock.invocationsDone()
+	return m.UpdateNamespaceConnectionMaxConcurrencyMock.invocationsDone()
 }
 
-// MinimockUpdateNamespaceConnectionByUIDInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockUpdateNamespaceConnectionByUIDInspect() {
-	for _, e := range m.UpdateNamespaceConnectionByUIDMock.expectations {
+// MinimockUpdateNamespaceConnectionMaxConcurrencyInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockUpdateNamespaceConnectionMaxConcurrencyInspect() {
+	for _, e := range m.UpdateNamespaceConnectionMaxConcurrencyMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionByUID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionMaxConcurrency at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterUpdateNamespaceConnectionByUIDCounter := mm_atomic.LoadUint64(&m.afterUpdateNamespaceConnectionByUIDCounter)
+	afterUpdateNamespaceConnectionMaxConcurrencyCounter := mm_atomic.LoadUint64(&m.afterUpdateNamespaceConnectionMaxConcurrencyCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.UpdateNamespaceConnectionByUIDMock.defaultExpectation != nil && afterUpdateNamespaceConnectionByUIDCounter < 1 {
-		if m.UpdateNamespaceConnectionByUIDMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionByUID at\n%s", m.UpdateNamespaceConnectionByUIDMock.defaultExpectation.returnOrigin)
+	if m.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation != nil && afterUpdateNamespaceConnectionMaxConcurrencyCounter < 1 {
+		if m.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionMaxConcurrency at\n%s", m.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionByUID at\n%s with params: %#v", m.UpdateNamespaceConnectionByUIDMock.defaultExpectation.expectationOrigins.origin, *m.UpdateNamespaceConnectionByUIDMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionMaxConcurrency at\n%s with params: %#v", m.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.expectationOrigins.origin, *m.UpdateNamespaceConnectionMaxConcurrencyMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcUpdateNamespaceConnectionByUID != nil && afterUpdateNamespaceConnectionByUIDCounter < 1 {
-		m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionByUID at\n%s", m.funcUpdateNamespaceConnectionByUIDOrigin)
+	if m.funcUpdateNamespaceConnectionMaxConcurrency != nil && afterUpdateNamespaceConnectionMaxConcurrencyCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceConnectionMaxConcurrency at\n%s", m.funcUpdateNamespaceConnectionMaxConcurrencyOrigin)
 	}
 
-	if !m.UpdateNamespaceConnectionByUIDMock.invocationsDone() && afterUpdateNamespaceConnectionByUIDCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.UpdateNamespaceConnectionByUID at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.UpdateNamespaceConnectionByUIDMock.expectedInvocations), m.UpdateNamespaceConnectionByUIDMock.expectedInvocationsOrigin, afterUpdateNamespaceConnectionByUIDCounter)
+	if !m.UpdateNamespaceConnectionMaxConcurrencyMock.invocationsDone() && afterUpdateNamespaceConnectionMaxConcurrencyCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.UpdateNamespaceConnectionMaxConcurrency at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.UpdateNamespaceConnectionMaxConcurrencyMock.expectedInvocations), m.UpdateNamespaceConnectionMaxConcurrencyMock.expectedInvocationsOrigin, afterUpdateNamespaceConnectionMaxConcurrencyCounter)
 	}
 }
 
@@ -19096,56 +26231,398 @@ func (mmUpdateNamespaceSecretByID *RepositoryMock) UpdateNamespaceSecretByIDBefo
 func (mmUpdateNamespaceSecretByID *mRepositoryMockUpdateNamespaceSecretByID) Calls() []*RepositoryMockUpdateNamespaceSecretByIDParams {
 	mmUpdateNamespaceSecretByID.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockUpdateNamespaceSecretByIDParams, len(mmUpdateNamespaceSecretByID.callArgs))
-	copy(argCopy, mmUpdateNamespaceSecretByID.callArgs)
+	argCopy := make([]*RepositoryMockUpdateNamespaceSecretByIDParams, len(mmUpdateNamespaceSecretByID.callArgs))
+	copy(argCopy, mmUpdateNamespaceSecretByID.callArgs)
+
+	mmUpdateNamespaceSecretByID.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockUpdateNamespaceSecretByIDDone returns true if the count of the UpdateNamespaceSecretByID invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockUpdateNamespaceSecretByIDDone() bool {
+	if m.UpdateNamespaceSecretByIDMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.UpdateNamespaceSecretByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.UpdateNamespaceSecretByIDMock.invocationsDone()
+}
+
+// MinimockUpdateNamespaceSecretByIDInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockUpdateNamespaceSecretByIDInspect() {
+	for _, e := range m.UpdateNamespaceSecretByIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceSecretByID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterUpdateNamespaceSecretByIDCounter := mm_atomic.LoadUint64(&m.afterUpdateNamespaceSecretByIDCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.UpdateNamespaceSecretByIDMock.defaultExpectation != nil && afterUpdateNamespaceSecretByIDCounter < 1 {
+		if m.UpdateNamespaceSecretByIDMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceSecretByID at\n%s", m.UpdateNamespaceSecretByIDMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceSecretByID at\n%s with params: %#v", m.UpdateNamespaceSecretByIDMock.defaultExpectation.expectationOrigins.origin, *m.UpdateNamespaceSecretByIDMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcUpdateNamespaceSecretByID != nil && afterUpdateNamespaceSecretByIDCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceSecretByID at\n%s", m.funcUpdateNamespaceSecretByIDOrigin)
+	}
+
+	if !m.UpdateNamespaceSecretByIDMock.invocationsDone() && afterUpdateNamespaceSecretByIDCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.UpdateNamespaceSecretByID at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.UpdateNamespaceSecretByIDMock.expectedInvocations), m.UpdateNamespaceSecretByIDMock.expectedInvocationsOrigin, afterUpdateNamespaceSecretByIDCounter)
+	}
+}
+
+type mRepositoryMockUpdatePipelineCanary struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockUpdatePipelineCanaryExpectation
+	expectations       []*RepositoryMockUpdatePipelineCanaryExpectation
+
+	callArgs []*RepositoryMockUpdatePipelineCanaryParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockUpdatePipelineCanaryExpectation specifies expectation struct of the Repository.UpdatePipelineCanary
+type RepositoryMockUpdatePipelineCanaryExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockUpdatePipelineCanaryParams
+	paramPtrs          *RepositoryMockUpdatePipelineCanaryParamPtrs
+	expectationOrigins RepositoryMockUpdatePipelineCanaryExpectationOrigins
+	results            *RepositoryMockUpdatePipelineCanaryResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockUpdatePipelineCanaryParams contains parameters of the Repository.UpdatePipelineCanary
+type RepositoryMockUpdatePipelineCanaryParams struct {
+	ctx context.Context
+	pp1 *datamodel.PipelineCanary
+}
+
+// RepositoryMockUpdatePipelineCanaryParamPtrs contains pointers to parameters of the Repository.UpdatePipelineCanary
+type RepositoryMockUpdatePipelineCanaryParamPtrs struct {
+	ctx *context.Context
+	pp1 **datamodel.PipelineCanary
+}
+
+// RepositoryMockUpdatePipelineCanaryResults contains results of the Repository.UpdatePipelineCanary
+type RepositoryMockUpdatePipelineCanaryResults struct {
+	err error
+}
+
+// RepositoryMockUpdatePipelineCanaryOrigins contains origins of expectations of the Repository.UpdatePipelineCanary
+type RepositoryMockUpdatePipelineCanaryExpectationOrigins struct {
+	origin    string
+	originCtx string
+	originPp1 string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) Optional() *mRepositoryMockUpdatePipelineCanary {
+	mmUpdatePipelineCanary.optional = true
+	return mmUpdatePipelineCanary
+}
+
+// Expect sets up expected params for Repository.UpdatePipelineCanary
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) Expect(ctx context.Context, pp1 *datamodel.PipelineCanary) *mRepositoryMockUpdatePipelineCanary {
+	if mmUpdatePipelineCanary.mock.funcUpdatePipelineCanary != nil {
+		mmUpdatePipelineCanary.mock.t.Fatalf("RepositoryMock.UpdatePipelineCanary mock is already set by Set")
+	}
+
+	if mmUpdatePipelineCanary.defaultExpectation == nil {
+		mmUpdatePipelineCanary.defaultExpectation = &RepositoryMockUpdatePipelineCanaryExpectation{}
+	}
+
+	if mmUpdatePipelineCanary.defaultExpectation.paramPtrs != nil {
+		mmUpdatePipelineCanary.mock.t.Fatalf("RepositoryMock.UpdatePipelineCanary mock is already set by ExpectParams functions")
+	}
+
+	mmUpdatePipelineCanary.defaultExpectation.params = &RepositoryMockUpdatePipelineCanaryParams{ctx, pp1}
+	mmUpdatePipelineCanary.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmUpdatePipelineCanary.expectations {
+		if minimock.Equal(e.params, mmUpdatePipelineCanary.defaultExpectation.params) {
+			mmUpdatePipelineCanary.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUpdatePipelineCanary.defaultExpectation.params)
+		}
+	}
+
+	return mmUpdatePipelineCanary
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.UpdatePipelineCanary
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) ExpectCtxParam1(ctx context.Context) *mRepositoryMockUpdatePipelineCanary {
+	if mmUpdatePipelineCanary.mock.funcUpdatePipelineCanary != nil {
+		mmUpdatePipelineCanary.mock.t.Fatalf("RepositoryMock.UpdatePipelineCanary mock is already set by Set")
+	}
+
+	if mmUpdatePipelineCanary.defaultExpectation == nil {
+		mmUpdatePipelineCanary.defaultExpectation = &RepositoryMockUpdatePipelineCanaryExpectation{}
+	}
+
+	if mmUpdatePipelineCanary.defaultExpectation.params != nil {
+		mmUpdatePipelineCanary.mock.t.Fatalf("RepositoryMock.UpdatePipelineCanary mock is already set by Expect")
+	}
+
+	if mmUpdatePipelineCanary.defaultExpectation.paramPtrs == nil {
+		mmUpdatePipelineCanary.defaultExpectation.paramPtrs = &RepositoryMockUpdatePipelineCanaryParamPtrs{}
+	}
+	mmUpdatePipelineCanary.defaultExpectation.paramPtrs.ctx = &ctx
+	mmUpdatePipelineCanary.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmUpdatePipelineCanary
+}
+
+// ExpectPp1Param2 sets up expected param pp1 for Repository.UpdatePipelineCanary
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) ExpectPp1Param2(pp1 *datamodel.PipelineCanary) *mRepositoryMockUpdatePipelineCanary {
+	if mmUpdatePipelineCanary.mock.funcUpdatePipelineCanary != nil {
+		mmUpdatePipelineCanary.mock.t.Fatalf("RepositoryMock.UpdatePipelineCanary mock is already set by Set")
+	}
+
+	if mmUpdatePipelineCanary.defaultExpectation == nil {
+		mmUpdatePipelineCanary.defaultExpectation = &RepositoryMockUpdatePipelineCanaryExpectation{}
+	}
+
+	if mmUpdatePipelineCanary.defaultExpectation.params != nil {
+		mmUpdatePipelineCanary.mock.t.Fatalf("RepositoryMock.UpdatePipelineCanary mock is already set by Expect")
+	}
+
+	if mmUpdatePipelineCanary.defaultExpectation.paramPtrs == nil {
+		mmUpdatePipelineCanary.defaultExpectation.paramPtrs = &RepositoryMockUpdatePipelineCanaryParamPtrs{}
+	}
+	mmUpdatePipelineCanary.defaultExpectation.paramPtrs.pp1 = &pp1
+	mmUpdatePipelineCanary.defaultExpectation.expectationOrigins.originPp1 = minimock.CallerInfo(1)
+
+	return mmUpdatePipelineCanary
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.UpdatePipelineCanary
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) Inspect(f func(ctx context.Context, pp1 *datamodel.PipelineCanary)) *mRepositoryMockUpdatePipelineCanary {
+	if mmUpdatePipelineCanary.mock.inspectFuncUpdatePipelineCanary != nil {
+		mmUpdatePipelineCanary.mock.t.Fatalf("Inspect function is already set for RepositoryMock.UpdatePipelineCanary")
+	}
+
+	mmUpdatePipelineCanary.mock.inspectFuncUpdatePipelineCanary = f
+
+	return mmUpdatePipelineCanary
+}
+
+// Return sets up results that will be returned by Repository.UpdatePipelineCanary
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) Return(err error) *RepositoryMock {
+	if mmUpdatePipelineCanary.mock.funcUpdatePipelineCanary != nil {
+		mmUpdatePipelineCanary.mock.t.Fatalf("RepositoryMock.UpdatePipelineCanary mock is already set by Set")
+	}
+
+	if mmUpdatePipelineCanary.defaultExpectation == nil {
+		mmUpdatePipelineCanary.defaultExpectation = &RepositoryMockUpdatePipelineCanaryExpectation{mock: mmUpdatePipelineCanary.mock}
+	}
+	mmUpdatePipelineCanary.defaultExpectation.results = &RepositoryMockUpdatePipelineCanaryResults{err}
+	mmUpdatePipelineCanary.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmUpdatePipelineCanary.mock
+}
+
+// Set uses given function f to mock the Repository.UpdatePipelineCanary method
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) Set(f func(ctx context.Context, pp1 *datamodel.PipelineCanary) (err error)) *RepositoryMock {
+	if mmUpdatePipelineCanary.defaultExpectation != nil {
+		mmUpdatePipelineCanary.mock.t.Fatalf("Default expectation is already set for the Repository.UpdatePipelineCanary method")
+	}
+
+	if len(mmUpdatePipelineCanary.expectations) > 0 {
+		mmUpdatePipelineCanary.mock.t.Fatalf("Some expectations are already set for the Repository.UpdatePipelineCanary method")
+	}
+
+	mmUpdatePipelineCanary.mock.funcUpdatePipelineCanary = f
+	mmUpdatePipelineCanary.mock.funcUpdatePipelineCanaryOrigin = minimock.CallerInfo(1)
+	return mmUpdatePipelineCanary.mock
+}
+
+// When sets expectation for the Repository.UpdatePipelineCanary which will trigger the result defined by the following
+// Then helper
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) When(ctx context.Context, pp1 *datamodel.PipelineCanary) *RepositoryMockUpdatePipelineCanaryExpectation {
+	if mmUpdatePipelineCanary.mock.funcUpdatePipelineCanary != nil {
+		mmUpdatePipelineCanary.mock.t.Fatalf("RepositoryMock.UpdatePipelineCanary mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockUpdatePipelineCanaryExpectation{
+		mock:               mmUpdatePipelineCanary.mock,
+		params:             &RepositoryMockUpdatePipelineCanaryParams{ctx, pp1},
+		expectationOrigins: RepositoryMockUpdatePipelineCanaryExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmUpdatePipelineCanary.expectations = append(mmUpdatePipelineCanary.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.UpdatePipelineCanary return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockUpdatePipelineCanaryExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockUpdatePipelineCanaryResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.UpdatePipelineCanary should be invoked
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) Times(n uint64) *mRepositoryMockUpdatePipelineCanary {
+	if n == 0 {
+		mmUpdatePipelineCanary.mock.t.Fatalf("Times of RepositoryMock.UpdatePipelineCanary mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmUpdatePipelineCanary.expectedInvocations, n)
+	mmUpdatePipelineCanary.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmUpdatePipelineCanary
+}
+
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) invocationsDone() bool {
+	if len(mmUpdatePipelineCanary.expectations) == 0 && mmUpdatePipelineCanary.defaultExpectation == nil && mmUpdatePipelineCanary.mock.funcUpdatePipelineCanary == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmUpdatePipelineCanary.mock.afterUpdatePipelineCanaryCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmUpdatePipelineCanary.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// UpdatePipelineCanary implements mm_repository.Repository
+func (mmUpdatePipelineCanary *RepositoryMock) UpdatePipelineCanary(ctx context.Context, pp1 *datamodel.PipelineCanary) (err error) {
+	mm_atomic.AddUint64(&mmUpdatePipelineCanary.beforeUpdatePipelineCanaryCounter, 1)
+	defer mm_atomic.AddUint64(&mmUpdatePipelineCanary.afterUpdatePipelineCanaryCounter, 1)
+
+	mmUpdatePipelineCanary.t.Helper()
+
+	if mmUpdatePipelineCanary.inspectFuncUpdatePipelineCanary != nil {
+		mmUpdatePipelineCanary.inspectFuncUpdatePipelineCanary(ctx, pp1)
+	}
+
+	mm_params := RepositoryMockUpdatePipelineCanaryParams{ctx, pp1}
+
+	// Record call args
+	mmUpdatePipelineCanary.UpdatePipelineCanaryMock.mutex.Lock()
+	mmUpdatePipelineCanary.UpdatePipelineCanaryMock.callArgs = append(mmUpdatePipelineCanary.UpdatePipelineCanaryMock.callArgs, &mm_params)
+	mmUpdatePipelineCanary.UpdatePipelineCanaryMock.mutex.Unlock()
+
+	for _, e := range mmUpdatePipelineCanary.UpdatePipelineCanaryMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmUpdatePipelineCanary.UpdatePipelineCanaryMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpdatePipelineCanary.UpdatePipelineCanaryMock.defaultExpectation.Counter, 1)
+		mm_want := mmUpdatePipelineCanary.UpdatePipelineCanaryMock.defaultExpectation.params
+		mm_want_ptrs := mmUpdatePipelineCanary.UpdatePipelineCanaryMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockUpdatePipelineCanaryParams{ctx, pp1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmUpdatePipelineCanary.t.Errorf("RepositoryMock.UpdatePipelineCanary got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdatePipelineCanary.UpdatePipelineCanaryMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.pp1 != nil && !minimock.Equal(*mm_want_ptrs.pp1, mm_got.pp1) {
+				mmUpdatePipelineCanary.t.Errorf("RepositoryMock.UpdatePipelineCanary got unexpected parameter pp1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdatePipelineCanary.UpdatePipelineCanaryMock.defaultExpectation.expectationOrigins.originPp1, *mm_want_ptrs.pp1, mm_got.pp1, minimock.Diff(*mm_want_ptrs.pp1, mm_got.pp1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmUpdatePipelineCanary.t.Errorf("RepositoryMock.UpdatePipelineCanary got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmUpdatePipelineCanary.UpdatePipelineCanaryMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmUpdatePipelineCanary.UpdatePipelineCanaryMock.defaultExpectation.results
+		if mm_results == nil {
+			mmUpdatePipelineCanary.t.Fatal("No results are set for the RepositoryMock.UpdatePipelineCanary")
+		}
+		return (*mm_results).err
+	}
+	if mmUpdatePipelineCanary.funcUpdatePipelineCanary != nil {
+		return mmUpdatePipelineCanary.funcUpdatePipelineCanary(ctx, pp1)
+	}
+	mmUpdatePipelineCanary.t.Fatalf("Unexpected call to RepositoryMock.UpdatePipelineCanary. %v %v", ctx, pp1)
+	return
+}
+
+// UpdatePipelineCanaryAfterCounter returns a count of finished RepositoryMock.UpdatePipelineCanary invocations
+func (mmUpdatePipelineCanary *RepositoryMock) UpdatePipelineCanaryAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdatePipelineCanary.afterUpdatePipelineCanaryCounter)
+}
+
+// UpdatePipelineCanaryBeforeCounter returns a count of RepositoryMock.UpdatePipelineCanary invocations
+func (mmUpdatePipelineCanary *RepositoryMock) UpdatePipelineCanaryBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdatePipelineCanary.beforeUpdatePipelineCanaryCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.UpdatePipelineCanary.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmUpdatePipelineCanary *mRepositoryMockUpdatePipelineCanary) Calls() []*RepositoryMockUpdatePipelineCanaryParams {
+	mmUpdatePipelineCanary.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockUpdatePipelineCanaryParams, len(mmUpdatePipelineCanary.callArgs))
+	copy(argCopy, mmUpdatePipelineCanary.callArgs)
 
-	mmUpdateNamespaceSecretByID.mutex.RUnlock()
+	mmUpdatePipelineCanary.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockUpdateNamespaceSecretByIDDone returns true if the count of the UpdateNamespaceSecretByID invocations corresponds
+// MinimockUpdatePipelineCanaryDone returns true if the count of the UpdatePipelineCanary invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockUpdateNamespaceSecretByIDDone() bool {
-	if m.UpdateNamespaceSecretByIDMock.optional {
+func (m *RepositoryMock) MinimockUpdatePipelineCanaryDone() bool {
+	if m.UpdatePipelineCanaryMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.UpdateNamespaceSecretByIDMock.expectations {
+	for _, e := range m.UpdatePipelineCanaryMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.UpdateNamespaceSecretByIDMock.invocationsDone()
+	return m.UpdatePipelineCanaryMock.invocationsDone()
 }
 
-// MinimockUpdateNamespaceSecretByIDInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockUpdateNamespaceSecretByIDInspect() {
-	for _, e := range m.UpdateNamespaceSecretByIDMock.expectations {
+// MinimockUpdatePipelineCanaryInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockUpdatePipelineCanaryInspect() {
+	for _, e := range m.UpdatePipelineCanaryMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceSecretByID at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.UpdatePipelineCanary at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterUpdateNamespaceSecretByIDCounter := mm_atomic.LoadUint64(&m.afterUpdateNamespaceSecretByIDCounter)
+	afterUpdatePipelineCanaryCounter := mm_atomic.LoadUint64(&m.afterUpdatePipelineCanaryCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.UpdateNamespaceSecretByIDMock.defaultExpectation != nil && afterUpdateNamespaceSecretByIDCounter < 1 {
-		if m.UpdateNamespaceSecretByIDMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceSecretByID at\n%s", m.UpdateNamespaceSecretByIDMock.defaultExpectation.returnOrigin)
+	if m.UpdatePipelineCanaryMock.defaultExpectation != nil && afterUpdatePipelineCanaryCounter < 1 {
+		if m.UpdatePipelineCanaryMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.UpdatePipelineCanary at\n%s", m.UpdatePipelineCanaryMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceSecretByID at\n%s with params: %#v", m.UpdateNamespaceSecretByIDMock.defaultExpectation.expectationOrigins.origin, *m.UpdateNamespaceSecretByIDMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.UpdatePipelineCanary at\n%s with params: %#v", m.UpdatePipelineCanaryMock.defaultExpectation.expectationOrigins.origin, *m.UpdatePipelineCanaryMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcUpdateNamespaceSecretByID != nil && afterUpdateNamespaceSecretByIDCounter < 1 {
-		m.t.Errorf("Expected call to RepositoryMock.UpdateNamespaceSecretByID at\n%s", m.funcUpdateNamespaceSecretByIDOrigin)
+	if m.funcUpdatePipelineCanary != nil && afterUpdatePipelineCanaryCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.UpdatePipelineCanary at\n%s", m.funcUpdatePipelineCanaryOrigin)
 	}
 
-	if !m.UpdateNamespaceSecretByIDMock.invocationsDone() && afterUpdateNamespaceSecretByIDCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.UpdateNamespaceSecretByID at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.UpdateNamespaceSecretByIDMock.expectedInvocations), m.UpdateNamespaceSecretByIDMock.expectedInvocationsOrigin, afterUpdateNamespaceSecretByIDCounter)
+	if !m.UpdatePipelineCanaryMock.invocationsDone() && afterUpdatePipelineCanaryCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.UpdatePipelineCanary at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.UpdatePipelineCanaryMock.expectedInvocations), m.UpdatePipelineCanaryMock.expectedInvocationsOrigin, afterUpdatePipelineCanaryCounter)
 	}
 }
 
@@ -20070,139 +27547,481 @@ func (mmUpsertComponentRun *mRepositoryMockUpsertComponentRun) invocationsDone()
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmUpsertComponentRun.mock.afterUpsertComponentRunCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmUpsertComponentRun.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmUpsertComponentRun.mock.afterUpsertComponentRunCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmUpsertComponentRun.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// UpsertComponentRun implements mm_repository.Repository
+func (mmUpsertComponentRun *RepositoryMock) UpsertComponentRun(ctx context.Context, componentRun *datamodel.ComponentRun) (err error) {
+	mm_atomic.AddUint64(&mmUpsertComponentRun.beforeUpsertComponentRunCounter, 1)
+	defer mm_atomic.AddUint64(&mmUpsertComponentRun.afterUpsertComponentRunCounter, 1)
+
+	mmUpsertComponentRun.t.Helper()
+
+	if mmUpsertComponentRun.inspectFuncUpsertComponentRun != nil {
+		mmUpsertComponentRun.inspectFuncUpsertComponentRun(ctx, componentRun)
+	}
+
+	mm_params := RepositoryMockUpsertComponentRunParams{ctx, componentRun}
+
+	// Record call args
+	mmUpsertComponentRun.UpsertComponentRunMock.mutex.Lock()
+	mmUpsertComponentRun.UpsertComponentRunMock.callArgs = append(mmUpsertComponentRun.UpsertComponentRunMock.callArgs, &mm_params)
+	mmUpsertComponentRun.UpsertComponentRunMock.mutex.Unlock()
+
+	for _, e := range mmUpsertComponentRun.UpsertComponentRunMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.Counter, 1)
+		mm_want := mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.params
+		mm_want_ptrs := mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockUpsertComponentRunParams{ctx, componentRun}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmUpsertComponentRun.t.Errorf("RepositoryMock.UpsertComponentRun got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.componentRun != nil && !minimock.Equal(*mm_want_ptrs.componentRun, mm_got.componentRun) {
+				mmUpsertComponentRun.t.Errorf("RepositoryMock.UpsertComponentRun got unexpected parameter componentRun, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.expectationOrigins.originComponentRun, *mm_want_ptrs.componentRun, mm_got.componentRun, minimock.Diff(*mm_want_ptrs.componentRun, mm_got.componentRun))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmUpsertComponentRun.t.Errorf("RepositoryMock.UpsertComponentRun got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.results
+		if mm_results == nil {
+			mmUpsertComponentRun.t.Fatal("No results are set for the RepositoryMock.UpsertComponentRun")
+		}
+		return (*mm_results).err
+	}
+	if mmUpsertComponentRun.funcUpsertComponentRun != nil {
+		return mmUpsertComponentRun.funcUpsertComponentRun(ctx, componentRun)
+	}
+	mmUpsertComponentRun.t.Fatalf("Unexpected call to RepositoryMock.UpsertComponentRun. %v %v", ctx, componentRun)
+	return
+}
+
+// UpsertComponentRunAfterCounter returns a count of finished RepositoryMock.UpsertComponentRun invocations
+func (mmUpsertComponentRun *RepositoryMock) UpsertComponentRunAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpsertComponentRun.afterUpsertComponentRunCounter)
+}
+
+// UpsertComponentRunBeforeCounter returns a count of RepositoryMock.UpsertComponentRun invocations
+func (mmUpsertComponentRun *RepositoryMock) UpsertComponentRunBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpsertComponentRun.beforeUpsertComponentRunCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.UpsertComponentRun.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmUpsertComponentRun *mRepositoryMockUpsertComponentRun) Calls() []*RepositoryMockUpsertComponentRunParams {
+	mmUpsertComponentRun.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockUpsertComponentRunParams, len(mmUpsertComponentRun.callArgs))
+	copy(argCopy, mmUpsertComponentRun.callArgs)
+
+	mmUpsertComponentRun.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockUpsertComponentRunDone returns true if the count of the UpsertComponentRun invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockUpsertComponentRunDone() bool {
+	if m.UpsertComponentRunMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.UpsertComponentRunMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.UpsertComponentRunMock.invocationsDone()
+}
+
+// MinimockUpsertComponentRunInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockUpsertComponentRunInspect() {
+	for _, e := range m.UpsertComponentRunMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.UpsertComponentRun at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterUpsertComponentRunCounter := mm_atomic.LoadUint64(&m.afterUpsertComponentRunCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.UpsertComponentRunMock.defaultExpectation != nil && afterUpsertComponentRunCounter < 1 {
+		if m.UpsertComponentRunMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.UpsertComponentRun at\n%s", m.UpsertComponentRunMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.UpsertComponentRun at\n%s with params: %#v", m.UpsertComponentRunMock.defaultExpectation.expectationOrigins.origin, *m.UpsertComponentRunMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcUpsertComponentRun != nil && afterUpsertComponentRunCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.UpsertComponentRun at\n%s", m.funcUpsertComponentRunOrigin)
+	}
+
+	if !m.UpsertComponentRunMock.invocationsDone() && afterUpsertComponentRunCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.UpsertComponentRun at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.UpsertComponentRunMock.expectedInvocations), m.UpsertComponentRunMock.expectedInvocationsOrigin, afterUpsertComponentRunCounter)
+	}
+}
+
+type mRepositoryMockUpsertNamespaceSettings struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockUpsertNamespaceSettingsExpectation
+	expectations       []*RepositoryMockUpsertNamespaceSettingsExpectation
+
+	callArgs []*RepositoryMockUpsertNamespaceSettingsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockUpsertNamespaceSettingsExpectation specifies expectation struct of the Repository.UpsertNamespaceSettings
+type RepositoryMockUpsertNamespaceSettingsExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockUpsertNamespaceSettingsParams
+	paramPtrs          *RepositoryMockUpsertNamespaceSettingsParamPtrs
+	expectationOrigins RepositoryMockUpsertNamespaceSettingsExpectationOrigins
+	results            *RepositoryMockUpsertNamespaceSettingsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockUpsertNamespaceSettingsParams contains parameters of the Repository.UpsertNamespaceSettings
+type RepositoryMockUpsertNamespaceSettingsParams struct {
+	ctx context.Context
+	np1 *datamodel.NamespaceSettings
+}
+
+// RepositoryMockUpsertNamespaceSettingsParamPtrs contains pointers to parameters of the Repository.UpsertNamespaceSettings
+type RepositoryMockUpsertNamespaceSettingsParamPtrs struct {
+	ctx *context.Context
+	np1 **datamodel.NamespaceSettings
+}
+
+// RepositoryMockUpsertNamespaceSettingsResults contains results of the Repository.UpsertNamespaceSettings
+type RepositoryMockUpsertNamespaceSettingsResults struct {
+	err error
+}
+
+// RepositoryMockUpsertNamespaceSettingsOrigins contains origins of expectations of the Repository.UpsertNamespaceSettings
+type RepositoryMockUpsertNamespaceSettingsExpectationOrigins struct {
+	origin    string
+	originCtx string
+	originNp1 string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) Optional() *mRepositoryMockUpsertNamespaceSettings {
+	mmUpsertNamespaceSettings.optional = true
+	return mmUpsertNamespaceSettings
+}
+
+// Expect sets up expected params for Repository.UpsertNamespaceSettings
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) Expect(ctx context.Context, np1 *datamodel.NamespaceSettings) *mRepositoryMockUpsertNamespaceSettings {
+	if mmUpsertNamespaceSettings.mock.funcUpsertNamespaceSettings != nil {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("RepositoryMock.UpsertNamespaceSettings mock is already set by Set")
+	}
+
+	if mmUpsertNamespaceSettings.defaultExpectation == nil {
+		mmUpsertNamespaceSettings.defaultExpectation = &RepositoryMockUpsertNamespaceSettingsExpectation{}
+	}
+
+	if mmUpsertNamespaceSettings.defaultExpectation.paramPtrs != nil {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("RepositoryMock.UpsertNamespaceSettings mock is already set by ExpectParams functions")
+	}
+
+	mmUpsertNamespaceSettings.defaultExpectation.params = &RepositoryMockUpsertNamespaceSettingsParams{ctx, np1}
+	mmUpsertNamespaceSettings.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmUpsertNamespaceSettings.expectations {
+		if minimock.Equal(e.params, mmUpsertNamespaceSettings.defaultExpectation.params) {
+			mmUpsertNamespaceSettings.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUpsertNamespaceSettings.defaultExpectation.params)
+		}
+	}
+
+	return mmUpsertNamespaceSettings
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.UpsertNamespaceSettings
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) ExpectCtxParam1(ctx context.Context) *mRepositoryMockUpsertNamespaceSettings {
+	if mmUpsertNamespaceSettings.mock.funcUpsertNamespaceSettings != nil {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("RepositoryMock.UpsertNamespaceSettings mock is already set by Set")
+	}
+
+	if mmUpsertNamespaceSettings.defaultExpectation == nil {
+		mmUpsertNamespaceSettings.defaultExpectation = &RepositoryMockUpsertNamespaceSettingsExpectation{}
+	}
+
+	if mmUpsertNamespaceSettings.defaultExpectation.params != nil {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("RepositoryMock.UpsertNamespaceSettings mock is already set by Expect")
+	}
+
+	if mmUpsertNamespaceSettings.defaultExpectation.paramPtrs == nil {
+		mmUpsertNamespaceSettings.defaultExpectation.paramPtrs = &RepositoryMockUpsertNamespaceSettingsParamPtrs{}
+	}
+	mmUpsertNamespaceSettings.defaultExpectation.paramPtrs.ctx = &ctx
+	mmUpsertNamespaceSettings.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmUpsertNamespaceSettings
+}
+
+// ExpectNp1Param2 sets up expected param np1 for Repository.UpsertNamespaceSettings
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) ExpectNp1Param2(np1 *datamodel.NamespaceSettings) *mRepositoryMockUpsertNamespaceSettings {
+	if mmUpsertNamespaceSettings.mock.funcUpsertNamespaceSettings != nil {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("RepositoryMock.UpsertNamespaceSettings mock is already set by Set")
+	}
+
+	if mmUpsertNamespaceSettings.defaultExpectation == nil {
+		mmUpsertNamespaceSettings.defaultExpectation = &RepositoryMockUpsertNamespaceSettingsExpectation{}
+	}
+
+	if mmUpsertNamespaceSettings.defaultExpectation.params != nil {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("RepositoryMock.UpsertNamespaceSettings mock is already set by Expect")
+	}
+
+	if mmUpsertNamespaceSettings.defaultExpectation.paramPtrs == nil {
+		mmUpsertNamespaceSettings.defaultExpectation.paramPtrs = &RepositoryMockUpsertNamespaceSettingsParamPtrs{}
+	}
+	mmUpsertNamespaceSettings.defaultExpectation.paramPtrs.np1 = &np1
+	mmUpsertNamespaceSettings.defaultExpectation.expectationOrigins.originNp1 = minimock.CallerInfo(1)
+
+	return mmUpsertNamespaceSettings
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.UpsertNamespaceSettings
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) Inspect(f func(ctx context.Context, np1 *datamodel.NamespaceSettings)) *mRepositoryMockUpsertNamespaceSettings {
+	if mmUpsertNamespaceSettings.mock.inspectFuncUpsertNamespaceSettings != nil {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("Inspect function is already set for RepositoryMock.UpsertNamespaceSettings")
+	}
+
+	mmUpsertNamespaceSettings.mock.inspectFuncUpsertNamespaceSettings = f
+
+	return mmUpsertNamespaceSettings
+}
+
+// Return sets up results that will be returned by Repository.UpsertNamespaceSettings
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) Return(err error) *RepositoryMock {
+	if mmUpsertNamespaceSettings.mock.funcUpsertNamespaceSettings != nil {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("RepositoryMock.UpsertNamespaceSettings mock is already set by Set")
+	}
+
+	if mmUpsertNamespaceSettings.defaultExpectation == nil {
+		mmUpsertNamespaceSettings.defaultExpectation = &RepositoryMockUpsertNamespaceSettingsExpectation{mock: mmUpsertNamespaceSettings.mock}
+	}
+	mmUpsertNamespaceSettings.defaultExpectation.results = &RepositoryMockUpsertNamespaceSettingsResults{err}
+	mmUpsertNamespaceSettings.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmUpsertNamespaceSettings.mock
+}
+
+// Set uses given function f to mock the Repository.UpsertNamespaceSettings method
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) Set(f func(ctx context.Context, np1 *datamodel.NamespaceSettings) (err error)) *RepositoryMock {
+	if mmUpsertNamespaceSettings.defaultExpectation != nil {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("Default expectation is already set for the Repository.UpsertNamespaceSettings method")
+	}
+
+	if len(mmUpsertNamespaceSettings.expectations) > 0 {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("Some expectations are already set for the Repository.UpsertNamespaceSettings method")
+	}
+
+	mmUpsertNamespaceSettings.mock.funcUpsertNamespaceSettings = f
+	mmUpsertNamespaceSettings.mock.funcUpsertNamespaceSettingsOrigin = minimock.CallerInfo(1)
+	return mmUpsertNamespaceSettings.mock
+}
+
+// When sets expectation for the Repository.UpsertNamespaceSettings which will trigger the result defined by the following
+// Then helper
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) When(ctx context.Context, np1 *datamodel.NamespaceSettings) *RepositoryMockUpsertNamespaceSettingsExpectation {
+	if mmUpsertNamespaceSettings.mock.funcUpsertNamespaceSettings != nil {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("RepositoryMock.UpsertNamespaceSettings mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockUpsertNamespaceSettingsExpectation{
+		mock:               mmUpsertNamespaceSettings.mock,
+		params:             &RepositoryMockUpsertNamespaceSettingsParams{ctx, np1},
+		expectationOrigins: RepositoryMockUpsertNamespaceSettingsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmUpsertNamespaceSettings.expectations = append(mmUpsertNamespaceSettings.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.UpsertNamespaceSettings return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockUpsertNamespaceSettingsExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockUpsertNamespaceSettingsResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.UpsertNamespaceSettings should be invoked
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) Times(n uint64) *mRepositoryMockUpsertNamespaceSettings {
+	if n == 0 {
+		mmUpsertNamespaceSettings.mock.t.Fatalf("Times of RepositoryMock.UpsertNamespaceSettings mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmUpsertNamespaceSettings.expectedInvocations, n)
+	mmUpsertNamespaceSettings.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmUpsertNamespaceSettings
+}
+
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) invocationsDone() bool {
+	if len(mmUpsertNamespaceSettings.expectations) == 0 && mmUpsertNamespaceSettings.defaultExpectation == nil && mmUpsertNamespaceSettings.mock.funcUpsertNamespaceSettings == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmUpsertNamespaceSettings.mock.afterUpsertNamespaceSettingsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmUpsertNamespaceSettings.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// UpsertComponentRun implements mm_repository.Repository
-func (mmUpsertComponentRun *RepositoryMock) UpsertComponentRun(ctx context.Context, componentRun *datamodel.ComponentRun) (err error) {
-	mm_atomic.AddUint64(&mmUpsertComponentRun.beforeUpsertComponentRunCounter, 1)
-	defer mm_atomic.AddUint64(&mmUpsertComponentRun.afterUpsertComponentRunCounter, 1)
+// UpsertNamespaceSettings implements mm_repository.Repository
+func (mmUpsertNamespaceSettings *RepositoryMock) UpsertNamespaceSettings(ctx context.Context, np1 *datamodel.NamespaceSettings) (err error) {
+	mm_atomic.AddUint64(&mmUpsertNamespaceSettings.beforeUpsertNamespaceSettingsCounter, 1)
+	defer mm_atomic.AddUint64(&mmUpsertNamespaceSettings.afterUpsertNamespaceSettingsCounter, 1)
 
-	mmUpsertComponentRun.t.Helper()
+	mmUpsertNamespaceSettings.t.Helper()
 
-	if mmUpsertComponentRun.inspectFuncUpsertComponentRun != nil {
-		mmUpsertComponentRun.inspectFuncUpsertComponentRun(ctx, componentRun)
+	if mmUpsertNamespaceSettings.inspectFuncUpsertNamespaceSettings != nil {
+		mmUpsertNamespaceSettings.inspectFuncUpsertNamespaceSettings(ctx, np1)
 	}
 
-	mm_params := RepositoryMockUpsertComponentRunParams{ctx, componentRun}
+	mm_params := RepositoryMockUpsertNamespaceSettingsParams{ctx, np1}
 
 	// Record call args
-	mmUpsertComponentRun.UpsertComponentRunMock.mutex.Lock()
-	mmUpsertComponentRun.UpsertComponentRunMock.callArgs = append(mmUpsertComponentRun.UpsertComponentRunMock.callArgs, &mm_params)
-	mmUpsertComponentRun.UpsertComponentRunMock.mutex.Unlock()
+	mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.mutex.Lock()
+	mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.callArgs = append(mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.callArgs, &mm_params)
+	mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.mutex.Unlock()
 
-	for _, e := range mmUpsertComponentRun.UpsertComponentRunMock.expectations {
+	for _, e := range mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
 			return e.results.err
 		}
 	}
 
-	if mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.Counter, 1)
-		mm_want := mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.params
-		mm_want_ptrs := mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.paramPtrs
+	if mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.defaultExpectation.Counter, 1)
+		mm_want := mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.defaultExpectation.params
+		mm_want_ptrs := mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockUpsertComponentRunParams{ctx, componentRun}
+		mm_got := RepositoryMockUpsertNamespaceSettingsParams{ctx, np1}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmUpsertComponentRun.t.Errorf("RepositoryMock.UpsertComponentRun got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmUpsertNamespaceSettings.t.Errorf("RepositoryMock.UpsertNamespaceSettings got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.componentRun != nil && !minimock.Equal(*mm_want_ptrs.componentRun, mm_got.componentRun) {
-				mmUpsertComponentRun.t.Errorf("RepositoryMock.UpsertComponentRun got unexpected parameter componentRun, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.expectationOrigins.originComponentRun, *mm_want_ptrs.componentRun, mm_got.componentRun, minimock.Diff(*mm_want_ptrs.componentRun, mm_got.componentRun))
+			if mm_want_ptrs.np1 != nil && !minimock.Equal(*mm_want_ptrs.np1, mm_got.np1) {
+				mmUpsertNamespaceSettings.t.Errorf("RepositoryMock.UpsertNamespaceSettings got unexpected parameter np1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.defaultExpectation.expectationOrigins.originNp1, *mm_want_ptrs.np1, mm_got.np1, minimock.Diff(*mm_want_ptrs.np1, mm_got.np1))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmUpsertComponentRun.t.Errorf("RepositoryMock.UpsertComponentRun got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmUpsertNamespaceSettings.t.Errorf("RepositoryMock.UpsertNamespaceSettings got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmUpsertComponentRun.UpsertComponentRunMock.defaultExpectation.results
+		mm_results := mmUpsertNamespaceSettings.UpsertNamespaceSettingsMock.defaultExpectation.results
 		if mm_results == nil {
-			mmUpsertComponentRun.t.Fatal("No results are set for the RepositoryMock.UpsertComponentRun")
+			mmUpsertNamespaceSettings.t.Fatal("No results are set for the RepositoryMock.UpsertNamespaceSettings")
 		}
 		return (*mm_results).err
 	}
-	if mmUpsertComponentRun.funcUpsertComponentRun != nil {
-		return mmUpsertComponentRun.funcUpsertComponentRun(ctx, componentRun)
+	if mmUpsertNamespaceSettings.funcUpsertNamespaceSettings != nil {
+		return mmUpsertNamespaceSettings.funcUpsertNamespaceSettings(ctx, np1)
 	}
-	mmUpsertComponentRun.t.Fatalf("Unexpected call to RepositoryMock.UpsertComponentRun. %v %v", ctx, componentRun)
+	mmUpsertNamespaceSettings.t.Fatalf("Unexpected call to RepositoryMock.UpsertNamespaceSettings. %v %v", ctx, np1)
 	return
 }
 
-// UpsertComponentRunAfterCounter returns a count of finished RepositoryMock.UpsertComponentRun invocations
-func (mmUpsertComponentRun *RepositoryMock) UpsertComponentRunAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmUpsertComponentRun.afterUpsertComponentRunCounter)
+// UpsertNamespaceSettingsAfterCounter returns a count of finished RepositoryMock.UpsertNamespaceSettings invocations
+func (mmUpsertNamespaceSettings *RepositoryMock) UpsertNamespaceSettingsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpsertNamespaceSettings.afterUpsertNamespaceSettingsCounter)
 }
 
-// UpsertComponentRunBeforeCounter returns a count of RepositoryMock.UpsertComponentRun invocations
-func (mmUpsertComponentRun *RepositoryMock) UpsertComponentRunBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmUpsertComponentRun.beforeUpsertComponentRunCounter)
+// UpsertNamespaceSettingsBeforeCounter returns a count of RepositoryMock.UpsertNamespaceSettings invocations
+func (mmUpsertNamespaceSettings *RepositoryMock) UpsertNamespaceSettingsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpsertNamespaceSettings.beforeUpsertNamespaceSettingsCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.UpsertComponentRun.
+// Calls returns a list of arguments used in each call to RepositoryMock.UpsertNamespaceSettings.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmUpsertComponentRun *mRepositoryMockUpsertComponentRun) Calls() []*RepositoryMockUpsertComponentRunParams {
-	mmUpsertComponentRun.mutex.RLock()
+func (mmUpsertNamespaceSettings *mRepositoryMockUpsertNamespaceSettings) Calls() []*RepositoryMockUpsertNamespaceSettingsParams {
+	mmUpsertNamespaceSettings.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockUpsertComponentRunParams, len(mmUpsertComponentRun.callArgs))
-	copy(argCopy, mmUpsertComponentRun.callArgs)
+	argCopy := make([]*RepositoryMockUpsertNamespaceSettingsParams, len(mmUpsertNamespaceSettings.callArgs))
+	copy(argCopy, mmUpsertNamespaceSettings.callArgs)
 
-	mmUpsertComponentRun.mutex.RUnlock()
+	mmUpsertNamespaceSettings.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockUpsertComponentRunDone returns true if the count of the UpsertComponentRun invocations corresponds
+// MinimockUpsertNamespaceSettingsDone returns true if the count of the UpsertNamespaceSettings invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockUpsertComponentRunDone() bool {
-	if m.UpsertComponentRunMock.optional {
+func (m *RepositoryMock) MinimockUpsertNamespaceSettingsDone() bool {
+	if m.UpsertNamespaceSettingsMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.UpsertComponentRunMock.expectations {
+	for _, e := range m.UpsertNamespaceSettingsMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.UpsertComponentRunMock.invocationsDone()
+	return m.UpsertNamespaceSettingsMock.invocationsDone()
 }
 
-// MinimockUpsertComponentRunInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockUpsertComponentRunInspect() {
-	for _, e := range m.UpsertComponentRunMock.expectations {
+// MinimockUpsertNamespaceSettingsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockUpsertNamespaceSettingsInspect() {
+	for _, e := range m.UpsertNamespaceSettingsMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.UpsertComponentRun at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.UpsertNamespaceSettings at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterUpsertComponentRunCounter := mm_atomic.LoadUint64(&m.afterUpsertComponentRunCounter)
+	afterUpsertNamespaceSettingsCounter := mm_atomic.LoadUint64(&m.afterUpsertNamespaceSettingsCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.UpsertComponentRunMock.defaultExpectation != nil && afterUpsertComponentRunCounter < 1 {
-		if m.UpsertComponentRunMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to RepositoryMock.UpsertComponentRun at\n%s", m.UpsertComponentRunMock.defaultExpectation.returnOrigin)
+	if m.UpsertNamespaceSettingsMock.defaultExpectation != nil && afterUpsertNamespaceSettingsCounter < 1 {
+		if m.UpsertNamespaceSettingsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.UpsertNamespaceSettings at\n%s", m.UpsertNamespaceSettingsMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.UpsertComponentRun at\n%s with params: %#v", m.UpsertComponentRunMock.defaultExpectation.expectationOrigins.origin, *m.UpsertComponentRunMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.UpsertNamespaceSettings at\n%s with params: %#v", m.UpsertNamespaceSettingsMock.defaultExpectation.expectationOrigins.origin, *m.UpsertNamespaceSettingsMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcUpsertComponentRun != nil && afterUpsertComponentRunCounter < 1 {
-		m.t.Errorf("Expected call to RepositoryMock.UpsertComponentRun at\n%s", m.funcUpsertComponentRunOrigin)
+	if m.funcUpsertNamespaceSettings != nil && afterUpsertNamespaceSettingsCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.UpsertNamespaceSettings at\n%s", m.funcUpsertNamespaceSettingsOrigin)
 	}
 
-	if !m.UpsertComponentRunMock.invocationsDone() && afterUpsertComponentRunCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.UpsertComponentRun at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.UpsertComponentRunMock.expectedInvocations), m.UpsertComponentRunMock.expectedInvocationsOrigin, afterUpsertComponentRunCounter)
+	if !m.UpsertNamespaceSettingsMock.invocationsDone() && afterUpsertNamespaceSettingsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.UpsertNamespaceSettings at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.UpsertNamespaceSettingsMock.expectedInvocations), m.UpsertNamespaceSettingsMock.expectedInvocationsOrigin, afterUpsertNamespaceSettingsCounter)
 	}
 }
 
@@ -20548,6 +28367,348 @@ func (m *RepositoryMock) MinimockUpsertPipelineRunInspect() {
 	}
 }
 
+type mRepositoryMockUpsertRunArtifact struct {
+	optional           bool
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockUpsertRunArtifactExpectation
+	expectations       []*RepositoryMockUpsertRunArtifactExpectation
+
+	callArgs []*RepositoryMockUpsertRunArtifactParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RepositoryMockUpsertRunArtifactExpectation specifies expectation struct of the Repository.UpsertRunArtifact
+type RepositoryMockUpsertRunArtifactExpectation struct {
+	mock               *RepositoryMock
+	params             *RepositoryMockUpsertRunArtifactParams
+	paramPtrs          *RepositoryMockUpsertRunArtifactParamPtrs
+	expectationOrigins RepositoryMockUpsertRunArtifactExpectationOrigins
+	results            *RepositoryMockUpsertRunArtifactResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RepositoryMockUpsertRunArtifactParams contains parameters of the Repository.UpsertRunArtifact
+type RepositoryMockUpsertRunArtifactParams struct {
+	ctx context.Context
+	rp1 *datamodel.RunArtifact
+}
+
+// RepositoryMockUpsertRunArtifactParamPtrs contains pointers to parameters of the Repository.UpsertRunArtifact
+type RepositoryMockUpsertRunArtifactParamPtrs struct {
+	ctx *context.Context
+	rp1 **datamodel.RunArtifact
+}
+
+// RepositoryMockUpsertRunArtifactResults contains results of the Repository.UpsertRunArtifact
+type RepositoryMockUpsertRunArtifactResults struct {
+	err error
+}
+
+// RepositoryMockUpsertRunArtifactOrigins contains origins of expectations of the Repository.UpsertRunArtifact
+type RepositoryMockUpsertRunArtifactExpectationOrigins struct {
+	origin    string
+	originCtx string
+	originRp1 string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) Optional() *mRepositoryMockUpsertRunArtifact {
+	mmUpsertRunArtifact.optional = true
+	return mmUpsertRunArtifact
+}
+
+// Expect sets up expected params for Repository.UpsertRunArtifact
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) Expect(ctx context.Context, rp1 *datamodel.RunArtifact) *mRepositoryMockUpsertRunArtifact {
+	if mmUpsertRunArtifact.mock.funcUpsertRunArtifact != nil {
+		mmUpsertRunArtifact.mock.t.Fatalf("RepositoryMock.UpsertRunArtifact mock is already set by Set")
+	}
+
+	if mmUpsertRunArtifact.defaultExpectation == nil {
+		mmUpsertRunArtifact.defaultExpectation = &RepositoryMockUpsertRunArtifactExpectation{}
+	}
+
+	if mmUpsertRunArtifact.defaultExpectation.paramPtrs != nil {
+		mmUpsertRunArtifact.mock.t.Fatalf("RepositoryMock.UpsertRunArtifact mock is already set by ExpectParams functions")
+	}
+
+	mmUpsertRunArtifact.defaultExpectation.params = &RepositoryMockUpsertRunArtifactParams{ctx, rp1}
+	mmUpsertRunArtifact.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmUpsertRunArtifact.expectations {
+		if minimock.Equal(e.params, mmUpsertRunArtifact.defaultExpectation.params) {
+			mmUpsertRunArtifact.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUpsertRunArtifact.defaultExpectation.params)
+		}
+	}
+
+	return mmUpsertRunArtifact
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.UpsertRunArtifact
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) ExpectCtxParam1(ctx context.Context) *mRepositoryMockUpsertRunArtifact {
+	if mmUpsertRunArtifact.mock.funcUpsertRunArtifact != nil {
+		mmUpsertRunArtifact.mock.t.Fatalf("RepositoryMock.UpsertRunArtifact mock is already set by Set")
+	}
+
+	if mmUpsertRunArtifact.defaultExpectation == nil {
+		mmUpsertRunArtifact.defaultExpectation = &RepositoryMockUpsertRunArtifactExpectation{}
+	}
+
+	if mmUpsertRunArtifact.defaultExpectation.params != nil {
+		mmUpsertRunArtifact.mock.t.Fatalf("RepositoryMock.UpsertRunArtifact mock is already set by Expect")
+	}
+
+	if mmUpsertRunArtifact.defaultExpectation.paramPtrs == nil {
+		mmUpsertRunArtifact.defaultExpectation.paramPtrs = &RepositoryMockUpsertRunArtifactParamPtrs{}
+	}
+	mmUpsertRunArtifact.defaultExpectation.paramPtrs.ctx = &ctx
+	mmUpsertRunArtifact.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmUpsertRunArtifact
+}
+
+// ExpectRp1Param2 sets up expected param rp1 for Repository.UpsertRunArtifact
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) ExpectRp1Param2(rp1 *datamodel.RunArtifact) *mRepositoryMockUpsertRunArtifact {
+	if mmUpsertRunArtifact.mock.funcUpsertRunArtifact != nil {
+		mmUpsertRunArtifact.mock.t.Fatalf("RepositoryMock.UpsertRunArtifact mock is already set by Set")
+	}
+
+	if mmUpsertRunArtifact.defaultExpectation == nil {
+		mmUpsertRunArtifact.defaultExpectation = &RepositoryMockUpsertRunArtifactExpectation{}
+	}
+
+	if mmUpsertRunArtifact.defaultExpectation.params != nil {
+		mmUpsertRunArtifact.mock.t.Fatalf("RepositoryMock.UpsertRunArtifact mock is already set by Expect")
+	}
+
+	if mmUpsertRunArtifact.defaultExpectation.paramPtrs == nil {
+		mmUpsertRunArtifact.defaultExpectation.paramPtrs = &RepositoryMockUpsertRunArtifactParamPtrs{}
+	}
+	mmUpsertRunArtifact.defaultExpectation.paramPtrs.rp1 = &rp1
+	mmUpsertRunArtifact.defaultExpectation.expectationOrigins.originRp1 = minimock.CallerInfo(1)
+
+	return mmUpsertRunArtifact
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.UpsertRunArtifact
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) Inspect(f func(ctx context.Context, rp1 *datamodel.RunArtifact)) *mRepositoryMockUpsertRunArtifact {
+	if mmUpsertRunArtifact.mock.inspectFuncUpsertRunArtifact != nil {
+		mmUpsertRunArtifact.mock.t.Fatalf("Inspect function is already set for RepositoryMock.UpsertRunArtifact")
+	}
+
+	mmUpsertRunArtifact.mock.inspectFuncUpsertRunArtifact = f
+
+	return mmUpsertRunArtifact
+}
+
+// Return sets up results that will be returned by Repository.UpsertRunArtifact
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) Return(err error) *RepositoryMock {
+	if mmUpsertRunArtifact.mock.funcUpsertRunArtifact != nil {
+		mmUpsertRunArtifact.mock.t.Fatalf("RepositoryMock.UpsertRunArtifact mock is already set by Set")
+	}
+
+	if mmUpsertRunArtifact.defaultExpectation == nil {
+		mmUpsertRunArtifact.defaultExpectation = &RepositoryMockUpsertRunArtifactExpectation{mock: mmUpsertRunArtifact.mock}
+	}
+	mmUpsertRunArtifact.defaultExpectation.results = &RepositoryMockUpsertRunArtifactResults{err}
+	mmUpsertRunArtifact.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmUpsertRunArtifact.mock
+}
+
+// Set uses given function f to mock the Repository.UpsertRunArtifact method
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) Set(f func(ctx context.Context, rp1 *datamodel.RunArtifact) (err error)) *RepositoryMock {
+	if mmUpsertRunArtifact.defaultExpectation != nil {
+		mmUpsertRunArtifact.mock.t.Fatalf("Default expectation is already set for the Repository.UpsertRunArtifact method")
+	}
+
+	if len(mmUpsertRunArtifact.expectations) > 0 {
+		mmUpsertRunArtifact.mock.t.Fatalf("Some expectations are already set for the Repository.UpsertRunArtifact method")
+	}
+
+	mmUpsertRunArtifact.mock.funcUpsertRunArtifact = f
+	mmUpsertRunArtifact.mock.funcUpsertRunArtifactOrigin = minimock.CallerInfo(1)
+	return mmUpsertRunArtifact.mock
+}
+
+// When sets expectation for the Repository.UpsertRunArtifact which will trigger the result defined by the following
+// Then helper
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) When(ctx context.Context, rp1 *datamodel.RunArtifact) *RepositoryMockUpsertRunArtifactExpectation {
+	if mmUpsertRunArtifact.mock.funcUpsertRunArtifact != nil {
+		mmUpsertRunArtifact.mock.t.Fatalf("RepositoryMock.UpsertRunArtifact mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockUpsertRunArtifactExpectation{
+		mock:               mmUpsertRunArtifact.mock,
+		params:             &RepositoryMockUpsertRunArtifactParams{ctx, rp1},
+		expectationOrigins: RepositoryMockUpsertRunArtifactExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmUpsertRunArtifact.expectations = append(mmUpsertRunArtifact.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.UpsertRunArtifact return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockUpsertRunArtifactExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockUpsertRunArtifactResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.UpsertRunArtifact should be invoked
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) Times(n uint64) *mRepositoryMockUpsertRunArtifact {
+	if n == 0 {
+		mmUpsertRunArtifact.mock.t.Fatalf("Times of RepositoryMock.UpsertRunArtifact mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmUpsertRunArtifact.expectedInvocations, n)
+	mmUpsertRunArtifact.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmUpsertRunArtifact
+}
+
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) invocationsDone() bool {
+	if len(mmUpsertRunArtifact.expectations) == 0 && mmUpsertRunArtifact.defaultExpectation == nil && mmUpsertRunArtifact.mock.funcUpsertRunArtifact == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmUpsertRunArtifact.mock.afterUpsertRunArtifactCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmUpsertRunArtifact.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// UpsertRunArtifact implements mm_repository.Repository
+func (mmUpsertRunArtifact *RepositoryMock) UpsertRunArtifact(ctx context.Context, rp1 *datamodel.RunArtifact) (err error) {
+	mm_atomic.AddUint64(&mmUpsertRunArtifact.beforeUpsertRunArtifactCounter, 1)
+	defer mm_atomic.AddUint64(&mmUpsertRunArtifact.afterUpsertRunArtifactCounter, 1)
+
+	mmUpsertRunArtifact.t.Helper()
+
+	if mmUpsertRunArtifact.inspectFuncUpsertRunArtifact != nil {
+		mmUpsertRunArtifact.inspectFuncUpsertRunArtifact(ctx, rp1)
+	}
+
+	mm_params := RepositoryMockUpsertRunArtifactParams{ctx, rp1}
+
+	// Record call args
+	mmUpsertRunArtifact.UpsertRunArtifactMock.mutex.Lock()
+	mmUpsertRunArtifact.UpsertRunArtifactMock.callArgs = append(mmUpsertRunArtifact.UpsertRunArtifactMock.callArgs, &mm_params)
+	mmUpsertRunArtifact.UpsertRunArtifactMock.mutex.Unlock()
+
+	for _, e := range mmUpsertRunArtifact.UpsertRunArtifactMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmUpsertRunArtifact.UpsertRunArtifactMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpsertRunArtifact.UpsertRunArtifactMock.defaultExpectation.Counter, 1)
+		mm_want := mmUpsertRunArtifact.UpsertRunArtifactMock.defaultExpectation.params
+		mm_want_ptrs := mmUpsertRunArtifact.UpsertRunArtifactMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockUpsertRunArtifactParams{ctx, rp1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmUpsertRunArtifact.t.Errorf("RepositoryMock.UpsertRunArtifact got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpsertRunArtifact.UpsertRunArtifactMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.rp1 != nil && !minimock.Equal(*mm_want_ptrs.rp1, mm_got.rp1) {
+				mmUpsertRunArtifact.t.Errorf("RepositoryMock.UpsertRunArtifact got unexpected parameter rp1, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpsertRunArtifact.UpsertRunArtifactMock.defaultExpectation.expectationOrigins.originRp1, *mm_want_ptrs.rp1, mm_got.rp1, minimock.Diff(*mm_want_ptrs.rp1, mm_got.rp1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmUpsertRunArtifact.t.Errorf("RepositoryMock.UpsertRunArtifact got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmUpsertRunArtifact.UpsertRunArtifactMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmUpsertRunArtifact.UpsertRunArtifactMock.defaultExpectation.results
+		if mm_results == nil {
+			mmUpsertRunArtifact.t.Fatal("No results are set for the RepositoryMock.UpsertRunArtifact")
+		}
+		return (*mm_results).err
+	}
+	if mmUpsertRunArtifact.funcUpsertRunArtifact != nil {
+		return mmUpsertRunArtifact.funcUpsertRunArtifact(ctx, rp1)
+	}
+	mmUpsertRunArtifact.t.Fatalf("Unexpected call to RepositoryMock.UpsertRunArtifact. %v %v", ctx, rp1)
+	return
+}
+
+// UpsertRunArtifactAfterCounter returns a count of finished RepositoryMock.UpsertRunArtifact invocations
+func (mmUpsertRunArtifact *RepositoryMock) UpsertRunArtifactAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpsertRunArtifact.afterUpsertRunArtifactCounter)
+}
+
+// UpsertRunArtifactBeforeCounter returns a count of RepositoryMock.UpsertRunArtifact invocations
+func (mmUpsertRunArtifact *RepositoryMock) UpsertRunArtifactBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpsertRunArtifact.beforeUpsertRunArtifactCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.UpsertRunArtifact.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmUpsertRunArtifact *mRepositoryMockUpsertRunArtifact) Calls() []*RepositoryMockUpsertRunArtifactParams {
+	mmUpsertRunArtifact.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockUpsertRunArtifactParams, len(mmUpsertRunArtifact.callArgs))
+	copy(argCopy, mmUpsertRunArtifact.callArgs)
+
+	mmUpsertRunArtifact.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockUpsertRunArtifactDone returns true if the count of the UpsertRunArtifact invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockUpsertRunArtifactDone() bool {
+	if m.UpsertRunArtifactMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.UpsertRunArtifactMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.UpsertRunArtifactMock.invocationsDone()
+}
+
+// MinimockUpsertRunArtifactInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockUpsertRunArtifactInspect() {
+	for _, e := range m.UpsertRunArtifactMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.UpsertRunArtifact at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterUpsertRunArtifactCounter := mm_atomic.LoadUint64(&m.afterUpsertRunArtifactCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.UpsertRunArtifactMock.defaultExpectation != nil && afterUpsertRunArtifactCounter < 1 {
+		if m.UpsertRunArtifactMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RepositoryMock.UpsertRunArtifact at\n%s", m.UpsertRunArtifactMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.UpsertRunArtifact at\n%s with params: %#v", m.UpsertRunArtifactMock.defaultExpectation.expectationOrigins.origin, *m.UpsertRunArtifactMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcUpsertRunArtifact != nil && afterUpsertRunArtifactCounter < 1 {
+		m.t.Errorf("Expected call to RepositoryMock.UpsertRunArtifact at\n%s", m.funcUpsertRunArtifactOrigin)
+	}
+
+	if !m.UpsertRunArtifactMock.invocationsDone() && afterUpsertRunArtifactCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.UpsertRunArtifact at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.UpsertRunArtifactMock.expectedInvocations), m.UpsertRunArtifactMock.expectedInvocationsOrigin, afterUpsertRunArtifactCounter)
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *RepositoryMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
@@ -20558,22 +28719,32 @@ func (m *RepositoryMock) MinimockFinish() {
 
 			m.MinimockCheckPinnedUserInspect()
 
+			m.MinimockClaimDuePipelineCanariesInspect()
+
 			m.MinimockCreateNamespaceConnectionInspect()
 
+			m.MinimockCreateNamespaceEventSourceInspect()
+
 			m.MinimockCreateNamespacePipelineInspect()
 
 			m.MinimockCreateNamespacePipelineReleaseInspect()
 
+			m.MinimockCreateNamespacePromptVersionInspect()
+
 			m.MinimockCreateNamespaceSecretInspect()
 
 			m.MinimockCreatePipelineTagsInspect()
 
 			m.MinimockDeleteNamespaceConnectionByIDInspect()
 
+			m.MinimockDeleteNamespaceEventSourceByIDInspect()
+
 			m.MinimockDeleteNamespacePipelineByIDInspect()
 
 			m.MinimockDeleteNamespacePipelineReleaseByIDInspect()
 
+			m.MinimockDeleteNamespacePromptByIDInspect()
+
 			m.MinimockDeleteNamespaceSecretByIDInspect()
 
 			m.MinimockDeletePipelineTagsInspect()
@@ -20586,12 +28757,18 @@ func (m *RepositoryMock) MinimockFinish() {
 
 			m.MinimockGetNamespaceConnectionByIDInspect()
 
+			m.MinimockGetNamespaceEventSourceByIDInspect()
+
 			m.MinimockGetNamespacePipelineByIDInspect()
 
 			m.MinimockGetNamespacePipelineReleaseByIDInspect()
 
+			m.MinimockGetNamespacePromptByIDInspect()
+
 			m.MinimockGetNamespaceSecretByIDInspect()
 
+			m.MinimockGetNamespaceSettingsInspect()
+
 			m.MinimockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsInspect()
 
 			m.MinimockGetPaginatedPipelineRunsByRequesterInspect()
@@ -20608,34 +28785,56 @@ func (m *RepositoryMock) MinimockFinish() {
 
 			m.MinimockGetPipelineRunByUIDInspect()
 
+			m.MinimockGetRunArtifactInspect()
+
 			m.MinimockListComponentDefinitionUIDsInspect()
 
+			m.MinimockListEventSourcesAdminInspect()
+
 			m.MinimockListIntegrationsInspect()
 
 			m.MinimockListNamespaceConnectionsInspect()
 
+			m.MinimockListNamespaceEventSourcesInspect()
+
 			m.MinimockListNamespacePipelineReleasesInspect()
 
 			m.MinimockListNamespacePipelinesInspect()
 
+			m.MinimockListNamespacePromptVersionsInspect()
+
+			m.MinimockListNamespacePromptsInspect()
+
 			m.MinimockListNamespaceSecretsInspect()
 
+			m.MinimockListPipelineCanariesInspect()
+
 			m.MinimockListPipelineIDsByConnectionIDInspect()
 
+			m.MinimockListPipelineRunsByScheduleIDInspect()
+
 			m.MinimockListPipelineTagsInspect()
 
 			m.MinimockListPipelinesInspect()
 
 			m.MinimockListPipelinesAdminInspect()
 
+			m.MinimockListRunArtifactsInspect()
+
 			m.MinimockPinUserInspect()
 
+			m.MinimockSyncPipelineCanariesInspect()
+
 			m.MinimockTranspileFilterInspect()
 
 			m.MinimockUpdateComponentRunInspect()
 
+			m.MinimockUpdateEventSourceCheckpointInspect()
+
 			m.MinimockUpdateNamespaceConnectionByUIDInspect()
 
+			m.MinimockUpdateNamespaceConnectionMaxConcurrencyInspect()
+
 			m.MinimockUpdateNamespacePipelineByUIDInspect()
 
 			m.MinimockUpdateNamespacePipelineIDByIDInspect()
@@ -20646,13 +28845,19 @@ func (m *RepositoryMock) MinimockFinish() {
 
 			m.MinimockUpdateNamespaceSecretByIDInspect()
 
+			m.MinimockUpdatePipelineCanaryInspect()
+
 			m.MinimockUpdatePipelineRunInspect()
 
 			m.MinimockUpsertComponentDefinitionInspect()
 
 			m.MinimockUpsertComponentRunInspect()
 
+			m.MinimockUpsertNamespaceSettingsInspect()
+
 			m.MinimockUpsertPipelineRunInspect()
+
+			m.MinimockUpsertRunArtifactInspect()
 		}
 	})
 }
@@ -20679,23 +28884,31 @@ func (m *RepositoryMock) minimockDone() bool {
 		m.MinimockAddPipelineClonesDone() &&
 		m.MinimockAddPipelineRunsDone() &&
 		m.MinimockCheckPinnedUserDone() &&
+		m.MinimockClaimDuePipelineCanariesDone() &&
 		m.MinimockCreateNamespaceConnectionDone() &&
+		m.MinimockCreateNamespaceEventSourceDone() &&
 		m.MinimockCreateNamespacePipelineDone() &&
 		m.MinimockCreateNamespacePipelineReleaseDone() &&
+		m.MinimockCreateNamespacePromptVersionDone() &&
 		m.MinimockCreateNamespaceSecretDone() &&
 		m.MinimockCreatePipelineTagsDone() &&
 		m.MinimockDeleteNamespaceConnectionByIDDone() &&
+		m.MinimockDeleteNamespaceEventSourceByIDDone() &&
 		m.MinimockDeleteNamespacePipelineByIDDone() &&
 		m.MinimockDeleteNamespacePipelineReleaseByIDDone() &&
+		m.MinimockDeleteNamespacePromptByIDDone() &&
 		m.MinimockDeleteNamespaceSecretByIDDone() &&
 		m.MinimockDeletePipelineTagsDone() &&
 		m.MinimockGetDefinitionByUIDDone() &&
 		m.MinimockGetHubStatsDone() &&
 		m.MinimockGetLatestNamespacePipelineReleaseDone() &&
 		m.MinimockGetNamespaceConnectionByIDDone() &&
+		m.MinimockGetNamespaceEventSourceByIDDone() &&
 		m.MinimockGetNamespacePipelineByIDDone() &&
 		m.MinimockGetNamespacePipelineReleaseByIDDone() &&
+		m.MinimockGetNamespacePromptByIDDone() &&
 		m.MinimockGetNamespaceSecretByIDDone() &&
+		m.MinimockGetNamespaceSettingsDone() &&
 		m.MinimockGetPaginatedComponentRunsByPipelineRunIDWithPermissionsDone() &&
 		m.MinimockGetPaginatedPipelineRunsByRequesterDone() &&
 		m.MinimockGetPaginatedPipelineRunsWithPermissionsDone() &&
@@ -20704,27 +28917,41 @@ func (m *RepositoryMock) minimockDone() bool {
 		m.MinimockGetPipelineByUIDAdminDone() &&
 		m.MinimockGetPipelineReleaseByUIDAdminDone() &&
 		m.MinimockGetPipelineRunByUIDDone() &&
+		m.MinimockGetRunArtifactDone() &&
 		m.MinimockListComponentDefinitionUIDsDone() &&
+		m.MinimockListEventSourcesAdminDone() &&
 		m.MinimockListIntegrationsDone() &&
 		m.MinimockListNamespaceConnectionsDone() &&
+		m.MinimockListNamespaceEventSourcesDone() &&
 		m.MinimockListNamespacePipelineReleasesDone() &&
 		m.MinimockListNamespacePipelinesDone() &&
+		m.MinimockListNamespacePromptVersionsDone() &&
+		m.MinimockListNamespacePromptsDone() &&
 		m.MinimockListNamespaceSecretsDone() &&
+		m.MinimockListPipelineCanariesDone() &&
 		m.MinimockListPipelineIDsByConnectionIDDone() &&
+		m.MinimockListPipelineRunsByScheduleIDDone() &&
 		m.MinimockListPipelineTagsDone() &&
 		m.MinimockListPipelinesDone() &&
 		m.MinimockListPipelinesAdminDone() &&
+		m.MinimockListRunArtifactsDone() &&
 		m.MinimockPinUserDone() &&
+		m.MinimockSyncPipelineCanariesDone() &&
 		m.MinimockTranspileFilterDone() &&
 		m.MinimockUpdateComponentRunDone() &&
+		m.MinimockUpdateEventSourceCheckpointDone() &&
 		m.MinimockUpdateNamespaceConnectionByUIDDone() &&
+		m.MinimockUpdateNamespaceConnectionMaxConcurrencyDone() &&
 		m.MinimockUpdateNamespacePipelineByUIDDone() &&
 		m.MinimockUpdateNamespacePipelineIDByIDDone() &&
 		m.MinimockUpdateNamespacePipelineReleaseByIDDone() &&
 		m.MinimockUpdateNamespacePipelineReleaseIDByIDDone() &&
 		m.MinimockUpdateNamespaceSecretByIDDone() &&
+		m.MinimockUpdatePipelineCanaryDone() &&
 		m.MinimockUpdatePipelineRunDone() &&
 		m.MinimockUpsertComponentDefinitionDone() &&
 		m.MinimockUpsertComponentRunDone() &&
-		m.MinimockUpsertPipelineRunDone()
+		m.MinimockUpsertNamespaceSettingsDone() &&
+		m.MinimockUpsertPipelineRunDone() &&
+		m.MinimockUpsertRunArtifactDone()
 }
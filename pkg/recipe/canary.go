@@ -0,0 +1,89 @@
+package recipe
+
+import (
+	"fmt"
+	"go/parser"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// CanaryID returns the ID that references the canary check of a pipeline in
+// the run log, so its runs can be told apart from the rest.
+func CanaryID(pipelineUID uuid.UUID, canaryID string) string {
+	return fmt.Sprintf("%s_%s_canary", pipelineUID, canaryID)
+}
+
+// NextCanaryRunTime returns the first time after a given one at which a
+// canary check is due.
+func NextCanaryRunTime(c *datamodel.Canary, from time.Time) (time.Time, error) {
+	sched, loc, err := ParseSchedule(c.Cron, c.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next := sched.Next(from.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q is never satisfied", c.Cron)
+	}
+	return next, nil
+}
+
+// ParseAssertion checks that a canary assertion is a valid condition that
+// only references the pipeline output.
+func ParseAssertion(assertion string) error {
+	// The references are replaced before the expression is parsed, so each
+	// of them must be closed before the next brace.
+	for s := assertion; ; {
+		left, right := strings.Index(s, "${"), strings.Index(s, "}")
+		if left == -1 {
+			break
+		}
+		if right < left {
+			return fmt.Errorf("malformed reference in %q", assertion)
+		}
+		s = s[right+1:]
+	}
+
+	expr, varMapping, _ := SanitizeCondition(assertion)
+	for _, src := range varMapping {
+		if src != "output" {
+			return fmt.Errorf("assertions can only reference the pipeline output, found %q", src)
+		}
+	}
+	if _, err := parser.ParseExpr(expr); err != nil {
+		return fmt.Errorf("invalid assertion %q: %w", assertion, err)
+	}
+
+	return nil
+}
+
+// EvalAssertion evaluates a canary assertion against the output of a
+// pipeline run.
+func EvalAssertion(assertion string, output map[string]any) (bool, error) {
+	if err := ParseAssertion(assertion); err != nil {
+		return false, err
+	}
+
+	expr, varMapping, _ := SanitizeCondition(assertion)
+	parsed, _ := parser.ParseExpr(expr)
+
+	value := map[string]any{}
+	for varName := range varMapping {
+		value[varName] = output
+	}
+
+	res, err := EvalCondition(parsed, value)
+	if err != nil {
+		return false, fmt.Errorf("evaluating assertion %q: %w", assertion, err)
+	}
+
+	b, ok := res.(bool)
+	if !ok {
+		return false, fmt.Errorf("assertion %q isn't a boolean expression", assertion)
+	}
+	return b, nil
+}
@@ -12,6 +12,7 @@ import (
 	"go/ast"
 	"go/token"
 
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/instill-ai/pipeline-backend/pkg/constant"
@@ -159,14 +160,124 @@ func (d *dag) TopologicalSort() ([]datamodel.ComponentMap, error) {
 	return ans, nil
 }
 
+// resolveReference resolves the path of a reference. The path can hold
+// fallbacks separated by `??`, e.g. `llm.output.title ?? variable.title ??
+// "Untitled"`: the first alternative that exists and isn't null is used. The
+// alternatives are paths or literals (double-quoted strings, numbers, true,
+// false or null).
 func resolveReference(ctx context.Context, wfm memory.WorkflowMemory, batchIdx int, path string) (data.Value, error) {
-	v, err := wfm.Get(ctx, batchIdx, path)
+	alternatives, err := splitCoalesce(path)
 	if err != nil {
 		return nil, err
 	}
+
+	var v data.Value
+	for _, alt := range alternatives {
+		if lit, ok := parseLiteral(alt); ok {
+			return lit, nil
+		}
+
+		if v, err = wfm.Get(ctx, batchIdx, alt); err == nil {
+			if _, isNull := v.(*data.Null); !isNull {
+				return v, nil
+			}
+		}
+	}
+	// The result of the last alternative is returned, so a missing path
+	// is still reported as such.
 	return v, err
 }
 
+// splitCoalesce splits the path of a reference into its alternatives.
+func splitCoalesce(path string) ([]string, error) {
+	var alternatives []string
+	start := 0
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '"':
+			quoted, err := strconv.QuotedPrefix(path[i:])
+			if err != nil {
+				return nil, fmt.Errorf("unterminated string in %q", path)
+			}
+			i += len(quoted) - 1
+		case strings.HasPrefix(path[i:], "??"):
+			alternatives = append(alternatives, strings.TrimSpace(path[start:i]))
+			start = i + 2
+			i++
+		}
+	}
+	alternatives = append(alternatives, strings.TrimSpace(path[start:]))
+
+	for _, alt := range alternatives {
+		if alt == "" {
+			return nil, fmt.Errorf("empty alternative in %q", path)
+		}
+	}
+	return alternatives, nil
+}
+
+// parseLiteral parses an alternative of a reference that is a literal.
+func parseLiteral(s string) (data.Value, bool) {
+	switch s {
+	case "null":
+		return data.NewNull(), true
+	case "true":
+		return data.NewBoolean(true), true
+	case "false":
+		return data.NewBoolean(false), true
+	}
+	if strings.HasPrefix(s, `"`) {
+		unquoted, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, false
+		}
+		return data.NewString(unquoted), true
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return data.NewNumberFromFloat(f), true
+	}
+	return nil, false
+}
+
+// ReferencePaths returns the paths a reference reads, without its literal
+// fallbacks and its formatting functions.
+func ReferencePaths(ref string) []string {
+	// The pipes within the string literals don't start a formatting
+	// function.
+	path, _, _ := strings.Cut(ref, "|")
+	if segments, err := splitUnquoted(ref, '|'); err == nil {
+		path = segments[0]
+	}
+	alternatives, err := splitCoalesce(path)
+	if err != nil {
+		return []string{strings.TrimSpace(path)}
+	}
+
+	paths := make([]string, 0, len(alternatives))
+	for _, alt := range alternatives {
+		if _, ok := parseLiteral(alt); !ok {
+			paths = append(paths, alt)
+		}
+	}
+	return paths
+}
+
+// formatReference pipes the value of a reference through its formatting
+// functions.
+func formatReference(v data.Value, ref string, calls []formatCall) (data.Value, error) {
+	if len(calls) == 0 {
+		return v, nil
+	}
+	v, err := applyFormatCalls(v, calls)
+	if err != nil {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("formatting reference: %w", err),
+			"Couldn't format reference "+ref+": "+err.Error()+".",
+		)
+	}
+	return v, nil
+}
+
 func Render(ctx context.Context, template data.Value, batchIdx int, wfm memory.WorkflowMemory, allowUnresolved bool) (data.Value, error) {
 
 	switch input := template.(type) {
@@ -179,17 +290,21 @@ func Render(ctx context.Context, template data.Value, batchIdx int, wfm memory.W
 			if s == constant.SegSecret+"."+constant.GlobalSecretKey {
 				return data.NewString(componentbase.SecretKeyword), nil
 			}
-			val, err := resolveReference(ctx, wfm, batchIdx, s)
+			path, calls, err := splitFormatPipe(s)
+			if err != nil {
+				return nil, errmsg.AddMessage(err, "Couldn't parse reference "+s+": "+err.Error()+".")
+			}
+			val, err := resolveReference(ctx, wfm, batchIdx, path)
 			if err != nil {
 				if allowUnresolved {
 					return data.NewNull(), nil
 				}
 				return nil, errmsg.AddMessage(
 					fmt.Errorf("resolving reference: %w", err),
-					"Couldn't resolve reference "+s+".",
+					"Couldn't resolve reference "+path+".",
 				)
 			}
-			return val, nil
+			return formatReference(val, s, calls)
 		}
 
 		val := ""
@@ -208,19 +323,36 @@ func Render(ctx context.Context, template data.Value, batchIdx int, wfm memory.W
 			}
 
 			ref := strings.TrimSpace(s[2:endIdx])
-			v, err := resolveReference(ctx, wfm, batchIdx, ref)
+			path, calls, err := splitFormatPipe(ref)
+			if err != nil {
+				return nil, errmsg.AddMessage(err, "Couldn't parse reference "+ref+": "+err.Error()+".")
+			}
+			v, err := resolveReference(ctx, wfm, batchIdx, path)
 			if err != nil {
 				if allowUnresolved {
 					return data.NewNull(), nil
 				}
 				return nil, err
 			}
+			if v, err = formatReference(v, ref, calls); err != nil {
+				return nil, err
+			}
 
 			switch v := v.(type) {
 			case *data.String:
 				val += v.GetString()
 			case *data.Number:
 				val += strconv.FormatFloat(v.GetFloat(), 'f', -1, 64)
+			case *data.DateTime:
+				val += v.String()
+			case *data.Duration:
+				val += v.String()
+			case *data.Decimal:
+				val += v.String()
+			case *data.Binary:
+				val += v.DataURI()
+			case *data.FileRef:
+				val += v.URI
 			default:
 				b, err := json.Marshal(v)
 				if err != nil {
@@ -543,6 +675,11 @@ func GenerateDAG(componentMap datamodel.ComponentMap) (*dag, error) {
 
 	componentIDMap := make(map[string]bool)
 
+	// The components in an error branch are executed right after their
+	// owner fails, so the components that reference them depend on the
+	// owner.
+	errorBranchOwner := make(map[string]string)
+
 	for id := range componentMap {
 		componentIDMap[id] = true
 		switch componentMap[id].Type {
@@ -551,6 +688,9 @@ func GenerateDAG(componentMap datamodel.ComponentMap) (*dag, error) {
 				componentIDMap[nestedID] = true
 			}
 		}
+		for branchID := range componentMap[id].OnError {
+			errorBranchOwner[branchID] = id
+		}
 	}
 
 	graph := NewDAG(componentMap)
@@ -601,6 +741,9 @@ func GenerateDAG(componentMap datamodel.ComponentMap) (*dag, error) {
 		}
 
 		for _, upstreamID := range parents {
+			if ownerID, ok := errorBranchOwner[upstreamID]; ok && ownerID != id {
+				upstreamID = ownerID
+			}
 			if _, ok := componentIDMap[upstreamID]; ok {
 				graph.AddEdge(upstreamID, id)
 			}
@@ -623,20 +766,51 @@ func FindReferenceParent(input string) []string {
 		if endIdx == -1 {
 			break
 		}
-		ref := strings.TrimSpace(input[2:endIdx])
-		upstreams = append(upstreams, strings.Split(ref, ".")[0])
+		for _, path := range ReferencePaths(input[2:endIdx]) {
+			upstreams = append(upstreams, strings.Split(path, ".")[0])
+		}
 		input = input[endIdx+1:]
 	}
 	return upstreams
 }
 
+// batchTraceError returns the error of a trace. The items of a batch fail
+// independently, so the error of the first item that failed is returned and,
+// in batches of several items, the error of each item is listed under
+// `batch-errors`, with null values for the items that didn't fail.
+func batchTraceError(errs []*structpb.Struct) *structpb.Struct {
+	var first *structpb.Struct
+	for _, e := range errs {
+		if e != nil {
+			first = e
+			break
+		}
+	}
+	if first == nil || len(errs) == 1 {
+		return first
+	}
+
+	batchErrors := make([]*structpb.Value, len(errs))
+	for idx, e := range errs {
+		if e == nil {
+			batchErrors[idx] = structpb.NewNullValue()
+			continue
+		}
+		batchErrors[idx] = structpb.NewStructValue(e)
+	}
+
+	traceErr := proto.Clone(first).(*structpb.Struct)
+	traceErr.Fields["batch-errors"] = structpb.NewListValue(&structpb.ListValue{Values: batchErrors})
+	return traceErr
+}
+
 func GenerateTraces(ctx context.Context, wfm memory.WorkflowMemory, full bool) (map[string]*pb.Trace, error) {
 
 	trace := map[string]*pb.Trace{}
 
 	batchSize := wfm.GetBatchSize()
 
-	for compID := range wfm.GetRecipe().Component {
+	for compID := range wfm.GetRecipe().Component.WithErrorBranches() {
 
 		inputs := make([]*structpb.Struct, batchSize)
 		outputs := make([]*structpb.Struct, batchSize)
@@ -692,9 +866,7 @@ func GenerateTraces(ctx context.Context, wfm memory.WorkflowMemory, full bool) (
 			Statuses: traceStatuses,
 			Inputs:   inputs,
 			Outputs:  outputs,
-
-			// Note: Currently, all errors in a batch are the same.
-			Error: errors[0],
+			Error:    batchTraceError(errors),
 		}
 	}
 
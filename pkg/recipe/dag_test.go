@@ -0,0 +1,214 @@
+package recipe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+)
+
+func TestSplitCoalesce(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "ok - single path",
+			path: "llm.output.title",
+			want: []string{"llm.output.title"},
+		},
+		{
+			name: "ok - chained",
+			path: `llm.output.title ?? variable.title ?? "Untitled"`,
+			want: []string{"llm.output.title", "variable.title", `"Untitled"`},
+		},
+		{
+			name: "ok - without spaces",
+			path: `variable.a??variable.b??0`,
+			want: []string{"variable.a", "variable.b", "0"},
+		},
+		{
+			name: "ok - quoted",
+			path: `variable.title ?? "what ?? now" ?? "say \"??\""`,
+			want: []string{"variable.title", `"what ?? now"`, `"say \"??\""`},
+		},
+		{
+			name:    "nok - empty alternative",
+			path:    "variable.title ??",
+			wantErr: `empty alternative in "variable.title \?\?"`,
+		},
+		{
+			name:    "nok - empty chained alternative",
+			path:    "variable.a ?? ?? variable.b",
+			wantErr: `empty alternative in .*`,
+		},
+		{
+			name:    "nok - unterminated string",
+			path:    `variable.title ?? "Untitled ?? x`,
+			wantErr: `unterminated string in .*`,
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			got, err := splitCoalesce(tc.path)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(got, quicktest.DeepEquals, tc.want)
+		})
+	}
+}
+
+func TestReferencePaths(t *testing.T) {
+	c := quicktest.New(t)
+
+	c.Check(ReferencePaths("variable.title"), quicktest.DeepEquals, []string{"variable.title"})
+	c.Check(ReferencePaths(`llm.output.title ?? variable.title ?? "a | b" ?? null | truncate 10`), quicktest.DeepEquals, []string{"llm.output.title", "variable.title"})
+	c.Check(ReferencePaths(`variable.title ?? "Untitled`), quicktest.DeepEquals, []string{`variable.title ?? "Untitled`})
+
+	// The components referenced after a literal holding a pipe are
+	// upstream too.
+	c.Check(FindReferenceParent(`${variable.title ?? "a | b" ?? llm.output.title | truncate 10} by ${author.output.name}`), quicktest.DeepEquals, []string{"variable", "llm", "author"})
+}
+
+func TestResolveReference(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	wfm, err := memory.NewMemoryStore(nil, 0, nil, 0, nil, nil).NewWorkflowMemory(ctx, "workflow", &datamodel.Recipe{}, 1)
+	c.Assert(err, quicktest.IsNil)
+	c.Assert(wfm.Set(ctx, 0, string(memory.PipelineVariable), data.NewMap(map[string]data.Value{
+		"title": data.NewString("Report"),
+		"empty": data.NewString(""),
+		"none":  data.NewNull(),
+		"zero":  data.NewNumberFromInteger(0),
+	})), quicktest.IsNil)
+
+	testcases := []struct {
+		name    string
+		path    string
+		want    data.Value
+		wantErr string
+	}{
+		{
+			name: "ok - existing path",
+			path: "variable.title ?? \"Untitled\"",
+			want: data.NewString("Report"),
+		},
+		{
+			name: "ok - missing key",
+			path: "variable.subtitle ?? variable.title",
+			want: data.NewString("Report"),
+		},
+		{
+			name: "ok - missing parent",
+			path: "llm.output.title ?? variable.title",
+			want: data.NewString("Report"),
+		},
+		{
+			name: "ok - null",
+			path: "variable.none ?? variable.title",
+			want: data.NewString("Report"),
+		},
+		{
+			// The empty and false values aren't missing.
+			name: "ok - empty string",
+			path: "variable.empty ?? variable.title",
+			want: data.NewString(""),
+		},
+		{
+			name: "ok - zero",
+			path: "variable.zero ?? 1",
+			want: data.NewNumberFromInteger(0),
+		},
+		{
+			name: "ok - chained",
+			path: `variable.subtitle ?? variable.none ?? variable.title ?? "Untitled"`,
+			want: data.NewString("Report"),
+		},
+		{
+			name: "ok - string literal",
+			path: `variable.subtitle ?? "what ?? now"`,
+			want: data.NewString("what ?? now"),
+		},
+		{
+			name: "ok - escaped literal",
+			path: `variable.subtitle ?? "say \"hi\""`,
+			want: data.NewString(`say "hi"`),
+		},
+		{
+			name: "ok - literals",
+			path: `variable.subtitle ?? 4.5`,
+			want: data.NewNumberFromFloat(4.5),
+		},
+		{
+			// A literal ends the chain, even when it's null.
+			name: "ok - null literal",
+			path: `variable.subtitle ?? null ?? variable.title`,
+			want: data.NewNull(),
+		},
+		{
+			name: "ok - boolean literal",
+			path: `variable.subtitle ?? false`,
+			want: data.NewBoolean(false),
+		},
+		{
+			// The result of the last alternative is returned.
+			name: "ok - every alternative null",
+			path: `variable.none ?? variable.none`,
+			want: data.NewNull(),
+		},
+		{
+			name:    "nok - every alternative missing",
+			path:    "variable.subtitle ?? variable.caption",
+			wantErr: "path not found: .*caption",
+		},
+		{
+			name:    "nok - invalid syntax",
+			path:    "variable.subtitle ??",
+			wantErr: "empty alternative in .*",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			got, err := resolveReference(ctx, wfm, 0, tc.path)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(data.Equal(got, tc.want), quicktest.IsTrue, quicktest.Commentf(tc.path))
+		})
+	}
+
+	c.Run("render", func(c *quicktest.C) {
+		testcases := []struct {
+			template string
+			want     data.Value
+		}{
+			{template: `${variable.subtitle ?? "Untitled"}`, want: data.NewString("Untitled")},
+			{template: `${ variable.none ?? variable.zero }`, want: data.NewNumberFromInteger(0)},
+			{template: `${variable.subtitle ?? variable.title | truncate 3 "."}`, want: data.NewString("Re.")},
+			{template: `${variable.subtitle ?? "a | b"}`, want: data.NewString("a | b")},
+			{template: `Title: ${variable.subtitle ?? variable.title}, ${variable.none ?? "what ?? now"}`, want: data.NewString("Title: Report, what ?? now")},
+		}
+
+		for _, tc := range testcases {
+			got, err := Render(ctx, data.NewString(tc.template), 0, wfm, false)
+			c.Assert(err, quicktest.IsNil, quicktest.Commentf(tc.template))
+			c.Check(data.Equal(got, tc.want), quicktest.IsTrue, quicktest.Commentf(tc.template))
+		}
+	})
+}
@@ -0,0 +1,582 @@
+package recipe
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+// The references of a template can be piped through formatting functions,
+// which shape the output without an extra component, e.g.
+//
+//	${ llm.output.price | currency "EUR" }
+//	${ variable.created-at | date "date" "Europe/Paris" }
+//	${ variable.created-at | shift "7d" | date "date" }
+//	${ variable.timeout | duration "iso" }
+//	${ llm.output.text | truncate 280 }
+//	${ detector.output | query "$.objects[?(@.score > 0.5)].label" }
+//	${ detector.output.objects | sort "score" "desc" }
+//	${ detector.output.objects | filter "@.score > 0.5" | group-by "category" }
+//
+// A reference can fall back to other paths or to a literal when its path
+// doesn't exist or holds null:
+//
+//	${ llm.output.title ?? variable.title ?? "Untitled" | truncate 80 }
+//
+// The arguments of a function are numbers or double-quoted strings, which
+// can't hold a closing brace.
+
+// formatFunc applies a formatting function to a value.
+type formatFunc func(v data.Value, args []string) (data.Value, error)
+
+var formatFuncs = map[string]formatFunc{
+	"date":     formatDate,
+	"shift":    formatShift,
+	"duration": formatDuration,
+	"round":    formatRound,
+	"fixed":    formatFixed,
+	"number":   formatNumber,
+	"currency": formatCurrency,
+	"plural":   formatPlural,
+	"truncate": formatTruncate,
+	"query":    formatQuery,
+	"sort":     formatSort,
+	"filter":   formatFilter,
+	"group-by": formatGroupBy,
+	"unique":   formatUnique,
+}
+
+// formatCall is a call to a formatting function in a reference.
+type formatCall struct {
+	name string
+	args []string
+}
+
+// TrimFormatPipe returns the path of a reference without the formatting
+// functions it's piped through, nor its fallbacks.
+func TrimFormatPipe(ref string) string {
+	path, _, _ := strings.Cut(ref, "|")
+	path, _, _ = strings.Cut(path, "??")
+	return strings.TrimSpace(path)
+}
+
+// splitFormatPipe splits a reference into its path and the formatting
+// functions it's piped through.
+func splitFormatPipe(ref string) (string, []formatCall, error) {
+	segments, err := splitUnquoted(ref, '|')
+	if err != nil {
+		return "", nil, err
+	}
+
+	path := strings.TrimSpace(segments[0])
+	calls := make([]formatCall, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		tokens, err := tokenizeCall(segment)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(tokens) == 0 {
+			return "", nil, fmt.Errorf("empty formatting function in %q", ref)
+		}
+		if _, ok := formatFuncs[tokens[0]]; !ok {
+			return "", nil, fmt.Errorf("unknown formatting function %q", tokens[0])
+		}
+		calls = append(calls, formatCall{name: tokens[0], args: tokens[1:]})
+	}
+
+	return path, calls, nil
+}
+
+// applyFormatCalls pipes a value through formatting functions. Null values
+// (e.g. unresolved references) aren't formatted.
+func applyFormatCalls(v data.Value, calls []formatCall) (data.Value, error) {
+	for _, c := range calls {
+		if _, isNull := v.(*data.Null); isNull {
+			return v, nil
+		}
+
+		var err error
+		if v, err = formatFuncs[c.name](v, c.args); err != nil {
+			return nil, fmt.Errorf("%s: %w", c.name, err)
+		}
+	}
+	return v, nil
+}
+
+// splitUnquoted splits a string on a separator that isn't in a
+// double-quoted string.
+func splitUnquoted(s string, sep byte) ([]string, error) {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted, err := strconv.QuotedPrefix(s[i:])
+			if err != nil {
+				return nil, fmt.Errorf("unterminated string in %q", s)
+			}
+			i += len(quoted) - 1
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:]), nil
+}
+
+// tokenizeCall splits a function call into its name and arguments, removing
+// the quotes of the string arguments.
+func tokenizeCall(s string) ([]string, error) {
+	var tokens []string
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		if s[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(s)
+			if err != nil {
+				return nil, fmt.Errorf("unterminated string in %q", s)
+			}
+			unquoted, _ := strconv.Unquote(quoted)
+			tokens = append(tokens, unquoted)
+			s = s[len(quoted):]
+			continue
+		}
+
+		end := strings.IndexAny(s, " \t")
+		if end == -1 {
+			end = len(s)
+		}
+		tokens = append(tokens, s[:end])
+		s = s[end:]
+	}
+	return tokens, nil
+}
+
+func intArg(args []string, i, defaultValue int) (int, error) {
+	if i >= len(args) {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(args[i])
+	if err != nil {
+		return 0, fmt.Errorf("argument %q isn't an integer", args[i])
+	}
+	return n, nil
+}
+
+func stringArg(args []string, i int, defaultValue string) string {
+	if i >= len(args) {
+		return defaultValue
+	}
+	return args[i]
+}
+
+// numberValue reads a number, or a string that holds one.
+func numberValue(v data.Value) (float64, error) {
+	switch v := v.(type) {
+	case *data.Number:
+		return v.GetFloat(), nil
+	case *data.String:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v.GetString()), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q isn't a number", v.GetString())
+		}
+		return f, nil
+	}
+	return 0, fmt.Errorf("value isn't a number")
+}
+
+// fixedDecimals writes a decimal with a number of decimals, or with all its
+// decimals if the number is negative. Decimals are rounded exactly, unlike
+// the floats, whose halves might be rounded down because of their binary
+// representation.
+func fixedDecimals(d *data.Decimal, decimals int) string {
+	if decimals < 0 {
+		return d.String()
+	}
+	return d.StringFixed(decimals)
+}
+
+var dateLayouts = map[string]string{
+	"rfc3339":  time.RFC3339,
+	"rfc1123":  time.RFC1123,
+	"date":     time.DateOnly,
+	"time":     time.TimeOnly,
+	"datetime": time.DateTime,
+	"kitchen":  time.Kitchen,
+}
+
+// dateTimeValue returns the datetime a value holds, as a datetime, a string
+// or Unix seconds.
+func dateTimeValue(v data.Value) (*data.DateTime, error) {
+	switch v := v.(type) {
+	case *data.DateTime:
+		return v, nil
+	case *data.Number:
+		sec, frac := math.Modf(v.GetFloat())
+		return data.NewDateTime(time.Unix(int64(sec), int64(frac*1e9)).UTC()), nil
+	case *data.String:
+		d, err := data.ParseDateTime(v.GetString(), time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("%q isn't a date", strings.TrimSpace(v.GetString()))
+		}
+		return d, nil
+	}
+	return nil, fmt.Errorf("value isn't a date")
+}
+
+// formatDate formats a date, given as a datetime, a string or Unix seconds,
+// with a Go layout or one of the named layouts (rfc3339, rfc1123, date,
+// time, datetime, kitchen). An optional IANA time zone converts the date
+// first.
+//
+//	date [layout] [time-zone]
+func formatDate(v data.Value, args []string) (data.Value, error) {
+	d, err := dateTimeValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if tz := stringArg(args, 1, ""); tz != "" {
+		if d, err = d.In(tz); err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", tz)
+		}
+	}
+
+	layout := stringArg(args, 0, "rfc3339")
+	if named, ok := dateLayouts[layout]; ok {
+		layout = named
+	}
+	return data.NewString(d.Format(layout)), nil
+}
+
+// formatShift moves a date by a duration (e.g. "-90m", "36h" or "PT2H") or
+// by a number of days, months or years (e.g. "7d", "-1mo" or "1y"). The
+// calendar units keep the wall clock of the date. The result is a datetime, so it can
+// be piped into date.
+//
+//	shift offset
+func formatShift(v data.Value, args []string) (data.Value, error) {
+	d, err := dateTimeValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := stringArg(args, 0, "")
+	for _, unit := range []string{"mo", "y", "d"} {
+		n, ok := strings.CutSuffix(offset, unit)
+		if !ok {
+			continue
+		}
+		count, err := strconv.Atoi(n)
+		if err != nil {
+			break
+		}
+		switch unit {
+		case "y":
+			return d.AddDate(count, 0, 0), nil
+		case "mo":
+			return d.AddDate(0, count, 0), nil
+		default:
+			return d.AddDate(0, 0, count), nil
+		}
+	}
+
+	dur, err := data.ParseDuration(offset)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date offset %q", offset)
+	}
+	return d.Add(dur.Raw), nil
+}
+
+// durationValue returns the duration a value holds, as a duration, a
+// string or a number of seconds.
+func durationValue(v data.Value) (*data.Duration, error) {
+	switch v := v.(type) {
+	case *data.Duration:
+		return v, nil
+	case *data.Number:
+		return data.NewDurationFromSeconds(v.GetFloat()), nil
+	case *data.String:
+		return data.ParseDuration(v.GetString())
+	}
+	return nil, fmt.Errorf("value isn't a duration")
+}
+
+// formatDuration formats a duration, given as a duration, a string or a
+// number of seconds, in the Go syntax (go, the default), in the ISO 8601
+// syntax (iso) or as a number of seconds (seconds).
+//
+//	duration [go|iso|seconds]
+func formatDuration(v data.Value, args []string) (data.Value, error) {
+	d, err := durationValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	switch style := stringArg(args, 0, "go"); style {
+	case "go":
+		return data.NewString(d.String()), nil
+	case "iso":
+		return data.NewString(d.ISO()), nil
+	case "seconds":
+		return data.NewNumberFromFloat(d.Seconds()), nil
+	default:
+		return nil, fmt.Errorf("unknown duration style %q", style)
+	}
+}
+
+// formatRound rounds a number to a number of decimals (0 by default).
+//
+//	round [decimals]
+func formatRound(v data.Value, args []string) (data.Value, error) {
+	decimals, err := intArg(args, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := v.(*data.Decimal); ok {
+		return d.Round(decimals), nil
+	}
+
+	f, err := numberValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := math.Pow10(decimals)
+	return data.NewNumberFromFloat(math.Round(f*scale) / scale), nil
+}
+
+// formatFixed renders a number with a fixed number of decimals.
+//
+//	fixed decimals
+func formatFixed(v data.Value, args []string) (data.Value, error) {
+	decimals, err := intArg(args, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := v.(*data.Decimal); ok {
+		return data.NewString(fixedDecimals(d, decimals)), nil
+	}
+
+	f, err := numberValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return data.NewString(strconv.FormatFloat(f, 'f', decimals, 64)), nil
+}
+
+// formatNumber renders a number with thousands separators and, optionally,
+// a fixed number of decimals.
+//
+//	number [decimals]
+func formatNumber(v data.Value, args []string) (data.Value, error) {
+	decimals, err := intArg(args, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := v.(*data.Decimal); ok {
+		return data.NewString(groupThousands(fixedDecimals(d, decimals))), nil
+	}
+
+	f, err := numberValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return data.NewString(groupThousands(strconv.FormatFloat(f, 'f', decimals, 64))), nil
+}
+
+// groupThousands adds thousands separators to a formatted number.
+func groupThousands(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(fracPart)
+	}
+	return sign + b.String()
+}
+
+// currencies holds the symbol and decimals of the common currencies.
+var currencies = map[string]struct {
+	symbol   string
+	decimals int
+}{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"JPY": {"¥", 0},
+	"CNY": {"CN¥", 2},
+	"INR": {"₹", 2},
+	"KRW": {"₩", 0},
+	"TWD": {"NT$", 2},
+	"CAD": {"CA$", 2},
+	"AUD": {"A$", 2},
+	"CHF": {"CHF ", 2},
+}
+
+// formatCurrency renders an amount in a currency (USD by default), given as
+// an ISO 4217 code. Unknown currencies are prefixed by their code.
+//
+//	currency [code] [decimals]
+func formatCurrency(v data.Value, args []string) (data.Value, error) {
+	code := strings.ToUpper(stringArg(args, 0, "USD"))
+	c, ok := currencies[code]
+	if !ok {
+		c.symbol, c.decimals = code+" ", 2
+	}
+	decimals, err := intArg(args, 1, c.decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	if d, ok := v.(*data.Decimal); ok {
+		sign := ""
+		if d.Sign() < 0 {
+			sign, d = "-", d.Abs()
+		}
+		return data.NewString(sign + c.symbol + groupThousands(fixedDecimals(d, decimals))), nil
+	}
+
+	f, err := numberValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	sign := ""
+	if f < 0 {
+		sign, f = "-", -f
+	}
+	return data.NewString(sign + c.symbol + groupThousands(strconv.FormatFloat(f, 'f', decimals, 64))), nil
+}
+
+// formatPlural picks the singular or plural form of a word for a count. The
+// plural form defaults to the singular one followed by "s".
+//
+//	plural singular [plural]
+func formatPlural(v data.Value, args []string) (data.Value, error) {
+	n, err := numberValue(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("the singular form is required")
+	}
+
+	if math.Abs(n) == 1 {
+		return data.NewString(args[0]), nil
+	}
+	return data.NewString(stringArg(args, 1, args[0]+"s")), nil
+}
+
+// formatTruncate shortens a text to a number of characters, ending it with
+// an ellipsis (… by default) if it's truncated.
+//
+//	truncate length [ellipsis]
+func formatTruncate(v data.Value, args []string) (data.Value, error) {
+	s, ok := v.(*data.String)
+	if !ok {
+		return nil, fmt.Errorf("value isn't a string")
+	}
+	length, err := intArg(args, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("the length must be positive")
+	}
+	ellipsis := stringArg(args, 1, "…")
+
+	text := s.GetString()
+	if utf8.RuneCountInString(text) <= length {
+		return s, nil
+	}
+
+	keep := max(length-utf8.RuneCountInString(ellipsis), 0)
+	runes := []rune(text)
+	return data.NewString(string(runes[:keep]) + ellipsis), nil
+}
+
+// formatQuery selects values with a JSONPath expression, e.g. the labels of
+// the confident detections.
+//
+//	query expression
+func formatQuery(v data.Value, args []string) (data.Value, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("missing query expression")
+	}
+	return data.Query(v, args[0])
+}
+
+// formatSort sorts an array by the value at a path of its items, which is
+// the items themselves if it's omitted.
+//
+//	sort [path [asc|desc]]
+func formatSort(v data.Value, args []string) (data.Value, error) {
+	arr, ok := v.(*data.Array)
+	if !ok {
+		return nil, fmt.Errorf("value isn't an array")
+	}
+	switch order := stringArg(args, 1, "asc"); order {
+	case "asc":
+		return arr.Sort(stringArg(args, 0, ""), false), nil
+	case "desc":
+		return arr.Sort(stringArg(args, 0, ""), true), nil
+	default:
+		return nil, fmt.Errorf("unknown order %q", order)
+	}
+}
+
+// formatFilter keeps the items of an array that match a filter expression,
+// as written in the JSONPath filters of query.
+//
+//	filter expression
+func formatFilter(v data.Value, args []string) (data.Value, error) {
+	arr, ok := v.(*data.Array)
+	if !ok {
+		return nil, fmt.Errorf("value isn't an array")
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("missing filter expression")
+	}
+	return arr.FilterExpr(args[0])
+}
+
+// formatGroupBy groups the items of an array by the value at a path.
+//
+//	group-by path
+func formatGroupBy(v data.Value, args []string) (data.Value, error) {
+	arr, ok := v.(*data.Array)
+	if !ok {
+		return nil, fmt.Errorf("value isn't an array")
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("missing path")
+	}
+	return arr.GroupBy(args[0])
+}
+
+// formatUnique removes the items of an array whose value at a path, or the
+// items themselves if it's omitted, was already seen.
+//
+//	unique [path]
+func formatUnique(v data.Value, args []string) (data.Value, error) {
+	arr, ok := v.(*data.Array)
+	if !ok {
+		return nil, fmt.Errorf("value isn't an array")
+	}
+	return arr.Unique(stringArg(args, 0, ""))
+}
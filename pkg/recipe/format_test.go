@@ -0,0 +1,153 @@
+package recipe
+
+import (
+	"testing"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+)
+
+func TestFormatCollections(t *testing.T) {
+	c := quicktest.New(t)
+
+	object := func(label string, score data.Value) data.Value {
+		fields := map[string]data.Value{"label": data.NewString(label)}
+		if score != nil {
+			fields["score"] = score
+		}
+		return data.NewMap(fields)
+	}
+	objects := data.NewArray([]data.Value{
+		object("cat", data.NewNumberFromFloat(0.5)),
+		object("dog", nil),
+		object("cat", data.NewNumberFromFloat(0.9)),
+		object("person", data.NewString("high")),
+		object("dog", data.NewNumberFromFloat(0.5)),
+	})
+
+	testcases := []struct {
+		name    string
+		ref     string
+		in      data.Value
+		want    data.Value
+		wantErr string
+	}{
+		{
+			// The ties keep their order and the items without a score go
+			// last.
+			name: "sort",
+			ref:  `objects | sort "score"`,
+			in:   objects,
+			want: data.NewArray([]data.Value{
+				objects.Values[0], objects.Values[4], objects.Values[2], objects.Values[3], objects.Values[1],
+			}),
+		},
+		{
+			name: "sort descending",
+			ref:  `objects | sort "score" "desc"`,
+			in:   objects,
+			want: data.NewArray([]data.Value{
+				objects.Values[3], objects.Values[2], objects.Values[0], objects.Values[4], objects.Values[1],
+			}),
+		},
+		{
+			name: "sort items",
+			ref:  `labels | sort`,
+			in:   data.NewArray([]data.Value{data.NewString("b"), data.NewNumberFromInteger(2), data.NewString("a"), data.NewNumberFromInteger(1)}),
+			want: data.NewArray([]data.Value{data.NewNumberFromInteger(1), data.NewNumberFromInteger(2), data.NewString("a"), data.NewString("b")}),
+		},
+		{
+			name: "filter",
+			ref:  `objects | filter "@.score >= 0.5 && @.label != 'dog'"`,
+			in:   objects,
+			want: data.NewArray([]data.Value{objects.Values[0], objects.Values[2]}),
+		},
+		{
+			name: "filter and sort",
+			ref:  `objects | filter "@.label == 'cat'" | sort "score" "desc"`,
+			in:   objects,
+			want: data.NewArray([]data.Value{objects.Values[2], objects.Values[0]}),
+		},
+		{
+			name: "group-by",
+			ref:  `objects | group-by "label"`,
+			in:   objects,
+			want: data.NewMap(map[string]data.Value{
+				"cat":    data.NewArray([]data.Value{objects.Values[0], objects.Values[2]}),
+				"dog":    data.NewArray([]data.Value{objects.Values[1], objects.Values[4]}),
+				"person": data.NewArray([]data.Value{objects.Values[3]}),
+			}),
+		},
+		{
+			name: "group-by missing path",
+			ref:  `objects | group-by "box.top"`,
+			in:   objects,
+			want: data.NewMap(nil),
+		},
+		{
+			name: "unique",
+			ref:  `objects | unique "label"`,
+			in:   objects,
+			want: data.NewArray([]data.Value{objects.Values[0], objects.Values[1], objects.Values[3]}),
+		},
+		{
+			name: "unique items",
+			ref:  `labels | unique`,
+			in:   data.NewArray([]data.Value{data.NewString("a"), data.NewNumberFromInteger(1), data.NewString("a"), data.NewString("1")}),
+			want: data.NewArray([]data.Value{data.NewString("a"), data.NewNumberFromInteger(1), data.NewString("1")}),
+		},
+		{
+			// The unresolved references aren't formatted.
+			name: "null",
+			ref:  `objects | sort "score"`,
+			in:   data.NewNull(),
+			want: data.NewNull(),
+		},
+		{
+			name:    "nok - unknown order",
+			ref:     `objects | sort "score" "up"`,
+			in:      objects,
+			wantErr: `sort: unknown order "up"`,
+		},
+		{
+			name:    "nok - not an array",
+			ref:     `objects | unique`,
+			in:      data.NewString("cat"),
+			wantErr: "unique: value isn't an array",
+		},
+		{
+			name:    "nok - missing filter",
+			ref:     `objects | filter`,
+			in:      objects,
+			wantErr: "filter: missing filter expression",
+		},
+		{
+			name:    "nok - invalid filter",
+			ref:     `objects | filter "@.score >"`,
+			in:      objects,
+			wantErr: "filter: .*",
+		},
+		{
+			name:    "nok - missing group path",
+			ref:     `objects | group-by`,
+			in:      objects,
+			wantErr: "group-by: missing path",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			_, calls, err := splitFormatPipe(tc.ref)
+			c.Assert(err, quicktest.IsNil)
+
+			got, err := applyFormatCalls(tc.in, calls)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(data.Equal(got, tc.want), quicktest.IsTrue)
+		})
+	}
+}
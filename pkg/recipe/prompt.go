@@ -0,0 +1,86 @@
+package recipe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+const promptRefPrefix = "prompt://"
+
+// ErrInvalidPromptReference indicates a malformed prompt reference.
+var ErrInvalidPromptReference = fmt.Errorf("%w: prompt reference", errdomain.ErrInvalidArgument)
+
+// PromptReference identifies a version of a prompt in the registry of a
+// namespace. A zero version references the latest one.
+type PromptReference struct {
+	ID      string
+	Version int32
+}
+
+// IsPromptReference returns whether a string value references a prompt.
+func IsPromptReference(s string) bool {
+	return strings.HasPrefix(s, promptRefPrefix)
+}
+
+// ParsePromptReference parses a prompt reference with the
+// `prompt://<id>@<version>` or `prompt://<id>` format.
+func ParsePromptReference(s string) (PromptReference, error) {
+	if !IsPromptReference(s) {
+		return PromptReference{}, ErrInvalidPromptReference
+	}
+
+	id, version, hasVersion := strings.Cut(strings.TrimPrefix(s, promptRefPrefix), "@")
+	if id == "" {
+		return PromptReference{}, errmsg.AddMessage(
+			ErrInvalidPromptReference,
+			"Prompt references must have the prompt://<prompt-id>@<version> format.",
+		)
+	}
+
+	ref := PromptReference{ID: id}
+	if !hasVersion {
+		return ref, nil
+	}
+
+	v, err := strconv.ParseInt(version, 10, 32)
+	if err != nil || v <= 0 {
+		return PromptReference{}, errmsg.AddMessage(
+			ErrInvalidPromptReference,
+			fmt.Sprintf("Invalid version %q in prompt reference. Versions are positive integers.", version),
+		)
+	}
+
+	ref.Version = int32(v)
+	return ref, nil
+}
+
+// PromptVariables returns the references (e.g. `variable.topic`) in a prompt
+// template, in order of appearance and without duplicates.
+func PromptVariables(template string) []string {
+	variables := []string{}
+	seen := map[string]bool{}
+	for {
+		startIdx := strings.Index(template, "${")
+		if startIdx == -1 {
+			break
+		}
+		template = template[startIdx:]
+		endIdx := strings.Index(template, "}")
+		if endIdx == -1 {
+			break
+		}
+
+		ref := strings.TrimSpace(template[2:endIdx])
+		if ref != "" && !seen[ref] {
+			seen[ref] = true
+			variables = append(variables, ref)
+		}
+		template = template[endIdx+1:]
+	}
+	return variables
+}
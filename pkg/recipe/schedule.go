@@ -0,0 +1,120 @@
+package recipe
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/robfig/cron"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+// MaxScheduleRunPreviews is the maximum number of upcoming run times that can
+// be computed for a schedule.
+const MaxScheduleRunPreviews = 100
+
+// MinScheduleCatchupWindow is the shortest catch-up window accepted by the
+// scheduler.
+const MinScheduleCatchupWindow = 10 * time.Second
+
+// ParseSchedule parses a cron expression in the standard 5-field format or a
+// descriptor (e.g. "@daily", "@every 1h30m"), evaluated in the provided
+// timezone. An empty timezone stands for UTC.
+func ParseSchedule(expr, timezone string) (cron.Schedule, *time.Location, error) {
+	loc := time.UTC
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return nil, nil, fmt.Errorf("invalid timezone %q", timezone)
+		}
+	}
+
+	sched, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+
+	return sched, loc, nil
+}
+
+// NextScheduleTimes returns the next n times at which a cron expression fires
+// after a given time.
+func NextScheduleTimes(expr, timezone string, from time.Time, n int) ([]time.Time, error) {
+	sched, loc, err := ParseSchedule(expr, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	n = min(n, MaxScheduleRunPreviews)
+	times := make([]time.Time, 0, n)
+	for t := from.In(loc); len(times) < n; {
+		t = sched.Next(t)
+		if t.IsZero() {
+			// The expression can't be satisfied (e.g. February 30th).
+			break
+		}
+		times = append(times, t)
+	}
+
+	return times, nil
+}
+
+// ScheduleID returns the ID of the Temporal schedule that triggers a pipeline
+// or a pipeline release.
+func ScheduleID(pipelineUID, releaseUID uuid.UUID) string {
+	return fmt.Sprintf("%s_%s_schedule", pipelineUID, releaseUID)
+}
+
+// TemporalCronExpression returns the cron string of a schedule as expected by
+// Temporal, which takes the timezone as a CRON_TZ prefix.
+func TemporalCronExpression(s *datamodel.Schedule) string {
+	if s.Timezone == "" {
+		return s.Cron
+	}
+	return fmt.Sprintf("CRON_TZ=%s %s", s.Timezone, s.Cron)
+}
+
+// SchedulePolicy returns the overlap policy and the catch-up window of the
+// schedules of a recipe. The runs of a pipeline can overlap across schedules,
+// so every schedule that declares a policy must declare the same one. Empty
+// values stand for the scheduler defaults.
+func SchedulePolicy(schedules map[string]*datamodel.Schedule) (overlap, catchupWindow string, err error) {
+	ids := make([]string, 0, len(schedules))
+	for id := range schedules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		s := schedules[id]
+
+		switch s.Overlap {
+		case "", datamodel.ScheduleOverlapSkip, datamodel.ScheduleOverlapQueue, datamodel.ScheduleOverlapCancelPrevious:
+		default:
+			return "", "", fmt.Errorf("schedule %s: overlap must be one of %s, %s or %s", id,
+				datamodel.ScheduleOverlapSkip, datamodel.ScheduleOverlapQueue, datamodel.ScheduleOverlapCancelPrevious)
+		}
+		if s.CatchupWindow != "" {
+			if d, err := time.ParseDuration(s.CatchupWindow); err != nil || d < MinScheduleCatchupWindow {
+				return "", "", fmt.Errorf("schedule %s: catchup-window must be a duration of at least %s", id, MinScheduleCatchupWindow)
+			}
+		}
+
+		if s.Overlap != "" {
+			if overlap != "" && overlap != s.Overlap {
+				return "", "", fmt.Errorf("schedule %s: all the schedules of a pipeline must share the overlap policy", id)
+			}
+			overlap = s.Overlap
+		}
+		if s.CatchupWindow != "" {
+			if catchupWindow != "" && catchupWindow != s.CatchupWindow {
+				return "", "", fmt.Errorf("schedule %s: all the schedules of a pipeline must share the catch-up window", id)
+			}
+			catchupWindow = s.CatchupWindow
+		}
+	}
+
+	return overlap, catchupWindow, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	"go.einride.tech/aip/filtering"
 	"go.einride.tech/aip/ordering"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"gorm.io/plugin/dbresolver"
@@ -80,9 +81,31 @@ type Repository interface {
 	UpdateNamespaceConnectionByUID(context.Context, uuid.UUID, *datamodel.Connection) (*datamodel.Connection, error)
 	DeleteNamespaceConnectionByID(_ context.Context, nsUID uuid.UUID, id string) error
 	GetNamespaceConnectionByID(_ context.Context, nsUID uuid.UUID, id string) (*datamodel.Connection, error)
+	UpdateNamespaceConnectionMaxConcurrency(_ context.Context, nsUID uuid.UUID, id string, maxConcurrency int32) (*datamodel.Connection, error)
 	ListNamespaceConnections(context.Context, ListNamespaceConnectionsParams) (ConnectionList, error)
 	ListPipelineIDsByConnectionID(context.Context, ListPipelineIDsByConnectionIDParams) (PipelinesByConnectionList, error)
 
+	CreateNamespaceEventSource(context.Context, *datamodel.EventSource) error
+	GetNamespaceEventSourceByID(_ context.Context, nsUID uuid.UUID, id string) (*datamodel.EventSource, error)
+	ListNamespaceEventSources(_ context.Context, nsUID uuid.UUID) ([]*datamodel.EventSource, error)
+	DeleteNamespaceEventSourceByID(_ context.Context, nsUID uuid.UUID, id string) error
+	ListEventSourcesAdmin(context.Context) ([]*datamodel.EventSource, error)
+	UpdateEventSourceCheckpoint(_ context.Context, uid uuid.UUID, checkpoint []byte) error
+
+	CreateNamespacePromptVersion(context.Context, *datamodel.Prompt) error
+	GetNamespacePromptByID(_ context.Context, nsUID uuid.UUID, id string, version int32) (*datamodel.Prompt, error)
+	ListNamespacePrompts(_ context.Context, nsUID uuid.UUID) ([]*datamodel.Prompt, error)
+	ListNamespacePromptVersions(_ context.Context, nsUID uuid.UUID, id string) ([]*datamodel.Prompt, error)
+	DeleteNamespacePromptByID(_ context.Context, nsUID uuid.UUID, id string) error
+
+	GetNamespaceSettings(_ context.Context, nsUID uuid.UUID) (*datamodel.NamespaceSettings, error)
+	UpsertNamespaceSettings(context.Context, *datamodel.NamespaceSettings) error
+
+	SyncPipelineCanaries(_ context.Context, pipelineUID uuid.UUID, canaries []*datamodel.PipelineCanary) error
+	ListPipelineCanaries(_ context.Context, pipelineUID uuid.UUID) ([]*datamodel.PipelineCanary, error)
+	ClaimDuePipelineCanaries(_ context.Context, now time.Time, lease time.Duration, limit int) ([]*datamodel.PipelineCanary, error)
+	UpdatePipelineCanary(context.Context, *datamodel.PipelineCanary) error
+
 	CreateNamespaceSecret(ctx context.Context, ownerPermalink string, secret *datamodel.Secret) error
 	ListNamespaceSecrets(ctx context.Context, ownerPermalink string, pageSize int64, pageToken string, filter filtering.Filter) ([]*datamodel.Secret, int64, string, error)
 	GetNamespaceSecretByID(ctx context.Context, ownerPermalink string, id string) (*datamodel.Secret, error)
@@ -105,6 +128,10 @@ type Repository interface {
 	GetPaginatedPipelineRunsWithPermissions(ctx context.Context, requesterUID, pipelineUID string, page, pageSize int, filter filtering.Filter, order ordering.OrderBy, isOwner bool) ([]datamodel.PipelineRun, int64, error)
 	GetPaginatedComponentRunsByPipelineRunIDWithPermissions(ctx context.Context, pipelineRunID string, page, pageSize int, filter filtering.Filter, order ordering.OrderBy) ([]datamodel.ComponentRun, int64, error)
 	GetPaginatedPipelineRunsByRequester(ctx context.Context, params GetPipelineRunsByRequesterParams) ([]datamodel.PipelineRun, int64, error)
+	ListPipelineRunsByScheduleID(_ context.Context, scheduleID string, limit int) ([]datamodel.PipelineRun, error)
+	UpsertRunArtifact(context.Context, *datamodel.RunArtifact) error
+	ListRunArtifacts(_ context.Context, pipelineTriggerUID uuid.UUID) ([]datamodel.RunArtifact, error)
+	GetRunArtifact(_ context.Context, pipelineTriggerUID, artifactUID uuid.UUID) (*datamodel.RunArtifact, error)
 }
 
 type repository struct {
@@ -1146,6 +1173,72 @@ func (r *repository) UpdatePipelineRun(ctx context.Context, pipelineTriggerUID s
 	return r.db.Model(&datamodel.PipelineRun{}).Where(&datamodel.PipelineRun{PipelineTriggerUID: uid}).Updates(&pipelineRun).Error
 }
 
+// ListPipelineRunsByScheduleID returns the most recent runs triggered by a
+// schedule, latest first.
+func (r *repository) ListPipelineRunsByScheduleID(ctx context.Context, scheduleID string, limit int) ([]datamodel.PipelineRun, error) {
+	db := r.db.WithContext(ctx)
+
+	var pipelineRuns []datamodel.PipelineRun
+	err := db.Where("schedule_id = ?", scheduleID).
+		Order("started_time desc").
+		Limit(limit).
+		Find(&pipelineRuns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return pipelineRuns, nil
+}
+
+// UpsertRunArtifact stores the metadata of a run artifact. An artifact that
+// is written again by the same component in the same batch item (e.g. when
+// the component activity is retried) replaces the previous one.
+func (r *repository) UpsertRunArtifact(ctx context.Context, artifact *datamodel.RunArtifact) error {
+	db := r.db.WithContext(ctx)
+
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "pipeline_trigger_uid"},
+			{Name: "component_id"},
+			{Name: "batch_index"},
+			{Name: "name"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"content_type", "size", "object_key", "metadata", "create_time"}),
+	}).Create(artifact).Error
+}
+
+// ListRunArtifacts returns the artifacts of a pipeline run in creation order.
+func (r *repository) ListRunArtifacts(ctx context.Context, pipelineTriggerUID uuid.UUID) ([]datamodel.RunArtifact, error) {
+	db := r.db.WithContext(ctx)
+
+	var artifacts []datamodel.RunArtifact
+	err := db.Where("pipeline_trigger_uid = ?", pipelineTriggerUID).
+		Order("create_time, component_id, batch_index, name").
+		Find(&artifacts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return artifacts, nil
+}
+
+// GetRunArtifact returns an artifact of a pipeline run.
+func (r *repository) GetRunArtifact(ctx context.Context, pipelineTriggerUID, artifactUID uuid.UUID) (*datamodel.RunArtifact, error) {
+	db := r.db.WithContext(ctx)
+
+	artifact := new(datamodel.RunArtifact)
+	err := db.Where("pipeline_trigger_uid = ? AND uid = ?", pipelineTriggerUID, artifactUID).
+		First(artifact).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errdomain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return artifact, nil
+}
+
 func (r *repository) UpsertComponentRun(ctx context.Context, componentRun *datamodel.ComponentRun) error {
 	return r.db.Save(componentRun).Error
 }
@@ -1380,6 +1473,27 @@ func (r *repository) DeleteNamespaceConnectionByID(ctx context.Context, nsUID uu
 
 	return nil
 }
+
+// UpdateNamespaceConnectionMaxConcurrency sets the concurrency limit of a
+// connection. As zero disables the limit, it can't be updated with the rest
+// of fields.
+func (r *repository) UpdateNamespaceConnectionMaxConcurrency(ctx context.Context, nsUID uuid.UUID, id string, maxConcurrency int32) (*datamodel.Connection, error) {
+	db := r.db.WithContext(ctx)
+
+	result := db.Model(&datamodel.Connection{}).
+		Where("namespace_uid = ? AND id = ?", nsUID, id).
+		Update("max_concurrency", maxConcurrency)
+	if result.Error != nil {
+		return nil, r.toDomainErr(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, errdomain.ErrNotFound
+	}
+
+	return r.GetNamespaceConnectionByID(ctx, nsUID, id)
+}
+
 func (r *repository) GetNamespaceConnectionByID(ctx context.Context, nsUID uuid.UUID, id string) (*datamodel.Connection, error) {
 	db := r.db.WithContext(ctx)
 
@@ -1605,3 +1719,306 @@ func (r *repository) ListPipelineIDsByConnectionID(
 
 	return page, nil
 }
+
+func (r *repository) CreateNamespaceEventSource(ctx context.Context, es *datamodel.EventSource) error {
+	db := r.db.WithContext(ctx)
+
+	if err := db.Create(es).Error; err != nil {
+		return r.toDomainErr(err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetNamespaceEventSourceByID(ctx context.Context, nsUID uuid.UUID, id string) (*datamodel.EventSource, error) {
+	db := r.db.WithContext(ctx)
+
+	es := new(datamodel.EventSource)
+	if err := db.Where("namespace_uid = ? AND id = ?", nsUID, id).First(es).Error; err != nil {
+		return nil, r.toDomainErr(err)
+	}
+
+	return es, nil
+}
+
+func (r *repository) ListNamespaceEventSources(ctx context.Context, nsUID uuid.UUID) ([]*datamodel.EventSource, error) {
+	db := r.db.WithContext(ctx)
+
+	var sources []*datamodel.EventSource
+	if err := db.Where("namespace_uid = ?", nsUID).Order("create_time DESC").Find(&sources).Error; err != nil {
+		return nil, r.toDomainErr(err)
+	}
+
+	return sources, nil
+}
+
+func (r *repository) DeleteNamespaceEventSourceByID(ctx context.Context, nsUID uuid.UUID, id string) error {
+	db := r.db.WithContext(ctx)
+
+	result := db.Where("(id = ? AND namespace_uid = ?)", id, nsUID).Delete(&datamodel.EventSource{})
+	if result.Error != nil {
+		return r.toDomainErr(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errdomain.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListEventSourcesAdmin returns the event sources of all the namespaces. It
+// is used by the dispatcher to know which sources it has to consume.
+func (r *repository) ListEventSourcesAdmin(ctx context.Context) ([]*datamodel.EventSource, error) {
+	db := r.db.WithContext(ctx)
+
+	var sources []*datamodel.EventSource
+	if err := db.Find(&sources).Error; err != nil {
+		return nil, r.toDomainErr(err)
+	}
+
+	return sources, nil
+}
+
+// UpdateEventSourceCheckpoint stores the position of the last acknowledged
+// message of an event source. The update time isn't modified, as the
+// dispatcher uses it to detect configuration changes.
+func (r *repository) UpdateEventSourceCheckpoint(ctx context.Context, uid uuid.UUID, checkpoint []byte) error {
+	db := r.db.WithContext(ctx)
+
+	result := db.Model(&datamodel.EventSource{}).
+		Where("uid = ?", uid).
+		UpdateColumn("checkpoint", datatypes.JSON(checkpoint))
+	if result.Error != nil {
+		return r.toDomainErr(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errdomain.ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateNamespacePromptVersion stores a prompt as the next version of its ID
+// in the namespace. The version of the prompt is set accordingly.
+func (r *repository) CreateNamespacePromptVersion(ctx context.Context, p *datamodel.Prompt) error {
+	db := r.db.WithContext(ctx)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var latest int32
+		if err := tx.Model(&datamodel.Prompt{}).
+			Where("namespace_uid = ? AND id = ?", p.NamespaceUID, p.ID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		// Concurrent creations of the same version are rejected by the
+		// unique index.
+		p.Version = latest + 1
+		return tx.Create(p).Error
+	})
+
+	return r.toDomainErr(err)
+}
+
+// GetNamespacePromptByID fetches a version of a prompt. A zero version
+// returns the latest one.
+func (r *repository) GetNamespacePromptByID(ctx context.Context, nsUID uuid.UUID, id string, version int32) (*datamodel.Prompt, error) {
+	db := r.db.WithContext(ctx)
+
+	q := db.Where("namespace_uid = ? AND id = ?", nsUID, id)
+	if version > 0 {
+		q = q.Where("version = ?", version)
+	}
+
+	p := new(datamodel.Prompt)
+	if err := q.Order("version DESC").First(p).Error; err != nil {
+		return nil, r.toDomainErr(err)
+	}
+
+	return p, nil
+}
+
+// ListNamespacePrompts returns the latest version of each prompt in a
+// namespace.
+func (r *repository) ListNamespacePrompts(ctx context.Context, nsUID uuid.UUID) ([]*datamodel.Prompt, error) {
+	db := r.db.WithContext(ctx)
+
+	var prompts []*datamodel.Prompt
+	if err := db.Raw(
+		"SELECT DISTINCT ON (id) * FROM prompt WHERE namespace_uid = ? ORDER BY id, version DESC",
+		nsUID,
+	).Scan(&prompts).Error; err != nil {
+		return nil, r.toDomainErr(err)
+	}
+
+	return prompts, nil
+}
+
+// ListNamespacePromptVersions returns the versions of a prompt, the most
+// recent first.
+func (r *repository) ListNamespacePromptVersions(ctx context.Context, nsUID uuid.UUID, id string) ([]*datamodel.Prompt, error) {
+	db := r.db.WithContext(ctx)
+
+	var prompts []*datamodel.Prompt
+	if err := db.Where("namespace_uid = ? AND id = ?", nsUID, id).Order("version DESC").Find(&prompts).Error; err != nil {
+		return nil, r.toDomainErr(err)
+	}
+
+	if len(prompts) == 0 {
+		return nil, errdomain.ErrNotFound
+	}
+
+	return prompts, nil
+}
+
+// DeleteNamespacePromptByID deletes all the versions of a prompt.
+func (r *repository) DeleteNamespacePromptByID(ctx context.Context, nsUID uuid.UUID, id string) error {
+	db := r.db.WithContext(ctx)
+
+	result := db.Where("(id = ? AND namespace_uid = ?)", id, nsUID).Delete(&datamodel.Prompt{})
+	if result.Error != nil {
+		return r.toDomainErr(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errdomain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetNamespaceSettings fetches the settings of a namespace. A namespace that
+// never set them doesn't have a record.
+func (r *repository) GetNamespaceSettings(ctx context.Context, nsUID uuid.UUID) (*datamodel.NamespaceSettings, error) {
+	db := r.db.WithContext(ctx)
+
+	settings := new(datamodel.NamespaceSettings)
+	if err := db.Where("namespace_uid = ?", nsUID).First(settings).Error; err != nil {
+		return nil, r.toDomainErr(err)
+	}
+
+	return settings, nil
+}
+
+// UpsertNamespaceSettings creates or replaces the settings of a namespace.
+func (r *repository) UpsertNamespaceSettings(ctx context.Context, settings *datamodel.NamespaceSettings) error {
+	db := r.db.WithContext(ctx)
+
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "namespace_uid"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"max_duration",
+			"component_timeout",
+			"retry_max_attempts",
+			"retry_initial_interval",
+			"error_policy",
+			"cache_ttl",
+			"allowed_components",
+			"blocked_components",
+			"update_time",
+		}),
+	}).Create(settings).Error
+
+	return r.toDomainErr(err)
+}
+
+// SyncPipelineCanaries replaces the canary checks of a pipeline. The outcome
+// of the checks that are kept is preserved, while their next run time is
+// updated, as their schedule might have changed.
+func (r *repository) SyncPipelineCanaries(ctx context.Context, pipelineUID uuid.UUID, canaries []*datamodel.PipelineCanary) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids := make([]string, 0, len(canaries))
+		for _, c := range canaries {
+			ids = append(ids, c.CanaryID)
+		}
+
+		del := tx.Where("pipeline_uid = ?", pipelineUID)
+		if len(ids) > 0 {
+			del = del.Where("canary_id NOT IN ?", ids)
+		}
+		if err := del.Delete(&datamodel.PipelineCanary{}).Error; err != nil {
+			return r.toDomainErr(err)
+		}
+
+		if len(canaries) == 0 {
+			return nil
+		}
+
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "pipeline_uid"}, {Name: "canary_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"next_run_time", "update_time"}),
+		}).Create(&canaries).Error
+
+		return r.toDomainErr(err)
+	})
+}
+
+// ListPipelineCanaries returns the canary checks of a pipeline, sorted by
+// ID.
+func (r *repository) ListPipelineCanaries(ctx context.Context, pipelineUID uuid.UUID) ([]*datamodel.PipelineCanary, error) {
+	db := r.db.WithContext(ctx)
+
+	var canaries []*datamodel.PipelineCanary
+	if err := db.Where("pipeline_uid = ?", pipelineUID).Order("canary_id").Find(&canaries).Error; err != nil {
+		return nil, r.toDomainErr(err)
+	}
+
+	return canaries, nil
+}
+
+// ClaimDuePipelineCanaries returns the canary checks whose next run time has
+// passed, the most overdue first. Their next run time is pushed by the
+// lease, so they aren't claimed by another replica while they run, nor
+// lost if the replica crashes.
+func (r *repository) ClaimDuePipelineCanaries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*datamodel.PipelineCanary, error) {
+	db := r.db.WithContext(ctx)
+
+	var canaries []*datamodel.PipelineCanary
+	err := db.Raw(`
+		UPDATE pipeline_canary SET next_run_time = ?, update_time = ?
+		WHERE (pipeline_uid, canary_id) IN (
+			SELECT pipeline_uid, canary_id FROM pipeline_canary
+			WHERE next_run_time <= ?
+			ORDER BY next_run_time
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), now, now, limit,
+	).Scan(&canaries).Error
+	if err != nil {
+		return nil, r.toDomainErr(err)
+	}
+
+	return canaries, nil
+}
+
+// UpdatePipelineCanary records the outcome of a canary check and its next
+// run time.
+func (r *repository) UpdatePipelineCanary(ctx context.Context, canary *datamodel.PipelineCanary) error {
+	db := r.db.WithContext(ctx)
+
+	result := db.Model(&datamodel.PipelineCanary{}).
+		Where("pipeline_uid = ? AND canary_id = ?", canary.PipelineUID, canary.CanaryID).
+		Updates(map[string]any{
+			"next_run_time":        canary.NextRunTime,
+			"last_run_time":        canary.LastRunTime,
+			"last_status":          canary.LastStatus,
+			"last_error":           canary.LastError,
+			"last_trigger_uid":     canary.LastTriggerUID,
+			"consecutive_failures": canary.ConsecutiveFailures,
+		})
+	if result.Error != nil {
+		return r.toDomainErr(result.Error)
+	}
+
+	// The canary might have been removed from the recipe while it ran.
+	if result.RowsAffected == 0 {
+		return errdomain.ErrNotFound
+	}
+
+	return nil
+}
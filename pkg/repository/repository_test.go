@@ -5,6 +5,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -296,6 +297,36 @@ func TestRepository_Connection(t *testing.T) {
 		c.Check(errors.Is(err, errdomain.ErrNotFound), qt.IsTrue)
 	})
 
+	c.Run("ok - max concurrency", func(c *qt.C) {
+		repo := newRepo(c)
+		conn := newConn()
+		conn.ID = "limited"
+		conn.IntegrationUID = uuid.FromStringOrNil(email.GetUid())
+
+		_, err := repo.CreateNamespaceConnection(ctx, conn)
+		c.Assert(err, qt.IsNil)
+
+		updated, err := repo.UpdateNamespaceConnectionMaxConcurrency(ctx, nsUID, "limited", 5)
+		c.Assert(err, qt.IsNil)
+		c.Check(updated.MaxConcurrency, qt.Equals, int32(5))
+
+		// Updating the rest of fields doesn't reset the limit.
+		updated, err = repo.UpdateNamespaceConnectionByUID(ctx, updated.UID, &datamodel.Connection{
+			ID:           "limited",
+			NamespaceUID: nsUID,
+			Setup:        datatypes.JSON(`{"foo":"bar"}`),
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(updated.MaxConcurrency, qt.Equals, int32(5))
+
+		updated, err = repo.UpdateNamespaceConnectionMaxConcurrency(ctx, nsUID, "limited", 0)
+		c.Assert(err, qt.IsNil)
+		c.Check(updated.MaxConcurrency, qt.Equals, int32(0))
+
+		_, err = repo.UpdateNamespaceConnectionMaxConcurrency(ctx, nsUID, "foo", 5)
+		c.Check(errors.Is(err, errdomain.ErrNotFound), qt.IsTrue)
+	})
+
 	c.Run("ok - create, get, list", func(c *qt.C) {
 		repo := newRepo(c)
 
@@ -382,6 +413,157 @@ func TestRepository_Connection(t *testing.T) {
 	})
 }
 
+func TestRepository_Prompt(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	tx := db.Begin()
+	c.Cleanup(func() { tx.Rollback() })
+
+	repo := NewRepository(tx, nil)
+	nsUID := uuid.Must(uuid.NewV4())
+
+	for _, template := range []string{"Summarize ${variable.text}", "Summarize ${variable.text} in ${variable.lang}"} {
+		p := &datamodel.Prompt{
+			ID:           "summarize",
+			NamespaceUID: nsUID,
+			Template:     template,
+		}
+		err := repo.CreateNamespacePromptVersion(ctx, p)
+		c.Assert(err, qt.IsNil)
+	}
+	err := repo.CreateNamespacePromptVersion(ctx, &datamodel.Prompt{ID: "translate", NamespaceUID: nsUID, Template: "Translate"})
+	c.Assert(err, qt.IsNil)
+
+	latest, err := repo.GetNamespacePromptByID(ctx, nsUID, "summarize", 0)
+	c.Assert(err, qt.IsNil)
+	c.Check(latest.Version, qt.Equals, int32(2))
+	c.Check(latest.Template, qt.Equals, "Summarize ${variable.text} in ${variable.lang}")
+
+	first, err := repo.GetNamespacePromptByID(ctx, nsUID, "summarize", 1)
+	c.Assert(err, qt.IsNil)
+	c.Check(first.Template, qt.Equals, "Summarize ${variable.text}")
+
+	_, err = repo.GetNamespacePromptByID(ctx, nsUID, "summarize", 3)
+	c.Check(errors.Is(err, errdomain.ErrNotFound), qt.IsTrue)
+
+	prompts, err := repo.ListNamespacePrompts(ctx, nsUID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(prompts, qt.HasLen, 2)
+	c.Check(prompts[0].ID, qt.Equals, "summarize")
+	c.Check(prompts[0].Version, qt.Equals, int32(2))
+
+	versions, err := repo.ListNamespacePromptVersions(ctx, nsUID, "summarize")
+	c.Assert(err, qt.IsNil)
+	c.Assert(versions, qt.HasLen, 2)
+	c.Check(versions[0].Version, qt.Equals, int32(2))
+
+	err = repo.DeleteNamespacePromptByID(ctx, nsUID, "summarize")
+	c.Check(err, qt.IsNil)
+	_, err = repo.ListNamespacePromptVersions(ctx, nsUID, "summarize")
+	c.Check(errors.Is(err, errdomain.ErrNotFound), qt.IsTrue)
+}
+
+func TestRepository_NamespaceSettings(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	tx := db.Begin()
+	c.Cleanup(func() { tx.Rollback() })
+
+	repo := NewRepository(tx, nil)
+	nsUID := uuid.Must(uuid.NewV4())
+
+	_, err := repo.GetNamespaceSettings(ctx, nsUID)
+	c.Check(errors.Is(err, errdomain.ErrNotFound), qt.IsTrue)
+
+	err = repo.UpsertNamespaceSettings(ctx, &datamodel.NamespaceSettings{
+		NamespaceUID:      nsUID,
+		MaxDuration:       "10m",
+		RetryMaxAttempts:  3,
+		AllowedComponents: []string{"openai"},
+	})
+	c.Assert(err, qt.IsNil)
+
+	err = repo.UpsertNamespaceSettings(ctx, &datamodel.NamespaceSettings{
+		NamespaceUID:      nsUID,
+		ComponentTimeout:  "1m",
+		AllowedComponents: []string{"openai", "json"},
+		BlockedComponents: []string{"openai@0"},
+	})
+	c.Assert(err, qt.IsNil)
+
+	got, err := repo.GetNamespaceSettings(ctx, nsUID)
+	c.Assert(err, qt.IsNil)
+	c.Check(got.MaxDuration, qt.Equals, "")
+	c.Check(got.ComponentTimeout, qt.Equals, "1m")
+	c.Check(got.RetryMaxAttempts, qt.Equals, int32(0))
+	c.Check([]string(got.AllowedComponents), qt.DeepEquals, []string{"openai", "json"})
+	c.Check([]string(got.BlockedComponents), qt.DeepEquals, []string{"openai@0"})
+}
+
+func TestRepository_PipelineCanaries(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	tx := db.Begin()
+	c.Cleanup(func() { tx.Rollback() })
+
+	repo := NewRepository(tx, nil)
+	pipelineUID := uuid.Must(uuid.NewV4())
+	t0 := time.Now().UTC().Truncate(time.Second)
+
+	err := repo.SyncPipelineCanaries(ctx, pipelineUID, []*datamodel.PipelineCanary{
+		{PipelineUID: pipelineUID, CanaryID: "hourly", NextRunTime: t0.Add(-time.Minute)},
+		{PipelineUID: pipelineUID, CanaryID: "daily", NextRunTime: t0.Add(time.Hour)},
+	})
+	c.Assert(err, qt.IsNil)
+
+	claimed, err := repo.ClaimDuePipelineCanaries(ctx, t0, 10*time.Minute, 10)
+	c.Assert(err, qt.IsNil)
+	c.Assert(claimed, qt.HasLen, 1)
+	c.Check(claimed[0].CanaryID, qt.Equals, "hourly")
+
+	// The claimed canary is leased.
+	claimed, err = repo.ClaimDuePipelineCanaries(ctx, t0, 10*time.Minute, 10)
+	c.Assert(err, qt.IsNil)
+	c.Check(claimed, qt.HasLen, 0)
+
+	err = repo.UpdatePipelineCanary(ctx, &datamodel.PipelineCanary{
+		PipelineUID:         pipelineUID,
+		CanaryID:            "hourly",
+		NextRunTime:         t0.Add(time.Hour),
+		LastRunTime:         sql.NullTime{Time: t0, Valid: true},
+		LastStatus:          datamodel.CanaryFailed,
+		LastError:           "assertion failed",
+		ConsecutiveFailures: 1,
+	})
+	c.Assert(err, qt.IsNil)
+
+	// Syncing keeps the outcome of the remaining canaries.
+	err = repo.SyncPipelineCanaries(ctx, pipelineUID, []*datamodel.PipelineCanary{
+		{PipelineUID: pipelineUID, CanaryID: "hourly", NextRunTime: t0.Add(30 * time.Minute)},
+	})
+	c.Assert(err, qt.IsNil)
+
+	canaries, err := repo.ListPipelineCanaries(ctx, pipelineUID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(canaries, qt.HasLen, 1)
+	c.Check(canaries[0].CanaryID, qt.Equals, "hourly")
+	c.Check(canaries[0].NextRunTime.Equal(t0.Add(30*time.Minute)), qt.IsTrue)
+	c.Check(canaries[0].LastStatus, qt.Equals, datamodel.CanaryFailed)
+	c.Check(canaries[0].ConsecutiveFailures, qt.Equals, int32(1))
+
+	err = repo.UpdatePipelineCanary(ctx, &datamodel.PipelineCanary{PipelineUID: pipelineUID, CanaryID: "daily"})
+	c.Check(errors.Is(err, errdomain.ErrNotFound), qt.IsTrue)
+
+	err = repo.SyncPipelineCanaries(ctx, pipelineUID, nil)
+	c.Assert(err, qt.IsNil)
+	canaries, err = repo.ListPipelineCanaries(ctx, pipelineUID)
+	c.Assert(err, qt.IsNil)
+	c.Check(canaries, qt.HasLen, 0)
+}
+
 func TestRepository_AddPipelineRuns(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
@@ -481,6 +663,27 @@ func TestRepository_UpsertPipelineRun(t *testing.T) {
 	c.Check(got1.Status, qt.Equals, pipelineRun.Status)
 	c.Check(got1.Source, qt.Equals, pipelineRun.Source)
 	c.Check(got1.Pipeline.UID, qt.Equals, p.UID)
+	c.Check(got1.RetriedFromUID.Valid, qt.IsFalse)
+
+	retriedFromUID := uuid.Must(uuid.NewV4())
+	err = repo.UpdatePipelineRun(ctx, pipelineRun.PipelineTriggerUID.String(), &datamodel.PipelineRun{
+		RetriedFromUID: uuid.NullUUID{UUID: retriedFromUID, Valid: true},
+	})
+	c.Assert(err, qt.IsNil)
+
+	got1, err = repo.GetPipelineRunByUID(ctx, pipelineRun.PipelineTriggerUID)
+	c.Assert(err, qt.IsNil)
+	c.Check(got1.RetriedFromUID.UUID, qt.Equals, retriedFromUID)
+	c.Check(got1.Status, qt.Equals, pipelineRun.Status)
+
+	scheduleID := fmt.Sprintf("%s_%s_schedule", p.UID, uuid.Nil)
+	err = repo.UpdatePipelineRun(ctx, pipelineRun.PipelineTriggerUID.String(), &datamodel.PipelineRun{ScheduleID: scheduleID})
+	c.Assert(err, qt.IsNil)
+
+	scheduledRuns, err := repo.ListPipelineRunsByScheduleID(ctx, scheduleID, 10)
+	c.Assert(err, qt.IsNil)
+	c.Assert(scheduledRuns, qt.HasLen, 1)
+	c.Check(scheduledRuns[0].PipelineTriggerUID, qt.Equals, pipelineRun.PipelineTriggerUID)
 
 	componentRun := &datamodel.ComponentRun{
 		PipelineTriggerUID: pipelineRun.PipelineTriggerUID,
@@ -502,6 +705,38 @@ func TestRepository_UpsertPipelineRun(t *testing.T) {
 	c.Check(got2.TotalDuration.Valid, qt.IsTrue)
 	c.Check(got2.TotalDuration.Int64, qt.Equals, componentRun.TotalDuration.Int64)
 
+	artifact := &datamodel.RunArtifact{
+		UID:                uuid.Must(uuid.NewV4()),
+		PipelineTriggerUID: pipelineRun.PipelineTriggerUID,
+		ComponentID:        componentRun.ComponentID,
+		Name:               "report.pdf",
+		ContentType:        "application/pdf",
+		Size:               1024,
+		ObjectKey:          "pipeline-runs/artifact/report.pdf",
+		Metadata:           datatypes.JSONMap{"pages": "3"},
+	}
+	err = repo.UpsertRunArtifact(ctx, artifact)
+	c.Assert(err, qt.IsNil)
+
+	// Writing the artifact again replaces it.
+	rewritten := *artifact
+	rewritten.UID = uuid.Must(uuid.NewV4())
+	rewritten.Size = 2048
+	err = repo.UpsertRunArtifact(ctx, &rewritten)
+	c.Assert(err, qt.IsNil)
+
+	artifacts, err := repo.ListRunArtifacts(ctx, pipelineRun.PipelineTriggerUID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(artifacts, qt.HasLen, 1)
+	c.Check(artifacts[0].Size, qt.Equals, int64(2048))
+	c.Check(artifacts[0].Metadata["pages"], qt.Equals, "3")
+
+	gotArtifact, err := repo.GetRunArtifact(ctx, pipelineRun.PipelineTriggerUID, artifacts[0].UID)
+	c.Assert(err, qt.IsNil)
+	c.Check(gotArtifact.Name, qt.Equals, artifact.Name)
+
+	_, err = repo.GetRunArtifact(ctx, pipelineRun.PipelineTriggerUID, uuid.Must(uuid.NewV4()))
+	c.Check(errors.Is(err, errdomain.ErrNotFound), qt.IsTrue)
 }
 
 func TestRepository_GetPaginatedPipelineRunsWithPermissions(t *testing.T) {
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/utils"
+	"github.com/instill-ai/pipeline-backend/pkg/worker"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+)
+
+// RunReview is the review of an approval component in a pipeline run.
+type RunReview struct {
+	ComponentID string `json:"componentId"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+// ApproveRun approves an approval component of a running pipeline, which
+// resumes the execution of its downstream components.
+func (s *service) ApproveRun(ctx context.Context, namespaceID, pipelineID, pipelineRunID string, review *RunReview) error {
+	return s.reviewRun(ctx, namespaceID, pipelineID, pipelineRunID, review, true)
+}
+
+// RejectRun rejects an approval component of a running pipeline. The
+// component fails and its downstream components are skipped.
+func (s *service) RejectRun(ctx context.Context, namespaceID, pipelineID, pipelineRunID string, review *RunReview) error {
+	return s.reviewRun(ctx, namespaceID, pipelineID, pipelineRunID, review, false)
+}
+
+// reviewRun sends the decision on an approval component to the workflow that
+// orchestrates the run. Only the users that can trigger the pipeline can
+// review its runs.
+func (s *service) reviewRun(ctx context.Context, namespaceID, pipelineID, pipelineRunID string, review *RunReview, approved bool) error {
+	if review.ComponentID == "" {
+		return errmsg.AddMessage(
+			fmt.Errorf("%w: missing component ID", errdomain.ErrInvalidArgument),
+			"The ID of the approval component is required.",
+		)
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	dbPipeline, err := s.repository.GetNamespacePipelineByID(ctx, ns.Permalink(), pipelineID, false, true)
+	if err != nil {
+		return errdomain.ErrNotFound
+	}
+
+	if err := s.checkTriggerPermission(ctx, dbPipeline); err != nil {
+		return err
+	}
+
+	run, err := s.repository.GetPipelineRunByUID(ctx, uuid.FromStringOrNil(pipelineRunID))
+	if err != nil || run.PipelineUID != dbPipeline.UID {
+		return errdomain.ErrNotFound
+	}
+
+	if run.Status != datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_PROCESSING) {
+		return errmsg.AddMessage(
+			fmt.Errorf("%w: pipeline run isn't in progress", errdomain.ErrInvalidArgument),
+			"Only runs in progress can be reviewed.",
+		)
+	}
+
+	_, userUID := utils.GetRequesterUIDAndUserUID(ctx)
+	decision := &worker.ApprovalDecision{
+		ComponentID: review.ComponentID,
+		Approved:    approved,
+		Reviewer:    userUID,
+		Comment:     review.Comment,
+	}
+
+	// The pipeline trigger ID is used as the ID of the trigger workflow.
+	if err := s.temporalFor(run.TemporalCluster).SignalWorkflow(ctx, pipelineRunID, "", worker.ApprovalSignal, decision); err != nil {
+		return fmt.Errorf("sending approval decision: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/utils"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// artifactURLExpiry is the validity of the artifact download URLs.
+const artifactURLExpiry = time.Hour
+
+// RunArtifact is a file registered by a component during a pipeline run.
+type RunArtifact struct {
+	UID         string         `json:"uid"`
+	Name        string         `json:"name"`
+	ComponentID string         `json:"componentId"`
+	BatchIndex  int            `json:"batchIndex"`
+	ContentType string         `json:"contentType"`
+	Size        int64          `json:"size"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+	CreateTime  time.Time      `json:"createTime"`
+	DownloadURL string         `json:"downloadUrl,omitempty"`
+}
+
+// ListRunArtifacts returns the artifacts registered in a pipeline run. The
+// download URLs are only provided when fetching a single artifact.
+func (s *service) ListRunArtifacts(ctx context.Context, namespaceID, pipelineID, pipelineRunID string) ([]*RunArtifact, error) {
+	run, err := s.getViewablePipelineRun(ctx, namespaceID, pipelineID, pipelineRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbArtifacts, err := s.repository.ListRunArtifacts(ctx, run.PipelineTriggerUID)
+	if err != nil {
+		return nil, fmt.Errorf("listing run artifacts: %w", err)
+	}
+
+	artifacts := make([]*RunArtifact, 0, len(dbArtifacts))
+	for i := range dbArtifacts {
+		artifacts = append(artifacts, convertRunArtifact(&dbArtifacts[i]))
+	}
+
+	return artifacts, nil
+}
+
+// GetRunArtifact returns an artifact of a pipeline run with a presigned URL
+// to download its content.
+func (s *service) GetRunArtifact(ctx context.Context, namespaceID, pipelineID, pipelineRunID, artifactUID string) (*RunArtifact, error) {
+	run, err := s.getViewablePipelineRun(ctx, namespaceID, pipelineID, pipelineRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbArtifact, err := s.repository.GetRunArtifact(ctx, run.PipelineTriggerUID, uuid.FromStringOrNil(artifactUID))
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := convertRunArtifact(dbArtifact)
+	artifact.DownloadURL, err = s.minioClient.GetDownloadURL(ctx, dbArtifact.ObjectKey, dbArtifact.Name, artifactURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("generating download URL: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// getViewablePipelineRun returns a run of a pipeline if the requester can
+// access its data, i.e., if they're the credit owner of the run.
+func (s *service) getViewablePipelineRun(ctx context.Context, namespaceID, pipelineID, pipelineRunID string) (*datamodel.PipelineRun, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	dbPipeline, err := s.repository.GetNamespacePipelineByID(ctx, ns.Permalink(), pipelineID, false, true)
+	if err != nil {
+		return nil, errdomain.ErrNotFound
+	}
+
+	if granted, err := s.aclClient.CheckPermission(ctx, "pipeline", dbPipeline.UID, "reader"); err != nil {
+		return nil, err
+	} else if !granted {
+		return nil, errdomain.ErrNotFound
+	}
+
+	run, err := s.repository.GetPipelineRunByUID(ctx, uuid.FromStringOrNil(pipelineRunID))
+	if err != nil || run.PipelineUID != dbPipeline.UID {
+		return nil, errdomain.ErrNotFound
+	}
+
+	requesterUID, _ := utils.GetRequesterUIDAndUserUID(ctx)
+	if !CanViewPrivateData(run.Namespace, requesterUID) {
+		return nil, errdomain.ErrUnauthorized
+	}
+
+	return run, nil
+}
+
+func convertRunArtifact(a *datamodel.RunArtifact) *RunArtifact {
+	return &RunArtifact{
+		UID:         a.UID.String(),
+		Name:        a.Name,
+		ComponentID: a.ComponentID,
+		BatchIndex:  a.BatchIndex,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Metadata:    a.Metadata,
+		CreateTime:  a.CreateTime,
+	}
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/config"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+	pipelinepb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+// Canary checks are synthetic runs of a pipeline with fixed inputs whose
+// outputs are checked against a set of assertions. They run on a schedule,
+// so a broken upstream API (or a recipe that stopped producing the expected
+// results) is detected before the users of the pipeline notice it.
+
+// canaryClaimLimit is the maximum number of canary checks a replica runs on
+// each tick.
+const canaryClaimLimit = 20
+
+const canaryMeterName = "github.com/instill-ai/pipeline-backend/pkg/service/canary"
+
+// The canary runs are reported in their own metrics rather than in the usage
+// ones, so they don't skew the figures of the user traffic.
+var (
+	canaryRuns     metric.Int64Counter
+	canaryDuration metric.Float64Histogram
+	canaryAlerts   metric.Int64Counter
+)
+
+func init() {
+	meter := otel.Meter(canaryMeterName)
+
+	canaryRuns, _ = meter.Int64Counter(
+		"pipeline_canary_runs_total",
+		metric.WithDescription("Canary checks run on the pipelines, by result."),
+	)
+	canaryDuration, _ = meter.Float64Histogram(
+		"pipeline_canary_duration_seconds",
+		metric.WithDescription("Time it takes to run a canary check, including the pipeline trigger."),
+		metric.WithUnit("s"),
+	)
+	canaryAlerts, _ = meter.Int64Counter(
+		"pipeline_canary_alerts_total",
+		metric.WithDescription("Canary checks that failed more times in a row than their alert threshold."),
+	)
+}
+
+type canaryRunKey struct{}
+
+// withCanaryRun marks the triggers started from a context as the runs of a
+// canary check.
+func withCanaryRun(ctx context.Context, canaryID string) context.Context {
+	return context.WithValue(ctx, canaryRunKey{}, canaryID)
+}
+
+// canaryRunID returns the canary check a trigger belongs to, if any.
+func canaryRunID(ctx context.Context) string {
+	id, _ := ctx.Value(canaryRunKey{}).(string)
+	return id
+}
+
+func isCanaryRun(ctx context.Context) bool {
+	return canaryRunID(ctx) != ""
+}
+
+// CanaryStatus holds the definition of a canary check and the outcome of its
+// last run.
+type CanaryStatus struct {
+	ID                  string     `json:"id"`
+	Cron                string     `json:"cron"`
+	Timezone            string     `json:"timezone,omitempty"`
+	Assert              []string   `json:"assert"`
+	AlertAfter          int        `json:"alertAfter"`
+	NextRunTime         time.Time  `json:"nextRunTime"`
+	LastRunTime         *time.Time `json:"lastRunTime,omitempty"`
+	LastStatus          string     `json:"lastStatus,omitempty"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastPipelineRunUID  string     `json:"lastPipelineRunUid,omitempty"`
+	ConsecutiveFailures int32      `json:"consecutiveFailures"`
+	Alerting            bool       `json:"alerting"`
+}
+
+// ListPipelineCanaries returns the canary checks of a pipeline with the
+// outcome of their last run, sorted by ID.
+func (s *service) ListPipelineCanaries(ctx context.Context, namespaceID, pipelineID string) ([]*CanaryStatus, error) {
+	dbPipeline, err := s.getViewablePipeline(ctx, namespaceID, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbCanaries, err := s.repository.ListPipelineCanaries(ctx, dbPipeline.UID)
+	if err != nil {
+		return nil, fmt.Errorf("listing canaries: %w", err)
+	}
+
+	states := make(map[string]*datamodel.PipelineCanary, len(dbCanaries))
+	for _, pc := range dbCanaries {
+		states[pc.CanaryID] = pc
+	}
+
+	canaries := []*CanaryStatus{}
+	if dbPipeline.Recipe == nil {
+		return canaries, nil
+	}
+
+	for id, c := range dbPipeline.Recipe.Canary {
+		if c == nil {
+			continue
+		}
+
+		cs := &CanaryStatus{
+			ID:         id,
+			Cron:       c.Cron,
+			Timezone:   c.Timezone,
+			Assert:     c.Assert,
+			AlertAfter: alertThreshold(c),
+		}
+		if pc, ok := states[id]; ok {
+			cs.NextRunTime = pc.NextRunTime
+			cs.LastStatus = pc.LastStatus
+			cs.LastError = pc.LastError
+			cs.ConsecutiveFailures = pc.ConsecutiveFailures
+			cs.Alerting = int(pc.ConsecutiveFailures) >= cs.AlertAfter
+			if pc.LastRunTime.Valid {
+				cs.LastRunTime = &pc.LastRunTime.Time
+			}
+			if pc.LastTriggerUID.Valid {
+				cs.LastPipelineRunUID = pc.LastTriggerUID.UUID.String()
+			}
+		}
+		canaries = append(canaries, cs)
+	}
+	sort.Slice(canaries, func(i, j int) bool {
+		return canaries[i].ID < canaries[j].ID
+	})
+
+	return canaries, nil
+}
+
+// alertThreshold returns the consecutive failures after which a canary check
+// raises an alert.
+func alertThreshold(c *datamodel.Canary) int {
+	return max(c.AlertAfter, 1)
+}
+
+// syncPipelineCanaries stores the canary checks defined in the recipe of a
+// pipeline, so they can be claimed by any replica when they're due. A nil
+// recipe removes them.
+func (s *service) syncPipelineCanaries(ctx context.Context, pipelineUID uuid.UUID, r *datamodel.Recipe) error {
+	canaries := []*datamodel.PipelineCanary{}
+	if r != nil {
+		now := time.Now()
+		for id, c := range r.Canary {
+			if c == nil {
+				continue
+			}
+
+			// Invalid schedules are reported by the recipe validation, the
+			// check isn't scheduled until they're fixed.
+			next, err := recipe.NextCanaryRunTime(c, now)
+			if err != nil {
+				continue
+			}
+
+			canaries = append(canaries, &datamodel.PipelineCanary{
+				PipelineUID: pipelineUID,
+				CanaryID:    id,
+				NextRunTime: next,
+			})
+		}
+	}
+
+	if err := s.repository.SyncPipelineCanaries(ctx, pipelineUID, canaries); err != nil {
+		return fmt.Errorf("storing canaries: %w", err)
+	}
+
+	return nil
+}
+
+// RunCanaryScheduler periodically runs the canary checks that are due until
+// the context is cancelled.
+func (s *service) RunCanaryScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		ran, err := s.runDueCanaries(ctx)
+		if err != nil {
+			s.log.Error("Couldn't run canary checks", zap.Error(err))
+			continue
+		}
+		if ran > 0 {
+			s.log.Info("Ran canary checks", zap.Int("count", ran))
+		}
+	}
+}
+
+// runDueCanaries claims the canary checks that are due and runs them. A
+// claimed check isn't due again until the workflow timeout elapses, so it
+// isn't run by other replicas meanwhile but it is retried if this replica
+// stops before recording the outcome.
+func (s *service) runDueCanaries(ctx context.Context) (int, error) {
+	lease := time.Duration(config.Config.Server.Workflow.MaxWorkflowTimeout) * time.Second
+	claimed, err := s.repository.ClaimDuePipelineCanaries(ctx, time.Now(), lease, canaryClaimLimit)
+	if err != nil {
+		return 0, fmt.Errorf("claiming canaries: %w", err)
+	}
+
+	for _, pc := range claimed {
+		if err := s.runCanary(ctx, pc); err != nil {
+			s.log.Error("Couldn't run canary check",
+				zap.String("pipelineUID", pc.PipelineUID.String()),
+				zap.String("canary", pc.CanaryID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return len(claimed), nil
+}
+
+// runCanary triggers a pipeline with the input of a canary check, evaluates
+// the assertions on its output and records the outcome.
+func (s *service) runCanary(ctx context.Context, pc *datamodel.PipelineCanary) error {
+	dbPipeline, err := s.repository.GetPipelineByUID(ctx, pc.PipelineUID, false, true)
+	if errors.Is(err, errdomain.ErrNotFound) {
+		return s.syncPipelineCanaries(ctx, pc.PipelineUID, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("fetching pipeline: %w", err)
+	}
+
+	var c *datamodel.Canary
+	if dbPipeline.Recipe != nil {
+		c = dbPipeline.Recipe.Canary[pc.CanaryID]
+	}
+	if c == nil {
+		// The canary was removed from the recipe after it was claimed.
+		return s.syncPipelineCanaries(ctx, pc.PipelineUID, dbPipeline.Recipe)
+	}
+
+	start := time.Now()
+	triggerUID, runErr := s.triggerCanary(ctx, dbPipeline, pc.CanaryID, c)
+	elapsed := time.Since(start)
+
+	pc.LastRunTime = sql.NullTime{Time: start, Valid: true}
+	pc.LastTriggerUID = uuid.NullUUID{UUID: triggerUID, Valid: !triggerUID.IsNil()}
+	if runErr != nil {
+		pc.LastStatus = datamodel.CanaryFailed
+		pc.LastError = runErr.Error()
+		pc.ConsecutiveFailures++
+	} else {
+		pc.LastStatus = datamodel.CanaryPassed
+		pc.LastError = ""
+		pc.ConsecutiveFailures = 0
+	}
+
+	if pc.NextRunTime, err = recipe.NextCanaryRunTime(c, time.Now()); err != nil {
+		return fmt.Errorf("computing next run time: %w", err)
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("pipeline_uid", pc.PipelineUID.String()),
+		attribute.String("canary", pc.CanaryID),
+	)
+	canaryRuns.Add(ctx, 1, attrs, metric.WithAttributes(attribute.String("result", pc.LastStatus)))
+	canaryDuration.Record(ctx, elapsed.Seconds(), attrs)
+
+	if runErr != nil && int(pc.ConsecutiveFailures) >= alertThreshold(c) {
+		canaryAlerts.Add(ctx, 1, attrs)
+		s.log.Error("Canary check is failing",
+			zap.String("pipelineUID", pc.PipelineUID.String()),
+			zap.String("pipelineID", dbPipeline.ID),
+			zap.String("owner", dbPipeline.Owner),
+			zap.String("canary", pc.CanaryID),
+			zap.Int32("consecutiveFailures", pc.ConsecutiveFailures),
+			zap.Error(runErr),
+		)
+	}
+
+	if err := s.repository.UpdatePipelineCanary(ctx, pc); err != nil && !errors.Is(err, errdomain.ErrNotFound) {
+		return fmt.Errorf("recording canary outcome: %w", err)
+	}
+
+	return nil
+}
+
+// triggerCanary runs a canary check on behalf of the pipeline owner and
+// returns the UID of the pipeline run. The run is logged with the canary ID
+// as its schedule, so the canary runs can be told apart from the rest.
+func (s *service) triggerCanary(ctx context.Context, dbPipeline *datamodel.Pipeline, canaryID string, c *datamodel.Canary) (uuid.UUID, error) {
+	ctx, ns, err := s.impersonateNamespace(ctx, dbPipeline.OwnerUID())
+	if err != nil {
+		return uuid.Nil, err
+	}
+	ctx = withCanaryRun(ctx, recipe.CanaryID(dbPipeline.UID, canaryID))
+
+	variables, err := structpb.NewStruct(c.Input)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("converting canary input: %w", err)
+	}
+
+	pipelineTriggerID, err := uuid.NewV4()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	pipelineRun := s.logPipelineRunStart(ctx, pipelineTriggerID.String(), dbPipeline.UID, defaultPipelineReleaseID)
+	data := []*pipelinepb.TriggerData{{Variable: variables}}
+	outputs, _, err := s.triggerPipeline(ctx, ns, dbPipeline.Recipe, dbPipeline.ID, dbPipeline.UID, "", uuid.Nil, data, pipelineTriggerID.String(), false)
+	if err != nil {
+		s.logPipelineRunError(ctx, pipelineTriggerID.String(), err, pipelineRun.StartedTime)
+		return pipelineTriggerID, err
+	}
+
+	var output map[string]any
+	if len(outputs) > 0 {
+		output = outputs[0].AsMap()
+	}
+	for _, assertion := range c.Assert {
+		passed, err := recipe.EvalAssertion(assertion, output)
+		if err != nil {
+			return pipelineTriggerID, err
+		}
+		if !passed {
+			return pipelineTriggerID, fmt.Errorf("assertion %q failed", assertion)
+		}
+	}
+
+	return pipelineTriggerID, nil
+}
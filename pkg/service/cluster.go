@@ -0,0 +1,50 @@
+package service
+
+import (
+	"go.temporal.io/sdk/client"
+
+	"github.com/instill-ai/pipeline-backend/pkg/worker"
+)
+
+// FailoverTemporalCluster makes the service dispatch the new runs and
+// schedules to another Temporal cluster. The runs that were already started
+// remain owned by their cluster, which is still used to query and signal
+// them.
+func (s *service) FailoverTemporalCluster(cluster worker.TemporalCluster) {
+	s.temporalMu.Lock()
+	defer s.temporalMu.Unlock()
+
+	s.temporalClient = cluster.Client
+	s.temporalCluster = cluster.Name
+	s.temporalClusters[cluster.Name] = cluster.Client
+}
+
+// temporal returns the client of the Temporal cluster where new runs are
+// dispatched.
+func (s *service) temporal() client.Client {
+	s.temporalMu.RLock()
+	defer s.temporalMu.RUnlock()
+
+	return s.temporalClient
+}
+
+// activeTemporalCluster returns the name of the Temporal cluster where new
+// runs are dispatched.
+func (s *service) activeTemporalCluster() string {
+	s.temporalMu.RLock()
+	defer s.temporalMu.RUnlock()
+
+	return s.temporalCluster
+}
+
+// temporalFor returns the client of the Temporal cluster that owns a run.
+// Runs recorded before clusters were tracked belong to the active cluster.
+func (s *service) temporalFor(clusterName string) client.Client {
+	s.temporalMu.RLock()
+	defer s.temporalMu.RUnlock()
+
+	if c, ok := s.temporalClusters[clusterName]; ok {
+		return c
+	}
+	return s.temporalClient
+}
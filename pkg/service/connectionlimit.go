@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/instill-ai/pipeline-backend/pkg/worker"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// ConnectionConcurrency holds the limit of concurrent calls that the
+// components make with a connection, across all the pipelines, and the calls
+// that are currently running.
+type ConnectionConcurrency struct {
+	ConnectionID   string `json:"connectionId"`
+	MaxConcurrency int32  `json:"maxConcurrency"`
+	InFlight       int64  `json:"inFlight"`
+}
+
+// GetConnectionConcurrency returns the concurrency limit of a connection.
+func (s *service) GetConnectionConcurrency(ctx context.Context, namespaceID, connectionID string) (*ConnectionConcurrency, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching namespace: %w", err)
+	}
+
+	if err := s.checkNamespacePermission(ctx, ns); err != nil {
+		return nil, fmt.Errorf("checking namespace permissions: %w", err)
+	}
+
+	conn, err := s.repository.GetNamespaceConnectionByID(ctx, ns.NsUID, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching connection: %w", err)
+	}
+
+	inFlight, err := worker.ConnectionCallsInFlight(ctx, s.redisClient, conn.UID)
+	if err != nil {
+		return nil, fmt.Errorf("counting calls in flight: %w", err)
+	}
+
+	return &ConnectionConcurrency{
+		ConnectionID:   conn.ID,
+		MaxConcurrency: conn.MaxConcurrency,
+		InFlight:       inFlight,
+	}, nil
+}
+
+// UpdateConnectionConcurrency sets the concurrency limit of a connection. A
+// zero limit removes it. The calls that are running when the limit changes
+// aren't interrupted.
+func (s *service) UpdateConnectionConcurrency(ctx context.Context, namespaceID, connectionID string, cc *ConnectionConcurrency) (*ConnectionConcurrency, error) {
+	if cc.MaxConcurrency < 0 {
+		return nil, fmt.Errorf("%w: maxConcurrency can't be negative", errdomain.ErrInvalidArgument)
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching namespace: %w", err)
+	}
+
+	if err := s.checkNamespacePermission(ctx, ns); err != nil {
+		return nil, fmt.Errorf("checking namespace permissions: %w", err)
+	}
+
+	if _, err := s.repository.UpdateNamespaceConnectionMaxConcurrency(ctx, ns.NsUID, connectionID, cc.MaxConcurrency); err != nil {
+		return nil, fmt.Errorf("updating connection: %w", err)
+	}
+
+	return s.GetConnectionConcurrency(ctx, namespaceID, connectionID)
+}
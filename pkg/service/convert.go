@@ -279,6 +279,7 @@ func (c *converter) includeIteratorComponentDetail(ctx context.Context, ownerPer
 			// Remove "${" and "}"
 			path = path[2:]
 			path = path[:len(path)-1]
+			path = recipe.TrimFormatPipe(path)
 			path = strings.ReplaceAll(path, " ", "")
 
 			// Find upstream component
@@ -387,6 +388,30 @@ func (c *converter) includeIteratorComponentDetail(ctx context.Context, ownerPer
 	return nil
 }
 
+// approvalDataSpecification returns the output schema of an approval
+// component, which holds the decision taken by the reviewer.
+func approvalDataSpecification() *pb.DataSpecification {
+	field := func(typ, format, title string) *structpb.Value {
+		return structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+			"type":          structpb.NewStringValue(typ),
+			"instillFormat": structpb.NewStringValue(format),
+			"title":         structpb.NewStringValue(title),
+		}})
+	}
+
+	return &pb.DataSpecification{
+		Output: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"type": structpb.NewStringValue("object"),
+			"properties": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+				"approved":  field("boolean", "boolean", "Approved"),
+				"reviewer":  field("string", "string", "Reviewer"),
+				"comment":   field("string", "string", "Comment"),
+				"timed-out": field("boolean", "boolean", "Timed Out"),
+			}}),
+		}},
+	}
+}
+
 func (c *converter) IncludeDetailInRecipe(ctx context.Context, ownerPermalink string, recipe *datamodel.Recipe, useDynamicDef bool) error {
 
 	if recipe == nil {
@@ -394,14 +419,24 @@ func (c *converter) IncludeDetailInRecipe(ctx context.Context, ownerPermalink st
 	}
 	for _, comp := range recipe.Component {
 		var err error
-		if comp.Type != datamodel.Iterator {
-			err = c.includeComponentDetail(ctx, ownerPermalink, comp, useDynamicDef)
-		} else {
+		switch comp.Type {
+		case datamodel.Iterator:
 			err = c.includeIteratorComponentDetail(ctx, ownerPermalink, comp, useDynamicDef)
+		case datamodel.Approval:
+			// Approval components are handled by the workflow and don't have
+			// a definition.
+			comp.DataSpecification = approvalDataSpecification()
+		default:
+			err = c.includeComponentDetail(ctx, ownerPermalink, comp, useDynamicDef)
 		}
 		if err != nil {
 			return err
 		}
+		for _, branchComp := range comp.OnError {
+			if err := c.includeComponentDetail(ctx, ownerPermalink, branchComp, useDynamicDef); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -898,6 +933,7 @@ var supportedInstillFormats = []string{
 	"video", "array:video",
 	"document", "array:document",
 	"file", "array:file",
+	"file-ref", "array:file-ref",
 }
 
 // For fields without valid "instillFormat", we will fall back to using JSON format.
@@ -961,6 +997,7 @@ func (c *converter) GeneratePipelineDataSpec(variables map[string]*datamodel.Var
 		if strings.HasPrefix(str, "${") && strings.HasSuffix(str, "}") && strings.Count(str, "${") == 1 {
 			str = str[2:]
 			str = str[:len(str)-1]
+			str = recipe.TrimFormatPipe(str)
 			str = strings.ReplaceAll(str, " ", "")
 			str, err = data.StandardizePath(str)
 			if err != nil {
@@ -989,6 +1026,11 @@ func (c *converter) GeneratePipelineDataSpec(variables map[string]*datamodel.Var
 					comp := compsOrigin[upstreamCompID]
 
 					switch comp.Type {
+					case datamodel.Approval:
+						if seg != constant.SegOutput {
+							return nil, fmt.Errorf("generate pipeline data spec error")
+						}
+						walk = structpb.NewStructValue(approvalDataSpecification().Output)
 					case datamodel.Iterator:
 
 						if seg == constant.SegOutput {
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/gofrs/uuid"
+	"go.einride.tech/aip/filtering"
+	"go.einride.tech/aip/ordering"
+
+	"github.com/instill-ai/pipeline-backend/pkg/constant"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+	"github.com/instill-ai/pipeline-backend/pkg/resource"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// Kinds of the resources a pipeline depends on or is depended on by.
+const (
+	DependencyConnection  = "connection"
+	DependencySecret      = "secret"
+	DependencyPrompt      = "prompt"
+	DependencyPipeline    = "pipeline"
+	DependencyEventSource = "event-source"
+)
+
+// dependencyRefPattern matches the references to the namespace connections
+// and secrets, e.g. ${connection.my-conn} or ${secret.api-key}.
+var dependencyRefPattern = regexp.MustCompile(`\$\{\s*(` + constant.SegConnection + `|` + constant.SegSecret + `)\.([^\s|}]+)\s*(?:\|[^}]*)?\}`)
+
+// Dependency is a namespace resource referenced by a pipeline recipe. The
+// locations are the recipe fields that reference it, e.g.
+// component.chat.setup.api-key.
+type Dependency struct {
+	Kind      string   `json:"kind"`
+	ID        string   `json:"id"`
+	Locations []string `json:"locations"`
+}
+
+// Dependent is a resource that stops working when the resource it depends on
+// is deleted or rotated.
+type Dependent struct {
+	Kind      string   `json:"kind"`
+	ID        string   `json:"id"`
+	Locations []string `json:"locations,omitempty"`
+}
+
+// DependencyImpact lists the dependents of a namespace resource, i.e. what
+// breaks if the resource is deleted or rotated.
+type DependencyImpact struct {
+	Kind       string       `json:"kind"`
+	ID         string       `json:"id"`
+	Dependents []*Dependent `json:"dependents"`
+}
+
+// ListPipelineDependencies returns the connections, secrets and prompts the
+// recipe of a pipeline references.
+func (s *service) ListPipelineDependencies(ctx context.Context, namespaceID, pipelineID string) ([]*Dependency, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	dbPipeline, err := s.repository.GetNamespacePipelineByID(ctx, ns.Permalink(), pipelineID, false, false)
+	if err != nil {
+		return nil, errdomain.ErrNotFound
+	}
+
+	if granted, err := s.aclClient.CheckPermission(ctx, "pipeline", dbPipeline.UID, "reader"); err != nil {
+		return nil, err
+	} else if !granted {
+		return nil, errdomain.ErrNotFound
+	}
+
+	return recipeDependencies(dbPipeline.Recipe), nil
+}
+
+// GetDependencyImpact returns the resources of a namespace that depend on a
+// connection, a secret, a prompt or a pipeline. Only the pipelines the
+// requester can read are listed.
+func (s *service) GetDependencyImpact(ctx context.Context, namespaceID, kind, id string) (*DependencyImpact, error) {
+	switch kind {
+	case DependencyConnection, DependencySecret, DependencyPrompt, DependencyPipeline:
+	default:
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("%w: unsupported dependency kind %q", errdomain.ErrInvalidArgument, kind),
+			"Dependency kind must be connection, secret, prompt or pipeline.",
+		)
+	}
+	if id == "" {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("%w: missing dependency ID", errdomain.ErrInvalidArgument),
+			"Dependency ID is required.",
+		)
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	uidAllowList, err := s.aclClient.ListPermissions(ctx, "pipeline", "reader", false)
+	if err != nil {
+		return nil, err
+	}
+
+	dependents, err := s.findDependents(ctx, ns, kind, id, uidAllowList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DependencyImpact{Kind: kind, ID: id, Dependents: dependents}, nil
+}
+
+// checkDependents prevents the deletion of a resource that other resources
+// of the namespace depend on, unless the request forces it. Every pipeline
+// is checked, regardless of the requester permissions, as the deletion
+// would break them too.
+func (s *service) checkDependents(ctx context.Context, ns resource.Namespace, kind, id string) error {
+	if resource.GetRequestSingleHeader(ctx, constant.HeaderForceDeleteKey) == "true" {
+		return nil
+	}
+
+	dependents, err := s.findDependents(ctx, ns, kind, id, nil)
+	if err != nil {
+		return fmt.Errorf("finding dependents: %w", err)
+	}
+	if len(dependents) == 0 {
+		return nil
+	}
+
+	return errmsg.AddMessage(
+		fmt.Errorf("%w: %d resources depend on %s %s", errdomain.ErrFailedPrecondition, len(dependents), kind, id),
+		fmt.Sprintf(
+			"The %s is used by %d resources. Remove the references or set the %s header to force the deletion.",
+			kind, len(dependents), constant.HeaderForceDeleteKey,
+		),
+	)
+}
+
+// findDependents returns the resources of a namespace that depend on
+// another one. A pipeline is depended on by the event sources that trigger
+// it, and the rest of resources by the pipelines whose recipe references
+// them. A nil allow list doesn't filter the pipelines.
+func (s *service) findDependents(ctx context.Context, ns resource.Namespace, kind, id string, uidAllowList []uuid.UUID) ([]*Dependent, error) {
+	dependents := []*Dependent{}
+
+	if kind == DependencyPipeline {
+		dbPipeline, err := s.repository.GetNamespacePipelineByID(ctx, ns.Permalink(), id, true, false)
+		if err != nil {
+			// A pipeline that doesn't exist has no dependents.
+			return dependents, nil
+		}
+
+		sources, err := s.repository.ListNamespaceEventSources(ctx, ns.NsUID)
+		if err != nil {
+			return nil, fmt.Errorf("listing event sources: %w", err)
+		}
+		for _, src := range sources {
+			if src.PipelineUID == dbPipeline.UID {
+				dependents = append(dependents, &Dependent{Kind: DependencyEventSource, ID: src.ID})
+			}
+		}
+		return dependents, nil
+	}
+
+	pageToken := ""
+	for {
+		dbPipelines, _, nextPageToken, err := s.repository.ListNamespacePipelines(ctx, ns.Permalink(), deprecationsPageSize, pageToken, false, filtering.Filter{}, uidAllowList, false, false, ordering.OrderBy{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range dbPipelines {
+			for _, d := range recipeDependencies(p.Recipe) {
+				if d.Kind == kind && d.ID == id {
+					dependents = append(dependents, &Dependent{Kind: DependencyPipeline, ID: p.ID, Locations: d.Locations})
+				}
+			}
+		}
+
+		if nextPageToken == "" {
+			return dependents, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// recipeDependencies collects the namespace resources a recipe references,
+// including the ones in nested components, error branches and failover
+// targets. The dependencies are sorted by kind and ID.
+func recipeDependencies(r *datamodel.Recipe) []*Dependency {
+	deps := []*Dependency{}
+	if r == nil {
+		return deps
+	}
+
+	byKey := map[string]*Dependency{}
+	add := func(kind, id, location string) {
+		key := kind + "/" + id
+		d, ok := byKey[key]
+		if !ok {
+			d = &Dependency{Kind: kind, ID: id}
+			byKey[key] = d
+			deps = append(deps, d)
+		}
+		d.Locations = append(d.Locations, location)
+	}
+
+	var walkValue func(v any, location string)
+	walkValue = func(v any, location string) {
+		switch v := v.(type) {
+		case string:
+			if recipe.IsPromptReference(v) {
+				if ref, err := recipe.ParsePromptReference(v); err == nil {
+					add(DependencyPrompt, ref.ID, location)
+				}
+				return
+			}
+			for _, m := range dependencyRefPattern.FindAllStringSubmatch(v, -1) {
+				add(m[1], m[2], location)
+			}
+		case map[string]any:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				walkValue(v[k], location+"."+k)
+			}
+		case []any:
+			for i, item := range v {
+				walkValue(item, location+"."+strconv.Itoa(i))
+			}
+		}
+	}
+
+	var walk func(comps datamodel.ComponentMap, prefix string)
+	walk = func(comps datamodel.ComponentMap, prefix string) {
+		ids := make([]string, 0, len(comps))
+		for id := range comps {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			comp := comps[id]
+			location := prefix + id
+
+			walkValue(comp.Setup, location+".setup")
+			walkValue(comp.Input, location+".input")
+			walkValue(comp.Condition, location+".condition")
+			for i, f := range comp.Failover {
+				walkValue(f.Setup, location+".failover."+strconv.Itoa(i)+".setup")
+			}
+
+			walk(comp.Component, location+".component.")
+			walk(comp.OnError, location+".on-error.")
+		}
+	}
+	walk(r.Component, "component.")
+
+	outputIDs := make([]string, 0, len(r.Output))
+	for id := range r.Output {
+		outputIDs = append(outputIDs, id)
+	}
+	sort.Strings(outputIDs)
+	for _, id := range outputIDs {
+		if o := r.Output[id]; o != nil {
+			walkValue(o.Value, "output."+id+".value")
+		}
+	}
+
+	sort.SliceStable(deps, func(i, j int) bool {
+		if deps[i].Kind != deps[j].Kind {
+			return deps[i].Kind < deps[j].Kind
+		}
+		return deps[i].ID < deps[j].ID
+	})
+	return deps
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+func TestRecipeDependencies(t *testing.T) {
+	c := qt.New(t)
+
+	r := &datamodel.Recipe{
+		Component: datamodel.ComponentMap{
+			"chat": {
+				Type:  "openai",
+				Task:  "TASK_TEXT_GENERATION",
+				Setup: "${connection.openai}",
+				Input: map[string]any{
+					"prompt": "prompt://summarize@2",
+					"extra":  []any{"Bearer ${secret.token}", "${ secret.token | json }"},
+				},
+				Failover: []*datamodel.FailoverTarget{
+					{Type: "anthropic", Setup: map[string]any{"api-key": "${secret.anthropic-key}"}},
+				},
+			},
+			"loop": {
+				Type: datamodel.Iterator,
+				Component: datamodel.ComponentMap{
+					"fetch": {Type: "http", Setup: map[string]any{"token": "${secret.token}"}},
+				},
+			},
+		},
+		Output: map[string]*datamodel.Output{
+			"answer": {Value: "${chat.output.texts[0]}"},
+		},
+	}
+
+	got := recipeDependencies(r)
+
+	c.Assert(got, qt.HasLen, 4)
+	c.Check(got[0], qt.DeepEquals, &Dependency{
+		Kind:      DependencyConnection,
+		ID:        "openai",
+		Locations: []string{"component.chat.setup"},
+	})
+	c.Check(got[1], qt.DeepEquals, &Dependency{
+		Kind:      DependencyPrompt,
+		ID:        "summarize",
+		Locations: []string{"component.chat.input.prompt"},
+	})
+	c.Check(got[2], qt.DeepEquals, &Dependency{
+		Kind:      DependencySecret,
+		ID:        "anthropic-key",
+		Locations: []string{"component.chat.failover.0.setup.api-key"},
+	})
+	c.Check(got[3], qt.DeepEquals, &Dependency{
+		Kind: DependencySecret,
+		ID:   "token",
+		Locations: []string{
+			"component.chat.input.extra.0",
+			"component.chat.input.extra.1",
+			"component.loop.component.fetch.setup.token",
+		},
+	})
+
+	c.Check(recipeDependencies(nil), qt.HasLen, 0)
+}
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"go.einride.tech/aip/filtering"
+	"go.einride.tech/aip/ordering"
+	"gopkg.in/yaml.v3"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+
+	componentstore "github.com/instill-ai/pipeline-backend/pkg/component/store"
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+	pipelinepb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+const deprecationsPageSize = 100
+
+// PipelineDeprecations lists the components of a pipeline recipe that are
+// deprecated. When some of them can be migrated mechanically, the migration
+// previews the rewritten recipe.
+type PipelineDeprecations struct {
+	PipelineID string             `json:"pipelineId"`
+	Usages     []*DeprecatedUsage `json:"usages"`
+	Migration  *RecipeMigration   `json:"migration,omitempty"`
+}
+
+// DeprecatedUsage is a component of a recipe that uses a deprecated
+// component, task or input field. The ID of a nested component (e.g. in an
+// iterator) is prefixed by the ID of its parent.
+type DeprecatedUsage struct {
+	ComponentID string                      `json:"componentId"`
+	Type        string                      `json:"type"`
+	Task        string                      `json:"task,omitempty"`
+	Input       string                      `json:"input,omitempty"`
+	Message     string                      `json:"message"`
+	Replacement *componentstore.Replacement `json:"replacement,omitempty"`
+
+	// keys is the path of the component in the recipe YAML document.
+	keys []string
+}
+
+// RecipeMigration is the rewrite of a recipe that replaces its deprecated
+// components. The diff is in the unified format.
+type RecipeMigration struct {
+	RawRecipe string `json:"rawRecipe"`
+	Diff      string `json:"diff"`
+}
+
+// ListDeprecatedPipelines returns the pipelines of a namespace that use
+// deprecated components.
+func (s *service) ListDeprecatedPipelines(ctx context.Context, namespaceID string) ([]*PipelineDeprecations, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	uidAllowList, err := s.aclClient.ListPermissions(ctx, "pipeline", "reader", false)
+	if err != nil {
+		return nil, err
+	}
+
+	deprecated := []*PipelineDeprecations{}
+	pageToken := ""
+	for {
+		dbPipelines, _, nextPageToken, err := s.repository.ListNamespacePipelines(ctx, ns.Permalink(), deprecationsPageSize, pageToken, false, filtering.Filter{}, uidAllowList, false, false, ordering.OrderBy{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range dbPipelines {
+			if usages := findDeprecatedUsages(s.component, p.Recipe); len(usages) > 0 {
+				deprecated = append(deprecated, &PipelineDeprecations{PipelineID: p.ID, Usages: usages})
+			}
+		}
+
+		if nextPageToken == "" {
+			return deprecated, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// GetPipelineDeprecations returns the deprecated components of a pipeline
+// and the preview of their migration.
+func (s *service) GetPipelineDeprecations(ctx context.Context, namespaceID, pipelineID string) (*PipelineDeprecations, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	dbPipeline, err := s.repository.GetNamespacePipelineByID(ctx, ns.Permalink(), pipelineID, false, true)
+	if err != nil {
+		return nil, errdomain.ErrNotFound
+	}
+
+	if granted, err := s.aclClient.CheckPermission(ctx, "pipeline", dbPipeline.UID, "reader"); err != nil {
+		return nil, err
+	} else if !granted {
+		return nil, errdomain.ErrNotFound
+	}
+
+	return s.pipelineDeprecations(dbPipeline)
+}
+
+// MigratePipelineRecipe rewrites the recipe of a pipeline so it stops using
+// the deprecated components that have a mechanical replacement. The rest of
+// deprecated components are left untouched and are returned along with the
+// applied migration.
+func (s *service) MigratePipelineRecipe(ctx context.Context, namespaceID, pipelineID string) (*PipelineDeprecations, error) {
+	deprecations, err := s.GetPipelineDeprecations(ctx, namespaceID, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	if deprecations.Migration == nil {
+		return nil, fmt.Errorf("%w: the pipeline has no deprecated components that can be migrated", errdomain.ErrInvalidArgument)
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	pbPipeline, err := s.GetNamespacePipelineByID(ctx, ns, pipelineID, pipelinepb.Pipeline_VIEW_RECIPE)
+	if err != nil {
+		return nil, err
+	}
+
+	pbPipeline.RawRecipe = deprecations.Migration.RawRecipe
+	if _, err := s.UpdateNamespacePipelineByID(ctx, ns, pipelineID, pbPipeline); err != nil {
+		return nil, fmt.Errorf("updating pipeline recipe: %w", err)
+	}
+
+	remaining := deprecations.Usages[:0]
+	for _, u := range deprecations.Usages {
+		if u.Replacement == nil {
+			remaining = append(remaining, u)
+		}
+	}
+	deprecations.Usages = remaining
+
+	return deprecations, nil
+}
+
+func (s *service) pipelineDeprecations(dbPipeline *datamodel.Pipeline) (*PipelineDeprecations, error) {
+	deprecations := &PipelineDeprecations{
+		PipelineID: dbPipeline.ID,
+		Usages:     findDeprecatedUsages(s.component, dbPipeline.Recipe),
+	}
+
+	migrated, err := migrateRecipe(dbPipeline.RecipeYAML, deprecations.Usages)
+	if err != nil {
+		return nil, err
+	}
+	if migrated != dbPipeline.RecipeYAML {
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(dbPipeline.RecipeYAML),
+			B:        difflib.SplitLines(migrated),
+			FromFile: "recipe.yaml",
+			ToFile:   "recipe.yaml",
+			Context:  3,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("computing recipe diff: %w", err)
+		}
+		deprecations.Migration = &RecipeMigration{RawRecipe: migrated, Diff: diff}
+	}
+
+	return deprecations, nil
+}
+
+// findDeprecatedUsages walks the components of a recipe, including the ones
+// nested in iterators and error branches.
+func findDeprecatedUsages(cs *componentstore.Store, r *datamodel.Recipe) []*DeprecatedUsage {
+	usages := []*DeprecatedUsage{}
+	if r == nil {
+		return usages
+	}
+
+	var walk func(comps datamodel.ComponentMap, keys []string, idPrefix string)
+	walk = func(comps datamodel.ComponentMap, keys []string, idPrefix string) {
+		ids := make([]string, 0, len(comps))
+		for id := range comps {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			comp := comps[id]
+			compKeys := append(slices.Clone(keys), id)
+
+			for _, d := range cs.Deprecations(comp.Type, comp.Task) {
+				if d.Input != "" && !hasInputField(comp.Input, d.Input) {
+					continue
+				}
+				usages = append(usages, &DeprecatedUsage{
+					ComponentID: idPrefix + id,
+					Type:        comp.Type,
+					Task:        comp.Task,
+					Input:       d.Input,
+					Message:     d.Message,
+					Replacement: d.Replacement,
+					keys:        compKeys,
+				})
+			}
+
+			walk(comp.Component, append(slices.Clone(compKeys), "component"), idPrefix+id+".")
+			walk(comp.OnError, append(slices.Clone(compKeys), "on-error"), idPrefix+id+".")
+		}
+	}
+	walk(r.Component, []string{"component"}, "")
+
+	return usages
+}
+
+func hasInputField(input any, field string) bool {
+	m, ok := input.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, ok = m[field]
+	return ok
+}
+
+// recipeEdit replaces the scalar of a YAML node.
+type recipeEdit struct {
+	node  *yaml.Node
+	value string
+}
+
+// migrateRecipe applies the replacements of the deprecated usages to a
+// recipe. The YAML document is edited in place, rather than marshalled
+// again, so the comments and the formatting of the recipe are kept.
+func migrateRecipe(rawRecipe string, usages []*DeprecatedUsage) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(rawRecipe), &doc); err != nil {
+		return "", fmt.Errorf("%w: invalid recipe: %w", errdomain.ErrInvalidArgument, err)
+	}
+
+	var edits []recipeEdit
+	for _, u := range usages {
+		if u.Replacement == nil {
+			continue
+		}
+
+		comp := lookupYAMLNode(&doc, u.keys)
+		if comp == nil {
+			return "", fmt.Errorf("component %s not found in the recipe", u.ComponentID)
+		}
+
+		if u.Replacement.Type != "" {
+			if n := yamlMappingValue(comp, "type"); n != nil {
+				edits = append(edits, recipeEdit{node: n, value: u.Replacement.Type})
+			}
+		}
+		if u.Replacement.Task != "" {
+			if n := yamlMappingValue(comp, "task"); n != nil {
+				edits = append(edits, recipeEdit{node: n, value: u.Replacement.Task})
+			}
+		}
+		input := yamlMappingValue(comp, "input")
+		for from, to := range u.Replacement.Input {
+			if n := yamlMappingKey(input, from); n != nil {
+				edits = append(edits, recipeEdit{node: n, value: to})
+			}
+		}
+	}
+
+	return applyRecipeEdits(rawRecipe, edits)
+}
+
+func applyRecipeEdits(rawRecipe string, edits []recipeEdit) (string, error) {
+	// Several usages can rewrite the same node.
+	seen := map[*yaml.Node]bool{}
+	unique := edits[:0]
+	for _, e := range edits {
+		if !seen[e.node] {
+			seen[e.node] = true
+			unique = append(unique, e)
+		}
+	}
+
+	// The edits of a line are applied from its end, so the columns of the
+	// rest of edits don't shift.
+	sort.Slice(unique, func(i, j int) bool {
+		if unique[i].node.Line != unique[j].node.Line {
+			return unique[i].node.Line < unique[j].node.Line
+		}
+		return unique[i].node.Column > unique[j].node.Column
+	})
+
+	lines := strings.Split(rawRecipe, "\n")
+	for _, e := range unique {
+		lineIdx, col := e.node.Line-1, e.node.Column-1
+		if lineIdx < 0 || lineIdx >= len(lines) || col < 0 || col > len(lines[lineIdx]) {
+			return "", fmt.Errorf("recipe field %s can't be rewritten", e.node.Value)
+		}
+
+		line := lines[lineIdx]
+		token := yamlScalarToken(e.node.Style, e.node.Value)
+		if !strings.HasPrefix(line[col:], token) {
+			return "", fmt.Errorf("recipe field %s can't be rewritten", e.node.Value)
+		}
+		lines[lineIdx] = line[:col] + yamlScalarToken(e.node.Style, e.value) + line[col+len(token):]
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func yamlScalarToken(style yaml.Style, value string) string {
+	switch {
+	case style&yaml.DoubleQuotedStyle != 0:
+		return `"` + value + `"`
+	case style&yaml.SingleQuotedStyle != 0:
+		return "'" + value + "'"
+	default:
+		return value
+	}
+}
+
+func lookupYAMLNode(doc *yaml.Node, keys []string) *yaml.Node {
+	n := doc
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	for _, k := range keys {
+		if n = yamlMappingValue(n, k); n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+func yamlMappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func yamlMappingKey(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i]
+		}
+	}
+	return nil
+}
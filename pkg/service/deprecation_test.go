@@ -0,0 +1,127 @@
+package service
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+
+	componentstore "github.com/instill-ai/pipeline-backend/pkg/component/store"
+)
+
+const deprecatedRecipe = `version: v1beta
+component:
+  # Chat with the legacy model.
+  chat:
+    type: legacy-ai
+    task: TASK_CHAT
+    input:
+      prompt: ${variable.prompt}
+      max-tokens: 10
+  loop:
+    type: iterator
+    range: ${variable.texts}
+    component:
+      summary:
+        type: "legacy-ai"
+        task: TASK_SUMMARIZE
+        input:
+          text: ${loop.element}
+  jq:
+    type: json
+    task: TASK_JQ
+    input:
+      json-string: ${variable.json}
+      jq-filter: .
+  jq-value:
+    type: json
+    task: TASK_JQ
+    input:
+      json-value: ${variable.json}
+      jq-filter: .
+`
+
+const migratedRecipe = `version: v1beta
+component:
+  # Chat with the legacy model.
+  chat:
+    type: universal-ai
+    task: TASK_CHAT
+    input:
+      prompt: ${variable.prompt}
+      max-output-tokens: 10
+  loop:
+    type: iterator
+    range: ${variable.texts}
+    component:
+      summary:
+        type: "universal-ai"
+        task: TASK_SUMMARIZE
+        input:
+          text: ${loop.element}
+  jq:
+    type: json
+    task: TASK_JQ
+    input:
+      json-string: ${variable.json}
+      jq-filter: .
+  jq-value:
+    type: json
+    task: TASK_JQ
+    input:
+      json-value: ${variable.json}
+      jq-filter: .
+`
+
+func TestDeprecatedUsages(t *testing.T) {
+	c := qt.New(t)
+
+	cs := &componentstore.Store{}
+	cs.Deprecate(
+		&componentstore.Deprecation{
+			Type:    "legacy-ai",
+			Message: "Use universal-ai instead.",
+			Replacement: &componentstore.Replacement{
+				Type:  "universal-ai",
+				Input: map[string]string{"max-tokens": "max-output-tokens"},
+			},
+		},
+		&componentstore.Deprecation{
+			Type:    "json",
+			Task:    "TASK_JQ",
+			Input:   "json-string",
+			Message: "Use json-value instead.",
+		},
+	)
+
+	r := new(datamodel.Recipe)
+	c.Assert(yaml.Unmarshal([]byte(deprecatedRecipe), r), qt.IsNil)
+
+	usages := findDeprecatedUsages(cs, r)
+	ids := make([]string, len(usages))
+	for i, u := range usages {
+		ids[i] = u.ComponentID
+	}
+	c.Check(ids, qt.DeepEquals, []string{"chat", "jq", "loop.summary"})
+	c.Check(usages[1].Input, qt.Equals, "json-string")
+	c.Check(usages[1].Replacement, qt.IsNil)
+
+	c.Run("ok - migrate", func(c *qt.C) {
+		migrated, err := migrateRecipe(deprecatedRecipe, usages)
+		c.Assert(err, qt.IsNil)
+		c.Check(migrated, qt.Equals, migratedRecipe)
+	})
+
+	c.Run("ok - nothing to migrate", func(c *qt.C) {
+		migrated, err := migrateRecipe(deprecatedRecipe, usages[1:2])
+		c.Assert(err, qt.IsNil)
+		c.Check(migrated, qt.Equals, deprecatedRecipe)
+	})
+
+	c.Run("ok - no recipe", func(c *qt.C) {
+		c.Check(findDeprecatedUsages(cs, nil), qt.HasLen, 0)
+	})
+}
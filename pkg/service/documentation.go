@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// PipelineDocumentation gathers the documentation of a pipeline (or of one
+// of its releases): its README and the annotations of its components. The
+// Markdown field renders all of them as a single document.
+type PipelineDocumentation struct {
+	PipelineID  string                    `json:"pipelineId"`
+	ReleaseID   string                    `json:"releaseId,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	Readme      string                    `json:"readme"`
+	Components  []*ComponentDocumentation `json:"components"`
+	Markdown    string                    `json:"markdown"`
+}
+
+// ComponentDocumentation holds the annotations of a recipe component. The
+// description is the YAML comment above the component and the note is the
+// `note` field of the component. The ID of a nested component (e.g. in an
+// iterator) is prefixed by the ID of its parent.
+type ComponentDocumentation struct {
+	ComponentID string `json:"componentId"`
+	Type        string `json:"type"`
+	Task        string `json:"task,omitempty"`
+	Description string `json:"description,omitempty"`
+	Note        string `json:"note,omitempty"`
+}
+
+// PipelineBundle is the self-contained export of a pipeline, so it can be
+// shared and imported elsewhere along with its documentation.
+type PipelineBundle struct {
+	PipelineID       string                 `json:"pipelineId"`
+	Description      string                 `json:"description,omitempty"`
+	RawRecipe        string                 `json:"rawRecipe"`
+	Readme           string                 `json:"readme"`
+	SourceURL        string                 `json:"sourceUrl,omitempty"`
+	DocumentationURL string                 `json:"documentationUrl,omitempty"`
+	License          string                 `json:"license,omitempty"`
+	Documentation    *PipelineDocumentation `json:"documentation"`
+	ExportTime       time.Time              `json:"exportTime"`
+}
+
+// GetPipelineDocumentation returns the README of a pipeline and the
+// annotations of the components of its recipe.
+func (s *service) GetPipelineDocumentation(ctx context.Context, namespaceID, pipelineID string) (*PipelineDocumentation, error) {
+	dbPipeline, err := s.getViewablePipeline(ctx, namespaceID, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPipelineDocumentation(dbPipeline.ID, "", dbPipeline.Description.String, dbPipeline.Readme, dbPipeline.Recipe), nil
+}
+
+// GetPipelineReleaseDocumentation returns the README of a pipeline release
+// and the annotations of the components of its recipe.
+func (s *service) GetPipelineReleaseDocumentation(ctx context.Context, namespaceID, pipelineID, releaseID string) (*PipelineDocumentation, error) {
+	dbPipeline, err := s.getViewablePipeline(ctx, namespaceID, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRelease, err := s.repository.GetNamespacePipelineReleaseByID(ctx, dbPipeline.Owner, dbPipeline.UID, releaseID, false)
+	if err != nil {
+		return nil, errdomain.ErrNotFound
+	}
+
+	return newPipelineDocumentation(dbPipeline.ID, dbRelease.ID, dbRelease.Description.String, dbRelease.Readme, dbRelease.Recipe), nil
+}
+
+// ExportPipeline bundles the recipe of a pipeline with its README, the
+// annotations of its components and its metadata.
+func (s *service) ExportPipeline(ctx context.Context, namespaceID, pipelineID string) (*PipelineBundle, error) {
+	dbPipeline, err := s.getViewablePipeline(ctx, namespaceID, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PipelineBundle{
+		PipelineID:       dbPipeline.ID,
+		Description:      dbPipeline.Description.String,
+		RawRecipe:        dbPipeline.RecipeYAML,
+		Readme:           dbPipeline.Readme,
+		SourceURL:        dbPipeline.SourceURL.String,
+		DocumentationURL: dbPipeline.DocumentationURL.String,
+		License:          dbPipeline.License.String,
+		Documentation:    newPipelineDocumentation(dbPipeline.ID, "", dbPipeline.Description.String, dbPipeline.Readme, dbPipeline.Recipe),
+		ExportTime:       time.Now().UTC(),
+	}, nil
+}
+
+func (s *service) getViewablePipeline(ctx context.Context, namespaceID, pipelineID string) (*datamodel.Pipeline, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	dbPipeline, err := s.repository.GetNamespacePipelineByID(ctx, ns.Permalink(), pipelineID, false, true)
+	if err != nil {
+		return nil, errdomain.ErrNotFound
+	}
+
+	if granted, err := s.aclClient.CheckPermission(ctx, "pipeline", dbPipeline.UID, "reader"); err != nil {
+		return nil, err
+	} else if !granted {
+		return nil, errdomain.ErrNotFound
+	}
+
+	return dbPipeline, nil
+}
+
+func newPipelineDocumentation(pipelineID, releaseID, description, readme string, r *datamodel.Recipe) *PipelineDocumentation {
+	doc := &PipelineDocumentation{
+		PipelineID:  pipelineID,
+		ReleaseID:   releaseID,
+		Description: description,
+		Readme:      readme,
+		Components:  collectComponentDocumentation(r),
+	}
+	doc.Markdown = renderDocumentation(doc)
+
+	return doc
+}
+
+// collectComponentDocumentation lists the components of a recipe, including
+// the nested ones, sorted by ID.
+func collectComponentDocumentation(r *datamodel.Recipe) []*ComponentDocumentation {
+	docs := []*ComponentDocumentation{}
+	if r == nil {
+		return docs
+	}
+
+	var walk func(comps datamodel.ComponentMap, idPrefix string)
+	walk = func(comps datamodel.ComponentMap, idPrefix string) {
+		ids := make([]string, 0, len(comps))
+		for id := range comps {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			comp := comps[id]
+			if comp == nil {
+				continue
+			}
+
+			docs = append(docs, &ComponentDocumentation{
+				ComponentID: idPrefix + id,
+				Type:        comp.Type,
+				Task:        comp.Task,
+				Description: strings.TrimSpace(comp.Description),
+				Note:        strings.TrimSpace(comp.Note),
+			})
+
+			walk(comp.Component, idPrefix+id+".")
+			walk(comp.OnError, idPrefix+id+".")
+		}
+	}
+	walk(r.Component, "")
+
+	return docs
+}
+
+// renderDocumentation renders the documentation of a pipeline as Markdown:
+// the README followed by a section per annotated component.
+func renderDocumentation(doc *PipelineDocumentation) string {
+	var b strings.Builder
+
+	if readme := strings.TrimSpace(doc.Readme); readme != "" {
+		b.WriteString(readme)
+		b.WriteString("\n")
+	} else {
+		fmt.Fprintf(&b, "# %s\n", doc.PipelineID)
+		if doc.Description != "" {
+			fmt.Fprintf(&b, "\n%s\n", doc.Description)
+		}
+	}
+
+	annotated := make([]*ComponentDocumentation, 0, len(doc.Components))
+	for _, c := range doc.Components {
+		if c.Description != "" || c.Note != "" {
+			annotated = append(annotated, c)
+		}
+	}
+	if len(annotated) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("\n## Components\n")
+	for _, c := range annotated {
+		fmt.Fprintf(&b, "\n### `%s`\n\n", c.ComponentID)
+
+		kind := c.Type
+		if c.Task != "" {
+			kind += " · " + c.Task
+		}
+		if kind != "" {
+			fmt.Fprintf(&b, "_%s_\n\n", kind)
+		}
+
+		if c.Description != "" {
+			b.WriteString(c.Description)
+			b.WriteString("\n")
+		}
+		if c.Note != "" {
+			if c.Description != "" {
+				b.WriteString("\n")
+			}
+			for _, line := range strings.Split(c.Note, "\n") {
+				b.WriteString(strings.TrimRight("> "+line, " "))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"gopkg.in/yaml.v3"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+)
+
+const documentedRecipe = `
+variable:
+  urls:
+    format: array:string
+component:
+  fetch:
+    type: web
+    task: TASK_SCRAPE_PAGES
+    input:
+      urls: ${variable.urls}
+  loop:
+    type: iterator
+    range: ${fetch.output.pages}
+    component:
+      # Summarizes each page.
+      summary:
+        type: universal-ai
+        task: TASK_CHAT
+        note: |-
+          Keep the prompt short,
+          the pages can be long.
+        input:
+          prompt: ${loop.element.content}
+`
+
+func TestPipelineDocumentation(t *testing.T) {
+	c := qt.New(t)
+
+	r := new(datamodel.Recipe)
+	c.Assert(yaml.Unmarshal([]byte(documentedRecipe), r), qt.IsNil)
+
+	c.Run("ok - components", func(c *qt.C) {
+		doc := newPipelineDocumentation("summarizer", "", "", "", r)
+		c.Check(doc.Components, qt.DeepEquals, []*ComponentDocumentation{
+			{ComponentID: "fetch", Type: "web", Task: "TASK_SCRAPE_PAGES"},
+			{ComponentID: "loop", Type: "iterator"},
+			{
+				ComponentID: "loop.summary",
+				Type:        "universal-ai",
+				Task:        "TASK_CHAT",
+				Description: "Summarizes each page.",
+				Note:        "Keep the prompt short,\nthe pages can be long.",
+			},
+		})
+	})
+
+	c.Run("ok - markdown with README", func(c *qt.C) {
+		doc := newPipelineDocumentation("summarizer", "v1.0.0", "", "# Summarizer\n\nSummarizes web pages.\n", r)
+		c.Check(doc.Markdown, qt.Equals, `# Summarizer
+
+Summarizes web pages.
+
+## Components
+
+### `+"`loop.summary`"+`
+
+_universal-ai · TASK_CHAT_
+
+Summarizes each page.
+
+> Keep the prompt short,
+> the pages can be long.
+`)
+	})
+
+	c.Run("ok - markdown without README", func(c *qt.C) {
+		doc := newPipelineDocumentation("summarizer", "", "Summarizes web pages.", "", nil)
+		c.Check(doc.Components, qt.HasLen, 0)
+		c.Check(doc.Markdown, qt.Equals, "# summarizer\n\nSummarizes web pages.\n")
+	})
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/eventsource"
+	"github.com/instill-ai/x/checkfield"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+	pipelinepb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+// EventSource is the API representation of an event source.
+type EventSource struct {
+	ID              string                   `json:"id"`
+	Type            string                   `json:"type"`
+	PipelineID      string                   `json:"pipelineId"`
+	ReleaseID       string                   `json:"releaseId,omitempty"`
+	Setup           map[string]any           `json:"setup"`
+	VariableMapping map[string]string        `json:"variableMapping,omitempty"`
+	MaxAttempts     int32                    `json:"maxAttempts,omitempty"`
+	Deduplication   *datamodel.Deduplication `json:"deduplication,omitempty"`
+	Checkpoint      map[string]any           `json:"checkpoint,omitempty"`
+	CreateTime      time.Time                `json:"createTime"`
+	UpdateTime      time.Time                `json:"updateTime"`
+}
+
+func (s *service) CreateNamespaceEventSource(ctx context.Context, namespaceID string, es *EventSource) (*EventSource, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching namespace: %w", err)
+	}
+
+	if err := s.checkNamespacePermission(ctx, ns); err != nil {
+		return nil, fmt.Errorf("checking namespace permissions: %w", err)
+	}
+
+	if err := checkfield.CheckResourceID(es.ID); err != nil {
+		return nil, fmt.Errorf("%w: %w", errdomain.ErrInvalidArgument, err)
+	}
+
+	sourceType := datamodel.EventSourceType(es.Type)
+	setup, err := json.Marshal(es.Setup)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling setup: %w", err)
+	}
+	if err := eventsource.ValidateSetup(sourceType, setup); err != nil {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("%w: %w", errdomain.ErrInvalidArgument, err),
+			fmt.Sprintf("Invalid event source setup: %s.", err),
+		)
+	}
+
+	if es.MaxAttempts < 0 {
+		return nil, fmt.Errorf("%w: max attempts must be positive", errdomain.ErrInvalidArgument)
+	}
+	if es.MaxAttempts == 0 {
+		es.MaxAttempts = eventsource.DefaultMaxAttempts
+	}
+
+	if err := eventsource.ValidateDeduplication(es.Deduplication); err != nil {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("%w: %w", errdomain.ErrInvalidArgument, err),
+			fmt.Sprintf("Invalid deduplication: %s.", err),
+		)
+	}
+	var dedup []byte
+	if es.Deduplication != nil {
+		if dedup, err = json.Marshal(es.Deduplication); err != nil {
+			return nil, fmt.Errorf("marshalling deduplication: %w", err)
+		}
+	}
+
+	dbPipeline, err := s.repository.GetNamespacePipelineByID(ctx, ns.Permalink(), es.PipelineID, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("fetching pipeline: %w", err)
+	}
+	if es.ReleaseID != "" {
+		if _, err := s.repository.GetNamespacePipelineReleaseByID(ctx, ns.Permalink(), dbPipeline.UID, es.ReleaseID, true); err != nil {
+			return nil, fmt.Errorf("fetching pipeline release: %w", err)
+		}
+	}
+
+	mapping, err := json.Marshal(es.VariableMapping)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling variable mapping: %w", err)
+	}
+
+	dbEventSource := &datamodel.EventSource{
+		ID:              es.ID,
+		NamespaceUID:    ns.NsUID,
+		PipelineUID:     dbPipeline.UID,
+		ReleaseID:       es.ReleaseID,
+		Type:            sourceType,
+		Setup:           setup,
+		VariableMapping: mapping,
+		MaxAttempts:     es.MaxAttempts,
+		Deduplication:   dedup,
+		Checkpoint:      []byte("{}"),
+	}
+	if err := s.repository.CreateNamespaceEventSource(ctx, dbEventSource); err != nil {
+		return nil, err
+	}
+
+	return s.convertEventSourceToAPI(dbEventSource, dbPipeline.ID)
+}
+
+func (s *service) ListNamespaceEventSources(ctx context.Context, namespaceID string) ([]*EventSource, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching namespace: %w", err)
+	}
+
+	if err := s.checkNamespacePermission(ctx, ns); err != nil {
+		return nil, fmt.Errorf("checking namespace permissions: %w", err)
+	}
+
+	dbEventSources, err := s.repository.ListNamespaceEventSources(ctx, ns.NsUID)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineIDs := map[uuid.UUID]string{}
+	eventSources := make([]*EventSource, 0, len(dbEventSources))
+	for _, dbEventSource := range dbEventSources {
+		pipelineID, ok := pipelineIDs[dbEventSource.PipelineUID]
+		if !ok {
+			dbPipeline, err := s.repository.GetPipelineByUID(ctx, dbEventSource.PipelineUID, true, false)
+			if err != nil {
+				return nil, fmt.Errorf("fetching pipeline: %w", err)
+			}
+			pipelineID = dbPipeline.ID
+			pipelineIDs[dbEventSource.PipelineUID] = pipelineID
+		}
+
+		es, err := s.convertEventSourceToAPI(dbEventSource, pipelineID)
+		if err != nil {
+			return nil, err
+		}
+		eventSources = append(eventSources, es)
+	}
+
+	return eventSources, nil
+}
+
+func (s *service) GetNamespaceEventSource(ctx context.Context, namespaceID, id string) (*EventSource, error) {
+	dbEventSource, err := s.getNamespaceEventSource(ctx, namespaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPipeline, err := s.repository.GetPipelineByUID(ctx, dbEventSource.PipelineUID, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("fetching pipeline: %w", err)
+	}
+
+	return s.convertEventSourceToAPI(dbEventSource, dbPipeline.ID)
+}
+
+func (s *service) DeleteNamespaceEventSource(ctx context.Context, namespaceID, id string) error {
+	dbEventSource, err := s.getNamespaceEventSource(ctx, namespaceID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteNamespaceEventSourceByID(ctx, dbEventSource.NamespaceUID, id); err != nil {
+		return err
+	}
+
+	return s.redisClient.Del(ctx, eventsource.DeadLetterKey(dbEventSource.UID), eventsource.EventSourceDedupKey(dbEventSource.UID), eventsource.ObjectClaimKey(dbEventSource.UID)).Err()
+}
+
+// ListEventSourceDeadLetters returns the messages that couldn't trigger the
+// pipeline of an event source, the most recent first.
+func (s *service) ListEventSourceDeadLetters(ctx context.Context, namespaceID, id string) ([]*eventsource.DeadLetter, error) {
+	dbEventSource, err := s.getNamespaceEventSource(ctx, namespaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.redisClient.LRange(ctx, eventsource.DeadLetterKey(dbEventSource.UID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("fetching dead letters: %w", err)
+	}
+
+	deadLetters := make([]*eventsource.DeadLetter, 0, len(entries))
+	for _, e := range entries {
+		dl := new(eventsource.DeadLetter)
+		if err := json.Unmarshal([]byte(e), dl); err != nil {
+			return nil, fmt.Errorf("unmarshalling dead letter: %w", err)
+		}
+		deadLetters = append(deadLetters, dl)
+	}
+
+	return deadLetters, nil
+}
+
+// TriggerEventSource triggers asynchronously the pipeline of an event source.
+// It is called by the event source dispatcher.
+func (s *service) TriggerEventSource(ctx context.Context, es *datamodel.EventSource, variables *structpb.Struct) error {
+	ctx, ns, err := s.impersonateNamespace(ctx, es.NamespaceUID)
+	if err != nil {
+		return err
+	}
+
+	dbPipeline, err := s.repository.GetPipelineByUID(ctx, es.PipelineUID, false, true)
+	if err != nil {
+		return fmt.Errorf("fetching pipeline: %w", err)
+	}
+
+	recipe := dbPipeline.Recipe
+	releaseID, releaseUID := "", uuid.Nil
+	logReleaseID := defaultPipelineReleaseID
+	if es.ReleaseID != "" {
+		dbPipelineRelease, err := s.repository.GetNamespacePipelineReleaseByID(ctx, ns.Permalink(), dbPipeline.UID, es.ReleaseID, false)
+		if err != nil {
+			return fmt.Errorf("fetching pipeline release: %w", err)
+		}
+		recipe = dbPipelineRelease.Recipe
+		releaseID, releaseUID = dbPipelineRelease.ID, dbPipelineRelease.UID
+		logReleaseID = releaseID
+	}
+
+	pipelineTriggerID, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	pipelineRun := s.logPipelineRunStart(ctx, pipelineTriggerID.String(), dbPipeline.UID, logReleaseID)
+	defer func() {
+		if err != nil {
+			s.logPipelineRunError(ctx, pipelineTriggerID.String(), err, pipelineRun.StartedTime)
+		}
+	}()
+
+	data := []*pipelinepb.TriggerData{{Variable: variables}}
+	_, err = s.triggerAsyncPipeline(ctx, ns, recipe, dbPipeline.ID, dbPipeline.UID, releaseID, releaseUID, data, pipelineTriggerID.String(), false)
+	return err
+}
+
+func (s *service) getNamespaceEventSource(ctx context.Context, namespaceID, id string) (*datamodel.EventSource, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching namespace: %w", err)
+	}
+
+	if err := s.checkNamespacePermission(ctx, ns); err != nil {
+		return nil, fmt.Errorf("checking namespace permissions: %w", err)
+	}
+
+	return s.repository.GetNamespaceEventSourceByID(ctx, ns.NsUID, id)
+}
+
+func (s *service) convertEventSourceToAPI(dbEventSource *datamodel.EventSource, pipelineID string) (*EventSource, error) {
+	es := &EventSource{
+		ID:          dbEventSource.ID,
+		Type:        string(dbEventSource.Type),
+		PipelineID:  pipelineID,
+		ReleaseID:   dbEventSource.ReleaseID,
+		MaxAttempts: dbEventSource.MaxAttempts,
+		CreateTime:  dbEventSource.CreateTime,
+		UpdateTime:  dbEventSource.UpdateTime,
+	}
+
+	setup := map[string]any{}
+	if err := json.Unmarshal(dbEventSource.Setup, &setup); err != nil {
+		return nil, fmt.Errorf("unmarshalling setup: %w", err)
+	}
+	es.Setup = eventsource.RedactSetup(setup)
+
+	if len(dbEventSource.VariableMapping) > 0 {
+		if err := json.Unmarshal(dbEventSource.VariableMapping, &es.VariableMapping); err != nil {
+			return nil, fmt.Errorf("unmarshalling variable mapping: %w", err)
+		}
+	}
+	if len(dbEventSource.Deduplication) > 0 {
+		if err := json.Unmarshal(dbEventSource.Deduplication, &es.Deduplication); err != nil {
+			return nil, fmt.Errorf("unmarshalling deduplication: %w", err)
+		}
+	}
+	if len(dbEventSource.Checkpoint) > 0 {
+		if err := json.Unmarshal(dbEventSource.Checkpoint, &es.Checkpoint); err != nil {
+			return nil, fmt.Errorf("unmarshalling checkpoint: %w", err)
+		}
+	}
+
+	return es, nil
+}
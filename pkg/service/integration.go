@@ -588,6 +588,10 @@ func (s *service) DeleteNamespaceConnection(ctx context.Context, namespaceID, id
 		return fmt.Errorf("checking namespace permissions: %w", err)
 	}
 
+	if err := s.checkDependents(ctx, ns, DependencyConnection, id); err != nil {
+		return err
+	}
+
 	return s.repository.DeleteNamespaceConnectionByID(ctx, ns.NsUID, id)
 
 }
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/instill-ai/pipeline-backend/pkg/constant"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+	"github.com/instill-ai/pipeline-backend/pkg/resource"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+)
+
+// The lineage of a run links the fields each component consumed to the
+// fields of the upstream components (or of the pipeline variables) they
+// were resolved from. It's derived from the recipe the run was executed
+// with, so it reflects the run even if the pipeline was edited afterwards,
+// and it only holds the components that ran.
+
+// Nodes of the lineage graph that aren't components.
+const (
+	LineageNodeVariable = "variable"
+	LineageNodeOutput   = "output"
+)
+
+// lineageRefPattern matches the references of a template.
+var lineageRefPattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// LineageField is a field of a node of the lineage graph, i.e. a pipeline
+// variable, a component or the pipeline output. The field is the path of the
+// value in the node, e.g. output.texts[0] or input.prompt.
+type LineageField struct {
+	Node  string `json:"node"`
+	Field string `json:"field"`
+}
+
+// LineageEdge records that a field was consumed from an upstream field.
+type LineageEdge struct {
+	From LineageField `json:"from"`
+	To   LineageField `json:"to"`
+}
+
+// RunLineage is the lineage graph of a pipeline run.
+type RunLineage struct {
+	PipelineRunUID string         `json:"pipelineRunUid"`
+	Edges          []*LineageEdge `json:"edges"`
+}
+
+// GetPipelineRunLineage returns the lineage graph of a pipeline run.
+func (s *service) GetPipelineRunLineage(ctx context.Context, namespaceID, pipelineID, pipelineRunID string) (*RunLineage, error) {
+	_, _, run, r, err := s.loadRunLineageRecipe(ctx, namespaceID, pipelineID, pipelineRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RunLineage{
+		PipelineRunUID: run.PipelineTriggerUID.String(),
+		Edges:          runLineageEdges(r, run),
+	}, nil
+}
+
+// GetPipelineRunOpenLineage returns the lineage graph of a pipeline run as
+// an OpenLineage run event, so it can be ingested by the data catalogs that
+// implement the standard.
+func (s *service) GetPipelineRunOpenLineage(ctx context.Context, namespaceID, pipelineID, pipelineRunID string) (*OpenLineageRunEvent, error) {
+	ns, dbPipeline, run, r, err := s.loadRunLineageRecipe(ctx, namespaceID, pipelineID, pipelineRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOpenLineage(ns.NsID, dbPipeline.ID, run, runLineageEdges(r, run)), nil
+}
+
+func (s *service) loadRunLineageRecipe(ctx context.Context, namespaceID, pipelineID, pipelineRunID string) (ns resource.Namespace, dbPipeline *datamodel.Pipeline, run *datamodel.PipelineRun, r *datamodel.Recipe, err error) {
+	if run, err = s.getViewablePipelineRun(ctx, namespaceID, pipelineID, pipelineRunID); err != nil {
+		return ns, nil, nil, nil, err
+	}
+
+	if ns, err = s.GetRscNamespace(ctx, namespaceID); err != nil {
+		return ns, nil, nil, nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+	if dbPipeline, err = s.repository.GetNamespacePipelineByID(ctx, ns.Permalink(), pipelineID, false, true); err != nil {
+		return ns, nil, nil, nil, errdomain.ErrNotFound
+	}
+
+	if r, _, _, err = s.loadPipelineRunRecipe(ctx, ns, dbPipeline, run); err != nil {
+		return ns, nil, nil, nil, err
+	}
+	return ns, dbPipeline, run, r, nil
+}
+
+// runLineageEdges collects the references of the components that ran and of
+// the pipeline output. The components nested in an iterator ran if their
+// iterator did. A run without component records (e.g. a run whose logs were
+// purged) keeps every edge. The edges are sorted by their consumer.
+func runLineageEdges(r *datamodel.Recipe, run *datamodel.PipelineRun) []*LineageEdge {
+	edges := []*LineageEdge{}
+	if r == nil {
+		return edges
+	}
+
+	ran := map[string]bool{}
+	for _, c := range run.Components {
+		ran[c.ComponentID] = true
+	}
+
+	// owners maps each component to the top-level component whose run
+	// records its execution.
+	owners := map[string]string{}
+	for id, comp := range r.Component.WithErrorBranches() {
+		owners[id] = id
+		for nestedID := range comp.Component {
+			owners[nestedID] = id
+		}
+	}
+	hasRun := func(node string) bool {
+		if node == LineageNodeVariable || node == LineageNodeOutput {
+			return true
+		}
+		owner, ok := owners[node]
+		return ok && (len(ran) == 0 || ran[owner])
+	}
+
+	addRefs := func(consumer, field, template string) {
+		if !hasRun(consumer) {
+			return
+		}
+		for _, m := range lineageRefPattern.FindAllStringSubmatch(template, -1) {
+			for _, path := range recipe.ReferencePaths(m[1]) {
+				node, upstreamField, _ := strings.Cut(path, ".")
+				if node == constant.SegSecret || node == constant.SegConnection || !hasRun(node) {
+					continue
+				}
+				edges = append(edges, &LineageEdge{
+					From: LineageField{Node: node, Field: upstreamField},
+					To:   LineageField{Node: consumer, Field: field},
+				})
+			}
+		}
+	}
+
+	var walkValue func(consumer string, v any, field string)
+	walkValue = func(consumer string, v any, field string) {
+		switch v := v.(type) {
+		case string:
+			addRefs(consumer, field, v)
+		case map[string]any:
+			for k, item := range v {
+				walkValue(consumer, item, field+"."+k)
+			}
+		case []any:
+			for i, item := range v {
+				walkValue(consumer, item, field+"["+strconv.Itoa(i)+"]")
+			}
+		}
+	}
+
+	var walk func(comps datamodel.ComponentMap)
+	walk = func(comps datamodel.ComponentMap) {
+		for id, comp := range comps {
+			walkValue(id, comp.Input, "input")
+			addRefs(id, "condition", comp.Condition)
+			walk(comp.Component)
+			walk(comp.OnError)
+		}
+	}
+	walk(r.Component)
+
+	for id, o := range r.Output {
+		if o != nil {
+			addRefs(LineageNodeOutput, id, o.Value)
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.To != b.To {
+			return a.To.Node < b.To.Node || (a.To.Node == b.To.Node && a.To.Field < b.To.Field)
+		}
+		if a.From.Node != b.From.Node {
+			return a.From.Node < b.From.Node
+		}
+		return a.From.Field < b.From.Field
+	})
+	return edges
+}
+
+// The OpenLineage specification describes a run as the job that reads input
+// datasets and writes output datasets. A pipeline run is a run of the
+// pipeline job that reads its variables and writes the data of each
+// component and its output. The lineage of the fields is described by the
+// column lineage facet of the datasets it writes.
+const (
+	openLineageProducer            = "https://github.com/instill-ai/pipeline-backend"
+	openLineageRunEventSchemaURL   = "https://openlineage.io/spec/2-0-2/OpenLineage.json#/$defs/RunEvent"
+	openLineageColumnLineageSchema = "https://openlineage.io/spec/facets/1-2-0/ColumnLineageDatasetFacet.json#/$defs/ColumnLineageDatasetFacet"
+)
+
+// OpenLineageRunEvent is an OpenLineage run event.
+type OpenLineageRunEvent struct {
+	EventType string                `json:"eventType"`
+	EventTime time.Time             `json:"eventTime"`
+	Producer  string                `json:"producer"`
+	SchemaURL string                `json:"schemaURL"`
+	Run       OpenLineageRun        `json:"run"`
+	Job       OpenLineageJob        `json:"job"`
+	Inputs    []*OpenLineageDataset `json:"inputs"`
+	Outputs   []*OpenLineageDataset `json:"outputs"`
+}
+
+// OpenLineageRun identifies the run of a job.
+type OpenLineageRun struct {
+	RunID string `json:"runId"`
+}
+
+// OpenLineageJob identifies a job, i.e. a pipeline.
+type OpenLineageJob struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// OpenLineageDataset is a dataset a run reads or writes.
+type OpenLineageDataset struct {
+	Namespace string                    `json:"namespace"`
+	Name      string                    `json:"name"`
+	Facets    *OpenLineageDatasetFacets `json:"facets,omitempty"`
+}
+
+// OpenLineageDatasetFacets are the facets of a dataset.
+type OpenLineageDatasetFacets struct {
+	ColumnLineage *OpenLineageColumnLineage `json:"columnLineage,omitempty"`
+}
+
+// OpenLineageColumnLineage maps each field of a dataset to the fields it was
+// derived from.
+type OpenLineageColumnLineage struct {
+	Producer  string                                    `json:"_producer"`
+	SchemaURL string                                    `json:"_schemaURL"`
+	Fields    map[string]*OpenLineageColumnLineageField `json:"fields"`
+}
+
+// OpenLineageColumnLineageField lists the fields a field was derived from.
+type OpenLineageColumnLineageField struct {
+	InputFields []*OpenLineageInputField `json:"inputFields"`
+}
+
+// OpenLineageInputField is a field of a dataset.
+type OpenLineageInputField struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Field     string `json:"field"`
+}
+
+// openLineageEventType maps the status of a run to the OpenLineage event
+// type.
+func openLineageEventType(status datamodel.RunStatus) string {
+	switch runpb.RunStatus(status) {
+	case runpb.RunStatus_RUN_STATUS_COMPLETED:
+		return "COMPLETE"
+	case runpb.RunStatus_RUN_STATUS_FAILED:
+		return "FAIL"
+	case runpb.RunStatus_RUN_STATUS_QUEUED:
+		return "START"
+	default:
+		return "RUNNING"
+	}
+}
+
+// toOpenLineage converts the lineage graph of a run into an OpenLineage run
+// event. The datasets are named after the pipeline and the node, e.g.
+// my-pipeline.variable or my-pipeline.chat, in the namespace of the
+// pipeline.
+func toOpenLineage(namespaceID, pipelineID string, run *datamodel.PipelineRun, edges []*LineageEdge) *OpenLineageRunEvent {
+	eventTime := run.StartedTime
+	if run.CompletedTime.Valid {
+		eventTime = run.CompletedTime.Time
+	}
+
+	datasetName := func(node string) string {
+		return pipelineID + "." + node
+	}
+
+	outputs := map[string]*OpenLineageDataset{}
+	consumesVariables := false
+	for _, e := range edges {
+		if e.From.Node == LineageNodeVariable {
+			consumesVariables = true
+		}
+
+		ds, ok := outputs[e.To.Node]
+		if !ok {
+			ds = &OpenLineageDataset{
+				Namespace: namespaceID,
+				Name:      datasetName(e.To.Node),
+				Facets: &OpenLineageDatasetFacets{ColumnLineage: &OpenLineageColumnLineage{
+					Producer:  openLineageProducer,
+					SchemaURL: openLineageColumnLineageSchema,
+					Fields:    map[string]*OpenLineageColumnLineageField{},
+				}},
+			}
+			outputs[e.To.Node] = ds
+		}
+
+		fields := ds.Facets.ColumnLineage.Fields
+		if fields[e.To.Field] == nil {
+			fields[e.To.Field] = &OpenLineageColumnLineageField{InputFields: []*OpenLineageInputField{}}
+		}
+		fields[e.To.Field].InputFields = append(fields[e.To.Field].InputFields, &OpenLineageInputField{
+			Namespace: namespaceID,
+			Name:      datasetName(e.From.Node),
+			Field:     e.From.Field,
+		})
+	}
+
+	event := &OpenLineageRunEvent{
+		EventType: openLineageEventType(run.Status),
+		EventTime: eventTime,
+		Producer:  openLineageProducer,
+		SchemaURL: openLineageRunEventSchemaURL,
+		Run:       OpenLineageRun{RunID: run.PipelineTriggerUID.String()},
+		Job:       OpenLineageJob{Namespace: namespaceID, Name: pipelineID},
+		Inputs:    []*OpenLineageDataset{},
+		Outputs:   make([]*OpenLineageDataset, 0, len(outputs)),
+	}
+	if consumesVariables {
+		event.Inputs = append(event.Inputs, &OpenLineageDataset{Namespace: namespaceID, Name: datasetName(LineageNodeVariable)})
+	}
+
+	nodes := make([]string, 0, len(outputs))
+	for node := range outputs {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		event.Outputs = append(event.Outputs, outputs[node])
+	}
+
+	return event
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+)
+
+func TestRunLineageEdges(t *testing.T) {
+	c := qt.New(t)
+
+	r := &datamodel.Recipe{
+		Component: datamodel.ComponentMap{
+			"fetch": {
+				Type:  "http",
+				Input: map[string]any{"endpoint-url": "${variable.url}", "header": map[string]any{"authorization": "${secret.token}"}},
+			},
+			"chat": {
+				Type: "openai",
+				Input: map[string]any{
+					"prompt": "Summarize ${fetch.output.body | truncate 100}",
+					"images": []any{"${variable.image}"},
+				},
+			},
+			"notify": {
+				Type:  "slack",
+				Input: map[string]any{"message": "${chat.output.texts[0]}"},
+			},
+		},
+		Output: map[string]*datamodel.Output{
+			"summary": {Value: "${chat.output.texts[0]}"},
+		},
+	}
+	run := &datamodel.PipelineRun{
+		PipelineTriggerUID: uuid.Must(uuid.NewV4()),
+		Status:             datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_COMPLETED),
+		Components: []datamodel.ComponentRun{
+			{ComponentID: "fetch"},
+			{ComponentID: "chat"},
+		},
+	}
+
+	edges := runLineageEdges(r, run)
+	c.Assert(edges, qt.DeepEquals, []*LineageEdge{
+		{From: LineageField{Node: "variable", Field: "image"}, To: LineageField{Node: "chat", Field: "input.images[0]"}},
+		{From: LineageField{Node: "fetch", Field: "output.body"}, To: LineageField{Node: "chat", Field: "input.prompt"}},
+		{From: LineageField{Node: "variable", Field: "url"}, To: LineageField{Node: "fetch", Field: "input.endpoint-url"}},
+		{From: LineageField{Node: "chat", Field: "output.texts[0]"}, To: LineageField{Node: "output", Field: "summary"}},
+	})
+
+	event := toOpenLineage("acme", "summarizer", run, edges)
+	c.Check(event.EventType, qt.Equals, "COMPLETE")
+	c.Check(event.Run.RunID, qt.Equals, run.PipelineTriggerUID.String())
+	c.Check(event.Job, qt.Equals, OpenLineageJob{Namespace: "acme", Name: "summarizer"})
+	c.Check(event.Inputs, qt.HasLen, 1)
+	c.Check(event.Inputs[0].Name, qt.Equals, "summarizer.variable")
+
+	c.Assert(event.Outputs, qt.HasLen, 3)
+	c.Check(event.Outputs[0].Name, qt.Equals, "summarizer.chat")
+	c.Check(event.Outputs[2].Name, qt.Equals, "summarizer.output")
+	c.Check(event.Outputs[2].Facets.ColumnLineage.Fields["summary"].InputFields, qt.DeepEquals, []*OpenLineageInputField{
+		{Namespace: "acme", Name: "summarizer.chat", Field: "output.texts[0]"},
+	})
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 	"github.com/gofrs/uuid"
@@ -12,12 +14,17 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/instill-ai/pipeline-backend/config"
 	"github.com/instill-ai/pipeline-backend/pkg/acl"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/eventsource"
 	"github.com/instill-ai/pipeline-backend/pkg/logger"
 	"github.com/instill-ai/pipeline-backend/pkg/memory"
 	"github.com/instill-ai/pipeline-backend/pkg/minio"
 	"github.com/instill-ai/pipeline-backend/pkg/repository"
 	"github.com/instill-ai/pipeline-backend/pkg/resource"
+	"github.com/instill-ai/pipeline-backend/pkg/session"
+	"github.com/instill-ai/pipeline-backend/pkg/worker"
 
 	componentstore "github.com/instill-ai/pipeline-backend/pkg/component/store"
 	mgmtpb "github.com/instill-ai/protogen-go/core/mgmt/v1beta"
@@ -66,6 +73,7 @@ type Service interface {
 	TriggerNamespacePipelineReleaseByID(ctx context.Context, ns resource.Namespace, pipelineUID uuid.UUID, id string, data []*pb.TriggerData, pipelineTriggerID string, returnTraces bool) ([]*structpb.Struct, *pb.TriggerMetadata, error)
 	TriggerAsyncNamespacePipelineReleaseByID(ctx context.Context, ns resource.Namespace, pipelineUID uuid.UUID, id string, data []*pb.TriggerData, pipelineTriggerID string, returnTraces bool) (*longrunningpb.Operation, error)
 	GetOperation(ctx context.Context, workflowID string) (*longrunningpb.Operation, error)
+	WaitForTriggerResult(_ context.Context, operationID string, timeout time.Duration) (*longrunningpb.Operation, error)
 
 	GetCtxUserNamespace(ctx context.Context) (resource.Namespace, error)
 	GetRscNamespace(ctx context.Context, namespaceID string) (resource.Namespace, error)
@@ -88,6 +96,56 @@ type Service interface {
 	GetNamespaceConnection(context.Context, *pb.GetNamespaceConnectionRequest) (*pb.Connection, error)
 	ListNamespaceConnections(context.Context, *pb.ListNamespaceConnectionsRequest) (*pb.ListNamespaceConnectionsResponse, error)
 	ListPipelineIDsByConnectionID(context.Context, *pb.ListPipelineIDsByConnectionIDRequest) (*pb.ListPipelineIDsByConnectionIDResponse, error)
+	GetConnectionConcurrency(_ context.Context, namespaceID, connectionID string) (*ConnectionConcurrency, error)
+	UpdateConnectionConcurrency(_ context.Context, namespaceID, connectionID string, _ *ConnectionConcurrency) (*ConnectionConcurrency, error)
+
+	CreateNamespaceEventSource(_ context.Context, namespaceID string, _ *EventSource) (*EventSource, error)
+	ListNamespaceEventSources(_ context.Context, namespaceID string) ([]*EventSource, error)
+	GetNamespaceEventSource(_ context.Context, namespaceID, id string) (*EventSource, error)
+	DeleteNamespaceEventSource(_ context.Context, namespaceID, id string) error
+	ListEventSourceDeadLetters(_ context.Context, namespaceID, id string) ([]*eventsource.DeadLetter, error)
+	TriggerEventSource(context.Context, *datamodel.EventSource, *structpb.Struct) error
+
+	CreateNamespacePrompt(_ context.Context, namespaceID string, _ *Prompt) (*Prompt, error)
+	ListNamespacePrompts(_ context.Context, namespaceID string) ([]*Prompt, error)
+	GetNamespacePrompt(_ context.Context, namespaceID, id string, version int32) (*Prompt, error)
+	ListNamespacePromptVersions(_ context.Context, namespaceID, id string) ([]*Prompt, error)
+	DeleteNamespacePrompt(_ context.Context, namespaceID, id string) error
+
+	GetNamespaceSettings(_ context.Context, namespaceID string) (*NamespaceSettings, error)
+	UpdateNamespaceSettings(_ context.Context, namespaceID string, _ *NamespaceSettings) (*NamespaceSettings, error)
+
+	AppendSessionMessages(_ context.Context, namespaceID, sessionID string, _ []session.Message) error
+	ListSessionMessages(_ context.Context, namespaceID, sessionID string, limit int) ([]session.Message, error)
+	DeleteSession(_ context.Context, namespaceID, sessionID string) error
+
+	RetryPipelineTrigger(_ context.Context, namespaceID, pipelineID, pipelineRunID string) (*longrunningpb.Operation, error)
+	ApproveRun(_ context.Context, namespaceID, pipelineID, pipelineRunID string, _ *RunReview) error
+	RejectRun(_ context.Context, namespaceID, pipelineID, pipelineRunID string, _ *RunReview) error
+	ListRunArtifacts(_ context.Context, namespaceID, pipelineID, pipelineRunID string) ([]*RunArtifact, error)
+	GetRunArtifact(_ context.Context, namespaceID, pipelineID, pipelineRunID, artifactUID string) (*RunArtifact, error)
+	GetPipelineRunState(_ context.Context, namespaceID, pipelineID, pipelineRunID string) (*PipelineRunState, error)
+	GetPipelineRunMemory(_ context.Context, namespaceID, pipelineID, pipelineRunID string) (*memory.MemoryTree, error)
+	GetTriggerMemorySnapshot(_ context.Context, namespaceID, pipelineID, pipelineRunID, path string) (*memory.MemorySnapshot, error)
+	ExportPipelineRunMemory(_ context.Context, namespaceID, pipelineID, pipelineRunID string) (*RunArtifact, error)
+	CompareRuns(_ context.Context, namespaceID, pipelineID, runAID, runBID string) (*RunComparison, error)
+	GetPipelineRunLineage(_ context.Context, namespaceID, pipelineID, pipelineRunID string) (*RunLineage, error)
+	GetPipelineRunOpenLineage(_ context.Context, namespaceID, pipelineID, pipelineRunID string) (*OpenLineageRunEvent, error)
+	ListDeprecatedPipelines(_ context.Context, namespaceID string) ([]*PipelineDeprecations, error)
+	GetPipelineDeprecations(_ context.Context, namespaceID, pipelineID string) (*PipelineDeprecations, error)
+	MigratePipelineRecipe(_ context.Context, namespaceID, pipelineID string) (*PipelineDeprecations, error)
+	ListPipelineDependencies(_ context.Context, namespaceID, pipelineID string) ([]*Dependency, error)
+	GetDependencyImpact(_ context.Context, namespaceID, kind, id string) (*DependencyImpact, error)
+	GetPipelineDocumentation(_ context.Context, namespaceID, pipelineID string) (*PipelineDocumentation, error)
+	GetPipelineReleaseDocumentation(_ context.Context, namespaceID, pipelineID, releaseID string) (*PipelineDocumentation, error)
+	ExportPipeline(_ context.Context, namespaceID, pipelineID string) (*PipelineBundle, error)
+	FailoverTemporalCluster(cluster worker.TemporalCluster)
+
+	PreviewSchedule(_ context.Context, _ *SchedulePreview, count int) (*SchedulePreview, error)
+	GetPipelineScheduleHistory(_ context.Context, namespaceID, pipelineID string, pageSize int) (*PipelineScheduleHistory, error)
+
+	ListPipelineCanaries(_ context.Context, namespaceID, pipelineID string) ([]*CanaryStatus, error)
+	RunCanaryScheduler(_ context.Context, interval time.Duration)
 }
 
 // TriggerResult defines a new type to encapsulate the stream data
@@ -99,15 +157,22 @@ type TriggerResult struct {
 type service struct {
 	repository               repository.Repository
 	redisClient              *redis.Client
-	temporalClient           client.Client
 	component                *componentstore.Store
 	mgmtPrivateServiceClient mgmtpb.MgmtPrivateServiceClient
 	aclClient                acl.ACLClientInterface
 	converter                Converter
 	minioClient              minio.MinioI
 	memory                   memory.MemoryStore
+	sessions                 session.Store
 	log                      *zap.Logger
 	workerUID                uuid.UUID
+
+	// temporalMu protects the Temporal clients, as the cluster where new
+	// runs are dispatched changes on failover.
+	temporalMu       sync.RWMutex
+	temporalClient   client.Client
+	temporalCluster  string
+	temporalClusters map[string]client.Client
 }
 
 // NewService initiates a service instance
@@ -121,6 +186,7 @@ func NewService(
 	minioClient minio.MinioI,
 	cs *componentstore.Store,
 	memory memory.MemoryStore,
+	sessions session.Store,
 	workerUID uuid.UUID,
 ) Service {
 	zapLogger, _ := logger.GetZapLogger(context.Background())
@@ -135,7 +201,10 @@ func NewService(
 		converter:                c,
 		minioClient:              minioClient,
 		memory:                   memory,
+		sessions:                 sessions,
 		log:                      zapLogger,
 		workerUID:                workerUID,
+		temporalCluster:          config.Config.Temporal.ClusterName,
+		temporalClusters:         map[string]client.Client{config.Config.Temporal.ClusterName: t},
 	}
 }
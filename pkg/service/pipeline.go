@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -34,7 +35,9 @@ import (
 	"github.com/instill-ai/pipeline-backend/pkg/constant"
 	"github.com/instill-ai/pipeline-backend/pkg/data"
 	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/eventsource"
 	"github.com/instill-ai/pipeline-backend/pkg/logger"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
 	"github.com/instill-ai/pipeline-backend/pkg/recipe"
 	"github.com/instill-ai/pipeline-backend/pkg/resource"
 	"github.com/instill-ai/pipeline-backend/pkg/utils"
@@ -159,6 +162,9 @@ func (s *service) CreateNamespacePipeline(ctx context.Context, ns resource.Names
 		if err := s.checkSecret(ctx, dbPipeline.Recipe.Component); err != nil {
 			return nil, fmt.Errorf("checking referenced secrets: %w", err)
 		}
+		if err := s.checkComponentPolicy(ctx, ns.NsUID, dbPipeline.Recipe); err != nil {
+			return nil, err
+		}
 	}
 
 	dbPipeline.ShareCode = generateShareCode()
@@ -169,6 +175,10 @@ func (s *service) CreateNamespacePipeline(ctx context.Context, ns resource.Names
 	if err := s.repository.CreateNamespacePipeline(ctx, dbPipeline); err != nil {
 		return nil, err
 	}
+	if err := s.syncPipelineCanaries(ctx, dbPipeline.UID, dbPipeline.Recipe); err != nil {
+		return nil, err
+	}
+	s.reportRecipeTypeWarnings(ctx, dbPipeline.Recipe)
 
 	dbCreatedPipeline, err := s.repository.GetNamespacePipelineByID(ctx, ownerPermalink, dbPipeline.ID, false, true)
 	if err != nil {
@@ -317,25 +327,43 @@ func (s *service) GetPipelineByUIDAdmin(ctx context.Context, uid uuid.UUID, view
 
 }
 
-func (s *service) setSchedulePipeline(ctx context.Context, ns resource.Namespace, pipelineID, pipelineReleaseID string, pipelineUID, releaseUID uuid.UUID, recipe *datamodel.Recipe) error {
+// scheduleOverlapPolicies maps the overlap policies of a recipe schedule to
+// the Temporal ones. Scheduled runs are skipped by default.
+var scheduleOverlapPolicies = map[string]enums.ScheduleOverlapPolicy{
+	"":                                      enums.SCHEDULE_OVERLAP_POLICY_SKIP,
+	datamodel.ScheduleOverlapSkip:           enums.SCHEDULE_OVERLAP_POLICY_SKIP,
+	datamodel.ScheduleOverlapQueue:          enums.SCHEDULE_OVERLAP_POLICY_BUFFER_ALL,
+	datamodel.ScheduleOverlapCancelPrevious: enums.SCHEDULE_OVERLAP_POLICY_CANCEL_OTHER,
+}
+
+func (s *service) setSchedulePipeline(ctx context.Context, ns resource.Namespace, pipelineID, pipelineReleaseID string, pipelineUID, releaseUID uuid.UUID, dbRecipe *datamodel.Recipe) error {
 	// TODO This check could be removed, as the receiver should be initialized
 	// at this point. However, some tests depend on it, so we would need to
 	// either mock this interface or (better) communicate with Temporal through
 	// our own interface.
-	if s.temporalClient == nil {
+	if s.temporal() == nil {
 		return nil
 	}
 
 	crons := []string{}
-	if recipe != nil && recipe.On != nil && recipe.On.Schedule != nil {
-		for _, v := range recipe.On.Schedule {
-			crons = append(crons, v.Cron)
+	var overlap, catchupWindow string
+	if dbRecipe != nil && dbRecipe.On != nil && dbRecipe.On.Schedule != nil {
+		for _, v := range dbRecipe.On.Schedule {
+			crons = append(crons, recipe.TemporalCronExpression(v))
+		}
+
+		var err error
+		if overlap, catchupWindow, err = recipe.SchedulePolicy(dbRecipe.On.Schedule); err != nil {
+			return errmsg.AddMessage(
+				fmt.Errorf("%w: %w", errdomain.ErrInvalidArgument, err),
+				fmt.Sprintf("Invalid schedule: %s.", err),
+			)
 		}
 	}
 
-	scheduleID := fmt.Sprintf("%s_%s_schedule", pipelineUID, releaseUID)
+	scheduleID := recipe.ScheduleID(pipelineUID, releaseUID)
 
-	handle := s.temporalClient.ScheduleClient().GetHandle(ctx, scheduleID)
+	handle := s.temporal().ScheduleClient().GetHandle(ctx, scheduleID)
 	_ = handle.Delete(ctx)
 
 	if len(crons) > 0 {
@@ -347,11 +375,12 @@ func (s *service) setSchedulePipeline(ctx context.Context, ns resource.Namespace
 			PipelineReleaseID:  pipelineReleaseID,
 			PipelineReleaseUID: releaseUID,
 		}
-		_, err := s.temporalClient.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		opts := client.ScheduleOptions{
 			ID: scheduleID,
 			Spec: client.ScheduleSpec{
 				CronExpressions: crons,
 			},
+			Overlap: scheduleOverlapPolicies[overlap],
 			Action: &client.ScheduleWorkflowAction{
 				Args:      []any{param},
 				ID:        scheduleID,
@@ -361,9 +390,13 @@ func (s *service) setSchedulePipeline(ctx context.Context, ns resource.Namespace
 					MaximumAttempts: 1,
 				},
 			},
-		})
+		}
+		if catchupWindow != "" {
+			// The window is checked when the recipe is validated.
+			opts.CatchupWindow, _ = time.ParseDuration(catchupWindow)
+		}
 
-		if err != nil {
+		if _, err := s.temporal().ScheduleClient().Create(ctx, opts); err != nil {
 			return err
 		}
 	}
@@ -383,6 +416,9 @@ func (s *service) UpdateNamespacePipelineByID(ctx context.Context, ns resource.N
 		if err := s.checkSecret(ctx, dbPipeline.Recipe.Component); err != nil {
 			return nil, fmt.Errorf("checking referenced secrets: %w", err)
 		}
+		if err := s.checkComponentPolicy(ctx, ns.NsUID, dbPipeline.Recipe); err != nil {
+			return nil, err
+		}
 	}
 
 	if granted, err := s.aclClient.CheckPermission(ctx, "pipeline", dbPipeline.UID, "reader"); err != nil {
@@ -414,6 +450,10 @@ func (s *service) UpdateNamespacePipelineByID(ctx context.Context, ns resource.N
 	if err := s.repository.UpdateNamespacePipelineByUID(ctx, dbPipeline.UID, dbPipeline); err != nil {
 		return nil, err
 	}
+	if err := s.syncPipelineCanaries(ctx, dbPipeline.UID, dbPipeline.Recipe); err != nil {
+		return nil, err
+	}
+	s.reportRecipeTypeWarnings(ctx, dbPipeline.Recipe)
 
 	toUpdTags := toUpdPipeline.GetTags()
 	for i := range toUpdTags {
@@ -501,6 +541,10 @@ func (s *service) DeleteNamespacePipelineByID(ctx context.Context, ns resource.N
 		return errdomain.ErrUnauthorized
 	}
 
+	if err := s.checkDependents(ctx, ns, DependencyPipeline, id); err != nil {
+		return err
+	}
+
 	// TODO: pagination
 	pipelineReleases, _, _, err := s.repository.ListNamespacePipelineReleases(ctx, ownerPermalink, dbPipeline.UID, 1000, "", false, filtering.Filter{}, false, false)
 	if err != nil {
@@ -529,6 +573,9 @@ func (s *service) DeleteNamespacePipelineByID(ctx context.Context, ns resource.N
 	if err != nil {
 		return err
 	}
+	if err := s.syncPipelineCanaries(ctx, dbPipeline.UID, nil); err != nil {
+		return err
+	}
 	return s.repository.DeleteNamespacePipelineByID(ctx, ownerPermalink, id)
 }
 
@@ -645,6 +692,17 @@ func (s *service) ValidateNamespacePipelineByID(ctx context.Context, ns resource
 		return nil, err
 	}
 
+	violations, err := s.componentPolicyViolations(ctx, ns.NsUID, dbPipeline.Recipe)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range violations {
+		validateErrs = append(validateErrs, &pipelinepb.ErrPipelineValidation{
+			Location: v.Location,
+			Error:    v.Message(),
+		})
+	}
+
 	return validateErrs, nil
 
 }
@@ -682,11 +740,38 @@ func (s *service) UpdateNamespacePipelineIDByID(ctx context.Context, ns resource
 	return s.converter.ConvertPipelineToPB(ctx, dbPipeline, pipelinepb.Pipeline_VIEW_FULL, true, true)
 }
 
+// checkTriggerDataLimits rejects the trigger data whose variables exceed the
+// configured limits, before they're decoded.
+func checkTriggerDataLimits(pipelineData []*pipelinepb.TriggerData) error {
+	cfg := config.Config.Server.TriggerInput
+	limits := data.Limits{
+		MaxDepth:       cfg.MaxDepth,
+		MaxArrayLength: cfg.MaxArrayLength,
+		MaxBytes:       int64(cfg.MaxSize) << 10,
+	}
+
+	for idx, d := range pipelineData {
+		err := limits.CheckStruct(structpb.NewStructValue(d.GetVariable()))
+		if err == nil {
+			continue
+		}
+
+		return errmsg.AddMessage(
+			fmt.Errorf("%w: inputs[%d]: %w", errdomain.ErrInvalidArgument, idx, err),
+			fmt.Sprintf("The data of inputs[%d] is too large: %s.", idx, err),
+		)
+	}
+	return nil
+}
+
 func (s *service) preTriggerPipeline(ctx context.Context, ns resource.Namespace, r *datamodel.Recipe, pipelineTriggerID string, pipelineData []*pipelinepb.TriggerData) error {
 	batchSize := len(pipelineData)
 	if batchSize > constant.MaxBatchSize {
 		return ErrExceedMaxBatchSize
 	}
+	if err := checkTriggerDataLimits(pipelineData); err != nil {
+		return err
+	}
 
 	var metadata []byte
 
@@ -750,8 +835,12 @@ func (s *service) preTriggerPipeline(ctx context.Context, ns resource.Namespace,
 		for k := range m {
 			switch s := m[k].(type) {
 			case string:
-				if instillFormatMap[k] != "string" {
-					if !strings.HasPrefix(s, "data:") {
+				// The file references are URIs, they're checked when the
+				// references are created.
+				if instillFormatMap[k] != "string" && instillFormatMap[k] != "file-ref" {
+					// Files can be passed as data URIs, HTTP URLs or
+					// base64-encoded strings.
+					if !strings.HasPrefix(s, "data:") && !isHTTPURL(s) {
 						b, err := base64.StdEncoding.DecodeString(s)
 						if err != nil {
 							return fmt.Errorf("can not decode file %s, %s", instillFormatMap[k], s)
@@ -761,9 +850,9 @@ func (s *service) preTriggerPipeline(ctx context.Context, ns resource.Namespace,
 					}
 				}
 			case []string:
-				if instillFormatMap[k] != "array:string" {
+				if instillFormatMap[k] != "array:string" && instillFormatMap[k] != "array:file-ref" {
 					for idx := range s {
-						if !strings.HasPrefix(s[idx], "data:") {
+						if !strings.HasPrefix(s[idx], "data:") && !isHTTPURL(s[idx]) {
 							b, err := base64.StdEncoding.DecodeString(s[idx])
 							if err != nil {
 								return fmt.Errorf("can not decode file %s, %s", instillFormatMap[k], s)
@@ -794,7 +883,20 @@ func (s *service) preTriggerPipeline(ctx context.Context, ns resource.Namespace,
 		return fmt.Errorf("[Pipeline Trigger Data Error] %s", strings.Join(errors, "; "))
 	}
 
-	wfm, err := s.memory.NewWorkflowMemory(ctx, pipelineTriggerID, nil, len(pipelineData))
+	// The decoded payload is attributed to the trigger until the workflow
+	// memory holds it.
+	var payloadSize int64
+	for _, d := range pipelineData {
+		payloadSize += int64(proto.Size(d))
+	}
+	release, err := s.memory.AdmitTrigger(ctx, pipelineTriggerID, payloadSize)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// The memory of the run belongs to the namespace of the pipeline.
+	wfm, err := s.memory.NewWorkflowMemory(memory.WithTenant(ctx, ns.NsUID.String()), pipelineTriggerID, nil, len(pipelineData))
 	if err != nil {
 		return err
 	}
@@ -804,6 +906,9 @@ func (s *service) preTriggerPipeline(ctx context.Context, ns resource.Namespace,
 		formats[k] = []string{v}
 	}
 
+	// The batch items often share some of their data (e.g. the same file or
+	// the same secrets), which is held once by the memory.
+	interner := data.NewInterner()
 	for idx, d := range pipelineData {
 
 		// TODO: refactor array parser
@@ -902,6 +1007,22 @@ func (s *service) preTriggerPipeline(ctx context.Context, ns resource.Namespace,
 					}
 				}
 				variable.Fields[k] = array
+			case "file-ref":
+				// The content of the referenced file is only fetched when a
+				// component reads it.
+				variable.Fields[k], err = data.NewFileRef(v.GetStringValue(), "", "", 0)
+				if err != nil {
+					return err
+				}
+			case "array:file-ref":
+				array := data.NewArray(make([]data.Value, len(v.GetListValue().Values)))
+				for idx, val := range v.GetListValue().Values {
+					array.Values[idx], err = data.NewFileRef(val.GetStringValue(), "", "", 0)
+					if err != nil {
+						return err
+					}
+				}
+				variable.Fields[k] = array
 			case "semi-structured/*", "semi-structured/json", "json":
 
 				switch v.Kind.(type) {
@@ -942,7 +1063,7 @@ func (s *service) preTriggerPipeline(ctx context.Context, ns resource.Namespace,
 				return err
 			}
 		}
-		err = wfm.Set(ctx, idx, constant.SegVariable, variable)
+		err = wfm.Set(ctx, idx, constant.SegVariable, interner.Intern(variable))
 		if err != nil {
 			return err
 		}
@@ -951,7 +1072,7 @@ func (s *service) preTriggerPipeline(ctx context.Context, ns resource.Namespace,
 		for k, v := range d.Secret {
 			secret.Fields[k] = data.NewString(v)
 		}
-		err = wfm.Set(ctx, idx, constant.SegSecret, secret)
+		err = wfm.Set(ctx, idx, constant.SegSecret, interner.Intern(secret))
 		if err != nil {
 			return err
 		}
@@ -960,6 +1081,9 @@ func (s *service) preTriggerPipeline(ctx context.Context, ns resource.Namespace,
 	isStreaming := resource.GetRequestSingleHeader(ctx, constant.HeaderAccept) == "text/event-stream"
 	if isStreaming {
 		wfm.EnableStreaming()
+		if resource.GetRequestSingleHeader(ctx, constant.HeaderEventFormatKey) == constant.HeaderValueJSONPatch {
+			wfm.EnablePatchEvents()
+		}
 	}
 	return nil
 }
@@ -990,6 +1114,10 @@ func (s *service) CreateNamespacePipelineRelease(ctx context.Context, ns resourc
 		return nil, err
 	}
 
+	if err := s.checkComponentPolicy(ctx, ns.NsUID, dbPipeline.Recipe); err != nil {
+		return nil, err
+	}
+
 	dbPipelineReleaseToCreate.RecipeYAML = dbPipeline.RecipeYAML
 	dbPipelineReleaseToCreate.Metadata = dbPipeline.Metadata
 
@@ -1228,7 +1356,7 @@ func (s *service) triggerPipeline(
 		requesterUID = userUID
 	}
 
-	we, err := s.temporalClient.ExecuteWorkflow(
+	we, err := s.temporal().ExecuteWorkflow(
 		ctx,
 		workflowOptions,
 		"TriggerPipelineWorkflow",
@@ -1248,6 +1376,7 @@ func (s *service) triggerPipeline(
 			},
 			Mode:      mgmtpb.Mode_MODE_SYNC,
 			WorkerUID: s.workerUID,
+			Canary:    isCanaryRun(ctx),
 		})
 	if err != nil {
 		logger.Error(fmt.Sprintf("unable to execute workflow: %s", err.Error()))
@@ -1313,7 +1442,7 @@ func (s *service) triggerAsyncPipeline(
 		requesterUID = userUID
 	}
 
-	we, err := s.temporalClient.ExecuteWorkflow(
+	we, err := s.temporal().ExecuteWorkflow(
 		ctx,
 		workflowOptions,
 		"TriggerPipelineWorkflow",
@@ -1333,6 +1462,7 @@ func (s *service) triggerAsyncPipeline(
 			Mode:           mgmtpb.Mode_MODE_ASYNC,
 			TriggerFromAPI: true,
 			WorkerUID:      s.workerUID,
+			Canary:         isCanaryRun(ctx),
 		})
 	if err != nil {
 		logger.Error(fmt.Sprintf("unable to execute workflow: %s", err.Error()))
@@ -1513,10 +1643,25 @@ func (s *service) HandleNamespacePipelineEventByID(ctx context.Context, ns resou
 	md.Set(constant.HeaderRequesterUIDKey, ns.NsUID.String())
 	ctx = metadata.NewIncomingContext(ctx, md)
 
+	// Duplicates are dropped before the run is logged, as they don't start
+	// any run.
+	dedupKey, dedupID, duplicate, err := s.claimWebhookEvent(ctx, dbPipeline, eventID, data)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate {
+		return nil, nil
+	}
+
 	pipelineRun := s.logPipelineRunStart(ctx, pipelineTriggerID, dbPipeline.UID, defaultPipelineReleaseID)
 	defer func() {
 		if err != nil {
 			s.logPipelineRunError(ctx, pipelineTriggerID, err, pipelineRun.StartedTime)
+			if dedupID != "" {
+				if releaseErr := eventsource.ReleaseEvent(ctx, s.redisClient, dedupKey, dedupID); releaseErr != nil {
+					s.log.Warn("failed to release webhook event ID", zap.String("pipelineTriggerID", pipelineTriggerID), zap.Error(releaseErr))
+				}
+			}
 		}
 	}()
 
@@ -1580,6 +1725,52 @@ func (s *service) HandleNamespacePipelineEventByID(ctx context.Context, ns resou
 	return out, nil
 }
 
+// claimWebhookEvent records the ID of a webhook event when the recipe
+// deduplicates the event. It returns the deduplication key and ID, so the
+// event can be released if it doesn't trigger the pipeline, and whether the
+// event is a duplicate of one received within the deduplication window.
+// Events without an ID, such as the verification requests, aren't
+// deduplicated.
+func (s *service) claimWebhookEvent(ctx context.Context, dbPipeline *datamodel.Pipeline, eventID string, data *structpb.Struct) (key, id string, duplicate bool, err error) {
+	if dbPipeline.Recipe == nil || dbPipeline.Recipe.On == nil {
+		return "", "", false, nil
+	}
+	e, ok := dbPipeline.Recipe.On.Event[eventID]
+	if !ok || e.Deduplication == nil {
+		return "", "", false, nil
+	}
+
+	window, err := e.Deduplication.ParseWindow()
+	if err != nil {
+		return "", "", false, fmt.Errorf("%w: %w", errdomain.ErrInvalidArgument, err)
+	}
+
+	payload, err := protojson.Marshal(data)
+	if err != nil {
+		return "", "", false, err
+	}
+	id, err = eventsource.EventID(payload, e.Deduplication.Field)
+	if err != nil {
+		return "", "", false, nil
+	}
+
+	key = eventsource.WebhookDedupKey(dbPipeline.UID, eventID)
+	claimed, err := eventsource.ClaimEvent(ctx, s.redisClient, key, id, window)
+	if err != nil {
+		return "", "", false, err
+	}
+	if !claimed {
+		s.log.Info("dropping duplicate webhook event",
+			zap.String("pipelineUID", dbPipeline.UID.String()),
+			zap.String("eventID", eventID),
+			zap.String("dedupID", id),
+		)
+		return "", "", true, nil
+	}
+
+	return key, id, false, nil
+}
+
 func (s *service) TriggerNamespacePipelineByID(ctx context.Context, ns resource.Namespace, id string, data []*pipelinepb.TriggerData, pipelineTriggerID string, returnTraces bool) ([]*structpb.Struct, *pipelinepb.TriggerMetadata, error) {
 	ownerPermalink := ns.Permalink()
 
@@ -1700,7 +1891,7 @@ func (s *service) TriggerAsyncNamespacePipelineReleaseByID(ctx context.Context,
 }
 
 func (s *service) GetOperation(ctx context.Context, workflowID string) (*longrunningpb.Operation, error) {
-	workflowExecutionRes, err := s.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
+	workflowExecutionRes, err := s.temporal().DescribeWorkflowExecution(ctx, workflowID, "")
 
 	if err != nil {
 		return nil, err
@@ -1708,6 +1899,49 @@ func (s *service) GetOperation(ctx context.Context, workflowID string) (*longrun
 	return s.getOperationFromWorkflowInfo(ctx, workflowExecutionRes.WorkflowExecutionInfo)
 }
 
+// Bounds of the time WaitForTriggerResult holds a request.
+const (
+	defaultTriggerResultWait = 20 * time.Second
+	maxTriggerResultWait     = 60 * time.Second
+)
+
+// WaitForTriggerResult long-polls the operation of an async trigger. It
+// returns as soon as the run finishes, with its result, or when the timeout
+// elapses, with the operation of the running trigger. Clients that can't
+// keep a stream open (e.g. serverless functions) can wait for the result
+// without polling in a tight loop. A non-positive timeout waits the default
+// time, and the timeout is capped so the request doesn't outlive the
+// gateway.
+func (s *service) WaitForTriggerResult(ctx context.Context, operationID string, timeout time.Duration) (*longrunningpb.Operation, error) {
+	workflowID := strings.TrimPrefix(operationID, "operations/")
+	if workflowID == "" {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("%w: missing operation ID", errdomain.ErrInvalidArgument),
+			"Operation ID is required.",
+		)
+	}
+
+	switch {
+	case timeout <= 0:
+		timeout = defaultTriggerResultWait
+	case timeout > maxTriggerResultWait:
+		timeout = maxTriggerResultWait
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The result of the workflow isn't read from here, as the outputs are
+	// in the workflow memory. Waiting for the workflow to close long-polls
+	// Temporal instead of describing the execution repeatedly.
+	err := s.temporal().GetWorkflow(waitCtx, workflowID, "").Get(waitCtx, nil)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return s.GetOperation(ctx, workflowID)
+}
+
 func (s *service) getOperationFromWorkflowInfo(ctx context.Context, workflowExecutionInfo *workflowpb.WorkflowExecutionInfo) (*longrunningpb.Operation, error) {
 	operation := longrunningpb.Operation{}
 
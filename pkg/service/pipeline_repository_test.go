@@ -192,6 +192,7 @@ func TestService_ListPipelineRuns(t *testing.T) {
 				mockMinio,
 				nil,
 				nil,
+				nil,
 				uuid.UUID{},
 			)
 
@@ -416,6 +417,7 @@ func TestService_ListPipelineRuns_OrgResource(t *testing.T) {
 				mockMinio,
 				nil,
 				nil,
+				nil,
 				uuid.UUID{},
 			)
 
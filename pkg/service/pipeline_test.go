@@ -75,7 +75,8 @@ func TestService_UpdateNamespacePipelineByID(t *testing.T) {
 		mgmtPrivateClient,
 		nil,
 		compStore,
-		memory.NewMemoryStore(),
+		memory.NewMemoryStore(nil, 0, nil, 0, nil, nil),
+		nil,
 		workerUID,
 	)
 
@@ -87,6 +88,7 @@ func TestService_UpdateNamespacePipelineByID(t *testing.T) {
 
 	repo.GetNamespacePipelineByIDMock.Return(&dataPipeline, nil)
 	repo.UpdateNamespacePipelineByUIDMock.Return(nil)
+	repo.SyncPipelineCanariesMock.Return(nil)
 	repo.DeletePipelineTagsMock.Expect(ctx, uid, []string{"tag3"}).Return(nil)
 	repo.CreatePipelineTagsMock.Expect(ctx, uid, []string{"tag2"}).Return(nil)
 
@@ -2,14 +2,17 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 	"github.com/gofrs/uuid"
 	"go.einride.tech/aip/filtering"
 	"go.einride.tech/aip/ordering"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/instill-ai/pipeline-backend/pkg/constant"
@@ -17,7 +20,9 @@ import (
 	"github.com/instill-ai/pipeline-backend/pkg/repository"
 	"github.com/instill-ai/pipeline-backend/pkg/resource"
 	"github.com/instill-ai/pipeline-backend/pkg/utils"
+	"github.com/instill-ai/x/errmsg"
 
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
 	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
 	mgmtpb "github.com/instill-ai/protogen-go/core/mgmt/v1beta"
 	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
@@ -42,6 +47,8 @@ func (s *service) logPipelineRunStart(ctx context.Context, pipelineTriggerID str
 		Source:             runSource,
 		Namespace:          requesterUID,
 		TriggeredBy:        userUID,
+		TemporalCluster:    s.activeTemporalCluster(),
+		ScheduleID:         canaryRunID(ctx),
 		StartedTime:        time.Now(),
 	}
 
@@ -357,3 +364,153 @@ func (s *service) ListPipelineRunsByRequester(ctx context.Context, req *pb.ListP
 		PageSize:     int32(pageSize),
 	}, nil
 }
+
+// RetryPipelineTrigger triggers a pipeline asynchronously with the data of a
+// previous run: its inputs, its trigger-time secrets and the recipe snapshot
+// taken when it started. The new run references the original one, so the
+// retries of a run can be listed by filtering the run history by
+// retriedFromUID.
+func (s *service) RetryPipelineTrigger(ctx context.Context, namespaceID, pipelineID, pipelineRunID string) (*longrunningpb.Operation, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	dbPipeline, err := s.repository.GetNamespacePipelineByID(ctx, ns.Permalink(), pipelineID, false, true)
+	if err != nil {
+		return nil, errdomain.ErrNotFound
+	}
+
+	if err := s.checkTriggerPermission(ctx, dbPipeline); err != nil {
+		return nil, err
+	}
+
+	originalRun, err := s.repository.GetPipelineRunByUID(ctx, uuid.FromStringOrNil(pipelineRunID))
+	if err != nil || originalRun.PipelineUID != dbPipeline.UID {
+		return nil, errdomain.ErrNotFound
+	}
+
+	requesterUID, _ := utils.GetRequesterUIDAndUserUID(ctx)
+	if !CanViewPrivateData(originalRun.Namespace, requesterUID) {
+		return nil, errdomain.ErrUnauthorized
+	}
+
+	data, err := s.loadPipelineRunTriggerData(ctx, originalRun)
+	if err != nil {
+		return nil, err
+	}
+
+	recipe, releaseID, releaseUID, err := s.loadPipelineRunRecipe(ctx, ns, dbPipeline, originalRun)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineTriggerID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineRun := s.logPipelineRunStart(ctx, pipelineTriggerID.String(), dbPipeline.UID, originalRun.PipelineVersion)
+	defer func() {
+		if err != nil {
+			s.logPipelineRunError(ctx, pipelineTriggerID.String(), err, pipelineRun.StartedTime)
+		}
+	}()
+
+	lineage := &datamodel.PipelineRun{RetriedFromUID: uuid.NullUUID{UUID: originalRun.PipelineTriggerUID, Valid: true}}
+	if err = s.repository.UpdatePipelineRun(ctx, pipelineTriggerID.String(), lineage); err != nil {
+		return nil, fmt.Errorf("linking pipeline run: %w", err)
+	}
+
+	operation, err := s.triggerAsyncPipeline(ctx, ns, recipe, dbPipeline.ID, dbPipeline.UID, releaseID, releaseUID, data, pipelineTriggerID.String(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// loadPipelineRunTriggerData rebuilds the trigger request data of a run from
+// the inputs and secrets stored in MinIO.
+func (s *service) loadPipelineRunTriggerData(ctx context.Context, run *datamodel.PipelineRun) ([]*pb.TriggerData, error) {
+	if len(run.Inputs) != 1 {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("%w: pipeline run has no stored inputs", errdomain.ErrInvalidArgument),
+			"The inputs of this run weren't stored, so it can't be retried.",
+		)
+	}
+
+	b, err := s.minioClient.GetFile(ctx, run.Inputs[0].Name)
+	if err != nil {
+		return nil, fmt.Errorf("fetching pipeline run inputs: %w", err)
+	}
+
+	inputs := make([]*structpb.Struct, 0)
+	if err := json.Unmarshal(b, &inputs); err != nil {
+		return nil, fmt.Errorf("unmarshalling pipeline run inputs: %w", err)
+	}
+
+	secrets := make([]*structpb.Struct, 0)
+	if len(run.Secrets) == 1 {
+		b, err := s.minioClient.GetFile(ctx, run.Secrets[0].Name)
+		if err != nil {
+			return nil, fmt.Errorf("fetching pipeline run secrets: %w", err)
+		}
+		if err := json.Unmarshal(b, &secrets); err != nil {
+			return nil, fmt.Errorf("unmarshalling pipeline run secrets: %w", err)
+		}
+	}
+
+	data := make([]*pb.TriggerData, len(inputs))
+	for i, input := range inputs {
+		data[i] = &pb.TriggerData{Variable: input}
+		if i >= len(secrets) {
+			continue
+		}
+
+		data[i].Secret = make(map[string]string, len(secrets[i].GetFields()))
+		for k, v := range secrets[i].GetFields() {
+			data[i].Secret[k] = v.GetStringValue()
+		}
+	}
+
+	return data, nil
+}
+
+// loadPipelineRunRecipe returns the recipe a run was executed with, along with
+// the release it belongs to. The recipe snapshot is preferred, as the latest
+// version of a pipeline might have been edited since the run. Release recipes
+// are immutable, so they can be used when the snapshot isn't available.
+func (s *service) loadPipelineRunRecipe(ctx context.Context, ns resource.Namespace, dbPipeline *datamodel.Pipeline, run *datamodel.PipelineRun) (recipe *datamodel.Recipe, releaseID string, releaseUID uuid.UUID, err error) {
+	if run.PipelineVersion != "" && run.PipelineVersion != defaultPipelineReleaseID {
+		dbPipelineRelease, err := s.repository.GetNamespacePipelineReleaseByID(ctx, ns.Permalink(), dbPipeline.UID, run.PipelineVersion, false)
+		if err != nil {
+			return nil, "", uuid.Nil, fmt.Errorf("fetching pipeline release: %w", err)
+		}
+
+		recipe = dbPipelineRelease.Recipe
+		releaseID, releaseUID = dbPipelineRelease.ID, dbPipelineRelease.UID
+	}
+
+	if len(run.RecipeSnapshot) == 1 {
+		b, err := s.minioClient.GetFile(ctx, run.RecipeSnapshot[0].Name)
+		if err != nil {
+			return nil, "", uuid.Nil, fmt.Errorf("fetching recipe snapshot: %w", err)
+		}
+
+		snapshot := new(datamodel.Recipe)
+		if err := json.Unmarshal(b, snapshot); err != nil {
+			return nil, "", uuid.Nil, fmt.Errorf("unmarshalling recipe snapshot: %w", err)
+		}
+		recipe = snapshot
+	}
+
+	if recipe == nil {
+		return nil, "", uuid.Nil, errmsg.AddMessage(
+			fmt.Errorf("%w: pipeline run has no recipe snapshot", errdomain.ErrInvalidArgument),
+			"The recipe of this run wasn't stored, so it can't be retried.",
+		)
+	}
+
+	return recipe, releaseID, releaseUID, nil
+}
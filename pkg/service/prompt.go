@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+	"github.com/instill-ai/pipeline-backend/pkg/resource"
+	"github.com/instill-ai/x/checkfield"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// Prompt is the API representation of a prompt version.
+type Prompt struct {
+	ID          string    `json:"id"`
+	Version     int32     `json:"version"`
+	Description string    `json:"description,omitempty"`
+	Template    string    `json:"template"`
+	Variables   []string  `json:"variables"`
+	Reference   string    `json:"reference"`
+	CreateTime  time.Time `json:"createTime"`
+}
+
+// CreateNamespacePrompt stores a new version of a prompt. The first version
+// of a prompt is created when its ID isn't in the namespace yet. Rolling back
+// a prompt is done by creating a new version with the template of a previous
+// one, so the references to the latest version pick it up.
+func (s *service) CreateNamespacePrompt(ctx context.Context, namespaceID string, p *Prompt) (*Prompt, error) {
+	ns, err := s.getPromptNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkfield.CheckResourceID(p.ID); err != nil {
+		return nil, fmt.Errorf("%w: %w", errdomain.ErrInvalidArgument, err)
+	}
+	if strings.TrimSpace(p.Template) == "" {
+		return nil, fmt.Errorf("%w: prompt template can't be empty", errdomain.ErrInvalidArgument)
+	}
+
+	dbPrompt := &datamodel.Prompt{
+		ID:           p.ID,
+		NamespaceUID: ns.NsUID,
+		Description:  p.Description,
+		Template:     p.Template,
+		Variables:    recipe.PromptVariables(p.Template),
+	}
+	if err := s.repository.CreateNamespacePromptVersion(ctx, dbPrompt); err != nil {
+		return nil, err
+	}
+
+	return convertPromptToAPI(dbPrompt), nil
+}
+
+// ListNamespacePrompts returns the latest version of the prompts in a
+// namespace.
+func (s *service) ListNamespacePrompts(ctx context.Context, namespaceID string) ([]*Prompt, error) {
+	ns, err := s.getPromptNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPrompts, err := s.repository.ListNamespacePrompts(ctx, ns.NsUID)
+	if err != nil {
+		return nil, err
+	}
+
+	prompts := make([]*Prompt, 0, len(dbPrompts))
+	for _, dbPrompt := range dbPrompts {
+		prompts = append(prompts, convertPromptToAPI(dbPrompt))
+	}
+
+	return prompts, nil
+}
+
+// GetNamespacePrompt returns a version of a prompt. A zero version returns
+// the latest one.
+func (s *service) GetNamespacePrompt(ctx context.Context, namespaceID, id string, version int32) (*Prompt, error) {
+	ns, err := s.getPromptNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPrompt, err := s.repository.GetNamespacePromptByID(ctx, ns.NsUID, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertPromptToAPI(dbPrompt), nil
+}
+
+// ListNamespacePromptVersions returns the versions of a prompt, the most
+// recent first.
+func (s *service) ListNamespacePromptVersions(ctx context.Context, namespaceID, id string) ([]*Prompt, error) {
+	ns, err := s.getPromptNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPrompts, err := s.repository.ListNamespacePromptVersions(ctx, ns.NsUID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	prompts := make([]*Prompt, 0, len(dbPrompts))
+	for _, dbPrompt := range dbPrompts {
+		prompts = append(prompts, convertPromptToAPI(dbPrompt))
+	}
+
+	return prompts, nil
+}
+
+// DeleteNamespacePrompt deletes all the versions of a prompt. The components
+// that reference it will fail until the reference is removed.
+func (s *service) DeleteNamespacePrompt(ctx context.Context, namespaceID, id string) error {
+	ns, err := s.getPromptNamespace(ctx, namespaceID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkDependents(ctx, ns, DependencyPrompt, id); err != nil {
+		return err
+	}
+
+	return s.repository.DeleteNamespacePromptByID(ctx, ns.NsUID, id)
+}
+
+func (s *service) getPromptNamespace(ctx context.Context, namespaceID string) (resource.Namespace, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return ns, fmt.Errorf("fetching namespace: %w", err)
+	}
+
+	if err := s.checkNamespacePermission(ctx, ns); err != nil {
+		return ns, fmt.Errorf("checking namespace permissions: %w", err)
+	}
+
+	return ns, nil
+}
+
+func convertPromptToAPI(p *datamodel.Prompt) *Prompt {
+	variables := []string(p.Variables)
+	if variables == nil {
+		variables = []string{}
+	}
+
+	return &Prompt{
+		ID:          p.ID,
+		Version:     p.Version,
+		Description: p.Description,
+		Template:    p.Template,
+		Variables:   variables,
+		Reference:   fmt.Sprintf("prompt://%s@%d", p.ID, p.Version),
+		CreateTime:  p.CreateTime,
+	}
+}
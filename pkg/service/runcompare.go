@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+)
+
+// RunComparison puts side by side two runs of a pipeline, so users can find
+// out why a run behaved differently from another one. Only the values that
+// differ are listed.
+type RunComparison struct {
+	RunA       *RunSummary            `json:"runA"`
+	RunB       *RunSummary            `json:"runB"`
+	Inputs     []*ValueDiff           `json:"inputs"`
+	Outputs    []*ValueDiff           `json:"outputs"`
+	Components []*ComponentComparison `json:"components"`
+}
+
+// RunSummary holds the timing and the cost of a pipeline run. The cost is
+// the token usage reported by the components in their outputs, as runs
+// don't record the credits they consume.
+type RunSummary struct {
+	PipelineRunUID  string             `json:"pipelineRunUid"`
+	PipelineVersion string             `json:"pipelineVersion"`
+	Status          string             `json:"status"`
+	StartTime       time.Time          `json:"startTime"`
+	DurationMs      *int64             `json:"durationMs,omitempty"`
+	MemorySize      *int64             `json:"memorySizeBytes,omitempty"`
+	Usage           map[string]float64 `json:"usage,omitempty"`
+}
+
+// ComponentComparison puts side by side the execution of a component in two
+// runs. A component that only ran in one of them has no status in the
+// other.
+type ComponentComparison struct {
+	ComponentID     string             `json:"componentId"`
+	StatusA         string             `json:"statusA,omitempty"`
+	StatusB         string             `json:"statusB,omitempty"`
+	ProviderA       string             `json:"providerA,omitempty"`
+	ProviderB       string             `json:"providerB,omitempty"`
+	DurationMsA     *int64             `json:"durationMsA,omitempty"`
+	DurationMsB     *int64             `json:"durationMsB,omitempty"`
+	DurationDeltaMs *int64             `json:"durationDeltaMs,omitempty"`
+	UsageA          map[string]float64 `json:"usageA,omitempty"`
+	UsageB          map[string]float64 `json:"usageB,omitempty"`
+	Inputs          []*ValueDiff       `json:"inputs"`
+	Outputs         []*ValueDiff       `json:"outputs"`
+}
+
+// ValueDiff is a value that differs between two runs. The path locates the
+// value in the batch of the run, e.g. `$[0].text`. A value that is missing
+// in one of the runs is null.
+type ValueDiff struct {
+	Path string `json:"path"`
+	A    any    `json:"a"`
+	B    any    `json:"b"`
+}
+
+// CompareRuns diffs two runs of a pipeline: their resolved inputs, the
+// outputs of the pipeline and of each component, their timings and their
+// costs. The inputs and outputs are only available while the blob storage
+// retains them.
+func (s *service) CompareRuns(ctx context.Context, namespaceID, pipelineID, runAID, runBID string) (*RunComparison, error) {
+	runA, err := s.getViewablePipelineRun(ctx, namespaceID, pipelineID, runAID)
+	if err != nil {
+		return nil, err
+	}
+	runB, err := s.getViewablePipelineRun(ctx, namespaceID, pipelineID, runBID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := s.loadRunData(ctx, runA, runB)
+
+	cmp := &RunComparison{
+		RunA:       summarizeRun(runA),
+		RunB:       summarizeRun(runB),
+		Inputs:     diffValues(data[refName(runA.Inputs)], data[refName(runB.Inputs)]),
+		Outputs:    diffValues(data[refName(runA.Outputs)], data[refName(runB.Outputs)]),
+		Components: []*ComponentComparison{},
+	}
+
+	componentsA := componentRunsByID(runA)
+	componentsB := componentRunsByID(runB)
+	ids := make([]string, 0, len(componentsA)+len(componentsB))
+	for id := range componentsA {
+		ids = append(ids, id)
+	}
+	for id := range componentsB {
+		if _, ok := componentsA[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		a, b := componentsA[id], componentsB[id]
+		cc := &ComponentComparison{ComponentID: id}
+
+		var inputsA, inputsB, outputsA, outputsB any
+		if a != nil {
+			cc.StatusA = runpb.RunStatus(a.Status).String()
+			cc.ProviderA = a.Provider
+			cc.DurationMsA = a.TotalDuration.Ptr()
+			inputsA, outputsA = data[refName(a.Inputs)], data[refName(a.Outputs)]
+			cc.UsageA = usageOf(outputsA)
+		}
+		if b != nil {
+			cc.StatusB = runpb.RunStatus(b.Status).String()
+			cc.ProviderB = b.Provider
+			cc.DurationMsB = b.TotalDuration.Ptr()
+			inputsB, outputsB = data[refName(b.Inputs)], data[refName(b.Outputs)]
+			cc.UsageB = usageOf(outputsB)
+		}
+		if cc.DurationMsA != nil && cc.DurationMsB != nil {
+			delta := *cc.DurationMsB - *cc.DurationMsA
+			cc.DurationDeltaMs = &delta
+		}
+		cc.Inputs = diffValues(inputsA, inputsB)
+		cc.Outputs = diffValues(outputsA, outputsB)
+
+		addUsage(cmp.RunA, cc.UsageA)
+		addUsage(cmp.RunB, cc.UsageB)
+		cmp.Components = append(cmp.Components, cc)
+	}
+
+	return cmp, nil
+}
+
+// loadRunData fetches the inputs and outputs of the runs and their
+// components, indexed by their reference. The data that can't be fetched
+// is left out, so the runs can still be compared by their timing.
+func (s *service) loadRunData(ctx context.Context, runs ...*datamodel.PipelineRun) map[string]any {
+	var refs []string
+	addRef := func(files datamodel.JSONB) {
+		if name := refName(files); name != "" {
+			refs = append(refs, name)
+		}
+	}
+	for _, run := range runs {
+		addRef(run.Inputs)
+		addRef(run.Outputs)
+		for _, c := range run.Components {
+			addRef(c.Inputs)
+			addRef(c.Outputs)
+		}
+	}
+
+	data := map[string]any{}
+	if len(refs) == 0 {
+		return data
+	}
+
+	files, err := s.minioClient.GetFilesByPaths(ctx, refs)
+	if err != nil {
+		s.log.Error("failed to get run data from minio", zap.Error(err))
+		return data
+	}
+	for _, f := range files {
+		var v any
+		if err := json.Unmarshal(f.Content, &v); err != nil {
+			s.log.Error("failed to decode run data", zap.String("referenceID", f.Name), zap.Error(err))
+			continue
+		}
+		data[f.Name] = v
+	}
+	return data
+}
+
+// refName returns the reference of the data of a run, which is stored in a
+// single file.
+func refName(files datamodel.JSONB) string {
+	if len(files) != 1 {
+		return ""
+	}
+	return files[0].Name
+}
+
+func componentRunsByID(run *datamodel.PipelineRun) map[string]*datamodel.ComponentRun {
+	m := make(map[string]*datamodel.ComponentRun, len(run.Components))
+	for i := range run.Components {
+		m[run.Components[i].ComponentID] = &run.Components[i]
+	}
+	return m
+}
+
+func summarizeRun(run *datamodel.PipelineRun) *RunSummary {
+	return &RunSummary{
+		PipelineRunUID:  run.PipelineTriggerUID.String(),
+		PipelineVersion: run.PipelineVersion,
+		Status:          runpb.RunStatus(run.Status).String(),
+		StartTime:       run.StartedTime,
+		DurationMs:      run.TotalDuration.Ptr(),
+		MemorySize:      run.MemorySize.Ptr(),
+	}
+}
+
+func addUsage(run *RunSummary, usage map[string]float64) {
+	for k, v := range usage {
+		if run.Usage == nil {
+			run.Usage = map[string]float64{}
+		}
+		run.Usage[k] += v
+	}
+}
+
+// usageOf sums the numeric fields of the `usage` objects found in the
+// outputs of a component, e.g. the tokens consumed by an AI model.
+func usageOf(outputs any) map[string]float64 {
+	var usage map[string]float64
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, f := range v {
+				if u, ok := f.(map[string]any); ok && k == "usage" {
+					for name, n := range u {
+						if n, ok := n.(float64); ok {
+							if usage == nil {
+								usage = map[string]float64{}
+							}
+							usage[name] += n
+						}
+					}
+					continue
+				}
+				walk(f)
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(outputs)
+	return usage
+}
+
+// diffValues lists the leaves that differ between two JSON values.
+func diffValues(a, b any) []*ValueDiff {
+	diffs := []*ValueDiff{}
+	appendDiffs("$", a, b, &diffs)
+	return diffs
+}
+
+func appendDiffs(path string, a, b any, diffs *[]*ValueDiff) {
+	switch a := a.(type) {
+	case map[string]any:
+		if b, ok := b.(map[string]any); ok {
+			keys := make([]string, 0, len(a)+len(b))
+			for k := range a {
+				keys = append(keys, k)
+			}
+			for k := range b {
+				if _, ok := a[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				appendDiffs(path+"."+k, a[k], b[k], diffs)
+			}
+			return
+		}
+	case []any:
+		if b, ok := b.([]any); ok {
+			for i := range max(len(a), len(b)) {
+				var itemA, itemB any
+				if i < len(a) {
+					itemA = a[i]
+				}
+				if i < len(b) {
+					itemB = b[i]
+				}
+				appendDiffs(path+"["+strconv.Itoa(i)+"]", itemA, itemB, diffs)
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, &ValueDiff{Path: path, A: a, B: b})
+	}
+}
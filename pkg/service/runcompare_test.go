@@ -0,0 +1,64 @@
+package service
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDiffValues(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name string
+		a, b any
+		want []*ValueDiff
+	}{
+		{
+			name: "ok - equal",
+			a:    []any{map[string]any{"text": "hello"}},
+			b:    []any{map[string]any{"text": "hello"}},
+			want: []*ValueDiff{},
+		},
+		{
+			name: "ok - changed and missing fields",
+			a:    []any{map[string]any{"text": "hello", "n": float64(1)}},
+			b:    []any{map[string]any{"text": "bye", "lang": "en"}},
+			want: []*ValueDiff{
+				{Path: "$[0].lang", A: nil, B: "en"},
+				{Path: "$[0].n", A: float64(1), B: nil},
+				{Path: "$[0].text", A: "hello", B: "bye"},
+			},
+		},
+		{
+			name: "ok - different batch sizes",
+			a:    []any{"a"},
+			b:    []any{"a", "b"},
+			want: []*ValueDiff{{Path: "$[1]", A: nil, B: "b"}},
+		},
+		{
+			name: "ok - different types",
+			a:    map[string]any{"v": []any{"x"}},
+			b:    map[string]any{"v": "x"},
+			want: []*ValueDiff{{Path: "$.v", A: []any{"x"}, B: "x"}},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			c.Check(diffValues(tc.a, tc.b), qt.DeepEquals, tc.want)
+		})
+	}
+}
+
+func TestUsageOf(t *testing.T) {
+	c := qt.New(t)
+
+	outputs := []any{
+		map[string]any{"data": "a", "metadata": map[string]any{"usage": map[string]any{"total-tokens": float64(10)}}},
+		map[string]any{"data": "b", "metadata": map[string]any{"usage": map[string]any{"total-tokens": float64(5), "model": "gpt-4o"}}},
+	}
+
+	c.Check(usageOf(outputs), qt.DeepEquals, map[string]float64{"total-tokens": 15})
+	c.Check(usageOf([]any{map[string]any{"data": "a"}}), qt.IsNil)
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+)
+
+// Files of a run memory export.
+const (
+	memoryExportRunFile    = "run.json"
+	memoryExportMemoryFile = "memory.jsonl"
+)
+
+// ExportPipelineRunMemory archives the memory of a finished pipeline run for
+// audit and offline analysis. The archive is a zip file with the run
+// details and a JSONL file holding the memory of each batch item, with the
+// secrets redacted. It's stored as an artifact of the run, so it outlives
+// the memory and can be downloaded as the rest of artifacts.
+func (s *service) ExportPipelineRunMemory(ctx context.Context, namespaceID, pipelineID, pipelineRunID string) (*RunArtifact, error) {
+	run, err := s.getViewablePipelineRun(ctx, namespaceID, pipelineID, pipelineRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	if run.Status == datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_PROCESSING) {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("%w: run is still processing", errdomain.ErrInvalidArgument),
+			"The memory of a run can only be exported once it finishes.",
+		)
+	}
+
+	tree, err := s.memory.InspectWorkflowMemory(ctx, run.PipelineTriggerUID.String())
+	if err != nil {
+		if errors.Is(err, memory.ErrWorkflowMemoryNotFound) {
+			return nil, errmsg.AddMessage(
+				fmt.Errorf("%w: %w", errdomain.ErrNotFound, err),
+				"The memory of this run is no longer available.",
+			)
+		}
+		return nil, fmt.Errorf("inspecting run memory: %w", err)
+	}
+
+	archive, err := s.archiveRunMemory(*run, tree)
+	if err != nil {
+		return nil, fmt.Errorf("archiving run memory: %w", err)
+	}
+
+	now := time.Now().UTC()
+	name := fmt.Sprintf("memory-%s.zip", now.Format("20060102T150405Z"))
+	objectName := fmt.Sprintf("pipeline-runs/artifact/%s/%s", run.PipelineTriggerUID, name)
+	_, objectInfo, err := s.minioClient.UploadFileBytes(ctx, objectName, archive, "application/zip")
+	if err != nil {
+		return nil, fmt.Errorf("uploading run memory archive: %w", err)
+	}
+
+	dbArtifact := &datamodel.RunArtifact{
+		UID:                uuid.Must(uuid.NewV4()),
+		PipelineTriggerUID: run.PipelineTriggerUID,
+		Name:               name,
+		ContentType:        objectInfo.ContentType,
+		Size:               objectInfo.Size,
+		ObjectKey:          objectInfo.Key,
+		Metadata:           map[string]any{"source": tree.Source, "batchSize": len(tree.Batches)},
+		CreateTime:         now,
+	}
+	if err := s.repository.UpsertRunArtifact(ctx, dbArtifact); err != nil {
+		return nil, fmt.Errorf("registering run memory archive: %w", err)
+	}
+
+	artifact := convertRunArtifact(dbArtifact)
+	artifact.DownloadURL, err = s.minioClient.GetDownloadURL(ctx, dbArtifact.ObjectKey, dbArtifact.Name, artifactURLExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("generating download URL: %w", err)
+	}
+
+	return artifact, nil
+}
+
+func (s *service) archiveRunMemory(run datamodel.PipelineRun, tree *memory.MemoryTree) ([]byte, error) {
+	pbRun, err := s.convertPipelineRunToPB(run)
+	if err != nil {
+		return nil, err
+	}
+	runJSON, err := protojson.Marshal(pbRun)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling run: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	w, err := zw.Create(memoryExportRunFile)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(runJSON); err != nil {
+		return nil, err
+	}
+
+	w, err = zw.Create(memoryExportMemoryFile)
+	if err != nil {
+		return nil, err
+	}
+	enc := json.NewEncoder(w)
+	for idx, batch := range tree.Batches {
+		line := struct {
+			BatchIndex int `json:"batchIndex"`
+			*memory.BatchTree
+		}{BatchIndex: idx, BatchTree: batch}
+		if err := enc.Encode(line); err != nil {
+			return nil, fmt.Errorf("encoding batch %d: %w", idx, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// GetPipelineRunMemory returns the memory tree of a pipeline run, so users
+// can check the value each reference of the recipe resolved to. The memory
+// is only available while the run is retained in the process or in the
+// persistence backend.
+func (s *service) GetPipelineRunMemory(ctx context.Context, namespaceID, pipelineID, pipelineRunID string) (*memory.MemoryTree, error) {
+	run, err := s.getViewablePipelineRun(ctx, namespaceID, pipelineID, pipelineRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := s.memory.InspectWorkflowMemory(ctx, run.PipelineTriggerUID.String())
+	if err != nil {
+		if errors.Is(err, memory.ErrWorkflowMemoryNotFound) {
+			return nil, errmsg.AddMessage(
+				fmt.Errorf("%w: %w", errdomain.ErrNotFound, err),
+				"The memory of this run is no longer available.",
+			)
+		}
+		return nil, fmt.Errorf("inspecting run memory: %w", err)
+	}
+
+	return tree, nil
+}
+
+// GetTriggerMemorySnapshot returns the value a path resolves to in the
+// memory of a pipeline run, so the intermediate state of a long run can be
+// inspected before it finishes.
+func (s *service) GetTriggerMemorySnapshot(ctx context.Context, namespaceID, pipelineID, pipelineRunID, path string) (*memory.MemorySnapshot, error) {
+	run, err := s.getViewablePipelineRun(ctx, namespaceID, pipelineID, pipelineRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.memory.SnapshotWorkflowMemory(ctx, run.PipelineTriggerUID.String(), path)
+	if err != nil {
+		switch {
+		case errors.Is(err, memory.ErrWorkflowMemoryNotFound):
+			return nil, errmsg.AddMessage(
+				fmt.Errorf("%w: %w", errdomain.ErrNotFound, err),
+				"The memory of this run is no longer available.",
+			)
+		case errors.Is(err, memory.ErrForbiddenSnapshotPath):
+			return nil, errmsg.AddMessage(
+				fmt.Errorf("%w: %w", errdomain.ErrInvalidArgument, err),
+				"Secrets, connections and component setups can't be inspected.",
+			)
+		}
+		return nil, fmt.Errorf("inspecting run memory: %w", err)
+	}
+
+	return snapshot, nil
+}
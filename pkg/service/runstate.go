@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/pipeline-backend/pkg/worker"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// ComponentRunState is the live state of a component in a pipeline run.
+type ComponentRunState struct {
+	ComponentID  string     `json:"componentId"`
+	Status       string     `json:"status"`
+	StartTime    *time.Time `json:"startTime,omitempty"`
+	CompleteTime *time.Time `json:"completeTime,omitempty"`
+	ElapsedMs    int64      `json:"elapsedMs"`
+
+	// Error is the failure of the component, if any.
+	Error *memory.ComponentError `json:"error,omitempty"`
+}
+
+// PipelineRunState is the live state of a pipeline run. Large batches are
+// split in chunks, each of them with its own component states.
+type PipelineRunState struct {
+	PipelineRunUID string               `json:"pipelineRunUid"`
+	StartTime      time.Time            `json:"startTime"`
+	ElapsedMs      int64                `json:"elapsedMs"`
+	TimedOut       bool                 `json:"timedOut,omitempty"`
+	Components     []*ComponentRunState `json:"components"`
+	Chunks         []*PipelineRunState  `json:"chunks,omitempty"`
+}
+
+// GetPipelineRunState returns what a pipeline run is doing right now. The
+// state is read from the workflow that orchestrates the run, so it's only
+// available while the workflow history is retained.
+func (s *service) GetPipelineRunState(ctx context.Context, namespaceID, pipelineID, pipelineRunID string) (*PipelineRunState, error) {
+	run, err := s.getViewablePipelineRun(ctx, namespaceID, pipelineID, pipelineRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.queryRunState(ctx, s.temporalFor(run.TemporalCluster), run.PipelineTriggerUID.String(), time.Now())
+}
+
+func (s *service) queryRunState(ctx context.Context, tc client.Client, workflowID string, now time.Time) (*PipelineRunState, error) {
+	resp, err := tc.QueryWorkflow(ctx, workflowID, "", worker.RunStateQuery)
+	if err != nil {
+		if errors.As(err, new(*serviceerror.NotFound)) {
+			return nil, errmsg.AddMessage(
+				fmt.Errorf("%w: run workflow not found", errdomain.ErrNotFound),
+				"The state of this run is no longer available.",
+			)
+		}
+		return nil, fmt.Errorf("querying run state: %w", err)
+	}
+
+	wfState := new(worker.RunState)
+	if err := resp.Get(wfState); err != nil {
+		return nil, fmt.Errorf("reading run state: %w", err)
+	}
+
+	state := &PipelineRunState{
+		PipelineRunUID: workflowID,
+		StartTime:      wfState.StartTime,
+		ElapsedMs:      now.Sub(wfState.StartTime).Milliseconds(),
+		TimedOut:       wfState.TimedOut,
+		Components:     make([]*ComponentRunState, 0, len(wfState.Components)),
+	}
+
+	for compID, c := range wfState.Components {
+		compState := &ComponentRunState{ComponentID: compID, Status: c.Status, Error: c.Error}
+		if !c.StartTime.IsZero() {
+			end := now
+			if !c.CompleteTime.IsZero() {
+				end = c.CompleteTime
+				compState.CompleteTime = &c.CompleteTime
+			}
+			compState.StartTime = &c.StartTime
+			compState.ElapsedMs = end.Sub(c.StartTime).Milliseconds()
+		}
+		state.Components = append(state.Components, compState)
+	}
+	sort.Slice(state.Components, func(i, j int) bool {
+		return state.Components[i].ComponentID < state.Components[j].ComponentID
+	})
+
+	for _, chunkID := range wfState.ChunkWorkflowIDs {
+		chunk, err := s.queryRunState(ctx, tc, chunkID, now)
+		if err != nil {
+			return nil, fmt.Errorf("querying chunk %s: %w", chunkID, err)
+		}
+		state.Chunks = append(state.Chunks, chunk)
+	}
+
+	return state, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+	"github.com/instill-ai/pipeline-backend/pkg/utils"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+)
+
+const (
+	defaultScheduleRunPreviews = 5
+	maxScheduleHistorySize     = 100
+)
+
+// SchedulePreview holds the upcoming run times of a cron expression.
+type SchedulePreview struct {
+	ID           string      `json:"id,omitempty"`
+	Cron         string      `json:"cron"`
+	Timezone     string      `json:"timezone,omitempty"`
+	NextRunTimes []time.Time `json:"nextRunTimes"`
+
+	// Overlap and CatchupWindow are only informed in the schedule history.
+	Overlap       string `json:"overlap,omitempty"`
+	CatchupWindow string `json:"catchupWindow,omitempty"`
+}
+
+// ScheduleRun is an execution of a pipeline schedule.
+type ScheduleRun struct {
+	PipelineRunUID string     `json:"pipelineRunUid"`
+	Status         string     `json:"status"`
+	StartTime      time.Time  `json:"startTime"`
+	CompleteTime   *time.Time `json:"completeTime,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+// PipelineScheduleHistory contains the schedules of a pipeline, with their
+// upcoming run times, and the runs they have triggered.
+type PipelineScheduleHistory struct {
+	ScheduleID string             `json:"scheduleId"`
+	Schedules  []*SchedulePreview `json:"schedules"`
+	Runs       []*ScheduleRun     `json:"runs"`
+}
+
+// PreviewSchedule validates a cron expression and computes its next run
+// times.
+func (s *service) PreviewSchedule(_ context.Context, preview *SchedulePreview, count int) (*SchedulePreview, error) {
+	if count <= 0 {
+		count = defaultScheduleRunPreviews
+	}
+	if count > recipe.MaxScheduleRunPreviews {
+		return nil, fmt.Errorf("%w: count can't exceed %d", errdomain.ErrInvalidArgument, recipe.MaxScheduleRunPreviews)
+	}
+
+	nextRunTimes, err := recipe.NextScheduleTimes(preview.Cron, preview.Timezone, time.Now(), count)
+	if err != nil {
+		return nil, errmsg.AddMessage(
+			fmt.Errorf("%w: %w", errdomain.ErrInvalidArgument, err),
+			fmt.Sprintf("Invalid schedule: %s.", err),
+		)
+	}
+
+	preview.NextRunTimes = nextRunTimes
+	return preview, nil
+}
+
+// GetPipelineScheduleHistory returns the schedules of a pipeline and the runs
+// they have triggered, latest first. The runs are billed to the pipeline
+// owner, so only the owner can list them.
+func (s *service) GetPipelineScheduleHistory(ctx context.Context, namespaceID, pipelineID string, pageSize int) (*PipelineScheduleHistory, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	dbPipeline, err := s.repository.GetNamespacePipelineByID(ctx, ns.Permalink(), pipelineID, false, true)
+	if err != nil {
+		return nil, errdomain.ErrNotFound
+	}
+
+	if granted, err := s.aclClient.CheckPermission(ctx, "pipeline", dbPipeline.UID, "reader"); err != nil {
+		return nil, err
+	} else if !granted {
+		return nil, errdomain.ErrNotFound
+	}
+
+	history := &PipelineScheduleHistory{
+		ScheduleID: recipe.ScheduleID(dbPipeline.UID, uuid.Nil),
+		Schedules:  []*SchedulePreview{},
+		Runs:       []*ScheduleRun{},
+	}
+
+	if dbPipeline.Recipe != nil && dbPipeline.Recipe.On != nil {
+		now := time.Now()
+		for id, sched := range dbPipeline.Recipe.On.Schedule {
+			preview := &SchedulePreview{
+				ID:            id,
+				Cron:          sched.Cron,
+				Timezone:      sched.Timezone,
+				Overlap:       sched.Overlap,
+				CatchupWindow: sched.CatchupWindow,
+			}
+
+			// Invalid expressions are rejected when the recipe is validated,
+			// but older recipes might still contain them.
+			preview.NextRunTimes, _ = recipe.NextScheduleTimes(sched.Cron, sched.Timezone, now, defaultScheduleRunPreviews)
+			history.Schedules = append(history.Schedules, preview)
+		}
+		sort.Slice(history.Schedules, func(i, j int) bool {
+			return history.Schedules[i].ID < history.Schedules[j].ID
+		})
+	}
+
+	requesterUID, _ := utils.GetRequesterUIDAndUserUID(ctx)
+	if dbPipeline.OwnerUID().String() != requesterUID {
+		return history, nil
+	}
+
+	if pageSize <= 0 || pageSize > maxScheduleHistorySize {
+		pageSize = maxScheduleHistorySize
+	}
+
+	runs, err := s.repository.ListPipelineRunsByScheduleID(ctx, history.ScheduleID, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("listing schedule runs: %w", err)
+	}
+
+	for _, run := range runs {
+		scheduleRun := &ScheduleRun{
+			PipelineRunUID: run.PipelineTriggerUID.String(),
+			Status:         runpb.RunStatus(run.Status).String(),
+			StartTime:      run.StartedTime,
+			Error:          run.Error.String,
+		}
+		if run.CompletedTime.Valid {
+			scheduleRun.CompleteTime = &run.CompletedTime.Time
+		}
+		history.Runs = append(history.Runs, scheduleRun)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestService_PreviewSchedule(t *testing.T) {
+	c := qt.New(t)
+	s := &service{}
+
+	c.Run("ok - timezone", func(c *qt.C) {
+		got, err := s.PreviewSchedule(context.Background(), &SchedulePreview{
+			Cron:     "30 9 * * 1-5",
+			Timezone: "Europe/Paris",
+		}, 3)
+		c.Assert(err, qt.IsNil)
+		c.Assert(got.NextRunTimes, qt.HasLen, 3)
+
+		for i, rt := range got.NextRunTimes {
+			c.Check(rt.Location().String(), qt.Equals, "Europe/Paris")
+			c.Check(rt.Hour(), qt.Equals, 9)
+			c.Check(rt.Minute(), qt.Equals, 30)
+			c.Check(rt.Weekday(), qt.Not(qt.Equals), time.Saturday)
+			c.Check(rt.Weekday(), qt.Not(qt.Equals), time.Sunday)
+			if i > 0 {
+				c.Check(rt.After(got.NextRunTimes[i-1]), qt.IsTrue)
+			}
+		}
+	})
+
+	c.Run("ok - default count", func(c *qt.C) {
+		got, err := s.PreviewSchedule(context.Background(), &SchedulePreview{Cron: "@hourly"}, 0)
+		c.Assert(err, qt.IsNil)
+		c.Check(got.NextRunTimes, qt.HasLen, defaultScheduleRunPreviews)
+	})
+
+	c.Run("nok - invalid cron", func(c *qt.C) {
+		_, err := s.PreviewSchedule(context.Background(), &SchedulePreview{Cron: "61 * * * *"}, 1)
+		c.Check(err, qt.ErrorMatches, `invalid: invalid cron expression "61 \* \* \* \*".*`)
+	})
+
+	c.Run("nok - invalid timezone", func(c *qt.C) {
+		_, err := s.PreviewSchedule(context.Background(), &SchedulePreview{Cron: "@daily", Timezone: "Mars/Olympus"}, 1)
+		c.Check(err, qt.ErrorMatches, `invalid: invalid timezone "Mars/Olympus"`)
+	})
+
+	c.Run("nok - count too high", func(c *qt.C) {
+		_, err := s.PreviewSchedule(context.Background(), &SchedulePreview{Cron: "@daily"}, 1000)
+		c.Check(err, qt.ErrorMatches, "invalid: count can't exceed 100")
+	})
+}
@@ -106,6 +106,9 @@ func (s *service) DeleteNamespaceSecretByID(ctx context.Context, ns resource.Nam
 	if err := s.checkNamespacePermission(ctx, ns); err != nil {
 		return err
 	}
+	if err := s.checkDependents(ctx, ns, DependencySecret, id); err != nil {
+		return err
+	}
 	ownerPermalink := ns.Permalink()
 
 	return s.repository.DeleteNamespaceSecretByID(ctx, ownerPermalink, id)
@@ -157,6 +160,9 @@ func (s *service) checkSecret(ctx context.Context, components datamodel.Componen
 					return err
 				}
 			}
+			if err := s.checkSecret(ctx, comp.OnError); err != nil {
+				return err
+			}
 
 		case datamodel.Iterator:
 			err := s.checkSecret(ctx, comp.Component)
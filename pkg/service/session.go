@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/instill-ai/pipeline-backend/pkg/resource"
+	"github.com/instill-ai/pipeline-backend/pkg/session"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// AppendSessionMessages adds messages to the history of a conversation
+// session, creating the session if it doesn't exist.
+func (s *service) AppendSessionMessages(ctx context.Context, namespaceID, sessionID string, messages []session.Message) error {
+	ns, err := s.getSessionNamespace(ctx, namespaceID, sessionID)
+	if err != nil {
+		return err
+	}
+	if err := session.ValidateMessages(messages); err != nil {
+		return err
+	}
+
+	return s.sessions.Append(ctx, ns.NsUID, sessionID, messages)
+}
+
+// ListSessionMessages returns the last messages of a conversation session,
+// oldest first. A non-positive limit returns the whole history.
+func (s *service) ListSessionMessages(ctx context.Context, namespaceID, sessionID string, limit int) ([]session.Message, error) {
+	ns, err := s.getSessionNamespace(ctx, namespaceID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sessions.List(ctx, ns.NsUID, sessionID, limit)
+}
+
+// DeleteSession removes the history of a conversation session.
+func (s *service) DeleteSession(ctx context.Context, namespaceID, sessionID string) error {
+	ns, err := s.getSessionNamespace(ctx, namespaceID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	return s.sessions.Delete(ctx, ns.NsUID, sessionID)
+}
+
+func (s *service) getSessionNamespace(ctx context.Context, namespaceID, sessionID string) (resource.Namespace, error) {
+	if s.sessions == nil {
+		return resource.Namespace{}, errmsg.AddMessage(
+			fmt.Errorf("%w: session store isn't configured", errdomain.ErrNotFound),
+			"Conversation sessions aren't enabled in this deployment.",
+		)
+	}
+	if err := session.ValidateSessionID(sessionID); err != nil {
+		return resource.Namespace{}, err
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return ns, fmt.Errorf("fetching namespace: %w", err)
+	}
+
+	if err := s.checkNamespacePermission(ctx, ns); err != nil {
+		return ns, fmt.Errorf("checking namespace permissions: %w", err)
+	}
+
+	return ns, nil
+}
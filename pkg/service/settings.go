@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/resource"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+// NamespaceSettings is the API representation of the defaults that the
+// recipes of a namespace inherit unless they override them.
+type NamespaceSettings struct {
+	MaxDuration      string                 `json:"maxDuration,omitempty"`
+	ComponentTimeout string                 `json:"componentTimeout,omitempty"`
+	Retry            *datamodel.RetryPolicy `json:"retry,omitempty"`
+	ErrorPolicy      string                 `json:"errorPolicy,omitempty"`
+	CacheTTL         string                 `json:"cacheTtl,omitempty"`
+	// AllowedComponents and BlockedComponents hold the IDs of the component
+	// definitions that the pipelines can and can't use, optionally followed
+	// by a version or a version prefix (e.g. `openai@1`). Every component is
+	// allowed when the allow list is empty.
+	AllowedComponents []string  `json:"allowedComponents"`
+	BlockedComponents []string  `json:"blockedComponents"`
+	UpdateTime        time.Time `json:"updateTime,omitempty"`
+}
+
+// GetNamespaceSettings returns the settings of a namespace. A namespace that
+// never set them has empty settings.
+func (s *service) GetNamespaceSettings(ctx context.Context, namespaceID string) (*NamespaceSettings, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching namespace: %w", err)
+	}
+	if err := s.checkNamespacePermission(ctx, ns); err != nil {
+		return nil, fmt.Errorf("checking namespace permissions: %w", err)
+	}
+
+	settings, err := s.repository.GetNamespaceSettings(ctx, ns.NsUID)
+	if errors.Is(err, errdomain.ErrNotFound) {
+		return &NamespaceSettings{AllowedComponents: []string{}, BlockedComponents: []string{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return convertNamespaceSettingsToAPI(settings), nil
+}
+
+// UpdateNamespaceSettings replaces the settings of a namespace. In
+// organizations, only the admins can update them. The new settings apply to
+// the runs triggered after the update.
+func (s *service) UpdateNamespaceSettings(ctx context.Context, namespaceID string, settings *NamespaceSettings) (*NamespaceSettings, error) {
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching namespace: %w", err)
+	}
+	if err := s.checkNamespaceAdminPermission(ctx, ns); err != nil {
+		return nil, fmt.Errorf("checking namespace permissions: %w", err)
+	}
+
+	validationErrors := []*pb.ErrPipelineValidation{}
+	checkRunDefaults(settings.MaxDuration, settings.ComponentTimeout, settings.Retry, settings.ErrorPolicy, &validationErrors)
+	if settings.CacheTTL != "" {
+		if d, err := data.ParseDuration(settings.CacheTTL); err != nil || d.GetDuration() <= 0 {
+			validationErrors = append(validationErrors, &pb.ErrPipelineValidation{
+				Location: "cacheTtl",
+				Error:    "cache-ttl must be a positive duration, e.g. 30m or 24h",
+			})
+		}
+	}
+	s.checkComponentPolicyEntries("allowedComponents", settings.AllowedComponents, &validationErrors)
+	s.checkComponentPolicyEntries("blockedComponents", settings.BlockedComponents, &validationErrors)
+	if len(validationErrors) > 0 {
+		msgs := make([]string, 0, len(validationErrors))
+		for _, e := range validationErrors {
+			msgs = append(msgs, e.Error)
+		}
+		return nil, fmt.Errorf("%w: %s", errdomain.ErrInvalidArgument, strings.Join(msgs, "; "))
+	}
+
+	dbSettings := &datamodel.NamespaceSettings{
+		NamespaceUID:      ns.NsUID,
+		MaxDuration:       settings.MaxDuration,
+		ComponentTimeout:  settings.ComponentTimeout,
+		ErrorPolicy:       settings.ErrorPolicy,
+		CacheTTL:          settings.CacheTTL,
+		AllowedComponents: settings.AllowedComponents,
+		BlockedComponents: settings.BlockedComponents,
+	}
+	if settings.Retry != nil {
+		dbSettings.RetryMaxAttempts = settings.Retry.MaxAttempts
+		dbSettings.RetryInitialInterval = settings.Retry.InitialInterval
+	}
+	if dbSettings.AllowedComponents == nil {
+		dbSettings.AllowedComponents = []string{}
+	}
+	if dbSettings.BlockedComponents == nil {
+		dbSettings.BlockedComponents = []string{}
+	}
+
+	if err := s.repository.UpsertNamespaceSettings(ctx, dbSettings); err != nil {
+		return nil, err
+	}
+
+	return convertNamespaceSettingsToAPI(dbSettings), nil
+}
+
+// checkComponentPolicyEntries validates the entries of a component policy,
+// which reference a component definition and optionally a version.
+func (s *service) checkComponentPolicyEntries(loc string, entries []string, validationErrors *[]*pb.ErrPipelineValidation) {
+	for _, entry := range entries {
+		compType, version, hasVersion := strings.Cut(entry, "@")
+		if _, err := s.component.GetDefinitionByID(compType, nil, nil); err != nil {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc,
+				Error:    fmt.Sprintf("component %q doesn't exist", compType),
+			})
+			continue
+		}
+		if hasVersion && version == "" {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc,
+				Error:    fmt.Sprintf("entry %q has an empty version", entry),
+			})
+		}
+	}
+}
+
+// componentPolicyViolations returns the components of a recipe that the
+// component policy of a namespace doesn't allow.
+func (s *service) componentPolicyViolations(ctx context.Context, nsUID uuid.UUID, r *datamodel.Recipe) ([]datamodel.ComponentViolation, error) {
+	if r == nil || len(r.Component) == 0 {
+		return nil, nil
+	}
+
+	settings, err := s.repository.GetNamespaceSettings(ctx, nsUID)
+	if errors.Is(err, errdomain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching namespace settings: %w", err)
+	}
+
+	return settings.ComponentViolations(r, s.componentVersion), nil
+}
+
+// checkComponentPolicy rejects the recipes that use components that aren't
+// allowed in a namespace.
+func (s *service) checkComponentPolicy(ctx context.Context, nsUID uuid.UUID, r *datamodel.Recipe) error {
+	violations, err := s.componentPolicyViolations(ctx, nsUID, r)
+	if err != nil || len(violations) == 0 {
+		return err
+	}
+
+	msgs := make([]string, 0, len(violations))
+	for _, v := range violations {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", v.Location, v.Message()))
+	}
+	err = fmt.Errorf("%w: %s", errdomain.ErrInvalidArgument, strings.Join(msgs, "; "))
+	return errmsg.AddMessage(err, "The recipe uses components that aren't allowed in this namespace: "+strings.Join(msgs, "; ")+".")
+}
+
+// componentVersion returns the version of a component definition in the
+// deployment, or an empty string if it doesn't exist.
+func (s *service) componentVersion(compType string) string {
+	def, err := s.component.GetDefinitionByID(compType, nil, nil)
+	if err != nil {
+		return ""
+	}
+	return def.GetVersion()
+}
+
+// checkNamespaceAdminPermission checks that the requester can administrate
+// a namespace. Users administrate their own namespace.
+func (s *service) checkNamespaceAdminPermission(ctx context.Context, ns resource.Namespace) error {
+	if ns.NsType != "organizations" {
+		return s.checkNamespacePermission(ctx, ns)
+	}
+
+	granted, err := s.aclClient.CheckPermission(ctx, "organization", ns.NsUID, "admin")
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return errdomain.ErrUnauthorized
+	}
+
+	return nil
+}
+
+func convertNamespaceSettingsToAPI(s *datamodel.NamespaceSettings) *NamespaceSettings {
+	settings := &NamespaceSettings{
+		MaxDuration:       s.MaxDuration,
+		ComponentTimeout:  s.ComponentTimeout,
+		ErrorPolicy:       s.ErrorPolicy,
+		CacheTTL:          s.CacheTTL,
+		AllowedComponents: []string(s.AllowedComponents),
+		BlockedComponents: []string(s.BlockedComponents),
+		UpdateTime:        s.UpdateTime,
+	}
+	if s.RetryMaxAttempts > 0 || s.RetryInitialInterval != "" {
+		settings.Retry = &datamodel.RetryPolicy{
+			MaxAttempts:     s.RetryMaxAttempts,
+			InitialInterval: s.RetryInitialInterval,
+		}
+	}
+	if settings.AllowedComponents == nil {
+		settings.AllowedComponents = []string{}
+	}
+	if settings.BlockedComponents == nil {
+		settings.BlockedComponents = []string{}
+	}
+
+	return settings
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frankban/quicktest"
+	"github.com/gofrs/uuid"
+	"github.com/gojuno/minimock/v3"
+
+	"github.com/instill-ai/pipeline-backend/config"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/mock"
+
+	componentstore "github.com/instill-ai/pipeline-backend/pkg/component/store"
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+func TestService_CheckComponentPolicy(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+	ctx := context.Background()
+
+	repo := mock.NewRepositoryMock(mc)
+	s := &service{
+		repository: repo,
+		component:  componentstore.Init(nil, config.Config.Connector.Secrets, nil),
+	}
+
+	nsUID := uuid.Must(uuid.NewV4())
+	r := &datamodel.Recipe{
+		Component: datamodel.ComponentMap{
+			"parse": {Type: "json", Task: "TASK_MARSHAL"},
+			"split": {Type: "text", Task: "TASK_CHUNK_TEXT"},
+		},
+	}
+
+	c.Run("ok - no settings", func(c *quicktest.C) {
+		repo.GetNamespaceSettingsMock.Return(nil, errdomain.ErrNotFound)
+		c.Check(s.checkComponentPolicy(ctx, nsUID, r), quicktest.IsNil)
+	})
+
+	c.Run("ok - allowed", func(c *quicktest.C) {
+		repo.GetNamespaceSettingsMock.Return(&datamodel.NamespaceSettings{
+			AllowedComponents: []string{"json", "text"},
+		}, nil)
+		c.Check(s.checkComponentPolicy(ctx, nsUID, r), quicktest.IsNil)
+	})
+
+	c.Run("nok - blocked", func(c *quicktest.C) {
+		repo.GetNamespaceSettingsMock.Return(&datamodel.NamespaceSettings{
+			AllowedComponents: []string{"json", "text"},
+			BlockedComponents: []string{"text"},
+		}, nil)
+		err := s.checkComponentPolicy(ctx, nsUID, r)
+		c.Check(errors.Is(err, errdomain.ErrInvalidArgument), quicktest.IsTrue)
+		c.Check(err, quicktest.ErrorMatches, ".*component.split: component text@.* isn't allowed in this namespace")
+	})
+
+	c.Run("nok - version not allowed", func(c *quicktest.C) {
+		repo.GetNamespaceSettingsMock.Return(&datamodel.NamespaceSettings{
+			AllowedComponents: []string{"json@99", "text"},
+		}, nil)
+		err := s.checkComponentPolicy(ctx, nsUID, r)
+		c.Check(err, quicktest.ErrorMatches, ".*component.parse: component json@.* isn't allowed in this namespace")
+	})
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/config"
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+	pipelinepb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+func TestCheckTriggerDataLimits(t *testing.T) {
+	c := qt.New(t)
+
+	cfg := config.Config.Server.TriggerInput
+	c.Cleanup(func() { config.Config.Server.TriggerInput = cfg })
+	config.Config.Server.TriggerInput.MaxDepth = 3
+	config.Config.Server.TriggerInput.MaxArrayLength = 4
+	config.Config.Server.TriggerInput.MaxSize = 1
+
+	triggerData := func(c *qt.C, variable map[string]any) []*pipelinepb.TriggerData {
+		s, err := structpb.NewStruct(variable)
+		c.Assert(err, qt.IsNil)
+		return []*pipelinepb.TriggerData{{Variable: s}}
+	}
+
+	testCases := []struct {
+		name     string
+		variable map[string]any
+		wantPath string
+		limit    string
+	}{
+		{
+			name: "ok",
+			variable: map[string]any{
+				"texts": []any{"a", "b", "c", "d"},
+				"json":  map[string]any{"a": map[string]any{"b": 1}},
+			},
+		},
+		{
+			name: "nok - depth",
+			variable: map[string]any{
+				"json": map[string]any{"a": map[string]any{"b": []any{1}}},
+			},
+			wantPath: "json.a.b",
+			limit:    data.LimitDepth,
+		},
+		{
+			name: "nok - array length",
+			variable: map[string]any{
+				"texts": []any{"a", "b", "c", "d", "e"},
+			},
+			wantPath: "texts",
+			limit:    data.LimitArrayLength,
+		},
+		{
+			name: "nok - size",
+			variable: map[string]any{
+				"text": strings.Repeat("a", 1024),
+			},
+			limit: data.LimitBytes,
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Run(tc.name, func(c *qt.C) {
+			err := checkTriggerDataLimits(triggerData(c, tc.variable))
+			if tc.limit == "" {
+				c.Check(err, qt.IsNil)
+				return
+			}
+
+			c.Check(err, qt.ErrorIs, errdomain.ErrInvalidArgument)
+
+			var limitErr *data.LimitError
+			c.Assert(err, qt.ErrorAs, &limitErr)
+			c.Check(limitErr.Limit, qt.Equals, tc.limit)
+			c.Check(limitErr.Path, qt.Equals, tc.wantPath)
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/constant"
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+
+	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+// Kinds of the values that flow between components. The formats of the data
+// specifications are reduced to these kinds in order to compare them. An
+// empty kind means the format is unknown and any value is compatible with
+// it.
+const (
+	kindString   = "string"
+	kindNumber   = "number"
+	kindBoolean  = "boolean"
+	kindObject   = "object"
+	kindImage    = "image"
+	kindAudio    = "audio"
+	kindVideo    = "video"
+	kindDocument = "document"
+	kindAny      = "*"
+
+	kindArrayPrefix = "array:"
+)
+
+// dataSpecLookup returns the data specification of a component in a recipe,
+// or nil if it's unknown.
+type dataSpecLookup func(comp *datamodel.Component) *pb.DataSpecification
+
+// recipeTypeWarnings infers the type of the values referenced in the
+// component inputs and reports the references whose type isn't accepted by
+// the input field, e.g. an object referenced in a text field. These aren't
+// errors, as some of the values can be converted at runtime, but they
+// usually make the trigger fail midway.
+//
+// Only the inputs that are a single reference are checked, as the
+// interpolated strings are always rendered as text.
+func recipeTypeWarnings(r *datamodel.Recipe, specOf dataSpecLookup) []*pb.ErrPipelineValidation {
+	warnings := []*pb.ErrPipelineValidation{}
+	if r == nil {
+		return warnings
+	}
+
+	ids := make([]string, 0, len(r.Component))
+	for id := range r.Component {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		comp := r.Component[id]
+		if comp.Type == datamodel.Iterator || comp.Type == datamodel.Approval {
+			continue
+		}
+		spec := specOf(comp)
+		if spec == nil || spec.Input == nil {
+			continue
+		}
+		input, ok := comp.Input.(map[string]any)
+		if !ok {
+			continue
+		}
+		fields := spec.Input.Fields["properties"].GetStructValue().GetFields()
+
+		keys := make([]string, 0, len(input))
+		for k := range input {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			ref, ok := singleReference(input[k])
+			if !ok {
+				continue
+			}
+			field, ok := fields[k]
+			if !ok {
+				continue
+			}
+
+			got := inferReferenceKind(r, ref, specOf)
+			accepted := acceptedKinds(field.GetStructValue())
+			if kindAccepted(got, accepted) {
+				continue
+			}
+
+			warnings = append(warnings, &pb.ErrPipelineValidation{
+				Location: "component." + id + ".input." + k,
+				Error: fmt.Sprintf("type mismatch: ${%s} is %s but the field accepts %s",
+					ref, got, strings.Join(accepted, ", ")),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// singleReference returns the path of a value that is a single reference,
+// without its formatting functions.
+func singleReference(v any) (string, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return "", false
+	}
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "${") || !strings.HasSuffix(s, "}") || strings.Count(s, "${") != 1 {
+		return "", false
+	}
+
+	ref := strings.TrimSpace(s[2 : len(s)-1])
+	if path := recipe.TrimFormatPipe(ref); path != ref {
+		// The formatting functions and the fallbacks change the type of
+		// the value.
+		return "", false
+	}
+	return ref, true
+}
+
+// inferReferenceKind returns the kind of the value a reference resolves to,
+// or an empty kind if it can't be inferred.
+func inferReferenceKind(r *datamodel.Recipe, ref string, specOf dataSpecLookup) string {
+	path, err := data.StandardizePath(strings.ReplaceAll(ref, " ", ""))
+	if err != nil || len(path) < 2 {
+		return ""
+	}
+	segs := strings.Split(path[1:len(path)-1], "][")
+	if len(segs) < 2 {
+		return ""
+	}
+	for i, seg := range segs {
+		segs[i] = strings.Trim(seg, `"`)
+	}
+
+	if segs[0] == constant.SegVariable {
+		v, ok := r.Variable[segs[1]]
+		if !ok || len(segs) > 2 {
+			return ""
+		}
+		return formatKind(v.InstillFormat)
+	}
+
+	comp, ok := r.Component[segs[0]]
+	if !ok || segs[1] != constant.SegOutput {
+		return ""
+	}
+	var walk *structpb.Struct
+	switch comp.Type {
+	case datamodel.Approval:
+		walk = approvalDataSpecification().Output
+	case datamodel.Iterator:
+		return ""
+	default:
+		spec := specOf(comp)
+		if spec == nil {
+			return ""
+		}
+		walk = spec.Output
+	}
+
+	for _, seg := range segs[2:] {
+		if walk == nil {
+			return ""
+		}
+		if _, err := strconv.Atoi(seg); err == nil {
+			walk = walk.Fields["items"].GetStructValue()
+			continue
+		}
+		walk = walk.Fields["properties"].GetStructValue().GetFields()[seg].GetStructValue()
+	}
+
+	return schemaKind(walk)
+}
+
+// schemaKind returns the kind of the values of a data specification field.
+func schemaKind(s *structpb.Struct) string {
+	if s == nil {
+		return ""
+	}
+	if f := s.Fields["instillFormat"].GetStringValue(); f != "" {
+		return formatKind(f)
+	}
+
+	switch s.Fields["type"].GetStringValue() {
+	case "string":
+		return kindString
+	case "integer", "number":
+		return kindNumber
+	case "boolean":
+		return kindBoolean
+	case "object":
+		return kindObject
+	case "array":
+		if k := schemaKind(s.Fields["items"].GetStructValue()); k != "" && k != kindAny {
+			return kindArrayPrefix + k
+		}
+	}
+	return ""
+}
+
+// acceptedKinds returns the kinds an input field accepts.
+func acceptedKinds(s *structpb.Struct) []string {
+	if s == nil {
+		return nil
+	}
+
+	formats := s.Fields["instillAcceptFormats"].GetListValue().GetValues()
+	if len(formats) == 0 {
+		if k := schemaKind(s); k != "" {
+			return []string{k}
+		}
+		return nil
+	}
+
+	kinds := make([]string, 0, len(formats))
+	for _, f := range formats {
+		kinds = append(kinds, formatKind(f.GetStringValue()))
+	}
+	return kinds
+}
+
+// formatKind reduces a format of the data specifications (e.g. image/png,
+// array:string or semi-structured/object) to its kind.
+func formatKind(format string) string {
+	if inner, ok := strings.CutPrefix(format, kindArrayPrefix); ok {
+		switch k := formatKind(inner); k {
+		case "", kindAny:
+			return k
+		default:
+			return kindArrayPrefix + k
+		}
+	}
+
+	switch format {
+	case "*", "*/*", "semi-structured/*", "json", "semi-structured/json":
+		return kindAny
+	case "string", "text", "text/*", "text/plain", "text/markdown", "text/html":
+		return kindString
+	case "integer", "number":
+		return kindNumber
+	case "boolean":
+		return kindBoolean
+	case "object", "semi-structured/object", "structured/*":
+		return kindObject
+	}
+
+	switch t, _, _ := strings.Cut(format, "/"); t {
+	case kindImage, kindAudio, kindVideo, kindDocument:
+		return t
+	}
+	return ""
+}
+
+// kindAccepted tells whether a value of some kind can be passed to a field
+// that accepts some other kinds. The unknown kinds are always accepted, as
+// the check is only meant to find clear mismatches.
+func kindAccepted(got string, accepted []string) bool {
+	if got == "" || got == kindAny || len(accepted) == 0 {
+		return true
+	}
+
+	for _, a := range accepted {
+		switch {
+		case a == "" || a == kindAny || a == got:
+			return true
+		case a == kindString && (got == kindNumber || got == kindBoolean):
+			// Scalars are converted to their text representation.
+			return true
+		}
+	}
+	return false
+}
+
+// reportRecipeTypeWarnings infers the types of the references in a recipe
+// that is being saved and sends the mismatches to the client in the
+// response headers, so they're surfaced before a trigger fails.
+func (s *service) reportRecipeTypeWarnings(ctx context.Context, r *datamodel.Recipe) {
+	warnings := recipeTypeWarnings(r, s.componentDataSpec)
+	if len(warnings) == 0 {
+		return
+	}
+
+	md := metadata.MD{}
+	for _, w := range warnings {
+		md.Append(constant.HeaderRecipeWarningKey, w.Location+": "+w.Error)
+	}
+	if err := grpc.SetHeader(ctx, md); err != nil {
+		s.log.Warn("Couldn't send recipe type warnings", zap.Error(err))
+	}
+}
+
+// componentDataSpec returns the data specification of the task of a
+// component.
+func (s *service) componentDataSpec(comp *datamodel.Component) *pb.DataSpecification {
+	def, err := s.component.GetDefinitionByID(comp.Type, nil, nil)
+	if err != nil || def.GetSpec() == nil {
+		return nil
+	}
+	return def.GetSpec().GetDataSpecifications()[comp.Task]
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+
+	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+func TestRecipeTypeWarnings(t *testing.T) {
+	c := qt.New(t)
+
+	mustStruct := func(m map[string]any) *structpb.Struct {
+		s, err := structpb.NewStruct(m)
+		c.Assert(err, qt.IsNil)
+		return s
+	}
+
+	specs := map[string]*pb.DataSpecification{
+		"json": {
+			Output: mustStruct(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"json": map[string]any{"type": "object", "instillFormat": "semi-structured/object"},
+				},
+			}),
+		},
+		"image": {
+			Input: mustStruct(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"image": map[string]any{"type": "string", "instillAcceptFormats": []any{"image/*"}},
+				},
+			}),
+			Output: mustStruct(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"images": map[string]any{
+						"type":  "array",
+						"items": map[string]any{"type": "string", "instillFormat": "image/png"},
+					},
+				},
+			}),
+		},
+		"llm": {
+			Input: mustStruct(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"prompt":     map[string]any{"type": "string", "instillAcceptFormats": []any{"string"}},
+					"max-tokens": map[string]any{"type": "integer", "instillAcceptFormats": []any{"integer"}},
+				},
+			}),
+		},
+	}
+	specOf := func(comp *datamodel.Component) *pb.DataSpecification {
+		return specs[comp.Type]
+	}
+
+	r := &datamodel.Recipe{
+		Variable: map[string]*datamodel.Variable{
+			"prompt": {InstillFormat: "string"},
+			"photo":  {InstillFormat: "image"},
+		},
+		Component: datamodel.ComponentMap{
+			"parse": {Type: "json", Task: "TASK_PARSE"},
+			"thumb": {Type: "image", Task: "TASK_RESIZE", Input: map[string]any{
+				"image": "${variable.prompt}",
+			}},
+			"again": {Type: "image", Task: "TASK_RESIZE", Input: map[string]any{
+				"image": "${thumb.output.images[0]}",
+			}},
+			"chat": {Type: "llm", Task: "TASK_CHAT", Input: map[string]any{
+				"prompt":     "${parse.output.json}",
+				"max-tokens": "${variable.photo}",
+			}},
+			"ok": {Type: "llm", Task: "TASK_CHAT", Input: map[string]any{
+				"prompt":     "Describe ${thumb.output.images[0]}",
+				"max-tokens": "${parse.output.json.limit}",
+			}},
+			"formatted": {Type: "llm", Task: "TASK_CHAT", Input: map[string]any{
+				"prompt": "${parse.output.json | json}",
+			}},
+		},
+	}
+
+	got := recipeTypeWarnings(r, specOf)
+
+	gotLoc := []string{}
+	for _, w := range got {
+		gotLoc = append(gotLoc, w.Location)
+	}
+	c.Check(gotLoc, qt.DeepEquals, []string{
+		"component.chat.input.max-tokens",
+		"component.chat.input.prompt",
+		"component.thumb.input.image",
+	})
+	c.Check(got[1].Error, qt.Equals, "type mismatch: ${parse.output.json} is object but the field accepts string")
+}
+
+func TestFormatKind(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := map[string]string{
+		"image/png":              kindImage,
+		"array:image/*":          "array:image",
+		"string":                 kindString,
+		"integer":                kindNumber,
+		"semi-structured/object": kindObject,
+		"*/*":                    kindAny,
+		"array:*":                kindAny,
+		"application/x-unknown":  "",
+	}
+	for format, want := range testcases {
+		c.Check(formatKind(format), qt.Equals, want, qt.Commentf(format))
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -97,6 +99,33 @@ func (s *service) GetRscNamespace(ctx context.Context, namespaceID string) (reso
 	return resource.Namespace{}, fmt.Errorf("namespace error")
 }
 
+// impersonateNamespace returns a context that acts on behalf of a namespace
+// for the triggers that aren't started by an end user (e.g. events or canary
+// checks). The namespace is used as the user and the requester.
+func (s *service) impersonateNamespace(ctx context.Context, nsUID uuid.UUID) (context.Context, resource.Namespace, error) {
+	resp, err := s.mgmtPrivateServiceClient.CheckNamespaceByUIDAdmin(ctx, &mgmtpb.CheckNamespaceByUIDAdminRequest{
+		Uid: nsUID.String(),
+	})
+	if err != nil {
+		return ctx, resource.Namespace{}, fmt.Errorf("fetching namespace: %w", err)
+	}
+
+	ns := resource.Namespace{
+		NsType: resource.User,
+		NsID:   resp.GetId(),
+		NsUID:  nsUID,
+	}
+	if resp.GetType() == mgmtpb.CheckNamespaceByUIDAdminResponse_NAMESPACE_ORGANIZATION {
+		ns.NsType = resource.Organization
+	}
+
+	md := metadata.MD{}
+	md.Set(constant.HeaderUserUIDKey, ns.NsUID.String())
+	md.Set(constant.HeaderRequesterUIDKey, ns.NsUID.String())
+
+	return metadata.NewIncomingContext(ctx, md), ns, nil
+}
+
 // Helper methods
 func (s *service) convertPipelineRunToPB(run datamodel.PipelineRun) (*pipelinepb.PipelineRun, error) {
 	result := &pipelinepb.PipelineRun{
@@ -206,3 +235,9 @@ func parseRecipeMetadata(ctx context.Context, metadataMap map[string][]byte, con
 	dataSpec, _ := converter.GeneratePipelineDataSpec(dbRecipe.Variable, dbRecipe.Output, dbRecipe.Component)
 	return pbStruct, dataSpec, nil
 }
+
+// isHTTPURL checks whether a file variable references a remote file, e.g. a
+// presigned URL to an object storage bucket.
+func isHTTPURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
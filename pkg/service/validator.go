@@ -3,14 +3,21 @@ package service
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/santhosh-tekuri/jsonschema/v5"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/instill-ai/pipeline-backend/pkg/data"
 	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/eventsource"
 	"github.com/instill-ai/pipeline-backend/pkg/recipe"
 
+	component "github.com/instill-ai/pipeline-backend/pkg/component/store"
+
 	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
 )
 
@@ -42,6 +49,56 @@ func (s *service) checkRecipe(recipePermalink *datamodel.Recipe) ([]*pb.ErrPipel
 
 	compProperties := map[string]any{}
 
+	checkRunDefaults(recipePermalink.MaxDuration, recipePermalink.ComponentTimeout, recipePermalink.Retry, recipePermalink.ErrorPolicy, &validationErrors)
+	if recipePermalink.MemoryTTL != "" {
+		if d, err := data.ParseDuration(recipePermalink.MemoryTTL); err != nil || d.GetDuration() <= 0 {
+			validationErrors = append(validationErrors, &pb.ErrPipelineValidation{
+				Location: "memory-ttl",
+				Error:    "memory-ttl must be a positive duration, e.g. 30m or 6h",
+			})
+		}
+	}
+
+	switch recipePermalink.Coercion {
+	case "", datamodel.CoercionLenient, datamodel.CoercionStrict:
+	default:
+		validationErrors = append(validationErrors, &pb.ErrPipelineValidation{
+			Location: "coercion",
+			Error:    fmt.Sprintf("coercion must be %q or %q", datamodel.CoercionLenient, datamodel.CoercionStrict),
+		})
+	}
+
+	checkCapture(recipePermalink.Capture, &validationErrors)
+	checkCanary(recipePermalink.Canary, recipePermalink.Variable, &validationErrors)
+
+	if recipePermalink.On != nil {
+		for id, sched := range recipePermalink.On.Schedule {
+			if _, _, err := recipe.ParseSchedule(sched.Cron, sched.Timezone); err != nil {
+				validationErrors = append(validationErrors, &pb.ErrPipelineValidation{
+					Location: "on.schedule." + id,
+					Error:    err.Error(),
+				})
+			}
+		}
+		if _, _, err := recipe.SchedulePolicy(recipePermalink.On.Schedule); err != nil {
+			validationErrors = append(validationErrors, &pb.ErrPipelineValidation{
+				Location: "on.schedule",
+				Error:    err.Error(),
+			})
+		}
+		for id, e := range recipePermalink.On.Event {
+			if e == nil {
+				continue
+			}
+			if err := eventsource.ValidateDeduplication(e.Deduplication); err != nil {
+				validationErrors = append(validationErrors, &pb.ErrPipelineValidation{
+					Location: "on.event." + id + ".deduplication",
+					Error:    err.Error(),
+				})
+			}
+		}
+	}
+
 	for id, comp := range recipePermalink.Component {
 		switch comp.Type {
 		default:
@@ -52,10 +109,42 @@ func (s *service) checkRecipe(recipePermalink *datamodel.Recipe) ([]*pb.ErrPipel
 			}
 			checkTask(id, comp.Task, def.Spec.ComponentSpecification, compProperties, &validationErrors)
 
+			if err := s.checkErrorBranch(id, comp.OnError, recipePermalink.Component, &validationErrors); err != nil {
+				return nil, err
+			}
+			if err := s.checkFailover(id, comp, &validationErrors); err != nil {
+				return nil, err
+			}
+			checkCache(id, comp.Cache, &validationErrors)
+			checkBatching(id, comp.Batching, &validationErrors)
+			checkPromptReferences("component."+id+".input", comp.Input, &validationErrors)
+
+		case datamodel.Approval:
+			checkApproval(id, comp, &validationErrors)
+
 		case datamodel.Iterator:
+			if len(comp.OnError) > 0 {
+				validationErrors = append(validationErrors, &pb.ErrPipelineValidation{
+					Location: "component." + id + ".on-error",
+					Error:    "error branches aren't supported in iterators",
+				})
+			}
+			if len(comp.Failover) > 0 {
+				validationErrors = append(validationErrors, &pb.ErrPipelineValidation{
+					Location: "component." + id + ".failover",
+					Error:    "failover groups aren't supported in iterators",
+				})
+			}
 			nestedCompProperties := map[string]any{}
 			nestedValidationErrors := []*pb.ErrPipelineValidation{}
 			for nestedID, nestedComp := range comp.Component {
+				if nestedComp.Type == datamodel.Approval {
+					nestedValidationErrors = append(nestedValidationErrors, &pb.ErrPipelineValidation{
+						Location: "component." + nestedID,
+						Error:    "approval components aren't supported in iterators",
+					})
+					continue
+				}
 				if nestedComp.Type != datamodel.Iterator {
 					def, err := s.component.GetDefinitionByID(nestedComp.Type, nil, nil)
 					if err != nil {
@@ -135,3 +224,335 @@ func (s *service) checkRecipe(recipePermalink *datamodel.Recipe) ([]*pb.ErrPipel
 
 	return validationErrors, nil
 }
+
+// checkApproval validates the timeout and the default action of an approval
+// component.
+func checkApproval(compID string, comp *datamodel.Component, validationErrors *[]*pb.ErrPipelineValidation) {
+	loc := "component." + compID
+	if comp.Timeout != "" {
+		if d, err := data.ParseDuration(comp.Timeout); err != nil || d.Raw <= 0 {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc + ".timeout",
+				Error:    "timeout must be a positive duration, e.g. 30m, 24h or PT24H",
+			})
+		}
+	}
+
+	switch comp.DefaultAction {
+	case "", datamodel.ApprovalActionApprove, datamodel.ApprovalActionReject:
+	default:
+		*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+			Location: loc + ".default-action",
+			Error:    fmt.Sprintf("default-action must be %s or %s", datamodel.ApprovalActionApprove, datamodel.ApprovalActionReject),
+		})
+	}
+
+	if len(comp.OnError) > 0 {
+		*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+			Location: loc + ".on-error",
+			Error:    "error branches aren't supported in approval components",
+		})
+	}
+	if len(comp.Failover) > 0 {
+		*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+			Location: loc + ".failover",
+			Error:    "failover groups aren't supported in approval components",
+		})
+	}
+}
+
+// checkCache validates the semantic cache configuration of a component.
+func checkCache(compID string, cache *datamodel.ComponentCache, validationErrors *[]*pb.ErrPipelineValidation) {
+	if cache == nil {
+		return
+	}
+
+	loc := "component." + compID + ".cache"
+	if cache.Threshold < 0 || cache.Threshold > 1 {
+		*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+			Location: loc + ".threshold",
+			Error:    "threshold must be between 0 and 1",
+		})
+	}
+	if cache.TTL != "" {
+		if d, err := data.ParseDuration(cache.TTL); err != nil || d.GetDuration() <= 0 {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc + ".ttl",
+				Error:    "ttl must be a positive duration, e.g. 30m or 24h",
+			})
+		}
+	}
+}
+
+// checkRunDefaults validates the run settings that a recipe can inherit from
+// the settings of its namespace.
+func checkRunDefaults(maxDuration, componentTimeout string, retry *datamodel.RetryPolicy, errorPolicy string, validationErrors *[]*pb.ErrPipelineValidation) {
+	for _, f := range []struct {
+		name  string
+		value string
+	}{
+		{name: "max-duration", value: maxDuration},
+		{name: "component-timeout", value: componentTimeout},
+	} {
+		if f.value == "" {
+			continue
+		}
+		if d, err := data.ParseDuration(f.value); err != nil || d.Raw <= 0 {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: f.name,
+				Error:    f.name + " must be a positive duration, e.g. 30s, 5m or PT5M",
+			})
+		}
+	}
+
+	if retry != nil {
+		if retry.MaxAttempts < 0 {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: "retry.max-attempts",
+				Error:    "max-attempts can't be negative",
+			})
+		}
+		if retry.InitialInterval != "" {
+			if d, err := data.ParseDuration(retry.InitialInterval); err != nil || d.Raw <= 0 {
+				*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+					Location: "retry.initial-interval",
+					Error:    "initial-interval must be a positive duration, e.g. 1s",
+				})
+			}
+		}
+	}
+
+	switch errorPolicy {
+	case "", datamodel.ErrorPolicyContinue, datamodel.ErrorPolicyFailFast:
+	default:
+		*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+			Location: "error-policy",
+			Error:    fmt.Sprintf("error-policy must be %q or %q", datamodel.ErrorPolicyContinue, datamodel.ErrorPolicyFailFast),
+		})
+	}
+}
+
+// checkCapture validates the capture policy of a recipe.
+func checkCapture(capture *datamodel.CapturePolicy, validationErrors *[]*pb.ErrPipelineValidation) {
+	if capture == nil {
+		return
+	}
+
+	switch capture.Mode {
+	case "", datamodel.CaptureAll, datamodel.CaptureFailures, datamodel.CaptureNever:
+	case datamodel.CaptureSampled:
+		if capture.SampleRate <= 0 || capture.SampleRate > 100 {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: "capture.sample-rate",
+				Error:    "sample-rate must be a percentage greater than 0 and up to 100",
+			})
+		}
+	default:
+		*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+			Location: "capture.mode",
+			Error: fmt.Sprintf("capture mode must be %q, %q, %q or %q",
+				datamodel.CaptureAll, datamodel.CaptureSampled, datamodel.CaptureFailures, datamodel.CaptureNever),
+		})
+	}
+}
+
+// checkCanary validates the canary checks of a recipe. Their input can only
+// set the variables of the recipe.
+func checkCanary(canaries map[string]*datamodel.Canary, variables map[string]*datamodel.Variable, validationErrors *[]*pb.ErrPipelineValidation) {
+	ids := make([]string, 0, len(canaries))
+	for id := range canaries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		c, loc := canaries[id], "canary."+id
+		if c == nil {
+			continue
+		}
+
+		if _, _, err := recipe.ParseSchedule(c.Cron, c.Timezone); err != nil {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc + ".cron",
+				Error:    err.Error(),
+			})
+		}
+
+		keys := make([]string, 0, len(c.Input))
+		for k := range c.Input {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if _, ok := variables[k]; !ok {
+				*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+					Location: loc + ".input." + k,
+					Error:    fmt.Sprintf("variable %s isn't defined in the recipe", k),
+				})
+			}
+		}
+
+		for i, a := range c.Assert {
+			if err := recipe.ParseAssertion(a); err != nil {
+				*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+					Location: fmt.Sprintf("%s.assert[%d]", loc, i),
+					Error:    err.Error(),
+				})
+			}
+		}
+
+		if c.AlertAfter < 0 {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc + ".alert-after",
+				Error:    "alert-after can't be negative",
+			})
+		}
+	}
+}
+
+// checkBatching validates the batching configuration of a component. Zero
+// values fall back to the defaults of the worker.
+func checkBatching(compID string, batching *datamodel.ComponentBatching, validationErrors *[]*pb.ErrPipelineValidation) {
+	if batching == nil {
+		return
+	}
+
+	loc := "component." + compID + ".batching"
+	for _, f := range []struct {
+		name string
+		v    int
+	}{
+		{"size", batching.Size},
+		{"concurrency", batching.Concurrency},
+		{"max-retries", batching.MaxRetries},
+	} {
+		if f.v < 0 {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc + "." + f.name,
+				Error:    f.name + " can't be negative",
+			})
+		}
+	}
+}
+
+// checkPromptReferences validates the format of the prompt references in a
+// component input. Whether the referenced prompts exist is checked when the
+// component is executed, as the prompts are versioned independently of the
+// recipe.
+func checkPromptReferences(loc string, input any, validationErrors *[]*pb.ErrPipelineValidation) {
+	switch v := input.(type) {
+	case string:
+		if !recipe.IsPromptReference(v) {
+			return
+		}
+		if _, err := recipe.ParsePromptReference(v); err != nil {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc,
+				Error:    "prompt references must have the prompt://<prompt-id>@<version> format",
+			})
+		}
+	case map[string]any:
+		for k, f := range v {
+			checkPromptReferences(loc+"."+k, f, validationErrors)
+		}
+	case []any:
+		for i, item := range v {
+			checkPromptReferences(fmt.Sprintf("%s.%d", loc, i), item, validationErrors)
+		}
+	}
+}
+
+// checkFailover validates the providers in the failover group of a component.
+// Each of them must be a regular component that supports the task.
+func (s *service) checkFailover(compID string, comp *datamodel.Component, validationErrors *[]*pb.ErrPipelineValidation) error {
+	for i, target := range comp.Failover {
+		loc := fmt.Sprintf("component.%s.failover.%d", compID, i)
+		if target == nil {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc,
+				Error:    "failover target can't be empty",
+			})
+			continue
+		}
+
+		compType, task := comp.Type, comp.Task
+		if target.Type != "" {
+			compType = target.Type
+		}
+		if target.Task != "" {
+			task = target.Task
+		}
+
+		if compType == datamodel.Iterator || compType == datamodel.Approval {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc + ".type",
+				Error:    "failover groups can only contain regular components",
+			})
+			continue
+		}
+
+		def, err := s.component.GetDefinitionByID(compType, nil, nil)
+		if err != nil {
+			if errors.Is(err, component.ErrComponentDefinitionNotFound) {
+				*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+					Location: loc + ".type",
+					Error:    fmt.Sprintf("component type %s doesn't exist", compType),
+				})
+				continue
+			}
+			return err
+		}
+
+		targetErrors := []*pb.ErrPipelineValidation{}
+		checkTask(compID, task, def.Spec.ComponentSpecification, map[string]any{}, &targetErrors)
+		for _, e := range targetErrors {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc + ".task",
+				Error:    e.Error,
+			})
+		}
+	}
+
+	return nil
+}
+
+// checkErrorBranch validates the components of an error branch. Their IDs
+// share the namespace of the recipe components, as they are stored in the same
+// memory.
+func (s *service) checkErrorBranch(ownerID string, branch, recipeComps datamodel.ComponentMap, validationErrors *[]*pb.ErrPipelineValidation) error {
+	branchProperties := map[string]any{}
+	for branchID, branchComp := range branch {
+		loc := "component." + ownerID + ".on-error." + branchID
+
+		if _, ok := recipeComps[branchID]; ok {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc,
+				Error:    "component ID already used in the recipe",
+			})
+			continue
+		}
+		if branchComp.Type == datamodel.Iterator || branchComp.Type == datamodel.Approval || len(branchComp.OnError) > 0 {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc,
+				Error:    "error branches can only contain regular components",
+			})
+			continue
+		}
+
+		def, err := s.component.GetDefinitionByID(branchComp.Type, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		branchErrors := []*pb.ErrPipelineValidation{}
+		checkTask(branchID, branchComp.Task, def.Spec.ComponentSpecification, branchProperties, &branchErrors)
+		for _, e := range branchErrors {
+			*validationErrors = append(*validationErrors, &pb.ErrPipelineValidation{
+				Location: loc,
+				Error:    e.Error,
+			})
+		}
+	}
+
+	return nil
+}
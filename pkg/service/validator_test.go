@@ -0,0 +1,234 @@
+package service
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+
+	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+func TestCheckApproval(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name    string
+		comp    *datamodel.Component
+		wantLoc []string
+	}{
+		{
+			name: "ok",
+			comp: &datamodel.Component{
+				Type:          datamodel.Approval,
+				Timeout:       "24h",
+				DefaultAction: datamodel.ApprovalActionApprove,
+			},
+		},
+		{
+			name: "ok - no timeout",
+			comp: &datamodel.Component{Type: datamodel.Approval},
+		},
+		{
+			name: "nok - invalid fields",
+			comp: &datamodel.Component{
+				Type:          datamodel.Approval,
+				Timeout:       "-1h",
+				DefaultAction: "escalate",
+				OnError: datamodel.ComponentMap{
+					"notify": {Type: "slack"},
+				},
+			},
+			wantLoc: []string{
+				"component.review.timeout",
+				"component.review.default-action",
+				"component.review.on-error",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			validationErrors := []*pb.ErrPipelineValidation{}
+			checkApproval("review", tc.comp, &validationErrors)
+
+			gotLoc := []string{}
+			for _, e := range validationErrors {
+				gotLoc = append(gotLoc, e.Location)
+			}
+			if tc.wantLoc == nil {
+				tc.wantLoc = []string{}
+			}
+			c.Check(gotLoc, qt.DeepEquals, tc.wantLoc)
+		})
+	}
+}
+
+func TestCheckPromptReferences(t *testing.T) {
+	c := qt.New(t)
+
+	input := map[string]any{
+		"prompt":          "prompt://summarize@2",
+		"system-message":  "prompt://summarize",
+		"chat-history":    []any{"prompt://@1", "Hello"},
+		"max-new-tokens":  100,
+		"prompt-template": "prompt://summarize@latest",
+	}
+
+	validationErrors := []*pb.ErrPipelineValidation{}
+	checkPromptReferences("component.llm.input", input, &validationErrors)
+
+	gotLoc := []string{}
+	for _, e := range validationErrors {
+		gotLoc = append(gotLoc, e.Location)
+	}
+	c.Check(gotLoc, qt.ContentEquals, []string{
+		"component.llm.input.chat-history.0",
+		"component.llm.input.prompt-template",
+	})
+}
+
+func TestCheckBatching(t *testing.T) {
+	c := qt.New(t)
+
+	c.Run("nil", func(c *qt.C) {
+		validationErrors := []*pb.ErrPipelineValidation{}
+		checkBatching("embed", nil, &validationErrors)
+		c.Check(validationErrors, qt.HasLen, 0)
+	})
+
+	c.Run("negative values", func(c *qt.C) {
+		validationErrors := []*pb.ErrPipelineValidation{}
+		checkBatching("embed", &datamodel.ComponentBatching{
+			Size:       -1,
+			MaxRetries: -2,
+		}, &validationErrors)
+
+		gotLoc := []string{}
+		for _, e := range validationErrors {
+			gotLoc = append(gotLoc, e.Location)
+		}
+		c.Check(gotLoc, qt.ContentEquals, []string{
+			"component.embed.batching.size",
+			"component.embed.batching.max-retries",
+		})
+	})
+}
+
+func TestCheckRunDefaults(t *testing.T) {
+	c := qt.New(t)
+
+	validationErrors := []*pb.ErrPipelineValidation{}
+	checkRunDefaults("5m", "0s", &datamodel.RetryPolicy{MaxAttempts: -1, InitialInterval: "1s"}, "retry", &validationErrors)
+
+	gotLoc := []string{}
+	for _, e := range validationErrors {
+		gotLoc = append(gotLoc, e.Location)
+	}
+	c.Check(gotLoc, qt.DeepEquals, []string{"component-timeout", "retry.max-attempts", "error-policy"})
+
+	validationErrors = validationErrors[:0]
+	checkRunDefaults("", "", nil, datamodel.ErrorPolicyFailFast, &validationErrors)
+	c.Check(validationErrors, qt.HasLen, 0)
+}
+
+func TestCheckCapture(t *testing.T) {
+	c := qt.New(t)
+
+	testcases := []struct {
+		name    string
+		capture *datamodel.CapturePolicy
+		wantLoc []string
+	}{
+		{name: "ok - unset"},
+		{name: "ok - failures", capture: &datamodel.CapturePolicy{Mode: datamodel.CaptureFailures}},
+		{name: "ok - sampled", capture: &datamodel.CapturePolicy{Mode: datamodel.CaptureSampled, SampleRate: 12.5}},
+		{
+			name:    "nok - sample rate out of range",
+			capture: &datamodel.CapturePolicy{Mode: datamodel.CaptureSampled, SampleRate: 150},
+			wantLoc: []string{"capture.sample-rate"},
+		},
+		{
+			name:    "nok - unknown mode",
+			capture: &datamodel.CapturePolicy{Mode: "sometimes"},
+			wantLoc: []string{"capture.mode"},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			validationErrors := []*pb.ErrPipelineValidation{}
+			checkCapture(tc.capture, &validationErrors)
+
+			gotLoc := []string{}
+			for _, e := range validationErrors {
+				gotLoc = append(gotLoc, e.Location)
+			}
+			if tc.wantLoc == nil {
+				tc.wantLoc = []string{}
+			}
+			c.Check(gotLoc, qt.DeepEquals, tc.wantLoc)
+		})
+	}
+}
+
+func TestCheckCanary(t *testing.T) {
+	c := qt.New(t)
+
+	variables := map[string]*datamodel.Variable{"url": {InstillFormat: "string"}}
+	testcases := []struct {
+		name    string
+		canary  *datamodel.Canary
+		wantLoc []string
+	}{
+		{
+			name: "ok",
+			canary: &datamodel.Canary{
+				Cron:     "*/15 * * * *",
+				Timezone: "Europe/Paris",
+				Input:    map[string]any{"url": "https://example.com"},
+				Assert:   []string{`${output.title} != ""`, `${output.status} == 200 && ${output.count} > 0`},
+			},
+		},
+		{
+			name:    "nok - invalid cron",
+			canary:  &datamodel.Canary{Cron: "every minute"},
+			wantLoc: []string{"canary.smoke.cron"},
+		},
+		{
+			name:    "nok - undefined variable",
+			canary:  &datamodel.Canary{Cron: "@hourly", Input: map[string]any{"uri": "https://example.com"}},
+			wantLoc: []string{"canary.smoke.input.uri"},
+		},
+		{
+			name: "nok - invalid assertions",
+			canary: &datamodel.Canary{
+				Cron:   "@hourly",
+				Assert: []string{`${variable.url} != ""`, `${output.title != ""`, `${output.title} !=`},
+			},
+			wantLoc: []string{"canary.smoke.assert[0]", "canary.smoke.assert[1]", "canary.smoke.assert[2]"},
+		},
+		{
+			name:    "nok - negative alert threshold",
+			canary:  &datamodel.Canary{Cron: "@hourly", AlertAfter: -1},
+			wantLoc: []string{"canary.smoke.alert-after"},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *qt.C) {
+			validationErrors := []*pb.ErrPipelineValidation{}
+			checkCanary(map[string]*datamodel.Canary{"smoke": tc.canary}, variables, &validationErrors)
+
+			gotLoc := []string{}
+			for _, e := range validationErrors {
+				gotLoc = append(gotLoc, e.Location)
+			}
+			if tc.wantLoc == nil {
+				tc.wantLoc = []string{}
+			}
+			c.Check(gotLoc, qt.DeepEquals, tc.wantLoc)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package session keeps the history of the conversation sessions of chat
+// pipelines, so they don't need an external store to remember the previous
+// turns of a conversation.
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// maxSessionIDLength is the length of the session ID column.
+const maxSessionIDLength = 255
+
+// Message is a message in the history of a session.
+type Message struct {
+	Role       string    `json:"role"`
+	Content    string    `json:"content"`
+	CreateTime time.Time `json:"createTime"`
+}
+
+// Store keeps the conversation sessions. Sessions are identified by an ID
+// chosen by the client (e.g. the ID of a chat) within a namespace, and are
+// created when the first message is appended.
+type Store interface {
+	// Append adds messages at the end of the history of a session.
+	Append(ctx context.Context, namespaceUID uuid.UUID, sessionID string, messages []Message) error
+	// List returns the last messages of a session, oldest first. A
+	// non-positive limit returns the whole history.
+	List(ctx context.Context, namespaceUID uuid.UUID, sessionID string, limit int) ([]Message, error)
+	// Delete removes the history of a session.
+	Delete(ctx context.Context, namespaceUID uuid.UUID, sessionID string) error
+}
+
+// ValidateSessionID checks that a session ID can be stored.
+func ValidateSessionID(id string) error {
+	if id == "" || len(id) > maxSessionIDLength {
+		return errmsg.AddMessage(
+			fmt.Errorf("%w: invalid session ID %q", errdomain.ErrInvalidArgument, id),
+			fmt.Sprintf("The session ID must have between 1 and %d characters.", maxSessionIDLength),
+		)
+	}
+	return nil
+}
+
+// ValidateMessages checks the messages before they're appended to a session.
+func ValidateMessages(messages []Message) error {
+	for i, m := range messages {
+		if m.Role == "" {
+			return errmsg.AddMessage(
+				fmt.Errorf("%w: message %d has no role", errdomain.ErrInvalidArgument, i),
+				"The role of a message can't be empty.",
+			)
+		}
+	}
+	return nil
+}
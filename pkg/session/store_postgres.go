@@ -0,0 +1,87 @@
+package session
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// messageRecord is a session message stored in PostgreSQL.
+type messageRecord struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement"`
+	NamespaceUID uuid.UUID `gorm:"type:uuid"`
+	SessionID    string    `gorm:"type:varchar(255)"`
+	Role         string
+	Content      string
+	CreateTime   time.Time
+}
+
+// TableName maps the messageRecord object to a SQL table.
+func (messageRecord) TableName() string {
+	return "session_message"
+}
+
+type postgresStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresStore returns a session store that keeps the messages in
+// PostgreSQL. Sessions are kept until they're deleted.
+func NewPostgresStore(db *gorm.DB) Store {
+	return &postgresStore{db: db}
+}
+
+func (s *postgresStore) Append(ctx context.Context, namespaceUID uuid.UUID, sessionID string, messages []Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	records := make([]*messageRecord, len(messages))
+	now := time.Now()
+	for i, m := range messages {
+		if m.CreateTime.IsZero() {
+			m.CreateTime = now
+		}
+		records[i] = &messageRecord{
+			NamespaceUID: namespaceUID,
+			SessionID:    sessionID,
+			Role:         m.Role,
+			Content:      m.Content,
+			CreateTime:   m.CreateTime,
+		}
+	}
+
+	return s.db.WithContext(ctx).Create(records).Error
+}
+
+func (s *postgresStore) List(ctx context.Context, namespaceUID uuid.UUID, sessionID string, limit int) ([]Message, error) {
+	// The last messages are selected by ID, which follows the order in which
+	// they were appended, and then put back in chronological order.
+	q := s.db.WithContext(ctx).
+		Where("namespace_uid = ? AND session_id = ?", namespaceUID, sessionID).
+		Order("id DESC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	var records []*messageRecord
+	if err := q.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	slices.Reverse(records)
+
+	messages := make([]Message, len(records))
+	for i, r := range records {
+		messages[i] = Message{Role: r.Role, Content: r.Content, CreateTime: r.CreateTime}
+	}
+	return messages, nil
+}
+
+func (s *postgresStore) Delete(ctx context.Context, namespaceUID uuid.UUID, sessionID string) error {
+	return s.db.WithContext(ctx).
+		Where("namespace_uid = ? AND session_id = ?", namespaceUID, sessionID).
+		Delete(&messageRecord{}).Error
+}
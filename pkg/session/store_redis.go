@@ -0,0 +1,82 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const redisSessionKeyPrefix = "pipeline_session:"
+
+type redisStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisStore returns a session store that keeps each session in a Redis
+// list. A session expires when no message is appended to it for the provided
+// TTL, or never if the TTL is zero.
+func NewRedisStore(client *redis.Client, ttl time.Duration) Store {
+	return &redisStore{client: client, ttl: ttl}
+}
+
+func redisSessionKey(namespaceUID uuid.UUID, sessionID string) string {
+	return redisSessionKeyPrefix + namespaceUID.String() + ":" + sessionID
+}
+
+func (s *redisStore) Append(ctx context.Context, namespaceUID uuid.UUID, sessionID string, messages []Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	values := make([]any, len(messages))
+	now := time.Now()
+	for i, m := range messages {
+		if m.CreateTime.IsZero() {
+			m.CreateTime = now
+		}
+		b, err := json.Marshal(m)
+		if err != nil {
+			return fmt.Errorf("marshalling message: %w", err)
+		}
+		values[i] = b
+	}
+
+	key := redisSessionKey(namespaceUID, sessionID)
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, key, values...)
+		if s.ttl > 0 {
+			pipe.Expire(ctx, key, s.ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+func (s *redisStore) List(ctx context.Context, namespaceUID uuid.UUID, sessionID string, limit int) ([]Message, error) {
+	start := int64(0)
+	if limit > 0 {
+		start = -int64(limit)
+	}
+
+	values, err := s.client.LRange(ctx, redisSessionKey(namespaceUID, sessionID), start, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]Message, len(values))
+	for i, v := range values {
+		if err := json.Unmarshal([]byte(v), &messages[i]); err != nil {
+			return nil, fmt.Errorf("unmarshalling message: %w", err)
+		}
+	}
+	return messages, nil
+}
+
+func (s *redisStore) Delete(ctx context.Context, namespaceUID uuid.UUID, sessionID string) error {
+	return s.client.Del(ctx, redisSessionKey(namespaceUID, sessionID)).Err()
+}
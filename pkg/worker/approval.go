@@ -0,0 +1,250 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/zap"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/logger"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/x/errmsg"
+
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+)
+
+// ApprovalSignal is the name of the Temporal signal that carries the
+// decisions on the approval components of a pipeline run.
+const ApprovalSignal = "approval"
+
+// ApprovalDecision is the decision taken on an approval component.
+type ApprovalDecision struct {
+	ComponentID string
+	Approved    bool
+	// Reviewer is the UID of the user that took the decision. It's empty
+	// when the default action is applied.
+	Reviewer string
+	Comment  string
+	TimedOut bool
+}
+
+// PreApprovalActivity renders the input of an approval component, which is
+// presented to the reviewers. It returns whether any batch item is pending
+// approval, i.e., whether the component isn't skipped in the whole batch.
+func (w *worker) PreApprovalActivity(ctx context.Context, param *ApprovalActivityParam) (bool, error) {
+	logger, _ := logger.GetZapLogger(ctx)
+	logger = logger.With(zap.String("ComponentID", param.ID))
+	logger.Info("PreApprovalActivity started")
+
+	wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.WorkflowID)
+	if err != nil {
+		return false, temporal.NewApplicationErrorWithCause("loading pipeline memory", approvalActivityErrorType, err)
+	}
+
+	conditionMap, err := w.processCondition(ctx, wfm, param.ID, param.UpstreamIDs, param.Condition)
+	if err != nil {
+		return false, componentActivityError(ctx, wfm, err, approvalActivityErrorType, param.ID)
+	}
+
+	// The input might reference the files of the run.
+	readCtx := contextWithRunArtifacts(ctx, param.SystemVariables.PipelineTriggerID)
+	for _, idx := range conditionMap {
+		if _, err := NewInputReader(wfm, param.ID, idx, nil).Read(readCtx); err != nil {
+			return false, componentActivityError(ctx, wfm, err, approvalActivityErrorType, param.ID)
+		}
+	}
+
+	logger.Info("PreApprovalActivity completed")
+	return len(conditionMap) > 0, nil
+}
+
+// PostApprovalActivity writes the decision on an approval component as its
+// output. A rejection makes the component fail, so the components that
+// depend on it are skipped.
+func (w *worker) PostApprovalActivity(ctx context.Context, param *PostApprovalActivityParam) (err error) {
+	logger, _ := logger.GetZapLogger(ctx)
+	logger = logger.With(zap.String("ComponentID", param.ID))
+	logger.Info("PostApprovalActivity started")
+
+	completedTime := time.Now()
+	defer func() {
+		componentRun := &datamodel.ComponentRun{
+			Status:        datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_COMPLETED),
+			CompletedTime: null.TimeFrom(completedTime),
+		}
+		if err != nil {
+			componentRun.Status = datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_FAILED)
+			componentRun.Error = null.StringFrom(err.Error())
+		}
+		if err := w.repository.UpdateComponentRun(ctx, param.SystemVariables.PipelineTriggerID, param.ID, componentRun); err != nil {
+			logger.Error("failed to log component run end time", zap.Error(err))
+		}
+	}()
+
+	wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.WorkflowID)
+	if err != nil {
+		return temporal.NewApplicationErrorWithCause("loading pipeline memory", approvalActivityErrorType, err)
+	}
+
+	d := param.Decision
+	output := data.NewMap(map[string]data.Value{
+		"approved":  data.NewBoolean(d.Approved),
+		"reviewer":  data.NewString(d.Reviewer),
+		"comment":   data.NewString(d.Comment),
+		"timed-out": data.NewBoolean(d.TimedOut),
+	})
+
+	for idx := range wfm.GetBatchSize() {
+		if skipped, err := wfm.GetComponentStatus(ctx, idx, param.ID, memory.ComponentStatusSkipped); err == nil && skipped {
+			continue
+		}
+
+		if err := wfm.SetComponentData(ctx, idx, param.ID, memory.ComponentDataOutput, output); err != nil {
+			return componentActivityError(ctx, wfm, err, approvalActivityErrorType, param.ID)
+		}
+		if !d.Approved {
+			continue
+		}
+		if err := wfm.SetComponentStatus(ctx, idx, param.ID, memory.ComponentStatusCompleted, true); err != nil {
+			return componentActivityError(ctx, wfm, err, approvalActivityErrorType, param.ID)
+		}
+	}
+
+	if !d.Approved {
+		msg := "The run was rejected by a reviewer."
+		if d.TimedOut {
+			msg = "The run was rejected as no reviewer approved it in time."
+		}
+		if d.Comment != "" {
+			msg = fmt.Sprintf("%s Comment: %s", msg, d.Comment)
+		}
+		err := errmsg.AddMessage(fmt.Errorf("approval rejected"), msg)
+		return componentActivityError(ctx, wfm, err, approvalActivityErrorType, param.ID)
+	}
+
+	logger.Info("PostApprovalActivity completed")
+	return nil
+}
+
+// executeApproval waits for the decision on an approval component and records
+// it. It returns false if the run deadline is reached before a decision is
+// taken.
+func (w *worker) executeApproval(
+	ctx, minioCtx workflow.Context,
+	args *ApprovalActivityParam,
+	comp *datamodel.Component,
+	deadline time.Time,
+	decisions map[string]*ApprovalDecision,
+) (uploads []workflow.Future, inTime bool, err error) {
+	decision, inTime := awaitApproval(ctx, args.ID, comp, deadline, decisions)
+	if !inTime {
+		return nil, false, nil
+	}
+
+	err = workflow.ExecuteActivity(ctx, w.PostApprovalActivity, &PostApprovalActivityParam{
+		ApprovalActivityParam: *args,
+		Decision:              decision,
+	}).Get(ctx, nil)
+	if err != nil {
+		return nil, true, err
+	}
+
+	uploads = append(uploads, workflow.ExecuteActivity(minioCtx, w.UploadComponentOutputsActivity, &ComponentActivityParam{
+		WorkflowID:      args.WorkflowID,
+		ID:              args.ID,
+		SystemVariables: args.SystemVariables,
+	}))
+	return uploads, true, nil
+}
+
+// awaitApproval blocks until a decision is taken on an approval component or
+// its timeout elapses, in which case the default action is returned. If the
+// run deadline is reached first, it returns false. Decisions on other
+// components received in the meantime are kept in decisions, as several
+// approvals can be pending at once.
+func awaitApproval(ctx workflow.Context, compID string, comp *datamodel.Component, deadline time.Time, decisions map[string]*ApprovalDecision) (*ApprovalDecision, bool) {
+	timerCtx, cancelTimers := workflow.WithCancel(ctx)
+	defer cancelTimers()
+
+	var timeoutTimer, deadlineTimer workflow.Future
+	if comp.Timeout != "" {
+		// The timeout is checked when the recipe is validated.
+		if timeout, err := data.ParseDuration(comp.Timeout); err == nil && timeout.Raw > 0 {
+			timeoutTimer = workflow.NewTimer(timerCtx, timeout.Raw)
+		}
+	}
+	if !deadline.IsZero() {
+		remaining := deadline.Sub(workflow.Now(ctx))
+		if remaining <= 0 {
+			return nil, false
+		}
+		deadlineTimer = workflow.NewTimer(timerCtx, remaining)
+	}
+
+	ch := workflow.GetSignalChannel(ctx, ApprovalSignal)
+	for {
+		if d, ok := decisions[compID]; ok {
+			return d, true
+		}
+
+		timedOut, reachedDeadline := false, false
+		sel := workflow.NewSelector(ctx)
+		sel.AddReceive(ch, func(c workflow.ReceiveChannel, _ bool) {
+			d := new(ApprovalDecision)
+			c.Receive(ctx, d)
+
+			// The first decision on a component prevails.
+			if _, ok := decisions[d.ComponentID]; !ok {
+				decisions[d.ComponentID] = d
+			}
+		})
+		if timeoutTimer != nil {
+			sel.AddFuture(timeoutTimer, func(workflow.Future) { timedOut = true })
+		}
+		if deadlineTimer != nil {
+			sel.AddFuture(deadlineTimer, func(workflow.Future) { reachedDeadline = true })
+		}
+		sel.Select(ctx)
+
+		if reachedDeadline {
+			return nil, false
+		}
+		if timedOut {
+			return &ApprovalDecision{
+				ComponentID: compID,
+				Approved:    comp.DefaultAction == datamodel.ApprovalActionApprove,
+				TimedOut:    true,
+			}, true
+		}
+	}
+}
+
+// forwardApprovalSignals relays the approval decisions received by a trigger
+// workflow to its batch chunks until the context is cancelled.
+func forwardApprovalSignals(ctx workflow.Context, childWorkflowIDs []string) {
+	ch := workflow.GetSignalChannel(ctx, ApprovalSignal)
+	for {
+		done := false
+		sel := workflow.NewSelector(ctx)
+		sel.AddReceive(ch, func(c workflow.ReceiveChannel, _ bool) {
+			d := new(ApprovalDecision)
+			c.Receive(ctx, d)
+			for _, id := range childWorkflowIDs {
+				// The chunks that are already closed can't be signalled.
+				_ = workflow.SignalExternalWorkflow(ctx, id, "", ApprovalSignal, d).Get(ctx, nil)
+			}
+		})
+		sel.AddReceive(ctx.Done(), func(workflow.ReceiveChannel, bool) { done = true })
+		sel.Select(ctx)
+
+		if done {
+			return
+		}
+	}
+}
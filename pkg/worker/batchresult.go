@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"slices"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+)
+
+// batchResults reads the outcome of each batch item from the run memory. An
+// item fails when one of its components errored and the error wasn't
+// recovered by an error branch. The failures of an item don't affect the
+// rest of the batch.
+func batchResults(ctx context.Context, wfm memory.WorkflowMemory) datamodel.BatchResults {
+	comps := wfm.GetRecipe().Component.WithErrorBranches()
+	compIDs := make([]string, 0, len(comps))
+	for id := range comps {
+		compIDs = append(compIDs, id)
+	}
+	slices.Sort(compIDs)
+
+	results := make(datamodel.BatchResults, wfm.GetBatchSize())
+	for idx := range results {
+		results[idx] = datamodel.BatchResult{
+			BatchIndex: idx,
+			Status:     datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_COMPLETED),
+		}
+
+		for _, compID := range compIDs {
+			if errored, err := wfm.GetComponentStatus(ctx, idx, compID, memory.ComponentStatusErrored); err != nil || !errored {
+				continue
+			}
+
+			results[idx].Status = datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_FAILED)
+			results[idx].ComponentID = compID
+			if v, err := wfm.GetComponentData(ctx, idx, compID, memory.ComponentDataError); err == nil {
+				results[idx].Error = memory.ComponentErrorFromValue(v).Message
+			}
+			break
+		}
+	}
+
+	return results
+}
+
+// batchItemFailed returns whether a batch item failed.
+func batchItemFailed(r datamodel.BatchResult) bool {
+	return r.Status == datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_FAILED)
+}
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+)
+
+// capturesPayloads tells whether the inputs and outputs of a run are
+// uploaded as they're produced, according to the capture policy of its
+// recipe.
+func capturesPayloads(wfm memory.WorkflowMemory, pipelineTriggerID string) bool {
+	r := wfm.GetRecipe()
+	if r == nil {
+		return true
+	}
+	return r.Capture.ModeFor(pipelineTriggerID) == datamodel.CaptureAll
+}
+
+// CaptureFailedRunActivity uploads the payloads of a failed run whose
+// capture policy only keeps the failures: the inputs and outputs of the
+// pipeline and of the components that started. The runs with a different
+// policy are left untouched.
+func (w *worker) CaptureFailedRunActivity(ctx context.Context, param *CaptureFailedRunActivityParam) error {
+	log := w.log.With(zap.String("PipelineTriggerUID", param.PipelineTriggerID))
+
+	wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.WorkflowID)
+	if err != nil {
+		return err
+	}
+	r := wfm.GetRecipe()
+	if r == nil || r.Capture.ModeFor(param.PipelineTriggerID) != datamodel.CaptureFailures {
+		return nil
+	}
+
+	log.Info("CaptureFailedRunActivity started")
+
+	if err := w.uploadPipelineInputs(ctx, wfm, param.PipelineTriggerID); err != nil {
+		return err
+	}
+
+	componentIDs := make([]string, 0, len(r.Component))
+	for id := range r.Component.WithErrorBranches() {
+		componentIDs = append(componentIDs, id)
+	}
+	sort.Strings(componentIDs)
+
+	for _, id := range componentIDs {
+		if !componentStarted(ctx, wfm, id) {
+			continue
+		}
+		if err := w.uploadComponentInputs(ctx, wfm, param.PipelineTriggerID, id); err != nil {
+			return err
+		}
+		if err := w.uploadComponentOutputs(ctx, wfm, param.PipelineTriggerID, id); err != nil {
+			return err
+		}
+	}
+
+	if err := w.uploadPipelineOutputs(ctx, wfm, param.PipelineTriggerID); err != nil {
+		return err
+	}
+
+	log.Info("CaptureFailedRunActivity finished")
+	return nil
+}
+
+// componentStarted tells whether a component started in any batch item of
+// a run. The components that weren't reached have no memory.
+func componentStarted(ctx context.Context, wfm memory.WorkflowMemory, componentID string) bool {
+	for idx := range wfm.GetBatchSize() {
+		if started, err := wfm.GetComponentStatus(ctx, idx, componentID, memory.ComponentStatusStarted); err == nil && started {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/client"
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/pipeline-backend/config"
+
+	temporalworker "go.temporal.io/sdk/worker"
+)
+
+// TemporalCluster is a Temporal cluster the worker can poll tasks from.
+type TemporalCluster struct {
+	// Name identifies the cluster in the run records.
+	Name   string
+	Client client.Client
+}
+
+// Start registers the pipeline workflows and activities against a Temporal
+// cluster and starts polling its task queues. Workers that are stopped take
+// up to stopTimeout to finish their in-flight tasks.
+func (w *worker) Start(cluster TemporalCluster, stopTimeout time.Duration) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.temporalWorkers) > 0 {
+		return fmt.Errorf("worker is already running against cluster %s", w.cluster)
+	}
+
+	w.stopTimeout = stopTimeout
+	return w.start(cluster)
+}
+
+// Stop stops polling tasks from the current Temporal cluster.
+func (w *worker) Stop() {
+	w.mu.Lock()
+	tws := w.temporalWorkers
+	w.temporalWorkers = nil
+	w.mu.Unlock()
+
+	for _, tw := range tws {
+		tw.Stop()
+	}
+}
+
+// Failover re-registers the worker against another Temporal cluster. The
+// workers of the previous cluster are stopped in the background, as their
+// cluster might not be reachable and the in-flight activities can take long
+// to finish.
+func (w *worker) Failover(cluster TemporalCluster) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cluster == cluster.Name && len(w.temporalWorkers) > 0 {
+		return nil
+	}
+
+	previous, previousCluster := w.temporalWorkers, w.cluster
+	if err := w.start(cluster); err != nil {
+		return err
+	}
+
+	w.log.Warn("Temporal cluster failover",
+		zap.String("from", previousCluster),
+		zap.String("to", cluster.Name),
+	)
+	go func() {
+		for _, tw := range previous {
+			tw.Stop()
+		}
+	}()
+
+	return nil
+}
+
+// Cluster returns the name of the Temporal cluster the worker is running
+// against.
+func (w *worker) Cluster() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.cluster
+}
+
+// temporalClient returns the client of the Temporal cluster the worker is
+// running against.
+func (w *worker) temporalClient() client.Client {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.client
+}
+
+// start must be called with the lock held.
+func (w *worker) start(cluster TemporalCluster) error {
+	// Workflows are dispatched to a shared queue, while activities are
+	// dispatched to queues local to the worker, so they can access the
+	// in-memory data of the workflows.
+	cfg := config.Config.Server.Worker
+	tw := temporalworker.New(cluster.Client, TaskQueue, temporalworker.Options{
+		WorkflowPanicPolicy:                    temporalworker.BlockWorkflow,
+		WorkerStopTimeout:                      w.stopTimeout,
+		MaxConcurrentWorkflowTaskExecutionSize: valueOrDefault(cfg.MaxConcurrentWorkflowTaskExecutionSize, defaultMaxConcurrentWorkflowTaskExecutionSize),
+	})
+	lw := temporalworker.New(cluster.Client, w.workerUID.String(), temporalworker.Options{
+		WorkflowPanicPolicy:                temporalworker.BlockWorkflow,
+		WorkerStopTimeout:                  w.stopTimeout,
+		MaxConcurrentActivityExecutionSize: valueOrDefault(cfg.MaxConcurrentActivityExecutionSize, defaultMaxConcurrentActivityExecutionSize),
+	})
+	mw := temporalworker.New(cluster.Client, fmt.Sprintf("%s-minio", w.workerUID.String()), temporalworker.Options{
+		WorkflowPanicPolicy:                temporalworker.BlockWorkflow,
+		WorkerStopTimeout:                  w.stopTimeout,
+		MaxConcurrentActivityExecutionSize: valueOrDefault(cfg.MaxConcurrentMinioActivityExecutionSize, defaultMaxConcurrentMinioActivityExecutionSize),
+	})
+
+	tw.RegisterWorkflow(w.TriggerPipelineWorkflow)
+	tw.RegisterWorkflow(w.SchedulePipelineWorkflow)
+	tw.RegisterWorkflow(w.ReapWorkflowMemoryWorkflow)
+
+	lw.RegisterActivity(w.ComponentActivity)
+	lw.RegisterActivity(w.OutputActivity)
+	lw.RegisterActivity(w.PreIteratorActivity)
+	lw.RegisterActivity(w.PostIteratorActivity)
+	lw.RegisterActivity(w.PreErrorBranchActivity)
+	lw.RegisterActivity(w.PostErrorBranchActivity)
+	lw.RegisterActivity(w.PreApprovalActivity)
+	lw.RegisterActivity(w.PostApprovalActivity)
+	lw.RegisterActivity(w.PreBatchChunkActivity)
+	lw.RegisterActivity(w.PostBatchChunkActivity)
+	lw.RegisterActivity(w.ReleaseBatchChunkActivity)
+	lw.RegisterActivity(w.PipelineTimedOutActivity)
+	lw.RegisterActivity(w.PreTriggerActivity)
+	lw.RegisterActivity(w.LoadDAGDataActivity)
+	lw.RegisterActivity(w.PostTriggerActivity)
+	lw.RegisterActivity(w.ClosePipelineActivity)
+	lw.RegisterActivity(w.IncreasePipelineTriggerCountActivity)
+	lw.RegisterActivity(w.UpsertPipelineRunActivity)
+	lw.RegisterActivity(w.UpdatePipelineRunActivity)
+	lw.RegisterActivity(w.UpsertComponentRunActivity)
+	lw.RegisterActivity(w.ReapWorkflowMemoryActivity)
+
+	mw.RegisterActivity(w.UploadInputsToMinioActivity)
+	mw.RegisterActivity(w.UploadOutputsToMinioActivity)
+	mw.RegisterActivity(w.UploadRecipeToMinioActivity)
+	mw.RegisterActivity(w.UploadComponentInputsActivity)
+	mw.RegisterActivity(w.UploadComponentOutputsActivity)
+	mw.RegisterActivity(w.CaptureFailedRunActivity)
+
+	started := make([]temporalworker.Worker, 0, 3)
+	for _, sw := range []temporalworker.Worker{tw, lw, mw} {
+		if err := sw.Start(); err != nil {
+			for _, s := range started {
+				s.Stop()
+			}
+			return fmt.Errorf("starting worker on cluster %s: %w", cluster.Name, err)
+		}
+		started = append(started, sw)
+	}
+
+	w.cluster = cluster.Name
+	w.client = cluster.Client
+	w.temporalWorkers = started
+	return nil
+}
+
+// WatchTemporalCluster checks the health of a Temporal cluster periodically.
+// It returns nil once maxFailedChecks consecutive checks fail, which means the
+// workload should fail over to another cluster, or the context error when
+// the context is done.
+func WatchTemporalCluster(ctx context.Context, c client.Client, interval time.Duration, maxFailedChecks int) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failed := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		_, err := c.CheckHealth(checkCtx, &client.CheckHealthRequest{})
+		cancel()
+
+		if err == nil {
+			failed = 0
+			continue
+		}
+
+		failed++
+		if failed >= maxFailedChecks {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/x/errmsg"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// newComponentError classifies the error of a failed component, so the
+// clients can tell a bad input apart from a failure of the service the
+// component connects to without parsing the message.
+func newComponentError(err error) memory.ComponentError {
+	compErr := memory.ComponentError{
+		Code:    memory.ComponentErrorInternal,
+		Message: errmsg.MessageOrErr(err),
+	}
+
+	status, hasStatus := componentbase.UpstreamStatus(err)
+	if hasStatus {
+		compErr.UpstreamStatus = status
+	}
+
+	switch {
+	case errors.Is(err, componentbase.ErrProviderUnavailable):
+		compErr.Code = memory.ComponentErrorUpstreamUnavailable
+		compErr.Retryable = true
+	case errors.Is(err, context.DeadlineExceeded):
+		compErr.Code = memory.ComponentErrorTimeout
+		compErr.Retryable = true
+	case hasStatus:
+		compErr.Code = memory.ComponentErrorUpstream
+		compErr.Retryable = status == http.StatusRequestTimeout
+	case errors.Is(err, errdomain.ErrInvalidArgument):
+		compErr.Code = memory.ComponentErrorInvalidInput
+	}
+
+	return compErr
+}
@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/instill-ai/pipeline-backend/config"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+	pb "github.com/instill-ai/protogen-go/vdp/pipeline/v1beta"
+)
+
+// Default concurrency of the Temporal workers, used when the configuration
+// doesn't set it.
+const (
+	defaultMaxConcurrentWorkflowTaskExecutionSize  = 100
+	defaultMaxConcurrentActivityExecutionSize      = 100
+	defaultMaxConcurrentMinioActivityExecutionSize = 50
+)
+
+func valueOrDefault(v, d int) int {
+	if v > 0 {
+		return v
+	}
+	return d
+}
+
+// activityLimiter bounds the number of activities of a kind that a worker
+// executes at once. A nil limiter doesn't apply any limit.
+type activityLimiter chan struct{}
+
+func newActivityLimiter(limit int) activityLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return make(activityLimiter, limit)
+}
+
+// acquire blocks until the activity can be executed or the context is done.
+func (l activityLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l activityLimiter) release() {
+	if l != nil {
+		<-l
+	}
+}
+
+// componentLimiter returns the limiter that applies to the execution of a
+// component, depending on whether it's a connector or an operator.
+func (w *worker) componentLimiter(x componentbase.IExecution) activityLimiter {
+	def, err := x.GetComponent().GetDefinition(nil, nil)
+	if err != nil {
+		return nil
+	}
+
+	switch def.GetType() {
+	case pb.ComponentType_COMPONENT_TYPE_OPERATOR:
+		return w.operatorLimiter
+	case pb.ComponentType_COMPONENT_TYPE_AI,
+		pb.ComponentType_COMPONENT_TYPE_DATA,
+		pb.ComponentType_COMPONENT_TYPE_APPLICATION,
+		pb.ComponentType_COMPONENT_TYPE_GENERIC:
+		return w.connectorLimiter
+	default:
+		return nil
+	}
+}
+
+func newComponentLimiters() (connector, operator activityLimiter) {
+	cfg := config.Config.Server.Worker
+	return newActivityLimiter(cfg.MaxConcurrentConnectorActivities),
+		newActivityLimiter(cfg.MaxConcurrentOperatorActivities)
+}
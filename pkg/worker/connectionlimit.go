@@ -0,0 +1,225 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// A connection can limit the calls that the components make with it at once,
+// across all the pipelines and workers, so a burst of triggers doesn't get
+// the credentials of the connection banned by the external service.
+//
+// The limit is enforced with a semaphore in Redis. The permits are held in a
+// sorted set scored by their expiry time, so the permits of a worker that
+// stops are released once their lease expires. Holders renew their permits
+// while the call runs, and the call is cancelled if its permit expires
+// anyway, as the limit would be exceeded otherwise.
+const (
+	redisConnectionSemaphorePrefix = "pipeline_connection_semaphore:"
+
+	connectionPermitLease = 30 * time.Second
+
+	minConnectionPermitWait = 50 * time.Millisecond
+	maxConnectionPermitWait = 2 * time.Second
+)
+
+// connectionPermitRenewal is the interval at which the permits are renewed.
+// The lease outlasts a few renewals, so a renewal that fails because Redis
+// can't be reached is retried on the next one.
+var connectionPermitRenewal = connectionPermitLease / 3
+
+// errConnectionPermitLost is the cause of the cancellation of a call whose
+// permit expired before the call completed.
+var errConnectionPermitLost = errors.New("connection permit expired before the call completed")
+
+// acquireConnectionPermitScript drops the expired permits and adds a new one
+// if the limit isn't reached. It returns 1 if the permit was acquired. The
+// time of the Redis server is used, so the workers don't need synchronized
+// clocks.
+var acquireConnectionPermitScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local lease = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now)
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], now + lease, ARGV[2])
+redis.call('PEXPIRE', KEYS[1], lease)
+return 1
+`)
+
+// renewConnectionPermitScript extends the lease of a permit that is still
+// held. It returns 0 if the permit expired.
+var renewConnectionPermitScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local lease = tonumber(ARGV[2])
+if not redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], 'XX', now + lease, ARGV[1])
+redis.call('PEXPIRE', KEYS[1], lease)
+return 1
+`)
+
+func connectionSemaphoreKey(connUID uuid.UUID) string {
+	return redisConnectionSemaphorePrefix + connUID.String()
+}
+
+// ConnectionCallsInFlight returns the number of calls that hold a permit of
+// a connection.
+func ConnectionCallsInFlight(ctx context.Context, rc *redis.Client, connUID uuid.UUID) (int64, error) {
+	t, err := rc.Time(ctx).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	return rc.ZCount(ctx, connectionSemaphoreKey(connUID), fmt.Sprint(t.UnixMilli()), "+inf").Result()
+}
+
+// connectionSemaphore bounds the concurrent calls made with a connection.
+type connectionSemaphore struct {
+	client *redis.Client
+	key    string
+	limit  int
+}
+
+// acquire blocks until a permit is available or the context is done. It
+// returns the context of the call, which is cancelled if the permit can't be
+// renewed, and the function that releases the permit.
+func (s *connectionSemaphore) acquire(ctx context.Context) (callCtx context.Context, release func(), err error) {
+	holder := uuid.Must(uuid.NewV4()).String()
+	lease := connectionPermitLease.Milliseconds()
+
+	wait := minConnectionPermitWait
+	for {
+		ok, err := acquireConnectionPermitScript.Run(ctx, s.client, []string{s.key}, s.limit, holder, lease).Bool()
+		if err != nil {
+			return nil, nil, fmt.Errorf("acquiring connection permit: %w", err)
+		}
+		if ok {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		wait = min(2*wait, maxConnectionPermitWait)
+	}
+
+	callCtx, cancelCall := context.WithCancelCause(ctx)
+	renewCtx, stopRenewal := context.WithCancel(context.WithoutCancel(ctx))
+	ticker := time.NewTicker(connectionPermitRenewal)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		defer ticker.Stop()
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-ticker.C:
+				ok, err := renewConnectionPermitScript.Run(renewCtx, s.client, []string{s.key}, holder, lease).Bool()
+				if err == nil && !ok {
+					cancelCall(errConnectionPermitLost)
+					return
+				}
+			}
+		}
+	}()
+
+	return callCtx, func() {
+		stopRenewal()
+		wg.Wait()
+		cancelCall(nil)
+		_ = s.client.ZRem(context.WithoutCancel(ctx), s.key, holder).Err()
+	}, nil
+}
+
+// connectionLimitedExecution executes the jobs of a component after it gets
+// a permit of the connection the component uses.
+type connectionLimitedExecution struct {
+	componentbase.IExecution
+	semaphore *connectionSemaphore
+}
+
+// limitConnectionCalls wraps the execution of a component that uses a
+// connection with a concurrency limit. Other executions are returned as they
+// are.
+func (w *worker) limitConnectionCalls(ctx context.Context, x componentbase.IExecution, nsUID uuid.UUID, connID string) (componentbase.IExecution, error) {
+	if connID == "" || w.redisClient == nil {
+		return x, nil
+	}
+
+	// The setup of the connection is loaded when the pipeline is triggered,
+	// so the calls aren't limited if it has been deleted since.
+	conn, err := w.repository.GetNamespaceConnectionByID(ctx, nsUID, connID)
+	if errors.Is(err, errdomain.ErrNotFound) {
+		return x, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching connection: %w", err)
+	}
+	if conn.MaxConcurrency <= 0 {
+		return x, nil
+	}
+
+	return &connectionLimitedExecution{
+		IExecution: x,
+		semaphore: &connectionSemaphore{
+			client: w.redisClient,
+			key:    connectionSemaphoreKey(conn.UID),
+			limit:  int(conn.MaxConcurrency),
+		},
+	}, nil
+}
+
+// referencedConnectionID returns the ID of the connection a component setup
+// references, if any.
+func referencedConnectionID(setup any) string {
+	ref, ok := setup.(string)
+	if !ok {
+		return ""
+	}
+
+	id, err := recipe.ConnectionIDFromReference(ref)
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// Execute acquires a single permit for the batch of jobs, as the components
+// may send the whole batch to the external service in one call.
+func (x *connectionLimitedExecution) Execute(ctx context.Context, jobs []*componentbase.Job) error {
+	callCtx, release, err := x.semaphore.acquire(ctx)
+	if err != nil {
+		for _, job := range jobs {
+			job.Error.Error(ctx, err)
+		}
+		return nil
+	}
+	defer release()
+
+	err = x.IExecution.Execute(callCtx, jobs)
+	if err != nil && errors.Is(context.Cause(callCtx), errConnectionPermitLost) {
+		return fmt.Errorf("%w: %w", errConnectionPermitLost, err)
+	}
+	return err
+}
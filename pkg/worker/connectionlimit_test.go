@@ -0,0 +1,156 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/frankban/quicktest"
+	"github.com/gofrs/uuid"
+	"github.com/redis/go-redis/v9"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+// batchExecution records the batches of jobs it executes, along with the
+// calls in flight with the connection during each of them.
+type batchExecution struct {
+	componentbase.IExecution
+
+	inFlight func() int64
+	batches  []int
+	calls    []int64
+}
+
+func (x *batchExecution) Execute(_ context.Context, jobs []*componentbase.Job) error {
+	x.batches = append(x.batches, len(jobs))
+	x.calls = append(x.calls, x.inFlight())
+	return nil
+}
+
+func TestConnectionSemaphore(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	mr := miniredis.RunT(t)
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	c.Cleanup(func() { rc.Close() })
+
+	now := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	mr.SetTime(now)
+
+	newSemaphore := func(c *quicktest.C, limit int) (*connectionSemaphore, func() int64) {
+		connUID := uuid.Must(uuid.NewV4())
+		inFlight := func() int64 {
+			n, err := ConnectionCallsInFlight(ctx, rc, connUID)
+			c.Assert(err, quicktest.IsNil)
+			return n
+		}
+		return &connectionSemaphore{client: rc, key: connectionSemaphoreKey(connUID), limit: limit}, inFlight
+	}
+	// acquireBefore tries to acquire a permit before the timeout.
+	acquireBefore := func(s *connectionSemaphore, timeout time.Duration) (func(), error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		_, release, err := s.acquire(ctx)
+		return release, err
+	}
+
+	c.Run("ok - limit", func(c *quicktest.C) {
+		s, inFlight := newSemaphore(c, 2)
+
+		first, err := acquireBefore(s, time.Second)
+		c.Assert(err, quicktest.IsNil)
+		second, err := acquireBefore(s, time.Second)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(inFlight(), quicktest.Equals, int64(2))
+
+		_, err = acquireBefore(s, 200*time.Millisecond)
+		c.Check(err, quicktest.ErrorIs, context.DeadlineExceeded)
+
+		// A released permit is available to the waiting calls.
+		time.AfterFunc(100*time.Millisecond, first)
+		third, err := acquireBefore(s, 2*time.Second)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(inFlight(), quicktest.Equals, int64(2))
+
+		second()
+		third()
+		c.Check(inFlight(), quicktest.Equals, int64(0))
+	})
+
+	c.Run("ok - expired permits", func(c *quicktest.C) {
+		s, inFlight := newSemaphore(c, 1)
+
+		// The permit of a worker that stopped isn't released.
+		_, err := acquireBefore(s, time.Second)
+		c.Assert(err, quicktest.IsNil)
+		_, err = acquireBefore(s, 200*time.Millisecond)
+		c.Check(err, quicktest.ErrorIs, context.DeadlineExceeded)
+
+		now = now.Add(connectionPermitLease + time.Second)
+		mr.SetTime(now)
+		c.Check(inFlight(), quicktest.Equals, int64(0))
+
+		release, err := acquireBefore(s, time.Second)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(inFlight(), quicktest.Equals, int64(1))
+		release()
+	})
+
+	c.Run("ok - renewal", func(c *quicktest.C) {
+		renewal := connectionPermitRenewal
+		connectionPermitRenewal = 10 * time.Millisecond
+		c.Cleanup(func() { connectionPermitRenewal = renewal })
+		s, inFlight := newSemaphore(c, 1)
+
+		callCtx, release, err := s.acquire(ctx)
+		c.Assert(err, quicktest.IsNil)
+		defer release()
+
+		// The permit outlasts its first lease while it's renewed.
+		now = now.Add(connectionPermitLease / 2)
+		mr.SetTime(now)
+		time.Sleep(100 * time.Millisecond)
+		now = now.Add(connectionPermitLease / 2)
+		mr.SetTime(now)
+		c.Check(inFlight(), quicktest.Equals, int64(1))
+		c.Check(callCtx.Err(), quicktest.IsNil)
+	})
+
+	c.Run("nok - lost permit", func(c *quicktest.C) {
+		renewal := connectionPermitRenewal
+		connectionPermitRenewal = 10 * time.Millisecond
+		c.Cleanup(func() { connectionPermitRenewal = renewal })
+		s, _ := newSemaphore(c, 1)
+
+		callCtx, release, err := s.acquire(ctx)
+		c.Assert(err, quicktest.IsNil)
+		defer release()
+
+		// The permit expired, e.g. while Redis couldn't be reached.
+		c.Assert(rc.Del(ctx, s.key).Err(), quicktest.IsNil)
+
+		select {
+		case <-callCtx.Done():
+		case <-time.After(time.Second):
+			c.Fatal("the call wasn't cancelled")
+		}
+		c.Check(context.Cause(callCtx), quicktest.ErrorIs, errConnectionPermitLost)
+	})
+
+	c.Run("ok - batch", func(c *quicktest.C) {
+		s, inFlight := newSemaphore(c, 2)
+		x := &batchExecution{inFlight: inFlight}
+		jobs := []*componentbase.Job{{}, {}, {}}
+
+		limited := &connectionLimitedExecution{IExecution: x, semaphore: s}
+		c.Assert(limited.Execute(ctx, jobs), quicktest.IsNil)
+
+		// The batch is executed in a single call, with a single permit.
+		c.Check(x.batches, quicktest.DeepEquals, []int{3})
+		c.Check(x.calls, quicktest.DeepEquals, []int64{1})
+		c.Check(inFlight(), quicktest.Equals, int64(0))
+	})
+}
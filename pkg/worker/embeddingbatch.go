@@ -0,0 +1,270 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+// Defaults of the batching of the embedding requests.
+const (
+	embeddingTask = "TASK_EMBEDDING"
+
+	defaultEmbeddingBatchSize        = 32
+	defaultEmbeddingBatchConcurrency = 4
+	defaultEmbeddingBatchMaxRetries  = 2
+
+	embeddingBatchRetryInterval = time.Second
+)
+
+// embeddingBatchExecution executes the embedding requests of a component in
+// sub-batches. The inputs are split into requests of a bounded size, which are
+// executed concurrently. When a request fails, only its inputs are retried,
+// and the embeddings are reassembled in the order of the inputs.
+//
+// Embedding tasks share the standardized input and output format of the AI
+// components, so this is handled by the worker instead of each connector.
+type embeddingBatchExecution struct {
+	componentbase.IExecution
+
+	size        int
+	concurrency int
+	maxRetries  int
+}
+
+// newEmbeddingBatchExecution wraps the execution of an embedding task. Other
+// executions are returned as they are.
+func newEmbeddingBatchExecution(x componentbase.IExecution, cfg *datamodel.ComponentBatching) componentbase.IExecution {
+	if x.GetTask() != embeddingTask {
+		return x
+	}
+	if cfg == nil {
+		cfg = &datamodel.ComponentBatching{}
+	}
+
+	return &embeddingBatchExecution{
+		IExecution:  x,
+		size:        valueOrDefault(cfg.Size, defaultEmbeddingBatchSize),
+		concurrency: valueOrDefault(cfg.Concurrency, defaultEmbeddingBatchConcurrency),
+		maxRetries:  valueOrDefault(cfg.MaxRetries, defaultEmbeddingBatchMaxRetries),
+	}
+}
+
+// embeddingSubBatch is a request with a slice of the inputs of a job.
+type embeddingSubBatch struct {
+	offset int
+	input  *structpb.Struct
+	size   int
+
+	output *structpb.Struct
+	err    error
+}
+
+func (b *embeddingSubBatch) Read(context.Context) (*structpb.Struct, error) {
+	return b.input, nil
+}
+
+func (b *embeddingSubBatch) Write(_ context.Context, output *structpb.Struct) error {
+	b.output = output
+	return nil
+}
+
+func (b *embeddingSubBatch) Error(_ context.Context, err error) {
+	b.err = err
+}
+
+func (x *embeddingBatchExecution) Execute(ctx context.Context, jobs []*componentbase.Job) error {
+	// The jobs whose inputs fit in a request are executed together, as the
+	// component would do without batching.
+	direct := make([]*componentbase.Job, 0, len(jobs))
+
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		input, err := job.Input.Read(ctx)
+		if err != nil {
+			job.Error.Error(ctx, err)
+			continue
+		}
+
+		items := embeddingInputs(input)
+		if len(items) <= x.size {
+			direct = append(direct, &componentbase.Job{
+				Input:    &cachedInputReader{input: input},
+				Output:   job.Output,
+				Error:    job.Error,
+				Artifact: job.Artifact,
+			})
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			x.executeInBatches(ctx, job, input, items)
+		}()
+	}
+
+	var err error
+	if len(direct) > 0 {
+		err = x.IExecution.Execute(ctx, direct)
+	}
+	wg.Wait()
+
+	return err
+}
+
+// executeInBatches splits the inputs of a job into sub-batches and writes the
+// reassembled output once all of them succeed.
+func (x *embeddingBatchExecution) executeInBatches(ctx context.Context, job *componentbase.Job, input *structpb.Struct, items []*structpb.Value) {
+	batches := make([]*embeddingSubBatch, 0, (len(items)+x.size-1)/x.size)
+	for start := 0; start < len(items); start += x.size {
+		end := min(start+x.size, len(items))
+		batches = append(batches, &embeddingSubBatch{
+			offset: start,
+			input:  withEmbeddings(input, items[start:end]),
+			size:   end - start,
+		})
+	}
+
+	pending := batches
+	for attempt := 0; ; attempt++ {
+		x.executeSubBatches(ctx, job, pending)
+
+		failed := make([]*embeddingSubBatch, 0, len(pending))
+		for _, b := range pending {
+			if b.err != nil {
+				failed = append(failed, b)
+			}
+		}
+		if len(failed) == 0 {
+			break
+		}
+
+		if attempt == x.maxRetries || !waitForRetry(ctx, attempt) {
+			b := failed[0]
+			job.Error.Error(ctx, fmt.Errorf("embedding inputs %d to %d: %w", b.offset, b.offset+b.size-1, b.err))
+			return
+		}
+
+		for _, b := range failed {
+			b.output, b.err = nil, nil
+		}
+		pending = failed
+	}
+
+	output, err := reassembleEmbeddings(batches, len(items))
+	if err != nil {
+		job.Error.Error(ctx, err)
+		return
+	}
+	if err := job.Output.Write(ctx, output); err != nil {
+		job.Error.Error(ctx, err)
+	}
+}
+
+// executeSubBatches executes each sub-batch in its own request, with at most
+// x.concurrency requests in flight.
+func (x *embeddingBatchExecution) executeSubBatches(ctx context.Context, job *componentbase.Job, batches []*embeddingSubBatch) {
+	limiter := newActivityLimiter(x.concurrency)
+
+	var wg sync.WaitGroup
+	for _, b := range batches {
+		if err := limiter.acquire(ctx); err != nil {
+			b.err = err
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer limiter.release()
+
+			err := x.IExecution.Execute(ctx, []*componentbase.Job{{
+				Input:    b,
+				Output:   b,
+				Error:    b,
+				Artifact: job.Artifact,
+			}})
+			switch {
+			case err != nil:
+				b.err = err
+			case b.err == nil && len(embeddingOutputs(b.output)) != b.size:
+				b.err = fmt.Errorf("expected %d embeddings, got %d", b.size, len(embeddingOutputs(b.output)))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// waitForRetry waits before a failed sub-batch is retried, with an
+// exponential backoff. It returns false if the context is done first.
+func waitForRetry(ctx context.Context, attempt int) bool {
+	select {
+	case <-time.After(embeddingBatchRetryInterval << attempt):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// reassembleEmbeddings merges the outputs of the sub-batches. The index of
+// each embedding is relative to its sub-batch, so it's shifted to refer to
+// the position of the input in the original request.
+func reassembleEmbeddings(batches []*embeddingSubBatch, total int) (*structpb.Struct, error) {
+	embeddings := make([]*structpb.Value, total)
+	for _, b := range batches {
+		for i, v := range embeddingOutputs(b.output) {
+			idx := i
+			e := v.GetStructValue()
+			if n, ok := e.GetFields()["index"]; ok {
+				idx = int(n.GetNumberValue())
+			}
+			if idx < 0 || idx >= b.size || embeddings[b.offset+idx] != nil {
+				return nil, fmt.Errorf("invalid embedding index %d in inputs %d to %d", idx, b.offset, b.offset+b.size-1)
+			}
+
+			if e != nil {
+				fields := maps.Clone(e.GetFields())
+				fields["index"] = structpb.NewNumberValue(float64(b.offset + idx))
+				v = structpb.NewStructValue(&structpb.Struct{Fields: fields})
+			}
+			embeddings[b.offset+idx] = v
+		}
+	}
+
+	return withEmbeddings(batches[0].output, embeddings), nil
+}
+
+func embeddingInputs(input *structpb.Struct) []*structpb.Value {
+	return input.GetFields()["data"].GetStructValue().GetFields()["embeddings"].GetListValue().GetValues()
+}
+
+func embeddingOutputs(output *structpb.Struct) []*structpb.Value {
+	return embeddingInputs(output)
+}
+
+// withEmbeddings returns a copy of a request or response with a different
+// list in the data.embeddings field. The rest of the fields are shared.
+func withEmbeddings(s *structpb.Struct, embeddings []*structpb.Value) *structpb.Struct {
+	data := maps.Clone(s.GetFields()["data"].GetStructValue().GetFields())
+	if data == nil {
+		data = map[string]*structpb.Value{}
+	}
+	data["embeddings"] = structpb.NewListValue(&structpb.ListValue{Values: embeddings})
+
+	fields := maps.Clone(s.GetFields())
+	if fields == nil {
+		fields = map[string]*structpb.Value{}
+	}
+	fields["data"] = structpb.NewStructValue(&structpb.Struct{Fields: data})
+
+	return &structpb.Struct{Fields: fields}
+}
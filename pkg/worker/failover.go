@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+// failoverTargets returns the providers a component can be executed on, in
+// order. The first one is the component itself. The failover entries inherit
+// the type and task of the component unless they override them.
+func failoverTargets(param *ComponentActivityParam) []*datamodel.FailoverTarget {
+	targets := make([]*datamodel.FailoverTarget, 0, len(param.Failover)+1)
+	targets = append(targets, &datamodel.FailoverTarget{Type: param.Type, Task: param.Task})
+	for _, f := range param.Failover {
+		t := *f
+		if t.Type == "" {
+			t.Type = param.Type
+		}
+		if t.Task == "" {
+			t.Task = param.Task
+		}
+		targets = append(targets, &t)
+	}
+	return targets
+}
+
+// renderFailoverSetup resolves the references (e.g. connections) in the setup
+// of a failover entry.
+func renderFailoverSetup(ctx context.Context, wfm memory.WorkflowMemory, target *datamodel.FailoverTarget, batchIdx int) (*structpb.Struct, error) {
+	setupTemplate, err := data.NewValue(target.Setup)
+	if err != nil {
+		return nil, fmt.Errorf("reading failover setup: %w", err)
+	}
+
+	setupVal, err := recipe.Render(ctx, setupTemplate, batchIdx, wfm, false)
+	if err != nil {
+		return nil, err
+	}
+
+	setup, err := setupVal.ToStructValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return setup.GetStructValue(), nil
+}
+
+// unavailableItems collects the batch items whose provider was unavailable.
+// Jobs are executed concurrently by some components, so it's safe for
+// concurrent use.
+type unavailableItems struct {
+	mu    sync.Mutex
+	items []int
+}
+
+func (u *unavailableItems) add(originalIdx int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.items = append(u.items, originalIdx)
+}
+
+// list returns the collected items in batch order. A nil receiver returns no
+// items.
+func (u *unavailableItems) list() []int {
+	if u == nil {
+		return nil
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	items := append([]int(nil), u.items...)
+	sort.Ints(items)
+	return items
+}
+
+// failoverErrorHandler holds back the errors caused by an unavailable
+// provider, so the job can be executed again on the next provider of the
+// failover group. The rest of errors are written to the memory.
+type failoverErrorHandler struct {
+	componentbase.ErrorHandler
+
+	unavailable *unavailableItems
+	originalIdx int
+}
+
+func newFailoverErrorHandler(h componentbase.ErrorHandler, unavailable *unavailableItems, originalIdx int) componentbase.ErrorHandler {
+	if unavailable == nil {
+		return h
+	}
+
+	return &failoverErrorHandler{
+		ErrorHandler: h,
+		unavailable:  unavailable,
+		originalIdx:  originalIdx,
+	}
+}
+
+func (h *failoverErrorHandler) Error(ctx context.Context, err error) {
+	if errors.Is(err, componentbase.ErrProviderUnavailable) {
+		h.unavailable.add(h.originalIdx)
+		return
+	}
+
+	h.ErrorHandler.Error(ctx, err)
+}
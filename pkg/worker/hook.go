@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+)
+
+// TriggerHook is a plugin that runs around every pipeline trigger, regardless
+// of its recipe. Hooks are provided to the worker on creation and can
+// operate on the trigger memory, e.g. to sanitize the inputs, log the run for
+// compliance purposes or watermark the outputs.
+//
+// Hooks are executed within the trigger activities, which might be retried,
+// so they should be idempotent.
+type TriggerHook interface {
+	// Name identifies the hook in logs and errors.
+	Name() string
+
+	// PreTrigger is called once the trigger memory is initialized, before
+	// any component is executed. An error aborts the trigger.
+	PreTrigger(context.Context, *TriggerHookParam) error
+
+	// PostTrigger is called once the pipeline outputs are rendered, before
+	// they're stored and returned. An error makes the trigger fail.
+	PostTrigger(context.Context, *TriggerHookParam) error
+}
+
+// TriggerHookParam contains the data of the trigger that is exposed to the
+// hooks.
+type TriggerHookParam struct {
+	WorkflowID      string
+	SystemVariables recipe.SystemVariables
+
+	// Memory holds the inputs and outputs of every batch item. The pipeline
+	// outputs are only available in the PostTrigger stage.
+	Memory memory.WorkflowMemory
+}
+
+func (w *worker) runPreTriggerHooks(ctx context.Context, param *TriggerHookParam) error {
+	for _, h := range w.hooks {
+		if err := h.PreTrigger(ctx, param); err != nil {
+			return fmt.Errorf("running pre-trigger hook %s: %w", h.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (w *worker) runPostTriggerHooks(ctx context.Context, param *TriggerHookParam) error {
+	for _, h := range w.hooks {
+		if err := h.PostTrigger(ctx, param); err != nil {
+			return fmt.Errorf("running post-trigger hook %s: %w", h.Name(), err)
+		}
+	}
+	return nil
+}
@@ -1,13 +1,27 @@
 package worker
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/datatypes"
 
 	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
 	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/pipeline-backend/pkg/minio"
 	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+	"github.com/instill-ai/pipeline-backend/pkg/repository"
 	"github.com/instill-ai/x/errmsg"
 	"google.golang.org/protobuf/types/known/structpb"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
 )
 
 type setupReader struct {
@@ -48,13 +62,18 @@ type inputReader struct {
 	compID      string
 	wfm         memory.WorkflowMemory
 	originalIdx int
+
+	// schema is the input schema of the component task. When it's set, the
+	// rendered input is validated against it.
+	schema *data.Schema
 }
 
-func NewInputReader(wfm memory.WorkflowMemory, compID string, originalIdx int) *inputReader {
+func NewInputReader(wfm memory.WorkflowMemory, compID string, originalIdx int, schema *data.Schema) *inputReader {
 	return &inputReader{
 		compID:      compID,
 		wfm:         wfm,
 		originalIdx: originalIdx,
+		schema:      schema,
 	}
 }
 
@@ -70,10 +89,34 @@ func (i *inputReader) Read(ctx context.Context) (inputStruct *structpb.Struct, e
 		return nil, err
 	}
 
+	// Unless the recipe is strict, the input values that unambiguously
+	// convert to the type of the schema (e.g. "42" where a number is
+	// needed) are converted.
+	if r := i.wfm.GetRecipe(); i.schema != nil && (r == nil || r.Coercion != datamodel.CoercionStrict) {
+		inputVal = i.schema.Coerce(inputVal)
+	}
+
 	if err = i.wfm.SetComponentData(ctx, i.originalIdx, i.compID, memory.ComponentDataInput, inputVal); err != nil {
 		return nil, err
 	}
 
+	// The memory keeps the file references, the component receives the
+	// content of the files.
+	if inputVal, err = data.MaterializeFileRefs(ctx, inputVal); err != nil {
+		return nil, err
+	}
+
+	// The values referenced by the input might not have the type the
+	// component expects, e.g. a string where a number is needed. They're
+	// reported with their location in the recipe rather than failing within
+	// the component.
+	if i.schema != nil {
+		if verr := i.schema.Validate(inputVal, i.compID+".input"); verr != nil {
+			err = fmt.Errorf("%w: %w", errdomain.ErrInvalidArgument, verr)
+			return nil, errmsg.AddMessage(err, "Invalid component input: "+verr.Error()+".")
+		}
+	}
+
 	input, err := inputVal.ToStructValue()
 	if err != nil {
 		return nil, err
@@ -143,5 +186,96 @@ func NewErrorHandler(wfm memory.WorkflowMemory, compID string, originalIdx int)
 
 func (e *errorHandler) Error(ctx context.Context, err error) {
 	_ = e.wfm.SetComponentStatus(ctx, e.originalIdx, e.compID, memory.ComponentStatusErrored, true)
-	_ = e.wfm.SetComponentErrorMessage(ctx, e.originalIdx, e.compID, errmsg.MessageOrErr(err))
+	_ = e.wfm.SetComponentError(ctx, e.originalIdx, e.compID, newComponentError(err))
+}
+
+var artifactNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,254}$`)
+
+type artifactWriter struct {
+	repository        repository.Repository
+	minioClient       minio.MinioI
+	pipelineTriggerID string
+	compID            string
+	originalIdx       int
+}
+
+func NewArtifactWriter(r repository.Repository, minioClient minio.MinioI, pipelineTriggerID, compID string, originalIdx int) *artifactWriter {
+	return &artifactWriter{
+		repository:        r,
+		minioClient:       minioClient,
+		pipelineTriggerID: pipelineTriggerID,
+		compID:            compID,
+		originalIdx:       originalIdx,
+	}
+}
+
+func (a *artifactWriter) WriteArtifact(ctx context.Context, artifact *componentbase.Artifact) error {
+	if !artifactNameRegexp.MatchString(artifact.Name) {
+		return errmsg.AddMessage(
+			fmt.Errorf("invalid artifact name %q", artifact.Name),
+			fmt.Sprintf("Artifact name %q is invalid. Only letters, digits, dots, hyphens and underscores are allowed.", artifact.Name),
+		)
+	}
+
+	objectName := fmt.Sprintf("%s%s/%s/%d/%s", artifactObjectPrefix, a.pipelineTriggerID, a.compID, a.originalIdx, artifact.Name)
+	_, objectInfo, err := a.minioClient.UploadFileBytes(ctx, objectName, artifact.Content, artifact.ContentType)
+	if err != nil {
+		return fmt.Errorf("uploading artifact: %w", err)
+	}
+
+	metadata := datatypes.JSONMap{}
+	for k, v := range artifact.Metadata {
+		metadata[k] = v
+	}
+
+	return a.repository.UpsertRunArtifact(ctx, &datamodel.RunArtifact{
+		UID:                uuid.Must(uuid.NewV4()),
+		PipelineTriggerUID: uuid.FromStringOrNil(a.pipelineTriggerID),
+		ComponentID:        a.compID,
+		BatchIndex:         a.originalIdx,
+		Name:               artifact.Name,
+		ContentType:        objectInfo.ContentType,
+		Size:               objectInfo.Size,
+		ObjectKey:          objectInfo.Key,
+		Metadata:           metadata,
+	})
+}
+
+// The artifacts of a run can be referenced as files, e.g. to pass a large
+// artifact to a downstream component without holding it in the memory, with
+// URIs like artifact://pipeline-runs/artifact/<run>/<component>/<index>/<name>.
+const (
+	artifactURIScheme    = "artifact"
+	artifactObjectPrefix = "pipeline-runs/artifact/"
+)
+
+type runArtifactsKey struct{}
+
+// contextWithRunArtifacts allows the components executed with the context
+// to open the artifacts of a run.
+func contextWithRunArtifacts(ctx context.Context, pipelineTriggerID string) context.Context {
+	return context.WithValue(ctx, runArtifactsKey{}, pipelineTriggerID)
+}
+
+// newArtifactOpener returns the opener of the artifact URIs. A component
+// can only open the artifacts of the run it's executed in, as the URIs
+// might come from the user input.
+func newArtifactOpener(minioClient minio.MinioI) data.FileOpener {
+	return func(ctx context.Context, uri string) (io.ReadCloser, error) {
+		key := strings.TrimPrefix(uri, artifactURIScheme+"://")
+
+		pipelineTriggerID, _ := ctx.Value(runArtifactsKey{}).(string)
+		if pipelineTriggerID == "" || !strings.HasPrefix(key, artifactObjectPrefix+pipelineTriggerID+"/") {
+			return nil, errmsg.AddMessage(
+				fmt.Errorf("artifact %s doesn't belong to the run", key),
+				"Only the artifacts of the current run can be referenced.",
+			)
+		}
+
+		b, err := minioClient.GetFile(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("fetching artifact: %w", err)
+		}
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
 }
@@ -0,0 +1,235 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/frankban/quicktest"
+	"github.com/gojuno/minimock/v3"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/pipeline-backend/pkg/mock"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+	jsonoperator "github.com/instill-ai/pipeline-backend/pkg/component/operator/json/v0"
+	textoperator "github.com/instill-ai/pipeline-backend/pkg/component/operator/text/v0"
+)
+
+func TestArtifactOpener(t *testing.T) {
+	c := quicktest.New(t)
+	mc := minimock.NewController(t)
+
+	minioClient := mock.NewMinioIMock(mc)
+	minioClient.GetFileMock.Set(func(_ context.Context, filePath string) ([]byte, error) {
+		return []byte("content of " + filePath), nil
+	})
+	open := newArtifactOpener(minioClient)
+
+	const key = artifactObjectPrefix + "run-1/comp/0/report.pdf"
+	uri := artifactURIScheme + "://" + key
+
+	c.Run("ok - artifact of the run", func(c *quicktest.C) {
+		r, err := open(contextWithRunArtifacts(context.Background(), "run-1"), uri)
+		c.Assert(err, quicktest.IsNil)
+		defer r.Close()
+
+		b, err := io.ReadAll(r)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(string(b), quicktest.Equals, "content of "+key)
+	})
+
+	testcases := []struct {
+		name string
+		ctx  context.Context
+		uri  string
+	}{
+		{
+			name: "nok - artifact of another run",
+			ctx:  contextWithRunArtifacts(context.Background(), "run-2"),
+			uri:  uri,
+		},
+		{
+			name: "nok - run with the same prefix",
+			ctx:  contextWithRunArtifacts(context.Background(), "run"),
+			uri:  uri,
+		},
+		{
+			name: "nok - no run",
+			ctx:  context.Background(),
+			uri:  uri,
+		},
+		{
+			name: "nok - object outside of the artifacts",
+			ctx:  contextWithRunArtifacts(context.Background(), "run-1"),
+			uri:  artifactURIScheme + "://pipeline-runs/recipe/run-1/recipe.json",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			_, err := open(tc.ctx, tc.uri)
+			c.Check(err, quicktest.ErrorMatches, "artifact .* doesn't belong to the run")
+		})
+	}
+
+	c.Check(minioClient.GetFileAfterCounter(), quicktest.Equals, uint64(1))
+}
+
+// readInput reads the input of a component of a new run, as the component
+// activity does.
+func readInput(c *quicktest.C, recipe *datamodel.Recipe, schema *data.Schema, input data.Value) (data.Value, error) {
+	ctx := context.Background()
+	wfm, err := memory.NewMemoryStore(nil, 0, nil, 0, nil, nil).NewWorkflowMemory(ctx, "workflow", recipe, 1)
+	c.Assert(err, quicktest.IsNil)
+	wfm.InitComponent(ctx, 0, "comp")
+	c.Assert(wfm.SetComponentData(ctx, 0, "comp", memory.ComponentDataInput, input), quicktest.IsNil)
+
+	if _, err := NewInputReader(wfm, "comp", 0, schema).Read(ctx); err != nil {
+		return nil, err
+	}
+	return wfm.GetComponentData(ctx, 0, "comp", memory.ComponentDataInput)
+}
+
+func TestInputReaderSchema(t *testing.T) {
+	c := quicktest.New(t)
+
+	// The input is validated against the task schemas of the components.
+	schemas := map[string]map[string]string{
+		"json": jsonoperator.Init(componentbase.Component{}).GetTaskInputSchemas(),
+		"text": textoperator.Init(componentbase.Component{}).GetTaskInputSchemas(),
+	}
+	for compType, tasks := range schemas {
+		for task, schema := range tasks {
+			_, err := data.ParseSchema([]byte(schema))
+			c.Check(err, quicktest.IsNil, quicktest.Commentf("%s %s", compType, task))
+		}
+	}
+
+	newValue := func(v any) data.Value {
+		value, err := data.NewValue(v)
+		c.Assert(err, quicktest.IsNil)
+		return value
+	}
+
+	testcases := []struct {
+		name    string
+		comp    string
+		task    string
+		input   any
+		wantErr string
+	}{
+		{
+			name:  "ok - jq",
+			comp:  "json",
+			task:  "TASK_JQ",
+			input: map[string]any{"json-value": map[string]any{"a": 1}, "jq-filter": ".a"},
+		},
+		{
+			name:    "nok - jq without filter",
+			comp:    "json",
+			task:    "TASK_JQ",
+			input:   map[string]any{"json-value": []any{1}},
+			wantErr: ".*field jq-filter is required at comp.input.jq-filter",
+		},
+		{
+			name:    "nok - jq filter as object",
+			comp:    "json",
+			task:    "TASK_JQ",
+			input:   map[string]any{"jq-filter": map[string]any{"a": 1}},
+			wantErr: ".*field jq-filter must be string, got object at comp.input.jq-filter",
+		},
+		{
+			name: "ok - chunk text",
+			comp: "text",
+			task: "TASK_CHUNK_TEXT",
+			input: map[string]any{
+				"text":     "hello world",
+				"strategy": map[string]any{"setting": map[string]any{"chunk-method": "Recursive", "chunk-size": 100}},
+			},
+		},
+		{
+			name: "nok - unknown chunk method",
+			comp: "text",
+			task: "TASK_CHUNK_TEXT",
+			input: map[string]any{
+				"text":     "hello world",
+				"strategy": map[string]any{"setting": map[string]any{"chunk-method": "Unknown"}},
+			},
+			wantErr: ".*field chunk-method must be .* at comp.input.strategy.setting.chunk-method.*",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			schema, err := data.ParseSchema([]byte(schemas[tc.comp][tc.task]))
+			c.Assert(err, quicktest.IsNil)
+
+			_, err = readInput(c, &datamodel.Recipe{}, schema, newValue(tc.input))
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Check(err, quicktest.IsNil)
+		})
+	}
+}
+
+func TestInputReaderCoercion(t *testing.T) {
+	c := quicktest.New(t)
+
+	schema, err := data.ParseSchema([]byte(`{
+		"type": "object",
+		"properties": {
+			"top-k": {"type": "integer"},
+			"labels": {"type": "array", "items": {"type": "string"}}
+		}
+	}`))
+	c.Assert(err, quicktest.IsNil)
+	input := data.NewMap(map[string]data.Value{
+		"top-k":  data.NewString("42"),
+		"labels": data.NewString("cat"),
+	})
+
+	testcases := []struct {
+		name     string
+		coercion string
+		want     data.Value
+		wantErr  string
+	}{
+		{
+			name: "ok - lenient by default",
+			want: data.NewMap(map[string]data.Value{
+				"top-k":  data.NewNumberFromInteger(42),
+				"labels": data.NewArray([]data.Value{data.NewString("cat")}),
+			}),
+		},
+		{
+			name:     "ok - lenient",
+			coercion: datamodel.CoercionLenient,
+			want: data.NewMap(map[string]data.Value{
+				"top-k":  data.NewNumberFromInteger(42),
+				"labels": data.NewArray([]data.Value{data.NewString("cat")}),
+			}),
+		},
+		{
+			name:     "nok - strict",
+			coercion: datamodel.CoercionStrict,
+			wantErr:  ".*field labels must be array, got string at comp.input.labels; field top-k must be integer, got string at comp.input.top-k",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.name, func(c *quicktest.C) {
+			got, err := readInput(c, &datamodel.Recipe{Coercion: tc.coercion}, schema, input)
+			if tc.wantErr != "" {
+				c.Check(err, quicktest.ErrorMatches, tc.wantErr)
+				return
+			}
+			c.Assert(err, quicktest.IsNil)
+			c.Check(data.Equal(got, tc.want), quicktest.IsTrue)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/pipeline-backend/config"
+
+	workflowpb "go.temporal.io/api/workflow/v1"
+)
+
+// ReapWorkflowMemoryWorkflow purges the memory of the workflows whose
+// Temporal execution is closed. It can be scheduled in Temporal for the
+// deployments that don't run the memory janitor in the worker process.
+func (w *worker) ReapWorkflowMemoryWorkflow(wfctx workflow.Context) error {
+	ctx := workflow.WithActivityOptions(wfctx, workflow.ActivityOptions{
+		TaskQueue:           w.workerUID.String(),
+		StartToCloseTimeout: time.Hour,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: config.Config.Server.Workflow.MaxActivityRetry,
+		},
+	})
+
+	return workflow.ExecuteActivity(ctx, w.ReapWorkflowMemoryActivity).Get(ctx, nil)
+}
+
+// ReapWorkflowMemoryActivity purges the memory of the workflows whose
+// Temporal execution is closed. Only the memory held by the worker process
+// and by the persistence backend are swept.
+func (w *worker) ReapWorkflowMemoryActivity(ctx context.Context) error {
+	reaped, err := w.reapWorkflowMemory(ctx)
+	if err != nil {
+		return temporal.NewApplicationErrorWithCause("reaping workflow memory", reapMemoryActivityErrorType, err)
+	}
+
+	w.log.Info("ReapWorkflowMemoryActivity completed", zap.Int("reaped", reaped))
+	return nil
+}
+
+// RunMemoryJanitor reaps, periodically, the memory of the workflows that
+// weren't purged (e.g. because the workflow or the worker crashed) until the
+// context is done.
+func (w *worker) RunMemoryJanitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		reaped, err := w.reapWorkflowMemory(ctx)
+		if err != nil {
+			w.log.Error("Couldn't reap workflow memory", zap.Error(err))
+			continue
+		}
+		if reaped > 0 {
+			w.log.Info("Reaped workflow memory", zap.Int("reaped", reaped))
+		}
+	}
+}
+
+// reapWorkflowMemory purges the memory of the workflows that have been
+// closed for longer than the grace period and returns how many were purged.
+func (w *worker) reapWorkflowMemory(ctx context.Context) (int, error) {
+	workflowIDs, err := w.memoryStore.ListWorkflowMemory(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	c := w.temporalClient()
+	if c == nil {
+		return 0, fmt.Errorf("worker isn't running against a Temporal cluster")
+	}
+
+	grace := time.Duration(config.Config.Memory.ReapGracePeriod) * time.Second
+	now := time.Now()
+	listed := make(map[string]bool, len(workflowIDs))
+	reaped := 0
+	for _, workflowID := range workflowIDs {
+		listed[workflowID] = true
+
+		closedAt, err := w.closedAt(ctx, c, workflowID, now)
+		if err != nil {
+			w.log.Warn("Couldn't describe workflow execution", zap.String("workflowID", workflowID), zap.Error(err))
+			continue
+		}
+		if closedAt.IsZero() || now.Sub(closedAt) < grace {
+			continue
+		}
+
+		if err := w.memoryStore.PurgeWorkflowMemory(ctx, workflowID); err != nil {
+			w.log.Warn("Couldn't purge workflow memory", zap.String("workflowID", workflowID), zap.Error(err))
+			continue
+		}
+		reaped++
+	}
+
+	w.forgetOrphans(listed)
+	return reaped, nil
+}
+
+// closedAt returns the time the execution of a workflow closed, or the zero
+// time if it's still open.
+//
+// The memory of a workflow is created before its execution starts, so an
+// execution that isn't found is considered closed since the first sweep
+// that didn't find it. The memory of an iteration is merged into its
+// parent's after the iteration closes, so it's kept while the parent runs.
+func (w *worker) closedAt(ctx context.Context, c client.Client, workflowID string, now time.Time) (time.Time, error) {
+	info, err := describeExecution(ctx, c, workflowID)
+	if errors.As(err, new(*serviceerror.NotFound)) {
+		return w.orphanSince(workflowID, now), nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if info.GetStatus() == enums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		return time.Time{}, nil
+	}
+
+	if parent := info.GetParentExecution(); parent != nil {
+		parentInfo, err := describeExecution(ctx, c, parent.GetWorkflowId())
+		if err != nil && !errors.As(err, new(*serviceerror.NotFound)) {
+			return time.Time{}, err
+		}
+		if parentInfo.GetStatus() == enums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+			return time.Time{}, nil
+		}
+	}
+
+	if info.GetCloseTime() == nil {
+		return now, nil
+	}
+	return *info.GetCloseTime(), nil
+}
+
+func describeExecution(ctx context.Context, c client.Client, workflowID string) (*workflowpb.WorkflowExecutionInfo, error) {
+	resp, err := c.DescribeWorkflowExecution(ctx, workflowID, "")
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetWorkflowExecutionInfo(), nil
+}
+
+// orphanSince returns when a workflow was first found without execution.
+func (w *worker) orphanSince(workflowID string, now time.Time) time.Time {
+	w.orphansMu.Lock()
+	defer w.orphansMu.Unlock()
+
+	if w.orphans == nil {
+		w.orphans = map[string]time.Time{}
+	}
+	if since, ok := w.orphans[workflowID]; ok {
+		return since
+	}
+	w.orphans[workflowID] = now
+	return now
+}
+
+// forgetOrphans drops the workflows whose memory is no longer listed.
+func (w *worker) forgetOrphans(listed map[string]bool) {
+	w.orphansMu.Lock()
+	defer w.orphansMu.Unlock()
+
+	for workflowID := range w.orphans {
+		if !listed[workflowID] {
+			delete(w.orphans, workflowID)
+		}
+	}
+}
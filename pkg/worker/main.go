@@ -2,18 +2,25 @@ package worker
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/influxdata/influxdb-client-go/v2/api"
 	"github.com/redis/go-redis/v9"
+	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/workflow"
 	"go.uber.org/zap"
 
+	"github.com/instill-ai/pipeline-backend/pkg/data"
 	"github.com/instill-ai/pipeline-backend/pkg/logger"
 	"github.com/instill-ai/pipeline-backend/pkg/memory"
 	"github.com/instill-ai/pipeline-backend/pkg/minio"
 	"github.com/instill-ai/pipeline-backend/pkg/recipe"
 	"github.com/instill-ai/pipeline-backend/pkg/repository"
+	"github.com/instill-ai/pipeline-backend/pkg/session"
+
+	temporalworker "go.temporal.io/sdk/worker"
 
 	componentstore "github.com/instill-ai/pipeline-backend/pkg/component/store"
 )
@@ -25,17 +32,27 @@ const TaskQueue = "pipeline-backend"
 type Worker interface {
 	TriggerPipelineWorkflow(ctx workflow.Context, param *TriggerPipelineWorkflowParam) error
 	SchedulePipelineWorkflow(ctx workflow.Context, param *SchedulePipelineWorkflowParam) error
+	ReapWorkflowMemoryWorkflow(ctx workflow.Context) error
 
 	ComponentActivity(ctx context.Context, param *ComponentActivityParam) error
 	OutputActivity(ctx context.Context, param *ComponentActivityParam) error
 	PreIteratorActivity(ctx context.Context, param *PreIteratorActivityParam) (*PreIteratorActivityResult, error)
 	LoadDAGDataActivity(ctx context.Context, param *LoadDAGDataActivityParam) (*LoadDAGDataActivityResult, error)
 	PostIteratorActivity(ctx context.Context, param *PostIteratorActivityParam) error
+	PreErrorBranchActivity(ctx context.Context, param *ErrorBranchActivityParam) (bool, error)
+	PostErrorBranchActivity(ctx context.Context, param *ErrorBranchActivityParam) (bool, error)
+	PreApprovalActivity(ctx context.Context, param *ApprovalActivityParam) (bool, error)
+	PostApprovalActivity(ctx context.Context, param *PostApprovalActivityParam) error
+	PreBatchChunkActivity(ctx context.Context, param *PreBatchChunkActivityParam) (*PreBatchChunkActivityResult, error)
+	PostBatchChunkActivity(ctx context.Context, param *PostBatchChunkActivityParam) error
+	ReleaseBatchChunkActivity(ctx context.Context, workflowID string) error
+	PipelineTimedOutActivity(ctx context.Context, param *PipelineTimedOutActivityParam) error
 	PreTriggerActivity(ctx context.Context, param *PreTriggerActivityParam) error
 	PostTriggerActivity(ctx context.Context, param *PostTriggerActivityParam) error
 	ClosePipelineActivity(ctx context.Context, workflowID string) error
 	IncreasePipelineTriggerCountActivity(context.Context, recipe.SystemVariables) error
 
+	UpsertPipelineRunActivity(ctx context.Context, param *UpsertPipelineRunActivityParam) error
 	UpdatePipelineRunActivity(ctx context.Context, param *UpdatePipelineRunActivityParam) error
 	UpsertComponentRunActivity(ctx context.Context, param *UpsertComponentRunActivityParam) error
 	UploadInputsToMinioActivity(ctx context.Context, param *UploadInputsToMinioActivityParam) error
@@ -43,6 +60,16 @@ type Worker interface {
 	UploadRecipeToMinioActivity(ctx context.Context, param *UploadRecipeToMinioActivityParam) error
 	UploadComponentInputsActivity(ctx context.Context, param *ComponentActivityParam) error
 	UploadComponentOutputsActivity(ctx context.Context, param *ComponentActivityParam) error
+	CaptureFailedRunActivity(ctx context.Context, param *CaptureFailedRunActivityParam) error
+	ReapWorkflowMemoryActivity(ctx context.Context) error
+
+	RunMemoryJanitor(ctx context.Context, interval time.Duration)
+	RunMemoryWatchdog(ctx context.Context, interval time.Duration)
+
+	Start(cluster TemporalCluster, stopTimeout time.Duration) error
+	Stop()
+	Failover(cluster TemporalCluster) error
+	Cluster() string
 }
 
 // worker represents resources required to run Temporal workflow and activity
@@ -54,10 +81,29 @@ type worker struct {
 	minioClient         minio.MinioI
 	log                 *zap.Logger
 	memoryStore         memory.MemoryStore
+	sessions            session.Store
 	workerUID           uuid.UUID
+	hooks               []TriggerHook
+	connectorLimiter    activityLimiter
+	operatorLimiter     activityLimiter
+	sandbox             *sandbox
+
+	// mu protects the Temporal cluster the worker runs against, which
+	// changes on failover.
+	mu              sync.Mutex
+	cluster         string
+	client          client.Client
+	temporalWorkers []temporalworker.Worker
+	stopTimeout     time.Duration
+
+	// orphans holds when the workflows whose memory has no Temporal
+	// execution were first found, so they're reaped after a grace period.
+	orphansMu sync.Mutex
+	orphans   map[string]time.Time
 }
 
-// NewWorker initiates a temporal worker for workflow and activity definition
+// NewWorker initiates a temporal worker for workflow and activity definition.
+// The trigger hooks are executed, in order, around every pipeline trigger.
 func NewWorker(
 	r repository.Repository,
 	rc *redis.Client,
@@ -65,17 +111,28 @@ func NewWorker(
 	cs *componentstore.Store,
 	minioClient minio.MinioI,
 	m memory.MemoryStore,
+	sessions session.Store,
 	workerUID uuid.UUID,
+	hooks ...TriggerHook,
 ) Worker {
 	logger, _ := logger.GetZapLogger(context.Background())
+	connectorLimiter, operatorLimiter := newComponentLimiters()
+	if minioClient != nil {
+		data.RegisterFileOpener(artifactURIScheme, newArtifactOpener(minioClient))
+	}
 	return &worker{
 		repository:          r,
 		redisClient:         rc,
 		memoryStore:         m,
+		sessions:            sessions,
 		influxDBWriteClient: i,
 		component:           cs,
 		minioClient:         minioClient,
 		log:                 logger,
 		workerUID:           workerUID,
+		hooks:               hooks,
+		connectorLimiter:    connectorLimiter,
+		operatorLimiter:     operatorLimiter,
+		sandbox:             newSandbox(logger),
 	}
 }
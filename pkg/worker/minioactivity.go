@@ -21,6 +21,23 @@ func (w *worker) UploadInputsToMinioActivity(ctx context.Context, param *UploadI
 	if err != nil {
 		return err
 	}
+	if !capturesPayloads(wfm, param.PipelineTriggerID) {
+		log.Info("UploadInputsToMinioActivity skipped by the capture policy")
+		return nil
+	}
+
+	if err := w.uploadPipelineInputs(ctx, wfm, param.PipelineTriggerID); err != nil {
+		return err
+	}
+
+	log.Info("UploadInputsToMinioActivity finished")
+	return nil
+}
+
+// uploadPipelineInputs stores the variables and the trigger-time secrets of
+// a run.
+func (w *worker) uploadPipelineInputs(ctx context.Context, wfm memory.WorkflowMemory, pipelineTriggerID string) error {
+	log := w.log.With(zap.String("PipelineTriggerID", pipelineTriggerID))
 
 	pipelineData := make([]*structpb.Struct, wfm.GetBatchSize())
 
@@ -36,7 +53,7 @@ func (w *worker) UploadInputsToMinioActivity(ctx context.Context, param *UploadI
 		pipelineData[i] = varStr.GetStructValue()
 	}
 
-	objectName := fmt.Sprintf("pipeline-runs/input/%s.json", param.PipelineTriggerID)
+	objectName := fmt.Sprintf("pipeline-runs/input/%s.json", pipelineTriggerID)
 
 	url, objectInfo, err := w.minioClient.UploadFile(ctx, objectName, pipelineData, constant.ContentTypeJSON)
 	if err != nil {
@@ -51,16 +68,58 @@ func (w *worker) UploadInputsToMinioActivity(ctx context.Context, param *UploadI
 		URL:  url,
 	}}
 
-	err = w.repository.UpdatePipelineRun(ctx, param.PipelineTriggerID, &datamodel.PipelineRun{Inputs: inputs})
+	secrets, err := w.uploadTriggerSecrets(ctx, wfm, pipelineTriggerID)
+	if err != nil {
+		log.Error("failed to upload pipeline run secrets to minio", zap.Error(err))
+		return err
+	}
+
+	err = w.repository.UpdatePipelineRun(ctx, pipelineTriggerID, &datamodel.PipelineRun{Inputs: inputs, Secrets: secrets})
 	if err != nil {
 		log.Error("failed to save pipeline run input data", zap.Error(err))
 		return err
 	}
 
-	log.Info("UploadInputsToMinioActivity finished")
 	return nil
 }
 
+// uploadTriggerSecrets stores the secrets provided in the trigger request, so
+// the run can be retried with the same data. Runs without trigger-time
+// secrets don't produce any object.
+func (w *worker) uploadTriggerSecrets(ctx context.Context, wfm memory.WorkflowMemory, pipelineTriggerID string) (datamodel.JSONB, error) {
+	secrets := make([]*structpb.Struct, wfm.GetBatchSize())
+	hasSecrets := false
+	for i := range wfm.GetBatchSize() {
+		val, err := wfm.GetPipelineData(ctx, i, memory.PipelineSecret)
+		if err != nil {
+			return nil, err
+		}
+		secretStr, err := val.ToStructValue()
+		if err != nil {
+			return nil, err
+		}
+		secrets[i] = secretStr.GetStructValue()
+		hasSecrets = hasSecrets || len(secrets[i].GetFields()) > 0
+	}
+
+	if !hasSecrets {
+		return nil, nil
+	}
+
+	objectName := fmt.Sprintf("pipeline-runs/secret/%s.json", pipelineTriggerID)
+	url, objectInfo, err := w.minioClient.UploadFile(ctx, objectName, secrets, constant.ContentTypeJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return datamodel.JSONB{{
+		Name: objectInfo.Key,
+		Type: objectInfo.ContentType,
+		Size: objectInfo.Size,
+		URL:  url,
+	}}, nil
+}
+
 func (w *worker) UploadRecipeToMinioActivity(ctx context.Context, param *UploadRecipeToMinioActivityParam) error {
 	log := w.log.With(zap.String("PipelineTriggerUID", param.PipelineTriggerID))
 	log.Info("UploadReceiptToMinioActivity started")
@@ -76,6 +135,9 @@ func (w *worker) UploadRecipeToMinioActivity(ctx context.Context, param *UploadR
 		Component: wfm.GetRecipe().Component,
 		Variable:  wfm.GetRecipe().Variable,
 		Output:    wfm.GetRecipe().Output,
+
+		MaxDuration: wfm.GetRecipe().MaxDuration,
+		Capture:     wfm.GetRecipe().Capture,
 	}
 	b, err := json.Marshal(recipeForUpload)
 	if err != nil {
@@ -113,12 +175,27 @@ func (w *worker) UploadOutputsToMinioActivity(ctx context.Context, param *Upload
 	log := w.log.With(zap.String("PipelineTriggerUID", param.PipelineTriggerID))
 	log.Info(fmt.Sprintf("%s started", eventName))
 
-	objectName := fmt.Sprintf("pipeline-runs/output/%s.json", param.PipelineTriggerID)
-
 	wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.PipelineTriggerID)
 	if err != nil {
 		return err
 	}
+	if !capturesPayloads(wfm, param.PipelineTriggerID) {
+		log.Info(fmt.Sprintf("%s skipped by the capture policy", eventName))
+		return nil
+	}
+
+	if err := w.uploadPipelineOutputs(ctx, wfm, param.PipelineTriggerID); err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("%s finished", eventName))
+	return nil
+}
+
+// uploadPipelineOutputs stores the outputs of a run.
+func (w *worker) uploadPipelineOutputs(ctx context.Context, wfm memory.WorkflowMemory, pipelineTriggerID string) error {
+	log := w.log.With(zap.String("PipelineTriggerUID", pipelineTriggerID))
+	objectName := fmt.Sprintf("pipeline-runs/output/%s.json", pipelineTriggerID)
 
 	outputStructs := make([]*structpb.Struct, wfm.GetBatchSize())
 
@@ -149,13 +226,12 @@ func (w *worker) UploadOutputsToMinioActivity(ctx context.Context, param *Upload
 		URL:  url,
 	}}
 
-	err = w.repository.UpdatePipelineRun(ctx, param.PipelineTriggerID, &datamodel.PipelineRun{Outputs: outputs})
+	err = w.repository.UpdatePipelineRun(ctx, pipelineTriggerID, &datamodel.PipelineRun{Outputs: outputs})
 	if err != nil {
 		log.Error("failed to save pipeline run output data", zap.Error(err))
 		return err
 	}
 
-	log.Info(fmt.Sprintf("%s finished", eventName))
 	return nil
 }
 
@@ -169,11 +245,22 @@ func (w *worker) UploadComponentInputsActivity(ctx context.Context, param *Compo
 	if err != nil {
 		return err
 	}
+	if !capturesPayloads(wfm, pipelineTriggerID) {
+		log.Info("UploadComponentInputsActivity skipped by the capture policy")
+		return nil
+	}
+
+	return w.uploadComponentInputs(ctx, wfm, pipelineTriggerID, param.ID)
+}
+
+// uploadComponentInputs stores the inputs of a component in a run.
+func (w *worker) uploadComponentInputs(ctx context.Context, wfm memory.WorkflowMemory, pipelineTriggerID, componentID string) error {
+	log := w.log.With(zap.String("PipelineTriggerUID", pipelineTriggerID), zap.String("ComponentID", componentID))
 
 	compInputs := make([]*structpb.Struct, wfm.GetBatchSize())
 
 	for i := range wfm.GetBatchSize() {
-		val, err := wfm.GetComponentData(ctx, i, param.ID, memory.ComponentDataInput)
+		val, err := wfm.GetComponentData(ctx, i, componentID, memory.ComponentDataInput)
 		if err != nil {
 			return err
 		}
@@ -184,7 +271,7 @@ func (w *worker) UploadComponentInputsActivity(ctx context.Context, param *Compo
 		compInputs[i] = varStr.GetStructValue()
 	}
 
-	objectName := fmt.Sprintf("component-runs/%s/input/%s.json", param.ID, pipelineTriggerID)
+	objectName := fmt.Sprintf("component-runs/%s/input/%s.json", componentID, pipelineTriggerID)
 
 	url, objectInfo, err := w.minioClient.UploadFile(ctx, objectName, compInputs, constant.ContentTypeJSON)
 	if err != nil {
@@ -199,7 +286,7 @@ func (w *worker) UploadComponentInputsActivity(ctx context.Context, param *Compo
 		URL:  url,
 	}}
 
-	err = w.repository.UpdateComponentRun(ctx, pipelineTriggerID, param.ID, &datamodel.ComponentRun{Inputs: inputs})
+	err = w.repository.UpdateComponentRun(ctx, pipelineTriggerID, componentID, &datamodel.ComponentRun{Inputs: inputs})
 	if err != nil {
 		log.Error("failed to save pipeline run input data", zap.Error(err))
 		return err
@@ -213,17 +300,27 @@ func (w *worker) UploadComponentOutputsActivity(ctx context.Context, param *Comp
 
 	log.Info("UploadComponentOutputsActivity started")
 
-	objectName := fmt.Sprintf("component-runs/%s/output/%s.json", pipelineTriggerID, param.ID)
-
 	wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.WorkflowID)
 	if err != nil {
 		return err
 	}
+	if !capturesPayloads(wfm, pipelineTriggerID) {
+		log.Info("UploadComponentOutputsActivity skipped by the capture policy")
+		return nil
+	}
+
+	return w.uploadComponentOutputs(ctx, wfm, pipelineTriggerID, param.ID)
+}
+
+// uploadComponentOutputs stores the outputs of a component in a run.
+func (w *worker) uploadComponentOutputs(ctx context.Context, wfm memory.WorkflowMemory, pipelineTriggerID, componentID string) error {
+	log := w.log.With(zap.String("PipelineTriggerUID", pipelineTriggerID), zap.String("ComponentID", componentID))
+	objectName := fmt.Sprintf("component-runs/%s/output/%s.json", pipelineTriggerID, componentID)
 
 	compOutputs := make([]*structpb.Struct, wfm.GetBatchSize())
 
 	for i := range wfm.GetBatchSize() {
-		val, err := wfm.GetComponentData(ctx, i, param.ID, memory.ComponentDataOutput)
+		val, err := wfm.GetComponentData(ctx, i, componentID, memory.ComponentDataOutput)
 		if err != nil {
 			return err
 		}
@@ -247,7 +344,7 @@ func (w *worker) UploadComponentOutputsActivity(ctx context.Context, param *Comp
 		URL:  url,
 	}}
 
-	err = w.repository.UpdateComponentRun(ctx, pipelineTriggerID, param.ID, &datamodel.ComponentRun{Outputs: outputs})
+	err = w.repository.UpdateComponentRun(ctx, pipelineTriggerID, componentID, &datamodel.ComponentRun{Outputs: outputs})
 	if err != nil {
 		log.Error("failed to save pipeline run output data", zap.Error(err))
 		return err
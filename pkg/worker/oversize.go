@@ -0,0 +1,212 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/config"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/logger"
+	"github.com/instill-ai/x/errmsg"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+// Policies for the component outputs that exceed the size limit.
+const (
+	oversizedOutputTruncate = "truncate"
+	oversizedOutputSpill    = "spill"
+)
+
+// oversizedOutputArtifact is the name of the artifact that holds the full
+// output of a batch item when it's spilled.
+const oversizedOutputArtifact = "oversized-output.json"
+
+// truncationSlack accounts for the bytes of the truncation marker and the
+// length prefixes of the shortened strings.
+const truncationSlack = 64
+
+// oversizedOutputs collects the actions taken on the outputs of a component
+// that exceeded the size limit, so they're recorded on the component run.
+type oversizedOutputs struct {
+	mu      sync.Mutex
+	actions map[int]datamodel.OutputAction
+}
+
+// record keeps the last action of each batch item, as a streaming component
+// writes its output several times.
+func (o *oversizedOutputs) record(action datamodel.OutputAction) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.actions == nil {
+		o.actions = map[int]datamodel.OutputAction{}
+	}
+	o.actions[action.BatchIndex] = action
+}
+
+func (o *oversizedOutputs) list() datamodel.OutputActions {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.actions) == 0 {
+		return nil
+	}
+	actions := make(datamodel.OutputActions, 0, len(o.actions))
+	for _, a := range o.actions {
+		actions = append(actions, a)
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i].BatchIndex < actions[j].BatchIndex })
+	return actions
+}
+
+// sizeLimitedOutputWriter handles the outputs that exceed the size limit
+// before they're written, rather than letting them fail the component
+// activity.
+type sizeLimitedOutputWriter struct {
+	componentbase.OutputWriter
+
+	maxSize     int
+	policy      string
+	artifact    componentbase.ArtifactWriter
+	originalIdx int
+	oversized   *oversizedOutputs
+}
+
+// limitOutputSize wraps an output writer with the output size limit of the
+// deployment. The writer is returned as is if there's no limit.
+func limitOutputSize(ow componentbase.OutputWriter, artifact componentbase.ArtifactWriter, originalIdx int, oversized *oversizedOutputs) componentbase.OutputWriter {
+	cfg := config.Config.Server.Workflow
+	if cfg.MaxOutputSize <= 0 {
+		return ow
+	}
+
+	return &sizeLimitedOutputWriter{
+		OutputWriter: ow,
+		maxSize:      cfg.MaxOutputSize << 10,
+		policy:       cfg.OversizedOutputPolicy,
+		artifact:     artifact,
+		originalIdx:  originalIdx,
+		oversized:    oversized,
+	}
+}
+
+func (w *sizeLimitedOutputWriter) Write(ctx context.Context, output *structpb.Struct) error {
+	size := proto.Size(output)
+	if size <= w.maxSize {
+		return w.OutputWriter.Write(ctx, output)
+	}
+
+	logger, _ := logger.GetZapLogger(ctx)
+	action := datamodel.OutputAction{
+		BatchIndex: w.originalIdx,
+		Action:     datamodel.OutputActionTruncated,
+		Size:       size,
+	}
+	marker := "…[truncated %d bytes]"
+
+	if w.policy == oversizedOutputSpill {
+		b, err := protojson.Marshal(output)
+		if err != nil {
+			return fmt.Errorf("marshalling oversized output: %w", err)
+		}
+		if err := w.artifact.WriteArtifact(ctx, &componentbase.Artifact{
+			Name:        oversizedOutputArtifact,
+			ContentType: "application/json",
+			Content:     b,
+		}); err != nil {
+			return fmt.Errorf("spilling oversized output: %w", err)
+		}
+
+		action.Action = datamodel.OutputActionSpilled
+		action.Artifact = oversizedOutputArtifact
+		marker = "…[truncated %d bytes, the full output is in the run artifact " + oversizedOutputArtifact + "]"
+	}
+
+	// The component might keep a reference to its output, so a copy is
+	// truncated.
+	truncated := proto.Clone(output).(*structpb.Struct)
+	if !truncateOutput(truncated, w.maxSize, marker) {
+		return errmsg.AddMessage(
+			fmt.Errorf("output of %d bytes exceeds the limit of %d bytes", size, w.maxSize),
+			fmt.Sprintf("The component output exceeds the limit of %d KB and can't be truncated.", w.maxSize>>10),
+		)
+	}
+
+	logger.Warn("Component output exceeds the size limit",
+		zap.Int("batchIndex", w.originalIdx),
+		zap.Int("size", size),
+		zap.String("action", action.Action),
+	)
+	w.oversized.record(action)
+
+	return w.OutputWriter.Write(ctx, truncated)
+}
+
+// truncateOutput shortens the largest strings of an output until it fits in
+// maxSize bytes. Each truncated string ends with the marker, which is
+// formatted with the number of bytes removed. It returns false if the output
+// doesn't fit once all its strings are truncated, e.g. when most of the
+// output is made of numbers.
+func truncateOutput(output *structpb.Struct, maxSize int, marker string) bool {
+	truncated := map[*structpb.Value]bool{}
+	for {
+		excess := proto.Size(output) - maxSize
+		if excess <= 0 {
+			return true
+		}
+
+		v := largestString(structpb.NewStructValue(output), truncated)
+		if v == nil {
+			return false
+		}
+		truncated[v] = true
+
+		s := v.GetStringValue()
+		keep := max(len(s)-excess-len(marker)-truncationSlack, 0)
+		for keep > 0 && !utf8.RuneStart(s[keep]) {
+			keep--
+		}
+		if keep == 0 && len(s) <= len(marker)+truncationSlack {
+			// Replacing such a short string with the marker doesn't
+			// free any space.
+			continue
+		}
+		v.Kind = &structpb.Value_StringValue{StringValue: s[:keep] + fmt.Sprintf(marker, len(s)-keep)}
+	}
+}
+
+// largestString returns the longest string of a value that hasn't been
+// truncated yet.
+func largestString(v *structpb.Value, truncated map[*structpb.Value]bool) *structpb.Value {
+	var largest *structpb.Value
+	consider := func(c *structpb.Value) {
+		if c != nil && (largest == nil || len(c.GetStringValue()) > len(largest.GetStringValue())) {
+			largest = c
+		}
+	}
+
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		if !truncated[v] {
+			return v
+		}
+	case *structpb.Value_StructValue:
+		for _, f := range k.StructValue.GetFields() {
+			consider(largestString(f, truncated))
+		}
+	case *structpb.Value_ListValue:
+		for _, item := range k.ListValue.GetValues() {
+			consider(largestString(item, truncated))
+		}
+	}
+	return largest
+}
@@ -0,0 +1,266 @@
+package worker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"go.temporal.io/sdk/converter"
+
+	"github.com/instill-ai/pipeline-backend/config"
+
+	commonpb "go.temporal.io/api/common/v1"
+)
+
+// The encodings of the payloads transformed by the codecs. The original
+// payload, with its own metadata, is serialized into the data of the
+// transformed one.
+const (
+	encodingGzip      = "binary/gzip"
+	encodingZstd      = "binary/zstd"
+	encodingEncrypted = "binary/encrypted"
+
+	metadataEncryptionKeyID = "encryption-key-id"
+)
+
+// NewPayloadCodecs returns the codecs that encode the Temporal payloads, as
+// configured. The trigger payloads (inputs, recipes, secrets...) are kept in
+// the workflow history, so they're compressed to limit the history size and
+// encrypted so they can't be read by anyone with access to Temporal.
+//
+// The codecs are returned in the order expected by the Temporal data
+// converter: the first one is applied last on encode, so the payloads are
+// compressed before they're encrypted.
+func NewPayloadCodecs(cfg config.TemporalCodecConfig) ([]converter.PayloadCodec, error) {
+	var codecs []converter.PayloadCodec
+
+	if cfg.EncryptionKey != "" {
+		c, err := newEncryptionCodec(cfg.EncryptionKey, cfg.PreviousEncryptionKeys)
+		if err != nil {
+			return nil, err
+		}
+		codecs = append(codecs, c)
+	}
+
+	switch cfg.Compression {
+	case "":
+	case "gzip", "zstd":
+		codecs = append(codecs, &compressionCodec{algorithm: cfg.Compression, minSize: cfg.MinSize})
+	default:
+		return nil, fmt.Errorf("unsupported payload compression algorithm: %s", cfg.Compression)
+	}
+
+	return codecs, nil
+}
+
+// NewDataConverter returns a data converter that encodes the payloads with
+// the codecs, or the default one if there are no codecs. Every Temporal
+// client that starts or reads the workflows must use the same converter.
+func NewDataConverter(codecs []converter.PayloadCodec) converter.DataConverter {
+	if len(codecs) == 0 {
+		return converter.GetDefaultDataConverter()
+	}
+	return converter.NewCodecDataConverter(converter.GetDefaultDataConverter(), codecs...)
+}
+
+// transformPayloads applies a transformation to each payload.
+func transformPayloads(payloads []*commonpb.Payload, fn func(*commonpb.Payload) (*commonpb.Payload, error)) ([]*commonpb.Payload, error) {
+	result := make([]*commonpb.Payload, len(payloads))
+	for i, p := range payloads {
+		transformed, err := fn(p)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = transformed
+	}
+	return result, nil
+}
+
+func payloadEncoding(p *commonpb.Payload) string {
+	return string(p.GetMetadata()[converter.MetadataEncoding])
+}
+
+type compressionCodec struct {
+	algorithm string
+	minSize   int
+}
+
+var (
+	payloadZstdEncoder, _ = zstd.NewWriter(nil)
+	payloadZstdDecoder, _ = zstd.NewReader(nil)
+)
+
+func (c *compressionCodec) Encode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	return transformPayloads(payloads, func(p *commonpb.Payload) (*commonpb.Payload, error) {
+		if p.Size() < c.minSize {
+			return p, nil
+		}
+
+		b, err := p.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("serializing payload: %w", err)
+		}
+
+		encoding := encodingZstd
+		var compressed []byte
+		switch c.algorithm {
+		case "gzip":
+			encoding = encodingGzip
+			buf := new(bytes.Buffer)
+			zw := gzip.NewWriter(buf)
+			if _, err := zw.Write(b); err != nil {
+				return nil, err
+			}
+			if err := zw.Close(); err != nil {
+				return nil, err
+			}
+			compressed = buf.Bytes()
+		default:
+			compressed = payloadZstdEncoder.EncodeAll(b, make([]byte, 0, len(b)/2))
+		}
+
+		// Some payloads (e.g. the ones with compressed images) don't shrink.
+		if len(compressed) >= len(b) {
+			return p, nil
+		}
+
+		return &commonpb.Payload{
+			Metadata: map[string][]byte{converter.MetadataEncoding: []byte(encoding)},
+			Data:     compressed,
+		}, nil
+	})
+}
+
+// Decode decompresses the payloads with any of the supported algorithms, so
+// the algorithm can be changed without losing the existing histories.
+func (c *compressionCodec) Decode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	return transformPayloads(payloads, func(p *commonpb.Payload) (*commonpb.Payload, error) {
+		var b []byte
+		var err error
+		switch payloadEncoding(p) {
+		case encodingZstd:
+			b, err = payloadZstdDecoder.DecodeAll(p.GetData(), nil)
+		case encodingGzip:
+			var zr *gzip.Reader
+			if zr, err = gzip.NewReader(bytes.NewReader(p.GetData())); err == nil {
+				b, err = io.ReadAll(zr)
+				zr.Close()
+			}
+		default:
+			return p, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decompressing payload: %w", err)
+		}
+
+		decoded := &commonpb.Payload{}
+		if err := decoded.Unmarshal(b); err != nil {
+			return nil, fmt.Errorf("deserializing payload: %w", err)
+		}
+		return decoded, nil
+	})
+}
+
+type encryptionCodec struct {
+	keyID string
+	aead  cipher.AEAD
+
+	// keys holds the current key and the previous ones, indexed by their
+	// ID, to decrypt the payloads encrypted before a key rotation.
+	keys map[string]cipher.AEAD
+}
+
+func newEncryptionCodec(key string, previousKeys []string) (*encryptionCodec, error) {
+	c := &encryptionCodec{keys: map[string]cipher.AEAD{}}
+	for i, k := range append([]string{key}, previousKeys...) {
+		id, aead, err := payloadKey(k)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			c.keyID, c.aead = id, aead
+		}
+		c.keys[id] = aead
+	}
+	return c, nil
+}
+
+// payloadKey decodes an encryption key. Its ID is derived from the key, so
+// the encrypted payloads can reference it without revealing it.
+func payloadKey(encoded string) (string, cipher.AEAD, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("decoding payload encryption key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", nil, fmt.Errorf("initializing payload encryption key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", nil, fmt.Errorf("initializing payload encryption key: %w", err)
+	}
+
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8]), aead, nil
+}
+
+func (c *encryptionCodec) Encode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	return transformPayloads(payloads, func(p *commonpb.Payload) (*commonpb.Payload, error) {
+		b, err := p.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("serializing payload: %w", err)
+		}
+
+		nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(b)+c.aead.Overhead())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, fmt.Errorf("generating nonce: %w", err)
+		}
+
+		return &commonpb.Payload{
+			Metadata: map[string][]byte{
+				converter.MetadataEncoding: []byte(encodingEncrypted),
+				metadataEncryptionKeyID:    []byte(c.keyID),
+			},
+			Data: c.aead.Seal(nonce, nonce, b, nil),
+		}, nil
+	})
+}
+
+func (c *encryptionCodec) Decode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	return transformPayloads(payloads, func(p *commonpb.Payload) (*commonpb.Payload, error) {
+		if payloadEncoding(p) != encodingEncrypted {
+			return p, nil
+		}
+
+		keyID := string(p.GetMetadata()[metadataEncryptionKeyID])
+		aead, ok := c.keys[keyID]
+		if !ok {
+			return nil, fmt.Errorf("payload encrypted with unknown key %s", keyID)
+		}
+
+		data := p.GetData()
+		if len(data) < aead.NonceSize() {
+			return nil, fmt.Errorf("encrypted payload too short")
+		}
+		b, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting payload: %w", err)
+		}
+
+		decoded := &commonpb.Payload{}
+		if err := decoded.Unmarshal(b); err != nil {
+			return nil, fmt.Errorf("deserializing payload: %w", err)
+		}
+		return decoded, nil
+	})
+}
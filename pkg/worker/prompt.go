@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+	"github.com/instill-ai/pipeline-backend/pkg/recipe"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// resolvePromptReferences replaces the `prompt://` references in the input
+// of a component with the template of the referenced prompt version. The
+// templates are rendered along with the rest of the input, so their `${...}`
+// references are resolved against the pipeline data. The items whose
+// references can't be resolved are marked as errored, and the rest of the
+// items are returned.
+func (w *worker) resolvePromptReferences(ctx context.Context, wfm memory.WorkflowMemory, param *ComponentActivityParam, items []int) ([]int, error) {
+	templates := map[string]string{}
+	resolvedItems := make([]int, 0, len(items))
+	for _, idx := range items {
+		input, err := wfm.GetComponentData(ctx, idx, param.ID, memory.ComponentDataInput)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved, changed, err := w.resolvePromptValue(ctx, param, input, templates)
+		if err != nil {
+			NewErrorHandler(wfm, param.ID, idx).Error(ctx, err)
+			continue
+		}
+		resolvedItems = append(resolvedItems, idx)
+		if !changed {
+			continue
+		}
+
+		if err := wfm.SetComponentData(ctx, idx, param.ID, memory.ComponentDataInput, resolved); err != nil {
+			return nil, err
+		}
+	}
+
+	return resolvedItems, nil
+}
+
+// resolvePromptValue walks an input template. The fetched templates are kept
+// by reference so each prompt version is only fetched once per activity.
+func (w *worker) resolvePromptValue(ctx context.Context, param *ComponentActivityParam, v data.Value, templates map[string]string) (data.Value, bool, error) {
+	switch v := v.(type) {
+	case *data.String:
+		ref := v.GetString()
+		if !recipe.IsPromptReference(ref) {
+			return v, false, nil
+		}
+
+		template, ok := templates[ref]
+		if !ok {
+			var err error
+			if template, err = w.fetchPromptTemplate(ctx, param, ref); err != nil {
+				return nil, false, err
+			}
+			templates[ref] = template
+		}
+		return data.NewString(template), true, nil
+
+	case *data.Map:
+		changed := false
+		fields := make(map[string]data.Value, len(v.Fields))
+		for k, f := range v.Fields {
+			resolved, c, err := w.resolvePromptValue(ctx, param, f, templates)
+			if err != nil {
+				return nil, false, err
+			}
+			fields[k], changed = resolved, changed || c
+		}
+		if !changed {
+			return v, false, nil
+		}
+		return data.NewMap(fields), true, nil
+
+	case *data.Array:
+		changed := false
+		values := make([]data.Value, len(v.Values))
+		for i, item := range v.Values {
+			resolved, c, err := w.resolvePromptValue(ctx, param, item, templates)
+			if err != nil {
+				return nil, false, err
+			}
+			values[i], changed = resolved, changed || c
+		}
+		if !changed {
+			return v, false, nil
+		}
+		return data.NewArray(values), true, nil
+	}
+
+	return v, false, nil
+}
+
+func (w *worker) fetchPromptTemplate(ctx context.Context, param *ComponentActivityParam, ref string) (string, error) {
+	promptRef, err := recipe.ParsePromptReference(ref)
+	if err != nil {
+		return "", err
+	}
+
+	p, err := w.repository.GetNamespacePromptByID(ctx, param.SystemVariables.PipelineOwnerUID, promptRef.ID, promptRef.Version)
+	if err != nil {
+		if errors.Is(err, errdomain.ErrNotFound) {
+			err = errmsg.AddMessage(err, fmt.Sprintf("Prompt %s doesn't exist.", ref))
+		}
+		return "", fmt.Errorf("fetching prompt: %w", err)
+	}
+
+	return p.Template, nil
+}
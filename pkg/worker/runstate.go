@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"errors"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+)
+
+// RunStateQuery is the name of the Temporal query that returns the live state
+// of a trigger workflow.
+const RunStateQuery = "run-state"
+
+// Statuses of a component in a RunState.
+const (
+	ComponentStatePending         = "pending"
+	ComponentStateProcessing      = "processing"
+	ComponentStateWaitingApproval = "waiting-approval"
+	ComponentStateCompleted       = "completed"
+	ComponentStateFailed          = "failed"
+	ComponentStateCancelled       = "cancelled"
+)
+
+// ComponentState is the execution state of a recipe component.
+type ComponentState struct {
+	Status       string
+	StartTime    time.Time
+	CompleteTime time.Time
+	Error        *memory.ComponentError
+}
+
+// RunState is the live state of a pipeline run, as tracked by its trigger
+// workflow. A component failure here means the component activity failed;
+// the failures of single batch items are only reflected in the memory.
+type RunState struct {
+	StartTime  time.Time
+	Components map[string]*ComponentState
+
+	// ChunkWorkflowIDs holds the workflows that orchestrate each chunk of a
+	// large batch. In that case, the component states are tracked by the
+	// chunk workflows.
+	ChunkWorkflowIDs []string
+	TimedOut         bool
+}
+
+func newRunState(ctx workflow.Context) *RunState {
+	return &RunState{
+		StartTime:  workflow.GetInfo(ctx).WorkflowStartTime,
+		Components: map[string]*ComponentState{},
+	}
+}
+
+func (s *RunState) addComponents(orderedComp []datamodel.ComponentMap) {
+	for _, group := range orderedComp {
+		for compID := range group {
+			s.Components[compID] = &ComponentState{Status: ComponentStatePending}
+		}
+	}
+}
+
+func (s *RunState) start(ctx workflow.Context, compID, status string) {
+	if c, ok := s.Components[compID]; ok {
+		c.Status = status
+		c.StartTime = workflow.Now(ctx)
+	}
+}
+
+func (s *RunState) complete(ctx workflow.Context, compID string, err error) {
+	c, ok := s.Components[compID]
+	if !ok {
+		return
+	}
+
+	c.Status = ComponentStateCompleted
+	if err != nil {
+		c.Status = ComponentStateFailed
+		c.Error = componentErrorOf(err)
+	}
+	c.CompleteTime = workflow.Now(ctx)
+	if c.StartTime.IsZero() {
+		c.StartTime = c.CompleteTime
+	}
+}
+
+// componentErrorOf extracts the structured error that componentActivityError
+// attaches to the failures of the component activities. Other failures (e.g.
+// an activity timeout) are reported with their message.
+func componentErrorOf(err error) *memory.ComponentError {
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) && appErr.HasDetails() {
+		compErr := new(memory.ComponentError)
+		if appErr.Details(compErr) == nil {
+			return compErr
+		}
+	}
+
+	compErr := newComponentError(err)
+	if errors.As(err, new(*temporal.TimeoutError)) {
+		compErr.Code = memory.ComponentErrorTimeout
+		compErr.Retryable = true
+	}
+	return &compErr
+}
+
+// cancelInFlight marks the components that are still running as cancelled,
+// which happens when the run exceeds its max duration.
+func (s *RunState) cancelInFlight(ctx workflow.Context) {
+	s.TimedOut = true
+	for _, c := range s.Components {
+		if c.Status == ComponentStateProcessing || c.Status == ComponentStateWaitingApproval {
+			c.Status = ComponentStateCancelled
+			c.CompleteTime = workflow.Now(ctx)
+		}
+	}
+}
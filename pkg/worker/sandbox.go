@@ -0,0 +1,187 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/config"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+// sandbox runs the jobs of the CPU-bound components (image, video, document
+// processing...) in a bounded pool. Each job is executed with its own
+// deadline and a panic only fails the job that caused it, so a pathological
+// input can't wedge the worker or crash it along with the rest of the runs
+// it holds.
+//
+// Go can't stop a goroutine, so a job that exceeds its deadline is reported
+// as failed but keeps its slot in the pool until it returns. This keeps the
+// CPU usage bounded when the components ignore the context cancellation.
+type sandbox struct {
+	components []string
+	slots      chan struct{}
+	timeout    time.Duration
+	log        *zap.Logger
+}
+
+// newSandbox returns the sandbox of the worker, or nil if it's disabled.
+func newSandbox(log *zap.Logger) *sandbox {
+	cfg := config.Config.Server.Worker.Sandbox
+	if len(cfg.Components) == 0 {
+		return nil
+	}
+
+	return &sandbox{
+		components: cfg.Components,
+		slots:      make(chan struct{}, valueOrDefault(cfg.MaxConcurrency, runtime.NumCPU())),
+		timeout:    time.Duration(cfg.Timeout) * time.Second,
+		log:        log,
+	}
+}
+
+// wrap returns an execution that runs its jobs in the sandbox if the
+// component is CPU-bound. Other executions are returned as they are.
+func (sb *sandbox) wrap(x componentbase.IExecution) componentbase.IExecution {
+	if sb == nil || !slices.Contains(sb.components, x.GetComponent().GetDefinitionID()) {
+		return x
+	}
+	return &sandboxedExecution{IExecution: x, sandbox: sb}
+}
+
+type sandboxedExecution struct {
+	componentbase.IExecution
+	sandbox *sandbox
+}
+
+func (x *sandboxedExecution) Execute(ctx context.Context, jobs []*componentbase.Job) error {
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		select {
+		case x.sandbox.slots <- struct{}{}:
+		case <-ctx.Done():
+			job.Error.Error(ctx, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			x.run(ctx, job)
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// run executes a single job and waits until it finishes or its deadline
+// passes.
+func (x *sandboxedExecution) run(ctx context.Context, job *componentbase.Job) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	if x.sandbox.timeout > 0 {
+		jobCtx, cancel = context.WithTimeout(ctx, x.sandbox.timeout)
+	}
+	defer cancel()
+
+	sj := &sandboxedJob{output: job.Output, errorHandler: job.Error}
+	done := make(chan struct{})
+	go func() {
+		defer func() { <-x.sandbox.slots }()
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				x.sandbox.log.Error("Component panicked",
+					zap.String("componentID", x.GetComponentID()),
+					zap.Any("panic", r),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				sj.Error(ctx, fmt.Errorf("component execution panicked: %v", r))
+			}
+		}()
+
+		err := x.IExecution.Execute(jobCtx, []*componentbase.Job{{
+			Input:    job.Input,
+			Output:   sj,
+			Error:    sj,
+			Artifact: job.Artifact,
+		}})
+		if err != nil {
+			sj.Error(ctx, err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-jobCtx.Done():
+		// The job might have finished while the deadline passed.
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		err := jobCtx.Err()
+		if ctx.Err() == nil {
+			err = fmt.Errorf("component execution exceeded the time limit of %s: %w", x.sandbox.timeout, err)
+		}
+		if sj.abandon() {
+			x.sandbox.log.Warn("Abandoned component execution",
+				zap.String("componentID", x.GetComponentID()),
+				zap.Error(err),
+			)
+			job.Error.Error(ctx, err)
+		}
+	}
+}
+
+// sandboxedJob forwards the outcome of a job until it's abandoned, so a job
+// that outlives its deadline can't overwrite the reported failure.
+type sandboxedJob struct {
+	output       componentbase.OutputWriter
+	errorHandler componentbase.ErrorHandler
+
+	mu        sync.Mutex
+	reported  bool
+	abandoned bool
+}
+
+func (j *sandboxedJob) Write(ctx context.Context, output *structpb.Struct) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.abandoned {
+		return nil
+	}
+	j.reported = true
+	return j.output.Write(ctx, output)
+}
+
+func (j *sandboxedJob) Error(ctx context.Context, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.abandoned {
+		return
+	}
+	j.reported = true
+	j.errorHandler.Error(ctx, err)
+}
+
+// abandon stops forwarding the outcome of the job and tells whether the
+// job hadn't reported it yet.
+func (j *sandboxedJob) abandon() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.abandoned = true
+	return !j.reported
+}
@@ -0,0 +1,401 @@
+package worker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+// Defaults of the semantic cache configuration of a component.
+const (
+	defaultCacheThreshold   = 0.95
+	defaultCacheTTL         = 24 * time.Hour
+	defaultCachePromptField = "prompt"
+
+	// Only the most recent entries of a scope are compared against a prompt,
+	// which bounds the cost of a lookup.
+	cacheMaxEntries = 1000
+
+	cacheKeyPrefix = "pipeline_semantic_cache:"
+	embeddingDims  = 512
+)
+
+// semanticCache stores the outputs of components along with the embedding of
+// their prompt in Redis. A request is served from the cache when a previous
+// request in the same scope has a similar enough prompt.
+//
+// The entries of a scope are indexed in a sorted set, scored by their
+// expiration time, and each entry is stored in its own key with a TTL.
+type semanticCache struct {
+	client *redis.Client
+}
+
+// cacheScope groups the requests whose outputs are interchangeable: same
+// namespace, component, setup and input, except for the prompt.
+type cacheScope struct {
+	key       string
+	threshold float64
+	ttl       time.Duration
+}
+
+// cacheEntry is stored in MessagePack, which keeps the entries compact and
+// fast to decode, as every entry of a scope is decoded on a lookup.
+type cacheEntry struct {
+	Embedding []float32
+	Output    data.Value
+}
+
+func (e *cacheEntry) MarshalBinary() ([]byte, error) {
+	embedding := make([]byte, 0, 4*len(e.Embedding))
+	for _, f := range e.Embedding {
+		embedding = binary.LittleEndian.AppendUint32(embedding, math.Float32bits(f))
+	}
+	return data.MarshalMsgpack(data.NewMap(map[string]data.Value{
+		"embedding": data.NewByteArray(embedding),
+		"output":    e.Output,
+	}))
+}
+
+func (e *cacheEntry) UnmarshalBinary(b []byte) error {
+	v, err := data.UnmarshalMsgpack(b)
+	if err != nil {
+		return err
+	}
+	m, ok := v.(*data.Map)
+	if !ok {
+		return fmt.Errorf("invalid cache entry")
+	}
+	embedding, ok := m.Fields["embedding"].(*data.ByteArray)
+	if !ok || len(embedding.Raw)%4 != 0 {
+		return fmt.Errorf("invalid cache entry embedding")
+	}
+
+	e.Embedding = make([]float32, len(embedding.Raw)/4)
+	for i := range e.Embedding {
+		e.Embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(embedding.Raw[4*i:]))
+	}
+	e.Output = m.Fields["output"]
+	return nil
+}
+
+// newCacheScope computes the scope of a request. The prompt is removed from
+// the input and returned separately.
+func newCacheScope(param *ComponentActivityParam, setup, input *structpb.Struct) (*cacheScope, string, error) {
+	cfg := param.Cache
+	field := cfg.Prompt
+	if field == "" {
+		field = defaultCachePromptField
+	}
+
+	fields := input.AsMap()
+	prompt, ok := fields[field].(string)
+	if !ok || prompt == "" {
+		return nil, "", fmt.Errorf("input field %s isn't a string", field)
+	}
+	delete(fields, field)
+
+	// encoding/json sorts the map keys, so the scope is deterministic.
+	b, err := json.Marshal(map[string]any{
+		"owner": param.SystemVariables.PipelineOwnerUID.String(),
+		"type":  param.Type,
+		"task":  param.Task,
+		"setup": setup.AsMap(),
+		"input": fields,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(b)
+
+	scope := &cacheScope{
+		key:       cacheKeyPrefix + hex.EncodeToString(sum[:]),
+		threshold: defaultCacheThreshold,
+		ttl:       defaultCacheTTL,
+	}
+	if cfg.Threshold > 0 {
+		scope.threshold = cfg.Threshold
+	}
+	if cfg.TTL != "" {
+		ttl, err := data.ParseDuration(cfg.TTL)
+		if err != nil {
+			return nil, "", fmt.Errorf("parsing cache TTL: %w", err)
+		}
+		scope.ttl = ttl.GetDuration()
+	}
+
+	return scope, prompt, nil
+}
+
+// lookup returns the output of the most similar prompt in the scope, if its
+// similarity reaches the threshold of the scope.
+func (c *semanticCache) lookup(ctx context.Context, scope *cacheScope, embedding []float32) (*structpb.Struct, bool, error) {
+	now := time.Now()
+	if err := c.client.ZRemRangeByScore(ctx, scope.key, "-inf", fmt.Sprint(now.Unix())).Err(); err != nil {
+		return nil, false, err
+	}
+
+	ids, err := c.client.ZRevRange(ctx, scope.key, 0, cacheMaxEntries-1).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, false, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = scope.key + ":" + id
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var best *cacheEntry
+	bestScore := scope.threshold
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			// The entry expired after the index was cleaned up.
+			continue
+		}
+
+		// The entries that can't be decoded, e.g. written by a previous
+		// version, are treated as misses and expire with their TTL.
+		entry := &cacheEntry{}
+		if err := entry.UnmarshalBinary([]byte(s)); err != nil {
+			continue
+		}
+		if score := cosineSimilarity(embedding, entry.Embedding); score >= bestScore {
+			best, bestScore = entry, score
+		}
+	}
+	if best == nil {
+		return nil, false, nil
+	}
+
+	output, err := best.Output.ToStructValue()
+	if err != nil {
+		return nil, false, err
+	}
+	return output.GetStructValue(), true, nil
+}
+
+func (c *semanticCache) store(ctx context.Context, scope *cacheScope, embedding []float32, output *structpb.Struct) error {
+	v, err := data.NewValueFromStruct(structpb.NewStructValue(output))
+	if err != nil {
+		return err
+	}
+	entry, err := (&cacheEntry{Embedding: embedding, Output: v}).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+
+	expiration := time.Now().Add(scope.ttl)
+	_, err = c.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, scope.key+":"+id.String(), entry, scope.ttl)
+		p.ZAdd(ctx, scope.key, redis.Z{Score: float64(expiration.Unix()), Member: id.String()})
+		p.ZRemRangeByRank(ctx, scope.key, 0, -cacheMaxEntries-1)
+		p.ExpireAt(ctx, scope.key, expiration)
+		return nil
+	})
+	return err
+}
+
+// embedPrompt computes the embedding of a prompt locally, with feature
+// hashing over its words and character trigrams. This captures near-duplicate
+// prompts (changes in casing, punctuation, word order or small edits) without
+// calling an external model.
+func embedPrompt(prompt string) []float32 {
+	vec := make([]float32, embeddingDims)
+	add := func(feature string, weight float32) {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(feature))
+		sum := h.Sum32()
+
+		// The sign bit reduces the bias introduced by hash collisions.
+		if sum&1 == 0 {
+			weight = -weight
+		}
+		vec[(sum>>1)%embeddingDims] += weight
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(prompt), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		add("w:"+word, 1)
+
+		padded := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			add("c:"+string(padded[i:i+3]), 0.5)
+		}
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v * v)
+	}
+	if norm == 0 {
+		return vec
+	}
+	norm = math.Sqrt(norm)
+	for i := range vec {
+		vec[i] = float32(float64(vec[i]) / norm)
+	}
+	return vec
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// cachedInputReader returns an input that has already been read, so the
+// cache lookup doesn't render the input twice.
+type cachedInputReader struct {
+	input *structpb.Struct
+}
+
+func (r *cachedInputReader) Read(context.Context) (*structpb.Struct, error) {
+	return r.input, nil
+}
+
+// cacheOutputWriter keeps the last output written by a job, which is stored
+// in the cache once the execution finishes.
+type cacheOutputWriter struct {
+	componentbase.OutputWriter
+
+	mu     sync.Mutex
+	output *structpb.Struct
+}
+
+func (w *cacheOutputWriter) Write(ctx context.Context, output *structpb.Struct) error {
+	if err := w.OutputWriter.Write(ctx, output); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.output = output
+	return nil
+}
+
+func (w *cacheOutputWriter) last() *structpb.Struct {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.output
+}
+
+// cachedRequest is a batch item that missed the cache.
+type cachedRequest struct {
+	scope     *cacheScope
+	embedding []float32
+	input     *structpb.Struct
+	output    *cacheOutputWriter
+}
+
+// cacheEnabled reports whether the semantic cache applies to a component.
+func (w *worker) cacheEnabled(cfg *datamodel.ComponentCache) bool {
+	return cfg != nil && cfg.Enabled && w.redisClient != nil
+}
+
+// serveFromCache writes the cached outputs of the batch items whose prompt is
+// similar to a previous request. It returns the items that still have to be
+// executed and, for those that can be cached, the request to store once they
+// are executed. Items that can't use the cache (e.g. because they don't have
+// a prompt) are executed as usual.
+func (w *worker) serveFromCache(ctx context.Context, wfm memory.WorkflowMemory, param *ComponentActivityParam, setup *structpb.Struct, items []int) ([]int, map[int]*cachedRequest, error) {
+	cache := &semanticCache{client: w.redisClient}
+	pending := make([]int, 0, len(items))
+	requests := map[int]*cachedRequest{}
+
+	for _, idx := range items {
+		input, err := NewInputReader(wfm, param.ID, idx, nil).Read(ctx)
+		if err != nil {
+			pending = append(pending, idx)
+			continue
+		}
+
+		scope, prompt, err := newCacheScope(param, setup, input)
+		if err != nil {
+			w.log.Debug("Semantic cache skipped", zap.String("componentID", param.ID), zap.Error(err))
+			pending = append(pending, idx)
+			continue
+		}
+
+		embedding := embedPrompt(prompt)
+		output, hit, err := cache.lookup(ctx, scope, embedding)
+		if err != nil {
+			w.log.Warn("Semantic cache lookup failed", zap.String("componentID", param.ID), zap.Error(err))
+		}
+		if hit {
+			if err := NewOutputWriter(wfm, param.ID, idx, wfm.IsStreaming()).Write(ctx, output); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		pending = append(pending, idx)
+		requests[idx] = &cachedRequest{
+			scope:     scope,
+			embedding: embedding,
+			input:     input,
+			output:    &cacheOutputWriter{OutputWriter: NewOutputWriter(wfm, param.ID, idx, wfm.IsStreaming())},
+		}
+	}
+
+	return pending, requests, nil
+}
+
+// storeInCache stores the outputs of the executed requests that succeeded.
+func (w *worker) storeInCache(ctx context.Context, wfm memory.WorkflowMemory, param *ComponentActivityParam, requests map[int]*cachedRequest) {
+	cache := &semanticCache{client: w.redisClient}
+	for idx, req := range requests {
+		output := req.output.last()
+		if output == nil {
+			continue
+		}
+		if errored, err := wfm.GetComponentStatus(ctx, idx, param.ID, memory.ComponentStatusErrored); err != nil || errored {
+			continue
+		}
+
+		if err := cache.store(ctx, req.scope, req.embedding, output); err != nil {
+			w.log.Warn("Semantic cache store failed", zap.String("componentID", param.ID), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/pipeline-backend/pkg/session"
+
+	componentbase "github.com/instill-ai/pipeline-backend/pkg/component/base"
+)
+
+// conversationStore gives the components access to the conversation
+// sessions of the pipeline owner.
+type conversationStore struct {
+	sessions     session.Store
+	namespaceUID uuid.UUID
+}
+
+func (s *conversationStore) AppendMessages(ctx context.Context, sessionID string, messages []componentbase.ConversationMessage) error {
+	if err := session.ValidateSessionID(sessionID); err != nil {
+		return err
+	}
+
+	msgs := make([]session.Message, len(messages))
+	for i, m := range messages {
+		msgs[i] = session.Message{Role: m.Role, Content: m.Content}
+	}
+	if err := session.ValidateMessages(msgs); err != nil {
+		return err
+	}
+
+	return s.sessions.Append(ctx, s.namespaceUID, sessionID, msgs)
+}
+
+func (s *conversationStore) ListMessages(ctx context.Context, sessionID string, limit int) ([]componentbase.ConversationMessage, error) {
+	if err := session.ValidateSessionID(sessionID); err != nil {
+		return nil, err
+	}
+
+	msgs, err := s.sessions.List(ctx, s.namespaceUID, sessionID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]componentbase.ConversationMessage, len(msgs))
+	for i, m := range msgs {
+		messages[i] = componentbase.ConversationMessage{Role: m.Role, Content: m.Content}
+	}
+	return messages, nil
+}
@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/x/errmsg"
+
+	errdomain "github.com/instill-ai/pipeline-backend/pkg/errors"
+)
+
+// applyNamespaceSettings sets the defaults of the pipeline namespace in the
+// recipe of a run. The run is rejected if the recipe uses components that
+// the component policy of the namespace doesn't allow.
+func (w *worker) applyNamespaceSettings(ctx context.Context, nsUID uuid.UUID, r *datamodel.Recipe) error {
+	settings, err := w.repository.GetNamespaceSettings(ctx, nsUID)
+	if errors.Is(err, errdomain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("fetching namespace settings: %w", err)
+	}
+
+	// The policy is enforced when the recipes are saved, but it can change
+	// afterwards and the component versions can be upgraded.
+	if violations := settings.ComponentViolations(r, w.componentVersion); len(violations) > 0 {
+		msgs := make([]string, 0, len(violations))
+		for _, v := range violations {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", v.Location, v.Message()))
+		}
+		err := fmt.Errorf("%w: %s", errdomain.ErrInvalidArgument, strings.Join(msgs, "; "))
+		return errmsg.AddMessage(err, "The recipe uses components that aren't allowed in this namespace: "+strings.Join(msgs, "; ")+".")
+	}
+
+	settings.ApplyTo(r)
+	return nil
+}
+
+// componentVersion returns the version of a component definition, or an
+// empty string if it doesn't exist.
+func (w *worker) componentVersion(compType string) string {
+	def, err := w.component.GetDefinitionByID(compType, nil, nil)
+	if err != nil {
+		return ""
+	}
+	return def.GetVersion()
+}
+
+// componentActivityOptions returns the options of the component activities
+// with the timeout and the retry policy of a recipe.
+func componentActivityOptions(ao workflow.ActivityOptions, r *datamodel.Recipe) (workflow.ActivityOptions, error) {
+	if r.ComponentTimeout != "" {
+		timeout, err := data.ParseDuration(r.ComponentTimeout)
+		if err != nil {
+			return ao, fmt.Errorf("parsing component timeout: %w", err)
+		}
+		ao.StartToCloseTimeout = timeout.Raw
+	}
+
+	if r.Retry != nil {
+		policy := *ao.RetryPolicy
+		if r.Retry.MaxAttempts > 0 {
+			policy.MaximumAttempts = r.Retry.MaxAttempts
+		}
+		if r.Retry.InitialInterval != "" {
+			interval, err := data.ParseDuration(r.Retry.InitialInterval)
+			if err != nil {
+				return ao, fmt.Errorf("parsing retry interval: %w", err)
+			}
+			policy.InitialInterval = interval.Raw
+		}
+		ao.RetryPolicy = &policy
+	}
+
+	return ao, nil
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
 
 	"github.com/instill-ai/pipeline-backend/config"
 	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
 	"github.com/instill-ai/pipeline-backend/pkg/utils"
 )
 
@@ -82,3 +87,39 @@ func setIteratorIndex(v data.Value, identifier string, index int) data.Value {
 		return v
 	}
 }
+
+// awaitFuture blocks until the future is ready or the deadline is reached. It
+// returns false if the deadline was reached first. A zero deadline waits
+// indefinitely.
+func awaitFuture(ctx workflow.Context, f workflow.Future, deadline time.Time) bool {
+	if deadline.IsZero() || f.IsReady() {
+		return true
+	}
+
+	remaining := deadline.Sub(workflow.Now(ctx))
+	if remaining <= 0 {
+		return false
+	}
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	ready := false
+	workflow.NewSelector(ctx).
+		AddFuture(f, func(workflow.Future) { ready = true }).
+		AddFuture(workflow.NewTimer(timerCtx, remaining), func(workflow.Future) {}).
+		Select(ctx)
+
+	return ready
+}
+
+// sortedComponentIDs returns the component IDs in lexical order, so the
+// activities are scheduled deterministically.
+func sortedComponentIDs(comps datamodel.ComponentMap) []string {
+	ids := make([]string, 0, len(comps))
+	for id := range comps {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// watchdogTopWorkflows is the number of workflows whose usage is logged
+// when the worker approaches its memory limit.
+const watchdogTopWorkflows = 5
+
+// RunMemoryWatchdog checks, periodically, the data held by the workflow
+// memories of the worker until the context is done. When the usage
+// approaches the limit, the idle memories are spilled to the persistence
+// backend.
+func (w *worker) RunMemoryWatchdog(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		spilled, err := w.memoryStore.SpillIdleWorkflowMemory(ctx)
+		if err != nil {
+			w.log.Error("Couldn't spill idle workflow memory", zap.Error(err))
+		}
+
+		usage := w.memoryStore.MemoryUsage()
+		if spilled == 0 && usage.Total < usage.Limit {
+			continue
+		}
+
+		top := make([]zap.Field, 0, watchdogTopWorkflows)
+		for _, id := range usage.TopWorkflows(watchdogTopWorkflows) {
+			top = append(top, zap.Int64(id, usage.Workflows[id]))
+		}
+		w.log.Warn("Worker memory usage is high",
+			zap.Int64("total", usage.Total),
+			zap.Int64("limit", usage.Limit),
+			zap.Int("spilled", spilled),
+			zap.Dict("topWorkflows", top...),
+		)
+	}
+}
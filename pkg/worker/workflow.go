@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"go/ast"
 	"go/parser"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -43,6 +45,17 @@ type TriggerPipelineWorkflowParam struct {
 	Mode            mgmtpb.Mode
 	TriggerFromAPI  bool
 	WorkerUID       uuid.UUID
+
+	// Canary runs are synthetic checks of the pipeline, so they aren't
+	// reported in the usage metrics and the trigger count.
+	Canary bool
+	// SharedMemory is set on the batch chunks whose memory is kept in the
+	// persistence backend. Their activities can run on any worker and the
+	// memory is released from the worker when the chunk is done.
+	SharedMemory bool
+	// Deadline is the max duration deadline of the parent run, which child
+	// runs observe instead of computing their own from their start time.
+	Deadline time.Time
 }
 
 type SchedulePipelineWorkflowParam struct {
@@ -74,6 +87,12 @@ type ComponentActivityParam struct {
 	Task            string
 	SystemVariables recipe.SystemVariables // TODO: we should store vars directly in trigger memory.
 	Streaming       bool
+	Failover        []*datamodel.FailoverTarget
+	Cache           *datamodel.ComponentCache
+	Batching        *datamodel.ComponentBatching
+	// ConnectionID is the connection referenced by the component setup, if
+	// any.
+	ConnectionID string
 }
 
 type PreIteratorActivityParam struct {
@@ -98,6 +117,50 @@ type PostIteratorActivityParam struct {
 	SystemVariables recipe.SystemVariables
 }
 
+// ErrorBranchActivityParam identifies the error branch of a component.
+type ErrorBranchActivityParam struct {
+	WorkflowID string
+	ID         string
+	BranchIDs  []string
+}
+
+// ApprovalActivityParam identifies an approval component in a pipeline run.
+type ApprovalActivityParam struct {
+	WorkflowID      string
+	ID              string
+	UpstreamIDs     []string
+	Condition       string
+	SystemVariables recipe.SystemVariables
+}
+
+// PostApprovalActivityParam holds the decision taken on an approval
+// component.
+type PostApprovalActivityParam struct {
+	ApprovalActivityParam
+	Decision *ApprovalDecision
+}
+
+type PreBatchChunkActivityParam struct {
+	WorkflowID string
+}
+
+type PreBatchChunkActivityResult struct {
+	ChildWorkflowIDs []string
+	// Shared is true when the chunk memory was released to the persistence
+	// backend, so the chunks can be orchestrated by any worker.
+	Shared bool
+}
+
+type PostBatchChunkActivityParam struct {
+	WorkflowID       string
+	ChildWorkflowIDs []string
+}
+
+type PipelineTimedOutActivityParam struct {
+	WorkflowID  string
+	MaxDuration string
+}
+
 type PreTriggerActivityParam struct {
 	WorkflowID      string
 	SystemVariables recipe.SystemVariables
@@ -124,6 +187,9 @@ type UpsertPipelineRunActivityParam struct {
 type UpdatePipelineRunActivityParam struct {
 	PipelineTriggerID string
 	PipelineRun       *datamodel.PipelineRun
+	// WorkflowID is set when the run is completed, so the outcome of each
+	// batch item is read from the workflow memory.
+	WorkflowID string
 }
 
 type UpsertComponentRunActivityParam struct {
@@ -132,6 +198,10 @@ type UpsertComponentRunActivityParam struct {
 
 var tracer = otel.Tracer("pipeline-backend.temporal.tracer")
 
+// batchChunkChangeID identifies the versions of the batch chunking commands
+// in the trigger workflow history.
+const batchChunkChangeID = "batch-chunk"
+
 // WorkFlowSignal is used by sChan to signal the status of components in the Workflow.
 type WorkFlowSignal struct {
 	ID     string
@@ -182,6 +252,16 @@ func (w *worker) TriggerPipelineWorkflow(ctx workflow.Context, param *TriggerPip
 	minioCtx := workflow.WithActivityOptions(ctx, mo)
 
 	workflowID := workflow.GetInfo(ctx).WorkflowExecution.ID
+
+	// The run state can be queried while the workflow runs, e.g. to
+	// inspect a run without subscribing to its events.
+	state := newRunState(ctx)
+	if err := workflow.SetQueryHandler(ctx, RunStateQuery, func() (*RunState, error) {
+		return state, nil
+	}); err != nil {
+		return fmt.Errorf("registering run state query: %w", err)
+	}
+
 	if param.TriggerFromAPI {
 		cleanupCtx, _ := workflow.NewDisconnectedContext(ctx)
 		defer func() {
@@ -194,6 +274,18 @@ func (w *worker) TriggerPipelineWorkflow(ctx workflow.Context, param *TriggerPip
 			}
 		}()
 	}
+	if param.SharedMemory {
+		releaseCtx, _ := workflow.NewDisconnectedContext(ctx)
+		defer func() {
+			if err := workflow.ExecuteActivity(
+				releaseCtx,
+				w.ReleaseBatchChunkActivity,
+				workflowID,
+			).Get(releaseCtx, nil); err != nil {
+				logger.Error("Failed to release batch chunk memory", zap.Error(err))
+			}
+		}()
+	}
 
 	var ownerType mgmtpb.OwnerType
 	switch param.SystemVariables.PipelineOwnerType {
@@ -260,135 +352,401 @@ func (w *worker) TriggerPipelineWorkflow(ctx workflow.Context, param *TriggerPip
 	if err != nil {
 		return err
 	}
+	state.addComponents(orderedComp)
 
 	errs := []error{}
 	componentRunFutures := []workflow.Future{}
 	componentRunFailed := false
 	var componentRunErrors []string
-	// The components in the same group can be executed in parallel
-	for group := range orderedComp {
-		futures := []workflow.Future{}
-		futureArgs := []*ComponentActivityParam{}
-		for compID, comp := range orderedComp[group] {
-			upstreamIDs := dag.GetUpstreamCompIDs(compID)
 
-			switch comp.Type {
-			default:
-				componentRun := &datamodel.ComponentRun{
-					PipelineTriggerUID: uuid.FromStringOrNil(param.SystemVariables.PipelineTriggerID),
-					ComponentID:        compID,
-					Status:             datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_PROCESSING),
-					StartedTime:        time.Now(),
+	// When the recipe defines a max duration, no new components are
+	// scheduled after the deadline and the in-flight ones are cancelled.
+	var deadline time.Time
+	if dagData.Recipe.MaxDuration != "" {
+		maxDuration, err := data.ParseDuration(dagData.Recipe.MaxDuration)
+		if err != nil {
+			return fmt.Errorf("parsing max duration: %w", err)
+		}
+		deadline = workflow.GetInfo(ctx).WorkflowStartTime.Add(maxDuration.Raw)
+	}
+	if !param.Deadline.IsZero() {
+		deadline = param.Deadline
+	}
+	timedOut := false
+
+	// The recipe can set the timeout and the retries of its components.
+	co, err := componentActivityOptions(ao, dagData.Recipe)
+	if err != nil {
+		return err
+	}
+	componentCtx, cancelComponents := workflow.WithCancel(workflow.WithActivityOptions(ctx, co))
+	defer cancelComponents()
+
+	// Decisions on approval components, which might be received before the
+	// component is reached.
+	decisions := map[string]*ApprovalDecision{}
+
+	// Batch chunking was introduced while runs were in flight, so those are
+	// replayed without it. From version 2, the chunks observe the deadline.
+	chunkVersion := workflow.GetVersion(ctx, batchChunkChangeID, workflow.DefaultVersion, 2)
+
+	chunkResult := &PreBatchChunkActivityResult{}
+	if param.TriggerFromAPI && chunkVersion >= 1 {
+		if err := workflow.ExecuteActivity(ctx, w.PreBatchChunkActivity, &PreBatchChunkActivityParam{
+			WorkflowID: workflowID,
+		}).Get(ctx, chunkResult); err != nil {
+			return err
+		}
+	}
+
+	if len(chunkResult.ChildWorkflowIDs) > 0 {
+		state.ChunkWorkflowIDs = chunkResult.ChildWorkflowIDs
+
+		// Large batches are split into chunks. Each chunk is orchestrated by
+		// a child workflow and the results are merged back into the trigger
+		// memory once every chunk is done.
+		chunkFutures := make([]workflow.Future, len(chunkResult.ChildWorkflowIDs))
+
+		// Each chunk waits for the approval decisions on its own, so they
+		// are forwarded while the chunks run.
+		signalCtx, stopForwarding := workflow.WithCancel(ctx)
+		workflow.Go(signalCtx, func(ctx workflow.Context) {
+			forwardApprovalSignals(ctx, chunkResult.ChildWorkflowIDs)
+		})
+
+		// The chunks are cancelled if the deadline is reached. The
+		// cancellation is awaited so the chunk memory isn't written while
+		// it's merged.
+		chunkCtx, cancelChunks := workflow.WithCancel(ctx)
+		defer cancelChunks()
+
+		for idx, childWorkflowID := range chunkResult.ChildWorkflowIDs {
+			childWorkflowOptions := workflow.ChildWorkflowOptions{
+				TaskQueue:                TaskQueue,
+				WorkflowID:               childWorkflowID,
+				WorkflowExecutionTimeout: time.Duration(config.Config.Server.Workflow.MaxWorkflowTimeout) * time.Second,
+				RetryPolicy: &temporal.RetryPolicy{
+					MaximumAttempts: config.Config.Server.Workflow.MaxWorkflowRetry,
+				},
+				WaitForCancellation: true,
+			}
+
+			// Shared chunks are restored by whichever worker runs their
+			// activities. Otherwise, the chunk memory lives in the worker
+			// that holds the trigger memory, so the child activities must
+			// be scheduled there.
+			workerUID := uuid.Nil
+			if !chunkResult.Shared {
+				workerUID = param.WorkerUID
+				if workerUID == uuid.Nil {
+					workerUID = w.workerUID
 				}
+			}
 
-				// adding the data row in advance in case that UploadComponentInputsActivity starts before ComponentActivity
-				_ = workflow.ExecuteActivity(ctx, w.UpsertComponentRunActivity, &UpsertComponentRunActivityParam{
-					ComponentRun: componentRun,
-				}).Get(ctx, nil)
-
-				args := &ComponentActivityParam{
-					WorkflowID:      workflowID,
-					ID:              compID,
-					UpstreamIDs:     upstreamIDs,
-					Type:            comp.Type,
-					Task:            comp.Task,
-					Condition:       comp.Condition,
+			chunkFutures[idx] = workflow.ExecuteChildWorkflow(
+				workflow.WithChildOptions(chunkCtx, childWorkflowOptions),
+				"TriggerPipelineWorkflow",
+				&TriggerPipelineWorkflowParam{
+					TriggerFromAPI:  false,
 					SystemVariables: param.SystemVariables,
+					Mode:            param.Mode,
+					WorkerUID:       workerUID,
+					SharedMemory:    chunkResult.Shared,
+					Deadline:        deadline,
+				})
+		}
+		for idx := range chunkFutures {
+			if chunkVersion >= 2 && !timedOut && !awaitFuture(ctx, chunkFutures[idx], deadline) {
+				timedOut = true
+				cancelChunks()
+			}
+			if err := chunkFutures[idx].Get(ctx, nil); err != nil {
+				// The chunks that didn't finish in time are skipped by the
+				// timeout, so their cancellation isn't a failure.
+				if timedOut && temporal.IsCanceledError(err) {
+					continue
+				}
+				componentRunFailed = true
+				componentRunErrors = append(componentRunErrors, fmt.Sprintf("batch chunk(ID: %s) run failed", chunkResult.ChildWorkflowIDs[idx]))
+				errs = append(errs, err)
+			}
+		}
+		stopForwarding()
+
+		if err := workflow.ExecuteActivity(ctx, w.PostBatchChunkActivity, &PostBatchChunkActivityParam{
+			WorkflowID:       workflowID,
+			ChildWorkflowIDs: chunkResult.ChildWorkflowIDs,
+		}).Get(ctx, nil); err != nil {
+			return err
+		}
+	} else {
+		// The components in the same group can be executed in parallel
+		for group := range orderedComp {
+			if !deadline.IsZero() && !workflow.Now(ctx).Before(deadline) {
+				timedOut = true
+				break
+			}
+			if componentRunFailed && dagData.Recipe.ErrorPolicy == datamodel.ErrorPolicyFailFast {
+				logger.Info("TriggerPipelineWorkflow stopped after a component failure")
+				break
+			}
+
+			futures := []workflow.Future{}
+			futureArgs := []*ComponentActivityParam{}
+			approvals := []*ApprovalActivityParam{}
+			for compID, comp := range orderedComp[group] {
+				if timedOut {
+					break
 				}
+				upstreamIDs := dag.GetUpstreamCompIDs(compID)
+
+				switch comp.Type {
+				default:
+					componentRun := &datamodel.ComponentRun{
+						PipelineTriggerUID: uuid.FromStringOrNil(param.SystemVariables.PipelineTriggerID),
+						ComponentID:        compID,
+						Status:             datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_PROCESSING),
+						StartedTime:        time.Now(),
+					}
 
-				componentRunFutures = append(componentRunFutures, workflow.ExecuteActivity(minioCtx, w.UploadComponentInputsActivity, args))
+					// adding the data row in advance in case that UploadComponentInputsActivity starts before ComponentActivity
+					_ = workflow.ExecuteActivity(ctx, w.UpsertComponentRunActivity, &UpsertComponentRunActivityParam{
+						ComponentRun: componentRun,
+					}).Get(ctx, nil)
+
+					args := &ComponentActivityParam{
+						WorkflowID:      workflowID,
+						ID:              compID,
+						UpstreamIDs:     upstreamIDs,
+						Type:            comp.Type,
+						Task:            comp.Task,
+						Condition:       comp.Condition,
+						SystemVariables: param.SystemVariables,
+						Failover:        comp.Failover,
+						Cache:           comp.Cache,
+						Batching:        comp.Batching,
+						ConnectionID:    referencedConnectionID(comp.Setup),
+					}
 
-				futures = append(futures, workflow.ExecuteActivity(ctx, w.ComponentActivity, args))
-				futureArgs = append(futureArgs, args)
+					componentRunFutures = append(componentRunFutures, workflow.ExecuteActivity(minioCtx, w.UploadComponentInputsActivity, args))
 
-			case datamodel.Iterator:
-				// TODO tillknuesting: support intermediate result streaming for Iterator
+					futures = append(futures, workflow.ExecuteActivity(componentCtx, w.ComponentActivity, args))
+					futureArgs = append(futureArgs, args)
+					state.start(ctx, compID, ComponentStateProcessing)
 
-				preIteratorResult := &PreIteratorActivityResult{}
-				if err = workflow.ExecuteActivity(ctx, w.PreIteratorActivity, &PreIteratorActivityParam{
-					WorkflowID:  workflowID,
-					ID:          compID,
-					UpstreamIDs: upstreamIDs,
-					Input: func(c *datamodel.Component) string {
-						if c.Input != nil {
-							return c.Input.(string)
-						}
-						return ""
-					}(comp),
-					Range:           comp.Range,
-					Index:           comp.Index,
-					SystemVariables: param.SystemVariables,
-				}).Get(ctx, &preIteratorResult); err != nil {
-					if err != nil {
+				case datamodel.Approval:
+					_ = workflow.ExecuteActivity(ctx, w.UpsertComponentRunActivity, &UpsertComponentRunActivityParam{
+						ComponentRun: &datamodel.ComponentRun{
+							PipelineTriggerUID: uuid.FromStringOrNil(param.SystemVariables.PipelineTriggerID),
+							ComponentID:        compID,
+							Status:             datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_PROCESSING),
+							StartedTime:        time.Now(),
+						},
+					}).Get(ctx, nil)
+
+					args := &ApprovalActivityParam{
+						WorkflowID:      workflowID,
+						ID:              compID,
+						UpstreamIDs:     upstreamIDs,
+						Condition:       comp.Condition,
+						SystemVariables: param.SystemVariables,
+					}
+
+					pending := false
+					if err = workflow.ExecuteActivity(ctx, w.PreApprovalActivity, args).Get(ctx, &pending); err != nil {
+						state.complete(ctx, compID, err)
+						componentRunFailed = true
+						componentRunErrors = append(componentRunErrors, fmt.Sprintf("component(ID: %s) run failed", compID))
 						errs = append(errs, err)
 						continue
 					}
-				}
 
-				itFutures := []workflow.Future{}
-				for iter := range dagData.BatchSize {
-					childWorkflowOptions := workflow.ChildWorkflowOptions{
-						TaskQueue:                TaskQueue,
-						WorkflowID:               preIteratorResult.ChildWorkflowIDs[iter],
-						WorkflowExecutionTimeout: time.Duration(config.Config.Server.Workflow.MaxWorkflowTimeout) * time.Second,
-						RetryPolicy: &temporal.RetryPolicy{
-							MaximumAttempts: config.Config.Server.Workflow.MaxWorkflowRetry,
-						},
+					componentRunFutures = append(componentRunFutures, workflow.ExecuteActivity(minioCtx, w.UploadComponentInputsActivity, &ComponentActivityParam{
+						WorkflowID:      workflowID,
+						ID:              compID,
+						Type:            comp.Type,
+						SystemVariables: param.SystemVariables,
+					}))
+
+					if pending {
+						state.start(ctx, compID, ComponentStateWaitingApproval)
+						approvals = append(approvals, args)
+						continue
 					}
 
-					itFutures = append(itFutures, workflow.ExecuteChildWorkflow(
-						workflow.WithChildOptions(ctx, childWorkflowOptions),
-						"TriggerPipelineWorkflow",
-						&TriggerPipelineWorkflowParam{
-							TriggerFromAPI:  false,
-							SystemVariables: param.SystemVariables,
-							Mode:            mgmtpb.Mode_MODE_SYNC,
-							WorkerUID:       param.WorkerUID,
-							// TODO: support streaming inside iterator.
-							// IsStreaming:     param.IsStreaming,
-						}))
-				}
-				for iter := 0; iter < dagData.BatchSize; iter++ {
-					err = itFutures[iter].Get(ctx, nil)
+					// When the component is skipped in every batch item,
+					// there's nothing to review and the run record is
+					// closed right away.
+					_ = workflow.ExecuteActivity(ctx, w.PostApprovalActivity, &PostApprovalActivityParam{
+						ApprovalActivityParam: *args,
+						Decision:              &ApprovalDecision{ComponentID: compID, Approved: true},
+					}).Get(ctx, nil)
+					state.complete(ctx, compID, nil)
+
+				case datamodel.Iterator:
+					// TODO tillknuesting: support intermediate result streaming for Iterator
+					state.start(ctx, compID, ComponentStateProcessing)
+
+					preIteratorResult := &PreIteratorActivityResult{}
+					if err = workflow.ExecuteActivity(ctx, w.PreIteratorActivity, &PreIteratorActivityParam{
+						WorkflowID:  workflowID,
+						ID:          compID,
+						UpstreamIDs: upstreamIDs,
+						Input: func(c *datamodel.Component) string {
+							if c.Input != nil {
+								return c.Input.(string)
+							}
+							return ""
+						}(comp),
+						Range:           comp.Range,
+						Index:           comp.Index,
+						SystemVariables: param.SystemVariables,
+					}).Get(ctx, &preIteratorResult); err != nil {
+						if err != nil {
+							state.complete(ctx, compID, err)
+							errs = append(errs, err)
+							continue
+						}
+					}
+
+					itFutures := []workflow.Future{}
+					for iter := range dagData.BatchSize {
+						childWorkflowOptions := workflow.ChildWorkflowOptions{
+							TaskQueue:                TaskQueue,
+							WorkflowID:               preIteratorResult.ChildWorkflowIDs[iter],
+							WorkflowExecutionTimeout: time.Duration(config.Config.Server.Workflow.MaxWorkflowTimeout) * time.Second,
+							RetryPolicy: &temporal.RetryPolicy{
+								MaximumAttempts: config.Config.Server.Workflow.MaxWorkflowRetry,
+							},
+						}
+
+						itFutures = append(itFutures, workflow.ExecuteChildWorkflow(
+							workflow.WithChildOptions(ctx, childWorkflowOptions),
+							"TriggerPipelineWorkflow",
+							&TriggerPipelineWorkflowParam{
+								TriggerFromAPI:  false,
+								SystemVariables: param.SystemVariables,
+								Mode:            mgmtpb.Mode_MODE_SYNC,
+								WorkerUID:       param.WorkerUID,
+								Deadline:        deadline,
+								// TODO: support streaming inside iterator.
+								// IsStreaming:     param.IsStreaming,
+							}))
+					}
+					for iter := 0; iter < dagData.BatchSize; iter++ {
+						if !awaitFuture(ctx, itFutures[iter], deadline) {
+							timedOut = true
+							break
+						}
+						err = itFutures[iter].Get(ctx, nil)
+						if err != nil {
+							errs = append(errs, err)
+							continue
+						}
+					}
+					// Exits the switch, skipping PostIteratorActivity.
+					if timedOut {
+						break
+					}
+
+					err = workflow.ExecuteActivity(ctx, w.PostIteratorActivity, &PostIteratorActivityParam{
+						WorkflowID:      workflowID,
+						ID:              compID,
+						OutputElements:  comp.OutputElements,
+						SystemVariables: param.SystemVariables,
+					}).Get(ctx, nil)
+					state.complete(ctx, compID, err)
 					if err != nil {
 						errs = append(errs, err)
 						continue
 					}
 				}
 
-				if err = workflow.ExecuteActivity(ctx, w.PostIteratorActivity, &PostIteratorActivityParam{
-					WorkflowID:      workflowID,
-					ID:              compID,
-					OutputElements:  comp.OutputElements,
-					SystemVariables: param.SystemVariables,
-				}).Get(ctx, nil); err != nil {
-					if err != nil {
-						errs = append(errs, err)
+			}
+
+			for idx := range futures {
+				if timedOut || !awaitFuture(ctx, futures[idx], deadline) {
+					timedOut = true
+					break
+				}
+				err = futures[idx].Get(ctx, nil)
+
+				// The error branch also handles the failures of single
+				// batch items, which don't make the activity fail.
+				if onError := orderedComp[group][futureArgs[idx].ID].OnError; len(onError) > 0 {
+					recovered, uploads, branchErr := w.executeErrorBranch(ctx, minioCtx, componentCtx, param, futureArgs[idx].ID, onError)
+					componentRunFutures = append(componentRunFutures, uploads...)
+					if branchErr != nil {
+						state.complete(ctx, futureArgs[idx].ID, branchErr)
+						componentRunFailed = true
+						componentRunErrors = append(componentRunErrors, fmt.Sprintf("component(ID: %s) error branch failed", futureArgs[idx].ID))
+						errs = append(errs, branchErr)
 						continue
 					}
+					if recovered {
+						err = nil
+					}
 				}
-			}
 
-		}
+				state.complete(ctx, futureArgs[idx].ID, err)
+				if err != nil {
+					componentRunFailed = true
+					componentRunErrors = append(componentRunErrors, fmt.Sprintf("component(ID: %s) run failed", futureArgs[idx].ID))
+					errs = append(errs, err)
+					continue
+				}
+
+				// ComponentActivity is responsible for returning a temporal
+				// application error with the relevant information. Wrapping
+				// the error here prevents the client from accessing the error
+				// message from the activity.
+				// return err
+				componentRunFutures = append(componentRunFutures, workflow.ExecuteActivity(minioCtx, w.UploadComponentOutputsActivity, futureArgs[idx]))
 
-		for idx := range futures {
-			err = futures[idx].Get(ctx, nil)
-			if err != nil {
-				componentRunFailed = true
-				componentRunErrors = append(componentRunErrors, fmt.Sprintf("component(ID: %s) run failed", futureArgs[idx].ID))
-				errs = append(errs, err)
-				continue
 			}
 
-			// ComponentActivity is responsible for returning a temporal
-			// application error with the relevant information. Wrapping
-			// the error here prevents the client from accessing the error
-			// message from the activity.
-			// return err
-			componentRunFutures = append(componentRunFutures, workflow.ExecuteActivity(minioCtx, w.UploadComponentOutputsActivity, futureArgs[idx]))
+			// The approvals are awaited once the rest of the group is done,
+			// so the reviewers can see the outputs of the parallel
+			// components.
+			for _, args := range approvals {
+				if timedOut {
+					break
+				}
+
+				uploads, inTime, err := w.executeApproval(ctx, minioCtx, args, orderedComp[group][args.ID], deadline, decisions)
+				if !inTime {
+					timedOut = true
+					break
+				}
+				componentRunFutures = append(componentRunFutures, uploads...)
+				state.complete(ctx, args.ID, err)
+				if err != nil {
+					componentRunFailed = true
+					componentRunErrors = append(componentRunErrors, fmt.Sprintf("component(ID: %s) run failed", args.ID))
+					errs = append(errs, err)
+				}
+			}
 
+			if timedOut {
+				break
+			}
 		}
+	}
+
+	if timedOut {
+		cancelComponents()
+		state.cancelInFlight(ctx)
+
+		logger.Warn("TriggerPipelineWorkflow exceeded the max duration", zap.String("maxDuration", dagData.Recipe.MaxDuration))
+		componentRunFailed = true
+		componentRunErrors = append(componentRunErrors, fmt.Sprintf("pipeline run exceeded the max duration (%s)", dagData.Recipe.MaxDuration))
 
+		if err := workflow.ExecuteActivity(ctx, w.PipelineTimedOutActivity, &PipelineTimedOutActivityParam{
+			WorkflowID:  workflowID,
+			MaxDuration: dagData.Recipe.MaxDuration,
+		}).Get(ctx, nil); err != nil {
+			return err
+		}
 	}
 
 	duration := time.Since(startTime)
@@ -397,7 +755,8 @@ func (w *worker) TriggerPipelineWorkflow(ctx workflow.Context, param *TriggerPip
 
 	if param.TriggerFromAPI {
 		if err := workflow.ExecuteActivity(ctx, w.OutputActivity, &ComponentActivityParam{
-			WorkflowID: workflowID,
+			WorkflowID:      workflowID,
+			SystemVariables: param.SystemVariables,
 		}).Get(ctx, nil); err != nil {
 			return err
 		}
@@ -415,16 +774,18 @@ func (w *worker) TriggerPipelineWorkflow(ctx workflow.Context, param *TriggerPip
 			return err
 		}
 
-		// TODO: we should check whether to collect failed component or not
-		if err := workflow.ExecuteActivity(ctx, w.IncreasePipelineTriggerCountActivity, param.SystemVariables).Get(ctx, nil); err != nil {
-			return fmt.Errorf("updating pipeline trigger count: %w", err)
-		}
+		if !param.Canary {
+			// TODO: we should check whether to collect failed component or not
+			if err := workflow.ExecuteActivity(ctx, w.IncreasePipelineTriggerCountActivity, param.SystemVariables).Get(ctx, nil); err != nil {
+				return fmt.Errorf("updating pipeline trigger count: %w", err)
+			}
 
-		if len(errs) > 0 {
-			w.writeErrorDataPoint(sCtx, errs, span, startTime, &dataPoint)
-		} else {
-			if err := w.writeNewDataPoint(sCtx, dataPoint); err != nil {
-				logger.Warn(err.Error())
+			if len(errs) > 0 {
+				w.writeErrorDataPoint(sCtx, errs, span, startTime, &dataPoint)
+			} else {
+				if err := w.writeNewDataPoint(sCtx, dataPoint); err != nil {
+					logger.Warn(err.Error())
+				}
 			}
 		}
 
@@ -434,8 +795,18 @@ func (w *worker) TriggerPipelineWorkflow(ctx workflow.Context, param *TriggerPip
 		_ = f.Get(ctx, nil)
 	}
 
+	// The runs that only capture their failures upload the payloads once
+	// the outcome is known.
+	if param.TriggerFromAPI && (componentRunFailed || len(errs) > 0) {
+		_ = workflow.ExecuteActivity(minioCtx, w.CaptureFailedRunActivity, &CaptureFailedRunActivityParam{
+			WorkflowID:        workflowID,
+			PipelineTriggerID: param.SystemVariables.PipelineTriggerID,
+		}).Get(ctx, nil)
+	}
+
 	updatePipelineRunArgs := &UpdatePipelineRunActivityParam{
 		PipelineTriggerID: param.SystemVariables.PipelineTriggerID,
+		WorkflowID:        workflowID,
 		PipelineRun: &datamodel.PipelineRun{
 			CompletedTime: null.TimeFrom(time.Now()),
 			Status:        datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_COMPLETED),
@@ -454,11 +825,119 @@ func (w *worker) TriggerPipelineWorkflow(ctx workflow.Context, param *TriggerPip
 	return nil
 }
 
+// executeErrorBranch runs the error branch of a component for the batch items
+// in which the component failed. The branch components are executed in
+// topological order and their outputs are uploaded like the ones of the rest
+// of the recipe. It returns whether the component failed and every failure was
+// recovered by the branch.
+func (w *worker) executeErrorBranch(
+	ctx, minioCtx, componentCtx workflow.Context,
+	param *TriggerPipelineWorkflowParam,
+	compID string,
+	branch datamodel.ComponentMap,
+) (recovered bool, uploads []workflow.Future, err error) {
+	workflowID := workflow.GetInfo(ctx).WorkflowExecution.ID
+	branchParam := &ErrorBranchActivityParam{
+		WorkflowID: workflowID,
+		ID:         compID,
+		BranchIDs:  sortedComponentIDs(branch),
+	}
+
+	failed := false
+	if err := workflow.ExecuteActivity(ctx, w.PreErrorBranchActivity, branchParam).Get(ctx, &failed); err != nil {
+		return false, nil, err
+	}
+	if !failed {
+		return false, nil, nil
+	}
+
+	dag, err := recipe.GenerateDAG(branch)
+	if err != nil {
+		return false, nil, err
+	}
+	orderedBranch, err := dag.TopologicalSort()
+	if err != nil {
+		return false, nil, err
+	}
+
+	for group := range orderedBranch {
+		futures := []workflow.Future{}
+		futureArgs := []*ComponentActivityParam{}
+		for _, branchID := range sortedComponentIDs(orderedBranch[group]) {
+			comp := orderedBranch[group][branchID]
+			componentRun := &datamodel.ComponentRun{
+				PipelineTriggerUID: uuid.FromStringOrNil(param.SystemVariables.PipelineTriggerID),
+				ComponentID:        branchID,
+				Status:             datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_PROCESSING),
+				StartedTime:        time.Now(),
+			}
+			_ = workflow.ExecuteActivity(ctx, w.UpsertComponentRunActivity, &UpsertComponentRunActivityParam{
+				ComponentRun: componentRun,
+			}).Get(ctx, nil)
+
+			args := &ComponentActivityParam{
+				WorkflowID:      workflowID,
+				ID:              branchID,
+				UpstreamIDs:     dag.GetUpstreamCompIDs(branchID),
+				Type:            comp.Type,
+				Task:            comp.Task,
+				Condition:       comp.Condition,
+				SystemVariables: param.SystemVariables,
+				Failover:        comp.Failover,
+				Cache:           comp.Cache,
+				Batching:        comp.Batching,
+			}
+
+			uploads = append(uploads, workflow.ExecuteActivity(minioCtx, w.UploadComponentInputsActivity, args))
+			futures = append(futures, workflow.ExecuteActivity(componentCtx, w.ComponentActivity, args))
+			futureArgs = append(futureArgs, args)
+		}
+
+		for idx := range futures {
+			if err := futures[idx].Get(ctx, nil); err != nil {
+				return false, uploads, err
+			}
+			uploads = append(uploads, workflow.ExecuteActivity(minioCtx, w.UploadComponentOutputsActivity, futureArgs[idx]))
+		}
+	}
+
+	if err := workflow.ExecuteActivity(ctx, w.PostErrorBranchActivity, branchParam).Get(ctx, &recovered); err != nil {
+		return false, uploads, err
+	}
+
+	return recovered, uploads, nil
+}
+
+func (w *worker) UpsertPipelineRunActivity(ctx context.Context, param *UpsertPipelineRunActivityParam) error {
+	logger, _ := logger.GetZapLogger(ctx)
+	logger = logger.With(zap.String("PipelineTriggerUID", param.PipelineRun.PipelineTriggerUID.String()))
+	logger.Info("UpsertPipelineRunActivity started")
+
+	if param.PipelineRun.TemporalCluster == "" {
+		param.PipelineRun.TemporalCluster = w.Cluster()
+	}
+	if err := w.repository.UpsertPipelineRun(ctx, param.PipelineRun); err != nil {
+		logger.Error("failed to log pipeline run start", zap.Error(err))
+		return err
+	}
+
+	logger.Info("UpsertPipelineRunActivity completed")
+	return nil
+}
+
 func (w *worker) UpdatePipelineRunActivity(ctx context.Context, param *UpdatePipelineRunActivityParam) error {
 	logger, _ := logger.GetZapLogger(ctx)
 	logger = logger.With(zap.String("PipelineTriggerUID", param.PipelineTriggerID))
 	logger.Info("UpdatePipelineRunActivity started")
 
+	if param.WorkflowID != "" {
+		if wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.WorkflowID); err == nil {
+			param.PipelineRun.BatchResults = batchResults(ctx, wfm)
+		} else {
+			logger.Error("failed to load batch item results", zap.Error(err))
+		}
+	}
+
 	err := w.repository.UpdatePipelineRun(ctx, param.PipelineTriggerID, param.PipelineRun)
 	if err != nil {
 		logger.Error("failed to log completed pipeline run", zap.Error(err))
@@ -486,6 +965,15 @@ func (w *worker) ComponentActivity(ctx context.Context, param *ComponentActivity
 	logger.Info("ComponentActivity started")
 
 	startTime := time.Now()
+
+	// provider is the component definition that served the batch and
+	// failoverIdx its position in the failover group, if any.
+	var provider string
+	var failoverIdx int
+
+	// oversized records the outputs that exceeded the size limit.
+	oversized := &oversizedOutputs{}
+
 	// this is component run actual start time
 	err := w.repository.UpdateComponentRun(ctx, param.SystemVariables.PipelineTriggerID, param.ID, &datamodel.ComponentRun{StartedTime: startTime})
 	if err != nil {
@@ -495,6 +983,9 @@ func (w *worker) ComponentActivity(ctx context.Context, param *ComponentActivity
 			componentRun := &datamodel.ComponentRun{
 				CompletedTime: null.TimeFrom(time.Now()),
 				TotalDuration: null.IntFrom(time.Since(startTime).Milliseconds()),
+				Provider:      provider,
+				FailoverIndex: failoverIdx,
+				OutputActions: oversized.list(),
 			}
 			if err != nil {
 				componentRun.Status = datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_FAILED)
@@ -513,6 +1004,18 @@ func (w *worker) ComponentActivity(ctx context.Context, param *ComponentActivity
 	if err != nil {
 		return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
 	}
+	// The memory can't be spilled while the component runs, as the
+	// execution writes to it.
+	defer wfm.Hold()()
+
+	// If a previous attempt checkpointed the component but failed to report
+	// it (e.g. the worker stopped), the run resumes from the checkpoint
+	// instead of executing the component again.
+	if componentCompleted(ctx, wfm, param.ID) {
+		logger.Info("ComponentActivity resumed from checkpoint")
+		return nil
+	}
+
 	conditionMap, err := w.processCondition(ctx, wfm, param.ID, param.UpstreamIDs, param.Condition)
 	if err != nil {
 		return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
@@ -526,35 +1029,157 @@ func (w *worker) ComponentActivity(ctx context.Context, param *ComponentActivity
 		if err != nil {
 			return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
 		}
-		executionParams := componentstore.ExecutionParams{
-			ComponentID:           param.ID,
-			ComponentDefinitionID: param.Type,
-			SystemVariables:       sysVars,
 
-			// Note: currently, we assume that setup in the batch are all the same
-			Setup: setups[0],
-			Task:  param.Task,
+		// Executions that are rate limited by the remote service wait before
+		// retrying their requests. The items of the batch are flagged in the
+		// meantime so users understand why the component stalls.
+		var rateLimited atomic.Bool
+		execCtx := componentbase.ContextWithRateLimitHandler(ctx, func(ctx context.Context, wait time.Duration) {
+			logger.Info("Component rate limited", zap.Duration("wait", wait))
+			if rateLimited.Swap(true) {
+				return
+			}
+			for _, idx := range conditionMap {
+				_ = wfm.SetComponentStatus(ctx, idx, param.ID, memory.ComponentStatusRateLimited, true)
+			}
+		})
+		defer func() {
+			if rateLimited.Load() {
+				for _, idx := range conditionMap {
+					_ = wfm.SetComponentStatus(ctx, idx, param.ID, memory.ComponentStatusRateLimited, false)
+				}
+			}
+		}()
+
+		execCtx = contextWithRunArtifacts(execCtx, param.SystemVariables.PipelineTriggerID)
+		if w.sessions != nil {
+			execCtx = componentbase.ContextWithConversationStore(execCtx, &conversationStore{
+				sessions:     w.sessions,
+				namespaceUID: param.SystemVariables.PipelineOwnerUID,
+			})
 		}
 
-		execution, err := w.component.CreateExecution(executionParams)
-		if err != nil {
+		// The component is executed with its own setup first. If it belongs
+		// to a failover group, the batch items that fail because the provider
+		// is unavailable are executed again on the next connection.
+		pending := make([]int, 0, len(conditionMap))
+		for idx := range len(conditionMap) {
+			pending = append(pending, conditionMap[idx])
+		}
+
+		if pending, err = w.resolvePromptReferences(ctx, wfm, param, pending); err != nil {
 			return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
 		}
 
-		jobs := make([]*componentbase.Job, len(conditionMap))
-		for idx, originalIdx := range conditionMap {
-			jobs[idx] = &componentbase.Job{
-				Input:  NewInputReader(wfm, param.ID, originalIdx),
-				Output: NewOutputWriter(wfm, param.ID, originalIdx, wfm.IsStreaming()),
-				Error:  NewErrorHandler(wfm, param.ID, originalIdx),
+		// Items whose prompt is similar to a previous request are served
+		// from the semantic cache and aren't executed.
+		var cacheRequests map[int]*cachedRequest
+		if w.cacheEnabled(param.Cache) {
+			// The cached inputs are read with the context of the execution,
+			// as they hold the content of the referenced files.
+			if pending, cacheRequests, err = w.serveFromCache(execCtx, wfm, param, setups[0], pending); err != nil {
+				return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
 			}
 		}
-		err = execution.Execute(
-			ctx,
-			jobs,
-		)
-		if err != nil {
-			return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
+
+		targets := failoverTargets(param)
+		for i, target := range targets {
+			if len(pending) == 0 {
+				break
+			}
+
+			// Note: currently, we assume that setup in the batch are all the same
+			setup := setups[0]
+			if i > 0 {
+				if setup, err = renderFailoverSetup(ctx, wfm, target, pending[0]); err != nil {
+					return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
+				}
+			}
+
+			executionParams := componentstore.ExecutionParams{
+				ComponentID:           param.ID,
+				ComponentDefinitionID: target.Type,
+				SystemVariables:       sysVars,
+				Setup:                 setup,
+				Task:                  target.Task,
+			}
+
+			execution, err := w.component.CreateExecution(executionParams)
+			if err != nil {
+				return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
+			}
+			execution = w.sandbox.wrap(newEmbeddingBatchExecution(execution, param.Batching))
+
+			connID := param.ConnectionID
+			if i > 0 {
+				connID = referencedConnectionID(target.Setup)
+			}
+			if execution, err = w.limitConnectionCalls(ctx, execution, param.SystemVariables.PipelineOwnerUID, connID); err != nil {
+				return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
+			}
+
+			// The components validate their input too, but against its
+			// structpb representation, so the errors can't point at the
+			// recipe. The schemas that can't be parsed are left to them.
+			inputSchema, schemaErr := data.ParseSchema([]byte(execution.GetTaskInputSchema()))
+			if schemaErr != nil {
+				logger.Warn("Component input schema can't be parsed", zap.String("task", target.Task), zap.Error(schemaErr))
+			}
+
+			var unavailable *unavailableItems
+			if i < len(targets)-1 {
+				unavailable = new(unavailableItems)
+			}
+
+			jobs := make([]*componentbase.Job, len(pending))
+			for idx, originalIdx := range pending {
+				var input componentbase.InputReader = NewInputReader(wfm, param.ID, originalIdx, inputSchema)
+				var output componentbase.OutputWriter = NewOutputWriter(wfm, param.ID, originalIdx, wfm.IsStreaming())
+				if req, ok := cacheRequests[originalIdx]; ok {
+					input, output = &cachedInputReader{input: req.input}, req.output
+				}
+				artifact := NewArtifactWriter(
+					w.repository, w.minioClient,
+					param.SystemVariables.PipelineTriggerID, param.ID, originalIdx,
+				)
+
+				jobs[idx] = &componentbase.Job{
+					Input:    input,
+					Output:   limitOutputSize(output, artifact, originalIdx, oversized),
+					Error:    newFailoverErrorHandler(NewErrorHandler(wfm, param.ID, originalIdx), unavailable, originalIdx),
+					Artifact: artifact,
+				}
+			}
+
+			// Connectors and operators can be limited separately, e.g. to
+			// protect the external APIs from a worker with many runs in
+			// flight.
+			limiter := w.componentLimiter(execution)
+			if err = limiter.acquire(ctx); err != nil {
+				return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
+			}
+			err = execution.Execute(
+				execCtx,
+				jobs,
+			)
+			limiter.release()
+			if err != nil {
+				return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
+			}
+
+			provider, failoverIdx = target.Type, i
+			pending = unavailable.list()
+			if len(pending) > 0 {
+				logger.Warn("Component provider unavailable, failing over",
+					zap.String("provider", target.Type),
+					zap.Int("failoverIndex", i+1),
+					zap.Int("items", len(pending)),
+				)
+			}
+		}
+
+		if len(cacheRequests) > 0 {
+			w.storeInCache(ctx, wfm, param, cacheRequests)
 		}
 
 		for _, idx := range conditionMap {
@@ -566,6 +1191,11 @@ func (w *worker) ComponentActivity(ctx context.Context, param *ComponentActivity
 		}
 	}
 
+	if err = w.memoryStore.CommitComponentMemory(ctx, param.WorkflowID, param.ID); err != nil {
+		return componentActivityError(ctx, wfm, err, componentActivityErrorType, param.ID)
+	}
+	w.recordMemorySize(ctx, param.SystemVariables.PipelineTriggerID, wfm)
+
 	logger.Info("ComponentActivity completed")
 	return nil
 }
@@ -594,10 +1224,51 @@ func (w *worker) OutputActivity(ctx context.Context, param *ComponentActivityPar
 		}
 	}
 
+	if err := w.runPostTriggerHooks(ctx, &TriggerHookParam{
+		WorkflowID:      param.WorkflowID,
+		SystemVariables: param.SystemVariables,
+		Memory:          wfm,
+	}); err != nil {
+		return temporal.NewApplicationErrorWithCause("running trigger hooks", outputActivityErrorType, err)
+	}
+
+	if err := w.commitMemory(ctx, param.WorkflowID); err != nil {
+		return temporal.NewApplicationErrorWithCause("saving pipeline memory", outputActivityErrorType, err)
+	}
+	w.recordMemorySize(ctx, param.SystemVariables.PipelineTriggerID, wfm)
+
 	logger.Info("OutputActivity completed")
 	return nil
 }
 
+// recordMemorySize stores the size of the run memory in the run metadata,
+// which helps to tune the memory limit of the workers. Failures are only
+// logged, as the metadata isn't needed to complete the run.
+func (w *worker) recordMemorySize(ctx context.Context, pipelineTriggerID string, wfm memory.WorkflowMemory) {
+	size := wfm.GetMemorySize()
+	err := w.repository.UpdatePipelineRun(ctx, pipelineTriggerID, &datamodel.PipelineRun{
+		MemorySize:       null.IntFrom(size.Total),
+		BatchMemorySizes: size.Batches,
+	})
+	if err != nil {
+		logger, _ := logger.GetZapLogger(ctx)
+		logger.Error("failed to log run memory size", zap.Error(err))
+	}
+}
+
+// commitMemory saves the run memory in the persistence backend. When the
+// backend isn't encrypted, the memory of the runs that use secrets is only
+// kept in the process, so these runs can't be resumed by another worker.
+func (w *worker) commitMemory(ctx context.Context, workflowID string) error {
+	err := w.memoryStore.CommitWorkflowMemory(ctx, workflowID)
+	if errors.Is(err, memory.ErrUnencryptedSecrets) {
+		logger, _ := logger.GetZapLogger(ctx)
+		logger.Warn("pipeline memory isn't persisted", zap.String("workflowID", workflowID), zap.Error(err))
+		return nil
+	}
+	return err
+}
+
 // TODO: complete iterator
 // PreIteratorActivity generate the trigger memory for each iteration.
 func (w *worker) PreIteratorActivity(ctx context.Context, param *PreIteratorActivityParam) (*PreIteratorActivityResult, error) {
@@ -629,14 +1300,20 @@ func (w *worker) PreIteratorActivity(ctx context.Context, param *PreIteratorActi
 		useInput := param.Input != ""
 
 		var indexes []int
-		var elems []data.Value
+		var elems *data.ArrayIterator
 		if useInput {
 			input, err := recipe.Render(ctx, data.NewString(param.Input), iter, wfm, false)
 			if err != nil {
 				return nil, componentActivityError(ctx, wfm, err, preIteratorActivityErrorType, param.ID)
 			}
-			elems = input.(*data.Array).Values
-			indexes = make([]int, len(elems))
+			arr, ok := input.(*data.Array)
+			if !ok {
+				return nil, componentActivityError(ctx, wfm, fmt.Errorf("iterator input must be an array"), preIteratorActivityErrorType, param.ID)
+			}
+			// The input is rendered as a whole, and its elements are set in
+			// the iteration memory as they are, without copying them.
+			elems = arr.Iter()
+			indexes = make([]int, elems.Len())
 		} else {
 
 			// We offer two syntax options for defining `range`.
@@ -746,11 +1423,19 @@ func (w *worker) PreIteratorActivity(ctx context.Context, param *PreIteratorActi
 		}
 
 		result.ElementSize[iter] = len(indexes)
+		// The components of the iterator run with the settings of the
+		// pipeline ones.
+		parentRecipe := wfm.GetRecipe()
 		iteratorRecipe := &datamodel.Recipe{
-			Component: wfm.GetRecipe().Component[param.ID].Component,
+			Component:        parentRecipe.Component[param.ID].Component,
+			ComponentTimeout: parentRecipe.ComponentTimeout,
+			Retry:            parentRecipe.Retry,
+			ErrorPolicy:      parentRecipe.ErrorPolicy,
+			Coercion:         parentRecipe.Coercion,
+			Capture:          parentRecipe.Capture,
 		}
 
-		childWFM, err := w.memoryStore.NewWorkflowMemory(ctx, childWorkflowIDs[iter], iteratorRecipe, len(indexes))
+		childWFM, err := w.memoryStore.NewWorkflowMemory(memory.WithTenant(ctx, wfm.GetTenant()), childWorkflowIDs[iter], iteratorRecipe, len(indexes))
 		if err != nil {
 			return nil, componentActivityError(ctx, wfm, err, preIteratorActivityErrorType, param.ID)
 		}
@@ -758,17 +1443,20 @@ func (w *worker) PreIteratorActivity(ctx context.Context, param *PreIteratorActi
 		// When iterating over `input`, each element in the array is processed
 		// and stored in memory.
 		if useInput {
-			for e := range len(indexes) {
+			for elems.Next() {
 				iteratorElem := data.NewMap(
 					map[string]data.Value{
-						"element": elems[e],
+						"element": elems.Value(),
 					},
 				)
-				err = childWFM.Set(ctx, e, param.ID, iteratorElem)
+				err = childWFM.Set(ctx, elems.Index(), param.ID, iteratorElem)
 				if err != nil {
 					return nil, componentActivityError(ctx, wfm, err, preIteratorActivityErrorType, param.ID)
 				}
 			}
+			if err := elems.Err(); err != nil {
+				return nil, componentActivityError(ctx, wfm, err, preIteratorActivityErrorType, param.ID)
+			}
 		} else {
 			for e, rangeIndex := range indexes {
 				identifier := defaultRangeIdentifier
@@ -810,7 +1498,7 @@ func (w *worker) PreIteratorActivity(ctx context.Context, param *PreIteratorActi
 					return nil, componentActivityError(ctx, wfm, err, preIteratorActivityErrorType, param.ID)
 				}
 			}
-			for compID, comp := range iteratorRecipe.Component {
+			for compID, comp := range iteratorRecipe.Component.WithErrorBranches() {
 				inputVal, err := data.NewValue(comp.Input)
 				if err != nil {
 					return nil, componentActivityError(ctx, wfm, err, preIteratorActivityErrorType, param.ID)
@@ -856,15 +1544,12 @@ func (w *worker) PostIteratorActivity(ctx context.Context, param *PostIteratorAc
 
 		output := data.NewMap(nil)
 		for k, v := range param.OutputElements {
-			elemVals := data.NewArray(nil)
-
-			for elemIdx := range childWFM.GetBatchSize() {
-				elemVal, err := recipe.Render(ctx, data.NewString(v), elemIdx, childWFM, false)
-				if err != nil {
-					return componentActivityError(ctx, wfm, err, postIteratorActivityErrorType, param.ID)
-				}
-				elemVals.Values = append(elemVals.Values, elemVal)
-
+			// The output elements are rendered as they're collected.
+			elemVals, err := data.NewArrayIterator(childWFM.GetBatchSize(), func(elemIdx int) (data.Value, error) {
+				return recipe.Render(ctx, data.NewString(v), elemIdx, childWFM, false)
+			}).Collect()
+			if err != nil {
+				return componentActivityError(ctx, wfm, err, postIteratorActivityErrorType, param.ID)
 			}
 			output.Fields[k] = elemVals
 		}
@@ -881,6 +1566,265 @@ func (w *worker) PostIteratorActivity(ctx context.Context, param *PostIteratorAc
 	return nil
 }
 
+// PreErrorBranchActivity prepares the error branch of a component. The branch
+// components are skipped in the batch items where the component succeeded.
+// It returns whether the component failed in any batch item.
+func (w *worker) PreErrorBranchActivity(ctx context.Context, param *ErrorBranchActivityParam) (bool, error) {
+	logger, _ := logger.GetZapLogger(ctx)
+	logger.Info("PreErrorBranchActivity started")
+
+	wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.WorkflowID)
+	if err != nil {
+		return false, temporal.NewApplicationErrorWithCause("loading pipeline memory", errorBranchActivityErrorType, err)
+	}
+
+	failed := false
+	for idx := range wfm.GetBatchSize() {
+		errored, err := wfm.GetComponentStatus(ctx, idx, param.ID, memory.ComponentStatusErrored)
+		if err != nil {
+			return false, temporal.NewApplicationErrorWithCause("loading component status", errorBranchActivityErrorType, err)
+		}
+		if errored {
+			failed = true
+			continue
+		}
+
+		for _, branchID := range param.BranchIDs {
+			if err := wfm.SetComponentStatus(ctx, idx, branchID, memory.ComponentStatusSkipped, true); err != nil {
+				return false, temporal.NewApplicationErrorWithCause("updating component status", errorBranchActivityErrorType, err)
+			}
+		}
+	}
+
+	logger.Info("PreErrorBranchActivity completed")
+	return failed, nil
+}
+
+// PostErrorBranchActivity marks a failed component as completed in the batch
+// items where its error branch succeeded, so the downstream components are
+// executed. The error message is kept in the component memory. It returns
+// whether the branch recovered every failure.
+func (w *worker) PostErrorBranchActivity(ctx context.Context, param *ErrorBranchActivityParam) (bool, error) {
+	logger, _ := logger.GetZapLogger(ctx)
+	logger.Info("PostErrorBranchActivity started")
+
+	wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.WorkflowID)
+	if err != nil {
+		return false, temporal.NewApplicationErrorWithCause("loading pipeline memory", errorBranchActivityErrorType, err)
+	}
+
+	recovered := true
+	for idx := range wfm.GetBatchSize() {
+		if errored, err := wfm.GetComponentStatus(ctx, idx, param.ID, memory.ComponentStatusErrored); err != nil || !errored {
+			continue
+		}
+
+		branchSucceeded := true
+		for _, branchID := range param.BranchIDs {
+			errored, err := wfm.GetComponentStatus(ctx, idx, branchID, memory.ComponentStatusErrored)
+			if err != nil {
+				return false, temporal.NewApplicationErrorWithCause("loading component status", errorBranchActivityErrorType, err)
+			}
+			skipped, err := wfm.GetComponentStatus(ctx, idx, branchID, memory.ComponentStatusSkipped)
+			if err != nil {
+				return false, temporal.NewApplicationErrorWithCause("loading component status", errorBranchActivityErrorType, err)
+			}
+			if errored || skipped {
+				branchSucceeded = false
+				break
+			}
+		}
+		if !branchSucceeded {
+			recovered = false
+			continue
+		}
+
+		if err := wfm.SetComponentStatus(ctx, idx, param.ID, memory.ComponentStatusErrored, false); err != nil {
+			return false, temporal.NewApplicationErrorWithCause("updating component status", errorBranchActivityErrorType, err)
+		}
+		if err := wfm.SetComponentStatus(ctx, idx, param.ID, memory.ComponentStatusCompleted, true); err != nil {
+			return false, temporal.NewApplicationErrorWithCause("updating component status", errorBranchActivityErrorType, err)
+		}
+	}
+
+	logger.Info("PostErrorBranchActivity completed")
+	return recovered, nil
+}
+
+// PreBatchChunkActivity splits the trigger memory into chunks of, at most,
+// BatchChunkSize elements. A new memory is created for each chunk so it can be
+// orchestrated by a child workflow. If the batch doesn't need to be chunked,
+// no child workflow IDs are returned.
+//
+// When the memory store has a persistence backend, the chunk memory is
+// released to it so the chunks can run in parallel on different workers.
+func (w *worker) PreBatchChunkActivity(ctx context.Context, param *PreBatchChunkActivityParam) (*PreBatchChunkActivityResult, error) {
+	logger, _ := logger.GetZapLogger(ctx)
+	logger.Info("PreBatchChunkActivity started")
+
+	wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.WorkflowID)
+	if err != nil {
+		return nil, temporal.NewApplicationErrorWithCause("loading pipeline memory", batchChunkActivityErrorType, err)
+	}
+
+	result := &PreBatchChunkActivityResult{}
+
+	// Streamed triggers aren't chunked, as the events are sent through the
+	// trigger memory channel.
+	chunkSize := config.Config.Server.Workflow.BatchChunkSize
+	batchSize := wfm.GetBatchSize()
+	if chunkSize <= 0 || batchSize <= chunkSize || wfm.IsStreaming() {
+		logger.Info("PreBatchChunkActivity completed")
+		return result, nil
+	}
+
+	for start := 0; start < batchSize; start += chunkSize {
+		end := min(start+chunkSize, batchSize)
+		childWorkflowID := fmt.Sprintf("%s:%s:%d", param.WorkflowID, constant.SegChunk, len(result.ChildWorkflowIDs))
+
+		childWFM, err := w.memoryStore.NewWorkflowMemory(memory.WithTenant(ctx, wfm.GetTenant()), childWorkflowID, wfm.GetRecipe(), end-start)
+		if err != nil {
+			return nil, temporal.NewApplicationErrorWithCause("creating chunk memory", batchChunkActivityErrorType, err)
+		}
+
+		for batchIdx := start; batchIdx < end; batchIdx++ {
+			batchMemory, err := wfm.Get(ctx, batchIdx, "")
+			if err != nil {
+				return nil, temporal.NewApplicationErrorWithCause("loading pipeline memory", batchChunkActivityErrorType, err)
+			}
+			for key, value := range batchMemory.(*data.Map).Fields {
+				if err := childWFM.Set(ctx, batchIdx-start, key, value); err != nil {
+					return nil, temporal.NewApplicationErrorWithCause("setting chunk memory", batchChunkActivityErrorType, err)
+				}
+			}
+		}
+
+		released, err := w.memoryStore.ReleaseWorkflowMemory(ctx, childWorkflowID)
+		if err != nil {
+			return nil, temporal.NewApplicationErrorWithCause("releasing chunk memory", batchChunkActivityErrorType, err)
+		}
+		result.Shared = released
+		result.ChildWorkflowIDs = append(result.ChildWorkflowIDs, childWorkflowID)
+	}
+
+	logger.Info("PreBatchChunkActivity completed", zap.Int("chunks", len(result.ChildWorkflowIDs)))
+	return result, nil
+}
+
+// PostBatchChunkActivity merges the memory of each chunk back into the
+// trigger memory and purges the chunk memory.
+func (w *worker) PostBatchChunkActivity(ctx context.Context, param *PostBatchChunkActivityParam) error {
+	logger, _ := logger.GetZapLogger(ctx)
+	logger.Info("PostBatchChunkActivity started")
+
+	wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.WorkflowID)
+	if err != nil {
+		return temporal.NewApplicationErrorWithCause("loading pipeline memory", batchChunkActivityErrorType, err)
+	}
+
+	offset := 0
+	for _, childWorkflowID := range param.ChildWorkflowIDs {
+		childWFM, err := w.memoryStore.GetWorkflowMemory(ctx, childWorkflowID)
+		if err != nil {
+			return temporal.NewApplicationErrorWithCause("loading chunk memory", batchChunkActivityErrorType, err)
+		}
+
+		for idx := range childWFM.GetBatchSize() {
+			chunkMemory, err := childWFM.Get(ctx, idx, "")
+			if err != nil {
+				return temporal.NewApplicationErrorWithCause("loading chunk memory", batchChunkActivityErrorType, err)
+			}
+			for key, value := range chunkMemory.(*data.Map).Fields {
+				if err := wfm.Set(ctx, offset+idx, key, value); err != nil {
+					return temporal.NewApplicationErrorWithCause("merging chunk memory", batchChunkActivityErrorType, err)
+				}
+			}
+		}
+		offset += childWFM.GetBatchSize()
+
+		if err := w.memoryStore.PurgeWorkflowMemory(ctx, childWorkflowID); err != nil {
+			return temporal.NewApplicationErrorWithCause("purging chunk memory", batchChunkActivityErrorType, err)
+		}
+	}
+
+	logger.Info("PostBatchChunkActivity completed")
+	return nil
+}
+
+// ReleaseBatchChunkActivity persists the memory of a shared batch chunk and
+// drops it from the worker, so the trigger workflow can merge it from any
+// worker.
+func (w *worker) ReleaseBatchChunkActivity(ctx context.Context, workflowID string) error {
+	if _, err := w.memoryStore.ReleaseWorkflowMemory(ctx, workflowID); err != nil {
+		return temporal.NewApplicationErrorWithCause("releasing chunk memory", batchChunkActivityErrorType, err)
+	}
+	return nil
+}
+
+// PipelineTimedOutActivity marks the components that haven't finished when
+// the max duration of the run is reached as skipped and notifies the
+// streaming clients.
+func (w *worker) PipelineTimedOutActivity(ctx context.Context, param *PipelineTimedOutActivityParam) error {
+	logger, _ := logger.GetZapLogger(ctx)
+	logger.Info("PipelineTimedOutActivity started")
+
+	wfm, err := w.memoryStore.GetWorkflowMemory(ctx, param.WorkflowID)
+	if err != nil {
+		return temporal.NewApplicationErrorWithCause("loading pipeline memory", pipelineTimedOutActivityErrorType, err)
+	}
+
+	for batchIdx := range wfm.GetBatchSize() {
+		for compID := range wfm.GetRecipe().Component.WithErrorBranches() {
+			finished := false
+			for _, st := range []memory.ComponentStatusType{
+				memory.ComponentStatusCompleted,
+				memory.ComponentStatusErrored,
+				memory.ComponentStatusSkipped,
+			} {
+				if s, err := wfm.GetComponentStatus(ctx, batchIdx, compID, st); err == nil && s {
+					finished = true
+					break
+				}
+			}
+			if finished {
+				continue
+			}
+
+			if err := wfm.SetComponentStatus(ctx, batchIdx, compID, memory.ComponentStatusSkipped, true); err != nil {
+				return temporal.NewApplicationErrorWithCause("updating component status", pipelineTimedOutActivityErrorType, err)
+			}
+		}
+
+		if wfm.IsStreaming() {
+			err = w.memoryStore.SendWorkflowStatusEvent(
+				ctx,
+				param.WorkflowID,
+				memory.Event{
+					Event: string(memory.PipelineTimedOut),
+					Data: memory.PipelineTimedOutEventData{
+						PipelineEventData: memory.PipelineEventData{
+							UpdateTime: time.Now(),
+							BatchIndex: batchIdx,
+							Status: map[memory.PipelineStatusType]bool{
+								memory.PipelineStatusStarted:   true,
+								memory.PipelineStatusErrored:   true,
+								memory.PipelineStatusCompleted: false,
+							},
+						},
+						MaxDuration: param.MaxDuration,
+					},
+				},
+			)
+			if err != nil {
+				return temporal.NewApplicationErrorWithCause("sending event", pipelineTimedOutActivityErrorType, err)
+			}
+		}
+	}
+
+	logger.Info("PipelineTimedOutActivity completed")
+	return nil
+}
+
 func (w *worker) LoadDAGDataActivity(ctx context.Context, param *LoadDAGDataActivityParam) (*LoadDAGDataActivityResult, error) {
 
 	logger, _ := logger.GetZapLogger(ctx)
@@ -958,6 +1902,10 @@ func (w *worker) PreTriggerActivity(ctx context.Context, param *PreTriggerActivi
 		triggerRecipe = release.Recipe
 	}
 
+	if err := w.applyNamespaceSettings(ctx, param.SystemVariables.PipelineOwnerUID, triggerRecipe); err != nil {
+		return preTriggerErr(err)
+	}
+
 	wfm.SetRecipe(triggerRecipe)
 
 	// Loading secrets and connections into memory.
@@ -982,7 +1930,7 @@ func (w *worker) PreTriggerActivity(ctx context.Context, param *PreTriggerActivi
 	}
 
 	connections := data.NewMap(nil)
-	for _, comp := range triggerRecipe.Component {
+	for _, comp := range triggerRecipe.Component.WithErrorBranches() {
 		if connRef, ok := comp.Setup.(string); ok {
 			connID, err := recipe.ConnectionIDFromReference(connRef)
 			if err != nil {
@@ -1021,16 +1969,31 @@ func (w *worker) PreTriggerActivity(ctx context.Context, param *PreTriggerActivi
 		}
 	}
 
+	// The same connections are set for every batch item.
+	data.Share(connections)
+
+	nsSecretValues := data.NewMap(nil)
+	for _, secret := range nsSecrets {
+		nsSecretValues.Fields[secret.ID] = data.NewString(*secret.Value)
+	}
+
 	for idx := range wfm.GetBatchSize() {
 		pipelineSecrets, err := wfm.Get(ctx, idx, constant.SegSecret)
 		if err != nil {
 			return preTriggerErr(fmt.Errorf("loading pipeline secret memory: %w", err))
 		}
 
-		for _, secret := range nsSecrets {
-			if _, ok := pipelineSecrets.(*data.Map).Fields[secret.ID]; !ok {
-				pipelineSecrets.(*data.Map).Fields[secret.ID] = data.NewString(*secret.Value)
-			}
+		// The pipeline secrets take precedence over the namespace ones. The
+		// merge copies the secrets of the batch items, which can be shared.
+		secrets, err := pipelineSecrets.(*data.Map).Merge(nsSecretValues, data.MergeKeep)
+		if err != nil {
+			return preTriggerErr(fmt.Errorf("merging namespace secrets: %w", err))
+		}
+
+		// The secret scope is set again so the memory tracks the namespace
+		// secrets and masks them when it leaves the process.
+		if err := wfm.Set(ctx, idx, constant.SegSecret, secrets); err != nil {
+			return preTriggerErr(fmt.Errorf("setting pipeline secret memory: %w", err))
 		}
 
 		if err := wfm.Set(ctx, idx, constant.SegConnection, connections); err != nil {
@@ -1038,7 +2001,7 @@ func (w *worker) PreTriggerActivity(ctx context.Context, param *PreTriggerActivi
 		}
 
 		// Init component template
-		for compID, comp := range triggerRecipe.Component {
+		for compID, comp := range triggerRecipe.Component.WithErrorBranches() {
 			wfm.InitComponent(ctx, idx, compID)
 
 			inputVal, err := data.NewValue(comp.Input)
@@ -1068,6 +2031,14 @@ func (w *worker) PreTriggerActivity(ctx context.Context, param *PreTriggerActivi
 		}
 	}
 
+	if err := w.runPreTriggerHooks(ctx, &TriggerHookParam{
+		WorkflowID:      param.WorkflowID,
+		SystemVariables: param.SystemVariables,
+		Memory:          wfm,
+	}); err != nil {
+		return preTriggerErr(err)
+	}
+
 	if wfm.IsStreaming() {
 		for batchIdx := range wfm.GetBatchSize() {
 			err = w.memoryStore.SendWorkflowStatusEvent(
@@ -1094,6 +2065,10 @@ func (w *worker) PreTriggerActivity(ctx context.Context, param *PreTriggerActivi
 		}
 	}
 
+	if err := w.commitMemory(ctx, param.WorkflowID); err != nil {
+		return preTriggerErr(fmt.Errorf("saving pipeline memory: %w", err))
+	}
+
 	logger.Info("PreTriggerActivity completed")
 	return nil
 }
@@ -1109,6 +2084,7 @@ func (w *worker) PostTriggerActivity(ctx context.Context, param *PostTriggerActi
 		return temporal.NewApplicationErrorWithCause("loading pipeline memory", postTriggerActivityErrorType, err)
 	}
 
+	results := batchResults(ctx, wfm)
 	for batchIdx := range wfm.GetBatchSize() {
 		output, err := wfm.GetPipelineData(ctx, batchIdx, memory.PipelineOutput)
 		if err != nil {
@@ -1141,7 +2117,7 @@ func (w *worker) PostTriggerActivity(ctx context.Context, param *PostTriggerActi
 							BatchIndex: batchIdx,
 							Status: map[memory.PipelineStatusType]bool{
 								memory.PipelineStatusStarted:   true,
-								memory.PipelineStatusErrored:   false,
+								memory.PipelineStatusErrored:   batchItemFailed(results[batchIdx]),
 								memory.PipelineStatusCompleted: true,
 							},
 						},
@@ -1204,26 +2180,12 @@ func (w *worker) processCondition(ctx context.Context, wfm memory.WorkflowMemory
 				return nil, err
 			}
 
-			allMemory, err := wfm.Get(ctx, idx, "")
-			if err != nil {
-				return nil, err
-			}
-			condMemoryForConditionStruct, err := allMemory.ToStructValue()
-			if err != nil {
-				return nil, err
-			}
-			b, _ := protojson.Marshal(condMemoryForConditionStruct)
-			condMemoryForCondition := map[string]any{}
-			_ = json.Unmarshal(b, &condMemoryForCondition)
-
-			sanitizedCondMemoryForCondition := map[string]any{}
-			for k, v := range condMemoryForCondition {
-				sanitizedCondMemoryForCondition[varMapping[k]] = v
-			}
-
-			cond, err := recipe.EvalCondition(expr, sanitizedCondMemoryForCondition)
+			// The condition is evaluated against the data of each item, so
+			// an evaluation failure only fails the item.
+			cond, err := evalItemCondition(ctx, wfm, idx, expr, varMapping)
 			if err != nil {
-				return nil, err
+				NewErrorHandler(wfm, id, idx).Error(ctx, fmt.Errorf("evaluating condition: %w", err))
+				continue
 			}
 
 			if cond == false {
@@ -1244,6 +2206,44 @@ func (w *worker) processCondition(ctx context.Context, wfm memory.WorkflowMemory
 	return conditionMap, nil
 }
 
+// evalItemCondition evaluates a condition against the data of a batch item.
+func evalItemCondition(ctx context.Context, wfm memory.WorkflowMemory, idx int, expr ast.Expr, varMapping map[string]string) (any, error) {
+	allMemory, err := wfm.Get(ctx, idx, "")
+	if err != nil {
+		return nil, err
+	}
+	condMemoryForConditionStruct, err := allMemory.ToStructValue()
+	if err != nil {
+		return nil, err
+	}
+	b, _ := protojson.Marshal(condMemoryForConditionStruct)
+	condMemoryForCondition := map[string]any{}
+	_ = json.Unmarshal(b, &condMemoryForCondition)
+
+	sanitizedCondMemoryForCondition := map[string]any{}
+	for k, v := range condMemoryForCondition {
+		sanitizedCondMemoryForCondition[varMapping[k]] = v
+	}
+
+	return recipe.EvalCondition(expr, sanitizedCondMemoryForCondition)
+}
+
+// componentCompleted checks whether every batch item of a component is
+// either completed or skipped.
+func componentCompleted(ctx context.Context, wfm memory.WorkflowMemory, id string) bool {
+	for idx := range wfm.GetBatchSize() {
+		completed, err := wfm.GetComponentStatus(ctx, idx, id, memory.ComponentStatusCompleted)
+		if err != nil {
+			return false
+		}
+		skipped, err := wfm.GetComponentStatus(ctx, idx, id, memory.ComponentStatusSkipped)
+		if err != nil || (!completed && !skipped) {
+			return false
+		}
+	}
+	return true
+}
+
 // writeErrorDataPoint is a helper function that writes the error data point to
 // the usage metrics table.
 func (w *worker) writeErrorDataPoint(ctx context.Context, errs []error, span trace.Span, startTime time.Time, dataPoint *utils.PipelineUsageMetricData) {
@@ -1266,24 +2266,25 @@ func componentActivityError(ctx context.Context, wfm memory.WorkflowMemory, err
 		return fmt.Errorf("workflow memory is empty")
 	}
 
-	// TODO: huitang
-	// Currently, if any data in the batch has an error, we treat the entire
-	// batch as errored. In the future, we should allow partial errors within a
-	// batch.
+	compErr := newComponentError(err)
+
+	// The failures of a single batch item are recorded by its error handler
+	// and don't reach this point, so the error concerns the entire batch.
 	for batchIdx := range wfm.GetBatchSize() {
 		if wfmErr := wfm.SetComponentStatus(ctx, batchIdx, componentID, memory.ComponentStatusErrored, true); wfmErr != nil {
 			return wfmErr
 		}
-		if wfmErr := wfm.SetComponentErrorMessage(ctx, batchIdx, componentID, errmsg.MessageOrErr(err)); wfmErr != nil {
+		if wfmErr := wfm.SetComponentError(ctx, batchIdx, componentID, compErr); wfmErr != nil {
 			return wfmErr
 		}
 	}
 
 	// If no end-user message is present in the error, MessageOrErr will return
 	// the string version of the error. For an end user, this extra information
-	// is more actionable than no information at all.
+	// is more actionable than no information at all. The structured error is
+	// attached as a detail, so the workflow can report it in the run state.
 	msg := fmt.Sprintf("Component %s failed to execute. %s", componentID, errmsg.MessageOrErr(err))
-	return temporal.NewApplicationErrorWithCause(msg, errType, err)
+	return temporal.NewApplicationErrorWithCause(msg, errType, err, compErr)
 }
 
 // The following constants help temporal clients to trace the origin of an
@@ -1292,13 +2293,18 @@ func componentActivityError(ctx context.Context, wfm memory.WorkflowMemory, err
 // business domain (e.g. VendorError (non billable), InputDataError (billable),
 // etc.).
 const (
-	componentActivityErrorType    = "ComponentActivityError"
-	outputActivityErrorType       = "OutputActivityError"
-	preIteratorActivityErrorType  = "PreIteratorActivityError"
-	postIteratorActivityErrorType = "PostIteratorActivityError"
-	preTriggerActivityErrorType   = "PreTriggerActivityError"
-	loadDAGDataActivityErrorType  = "LoadDAGDataActivityError"
-	postTriggerActivityErrorType  = "PostTriggerActivityError"
+	componentActivityErrorType        = "ComponentActivityError"
+	outputActivityErrorType           = "OutputActivityError"
+	preIteratorActivityErrorType      = "PreIteratorActivityError"
+	postIteratorActivityErrorType     = "PostIteratorActivityError"
+	preTriggerActivityErrorType       = "PreTriggerActivityError"
+	loadDAGDataActivityErrorType      = "LoadDAGDataActivityError"
+	postTriggerActivityErrorType      = "PostTriggerActivityError"
+	batchChunkActivityErrorType       = "BatchChunkActivityError"
+	pipelineTimedOutActivityErrorType = "PipelineTimedOutActivityError"
+	errorBranchActivityErrorType      = "ErrorBranchActivityError"
+	approvalActivityErrorType         = "ApprovalActivityError"
+	reapMemoryActivityErrorType       = "ReapMemoryActivityError"
 )
 
 // EndUserErrorDetails provides a structured way to add an end-user error
@@ -1307,14 +2313,54 @@ type EndUserErrorDetails struct {
 	Message string
 }
 
+// SchedulePipelineWorkflow is started by the Temporal schedule of a pipeline.
+// Each execution triggers the pipeline in a child workflow and is logged as a
+// pipeline run that references the schedule, so the executions can be listed
+// in the schedule history.
 func (w *worker) SchedulePipelineWorkflow(wfctx workflow.Context, param *SchedulePipelineWorkflowParam) error {
 
-	scheduleID := fmt.Sprintf("%s_%s_schedule", param.PipelineUID, param.PipelineReleaseUID)
+	scheduleID := recipe.ScheduleID(param.PipelineUID, param.PipelineReleaseUID)
+
+	var pipelineTriggerID string
+	if err := workflow.SideEffect(wfctx, func(workflow.Context) any {
+		return uuid.Must(uuid.NewV4()).String()
+	}).Get(&pipelineTriggerID); err != nil {
+		return err
+	}
+
+	ctx := workflow.WithActivityOptions(wfctx, workflow.ActivityOptions{
+		TaskQueue:           w.workerUID.String(),
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: config.Config.Server.Workflow.MaxActivityRetry,
+		},
+	})
+
+	pipelineVersion := param.PipelineReleaseID
+	if pipelineVersion == "" {
+		pipelineVersion = "latest"
+	}
+	startedTime := workflow.Now(wfctx)
+	if err := workflow.ExecuteActivity(ctx, w.UpsertPipelineRunActivity, &UpsertPipelineRunActivityParam{
+		PipelineRun: &datamodel.PipelineRun{
+			PipelineTriggerUID: uuid.FromStringOrNil(pipelineTriggerID),
+			PipelineUID:        param.PipelineUID,
+			PipelineVersion:    pipelineVersion,
+			Status:             datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_PROCESSING),
+			Source:             datamodel.RunSource(runpb.RunSource_RUN_SOURCE_API),
+			Namespace:          param.Namespace.NsUID.String(),
+			TriggeredBy:        param.Namespace.NsUID.String(),
+			ScheduleID:         scheduleID,
+			StartedTime:        startedTime,
+		},
+	}).Get(ctx, nil); err != nil {
+		return err
+	}
 
 	// TODO: huitang - Handle pipeline release as well.
 	triggerParam := &TriggerPipelineWorkflowParam{
 		SystemVariables: recipe.SystemVariables{
-			PipelineTriggerID:    scheduleID,
+			PipelineTriggerID:    pipelineTriggerID,
 			PipelineID:           param.PipelineID,
 			PipelineUID:          param.PipelineUID,
 			PipelineReleaseID:    param.PipelineReleaseID,
@@ -1327,21 +2373,43 @@ func (w *worker) SchedulePipelineWorkflow(wfctx workflow.Context, param *Schedul
 		Mode: mgmtpb.Mode_MODE_ASYNC,
 	}
 
-	wfUID, _ := uuid.NewV4()
 	childWorkflowOptions := workflow.ChildWorkflowOptions{
 		TaskQueue:                w.workerUID.String(),
-		WorkflowID:               wfUID.String(),
+		WorkflowID:               pipelineTriggerID,
 		WorkflowExecutionTimeout: time.Duration(config.Config.Server.Workflow.MaxWorkflowTimeout) * time.Second,
 		RetryPolicy: &temporal.RetryPolicy{
 			MaximumAttempts: config.Config.Server.Workflow.MaxWorkflowRetry,
 		},
 	}
 
-	_ = workflow.ExecuteChildWorkflow(
+	err := workflow.ExecuteChildWorkflow(
 		workflow.WithChildOptions(wfctx, childWorkflowOptions),
 		"TriggerPipelineWorkflow",
 		triggerParam,
 	).Get(wfctx, nil)
+	if err != nil {
+		runErr := err.Error()
+
+		// When the schedule overlap policy is cancel-previous, the scheduler
+		// cancels this workflow before starting the next run. The run is
+		// still updated, so a disconnected context is needed.
+		if temporal.IsCanceledError(err) {
+			runErr = "cancelled by a newer scheduled run"
+			ctx, _ = workflow.NewDisconnectedContext(ctx)
+		}
+
+		// The trigger workflow only updates the run when it reaches its end.
+		completedTime := workflow.Now(wfctx)
+		_ = workflow.ExecuteActivity(ctx, w.UpdatePipelineRunActivity, &UpdatePipelineRunActivityParam{
+			PipelineTriggerID: pipelineTriggerID,
+			PipelineRun: &datamodel.PipelineRun{
+				Status:        datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_FAILED),
+				Error:         null.StringFrom(runErr),
+				CompletedTime: null.TimeFrom(completedTime),
+				TotalDuration: null.IntFrom(completedTime.Sub(startedTime).Milliseconds()),
+			},
+		}).Get(ctx, nil)
+	}
 
 	return nil
 }
@@ -0,0 +1,181 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/instill-ai/pipeline-backend/config"
+	"github.com/instill-ai/pipeline-backend/pkg/data"
+	"github.com/instill-ai/pipeline-backend/pkg/datamodel"
+	"github.com/instill-ai/pipeline-backend/pkg/memory"
+)
+
+// mapPersistence keeps the memory snapshots in a map, as a backend shared
+// by several workers.
+type mapPersistence struct {
+	mu        sync.Mutex
+	snapshots map[string][]byte
+}
+
+func (p *mapPersistence) Save(_ context.Context, workflowID string, snapshot []byte, _ time.Duration) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.snapshots[workflowID] = snapshot
+	return nil
+}
+
+func (p *mapPersistence) Load(_ context.Context, workflowID string) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b, ok := p.snapshots[workflowID]
+	if !ok {
+		return nil, memory.ErrSnapshotNotFound
+	}
+	return b, nil
+}
+
+func (p *mapPersistence) Touch(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (p *mapPersistence) Delete(_ context.Context, workflowID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.snapshots, workflowID)
+	return nil
+}
+
+func TestBatchChunk(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	chunkSize := config.Config.Server.Workflow.BatchChunkSize
+	c.Cleanup(func() { config.Config.Server.Workflow.BatchChunkSize = chunkSize })
+	config.Config.Server.Workflow.BatchChunkSize = 2
+
+	const batchSize = 5
+
+	// newTrigger creates the trigger memory of a worker, whose items hold
+	// their index as variable.
+	newTrigger := func(c *quicktest.C, w *worker, workflowID string) memory.WorkflowMemory {
+		wfm, err := w.memoryStore.NewWorkflowMemory(ctx, workflowID, &datamodel.Recipe{}, batchSize)
+		c.Assert(err, quicktest.IsNil)
+		for idx := range batchSize {
+			err := wfm.Set(ctx, idx, string(memory.PipelineVariable), data.NewMap(map[string]data.Value{
+				"n": data.NewNumberFromInteger(idx),
+			}))
+			c.Assert(err, quicktest.IsNil)
+		}
+		return wfm
+	}
+
+	// runChunk reads the variables of a chunk and writes their double as
+	// output, as the chunk workflow would.
+	runChunk := func(c *quicktest.C, w *worker, childWorkflowID string) {
+		childWFM, err := w.memoryStore.GetWorkflowMemory(ctx, childWorkflowID)
+		c.Assert(err, quicktest.IsNil)
+		for idx := range childWFM.GetBatchSize() {
+			n, err := childWFM.Get(ctx, idx, "variable.n")
+			c.Assert(err, quicktest.IsNil)
+			err = childWFM.Set(ctx, idx, string(memory.PipelineOutput), data.NewMap(map[string]data.Value{
+				"double": data.NewNumberFromInteger(2 * n.(*data.Number).GetInteger()),
+			}))
+			c.Assert(err, quicktest.IsNil)
+		}
+	}
+
+	checkMerged := func(c *quicktest.C, wfm memory.WorkflowMemory) {
+		for idx := range batchSize {
+			n, err := wfm.Get(ctx, idx, "variable.n")
+			c.Assert(err, quicktest.IsNil)
+			c.Check(n.(*data.Number).GetInteger(), quicktest.Equals, idx)
+
+			double, err := wfm.Get(ctx, idx, "output.double")
+			c.Assert(err, quicktest.IsNil, quicktest.Commentf("batch item %d", idx))
+			c.Check(double.(*data.Number).GetInteger(), quicktest.Equals, 2*idx)
+		}
+	}
+
+	c.Run("split and merge in process", func(c *quicktest.C) {
+		w := &worker{memoryStore: memory.NewMemoryStore(nil, 0, nil, 0, nil, nil)}
+		wfm := newTrigger(c, w, "in-process")
+
+		result, err := w.PreBatchChunkActivity(ctx, &PreBatchChunkActivityParam{WorkflowID: "in-process"})
+		c.Assert(err, quicktest.IsNil)
+		c.Check(result.Shared, quicktest.IsFalse)
+		c.Check(result.ChildWorkflowIDs, quicktest.DeepEquals, []string{
+			"in-process:chunk:0",
+			"in-process:chunk:1",
+			"in-process:chunk:2",
+		})
+
+		sizes := []int{}
+		for _, childWorkflowID := range result.ChildWorkflowIDs {
+			childWFM, err := w.memoryStore.GetWorkflowMemory(ctx, childWorkflowID)
+			c.Assert(err, quicktest.IsNil)
+			sizes = append(sizes, childWFM.GetBatchSize())
+			runChunk(c, w, childWorkflowID)
+		}
+		c.Check(sizes, quicktest.DeepEquals, []int{2, 2, 1})
+
+		err = w.PostBatchChunkActivity(ctx, &PostBatchChunkActivityParam{
+			WorkflowID:       "in-process",
+			ChildWorkflowIDs: result.ChildWorkflowIDs,
+		})
+		c.Assert(err, quicktest.IsNil)
+		checkMerged(c, wfm)
+
+		// The chunk memory is purged once merged.
+		for _, childWorkflowID := range result.ChildWorkflowIDs {
+			_, err := w.memoryStore.GetWorkflowMemory(ctx, childWorkflowID)
+			c.Check(err, quicktest.ErrorMatches, "workflow memory not found")
+		}
+	})
+
+	c.Run("chunks run on other workers", func(c *quicktest.C) {
+		persistence := &mapPersistence{snapshots: map[string][]byte{}}
+		trigger := &worker{memoryStore: memory.NewMemoryStore(persistence, 0, nil, 0, nil, nil)}
+		others := []*worker{
+			{memoryStore: memory.NewMemoryStore(persistence, 0, nil, 0, nil, nil)},
+			{memoryStore: memory.NewMemoryStore(persistence, 0, nil, 0, nil, nil)},
+		}
+		wfm := newTrigger(c, trigger, "shared")
+
+		result, err := trigger.PreBatchChunkActivity(ctx, &PreBatchChunkActivityParam{WorkflowID: "shared"})
+		c.Assert(err, quicktest.IsNil)
+		c.Check(result.Shared, quicktest.IsTrue)
+		c.Assert(result.ChildWorkflowIDs, quicktest.HasLen, 3)
+
+		for i, childWorkflowID := range result.ChildWorkflowIDs {
+			w := others[i%len(others)]
+			runChunk(c, w, childWorkflowID)
+			c.Assert(w.ReleaseBatchChunkActivity(ctx, childWorkflowID), quicktest.IsNil)
+		}
+
+		err = trigger.PostBatchChunkActivity(ctx, &PostBatchChunkActivityParam{
+			WorkflowID:       "shared",
+			ChildWorkflowIDs: result.ChildWorkflowIDs,
+		})
+		c.Assert(err, quicktest.IsNil)
+		checkMerged(c, wfm)
+
+		for _, childWorkflowID := range result.ChildWorkflowIDs {
+			_, err := persistence.Load(ctx, childWorkflowID)
+			c.Check(err, quicktest.ErrorIs, memory.ErrSnapshotNotFound)
+		}
+	})
+
+	c.Run("small batches aren't chunked", func(c *quicktest.C) {
+		config.Config.Server.Workflow.BatchChunkSize = batchSize
+		w := &worker{memoryStore: memory.NewMemoryStore(nil, 0, nil, 0, nil, nil)}
+		newTrigger(c, w, "small")
+
+		result, err := w.PreBatchChunkActivity(ctx, &PreBatchChunkActivityParam{WorkflowID: "small"})
+		c.Assert(err, quicktest.IsNil)
+		c.Check(result.ChildWorkflowIDs, quicktest.HasLen, 0)
+	})
+}
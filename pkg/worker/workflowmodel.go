@@ -23,6 +23,13 @@ type UploadOutputsToMinioActivityParam struct {
 	PipelineTriggerID string
 }
 
+// CaptureFailedRunActivityParam identifies the run whose payloads are
+// uploaded once it fails.
+type CaptureFailedRunActivityParam struct {
+	WorkflowID        string
+	PipelineTriggerID string
+}
+
 type UploadRecipeToMinioActivityParam struct {
 	PipelineTriggerID string
 	UploadToMinioActivityParam